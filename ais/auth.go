@@ -19,7 +19,6 @@ package ais
 import (
 	"fmt"
 	"sync"
-	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cmn"
@@ -28,8 +27,10 @@ import (
 type (
 	// TokenList is a list of tokens pushed by authn
 	TokenList struct {
-		Tokens  []string `json:"tokens"`
-		Version int64    `json:"version,string"`
+		Tokens []string `json:"tokens"`
+		// tokens of the users logged in to access the cluster via S3 API (see s3Token)
+		S3Tokens []string `json:"s3_tokens,omitempty"`
+		Version  int64    `json:"version,string"`
 	}
 
 	authList map[string]*cmn.AuthToken
@@ -41,7 +42,9 @@ type (
 		// list of invalid tokens(revoked or of deleted users)
 		// Authn sends these tokens to primary for broadcasting
 		revokedTokens map[string]bool
-		version       int64
+		// S3 access key ID => the latest token of the user (see cmn.S3AccessKeyID)
+		s3Tokens map[string]string
+		version  int64
 	}
 )
 
@@ -81,14 +84,54 @@ func (a *authManager) updateRevokedList(tokens *TokenList) {
 		a.revokedTokens[token] = true
 		delete(a.tokens, token)
 	}
+	a.addS3Tokens(tokens.S3Tokens)
 	// clean up the list from obsolete data
 	for token := range a.revokedTokens {
 		rec, err := a.extractTokenData(token)
-		if err == nil && rec.Expires.Before(time.Now()) {
+		if err == nil && cmn.Expired(rec.Expires) {
 			delete(a.revokedTokens, token)
 		}
 	}
+	for keyID, token := range a.s3Tokens {
+		if _, err := a.extractTokenData(token); err != nil || a.revokedTokens[token] {
+			delete(a.s3Tokens, keyID)
+		}
+	}
+	a.Unlock()
+}
+
+// Maps the S3 access key IDs of the token owners to their tokens - the latest
+// expiring token wins. It is internal service function, so it does not lock anything
+func (a *authManager) addS3Tokens(tokens []string) {
+	secret := cmn.GCO.Get().Auth.Secret
+	for _, token := range tokens {
+		if a.revokedTokens[token] {
+			continue
+		}
+		rec, err := a.extractTokenData(token)
+		if err != nil {
+			continue
+		}
+		keyID := cmn.S3AccessKeyID(rec.UserID, secret)
+		if prev, ok := a.s3Tokens[keyID]; ok {
+			if prec, err := a.extractTokenData(prev); err == nil && prec.Expires.After(rec.Expires) {
+				continue
+			}
+		}
+		a.s3Tokens[keyID] = token
+	}
+}
+
+// Returns decrypted information of the token that a given S3 access key ID
+// maps to. The token must be valid - see validateToken
+func (a *authManager) s3Token(accessKeyID string) (*cmn.AuthToken, error) {
+	a.Lock()
+	token, ok := a.s3Tokens[accessKeyID]
 	a.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown S3 access key ID %q (not logged in?)", accessKeyID)
+	}
+	return a.validateToken(token)
 }
 
 // Checks if a token is valid:
@@ -130,7 +173,7 @@ func (a *authManager) extractTokenData(token string) (*cmn.AuthToken, error) {
 		return nil, cmn.ErrInvalidToken
 	}
 
-	if auth.Expires.Before(time.Now()) {
+	if cmn.Expired(auth.Expires) {
 		glog.Errorf("Expired token was used: %s", token)
 		delete(a.tokens, token)
 		return nil, fmt.Errorf("token expired")
@@ -151,6 +194,9 @@ func (a *authManager) revokedTokenList() *TokenList {
 		tlist.Tokens[idx] = token
 		idx++
 	}
+	for _, token := range a.s3Tokens {
+		tlist.S3Tokens = append(tlist.S3Tokens, token)
+	}
 
 	a.Unlock()
 	return tlist
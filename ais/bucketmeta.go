@@ -105,6 +105,7 @@ func (m *bucketMD) add(bck *cluster.Bck, p *cmn.BucketProps) bool {
 	p.Provider = bck.Provider
 	p.BID = bck.MaskBID(m.Version)
 	p.Created = time.Now().UnixNano()
+	p.TrackProvenance(nil, m.Version)
 
 	m.Add(bck)
 	return true
@@ -132,6 +133,20 @@ func (m *bucketMD) set(bck *cluster.Bck, p *cmn.BucketProps) {
 	p.Provider = bck.Provider
 	m.Set(bck, p)
 	m.Version++
+	p.TrackProvenance(prevProps, m.Version)
+}
+
+// swap exchanges the props (including BIDs) of the two buckets - the BIDs
+// go along with the content (see swapBuckets)
+func (m *bucketMD) swap(bckA, bckB *cluster.Bck) {
+	propsA, presentA := m.Get(bckA)
+	propsB, presentB := m.Get(bckB)
+	cmn.Assert(presentA && presentB)
+
+	propsA, propsB = propsA.Clone(), propsB.Clone()
+	m.Set(bckA, propsB)
+	m.Set(bckB, propsA)
+	m.Version++
 }
 
 func (m *bucketMD) clone() *bucketMD {
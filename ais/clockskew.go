@@ -0,0 +1,62 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Clock skew
+//
+// The primary health-pings all other nodes, and the nodes send their
+// keepalives to the primary - the responses carry the responder's time, which
+// is how each node keeps the latest estimate of its peers' clock offsets (see
+// cmn.ClockSkew). A peer whose clock diverges beyond clock.max_skew gets
+// logged once - and again when back within the limit.
+
+type clockSkews struct {
+	m sync.Map // [sid => *cmn.ClockSkew]
+}
+
+func (cs *clockSkews) add(self, si *cluster.Snode, peerTime string, sent, recv time.Time) {
+	skew, err := cmn.NewClockSkew(peerTime, sent, recv, &cmn.GCO.Get().Clock)
+	if err != nil {
+		glog.Errorf("%s: invalid %s header from %s: %v", self, cmn.HeaderNodeTime, si, err)
+		return
+	}
+	prev, ok := cs.m.Load(si.ID())
+	cs.m.Store(si.ID(), skew)
+	switch {
+	case skew.Exceeded && (!ok || !prev.(*cmn.ClockSkew).Exceeded):
+		glog.Warningf("%s: %s clock is off by %v (round trip %v) - check NTP", self, si, skew.Skew, skew.RTT)
+	case !skew.Exceeded && ok && prev.(*cmn.ClockSkew).Exceeded:
+		glog.Infof("%s: %s clock is back in sync: off by %v (round trip %v)", self, si, skew.Skew, skew.RTT)
+	}
+}
+
+func (cs *clockSkews) get(smap *smapX) cmn.ClockSkews {
+	skews := make(cmn.ClockSkews, smap.CountTargets()+smap.CountProxies())
+	cs.m.Range(func(key, value interface{}) bool {
+		sid := key.(string)
+		if smap.containsID(sid) {
+			skews[sid] = value.(*cmn.ClockSkew)
+		} else {
+			cs.m.Delete(sid)
+		}
+		return true
+	})
+	return skews
+}
+
+func setNodeTime(w http.ResponseWriter) {
+	w.Header().Set(cmn.HeaderNodeTime, strconv.FormatInt(time.Now().UnixNano(), 10))
+}
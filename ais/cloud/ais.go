@@ -17,6 +17,7 @@ import (
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
 )
 
 const (
@@ -385,57 +386,146 @@ func (m *AisCloudProvider) HeadObj(ctx context.Context, lom *cluster.LOM) (objMe
 	}
 	err = m.try(remoteBck, func(bck cmn.Bck) error {
 		p, err := api.HeadObject(aisCluster.bp, bck, lom.ObjName)
+		if err != nil {
+			return err
+		}
+		objMeta = make(cmn.SimpleKVs, 16)
 		cmn.IterFields(p, func(uniqueTag string, field cmn.IterField) (e error, b bool) {
 			objMeta[uniqueTag] = fmt.Sprintf("%v", field.Value())
 			return nil, false
 		})
-		return err
+		return nil
 	})
 	err, errCode = extractErrCode(err)
 	return objMeta, err, errCode
 }
 
 func (m *AisCloudProvider) GetObj(ctx context.Context, workFQN string, lom *cluster.LOM) (err error, errCode int) {
-	var (
-		remoteBck = lom.Bck().Bck
-	)
+	return m.GetObjTo(ctx, workFQN, lom.Bck().Bck, lom.ObjName, lom)
+}
+
+// GetObjTo reads remote object `remoteBck/objName` into the workfile of a given
+// (local) lom - the latter may belong to a different bucket (e.g., remote cache),
+// in which case the lom also gets to keep the remote ETag (see RemoteETag)
+func (m *AisCloudProvider) GetObjTo(ctx context.Context, workFQN string, remoteBck cmn.Bck, objName string,
+	lom *cluster.LOM) (err error, errCode int) {
 	aisCluster, err := m.remoteCluster(remoteBck.Ns.UUID)
 	if err != nil {
 		return err, errCode
 	}
+	params := cluster.PutObjectParams{
+		LOM:          lom,
+		RecvType:     cluster.ColdGet,
+		WorkFQN:      workFQN,
+		WithFinalize: false,
+	}
+	var props *cmn.ObjectProps
+	if !lom.Bck().Bck.Equal(remoteBck) {
+		props = &cmn.ObjectProps{}
+	}
 	err = m.try(remoteBck, func(bck cmn.Bck) error {
+		return m.getObjTo(aisCluster.bp, bck, objName, params, props)
+	})
+	if err == nil && props != nil {
+		objMeta := cmn.SimpleKVs{cmn.HeaderObjVersion: props.Version, cmn.HeaderObjCksumVal: props.Checksum.Value}
+		lom.SetCustomMD(cmn.SimpleKVs{cluster.RemoteETagObjMD: RemoteETag(objMeta)})
+	}
+	return extractErrCode(err)
+}
+
+// RemoteETag identifies the content of a remote AIS object given its properties
+// as returned by HeadObj: the version (if versioned) and the checksum
+func RemoteETag(objMeta cmn.SimpleKVs) string {
+	return objMeta[cmn.HeaderObjVersion] + "/" + objMeta[cmn.HeaderObjCksumVal]
+}
+
+// reads the remote object via `bp` (remote proxy or target) and puts it into
+// the (local) lom as per `params` (see cluster.Target.PutObject); fills in the
+// remote object's properties if requested (non-nil `props`)
+func (m *AisCloudProvider) getObjTo(bp api.BaseParams, bck cmn.Bck, objName string,
+	params cluster.PutObjectParams, props *cmn.ObjectProps) error {
+	var (
+		r, w  = io.Pipe()
+		errCh = make(chan error, 1)
+	)
+	go func() {
 		var (
-			r, w  = io.Pipe()
-			errCh = make(chan error, 1)
+			err      error
+			objProps *cmn.ObjectProps
 		)
-		go func() {
-			goi := api.GetObjectInput{
-				Writer: w,
-			}
-			_, err = api.GetObject(aisCluster.bp, bck, lom.ObjName, goi)
-			w.CloseWithError(err)
-			errCh <- err
-		}()
+		if props == nil {
+			_, err = api.GetObject(bp, bck, objName, api.GetObjectInput{Writer: w})
+		} else if _, objProps, err = api.GetObjectWithProps(bp, bck, objName, api.GetObjectInput{Writer: w}); err == nil {
+			*props = *objProps
+		}
+		w.CloseWithError(err)
+		errCh <- err
+	}()
+	params.Reader = r
+	err := m.t.PutObject(params)
+	r.CloseWithError(err)
+	if errGet := <-errCh; err == nil {
+		err = errGet
+	}
+	return err
+}
 
-		err := m.t.PutObject(cluster.PutObjectParams{
+// PullObj reads the remote object from the remote target that owns it,
+// directly - rather than via remote proxy and its redirect - and stores it as
+// a new (local) object, the way PUT does (versioning, encryption, EC,
+// mirroring, quota). Upon failure to reach the owner (e.g., when the remote
+// cluster map has changed) refreshes the remote Smap and falls back to the
+// remote proxy.
+func (m *AisCloudProvider) PullObj(remoteBck cmn.Bck, objName string, lom *cluster.LOM) (err error, errCode int) {
+	aisCluster, err := m.remoteCluster(remoteBck.Ns.UUID)
+	if err != nil {
+		return err, errCode
+	}
+	var (
+		bck    = remoteBck
+		params = cluster.PutObjectParams{
 			LOM:          lom,
-			Reader:       r,
-			RecvType:     cluster.ColdGet,
-			WorkFQN:      workFQN,
-			WithFinalize: false,
-		})
-		r.CloseWithError(err)
-		if err != nil {
-			return err
+			RecvType:     cluster.WarmGet,
+			WorkFQN:      fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfilePut),
+			Started:      time.Now(),
+			WithFinalize: true,
 		}
-		if err := <-errCh; err != nil {
-			return err
+	)
+	bck.Ns.UUID = ""
+	m.mu.RLock()
+	si, err := cluster.HrwTarget(cluster.NewBckEmbed(bck).MakeUname(objName), aisCluster.smap)
+	m.mu.RUnlock()
+	if err == nil {
+		bp := api.BaseParams{Client: aisCluster.bp.Client, URL: si.URL(cmn.NetworkPublic)}
+		if err = m.getObjTo(bp, bck, objName, params, nil); err == nil {
+			return nil, http.StatusOK
 		}
-		return nil
+		if _, ok := err.(*cmn.ErrorQuotaExceeded); ok {
+			return err, http.StatusInsufficientStorage
+		}
+		// e.g., not found - the owner may have changed: let the remote proxy decide
+		glog.Warningf("%s: failed to pull %s/%s from %s: %v", aisCluster, bck, objName, si, err)
+	}
+	m.refreshSmap(aisCluster)
+	err = m.try(remoteBck, func(bck cmn.Bck) error {
+		return m.getObjTo(aisCluster.bp, bck, objName, params, nil)
 	})
 	return extractErrCode(err)
 }
 
+func (m *AisCloudProvider) refreshSmap(aisCluster *remAisClust) {
+	smap, err := api.GetClusterMap(aisCluster.bp)
+	if err != nil {
+		glog.Errorf("%s: failed to refresh cluster map: %v", aisCluster, err)
+		return
+	}
+	m.mu.Lock()
+	if smap.UUID == aisCluster.uuid && smap.Version > aisCluster.smap.Version {
+		aisCluster.smap = smap
+	}
+	m.mu.Unlock()
+}
+
 func (m *AisCloudProvider) PutObj(ctx context.Context, r io.Reader, lom *cluster.LOM) (version string, err error, errCode int) {
 	var (
 		remoteBck = lom.Bck().Bck
@@ -492,6 +582,9 @@ func extractErrCode(e error) (error, int) {
 	if e == nil {
 		return nil, http.StatusOK
 	}
+	if _, ok := e.(*cmn.ErrorQuotaExceeded); ok {
+		return e, http.StatusInsufficientStorage
+	}
 	httpErr := &cmn.HTTPError{}
 	if errors.As(e, &httpErr) {
 		return httpErr, httpErr.Status
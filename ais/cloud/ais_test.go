@@ -0,0 +1,177 @@
+// Package cloud contains implementation of various cloud providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cloud
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+// records the objects put (see PullObj)
+type putTargetMock struct {
+	cluster.TargetMock
+	params []cluster.PutObjectParams
+	data   []string
+}
+
+func (t *putTargetMock) PutObject(params cluster.PutObjectParams) error {
+	b, err := ioutil.ReadAll(params.Reader)
+	if err != nil {
+		return err
+	}
+	t.params = append(t.params, params)
+	t.data = append(t.data, string(b))
+	return nil
+}
+
+func TestPullObj(t *testing.T) {
+	fs.Mountpaths = fs.NewMountedFS(ios.NewIOStaterMock())
+	fs.Mountpaths.DisableFsIDCheck()
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	mpath, err := ioutil.TempDir("", "pull-obj")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(mpath)
+	tassert.CheckFatal(t, fs.Mountpaths.Add(mpath))
+	avail, _ := fs.Mountpaths.Get()
+
+	const content = "remote object content"
+	var requested []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requested = append(requested, r.URL.Path)
+		if !strings.HasSuffix(r.URL.Path, "/src-obj") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(cmn.MustMarshal(&cmn.HTTPError{Status: http.StatusNotFound, Message: "not found"}))
+			return
+		}
+		w.Write([]byte(content))
+	}))
+	defer srv.Close()
+
+	var (
+		tsi = &cluster.Snode{DaemonID: "remote-target", PublicNet: cluster.NetInfo{DirectURL: srv.URL}}
+		tm  = &putTargetMock{}
+		m   = &AisCloudProvider{t: tm, mu: &sync.RWMutex{}, remote: make(map[string]*remAisClust),
+			alias: make(map[string]string)}
+		remoteBck = cmn.Bck{Name: "src", Provider: cmn.ProviderAIS, Ns: cmn.Ns{UUID: "remote"}}
+		bck       = cmn.Bck{Name: "dst", Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+		lom       = &cluster.LOM{ObjName: "dst-obj", ParsedFQN: fs.ParsedFQN{
+			MpathInfo: avail[mpath], ContentType: fs.ObjectType, Bck: bck, ObjName: "dst-obj",
+		}}
+	)
+	m.remote["remote"] = &remAisClust{
+		m:    m,
+		uuid: "remote",
+		smap: &cluster.Smap{Tmap: cluster.NodeMap{tsi.DaemonID: tsi}, UUID: "remote", Version: 1},
+		bp:   api.BaseParams{Client: http.DefaultClient, URL: srv.URL},
+	}
+
+	err, _ = m.PullObj(remoteBck, "src-obj", lom)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(tm.params) == 1, "expected the object to be put once, got %d", len(tm.params))
+	tassert.Errorf(t, tm.data[0] == content, "expected %q, got %q", content, tm.data[0])
+
+	// stored as a new object - not as a cold GET
+	params := tm.params[0]
+	tassert.Errorf(t, params.RecvType == cluster.WarmGet, "expected regular PUT, got recv type %d", params.RecvType)
+	tassert.Errorf(t, params.WithFinalize, "expected the object to be finalized")
+	parsed, err := fs.Mountpaths.ParseFQN(params.WorkFQN)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, parsed.ContentType == fs.WorkfileType && strings.HasPrefix(parsed.ObjName, fs.WorkfilePut+"."),
+		"expected PUT workfile, got %q", params.WorkFQN)
+
+	// directly from the owner, not via remote proxy (see GetObjTo)
+	tassert.Errorf(t, len(requested) == 1, "expected a single request, got %v", requested)
+
+	// not found - retried via remote proxy
+	requested = requested[:0]
+	err, errCode := m.PullObj(remoteBck, "nonexistent", lom)
+	tassert.Errorf(t, err != nil && errCode == http.StatusNotFound, "expected not found, got %v(%d)", err, errCode)
+	tassert.Errorf(t, len(requested) == 3, "expected target, Smap, and proxy requests, got %v", requested)
+}
+
+func TestRemoteCacheETag(t *testing.T) {
+	fs.Mountpaths = fs.NewMountedFS(ios.NewIOStaterMock())
+	fs.Mountpaths.DisableFsIDCheck()
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	mpath, err := ioutil.TempDir("", "remote-cache")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(mpath)
+	tassert.CheckFatal(t, fs.Mountpaths.Add(mpath))
+
+	const content = "remote object content"
+	var (
+		mtx   sync.Mutex
+		props = cmn.ObjectProps{Name: "src-obj", Size: int64(len(content)), Version: "1", Present: true,
+			Checksum: cmn.ObjectCksumProps{Type: cmn.ChecksumXXHash, Value: "a1b2"}}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mtx.Lock()
+		cmn.IterFields(props, func(tag string, field cmn.IterField) (error, bool) {
+			w.Header().Set(tag, fmt.Sprintf("%v", field.Value()))
+			return nil, false
+		})
+		mtx.Unlock()
+		if r.Method == http.MethodGet {
+			w.Write([]byte(content))
+		}
+	}))
+	defer srv.Close()
+
+	var (
+		cbck = cluster.NewBck("remote-cache", cmn.ProviderAIS, cmn.NsGlobal, &cmn.BucketProps{
+			Cksum: cmn.CksumConf{Type: cmn.ChecksumNone},
+		})
+		rbck = cluster.NewBck("src", cmn.ProviderAIS, cmn.Ns{UUID: "remote"}, &cmn.BucketProps{})
+		tm   = &putTargetMock{TargetMock: *cluster.NewTargetMock(cluster.NewBaseBownerMock(cbck, rbck))}
+		m    = &AisCloudProvider{t: tm, mu: &sync.RWMutex{}, remote: make(map[string]*remAisClust),
+			alias: make(map[string]string)}
+		remoteBck = rbck.Bck
+		lom       = &cluster.LOM{T: tm, ObjName: "src-obj"}
+		clom      = &cluster.LOM{T: tm, ObjName: "remote/src/src-obj"}
+	)
+	m.remote["remote"] = &remAisClust{
+		m:    m,
+		uuid: "remote",
+		smap: &cluster.Smap{UUID: "remote", Version: 1},
+		bp:   api.BaseParams{Client: http.DefaultClient, URL: srv.URL},
+	}
+	tassert.CheckFatal(t, lom.Init(remoteBck))
+	tassert.CheckFatal(t, clom.Init(cbck.Bck))
+
+	// cold GET into the cache bucket records the remote ETag
+	err, _ = m.GetObjTo(context.Background(), "", remoteBck, lom.ObjName, clom)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(tm.data) == 1 && tm.data[0] == content, "expected %q, got %v", content, tm.data)
+	etag, ok := clom.GetCustomMD(cluster.RemoteETagObjMD)
+	tassert.Fatalf(t, ok, "expected the remote ETag to be recorded")
+
+	objMeta, err, _ := m.HeadObj(context.Background(), lom)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, RemoteETag(objMeta) == etag, "expected ETag %q, got %q", etag, RemoteETag(objMeta))
+
+	// the remote object gets overwritten
+	mtx.Lock()
+	props.Version, props.Checksum.Value = "2", "c3d4"
+	mtx.Unlock()
+	objMeta, err, _ = m.HeadObj(context.Background(), lom)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, RemoteETag(objMeta) != etag, "expected ETag to change from %q", etag)
+}
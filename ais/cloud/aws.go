@@ -8,10 +8,12 @@ package cloud
 
 import (
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
@@ -36,7 +38,8 @@ type (
 )
 
 var (
-	_ cluster.CloudProvider = &awsProvider{}
+	_ cluster.CloudProvider          = &awsProvider{}
+	_ cluster.VersionedCloudProvider = &awsProvider{}
 )
 
 func NewAWS(t cluster.Target) (cluster.CloudProvider, error) { return &awsProvider{t: t}, nil }
@@ -120,8 +123,11 @@ func (awsp *awsProvider) ListObjects(_ context.Context, bck *cluster.Bck, msg *c
 			entry.Size = *(key.Size)
 		}
 		if strings.Contains(msg.Props, cmn.GetPropsChecksum) {
-			omd5, _ := strconv.Unquote(*key.ETag)
-			entry.Checksum = omd5
+			if etag, ok := cmn.AwsMultipartETag(key.ETag); ok {
+				entry.Checksum, entry.CksumType = etag, cmn.ChecksumMultipartETag
+			} else {
+				entry.Checksum, entry.CksumType = etag, cmn.ChecksumMD5
+			}
 		}
 
 		bckList.Entries = append(bckList.Entries, entry)
@@ -292,6 +298,17 @@ func (awsp *awsProvider) HeadObj(_ context.Context, lom *cluster.LOM) (objMeta c
 ////////////////
 
 func (awsp *awsProvider) GetObj(ctx context.Context, workFQN string, lom *cluster.LOM) (err error, errCode int) {
+	return awsp.getObj(ctx, workFQN, lom, nil)
+}
+
+func (awsp *awsProvider) GetObjVersion(ctx context.Context, workFQN string, lom *cluster.LOM,
+	version string) (err error, errCode int) {
+	return awsp.getObj(ctx, workFQN, lom, aws.String(version))
+}
+
+// versionID: nil - the current version
+func (awsp *awsProvider) getObj(ctx context.Context, workFQN string, lom *cluster.LOM,
+	versionID *string) (err error, errCode int) {
 	var (
 		cksum        *cmn.Cksum
 		cksumToCheck *cmn.Cksum
@@ -301,8 +318,9 @@ func (awsp *awsProvider) GetObj(ctx context.Context, workFQN string, lom *cluste
 	sess := createSession()
 	svc := s3.New(sess)
 	obj, err := svc.GetObjectWithContext(ctx, &s3.GetObjectInput{
-		Bucket: aws.String(bck.Name),
-		Key:    aws.String(lom.ObjName),
+		Bucket:    aws.String(bck.Name),
+		Key:       aws.String(lom.ObjName),
+		VersionId: versionID,
 	})
 	if err != nil {
 		err, errCode = awsp.awsErrorToAISError(err, bck)
@@ -326,6 +344,8 @@ func (awsp *awsProvider) GetObj(ctx context.Context, workFQN string, lom *cluste
 	if v, ok := h.EncodeCksum(obj.ETag); ok {
 		cksumToCheck = cmn.NewCksum(cmn.ChecksumMD5, v)
 		customMD[cluster.MD5ObjMD] = v
+	} else if etag, ok := cmn.AwsMultipartETag(obj.ETag); ok {
+		customMD[cluster.ETagObjMD] = etag
 	}
 	lom.SetCksum(cksum)
 	lom.SetCustomMD(customMD)
@@ -347,6 +367,61 @@ func (awsp *awsProvider) GetObj(ctx context.Context, workFQN string, lom *cluste
 	return
 }
 
+// ObjVersionAsOf returns the ID of the version that was current at a given
+// time; the object did not exist at the time if it was deleted (delete marker)
+// or not yet created
+func (awsp *awsProvider) ObjVersionAsOf(ctx context.Context, lom *cluster.LOM,
+	asOf time.Time) (version string, err error, errCode int) {
+	var (
+		latest   time.Time
+		deleted  bool
+		found    bool
+		cloudBck = lom.Bck().CloudBck()
+		svc      = s3.New(createSession())
+		input    = &s3.ListObjectVersionsInput{
+			Bucket: aws.String(cloudBck.Name),
+			Prefix: aws.String(lom.ObjName),
+		}
+	)
+	visit := func(key *string, modified *time.Time) bool {
+		if *key != lom.ObjName || modified.After(asOf) {
+			return false
+		}
+		if found && !modified.After(latest) {
+			return false
+		}
+		latest, found = *modified, true
+		return true
+	}
+	err = svc.ListObjectVersionsPagesWithContext(ctx, input, func(page *s3.ListObjectVersionsOutput, _ bool) bool {
+		for _, v := range page.Versions {
+			if visit(v.Key, v.LastModified) {
+				version, deleted = aws.StringValue(v.VersionId), false
+			}
+		}
+		for _, m := range page.DeleteMarkers {
+			if visit(m.Key, m.LastModified) {
+				version, deleted = "", true
+			}
+		}
+		return true
+	})
+	if err != nil {
+		err, errCode = awsp.awsErrorToAISError(err, cloudBck)
+		return
+	}
+	if !found || deleted {
+		err = fmt.Errorf("%s as of %s %s", lom, asOf.Format(time.RFC3339), cmn.DoesNotExist)
+		errCode = http.StatusNotFound
+		return
+	}
+	if _, ok := cmn.CloudHelpers.Amazon.EncodeVersion(version); !ok {
+		err = fmt.Errorf("%s: versioning is not enabled for cloud bucket %s", lom, cloudBck)
+		errCode = http.StatusBadRequest
+	}
+	return
+}
+
 ////////////////
 // PUT OBJECT //
 ////////////////
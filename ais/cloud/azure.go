@@ -275,7 +275,7 @@ func (ap *azureProvider) ListObjects(ctx context.Context, bck *cluster.Bck, msg
 		}
 		if strings.Contains(msg.Props, cmn.GetPropsChecksum) {
 			if v, ok := h.EncodeCksum(blob.Properties.ContentMD5); ok {
-				entry.Checksum = v
+				entry.Checksum, entry.CksumType = v, cmn.ChecksumMD5
 			}
 		}
 
@@ -13,7 +13,9 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"cloud.google.com/go/storage"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -41,7 +43,8 @@ type (
 )
 
 var (
-	_ cluster.CloudProvider = &gcpProvider{}
+	_ cluster.CloudProvider          = &gcpProvider{}
+	_ cluster.VersionedCloudProvider = &gcpProvider{}
 )
 
 func readCredFile() (projectID string) {
@@ -154,7 +157,7 @@ func (gcpp *gcpProvider) ListObjects(ctx context.Context, bck *cluster.Bck, msg
 		}
 		if strings.Contains(msg.Props, cmn.GetPropsChecksum) {
 			if v, ok := h.EncodeCksum(attrs.MD5); ok {
-				entry.Checksum = v
+				entry.Checksum, entry.CksumType = v, cmn.ChecksumMD5
 			}
 		}
 		if strings.Contains(msg.Props, cmn.GetPropsVersion) {
@@ -272,6 +275,17 @@ func (gcpp *gcpProvider) HeadObj(ctx context.Context, lom *cluster.LOM) (objMeta
 ////////////////
 
 func (gcpp *gcpProvider) GetObj(ctx context.Context, workFQN string, lom *cluster.LOM) (err error, errCode int) {
+	return gcpp.getObj(ctx, workFQN, lom, "")
+}
+
+func (gcpp *gcpProvider) GetObjVersion(ctx context.Context, workFQN string, lom *cluster.LOM,
+	version string) (err error, errCode int) {
+	return gcpp.getObj(ctx, workFQN, lom, version)
+}
+
+// version (generation): empty - the current one
+func (gcpp *gcpProvider) getObj(ctx context.Context, workFQN string, lom *cluster.LOM,
+	version string) (err error, errCode int) {
 	gcpClient, gctx, err := createClient(ctx)
 	if err != nil {
 		return
@@ -281,6 +295,15 @@ func (gcpp *gcpProvider) GetObj(ctx context.Context, workFQN string, lom *cluste
 		cloudBck = lom.Bck().CloudBck()
 		o        = gcpClient.Bucket(cloudBck.Name).Object(lom.ObjName)
 	)
+	if version != "" {
+		gen, errParse := strconv.ParseInt(version, 10, 64)
+		if errParse != nil {
+			err = fmt.Errorf("%s: invalid generation %q", lom, version)
+			errCode = http.StatusBadRequest
+			return
+		}
+		o = o.Generation(gen)
+	}
 	attrs, err := o.Attrs(gctx)
 	if err != nil {
 		err, errCode = gcpp.gcpErrorToAISError(err, cloudBck)
@@ -332,6 +355,47 @@ func (gcpp *gcpProvider) GetObj(ctx context.Context, workFQN string, lom *cluste
 	return
 }
 
+// ObjVersionAsOf returns the generation that was live at a given time
+func (gcpp *gcpProvider) ObjVersionAsOf(ctx context.Context, lom *cluster.LOM,
+	asOf time.Time) (version string, err error, errCode int) {
+	gcpClient, gctx, err := createClient(ctx)
+	if err != nil {
+		return
+	}
+	var (
+		found    *storage.ObjectAttrs
+		cloudBck = lom.Bck().CloudBck()
+		query    = &storage.Query{Prefix: lom.ObjName, Versions: true}
+		it       = gcpClient.Bucket(cloudBck.Name).Objects(gctx, query)
+	)
+	for {
+		attrs, errNext := it.Next()
+		if errNext == iterator.Done {
+			break
+		}
+		if errNext != nil {
+			err, errCode = gcpp.gcpErrorToAISError(errNext, cloudBck)
+			return
+		}
+		if attrs.Name != lom.ObjName || attrs.Created.After(asOf) {
+			continue
+		}
+		if !attrs.Deleted.IsZero() && !attrs.Deleted.After(asOf) {
+			continue // noncurrent by then
+		}
+		if found == nil || attrs.Created.After(found.Created) {
+			found = attrs
+		}
+	}
+	if found == nil {
+		err = fmt.Errorf("%s as of %s %s", lom, asOf.Format(time.RFC3339), cmn.DoesNotExist)
+		errCode = http.StatusNotFound
+		return
+	}
+	version, _ = cmn.CloudHelpers.Google.EncodeVersion(found.Generation)
+	return
+}
+
 ////////////////
 // PUT OBJECT //
 ////////////////
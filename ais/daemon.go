@@ -5,21 +5,27 @@
 package ais
 
 import (
+	"context"
 	"errors"
 	"flag"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/encrypt"
+	"github.com/NVIDIA/aistore/events"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/health"
 	"github.com/NVIDIA/aistore/housekeep/hk"
+	"github.com/NVIDIA/aistore/housekeep/lru"
 	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/query"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/sys"
 	"github.com/NVIDIA/aistore/transport"
@@ -36,8 +42,13 @@ const (
 	xtargetkeepalive = "targetkeepalive"
 	xmetasyncer      = "metasyncer"
 	xfshc            = "fshc"
+	xevents          = "events"
+	xpromwatch       = "promote-watcher"
 )
 
+// the deadline for all runners to stop (see rungroup.stop)
+const shutdownTimeout = time.Minute
+
 // not to confuse with default ones, see memsys/mmsa.go
 const (
 	gmmName = ".ais.mm"
@@ -62,6 +73,9 @@ type (
 		transient   bool   // false: make cmn.ConfigCLI settings permanent, true: leave them transient
 		skipStartup bool   // determines if the proxy should skip waiting for targets
 		ntargets    int    // expected number of targets in a starting-up cluster (proxy only)
+		// mounted (Kubernetes) config and secrets - see watcher.go
+		confWatch  time.Duration // period to check config, TLS certificate, and secrets for updates (0 - never)
+		secretsDir string        // directory of files that set the same-name environment variables
 	}
 
 	// daemon instance: proxy or storage target
@@ -75,6 +89,7 @@ type (
 		runarr []cmn.Runner
 		runmap map[string]cmn.Runner // redundant, named
 		errCh  chan error
+		done   []chan struct{} // closed upon the respective runner's exit
 	}
 )
 
@@ -99,31 +114,70 @@ func (g *rungroup) add(r cmn.Runner, name string) {
 	g.runmap[name] = r
 }
 
+// run starts the runners in the order of their addition - which is the order
+// of their dependencies (see initProxy and initTarget) - and stops them in the
+// reverse order, so that the node stops serving HTTP before its subsystems do
 func (g *rungroup) run() error {
 	if len(g.runarr) == 0 {
 		return nil
 	}
 	g.errCh = make(chan error, len(g.runarr))
-	for _, r := range g.runarr {
-		go func(r cmn.Runner) {
+	g.done = make([]chan struct{}, len(g.runarr))
+	for i, r := range g.runarr {
+		g.done[i] = make(chan struct{})
+		go func(r cmn.Runner, done chan struct{}) {
 			err := r.Run()
 			if err != nil {
 				glog.Warningf("runner [%s] exited with err [%v]", r.GetRunName(), err)
 			}
+			close(done)
 			g.errCh <- err
-		}(r)
+		}(r, g.done[i])
 	}
 
 	// Wait here for (any/first) runner termination.
 	err := <-g.errCh
-	for _, r := range g.runarr {
-		r.Stop(err)
+	g.stop(err)
+	return err
+}
+
+// stop is the node's shutdown sequence: the runners get stopped one at a time,
+// each one is given a chance to exit before the next one (that it may depend on)
+// is stopped, and the housekeeper goes last - all within the same shutdownTimeout.
+// The runners that fail to exit in time are logged and abandoned.
+func (g *rungroup) stop(err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	if stuck := g.stopRunners(ctx, err); len(stuck) > 0 {
+		glog.Errorf("runner(s) %v failed to exit within %v", stuck, shutdownTimeout)
 	}
-	// Wait for all terminations.
-	for i := 0; i < len(g.runarr)-1; i++ {
-		<-g.errCh
+	if err := hk.Housekeeper.StopCtx(ctx); err != nil {
+		glog.Errorf("housekeeper failed to stop: %v", err)
 	}
-	return err
+	cancel()
+}
+
+// stopRunners stops the runners in the reverse order and returns the names
+// of those that failed to exit by the ctx deadline
+func (g *rungroup) stopRunners(ctx context.Context, err error) (stuck []string) {
+	for i := len(g.runarr) - 1; i >= 0; i-- {
+		r := g.runarr[i]
+		if gr, ok := r.(cmn.GracefulRunner); ok {
+			gr.StopCtx(ctx, err)
+		} else {
+			r.Stop(err)
+		}
+		select {
+		case <-g.done[i]:
+			continue
+		default:
+		}
+		select {
+		case <-g.done[i]:
+		case <-ctx.Done():
+			stuck = append(stuck, r.GetRunName())
+		}
+	}
+	return
 }
 
 func init() {
@@ -139,6 +193,12 @@ func init() {
 	flag.BoolVar(&daemon.cli.transient, "transient", false,
 		"false: apply command-line args to the configuration and save the latter to disk\ntrue: keep it transient (for this run only)")
 
+	flag.DurationVar(&daemon.cli.confWatch, "config_watch", 0,
+		"period to check the config, TLS certificate and key, and secrets for updates, and reload the ones that changed\n"+
+			"(e.g., when mounted from Kubernetes ConfigMaps and Secrets); zero disables")
+	flag.StringVar(&daemon.cli.secretsDir, "secrets_dir", "",
+		"directory (e.g., mounted Kubernetes Secret) with one file per environment variable to set - cloud credentials, et al.")
+
 	flag.BoolVar(&daemon.cli.skipStartup, "skip_startup", false,
 		"determines if primary proxy should skip waiting for target registrations when starting up")
 	flag.IntVar(&daemon.cli.ntargets, "ntargets", 0, "number of storage targets to expect at startup (hint, proxy-only)")
@@ -190,21 +250,19 @@ func initDaemon(version, build string) {
 		str += "Usage: aisnode -role=<proxy|target> -config=</dir/config.json> ..."
 		cmn.ExitLogf(str)
 	}
+	if daemon.cli.secretsDir != "" {
+		if err := loadSecrets(daemon.cli.secretsDir); err != nil {
+			cmn.ExitLogf("Failed to load secrets: %v", err)
+		}
+	}
 	config := jsp.LoadConfig(daemon.cli.confPath)
 
 	// even more config changes, e.g:
 	// -config=/etc/ais.json -role=target -persist=true -config_custom="client.timeout=13s, proxy.primary_url=https://localhost:10080"
 	if daemon.cli.confCustom != "" {
-		var (
-			nvmap = make(cmn.SimpleKVs, 10)
-			kvs   = strings.Split(daemon.cli.confCustom, ",")
-		)
-		for _, kv := range kvs {
-			entry := strings.SplitN(kv, "=", 1)
-			if len(entry) != 2 {
-				cmn.ExitLogf("Failed to parse `-config_custom` flag (invalid entry: %s)", kv)
-			}
-			nvmap[entry[0]] = entry[1]
+		nvmap, err := confCustomKVs(daemon.cli.confCustom)
+		if err != nil {
+			cmn.ExitLogf("%v", err)
 		}
 		if err := jsp.SetConfigMany(nvmap); err != nil {
 			cmn.ExitLogf("Failed to set config: %s", err)
@@ -215,6 +273,12 @@ func initDaemon(version, build string) {
 			cmn.ExitLogf("Failed to save config: %v", err)
 		}
 	}
+	if err := loadTLSCerts(&config.Net); err != nil {
+		cmn.ExitLogf("Failed to load TLS certificate: %v", err)
+	}
+	if daemon.cli.confWatch > 0 {
+		watchMounted(config)
+	}
 
 	glog.Infof("git: %s | build-time: %s\n", version, build)
 
@@ -240,8 +304,8 @@ func initDaemon(version, build string) {
 	// Initialize filesystem/mountpaths manager.
 	fs.InitMountedFS()
 
-	// NOTE: Proxy and, respectively, target terminations are executed in
-	//  the same exact order as the initializations below
+	// NOTE: the runners are started in the order of the initializations below
+	//  (dependencies first) and terminated in the reverse order
 	daemon.rg = &rungroup{
 		runarr: make([]cmn.Runner, 0, 8),
 		runmap: make(map[string]cmn.Runner, 8),
@@ -255,6 +319,23 @@ func initDaemon(version, build string) {
 	daemon.rg.add(&sigrunner{}, xsignal)
 }
 
+// parses `-config_custom` (e.g., "client.timeout=13s,log.level=4")
+func confCustomKVs(confCustom string) (cmn.SimpleKVs, error) {
+	var (
+		nvmap = make(cmn.SimpleKVs, 10)
+		kvs   = strings.Split(confCustom, ",")
+	)
+	for _, kv := range kvs {
+		entry := strings.SplitN(kv, "=", 2)
+		if len(entry) != 2 {
+			return nil, fmt.Errorf("failed to parse `-config_custom` flag (invalid entry: %s)", kv)
+		}
+		nvmap[strings.TrimSpace(entry[0])] = strings.TrimSpace(entry[1])
+	}
+	return nvmap, nil
+}
+
+// proxy startup: memsys => stats => events => HTTP (proxy) => keepalive and metasync
 func initProxy() {
 	p := &proxyrunner{
 		gmm: &memsys.MMSA{Name: gmmName, Small: true, MinFree: 100 * cmn.MiB},
@@ -262,40 +343,29 @@ func initProxy() {
 	_ = p.gmm.Init(true /*panicOnErr*/)
 	p.initSI(cmn.Proxy)
 	p.initClusterCIDR()
-	daemon.rg.add(p, cmn.Proxy)
 
 	ps := &stats.Prunner{}
 	startedUp := ps.Init(p)
 	daemon.rg.add(ps, xproxystats)
 
+	p.events = events.NewBus(p.si.ID())
+	daemon.rg.add(p.events, xevents)
+
+	daemon.rg.add(p, cmn.Proxy)
+
 	daemon.rg.add(newProxyKeepaliveRunner(p, ps, startedUp), xproxykeepalive)
 	daemon.rg.add(newMetasyncer(p), xmetasyncer)
 }
 
+// target startup: fs (mountpaths and content types) => memsys => stats => events =>
+// transport => fshc => HTTP (target) => keepalive
 func initTarget(config *cmn.Config) {
 	t := &targetrunner{
 		gmm: &memsys.MMSA{Name: gmmName},
 		smm: &memsys.MMSA{Name: smmName, Small: true},
 	}
-	_ = t.gmm.Init(true /*panicOnErr*/)
-	_ = t.smm.Init(true /*panicOnErr*/)
-	t.gmm.Sibling, t.smm.Sibling = t.smm, t.gmm
-
 	t.initSI(cmn.Target)
 	t.initHostIP()
-	daemon.rg.add(t, cmn.Target)
-
-	ts := &stats.Trunner{T: t} // iostat below
-	startedUp := ts.Init(t)
-	daemon.rg.add(ts, xstorstats)
-
-	daemon.rg.add(newTargetKeepaliveRunner(t, ts, startedUp), xtargetkeepalive)
-
-	t.fsprg.init(t) // subgroup of the daemon.rg rungroup
-
-	// Stream Collector - a singleton object with responsibilities that include:
-	sc := transport.Init()
-	daemon.rg.add(sc, xstreamc)
 
 	// fs.Mountpaths must be inited prior to all runners that utilize them
 	// for mountpath definition, see fs/mountfs.go
@@ -308,17 +378,72 @@ func initTarget(config *cmn.Config) {
 		for path := range config.FSpaths.Paths {
 			fsPaths = append(fsPaths, path)
 		}
+		fs.Mountpaths.SetLabels(config.FSpaths.Labels)
 		if err := fs.Mountpaths.Init(fsPaths); err != nil {
 			cmn.ExitLogf("%s", err)
 		}
 	}
+	// register object type, workfile type, object version types, trash type, and S3 multipart type
+	if err := fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{}); err != nil {
+		cmn.ExitLogf("%v", err)
+	}
+	if err := fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{}); err != nil {
+		cmn.ExitLogf("%v", err)
+	}
+	if err := fs.CSM.RegisterContentType(fs.ObjVersionType, &fs.ObjVersionContentResolver{}); err != nil {
+		cmn.ExitLogf("%v", err)
+	}
+	if err := fs.CSM.RegisterContentType(fs.RecycleType, &fs.RecycleContentResolver{}); err != nil {
+		cmn.ExitLogf("%v", err)
+	}
+	if err := fs.CSM.RegisterContentType(fs.RemoteVerType, &fs.RemoteVerContentResolver{}); err != nil {
+		cmn.ExitLogf("%v", err)
+	}
+	if err := fs.CSM.RegisterContentType(fs.MptPartType, &fs.WorkfileContentResolver{}); err != nil {
+		cmn.ExitLogf("%v", err)
+	}
+	// at-rest encryption keys
+	if enabled, err := encrypt.Init(); err != nil {
+		cmn.ExitLogf("%v", err)
+	} else if enabled {
+		glog.Infoln("at-rest encryption: enabled")
+	}
+
+	_ = t.gmm.Init(true /*panicOnErr*/)
+	_ = t.smm.Init(true /*panicOnErr*/)
+	t.gmm.Sibling, t.smm.Sibling = t.smm, t.gmm
+
+	ts := &stats.Trunner{T: t} // iostat below
+	startedUp := ts.Init(t)
+	daemon.rg.add(ts, xstorstats)
+
+	t.events = events.NewBus(t.si.ID())
+	daemon.rg.add(t.events, xevents)
+	daemon.rg.add(newPromWatcher(t), xpromwatch)
+	_ = ts.UpdateCapacities(nil) // goes after fs.Mountpaths.Init
+
+	t.fsprg.init(t) // subgroup of the daemon.rg rungroup
+
+	// Stream Collector - a singleton object with responsibilities that include:
+	sc := transport.Init()
+	daemon.rg.add(sc, xstreamc)
 
 	fshc := health.NewFSHC(t, fs.Mountpaths, t.gmm, fs.CSM)
 	daemon.rg.add(fshc, xfshc)
 
+	daemon.rg.add(t, cmn.Target)
+	daemon.rg.add(newTargetKeepaliveRunner(t, ts, startedUp), xtargetkeepalive)
+
 	housekeep, initialInterval := cluster.LomCacheHousekeep(t.gmm, t)
 	hk.Housekeeper.Register("lom-cache", housekeep, initialInterval)
-	_ = ts.UpdateCapacities(nil) // goes after fs.Mountpaths.Init
+	hk.Housekeeper.Register("remote-cache", t.housekeepRemoteCache, remoteCacheEvictIval)
+	hk.Housekeeper.Register("workfile-gc", func() time.Duration { return lru.GCWorkfiles(t.GetBowner(), t.statsT) }, time.Minute)
+	hk.Housekeeper.Register("empty-dir-gc", func() time.Duration { return lru.GCEmptyDirs(t.GetBowner(), t.statsT) }, time.Minute)
+	hk.Housekeeper.Register("trash-gc", func() time.Duration { return lru.GCTrash(t.GetBowner(), t.statsT) }, time.Minute)
+	hk.Housekeeper.Register("s3-mpt-gc", t.gcMptS3, mptGCIval)
+	hk.Housekeeper.Register("bucket-quota", func() time.Duration { return fs.Quota.Housekeep(t.bckProps) }, time.Minute)
+	hk.Housekeeper.Register("fshc-watchdog", t.fsprg.wd.housekeep, time.Minute)
+	hk.Housekeeper.Register("query-gc", query.Registry.Housekeep, time.Minute)
 }
 
 // Run is the 'main' where everything gets started
@@ -0,0 +1,135 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/golang/mux"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+type (
+	// runs until stopped, unless stuck
+	runnerMock struct {
+		cmn.Named
+		stopCh  *cmn.StopCh
+		stuck   bool
+		mtx     *sync.Mutex
+		stopped *[]string // in the order of stopping
+	}
+	// waits for itself to exit (see cmn.GracefulRunner)
+	gracefulRunnerMock struct {
+		runnerMock
+		doneCh chan struct{}
+	}
+)
+
+func (r *runnerMock) Run() error {
+	if !r.stuck {
+		<-r.stopCh.Listen()
+	} else {
+		select {}
+	}
+	return nil
+}
+
+func (r *runnerMock) Stop(error) {
+	r.mtx.Lock()
+	*r.stopped = append(*r.stopped, r.GetRunName())
+	r.mtx.Unlock()
+	r.stopCh.Close()
+}
+
+func (r *gracefulRunnerMock) Run() error {
+	err := r.runnerMock.Run()
+	time.Sleep(50 * time.Millisecond) // releasing resources
+	close(r.doneCh)
+	return err
+}
+
+func (r *gracefulRunnerMock) StopCtx(ctx context.Context, err error) {
+	r.Stop(err)
+	select {
+	case <-r.doneCh:
+	case <-ctx.Done():
+	}
+}
+
+func TestRungroupStop(t *testing.T) {
+	var (
+		stopped []string
+		mtx     = &sync.Mutex{}
+		g       = &rungroup{runmap: make(map[string]cmn.Runner)}
+		mock    = func(stuck bool) runnerMock {
+			return runnerMock{stopCh: cmn.NewStopCh(), stuck: stuck, mtx: mtx, stopped: &stopped}
+		}
+		graceful = &gracefulRunnerMock{runnerMock: mock(false), doneCh: make(chan struct{})}
+	)
+	regular, stuck := mock(false), mock(true)
+	g.add(&regular, "regular")
+	g.add(&stuck, "stuck")
+	g.add(graceful, "graceful")
+
+	g.errCh = make(chan error, len(g.runarr))
+	g.done = make([]chan struct{}, len(g.runarr))
+	for i, r := range g.runarr {
+		g.done[i] = make(chan struct{})
+		go func(r cmn.Runner, done chan struct{}) {
+			g.errCh <- r.Run()
+			close(done)
+		}(r, g.done[i])
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	names := g.stopRunners(ctx, nil)
+	elapsed := time.Since(started)
+
+	// the runner stopped after the one stuck - past the deadline - may or may not make it
+	tassert.Errorf(t, len(names) > 0 && names[0] == "stuck", "expected the stuck runner, got %v", names)
+	tassert.Errorf(t, elapsed < time.Second, "expected to return by the deadline, took %v", elapsed)
+	select {
+	case <-graceful.doneCh:
+	default:
+		t.Error("the graceful runner must be waited for")
+	}
+	mtx.Lock()
+	defer mtx.Unlock()
+	tassert.Fatalf(t, len(stopped) == 3, "expected all runners stopped, got %v", stopped)
+	tassert.Errorf(t, stopped[0] == "graceful" && stopped[1] == "stuck" && stopped[2] == "regular",
+		"expected the reverse order, got %v", stopped)
+}
+
+// until the node is ready, all but the health checks get 503 (see netServer.gate)
+func TestServerGate(t *testing.T) {
+	var (
+		h       = &httprunner{}
+		server  = &netServer{mux: mux.NewServeMux(), ready: &h.startup.ready}
+		handler = server.gate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}), &cmn.ListenerConf{})
+		status  = func(path string) int {
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+			return w.Code
+		}
+		health  = cmn.URLPath(cmn.Version, cmn.Health)
+		buckets = cmn.URLPath(cmn.Version, cmn.Buckets, "abc")
+	)
+	tassert.Errorf(t, status(health) == http.StatusOK, "expected health checks to go through")
+	tassert.Errorf(t, status(buckets) == http.StatusServiceUnavailable, "expected 503 while not ready")
+
+	h.markReady()
+	tassert.Errorf(t, status(buckets) == http.StatusOK, "expected to serve when ready")
+
+	h.startup.ready.Store(false) // stopping
+	tassert.Errorf(t, status(buckets) == http.StatusServiceUnavailable, "expected 503 when stopping")
+}
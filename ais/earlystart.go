@@ -5,8 +5,11 @@
 package ais
 
 import (
+	"fmt"
 	"net/url"
 	"os"
+	"reflect"
+	"sort"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -158,6 +161,10 @@ func (p *proxyrunner) secondaryStartup(smap *smapX, primaryURLs ...string) error
 		p.markClusterStarted()
 	}()
 
+	// the new primary (and the targets) take care of the unfinished transactions, if any
+	if records := p.txnJournal.drain(); len(records) > 0 {
+		glog.Warningf("%s: dropping %d unfinished txn(s) of the former primary (self)", p.si, len(records))
+	}
 	glog.Infof("%s: joined as non-primary, %s", p.si, smap.StringEx())
 	return nil
 }
@@ -215,9 +222,15 @@ func (p *proxyrunner) primaryStartup(loadedSmap *smapX, config *cmn.Config, ntar
 			p.owner.smap.Unlock()
 		}
 		glog.Infof("%s: initial %s, curr %s, added=%d", p.si, loadedSmap, smap.StringEx(), added)
-		bmd := p.owner.bmd.get()
-		msg := p.newAisMsgStr(metaction1, smap, bmd)
-		wg := p.metasyncer.sync(revsPair{smap, msg}, revsPair{bmd, msg})
+		var (
+			bmd   = p.owner.bmd.get()
+			msg   = p.newAisMsgStr(metaction1, smap, bmd)
+			pairs = []revsPair{{smap, msg}}
+		)
+		if bmd.version() > 0 { // otherwise, wait for recoverBMD (below)
+			pairs = append(pairs, revsPair{bmd, msg})
+		}
+		wg := p.metasyncer.sync(pairs...)
 		wg.Wait()
 	} else {
 		glog.Infof("%s: no registrations yet", p.si)
@@ -278,6 +291,9 @@ func (p *proxyrunner) primaryStartup(loadedSmap *smapX, config *cmn.Config, ntar
 	// 6: started up as primary
 	glog.Infof("%s: primary/cluster startup complete, %s", p.si, smap.StringEx())
 	p.markClusterStarted()
+
+	// 7: resolve the transactions that the previous incarnation left unfinished
+	p.recoverTxns()
 }
 
 func (p *proxyrunner) acceptRegistrations(smap, loadedSmap *smapX, config *cmn.Config, ntargets int) (maxVerSmap *smapX) {
@@ -348,6 +364,14 @@ func (p *proxyrunner) discoverMeta(smap *smapX) {
 	var (
 		maxVerSmap, maxVerBMD = p.uncoverMeta(smap)
 	)
+	if p.owner.bmd.get().version() == 0 && smap.CountTargets() > 0 {
+		// lost (or never had) BMD - reconstruct it from the targets' copies
+		if err := p.recoverBMD(smap); err == nil {
+			maxVerBMD = nil
+		} else if err != errNoBMD {
+			glog.Errorf("%s: failed to recover %s: %v", p.si, bmdTermName, err)
+		}
+	}
 	if maxVerBMD != nil {
 		p.owner.bmd.Lock()
 		bmd := p.owner.bmd.get()
@@ -397,6 +421,113 @@ func (p *proxyrunner) discoverMeta(smap *smapX) {
 	glog.Infof("%s: merged %s", p.si, clone.pp())
 }
 
+// recoverBMD is called when the primary starts up with no BMD (e.g., the local
+// copy is missing or corrupted) - to restore the latest BMD that the targets have
+// in common instead of coming up with an empty one:
+// 1. collect the BMDs from (at least) a quorum of targets;
+// 2. resolve the BMD uuid by simple majority (see resolveUUIDBMD);
+// 3. select the highest version that is present (or superseded) at a quorum of
+//    the targets and whose copies are all identical;
+// 4. adopt the latter with the version incremented past all of the above.
+// Returns errNoBMD when none of the targets has any (new cluster).
+func (p *proxyrunner) recoverBMD(smap *smapX) error {
+	var (
+		config   = cmn.GCO.Get()
+		deadline = time.Now().Add(config.Timeout.Startup)
+		q        = url.Values{cmn.URLParamWhat: []string{cmn.GetWhatBMD}}
+		args     = bcastArgs{req: cmn.ReqArgs{Path: cmn.URLPath(cmn.Version, cmn.Daemon), Query: q}, smap: smap}
+		quorum   = smap.CountTargets()/2 + 1
+		bmds     = make(map[*cluster.Snode]*bucketMD, smap.CountTargets())
+		maxVer   int64
+	)
+	for {
+		var responded int
+		for k := range bmds {
+			delete(bmds, k)
+		}
+		for res := range p.bcastTo(args) {
+			if res.err != nil {
+				continue
+			}
+			bmd := &bucketMD{}
+			if err := jsoniter.Unmarshal(res.outjson, bmd); err != nil {
+				glog.Errorf("%s: failed to unmarshal %s from %s: %v", p.si, bmdTermName, res.si, err)
+				continue
+			}
+			responded++
+			if bmd.version() > 0 {
+				bmds[res.si] = bmd
+			}
+		}
+		if responded >= quorum {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%d target(s) responded, quorum %d (%s)", responded, quorum, smap)
+		}
+		time.Sleep(config.Timeout.CplaneOperation)
+	}
+	rbmd, err := resolveUUIDBMD(bmds)
+	if err != nil {
+		if _, ok := err.(*errTgtBmdUUIDDiffer); !ok {
+			return err
+		}
+		glog.Error(err.Error())
+	}
+
+	// the copies with the majority uuid, by version (descending)
+	versions := make(map[int64][]*bucketMD, 4)
+	for _, bmd := range bmds {
+		if bmd.UUID == rbmd.UUID {
+			versions[bmd.Version] = append(versions[bmd.Version], bmd)
+		}
+		maxVer = cmn.MaxI64(maxVer, bmd.Version)
+	}
+	vers := make([]int64, 0, len(versions))
+	for ver := range versions {
+		vers = append(vers, ver)
+	}
+	sort.Slice(vers, func(i, j int) bool { return vers[i] > vers[j] })
+
+	rbmd = nil
+	for i, ver := range vers {
+		var have int // number of targets that have this version or newer
+		for _, v := range vers[:i+1] {
+			have += len(versions[v])
+		}
+		if have < quorum {
+			continue
+		}
+		copies := versions[ver]
+		if !bmdCopiesEqual(copies) {
+			glog.Errorf("%s: %s v%d differs across targets - skipping", p.si, bmdTermName, ver)
+			continue
+		}
+		rbmd = copies[0]
+		break
+	}
+	if rbmd == nil {
+		return fmt.Errorf("no %s version agreed upon by a quorum (%d) of targets", bmdTermName, quorum)
+	}
+
+	p.owner.bmd.Lock()
+	clone := rbmd.clone()
+	clone.Version = maxVer + 1
+	p.owner.bmd.put(clone)
+	p.owner.bmd.Unlock()
+	glog.Warningf("%s: recovered %s v%d (as %s) from the targets", p.si, bmdTermName, rbmd.Version, clone)
+	return nil
+}
+
+func bmdCopiesEqual(copies []*bucketMD) bool {
+	for _, bmd := range copies[1:] {
+		if !reflect.DeepEqual(bmd.Providers, copies[0].Providers) {
+			return false
+		}
+	}
+	return true
+}
+
 func (p *proxyrunner) uncoverMeta(bcastSmap *smapX) (maxVerSmap *smapX, maxVerBMD *bucketMD) {
 	var (
 		err         error
@@ -5,6 +5,7 @@
 package ais
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -26,12 +27,14 @@ type (
 		sync.RWMutex
 		t       *targetrunner
 		runners map[string]fs.PathRunner // subgroup of the daemon.runners rungroup
+		wd      fsWatchdog
 	}
 )
 
 func (g *fsprungroup) init(t *targetrunner) {
 	g.t = t
 	g.runners = make(map[string]fs.PathRunner, 8)
+	g.wd.init(g)
 }
 
 func (g *fsprungroup) Reg(r fs.PathRunner) {
@@ -61,6 +64,7 @@ func (g *fsprungroup) enableMountpath(mpath string) (enabled bool, err error) {
 		}
 		return
 	}
+	g.wd.forget(mpath)
 
 	g.addMpathEvent(enableMpathAct, mpath)
 	return
@@ -91,6 +95,7 @@ func (g *fsprungroup) addMountpath(mpath string) (err error) {
 		}
 		return
 	}
+	g.wd.forget(mpath)
 
 	g.addMpathEvent(addMpathAct, mpath)
 	return
@@ -106,11 +111,36 @@ func (g *fsprungroup) removeMountpath(mpath string) (err error) {
 		}
 		return
 	}
+	g.wd.forget(mpath)
 
 	g.delMpathEvent(removeMpathAct, mpath)
 	return
 }
 
+// drainMountpath starts copying the mountpath's content to the remaining
+// mountpaths; the mountpath gets removed once the content is verified to be
+// in place (see mirror.XactMpathDrain)
+func (g *fsprungroup) drainMountpath(mpath string) (xact cmn.Xact, err error) {
+	cleanMpath, err := cmn.ValidateMpath(mpath)
+	if err != nil {
+		return
+	}
+	availablePaths, _ := fs.Mountpaths.Get()
+	mpathInfo, ok := availablePaths[cleanMpath]
+	if !ok {
+		return nil, cmn.NewNoMountpathError(mpath)
+	}
+	if len(availablePaths) < 2 {
+		return nil, fmt.Errorf("cannot drain %s: no other mountpaths available", mpathInfo)
+	}
+	xdrain, err := xaction.Registry.RenewMpathDrain(g.t, mpathInfo, g.removeMountpath)
+	if err != nil {
+		return
+	}
+	go xdrain.Run()
+	return xdrain, nil
+}
+
 func (g *fsprungroup) addMpathEvent(action, mpath string) {
 	xaction.Registry.AbortAllMountpathsXactions()
 	g.RLock()
@@ -0,0 +1,256 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/reb"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Mountpath health watchdog
+//
+// Unlike FSHC that tests a mountpath upon (and only upon) an IO error, the
+// watchdog (see cmn.FSHCWatchdogConf) checks all available mountpaths every
+// `interval` and disables the mountpath that has:
+//   - `error_rate` or more IO errors (as reported via t.fshc) in the interval, or
+//   - average disk latency above `max_latency` for wdSlowChecks intervals in a row
+//     (with hysteresis: the count resets only when the latency drops below
+//     wdLatencyClearPct percent of `max_latency`), or
+//   - `max_dev_errors` or more new errors reported by the disk device itself
+// Disabling the mountpath triggers resilvering (see fsprungroup.delMpathEvent).
+// While rebalancing or resilvering (and the latency is expected to be high),
+// the watchdog neither disables nor re-enables mountpaths.
+// After `probation` the watchdog probes the mountpath with a small write and,
+// if it succeeds within `max_latency`, re-enables it; otherwise, the probation
+// starts over. The
+// watchdog never disables the last available mountpath, and does not re-enable
+// the mountpaths that get enabled, removed, or re-added by the user in the
+// meantime. Both events are counted (stats.FSHCDisableCount and FSHCEnableCount).
+
+const (
+	wdSlowChecks      = 3
+	wdLatencyClearPct = 80
+	wdProbeSize       = 4 * cmn.KiB
+	wdIdleInterval    = time.Minute // when disabled
+)
+
+type (
+	wdMpath struct {
+		ioErrs  atomic.Int64 // since the last check
+		devErrs int64        // device-reported, as of the last check
+		slow    int          // consecutive checks with latency above max
+	}
+	fsWatchdog struct {
+		mtx       sync.Mutex
+		g         *fsprungroup
+		mpaths    map[string]*wdMpath
+		probation map[string]int64 // disabled by the watchdog => mono time to probe
+		busy      func() bool      // rebalancing or resilvering
+	}
+)
+
+func (wd *fsWatchdog) init(g *fsprungroup) {
+	wd.g = g
+	wd.busy = wdBusy
+	wd.mpaths = make(map[string]*wdMpath, 8)
+	wd.probation = make(map[string]int64, 2)
+}
+
+// called upon IO error
+func (wd *fsWatchdog) onErr(mpath string) {
+	wd.mtx.Lock()
+	if m, ok := wd.mpaths[mpath]; ok {
+		m.ioErrs.Inc()
+	}
+	wd.mtx.Unlock()
+}
+
+// called when the user enables, removes, or adds the mountpath
+func (wd *fsWatchdog) forget(mpath string) {
+	wd.mtx.Lock()
+	delete(wd.mpaths, mpath)
+	delete(wd.probation, mpath)
+	wd.mtx.Unlock()
+}
+
+// as a housekeeping callback
+func (wd *fsWatchdog) housekeep() time.Duration {
+	config := cmn.GCO.Get()
+	conf := &config.FSHC.Watchdog
+	if !config.FSHC.Enabled || !conf.Enabled {
+		wd.mtx.Lock()
+		wd.mpaths = make(map[string]*wdMpath, 8)
+		wd.mtx.Unlock()
+		return wdIdleInterval
+	}
+	busy := wd.busy()
+	unhealthy := wd.check(conf, busy)
+	if busy {
+		if len(unhealthy) > 0 {
+			glog.Warningf("%s: rebalancing or resilvering - postponing mountpath health actions", wd.g.t.si)
+		}
+		return conf.Interval
+	}
+	for mpath, reason := range unhealthy {
+		wd.disable(mpath, reason, conf)
+	}
+	wd.probe(conf)
+	return conf.Interval
+}
+
+// updates the counters and returns the unhealthy mountpaths (with reasons)
+func (wd *fsWatchdog) check(conf *cmn.FSHCWatchdogConf, busy bool) (unhealthy cmn.SimpleKVs) {
+	availablePaths, _ := fs.Mountpaths.Get()
+	for mpath := range availablePaths {
+		var (
+			reason string
+			h      = fs.Mountpaths.GetMpathHealth(mpath)
+		)
+		wd.mtx.Lock()
+		m, ok := wd.mpaths[mpath]
+		if !ok {
+			wd.mpaths[mpath] = &wdMpath{devErrs: h.DevErrs}
+			wd.mtx.Unlock()
+			continue
+		}
+		wd.mtx.Unlock()
+
+		ioErrs, devErrs := m.ioErrs.Swap(0), h.DevErrs-m.devErrs
+		m.devErrs = h.DevErrs
+		if busy {
+			m.slow = 0 // not representative
+		} else {
+			m.sample(h.Latency, conf.MaxLatency)
+		}
+		switch {
+		case conf.ErrorRate > 0 && ioErrs >= conf.ErrorRate:
+			reason = fmt.Sprintf("%d IO errors in %v", ioErrs, conf.Interval)
+		case conf.MaxDevErrors > 0 && devErrs >= conf.MaxDevErrors:
+			reason = fmt.Sprintf("%d device IO errors in %v", devErrs, conf.Interval)
+		case m.slow >= wdSlowChecks:
+			reason = fmt.Sprintf("disk latency %v exceeds %v", h.Latency, conf.MaxLatency)
+		default:
+			continue
+		}
+		if unhealthy == nil {
+			unhealthy = make(cmn.SimpleKVs, 1)
+		}
+		unhealthy[mpath] = reason
+	}
+	return
+}
+
+func wdBusy() bool {
+	_, rebalancing := reb.IsRebalancing(cmn.ActRebalance)
+	_, resilvering := reb.IsRebalancing(cmn.ActResilver)
+	return rebalancing || resilvering
+}
+
+// counts consecutive checks with latency above max; in-between values (see
+// wdLatencyClearPct) neither increment nor reset the count
+func (m *wdMpath) sample(latency, max time.Duration) {
+	switch {
+	case max <= 0:
+		m.slow = 0
+	case latency > max:
+		m.slow++
+	case latency < max*wdLatencyClearPct/100:
+		m.slow = 0
+	}
+}
+
+func (wd *fsWatchdog) disable(mpath, reason string, conf *cmn.FSHCWatchdogConf) {
+	if fs.Mountpaths.NumAvail() < 2 {
+		glog.Errorf("%s: mountpath %s is unhealthy (%s) but it is the last one available", wd.g.t.si, mpath, reason)
+		return
+	}
+	disabled, err := wd.g.t.DisableMountpath(mpath, reason)
+	if err != nil || !disabled {
+		glog.Errorf("%s: failed to disable mountpath %s: %v", wd.g.t.si, mpath, err)
+		return
+	}
+	wd.g.t.statsT.Add(stats.FSHCDisableCount, 1)
+	wd.mtx.Lock()
+	delete(wd.mpaths, mpath)
+	wd.probation[mpath] = mono.NanoTime() + int64(conf.Probation)
+	wd.mtx.Unlock()
+}
+
+// re-enables the mountpaths that are past probation and pass the probe
+func (wd *fsWatchdog) probe(conf *cmn.FSHCWatchdogConf) {
+	var (
+		now   = mono.NanoTime()
+		ready = make([]string, 0, 2)
+	)
+	_, disabledPaths := fs.Mountpaths.Get()
+	wd.mtx.Lock()
+	for mpath, deadline := range wd.probation {
+		if _, ok := disabledPaths[mpath]; !ok {
+			delete(wd.probation, mpath) // enabled or removed by the user
+			continue
+		}
+		if now >= deadline {
+			ready = append(ready, mpath)
+		}
+	}
+	wd.mtx.Unlock()
+	for _, mpath := range ready {
+		if err := probeMpath(mpath, conf.MaxLatency); err != nil {
+			glog.Errorf("%s: mountpath %s failed the probe (%v) - extending probation", wd.g.t.si, mpath, err)
+			wd.mtx.Lock()
+			wd.probation[mpath] = now + int64(conf.Probation)
+			wd.mtx.Unlock()
+			continue
+		}
+		glog.Warningf("%s: mountpath %s passed probation - re-enabling", wd.g.t.si, mpath)
+		if enabled, err := wd.g.enableMountpath(mpath); err != nil || !enabled {
+			glog.Errorf("%s: failed to re-enable mountpath %s: %v", wd.g.t.si, mpath, err)
+			continue
+		}
+		wd.g.t.statsT.Add(stats.FSHCEnableCount, 1)
+	}
+}
+
+// writes, syncs, reads back, and removes a small temp file - all within
+// maxLatency, if specified
+func probeMpath(mpath string, maxLatency time.Duration) (err error) {
+	if _, err = os.Stat(mpath); err != nil {
+		return
+	}
+	started := mono.NanoTime()
+	file, err := ioutil.TempFile(mpath, fs.WorkfileFSHC)
+	if err != nil {
+		return
+	}
+	defer os.Remove(file.Name())
+	buf := make([]byte, wdProbeSize)
+	if _, err = file.Write(buf); err == nil {
+		err = file.Sync()
+	}
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		return
+	}
+	if _, err = ioutil.ReadFile(file.Name()); err != nil {
+		return
+	}
+	if elapsed := time.Duration(mono.NanoTime() - started); maxLatency > 0 && elapsed > maxLatency {
+		err = fmt.Errorf("probe took %v (max latency %v)", elapsed, maxLatency)
+	}
+	return
+}
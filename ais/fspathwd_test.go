@@ -0,0 +1,90 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+const wdTestMaxLatency = 100 * time.Millisecond
+
+func TestWatchdogLatencyHysteresis(test *testing.T) {
+	m := &wdMpath{}
+	for _, latency := range []time.Duration{150, 90, 150, 90, 150} {
+		m.sample(latency*time.Millisecond, wdTestMaxLatency)
+	}
+	// in-between values neither increment nor reset
+	tassert.Errorf(test, m.slow == wdSlowChecks, "expected %d slow checks, got %d", wdSlowChecks, m.slow)
+
+	m.sample(50*time.Millisecond, wdTestMaxLatency)
+	tassert.Errorf(test, m.slow == 0, "expected reset below %d%% of max, got %d", wdLatencyClearPct, m.slow)
+
+	m.slow = 1
+	m.sample(time.Second, 0)
+	tassert.Errorf(test, m.slow == 0, "expected no count when not checked, got %d", m.slow)
+}
+
+// NOTE: `t` is the target (see TestMain)
+func TestWatchdogCheck(test *testing.T) {
+	mpath, err := ioutil.TempDir("", "fspathwd")
+	tassert.CheckFatal(test, err)
+	defer os.RemoveAll(mpath)
+	iostater := ios.NewIOStaterMock()
+	oldMountpaths := fs.Mountpaths
+	fs.Mountpaths = fs.NewMountedFS(iostater)
+	fs.Mountpaths.DisableFsIDCheck()
+	defer func() { fs.Mountpaths = oldMountpaths }()
+	tassert.CheckFatal(test, fs.Mountpaths.Add(mpath))
+
+	var (
+		g    = &fsprungroup{}
+		conf = &cmn.FSHCWatchdogConf{ErrorRate: 2, MaxLatency: wdTestMaxLatency, Interval: time.Second}
+	)
+	g.init(t)
+	wd := &g.wd
+	tassert.Errorf(test, len(wd.check(conf, false)) == 0, "first check only takes the baseline")
+
+	// IO errors - regardless of rebalance
+	wd.onErr(mpath)
+	wd.onErr(mpath)
+	unhealthy := wd.check(conf, true)
+	tassert.Errorf(test, unhealthy[mpath] != "", "expected %q to be unhealthy", mpath)
+
+	// sustained latency - but not while rebalancing or resilvering
+	iostater.Health[mpath] = ios.MpathHealth{Latency: 2 * wdTestMaxLatency}
+	for i := 0; i < wdSlowChecks; i++ {
+		tassert.Errorf(test, len(wd.check(conf, true)) == 0, "latency must not count during rebalance")
+	}
+	for i := 0; i < wdSlowChecks-1; i++ {
+		tassert.Errorf(test, len(wd.check(conf, false)) == 0, "expected %d checks to pass", wdSlowChecks-1)
+	}
+	unhealthy = wd.check(conf, false)
+	tassert.Errorf(test, unhealthy[mpath] != "", "expected %q to be unhealthy", mpath)
+}
+
+func TestWatchdogProbe(test *testing.T) {
+	mpath, err := ioutil.TempDir("", "fspathwd")
+	tassert.CheckFatal(test, err)
+	defer os.RemoveAll(mpath)
+
+	tassert.CheckError(test, probeMpath(mpath, 0))
+	tassert.CheckError(test, probeMpath(mpath, time.Minute))
+	err = probeMpath(mpath, time.Nanosecond)
+	tassert.Errorf(test, err != nil, "expected the probe to fail on latency")
+	err = probeMpath(mpath+"/nonexistent", 0)
+	tassert.Errorf(test, err != nil, "expected the probe to fail")
+
+	files, err := ioutil.ReadDir(mpath)
+	tassert.CheckFatal(test, err)
+	tassert.Errorf(test, len(files) == 0, "expected the probe to clean up, got %d files", len(files))
+}
@@ -6,6 +6,7 @@ package ais
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
@@ -30,6 +31,8 @@ import (
 	"github.com/NVIDIA/aistore/3rdparty/golang/mux"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/events"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/xaction"
 	"github.com/OneOfOne/xxhash"
@@ -56,6 +59,7 @@ type (
 		req     cmn.ReqArgs
 		timeout time.Duration
 		si      *cluster.Snode
+		bcast   bool // part of a broadcast (see peerStats)
 	}
 
 	// bcastArgs contains arguments for an intra-cluster broadcast call
@@ -85,9 +89,10 @@ type (
 
 	// two pieces of metadata a self-registering (joining) target wants to know right away
 	nodeRegMeta struct {
-		Smap *smapX         `json:"smap"`
-		BMD  *bucketMD      `json:"bmd"`
-		SI   *cluster.Snode `json:"si"`
+		Smap      *smapX         `json:"smap"`
+		BMD       *bucketMD      `json:"bmd"`
+		SI        *cluster.Snode `json:"si"`
+		JoinToken string         `json:"join_token,omitempty"` // see cmn.ActJoinToken
 	}
 
 	// aisMsg is an extended ActionMsg with extra information for node <=> node control plane communications
@@ -116,6 +121,8 @@ type (
 	netServer struct {
 		s             *http.Server
 		mux           *mux.ServeMux
+		ready         *atomic.Bool // (httprunner.startup.ready)
+		network       string       // cmn.KnownNetworks (see cmn.NetConf.Listener)
 		sndRcvBufSize int
 	}
 	httprunner struct {
@@ -128,13 +135,17 @@ type (
 		httpclient         *http.Client // http client for intra-cluster comm
 		httpclientGetPut   *http.Client // http client to execute target <=> target GET & PUT (object)
 		keepalive          keepaliver
+		peers              peerStats // intra-cluster calls by peer
+		clock              clockSkews
 		owner              struct {
 			smap *smapOwner
 			bmd  bmdOwner
 			rmd  *rmdOwner
 		}
 		statsT  stats.Tracker
+		events  *events.Bus // cluster events => external sinks
 		startup struct {
+			ready   atomic.Bool // determines if the node's subsystems are initialized (to serve HTTP)
 			cluster atomic.Bool // determines if the cluster has started up
 			node    struct {
 				time atomic.Time // determines time when the node started up
@@ -246,6 +257,26 @@ func (server *netServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	go transfer(clientConn, destConn)
 }
 
+// gate rejects the clients that are not in the listener's allowed_cidrs (403)
+// and responds with 503 until the node is ready to serve HTTP; health checks
+// go through (see healthHandler)
+func (server *netServer) gate(handler http.Handler, lc *cmn.ListenerConf) http.Handler {
+	healthPath := cmn.URLPath(cmn.Version, cmn.Health)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !lc.IsAllowed(r.RemoteAddr) {
+			cmn.InvalidHandlerDetailed(w, r, fmt.Sprintf("%s is not allowed on the %s network", r.RemoteAddr, server.network),
+				http.StatusForbidden)
+			return
+		}
+		if !server.ready.Load() && !strings.HasPrefix(r.URL.Path, healthPath) {
+			cmn.InvalidHandlerDetailed(w, r, "node is not ready (starting up or shutting down)",
+				http.StatusServiceUnavailable)
+			return
+		}
+		handler.ServeHTTP(w, r)
+	})
+}
+
 func (server *netServer) listenAndServe(addr string, logger *log.Logger) error {
 	config := cmn.GCO.Get()
 
@@ -257,16 +288,19 @@ func (server *netServer) listenAndServe(addr string, logger *log.Logger) error {
 	if config.Net.HTTP.RevProxy == cmn.RevProxyCloud {
 		httpHandler = server
 	}
+	lc := config.Net.Listener(server.network)
 	server.s = &http.Server{
 		Addr:     addr,
-		Handler:  httpHandler,
+		Handler:  server.gate(httpHandler, lc),
 		ErrorLog: logger,
 	}
-	if server.sndRcvBufSize > 0 && !config.Net.HTTP.UseHTTPS {
+	if server.sndRcvBufSize > 0 && !lc.UseHTTPS {
 		server.s.ConnState = server.connStateListener // setsockopt; see also cmn.NewTransport
 	}
-	if config.Net.HTTP.UseHTTPS {
-		if err := server.s.ListenAndServeTLS(config.Net.HTTP.Certificate, config.Net.HTTP.Key); err != nil {
+	if lc.UseHTTPS {
+		// the certificate is loaded at startup and may get reloaded at runtime (see tlsCert)
+		server.s.TLSConfig = &tls.Config{GetCertificate: tlsCerts[lc.CertFile].get}
+		if err := server.s.ListenAndServeTLS("", ""); err != nil {
 			if err != http.ErrServerClosed {
 				glog.Errorf("Terminated server with err: %v", err)
 				return err
@@ -295,12 +329,12 @@ func (server *netServer) connStateListener(c net.Conn, cs http.ConnState) {
 	rawconn.Control(args.ConnControl(rawconn))
 }
 
-func (server *netServer) shutdown() {
+func (server *netServer) shutdown(ctx context.Context) {
 	if server.s == nil {
 		return
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), cmn.GCO.Get().Timeout.MaxHostBusy/2)
+	ctx, cancel := context.WithTimeout(ctx, cmn.GCO.Get().Timeout.MaxHostBusy/2)
 	if err := server.s.Shutdown(ctx); err != nil {
 		glog.Infof("Stopped server, err: %v", err)
 	}
@@ -318,6 +352,20 @@ func (h *httprunner) NodeStartedTime() time.Time { return h.startup.node.time.Lo
 func (h *httprunner) markClusterStarted()        { h.startup.cluster.Store(true) }
 func (h *httprunner) markNodeStarted()           { h.startup.node.time.Store(time.Now()) }
 
+// markReady is called once all the subsystems that the HTTP handlers depend on
+// are initialized (see initProxy and initTarget for the startup order); prior
+// to that, and when stopping, the node responds with 503 (see netServer.gate)
+func (h *httprunner) markReady() { h.startup.ready.Store(true) }
+
+// serve starts listening right away - as soon as the handlers are registered
+// and while the node is not ready yet (see markReady) - and returns the
+// channel to receive the error that the listeners terminate with (see run)
+func (h *httprunner) serve() <-chan error {
+	errCh := make(chan error, 1)
+	go func() { errCh <- h.run() }()
+	return errCh
+}
+
 func (h *httprunner) registerNetworkHandlers(networkHandlers []networkHandler) {
 	config := cmn.GCO.Get()
 
@@ -373,14 +421,14 @@ func (h *httprunner) init(s stats.Tracker, config *cmn.Config) {
 	h.statsT = s
 	h.httpclient = cmn.NewClient(cmn.TransportArgs{
 		Timeout:    config.Client.Timeout,
-		UseHTTPS:   config.Net.HTTP.UseHTTPS,
+		UseHTTPS:   config.Net.Listener(cmn.NetworkIntraControl).UseHTTPS,
 		SkipVerify: config.Net.HTTP.SkipVerify,
 	})
 	h.httpclientGetPut = cmn.NewClient(cmn.TransportArgs{
 		Timeout:         config.Client.TimeoutLong,
 		WriteBufferSize: config.Net.HTTP.WriteBufferSize,
 		ReadBufferSize:  config.Net.HTTP.ReadBufferSize,
-		UseHTTPS:        config.Net.HTTP.UseHTTPS,
+		UseHTTPS:        config.Net.Listener(cmn.NetworkIntraData).UseHTTPS,
 		SkipVerify:      config.Net.HTTP.SkipVerify,
 	})
 
@@ -390,12 +438,16 @@ func (h *httprunner) init(s stats.Tracker, config *cmn.Config) {
 	}
 	h.publicServer = &netServer{
 		mux:           mux.NewServeMux(),
+		ready:         &h.startup.ready,
+		network:       cmn.NetworkPublic,
 		sndRcvBufSize: bufsize,
 	}
 	h.intraControlServer = h.publicServer // by default intra control net is the same as public
 	if config.Net.UseIntraControl {
 		h.intraControlServer = &netServer{
 			mux:           mux.NewServeMux(),
+			ready:         &h.startup.ready,
+			network:       cmn.NetworkIntraControl,
 			sndRcvBufSize: 0,
 		}
 	}
@@ -403,6 +455,8 @@ func (h *httprunner) init(s stats.Tracker, config *cmn.Config) {
 	if config.Net.UseIntraData {
 		h.intraDataServer = &netServer{
 			mux:           mux.NewServeMux(),
+			ready:         &h.startup.ready,
+			network:       cmn.NetworkIntraData,
 			sndRcvBufSize: bufsize,
 		}
 	}
@@ -418,45 +472,45 @@ func (h *httprunner) initSI(daemonType string) {
 		s             string
 		config        = cmn.GCO.Get()
 		port          = config.Net.L4.Port
-		addrList, err = getLocalIPv4List()
+		addrList, err = getLocalIPList()
 	)
 	if err != nil {
 		glog.Fatalf("FATAL: %v", err)
 	}
 
-	ipAddr, err := getIPv4(addrList, config.Net.IPv4)
+	ipAddr, err := getIP(addrList, config.Net.IPv4, config.Net.IPFamily)
 	if err != nil {
-		glog.Fatalf("Failed to get PUBLIC IPv4/hostname: %v", err)
+		glog.Fatalf("Failed to get PUBLIC IP/hostname: %v", err)
 	}
 	if config.Net.IPv4 != "" {
 		s = " (config: " + config.Net.IPv4 + ")"
 	}
-	glog.Infof("PUBLIC (user) access: [%s:%d]%s", ipAddr, config.Net.L4.Port, s)
+	glog.Infof("PUBLIC (user) access: [%s]%s", net.JoinHostPort(ipAddr.String(), config.Net.L4.PortStr), s)
 
 	ipAddrIntraControl := net.IP{}
 	if config.Net.UseIntraControl {
-		ipAddrIntraControl, err = getIPv4(addrList, config.Net.IPv4IntraControl)
+		ipAddrIntraControl, err = getIP(addrList, config.Net.IPv4IntraControl, config.Net.IPFamilyIntraControl)
 		if err != nil {
-			glog.Fatalf("Failed to get INTRA-CONTROL IPv4/hostname: %v", err)
+			glog.Fatalf("Failed to get INTRA-CONTROL IP/hostname: %v", err)
 		}
 		s = ""
 		if config.Net.IPv4IntraControl != "" {
 			s = " (config: " + config.Net.IPv4IntraControl + ")"
 		}
-		glog.Infof("INTRA-CONTROL access: [%s:%d]%s", ipAddrIntraControl, config.Net.L4.PortIntraControl, s)
+		glog.Infof("INTRA-CONTROL access: [%s]%s", net.JoinHostPort(ipAddrIntraControl.String(), config.Net.L4.PortIntraControlStr), s)
 	}
 
 	ipAddrIntraData := net.IP{}
 	if config.Net.UseIntraData {
-		ipAddrIntraData, err = getIPv4(addrList, config.Net.IPv4IntraData)
+		ipAddrIntraData, err = getIP(addrList, config.Net.IPv4IntraData, config.Net.IPFamilyIntraData)
 		if err != nil {
-			glog.Fatalf("Failed to get INTRA-DATA IPv4/hostname: %v", err)
+			glog.Fatalf("Failed to get INTRA-DATA IP/hostname: %v", err)
 		}
 		s = ""
 		if config.Net.IPv4IntraData != "" {
 			s = " (config: " + config.Net.IPv4IntraData + ")"
 		}
-		glog.Infof("INTRA-DATA access: [%s:%d]%s", ipAddrIntraData, config.Net.L4.PortIntraData, s)
+		glog.Infof("INTRA-DATA access: [%s]%s", net.JoinHostPort(ipAddrIntraData.String(), config.Net.L4.PortIntraDataStr), s)
 	}
 
 	mustDiffer(ipAddr, config.Net.L4.Port, true,
@@ -495,6 +549,14 @@ func (h *httprunner) initSI(daemonType string) {
 	}
 
 	h.si = newSnode(daemonID, config.Net.HTTP.Proto, daemonType, publicAddr, intraControlAddr, intraDataAddr)
+	// the intra-cluster networks may have their own TLS settings (see cmn.ListenersConf)
+	if len(intraControlAddr.IP) > 0 {
+		h.si.IntraControlNet.DirectURL = config.Net.Listener(cmn.NetworkIntraControl).Proto + "://" + intraControlAddr.String()
+	}
+	if len(intraDataAddr.IP) > 0 {
+		h.si.IntraDataNet.DirectURL = config.Net.Listener(cmn.NetworkIntraData).Proto + "://" + intraDataAddr.String()
+	}
+	h.si.Domain = os.Getenv(cmn.EnvVars.FailureDomain)
 	cmn.InitShortID(h.si.Digest())
 }
 
@@ -502,8 +564,8 @@ func mustDiffer(ip1 net.IP, port1 int, use1 bool, ip2 net.IP, port2 int, use2 bo
 	if !use1 || !use2 {
 		return
 	}
-	if string(ip1) == string(ip2) && port1 == port2 {
-		glog.Fatalf("%s: cannot use the same IP:port (%s:%d) for two networks", tag, string(ip1), port1)
+	if ip1.Equal(ip2) && port1 == port2 {
+		glog.Fatalf("%s: cannot use the same IP:port (%s) for two networks", tag, net.JoinHostPort(ip1.String(), strconv.Itoa(port1)))
 	}
 }
 
@@ -525,12 +587,12 @@ func (h *httprunner) checkPresenceNetChange(smap *smapX) error {
 		return fmt.Errorf("%s: not present in the loaded Smap", h.si)
 	}
 	if h.si.PublicNet.NodeIPAddr != snode.PublicNet.NodeIPAddr {
-		glog.Errorf("Warning: %s: PUBLIC (user) IPv4 changed: previous %s, current %s", h.si,
+		glog.Errorf("Warning: %s: PUBLIC (user) IP changed: previous %s, current %s", h.si,
 			snode.PublicNet.NodeIPAddr, h.si.PublicNet.NodeIPAddr)
 		changed = true
 	}
 	if h.si.IntraControlNet.NodeIPAddr != snode.IntraControlNet.NodeIPAddr {
-		glog.Errorf("Warning: %s: INTRA-CONTROL IPv4 changed: previous %s, current %s", h.si,
+		glog.Errorf("Warning: %s: INTRA-CONTROL IP changed: previous %s, current %s", h.si,
 			snode.IntraControlNet.NodeIPAddr, h.si.IntraControlNet.NodeIPAddr)
 		changed = true
 	}
@@ -564,20 +626,20 @@ func (h *httprunner) run() error {
 
 		if config.Net.UseIntraControl {
 			go func() {
-				addr := h.si.IntraControlNet.NodeIPAddr + ":" + h.si.IntraControlNet.DaemonPort
+				addr := net.JoinHostPort(h.si.IntraControlNet.NodeIPAddr, h.si.IntraControlNet.DaemonPort)
 				errCh <- h.intraControlServer.listenAndServe(addr, h.logger)
 			}()
 		}
 
 		if config.Net.UseIntraData {
 			go func() {
-				addr := h.si.IntraDataNet.NodeIPAddr + ":" + h.si.IntraDataNet.DaemonPort
+				addr := net.JoinHostPort(h.si.IntraDataNet.NodeIPAddr, h.si.IntraDataNet.DaemonPort)
 				errCh <- h.intraDataServer.listenAndServe(addr, h.logger)
 			}()
 		}
 
 		go func() {
-			addr := h.si.PublicNet.NodeIPAddr + ":" + h.si.PublicNet.DaemonPort
+			addr := net.JoinHostPort(h.si.PublicNet.NodeIPAddr, h.si.PublicNet.DaemonPort)
 			errCh <- h.publicServer.listenAndServe(addr, h.logger)
 		}()
 
@@ -590,21 +652,22 @@ func (h *httprunner) run() error {
 }
 
 // stop gracefully
-func (h *httprunner) stop(err error) {
+func (h *httprunner) stop(ctx context.Context, err error) {
 	config := cmn.GCO.Get()
 	glog.Infof("Stopping %s, err: %v", h.GetRunName(), err)
+	h.startup.ready.Store(false)
 
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
 	go func() {
-		h.publicServer.shutdown()
+		h.publicServer.shutdown(ctx)
 		wg.Done()
 	}()
 
 	if config.Net.UseIntraControl {
 		wg.Add(1)
 		go func() {
-			h.intraControlServer.shutdown()
+			h.intraControlServer.shutdown(ctx)
 			wg.Done()
 		}()
 	}
@@ -612,7 +675,7 @@ func (h *httprunner) stop(err error) {
 	if config.Net.UseIntraData {
 		wg.Add(1)
 		go func() {
-			h.intraDataServer.shutdown()
+			h.intraDataServer.shutdown(ctx)
 			wg.Done()
 		}()
 	}
@@ -624,7 +687,7 @@ func (h *httprunner) stop(err error) {
 // intra-cluster IPC, control plane
 // call another target or a proxy; optionally, include a json-encoded body
 //
-func (h *httprunner) call(args callArgs) callResult {
+func (h *httprunner) call(args callArgs) (res callResult) {
 	var (
 		req     *http.Request
 		sid     = unknownDaemonID
@@ -690,6 +753,8 @@ func (h *httprunner) call(args callArgs) callResult {
 		req.Header.Set(cmn.HeaderCallerSmapVersion, strconv.FormatInt(smap.version(), 10))
 	}
 
+	defer h.peers.add(&args, mono.NanoTime(), &res)
+	sent := time.Now()
 	resp, err := client.Do(req)
 	if err != nil {
 		if resp != nil && resp.StatusCode > 0 {
@@ -729,6 +794,9 @@ func (h *httprunner) call(args callArgs) callResult {
 	if sid != unknownDaemonID {
 		h.keepalive.heardFrom(sid, false /* reset */)
 	}
+	if peerTime := resp.Header.Get(cmn.HeaderNodeTime); peerTime != "" && args.si != nil {
+		h.clock.add(h.si, args.si, peerTime, sent, time.Now())
+	}
 
 	return callResult{args.si, outjson, resp.Header, err, details, resp.StatusCode}
 }
@@ -833,7 +901,6 @@ func (h *httprunner) callTargets(method, path string, body []byte, query ...url.
 	return h.callBcast(method, path, body, cluster.Targets, query...)
 }
 
-// nolint:unused // integral with callTargets and callAll
 func (h *httprunner) callProxies(method, path string, body []byte, query ...url.Values) chan callResult {
 	return h.callBcast(method, path, body, cluster.Proxies, query...)
 }
@@ -868,6 +935,7 @@ func (h *httprunner) bcast(bargs bcastArgs) chan callResult {
 					si:      di,
 					req:     bargs.req,
 					timeout: bargs.timeout,
+					bcast:   true,
 				}
 				cargs.req.Base = di.URL(bargs.network)
 
@@ -1000,6 +1068,8 @@ func (h *httprunner) httpdaeget(w http.ResponseWriter, r *http.Request) {
 		body = msg
 	case cmn.GetWhatSnode:
 		body = h.si
+	case cmn.GetWhatPeerStats:
+		body = h.peers.get(h.owner.smap.get())
 	default:
 		s := fmt.Sprintf("Invalid GET /daemon request: unrecognized what=%s", getWhat)
 		h.invalmsghdlr(w, r, s)
@@ -1020,6 +1090,25 @@ func (h *httprunner) invalmsghdlr(w http.ResponseWriter, r *http.Request, msg st
 	h.statsT.AddErrorHTTP(r.Method, 1)
 }
 
+// invalmsghdlrErr is invalmsghdlr that classifies the error (see cmn.ErrCode)
+// for the clients to act upon.
+func (h *httprunner) invalmsghdlrErr(w http.ResponseWriter, r *http.Request, err error, errCode ...int) {
+	if _, ok := err.(*cmn.HTTPError); ok {
+		h.invalmsghdlr(w, r, err.Error(), errCode...) // already classified
+		return
+	}
+	status := http.StatusBadRequest
+	if len(errCode) > 0 && errCode[0] >= http.StatusBadRequest {
+		status = errCode[0]
+	}
+	msg := err.Error()
+	if caller := r.Header.Get(cmn.HeaderCallerName); caller != "" {
+		msg += " (from " + caller + ")"
+	}
+	cmn.InvalidHandlerCode(w, r, msg, cmn.ErrCode(err, status), status)
+	h.statsT.AddErrorHTTP(r.Method, 1)
+}
+
 func (h *httprunner) invalmsghdlrsilent(w http.ResponseWriter, r *http.Request, msg string, errCode ...int) {
 	cmn.InvalidHandlerDetailedNoLog(w, r, msg, errCode...)
 }
@@ -1344,9 +1433,13 @@ func (h *httprunner) join(query url.Values, primaryURLs ...string) (res callResu
 func (h *httprunner) registerToURL(url string, psi *cluster.Snode, tout time.Duration,
 	query url.Values, keepalive bool) (res callResult) {
 	regReq := nodeRegMeta{SI: h.si}
-	if h.si.IsTarget() && !keepalive {
-		regReq.BMD = h.owner.bmd.get()
-		regReq.Smap = h.owner.smap.get()
+	if h.si.IsTarget() {
+		if !keepalive {
+			regReq.BMD = h.owner.bmd.get()
+			regReq.Smap = h.owner.smap.get()
+		}
+		// (keepalive included - in case the primary no longer has us in its Smap)
+		regReq.JoinToken = os.Getenv(cmn.EnvVars.JoinToken)
 	}
 	info := cmn.MustMarshal(regReq)
 	path := cmn.URLPath(cmn.Version, cmn.Cluster)
@@ -1477,6 +1570,16 @@ func (h *httprunner) bucketPropsToHdr(bck *cluster.Bck, hdr http.Header) {
 	})
 }
 
+// adds the provenance of the bucket properties (see cmn.PropsProvenance)
+func (h *httprunner) bucketProvenanceToHdr(bck *cluster.Bck, hdr http.Header) {
+	provenance := &bck.Props.Provenance
+	cmn.IterFields(bck.Props, func(fieldName string, _ cmn.IterField) (error, bool) {
+		pp := provenance.Get(fieldName)
+		hdr.Add(cmn.HeaderBucketProvenance, fieldName+"="+pp.Source+":"+strconv.FormatInt(pp.Version, 10))
+		return nil, false
+	})
+}
+
 func (h *httprunner) selectBMDBuckets(bmd *bucketMD, query cmn.QueryBcks) cmn.BucketNames {
 	var (
 		names = make(cmn.BucketNames, 0, 10)
@@ -5,8 +5,17 @@
 package ais
 
 import (
+	"bytes"
+	"net"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/stats"
 )
 
 const (
@@ -122,3 +131,76 @@ func TestKeepaliveTrackerHeartBeat(t *testing.T) {
 		t.Fatal("Expecting time out")
 	}
 }
+
+// keepalive from a target that is not in the Smap requires a join token
+func TestKeepaliveJoinToken(t *testing.T) {
+	const secret = "keepalive-secret"
+	var (
+		p       = newDiscoverServerPrimary()
+		addr    = &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 8081}
+		known   = newSnode("known", httpProto, cmn.Target, addr, addr, addr)
+		unknown = newSnode("unknown", httpProto, cmn.Target, addr, addr, addr)
+		smap    = newSmap()
+	)
+	p.statsT = stats.NewTrackerMock()
+	p.keepalive = newProxyKeepaliveRunner(p, p.statsT, atomic.NewBool(true))
+	smap.addProxy(p.si)
+	smap.ProxySI = p.si
+	smap.addTarget(known)
+	smap.UUID = "keepalive-cluster"
+	p.owner.smap.put(smap)
+	p.markClusterStarted()
+
+	oldConfig := cmn.GCO.Get()
+	defer func() {
+		cmn.GCO.BeginUpdate()
+		cmn.GCO.CommitUpdate(oldConfig)
+	}()
+	config := cmn.GCO.BeginUpdate()
+	config.Auth.Secret = secret
+	config.Proxy.JoinTokens = true
+	cmn.GCO.CommitUpdate(config)
+
+	keepalive := func(si *cluster.Snode, token string) int {
+		body := cmn.MustMarshal(nodeRegMeta{SI: si, JoinToken: token})
+		r := httptest.NewRequest(http.MethodPost, cmn.URLPath(cmn.Version, cmn.Cluster, cmn.Keepalive), bytes.NewReader(body))
+		w := httptest.NewRecorder()
+		p.httpclupost(w, r)
+		return w.Code
+	}
+	if code := keepalive(known, ""); code != http.StatusOK {
+		t.Errorf("keepalive from %s: expected %d, got %d", known, http.StatusOK, code)
+	}
+	if code := keepalive(unknown, ""); code != http.StatusUnauthorized {
+		t.Errorf("keepalive from %s without token: expected %d, got %d", unknown, http.StatusUnauthorized, code)
+	}
+	expired, err := cmn.NewJoinToken(smap.UUID, time.Now().Add(-time.Hour), secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code := keepalive(unknown, expired); code != http.StatusUnauthorized {
+		t.Errorf("keepalive from %s with expired token: expected %d, got %d", unknown, http.StatusUnauthorized, code)
+	}
+	token, err := cmn.NewJoinToken(smap.UUID, time.Now().Add(time.Hour), secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.validateJoinToken(unknown, token); err != nil {
+		t.Errorf("valid token rejected: %v", err)
+	}
+
+	// the target that is in the Smap is admitted even if its network info has changed
+	newAddr := &net.TCPAddr{IP: net.ParseIP("127.0.0.2"), Port: 8081}
+	restarted := newSnode("known", httpProto, cmn.Target, newAddr, newAddr, newAddr)
+	if err := p.validateJoinToken(restarted, ""); err != nil {
+		t.Errorf("%s with new network info rejected: %v", restarted, err)
+	}
+
+	// join tokens disabled (the default)
+	config = cmn.GCO.BeginUpdate()
+	config.Proxy.JoinTokens = false
+	cmn.GCO.CommitUpdate(config)
+	if err := p.validateJoinToken(unknown, ""); err != nil {
+		t.Errorf("join tokens disabled: %v", err)
+	}
+}
@@ -119,7 +119,7 @@ type (
 		nodesRevs    map[string]nodeRevs // sync-ed versions (cluster-wide, by DaemonID)
 		lastSynced   map[string]revs     // last/current sync-ed
 		lastClone    msPayload           // to enforce CoW
-		stopCh       chan struct{}       // stop channel
+		stopCh       *cmn.StopCh         // stop channel
 		workCh       chan revsReq        // work channel
 		retryTimer   *time.Timer         // timer to sync pending
 		timerStopped bool                // true if retryTimer has been stopped, false otherwise
@@ -140,7 +140,7 @@ func newMetasyncer(p *proxyrunner) (y *metasyncer) {
 	y.lastClone = make(msPayload)
 	y.nodesRevs = make(map[string]nodeRevs)
 
-	y.stopCh = make(chan struct{}, 1)
+	y.stopCh = cmn.NewStopCh()
 	y.workCh = make(chan revsReq, 8)
 
 	y.retryTimer = time.NewTimer(time.Hour)
@@ -185,7 +185,7 @@ func (y *metasyncer) Run() error {
 			} else {
 				y.timerStopped = true
 			}
-		case <-y.stopCh:
+		case <-y.stopCh.Listen():
 			y.retryTimer.Stop()
 			return nil
 		}
@@ -195,8 +195,7 @@ func (y *metasyncer) Run() error {
 func (y *metasyncer) Stop(err error) {
 	glog.Infof("Stopping %s, err: %v", y.GetRunName(), err)
 
-	y.stopCh <- struct{}{}
-	close(y.stopCh)
+	y.stopCh.Close()
 }
 
 //
@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 	"time"
 
@@ -302,3 +303,99 @@ func TestDiscoverServers(t *testing.T) {
 		}
 	}
 }
+
+func newRecoverBMD(uuid string, version int64, buckets ...string) *bucketMD {
+	bmd := newBucketMD()
+	bmd.UUID = uuid
+	for _, name := range buckets {
+		bmd.add(cluster.NewBck(name, cmn.ProviderAIS, cmn.NsGlobal), &cmn.BucketProps{})
+	}
+	bmd.Version = version
+	return bmd
+}
+
+// recoverBMDHandler returns a given BMD (nil - always fails)
+func recoverBMDHandler(bmd *bucketMD) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if bmd == nil {
+				http.Error(w, "down", http.StatusServiceUnavailable)
+				return
+			}
+			w.Write(cmn.MustMarshal(bmd))
+		},
+	))
+}
+
+func TestRecoverBMD(t *testing.T) {
+	var (
+		empty = newRecoverBMD("", 0)
+		a5    = newRecoverBMD("uuid", 5, "a")
+		b5    = newRecoverBMD("uuid", 5, "b")
+		ab7   = newRecoverBMD("uuid", 7, "a", "b")
+		ab9   = newRecoverBMD("other-uuid", 9, "a", "b")
+	)
+	tcs := []struct {
+		name    string
+		bmds    []*bucketMD // per target
+		err     bool
+		version int64
+		buckets []string
+	}{
+		{"same BMD at all targets", []*bucketMD{a5, a5, a5}, false, 6, []string{"a"}},
+		{"newer BMD at a minority", []*bucketMD{a5, a5, ab7}, false, 8, []string{"a"}},
+		{"newer BMD at a majority", []*bucketMD{a5, ab7, ab7}, false, 8, []string{"a", "b"}},
+		{"one target is down", []*bucketMD{a5, a5, nil}, false, 6, []string{"a"}},
+		{"minority uuid", []*bucketMD{a5, a5, ab9}, false, 10, []string{"a"}},
+		{"copies differ", []*bucketMD{a5, b5, empty}, true, 0, nil},
+		{"no quorum", []*bucketMD{a5, nil, nil}, true, 0, nil},
+		{"no BMD at all", []*bucketMD{empty, empty, empty}, true, 0, nil},
+	}
+	for _, tc := range tcs {
+		var (
+			primary = newDiscoverServerPrimary()
+			smap    = newSmap()
+		)
+		config := cmn.GCO.BeginUpdate()
+		config.Timeout.Startup = time.Second
+		config.Timeout.CplaneOperation = 200 * time.Millisecond
+		cmn.GCO.CommitUpdate(config)
+		for i, bmd := range tc.bmds {
+			ts := recoverBMDHandler(bmd)
+			defer ts.Close()
+			addrInfo := serverTCPAddr(ts.URL)
+			smap.addTarget(newSnode("t"+strconv.Itoa(i), httpProto, cmn.Target, addrInfo, &net.TCPAddr{}, &net.TCPAddr{}))
+		}
+		err := primary.recoverBMD(smap)
+		if tc.err {
+			if err == nil {
+				t.Errorf("test case %q: expecting error", tc.name)
+			}
+			if bmd := primary.owner.bmd.get(); bmd.version() != 0 {
+				t.Errorf("test case %q: expecting no BMD, got %s", tc.name, bmd)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("test case %q: %v", tc.name, err)
+			continue
+		}
+		bmd := primary.owner.bmd.get()
+		if bmd.Version != tc.version {
+			t.Errorf("test case %q: expecting version %d, got %d", tc.name, tc.version, bmd.Version)
+		}
+		if bmd.UUID != "uuid" {
+			t.Errorf("test case %q: expecting uuid %q, got %q", tc.name, "uuid", bmd.UUID)
+		}
+		var n int
+		bmd.Range(nil, nil, func(*cluster.Bck) bool { n++; return false })
+		if n != len(tc.buckets) {
+			t.Errorf("test case %q: expecting %d bucket(s), got %d", tc.name, len(tc.buckets), n)
+		}
+		for _, name := range tc.buckets {
+			if _, present := bmd.Get(cluster.NewBck(name, cmn.ProviderAIS, cmn.NsGlobal)); !present {
+				t.Errorf("test case %q: %s is missing", tc.name, name)
+			}
+		}
+	}
+}
@@ -5,6 +5,7 @@
 package ais
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -24,7 +25,6 @@ import (
 // see also cmn/notif.go //
 ///////////////////////////
 
-// TODO: cmn.UponProgress as periodic (byte-count, object-count)
 // TODO: batch housekeeping for pending notifications
 // TODO: add an option to enforce 'if one notifier fails all fail'
 // TODO: housekeeping: broadcast in a separate goroutine
@@ -32,17 +32,28 @@ import (
 // notifMsg.Ty enum
 const (
 	notifXact = iota
+	notifJob  // asynchronous operation, notified by the proxy itself (see startJob)
+	notifOOS  // target's out-of-space state change (see capOOS)
 	// TODO: add more
 )
 
 var notifTyText = map[int]string{
 	notifXact: "xaction",
+	notifJob:  "job",
+	notifOOS:  "oos",
 }
 
+// notifMsg.Flags
+const (
+	notifFlagProgress = 1 << iota // periodic progress (cmn.XactProgress) - not to be counted as completion
+)
+
 const (
 	notifsName       = ".notifications.prx"
 	notifsHousekeepT = 2 * time.Minute
 	notifsRemoveMult = 3 // time-to-keep multiplier (time = notifsRemoveMult * notifsHousekeepT)
+	// waiting for the xaction (or job) listened to by another proxy
+	notifsPollT = 10 * time.Second
 )
 
 type (
@@ -68,6 +79,10 @@ type (
 		UUID() string
 		setUUID(string)
 		finTime() int64
+		setProgress(string /*sid*/, *cmn.XactProgress)
+		progress() *cmn.XactProgress
+		addNext(func(err error)) bool
+		finErr() error
 		String() string
 	}
 	notifListenerBase struct {
@@ -79,21 +94,43 @@ type (
 		rc   int              // refcount
 		ty   int              // notifMsg.Ty enum (above)
 		tfin atomic.Int64     // timestamp when finished
+
+		prog map[string]*cmn.XactProgress // [node-ID => latest progress] (notifXact only)
+
+		next     []func(err error) // to run upon completion (see notifs.waitFor)
+		nextDone bool              // true when the above have been run
 	}
 	notifListenerBck struct {
 		notifListenerBase
 		nlp *cluster.NameLockPair
+		job *notifListenerJob // to finish upon completion (async operation)
 	}
 	notifListenerFromTo struct {
 		notifListenerBase
 		nlpFrom, nlpTo *cluster.NameLockPair
+		job            *notifListenerJob // to finish upon completion (async operation)
+	}
+	notifListenerJob struct {
+		notifListenerBase
+		action  string
+		bck     cmn.Bck
+		started int64
+		waitFor string // UUID of the xaction (or job) to wait for (see cmn.URLParamWaitFor)
+		chained bool   // finishes upon completion of another notifListener
+		once    sync.Once
+	}
+	// completion status of a given xaction (or job) as seen by the proxy that
+	// listens to it (see notifs.waitFor)
+	notifStatus struct {
+		Finished bool   `json:"finished"`
+		Err      string `json:"err,omitempty"`
 	}
 	//
 	// notification messages
 	//
 	notifMsg struct {
 		Ty    int32          `json:"type"`    // enumerated type, one of (notifXact, et al.) - see above
-		Flags int32          `json:"flags"`   // TODO: add
+		Flags int32          `json:"flags"`   // notifFlagProgress, et al. - see above
 		Snode *cluster.Snode `json:"snode"`   // node
 		Data  []byte         `json:"message"` // typed message
 		Err   error          `json:"err"`     // error
@@ -124,6 +161,10 @@ func (nlb *notifListenerBase) callback(notifs *notifs, n notifListener, msg inte
 		notifs.fmu.Lock()
 		notifs.fin[n.UUID()] = n
 		notifs.fmu.Unlock()
+		if nlb.ty == notifXact {
+			notifs.p.events.Publish(xactFinishEvent(n, msg, err, now))
+		}
+		nlb.runNext(err)
 	}
 }
 func (nlb *notifListenerBase) lock()                      { nlb.Lock() }
@@ -143,6 +184,87 @@ func (nlb *notifListenerBase) addErr(sid string, err error) {
 	nlb.errs[sid] = err
 }
 
+// returns any one of the errors reported by the notifiers
+func (nlb *notifListenerBase) finErr() (err error) {
+	for _, e := range nlb.errs {
+		err = e
+		break
+	}
+	return
+}
+
+// registers a function to run upon completion; returns false if the
+// completion has already happened
+func (nlb *notifListenerBase) addNext(f func(err error)) (added bool) {
+	nlb.Lock()
+	if !nlb.nextDone {
+		nlb.next = append(nlb.next, f)
+		added = true
+	}
+	nlb.Unlock()
+	return
+}
+
+// runs the functions waiting for the completion; a failure at any of the
+// notifiers gets propagated to all of them
+func (nlb *notifListenerBase) runNext(err error) {
+	nlb.Lock()
+	if err == nil {
+		err = nlb.finErr()
+	}
+	next := nlb.next
+	nlb.next, nlb.nextDone = nil, true
+	nlb.Unlock()
+	for _, f := range next {
+		go f(err)
+	}
+}
+
+// records the latest progress of a given node; the node's estimated totals
+// (that only come with the periodic notifications) are kept
+func (nlb *notifListenerBase) setProgress(sid string, prog *cmn.XactProgress) {
+	if nlb.prog == nil {
+		nlb.prog = make(map[string]*cmn.XactProgress, len(nlb.srcs))
+	}
+	if prev, ok := nlb.prog[sid]; ok && prog.TotalObjs == 0 {
+		prog.TotalObjs, prog.TotalBytes = prev.TotalObjs, prev.TotalBytes
+	}
+	nlb.prog[sid] = prog
+}
+
+// aggregates the progress reported by all notifiers
+func (nlb *notifListenerBase) progress() *cmn.XactProgress {
+	agg := &cmn.XactProgress{UUID: nlb.uuid, Targets: len(nlb.srcs), Reported: len(nlb.prog), Pct: -1}
+	for _, prog := range nlb.prog {
+		agg.Kind, agg.Bck = prog.Kind, prog.Bck
+		agg.Objects += prog.Objects
+		agg.Bytes += prog.Bytes
+		agg.TotalObjs += prog.TotalObjs
+		agg.TotalBytes += prog.TotalBytes
+	}
+	switch {
+	case nlb.tfin.Load() > 0:
+		agg.Finished = true
+		if nlb.errs == nil {
+			agg.Pct = 100
+		}
+	case agg.Reported == agg.Targets && agg.TotalObjs > 0:
+		// an estimate - and not done until all notifiers say so
+		agg.Pct = int(cmn.MinI64(agg.Objects*100/agg.TotalObjs, 99))
+	}
+	return agg
+}
+
+func xactStatsProgress(stats *cmn.BaseXactStatsExt) *cmn.XactProgress {
+	return &cmn.XactProgress{
+		UUID:    stats.ID(),
+		Kind:    stats.Kind(),
+		Bck:     stats.Bck(),
+		Objects: stats.ObjCount(),
+		Bytes:   stats.BytesCount(),
+	}
+}
+
 func (nlb *notifListenerBase) String() string {
 	var tm, res string
 	hdr := fmt.Sprintf("%s-%q", notifText(nlb.ty), nlb.uuid)
@@ -223,7 +345,14 @@ func (n *notifs) handler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	switch notifMsg.Ty {
+	case notifOOS:
+		n.p.handleNotifOOS(w, r, notifMsg)
+		return
 	case notifXact:
+		if notifMsg.Flags&notifFlagProgress != 0 {
+			n.handleProgress(w, r, notifMsg, tid)
+			return
+		}
 		stats := &cmn.BaseXactStatsExt{}
 		if eru := jsoniter.Unmarshal(notifMsg.Data, stats); eru != nil {
 			n.p.invalmsghdlrstatusf(w, r, 0, "%s: failed to unmarshal %s: %v", n.p.si, notifMsg, eru)
@@ -255,6 +384,9 @@ func (n *notifs) handler(w http.ResponseWriter, r *http.Request) {
 	cmn.Assert(nl.notifTy() == int(notifMsg.Ty))
 
 	nl.lock()
+	if stats, ok := msg.(*cmn.BaseXactStatsExt); ok {
+		nl.setProgress(tid, xactStatsProgress(stats))
+	}
 	err, status, done := n.handleMsg(nl, tid, notifMsg.Err)
 	nl.unlock()
 	if done {
@@ -262,8 +394,54 @@ func (n *notifs) handler(w http.ResponseWriter, r *http.Request) {
 		n.del(nl)
 	}
 	if err != nil {
-		n.p.invalmsghdlr(w, r, err.Error(), status)
+		n.p.invalmsghdlrErr(w, r, err, status)
+	}
+}
+
+// periodic progress: recorded but otherwise not acted upon; late progress
+// notifications (that may race with the completion) are silently ignored
+func (n *notifs) handleProgress(w http.ResponseWriter, r *http.Request, notifMsg *notifMsg, tid string) {
+	prog := &cmn.XactProgress{}
+	if eru := jsoniter.Unmarshal(notifMsg.Data, prog); eru != nil {
+		n.p.invalmsghdlrstatusf(w, r, 0, "%s: failed to unmarshal %s: %v", n.p.si, notifMsg, eru)
+		return
 	}
+	n.RLock()
+	nl, ok := n.m[prog.UUID]
+	n.RUnlock()
+	if !ok {
+		return
+	}
+	nl.lock()
+	if tsi := nl.notifiers()[tid]; tsi != nil { // known and not done yet
+		nl.setProgress(tid, prog)
+	}
+	nl.unlock()
+}
+
+// returns the cluster-wide progress of a given (running or recently finished) xaction
+func (n *notifs) xactProgress(uuid string) (prog *cmn.XactProgress, err error, status int) {
+	n.RLock()
+	nl, ok := n.m[uuid]
+	n.RUnlock()
+	if !ok {
+		n.fmu.RLock()
+		nl, ok = n.fin[uuid]
+		n.fmu.RUnlock()
+	}
+	if !ok {
+		err = fmt.Errorf("%s: unknown xaction UUID %q", n.p.si, uuid)
+		status = http.StatusNotFound
+		return
+	}
+	if nl.notifTy() != notifXact {
+		err = fmt.Errorf("%s: %s is not an xaction", n.p.si, nl)
+		return
+	}
+	nl.rlock()
+	prog = nl.progress()
+	nl.runlock()
+	return
 }
 
 // is called under notifListener.lock()
@@ -314,6 +492,8 @@ func (n *notifs) housekeep() time.Duration {
 	req := bcastArgs{req: cmn.ReqArgs{Query: make(url.Values, 2)}, timeout: cmn.GCO.Get().Timeout.MaxKeepalive}
 	for uuid, nl := range tempn {
 		switch nl.notifTy() {
+		case notifJob:
+			continue // nothing to poll
 		case notifXact:
 			req.req.Path = cmn.URLPath(cmn.Version, cmn.Xactions)
 			req.req.Query.Set(cmn.URLParamWhat, cmn.GetWhatXactStats)
@@ -366,6 +546,9 @@ func (n *notifs) hkXact(nl notifListener, res callResult) (msg interface{}, err
 		return
 	}
 	msg = stats
+	nl.lock()
+	nl.setProgress(res.si.ID(), xactStatsProgress(stats))
+	nl.unlock()
 	if stats.Finished() {
 		if stats.Aborted() {
 			detail := fmt.Sprintf("%s, node %s", nl, res.si)
@@ -442,10 +625,215 @@ func (n *notifs) ListenSmapChanged() {
 	}
 }
 
+//////////////////////
+// notifListenerJob //
+//////////////////////
+
+func newJob(p *proxyrunner, action string, bck *cluster.Bck) *notifListenerJob {
+	return &notifListenerJob{
+		notifListenerBase: notifListenerBase{
+			srcs: cluster.NodeMap{p.si.ID(): p.si},
+			f:    p._logNotifDone,
+			ty:   notifJob,
+		},
+		action:  action,
+		bck:     bck.Bck,
+		started: time.Now().UnixNano(),
+	}
+}
+
+func (job *notifListenerJob) status() *cmn.JobStatus {
+	job.rlock()
+	defer job.runlock()
+	st := &cmn.JobStatus{
+		UUID:     job.uuid,
+		Action:   job.action,
+		Bck:      job.bck,
+		WaitFor:  job.waitFor,
+		Started:  job.started,
+		Finished: job.finTime(),
+	}
+	if err := job.finErr(); err != nil {
+		st.Err = err.Error()
+	}
+	return st
+}
+
+// the proxy (the job's only notifier) notifies itself - once: a chained job
+// may also get finished by the operation it has been waiting for (see startJob)
+func (n *notifs) jobDone(job *notifListenerJob, err error) {
+	job.once.Do(func() {
+		job.lock()
+		_, _, done := n.handleMsg(job, n.p.si.ID(), err)
+		job.unlock()
+		if done {
+			job.callback(n, job, nil, err)
+			n.del(job)
+		}
+	})
+}
+
+// runs a given function upon completion of the xaction (or job) with a given
+// UUID - right away if the latter has already finished (see cmn.URLParamWaitFor)
+//
+// The xaction (or job) is normally listened to by this (primary) proxy. If it
+// is not - e.g., when started by the previous primary - the other proxies get
+// asked, and the one that listens to it gets polled until completion.
+// Either way, the waiting is in-memory only and does not survive the restart
+// of this proxy (or, in the latter case, of the one that gets polled).
+func (n *notifs) waitFor(uuid string, f func(err error)) (err error, status int) {
+	if n.waitLocal(uuid, f) {
+		return
+	}
+	si, st := n.findRemote(uuid)
+	if si == nil {
+		err = fmt.Errorf("%s: cannot wait for %q: unknown (or long finished) xaction or job", n.p.si, uuid)
+		status = http.StatusNotFound
+		return
+	}
+	if st.Finished {
+		go f(st.error())
+		return
+	}
+	go n.pollRemote(si, uuid, f)
+	return
+}
+
+// returns false if the xaction (or job) is unknown
+func (n *notifs) waitLocal(uuid string, f func(err error)) bool {
+	nl, ok := n.find(uuid)
+	if !ok {
+		return false
+	}
+	if !nl.addNext(f) {
+		nl.rlock()
+		errPrev := nl.finErr()
+		nl.runlock()
+		go f(errPrev)
+	}
+	return true
+}
+
+func (n *notifs) find(uuid string) (nl notifListener, ok bool) {
+	n.RLock()
+	nl, ok = n.m[uuid]
+	n.RUnlock()
+	if !ok {
+		n.fmu.RLock()
+		nl, ok = n.fin[uuid]
+		n.fmu.RUnlock()
+	}
+	return
+}
+
+// the status of a given xaction (or job) listened to by this proxy (see
+// cmn.GetWhatNotifStatus); nil if unknown
+func (n *notifs) status(uuid string) *notifStatus {
+	nl, ok := n.find(uuid)
+	if !ok {
+		return nil
+	}
+	st := &notifStatus{Finished: nl.finTime() > 0}
+	if st.Finished {
+		nl.rlock()
+		if err := nl.finErr(); err != nil {
+			st.Err = err.Error()
+		}
+		nl.runlock()
+	}
+	return st
+}
+
+// asks the other proxies
+func (n *notifs) findRemote(uuid string) (si *cluster.Snode, st *notifStatus) {
+	query := url.Values{cmn.URLParamWhat: []string{cmn.GetWhatNotifStatus}, cmn.URLParamUUID: []string{uuid}}
+	results := n.p.callProxies(http.MethodGet, cmn.URLPath(cmn.Version, cmn.Daemon), nil, query)
+	for res := range results {
+		if res.err != nil || si != nil {
+			continue
+		}
+		st = &notifStatus{}
+		if err := jsoniter.Unmarshal(res.outjson, st); err != nil {
+			glog.Errorf("%s: failed to unmarshal %s status from %s: %v", n.p.si, uuid, res.si, err)
+			continue
+		}
+		si = res.si
+	}
+	return
+}
+
+// runs the function upon completion of the xaction (or job) listened to by
+// another proxy - or when the latter leaves the cluster or forgets about it
+func (n *notifs) pollRemote(si *cluster.Snode, uuid string, f func(err error)) {
+	query := url.Values{cmn.URLParamWhat: []string{cmn.GetWhatNotifStatus}, cmn.URLParamUUID: []string{uuid}}
+	for {
+		time.Sleep(notifsPollT)
+		if n.p.owner.smap.get().GetProxy(si.ID()) == nil {
+			f(fmt.Errorf("%s: cannot wait for %q: %s (that listens to it) has left the cluster", n.p.si, uuid, si))
+			return
+		}
+		res := n.p.call(callArgs{
+			si:      si,
+			req:     cmn.ReqArgs{Method: http.MethodGet, Path: cmn.URLPath(cmn.Version, cmn.Daemon), Query: query},
+			timeout: cmn.GCO.Get().Timeout.CplaneOperation,
+		})
+		if res.status == http.StatusNotFound {
+			f(fmt.Errorf("%s: cannot wait for %q: unknown to %s (restarted?)", n.p.si, uuid, si))
+			return
+		}
+		if res.err != nil {
+			glog.Warningf("%s: failed to get %q status from %s: %v - retrying", n.p.si, uuid, si, res.err)
+			continue
+		}
+		st := &notifStatus{}
+		if err := jsoniter.Unmarshal(res.outjson, st); err != nil {
+			f(fmt.Errorf("%s: failed to unmarshal %q status from %s: %v", n.p.si, uuid, si, err))
+			return
+		}
+		if st.Finished {
+			f(st.error())
+			return
+		}
+	}
+}
+
+func (st *notifStatus) error() error {
+	if st.Err == "" {
+		return nil
+	}
+	return errors.New(st.Err)
+}
+
+func (n *notifs) getJob(uuid string) (job *notifListenerJob) {
+	n.RLock()
+	nl, ok := n.m[uuid]
+	n.RUnlock()
+	if !ok {
+		n.fmu.RLock()
+		nl, ok = n.fin[uuid]
+		n.fmu.RUnlock()
+	}
+	if ok && nl.notifTy() == notifJob {
+		job = nl.(*notifListenerJob)
+	}
+	return
+}
+
 //////////
 // misc //
 //////////
 
+func xactFinishEvent(n notifListener, msg interface{}, err error, now int64) *cmn.Event {
+	ev := &cmn.Event{Type: cmn.EventXactFinish, Time: now, UUID: n.UUID()}
+	if stats, ok := msg.(*cmn.BaseXactStatsExt); ok && stats != nil {
+		ev.Xact, ev.Bck = stats.Kind(), stats.Bck()
+	}
+	if err != nil {
+		ev.Err = err.Error()
+	}
+	return ev
+}
+
 func notifText(ty int) string {
 	const unk = "unknown"
 	if txt, ok := notifTyText[ty]; ok {
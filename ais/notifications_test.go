@@ -0,0 +1,165 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+// proxyNotifsMock responds with the status of the xactions (or jobs) it
+// "listens to" (see cmn.GetWhatNotifStatus)
+type proxyNotifsMock map[string]*notifStatus
+
+func (mock proxyNotifsMock) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	if query.Get(cmn.URLParamWhat) != cmn.GetWhatNotifStatus {
+		return
+	}
+	st, ok := mock[query.Get(cmn.URLParamUUID)]
+	if !ok {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	w.Write(cmn.MustMarshal(st))
+}
+
+func newNotifsTest(mock proxyNotifsMock) (p *proxyrunner, ts *httptest.Server) {
+	p = newPrimary()
+	p.notifs.p = p
+	p.notifs.m = make(map[string]notifListener)
+	p.notifs.fin = make(map[string]notifListener)
+
+	ts = httptest.NewServer(mock)
+	clone := p.owner.smap.get().clone()
+	clone.addProxy(newSnode("proxyB", httpProto, cmn.Proxy, serverTCPAddr(ts.URL), &net.TCPAddr{}, &net.TCPAddr{}))
+	p.owner.smap.put(clone)
+	return
+}
+
+// waits for a given function to run and returns its error
+func waitNext(t *testing.T, ch chan error) error {
+	select {
+	case err := <-ch:
+		return err
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the function to run")
+	}
+	return nil
+}
+
+func TestNotifsWaitForLocal(t *testing.T) {
+	p, ts := newNotifsTest(proxyNotifsMock{})
+	defer ts.Close()
+
+	var (
+		running = &notifListenerJob{}
+		done    = &notifListenerJob{}
+		ch      = make(chan error, 2)
+		errFail = errors.New("failed")
+	)
+	p.notifs.m["running"] = running
+	done.tfin.Store(time.Now().UnixNano())
+	done.addErr("targetA", errFail)
+	done.runNext(nil)
+	p.notifs.fin["done"] = done
+
+	err, _ := p.notifs.waitFor("running", func(err error) { ch <- err })
+	tassert.CheckFatal(t, err)
+	select {
+	case <-ch:
+		t.Fatal("must wait for completion")
+	case <-time.After(100 * time.Millisecond):
+	}
+	running.runNext(nil)
+	tassert.CheckError(t, waitNext(t, ch))
+
+	// finished - runs right away, with the error propagated
+	err, _ = p.notifs.waitFor("done", func(err error) { ch <- err })
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, waitNext(t, ch) == errFail, "expected %v", errFail)
+
+	st := p.notifs.status("done")
+	tassert.Fatalf(t, st != nil && st.Finished && st.Err == errFail.Error(), "unexpected status %+v", st)
+	st = p.notifs.status("running")
+	tassert.Errorf(t, st != nil && !st.Finished, "unexpected status %+v", st)
+}
+
+// listened to by another proxy (e.g., the previous primary)
+func TestNotifsWaitForRemote(t *testing.T) {
+	p, ts := newNotifsTest(proxyNotifsMock{
+		"copied":  {Finished: true},
+		"failed":  {Finished: true, Err: "copy failed"},
+		"copying": {},
+	})
+	defer ts.Close()
+
+	ch := make(chan error, 1)
+	err, _ := p.notifs.waitFor("copied", func(err error) { ch <- err })
+	tassert.CheckFatal(t, err)
+	tassert.CheckError(t, waitNext(t, ch))
+
+	err, _ = p.notifs.waitFor("failed", func(err error) { ch <- err })
+	tassert.CheckFatal(t, err)
+	err = waitNext(t, ch)
+	tassert.Errorf(t, err != nil && err.Error() == "copy failed", "expected remote error, got %v", err)
+
+	si, st := p.notifs.findRemote("copying")
+	tassert.Fatalf(t, si != nil && si.ID() == "proxyB", "expected proxyB, got %v", si)
+	tassert.Errorf(t, !st.Finished, "unexpected status %+v", st)
+
+	err, status := p.notifs.waitFor("unknown", func(error) { t.Error("must not run") })
+	tassert.Errorf(t, err != nil && status == http.StatusNotFound, "expected 404, got %v (%d)", err, status)
+}
+
+// a chained job may get finished by both the operation it has been waiting for
+// and startJob (see jobDone)
+func TestNotifsJobDone(t *testing.T) {
+	p, ts := newNotifsTest(proxyNotifsMock{})
+	defer ts.Close()
+
+	var (
+		bck     = cluster.NewBck("bck", cmn.ProviderAIS, cmn.NsGlobal)
+		job     = newJob(p, cmn.ActRenameLB, bck)
+		errFail = errors.New("failed")
+		cnt     atomic.Int32
+		wg      sync.WaitGroup
+	)
+	job.f = func(notifListener, interface{}, error) { cnt.Inc() }
+	p.notifs.add("job", job)
+
+	st := job.status()
+	tassert.Errorf(t, st.UUID == "job" && st.Action == cmn.ActRenameLB && st.Finished == 0,
+		"unexpected status %+v", st)
+
+	p.notifs.jobDone(job, errFail)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.notifs.jobDone(job, nil)
+		}()
+	}
+	wg.Wait()
+	tassert.Errorf(t, cnt.Load() == 1, "expected the job to finish once, got %d", cnt.Load())
+
+	// finished - found among the finished ones
+	found := p.notifs.getJob("job")
+	tassert.Fatalf(t, found == job, "expected to find the finished job")
+	st = found.status()
+	tassert.Errorf(t, st.Finished != 0, "expected the job to be finished")
+	tassert.Errorf(t, st.Err == errFail.Error(), "expected %v, got %q", errFail, st.Err)
+	tassert.Errorf(t, p.notifs.getJob("unknown") == nil, "expected no job")
+}
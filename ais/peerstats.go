@@ -0,0 +1,80 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+	"github.com/NVIDIA/aistore/transport"
+)
+
+// Peer stats
+//
+// Each node counts its intra-cluster calls by destination node and channel:
+// point-to-point control-plane calls, broadcasts, and metasync (see
+// httprunner.call), and the objects sent via transport streams (see
+// transport.GetPeerStats). A call fails when the peer cannot be reached or
+// responds with 5xx; other (4xx) responses are part of the normal operation.
+// The stats accumulate since the node's startup (see cmn.GetWhatPeerStats).
+
+type (
+	peerStats struct {
+		m sync.Map // [peerChan => *cmn.PeerCallTracker]
+	}
+	peerChan struct {
+		sid, ch string
+	}
+)
+
+var metasyncPath = cmn.URLPath(cmn.Version, cmn.Metasync)
+
+func (ps *peerStats) add(args *callArgs, started int64, res *callResult) {
+	if args.si == nil {
+		return
+	}
+	ch := cmn.PeerChanCall
+	if strings.HasPrefix(args.req.Path, metasyncPath) {
+		ch = cmn.PeerChanMetasync
+	} else if args.bcast {
+		ch = cmn.PeerChanBcast
+	}
+	var err error
+	if res.err != nil && (res.status == 0 || res.status >= http.StatusInternalServerError) {
+		err = res.err
+	}
+	pt, _ := ps.m.LoadOrStore(peerChan{args.si.ID(), ch}, &cmn.PeerCallTracker{})
+	pt.(*cmn.PeerCallTracker).Add(mono.Since(started), err)
+}
+
+func (ps *peerStats) get(smap *smapX) cmn.PeerStats {
+	stats := make(cmn.PeerStats, smap.CountTargets()+smap.CountProxies())
+	ps.m.Range(func(key, value interface{}) bool {
+		pc := key.(peerChan)
+		stats.Add(pc.sid, pc.ch, value.(*cmn.PeerCallTracker).Stats())
+		return true
+	})
+	// transport streams: scheme://host => node ID
+	urls := make(cmn.SimpleKVs, (smap.CountTargets()+smap.CountProxies())*3)
+	for _, nodeMap := range []cluster.NodeMap{smap.Pmap, smap.Tmap} {
+		for sid, si := range nodeMap {
+			for _, network := range []string{cmn.NetworkPublic, cmn.NetworkIntraControl, cmn.NetworkIntraData} {
+				urls[si.URL(network)] = sid
+			}
+		}
+	}
+	for u, st := range transport.GetPeerStats() {
+		peer := u
+		if sid, ok := urls[u]; ok {
+			peer = sid
+		}
+		stats.Add(peer, cmn.PeerChanTransport, st)
+	}
+	return stats
+}
@@ -6,6 +6,7 @@ package ais
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -16,7 +17,8 @@ import (
 	"net/url"
 	"os"
 	"path"
-	"strings"
+	"regexp"
+	"sort"
 	"sync"
 	"syscall"
 	"time"
@@ -27,7 +29,6 @@ import (
 	"github.com/NVIDIA/aistore/cmn/jsp"
 	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/dsort"
-	"github.com/NVIDIA/aistore/housekeep/hk"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/sys"
@@ -65,6 +66,9 @@ type (
 		metasyncer *metasyncer
 		rproxy     reverseProxy
 		notifs     notifs
+		capOOS     capOOS
+		txnJournal txnJournal
+		usedTokens usedTokens
 		gmm        *memsys.MMSA // system pagesize-based memory manager and slab allocator
 	}
 	remBckAddArgs struct {
@@ -118,6 +122,7 @@ func (p *proxyrunner) Run() error {
 	p.authn = &authManager{
 		tokens:        make(map[string]*cmn.AuthToken),
 		revokedTokens: make(map[string]bool),
+		s3Tokens:      make(map[string]string),
 		version:       1,
 	}
 
@@ -125,6 +130,7 @@ func (p *proxyrunner) Run() error {
 	initListObjectsCache(p)
 
 	p.notifs.init(p)
+	p.txnJournal.load()
 
 	//
 	// REST API: register proxy handlers and start listening
@@ -147,6 +153,7 @@ func (p *proxyrunner) Run() error {
 		{r: cmn.Vote, h: p.voteHandler, net: []string{cmn.NetworkIntraControl}},
 
 		{r: cmn.Notifs, h: p.notifs.handler, net: []string{cmn.NetworkIntraControl}},
+		{r: cmn.Txn, h: p.txnHandler, net: []string{cmn.NetworkPublic, cmn.NetworkIntraControl}},
 
 		{r: "/" + cmn.S3, h: p.s3Handler, net: []string{cmn.NetworkPublic}},
 	}
@@ -165,6 +172,7 @@ func (p *proxyrunner) Run() error {
 	}
 
 	p.registerNetworkHandlers(networkHandlers)
+	errCh := p.serve() // (not ready yet - see markReady below)
 
 	glog.Infof("%s: [public net] listening on: %s", p.si, p.si.PublicNet.DirectURL)
 	if p.si.PublicNet.DirectURL != p.si.IntraControlNet.DirectURL {
@@ -178,7 +186,8 @@ func (p *proxyrunner) Run() error {
 	}
 
 	dsort.RegisterNode(p.owner.smap, p.owner.bmd, p.si, nil, nil, p.statsT)
-	return p.httprunner.run()
+	p.markReady()
+	return <-errCh
 }
 
 func (p *proxyrunner) sendKeepalive(timeout time.Duration) (status int, err error) {
@@ -245,21 +254,27 @@ func (p *proxyrunner) unregisterSelf() (int, error) {
 }
 
 // stop gracefully
-func (p *proxyrunner) Stop(err error) {
+func (p *proxyrunner) Stop(err error) { p.StopCtx(context.Background(), err) }
+
+func (p *proxyrunner) StopCtx(ctx context.Context, err error) {
 	var isPrimary bool
 	smap := p.owner.smap.get()
 	if smap != nil { // in tests
 		isPrimary = smap.isPrimary(p.si)
 	}
 	glog.Infof("Stopping %s (%s, primary=%t), err: %v", p.GetRunName(), p.si, isPrimary, err)
-	hk.Housekeeper.Abort()
 	xaction.Registry.AbortAll()
 
 	if isPrimary {
 		// give targets and non primary proxies some time to unregister
 		version := smap.version()
+	wait:
 		for i := 0; i < 20; i++ {
-			time.Sleep(time.Second)
+			select {
+			case <-time.After(time.Second):
+			case <-ctx.Done():
+				break wait
+			}
 			v := p.owner.smap.get().version()
 			if version == v {
 				break
@@ -276,7 +291,7 @@ func (p *proxyrunner) Stop(err error) {
 		}
 	}
 
-	p.httprunner.stop(err)
+	p.httprunner.stop(ctx, err)
 }
 
 //==================================
@@ -336,12 +351,12 @@ func (p *proxyrunner) httpbckget(w http.ResponseWriter, r *http.Request) {
 	switch apiItems[0] {
 	case cmn.AllBuckets:
 		if err := p.checkPermissions(r, nil, cmn.AccessBckLIST); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 			return
 		}
 		bck, err := newBckFromQuery("", r.URL.Query())
 		if err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+			p.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 			return
 		}
 		p.listBuckets(w, r, cmn.QueryBcks(bck.Bck))
@@ -360,7 +375,7 @@ func (p *proxyrunner) objGetRProxy(w http.ResponseWriter, r *http.Request) {
 	bucket, objName := apitems[0], apitems[1]
 	bck, err := newBckFromQuery(bucket, r.URL.Query())
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		p.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 	if err = bck.Init(p.owner.bmd, p.si); err != nil {
@@ -372,13 +387,13 @@ func (p *proxyrunner) objGetRProxy(w http.ResponseWriter, r *http.Request) {
 	smap := p.owner.smap.get()
 	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraControl)
 	pReq, err := http.NewRequest(r.Method, redirectURL, r.Body)
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	for header, values := range r.Header {
@@ -388,7 +403,7 @@ func (p *proxyrunner) objGetRProxy(w http.ResponseWriter, r *http.Request) {
 	}
 	pRes, err := p.httpclient.Do(pReq)
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if pRes.StatusCode >= http.StatusBadRequest {
@@ -409,7 +424,7 @@ func (p *proxyrunner) httpobjget(w http.ResponseWriter, r *http.Request) {
 	bucket, objName := apitems[0], apitems[1]
 	bck, err := newBckFromQuery(bucket, r.URL.Query())
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		p.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 	if err = bck.Init(p.owner.bmd, p.si); err != nil {
@@ -419,17 +434,17 @@ func (p *proxyrunner) httpobjget(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	if err := p.checkPermissions(r, &bck.Bck, cmn.AccessGET); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 		return
 	}
 	if err := bck.Allow(cmn.AccessGET); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 		return
 	}
 	smap := p.owner.smap.get()
 	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	config := cmn.GCO.Get()
@@ -461,7 +476,7 @@ func (p *proxyrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 	bucket, objName := apiItems[0], apiItems[1]
 	bck, err := newBckFromQuery(bucket, r.URL.Query())
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if err = bck.Init(p.owner.bmd, p.si); err != nil {
@@ -472,26 +487,31 @@ func (p *proxyrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var (
-		si       *cluster.Snode
-		nodeID   string
-		smap     = p.owner.smap.get()
-		appendTy = query.Get(cmn.URLParamAppendType)
+		si        *cluster.Snode
+		nodeID    string
+		misplaced bool
+		smap      = p.owner.smap.get()
+		appendTy  = query.Get(cmn.URLParamAppendType)
 	)
 	if appendTy == "" {
 		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessPUT); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 			return
 		}
 		err = bck.Allow(cmn.AccessPUT)
 	} else {
 		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessAPPEND); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 			return
 		}
 		var hi handleInfo
-		hi, err = parseAppendHandle(query.Get(cmn.URLParamAppendHandle))
+		if appendTy == cmn.WriteAtOp || appendTy == cmn.CommitOp {
+			hi, err = parseWriteAtHandle(query.Get(cmn.URLParamAppendHandle))
+		} else {
+			hi, err = parseAppendHandle(query.Get(cmn.URLParamAppendHandle))
+		}
 		if err != nil {
-			p.invalmsghdlr(w, r, err.Error())
+			p.invalmsghdlrErr(w, r, err)
 			return
 		}
 		nodeID = hi.nodeID
@@ -499,21 +519,21 @@ func (p *proxyrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 		return
 	}
 
 	if nodeID == "" {
-		si, err = cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+		si, misplaced, err = p.putTarget(bck, objName, smap)
 		if err != nil {
-			p.invalmsghdlr(w, r, err.Error())
+			p.invalmsghdlrErr(w, r, err)
 			return
 		}
 	} else {
 		si = smap.GetTarget(nodeID)
 		if si == nil {
 			err = &errNodeNotFound{"PUT failure", nodeID, p.si, smap}
-			p.invalmsghdlr(w, r, err.Error())
+			p.invalmsghdlrErr(w, r, err)
 			return
 		}
 	}
@@ -522,11 +542,17 @@ func (p *proxyrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 		glog.Infof("%s %s/%s => %s (append: %v)", r.Method, bucket, objName, si, appendTy != "")
 	}
 	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraData)
+	if misplaced {
+		redirectURL += "&" + cmn.URLParamMisplaced + "=true"
+	}
 	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
 
-	if appendTy == "" {
+	switch appendTy {
+	case "":
 		p.statsT.Add(stats.PutCount, 1)
-	} else {
+	case cmn.WriteAtOp, cmn.CommitOp:
+		p.statsT.Add(stats.WriteAtCount, 1)
+	default:
 		p.statsT.Add(stats.AppendCount, 1)
 	}
 }
@@ -541,7 +567,7 @@ func (p *proxyrunner) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 	bucket, objName := apitems[0], apitems[1]
 	bck, err := newBckFromQuery(bucket, r.URL.Query())
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		p.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 	if err = bck.Init(p.owner.bmd, p.si); err != nil {
@@ -551,17 +577,17 @@ func (p *proxyrunner) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	if err := p.checkPermissions(r, &bck.Bck, cmn.AccessObjDELETE); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 		return
 	}
 	if err = bck.Allow(cmn.AccessObjDELETE); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 		return
 	}
 	smap := p.owner.smap.get()
 	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if glog.FastV(4, glog.SmoduleAIS) {
@@ -586,15 +612,15 @@ func (p *proxyrunner) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 	bucket := apitems[0]
 	bck, err := newBckFromQuery(bucket, r.URL.Query())
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		p.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 	if err = bck.Init(p.owner.bmd, p.si); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		p.invalmsghdlrErr(w, r, err, http.StatusNotFound)
 		return
 	}
 	if err = bck.Allow(cmn.AccessBckDELETE); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 		return
 	}
 	switch msg.Action {
@@ -609,9 +635,33 @@ func (p *proxyrunner) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessBckDELETE); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 			return
 		}
+	case cmn.ActDelete, cmn.ActEvictObjects:
+		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessObjDELETE); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		if msg.Action == cmn.ActEvictObjects && bck.IsAIS() {
+			p.invalmsghdlrf(w, r, fmtNotCloud, bucket)
+			return
+		}
+	default:
+		p.invalmsghdlrf(w, r, fmtUnknownAct, msg)
+		return
+	}
+	if msg.Action == cmn.ActDestroyLB || msg.Action == cmn.ActDelete {
+		// (single-use confirmation tokens are validated by the primary)
+		if r.URL.Query().Get(cmn.URLParamConfirmToken) != "" && p.forwardCP(w, r, &msg, bucket, nil) {
+			return
+		}
+		if err, errCode := p.checkMassDelete(r, bck, &msg); err != nil {
+			p.invalmsghdlrErr(w, r, err, errCode)
+			return
+		}
+	}
+	if msg.Action == cmn.ActEvictCB || msg.Action == cmn.ActDestroyLB {
 		if bck.IsRemoteAIS() {
 			if err := p.reverseReqRemote(w, r, &msg, bck.Bck); err != nil {
 				return
@@ -621,23 +671,13 @@ func (p *proxyrunner) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 			if _, ok := err.(*cmn.ErrorBucketDoesNotExist); ok { // race
 				glog.Infof("%s: %s already %q-ed, nothing to do", p.si, bck, msg.Action)
 			} else {
-				p.invalmsghdlr(w, r, err.Error())
+				p.invalmsghdlrErr(w, r, err)
 			}
 		}
-	case cmn.ActDelete, cmn.ActEvictObjects:
-		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessObjDELETE); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
-			return
-		}
-		if msg.Action == cmn.ActEvictObjects && bck.IsAIS() {
-			p.invalmsghdlrf(w, r, fmtNotCloud, bucket)
-			return
-		}
-		if err = p.doListRange(http.MethodDelete, bucket, &msg, r.URL.Query()); err != nil {
-			p.invalmsghdlr(w, r, err.Error())
-		}
-	default:
-		p.invalmsghdlrf(w, r, fmtUnknownAct, msg)
+		return
+	}
+	if _, err = p.doListRange(http.MethodDelete, bucket, &msg, r.URL.Query()); err != nil {
+		p.invalmsghdlrErr(w, r, err)
 	}
 }
 
@@ -742,6 +782,7 @@ func (p *proxyrunner) healthHandler(w http.ResponseWriter, r *http.Request) {
 		primary = smap != nil && smap.version() > 0 && smap.isPrimary(p.si)
 		getCii  = cmn.IsParseBool(query.Get(cmn.URLParamClusterInfo))
 	)
+	setNodeTime(w)
 	// NOTE: internal use
 	if getCii {
 		cii := &clusterInfo{}
@@ -749,6 +790,10 @@ func (p *proxyrunner) healthHandler(w http.ResponseWriter, r *http.Request) {
 		_ = p.writeJSON(w, r, cii, "cluster-info")
 		return
 	}
+	if cmn.IsParseBool(query.Get(cmn.URLParamClockSkew)) {
+		_ = p.writeJSON(w, r, p.clock.get(smap), "clock-skew")
+		return
+	}
 	// non-primary will keep returning 503 until cluster starts up
 	if !primary && !p.ClusterStarted() {
 		w.WriteHeader(http.StatusServiceUnavailable)
@@ -786,7 +831,7 @@ func (p *proxyrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 	// 1. "all buckets"
 	if len(apiItems) == 0 {
 		if err := p.checkPermissions(r, nil, cmn.AccessBckLIST); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 			return
 		}
 
@@ -803,13 +848,13 @@ func (p *proxyrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 		case cmn.ActSummaryBucket:
 			bck, err := newBckFromQuery("", r.URL.Query())
 			if err != nil {
-				p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+				p.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 				return
 			}
 			// bck might be a query bck..
 			if err = bck.Init(p.owner.bmd, p.si); err == nil {
 				if err = bck.Allow(cmn.AccessBckHEAD); err != nil {
-					p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+					p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 					return
 				}
 			}
@@ -823,7 +868,7 @@ func (p *proxyrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 	bucket := apiItems[0]
 	bck, err := newBckFromQuery(bucket, r.URL.Query())
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		p.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 
@@ -840,11 +885,11 @@ func (p *proxyrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 	// 3. createlb
 	if msg.Action == cmn.ActCreateLB {
 		if err := p.checkPermissions(r, nil, cmn.AccessBckCreate); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 			return
 		}
 		if err = cmn.ValidateBckName(bucket); err != nil {
-			p.invalmsghdlr(w, r, err.Error())
+			p.invalmsghdlrErr(w, r, err)
 			return
 		}
 		if bck.Bck.IsCloud(cmn.AnyCloud) {
@@ -858,7 +903,7 @@ func (p *proxyrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 		if msg.Value != nil {
 			propsToUpdate := cmn.BucketPropsToUpdate{}
 			if err := cmn.MorphMarshal(msg.Value, &propsToUpdate); err != nil {
-				p.invalmsghdlr(w, r, err.Error())
+				p.invalmsghdlrErr(w, r, err)
 				return
 			}
 			bck.Props = cmn.DefaultBucketProps()
@@ -866,16 +911,20 @@ func (p *proxyrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 			// make and validate nprops
 			bck.Props, _, _, err = p.makeNprops(bck, propsToUpdate, true /*creating*/)
 			if err != nil {
-				p.invalmsghdlr(w, r, err.Error())
+				p.invalmsghdlrErr(w, r, err)
 				return
 			}
 		}
+		if isAsyncReq(r) {
+			p.startJob(w, r, msg.Action, bck, func(*notifListenerJob) error { return p.createBucket(&msg, bck) })
+			return
+		}
 		if err := p.createBucket(&msg, bck); err != nil {
 			errCode := http.StatusInternalServerError
 			if _, ok := err.(*cmn.ErrorBucketAlreadyExists); ok {
 				errCode = http.StatusConflict
 			}
-			p.invalmsghdlr(w, r, err.Error(), errCode)
+			p.invalmsghdlrErr(w, r, err, errCode)
 		}
 		return
 	} else if msg.Action != cmn.ActPrefetch {
@@ -890,13 +939,15 @@ func (p *proxyrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 
 	if err = bck.Init(p.owner.bmd, p.si); err != nil {
 		_, ok := err.(*cmn.ErrorRemoteBucketDoesNotExist)
-		if ok && msg.Action == cmn.ActRenameLB {
+		if ok && (msg.Action == cmn.ActRenameLB || msg.Action == cmn.ActSwapLB) {
 			p.invalmsghdlrstatusf(w, r, http.StatusNotFound, "cannot %q: ais bucket %q does not exist", msg.Action, bucket)
 			return
 		}
-		args := remBckAddArgs{p: p, w: w, r: r, queryBck: bck, err: err, msg: &msg}
-		if bck, err = args.try(); err != nil {
-			return
+		if !p.bckPendingOK(r, &msg, err) {
+			args := remBckAddArgs{p: p, w: w, r: r, queryBck: bck, err: err, msg: &msg}
+			if bck, err = args.try(); err != nil {
+				return
+			}
 		}
 	}
 
@@ -904,41 +955,90 @@ func (p *proxyrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 	switch msg.Action {
 	case cmn.ActRenameLB:
 		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessBckRENAME); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 			return
 		}
-		if !bck.IsAIS() {
+		// cloud buckets get renamed by copying (cached content) - see renameCloudBucket
+		if !bck.IsAIS() && !bck.Bck.IsCloud() {
 			p.invalmsghdlrf(w, r, fmtErr, msg.Action, bck.Provider)
 			return
 		}
 		if err = bck.Allow(cmn.AccessBckRENAME); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 			return
 		}
 		bckFrom, bucketTo := bck, msg.Name
-		if bucket == bucketTo {
+		if bucket == bucketTo && bck.IsAIS() {
 			p.invalmsghdlrf(w, r, "cannot rename bucket %q as %q", bucket, bucket)
 			return
 		}
 		if err := cmn.ValidateBckName(bucketTo); err != nil {
-			p.invalmsghdlr(w, r, err.Error())
+			p.invalmsghdlrErr(w, r, err)
 			return
 		}
 		bckTo := cluster.NewBck(bucketTo, cmn.ProviderAIS, cmn.NsGlobal)
 		if _, present := p.owner.bmd.get().Get(bckTo); present {
 			err := cmn.NewErrorBucketAlreadyExists(bckTo.Bck, p.si.String())
-			p.invalmsghdlr(w, r, err.Error())
+			p.invalmsghdlrErr(w, r, err)
 			return
 		}
 		glog.Infof("%s bucket %s => %s", msg.Action, bckFrom, bucketTo)
-		if err := p.renameBucket(bckFrom, bckTo, &msg); err != nil {
-			p.invalmsghdlr(w, r, err.Error())
+		rename := p.renameBucket
+		if !bckFrom.IsAIS() {
+			rename = p.renameCloudBucket
+		}
+		if isAsyncReq(r) {
+			p.startJob(w, r, msg.Action, bckFrom, func(job *notifListenerJob) error {
+				return rename(bckFrom, bckTo, &msg, job)
+			})
+			return
+		}
+		if err := rename(bckFrom, bckTo, &msg, nil); err != nil {
+			p.invalmsghdlrErr(w, r, err)
+			return
+		}
+	case cmn.ActSwapLB:
+		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessBckRENAME); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		if !bck.IsAIS() {
+			p.invalmsghdlrf(w, r, fmtErr, msg.Action, bck.Provider)
+			return
+		}
+		bckA, bucketB := bck, msg.Name
+		if bucket == bucketB {
+			p.invalmsghdlrf(w, r, "cannot swap bucket %q with itself", bucket)
+			return
+		}
+		if err := cmn.ValidateBckName(bucketB); err != nil {
+			p.invalmsghdlrErr(w, r, err)
+			return
+		}
+		bckB := cluster.NewBck(bucketB, cmn.ProviderAIS, cmn.NsGlobal)
+		if err := bckB.Init(p.owner.bmd, p.si); err != nil {
+			p.invalmsghdlrstatusf(w, r, http.StatusNotFound, "cannot %q: ais bucket %q does not exist", msg.Action, bucketB)
+			return
+		}
+		if err := p.checkPermissions(r, &bckB.Bck, cmn.AccessBckRENAME); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		for _, b := range []*cluster.Bck{bckA, bckB} {
+			if err = b.Allow(cmn.AccessBckRENAME); err != nil {
+				p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
+				return
+			}
+		}
+		glog.Infof("%s buckets %s <=> %s", msg.Action, bckA, bckB)
+		if err := p.swapBuckets(bckA, bckB, &msg); err != nil {
+			p.invalmsghdlrErr(w, r, err)
 			return
 		}
 	case cmn.ActCopyBucket:
 		// TODO: what permission is the best for COPY?
 		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessBckCreate); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 			return
 		}
 		bckFrom, bucketTo := bck, msg.Name
@@ -947,7 +1047,7 @@ func (p *proxyrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if err := cmn.ValidateBckName(bucketTo); err != nil {
-			p.invalmsghdlr(w, r, err.Error())
+			p.invalmsghdlrErr(w, r, err)
 			return
 		}
 		glog.Infof("%s bucket %s => %s", msg.Action, bckFrom, bucketTo)
@@ -958,20 +1058,26 @@ func (p *proxyrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 		if _, present := bmd.Get(bckTo); present {
 			if err = bckTo.Init(p.owner.bmd, p.si); err == nil {
 				if err = bckTo.Allow(cmn.AccessSYNC); err != nil {
-					p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+					p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 					return
 				}
 			}
 		}
 
-		if err := p.copyBucket(bckFrom, bckTo, &msg); err != nil {
-			p.invalmsghdlr(w, r, err.Error())
+		if isAsyncReq(r) {
+			p.startJob(w, r, msg.Action, bckFrom, func(job *notifListenerJob) error {
+				return p.copyBucket(bckFrom, bckTo, &msg, job)
+			})
+			return
+		}
+		if err := p.copyBucket(bckFrom, bckTo, &msg, nil); err != nil {
+			p.invalmsghdlrErr(w, r, err)
 			return
 		}
 	case cmn.ActRegisterCB:
 		// TODO: choose the best permission
 		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessBckCreate); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 			return
 		}
 		cloudConf := cmn.GCO.Get().Cloud
@@ -982,13 +1088,13 @@ func (p *proxyrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 			if _, ok := err.(*cmn.ErrorBucketAlreadyExists); ok {
 				errCode = http.StatusConflict
 			}
-			p.invalmsghdlr(w, r, err.Error(), errCode)
+			p.invalmsghdlrErr(w, r, err, errCode)
 			return
 		}
 	case cmn.ActPrefetch:
 		// TODO: GET vs SYNC?
 		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessGET); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 			return
 		}
 		if bck.IsAIS() {
@@ -996,69 +1102,387 @@ func (p *proxyrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if err = bck.Allow(cmn.AccessSYNC); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 			return
 		}
-		if err = p.doListRange(http.MethodPost, bucket, &msg, r.URL.Query()); err != nil {
-			p.invalmsghdlr(w, r, err.Error())
+		if _, err = p.doListRange(http.MethodPost, bucket, &msg, r.URL.Query()); err != nil {
+			p.invalmsghdlrErr(w, r, err)
 		}
 	case cmn.ActListObjects:
 		begin := mono.NanoTime()
 		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessObjLIST); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 			return
 		}
 		if err = bck.Allow(cmn.AccessObjLIST); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
+			return
+		}
+		if cmn.IsParseBool(r.URL.Query().Get(cmn.URLParamStream)) {
+			p.listObjectsStream(w, r, bck, msg)
 			return
 		}
 		p.listObjectsAndCollectStats(w, r, bck, msg, begin, false /* fast listing */)
 	case cmn.ActInvalListCache:
 		if err = bck.Allow(cmn.AccessObjLIST); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 			return
 		}
 		p.invalidateListAISBucketCache(w, r, bck, msg)
 	case cmn.ActSummaryBucket:
 		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessObjLIST); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 			return
 		}
 		if err = bck.Allow(cmn.AccessBckHEAD); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 			return
 		}
 		p.bucketSummary(w, r, bck, msg)
 	case cmn.ActMakeNCopies:
 		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessMAKENCOPIES); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		if isAsyncReq(r) {
+			p.startJob(w, r, msg.Action, bck, func(job *notifListenerJob) error {
+				if err := p.initBckPending(bck, cmn.AccessMAKENCOPIES); err != nil {
+					return err
+				}
+				return p.makeNCopies(&msg, bck, job)
+			})
 			return
 		}
 		if err = bck.Allow(cmn.AccessMAKENCOPIES); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 			return
 		}
-		if err = p.makeNCopies(&msg, bck); err != nil {
-			p.invalmsghdlr(w, r, err.Error())
+		if err = p.makeNCopies(&msg, bck, nil); err != nil {
+			p.invalmsghdlrErr(w, r, err)
 		}
 	case cmn.ActECEncode:
 		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessEC); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		dryRun := cmn.IsParseBool(r.URL.Query().Get(cmn.URLParamDryRun))
+		if isAsyncReq(r) && !dryRun {
+			p.startJob(w, r, msg.Action, bck, func(job *notifListenerJob) error {
+				if err := p.initBckPending(bck, cmn.AccessEC); err != nil {
+					return err
+				}
+				return p.ecEncode(bck, &msg, false /*dry-run*/, job)
+			})
+			return
+		}
+		if err = bck.Allow(cmn.AccessEC); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
+			return
+		}
+		if err := p.ecEncode(bck, &msg, dryRun, nil); err != nil {
+			p.invalmsghdlrErr(w, r, err)
+			return
+		}
+	case cmn.ActECRestore:
+		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessEC); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		if err = bck.Allow(cmn.AccessEC); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
+			return
+		}
+		if !bck.Props.EC.Enabled {
+			p.invalmsghdlrf(w, r, "%s: EC is not enabled for bucket %s", p.si, bck)
+			return
+		}
+		xactID, err := p.doListRange(http.MethodPost, bucket, &msg, r.URL.Query())
+		if err != nil {
+			p.invalmsghdlrErr(w, r, err)
+			return
+		}
+		w.Write([]byte(xactID))
+	case cmn.ActWarmup:
+		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessGET); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		if err = bck.Allow(cmn.AccessGET); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
+			return
+		}
+		warmupMsg := &cmn.WarmupMsg{}
+		if err := cmn.MorphMarshal(msg.Value, warmupMsg); err != nil {
+			p.invalmsghdlrf(w, r, "invalid %s action message: %v", msg.Action, err)
+			return
+		}
+		if len(warmupMsg.ObjNames) == 0 && warmupMsg.Template == "" {
+			p.invalmsghdlrf(w, r, "%s: expecting either a list or a template of the objects to warm up", msg.Action)
+			return
+		}
+		xactID, err := p.doListRange(http.MethodPost, bucket, &msg, r.URL.Query())
+		if err != nil {
+			p.invalmsghdlrErr(w, r, err)
+			return
+		}
+		w.Write([]byte(xactID))
+	case cmn.ActConfirmDelete:
+		var (
+			opMsg = &cmn.ActionMsg{}
+			perms = cmn.AccessAttrs(cmn.AccessObjDELETE)
+		)
+		if err := cmn.MorphMarshal(msg.Value, opMsg); err != nil {
+			p.invalmsghdlrf(w, r, "invalid %s action message: %v", msg.Action, err)
+			return
+		}
+		if opMsg.Action == cmn.ActDestroyLB {
+			perms = cmn.AccessBckDELETE
+		}
+		if err := p.checkPermissions(r, &bck.Bck, perms); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		token, err := p.newConfirmToken(bck, opMsg)
+		if err != nil {
+			p.invalmsghdlrErr(w, r, err)
+			return
+		}
+		w.Write([]byte(token))
+	case cmn.ActECUndelete:
+		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessEC); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 			return
 		}
 		if err = bck.Allow(cmn.AccessEC); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 			return
 		}
-		if err := p.ecEncode(bck, &msg); err != nil {
-			p.invalmsghdlr(w, r, err.Error())
+		if !bck.Props.EC.Enabled {
+			p.invalmsghdlrf(w, r, "%s: EC is not enabled for bucket %s", p.si, bck)
 			return
 		}
+		// first, all targets move back the tombstoned metafiles and slices;
+		// second, the main targets restore the objects
+		if _, err := p.doListRange(http.MethodPost, bucket, &msg, r.URL.Query()); err != nil {
+			p.invalmsghdlrErr(w, r, err)
+			return
+		}
+		msg.Action = cmn.ActECRestore
+		xactID, err := p.doListRange(http.MethodPost, bucket, &msg, r.URL.Query())
+		if err != nil {
+			p.invalmsghdlrErr(w, r, err)
+			return
+		}
+		w.Write([]byte(xactID))
+	case cmn.ActQueryJournal:
+		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessBckHEAD); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		if err = bck.Allow(cmn.AccessBckHEAD); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
+			return
+		}
+		p.queryJournal(w, r, bck, &msg)
+	case cmn.ActSimPlacement:
+		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessBckHEAD); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		if err = bck.Allow(cmn.AccessBckHEAD); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
+			return
+		}
+		p.simPlacement(w, r, bck, &msg)
+	case cmn.ActObjLocations:
+		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessObjHEAD); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		if err = bck.Allow(cmn.AccessObjHEAD); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
+			return
+		}
+		p.objLocations(w, r, bck, &msg)
 	default:
 		p.invalmsghdlrf(w, r, fmtUnknownAct, msg)
 	}
 }
 
+// An operation that waits for another one (see cmn.URLParamWaitFor) may target
+// an ais bucket that does not exist yet - e.g., the destination of the
+// copy-bucket to wait for; such a bucket gets initialized (and its access
+// checked) only when the operation starts - see initBckPending
+func (p *proxyrunner) bckPendingOK(r *http.Request, msg *cmn.ActionMsg, err error) bool {
+	if _, ok := err.(*cmn.ErrorBucketDoesNotExist); !ok {
+		return false
+	}
+	if r.URL.Query().Get(cmn.URLParamWaitFor) == "" {
+		return false
+	}
+	return msg.Action == cmn.ActMakeNCopies || msg.Action == cmn.ActECEncode
+}
+
+func (p *proxyrunner) initBckPending(bck *cluster.Bck, access int) error {
+	if bck.Props == nil {
+		if err := bck.Init(p.owner.bmd, p.si); err != nil {
+			return err
+		}
+	}
+	return bck.Allow(access)
+}
+
+// collects the journal records from all targets; the result is sorted by time
+func (p *proxyrunner) queryJournal(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, msg *cmn.ActionMsg) {
+	var (
+		smap    = p.owner.smap.get()
+		aisMsg  = p.newAisMsg(msg, smap, nil)
+		entries = make([]*cmn.JournalEntry, 0, 128)
+	)
+	results := p.bcastTo(bcastArgs{
+		req: cmn.ReqArgs{
+			Method: http.MethodPost,
+			Path:   cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name),
+			Query:  cmn.AddBckToQuery(nil, bck.Bck),
+			Body:   cmn.MustMarshal(aisMsg),
+		},
+		smap:    smap,
+		timeout: cmn.DefaultTimeout,
+	})
+	for res := range results {
+		if res.err != nil {
+			p.invalmsghdlrstatusf(w, r, res.status, "%s: failed to query journal of %s: %v",
+				res.si, bck, res.err)
+			return
+		}
+		var tentries []*cmn.JournalEntry
+		if err := jsoniter.Unmarshal(res.outjson, &tentries); err != nil {
+			p.invalmsghdlrf(w, r, "%s: invalid journal of %s: %v", res.si, bck, err)
+			return
+		}
+		entries = append(entries, tentries...)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Time < entries[j].Time })
+	p.writeJSON(w, r, entries, "query-journal")
+}
+
+// collects the placement simulation results from all targets (see tgtplacement.go)
+func (p *proxyrunner) simPlacement(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, msg *cmn.ActionMsg) {
+	var (
+		simMsg cmn.SimPlacementMsg
+		smap   = p.owner.smap.get()
+		sim    = cmn.NewSimPlacementResult()
+	)
+	if err := cmn.MorphMarshal(msg.Value, &simMsg); err != nil {
+		p.invalmsghdlrErr(w, r, err)
+		return
+	}
+	// validate in advance - the same way the targets do
+	if _, err := simSmap(smap, &simMsg); err != nil {
+		p.invalmsghdlrErr(w, r, err)
+		return
+	}
+	aisMsg := p.newAisMsg(msg, smap, nil)
+	results := p.bcastTo(bcastArgs{
+		req: cmn.ReqArgs{
+			Method: http.MethodPost,
+			Path:   cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name),
+			Query:  cmn.AddBckToQuery(nil, bck.Bck),
+			Body:   cmn.MustMarshal(aisMsg),
+		},
+		smap:    smap,
+		timeout: cmn.LongTimeout,
+	})
+	for res := range results {
+		if res.err != nil {
+			p.invalmsghdlrstatusf(w, r, res.status, "%s: failed to simulate placement of %s: %v",
+				res.si, bck, res.err)
+			return
+		}
+		tres := &cmn.SimPlacementResult{}
+		if err := jsoniter.Unmarshal(res.outjson, tres); err != nil {
+			p.invalmsghdlrf(w, r, "%s: invalid placement simulation result for %s: %v", res.si, bck, err)
+			return
+		}
+		sim.Merge(tres)
+	}
+	p.writeJSON(w, r, sim, "sim-placement")
+}
+
+// collects the locations of the objects from the targets that own them (see
+// tgtplacement.go) and adds the targets' hosts
+func (p *proxyrunner) objLocations(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, msg *cmn.ActionMsg) {
+	var (
+		locMsg cmn.ObjLocationsMsg
+		smap   = p.owner.smap.get()
+	)
+	if err := cmn.MorphMarshal(msg.Value, &locMsg); err != nil {
+		p.invalmsghdlrErr(w, r, err)
+		return
+	}
+	if len(locMsg.ObjNames) == 0 {
+		p.invalmsghdlrf(w, r, "%s: no objects to locate", msg.Action)
+		return
+	}
+	aisMsg := p.newAisMsg(msg, smap, nil)
+	results := p.bcastTo(bcastArgs{
+		req: cmn.ReqArgs{
+			Method: http.MethodPost,
+			Path:   cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name),
+			Query:  cmn.AddBckToQuery(nil, bck.Bck),
+			Body:   cmn.MustMarshal(aisMsg),
+		},
+		smap:    smap,
+		timeout: cmn.DefaultTimeout,
+	})
+	found := make(map[string]*cmn.ObjLocation, len(locMsg.ObjNames))
+	for res := range results {
+		if res.err != nil {
+			p.invalmsghdlrstatusf(w, r, res.status, "%s: failed to locate objects of %s: %v",
+				res.si, bck, res.err)
+			return
+		}
+		var tlocs []*cmn.ObjLocation
+		if err := jsoniter.Unmarshal(res.outjson, &tlocs); err != nil {
+			p.invalmsghdlrf(w, r, "%s: invalid object locations in %s: %v", res.si, bck, err)
+			return
+		}
+		for _, loc := range tlocs {
+			found[loc.Name] = loc
+		}
+	}
+	locs := &cmn.ObjLocations{
+		Objects: make([]*cmn.ObjLocation, 0, len(locMsg.ObjNames)),
+		Hosts:   make(cmn.SimpleKVs, smap.CountTargets()),
+	}
+	addHost := func(sid string) {
+		if tsi := smap.GetTarget(sid); tsi != nil {
+			locs.Hosts[sid] = tsi.PublicNet.NodeIPAddr
+		}
+	}
+	for _, objName := range locMsg.ObjNames {
+		loc, ok := found[objName]
+		if !ok {
+			// the targets use the same Smap - unless it has changed in the meantime
+			tsi, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+			if err != nil {
+				p.invalmsghdlrErr(w, r, err)
+				return
+			}
+			loc = &cmn.ObjLocation{Name: objName, Target: tsi.ID()}
+		}
+		if tsi := smap.GetTarget(loc.Target); tsi != nil {
+			loc.Host, loc.Domain = tsi.PublicNet.NodeIPAddr, tsi.Domain
+		}
+		addHost(loc.Target)
+		for _, sid := range loc.ECTargets {
+			addHost(sid)
+		}
+		locs.Objects = append(locs.Objects, loc)
+	}
+	p.writeJSON(w, r, locs, "obj-locations")
+}
+
 func (p *proxyrunner) listObjectsAndCollectStats(w http.ResponseWriter, r *http.Request, bck *cluster.Bck,
 	amsg cmn.ActionMsg, begin int64, fast bool) {
 	var (
@@ -1069,7 +1493,7 @@ func (p *proxyrunner) listObjectsAndCollectStats(w http.ResponseWriter, r *http.
 		query   = r.URL.Query()
 	)
 	if err := cmn.MorphMarshal(amsg.Value, &smsg); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	// override fastListing if it set
@@ -1096,7 +1520,7 @@ func (p *proxyrunner) listObjectsAndCollectStats(w http.ResponseWriter, r *http.
 		//  xaction and return new `UUID`.
 	}
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 
@@ -1139,7 +1563,7 @@ func (p *proxyrunner) listObjectsAndCollectStats(w http.ResponseWriter, r *http.
 func (p *proxyrunner) invalidateListAISBucketCache(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, amsg cmn.ActionMsg) {
 	smsg := cmn.SelectMsg{}
 	if err := cmn.MorphMarshal(amsg.Value, &smsg); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 
@@ -1156,7 +1580,7 @@ func (p *proxyrunner) bucketSummary(w http.ResponseWriter, r *http.Request, bck
 	)
 	listMsgJSON := cmn.MustMarshal(amsg.Value)
 	if err := jsoniter.Unmarshal(listMsgJSON, &smsg); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 
@@ -1166,7 +1590,7 @@ func (p *proxyrunner) bucketSummary(w http.ResponseWriter, r *http.Request, bck
 	}
 
 	if summaries, uuid, err = p.gatherBucketSummary(bck, smsg); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 
@@ -1250,11 +1674,11 @@ func (p *proxyrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 	bucket := apitems[0]
 	bck, err := newBckFromQuery(bucket, r.URL.Query())
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		p.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 	if err = bck.Init(p.owner.bmd, p.si); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if cmn.ReadJSON(w, r, &msg) != nil {
@@ -1263,7 +1687,7 @@ func (p *proxyrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 	switch msg.Action {
 	case cmn.ActRenameObject:
 		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessObjRENAME); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 			return
 		}
 		if !bck.IsAIS() {
@@ -1271,7 +1695,7 @@ func (p *proxyrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if err = bck.Allow(cmn.AccessObjRENAME); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 			return
 		}
 		if bck.Props.EC.Enabled {
@@ -1282,15 +1706,56 @@ func (p *proxyrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 		return
 	case cmn.ActPromote:
 		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessPROMOTE); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 			return
 		}
 		if err = bck.Allow(cmn.AccessPROMOTE); err != nil {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 			return
 		}
 		p.promoteFQN(w, r, bck, &msg)
 		return
+	case cmn.ActCopyRemote:
+		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessPUT); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		if err = bck.Allow(cmn.AccessPUT); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
+			return
+		}
+		p.objCopyRemote(w, r, bck, &msg)
+		return
+	case cmn.ActUndelete:
+		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessPUT); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		if !bck.IsAIS() {
+			p.invalmsghdlrf(w, r, "%q is not supported for Cloud buckets: %s", msg.Action, bck)
+			return
+		}
+		if err = bck.Allow(cmn.AccessPUT); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
+			return
+		}
+		p.objRedirectOwner(w, r, bck, msg.Action)
+		return
+	case cmn.ActECReprotect:
+		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessPUT); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		if !bck.Props.EC.Enabled {
+			p.invalmsghdlrf(w, r, "%q requires erasure-coded bucket: %s", msg.Action, bck)
+			return
+		}
+		if err = bck.Allow(cmn.AccessPUT); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
+			return
+		}
+		p.objRedirectOwner(w, r, bck, msg.Action)
+		return
 	default:
 		p.invalmsghdlrf(w, r, fmtUnknownAct, msg)
 	}
@@ -1306,12 +1771,12 @@ func (p *proxyrunner) httpbckhead(w http.ResponseWriter, r *http.Request) {
 	bucket := apiItems[0]
 	bck, err := newBckFromQuery(bucket, r.URL.Query())
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		p.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 	if err = bck.Init(p.owner.bmd, p.si); err != nil {
 		if _, ok := err.(*cmn.ErrorBucketDoesNotExist); ok {
-			p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+			p.invalmsghdlrErr(w, r, err, http.StatusNotFound)
 			return
 		}
 		args := remBckAddArgs{p: p, w: w, r: r, queryBck: bck, err: err}
@@ -1320,16 +1785,19 @@ func (p *proxyrunner) httpbckhead(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	if err := p.checkPermissions(r, &bck.Bck, cmn.AccessBckHEAD); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 		return
 	}
 	if bck.IsAIS() {
 		p.bucketPropsToHdr(bck, w.Header())
+		if cmn.IsParseBool(r.URL.Query().Get(cmn.URLParamProvenance)) {
+			p.bucketProvenanceToHdr(bck, w.Header())
+		}
 		return
 	}
 	si, err := p.owner.smap.get().GetRandTarget()
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if glog.FastV(4, glog.SmoduleAIS) {
@@ -1346,14 +1814,18 @@ func (p *proxyrunner) httpbckpatch(w http.ResponseWriter, r *http.Request) {
 		bucket        string
 		bck           *cluster.Bck
 		msg           *cmn.ActionMsg
-		apitems, err  = p.checkRESTItems(w, r, 1, true, cmn.Version, cmn.Buckets)
+		apitems, err  = p.checkRESTItems(w, r, 0, true, cmn.Version, cmn.Buckets)
 	)
 	if err != nil {
 		return
 	}
+	if len(apitems) == 0 {
+		p.httpbckpatchBatch(w, r)
+		return
+	}
 	bucket = apitems[0]
 	if bck, err = newBckFromQuery(bucket, r.URL.Query()); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		p.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 	if p.forwardCP(w, r, msg, "httpbckpatch", nil) {
@@ -1370,22 +1842,110 @@ func (p *proxyrunner) httpbckpatch(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	if err := p.checkPermissions(r, &bck.Bck, cmn.AccessPATCH); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 		return
 	}
 	if err := bck.Allow(cmn.AccessPATCH); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 		return
 	}
 	if err = p.checkAction(msg, cmn.ActSetBprops, cmn.ActResetBprops); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
-	if err = p.setBucketProps(msg, bck, propsToUpdate); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+	dryRun := cmn.IsParseBool(r.URL.Query().Get(cmn.URLParamDryRun))
+	if err = p.setBucketProps(msg, bck, propsToUpdate, dryRun); err != nil {
+		p.invalmsghdlrErr(w, r, err)
 	}
 }
 
+// PATCH /v1/buckets (the same props => multiple buckets - see cmn.BpropsBatchMsg)
+func (p *proxyrunner) httpbckpatchBatch(w http.ResponseWriter, r *http.Request) {
+	var (
+		batchMsg cmn.BpropsBatchMsg
+		msg      = &cmn.ActionMsg{Value: &batchMsg}
+		re       *regexp.Regexp
+		bcks     []*cluster.Bck
+		err      error
+	)
+	if p.forwardCP(w, r, nil, "httpbckpatch", nil) {
+		return
+	}
+	if err = cmn.ReadJSON(w, r, msg); err != nil {
+		return
+	}
+	if err = p.checkAction(msg, cmn.ActSetBpropsBatch); err != nil {
+		p.invalmsghdlrErr(w, r, err)
+		return
+	}
+	if batchMsg.Regex != "" {
+		if re, err = regexp.Compile(batchMsg.Regex); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
+			return
+		}
+	}
+	if bcks, err = p.selectBuckets(&batchMsg, re); err != nil {
+		p.invalmsghdlrErr(w, r, err)
+		return
+	}
+	if len(bcks) == 0 {
+		p.invalmsghdlrf(w, r, "%s: no buckets to %q", p.si, msg.Action)
+		return
+	}
+	for _, bck := range bcks {
+		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessPATCH); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		if err := bck.Allow(cmn.AccessPATCH); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
+			return
+		}
+	}
+	dryRun := cmn.IsParseBool(r.URL.Query().Get(cmn.URLParamDryRun))
+	if err = p.setBucketPropsBatch(msg, bcks, batchMsg.Props, dryRun); err != nil {
+		p.invalmsghdlrErr(w, r, err)
+	}
+}
+
+// the listed buckets (that must exist) plus the ones that match the regex, if any
+func (p *proxyrunner) selectBuckets(batchMsg *cmn.BpropsBatchMsg, re *regexp.Regexp) ([]*cluster.Bck, error) {
+	var (
+		bcks     = make([]*cluster.Bck, 0, len(batchMsg.Buckets))
+		unames   = make(cmn.StringSet, len(batchMsg.Buckets))
+		bmd      = p.owner.bmd.get()
+		provider *string
+	)
+	for _, b := range batchMsg.Buckets {
+		bck := cluster.NewBckEmbed(b)
+		if err := bck.Init(p.owner.bmd, p.si); err != nil {
+			return nil, err
+		}
+		if unames.Contains(bck.MakeUname("")) {
+			continue
+		}
+		unames.Add(bck.MakeUname(""))
+		bcks = append(bcks, bck)
+	}
+	if re == nil {
+		return bcks, nil
+	}
+	if batchMsg.Provider != "" {
+		if !cmn.IsValidProvider(batchMsg.Provider) {
+			return nil, fmt.Errorf("invalid provider %q", batchMsg.Provider)
+		}
+		provider = &batchMsg.Provider
+	}
+	bmd.Range(provider, nil, func(bck *cluster.Bck) bool {
+		if re.MatchString(bck.Name) && !unames.Contains(bck.MakeUname("")) {
+			unames.Add(bck.MakeUname(""))
+			bcks = append(bcks, bck)
+		}
+		return false
+	})
+	return bcks, nil
+}
+
 // HEAD /v1/objects/bucket-name/object-name
 func (p *proxyrunner) httpobjhead(w http.ResponseWriter, r *http.Request) {
 	var (
@@ -1400,7 +1960,7 @@ func (p *proxyrunner) httpobjhead(w http.ResponseWriter, r *http.Request) {
 	bucket, objName := apitems[0], apitems[1]
 	bck, err := newBckFromQuery(bucket, r.URL.Query())
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		p.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 	if err = bck.Init(p.owner.bmd, p.si); err != nil {
@@ -1410,17 +1970,17 @@ func (p *proxyrunner) httpobjhead(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	if err := p.checkPermissions(r, &bck.Bck, cmn.AccessObjHEAD); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 		return
 	}
 	if err := bck.Allow(cmn.AccessObjHEAD); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 		return
 	}
 	smap := p.owner.smap.get()
 	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusInternalServerError)
+		p.invalmsghdlrErr(w, r, err, http.StatusInternalServerError)
 		return
 	}
 	if glog.FastV(4, glog.SmoduleAIS) {
@@ -1529,7 +2089,7 @@ func (p *proxyrunner) reverseReqRemote(w http.ResponseWriter, r *http.Request, m
 
 	if !configured {
 		err = errors.New("ais remote cloud is not configured")
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return err
 	}
 
@@ -1537,14 +2097,14 @@ func (p *proxyrunner) reverseReqRemote(w http.ResponseWriter, r *http.Request, m
 	urls, exists := aisConf[remoteUUID]
 	if !exists {
 		err = fmt.Errorf("remote UUID/alias (%s) not found", remoteUUID)
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return err
 	}
 
 	cmn.Assert(len(urls) > 0)
 	u, err := url.Parse(urls[0])
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return err
 	}
 	if msg != nil {
@@ -1561,6 +2121,10 @@ func (p *proxyrunner) reverseReqRemote(w http.ResponseWriter, r *http.Request, m
 }
 
 func (p *proxyrunner) listBuckets(w http.ResponseWriter, r *http.Request, query cmn.QueryBcks) {
+	if cmn.IsParseBool(r.URL.Query().Get(cmn.URLParamBckEvents)) {
+		p.listBucketsEvents(w, r)
+		return
+	}
 	bmd := p.owner.bmd.get()
 	if query.IsAIS() {
 		bcks := p.selectBMDBuckets(bmd, query)
@@ -1569,7 +2133,7 @@ func (p *proxyrunner) listBuckets(w http.ResponseWriter, r *http.Request, query
 	}
 	si, err := p.owner.smap.get().GetRandTarget()
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	p.reverseNodeRequest(w, r, si)
@@ -1585,8 +2149,8 @@ func (p *proxyrunner) redirectURL(r *http.Request, si *cluster.Snode, ts time.Ti
 	} else {
 		var local bool
 		remote := r.RemoteAddr
-		if colon := strings.Index(remote, ":"); colon != -1 {
-			remote = remote[:colon]
+		if host, _, err := net.SplitHostPort(remote); err == nil {
+			remote = host // (IPv6 addresses come bracketed)
 		}
 		if ip := net.ParseIP(remote); ip != nil {
 			local = p.si.LocalNet.Contains(ip)
@@ -1844,7 +2408,7 @@ func (p *proxyrunner) objRename(w http.ResponseWriter, r *http.Request, bck *clu
 	smap := p.owner.smap.get()
 	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if glog.FastV(4, glog.SmoduleAIS) {
@@ -1860,6 +2424,64 @@ func (p *proxyrunner) objRename(w http.ResponseWriter, r *http.Request, bck *clu
 	p.statsT.Add(stats.RenameCount, 1)
 }
 
+// redirects to the target that owns the object - the target then performs the
+// action: restores the object from the bucket's trash or rebuilds its EC protection
+func (p *proxyrunner) objRedirectOwner(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, action string) {
+	started := time.Now()
+	apitems, err := p.checkRESTItems(w, r, 2, false, cmn.Version, cmn.Objects)
+	if err != nil {
+		return
+	}
+	objName := apitems[1]
+	smap := p.owner.smap.get()
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		p.invalmsghdlrErr(w, r, err)
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s %s %s/%s => %s", action, r.Method, bck.Name, objName, si)
+	}
+	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraControl)
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+}
+
+// redirects to the target that owns the destination object - the target then
+// pulls the source object from the remote cluster's owning target directly
+func (p *proxyrunner) objCopyRemote(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, msg *cmn.ActionMsg) {
+	started := time.Now()
+	apitems, err := p.checkRESTItems(w, r, 2, false, cmn.Version, cmn.Objects)
+	if err != nil {
+		return
+	}
+	objName := apitems[1]
+	src := cmn.ActValCopyRemote{}
+	if err := cmn.MorphMarshal(msg.Value, &src); err != nil {
+		p.invalmsghdlrErr(w, r, err)
+		return
+	}
+	if !src.Bck.IsRemoteAIS() || src.ObjName == "" {
+		p.invalmsghdlrf(w, r, "%q: source must be an object in attached remote AIS cluster, got %s/%s",
+			msg.Action, src.Bck, src.ObjName)
+		return
+	}
+	if bck.IsRemoteAIS() {
+		p.invalmsghdlrf(w, r, "%q: destination %s cannot be remote", msg.Action, bck)
+		return
+	}
+	smap := p.owner.smap.get()
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		p.invalmsghdlrErr(w, r, err)
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s %s/%s => %s/%s => %s", msg.Action, src.Bck, src.ObjName, bck, objName, si)
+	}
+	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraControl)
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+}
+
 func (p *proxyrunner) promoteFQN(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, msg *cmn.ActionMsg) {
 	apiItems, err := p.checkRESTItems(w, r, 1, false, cmn.Version, cmn.Objects)
 	if err != nil {
@@ -1868,7 +2490,7 @@ func (p *proxyrunner) promoteFQN(w http.ResponseWriter, r *http.Request, bck *cl
 
 	params := cmn.ActValPromote{}
 	if err := cmn.MorphMarshal(msg.Value, &params); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 
@@ -1883,7 +2505,7 @@ func (p *proxyrunner) promoteFQN(w http.ResponseWriter, r *http.Request, bck *cl
 		tsi := smap.GetTarget(params.Target)
 		if tsi == nil {
 			err = &errNodeNotFound{cmn.ActPromote + " failure", params.Target, p.si, smap}
-			p.invalmsghdlr(w, r, err.Error())
+			p.invalmsghdlrErr(w, r, err)
 			return
 		}
 		// NOTE:
@@ -1908,7 +2530,8 @@ func (p *proxyrunner) promoteFQN(w http.ResponseWriter, r *http.Request, bck *cl
 	}
 }
 
-func (p *proxyrunner) doListRange(method, bucket string, msg *cmn.ActionMsg, query url.Values) error {
+// returns the UUID of the xaction started on all targets
+func (p *proxyrunner) doListRange(method, bucket string, msg *cmn.ActionMsg, query url.Values) (string, error) {
 	var (
 		timeout time.Duration
 		results chan callResult
@@ -1917,7 +2540,8 @@ func (p *proxyrunner) doListRange(method, bucket string, msg *cmn.ActionMsg, que
 	var (
 		smap   = p.owner.smap.get()
 		bmd    = p.owner.bmd.get()
-		aisMsg = p.newAisMsg(msg, smap, bmd, cmn.GenUUID())
+		uuid   = cmn.GenUUID()
+		aisMsg = p.newAisMsg(msg, smap, bmd, uuid)
 		body   = cmn.MustMarshal(aisMsg)
 		path   = cmn.URLPath(cmn.Version, cmn.Buckets, bucket)
 	)
@@ -1933,11 +2557,11 @@ func (p *proxyrunner) doListRange(method, bucket string, msg *cmn.ActionMsg, que
 	})
 	for res := range results {
 		if res.err != nil {
-			return fmt.Errorf("%s failed to %s List/Range: %v (%d: %s)",
+			return "", fmt.Errorf("%s failed to %s List/Range: %v (%d: %s)",
 				res.si, msg.Action, res.err, res.status, res.details)
 		}
 	}
-	return nil
+	return uuid, nil
 }
 
 func (p *proxyrunner) reverseHandler(w http.ResponseWriter, r *http.Request) {
@@ -1968,7 +2592,7 @@ func (p *proxyrunner) reverseHandler(w http.ResponseWriter, r *http.Request) {
 	nodeURL := r.Header.Get(cmn.HeaderNodeURL)
 	if nodeURL == "" {
 		err = &errNodeNotFound{"cannot rproxy", nodeID, p.si, smap}
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 
@@ -2035,8 +2659,16 @@ func (p *proxyrunner) httpdaeget(w http.ResponseWriter, r *http.Request) {
 			p.handlePendingRenamedLB(renamedBucket)
 		}
 		fallthrough // fallthrough
-	case cmn.GetWhatConfig, cmn.GetWhatSmapVote, cmn.GetWhatSnode:
+	case cmn.GetWhatConfig, cmn.GetWhatSmapVote, cmn.GetWhatSnode, cmn.GetWhatPeerStats:
 		p.httprunner.httpdaeget(w, r)
+	case cmn.GetWhatNotifStatus:
+		uuid := q.Get(cmn.URLParamUUID)
+		st := p.notifs.status(uuid)
+		if st == nil {
+			p.invalmsghdlrstatusf(w, r, http.StatusNotFound, "%s: %q not found", p.si, uuid)
+			return
+		}
+		p.writeJSON(w, r, st, what)
 	case cmn.GetWhatStats:
 		pst := getproxystatsrunner()
 		ws := pst.GetWhatStats()
@@ -2105,7 +2737,7 @@ func (p *proxyrunner) httpdaeput(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 			if err := p.owner.smap.synchronize(newsmap, true /* lesserIsErr */); err != nil {
-				p.invalmsghdlr(w, r, err.Error())
+				p.invalmsghdlrErr(w, r, err)
 				return
 			}
 			glog.Infof("%s: %s %s done", p.si, cmn.SyncSmap, newsmap)
@@ -2113,7 +2745,7 @@ func (p *proxyrunner) httpdaeput(w http.ResponseWriter, r *http.Request) {
 		case cmn.ActSetConfig: // setconfig #1 - via query parameters and "?n1=v1&n2=v2..."
 			kvs := cmn.NewSimpleKVsFromQuery(r.URL.Query())
 			if err := jsp.SetConfigMany(kvs); err != nil {
-				p.invalmsghdlr(w, r, err.Error())
+				p.invalmsghdlrErr(w, r, err)
 				return
 			}
 			return
@@ -2156,7 +2788,7 @@ func (p *proxyrunner) httpdaeput(w http.ResponseWriter, r *http.Request) {
 		}
 		kvs := cmn.NewSimpleKVs(cmn.SimpleKVsEntry{Key: msg.Name, Value: value})
 		if err := jsp.SetConfigMany(kvs); err != nil {
-			p.invalmsghdlr(w, r, err.Error())
+			p.invalmsghdlrErr(w, r, err)
 			return
 		}
 	case cmn.ActShutdown:
@@ -2237,7 +2869,7 @@ func (p *proxyrunner) forcefulJoin(w http.ResponseWriter, r *http.Request, proxy
 	psi := smap.GetProxy(proxyID)
 	if psi == nil && newPrimaryURL == "" {
 		err := &errNodeNotFound{"failed to find new primary", proxyID, p.si, smap}
-		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		p.invalmsghdlrErr(w, r, err, http.StatusNotFound)
 		return
 	}
 	if newPrimaryURL == "" {
@@ -2245,12 +2877,12 @@ func (p *proxyrunner) forcefulJoin(w http.ResponseWriter, r *http.Request, proxy
 	}
 	if newPrimaryURL == "" {
 		err := &errNodeNotFound{"failed to get new primary's direct URL", proxyID, p.si, smap}
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	newSmap, err := p.smapFromURL(newPrimaryURL)
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if proxyID != newSmap.ProxySI.ID() {
@@ -2310,7 +2942,7 @@ func (p *proxyrunner) httpdaesetprimaryproxy(w http.ResponseWriter, r *http.Requ
 	psi := smap.GetProxy(proxyID)
 	if psi == nil {
 		err := &errNodeNotFound{"cannot set new primary", proxyID, p.si, smap}
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 
@@ -2359,6 +2991,7 @@ func (p *proxyrunner) becomeNewPrimary(proxyIDToRemove string) {
 				revsPair{bmd, msg},
 				revsPair{rmd, msg},
 			)
+			go p.recoverTxns()
 		},
 	)
 	cmn.AssertNoErr(err)
@@ -2451,6 +3084,8 @@ func (p *proxyrunner) tokenHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
 	case http.MethodDelete:
 		p.httpTokenDelete(w, r)
+	case http.MethodPost:
+		p.httpTokenPost(w, r)
 	default:
 		cmn.InvalidHandlerWithMsg(w, r, "invalid method for /token path")
 	}
@@ -2460,7 +3095,7 @@ func (p *proxyrunner) tokenHandler(w http.ResponseWriter, r *http.Request) {
 func (p *proxyrunner) dsortHandler(w http.ResponseWriter, r *http.Request) {
 	// TODO: separate permissions for dsort? xactions?
 	if err := p.checkPermissions(r, nil, cmn.AccessADMIN); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 		return
 	}
 	dsort.ProxySortHandler(w, r)
@@ -2536,6 +3171,22 @@ func (p *proxyrunner) httpcluget(w http.ResponseWriter, r *http.Request) {
 		p.queryClusterSysinfo(w, r, what)
 	case cmn.GetWhatXactStats, cmn.QueryXactStats:
 		p.queryXaction(w, r, what)
+	case cmn.GetWhatXactProgress:
+		// bucket xactions are started (and listened to) by the primary
+		if p.forwardCP(w, r, nil, what, nil) {
+			return
+		}
+		uuid := r.URL.Query().Get(cmn.URLParamUUID)
+		prog, err, status := p.notifs.xactProgress(uuid)
+		if err != nil {
+			p.invalmsghdlrErr(w, r, err, status)
+			return
+		}
+		p.writeJSON(w, r, prog, what)
+	case cmn.GetWhatXactHistory:
+		p.queryXactHistory(w, r, what)
+	case cmn.GetWhatXactSummary:
+		p.queryXactSummary(w, r, what)
 	case cmn.GetWhatMountpaths:
 		p.queryClusterMountpaths(w, r, what)
 	case cmn.GetWhatRemoteAIS:
@@ -2606,6 +3257,77 @@ func (p *proxyrunner) queryXaction(w http.ResponseWriter, r *http.Request, what
 	}
 }
 
+// queries the targets for the stats of the matching xactions and merges the
+// stats of each xaction into its cluster-wide summary (see cmn.MergeXactStats);
+// the targets that do not run (and have not run) any such xactions are skipped
+func (p *proxyrunner) queryXactSummary(w http.ResponseWriter, r *http.Request, what string) {
+	xactMsg := cmn.XactReqMsg{}
+	if cmn.ReadJSON(w, r, &xactMsg, true /*optional*/) != nil {
+		return
+	}
+	results := p.bcastGet(bcastArgs{
+		req: cmn.ReqArgs{
+			Path:  cmn.URLPath(cmn.Version, cmn.Xactions),
+			Query: url.Values{cmn.URLParamWhat: []string{cmn.QueryXactStats}},
+			Body:  cmn.MustMarshal(xactMsg),
+		},
+		timeout: cmn.GCO.Get().Timeout.MaxKeepalive,
+	})
+	nodeStats := make(map[string][]*cmn.BaseXactStats, len(results))
+	for res := range results {
+		if res.err != nil {
+			if res.status == http.StatusNotFound {
+				continue
+			}
+			p.invalmsghdlr(w, r, res.details)
+			return
+		}
+		var tstats []*cmn.BaseXactStats
+		if err := jsoniter.Unmarshal(res.outjson, &tstats); err != nil {
+			p.invalmsghdlrErr(w, r, err)
+			return
+		}
+		nodeStats[res.si.ID()] = tstats
+	}
+	if len(nodeStats) == 0 {
+		err := cmn.NewXactionNotFoundError(fmt.Sprintf("kind=%q, ID=%q, bucket=%s", xactMsg.Kind, xactMsg.ID, xactMsg.Bck))
+		p.invalmsghdlrsilent(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	p.writeJSON(w, r, cmn.MergeXactStats(nodeStats), what)
+}
+
+// merges the targets' xaction histories, in the order the xactions finished
+func (p *proxyrunner) queryXactHistory(w http.ResponseWriter, r *http.Request, what string) {
+	xactMsg := cmn.XactReqMsg{}
+	if cmn.ReadJSON(w, r, &xactMsg, true /*optional*/) != nil {
+		return
+	}
+	results := p.bcastGet(bcastArgs{
+		req: cmn.ReqArgs{
+			Path:  cmn.URLPath(cmn.Version, cmn.Daemon),
+			Query: r.URL.Query(),
+			Body:  cmn.MustMarshal(xactMsg),
+		},
+		timeout: cmn.GCO.Get().Client.Timeout,
+	})
+	recs := make([]*cmn.XactRecord, 0, 16)
+	for res := range results {
+		if res.err != nil {
+			p.invalmsghdlr(w, r, res.details)
+			return
+		}
+		var trecs []*cmn.XactRecord
+		if err := jsoniter.Unmarshal(res.outjson, &trecs); err != nil {
+			p.invalmsghdlrErr(w, r, err)
+			return
+		}
+		recs = append(recs, trecs...)
+	}
+	sort.Slice(recs, func(i, j int) bool { return recs[i].EndTimeX.Before(recs[j].EndTimeX) })
+	p.writeJSON(w, r, recs, what)
+}
+
 func (p *proxyrunner) queryClusterSysinfo(w http.ResponseWriter, r *http.Request, what string) {
 	fetchResults := func(broadcastType int) (cmn.JSONRawMsgs, string) {
 		results := p.bcastTo(bcastArgs{
@@ -2677,7 +3399,7 @@ func (p *proxyrunner) _queryTargets(w http.ResponseWriter, r *http.Request) cmn.
 	if r.Body != nil {
 		body, err = cmn.ReadBytes(r)
 		if err != nil {
-			p.invalmsghdlr(w, r, err.Error())
+			p.invalmsghdlrErr(w, r, err)
 			return nil
 		}
 	}
@@ -2752,17 +3474,27 @@ func (p *proxyrunner) httpclupost(w http.ResponseWriter, r *http.Request) {
 
 	nsi := regReq.SI
 	if err := nsi.Validate(); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if p.NodeStarted() {
 		bmd := p.owner.bmd.get()
 		if err := bmd.validateUUID(regReq.BMD, p.si, nsi, ""); err != nil {
-			p.invalmsghdlr(w, r, err.Error())
+			p.invalmsghdlrErr(w, r, err)
 			return
 		}
 	}
 	isProxy := nsi.IsProxy()
+	if !isProxy {
+		token := regReq.JoinToken
+		if userRegister {
+			token = r.URL.Query().Get(cmn.URLParamJoinToken)
+		}
+		if err := p.validateJoinToken(nsi, token); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+	}
 	if isProxy {
 		s := r.URL.Query().Get(cmn.URLParamNonElectable)
 		if nonElectable, err = cmn.ParseBool(s); err != nil {
@@ -2785,13 +3517,16 @@ func (p *proxyrunner) httpclupost(w http.ResponseWriter, r *http.Request) {
 	}
 
 	p.statsT.Add(stats.PostCount, 1)
+	if keepalive {
+		setNodeTime(w)
+	}
 
 	p.owner.smap.Lock()
 	smap, err, code, update := p.handleJoinKalive(nsi, regReq.Smap, tag, keepalive, userRegister, nonElectable)
 	p.owner.smap.Unlock()
 
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error(), code)
+		p.invalmsghdlrErr(w, r, err, code)
 		return
 	}
 	if !update {
@@ -2802,7 +3537,7 @@ func (p *proxyrunner) httpclupost(w http.ResponseWriter, r *http.Request) {
 	if !isProxy && selfRegister {
 		glog.Infof("%s: %s %s (%s)...", p.si, tag, nsi, regReq.Smap)
 		bmd := p.owner.bmd.get()
-		meta := &nodeRegMeta{smap, bmd, p.si}
+		meta := &nodeRegMeta{Smap: smap, BMD: bmd, SI: p.si}
 		p.writeJSON(w, r, meta, path.Join(cmn.ActRegTarget, nsi.ID()) /* tag */)
 	}
 	go p.updateAndDistribute(nsi, msg, nonElectable)
@@ -2838,7 +3573,7 @@ func (p *proxyrunner) handleJoinKalive(nsi *cluster.Snode, regSmap *smapX,
 			}
 			// send the joining node the current BMD and Smap as well
 			bmd := p.owner.bmd.get()
-			meta := &nodeRegMeta{smap, bmd, p.si}
+			meta := &nodeRegMeta{Smap: smap, BMD: bmd, SI: p.si}
 			body := cmn.MustMarshal(meta)
 			path := cmn.URLPath(cmn.Version, cmn.Daemon, cmn.UserRegister)
 			args := callArgs{
@@ -2982,7 +3717,7 @@ func (p *proxyrunner) httpcludel(w http.ResponseWriter, r *http.Request) {
 	)
 	if node == nil {
 		err = &errNodeNotFound{"cannot remove", sid, p.si, smap}
-		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		p.invalmsghdlrErr(w, r, err, http.StatusNotFound)
 		return
 	}
 	msg = &cmn.ActionMsg{Action: cmn.ActUnregTarget}
@@ -3013,7 +3748,7 @@ func (p *proxyrunner) httpcludel(w http.ResponseWriter, r *http.Request) {
 		},
 	)
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error(), status)
+		p.invalmsghdlrErr(w, r, err, status)
 		return
 	}
 }
@@ -3078,8 +3813,11 @@ func (p *proxyrunner) unregisterNode(clone *smapX, sid string) (status int, err
 // '{"action": "syncsmap"}' /v1/cluster => (proxy) => PUT '{Smap}' /v1/daemon/syncsmap => target(s)
 // '{"action": cmn.ActXactStart}' /v1/cluster
 // '{"action": cmn.ActXactStop}' /v1/cluster
+// '{"action": cmn.ActXactPause}' /v1/cluster
+// '{"action": cmn.ActXactResume}' /v1/cluster
 // '{"action": cmn.ActRebalance}' /v1/cluster => (proxy) => PUT '{Smap}' /v1/daemon/rebalance => target(s)
 // '{"action": "setconfig"}' /v1/cluster => (proxy) =>
+// '{"action": "forceunlock"}' /v1/cluster => (proxy) => PUT '{"action": "forceunlock"}' /v1/daemon => target
 func (p *proxyrunner) httpcluput(w http.ResponseWriter, r *http.Request) {
 	apitems, err := p.checkRESTItems(w, r, 0, true, cmn.Version, cmn.Cluster)
 	if err != nil {
@@ -3116,7 +3854,7 @@ func (p *proxyrunner) cluputJSON(w http.ResponseWriter, r *http.Request) {
 		}
 		kvs := cmn.NewSimpleKVs(cmn.SimpleKVsEntry{Key: msg.Name, Value: value})
 		if err := jsp.SetConfigMany(kvs); err != nil {
-			p.invalmsghdlr(w, r, err.Error())
+			p.invalmsghdlrErr(w, r, err)
 			return
 		}
 
@@ -3133,15 +3871,19 @@ func (p *proxyrunner) cluputJSON(w http.ResponseWriter, r *http.Request) {
 		p.callAll(http.MethodPut, cmn.URLPath(cmn.Version, cmn.Daemon), cmn.MustMarshal(msg))
 		time.Sleep(time.Second)
 		_ = syscall.Kill(syscall.Getpid(), syscall.SIGINT)
-	case cmn.ActXactStart, cmn.ActXactStop:
+	case cmn.ActXactStart, cmn.ActXactStop, cmn.ActXactPause, cmn.ActXactResume:
 		xactMsg := cmn.XactReqMsg{}
 		if err := cmn.MorphMarshal(msg.Value, &xactMsg); err != nil {
-			p.invalmsghdlr(w, r, err.Error())
+			p.invalmsghdlrErr(w, r, err)
+			return
+		}
+		if (msg.Action == cmn.ActXactPause || msg.Action == cmn.ActXactResume) && !cmn.XactsMeta[xactMsg.Kind].Pausable {
+			p.invalmsghdlrf(w, r, "xaction %q cannot be paused", xactMsg.Kind)
 			return
 		}
 		if msg.Action == cmn.ActXactStart && xactMsg.Kind == cmn.ActRebalance {
 			if err := p.canStartRebalance(); err != nil {
-				p.invalmsghdlr(w, r, err.Error())
+				p.invalmsghdlrErr(w, r, err)
 				return
 			}
 			clone := p.owner.rmd.modify(func(clone *rebMD) {
@@ -3165,11 +3907,56 @@ func (p *proxyrunner) cluputJSON(w http.ResponseWriter, r *http.Request) {
 		if msg.Action == cmn.ActXactStart {
 			w.Write([]byte(xactMsg.ID))
 		}
+	case cmn.ActForceUnlock:
+		if err := p.checkPermissions(r, nil, cmn.AccessADMIN); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		p.forceUnlock(w, r, msg)
+	case cmn.ActJoinToken:
+		if err := p.checkPermissions(r, nil, cmn.AccessADMIN); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
+			return
+		}
+		token, err := p.newJoinToken(msg)
+		if err != nil {
+			p.invalmsghdlrErr(w, r, err)
+			return
+		}
+		w.Write([]byte(token))
 	default:
 		p.invalmsghdlrf(w, r, fmtUnknownAct, msg)
 	}
 }
 
+// force-releases a given (by uname) object lock on a given target
+func (p *proxyrunner) forceUnlock(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg) {
+	tid, ok := msg.Value.(string)
+	if !ok || msg.Name == "" {
+		p.invalmsghdlrf(w, r, "%s: invalid request (name %q, target %v)", msg.Action, msg.Name, msg.Value)
+		return
+	}
+	si := p.owner.smap.get().GetTarget(tid)
+	if si == nil {
+		p.invalmsghdlrstatusf(w, r, http.StatusNotFound, "%s: target %q not found", msg.Action, tid)
+		return
+	}
+	res := p.call(callArgs{
+		si: si,
+		req: cmn.ReqArgs{
+			Method: http.MethodPut,
+			Path:   cmn.URLPath(cmn.Version, cmn.Daemon),
+			Body:   cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActForceUnlock, Name: msg.Name}),
+		},
+		timeout: cmn.DefaultTimeout,
+	})
+	if res.err != nil {
+		p.invalmsghdlr(w, r, res.err.Error(), res.status)
+		return
+	}
+	glog.Warningf("%s: force-released lock %q on %s", p.si, msg.Name, si)
+}
+
 func (p *proxyrunner) cluputQuery(w http.ResponseWriter, r *http.Request, action string) {
 	var (
 		err   error
@@ -3182,7 +3969,7 @@ func (p *proxyrunner) cluputQuery(w http.ResponseWriter, r *http.Request, action
 	case cmn.ActSetConfig: // setconfig #1 - via query parameters and "?n1=v1&n2=v2..."
 		kvs := cmn.NewSimpleKVsFromQuery(query)
 		if err := jsp.SetConfigMany(kvs); err != nil {
-			p.invalmsghdlr(w, r, err.Error())
+			p.invalmsghdlrErr(w, r, err)
 			return
 		}
 		results := p.callAll(http.MethodPut, cmn.URLPath(cmn.Version, cmn.Daemon, cmn.ActSetConfig), nil, query)
@@ -3351,7 +4138,7 @@ func (p *proxyrunner) recoverBuckets(w http.ResponseWriter, r *http.Request, msg
 		if rbmd, err = resolveUUIDBMD(bmds); err != nil {
 			_, split := err.(*errBmdUUIDSplit)
 			if !force || errors.Is(err, errNoBMD) || split {
-				p.invalmsghdlr(w, r, err.Error())
+				p.invalmsghdlrErr(w, r, err)
 				return
 			}
 			if _, ok := err.(*errTgtBmdUUIDDiffer); ok {
@@ -3502,7 +4289,7 @@ func resolveUUIDBMD(bmds map[*cluster.Snode]*bucketMD) (*bucketMD, error) {
 		if bmd.Version == 0 {
 			continue
 		}
-		mlist[bmd.UUID] = append(mlist[bmd.UUID], nodeRegMeta{nil, bmd, si})
+		mlist[bmd.UUID] = append(mlist[bmd.UUID], nodeRegMeta{BMD: bmd, SI: si})
 
 		if rbmd, ok := maxor[bmd.UUID]; !ok {
 			maxor[bmd.UUID] = bmd
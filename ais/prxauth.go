@@ -6,15 +6,55 @@ package ais
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/ais/s3compat"
+	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/OneOfOne/xxhash"
+)
+
+const (
+	// default lifetime of a join token (see cmn.ActJoinToken)
+	joinTokenTTL = time.Hour
+	// lifetime of a bulk deletion confirmation token (see cmn.ActConfirmDelete)
+	confirmTokenTTL = 5 * time.Minute
 )
 
 var errInvalidToken = errors.New("invalid token")
 
+// confirmation tokens (see checkMassDelete) that have been used already - by
+// nonce; kept until they expire
+type usedTokens struct {
+	mtx sync.Mutex
+	m   map[string]time.Time
+}
+
+// records the token as used; returns false if it has been used before
+func (u *usedTokens) use(ct *cmn.ConfirmToken) bool {
+	u.mtx.Lock()
+	defer u.mtx.Unlock()
+	if u.m == nil {
+		u.m = make(map[string]time.Time)
+	}
+	for nonce, expires := range u.m {
+		if cmn.Expired(expires) {
+			delete(u.m, nonce)
+		}
+	}
+	if _, ok := u.m[ct.Nonce]; ok {
+		return false
+	}
+	u.m[ct.Nonce] = ct.Expires
+	return true
+}
+
 func (p *proxyrunner) httpTokenDelete(w http.ResponseWriter, r *http.Request) {
 	tokenList := &TokenList{}
 	if _, err := p.checkRESTItems(w, r, 0, false, cmn.Version, cmn.Tokens); err != nil {
@@ -33,6 +73,40 @@ func (p *proxyrunner) httpTokenDelete(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// Registers the tokens of the users logged in to access the cluster via S3 API
+// (see cmn.S3AccessKeyID); the tokens issued for other clusters are ignored
+func (p *proxyrunner) httpTokenPost(w http.ResponseWriter, r *http.Request) {
+	tokenList := &TokenList{}
+	if _, err := p.checkRESTItems(w, r, 0, false, cmn.Version, cmn.Tokens); err != nil {
+		return
+	}
+	if p.forwardCP(w, r, &cmn.ActionMsg{Action: cmn.ActRegisterS3}, "register S3 token", nil) {
+		return
+	}
+	if err := cmn.ReadJSON(w, r, tokenList); err != nil {
+		return
+	}
+	var (
+		uid    = p.owner.smap.Get().UUID
+		s3List = &TokenList{}
+	)
+	for _, token := range tokenList.S3Tokens {
+		rec, err := p.authn.validateToken(token)
+		if err != nil || (rec.ClusterID != "" && rec.ClusterID != uid) {
+			continue
+		}
+		s3List.S3Tokens = append(s3List.S3Tokens, token)
+	}
+	if len(s3List.S3Tokens) == 0 {
+		return
+	}
+	p.authn.updateRevokedList(s3List)
+	if p.owner.smap.get().isPrimary(p.si) {
+		msg := p.newAisMsgStr(cmn.ActNewPrimary, nil, nil)
+		_ = p.metasyncer.sync(revsPair{p.authn.revokedTokenList(), msg})
+	}
+}
+
 // TODO: better check for internal request - nid & pid are too insecure
 func (p *proxyrunner) isInternalReq(r *http.Request) bool {
 	pid := r.URL.Query().Get(cmn.URLParamProxyID)
@@ -78,3 +152,259 @@ func (p *proxyrunner) checkPermissions(r *http.Request, bck *cmn.Bck, perms cmn.
 	uid := p.owner.smap.Get().UUID
 	return token.CheckPermissions(uid, bck, perms)
 }
+
+// Same as checkPermissions but for the S3 API. S3 clients authenticate by
+// signing requests (SigV4) with the AuthN-issued credentials: the access key ID
+// maps to the user's token (see httpTokenPost), and the secret key is derived
+// from the ID (see cmn.S3SecretKey). Unsigned requests must carry the token in
+// the header, as usual.
+func (p *proxyrunner) checkPermissionsS3(r *http.Request, apitems []string) error {
+	cfg := cmn.GCO.Get()
+	if !cfg.Auth.Enabled {
+		return nil
+	}
+	var (
+		bck   *cmn.Bck
+		perms = s3Perms(r, apitems)
+	)
+	if len(apitems) > 0 {
+		bck = &cmn.Bck{Name: apitems[0], Provider: cmn.ProviderAIS}
+	}
+	if !s3compat.IsSigned(r) {
+		return p.checkPermissions(r, bck, perms)
+	}
+	var token *cmn.AuthToken
+	_, err := s3compat.VerifySigV4(r, func(accessKey string) (string, error) {
+		var err error
+		if token, err = p.authn.s3Token(accessKey); err != nil {
+			return "", err
+		}
+		return cmn.S3SecretKey(accessKey, cfg.Auth.Secret), nil
+	})
+	if err != nil {
+		return err
+	}
+	uid := p.owner.smap.Get().UUID
+	return token.CheckPermissions(uid, bck, perms)
+}
+
+// S3 request => AIS access permissions
+func s3Perms(r *http.Request, apitems []string) cmn.AccessAttrs {
+	var (
+		query    = r.URL.Query()
+		_, multi = query[s3compat.URLParamMultiDelete]
+		_, versn = query[s3compat.URLParamVersioning]
+		_, acl   = query[s3compat.URLParamACL]
+	)
+	switch {
+	case len(apitems) == 0:
+		return cmn.AccessBckLIST
+	case len(apitems) > 1:
+		switch r.Method {
+		case http.MethodHead:
+			return cmn.AccessObjHEAD
+		case http.MethodPut, http.MethodPost:
+			return cmn.AccessPUT
+		case http.MethodDelete:
+			return cmn.AccessObjDELETE
+		}
+		return cmn.AccessGET
+	}
+	switch r.Method {
+	case http.MethodHead:
+		return cmn.AccessBckHEAD
+	case http.MethodPut:
+		if versn || acl {
+			return cmn.AccessPATCH
+		}
+		return cmn.AccessBckCreate
+	case http.MethodPost:
+		return cmn.AccessObjDELETE
+	case http.MethodDelete:
+		if multi {
+			return cmn.AccessObjDELETE
+		}
+		return cmn.AccessBckDELETE
+	}
+	if versn || acl {
+		return cmn.AccessBckHEAD
+	}
+	return cmn.AccessObjLIST
+}
+
+// Mints a join token for a new target. The token's lifetime (e.g. "30m")
+// is optional and defaults to joinTokenTTL.
+func (p *proxyrunner) newJoinToken(msg *cmn.ActionMsg) (string, error) {
+	var (
+		ttl    = joinTokenTTL
+		smap   = p.owner.smap.get()
+		config = cmn.GCO.Get()
+	)
+	if !smap.isPrimary(p.si) {
+		return "", fmt.Errorf("%s is not the primary(%s): cannot mint join tokens", p.si, smap.ProxySI)
+	}
+	if msg.Value != nil {
+		s, ok := msg.Value.(string)
+		if !ok {
+			return "", fmt.Errorf("%s: invalid token lifetime (%+v, %T)", cmn.ActJoinToken, msg.Value, msg.Value)
+		}
+		d, err := time.ParseDuration(s)
+		if err != nil || d <= 0 {
+			return "", fmt.Errorf("%s: invalid token lifetime %q", cmn.ActJoinToken, s)
+		}
+		ttl = d
+	}
+	return cmn.NewJoinToken(smap.UUID, time.Now().Add(ttl), config.Auth.Secret)
+}
+
+// Admits a target into the cluster when join tokens are enabled (see
+// cmn.ProxyConf): the target must present a valid join token - when
+// registering and when sending keepalives alike. No token is required while
+// the cluster is starting up, and from a target that is already in the
+// cluster map (e.g., restarting, possibly with a new IP address).
+func (p *proxyrunner) validateJoinToken(nsi *cluster.Snode, token string) error {
+	config := cmn.GCO.Get()
+	if !config.Proxy.JoinTokens || !p.ClusterStarted() {
+		return nil
+	}
+	smap := p.owner.smap.get()
+	if smap.GetTarget(nsi.ID()) != nil {
+		return nil
+	}
+	if token == "" {
+		return fmt.Errorf("%s: %s cannot join the cluster without a join token", p.si, nsi)
+	}
+	if err := cmn.ValidateJoinToken(token, smap.UUID, config.Auth.Secret); err != nil {
+		return fmt.Errorf("%s: %s presented invalid join token: %v", p.si, nsi, err)
+	}
+	return nil
+}
+
+// Describes a destructive bulk operation to bind confirmation tokens to it.
+// Returns the number of affected objects when it's known upfront (list and
+// range); otherwise, returns -1 and the prefix to count the objects.
+func massDeleteOp(msg *cmn.ActionMsg, bck *cluster.Bck) (op string, cnt int64, prefix string, err error) {
+	switch msg.Action {
+	case cmn.ActDestroyLB:
+		return msg.Action + " " + bck.MakeUname(""), -1, "", nil
+	case cmn.ActDelete:
+		var (
+			listMsg  = &cmn.ListMsg{}
+			rangeMsg = &cmn.RangeMsg{}
+		)
+		if err = cmn.MorphMarshal(msg.Value, listMsg); err == nil && len(listMsg.ObjNames) > 0 {
+			digest := xxhash.ChecksumString64S(strings.Join(listMsg.ObjNames, "\n"), cmn.MLCG32)
+			op = msg.Action + " " + bck.MakeUname("") + " list:" + strconv.FormatUint(digest, 16)
+			return op, int64(len(listMsg.ObjNames)), "", nil
+		}
+		if err = cmn.MorphMarshal(msg.Value, rangeMsg); err != nil {
+			return "", 0, "", fmt.Errorf("invalid %s action message: %v", msg.Action, err)
+		}
+		op = msg.Action + " " + bck.MakeUname("") + " range:" + rangeMsg.Template
+		pt, err := cmn.ParseBashTemplate(rangeMsg.Template)
+		if err != nil {
+			if pt, err = cmn.ParseAtTemplate(rangeMsg.Template); err != nil {
+				return op, -1, rangeMsg.Template, nil
+			}
+		}
+		return op, pt.Count(), "", nil
+	default:
+		return "", 0, "", fmt.Errorf(fmtUnknownAct, msg)
+	}
+}
+
+// Mints a token that confirms a given destructive bulk operation (see
+// cmn.ProxyConf.MassDeleteThreshold).
+func (p *proxyrunner) newConfirmToken(bck *cluster.Bck, opMsg *cmn.ActionMsg) (string, error) {
+	op, _, _, err := massDeleteOp(opMsg, bck)
+	if err != nil {
+		return "", err
+	}
+	var (
+		uuid   = p.owner.smap.get().UUID
+		config = cmn.GCO.Get()
+	)
+	return cmn.NewConfirmToken(uuid, op, time.Now().Add(confirmTokenTTL), config.Auth.Secret)
+}
+
+// Guards against accidental destroying of buckets and deleting of many
+// objects at once: when the number of affected objects exceeds the configured
+// threshold, the request must carry a valid confirmation token (see
+// cmn.ActConfirmDelete) - the token is single-use, which is why the requests
+// that carry one get validated by the primary. Authenticated callers that hold cmn.AccessMassDELETE
+// may override the protection with the `force` flag (e.g., for automation).
+func (p *proxyrunner) checkMassDelete(r *http.Request, bck *cluster.Bck, msg *cmn.ActionMsg) (error, int) {
+	var (
+		config    = cmn.GCO.Get()
+		threshold = config.Proxy.MassDeleteThreshold
+		query     = r.URL.Query()
+	)
+	if threshold <= 0 {
+		return nil, 0
+	}
+	if cmn.IsParseBool(query.Get(cmn.URLParamForce)) {
+		if !config.Auth.Enabled {
+			return fmt.Errorf("%s: cannot override bulk deletion protection without authentication", p.si),
+				http.StatusForbidden
+		}
+		if err := p.checkPermissions(r, &bck.Bck, cmn.AccessMassDELETE); err != nil {
+			return err, http.StatusUnauthorized
+		}
+		glog.Warningf("%s: %q %s - bulk deletion protection overridden", p.si, msg.Action, bck)
+		return nil, 0
+	}
+	op, cnt, prefix, err := massDeleteOp(msg, bck)
+	if err != nil {
+		return err, http.StatusBadRequest
+	}
+	if token := query.Get(cmn.URLParamConfirmToken); token != "" {
+		uuid := p.owner.smap.get().UUID
+		ct, err := cmn.ValidateConfirmToken(token, uuid, op, config.Auth.Secret)
+		if err != nil {
+			return fmt.Errorf("%s: %q %s: %v", p.si, msg.Action, bck, err), http.StatusPreconditionRequired
+		}
+		if !p.usedTokens.use(ct) {
+			return fmt.Errorf("%s: %q %s: confirmation token has already been used", p.si, msg.Action, bck),
+				http.StatusPreconditionRequired
+		}
+		return nil, 0
+	}
+	if cnt < 0 {
+		if cnt, err = p.countObjects(bck, prefix); err != nil {
+			return err, http.StatusInternalServerError
+		}
+	}
+	if cnt <= threshold {
+		return nil, 0
+	}
+	return fmt.Errorf("%s: %q %s affects %d objects (threshold %d) and must be confirmed (see %q)",
+		p.si, msg.Action, bck, cnt, threshold, cmn.ActConfirmDelete), http.StatusPreconditionRequired
+}
+
+// Counts the bucket's objects with a given prefix by running bucket summary
+// and waiting for its completion.
+func (p *proxyrunner) countObjects(bck *cluster.Bck, prefix string) (int64, error) {
+	var (
+		config  = cmn.GCO.Get()
+		smsg    = cmn.SelectMsg{Prefix: prefix, Fast: prefix == "" && bck.IsAIS()}
+		started = time.Now()
+	)
+	for {
+		summaries, uuid, err := p.gatherBucketSummary(bck, smsg)
+		if err != nil {
+			return 0, err
+		}
+		if uuid == "" {
+			var cnt int64
+			for _, summary := range summaries {
+				cnt += int64(summary.ObjCount)
+			}
+			return cnt, nil
+		}
+		if time.Since(started) > config.Client.ListObjects {
+			return 0, fmt.Errorf("%s: timed out counting objects of %s", p.si, bck)
+		}
+		smsg.UUID = uuid
+		time.Sleep(config.Timeout.CplaneOperation)
+	}
+}
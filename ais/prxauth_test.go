@@ -0,0 +1,94 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/dgrijalva/jwt-go"
+)
+
+func TestUsedConfirmTokens(t *testing.T) {
+	var (
+		used    usedTokens
+		ct      = &cmn.ConfirmToken{Nonce: "nonce-1", Expires: time.Now().Add(time.Minute)}
+		another = &cmn.ConfirmToken{Nonce: "nonce-2", Expires: time.Now().Add(time.Minute)}
+		expired = &cmn.ConfirmToken{Nonce: "nonce-3", Expires: time.Now().Add(-time.Hour)}
+	)
+	if !used.use(ct) {
+		t.Fatal("expected the token to be accepted the first time")
+	}
+	if used.use(ct) {
+		t.Error("expected the token to be rejected the second time")
+	}
+	if !used.use(another) {
+		t.Error("expected another token to be accepted")
+	}
+	// expired tokens get forgotten (they can't be validated anyway)
+	used.use(expired)
+	used.use(another)
+	if _, ok := used.m[expired.Nonce]; ok {
+		t.Error("expected the expired token to be forgotten")
+	}
+}
+
+func testToken(t *testing.T, userID string, expires time.Time) string {
+	tk := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"username": userID, "expires": expires})
+	token, err := tk.SignedString([]byte(cmn.GCO.Get().Auth.Secret))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestS3Tokens(t *testing.T) {
+	config := cmn.GCO.BeginUpdate()
+	config.Auth.Secret = "s3-secret"
+	cmn.GCO.CommitUpdate(config)
+
+	var (
+		a = &authManager{
+			tokens:        make(map[string]*cmn.AuthToken),
+			revokedTokens: make(map[string]bool),
+			s3Tokens:      make(map[string]string),
+			version:       1,
+		}
+		older = testToken(t, "user", time.Now().Add(time.Hour))
+		newer = testToken(t, "user", time.Now().Add(2*time.Hour))
+		keyID = cmn.S3AccessKeyID("user", "s3-secret")
+	)
+	if len(keyID) != 20 || strings.Contains(newer, keyID) {
+		t.Fatalf("unexpected access key ID %q", keyID)
+	}
+	if keyID == cmn.S3AccessKeyID("another-user", "s3-secret") {
+		t.Fatal("expected access key IDs to differ for different users")
+	}
+
+	// the latest expiring token wins
+	a.updateRevokedList(&TokenList{S3Tokens: []string{newer}})
+	a.updateRevokedList(&TokenList{S3Tokens: []string{older}})
+	tk, err := a.s3Token(keyID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tk.UserID != "user" || a.s3Tokens[keyID] != newer {
+		t.Errorf("expected the newer token of %q, got %+v", "user", tk)
+	}
+	if _, err := a.s3Token(cmn.S3AccessKeyID("another-user", "s3-secret")); err == nil {
+		t.Error("expected unknown access key ID to fail")
+	}
+
+	// the access key stops working once the token is revoked
+	a.updateRevokedList(&TokenList{Tokens: []string{newer}})
+	if _, err := a.s3Token(keyID); err == nil {
+		t.Error("expected revoked token's access key ID to fail")
+	}
+	if list := a.revokedTokenList(); len(list.S3Tokens) != 0 {
+		t.Errorf("expected no S3 tokens, got %d", len(list.S3Tokens))
+	}
+}
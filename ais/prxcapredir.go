@@ -0,0 +1,160 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Capacity-aware PUT redirection
+//
+// Targets notify proxies when they run out of space and when they recover
+// (notifOOS). Given lru.redirect_on_oos, a proxy redirects the PUT of a new
+// object whose HRW target is out of space to the next (in HRW order) target that
+// is not - with `?mpl=true`, so that the latter stores the object that does not
+// belong to it and counts it as misplaced-by-capacity (stats.PutMisplacedCount).
+// The objects that already exist are never redirected - their PUTs go to (and
+// fail at) the HRW target - so that there's always a single copy of an object.
+// Meanwhile, the GETs and DELETEs of the object get served by the HRW target via
+// lookup-all (see targetrunner.mayHaveMisplaced). Once a target recovers, the
+// proxies that redirected PUTs request the global rebalance, which moves the
+// misplaced objects to where they belong.
+
+const reconcileRetryInterval = 10 * time.Second
+
+type capOOS struct {
+	mtx         sync.RWMutex
+	targets     cmn.StringSet // IDs of the targets that are out of space
+	misplaced   atomic.Int64  // PUTs redirected since the last rebalance request
+	reconciling atomic.Bool   // reconcileMisplaced is running
+}
+
+func (c *capOOS) isOOS(tid string) bool {
+	c.mtx.RLock()
+	oos := c.targets.Contains(tid)
+	c.mtx.RUnlock()
+	return oos
+}
+
+func (c *capOOS) set(tid string, oos bool) {
+	c.mtx.Lock()
+	if c.targets == nil {
+		c.targets = make(cmn.StringSet)
+	}
+	if oos {
+		c.targets.Add(tid)
+	} else {
+		delete(c.targets, tid)
+	}
+	c.mtx.Unlock()
+}
+
+// returns the target to redirect the PUT to: the HRW target, unless it is out of space
+func (p *proxyrunner) putTarget(bck *cluster.Bck, objName string, smap *smapX) (si *cluster.Snode, misplaced bool, err error) {
+	uname := bck.MakeUname(objName)
+	if si, err = cluster.HrwTarget(uname, &smap.Smap); err != nil {
+		return
+	}
+	if !bck.Props.LRU.RedirectOnOOS || !p.capOOS.isOOS(si.ID()) {
+		return
+	}
+	if p.objExistsAt(si, bck, objName) {
+		return // overwrite: never leave the old copy behind
+	}
+	sis, err := cluster.HrwTargetList(uname, &smap.Smap, smap.CountTargets())
+	if err != nil {
+		return si, false, nil
+	}
+	for _, tsi := range sis[1:] {
+		if !p.capOOS.isOOS(tsi.ID()) {
+			p.capOOS.misplaced.Inc()
+			return tsi, true, nil
+		}
+	}
+	return si, false, nil // the entire cluster is out of space
+}
+
+// returns true if the object exists at a given target (or it can't be determined)
+func (p *proxyrunner) objExistsAt(si *cluster.Snode, bck *cluster.Bck, objName string) bool {
+	query := cmn.AddBckToQuery(url.Values{}, bck.Bck)
+	query.Set(cmn.URLParamCheckExists, "true")
+	query.Set(cmn.URLParamSilent, "true")
+	res := p.call(callArgs{
+		si: si,
+		req: cmn.ReqArgs{
+			Method: http.MethodHead,
+			Base:   si.URL(cmn.NetworkIntraControl),
+			Path:   cmn.URLPath(cmn.Version, cmn.Objects, bck.Name, objName),
+			Query:  query,
+		},
+		timeout: cmn.GCO.Get().Timeout.CplaneOperation,
+	})
+	return res.status != http.StatusNotFound
+}
+
+// notifOOS handler
+func (p *proxyrunner) handleNotifOOS(w http.ResponseWriter, r *http.Request, msg *notifMsg) {
+	var oos bool
+	if err := jsoniter.Unmarshal(msg.Data, &oos); err != nil {
+		p.invalmsghdlrstatusf(w, r, 0, "%s: failed to unmarshal %s: %v", p.si, msg, err)
+		return
+	}
+	glog.Infof("%s: %s out of space: %t", p.si, msg.Snode, oos)
+	p.capOOS.set(msg.Snode.ID(), oos)
+	if !oos && p.capOOS.misplaced.Load() > 0 && p.capOOS.reconciling.CAS(false, true) {
+		go p.reconcileMisplaced()
+	}
+}
+
+// requests the global rebalance to move the misplaced-by-capacity objects (see above);
+// keeps retrying until the request succeeds
+func (p *proxyrunner) reconcileMisplaced() {
+	defer p.capOOS.reconciling.Store(false)
+	for {
+		n := p.capOOS.misplaced.Load()
+		if n == 0 {
+			return
+		}
+		err := p.requestRebalance()
+		if err == nil {
+			p.capOOS.misplaced.Sub(n)
+			return
+		}
+		glog.Warningf("%s: cannot reconcile %d misplaced-by-capacity object(s), retrying in %v: %v",
+			p.si, n, reconcileRetryInterval, err)
+		time.Sleep(reconcileRetryInterval)
+	}
+}
+
+func (p *proxyrunner) requestRebalance() error {
+	smap := p.owner.smap.get()
+	if smap.isPrimary(p.si) {
+		if err := p.canStartRebalance(); err != nil {
+			return err
+		}
+		clone := p.owner.rmd.modify(func(clone *rebMD) {
+			clone.inc()
+		})
+		msg := &cmn.ActionMsg{Action: cmn.ActRebalance}
+		_ = p.metasyncer.sync(revsPair{clone, p.newAisMsg(msg, nil, nil)})
+		return nil
+	}
+	msg := cmn.ActionMsg{Action: cmn.ActXactStart, Value: cmn.XactReqMsg{Kind: cmn.ActRebalance}}
+	res := p.call(callArgs{
+		si:      smap.ProxySI,
+		req:     cmn.ReqArgs{Method: http.MethodPut, Path: cmn.URLPath(cmn.Version, cmn.Cluster), Body: cmn.MustMarshal(msg)},
+		timeout: cmn.GCO.Get().Timeout.CplaneOperation,
+	})
+	return res.err
+}
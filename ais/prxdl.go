@@ -122,7 +122,7 @@ func (p *proxyrunner) broadcastStartDownloadRequest(r *http.Request, id string,
 // [METHOD] /v1/download
 func (p *proxyrunner) downloadHandler(w http.ResponseWriter, r *http.Request) {
 	if err := p.checkPermissions(r, nil, cmn.AccessDownload); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+		p.invalmsghdlrErr(w, r, err, http.StatusUnauthorized)
 		return
 	}
 	switch r.Method {
@@ -152,7 +152,7 @@ func (p *proxyrunner) httpDownloadAdmin(w http.ResponseWriter, r *http.Request)
 		return
 	}
 	if err := payload.Validate(r.Method == http.MethodDelete); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 
@@ -179,7 +179,7 @@ func (p *proxyrunner) httpDownloadAdmin(w http.ResponseWriter, r *http.Request)
 
 	resp, statusCode, err := p.broadcastDownloadAdminRequest(r.Method, path, payload)
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error(), statusCode)
+		p.invalmsghdlrErr(w, r, err, statusCode)
 		return
 	}
 
@@ -234,7 +234,7 @@ func (p *proxyrunner) validateStartDownloadRequest(w http.ResponseWriter, r *htt
 		}
 	}
 	if err := bck.Allow(cmn.AccessDownload); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 		return
 	}
 	return true
@@ -436,7 +436,10 @@ func gatherTargetListObjsResults(uuid string, ch chan *targetListObjsResult, exp
 	result.allOK = true
 	allNotFound := true
 	result.lists = make([]*cmn.BucketList, 0, expectedListsSize)
-	requestedProps := smsg.PropsSet()
+	var (
+		requestedProps        = smsg.PropsSet()
+		customKeys, customAll = smsg.CustomMDKeys()
+	)
 
 	for singleResult := range ch {
 		result.err = singleResult.err
@@ -449,7 +452,7 @@ func gatherTargetListObjsResults(uuid string, ch chan *targetListObjsResult, exp
 			break
 		}
 
-		result.lists = append(result.lists, filteredPropsList(singleResult.list, requestedProps))
+		result.lists = append(result.lists, filteredPropsList(singleResult.list, requestedProps, customKeys, customAll))
 	}
 
 	if allNotFound {
@@ -460,7 +463,8 @@ func gatherTargetListObjsResults(uuid string, ch chan *targetListObjsResult, exp
 }
 
 // Filters only requested props. New bucket list is allocated!
-func filteredPropsList(list *cmn.BucketList, propsSet cmn.StringSet) (resultList *cmn.BucketList) {
+func filteredPropsList(list *cmn.BucketList, propsSet cmn.StringSet, customKeys []string,
+	customAll bool) (resultList *cmn.BucketList) {
 	if list == nil {
 		return nil
 	}
@@ -477,6 +481,7 @@ func filteredPropsList(list *cmn.BucketList, propsSet cmn.StringSet) (resultList
 
 		if propsSet.Contains(cmn.GetPropsChecksum) {
 			newEntry.Checksum = entry.Checksum
+			newEntry.CksumType = entry.CksumType
 		}
 		if propsSet.Contains(cmn.GetPropsSize) {
 			newEntry.Size = entry.Size
@@ -493,6 +498,12 @@ func filteredPropsList(list *cmn.BucketList, propsSet cmn.StringSet) (resultList
 		if propsSet.Contains(cmn.GetPropsCopies) {
 			newEntry.Copies = entry.Copies
 		}
+		if propsSet.Contains(cmn.GetPropsAlloc) {
+			newEntry.AllocSize = entry.AllocSize
+		}
+		if customAll || len(customKeys) > 0 {
+			newEntry.SetCustomMD(entry.Custom, customKeys, customAll)
+		}
 	}
 
 	return resultList
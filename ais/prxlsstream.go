@@ -0,0 +1,118 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Streaming list-objects
+//
+// Given `?stream=true`, the proxy runs the paged listing on behalf of the client
+// and pushes each page as soon as it gets assembled - as server-sent events
+// (text/event-stream) over a single long-lived response:
+//   event: entries  data: <cmn.BucketList>  - the next page of entries
+//   event: end      data: {}                - the listing is complete
+//   event: error    data: <error message>   - the listing failed
+// The client can start processing the first entries right away and cancel the
+// listing at any point by simply closing the connection (see api.ListObjectsStream).
+
+const (
+	lsoStreamPollMin = 50 * time.Millisecond
+	lsoStreamPollMax = time.Second
+)
+
+func (p *proxyrunner) listObjectsStream(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, amsg cmn.ActionMsg) {
+	var (
+		smsg  = cmn.SelectMsg{}
+		ctx   = r.Context()
+		pages int
+	)
+	if err := cmn.MorphMarshal(amsg.Value, &smsg); err != nil {
+		p.invalmsghdlrErr(w, r, err)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		p.invalmsghdlr(w, r, "streaming is not supported", http.StatusNotImplemented)
+		return
+	}
+	if prefix := r.URL.Query().Get(cmn.URLParamPrefix); prefix != "" {
+		smsg.Prefix = prefix
+	}
+	if smsg.PageSize == 0 {
+		smsg.PageSize = cmn.DefaultListPageSize
+	}
+	smsg.UUID = ""
+
+	w.Header().Set(cmn.HeaderContentType, "text/event-stream")
+	w.Header().Set(cmn.HeaderCacheControl, "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	defer listCache.discard(&smsg, bck)
+	for sleep := lsoStreamPollMin; ; {
+		var (
+			bckList *cmn.BucketList
+			uuid    string
+			err     error
+		)
+		if bck.IsAIS() || smsg.Cached {
+			bckList, uuid, err = p.listAISBucket(bck, smsg)
+		} else {
+			bckList, uuid, _, err = p.listObjectsCloud(bck, smsg)
+		}
+		if err != nil {
+			writeSSE(w, flusher, cmn.ListStreamError, []byte(err.Error()))
+			return
+		}
+		if uuid != "" {
+			// the page is still being assembled - poll
+			smsg.UUID = uuid
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(sleep):
+			}
+			if sleep < lsoStreamPollMax {
+				sleep += sleep / 2
+			}
+			continue
+		}
+		if len(bckList.Entries) > 0 {
+			if !writeSSE(w, flusher, cmn.ListStreamEntries, cmn.MustMarshal(bckList)) {
+				glog.Warningf("%s: %s listing canceled by the client after %d page(s)", p.si, bck, pages)
+				return
+			}
+			pages++
+		}
+		if bckList.PageMarker == "" {
+			break
+		}
+		smsg.UUID = ""
+		smsg.PageMarker = bckList.PageMarker
+		smsg.PersistentHandle = bckList.PersistentMarker
+		sleep = lsoStreamPollMin
+		if ctx.Err() != nil {
+			return
+		}
+	}
+	writeSSE(w, flusher, cmn.ListStreamEnd, []byte("{}"))
+}
+
+// returns false if the client is gone
+func writeSSE(w http.ResponseWriter, flusher http.Flusher, event string, data []byte) bool {
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return false
+	}
+	flusher.Flush()
+	return true
+}
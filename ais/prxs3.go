@@ -10,7 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"path"
-	"strings"
+	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -30,6 +30,10 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
+	if err := p.checkPermissionsS3(r, apitems); err != nil {
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
+		return
+	}
 
 	switch r.Method {
 	case http.MethodHead:
@@ -55,6 +59,14 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.getBckVersioningS3(w, r, apitems[0])
 				return
 			}
+			if _, acl := q[s3compat.URLParamACL]; acl {
+				p.getBckACLS3(w, r, apitems[0])
+				return
+			}
+			if _, versions := q[s3compat.URLParamVersions]; versions {
+				p.listObjVersionsS3(w, r, apitems[0])
+				return
+			}
 			// only bucket name - list objects in the bucket
 			p.bckListS3(w, r, apitems[0])
 			return
@@ -73,11 +85,20 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 				p.putBckVersioningS3(w, r, apitems[0])
 				return
 			}
+			if _, acl := q[s3compat.URLParamACL]; acl {
+				p.putBckACLS3(w, r, apitems[0])
+				return
+			}
 			p.putBckS3(w, r, apitems[0])
 			return
 		}
 		p.putObjS3(w, r, apitems)
 	case http.MethodPost:
+		if len(apitems) > 1 {
+			// multipart upload: initiate and complete
+			p.directPutObjS3(w, r, apitems)
+			return
+		}
 		if len(apitems) != 1 {
 			p.invalmsghdlr(w, r, "bucket name expected")
 			return
@@ -132,7 +153,7 @@ func (p *proxyrunner) bckNamesToS3(w http.ResponseWriter) {
 func (p *proxyrunner) putBckS3(w http.ResponseWriter, r *http.Request, bucket string) {
 	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
 	if err := cmn.ValidateBckName(bucket); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	msg := cmn.ActionMsg{Action: cmn.ActCreateLB}
@@ -144,7 +165,7 @@ func (p *proxyrunner) putBckS3(w http.ResponseWriter, r *http.Request, bucket st
 		if _, ok := err.(*cmn.ErrorBucketAlreadyExists); ok {
 			errCode = http.StatusConflict
 		}
-		p.invalmsghdlr(w, r, err.Error(), errCode)
+		p.invalmsghdlrErr(w, r, err, errCode)
 	}
 }
 
@@ -154,11 +175,11 @@ func (p *proxyrunner) delBckS3(w http.ResponseWriter, r *http.Request, bucket st
 	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
 	msg := cmn.ActionMsg{Action: cmn.ActDestroyLB}
 	if err := bck.Init(p.owner.bmd, p.si); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		p.invalmsghdlrErr(w, r, err, http.StatusNotFound)
 		return
 	}
 	if err := bck.Allow(cmn.AccessBckDELETE); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 		return
 	}
 	if p.forwardCP(w, r, &msg, bucket, nil) {
@@ -170,7 +191,7 @@ func (p *proxyrunner) delBckS3(w http.ResponseWriter, r *http.Request, bucket st
 			glog.Infof("%s: %s already %q-ed, nothing to do", p.si, bck, msg.Action)
 			return
 		}
-		p.invalmsghdlr(w, r, err.Error(), errCode)
+		p.invalmsghdlrErr(w, r, err, errCode)
 	}
 }
 
@@ -182,50 +203,102 @@ func (p *proxyrunner) delMultipleObjs(w http.ResponseWriter, r *http.Request, bu
 	}()
 	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(p.owner.bmd, p.si); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		p.invalmsghdlrErr(w, r, err, http.StatusNotFound)
 		return
 	}
 	if err := bck.Allow(cmn.AccessObjDELETE); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 		return
 	}
 	decoder := xml.NewDecoder(r.Body)
 	objList := &s3compat.Delete{}
 	if err := decoder.Decode(objList); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
-	if len(objList.Object) == 0 {
+	if len(objList.Object) > s3compat.MaxDeleteKeys {
+		p.invalmsghdlrf(w, r, "too many objects to delete: %d (max %d)", len(objList.Object), s3compat.MaxDeleteKeys)
 		return
 	}
-	msg := cmn.ActionMsg{Action: cmn.ActDelete}
-	query := make(url.Values)
-	query.Set(cmn.URLParamProvider, cmn.ProviderAIS)
-	listMsg := &cmn.ListMsg{ObjNames: make([]string, 0, len(objList.Object))}
-	for _, obj := range objList.Object {
-		listMsg.ObjNames = append(listMsg.ObjNames, obj.Key)
+	var (
+		smap   = p.owner.smap.get()
+		errs   = make([]error, len(objList.Object))
+		perTgt = make(map[*cluster.Snode][]int, smap.CountTargets()) // target => indices of its objects
+		wg     = &sync.WaitGroup{}
+	)
+	for i, obj := range objList.Object {
+		si, err := cluster.HrwTarget(bck.MakeUname(obj.Key), &smap.Smap)
+		if err != nil {
+			errs[i] = err
+			continue
+		}
+		perTgt[si] = append(perTgt[si], i)
+	}
+	// in parallel across the targets
+	for si, indices := range perTgt {
+		wg.Add(1)
+		go func(si *cluster.Snode, indices []int) {
+			for _, i := range indices {
+				errs[i] = p.delObjS3Target(si, bck, objList.Object[i].Key)
+			}
+			wg.Done()
+		}(si, indices)
+	}
+	wg.Wait()
+
+	resp := s3compat.NewDeleteResult()
+	for i, obj := range objList.Object {
+		if errs[i] == nil {
+			if !objList.Quiet {
+				resp.AddDeleted(obj.Key)
+			}
+			continue
+		}
+		msg := errs[i].Error()
+		if httpErr, ok := errs[i].(*cmn.HTTPError); ok {
+			msg = httpErr.Message
+		}
+		resp.AddError(obj.Key, cmn.ErrCode(errs[i], http.StatusInternalServerError), msg)
 	}
-	msg.Value = listMsg
-	bt := cmn.MustMarshal(&msg)
-	// Marshal+Unmashal to new struct:
-	// hack to make `doListRange` treat `listMsg` as `map[string]interface`
-	var msg2 cmn.ActionMsg
-	err := jsoniter.Unmarshal(bt, &msg2)
-	cmn.AssertNoErr(err)
-	if err := p.doListRange(http.MethodDelete, bucket, &msg2, query); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+	w.Header().Set("Content-Type", s3compat.ContentType)
+	w.Write(resp.MustMarshal())
+}
+
+// deletes a single object (as part of multiple object delete); as per S3,
+// deleting an object that does not exist is not an error
+func (p *proxyrunner) delObjS3Target(si *cluster.Snode, bck *cluster.Bck, objName string) error {
+	res := p.call(callArgs{
+		si: si,
+		req: cmn.ReqArgs{
+			Method: http.MethodDelete,
+			Path:   cmn.URLPath(cmn.Version, cmn.Objects, bck.Name, objName),
+			Query:  cmn.AddBckToQuery(nil, bck.Bck),
+		},
+		timeout: cmn.DefaultTimeout,
+	})
+	if res.err == nil || res.status == http.StatusNotFound {
+		return nil
+	}
+	httpErr := &cmn.HTTPError{}
+	if err := jsoniter.Unmarshal(res.outjson, httpErr); err != nil || httpErr.Message == "" {
+		httpErr = &cmn.HTTPError{Status: res.status, Message: res.details}
+		if res.status == 0 {
+			httpErr.Status = http.StatusInternalServerError
+		}
 	}
+	httpErr.SetCode(cmn.ErrCodeFromStatus(httpErr.Status))
+	return httpErr
 }
 
 // HEAD s3/bck-name
 func (p *proxyrunner) headBckS3(w http.ResponseWriter, r *http.Request, bucket string) {
 	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(p.owner.bmd, p.si); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		p.invalmsghdlrErr(w, r, err, http.StatusNotFound)
 		return
 	}
 	if err := bck.Allow(cmn.AccessBckHEAD); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 		return
 	}
 	// From AWS docs:
@@ -244,39 +317,137 @@ func (p *proxyrunner) headBckS3(w http.ResponseWriter, r *http.Request, bucket s
 func (p *proxyrunner) bckListS3(w http.ResponseWriter, r *http.Request, bucket string) {
 	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(p.owner.bmd, nil); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
+		return
+	}
+	q, err := s3compat.ParseListObjectQuery(r.URL.Query())
+	if err != nil {
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	var (
-		bckList *cmn.BucketList
-		uuid    string
-		err     error
+		resp   = s3compat.NewListObjectResult(bucket, q)
+		marker = q.Marker
+		handle = q.Handle // the same listing (on the targets) for all the pages
 	)
-	smsg := cmn.SelectMsg{Fast: false, TimeFormat: time.RFC3339}
-	smsg.AddProps(cmn.GetPropsSize, cmn.GetPropsChecksum, cmn.GetPropsAtime, cmn.GetPropsVersion)
-	s3compat.FillMsgFromS3Query(r.URL.Query(), &smsg)
-	_, uuid, err = p.listAISBucket(bck, smsg)
-	if err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+	if q.MaxKeys == 0 {
+		marker = "" // as per S3, an empty (and not truncated) page
+	}
+	// with delimiter, the objects get folded into common prefixes - keep
+	// reading pages until the response is full or the bucket is exhausted
+	for resp.KeyCount < q.MaxKeys {
+		smsg := cmn.SelectMsg{
+			Prefix:           q.Prefix,
+			PageMarker:       marker,
+			PageSize:         uint(q.MaxKeys - resp.KeyCount),
+			PersistentHandle: handle,
+			TimeFormat:       time.RFC3339,
+		}
+		smsg.AddProps(cmn.GetPropsSize, cmn.GetPropsChecksum, cmn.GetPropsAtime, cmn.GetPropsVersion)
+		bckList, err := p.listS3Page(bck, smsg)
+		if err != nil {
+			p.invalmsghdlrErr(w, r, err)
+			return
+		}
+		handle = bckList.PersistentMarker
+		var commonPrefix string
+		for _, entry := range bckList.Entries {
+			commonPrefix = resp.Add(entry)
+			marker = entry.Name
+		}
+		if bckList.PageMarker == "" || len(bckList.Entries) == 0 {
+			marker = "" // done
+			break
+		}
+		if commonPrefix != "" {
+			// skip the rest of the objects under the common prefix
+			marker = s3compat.SkipPrefixMarker(commonPrefix)
+		}
+	}
+	if marker != "" {
+		resp.Truncate(marker, handle)
+	}
+	b := resp.MustMarshal()
+	w.Header().Set("Content-Type", s3compat.ContentType)
+	w.Write(b)
+}
+
+// returns a single page of the bucket's objects
+func (p *proxyrunner) listS3Page(bck *cluster.Bck, smsg cmn.SelectMsg) (bckList *cmn.BucketList, err error) {
+	var uuid string
+	if _, uuid, err = p.listAISBucket(bck, smsg); err != nil {
 		return
 	}
 	smsg.UUID = uuid
 	for {
 		bckList, uuid, err = p.listAISBucket(bck, smsg)
-		if err != nil {
-			p.invalmsghdlr(w, r, err.Error())
+		if err != nil || bckList != nil {
 			return
 		}
-		if bckList != nil {
-			break
-		}
 		// just in case
 		smsg.UUID = uuid
 		time.Sleep(time.Second)
 	}
-	resp := s3compat.NewListObjectResult()
-	resp.PageMarker = smsg.PageMarker
-	resp.FillFromAisBckList(bckList)
+}
+
+// GET s3/bk-name?versions
+// The current versions (a page of the objects) are merged with the previous
+// versions that all targets keep.
+func (p *proxyrunner) listObjVersionsS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd, nil); err != nil {
+		p.invalmsghdlrErr(w, r, err)
+		return
+	}
+	q, err := s3compat.ParseListVersionsQuery(r.URL.Query())
+	if err != nil {
+		p.invalmsghdlrErr(w, r, err)
+		return
+	}
+	smsg := cmn.SelectMsg{
+		Prefix:     q.Prefix,
+		PageMarker: q.KeyMarker,
+		PageSize:   uint(q.MaxKeys),
+		TimeFormat: time.RFC3339,
+	}
+	smsg.AddProps(cmn.GetPropsSize, cmn.GetPropsChecksum, cmn.GetPropsAtime, cmn.GetPropsVersion)
+	current, err := p.listS3Page(bck, smsg)
+	if err != nil {
+		p.invalmsghdlrErr(w, r, err)
+		return
+	}
+	var (
+		prev  = make([]*cmn.BucketEntry, 0, 16)
+		query = url.Values{}
+	)
+	query.Set(s3compat.URLParamVersions, "")
+	query.Set("prefix", q.Prefix)
+	query.Set("key-marker", q.KeyMarker)
+	results := p.bcastTo(bcastArgs{
+		req: cmn.ReqArgs{
+			Method: http.MethodGet,
+			Path:   cmn.URLPath(cmn.S3, bucket),
+			Query:  query,
+		},
+		network: cmn.NetworkIntraData,
+		timeout: cmn.DefaultTimeout,
+		to:      cluster.Targets,
+	})
+	for res := range results {
+		if res.err != nil {
+			p.invalmsghdlrstatusf(w, r, res.status, "%s: failed to list object versions of %s: %v",
+				res.si, bck, res.err)
+			return
+		}
+		var tentries []*cmn.BucketEntry
+		if err := jsoniter.Unmarshal(res.outjson, &tentries); err != nil {
+			p.invalmsghdlrf(w, r, "%s: invalid object versions of %s: %v", res.si, bck, err)
+			return
+		}
+		prev = append(prev, tentries...)
+	}
+	resp := s3compat.NewListVersionsResult(bucket, q)
+	resp.Fill(current, prev)
 	b := resp.MustMarshal()
 	w.Header().Set("Content-Type", s3compat.ContentType)
 	w.Write(b)
@@ -285,25 +456,29 @@ func (p *proxyrunner) bckListS3(w http.ResponseWriter, r *http.Request, bucket s
 // PUT s3/bckName/objName - with HeaderObjSrc in request header - a source
 func (p *proxyrunner) copyObjS3(w http.ResponseWriter, r *http.Request, items []string) {
 	started := time.Now()
-	src := r.Header.Get(s3compat.HeaderObjSrc)
-	src = strings.Trim(src, "/") // in examples the path starts with "/"
-	parts := strings.SplitN(src, "/", 2)
-	if len(parts) < 2 {
-		p.invalmsghdlr(w, r, "copy is not an object name")
+	srcBck, objName, err := s3compat.ParseCopySource(r.Header.Get(s3compat.HeaderObjSrc))
+	if err != nil {
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
-	bckSrc := cluster.NewBck(parts[0], cmn.ProviderAIS, cmn.NsGlobal)
-	if err := bckSrc.Init(p.owner.bmd, nil); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+	if _, err := s3compat.MetaDirective(r.Header); err != nil {
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
+	bckSrc := cluster.NewBckEmbed(srcBck)
+	if err := bckSrc.Init(p.owner.bmd, p.si); err != nil {
+		args := remBckAddArgs{p: p, w: w, r: r, queryBck: bckSrc, err: err}
+		if bckSrc, err = args.try(); err != nil {
+			return
+		}
+	}
 	if err := bckSrc.Allow(cmn.AccessGET); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 		return
 	}
 	bckDst := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bckDst.Init(p.owner.bmd, nil); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if len(items) < 2 {
@@ -313,16 +488,14 @@ func (p *proxyrunner) copyObjS3(w http.ResponseWriter, r *http.Request, items []
 	var (
 		si   *cluster.Snode
 		smap = p.owner.smap.get()
-		err  error
 	)
 	if err = bckDst.Allow(cmn.AccessPUT); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 		return
 	}
-	objName := strings.Trim(parts[1], "/")
 	si, err = cluster.HrwTarget(bckSrc.MakeUname(objName), &smap.Smap)
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if glog.FastV(4, glog.SmoduleAIS) {
@@ -346,7 +519,7 @@ func (p *proxyrunner) directPutObjS3(w http.ResponseWriter, r *http.Request, ite
 	started := time.Now()
 	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(p.owner.bmd, nil); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if len(items) < 2 {
@@ -359,13 +532,13 @@ func (p *proxyrunner) directPutObjS3(w http.ResponseWriter, r *http.Request, ite
 		err  error
 	)
 	if err = bck.Allow(cmn.AccessPUT); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 		return
 	}
 	objName := path.Join(items[1:]...)
 	si, err = cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if glog.FastV(4, glog.SmoduleAIS) {
@@ -389,7 +562,7 @@ func (p *proxyrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []s
 	started := time.Now()
 	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(p.owner.bmd, nil); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if len(items) < 2 {
@@ -402,14 +575,14 @@ func (p *proxyrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []s
 		err  error
 	)
 	if err = bck.Allow(cmn.AccessGET); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 		return
 	}
 	objName, _ := cmn.S3ObjNameTag(path.Join(items[1:]...))
 
 	si, err = cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if glog.FastV(4, glog.SmoduleAIS) {
@@ -428,17 +601,17 @@ func (p *proxyrunner) headObjS3(w http.ResponseWriter, r *http.Request, items []
 	bucket, objName := items[0], path.Join(items[1:]...)
 	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(p.owner.bmd, nil); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if err := bck.Allow(cmn.AccessObjHEAD); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 		return
 	}
 	smap := p.owner.smap.get()
 	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusInternalServerError)
+		p.invalmsghdlrErr(w, r, err, http.StatusInternalServerError)
 		return
 	}
 	if glog.FastV(4, glog.SmoduleAIS) {
@@ -453,7 +626,7 @@ func (p *proxyrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []s
 	started := time.Now()
 	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(p.owner.bmd, nil); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if len(items) < 2 {
@@ -466,13 +639,13 @@ func (p *proxyrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []s
 		err  error
 	)
 	if err = bck.Allow(cmn.AccessObjDELETE); err != nil {
-		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 		return
 	}
 	objName := path.Join(items[1:]...)
 	si, err = cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if glog.FastV(4, glog.SmoduleAIS) {
@@ -486,7 +659,7 @@ func (p *proxyrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []s
 func (p *proxyrunner) getBckVersioningS3(w http.ResponseWriter, r *http.Request, bucket string) {
 	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(p.owner.bmd, nil); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	props, exists := p.owner.bmd.get().Get(bck)
@@ -508,7 +681,7 @@ func (p *proxyrunner) putBckVersioningS3(w http.ResponseWriter, r *http.Request,
 	}
 	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(p.owner.bmd, nil); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	_, exists := p.owner.bmd.get().Get(bck)
@@ -522,14 +695,59 @@ func (p *proxyrunner) putBckVersioningS3(w http.ResponseWriter, r *http.Request,
 	}()
 	vconf := &s3compat.VersioningConfiguration{}
 	if err := decoder.Decode(vconf); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+		p.invalmsghdlrErr(w, r, err)
 		return
 	}
 	enabled := vconf.Enabled()
 	propsToUpdate := cmn.BucketPropsToUpdate{
 		Versioning: &cmn.VersionConfToUpdate{Enabled: &enabled},
 	}
-	if err := p.setBucketProps(msg, bck, propsToUpdate); err != nil {
-		p.invalmsghdlr(w, r, err.Error())
+	if err := p.setBucketProps(msg, bck, propsToUpdate, false /*dry-run*/); err != nil {
+		p.invalmsghdlrErr(w, r, err)
+	}
+}
+
+// GET s3/bk-name?acl
+func (p *proxyrunner) getBckACLS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd, nil); err != nil {
+		p.invalmsghdlrErr(w, r, err)
+		return
+	}
+	if err := bck.Allow(cmn.AccessBckHEAD); err != nil {
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	resp := s3compat.NewAccessControlPolicy(bck.Props.Access)
+	w.Header().Set("Content-Type", s3compat.ContentType)
+	w.Write(resp.MustMarshal())
+}
+
+// PUT s3/bk-name?acl
+func (p *proxyrunner) putBckACLS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	msg := &cmn.ActionMsg{Action: cmn.ActSetBprops}
+	if p.forwardCP(w, r, msg, bucket, nil) {
+		return
+	}
+	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd, nil); err != nil {
+		p.invalmsghdlrErr(w, r, err)
+		return
+	}
+	if err := bck.Allow(cmn.AccessPATCH); err != nil {
+		p.invalmsghdlrErr(w, r, err, http.StatusForbidden)
+		return
+	}
+	defer func() {
+		debug.AssertNoErr(r.Body.Close())
+	}()
+	access, err := s3compat.ParseACL(r)
+	if err != nil {
+		p.invalmsghdlrErr(w, r, err)
+		return
+	}
+	propsToUpdate := cmn.BucketPropsToUpdate{Access: &access}
+	if err := p.setBucketProps(msg, bck, propsToUpdate, false /*dry-run*/); err != nil {
+		p.invalmsghdlrErr(w, r, err)
 	}
 }
@@ -11,6 +11,8 @@ import (
 	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -19,21 +21,56 @@ import (
 	jsoniter "github.com/json-iterator/go"
 )
 
-// convenience structure to gather all (or most) of the relevant context in one place
-// (compare with txnServerCtx & prepTxnServer)
-type txnClientCtx struct {
-	uuid    string
-	smap    *smapX
-	msg     *aisMsg
-	body    []byte
-	path    string
-	timeout time.Duration
-	req     cmn.ReqArgs
-}
+type (
+	// convenience structure to gather all (or most) of the relevant context in one place
+	// (compare with txnServerCtx & prepTxnServer)
+	txnClientCtx struct {
+		uuid    string
+		smap    *smapX
+		msg     *aisMsg
+		body    []byte
+		path    string
+		timeout time.Duration
+		req     cmn.ReqArgs
+	}
+
+	// txnSteps describes a CP transaction in terms of its (optional) steps - the
+	// parts that differ from one transaction to another; the rest - begin, abort,
+	// metasync, and commit - is done by runTxn
+	txnSteps struct {
+		msg *cmn.ActionMsg // sent to the targets
+		bck *cluster.Bck
+
+		// 1. confirm (non-)existence, etc. - under BMD lock; may change msg.Value
+		check func(bmd *bucketMD) error
+		// 3. update BMD clone - under lock; the updated BMD (if changed) gets metasync-ed prior to commit
+		updateBMD func(clone *bucketMD) error
+		// 4. right before commit - e.g., to start waiting for `finished` notifications
+		preCommit func(c *txnClientCtx)
+		// 5. replaces the default commit (see txnCommit) - e.g., to commit along with RMD
+		commit func(c *txnClientCtx) error
+		// undo the (metasync-ed) update when commit fails
+		rollback func()
+
+		// validate only: begin (on the targets) and update a throwaway BMD clone - then abort
+		dryRun bool
+
+		// NOTE: can be overridden on a per-action basis - see cmn.TxnConf
+		beginTimeout  time.Duration // begin broadcast (0: default)
+		txnTimeout    time.Duration // targets waiting for metasync upon commit (0: CplaneOperation)
+		commitTimeout time.Duration // commit broadcast (0: cmn.LongTimeout)
+	}
+
+	// set-bucket-props (batch): new props of a given bucket, as sent to the targets
+	bpropsBatchEntry struct {
+		Bck   cmn.Bck          `json:"bck"`
+		Props *cmn.BucketProps `json:"props"`
+	}
+)
 
 // NOTE
-// - implementation-wise, a typical CP transaction will execute, with minor variations,
-//   the same 6 (plus/minus) steps as shown below.
+// - implementation-wise, a typical CP transaction executes, with minor variations,
+//   the same 5 (plus/minus) steps - see txnSteps and runTxn.
 // - notice a certain symmetry between the client and the server sides whetreby
 //   the control flow looks as follows:
 //   	txnClientCtx =>
@@ -41,72 +78,239 @@ type txnClientCtx struct {
 //   			switch msg.Action =>
 //   				txnServerCtx =>
 //   					concrete transaction, etc.
+// - runTxn journals the transaction's progress - see txnJournal.
+// - name-locking the buckets is the caller's responsibility, and so is unlocking -
+//   unless the unlocking is delegated to the notification listener (preCommit).
+
+// runTxn: { check -- begin -- update locally -- metasync -- pre-commit -- commit }
+func (p *proxyrunner) runTxn(steps *txnSteps) error {
+	steps.confTimeouts(&cmn.GCO.Get().Txn)
+
+	// 1. check
+	if steps.check != nil {
+		p.owner.bmd.Lock()
+		err := steps.check(p.owner.bmd.get())
+		p.owner.bmd.Unlock()
+		if err != nil {
+			return err
+		}
+	}
 
-// create-bucket: { check non-existence -- begin -- create locally -- metasync -- commit }
-func (p *proxyrunner) createBucket(msg *cmn.ActionMsg, bck *cluster.Bck, cloudHeader ...http.Header) error {
-	var (
-		bucketProps = cmn.DefaultBucketProps()
-		nlp         = bck.GetNameLockPair()
-	)
+	// 2. begin
+	c := p.prepTxnClient(steps.msg, steps.bck)
+	if steps.dryRun {
+		return p.txnDryRun(c, steps)
+	}
+	p.txnJournal.add(c, steps.commit != nil)
+	if err := p.txnBegin(c, steps.beginTimeout); err != nil {
+		return err
+	}
 
-	if bck.Props != nil {
-		bucketProps = bck.Props
-	} else if len(cloudHeader) != 0 {
-		bucketProps = cmn.CloudBucketProps(cloudHeader[0])
+	// 3. update BMD locally and metasync
+	if steps.updateBMD != nil {
+		if err := p.txnUpdateBMD(c, steps.updateBMD); err != nil {
+			p.txnAbort(c)
+			return err
+		}
 	}
-	nlp.Lock()
-	defer nlp.Unlock()
 
-	// 1. try add
-	p.owner.bmd.Lock()
-	bmd := p.owner.bmd.get()
-	if _, present := bmd.Get(bck); present {
+	// 4. pre-commit
+	if steps.preCommit != nil {
+		steps.preCommit(c)
+	}
+
+	// 5. commit
+	var err error
+	if steps.commit != nil {
+		err = steps.commit(c)
+	} else {
+		err = p.txnCommit(c, steps.txnTimeout, steps.commitTimeout)
+	}
+	p.txnJournal.del(c.uuid)
+	if err != nil && steps.rollback != nil {
+		steps.rollback()
+	}
+	return err
+}
+
+// apply the configured per-action timeouts, if any
+func (steps *txnSteps) confTimeouts(conf *cmn.TxnConf) {
+	tc, ok := conf.Timeouts[steps.msg.Action]
+	if !ok {
+		return
+	}
+	if tc.Begin != 0 {
+		steps.beginTimeout = tc.Begin
+	}
+	if tc.Wait != 0 {
+		steps.txnTimeout = tc.Wait
+	}
+	if tc.Commit != 0 {
+		steps.commitTimeout = tc.Commit
+	}
+}
+
+// begin with the targets validating (only) and abort; returns the first error, if any
+func (p *proxyrunner) txnDryRun(c *txnClientCtx, steps *txnSteps) (err error) {
+	c.req.Query.Set(cmn.URLParamDryRun, "true")
+	if err = p.txnBegin(c, steps.beginTimeout); err != nil {
+		return
+	}
+	if steps.updateBMD != nil {
+		p.owner.bmd.Lock()
+		err = steps.updateBMD(p.owner.bmd.get().clone())
 		p.owner.bmd.Unlock()
-		return cmn.NewErrorBucketAlreadyExists(bck.Bck, p.si.String())
 	}
-	p.owner.bmd.Unlock()
+	p.txnAbort(c)
+	return
+}
 
-	// 3. begin
-	var (
-		c = p.prepTxnClient(msg, bck)
-	)
-	results := p.bcastPost(bcastArgs{req: c.req, smap: c.smap})
+func (p *proxyrunner) txnBegin(c *txnClientCtx, timeout time.Duration) (err error) {
+	results := p.bcastPost(bcastArgs{req: c.req, smap: c.smap, timeout: timeout})
 	for res := range results {
-		if res.err != nil {
-			// abort
-			c.req.Path = cmn.URLPath(c.path, cmn.ActAbort)
-			_ = p.bcastPost(bcastArgs{req: c.req, smap: c.smap})
-			return res.err
+		if res.err != nil && err == nil {
+			err = res.err
 		}
 	}
+	if err != nil {
+		p.txnAbort(c)
+	}
+	return
+}
 
-	// 3. lock & update BMD locally
+func (p *proxyrunner) txnAbort(c *txnClientCtx) {
+	c.req.Path = cmn.URLPath(c.path, cmn.ActAbort)
+	_ = p.bcastPost(bcastArgs{req: c.req, smap: c.smap})
+	p.txnJournal.del(c.uuid)
+}
+
+func (p *proxyrunner) txnUpdateBMD(c *txnClientCtx, update func(clone *bucketMD) error) error {
 	p.owner.bmd.Lock()
 	clone := p.owner.bmd.get().clone()
-	added := clone.add(bck, bucketProps)
-	cmn.Assert(added)
+	ver := clone.version()
+	if err := update(clone); err != nil {
+		p.owner.bmd.Unlock()
+		return err
+	}
+	if clone.version() == ver { // nothing to update
+		p.owner.bmd.Unlock()
+		return nil
+	}
+	p.txnJournal.setPhase(c, txnPhaseMetasync) // from now on, roll forward
 	p.owner.bmd.put(clone)
 
-	// 4. metasync updated BMD & unlock BMD
+	// metasync updated BMD; unlock BMD
 	c.msg.BMDVersion = clone.version()
 	wg := p.metasyncer.sync(revsPair{clone, c.msg})
 	p.owner.bmd.Unlock()
 
 	wg.Wait() // to synchronize prior to committing
+	c.req.Query.Set(cmn.URLParamWaitMetasync, "true")
+	return nil
+}
 
-	// 5. commit
+// commit must go thru: retries (see cmn.TxnConf) the targets that fail to respond,
+// logs all errors and returns the first one
+func (p *proxyrunner) txnCommit(c *txnClientCtx, txnTimeout, timeout time.Duration) (err error) {
 	c.req.Path = cmn.URLPath(c.path, cmn.ActCommit)
-	c.timeout = cmn.GCO.Get().Timeout.MaxKeepalive // making exception for this critical op
-	c.req.Query.Set(cmn.URLParamTxnTimeout, cmn.UnixNano2S(int64(c.timeout)))
-	results = p.bcastPost(bcastArgs{req: c.req, smap: c.smap, timeout: cmn.LongTimeout})
-	for res := range results {
-		if res.err != nil {
-			glog.Error(res.err) // commit must go thru
-			p.undoCreateBucket(msg, bck)
-			return res.err
+	if txnTimeout != 0 {
+		c.timeout = txnTimeout
+		c.req.Query.Set(cmn.URLParamTxnTimeout, cmn.UnixNano2S(int64(c.timeout)))
+	}
+	if timeout == 0 {
+		timeout = cmn.LongTimeout
+	}
+	p.txnJournal.setPhase(c, txnPhaseCommit)
+	var (
+		conf    = cmn.GCO.Get().Txn
+		backoff = conf.CommitBackoff
+		results = p.bcastPost(bcastArgs{req: c.req, smap: c.smap, timeout: timeout})
+	)
+	if backoff == 0 {
+		backoff = c.timeout
+	}
+	for retry := 0; ; retry++ {
+		var failed cluster.NodeMap
+		for res := range results {
+			if res.err == nil {
+				continue
+			}
+			if retry > 0 && strings.Contains(res.err.Error(), txnNotFound) {
+				// has committed while we were waiting for it
+				glog.Warningf("%s: %s txn[%s] (retry #%d): %v", p.si, res.si, c.uuid, retry, res.err)
+				continue
+			}
+			if retry < conf.CommitRetries && txnRetriable(&res) {
+				if failed == nil {
+					failed = make(cluster.NodeMap, 2)
+				}
+				failed[res.si.ID()] = res.si
+				continue
+			}
+			glog.Error(res.err)
+			if err == nil {
+				err = res.err
+			}
 		}
+		if len(failed) == 0 {
+			return
+		}
+		glog.Warningf("%s: retrying commit %q txn[%s] with %d target(s) in %v", p.si, c.msg.Action, c.uuid,
+			len(failed), backoff)
+		time.Sleep(backoff)
+		backoff *= 2
+		req := c.req
+		req.Method = http.MethodPost
+		results = p.bcast(bcastArgs{req: req, network: cmn.NetworkIntraControl, timeout: timeout,
+			nodes: []cluster.NodeMap{failed}})
 	}
-	return nil
+}
+
+// no response, or the target is (temporarily) unable to commit
+func txnRetriable(res *callResult) bool {
+	switch res.status {
+	case 0, http.StatusServiceUnavailable, http.StatusGatewayTimeout, http.StatusRequestTimeout:
+		return true
+	}
+	return false
+}
+
+// create-bucket: { check non-existence -- begin -- create locally -- metasync -- commit }
+func (p *proxyrunner) createBucket(msg *cmn.ActionMsg, bck *cluster.Bck, cloudHeader ...http.Header) error {
+	var (
+		bucketProps = cmn.DefaultBucketProps()
+		nlp         = bck.GetNameLockPair()
+	)
+
+	if bck.Props != nil {
+		bucketProps = bck.Props
+	} else if len(cloudHeader) != 0 {
+		bucketProps = cmn.CloudBucketProps(cloudHeader[0])
+	}
+	nlp.Lock()
+	defer nlp.Unlock()
+
+	err := p.runTxn(&txnSteps{
+		msg: msg,
+		bck: bck,
+		check: func(bmd *bucketMD) error {
+			if _, present := bmd.Get(bck); present {
+				return cmn.NewErrorBucketAlreadyExists(bck.Bck, p.si.String())
+			}
+			return nil
+		},
+		updateBMD: func(clone *bucketMD) error {
+			added := clone.add(bck, bucketProps)
+			cmn.Assert(added)
+			return nil
+		},
+		rollback:   func() { p.undoCreateBucket(msg, bck) },
+		txnTimeout: cmn.GCO.Get().Timeout.MaxKeepalive, // making exception for this critical op
+	})
+	if err == nil {
+		p.events.Publish(&cmn.Event{Type: cmn.EventBckCreate, Bck: bck.Bck})
+	}
+	return err
 }
 
 // destroy AIS bucket or evict Cloud bucket
@@ -134,16 +338,17 @@ func (p *proxyrunner) destroyBucket(msg *cmn.ActionMsg, bck *cluster.Bck) error
 	p.owner.bmd.Unlock()
 
 	wg.Wait()
+	p.events.Publish(&cmn.Event{Type: cmn.EventBckDestroy, Bck: bck.Bck})
 	return nil
 }
 
 // make-n-copies: { confirm existence -- begin -- update locally -- metasync -- commit }
-func (p *proxyrunner) makeNCopies(msg *cmn.ActionMsg, bck *cluster.Bck) error {
+func (p *proxyrunner) makeNCopies(msg *cmn.ActionMsg, bck *cluster.Bck, job *notifListenerJob) error {
 	var (
 		pname       = p.si.String()
-		c           = p.prepTxnClient(msg, bck)
 		nlp         = bck.GetNameLockPair()
 		copies, err = p.parseNCopies(msg.Value)
+		bprops      *cmn.BucketProps
 		unlockUpon  bool // unlock upon receiving target notifications
 	)
 	if err != nil {
@@ -158,77 +363,42 @@ func (p *proxyrunner) makeNCopies(msg *cmn.ActionMsg, bck *cluster.Bck) error {
 		}
 	}()
 
-	// 1. confirm existence
-	p.owner.bmd.Lock()
-	bmd := p.owner.bmd.get()
-	if _, present := bmd.Get(bck); !present {
-		p.owner.bmd.Unlock()
-		return cmn.NewErrorBucketDoesNotExist(bck.Bck, pname)
-	}
-	p.owner.bmd.Unlock()
-
-	// 2. begin
-	results := p.bcastPost(bcastArgs{req: c.req, smap: c.smap})
-	for res := range results {
-		if res.err != nil {
-			// abort
-			c.req.Path = cmn.URLPath(c.path, cmn.ActAbort)
-			_ = p.bcastPost(bcastArgs{req: c.req, smap: c.smap})
-			return res.err
-		}
-	}
-
-	// 3. lock & update BMD locally
-	p.owner.bmd.Lock()
-	clone := p.owner.bmd.get().clone()
-	bprops, present := clone.Get(bck)
-	cmn.Assert(present)
-	nprops := bprops.Clone()
-	nprops.Mirror.Enabled = copies > 1
-	nprops.Mirror.Copies = copies
-
-	clone.set(bck, nprops)
-	p.owner.bmd.put(clone)
-
-	// 4. metasync updated BMD; unlock BMD
-	c.msg.BMDVersion = clone.version()
-	wg := p.metasyncer.sync(revsPair{clone, c.msg})
-	p.owner.bmd.Unlock()
-
-	wg.Wait()
-
-	// 5. start waiting for `finished` notifications
-	c.req.Query.Set(cmn.URLParamNotifyMe, p.si.ID())
-	nl := notifListenerBck{
-		notifListenerBase: notifListenerBase{srcs: c.smap.Tmap.Clone(), f: p.nlBckCb}, nlp: &nlp,
-	}
-	p.notifs.add(c.uuid, &nl)
-
-	// 6. commit
-	unlockUpon = true
-	c.req.Path = cmn.URLPath(c.path, cmn.ActCommit)
-	results = p.bcastPost(bcastArgs{req: c.req, smap: c.smap, timeout: cmn.LongTimeout})
-	for res := range results {
-		if res.err != nil {
-			glog.Error(res.err) // commit must go thru
-			p.undoUpdateCopies(msg, bck, bprops.Mirror.Copies, bprops.Mirror.Enabled)
-			return res.err
-		}
-	}
-
-	return nil
+	return p.runTxn(&txnSteps{
+		msg: msg,
+		bck: bck,
+		check: func(bmd *bucketMD) error {
+			if _, present := bmd.Get(bck); !present {
+				return cmn.NewErrorBucketDoesNotExist(bck.Bck, pname)
+			}
+			return nil
+		},
+		updateBMD: func(clone *bucketMD) error {
+			var present bool
+			bprops, present = clone.Get(bck)
+			cmn.Assert(present)
+			nprops := bprops.Clone()
+			nprops.Mirror.Enabled = copies > 1
+			nprops.Mirror.Copies = copies
+			clone.set(bck, nprops)
+			return nil
+		},
+		preCommit: func(c *txnClientCtx) {
+			p.notifyBckDone(c, &nlp, job)
+			unlockUpon = true
+		},
+		rollback: func() { p.undoUpdateCopies(msg, bck, bprops.Mirror.Copies, bprops.Mirror.Enabled) },
+	})
 }
 
 // set-bucket-props: { confirm existence -- begin -- apply props -- metasync -- commit }
 func (p *proxyrunner) setBucketProps(msg *cmn.ActionMsg, bck *cluster.Bck,
-	propsToUpdate cmn.BucketPropsToUpdate) (err error) {
+	propsToUpdate cmn.BucketPropsToUpdate, dryRun bool) (err error) {
 	var (
-		c          *txnClientCtx
-		nlp        = bck.GetNameLockPair()
-		nprops     *cmn.BucketProps   // complete version of bucket props containing propsToUpdate changes
-		nmsg       = &cmn.ActionMsg{} // with nprops
-		pname      = p.si.String()
-		unlockUpon bool
+		nlp            = bck.GetNameLockPair()
+		nmsg           = &cmn.ActionMsg{} // with nprops
+		pname          = p.si.String()
+		remirror, reec bool
+		unlockUpon     bool
 	)
 
 	if !nlp.TryLock() {
@@ -240,88 +410,126 @@ func (p *proxyrunner) setBucketProps(msg *cmn.ActionMsg, bck *cluster.Bck,
 		}
 	}()
 
-	// 1. confirm existence
-	p.owner.bmd.Lock()
-	bmd := p.owner.bmd.get()
-	bprops, present := bmd.Get(bck)
-	if !present {
-		p.owner.bmd.Unlock()
-		return cmn.NewErrorBucketDoesNotExist(bck.Bck, pname)
-	}
-	bck.Props = bprops
-	p.owner.bmd.Unlock()
-
-	// 2. begin
-	switch msg.Action {
-	case cmn.ActSetBprops:
-		// make and validate new props
-		if nprops, _, _, err = p.makeNprops(bck, propsToUpdate); err != nil {
-			return
-		}
-	case cmn.ActResetBprops:
-		nprops = cmn.DefaultBucketProps()
-	default:
-		cmn.Assert(false)
-	}
-	// msg{propsToUpdate} => nmsg{nprops} and prep context(nmsg)
+	// msg{propsToUpdate} => nmsg{nprops}
 	*nmsg = *msg
-	nmsg.Value = nprops
-	c = p.prepTxnClient(nmsg, bck)
-
-	results := p.bcastPost(bcastArgs{req: c.req, smap: c.smap})
-	for res := range results {
-		if res.err != nil {
-			// abort
-			c.req.Path = cmn.URLPath(c.path, cmn.ActAbort)
-			_ = p.bcastPost(bcastArgs{req: c.req, smap: c.smap})
-			return res.err
-		}
-	}
-
-	// 3. lock and update BMD locally
-	var remirror, reec bool
-	p.owner.bmd.Lock()
-	clone := p.owner.bmd.get().clone()
-	bprops, present = clone.Get(bck)
-	cmn.Assert(present)
-	if msg.Action == cmn.ActSetBprops {
-		bck.Props = bprops
-		nprops, remirror, reec, err = p.makeNprops(bck, propsToUpdate) // under lock
-		if err != nil {
+	return p.runTxn(&txnSteps{
+		msg: nmsg,
+		bck: bck,
+		check: func(bmd *bucketMD) (err error) {
+			bprops, present := bmd.Get(bck)
+			if !present {
+				return cmn.NewErrorBucketDoesNotExist(bck.Bck, pname)
+			}
+			bck.Props = bprops
+			// make and validate new props
+			var nprops *cmn.BucketProps // complete version of bucket props containing propsToUpdate changes
+			switch msg.Action {
+			case cmn.ActSetBprops:
+				if nprops, _, _, err = p.makeNprops(bck, propsToUpdate); err != nil {
+					return
+				}
+			case cmn.ActResetBprops:
+				nprops = cmn.DefaultBucketProps()
+			default:
+				cmn.Assert(false)
+			}
+			nmsg.Value = nprops
 			return
-		}
-	}
-	clone.set(bck, nprops)
-	p.owner.bmd.put(clone)
-
-	// 4. metasync updated BMD; unlock BMD
-	c.msg.BMDVersion = clone.version()
-	wg := p.metasyncer.sync(revsPair{clone, c.msg})
-	p.owner.bmd.Unlock()
-
-	wg.Wait()
+		},
+		updateBMD: func(clone *bucketMD) (err error) {
+			nprops := nmsg.Value.(*cmn.BucketProps)
+			if msg.Action == cmn.ActSetBprops {
+				bprops, present := clone.Get(bck)
+				cmn.Assert(present)
+				bck.Props = bprops
+				if nprops, remirror, reec, err = p.makeNprops(bck, propsToUpdate); err != nil {
+					return
+				}
+			}
+			clone.set(bck, nprops)
+			return
+		},
+		// if remirror|re-EC|TBD-storage-svc: start waiting
+		preCommit: func(c *txnClientCtx) {
+			if remirror || reec {
+				p.notifyBckDone(c, &nlp, nil)
+				unlockUpon = true // unlock upon receiving target notifications
+			}
+		},
+		dryRun: dryRun,
+	})
+}
 
-	// 5. if remirror|re-EC|TBD-storage-svc: start waiting
-	if remirror || reec {
-		c.req.Query.Set(cmn.URLParamNotifyMe, p.si.ID())
-		nl := notifListenerBck{
-			notifListenerBase: notifListenerBase{srcs: c.smap.Tmap.Clone(), f: p.nlBckCb}, nlp: &nlp,
+// set-bucket-props (batch): { confirm existence -- begin -- apply props to all buckets -- metasync -- commit }
+// NOTE: applies the same props to a number of buckets in a single transaction - with a single
+// BMD update (and metasync) for all; the changes that require re-mirroring or EC-encoding
+// (and, therefore, waiting for the targets to finish) must be done one bucket at a time
+func (p *proxyrunner) setBucketPropsBatch(msg *cmn.ActionMsg, bcks []*cluster.Bck,
+	propsToUpdate cmn.BucketPropsToUpdate, dryRun bool) error {
+	var (
+		nmsg   = &cmn.ActionMsg{} // with []bpropsBatchEntry
+		pname  = p.si.String()
+		locked = make([]*cluster.NameLockPair, 0, len(bcks))
+	)
+	defer func() {
+		for _, nlp := range locked {
+			nlp.Unlock()
 		}
-		p.notifs.add(c.uuid, &nl)
-		unlockUpon = true // unlock upon receiving target notifications
+	}()
+	for _, bck := range bcks {
+		nlp := bck.GetNameLockPair()
+		if !nlp.TryLock() {
+			return cmn.NewErrorBucketIsBusy(bck.Bck, pname)
+		}
+		locked = append(locked, &nlp)
 	}
 
-	// 6. commit
-	c.req.Path = cmn.URLPath(c.path, cmn.ActCommit)
-	_ = p.bcastPost(bcastArgs{req: c.req, smap: c.smap, timeout: cmn.LongTimeout})
-
-	return nil
+	// msg{propsToUpdate} => nmsg{[]bpropsBatchEntry}
+	*nmsg = *msg
+	return p.runTxn(&txnSteps{
+		msg: nmsg,
+		bck: bcks[0],
+		check: func(bmd *bucketMD) error {
+			entries := make([]bpropsBatchEntry, 0, len(bcks))
+			for _, bck := range bcks {
+				bprops, present := bmd.Get(bck)
+				if !present {
+					return cmn.NewErrorBucketDoesNotExist(bck.Bck, pname)
+				}
+				bck.Props = bprops
+				nprops, _, reec, err := p.makeNprops(bck, propsToUpdate)
+				if err != nil {
+					return err
+				}
+				if reec || remirror(bprops, nprops) {
+					return fmt.Errorf("%s: cannot %q bucket %s: mirroring and EC can only be changed with %q",
+						pname, msg.Action, bck, cmn.ActSetBprops)
+				}
+				entries = append(entries, bpropsBatchEntry{Bck: bck.Bck, Props: nprops})
+			}
+			nmsg.Value = entries
+			return nil
+		},
+		updateBMD: func(clone *bucketMD) error {
+			for _, bck := range bcks {
+				bprops, present := clone.Get(bck)
+				cmn.Assert(present)
+				bck.Props = bprops
+				nprops, _, _, err := p.makeNprops(bck, propsToUpdate)
+				if err != nil {
+					return err
+				}
+				clone.set(bck, nprops)
+			}
+			return nil
+		},
+		dryRun: dryRun,
+	})
 }
 
 // rename-bucket: { confirm existence -- begin -- RebID -- metasync -- commit -- wait for rebalance and unlock }
-func (p *proxyrunner) renameBucket(bckFrom, bckTo *cluster.Bck, msg *cmn.ActionMsg) (err error) {
+func (p *proxyrunner) renameBucket(bckFrom, bckTo *cluster.Bck, msg *cmn.ActionMsg, job *notifListenerJob) (err error) {
 	var (
-		c          *txnClientCtx
 		nlpFrom    = bckFrom.GetNameLockPair()
 		nlpTo      = bckTo.GetNameLockPair()
 		nmsg       = &cmn.ActionMsg{} // + bckTo
@@ -345,96 +553,255 @@ func (p *proxyrunner) renameBucket(bckFrom, bckTo *cluster.Bck, msg *cmn.ActionM
 		}
 	}()
 
-	// 1. confirm existence & non-existence
-	p.owner.bmd.Lock()
-	bmd := p.owner.bmd.get()
-	if _, present := bmd.Get(bckFrom); !present {
-		p.owner.bmd.Unlock()
-		return cmn.NewErrorBucketDoesNotExist(bckFrom.Bck, pname)
+	// msg{} => nmsg{bckTo}
+	*nmsg = *msg
+	nmsg.Value = bckTo.Bck
+	return p.runTxn(&txnSteps{
+		msg: nmsg,
+		bck: bckFrom,
+		check: func(bmd *bucketMD) error {
+			if _, present := bmd.Get(bckFrom); !present {
+				return cmn.NewErrorBucketDoesNotExist(bckFrom.Bck, pname)
+			}
+			if _, present := bmd.Get(bckTo); present {
+				return cmn.NewErrorBucketAlreadyExists(bckTo.Bck, pname)
+			}
+			return nil
+		},
+		updateBMD: func(clone *bucketMD) error {
+			bprops, present := clone.Get(bckFrom)
+			cmn.Assert(present)
+
+			bckFrom.Props = bprops.Clone()
+			bckTo.Props = bprops.Clone()
+
+			added := clone.add(bckTo, bckTo.Props)
+			cmn.Assert(added)
+			bckFrom.Props.Renamed = cmn.ActRenameLB
+			clone.set(bckFrom, bckFrom.Props)
+			return nil
+		},
+		// commit along with the new RMD, and start rebalance and resilver
+		commit: func(c *txnClientCtx) (err error) {
+			var wg *sync.WaitGroup
+			_ = p.owner.rmd.modify(
+				func(clone *rebMD) {
+					clone.inc()
+					clone.Resilver = true
+				},
+				func(clone *rebMD) {
+					c.msg.RMDVersion = clone.version()
+
+					// commit
+					unlockUpon = true
+					c.body = cmn.MustMarshal(c.msg)
+					c.req.Body = c.body
+					err = p.txnCommit(c, 0, 0)
+
+					// start waiting for `finished` notifications
+					c.req.Query.Set(cmn.URLParamNotifyMe, p.si.ID())
+					nl := notifListenerFromTo{
+						notifListenerBase: notifListenerBase{srcs: c.smap.Tmap.Clone(), f: p.nlBckFromToCb},
+						nlpFrom:           &nlpFrom,
+						nlpTo:             &nlpTo,
+						job:               job,
+					}
+					if job != nil {
+						job.chained = true
+					}
+					rebUUID := strconv.FormatInt(clone.version(), 10)
+					p.notifs.add(rebUUID, &nl)
+
+					// start rebalance and resilver
+					wg = p.metasyncer.sync(revsPair{clone, c.msg})
+				},
+			)
+			wg.Wait()
+			return
+		},
+	})
+}
+
+// rename-cloud-bucket: { confirm existence -- begin -- add destination and make source read-only --
+// metasync -- start waiting for copy-done -- commit } and, upon completion, evict the source
+// NOTE: the (cloud) bucket itself cannot be renamed - the destination is an ais bucket
+// backed by the source (see cmn.BucketProps.BackendBck) that receives a copy of the
+// source's cached content; in the meantime, the source is read-only (see nlBckRenameCloud)
+func (p *proxyrunner) renameCloudBucket(bckFrom, bckTo *cluster.Bck, msg *cmn.ActionMsg,
+	job *notifListenerJob) (err error) {
+	var (
+		nmsg       = &cmn.ActionMsg{} // copybck + bckTo
+		nlpFrom    = bckFrom.GetNameLockPair()
+		nlpTo      = bckTo.GetNameLockPair()
+		pname      = p.si.String()
+		access     cmn.AccessAttrs // source access prior to the migration
+		unlockUpon bool
+	)
+	if !nlpFrom.TryRLock() {
+		return cmn.NewErrorBucketIsBusy(bckFrom.Bck, pname)
 	}
-	if _, present := bmd.Get(bckTo); present {
-		p.owner.bmd.Unlock()
-		return cmn.NewErrorBucketAlreadyExists(bckTo.Bck, pname)
+	if !nlpTo.TryLock() {
+		nlpFrom.RUnlock()
+		return cmn.NewErrorBucketIsBusy(bckTo.Bck, pname)
 	}
-	p.owner.bmd.Unlock()
+	defer func() {
+		if !unlockUpon {
+			nlpTo.Unlock()
+			nlpFrom.RUnlock()
+		}
+	}()
 
-	// msg{} => nmsg{bckTo} and prep context(nmsg)
+	// msg{} => nmsg{bckTo}; targets simply copy the bucket
 	*nmsg = *msg
+	nmsg.Action = cmn.ActCopyBucket
 	nmsg.Value = bckTo.Bck
-	c = p.prepTxnClient(nmsg, bckFrom)
+	return p.runTxn(&txnSteps{
+		msg: nmsg,
+		bck: bckFrom,
+		check: func(bmd *bucketMD) error {
+			bprops, present := bmd.Get(bckFrom)
+			if !present {
+				return cmn.NewErrorBucketDoesNotExist(bckFrom.Bck, pname)
+			}
+			if bprops.Renamed != "" {
+				return fmt.Errorf("%s: bucket %s is being renamed", pname, bckFrom)
+			}
+			if _, present := bmd.Get(bckTo); present {
+				return cmn.NewErrorBucketAlreadyExists(bckTo.Bck, pname)
+			}
+			return nil
+		},
+		updateBMD: func(clone *bucketMD) error {
+			bprops, present := clone.Get(bckFrom)
+			cmn.Assert(present)
+			access = bprops.Access
+
+			bckTo.Props = bprops.Clone()
+			bckTo.Props.BackendBck = bckFrom.Bck
+			added := clone.add(bckTo, bckTo.Props)
+			cmn.Assert(added)
+
+			bckFrom.Props = bprops.Clone()
+			bckFrom.Props.Access &= cmn.ReadOnlyAccess()
+			bckFrom.Props.Renamed = cmn.ActRenameLB
+			clone.set(bckFrom, bckFrom.Props)
+			return nil
+		},
+		// start waiting for `finished` notifications
+		preCommit: func(c *txnClientCtx) {
+			c.req.Query.Set(cmn.URLParamNotifyMe, p.si.ID())
+			nl := notifListenerFromTo{
+				notifListenerBase: notifListenerBase{srcs: c.smap.Tmap.Clone()},
+				nlpFrom:           &nlpFrom,
+				nlpTo:             &nlpTo,
+				job:               job,
+			}
+			nl.f = func(n notifListener, msg interface{}, err error) {
+				p.nlBckRenameCloud(n, msg, err, bckFrom, access)
+			}
+			if job != nil {
+				job.chained = true
+			}
+			p.notifs.add(c.uuid, &nl)
+			unlockUpon = true
+		},
+	})
+}
 
-	// 2. begin
-	results := p.bcastPost(bcastArgs{req: c.req, smap: c.smap})
-	for res := range results {
-		if res.err != nil {
-			// abort
-			c.req.Path = cmn.URLPath(c.path, cmn.ActAbort)
-			_ = p.bcastPost(bcastArgs{req: c.req, smap: c.smap})
-			return res.err
+// swap-buckets: { check existence -- begin -- swap BMD entries -- metasync -- commit [-- rebalance] --
+// wait for the objects to move and unlock }
+// NOTE: the buckets exchange their content along with the props (and BIDs); with a single
+// target and a single mountpath nothing needs to move - otherwise, the objects that HRW
+// now places elsewhere get moved by (global) rebalance and (local) resilver
+func (p *proxyrunner) swapBuckets(bckA, bckB *cluster.Bck, msg *cmn.ActionMsg) (err error) {
+	var (
+		nlpA       = bckA.GetNameLockPair()
+		nlpB       = bckB.GetNameLockPair()
+		nmsg       = &cmn.ActionMsg{} // + bckB
+		pname      = p.si.String()
+		reb        = p.owner.smap.get().CountTargets() > 1
+		unlockUpon bool
+	)
+	if reb {
+		if err := p.canStartRebalance(); err != nil {
+			return fmt.Errorf("%s: buckets cannot be swapped: %w", p.si, err)
 		}
 	}
+	if !nlpA.TryLock() {
+		return cmn.NewErrorBucketIsBusy(bckA.Bck, pname)
+	}
+	if !nlpB.TryLock() {
+		nlpA.Unlock()
+		return cmn.NewErrorBucketIsBusy(bckB.Bck, pname)
+	}
+	defer func() {
+		if !unlockUpon {
+			nlpB.Unlock()
+			nlpA.Unlock()
+		}
+	}()
 
-	// 3. lock and update BMD locally
-	p.owner.bmd.Lock()
-	cloneBMD := p.owner.bmd.get().clone()
-	bprops, present := cloneBMD.Get(bckFrom)
-	cmn.Assert(present)
-
-	bckFrom.Props = bprops.Clone()
-	bckTo.Props = bprops.Clone()
-
-	added := cloneBMD.add(bckTo, bckTo.Props)
-	cmn.Assert(added)
-	bckFrom.Props.Renamed = cmn.ActRenameLB
-	cloneBMD.set(bckFrom, bckFrom.Props)
-
-	p.owner.bmd.put(cloneBMD)
-
-	// 4. metasync updated BMD; unlock BMD
-	c.msg.BMDVersion = cloneBMD.version()
-	wg := p.metasyncer.sync(revsPair{cloneBMD, c.msg})
-	p.owner.bmd.Unlock()
-
-	wg.Wait()
-
-	_ = p.owner.rmd.modify(
-		func(clone *rebMD) {
-			clone.inc()
-			clone.Resilver = true
+	// msg{} => nmsg{bckB}
+	*nmsg = *msg
+	nmsg.Value = bckB.Bck
+	steps := &txnSteps{
+		msg: nmsg,
+		bck: bckA,
+		check: func(bmd *bucketMD) error {
+			for _, bck := range []*cluster.Bck{bckA, bckB} {
+				bprops, present := bmd.Get(bck)
+				if !present {
+					return cmn.NewErrorBucketDoesNotExist(bck.Bck, pname)
+				}
+				if bprops.Renamed != "" {
+					return fmt.Errorf("%s: bucket %s is being renamed", pname, bck)
+				}
+			}
+			return nil
 		},
-		func(clone *rebMD) {
-			c.msg.RMDVersion = clone.version()
-
-			// 5. commit
-			unlockUpon = true
-			c.req.Path = cmn.URLPath(c.path, cmn.ActCommit)
-			c.body = cmn.MustMarshal(c.msg)
-			c.req.Body = c.body
-
-			_ = p.bcastPost(bcastArgs{req: c.req, smap: c.smap, timeout: cmn.LongTimeout})
-
-			// 6. start waiting for `finished` notifications
+		updateBMD: func(clone *bucketMD) error {
+			clone.swap(bckA, bckB)
+			return nil
+		},
+		// start waiting for `finished` notifications (see BckSwap xaction)
+		preCommit: func(c *txnClientCtx) {
 			c.req.Query.Set(cmn.URLParamNotifyMe, p.si.ID())
 			nl := notifListenerFromTo{
 				notifListenerBase: notifListenerBase{srcs: c.smap.Tmap.Clone(), f: p.nlBckFromToCb},
-				nlpFrom:           &nlpFrom,
-				nlpTo:             &nlpTo,
+				nlpFrom:           &nlpA,
+				nlpTo:             &nlpB,
 			}
-			rebUUID := strconv.FormatInt(clone.version(), 10)
-			p.notifs.add(rebUUID, &nl)
-
-			// 7. start rebalance and resilver
-			wg = p.metasyncer.sync(revsPair{clone, c.msg})
+			p.notifs.add(c.uuid, &nl)
+			unlockUpon = true
 		},
-	)
-	wg.Wait()
-	return
+	}
+	if reb {
+		// commit along with the new RMD, and start rebalance and resilver
+		steps.commit = func(c *txnClientCtx) (err error) {
+			var wg *sync.WaitGroup
+			_ = p.owner.rmd.modify(
+				func(clone *rebMD) {
+					clone.inc()
+					clone.Resilver = true
+				},
+				func(clone *rebMD) {
+					c.msg.RMDVersion = clone.version()
+					c.body = cmn.MustMarshal(c.msg)
+					c.req.Body = c.body
+					err = p.txnCommit(c, 0, 0)
+					wg = p.metasyncer.sync(revsPair{clone, c.msg})
+				},
+			)
+			wg.Wait()
+			return
+		}
+	}
+	return p.runTxn(steps)
 }
 
 // copy-bucket: { confirm existence -- begin -- conditional metasync -- start waiting for copy-done -- commit }
-func (p *proxyrunner) copyBucket(bckFrom, bckTo *cluster.Bck, msg *cmn.ActionMsg) (err error) {
+func (p *proxyrunner) copyBucket(bckFrom, bckTo *cluster.Bck, msg *cmn.ActionMsg, job *notifListenerJob) (err error) {
 	var (
-		c          *txnClientCtx
 		nmsg       = &cmn.ActionMsg{} // + bckTo
 		nlpFrom    = bckFrom.GetNameLockPair()
 		nlpTo      = bckTo.GetNameLockPair()
@@ -455,72 +822,46 @@ func (p *proxyrunner) copyBucket(bckFrom, bckTo *cluster.Bck, msg *cmn.ActionMsg
 		}
 	}()
 
-	// 1. confirm existence
-	p.owner.bmd.Lock()
-	bmd := p.owner.bmd.get()
-	if _, present := bmd.Get(bckFrom); !present {
-		p.owner.bmd.Unlock()
-		return cmn.NewErrorBucketDoesNotExist(bckFrom.Bck, pname)
-	}
-	p.owner.bmd.Unlock()
-
-	// msg{} => nmsg{bckTo} and prep context(nmsg)
+	// msg{} => nmsg{bckTo}
 	*nmsg = *msg
 	nmsg.Value = bckTo.Bck
-	c = p.prepTxnClient(nmsg, bckFrom)
-
-	// 2. begin
-	results := p.bcastPost(bcastArgs{req: c.req, smap: c.smap})
-	for res := range results {
-		if res.err != nil {
-			// abort
-			c.req.Path = cmn.URLPath(c.path, cmn.ActAbort)
-			_ = p.bcastPost(bcastArgs{req: c.req, smap: c.smap})
-			return res.err
-		}
-	}
-
-	// 3. lock and update BMD locally
-	p.owner.bmd.Lock()
-	clone := p.owner.bmd.get().clone()
-	bprops, present := clone.Get(bckFrom)
-	cmn.Assert(present)
-
-	// create destination bucket but only if it doesn't exist
-	if _, present = clone.Get(bckTo); !present {
-		bckFrom.Props = bprops.Clone()
-		bckTo.Props = bprops.Clone()
-		added := clone.add(bckTo, bckTo.Props)
-		cmn.Assert(added)
-		p.owner.bmd.put(clone)
-
-		// 4. metasync updated BMD; unlock BMD
-		c.msg.BMDVersion = clone.version()
-		wg := p.metasyncer.sync(revsPair{clone, c.msg})
-		p.owner.bmd.Unlock()
-
-		wg.Wait()
-
-		c.req.Query.Set(cmn.URLParamWaitMetasync, "true")
-	} else {
-		p.owner.bmd.Unlock()
-	}
-
-	// 5. start waiting for `finished` notifications
-	c.req.Query.Set(cmn.URLParamNotifyMe, p.si.ID())
-	nl := notifListenerFromTo{
-		notifListenerBase: notifListenerBase{srcs: c.smap.Tmap.Clone(), f: p.nlBckCopy},
-		nlpFrom:           &nlpFrom,
-		nlpTo:             &nlpTo,
-	}
-	p.notifs.add(c.uuid, &nl)
-
-	// 6. commit
-	unlockUpon = true
-	c.req.Path = cmn.URLPath(c.path, cmn.ActCommit)
-	_ = p.bcastPost(bcastArgs{req: c.req, smap: c.smap, timeout: cmn.LongTimeout})
-
-	return
+	return p.runTxn(&txnSteps{
+		msg: nmsg,
+		bck: bckFrom,
+		check: func(bmd *bucketMD) error {
+			if _, present := bmd.Get(bckFrom); !present {
+				return cmn.NewErrorBucketDoesNotExist(bckFrom.Bck, pname)
+			}
+			return nil
+		},
+		// create destination bucket but only if it doesn't exist
+		updateBMD: func(clone *bucketMD) error {
+			bprops, present := clone.Get(bckFrom)
+			cmn.Assert(present)
+			if _, present = clone.Get(bckTo); !present {
+				bckFrom.Props = bprops.Clone()
+				bckTo.Props = bprops.Clone()
+				added := clone.add(bckTo, bckTo.Props)
+				cmn.Assert(added)
+			}
+			return nil
+		},
+		// start waiting for `finished` notifications
+		preCommit: func(c *txnClientCtx) {
+			c.req.Query.Set(cmn.URLParamNotifyMe, p.si.ID())
+			nl := notifListenerFromTo{
+				notifListenerBase: notifListenerBase{srcs: c.smap.Tmap.Clone(), f: p.nlBckCopy},
+				nlpFrom:           &nlpFrom,
+				nlpTo:             &nlpTo,
+				job:               job,
+			}
+			if job != nil {
+				job.chained = true
+			}
+			p.notifs.add(c.uuid, &nl)
+			unlockUpon = true
+		},
+	})
 }
 
 func parseECConf(value interface{}) (*cmn.ECConfToUpdate, error) {
@@ -539,10 +880,9 @@ func parseECConf(value interface{}) (*cmn.ECConfToUpdate, error) {
 }
 
 // ec-encode: { confirm existence -- begin -- update locally -- metasync -- commit }
-func (p *proxyrunner) ecEncode(bck *cluster.Bck, msg *cmn.ActionMsg) error {
+func (p *proxyrunner) ecEncode(bck *cluster.Bck, msg *cmn.ActionMsg, dryRun bool, job *notifListenerJob) error {
 	var (
 		pname       = p.si.String()
-		c           = p.prepTxnClient(msg, bck)
 		nlp         = bck.GetNameLockPair()
 		ecConf, err = parseECConf(msg.Value)
 		unlockUpon  bool
@@ -564,77 +904,59 @@ func (p *proxyrunner) ecEncode(bck *cluster.Bck, msg *cmn.ActionMsg) error {
 		}
 	}()
 
-	// 1. confirm existence
-	p.owner.bmd.Lock()
-	bmd := p.owner.bmd.get()
-	props, present := bmd.Get(bck)
-	if !present {
-		p.owner.bmd.Unlock()
-		return cmn.NewErrorBucketDoesNotExist(bck.Bck, pname)
-	}
-	if props.EC.Enabled {
-		// Changing data or parity slice count on the fly is unsupported yet
-		p.owner.bmd.Unlock()
-		return fmt.Errorf("%s: EC is already enabled for bucket %s", p.si, bck)
-	}
-	p.owner.bmd.Unlock()
-
-	// 2. begin
-	results := p.bcastPost(bcastArgs{req: c.req, smap: c.smap})
-	for res := range results {
-		if res.err != nil {
-			// abort
-			c.req.Path = cmn.URLPath(c.path, cmn.ActAbort)
-			_ = p.bcastPost(bcastArgs{req: c.req, smap: c.smap})
-			return res.err
-		}
-	}
-
-	// 3. lock & update BMD locally
-	p.owner.bmd.Lock()
-	clone := p.owner.bmd.get().clone()
-	bprops, present := clone.Get(bck)
-	cmn.Assert(present)
-	nprops := bprops.Clone()
-	nprops.EC.Enabled = true
-	nprops.EC.DataSlices = *ecConf.DataSlices
-	nprops.EC.ParitySlices = *ecConf.ParitySlices
-
-	clone.set(bck, nprops)
-	p.owner.bmd.put(clone)
-
-	// 4. metasync updated BMD; unlock BMD
-	c.msg.BMDVersion = clone.version()
-	wg := p.metasyncer.sync(revsPair{clone, c.msg})
-	p.owner.bmd.Unlock()
+	return p.runTxn(&txnSteps{
+		msg: msg,
+		bck: bck,
+		check: func(bmd *bucketMD) error {
+			props, present := bmd.Get(bck)
+			if !present {
+				return cmn.NewErrorBucketDoesNotExist(bck.Bck, pname)
+			}
+			if props.EC.Enabled {
+				// Changing data or parity slice count on the fly is unsupported yet
+				return fmt.Errorf("%s: EC is already enabled for bucket %s", p.si, bck)
+			}
+			return nil
+		},
+		updateBMD: func(clone *bucketMD) error {
+			bprops, present := clone.Get(bck)
+			cmn.Assert(present)
+			nprops := bprops.Clone()
+			nprops.EC.Enabled = true
+			nprops.EC.DataSlices = *ecConf.DataSlices
+			nprops.EC.ParitySlices = *ecConf.ParitySlices
+			if err := nprops.Validate(p.owner.smap.get().CountTargets()); err != nil {
+				return err
+			}
+			clone.set(bck, nprops)
+			return nil
+		},
+		preCommit: func(c *txnClientCtx) {
+			p.notifyBckDone(c, &nlp, job)
+			unlockUpon = true
+		},
+		dryRun:        dryRun,
+		commitTimeout: cmn.GCO.Get().Timeout.CplaneOperation,
+	})
+}
 
-	wg.Wait()
+/////////////////////////////
+// rollback & misc helpers //
+/////////////////////////////
 
-	// 5. start waiting for `finished` notifications
+// start waiting for `finished` notifications; the listener unlocks the bucket
+// and finishes the job (if any)
+func (p *proxyrunner) notifyBckDone(c *txnClientCtx, nlp *cluster.NameLockPair, job *notifListenerJob) {
 	c.req.Query.Set(cmn.URLParamNotifyMe, p.si.ID())
 	nl := notifListenerBck{
-		notifListenerBase: notifListenerBase{srcs: c.smap.Tmap.Clone(), f: p.nlBckCb}, nlp: &nlp,
+		notifListenerBase: notifListenerBase{srcs: c.smap.Tmap.Clone(), f: p.nlBckCb}, nlp: nlp, job: job,
 	}
-	p.notifs.add(c.uuid, &nl)
-
-	// 6. commit
-	unlockUpon = true
-	config := cmn.GCO.Get()
-	c.req.Path = cmn.URLPath(c.path, cmn.ActCommit)
-	results = p.bcastPost(bcastArgs{req: c.req, smap: c.smap, timeout: config.Timeout.CplaneOperation})
-	for res := range results {
-		if res.err != nil {
-			glog.Error(res.err)
-			return res.err
-		}
+	if job != nil {
+		job.chained = true
 	}
-	return nil
+	p.notifs.add(c.uuid, &nl)
 }
 
-/////////////////////////////
-// rollback & misc helpers //
-/////////////////////////////
-
 // txn client context
 func (p *proxyrunner) prepTxnClient(msg *cmn.ActionMsg, bck *cluster.Bck) *txnClientCtx {
 	var (
@@ -751,6 +1073,7 @@ func (p *proxyrunner) nlBckCb(n notifListener, msg interface{}, err error) {
 	nl := n.(*notifListenerBck)
 	nl.nlp.Unlock()
 	p._logNotifDone(n, msg, err)
+	p.nlJobDone(nl.job, &nl.notifListenerBase, err)
 }
 
 func (p *proxyrunner) nlBckCopy(n notifListener, msg interface{}, err error) {
@@ -758,6 +1081,7 @@ func (p *proxyrunner) nlBckCopy(n notifListener, msg interface{}, err error) {
 	nl.nlpTo.Unlock()
 	nl.nlpFrom.RUnlock()
 	p._logNotifDone(n, msg, err)
+	p.nlJobDone(nl.job, &nl.notifListenerBase, err)
 }
 
 func (p *proxyrunner) nlBckFromToCb(n notifListener, msg interface{}, err error) {
@@ -765,4 +1089,119 @@ func (p *proxyrunner) nlBckFromToCb(n notifListener, msg interface{}, err error)
 	nl.nlpTo.Unlock()
 	nl.nlpFrom.Unlock()
 	p._logNotifDone(n, msg, err)
+	p.nlJobDone(nl.job, &nl.notifListenerBase, err)
+}
+
+// upon copying the cached content: evict the renamed cloud bucket or, if the copying
+// has failed, make it writable again (keeping the destination as is)
+func (p *proxyrunner) nlBckRenameCloud(n notifListener, msg interface{}, err error,
+	bckFrom *cluster.Bck, access cmn.AccessAttrs) {
+	nl := n.(*notifListenerFromTo)
+	nl.nlpTo.Unlock()
+	nl.nlpFrom.RUnlock()
+	p._logNotifDone(n, msg, err)
+	if err == nil {
+		for _, e := range nl.errs {
+			err = e
+			break
+		}
+	}
+	go func() {
+		if err == nil {
+			evictMsg := &cmn.ActionMsg{Action: cmn.ActEvictCB}
+			if erd := p.destroyBucket(evictMsg, bckFrom); erd != nil {
+				glog.Errorf("%s: failed to evict renamed %s: %v", p.si, bckFrom, erd)
+			}
+		} else {
+			p.owner.bmd.Lock()
+			clone := p.owner.bmd.get().clone()
+			if bprops, present := clone.Get(bckFrom); present && bprops.Renamed != "" {
+				nprops := bprops.Clone()
+				nprops.Access, nprops.Renamed = access, ""
+				clone.set(bckFrom, nprops)
+				p.owner.bmd.put(clone)
+				_ = p.metasyncer.sync(revsPair{clone, p.newAisMsgStr(cmn.ActRenameLB, nil, clone)})
+			}
+			p.owner.bmd.Unlock()
+		}
+		p.nlJobDone(nl.job, &nl.notifListenerBase, err)
+	}()
+}
+
+// finish the job (if any) that has been waiting for the operation to complete
+func (p *proxyrunner) nlJobDone(job *notifListenerJob, nlb *notifListenerBase, err error) {
+	if job == nil {
+		return
+	}
+	if err == nil {
+		err = nlb.finErr()
+	}
+	p.notifs.jobDone(job, err)
+}
+
+// whether to run a given bucket operation asynchronously (see startJob)
+func isAsyncReq(r *http.Request) bool {
+	query := r.URL.Query()
+	return cmn.IsParseBool(query.Get(cmn.URLParamAsync)) || query.Get(cmn.URLParamWaitFor) != ""
+}
+
+// run a given bucket operation asynchronously (see cmn.URLParamAsync) and
+// respond with the UUID of the job right away; with cmn.URLParamWaitFor, the
+// operation starts only upon successful completion of the xaction (or job)
+// with the given UUID - and fails without starting otherwise (see
+// notifs.waitFor); NOTE: the waiting operations do not survive restart
+func (p *proxyrunner) startJob(w http.ResponseWriter, r *http.Request, action string, bck *cluster.Bck,
+	run func(job *notifListenerJob) error) {
+	var (
+		job     = newJob(p, action, bck)
+		uuid    = cmn.GenUUID()
+		waitFor = r.URL.Query().Get(cmn.URLParamWaitFor)
+	)
+	job.waitFor = waitFor
+	p.notifs.add(uuid, job)
+	start := func() {
+		// (chained) jobs finish upon completion of the operation - see nlJobDone
+		if err := run(job); err != nil || !job.chained {
+			p.notifs.jobDone(job, err)
+		}
+	}
+	if waitFor == "" {
+		go start()
+		w.Write([]byte(uuid))
+		return
+	}
+	err, status := p.notifs.waitFor(waitFor, func(err error) {
+		if err != nil {
+			p.notifs.jobDone(job, fmt.Errorf("%s %s not started: %q failed: %v", action, bck, waitFor, err))
+			return
+		}
+		start()
+	})
+	if err != nil {
+		p.notifs.del(job)
+		p.invalmsghdlrErr(w, r, err, status)
+		return
+	}
+	w.Write([]byte(uuid))
+}
+
+// verb /v1/txn/<uuid>
+func (p *proxyrunner) txnHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		cmn.InvalidHandlerWithMsg(w, r, "invalid method for /txn path")
+		return
+	}
+	apiItems, err := p.checkRESTItems(w, r, 1, false, cmn.Version, cmn.Txn)
+	if err != nil {
+		return
+	}
+	if p.forwardCP(w, r, nil, "job status", nil) {
+		return
+	}
+	job := p.notifs.getJob(apiItems[0])
+	if job == nil {
+		p.invalmsghdlrstatusf(w, r, http.StatusNotFound, "%s: job %q not found", p.si, apiItems[0])
+		return
+	}
+	p.writeJSON(w, r, job.status(), "job-status")
 }
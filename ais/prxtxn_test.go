@@ -0,0 +1,477 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+type (
+	// txnTargetMock records the txn requests it receives and fails the
+	// ones it is told to (txn action => HTTP status); reports its orphaned
+	// transactions, if any (see recoverTxns)
+	txnTargetMock struct {
+		mtx      sync.Mutex
+		actions  []string
+		queries  map[string]url.Values
+		fail     map[string]int
+		byBucket map[string]string // bucket => last action
+		orphans  []*txnRecord
+	}
+	txnTest struct {
+		primary *proxyrunner
+		targets []*txnTargetMock
+		servers []*httptest.Server
+	}
+)
+
+func (mock *txnTargetMock) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, cmn.URLPath(cmn.Version, cmn.Txn)) {
+		return // metasync, etc.
+	}
+	if r.Method == http.MethodGet {
+		mock.mtx.Lock()
+		w.Write(cmn.MustMarshal(mock.orphans))
+		mock.mtx.Unlock()
+		return
+	}
+	action := path.Base(r.URL.Path)
+	mock.mtx.Lock()
+	mock.actions = append(mock.actions, action)
+	mock.queries[action] = r.URL.Query()
+	mock.byBucket[path.Base(path.Dir(r.URL.Path))] = action
+	status := mock.fail[action]
+	mock.mtx.Unlock()
+	if status != 0 {
+		http.Error(w, "failed to "+action, status)
+	}
+}
+
+func (mock *txnTargetMock) received() string {
+	mock.mtx.Lock()
+	defer mock.mtx.Unlock()
+	return strings.Join(mock.actions, ",")
+}
+
+func newTxnTest(cnt int) *txnTest {
+	tt := &txnTest{primary: newPrimary()}
+	tt.primary.metasyncer = testSyncer(tt.primary)
+	go tt.primary.metasyncer.Run()
+
+	clone := tt.primary.owner.smap.get().clone()
+	for i := 0; i < cnt; i++ {
+		var (
+			id   = "target" + string(rune('A'+i))
+			mock = &txnTargetMock{
+				queries:  make(map[string]url.Values),
+				fail:     make(map[string]int),
+				byBucket: make(map[string]string),
+			}
+			ts = httptest.NewServer(mock)
+		)
+		clone.addTarget(newSnode(id, httpProto, cmn.Target, serverTCPAddr(ts.URL), &net.TCPAddr{}, &net.TCPAddr{}))
+		tt.targets = append(tt.targets, mock)
+		tt.servers = append(tt.servers, ts)
+	}
+	tt.primary.owner.smap.put(clone)
+	return tt
+}
+
+func (tt *txnTest) cleanup() {
+	tt.primary.metasyncer.Stop(nil)
+	for _, ts := range tt.servers {
+		ts.Close()
+	}
+}
+
+// adds a bucket to BMD, and keeps track of the rollback
+func (tt *txnTest) steps(bck *cluster.Bck, rolledBack *bool) *txnSteps {
+	return &txnSteps{
+		msg: &cmn.ActionMsg{Action: cmn.ActCreateLB},
+		bck: bck,
+		updateBMD: func(clone *bucketMD) error {
+			clone.add(bck, cmn.DefaultBucketProps())
+			return nil
+		},
+		rollback: func() { *rolledBack = true },
+	}
+}
+
+func (tt *txnTest) journaled() int {
+	tt.primary.txnJournal.mtx.Lock()
+	defer tt.primary.txnJournal.mtx.Unlock()
+	return len(tt.primary.txnJournal.Records)
+}
+
+func TestTxnCommit(t *testing.T) {
+	tt := newTxnTest(2)
+	defer tt.cleanup()
+
+	var (
+		rolledBack bool
+		bck        = cluster.NewBck("txn-commit", cmn.ProviderAIS, cmn.NsGlobal)
+		ver        = tt.primary.owner.bmd.get().version()
+	)
+	err := tt.primary.runTxn(tt.steps(bck, &rolledBack))
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, !rolledBack, "unexpected rollback")
+	tassert.Errorf(t, tt.primary.owner.bmd.get().version() == ver+1, "expected BMD v%d", ver+1)
+	tassert.Errorf(t, tt.journaled() == 0, "expected txn to be removed from the journal")
+	for _, mock := range tt.targets {
+		tassert.Errorf(t, mock.received() == "begin,commit", "unexpected %q", mock.received())
+		// updated BMD => commit waits for metasync
+		tassert.Errorf(t, mock.queries[cmn.ActCommit].Get(cmn.URLParamWaitMetasync) == "true",
+			"expected commit to wait for metasync")
+	}
+}
+
+func TestTxnBeginAbort(t *testing.T) {
+	tt := newTxnTest(2)
+	defer tt.cleanup()
+
+	var (
+		rolledBack bool
+		bck        = cluster.NewBck("txn-begin", cmn.ProviderAIS, cmn.NsGlobal)
+		ver        = tt.primary.owner.bmd.get().version()
+	)
+	tt.targets[1].fail[cmn.ActBegin] = http.StatusBadRequest
+	err := tt.primary.runTxn(tt.steps(bck, &rolledBack))
+	tassert.Fatalf(t, err != nil && strings.Contains(err.Error(), "failed to begin"), "expected begin error, got %v", err)
+	tassert.Errorf(t, !rolledBack, "unexpected rollback")
+	tassert.Errorf(t, tt.primary.owner.bmd.get().version() == ver, "BMD must not be updated")
+	tassert.Errorf(t, tt.journaled() == 0, "expected txn to be removed from the journal")
+	// all targets, including the ones that have begun, abort
+	for _, mock := range tt.targets {
+		tassert.Errorf(t, mock.received() == "begin,abort", "unexpected %q", mock.received())
+	}
+}
+
+func TestTxnUpdateBMDAbort(t *testing.T) {
+	tt := newTxnTest(2)
+	defer tt.cleanup()
+
+	var (
+		rolledBack bool
+		bck        = cluster.NewBck("txn-update", cmn.ProviderAIS, cmn.NsGlobal)
+		ver        = tt.primary.owner.bmd.get().version()
+		steps      = tt.steps(bck, &rolledBack)
+		errUpdate  = errors.New("failed to update")
+	)
+	steps.updateBMD = func(clone *bucketMD) error { return errUpdate }
+	err := tt.primary.runTxn(steps)
+	tassert.Fatalf(t, err == errUpdate, "expected %v, got %v", errUpdate, err)
+	tassert.Errorf(t, !rolledBack, "unexpected rollback")
+	tassert.Errorf(t, tt.primary.owner.bmd.get().version() == ver, "BMD must not be updated")
+	tassert.Errorf(t, tt.journaled() == 0, "expected txn to be removed from the journal")
+	for _, mock := range tt.targets {
+		tassert.Errorf(t, mock.received() == "begin,abort", "unexpected %q", mock.received())
+	}
+}
+
+func TestTxnDryRun(t *testing.T) {
+	tt := newTxnTest(2)
+	defer tt.cleanup()
+
+	var (
+		rolledBack bool
+		bck        = cluster.NewBck("txn-dry-run", cmn.ProviderAIS, cmn.NsGlobal)
+		ver        = tt.primary.owner.bmd.get().version()
+		steps      = tt.steps(bck, &rolledBack)
+	)
+	steps.dryRun = true
+	err := tt.primary.runTxn(steps)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, tt.primary.owner.bmd.get().version() == ver, "BMD must not be updated")
+	tassert.Errorf(t, tt.journaled() == 0, "dry-run must not be journaled")
+	for _, mock := range tt.targets {
+		tassert.Errorf(t, mock.received() == "begin,abort", "unexpected %q", mock.received())
+		tassert.Errorf(t, mock.queries[cmn.ActBegin].Get(cmn.URLParamDryRun) == "true",
+			"expected begin to validate only")
+	}
+
+	// validation fails on a target
+	tt.targets[0].fail[cmn.ActBegin] = http.StatusBadRequest
+	err = tt.primary.runTxn(steps)
+	tassert.Errorf(t, err != nil && strings.Contains(err.Error(), "failed to begin"), "expected begin error, got %v", err)
+	tassert.Errorf(t, tt.primary.owner.bmd.get().version() == ver, "BMD must not be updated")
+}
+
+func TestTxnCommitFailure(t *testing.T) {
+	tt := newTxnTest(2)
+	defer tt.cleanup()
+
+	var (
+		rolledBack bool
+		bck        = cluster.NewBck("txn-commit-fail", cmn.ProviderAIS, cmn.NsGlobal)
+	)
+	tt.targets[0].fail[cmn.ActCommit] = http.StatusBadRequest // not retriable
+	err := tt.primary.runTxn(tt.steps(bck, &rolledBack))
+	tassert.Fatalf(t, err != nil && strings.Contains(err.Error(), "failed to commit"),
+		"expected commit error, got %v", err)
+	tassert.Errorf(t, rolledBack, "expected rollback")
+	tassert.Errorf(t, tt.journaled() == 0, "expected txn to be removed from the journal")
+	// commit must go thru: no abort
+	for _, mock := range tt.targets {
+		tassert.Errorf(t, mock.received() == "begin,commit", "unexpected %q", mock.received())
+	}
+}
+
+func TestTxnCustomCommitFailure(t *testing.T) {
+	tt := newTxnTest(1)
+	defer tt.cleanup()
+
+	var (
+		rolledBack bool
+		bck        = cluster.NewBck("txn-custom-commit", cmn.ProviderAIS, cmn.NsGlobal)
+		steps      = tt.steps(bck, &rolledBack)
+		errCommit  = errors.New("failed to commit")
+	)
+	steps.commit = func(c *txnClientCtx) error { return errCommit }
+	err := tt.primary.runTxn(steps)
+	tassert.Fatalf(t, err == errCommit, "expected %v, got %v", errCommit, err)
+	tassert.Errorf(t, rolledBack, "expected rollback")
+	tassert.Errorf(t, tt.journaled() == 0, "expected txn to be removed from the journal")
+	tassert.Errorf(t, tt.targets[0].received() == "begin", "unexpected %q", tt.targets[0].received())
+}
+
+// swap-buckets holds both buckets locked until the targets notify that the objects are in place
+func TestTxnSwapBucketsLocked(t *testing.T) {
+	tt := newTxnTest(1)
+	defer tt.cleanup()
+
+	var (
+		bckA = cluster.NewBck("swap-a", cmn.ProviderAIS, cmn.NsGlobal)
+		bckB = cluster.NewBck("swap-b", cmn.ProviderAIS, cmn.NsGlobal)
+		nlpA = bckA.GetNameLockPair()
+		nlpB = bckB.GetNameLockPair()
+		bmd  = newBucketMD()
+		o    = newBMDOwnerPrx(cmn.GCO.Get())
+	)
+	bmd.add(bckA, cmn.DefaultBucketProps())
+	bmd.add(bckB, cmn.DefaultBucketProps())
+	o._put(bmd)
+	tt.primary.owner.bmd = o
+	tt.primary.notifs.init(tt.primary)
+
+	err := tt.primary.swapBuckets(bckA, bckB, &cmn.ActionMsg{Action: cmn.ActSwapLB, Name: bckB.Name})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, tt.targets[0].received() == "begin,commit", "unexpected %q", tt.targets[0].received())
+	tassert.Errorf(t, tt.targets[0].queries[cmn.ActCommit].Get(cmn.URLParamNotifyMe) == tt.primary.si.ID(),
+		"expected commit to request notification")
+
+	tassert.Fatalf(t, !nlpA.TryLock() && !nlpB.TryLock(), "expected buckets to remain locked")
+	tassert.Fatalf(t, len(tt.primary.notifs.m) == 1, "expected one listener, got %d", len(tt.primary.notifs.m))
+	for _, nl := range tt.primary.notifs.m {
+		tt.primary.nlBckFromToCb(nl, nil, nil) // objects are in place
+	}
+	tassert.Fatalf(t, nlpA.TryLock() && nlpB.TryLock(), "expected buckets to be unlocked upon notification")
+	nlpA.Unlock()
+	nlpB.Unlock()
+
+	// failed to begin: unlocked right away
+	tt.targets[0].fail[cmn.ActBegin] = http.StatusBadRequest
+	err = tt.primary.swapBuckets(bckA, bckB, &cmn.ActionMsg{Action: cmn.ActSwapLB, Name: bckB.Name})
+	tassert.Fatalf(t, err != nil, "expected begin error")
+	tassert.Fatalf(t, nlpA.TryLock() && nlpB.TryLock(), "expected buckets to be unlocked upon failure")
+	nlpA.Unlock()
+	nlpB.Unlock()
+}
+
+func txnTestRecord(uuid, action, phase string) *txnRecord {
+	return &txnRecord{
+		UUID:   uuid,
+		Action: action,
+		Phase:  phase,
+		Path:   cmn.URLPath(cmn.Version, cmn.Txn, "bck-"+uuid),
+		Query:  url.Values{},
+	}
+}
+
+// the same node starts up as primary: resolve the journaled transactions
+func TestTxnRecoverJournal(t *testing.T) {
+	tt := newTxnTest(2)
+	defer tt.cleanup()
+
+	tt.primary.txnJournal.Records = []*txnRecord{
+		txnTestRecord("begun", cmn.ActCreateLB, txnPhaseBegin),
+		txnTestRecord("synced", cmn.ActCreateLB, txnPhaseMetasync),
+		txnTestRecord("custom", cmn.ActRenameLB, txnPhaseMetasync),
+		txnTestRecord("committing", cmn.ActCreateLB, txnPhaseCommit),
+	}
+	tt.primary.txnJournal.Records[2].Custom = true
+	tt.primary.recoverTxns()
+	tassert.Errorf(t, tt.journaled() == 0, "expected the journal to be drained")
+	expected := map[string]string{
+		"bck-begun":      cmn.ActAbort,
+		"bck-synced":     cmn.ActCommit,
+		"bck-custom":     cmn.ActAbort, // cannot roll forward
+		"bck-committing": cmn.ActCommit,
+	}
+	for _, mock := range tt.targets {
+		for bucket, action := range expected {
+			tassert.Errorf(t, mock.byBucket[bucket] == action, "%s: expected %q, got %q",
+				bucket, action, mock.byBucket[bucket])
+		}
+	}
+}
+
+// a new primary takes over: resolve the transactions reported by the targets
+func TestTxnRecoverOrphaned(t *testing.T) {
+	tt := newTxnTest(2)
+	defer tt.cleanup()
+
+	// only one of the targets has received the updated BMD
+	tt.targets[0].orphans = []*txnRecord{
+		txnTestRecord("synced", cmn.ActCreateLB, txnPhaseMetasync),
+		txnTestRecord("begun", cmn.ActCreateLB, txnPhaseBegin),
+		txnTestRecord("custom", cmn.ActSwapLB, txnPhaseMetasync),
+	}
+	tt.targets[1].orphans = []*txnRecord{
+		txnTestRecord("synced", cmn.ActCreateLB, txnPhaseBegin),
+		txnTestRecord("begun", cmn.ActCreateLB, txnPhaseBegin),
+	}
+	tt.primary.recoverTxns()
+	expected := map[string]string{
+		"bck-synced": cmn.ActCommit,
+		"bck-begun":  cmn.ActAbort,
+		"bck-custom": cmn.ActAbort,
+	}
+	for _, mock := range tt.targets {
+		for bucket, action := range expected {
+			tassert.Errorf(t, mock.byBucket[bucket] == action, "%s: expected %q, got %q",
+				bucket, action, mock.byBucket[bucket])
+		}
+		// each unfinished txn gets resolved only once
+		tassert.Errorf(t, len(mock.actions) == len(expected), "expected %d requests, got %q",
+			len(expected), mock.received())
+	}
+}
+
+func TestTxnAbortOrphaned(t *testing.T) {
+	var (
+		tgt  = &targetrunner{}
+		txns = &transactions{t: tgt, m: make(map[string]txn), rendezvous: make(map[string]rndzvs)}
+		bck  = cluster.NewBck("orphaned", cmn.ProviderAIS, cmn.NsGlobal)
+	)
+	tgt.si = newSnode("target", httpProto, cmn.Target, &net.TCPAddr{}, &net.TCPAddr{}, &net.TCPAddr{})
+	begin := func(uuid, callerID string) {
+		c := &txnServerCtx{
+			uuid:     uuid,
+			callerID: callerID,
+			msg:      &aisMsg{ActionMsg: cmn.ActionMsg{Action: cmn.ActCreateLB}, UUID: uuid},
+			bck:      bck,
+			query:    url.Values{},
+		}
+		tassert.CheckFatal(t, txns.begin(newTxnCreateBucket(c)))
+	}
+	begin("begun", "old-primary")
+	begin("synced", "old-primary")
+	begin("new", "new-primary")
+	tassert.CheckFatal(t, txns.commitBefore("old-primary", &aisMsg{UUID: "synced"})) // received BMD
+
+	// reported to the new primary, with the original request
+	records := txns.orphaned("new-primary")
+	tassert.Fatalf(t, len(records) == 2, "expected 2 orphaned txns, got %d", len(records))
+	for _, rec := range records {
+		phase := txnPhaseBegin
+		if rec.UUID == "synced" {
+			phase = txnPhaseMetasync
+		}
+		tassert.Errorf(t, rec.Phase == phase, "%s: expected phase %q, got %q", rec.UUID, phase, rec.Phase)
+		tassert.Errorf(t, rec.Action == cmn.ActCreateLB && rec.Path == cmn.URLPath(cmn.Version, cmn.Txn, bck.Name),
+			"%s: unexpected %q %q", rec.UUID, rec.Action, rec.Path)
+	}
+
+	// aborted upon the new Smap: all except the one that has received BMD
+	txns.abortOrphaned("old-primary")
+	_, err := txns.find("begun", false)
+	tassert.Errorf(t, err != nil, "expected %q to be aborted", "begun")
+	_, err = txns.find("synced", false)
+	tassert.Errorf(t, err == nil, "expected %q to remain, got %v", "synced", err)
+	_, err = txns.find("new", false)
+	tassert.Errorf(t, err == nil, "expected %q to remain, got %v", "new", err)
+}
+
+// rename-cloud-bucket: the destination gets added right away, the source - evicted upon copying
+func TestTxnRenameCloudBucket(t *testing.T) {
+	tt := newTxnTest(1)
+	defer tt.cleanup()
+
+	var (
+		bckFrom = cluster.NewBck("rename-cloud", cmn.ProviderAmazon, cmn.NsGlobal)
+		bckTo   = cluster.NewBck("rename-cloud-to", cmn.ProviderAIS, cmn.NsGlobal)
+		bmd     = newBucketMD()
+		o       = newBMDOwnerPrx(cmn.GCO.Get())
+		msg     = &cmn.ActionMsg{Action: cmn.ActRenameLB, Name: bckTo.Name}
+		access  = cmn.DefaultBucketProps().Access
+	)
+	bmd.add(bckFrom, cmn.DefaultBucketProps())
+	o._put(bmd)
+	tt.primary.owner.bmd = o
+	tt.primary.notifs.init(tt.primary)
+
+	// waits for the (async) BMD update upon copying
+	waitBMD := func(cond func(bmd *bucketMD) bool) {
+		for i := 0; i < 100 && !cond(tt.primary.owner.bmd.get()); i++ {
+			time.Sleep(50 * time.Millisecond)
+		}
+		tassert.Fatalf(t, cond(tt.primary.owner.bmd.get()), "timed out waiting for BMD update")
+	}
+	copied := func(err error) {
+		tassert.Fatalf(t, len(tt.primary.notifs.m) == 1, "expected one listener, got %d", len(tt.primary.notifs.m))
+		for uuid, nl := range tt.primary.notifs.m {
+			nl.callback(&tt.primary.notifs, nl, nil, err)
+			delete(tt.primary.notifs.m, uuid)
+		}
+	}
+
+	err := tt.primary.renameCloudBucket(bckFrom, bckTo, msg, nil)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, tt.targets[0].received() == "begin,commit", "unexpected %q", tt.targets[0].received())
+	props, present := tt.primary.owner.bmd.get().Get(bckTo)
+	tassert.Fatalf(t, present && props.BackendBck.Equal(bckFrom.Bck), "expected %s backed by %s", bckTo, bckFrom)
+	props, present = tt.primary.owner.bmd.get().Get(bckFrom)
+	tassert.Fatalf(t, present && props.Renamed != "", "expected %s to remain (while being renamed)", bckFrom)
+	tassert.Errorf(t, props.Access&cmn.AccessPUT == 0, "expected %s to be read-only", bckFrom)
+
+	// copying fails: writable again, destination stays
+	copied(errors.New("copy failed"))
+	waitBMD(func(bmd *bucketMD) bool {
+		props, present := bmd.Get(bckFrom)
+		return present && props.Renamed == ""
+	})
+	props, _ = tt.primary.owner.bmd.get().Get(bckFrom)
+	tassert.Errorf(t, props.Access == access, "expected access restored, got %v", props.Access)
+	_, present = tt.primary.owner.bmd.get().Get(bckTo)
+	tassert.Errorf(t, present, "expected %s to stay", bckTo)
+
+	// copying succeeds: evicted - by a separate BMD update
+	bckTo = cluster.NewBck("rename-cloud-to2", cmn.ProviderAIS, cmn.NsGlobal)
+	msg.Name = bckTo.Name
+	err = tt.primary.renameCloudBucket(bckFrom, bckTo, msg, nil)
+	tassert.CheckFatal(t, err)
+	ver := tt.primary.owner.bmd.get().version()
+	copied(nil)
+	waitBMD(func(bmd *bucketMD) bool {
+		_, present := bmd.Get(bckFrom)
+		return !present
+	})
+	tassert.Errorf(t, tt.primary.owner.bmd.get().version() == ver+1, "expected BMD v%d", ver+1)
+	_, present = tt.primary.owner.bmd.get().Get(bckTo)
+	tassert.Errorf(t, present, "expected %s", bckTo)
+}
@@ -0,0 +1,226 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Transaction journal
+//
+// The primary records each CP transaction (see runTxn) in the journal that
+// is persisted in its local config directory - prior to the begin broadcast -
+// and removes the record once the transaction is committed or aborted.
+// If the primary goes down in the middle, the unfinished transactions get
+// resolved by the next primary (see recoverTxns) that combines its own journal
+// (when the same node starts up as primary again) with the transactions
+// reported by the targets (the latter keep the original requests - see orphaned):
+// - txnPhaseBegin:    targets may have begun (but the BMD has not changed) - abort;
+// - txnPhaseMetasync: BMD has changed - roll forward and commit unless the
+//                     transaction commits in its own way (e.g., along with RMD);
+// - txnPhaseCommit:   re-send the commit (the targets that have already
+//                     committed respond with "doesn't exist").
+// Targets, on their part, drop the uncommitted transactions of the former
+// primary upon receiving the Smap with a new one (see abortOrphaned) - all
+// except those that have already received the updated BMD.
+
+const (
+	txnJournalFname = ".ais.txn" // txn journal basename
+
+	txnPhaseBegin    = "begin"
+	txnPhaseMetasync = "metasync"
+	txnPhaseCommit   = "commit"
+)
+
+type (
+	txnRecord struct {
+		UUID    string     `json:"uuid"`
+		Action  string     `json:"action"`
+		Phase   string     `json:"phase"`
+		Path    string     `json:"path"`  // /v1/txn/bucket-name
+		Query   url.Values `json:"query"` // includes bucket
+		Body    []byte     `json:"body"`
+		Custom  bool       `json:"custom_commit,omitempty"`
+		Started int64      `json:"started,string"`
+	}
+	txnJournal struct {
+		mtx     sync.Mutex
+		Records []*txnRecord `json:"txns"`
+	}
+)
+
+func (j *txnJournal) fpath() string { return filepath.Join(cmn.GCO.Get().Confdir, txnJournalFname) }
+
+func (j *txnJournal) load() {
+	j.mtx.Lock()
+	err := jsp.Load(j.fpath(), j, jsp.CCSign())
+	j.mtx.Unlock()
+	if err != nil && !os.IsNotExist(err) {
+		glog.Errorf("failed to load txn journal: %v", err)
+	}
+}
+
+// under lock
+func (j *txnJournal) persist() {
+	if err := jsp.Save(j.fpath(), j, jsp.CCSign()); err != nil {
+		glog.Errorf("failed to write txn journal %s: %v", j.fpath(), err)
+	}
+}
+
+func (j *txnJournal) add(c *txnClientCtx, custom bool) {
+	rec := &txnRecord{
+		UUID:    c.uuid,
+		Action:  c.msg.Action,
+		Phase:   txnPhaseBegin,
+		Path:    c.path,
+		Query:   c.req.Query,
+		Body:    c.body,
+		Custom:  custom,
+		Started: time.Now().UnixNano(),
+	}
+	j.mtx.Lock()
+	j.Records = append(j.Records, rec)
+	j.persist()
+	j.mtx.Unlock()
+}
+
+func (j *txnJournal) setPhase(c *txnClientCtx, phase string) {
+	j.mtx.Lock()
+	for _, rec := range j.Records {
+		if rec.UUID == c.uuid {
+			rec.Phase, rec.Query, rec.Body = phase, c.req.Query, c.body
+			j.persist()
+			break
+		}
+	}
+	j.mtx.Unlock()
+}
+
+func (j *txnJournal) del(uuid string) {
+	j.mtx.Lock()
+	for i, rec := range j.Records {
+		if rec.UUID == uuid {
+			j.Records = append(j.Records[:i], j.Records[i+1:]...)
+			j.persist()
+			break
+		}
+	}
+	j.mtx.Unlock()
+}
+
+// clear the journal and return the unfinished transactions (if any)
+func (j *txnJournal) drain() (records []*txnRecord) {
+	j.mtx.Lock()
+	if len(j.Records) > 0 {
+		records, j.Records = j.Records, nil
+		j.persist()
+	}
+	j.mtx.Unlock()
+	return
+}
+
+// the transactions that commit along with the new RMD (see renameBucket and swapBuckets)
+func txnCustomCommit(action string) bool {
+	return action == cmn.ActRenameLB || action == cmn.ActSwapLB
+}
+
+func txnPhaseOrder(phase string) int {
+	switch phase {
+	case txnPhaseMetasync:
+		return 1
+	case txnPhaseCommit:
+		return 2
+	default:
+		return 0
+	}
+}
+
+// query the targets for the transactions begun by the former primary
+func (p *proxyrunner) orphanedTxns(smap *smapX) (records []*txnRecord) {
+	args := bcastArgs{req: cmn.ReqArgs{Path: cmn.URLPath(cmn.Version, cmn.Txn)}, smap: smap}
+	results := p.bcastGet(args)
+	for res := range results {
+		if res.err != nil {
+			glog.Errorf("%s: failed to query %s for unfinished txns: %v", p.si, res.si, res.err)
+			continue
+		}
+		var recs []*txnRecord
+		if err := jsoniter.Unmarshal(res.outjson, &recs); err != nil {
+			glog.Errorf("%s: %s: invalid txn records: %v", p.si, res.si, err)
+			continue
+		}
+		records = append(records, recs...)
+	}
+	return
+}
+
+// merge by uuid: the furthest phase reached by any of the nodes
+func mergeTxns(journaled, reported []*txnRecord) []*txnRecord {
+	var (
+		records = journaled
+		idx     = make(map[string]*txnRecord, len(journaled)+len(reported))
+	)
+	for _, rec := range journaled {
+		idx[rec.UUID] = rec
+	}
+	for _, rec := range reported {
+		rec.Custom = txnCustomCommit(rec.Action)
+		prev, ok := idx[rec.UUID]
+		if !ok {
+			idx[rec.UUID] = rec
+			records = append(records, rec)
+			continue
+		}
+		if txnPhaseOrder(rec.Phase) > txnPhaseOrder(prev.Phase) {
+			prev.Phase = rec.Phase
+		}
+	}
+	return records
+}
+
+// resolve the transactions that were left unfinished by the former primary
+// (see "Transaction journal" above)
+func (p *proxyrunner) recoverTxns() {
+	var (
+		smap    = p.owner.smap.get()
+		records = mergeTxns(p.txnJournal.drain(), p.orphanedTxns(smap))
+	)
+	for _, rec := range records {
+		var (
+			phase = cmn.ActAbort
+			req   = cmn.ReqArgs{Query: rec.Query, Body: rec.Body}
+		)
+		switch {
+		case rec.Phase == txnPhaseCommit, rec.Phase == txnPhaseMetasync && !rec.Custom:
+			phase = cmn.ActCommit
+		case rec.Phase == txnPhaseMetasync:
+			glog.Errorf("%s: cannot roll forward %q txn[%s] - aborting (manual cleanup may be required)",
+				p.si, rec.Action, rec.UUID)
+		}
+		glog.Warningf("%s: %s unfinished %q txn[%s] (phase %q, started %v)", p.si, phase, rec.Action,
+			rec.UUID, rec.Phase, time.Unix(0, rec.Started))
+		req.Path = cmn.URLPath(rec.Path, phase)
+		results := p.bcastPost(bcastArgs{req: req, smap: smap, timeout: cmn.LongTimeout})
+		for res := range results {
+			switch {
+			case res.err == nil:
+			case strings.Contains(res.err.Error(), txnNotFound): // e.g., aborted upon the new Smap
+				glog.Warningf("%s: %s %q txn[%s]: %v", p.si, phase, rec.Action, rec.UUID, res.err)
+			default:
+				glog.Errorf("%s: %s %q txn[%s]: %v", p.si, phase, rec.Action, rec.UUID, res.err)
+			}
+		}
+	}
+}
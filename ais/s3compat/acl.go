@@ -0,0 +1,167 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Access control lists (ACLs)
+//
+// AIS has no per-object grants, and it does not tell the owner from the other
+// users - bucket access attributes (cmn.AccessAttrs) apply to all clients that
+// AuthN (if enabled) lets in. Hence, only the canned ACLs are supported, and
+// each maps to access attributes as follows:
+//   private           - full (owner) access; the default
+//   public-read       - read-only access (the bucket's ACL can still be changed)
+//   public-read-write - read-write access to the objects (ditto); the rest of
+//                       bucket operations (e.g., rename) require "private"
+// An object's ACL is its bucket's ACL.
+
+const (
+	URLParamACL = "acl"
+	HeaderACL   = "x-amz-acl"
+
+	ACLPrivate         = "private"
+	ACLPublicRead      = "public-read"
+	ACLPublicReadWrite = "public-read-write"
+
+	permFullControl = "FULL_CONTROL"
+	permRead        = "READ"
+	permWrite       = "WRITE"
+
+	xsiNamespace   = "http://www.w3.org/2001/XMLSchema-instance"
+	granteeAllUsrs = "http://acs.amazonaws.com/groups/global/AllUsers"
+	granteeUser    = "CanonicalUser"
+	granteeGroup   = "Group"
+
+	aclMgmtAccess = cmn.AccessBckHEAD | cmn.AccessPATCH // to be able to change the ACL back
+)
+
+type (
+	AccessControlPolicy struct {
+		XMLName xml.Name `xml:"AccessControlPolicy"`
+		Ns      string   `xml:"xmlns,attr"`
+		Owner   BckOwner `xml:"Owner"`
+		Grants  []*Grant `xml:"AccessControlList>Grant"`
+	}
+	Grant struct {
+		Grantee    Grantee `xml:"Grantee"`
+		Permission string  `xml:"Permission"`
+	}
+	Grantee struct {
+		Xsi  string `xml:"xmlns:xsi,attr,omitempty"`
+		Type string `xml:"xsi:type,attr,omitempty"`
+		ID   string `xml:"ID,omitempty"`
+		Name string `xml:"DisplayName,omitempty"`
+		URI  string `xml:"URI,omitempty"`
+	}
+)
+
+// CannedToAccess converts canned ACL to bucket access attributes
+func CannedToAccess(canned string) (cmn.AccessAttrs, error) {
+	switch canned {
+	case ACLPrivate:
+		return cmn.AllAccess(), nil
+	case ACLPublicRead:
+		return cmn.ReadOnlyAccess() | aclMgmtAccess, nil
+	case ACLPublicReadWrite:
+		return cmn.ReadWriteAccess() | aclMgmtAccess, nil
+	default:
+		return 0, fmt.Errorf("canned ACL %q is not supported (supported: %s, %s, %s)",
+			canned, ACLPrivate, ACLPublicRead, ACLPublicReadWrite)
+	}
+}
+
+// AccessToCanned converts bucket access attributes to the closest canned ACL
+// (and all access, the default - to "private")
+func AccessToCanned(access cmn.AccessAttrs) string {
+	switch {
+	case access.Has(cmn.AllAccess()):
+		return ACLPrivate
+	case access.Has(cmn.ReadWriteAccess()):
+		return ACLPublicReadWrite
+	case access.Has(cmn.ReadOnlyAccess()):
+		return ACLPublicRead
+	default:
+		return ACLPrivate
+	}
+}
+
+func NewAccessControlPolicy(access cmn.AccessAttrs) *AccessControlPolicy {
+	owner := BckOwner{ID: "1", Name: "ais"} // TODO: same as in ListBucketResult
+	acp := &AccessControlPolicy{
+		Ns:    s3Namespace,
+		Owner: owner,
+		Grants: []*Grant{{
+			Grantee:    Grantee{Xsi: xsiNamespace, Type: granteeUser, ID: owner.ID, Name: owner.Name},
+			Permission: permFullControl,
+		}},
+	}
+	canned := AccessToCanned(access)
+	if canned == ACLPrivate {
+		return acp
+	}
+	allUsers := Grantee{Xsi: xsiNamespace, Type: granteeGroup, URI: granteeAllUsrs}
+	acp.Grants = append(acp.Grants, &Grant{Grantee: allUsers, Permission: permRead})
+	if canned == ACLPublicReadWrite {
+		acp.Grants = append(acp.Grants, &Grant{Grantee: allUsers, Permission: permWrite})
+	}
+	return acp
+}
+
+func (acp *AccessControlPolicy) MustMarshal() []byte {
+	b, err := xml.Marshal(acp)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+// Canned returns the canned ACL that corresponds to the policy's grants to
+// all users (the grants to specific users are ignored)
+func (acp *AccessControlPolicy) Canned() string {
+	var read, write bool
+	for _, grant := range acp.Grants {
+		if grant.Grantee.URI != granteeAllUsrs {
+			continue
+		}
+		switch grant.Permission {
+		case permRead:
+			read = true
+		case permWrite:
+			write = true
+		case permFullControl:
+			read, write = true, true
+		}
+	}
+	switch {
+	case write:
+		return ACLPublicReadWrite
+	case read:
+		return ACLPublicRead
+	default:
+		return ACLPrivate
+	}
+}
+
+// ParseACL returns the access attributes requested by PUT ?acl - either
+// canned ACL in the x-amz-acl header or the access control policy in the body
+func ParseACL(r *http.Request) (cmn.AccessAttrs, error) {
+	if canned := r.Header.Get(HeaderACL); canned != "" {
+		return CannedToAccess(canned)
+	}
+	acp := &AccessControlPolicy{}
+	if err := xml.NewDecoder(r.Body).Decode(acp); err != nil {
+		if err == io.EOF {
+			return 0, fmt.Errorf("either %s header or access control policy is required", HeaderACL)
+		}
+		return 0, err
+	}
+	return CannedToAccess(acp.Canned())
+}
@@ -0,0 +1,61 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestCannedACL(t *testing.T) {
+	for _, canned := range []string{ACLPrivate, ACLPublicRead, ACLPublicReadWrite} {
+		access, err := CannedToAccess(canned)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, AccessToCanned(access) == canned, "%s: round trip yields %q", canned, AccessToCanned(access))
+		tassert.Errorf(t, access.Has(cmn.AccessPATCH), "%s: the ACL must remain changeable", canned)
+	}
+	// the owner has full access
+	access, err := CannedToAccess(ACLPrivate)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, access == cmn.AllAccess(), "private: expected full access, got %s", access.Describe())
+
+	// a new bucket is private
+	tassert.Errorf(t, AccessToCanned(cmn.AllAccess()) == ACLPrivate, "expected default access to be private")
+	acp := NewAccessControlPolicy(cmn.AllAccess())
+	tassert.Errorf(t, len(acp.Grants) == 1 && acp.Grants[0].Permission == permFullControl,
+		"expected owner-only grant, got %d grants", len(acp.Grants))
+
+	_, err = CannedToAccess("authenticated-read")
+	tassert.Errorf(t, err != nil, "expected unsupported canned ACL to fail")
+}
+
+func TestParseACL(t *testing.T) {
+	for _, canned := range []string{ACLPrivate, ACLPublicRead, ACLPublicReadWrite} {
+		expected, err := CannedToAccess(canned)
+		tassert.CheckFatal(t, err)
+
+		// header
+		r, err := http.NewRequest(http.MethodPut, "/s3/bck?acl", nil)
+		tassert.CheckFatal(t, err)
+		r.Header.Set(HeaderACL, canned)
+		access, err := ParseACL(r)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, access == expected, "%s (header): expected %s, got %s", canned, expected, access)
+
+		// access control policy, as returned by GET ?acl
+		b, err := xml.Marshal(NewAccessControlPolicy(expected))
+		tassert.CheckFatal(t, err)
+		r, err = http.NewRequest(http.MethodPut, "/s3/bck?acl", strings.NewReader(string(b)))
+		tassert.CheckFatal(t, err)
+		access, err = ParseACL(r)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, access == expected, "%s (policy): expected %s, got %s", canned, expected, access)
+	}
+}
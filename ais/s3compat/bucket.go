@@ -48,6 +48,22 @@ type (
 		Key     string `xml:"Key"`
 		Version string `xml:"Version"`
 	}
+
+	// Multiple object delete response
+	DeleteResult struct {
+		XMLName xml.Name         `xml:"DeleteResult"`
+		Ns      string           `xml:"xmlns,attr"`
+		Deleted []*DeletedObject `xml:"Deleted"`
+		Errors  []*DeleteError   `xml:"Error"`
+	}
+	DeletedObject struct {
+		Key string `xml:"Key"`
+	}
+	DeleteError struct {
+		Key     string `xml:"Key"`
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
 )
 
 func NewListBucketResult() *ListBucketResult {
@@ -101,3 +117,21 @@ func (r *VersioningConfiguration) MustMarshal() []byte {
 func (r *VersioningConfiguration) Enabled() bool {
 	return r.Status == versioningEnabled
 }
+
+func NewDeleteResult() *DeleteResult {
+	return &DeleteResult{Ns: s3Namespace}
+}
+
+func (r *DeleteResult) AddDeleted(key string) {
+	r.Deleted = append(r.Deleted, &DeletedObject{Key: key})
+}
+
+func (r *DeleteResult) AddError(key, code, msg string) {
+	r.Errors = append(r.Errors, &DeleteError{Key: key, Code: code, Message: msg})
+}
+
+func (r *DeleteResult) MustMarshal() []byte {
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
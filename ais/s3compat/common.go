@@ -20,6 +20,10 @@ const (
 	// versioning
 	URLParamVersioning  = "versioning" // URL parameter
 	URLParamMultiDelete = "delete"
+	MaxDeleteKeys       = 1000        // max number of objects in a single multiple object delete request
+	URLParamListType    = "list-type" // "2" - ListObjectsV2
+	defaultMaxKeys      = 1000
+	contTokenSepa       = "\n" // V2 continuation token: listing handle + contTokenSepa + marker
 	versioningEnabled   = "Enabled"
 	versioningDisabled  = "Suspended"
 
@@ -28,8 +32,9 @@ const (
 
 	// Headers
 	headerETag    = "ETag"
-	headerVersion = "x-amz-version-id"
+	HeaderVersion = "x-amz-version-id"
 	HeaderObjSrc  = "x-amz-copy-source"
+	HeaderSSE     = "x-amz-server-side-encryption"
 
 	headerAtime = "Last-Modified"
 )
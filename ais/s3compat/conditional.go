@@ -0,0 +1,101 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+)
+
+// Conditional GET and HEAD (RFC 7232)
+//
+// The ETag is the one GET and HEAD return (see ETag), and the modification
+// time is the time the object was last written - not the `Last-Modified`
+// that AIS reports (the object's access time). The latter is never earlier
+// than the former, so that the client that sends back the `Last-Modified` it
+// got does not re-download the object until the object gets overwritten.
+
+const (
+	headerIfMatch           = "If-Match"
+	headerIfNoneMatch       = "If-None-Match"
+	headerIfModifiedSince   = "If-Modified-Since"
+	headerIfUnmodifiedSince = "If-Unmodified-Since"
+)
+
+// ETag returns the object's ETag: MD5 of the objects that came from S3 (or
+// their multipart ETag) and the object's checksum otherwise - empty if none
+func ETag(lom *cluster.LOM) string {
+	if v, exists := lom.GetCustomMD(cluster.SourceObjMD); exists && v == cluster.SourceAmazonObjMD {
+		if v, exists := lom.GetCustomMD(cluster.MD5ObjMD); exists {
+			return v
+		}
+		if v, exists := lom.GetCustomMD(cluster.ETagObjMD); exists {
+			return v
+		}
+	}
+	if cksum := lom.Cksum(); cksum != nil {
+		return cksum.Value()
+	}
+	return ""
+}
+
+// CheckPreconditions evaluates the conditional headers of a GET or HEAD request
+// in the order RFC 7232 (section 6) prescribes. Returns http.StatusNotModified
+// or http.StatusPreconditionFailed when the object must not be returned, and
+// zero otherwise.
+func CheckPreconditions(header http.Header, etag string, mtime time.Time) int {
+	mtime = mtime.Truncate(time.Second) // (HTTP dates have one-second resolution)
+	if im := header.Get(headerIfMatch); im != "" {
+		if !etagMatch(im, etag, false /*weak*/) {
+			return http.StatusPreconditionFailed
+		}
+	} else if ius, err := http.ParseTime(header.Get(headerIfUnmodifiedSince)); err == nil {
+		if mtime.After(ius) {
+			return http.StatusPreconditionFailed
+		}
+	}
+	if inm := header.Get(headerIfNoneMatch); inm != "" {
+		if etagMatch(inm, etag, true /*weak*/) {
+			return http.StatusNotModified
+		}
+	} else if ims, err := http.ParseTime(header.Get(headerIfModifiedSince)); err == nil {
+		if !mtime.After(ims) {
+			return http.StatusNotModified
+		}
+	}
+	return 0
+}
+
+// SetNotModifiedHeader sets the headers of 304 response: the ETag and Last-Modified
+func SetNotModifiedHeader(header http.Header, lom *cluster.LOM) {
+	if etag := ETag(lom); etag != "" {
+		SetETag(header, etag)
+	}
+	header.Set(headerAtime, FormatTime(lom.Atime()))
+}
+
+// checks a comma-separated list of (quoted) entity tags, or "*", against the
+// ETag; weak comparison ignores the "W/" prefix, strong one never matches it
+func etagMatch(list, etag string, weak bool) bool {
+	for _, tag := range strings.Split(list, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if strings.HasPrefix(tag, "W/") {
+			if !weak {
+				continue
+			}
+			tag = tag[2:]
+		}
+		if etag != "" && UnquoteETag(tag) == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestCheckPreconditions(t *testing.T) {
+	const etag = "0123abcd"
+	var (
+		mtime  = time.Date(2020, 6, 1, 12, 0, 0, 500, time.UTC)
+		before = mtime.Add(-time.Hour).Format(http.TimeFormat)
+		same   = mtime.Format(http.TimeFormat) // (truncated to seconds)
+		after  = mtime.Add(time.Hour).Format(http.TimeFormat)
+	)
+	tests := []struct {
+		name   string
+		header map[string]string
+		status int
+	}{
+		{name: "none", status: 0},
+
+		{name: "if-match", header: map[string]string{headerIfMatch: `"0123abcd"`}, status: 0},
+		{name: "if-match list", header: map[string]string{headerIfMatch: `"x", "0123abcd"`}, status: 0},
+		{name: "if-match any", header: map[string]string{headerIfMatch: "*"}, status: 0},
+		{name: "if-match mismatch", header: map[string]string{headerIfMatch: `"x"`}, status: http.StatusPreconditionFailed},
+		{
+			name:   "if-match weak",
+			header: map[string]string{headerIfMatch: `W/"0123abcd"`},
+			status: http.StatusPreconditionFailed,
+		},
+
+		{name: "if-none-match", header: map[string]string{headerIfNoneMatch: `"0123abcd"`}, status: http.StatusNotModified},
+		{
+			name:   "if-none-match weak",
+			header: map[string]string{headerIfNoneMatch: `W/"0123abcd"`},
+			status: http.StatusNotModified,
+		},
+		{name: "if-none-match mismatch", header: map[string]string{headerIfNoneMatch: `"x"`}, status: 0},
+
+		{name: "if-modified-since before", header: map[string]string{headerIfModifiedSince: before}, status: 0},
+		{
+			name:   "if-modified-since same",
+			header: map[string]string{headerIfModifiedSince: same},
+			status: http.StatusNotModified,
+		},
+		{name: "if-unmodified-since after", header: map[string]string{headerIfUnmodifiedSince: after}, status: 0},
+		{
+			name:   "if-unmodified-since before",
+			header: map[string]string{headerIfUnmodifiedSince: before},
+			status: http.StatusPreconditionFailed,
+		},
+		{name: "invalid date", header: map[string]string{headerIfModifiedSince: "yesterday"}, status: 0},
+
+		// If-Match takes precedence over If-Unmodified-Since, and
+		// If-None-Match over If-Modified-Since (RFC 7232, section 6)
+		{
+			name:   "if-match and if-unmodified-since",
+			header: map[string]string{headerIfMatch: `"0123abcd"`, headerIfUnmodifiedSince: before},
+			status: 0,
+		},
+		{
+			name:   "if-none-match and if-modified-since",
+			header: map[string]string{headerIfNoneMatch: `"x"`, headerIfModifiedSince: after},
+			status: 0,
+		},
+		{
+			name:   "if-match failure first",
+			header: map[string]string{headerIfMatch: `"x"`, headerIfNoneMatch: `"0123abcd"`},
+			status: http.StatusPreconditionFailed,
+		},
+	}
+	for _, test := range tests {
+		header := http.Header{}
+		for k, v := range test.header {
+			header.Set(k, v)
+		}
+		status := CheckPreconditions(header, etag, mtime)
+		tassert.Errorf(t, status == test.status, "%s: expected %d, got %d", test.name, test.status, status)
+	}
+
+	// an object with no ETag matches only "*"
+	header := http.Header{}
+	header.Set(headerIfMatch, `""`)
+	tassert.Errorf(t, CheckPreconditions(header, "", mtime) == http.StatusPreconditionFailed, "expected no match")
+}
+
+func TestETag(t *testing.T) {
+	lom := &cluster.LOM{}
+	tassert.Errorf(t, ETag(lom) == "", "expected no ETag")
+
+	lom.SetCksum(cmn.NewCksum(cmn.ChecksumXXHash, "xxh"))
+	tassert.Errorf(t, ETag(lom) == "xxh", "expected the checksum, got %q", ETag(lom))
+
+	// from S3: MD5, or the multipart ETag
+	lom.SetCustomMD(cmn.SimpleKVs{cluster.SourceObjMD: cluster.SourceAmazonObjMD, cluster.ETagObjMD: "abc-2"})
+	tassert.Errorf(t, ETag(lom) == "abc-2", "expected the multipart ETag, got %q", ETag(lom))
+	lom.SetCustomMD(cmn.SimpleKVs{cluster.SourceObjMD: cluster.SourceAmazonObjMD, cluster.MD5ObjMD: "md5"})
+	tassert.Errorf(t, ETag(lom) == "md5", "expected MD5, got %q", ETag(lom))
+
+	// not from S3
+	lom.SetCustomMD(cmn.SimpleKVs{cluster.MD5ObjMD: "md5"})
+	tassert.Errorf(t, ETag(lom) == "xxh", "expected the checksum, got %q", ETag(lom))
+}
@@ -0,0 +1,112 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Object copy and user-defined metadata
+//
+// User-defined metadata (the x-amz-meta-* headers) is stored in the object's
+// custom metadata as is - the lower-cased header name is the key - and is
+// returned as the same headers by GET and HEAD.
+
+const (
+	HeaderMetaDirective  = "x-amz-metadata-directive"
+	MetaDirectiveCopy    = "COPY"
+	MetaDirectiveReplace = "REPLACE"
+
+	headerMetaPrefix = "x-amz-meta-"
+)
+
+// ParseCopySource parses x-amz-copy-source - URL-encoded "[/]bucket/object"
+// with an optional "?versionId=..." (ignored). Unlike S3, the bucket can be
+// of any provider and namespace: "[provider://][@uuid#namespace/]bucket/object";
+// the provider defaults to AIS.
+func ParseCopySource(header string) (bck cmn.Bck, objName string, err error) {
+	src := header
+	if idx := strings.Index(src, "?"); idx >= 0 {
+		src = src[:idx]
+	}
+	if src, err = url.PathUnescape(src); err != nil {
+		return bck, "", fmt.Errorf("invalid %s %q: %v", HeaderObjSrc, header, err)
+	}
+	src = strings.TrimLeft(src, "/") // in AWS examples the path starts with "/"
+	bck.Provider = cmn.ProviderAIS
+	if parts := strings.SplitN(src, cmn.BckProviderSeparator, 2); len(parts) > 1 {
+		if !cmn.IsValidProvider(parts[0]) {
+			return bck, "", fmt.Errorf("invalid %s %q: unknown provider %q", HeaderObjSrc, header, parts[0])
+		}
+		bck.Provider, src = parts[0], parts[1]
+	}
+	if src != "" && (src[0] == cmn.NsUUIDPrefix || src[0] == cmn.NsNamePrefix) {
+		parts := strings.SplitN(src, "/", 2)
+		bck.Ns = cmn.ParseNsUname(parts[0])
+		if err = bck.Ns.Validate(); err != nil {
+			return
+		}
+		if len(parts) < 2 {
+			parts = append(parts, "")
+		}
+		src = parts[1]
+	}
+	parts := strings.SplitN(src, "/", 2)
+	if len(parts) < 2 || parts[0] == "" || strings.Trim(parts[1], "/") == "" {
+		return bck, "", fmt.Errorf("invalid %s %q: expecting bucket/object", HeaderObjSrc, header)
+	}
+	bck.Name, objName = parts[0], strings.Trim(parts[1], "/")
+	return
+}
+
+// MetaDirective returns x-amz-metadata-directive (COPY if not specified)
+func MetaDirective(header http.Header) (string, error) {
+	switch directive := header.Get(HeaderMetaDirective); directive {
+	case "", MetaDirectiveCopy:
+		return MetaDirectiveCopy, nil
+	case MetaDirectiveReplace:
+		return MetaDirectiveReplace, nil
+	default:
+		return "", fmt.Errorf("invalid %s %q", HeaderMetaDirective, directive)
+	}
+}
+
+// ReplaceUserMD returns a copy of the custom metadata in which the user-defined
+// metadata is replaced with the one in the header; the rest (e.g., the tags
+// and the metadata AIS maintains itself) is preserved
+func ReplaceUserMD(md cmn.SimpleKVs, header http.Header) cmn.SimpleKVs {
+	newMD := make(cmn.SimpleKVs, len(md)+len(header))
+	for k, v := range md {
+		if !strings.HasPrefix(k, headerMetaPrefix) {
+			newMD[k] = v
+		}
+	}
+	for k := range header {
+		if key := strings.ToLower(k); strings.HasPrefix(key, headerMetaPrefix) {
+			newMD[key] = header.Get(k)
+		}
+	}
+	return newMD
+}
+
+// SetUserMD replaces the object's user-defined metadata with the one in the
+// header (in memory - the caller persists the object's metadata)
+func SetUserMD(lom *cluster.LOM, header http.Header) {
+	lom.SetCustomMD(ReplaceUserMD(lom.CustomMD(), header))
+}
+
+func setUserMDHeaders(header http.Header, lom *cluster.LOM) {
+	for k, v := range lom.CustomMD() {
+		if strings.HasPrefix(k, headerMetaPrefix) {
+			header.Set(k, v)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestParseCopySource(t *testing.T) {
+	tests := []struct {
+		header  string
+		bck     cmn.Bck
+		objName string
+		fail    bool
+	}{
+		{header: "bck/obj", bck: cmn.Bck{Name: "bck", Provider: cmn.ProviderAIS}, objName: "obj"},
+		{header: "/bck/dir/obj?versionId=3", bck: cmn.Bck{Name: "bck", Provider: cmn.ProviderAIS}, objName: "dir/obj"},
+		{header: "bck/dir%2Fobj%20name", bck: cmn.Bck{Name: "bck", Provider: cmn.ProviderAIS}, objName: "dir/obj name"},
+		{header: "aws://bck/obj", bck: cmn.Bck{Name: "bck", Provider: cmn.ProviderAmazon}, objName: "obj"},
+		{
+			header:  "ais://@uuid#ns/bck/obj",
+			bck:     cmn.Bck{Name: "bck", Provider: cmn.ProviderAIS, Ns: cmn.Ns{UUID: "uuid", Name: "ns"}},
+			objName: "obj",
+		},
+		{
+			header:  "#ns/bck/obj",
+			bck:     cmn.Bck{Name: "bck", Provider: cmn.ProviderAIS, Ns: cmn.Ns{Name: "ns"}},
+			objName: "obj",
+		},
+
+		{header: "", fail: true},
+		{header: "bck", fail: true},
+		{header: "bck/", fail: true},
+		{header: "/obj", fail: true},
+		{header: "bck/%zz", fail: true},
+		{header: "s4://bck/obj", fail: true},
+		{header: "#ns", fail: true},
+	}
+	for _, test := range tests {
+		bck, objName, err := ParseCopySource(test.header)
+		if test.fail {
+			tassert.Errorf(t, err != nil, "%q: expected error", test.header)
+			continue
+		}
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, bck.Equal(test.bck) && objName == test.objName, "%q: expected %s/%s, got %s/%s",
+			test.header, test.bck, test.objName, bck, objName)
+	}
+}
+
+func TestMetaDirective(t *testing.T) {
+	for value, expected := range map[string]string{
+		"": MetaDirectiveCopy, MetaDirectiveCopy: MetaDirectiveCopy, MetaDirectiveReplace: MetaDirectiveReplace,
+	} {
+		header := http.Header{}
+		header.Set(HeaderMetaDirective, value)
+		directive, err := MetaDirective(header)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, directive == expected, "%q: expected %s, got %s", value, expected, directive)
+	}
+	header := http.Header{}
+	header.Set(HeaderMetaDirective, "MERGE")
+	_, err := MetaDirective(header)
+	tassert.Errorf(t, err != nil, "expected error for invalid directive")
+}
+
+func TestReplaceUserMD(t *testing.T) {
+	lom := &cluster.LOM{}
+	md := cmn.SimpleKVs{
+		"x-amz-meta-color":  "red",
+		"x-amz-meta-shape":  "round",
+		cluster.S3TagsObjMD: "k=v",
+	}
+	lom.SetCustomMD(md)
+
+	header := http.Header{}
+	header.Set("X-Amz-Meta-Color", "blue")
+	header.Set("Content-Type", "text/plain")
+	SetUserMD(lom, header)
+	expected := cmn.SimpleKVs{"x-amz-meta-color": "blue", cluster.S3TagsObjMD: "k=v"}
+	tassert.Errorf(t, len(lom.CustomMD()) == len(expected), "expected %v, got %v", expected, lom.CustomMD())
+	for k, v := range expected {
+		tassert.Errorf(t, lom.CustomMD()[k] == v, "%s: expected %q, got %q", k, v, lom.CustomMD()[k])
+	}
+	tassert.Errorf(t, md["x-amz-meta-color"] == "red", "the original metadata must not change")
+
+	// GET and HEAD return the user-defined metadata as is
+	header = http.Header{}
+	setUserMDHeaders(header, lom)
+	tassert.Errorf(t, len(header) == 1 && header.Get("x-amz-meta-color") == "blue", "unexpected headers %v", header)
+}
@@ -0,0 +1,142 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+const (
+	// multipart upload
+	URLParamMultipartUploads = "uploads"
+	URLParamMultipartUpload  = "uploadId"
+	URLParamMultipartPartNo  = "partNumber"
+
+	MaxMultipartParts = 10000
+)
+
+type (
+	// Multipart upload: initiate response
+	InitiateMptUploadResult struct {
+		XMLName  xml.Name `xml:"InitiateMultipartUploadResult"`
+		Ns       string   `xml:"xmlns,attr"`
+		Bucket   string   `xml:"Bucket"`
+		Key      string   `xml:"Key"`
+		UploadID string   `xml:"UploadId"`
+	}
+
+	// Multipart upload: complete request
+	CompleteMptUpload struct {
+		Parts []*PartInfo `xml:"Part"`
+	}
+	PartInfo struct {
+		PartNumber   int    `xml:"PartNumber"`
+		ETag         string `xml:"ETag"`
+		Size         int64  `xml:"Size,omitempty"`
+		LastModified string `xml:"LastModified,omitempty"`
+	}
+
+	// Multipart upload: complete response
+	CompleteMptUploadResult struct {
+		XMLName  xml.Name `xml:"CompleteMultipartUploadResult"`
+		Ns       string   `xml:"xmlns,attr"`
+		Location string   `xml:"Location"`
+		Bucket   string   `xml:"Bucket"`
+		Key      string   `xml:"Key"`
+		ETag     string   `xml:"ETag"`
+	}
+
+	// Multipart upload: list parts response
+	ListPartsResult struct {
+		XMLName  xml.Name    `xml:"ListPartsResult"`
+		Ns       string      `xml:"xmlns,attr"`
+		Bucket   string      `xml:"Bucket"`
+		Key      string      `xml:"Key"`
+		UploadID string      `xml:"UploadId"`
+		Parts    []*PartInfo `xml:"Part"`
+	}
+)
+
+func NewInitiateMptUploadResult(bucket, key, uploadID string) *InitiateMptUploadResult {
+	return &InitiateMptUploadResult{Ns: s3Namespace, Bucket: bucket, Key: key, UploadID: uploadID}
+}
+
+func (r *InitiateMptUploadResult) MustMarshal() []byte {
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+// Validate checks that the request lists at least one part and that the parts
+// are listed in ascending order of their numbers (as S3 requires)
+func (r *CompleteMptUpload) Validate() error {
+	if len(r.Parts) == 0 {
+		return fmt.Errorf("no parts to complete")
+	}
+	for i, p := range r.Parts {
+		if p.PartNumber < 1 || p.PartNumber > MaxMultipartParts {
+			return fmt.Errorf("invalid part number %d (expecting 1 to %d)", p.PartNumber, MaxMultipartParts)
+		}
+		if i > 0 && p.PartNumber <= r.Parts[i-1].PartNumber {
+			return fmt.Errorf("parts must be listed in ascending order (part %d after %d)",
+				p.PartNumber, r.Parts[i-1].PartNumber)
+		}
+	}
+	return nil
+}
+
+func NewCompleteMptUploadResult(bucket, key, etag string) *CompleteMptUploadResult {
+	return &CompleteMptUploadResult{
+		Ns:       s3Namespace,
+		Location: "/" + bucket + "/" + key,
+		Bucket:   bucket,
+		Key:      key,
+		ETag:     QuoteETag(etag),
+	}
+}
+
+func (r *CompleteMptUploadResult) MustMarshal() []byte {
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+func NewListPartsResult(bucket, key, uploadID string, parts []*PartInfo) *ListPartsResult {
+	return &ListPartsResult{Ns: s3Namespace, Bucket: bucket, Key: key, UploadID: uploadID, Parts: parts}
+}
+
+func (r *ListPartsResult) MustMarshal() []byte {
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+// S3 clients send and expect ETags in double quotes
+func QuoteETag(etag string) string   { return "\"" + etag + "\"" }
+func UnquoteETag(etag string) string { return strings.Trim(etag, "\"") }
+
+func SetETag(header http.Header, etag string) { header.Set(headerETag, QuoteETag(etag)) }
+
+// MptETag computes the ETag of the object assembled from the parts with given
+// (hex-encoded) MD5 checksums - the way S3 does it: MD5 of the concatenated
+// binary checksums followed by "-<number of parts>"
+func MptETag(md5s []string) (string, error) {
+	h := md5.New()
+	for _, s := range md5s {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			return "", err
+		}
+		h.Write(b)
+	}
+	return fmt.Sprintf("%s-%d", hex.EncodeToString(h.Sum(nil)), len(md5s)), nil
+}
@@ -0,0 +1,98 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestMptETag(t *testing.T) {
+	tests := []struct {
+		md5s []string
+		etag string
+	}{
+		// md5("part-1")
+		{md5s: []string{"78429f7462d636a84d9c922f495599c5"}, etag: "ba1476cc45dcc66e00a84a790a815fe2-1"},
+		// md5("part-1"), md5("part-2")
+		{
+			md5s: []string{"78429f7462d636a84d9c922f495599c5", "bca7c72402361f2a3af235b051ce87f4"},
+			etag: "57c153b6559452d80e500c9097bb0f41-2",
+		},
+	}
+	for _, test := range tests {
+		etag, err := MptETag(test.md5s)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, etag == test.etag, "expected %q, got %q", test.etag, etag)
+	}
+	_, err := MptETag([]string{"not-hex"})
+	tassert.Errorf(t, err != nil, "expected error for an invalid checksum")
+}
+
+func TestCompleteMptUpload(t *testing.T) {
+	const body = `<CompleteMultipartUpload xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+   <Part>
+      <ETag>"78429f7462d636a84d9c922f495599c5"</ETag>
+      <PartNumber>1</PartNumber>
+   </Part>
+   <Part>
+      <ETag>"bca7c72402361f2a3af235b051ce87f4"</ETag>
+      <PartNumber>3</PartNumber>
+   </Part>
+</CompleteMultipartUpload>`
+	req := &CompleteMptUpload{}
+	tassert.CheckFatal(t, xml.NewDecoder(strings.NewReader(body)).Decode(req))
+	tassert.Fatalf(t, len(req.Parts) == 2, "expected 2 parts, got %d", len(req.Parts))
+	tassert.Errorf(t, req.Parts[1].PartNumber == 3, "expected part number 3, got %d", req.Parts[1].PartNumber)
+	tassert.Errorf(t, UnquoteETag(req.Parts[0].ETag) == "78429f7462d636a84d9c922f495599c5",
+		"unexpected ETag %q", req.Parts[0].ETag)
+	tassert.CheckError(t, req.Validate())
+
+	tests := []struct {
+		name  string
+		parts []int
+	}{
+		{name: "empty", parts: []int{}},
+		{name: "descending", parts: []int{2, 1}},
+		{name: "duplicate", parts: []int{1, 1}},
+		{name: "zero", parts: []int{0, 1}},
+		{name: "too-large", parts: []int{1, MaxMultipartParts + 1}},
+	}
+	for _, test := range tests {
+		req := &CompleteMptUpload{}
+		for _, num := range test.parts {
+			req.Parts = append(req.Parts, &PartInfo{PartNumber: num})
+		}
+		tassert.Errorf(t, req.Validate() != nil, "%s: expected error", test.name)
+	}
+}
+
+func TestMptResults(t *testing.T) {
+	b := NewInitiateMptUploadResult("bck", "dir/obj", "upload-id").MustMarshal()
+	tassert.Errorf(t, string(b) == xml.Header+`<InitiateMultipartUploadResult xmlns="`+s3Namespace+`">`+
+		`<Bucket>bck</Bucket><Key>dir/obj</Key><UploadId>upload-id</UploadId></InitiateMultipartUploadResult>`,
+		"unexpected %s", b)
+
+	b = NewCompleteMptUploadResult("bck", "dir/obj", "57c153b6559452d80e500c9097bb0f41-2").MustMarshal()
+	tassert.Errorf(t, string(b) == xml.Header+`<CompleteMultipartUploadResult xmlns="`+s3Namespace+`">`+
+		`<Location>/bck/dir/obj</Location><Bucket>bck</Bucket><Key>dir/obj</Key>`+
+		`<ETag>&#34;57c153b6559452d80e500c9097bb0f41-2&#34;</ETag></CompleteMultipartUploadResult>`,
+		"unexpected %s", b)
+
+	parts := []*PartInfo{{PartNumber: 1, ETag: QuoteETag("78429f7462d636a84d9c922f495599c5"), Size: 6}}
+	res := &ListPartsResult{}
+	tassert.CheckFatal(t, xml.Unmarshal(NewListPartsResult("bck", "dir/obj", "upload-id", parts).MustMarshal(), res))
+	tassert.Errorf(t, res.UploadID == "upload-id" && len(res.Parts) == 1 && res.Parts[0].Size == 6,
+		"unexpected %+v", res)
+
+	header := make(http.Header)
+	SetETag(header, "78429f7462d636a84d9c922f495599c5")
+	tassert.Errorf(t, header.Get(headerETag) == `"78429f7462d636a84d9c922f495599c5"`,
+		"unexpected ETag header %q", header.Get(headerETag))
+}
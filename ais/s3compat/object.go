@@ -5,28 +5,40 @@
 package s3compat
 
 import (
+	"encoding/base64"
 	"encoding/xml"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/encrypt"
 )
 
 type (
 	// List objects response
 	ListObjectResult struct {
-		Ns          string     `xml:"xmlns,attr"`
-		Prefix      string     `xml:"Prefix"`
-		KeyCount    int        `xml:"KeyCount"` // number of objects in the response
-		MaxKeys     int        `xml:"MaxKeys"`
-		IsTruncated bool       `xml:"IsTruncated"`           // true if there are more pages to read
-		PageMarker  string     `xml:"ContinuationToken"`     // original PageMarker
-		NextMarker  string     `xml:"NextContinuationToken"` // PageMarker to read the next page
-		Contents    []*ObjInfo `xml:"Contents"`              // list of objects
+		XMLName        xml.Name        `xml:"ListBucketResult"`
+		Ns             string          `xml:"xmlns,attr"`
+		Name           string          `xml:"Name"` // bucket
+		Prefix         string          `xml:"Prefix"`
+		Delimiter      string          `xml:"Delimiter,omitempty"`
+		KeyCount       int             `xml:"KeyCount"` // number of objects and common prefixes in the response
+		MaxKeys        int             `xml:"MaxKeys"`
+		IsTruncated    bool            `xml:"IsTruncated"`                     // true if there are more pages to read
+		Marker         string          `xml:"Marker,omitempty"`                // V1: original marker
+		NextMarker     string          `xml:"NextMarker,omitempty"`            // V1: marker to read the next page
+		StartAfter     string          `xml:"StartAfter,omitempty"`            // V2: original start-after
+		PageMarker     string          `xml:"ContinuationToken,omitempty"`     // V2: original continuation token
+		NextMarkerV2   string          `xml:"NextContinuationToken,omitempty"` // V2: token to read the next page
+		Contents       []*ObjInfo      `xml:"Contents"`                        // list of objects
+		CommonPrefixes []*CommonPrefix `xml:"CommonPrefixes"`
+		v2             bool
 	}
 	ObjInfo struct {
 		Key          string `xml:"Key"`
@@ -35,6 +47,21 @@ type (
 		Size         int64  `xml:"Size"`
 		Class        string `xml:"StorageClass"`
 	}
+	CommonPrefix struct {
+		Prefix string `xml:"Prefix"`
+	}
+
+	// List objects request (query)
+	ListObjectQuery struct {
+		V2         bool
+		Prefix     string
+		Delimiter  string
+		MaxKeys    int
+		Marker     string // list the objects that follow (V1 marker, V2 start-after or decoded continuation token)
+		Handle     string // V2: the listing to continue (see cmn.SelectMsg.PersistentHandle)
+		StartAfter string
+		ContToken  string
+	}
 
 	// Response for object copy request
 	CopyObjectResult struct {
@@ -43,31 +70,60 @@ type (
 	}
 )
 
-func FillMsgFromS3Query(query url.Values, msg *cmn.SelectMsg) {
-	mxStr := query.Get("max-keys")
-	if pageSize, err := strconv.Atoi(mxStr); err == nil && pageSize > 0 {
-		msg.PageSize = uint(pageSize)
+// ParseListObjectQuery parses the query of the list objects request, V1 or V2
+// (list-type=2)
+func ParseListObjectQuery(query url.Values) (*ListObjectQuery, error) {
+	q := &ListObjectQuery{
+		V2:        query.Get(URLParamListType) == "2",
+		Prefix:    query.Get("prefix"),
+		Delimiter: query.Get("delimiter"),
+		MaxKeys:   defaultMaxKeys,
 	}
-	if prefix := query.Get("prefix"); prefix != "" {
-		msg.Prefix = prefix
+	if mxStr := query.Get("max-keys"); mxStr != "" {
+		maxKeys, err := strconv.Atoi(mxStr)
+		if err != nil || maxKeys < 0 {
+			return nil, fmt.Errorf("invalid max-keys %q", mxStr)
+		}
+		q.MaxKeys = cmn.Min(maxKeys, defaultMaxKeys)
 	}
-	var marker string
-	if marker = query.Get("continuation-token"); marker != "" {
-		msg.PageMarker = marker
+	if !q.V2 {
+		q.Marker = query.Get("marker")
+		return q, nil
 	}
 	// start-after makes sense only on first call. For the next call,
 	// when continuation-token is set, start-after is ignored
-	if after := query.Get("start-after"); after != "" && marker == "" {
-		msg.PageMarker = after
+	q.StartAfter = query.Get("start-after")
+	q.Marker = q.StartAfter
+	if q.ContToken = query.Get("continuation-token"); q.ContToken != "" {
+		token, err := base64.RawURLEncoding.DecodeString(q.ContToken)
+		if err != nil {
+			return nil, fmt.Errorf("invalid continuation-token %q", q.ContToken)
+		}
+		q.Marker = string(token)
+		if idx := strings.Index(q.Marker, contTokenSepa); idx >= 0 {
+			q.Handle, q.Marker = q.Marker[:idx], q.Marker[idx+len(contTokenSepa):]
+		}
 	}
+	return q, nil
 }
 
-func NewListObjectResult() *ListObjectResult {
-	return &ListObjectResult{
-		Ns:       s3Namespace,
-		MaxKeys:  1000,
-		Contents: make([]*ObjInfo, 0),
+func NewListObjectResult(bucket string, q *ListObjectQuery) *ListObjectResult {
+	r := &ListObjectResult{
+		Ns:             s3Namespace,
+		Name:           bucket,
+		Prefix:         q.Prefix,
+		Delimiter:      q.Delimiter,
+		MaxKeys:        q.MaxKeys,
+		Contents:       make([]*ObjInfo, 0),
+		CommonPrefixes: make([]*CommonPrefix, 0),
 	}
+	if q.V2 {
+		r.v2 = true
+		r.StartAfter, r.PageMarker = q.StartAfter, q.ContToken
+	} else {
+		r.Marker = q.Marker
+	}
+	return r
 }
 
 func (r *ListObjectResult) MustMarshal() []byte {
@@ -76,8 +132,36 @@ func (r *ListObjectResult) MustMarshal() []byte {
 	return []byte(xml.Header + string(b))
 }
 
-func (r *ListObjectResult) Add(entry *cmn.BucketEntry) {
+// Add adds the object to the list or, if the object's name contains the
+// delimiter (after the prefix), its common prefix - unless already added.
+// Returns the common prefix, if any.
+func (r *ListObjectResult) Add(entry *cmn.BucketEntry) (commonPrefix string) {
+	if r.Delimiter != "" && strings.HasPrefix(entry.Name, r.Prefix) {
+		if idx := strings.Index(entry.Name[len(r.Prefix):], r.Delimiter); idx >= 0 {
+			commonPrefix = entry.Name[:len(r.Prefix)+idx+len(r.Delimiter)]
+			l := len(r.CommonPrefixes)
+			if l == 0 || r.CommonPrefixes[l-1].Prefix != commonPrefix {
+				r.CommonPrefixes = append(r.CommonPrefixes, &CommonPrefix{Prefix: commonPrefix})
+				r.KeyCount++
+			}
+			return
+		}
+	}
 	r.Contents = append(r.Contents, entryToS3(entry))
+	r.KeyCount++
+	return
+}
+
+// Truncate marks the list as truncated; the next page starts after a given
+// marker and, with V2, continues the listing with a given handle (that the
+// continuation token carries along with the marker)
+func (r *ListObjectResult) Truncate(marker, handle string) {
+	r.IsTruncated = true
+	if r.v2 {
+		r.NextMarkerV2 = base64.RawURLEncoding.EncodeToString([]byte(handle + contTokenSepa + marker))
+	} else {
+		r.NextMarker = marker
+	}
 }
 
 func entryToS3(entry *cmn.BucketEntry) *ObjInfo {
@@ -89,14 +173,9 @@ func entryToS3(entry *cmn.BucketEntry) *ObjInfo {
 	}
 }
 
-func (r *ListObjectResult) FillFromAisBckList(bckList *cmn.BucketList) {
-	r.KeyCount = len(bckList.Entries)
-	r.IsTruncated = bckList.PageMarker != ""
-	r.NextMarker = bckList.PageMarker
-	for _, e := range bckList.Entries {
-		r.Add(e)
-	}
-}
+// SkipPrefixMarker returns the marker to list the objects that follow all the
+// objects with a given (common) prefix
+func SkipPrefixMarker(prefix string) string { return prefix + string(utf8.MaxRune) }
 
 func FormatTime(t time.Time) string {
 	s := t.UTC().Format(time.RFC1123)
@@ -104,15 +183,18 @@ func FormatTime(t time.Time) string {
 }
 
 func SetHeaderFromLOM(header http.Header, lom *cluster.LOM, size int64) {
-	if v, exists := lom.GetCustomMD(cluster.SourceObjMD); exists && v == cluster.SourceAmazonObjMD {
-		if v, exists := lom.GetCustomMD(cluster.MD5ObjMD); exists {
-			header.Set(headerETag, v)
-		}
+	if etag := ETag(lom); etag != "" {
+		SetETag(header, etag)
 	}
 	header.Set(headerAtime, FormatTime(lom.Atime()))
 	header.Set(cmn.HeaderContentLength, strconv.FormatInt(size, 10))
 	header.Set(cmn.HeaderContentType, GetContentType)
-	header.Set(headerVersion, lom.Version())
+	header.Set(HeaderVersion, VersionID(lom))
+	setTaggingCount(header, lom)
+	setUserMDHeaders(header, lom)
+	if v, exists := lom.GetCustomMD(encrypt.AlgMD); exists {
+		header.Set(HeaderSSE, v)
+	}
 }
 
 func (r *CopyObjectResult) MustMarshal() []byte {
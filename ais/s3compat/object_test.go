@@ -0,0 +1,76 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestListObjectQueryMaxKeys(t *testing.T) {
+	tests := []struct {
+		maxKeys  string
+		expected int
+		err      bool
+	}{
+		{maxKeys: "", expected: defaultMaxKeys},
+		{maxKeys: "0", expected: 0},
+		{maxKeys: "10", expected: 10},
+		{maxKeys: "100000", expected: defaultMaxKeys},
+		{maxKeys: "-1", err: true},
+		{maxKeys: "ten", err: true},
+	}
+	for _, test := range tests {
+		query := url.Values{}
+		if test.maxKeys != "" {
+			query.Set("max-keys", test.maxKeys)
+		}
+		q, err := ParseListObjectQuery(query)
+		if test.err {
+			tassert.Errorf(t, err != nil, "max-keys %q: expected error", test.maxKeys)
+			continue
+		}
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, q.MaxKeys == test.expected, "max-keys %q: expected %d, got %d",
+			test.maxKeys, test.expected, q.MaxKeys)
+	}
+}
+
+// V2: the continuation token carries the listing handle along with the marker
+func TestListObjectContToken(t *testing.T) {
+	const marker, handle = "dir/obj-099", "Hc7Y5Tlz"
+	resp := NewListObjectResult("bck", &ListObjectQuery{V2: true, MaxKeys: 100})
+	resp.Truncate(marker, handle)
+	tassert.Fatalf(t, resp.IsTruncated && resp.NextMarkerV2 != "", "expected truncated V2 page")
+
+	query := url.Values{URLParamListType: []string{"2"}, "continuation-token": []string{resp.NextMarkerV2}}
+	q, err := ParseListObjectQuery(query)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, q.Marker == marker && q.Handle == handle, "unexpected marker %q, handle %q", q.Marker, q.Handle)
+
+	// a token without the handle (e.g., issued before upgrade) is the marker
+	query.Set("continuation-token", base64.RawURLEncoding.EncodeToString([]byte(marker)))
+	q, err = ParseListObjectQuery(query)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, q.Marker == marker && q.Handle == "", "unexpected marker %q, handle %q", q.Marker, q.Handle)
+
+	// V1: the marker is the object name
+	resp = NewListObjectResult("bck", &ListObjectQuery{MaxKeys: 100})
+	resp.Truncate(marker, handle)
+	tassert.Errorf(t, resp.NextMarker == marker, "expected next marker %q, got %q", marker, resp.NextMarker)
+}
+
+func TestListObjectEmptyPage(t *testing.T) {
+	q, err := ParseListObjectQuery(url.Values{"max-keys": []string{"0"}, "marker": []string{"obj"}})
+	tassert.CheckFatal(t, err)
+	b := string(NewListObjectResult("bck", q).MustMarshal())
+	tassert.Errorf(t,
+		strings.Contains(b, "<IsTruncated>false</IsTruncated>") && strings.Contains(b, "<KeyCount>0</KeyCount>"),
+		"expected an empty, not truncated, page: %s", b)
+}
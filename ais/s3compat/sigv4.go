@@ -0,0 +1,340 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AWS Signature Version 4 (SigV4) verification - both presigned URLs (query
+// string authentication) and signed requests (the `Authorization` header).
+// See https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-authenticating-requests.html
+//
+// The caller provides the secret key that corresponds to the access key ID
+// found in the request (see SecretKeyFunc). The payload, unless unsigned,
+// gets verified as it is read (see VerifyPayload).
+
+const (
+	sigV4Algorithm = "AWS4-HMAC-SHA256"
+	sigV4Request   = "aws4_request"
+	sigV4Service   = "s3"
+	sigV4TimeFmt   = "20060102T150405Z"
+	sigV4DateFmt   = "20060102"
+
+	// presigned URL query parameters
+	URLParamAlgorithm     = "X-Amz-Algorithm"
+	URLParamCredential    = "X-Amz-Credential"
+	URLParamDate          = "X-Amz-Date"
+	URLParamExpires       = "X-Amz-Expires"
+	URLParamSignedHeaders = "X-Amz-SignedHeaders"
+	URLParamSignature     = "X-Amz-Signature"
+
+	headerDate          = "X-Amz-Date"
+	headerContentSHA256 = "X-Amz-Content-Sha256"
+	unsignedPayload     = "UNSIGNED-PAYLOAD"
+
+	maxPresignedExpires = 7 * 24 * time.Hour // as per AWS
+	maxClockSkew        = 15 * time.Minute
+)
+
+var (
+	ErrNoSignature      = errors.New("request is not signed")
+	ErrSignatureInvalid = errors.New("the request signature does not match the signature computed with the provided credentials")
+	ErrRequestExpired   = errors.New("request has expired")
+	ErrContentSHA256    = errors.New("the provided 'x-amz-content-sha256' header does not match what was computed")
+)
+
+type (
+	// returns the secret key for a given access key ID
+	SecretKeyFunc func(accessKey string) (secretKey string, err error)
+
+	sigV4 struct {
+		accessKey     string
+		date          string // YYYYMMDD (credential scope)
+		region        string
+		signedHeaders []string
+		signature     string
+		amzDate       time.Time
+		expires       time.Duration // presigned URL only
+		presigned     bool
+		payloadHash   string
+	}
+	// computes the hash of the payload as it is read
+	payloadReader struct {
+		io.ReadCloser
+		h        hash.Hash
+		expected []byte
+	}
+)
+
+// IsSigned returns true if the request carries SigV4 signature - either in the
+// URL (presigned) or in the `Authorization` header
+func IsSigned(r *http.Request) bool {
+	return r.URL.Query().Get(URLParamSignature) != "" ||
+		strings.HasPrefix(r.Header.Get("Authorization"), sigV4Algorithm)
+}
+
+// VerifySigV4 verifies the request's signature and its expiration; returns
+// the access key ID that the request has been signed with
+func VerifySigV4(r *http.Request, secretFn SecretKeyFunc) (accessKey string, err error) {
+	return verifySigV4(r, secretFn, time.Now())
+}
+
+func verifySigV4(r *http.Request, secretFn SecretKeyFunc, now time.Time) (accessKey string, err error) {
+	var (
+		sig       *sigV4
+		secretKey string
+		query     = r.URL.Query()
+	)
+	if query.Get(URLParamSignature) != "" {
+		sig, err = parsePresigned(query)
+	} else if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, sigV4Algorithm) {
+		sig, err = parseAuthHeader(auth, r.Header)
+	} else {
+		return "", ErrNoSignature
+	}
+	if err != nil {
+		return "", err
+	}
+	if err = sig.checkTime(now); err != nil {
+		return "", err
+	}
+	if secretKey, err = secretFn(sig.accessKey); err != nil {
+		return "", err
+	}
+	expected := sig.sign(secretKey, sig.canonicalRequest(r))
+	if !hmac.Equal([]byte(expected), []byte(sig.signature)) {
+		return "", ErrSignatureInvalid
+	}
+	if !sig.presigned {
+		if err = VerifyPayload(r); err != nil {
+			return "", err
+		}
+	}
+	return sig.accessKey, nil
+}
+
+// VerifyPayload makes sure that the request's body matches its (signed)
+// `X-Amz-Content-Sha256` header, if any: the body gets wrapped so that reading
+// it fails at the end on mismatch. Unsigned payloads are accepted as is;
+// chunked (streaming) payload signing is not supported.
+// NOTE: the body may be read elsewhere (e.g., by the target the request gets
+// redirected to), which must call VerifyPayload as well.
+func VerifyPayload(r *http.Request) error {
+	payloadHash := r.Header.Get(headerContentSHA256)
+	if payloadHash == "" || payloadHash == unsignedPayload || r.Body == nil {
+		return nil
+	}
+	if _, ok := r.Body.(*payloadReader); ok {
+		return nil
+	}
+	expected, err := hex.DecodeString(payloadHash)
+	if err != nil || len(expected) != sha256.Size {
+		return fmt.Errorf("unsupported %s %q", headerContentSHA256, payloadHash)
+	}
+	r.Body = &payloadReader{ReadCloser: r.Body, h: sha256.New(), expected: expected}
+	return nil
+}
+
+func (pr *payloadReader) Read(b []byte) (n int, err error) {
+	n, err = pr.ReadCloser.Read(b)
+	pr.h.Write(b[:n])
+	if err == io.EOF && !hmac.Equal(pr.h.Sum(nil), pr.expected) {
+		err = ErrContentSHA256
+	}
+	return
+}
+
+func parsePresigned(query url.Values) (sig *sigV4, err error) {
+	if algo := query.Get(URLParamAlgorithm); algo != sigV4Algorithm {
+		return nil, fmt.Errorf("unsupported signature algorithm %q", algo)
+	}
+	sig = &sigV4{presigned: true, payloadHash: unsignedPayload, signature: query.Get(URLParamSignature)}
+	if err = sig.parseCredential(query.Get(URLParamCredential)); err != nil {
+		return nil, err
+	}
+	if sig.amzDate, err = time.Parse(sigV4TimeFmt, query.Get(URLParamDate)); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", URLParamDate, err)
+	}
+	secs, err := strconv.ParseInt(query.Get(URLParamExpires), 10, 64)
+	if err != nil || secs <= 0 || time.Duration(secs)*time.Second > maxPresignedExpires {
+		return nil, fmt.Errorf("invalid %s %q", URLParamExpires, query.Get(URLParamExpires))
+	}
+	sig.expires = time.Duration(secs) * time.Second
+	sig.signedHeaders = strings.Split(query.Get(URLParamSignedHeaders), ";")
+	if err = sig.checkSignedHeaders(); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+// Authorization: AWS4-HMAC-SHA256 Credential=<...>, SignedHeaders=<...>, Signature=<...>
+func parseAuthHeader(auth string, header http.Header) (sig *sigV4, err error) {
+	sig = &sigV4{payloadHash: header.Get(headerContentSHA256)}
+	if sig.payloadHash == "" {
+		sig.payloadHash = unsignedPayload
+	}
+	for _, kv := range strings.Split(strings.TrimPrefix(auth, sigV4Algorithm), ",") {
+		kv = strings.TrimSpace(kv)
+		idx := strings.Index(kv, "=")
+		if idx < 0 {
+			return nil, fmt.Errorf("invalid Authorization header %q", auth)
+		}
+		switch key, val := kv[:idx], kv[idx+1:]; key {
+		case "Credential":
+			err = sig.parseCredential(val)
+		case "SignedHeaders":
+			sig.signedHeaders = strings.Split(val, ";")
+		case "Signature":
+			sig.signature = val
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if sig.accessKey == "" || sig.signature == "" || len(sig.signedHeaders) == 0 {
+		return nil, fmt.Errorf("invalid Authorization header %q", auth)
+	}
+	if err = sig.checkSignedHeaders(); err != nil {
+		return nil, err
+	}
+	if sig.amzDate, err = time.Parse(sigV4TimeFmt, header.Get(headerDate)); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", headerDate, err)
+	}
+	return sig, nil
+}
+
+// <access key>/<YYYYMMDD>/<region>/s3/aws4_request
+func (sig *sigV4) parseCredential(cred string) error {
+	parts := strings.Split(cred, "/")
+	if len(parts) != 5 || parts[0] == "" || parts[3] != sigV4Service || parts[4] != sigV4Request {
+		return fmt.Errorf("invalid credential %q", cred)
+	}
+	sig.accessKey, sig.date, sig.region = parts[0], parts[1], parts[2]
+	return nil
+}
+
+// the host must be signed (as per AWS) - otherwise, the request could be
+// replayed against any other endpoint that trusts the same credentials
+func (sig *sigV4) checkSignedHeaders() error {
+	for _, name := range sig.signedHeaders {
+		if name == "host" {
+			return nil
+		}
+	}
+	return fmt.Errorf("signed headers %q must include \"host\"", strings.Join(sig.signedHeaders, ";"))
+}
+
+func (sig *sigV4) checkTime(now time.Time) error {
+	if sig.amzDate.Format(sigV4DateFmt) != sig.date {
+		return fmt.Errorf("credential date %q does not match request date %s", sig.date, sig.amzDate.Format(sigV4TimeFmt))
+	}
+	if sig.amzDate.After(now.Add(maxClockSkew)) {
+		return fmt.Errorf("request date %s is in the future", sig.amzDate.Format(sigV4TimeFmt))
+	}
+	if sig.presigned {
+		if now.After(sig.amzDate.Add(sig.expires)) {
+			return ErrRequestExpired
+		}
+	} else if now.After(sig.amzDate.Add(maxClockSkew)) {
+		return ErrRequestExpired
+	}
+	return nil
+}
+
+func (sig *sigV4) canonicalRequest(r *http.Request) string {
+	var (
+		sb      strings.Builder
+		headers = make([]string, 0, len(sig.signedHeaders))
+	)
+	for _, name := range sig.signedHeaders {
+		var val string
+		if name == "host" {
+			val = r.Host
+		} else {
+			val = strings.Join(r.Header[http.CanonicalHeaderKey(name)], ",")
+		}
+		headers = append(headers, name+":"+strings.Join(strings.Fields(val), " ")+"\n")
+	}
+	sb.WriteString(r.Method)
+	sb.WriteByte('\n')
+	sb.WriteString(uriEncode(r.URL.Path, false))
+	sb.WriteByte('\n')
+	sb.WriteString(canonicalQuery(r.URL.Query()))
+	sb.WriteByte('\n')
+	sb.WriteString(strings.Join(headers, ""))
+	sb.WriteByte('\n')
+	sb.WriteString(strings.Join(sig.signedHeaders, ";"))
+	sb.WriteByte('\n')
+	sb.WriteString(sig.payloadHash)
+	return sb.String()
+}
+
+func (sig *sigV4) sign(secretKey, canonicalRequest string) string {
+	var (
+		scope = strings.Join([]string{sig.date, sig.region, sigV4Service, sigV4Request}, "/")
+		hash  = sha256.Sum256([]byte(canonicalRequest))
+		sts   = strings.Join([]string{sigV4Algorithm, sig.amzDate.Format(sigV4TimeFmt), scope,
+			hex.EncodeToString(hash[:])}, "\n")
+		key = hmacSHA256([]byte("AWS4"+secretKey), sig.date)
+	)
+	key = hmacSHA256(key, sig.region)
+	key = hmacSHA256(key, sigV4Service)
+	key = hmacSHA256(key, sigV4Request)
+	return hex.EncodeToString(hmacSHA256(key, sts))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// all query parameters but the signature itself, sorted by name
+func canonicalQuery(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for k := range query {
+		if k != URLParamSignature {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	params := make([]string, 0, len(keys))
+	for _, k := range keys {
+		vals := query[k]
+		sort.Strings(vals)
+		for _, v := range vals {
+			params = append(params, uriEncode(k, true)+"="+uriEncode(v, true))
+		}
+	}
+	return strings.Join(params, "&")
+}
+
+// URI-encodes all but the unreserved characters (and, optionally, slashes)
+func uriEncode(s string, encodeSlash bool) string {
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' || (c == '/' && !encodeSlash) {
+			sb.WriteByte(c)
+		} else {
+			fmt.Fprintf(&sb, "%%%02X", c)
+		}
+	}
+	return sb.String()
+}
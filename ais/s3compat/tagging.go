@@ -0,0 +1,139 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Object tagging
+//
+// The tags are stored URL-encoded (same as in the x-amz-tagging header),
+// as a single entry of the object's custom metadata (see cluster.S3TagsObjMD).
+
+const (
+	URLParamTagging    = "tagging"
+	HeaderTagging      = "x-amz-tagging"
+	headerTaggingCount = "x-amz-tagging-count"
+
+	MaxTags         = 10
+	maxTagKeyLen    = 128
+	maxTagValueLen  = 256
+	maxTagsEncodLen = 2048 // the object's metadata must fit into its xattr (see cluster.LOM.Persist)
+)
+
+type (
+	Tagging struct {
+		XMLName xml.Name `xml:"Tagging"`
+		Ns      string   `xml:"xmlns,attr"`
+		TagSet  []*Tag   `xml:"TagSet>Tag"`
+	}
+	Tag struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	}
+)
+
+func NewTagging(tags url.Values) *Tagging {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	tagging := &Tagging{Ns: s3Namespace, TagSet: make([]*Tag, 0, len(tags))}
+	for _, k := range keys {
+		tagging.TagSet = append(tagging.TagSet, &Tag{Key: k, Value: tags.Get(k)})
+	}
+	return tagging
+}
+
+func (t *Tagging) MustMarshal() []byte {
+	b, err := xml.Marshal(t)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+// Tags validates the tag set and returns the tags
+func (t *Tagging) Tags() (url.Values, error) {
+	tags := make(url.Values, len(t.TagSet))
+	for _, tag := range t.TagSet {
+		if _, ok := tags[tag.Key]; ok {
+			return nil, fmt.Errorf("duplicate tag key %q", tag.Key)
+		}
+		tags.Set(tag.Key, tag.Value)
+	}
+	return tags, validateTags(tags)
+}
+
+// ParseTaggingHeader parses the tags in the x-amz-tagging header: "k1=v1&k2=v2"
+func ParseTaggingHeader(s string) (url.Values, error) {
+	tags, err := url.ParseQuery(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s %q: %v", HeaderTagging, s, err)
+	}
+	for k, vals := range tags {
+		if len(vals) > 1 {
+			return nil, fmt.Errorf("duplicate tag key %q", k)
+		}
+	}
+	return tags, validateTags(tags)
+}
+
+func validateTags(tags url.Values) error {
+	if len(tags) > MaxTags {
+		return fmt.Errorf("too many tags: %d (max %d)", len(tags), MaxTags)
+	}
+	for k := range tags {
+		if k == "" || len(k) > maxTagKeyLen {
+			return fmt.Errorf("invalid tag key %q: expecting 1 to %d characters", k, maxTagKeyLen)
+		}
+		if v := tags.Get(k); len(v) > maxTagValueLen {
+			return fmt.Errorf("invalid value of the tag %q: exceeds %d characters", k, maxTagValueLen)
+		}
+	}
+	if l := len(tags.Encode()); l > maxTagsEncodLen {
+		return fmt.Errorf("tags are too long: %d bytes encoded (max %d)", l, maxTagsEncodLen)
+	}
+	return nil
+}
+
+// GetTags returns the object's tags (empty if none)
+func GetTags(lom *cluster.LOM) url.Values {
+	if s, ok := lom.GetCustomMD(cluster.S3TagsObjMD); ok {
+		if tags, err := url.ParseQuery(s); err == nil {
+			return tags
+		}
+	}
+	return url.Values{}
+}
+
+// SetTags replaces the object's tags (in memory - the caller persists the
+// object's metadata); empty tags remove the existing ones
+func SetTags(lom *cluster.LOM, tags url.Values) {
+	md := make(cmn.SimpleKVs, len(lom.CustomMD())+1)
+	for k, v := range lom.CustomMD() {
+		md[k] = v
+	}
+	if len(tags) == 0 {
+		delete(md, cluster.S3TagsObjMD)
+	} else {
+		md[cluster.S3TagsObjMD] = tags.Encode()
+	}
+	lom.SetCustomMD(md)
+}
+
+func setTaggingCount(header http.Header, lom *cluster.LOM) {
+	if tags := GetTags(lom); len(tags) > 0 {
+		header.Set(headerTaggingCount, strconv.Itoa(len(tags)))
+	}
+}
@@ -0,0 +1,92 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestParseTaggingHeader(t *testing.T) {
+	tooMany := make([]string, 0, MaxTags+1)
+	for i := 0; i <= MaxTags; i++ {
+		tooMany = append(tooMany, fmt.Sprintf("k%d=v", i))
+	}
+	tests := []struct {
+		header string
+		tags   url.Values
+		fail   bool
+	}{
+		{header: "", tags: url.Values{}},
+		{header: "project=ais&owner=", tags: url.Values{"project": {"ais"}, "owner": {""}}},
+		{header: "a%20b=c%26d", tags: url.Values{"a b": {"c&d"}}},
+
+		{header: "k=v1&k=v2", fail: true},
+		{header: "=v", fail: true},
+		{header: "k=%zz", fail: true},
+		{header: strings.Repeat("k", maxTagKeyLen+1) + "=v", fail: true},
+		{header: "k=" + strings.Repeat("v", maxTagValueLen+1), fail: true},
+		{header: strings.Join(tooMany, "&"), fail: true},
+	}
+	for _, test := range tests {
+		tags, err := ParseTaggingHeader(test.header)
+		if test.fail {
+			tassert.Errorf(t, err != nil, "%q: expected error", test.header)
+			continue
+		}
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, tags.Encode() == test.tags.Encode(), "%q: expected %v, got %v", test.header, test.tags, tags)
+	}
+}
+
+func TestTaggingXML(t *testing.T) {
+	tags := url.Values{"b": {"2"}, "a": {"1"}}
+	b := NewTagging(tags).MustMarshal()
+	tassert.Errorf(t, strings.Index(string(b), "<Key>a</Key>") < strings.Index(string(b), "<Key>b</Key>"),
+		"expected the tags sorted by key: %s", b)
+
+	// PUT ?tagging body, as returned by GET ?tagging
+	tagging := &Tagging{}
+	tassert.CheckFatal(t, xml.Unmarshal(b, tagging))
+	parsed, err := tagging.Tags()
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, parsed.Encode() == tags.Encode(), "expected %v, got %v", tags, parsed)
+
+	tagging.TagSet = append(tagging.TagSet, &Tag{Key: "a", Value: "3"})
+	_, err = tagging.Tags()
+	tassert.Errorf(t, err != nil, "expected error for duplicate tag keys")
+}
+
+func TestObjTags(t *testing.T) {
+	lom := &cluster.LOM{}
+	lom.SetCustomMD(cmn.SimpleKVs{cluster.SourceObjMD: cluster.SourceAmazonObjMD})
+	header := http.Header{}
+	setTaggingCount(header, lom)
+	tassert.Errorf(t, len(GetTags(lom)) == 0, "expected no tags")
+	tassert.Errorf(t, header.Get(headerTaggingCount) == "", "expected no tagging count")
+
+	tags := url.Values{"project": {"ais"}, "tier": {"hot"}}
+	SetTags(lom, tags)
+	tassert.Errorf(t, GetTags(lom).Encode() == tags.Encode(), "expected %v, got %v", tags, GetTags(lom))
+	setTaggingCount(header, lom)
+	tassert.Errorf(t, header.Get(headerTaggingCount) == "2", "expected tagging count 2, got %q",
+		header.Get(headerTaggingCount))
+	_, ok := lom.GetCustomMD(cluster.SourceObjMD)
+	tassert.Errorf(t, ok, "expected the other custom metadata to be kept")
+
+	SetTags(lom, nil)
+	_, ok = lom.GetCustomMD(cluster.S3TagsObjMD)
+	tassert.Errorf(t, !ok, "expected the tags to be removed")
+	_, ok = lom.GetCustomMD(cluster.SourceObjMD)
+	tassert.Errorf(t, ok, "expected the other custom metadata to be kept")
+}
@@ -0,0 +1,153 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Object versions
+//
+// S3 version ID is the AIS object version (see cluster.LOM.KeepVersion);
+// the objects put before versioning was enabled have "null" version ID.
+// ListObjectVersions paginates by keys: max-keys limits the number of the
+// current versions (objects) in the page, and all the previous versions of
+// the keys in the page are returned along with them.
+
+const (
+	URLParamVersions  = "versions"  // ListObjectVersions
+	URLParamVersionID = "versionId" // GET, HEAD, and DELETE a given version
+
+	NullVersionID = "null"
+)
+
+type (
+	// List object versions response
+	ListVersionsResult struct {
+		XMLName         xml.Name      `xml:"ListVersionsResult"`
+		Ns              string        `xml:"xmlns,attr"`
+		Name            string        `xml:"Name"` // bucket
+		Prefix          string        `xml:"Prefix"`
+		KeyMarker       string        `xml:"KeyMarker"`
+		VersionIDMarker string        `xml:"VersionIdMarker"`
+		NextKeyMarker   string        `xml:"NextKeyMarker,omitempty"`
+		MaxKeys         int           `xml:"MaxKeys"`
+		IsTruncated     bool          `xml:"IsTruncated"`
+		Versions        []*ObjVersion `xml:"Version"`
+	}
+	ObjVersion struct {
+		Key          string `xml:"Key"`
+		VersionID    string `xml:"VersionId"`
+		IsLatest     bool   `xml:"IsLatest"`
+		LastModified string `xml:"LastModified"`
+		ETag         string `xml:"ETag"`
+		Size         int64  `xml:"Size"`
+		num          uint64 // (to sort the versions of the same key)
+	}
+
+	// List object versions request (query)
+	ListVersionsQuery struct {
+		Prefix    string
+		KeyMarker string // list the versions of the objects that follow
+		MaxKeys   int
+	}
+)
+
+// VersionID returns S3 version ID of the object
+func VersionID(lom *cluster.LOM) string {
+	if lom.Version() == "" {
+		return NullVersionID
+	}
+	return lom.Version()
+}
+
+func ParseListVersionsQuery(query url.Values) (*ListVersionsQuery, error) {
+	q := &ListVersionsQuery{
+		Prefix:    query.Get("prefix"),
+		KeyMarker: query.Get("key-marker"),
+		MaxKeys:   defaultMaxKeys,
+	}
+	if mxStr := query.Get("max-keys"); mxStr != "" {
+		maxKeys, err := strconv.Atoi(mxStr)
+		if err != nil || maxKeys <= 0 {
+			return nil, fmt.Errorf("invalid max-keys %q", mxStr)
+		}
+		q.MaxKeys = cmn.Min(maxKeys, defaultMaxKeys)
+	}
+	return q, nil
+}
+
+func NewListVersionsResult(bucket string, q *ListVersionsQuery) *ListVersionsResult {
+	return &ListVersionsResult{
+		Ns:        s3Namespace,
+		Name:      bucket,
+		Prefix:    q.Prefix,
+		KeyMarker: q.KeyMarker,
+		MaxKeys:   q.MaxKeys,
+		Versions:  make([]*ObjVersion, 0),
+	}
+}
+
+func (r *ListVersionsResult) MustMarshal() []byte {
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+// Fill fills the response with the current versions of the objects (a page
+// of the object list) and the previous ones. If the object list is truncated,
+// the previous versions of the objects that follow its last object are left
+// for the next page.
+func (r *ListVersionsResult) Fill(current *cmn.BucketList, prev []*cmn.BucketEntry) {
+	var lastKey string
+	if current.PageMarker != "" && len(current.Entries) > 0 {
+		lastKey = current.Entries[len(current.Entries)-1].Name
+		r.IsTruncated, r.NextKeyMarker = true, lastKey
+	}
+	for _, entry := range current.Entries {
+		v := entryToVersion(entry)
+		v.IsLatest = true
+		r.Versions = append(r.Versions, v)
+	}
+	for _, entry := range prev {
+		if lastKey == "" || entry.Name <= lastKey {
+			r.Versions = append(r.Versions, entryToVersion(entry))
+		}
+	}
+	// by key and, within the key, the latest first
+	sort.Slice(r.Versions, func(i, j int) bool {
+		vi, vj := r.Versions[i], r.Versions[j]
+		if vi.Key != vj.Key {
+			return vi.Key < vj.Key
+		}
+		if vi.IsLatest != vj.IsLatest {
+			return vi.IsLatest
+		}
+		return vi.num > vj.num
+	})
+}
+
+func entryToVersion(entry *cmn.BucketEntry) *ObjVersion {
+	v := &ObjVersion{
+		Key:          entry.Name,
+		VersionID:    entry.Version,
+		LastModified: entry.Atime,
+		ETag:         entry.Checksum,
+		Size:         entry.Size,
+	}
+	if v.VersionID == "" {
+		v.VersionID = NullVersionID
+	} else {
+		v.num, _ = strconv.ParseUint(v.VersionID, 10, 64)
+	}
+	return v
+}
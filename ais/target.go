@@ -21,6 +21,7 @@ import (
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/ais/cloud"
+	"github.com/NVIDIA/aistore/ais/s3compat"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -28,6 +29,7 @@ import (
 	"github.com/NVIDIA/aistore/dbdriver"
 	"github.com/NVIDIA/aistore/dsort"
 	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/encrypt"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/mirror"
@@ -42,12 +44,17 @@ const (
 	bucketMDFixup    = "fixup"
 	bucketMDReceive  = "receive"
 	bucketMDRegister = "register"
-	dbName           = "ais.db"
+	dbName           = "ais.db"     // BuntDB (older versions)
+	dbLogFname       = ".ais.kvlog" // see initDB
 
 	nodeRestartedMarker = ".noderestarted"
 )
 const (
 	clusterClockDrift = 5 * time.Millisecond // is expected to be bounded by
+	rebGetWaitTime    = 2 * time.Second      // GET: max time to wait for the object in transit (rebalance)
+	spillRetryAfter   = 5                    // PUT: seconds for the client to wait while spilling (lru.spill_on_oos)
+	// PUT: max time to wait for spilling to free up the space (ditto)
+	spillWaitTime = 2 * time.Second
 )
 
 type (
@@ -73,8 +80,9 @@ type (
 	}
 	capUsed struct {
 		sync.RWMutex
-		used int32
-		oos  bool
+		used      int32
+		oos       bool
+		misplaced bool // has been out of space since the last rebalance (see capOOS)
 	}
 	clouds struct {
 		ais *cloud.AisCloudProvider
@@ -88,8 +96,15 @@ type (
 		fsprg        fsprungroup
 		rebManager   *reb.Manager
 		dbDriver     dbdriver.Driver
+		opJournal    *opJournal
+		xhistory     xactHistory
+		warmUp       warmUpPool
+		objWaiters   objWaiters
+		mptUploads   mptUploads
+		bckEvents    bckEvents
 		capUsed      capUsed
 		transactions transactions
+		repairing    sync.Map // uname => corrupted object being repaired (see repairAsync)
 		gfn          struct {
 			local  localGFN
 			global globalGFN
@@ -188,15 +203,7 @@ func (t *targetrunner) Run() error {
 	t.statsT.RegisterAll()
 	t.httprunner.keepalive = gettargetkeepalive()
 
-	t.checkRestarted()
-
-	// register object type and workfile type
-	if err := fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{}); err != nil {
-		cmn.ExitLogf("%v", err)
-	}
-	if err := fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{}); err != nil {
-		cmn.ExitLogf("%v", err)
-	}
+	unclean := t.checkRestarted()
 
 	dryRunInit()
 	t.gfn.local.tag, t.gfn.global.tag = "local GFN", "global GFN"
@@ -256,14 +263,17 @@ func (t *targetrunner) Run() error {
 	t.authn = &authManager{
 		tokens:        make(map[string]*cmn.AuthToken),
 		revokedTokens: make(map[string]bool),
+		s3Tokens:      make(map[string]string),
 		version:       1,
 	}
-	driver, err := dbdriver.NewBuntDB(filepath.Join(config.Confdir, dbName))
+	driver, err := t.initDB(config)
 	if err != nil {
 		glog.Errorf("Failed to initialize DB: %v", err)
 		return err
 	}
 	t.dbDriver = driver
+	t.opJournal = newOpJournal()
+	t.xhistory.init(t)
 	defer func() {
 		debug.AssertNoErr(driver.Close())
 	}()
@@ -282,9 +292,16 @@ func (t *targetrunner) Run() error {
 		transport.SetMux(cmn.NetworkIntraData, t.intraDataServer.mux)
 	}
 	t.initRecvHandlers()
+	errCh := t.serve() // (not ready yet - see markReady below)
 
+	if err := fs.Mountpaths.SetContentRouting(config.ContentRouting.Rules); err != nil {
+		cmn.ExitLogf("%v", err)
+	}
 	t.rebManager = reb.NewManager(t, config, getstorstatsrunner())
-	ec.Init(t, xaction.Registry)
+	if err := ec.Init(t, xaction.Registry); err != nil {
+		cmn.ExitLogf("%v", err)
+	}
+	go t.resumeECEncode()
 
 	aborted, _ := reb.IsRebalancing(cmn.ActResilver)
 	if aborted {
@@ -292,14 +309,14 @@ func (t *targetrunner) Run() error {
 			glog.Infoln("resuming resilver...")
 			t.rebManager.RunResilver("", false /*skipGlobMisplaced*/)
 		}()
+	} else if unclean {
+		go t.runDeltaResilver()
 	}
 
 	dsort.InitManagers(driver)
 	dsort.RegisterNode(t.owner.smap, t.owner.bmd, t.si, t.gmm, t, t.statsT)
-	if err := t.httprunner.run(); err != nil {
-		return err
-	}
-	return nil
+	t.markReady()
+	return <-errCh
 }
 
 func (c *clouds) init(t *targetrunner, config *cmn.Config) {
@@ -360,23 +377,63 @@ func (t *targetrunner) initRecvHandlers() {
 	t.registerNetworkHandlers(networkHandlers)
 }
 
+// the DB that subsystems use to persist their state: a log file per mountpath
+// (see dbdriver.LogDriver); the content of the older (BuntDB) one is imported once
+func (t *targetrunner) initDB(config *cmn.Config) (driver *dbdriver.LogDriver, err error) {
+	var (
+		availablePaths, _ = fs.Mountpaths.Get()
+		paths             = make([]string, 0, len(availablePaths))
+	)
+	for mpath := range availablePaths {
+		paths = append(paths, filepath.Join(mpath, dbLogFname))
+	}
+	if len(paths) == 0 {
+		paths = append(paths, filepath.Join(config.Confdir, dbLogFname))
+	}
+	if driver, err = dbdriver.NewLogDriver(paths...); err != nil {
+		return
+	}
+	oldPath := filepath.Join(config.Confdir, dbName)
+	if err := fs.Access(oldPath); err != nil {
+		return driver, nil
+	}
+	n, err := dbdriver.ImportBuntDB(oldPath, driver)
+	if err != nil {
+		driver.Close()
+		return nil, fmt.Errorf("failed to import %s: %v", oldPath, err)
+	}
+	glog.Infof("%s: imported %d record(s) from %s", t.si, n, oldPath)
+	if err := os.Rename(oldPath, oldPath+".imported"); err != nil {
+		glog.Errorf("%s: %v", t.si, err)
+	}
+	return driver, nil
+}
+
 // stop gracefully
-func (t *targetrunner) Stop(err error) {
+func (t *targetrunner) Stop(err error) { t.StopCtx(context.Background(), err) }
+
+func (t *targetrunner) StopCtx(ctx context.Context, err error) {
 	glog.Infof("Stopping %s, err: %v", t.GetRunName(), err)
 	xaction.Registry.AbortAll()
+	if t.dbDriver != nil {
+		t.xhistory.record()
+	}
 	if t.publicServer.s != nil {
 		t.unregister() // ignore errors
 	}
+	t.putCleanShutdown(time.Now()) // see runDeltaResilver
 
-	t.httprunner.stop(err)
+	t.httprunner.stop(ctx, err)
 }
 
-func (t *targetrunner) checkRestarted() {
+// returns true if the target restarts after unclean shutdown
+func (t *targetrunner) checkRestarted() (unclean bool) {
 	if fs.MarkerExists(nodeRestartedMarker) {
 		t.statsT.Add(stats.RestartCount, 1)
-	} else {
-		fs.PutMarker(nodeRestartedMarker)
+		return true
 	}
+	fs.PutMarker(nodeRestartedMarker)
+	return false
 }
 
 //
@@ -443,7 +500,7 @@ func (t *targetrunner) httpbckget(w http.ResponseWriter, r *http.Request) {
 		} else {
 			bck, err := newBckFromQuery("", r.URL.Query())
 			if err != nil {
-				t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+				t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 				return
 			}
 			t.listBuckets(w, r, cmn.QueryBcks(bck.Bck))
@@ -475,7 +532,7 @@ func (t *targetrunner) validRedirect(w http.ResponseWriter, r *http.Request, tag
 	}
 	if smap.GetProxy(pid) == nil {
 		err := &errNodeNotFound{tag + " from unknown", pid, t.si, smap}
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		pid = ""
 	}
 	return
@@ -504,7 +561,7 @@ func (t *targetrunner) sendECCT(w http.ResponseWriter, r *http.Request, apiItems
 	bucket, objName := apiItems[0], apiItems[1]
 	bck, err := newBckFromQuery(bucket, r.URL.Query())
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 	lom := &cluster.LOM{T: t, ObjName: objName}
@@ -514,7 +571,7 @@ func (t *targetrunner) sendECCT(w http.ResponseWriter, r *http.Request, apiItems
 			err = lom.Init(bck.Bck, config)
 		}
 		if err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 			return
 		}
 	}
@@ -524,15 +581,24 @@ func (t *targetrunner) sendECCT(w http.ResponseWriter, r *http.Request, apiItems
 		t.invalmsghdlrsilent(w, r, err.Error(), http.StatusNotFound)
 		return
 	}
-	file, err := os.Open(sliceFQN)
+	size := finfo.Size()
+	md, err := ec.LoadMetadata(lom.ParsedFQN.MpathInfo.MakePathFQN(bck.Bck, ec.MetaType, objName))
+	if err != nil {
+		t.invalmsghdlrsilent(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	if md.Compressed {
+		size = ec.SliceSize(md.Size, md.Data)
+	}
+	file, err := ec.OpenSlice(sliceFQN, md)
 	if err != nil {
 		t.fshc(err, sliceFQN)
-		t.invalmsghdlr(w, r, err.Error(), http.StatusInternalServerError)
+		t.invalmsghdlrErr(w, r, err, http.StatusInternalServerError)
 		return
 	}
 
-	buf, slab := t.gmm.Alloc(finfo.Size())
-	w.Header().Set("Content-Length", strconv.FormatInt(finfo.Size(), 10))
+	buf, slab := t.gmm.Alloc(size)
+	w.Header().Set("Content-Length", strconv.FormatInt(size, 10))
 	_, err = io.CopyBuffer(w, file, buf)
 	slab.Free(buf)
 	debug.AssertNoErr(file.Close())
@@ -588,7 +654,7 @@ func (t *targetrunner) httpobjget(w http.ResponseWriter, r *http.Request) {
 	}
 	bck, err := newBckFromQuery(bucket, r.URL.Query())
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 	lom := &cluster.LOM{T: t, ObjName: objName}
@@ -598,26 +664,43 @@ func (t *targetrunner) httpobjget(w http.ResponseWriter, r *http.Request) {
 			err = lom.Init(bck.Bck, config)
 		}
 		if err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 			return
 		}
 	}
+	if query.Get(cmn.URLParamObjVersion) != "" || query.Get(cmn.URLParamAsOf) != "" {
+		t.getObjVersion(w, r, lom)
+		return
+	}
 	goi := &getObjInfo{
-		started: started,
-		t:       t,
-		lom:     lom,
-		w:       w,
-		ctx:     context.Background(),
-		ranges:  cmn.RangesQuery{Range: r.Header.Get(cmn.HeaderRange), Size: 0},
-		isGFN:   isGFNRequest,
-		chunked: config.Net.HTTP.Chunked,
+		started:   started,
+		t:         t,
+		lom:       lom,
+		w:         w,
+		ctx:       context.Background(),
+		ranges:    cmn.RangesQuery{Range: r.Header.Get(cmn.HeaderRange), Size: 0},
+		isGFN:     isGFNRequest,
+		chunked:   config.Net.HTTP.Chunked,
+		redirReq:  r,
+		withProps: cmn.IsParseBool(query.Get(cmn.URLParamObjProps)),
+	}
+	if lom.Bck().IsRemoteAIS() && config.RemoteCache.Enabled {
+		if clom, err := t.remoteCacheLOM(lom, config); err == nil {
+			goi.lom, goi.remote = clom, lom
+		} else if glog.FastV(4, glog.SmoduleAIS) {
+			glog.Infof("%s: not caching %s, err: %v", t.si, lom, err)
+		}
 	}
 	if err, errCode := goi.getObject(); err != nil {
 		if cmn.IsErrConnectionReset(err) {
 			glog.Errorf("GET %s: %v", lom, err)
 		} else {
-			t.invalmsghdlr(w, r, err.Error(), errCode)
+			t.invalmsghdlrErr(w, r, err, errCode)
 		}
+		return
+	}
+	if goi.redirected == nil {
+		t.journal(lom, cmn.JournalGet, "", t.requester(r))
 	}
 }
 
@@ -636,19 +719,19 @@ func (t *targetrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 	if tid, pid, query = t.validRedirect(w, r, r.Method, true); tid == "" && pid == "" {
 		return
 	}
+	if cmn.IsECPackName(objName) {
+		t.invalmsghdlrstatusf(w, r, http.StatusForbidden, "%q: reserved name (see %s)", objName, cmn.ECPackPrefix)
+		return
+	}
 	if pid != "" {
 		if redelta := t.redirectLatency(started, query); redelta != 0 {
 			t.statsT.Add(stats.PutRedirLatency, redelta)
 		}
 	}
 	config := cmn.GCO.Get()
-	if capInfo := t.AvgCapUsed(config); capInfo.OOS {
-		t.invalmsghdlr(w, r, capInfo.Err.Error())
-		return
-	}
 	bck, err := newBckFromQuery(bucket, query)
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 	lom := &cluster.LOM{T: t, ObjName: objName}
@@ -658,7 +741,28 @@ func (t *targetrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 			err = lom.Init(bck.Bck, config)
 		}
 		if err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
+			return
+		}
+	}
+	var tracker cluster.PutTracker
+	if pid != "" {
+		var errCode int
+		if tracker, err, errCode = t.fencePut(lom); err != nil {
+			t.invalmsghdlrErr(w, r, err, errCode)
+			return
+		}
+	}
+	if capInfo := t.AvgCapUsed(config); capInfo.OOS {
+		if !t.spillOnOOS(lom.Bck()) {
+			t.invalmsghdlr(w, r, capInfo.Err.Error())
+			return
+		}
+		// admit the PUT as soon as spilling frees up the space - otherwise,
+		// the client is expected to retry (not out of space: temporarily unavailable)
+		if capInfo = t.waitSpill(config); capInfo.OOS {
+			w.Header().Set("Retry-After", strconv.Itoa(spillRetryAfter))
+			t.invalmsghdlrstatusf(w, r, http.StatusServiceUnavailable, "%v (spilling %s)", capInfo.Err, lom.Bck())
 			return
 		}
 	}
@@ -681,13 +785,27 @@ func (t *targetrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 	if appendTy == "" {
 		if err, errCode := t.doPut(r, lom, started); err != nil {
 			t.fshc(err, lom.FQN)
-			t.invalmsghdlr(w, r, err.Error(), errCode)
+			t.invalmsghdlrErr(w, r, err, errCode)
+			return
+		}
+		if cmn.IsParseBool(query.Get(cmn.URLParamMisplaced)) {
+			t.statsT.Add(stats.PutMisplacedCount, 1) // see capOOS
 		}
+		if tracker != nil {
+			t.trackPut(tracker, lom)
+		}
+		t.journal(lom, cmn.JournalPut, "", t.requester(r))
 	} else {
 		if handle, err, errCode := t.doAppend(r, lom, started); err != nil {
-			t.invalmsghdlr(w, r, err.Error(), errCode)
+			t.invalmsghdlrErr(w, r, err, errCode)
 		} else {
 			w.Header().Set(cmn.HeaderAppendHandle, handle)
+			if appendTy == cmn.FlushOp || appendTy == cmn.CommitOp {
+				if tracker != nil {
+					t.trackPut(tracker, lom)
+				}
+				t.journal(lom, cmn.JournalAppend, "", t.requester(r))
+			}
 		}
 	}
 }
@@ -705,7 +823,7 @@ func (t *targetrunner) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 	bucket := apitems[0]
 	bck, err := newBckFromQuery(bucket, r.URL.Query())
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 	if err = bck.Init(t.owner.bmd, t.si); err != nil {
@@ -714,7 +832,7 @@ func (t *targetrunner) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 			err = bck.Init(t.owner.bmd, t.si)
 		}
 		if err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 			return
 		}
 	}
@@ -757,9 +875,14 @@ func (t *targetrunner) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 			t.invalmsghdlrf(w, r, "invalid %s action message: %s, %T", msg.Action, msg.Name, msg.Value)
 			return
 		}
+		op, req := cmn.JournalDelete, t.requester(r)
+		if args.Evict {
+			op = cmn.JournalEvict
+		}
+		args.Deleted = func(lom *cluster.LOM) { t.journal(lom, op, "", req) }
 		xact, err := xaction.Registry.RenewEvictDelete(t, bck, args)
 		if err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 			return
 		}
 		go xact.Run()
@@ -787,16 +910,19 @@ func (t *targetrunner) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 
 	bck, err := newBckFromQuery(bucket, query)
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 	lom := &cluster.LOM{T: t, ObjName: objName}
 	if err = lom.Init(bck.Bck); err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 	err, errCode := t.objDelete(context.Background(), lom, evict)
 	if err != nil {
+		if errCode == http.StatusNotFound && !evict && t.deleteMisplaced(r, lom) {
+			return
+		}
 		if errCode == http.StatusNotFound {
 			t.invalmsghdlrsilent(w, r,
 				fmt.Sprintf("object %s/%s doesn't exist", lom.Bck(), lom.ObjName),
@@ -807,6 +933,11 @@ func (t *targetrunner) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
+	if evict {
+		t.journal(lom, cmn.JournalEvict, "", t.requester(r))
+	} else {
+		t.journal(lom, cmn.JournalDelete, "", t.requester(r))
+	}
 	// EC cleanup if EC is enabled
 	ec.ECM.CleanupObject(lom)
 }
@@ -832,7 +963,7 @@ func (t *targetrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 		case cmn.ActSummaryBucket:
 			bck, err := newBckFromQuery("", r.URL.Query())
 			if err != nil {
-				t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+				t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 				return
 			}
 			if !t.bucketSummary(w, r, bck, msg) {
@@ -847,7 +978,7 @@ func (t *targetrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 	bucket = apiItems[0]
 	bck, err := newBckFromQuery(bucket, r.URL.Query())
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 
@@ -857,7 +988,7 @@ func (t *targetrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 			err = bck.Init(t.owner.bmd, t.si)
 		}
 		if err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 			return
 		}
 	}
@@ -885,10 +1016,73 @@ func (t *targetrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 		args.UUID = msg.UUID
 		xact, err = xaction.Registry.RenewPrefetch(t, bck, args)
 		if err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
+			return
+		}
+		xact.SetTags(msg.Tags)
+		go xact.Run()
+	case cmn.ActWarmup:
+		var (
+			err       error
+			xact      *xaction.Warmup
+			warmupMsg = &cmn.WarmupMsg{}
+			args      = &xaction.WarmupArgs{}
+		)
+		if err = cmn.MorphMarshal(msg.Value, warmupMsg); err != nil {
+			t.invalmsghdlrf(w, r, "invalid %s action message: %s, %T", msg.Action, msg.Name, msg.Value)
+			return
+		}
+		if warmupMsg.Template != "" {
+			args.RangeMsg = &cmn.RangeMsg{Template: warmupMsg.Template}
+		} else {
+			args.ListMsg = &cmn.ListMsg{ObjNames: warmupMsg.ObjNames}
+		}
+		args.Ctx = context.Background()
+		args.UUID = msg.UUID
+		args.MlockBudget = warmupMsg.MlockBudget
+		xact, err = xaction.Registry.RenewWarmup(t, bck, args)
+		if err != nil {
+			t.invalmsghdlrErr(w, r, err)
+			return
+		}
+		xact.SetTags(msg.Tags)
+		go xact.Run()
+	case cmn.ActECRestore:
+		var (
+			err      error
+			xact     *ec.XactBckRestore
+			rangeMsg = &cmn.RangeMsg{}
+		)
+		if !bck.Props.EC.Enabled {
+			t.invalmsghdlrf(w, r, "%s: EC is not enabled for bucket %s", t.si, bck)
+			return
+		}
+		if err = cmn.MorphMarshal(msg.Value, &rangeMsg); err != nil {
+			t.invalmsghdlrf(w, r, "invalid %s action message: %s, %T", msg.Action, msg.Name, msg.Value)
+			return
+		}
+		xact, err = xaction.Registry.RenewECRestoreXact(t, bck, msg.UUID, rangeMsg.Template)
+		if err != nil {
+			t.invalmsghdlrErr(w, r, err)
 			return
 		}
 		go xact.Run()
+	case cmn.ActECUndelete:
+		rangeMsg := &cmn.RangeMsg{}
+		if !bck.Props.EC.Enabled {
+			t.invalmsghdlrf(w, r, "%s: EC is not enabled for bucket %s", t.si, bck)
+			return
+		}
+		if err := cmn.MorphMarshal(msg.Value, &rangeMsg); err != nil {
+			t.invalmsghdlrf(w, r, "invalid %s action message: %s, %T", msg.Action, msg.Name, msg.Value)
+			return
+		}
+		n, err := ec.Undelete(bck, rangeMsg.Template)
+		if err != nil {
+			t.invalmsghdlrErr(w, r, err)
+			return
+		}
+		glog.Infof("%s: undeleted %d EC file(s) of %s (%q)", t.si, n, bck, rangeMsg.Template)
 	case cmn.ActListObjects:
 		// list the bucket and return
 		begin := mono.NanoTime()
@@ -908,6 +1102,12 @@ func (t *targetrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 		if !t.bucketSummary(w, r, bck, msg) {
 			return
 		}
+	case cmn.ActQueryJournal:
+		t.queryJournal(w, r, bck, msg)
+	case cmn.ActSimPlacement:
+		t.simPlacement(w, r, bck, msg)
+	case cmn.ActObjLocations:
+		t.objLocations(w, r, bck, msg)
 	default:
 		t.invalmsghdlrf(w, r, fmtUnknownAct, msg)
 	}
@@ -924,6 +1124,12 @@ func (t *targetrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 		t.renameObject(w, r, &msg)
 	case cmn.ActPromote:
 		t.promoteFQN(w, r, &msg)
+	case cmn.ActCopyRemote:
+		t.copyRemoteObject(w, r, &msg)
+	case cmn.ActUndelete:
+		t.undeleteObject(w, r)
+	case cmn.ActECReprotect:
+		t.reprotectObject(w, r)
 	default:
 		t.invalmsghdlrf(w, r, fmtUnknownAct, msg)
 	}
@@ -945,12 +1151,12 @@ func (t *targetrunner) httpbckhead(w http.ResponseWriter, r *http.Request) {
 	bucket := apitems[0]
 	bck, err := newBckFromQuery(bucket, query)
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 	if err = bck.Init(t.owner.bmd, t.si); err != nil {
 		if _, ok := err.(*cmn.ErrorRemoteBucketDoesNotExist); !ok { // is ais
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 			return
 		}
 		inBMD = false
@@ -961,6 +1167,9 @@ func (t *targetrunner) httpbckhead(w http.ResponseWriter, r *http.Request) {
 	}
 	if bck.IsAIS() {
 		t.bucketPropsToHdr(bck, hdr)
+		if cmn.IsParseBool(query.Get(cmn.URLParamProvenance)) {
+			t.bucketProvenanceToHdr(bck, hdr)
+		}
 		return
 	}
 	// + cloud
@@ -972,7 +1181,7 @@ func (t *targetrunner) httpbckhead(w http.ResponseWriter, r *http.Request) {
 				t.invalmsghdlrsilent(w, r, err.Error(), code)
 			} else {
 				err = fmt.Errorf("%s: bucket %s, err: %v", t.si, bucket, err)
-				t.invalmsghdlr(w, r, err.Error(), code)
+				t.invalmsghdlrErr(w, r, err, code)
 			}
 			return
 		}
@@ -986,6 +1195,9 @@ func (t *targetrunner) httpbckhead(w http.ResponseWriter, r *http.Request) {
 		hdr.Set(k, v)
 	}
 	t.bucketPropsToHdr(bck, hdr)
+	if cmn.IsParseBool(query.Get(cmn.URLParamProvenance)) {
+		t.bucketProvenanceToHdr(bck, hdr)
+	}
 }
 
 // HEAD /v1/objects/bucket-name/object-name
@@ -996,6 +1208,7 @@ func (t *targetrunner) httpobjhead(w http.ResponseWriter, r *http.Request) {
 		exists         bool
 		checkExists    = cmn.IsParseBool(query.Get(cmn.URLParamCheckExists))
 		checkExistsAny = cmn.IsParseBool(query.Get(cmn.URLParamCheckExistsAny))
+		waitTimeout    = query.Get(cmn.URLParamWaitTimeout)
 		// TODO: add cmn.URLParamHeadCloudAlways  - to always resync props from the Cloud
 		silent = cmn.IsParseBool(query.Get(cmn.URLParamSilent))
 	)
@@ -1015,7 +1228,7 @@ func (t *targetrunner) httpobjhead(w http.ResponseWriter, r *http.Request) {
 
 	bck, err := newBckFromQuery(bucket, query)
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 	lom := &cluster.LOM{T: t, ObjName: objName}
@@ -1048,6 +1261,15 @@ func (t *targetrunner) httpobjhead(w http.ResponseWriter, r *http.Request) {
 			exists = lom.RestoreObjectFromAny()
 		}
 	}
+	if !exists && waitTimeout != "" {
+		timeout, err := time.ParseDuration(waitTimeout)
+		if err != nil || timeout <= 0 {
+			t.invalmsghdlrf(w, r, "invalid %s=%q: expecting positive duration (e.g., \"30s\")",
+				cmn.URLParamWaitTimeout, waitTimeout)
+			return
+		}
+		exists = t.waitObj(r.Context(), lom, timeout)
+	}
 
 	if checkExists || checkExistsAny {
 		if !exists {
@@ -1073,21 +1295,31 @@ func (t *targetrunner) httpobjhead(w http.ResponseWriter, r *http.Request) {
 			hdr.Set(k, v)
 		}
 	}
+	objPropsToHdr(hdr, lom, exists)
+}
+
+// sets the object's properties (cmn.ObjectProps) that are not in the header yet -
+// upon HEAD and GET with cmn.URLParamObjProps
+func objPropsToHdr(hdr http.Header, lom *cluster.LOM, exists bool) {
 	objProps := cmn.ObjectProps{
-		Name:    objName,
+		Name:    lom.ObjName,
 		Bck:     lom.Bck().Bck,
 		Present: exists,
 	}
 	if exists {
 		objProps.Size = lom.Size()
 		objProps.NumCopies = lom.NumCopies()
+		objProps.AccessCount = lom.AccessCount()
+		if fi, err := os.Stat(lom.FQN); err == nil {
+			objProps.AllocSize = cmn.AllocatedSize(fi)
+		}
 		if lom.Bck().Props.EC.Enabled {
-			if md, err := ec.ObjectMetadata(lom.Bck(), objName); err == nil {
+			if md, err := ec.ObjectMetadata(lom.Bck(), lom.ObjName); err == nil {
 				hdr.Set(cmn.HeaderObjECMeta, ec.MetaToString(md))
 			}
 		}
 	}
-	err = cmn.IterFields(objProps, func(tag string, field cmn.IterField) (err error, b bool) {
+	err := cmn.IterFields(objProps, func(tag string, field cmn.IterField) (err error, b bool) {
 		if hdr.Get(tag) == "" {
 			hdr.Set(tag, fmt.Sprintf("%v", field.Value()))
 		}
@@ -1177,6 +1409,10 @@ func (t *targetrunner) listBuckets(w http.ResponseWriter, r *http.Request, query
 		}
 	}
 	sort.Sort(bucketNames)
+	if cmn.IsParseBool(r.URL.Query().Get(cmn.URLParamBckEvents)) {
+		t.listBucketsEvents(w, r, bucketNames)
+		return
+	}
 	t.writeJSON(w, r, bucketNames, listBuckets)
 }
 
@@ -1198,7 +1434,11 @@ func (t *targetrunner) doAppend(r *http.Request, lom *cluster.LOM, started time.
 		cksumType     = r.Header.Get(cmn.HeaderObjCksumType)
 		contentLength = r.Header.Get("Content-Length")
 		handle        = r.URL.Query().Get(cmn.URLParamAppendHandle)
+		op            = r.URL.Query().Get(cmn.URLParamAppendType)
 	)
+	if op == cmn.WriteAtOp || op == cmn.CommitOp {
+		return t.doWriteAt(r, lom, started)
+	}
 
 	hi, err := parseAppendHandle(handle)
 	if err != nil {
@@ -1210,7 +1450,7 @@ func (t *targetrunner) doAppend(r *http.Request, lom *cluster.LOM, started time.
 		t:       t,
 		lom:     lom,
 		r:       r.Body,
-		op:      r.URL.Query().Get(cmn.URLParamAppendType),
+		op:      op,
 		hi:      hi,
 	}
 	if contentLength != "" {
@@ -1224,6 +1464,43 @@ func (t *targetrunner) doAppend(r *http.Request, lom *cluster.LOM, started time.
 	return aoi.appendObject()
 }
 
+func (t *targetrunner) doWriteAt(r *http.Request, lom *cluster.LOM, started time.Time) (newHandle string, err error, errCode int) {
+	var (
+		query         = r.URL.Query()
+		cksumValue    = r.Header.Get(cmn.HeaderObjCksumVal)
+		cksumType     = r.Header.Get(cmn.HeaderObjCksumType)
+		contentLength = r.Header.Get("Content-Length")
+	)
+
+	hi, err := parseWriteAtHandle(query.Get(cmn.URLParamAppendHandle))
+	if err != nil {
+		return "", err, http.StatusBadRequest
+	}
+
+	woi := &writeAtObjInfo{
+		started:  started,
+		t:        t,
+		lom:      lom,
+		r:        r.Body,
+		op:       query.Get(cmn.URLParamAppendType),
+		filePath: hi.filePath,
+	}
+	if offset := query.Get(cmn.URLParamOffset); offset != "" {
+		if woi.offset, err = strconv.ParseInt(offset, 10, 64); err != nil {
+			return "", err, http.StatusBadRequest
+		}
+	}
+	if contentLength != "" {
+		if size, ers := strconv.ParseInt(contentLength, 10, 64); ers == nil {
+			woi.size = size
+		}
+	}
+	if cksumValue != "" && cksumType != cmn.ChecksumNone {
+		woi.cksum = cmn.NewCksum(cksumType, cksumValue)
+	}
+	return woi.writeAtObject()
+}
+
 // PUT new version and update object metadata
 // ais bucket:
 //  - if bucket versioning is enabled, the version is autoincremented
@@ -1246,6 +1523,7 @@ func (t *targetrunner) doPut(r *http.Request, lom *cluster.LOM, started time.Tim
 		cksumToCheck: cmn.NewCksum(cksumType, cksumValue),
 		ctx:          context.Background(),
 		workFQN:      fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfilePut),
+		sse:          header.Get(s3compat.HeaderSSE) == encrypt.AlgAES256,
 	}
 	if recvType != "" {
 		n, err := strconv.Atoi(recvType)
@@ -1254,6 +1532,11 @@ func (t *targetrunner) doPut(r *http.Request, lom *cluster.LOM, started time.Tim
 		}
 		poi.migrated = cluster.RecvType(n) == cluster.Migrated
 	}
+	if policy := header.Get(cmn.HeaderObjECPolicy); policy != "" {
+		if poi.ecPolicy, err = t.parseECPolicy(lom, policy); err != nil {
+			return err, http.StatusBadRequest
+		}
+	}
 	sizeStr := header.Get("Content-Length")
 	if sizeStr != "" {
 		if size, ers := strconv.ParseInt(sizeStr, 10, 64); ers == nil {
@@ -1263,6 +1546,29 @@ func (t *targetrunner) doPut(r *http.Request, lom *cluster.LOM, started time.Tim
 	return poi.putObject()
 }
 
+// validates the per-object EC policy against the bucket's EC configuration
+func (t *targetrunner) parseECPolicy(lom *cluster.LOM, value string) (*ec.Policy, error) {
+	ecConf := lom.Bprops().EC
+	if !ecConf.Enabled {
+		return nil, fmt.Errorf("%s: cannot apply EC policy %q - EC is disabled for the bucket", lom, value)
+	}
+	policy, err := ec.ParsePolicy(value)
+	if err != nil {
+		return nil, err
+	}
+	if ecConf, err = policy.Apply(ecConf); err != nil {
+		return nil, err
+	}
+	required := ecConf.RequiredEncodeTargets()
+	if policy.Replicas != 0 {
+		required = policy.Replicas
+	}
+	if cnt := t.owner.smap.get().CountTargets(); cnt < required {
+		return nil, fmt.Errorf("%s: EC policy %q requires at least %d targets (have %d)", lom, value, required, cnt)
+	}
+	return policy, nil
+}
+
 func (t *targetrunner) putMirror(lom *cluster.LOM) {
 	const retries = 2
 	var (
@@ -1300,6 +1606,9 @@ func (t *targetrunner) objDelete(ctx context.Context, lom *cluster.LOM, evict bo
 		errRet       error
 		delFromAIS   bool
 	)
+	if cmn.IsECPackName(lom.ObjName) {
+		return fmt.Errorf("%s is an internal container of erasure coded objects", lom), http.StatusForbidden
+	}
 	lom.Lock(true)
 	defer lom.Unlock(true)
 
@@ -1320,7 +1629,11 @@ func (t *targetrunner) objDelete(ctx context.Context, lom *cluster.LOM, evict bo
 		}
 	}
 	if delFromAIS {
-		errRet = lom.Remove()
+		if bprops := lom.Bck().Props; !evict && bprops.Trash.Enabled && lom.Bck().IsAIS() {
+			errRet = lom.Trash(bprops.Trash.TTLDuration())
+		} else {
+			errRet = lom.Remove()
+		}
 		if errRet != nil {
 			if !os.IsNotExist(errRet) {
 				if cloudErr != nil {
@@ -1329,6 +1642,9 @@ func (t *targetrunner) objDelete(ctx context.Context, lom *cluster.LOM, evict bo
 				return errRet, 0
 			}
 		}
+		if errRet == nil {
+			fs.Quota.Add(lom.Bck().Bck, lom.ParsedFQN.MpathInfo.Path, -lom.Size(), -1)
+		}
 		if evict {
 			cmn.Assert(lom.Bck().IsRemote())
 			t.statsT.AddMany(
@@ -1355,12 +1671,12 @@ func (t *targetrunner) renameObject(w http.ResponseWriter, r *http.Request, msg
 	bucket, objNameFrom := apitems[0], apitems[1]
 	bck, err := newBckFromQuery(bucket, r.URL.Query())
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 	lom := &cluster.LOM{T: t, ObjName: objNameFrom}
 	if err = lom.Init(bck.Bck); err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if lom.Bck().IsRemote() {
@@ -1377,16 +1693,134 @@ func (t *targetrunner) renameObject(w http.ResponseWriter, r *http.Request, msg
 	copied, err := ri.copyObject(lom, msg.Name /* new object name */)
 	slab.Free(buf)
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if copied {
 		lom.Lock(true)
-		if err = lom.Remove(); err != nil {
-			t.invalmsghdlr(w, r, err.Error())
-		}
+		err = lom.Remove()
 		lom.Unlock(true)
+		if err != nil {
+			t.invalmsghdlrErr(w, r, err)
+			return
+		}
+		t.journal(lom, cmn.JournalRename, msg.Name, t.requester(r))
+	}
+}
+
+// restores the object that was deleted last from the bucket's trash (see cmn.TrashConf)
+func (t *targetrunner) undeleteObject(w http.ResponseWriter, r *http.Request) {
+	apitems, err := t.checkRESTItems(w, r, 2, false, cmn.Version, cmn.Objects)
+	if err != nil {
+		return
+	}
+	bucket, objName := apitems[0], apitems[1]
+	bck, err := newBckFromQuery(bucket, r.URL.Query())
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
+		return
+	}
+	lom := &cluster.LOM{T: t, ObjName: objName}
+	if err = lom.Init(bck.Bck); err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	if !lom.Bck().IsAIS() {
+		t.invalmsghdlrf(w, r, "%s: cannot undelete object from remote bucket", lom)
+		return
+	}
+	lom.Lock(true)
+	err = lom.Undelete()
+	lom.Unlock(true)
+	if err != nil {
+		switch {
+		case os.IsNotExist(err):
+			t.invalmsghdlrstatusf(w, r, http.StatusNotFound, "%s: not found in trash", lom)
+		case os.IsExist(err):
+			t.invalmsghdlrstatusf(w, r, http.StatusConflict, "%s: already exists (delete it first)", lom)
+		default:
+			t.invalmsghdlrErr(w, r, err)
+		}
+		return
+	}
+	fs.Quota.Add(lom.Bck().Bck, lom.ParsedFQN.MpathInfo.Path, lom.Size(), 1)
+	t.statsT.Add(stats.UndeleteCount, 1)
+	t.putMirror(lom)
+	// the slices (or replicas) were removed upon deletion - re-encode
+	if err = ec.ECM.EncodeObject(lom); err != nil && err != ec.ErrorECDisabled {
+		t.invalmsghdlrErr(w, r, err)
+	}
+}
+
+// rebuilds the object's EC protection from scratch and responds with the
+// resulting placement of its replicas or slices (see ec.Manager.ReprotectObject)
+func (t *targetrunner) reprotectObject(w http.ResponseWriter, r *http.Request) {
+	apitems, err := t.checkRESTItems(w, r, 2, false, cmn.Version, cmn.Objects)
+	if err != nil {
+		return
+	}
+	bucket, objName := apitems[0], apitems[1]
+	bck, err := newBckFromQuery(bucket, r.URL.Query())
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
+		return
+	}
+	lom := &cluster.LOM{T: t, ObjName: objName}
+	if err = lom.Init(bck.Bck); err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	if !lom.Bprops().EC.Enabled {
+		t.invalmsghdlrf(w, r, "%s: bucket %s is not erasure coded", lom, lom.Bck())
+		return
+	}
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err = lom.Load(); err != nil {
+		if cmn.IsObjNotExist(err) {
+			t.invalmsghdlrErr(w, r, err, http.StatusNotFound)
+		} else {
+			t.invalmsghdlrErr(w, r, err)
+		}
+		return
+	}
+	placement, err := ec.ECM.ReprotectObject(lom)
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	t.writeJSON(w, r, placement, "ec-reprotect")
+}
+
+// pulls the object from attached remote AIS cluster (see proxy's objCopyRemote)
+func (t *targetrunner) copyRemoteObject(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg) {
+	apitems, err := t.checkRESTItems(w, r, 2, false, cmn.Version, cmn.Objects)
+	if err != nil {
+		return
+	}
+	bucket, objName := apitems[0], apitems[1]
+	bck, err := newBckFromQuery(bucket, r.URL.Query())
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
+		return
+	}
+	src := cmn.ActValCopyRemote{}
+	if err := cmn.MorphMarshal(msg.Value, &src); err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	lom := &cluster.LOM{T: t, ObjName: objName}
+	if err = lom.Init(bck.Bck); err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	// a new object as far as this cluster is concerned - PUT (rather than
+	// cold GET) that gets versioned, encrypted, and so on
+	if err, errCode := t.cloud.ais.PullObj(src.Bck, src.ObjName, lom); err != nil {
+		t.invalmsghdlrErr(w, r, err, errCode)
+		return
 	}
+	t.journal(lom, cmn.JournalPut, "", t.requester(r))
 }
 
 ///////////////////////////////////////
@@ -1419,12 +1853,12 @@ func (t *targetrunner) promoteFQN(w http.ResponseWriter, r *http.Request, msg *c
 
 	finfo, err := os.Stat(srcFQN)
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 	bck, err := newBckFromQuery(bucket, r.URL.Query())
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 	if err = bck.Init(t.owner.bmd, t.si); err != nil {
@@ -1433,7 +1867,7 @@ func (t *targetrunner) promoteFQN(w http.ResponseWriter, r *http.Request, msg *c
 			err = bck.Init(t.owner.bmd, t.si)
 		}
 		if err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 			return
 		}
 	}
@@ -1446,7 +1880,7 @@ func (t *targetrunner) promoteFQN(w http.ResponseWriter, r *http.Request, msg *c
 		var xact *mirror.XactDirPromote
 		xact, err = xaction.Registry.RenewDirPromote(srcFQN, bck, t, &params)
 		if err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 			return
 		}
 		go xact.Run()
@@ -1505,5 +1939,6 @@ func (t *targetrunner) fshc(err error, filepath string) {
 	keyName := mpathInfo.Path
 	// keyName is the mountpath is the fspath - counting IO errors on a per basis..
 	t.statsT.AddMany(stats.NamedVal64{Name: stats.ErrIOCount, NameSuffix: keyName, Value: 1})
+	t.fsprg.wd.onErr(keyName)
 	getfshealthchecker().OnErr(filepath)
 }
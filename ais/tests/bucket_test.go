@@ -6,6 +6,7 @@ package integration
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/rand"
 	"net/http"
@@ -18,9 +19,11 @@ import (
 	"time"
 
 	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/containers"
 	"github.com/NVIDIA/aistore/tutils"
+	"github.com/NVIDIA/aistore/tutils/readers"
 	"github.com/NVIDIA/aistore/tutils/tassert"
 	"golang.org/x/sync/errgroup"
 )
@@ -750,6 +753,233 @@ func TestListObjectsPrefix(t *testing.T) {
 	}
 }
 
+func TestListObjectsStream(t *testing.T) {
+	var (
+		m = ioContext{
+			t:        t,
+			num:      1000,
+			fileSize: cmn.KiB,
+		}
+		baseParams = tutils.BaseAPIParams()
+	)
+
+	m.saveClusterState()
+	tutils.CreateFreshBucket(t, m.proxyURL, m.bck)
+	defer tutils.DestroyBucket(t, m.proxyURL, m.bck)
+
+	m.puts()
+
+	t.Run("all", func(t *testing.T) {
+		var (
+			names = make(cmn.StringSet, m.num)
+			msg   = &cmn.SelectMsg{PageSize: 100}
+		)
+		err := api.ListObjectsStream(baseParams, m.bck, msg, func(entry *cmn.BucketEntry) error {
+			tassert.Errorf(t, !names.Contains(entry.Name), "%q listed twice", entry.Name)
+			names.Add(entry.Name)
+			return nil
+		})
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, len(names) == m.num, "expected %d objects, got %d", m.num, len(names))
+		for _, objName := range m.objNames {
+			tassert.Errorf(t, names.Contains(objName), "%q is missing", objName)
+		}
+	})
+
+	t.Run("prefix", func(t *testing.T) {
+		var (
+			cnt    int
+			prefix = m.objNames[0][:len(m.objNames[0])-1]
+			msg    = &cmn.SelectMsg{Prefix: prefix, PageSize: 10}
+		)
+		err := api.ListObjectsStream(baseParams, m.bck, msg, func(entry *cmn.BucketEntry) error {
+			tassert.Errorf(t, strings.HasPrefix(entry.Name, prefix), "%q does not have prefix %q", entry.Name, prefix)
+			cnt++
+			return nil
+		})
+		tassert.CheckFatal(t, err)
+		var expected int
+		for _, objName := range m.objNames {
+			if strings.HasPrefix(objName, prefix) {
+				expected++
+			}
+		}
+		tassert.Errorf(t, cnt == expected, "expected %d objects, got %d", expected, cnt)
+	})
+
+	t.Run("cancel", func(t *testing.T) {
+		var (
+			cnt       int
+			errCancel = errors.New("canceled")
+			msg       = &cmn.SelectMsg{PageSize: 100}
+		)
+		err := api.ListObjectsStream(baseParams, m.bck, msg, func(*cmn.BucketEntry) error {
+			if cnt++; cnt == 10 {
+				return errCancel
+			}
+			return nil
+		})
+		tassert.Errorf(t, err == errCancel, "expected %v, got %v", errCancel, err)
+		tassert.Errorf(t, cnt == 10, "expected the listing to stop after 10 objects, got %d", cnt)
+	})
+
+	t.Run("nonexistent bucket", func(t *testing.T) {
+		bck := cmn.Bck{Name: cmn.RandString(10), Provider: cmn.ProviderAIS}
+		err := api.ListObjectsStream(baseParams, bck, nil, func(*cmn.BucketEntry) error { return nil })
+		tassert.Errorf(t, err != nil, "expected error listing nonexistent bucket %s", bck)
+	})
+}
+
+func TestSimulatePlacement(t *testing.T) {
+	var (
+		m = ioContext{
+			t:         t,
+			num:       500,
+			fileSize:  cmn.KiB,
+			fixedSize: true,
+		}
+		baseParams = tutils.BaseAPIParams()
+	)
+
+	m.saveClusterState()
+	if m.originalTargetCount < 2 {
+		t.Skipf("%s requires at least 2 targets", t.Name())
+	}
+	tutils.CreateFreshBucket(t, m.proxyURL, m.bck)
+	defer tutils.DestroyBucket(t, m.proxyURL, m.bck)
+
+	m.puts()
+
+	// the current placement of the objects
+	m.smap.InitDigests()
+	var (
+		cbck   = cluster.NewBckEmbed(m.bck)
+		placed = make(map[string]int64, m.originalTargetCount)
+		total  = int64(m.num)
+		size   = total * int64(m.fileSize)
+	)
+	removed, err := m.smap.GetRandTarget()
+	tassert.CheckFatal(t, err)
+	for _, objName := range m.objNames {
+		si, err := cluster.HrwTarget(cbck.MakeUname(objName), m.smap)
+		tassert.CheckFatal(t, err)
+		placed[si.ID()]++
+	}
+
+	t.Run("remove", func(t *testing.T) {
+		res, err := api.SimulatePlacement(baseParams, m.bck, nil, []string{removed.ID()})
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, res.Objects == total && res.Size == size,
+			"expected %d objects (%d bytes), got %d (%d)", total, size, res.Objects, res.Size)
+		// all the objects of the removed target, and only those, move
+		tassert.Errorf(t, res.MovedObjects == placed[removed.ID()], "expected %d objects to move, got %d",
+			placed[removed.ID()], res.MovedObjects)
+		tassert.Errorf(t, res.MovedSize == res.MovedObjects*int64(m.fileSize), "unexpected moved size %d", res.MovedSize)
+		for id, st := range res.Targets {
+			if id == removed.ID() {
+				tassert.Errorf(t, st.SentObjects == res.MovedObjects && st.RecvObjects == 0,
+					"%s: unexpected %+v", id, st)
+			} else {
+				tassert.Errorf(t, st.SentObjects == 0, "%s: expected nothing to send, got %+v", id, st)
+			}
+		}
+	})
+
+	t.Run("add", func(t *testing.T) {
+		added := "sim-" + cmn.GenTie()
+		res, err := api.SimulatePlacement(baseParams, m.bck, []string{added}, nil)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, res.Objects == total, "expected %d objects, got %d", total, res.Objects)
+		// the objects move only to the new target
+		tassert.Errorf(t, res.MovedObjects > 0 && res.MovedObjects < total,
+			"expected some objects to move, got %d", res.MovedObjects)
+		st, ok := res.Targets[added]
+		tassert.Fatalf(t, ok, "%s is missing in the result", added)
+		tassert.Errorf(t, st.RecvObjects == res.MovedObjects && st.SentObjects == 0, "unexpected %+v", st)
+	})
+
+	t.Run("nothing changes", func(t *testing.T) {
+		res, err := api.SimulatePlacement(baseParams, m.bck, nil, nil)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, res.Objects == total && res.MovedObjects == 0,
+			"expected %d objects and none to move, got %d and %d", total, res.Objects, res.MovedObjects)
+		// placement simulation does not change the cluster
+		smap := tutils.GetClusterMap(t, m.proxyURL)
+		tassert.Errorf(t, smap.Version == m.smap.Version, "expected Smap v%d, got v%d", m.smap.Version, smap.Version)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := api.SimulatePlacement(baseParams, m.bck, []string{removed.ID()}, nil)
+		tassert.Errorf(t, err != nil, "expected error adding existing %s", removed)
+		_, err = api.SimulatePlacement(baseParams, m.bck, nil, []string{"sim-" + cmn.GenTie()})
+		tassert.Errorf(t, err != nil, "expected error removing nonexistent target")
+	})
+}
+
+func TestObjLocations(t *testing.T) {
+	var (
+		m = ioContext{
+			t:         t,
+			num:       100,
+			fileSize:  cmn.KiB,
+			fixedSize: true,
+		}
+		baseParams = tutils.BaseAPIParams()
+		missing    = "missing-" + cmn.GenTie()
+	)
+
+	m.saveClusterState()
+	tutils.CreateFreshBucket(t, m.proxyURL, m.bck)
+	defer tutils.DestroyBucket(t, m.proxyURL, m.bck)
+
+	m.puts()
+	m.smap.InitDigests()
+	cbck := cluster.NewBckEmbed(m.bck)
+
+	locate := func(copies int) {
+		objNames := append(append([]string{}, m.objNames...), missing)
+		locs, err := api.ObjLocations(baseParams, m.bck, objNames)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, len(locs.Objects) == len(objNames), "expected %d locations, got %d",
+			len(objNames), len(locs.Objects))
+		for i, loc := range locs.Objects {
+			// in the requested order, each at its HRW target - present or not
+			tassert.Fatalf(t, loc.Name == objNames[i], "expected %q, got %q", objNames[i], loc.Name)
+			si, err := cluster.HrwTarget(cbck.MakeUname(loc.Name), m.smap)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, loc.Target == si.ID(), "%s: expected target %s, got %s", loc.Name, si, loc.Target)
+			tassert.Errorf(t, loc.Host != "" && locs.Hosts[loc.Target] == loc.Host,
+				"%s: unexpected host %q (hosts: %v)", loc.Name, loc.Host, locs.Hosts)
+			if loc.Name == missing {
+				tassert.Errorf(t, !loc.Present && len(loc.Mountpaths) == 0, "%s: unexpected %+v", loc.Name, loc)
+				continue
+			}
+			tassert.Errorf(t, loc.Present && loc.Size == int64(m.fileSize), "%s: unexpected %+v", loc.Name, loc)
+			tassert.Errorf(t, len(loc.Mountpaths) == copies, "%s: expected %d mountpath(s), got %v",
+				loc.Name, copies, loc.Mountpaths)
+			tassert.Errorf(t, len(loc.ECTargets) == 0, "%s: unexpected EC targets %v", loc.Name, loc.ECTargets)
+		}
+	}
+
+	t.Run("single", func(t *testing.T) { locate(1) })
+
+	t.Run("mirrored", func(t *testing.T) {
+		target := tutils.ExtractTargetNodes(m.smap)[0]
+		mpList, err := api.GetMountpaths(baseParams, target)
+		tassert.CheckFatal(t, err)
+		if len(mpList.Available) < 2 {
+			t.Skipf("%s requires at least 2 mountpaths (target %s has %d)", t.Name(), target, len(mpList.Available))
+		}
+		makeNCopies(t, baseParams, m.bck, 2)
+		locate(2)
+	})
+
+	t.Run("none", func(t *testing.T) {
+		_, err := api.ObjLocations(baseParams, m.bck, nil)
+		tassert.Errorf(t, err != nil, "expected locating no objects to fail")
+	})
+}
+
 func TestBucketListAndSummary(t *testing.T) {
 	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true})
 
@@ -986,6 +1216,40 @@ func TestBucketSingleProp(t *testing.T) {
 	tassert.CheckError(t, err)
 }
 
+func TestSetBucketPropsDryRun(t *testing.T) {
+	var (
+		proxyURL   = tutils.RandomProxyURL()
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		bck        = cmn.Bck{Name: TestBucketName, Provider: cmn.ProviderAIS}
+	)
+	tutils.CreateFreshBucket(t, proxyURL, bck)
+	defer tutils.DestroyBucket(t, proxyURL, bck)
+
+	smap := tutils.GetClusterMap(t, proxyURL)
+	// valid - validated but not applied
+	err := api.SetBucketPropsDryRun(baseParams, bck, cmn.BucketPropsToUpdate{
+		Cksum: &cmn.CksumConfToUpdate{Type: api.String(cmn.ChecksumSHA256)},
+	})
+	tassert.CheckFatal(t, err)
+	p, err := api.HeadBucket(baseParams, bck)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, p.Cksum.Type != cmn.ChecksumSHA256, "dry-run must not change bucket props")
+
+	// invalid
+	err = api.SetBucketPropsDryRun(baseParams, bck, cmn.BucketPropsToUpdate{
+		EC:     &cmn.ECConfToUpdate{Enabled: api.Bool(true)},
+		Mirror: &cmn.MirrorConfToUpdate{Enabled: api.Bool(true)},
+	})
+	tassert.Errorf(t, err != nil, "expected error when enabling both ec and mirroring")
+
+	// too many slices for the cluster
+	err = api.ECEncodeBucketDryRun(baseParams, bck, 1, smap.CountTargets())
+	tassert.Errorf(t, err != nil, "expected error for %d parity slices", smap.CountTargets())
+	p, err = api.HeadBucket(baseParams, bck)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, !p.EC.Enabled, "dry-run must not change bucket props")
+}
+
 func TestSetBucketPropsOfNonexistentBucket(t *testing.T) {
 	var (
 		baseParams = tutils.BaseAPIParams()
@@ -1061,11 +1325,11 @@ func TestBucketInvalidName(t *testing.T) {
 	}
 }
 
-//===============================================================
+// ===============================================================
 //
 // n-way mirror
 //
-//===============================================================
+// ===============================================================
 func TestLocalMirror(t *testing.T) {
 	tests := []struct {
 		numCopies []int // each of the number in the list represents the number of copies enforced on the bucket
@@ -1507,6 +1771,115 @@ func TestRenameBucketTwice(t *testing.T) {
 	}
 }
 
+// rename and copy asynchronously - the job status gets polled until completion
+func TestRenameCopyBucketAsync(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true})
+	var (
+		m = ioContext{
+			t:   t,
+			num: 200,
+		}
+		baseParams = tutils.BaseAPIParams()
+		renamedBck = cmn.Bck{Name: TestBucketName + "_renamed", Provider: cmn.ProviderAIS}
+		copiedBck  = cmn.Bck{Name: TestBucketName + "_copied", Provider: cmn.ProviderAIS}
+	)
+	m.saveClusterState()
+	m.proxyURL = tutils.RandomProxyURL()
+	tutils.CreateFreshBucket(t, m.proxyURL, m.bck)
+	defer func() {
+		api.DestroyBucket(baseParams, m.bck)
+		api.DestroyBucket(baseParams, renamedBck)
+		api.DestroyBucket(baseParams, copiedBck)
+	}()
+	m.puts()
+
+	waitJob := func(uuid, action string) {
+		deadline := time.Now().Add(rebalanceTimeout)
+		for {
+			st, err := api.GetJobStatus(baseParams, uuid)
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, st.UUID == uuid && st.Action == action, "unexpected job status %+v", st)
+			if st.Done() {
+				tassert.Fatalf(t, st.Err == "", "%s failed: %s", action, st.Err)
+				return
+			}
+			tassert.Fatalf(t, time.Now().Before(deadline), "timed out waiting for %s (%s)", action, uuid)
+			time.Sleep(time.Second)
+		}
+	}
+	checkObjs := func(bck cmn.Bck) {
+		bckList, err := api.ListObjects(baseParams, bck, &cmn.SelectMsg{}, 0)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, len(bckList.Entries) == m.num, "%s: expected %d objects, got %d",
+			bck, m.num, len(bckList.Entries))
+	}
+
+	tutils.Logf("rename %s => %s (async)\n", m.bck, renamedBck)
+	uuid, err := api.RenameBucketAsync(baseParams, m.bck, renamedBck)
+	tassert.CheckFatal(t, err)
+	waitJob(uuid, cmn.ActRenameLB)
+	checkObjs(renamedBck)
+
+	tutils.Logf("copy %s => %s (async)\n", renamedBck, copiedBck)
+	uuid, err = api.CopyBucketAsync(baseParams, renamedBck, copiedBck)
+	tassert.CheckFatal(t, err)
+	waitJob(uuid, cmn.ActCopyBucket)
+	checkObjs(renamedBck)
+	checkObjs(copiedBck)
+
+	_, err = api.GetJobStatus(baseParams, cmn.GenUUID())
+	tassert.Errorf(t, err != nil, "expected unknown job to fail")
+}
+
+// finished xactions are recorded by each target (see api.GetXactionHistory)
+func TestXactionHistory(t *testing.T) {
+	var (
+		m = ioContext{
+			t:   t,
+			num: 50,
+		}
+		baseParams = tutils.BaseAPIParams()
+		dstBck     = cmn.Bck{Name: TestBucketName + "_xhist", Provider: cmn.ProviderAIS}
+	)
+	m.saveClusterState()
+	tutils.CreateFreshBucket(t, m.proxyURL, m.bck)
+	defer tutils.DestroyBucket(t, m.proxyURL, m.bck)
+	defer api.DestroyBucket(baseParams, dstBck)
+	m.puts()
+
+	tassert.CheckFatal(t, api.CopyBucket(baseParams, m.bck, dstBck))
+	xactArgs := api.XactReqArgs{Kind: cmn.ActCopyBucket, Bck: m.bck, Timeout: rebalanceTimeout}
+	tassert.CheckFatal(t, api.WaitForXaction(baseParams, xactArgs))
+
+	recs, err := api.GetXactionHistory(baseParams, api.XactReqArgs{Kind: cmn.ActCopyBucket, Bck: m.bck})
+	tassert.CheckFatal(t, err)
+	if len(recs) == 0 {
+		t.Skipf("%s: xaction history is disabled (see xaction_history.retention)", t.Name())
+	}
+	var (
+		last    = recs[len(recs)-1]
+		targets = make(cmn.StringSet, m.originalTargetCount)
+		objs    int64
+	)
+	for i, rec := range recs {
+		tassert.Errorf(t, rec.KindX == cmn.ActCopyBucket && rec.BckX.Equal(m.bck), "unexpected record %+v", rec)
+		tassert.Errorf(t, i == 0 || !rec.EndTimeX.Before(recs[i-1].EndTimeX), "records out of order: %+v", recs)
+		if rec.IDX == last.IDX {
+			tassert.Errorf(t, rec.Err == "" && !rec.AbortedX && rec.Duration() >= 0, "unexpected record %+v", rec)
+			targets.Add(rec.Target)
+			objs += rec.ObjCountX
+		}
+	}
+	tassert.Errorf(t, len(targets) == m.originalTargetCount, "expected records from %d targets, got %d",
+		m.originalTargetCount, len(targets))
+	tassert.Errorf(t, objs == int64(m.num), "expected %d objects copied, got %d", m.num, objs)
+
+	// by ID
+	recs, err = api.GetXactionHistory(baseParams, api.XactReqArgs{ID: last.IDX})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(recs) == m.originalTargetCount, "expected %d records, got %d", m.originalTargetCount, len(recs))
+}
+
 func TestCopyBucket(t *testing.T) {
 	numput := 100
 	tests := []struct {
@@ -1885,6 +2258,78 @@ func TestCopyAndRenameBucket(t *testing.T) {
 	}
 }
 
+// Puts into the bucket that is being copied with the "reject" fencing mode
+func TestCopyBucketWriteFencing(t *testing.T) {
+	var (
+		m = ioContext{
+			t:   t,
+			num: 2000,
+		}
+		baseParams = tutils.BaseAPIParams()
+		dstBck     = cmn.Bck{
+			Name:     TestBucketName + "_new",
+			Provider: cmn.ProviderAIS,
+		}
+	)
+
+	m.saveClusterState()
+	srcBck := m.bck
+	tutils.CreateFreshBucket(t, m.proxyURL, srcBck)
+	defer func() {
+		tutils.DestroyBucket(t, m.proxyURL, srcBck)
+		tutils.DestroyBucket(t, m.proxyURL, dstBck)
+	}()
+
+	m.puts()
+
+	err := api.SetBucketProps(baseParams, srcBck, cmn.BucketPropsToUpdate{
+		Fencing: &cmn.FencingConfToUpdate{Mode: api.String(cmn.FenceReject)},
+	})
+	tassert.CheckFatal(t, err)
+	p, err := api.HeadBucket(baseParams, srcBck)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, p.Fencing.Mode == cmn.FenceReject, "expected fencing mode %q, got %q",
+		cmn.FenceReject, p.Fencing.Mode)
+
+	tutils.Logf("copy %s => %s\n", srcBck, dstBck)
+	err = api.CopyBucket(baseParams, srcBck, dstBck)
+	tassert.CheckFatal(t, err)
+
+	reader, err := readers.NewRandReader(cmn.KiB, cmn.ChecksumNone)
+	tassert.CheckFatal(t, err)
+	putArgs := api.PutObjectArgs{BaseParams: baseParams, Bck: srcBck, Object: "fenced", Reader: reader}
+	err = api.PutObject(putArgs)
+	if err == nil {
+		// the copy may have finished before the PUT (see also ais.TestFencePut)
+		xactArgs := api.XactReqArgs{Kind: cmn.ActCopyBucket, Bck: dstBck}
+		stats, err := api.QueryXactionStats(baseParams, xactArgs)
+		tassert.CheckFatal(t, err)
+		if stats.Running() {
+			t.Fatal("PUT into the bucket that is being copied did not fail")
+		}
+		tutils.Logf("%s => %s finished prior to PUT\n", srcBck, dstBck)
+	} else {
+		httpErr, ok := err.(*cmn.HTTPError)
+		if !ok {
+			t.Fatalf("Expected error of *cmn.HTTPError type, got %v", err)
+		}
+		if httpErr.Status != http.StatusServiceUnavailable {
+			t.Errorf("Expected status: %d, but got: %d.", http.StatusServiceUnavailable, httpErr.Status)
+		}
+	}
+
+	xactArgs := api.XactReqArgs{Kind: cmn.ActCopyBucket, Bck: dstBck, Timeout: rebalanceTimeout}
+	err = api.WaitForXaction(baseParams, xactArgs)
+	tassert.CheckFatal(t, err)
+
+	// no longer fenced
+	reader, err = readers.NewRandReader(cmn.KiB, cmn.ChecksumNone)
+	tassert.CheckFatal(t, err)
+	putArgs.Reader = reader
+	err = api.PutObject(putArgs)
+	tassert.CheckFatal(t, err)
+}
+
 func TestBackendBucket(t *testing.T) {
 	var (
 		cloudBck = cmn.Bck{
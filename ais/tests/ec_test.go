@@ -2771,3 +2771,291 @@ func ecAndRegularUnregisterWhileRebalancing(t *testing.T, o *ecOptions, smap *cl
 			len(newECList), len(oldECList))
 	}
 }
+
+// Damages an object's EC protection (by removing one of its slices) and then
+// re-protects the object from scratch
+func TestECReprotect(t *testing.T) {
+	const sleepRestoreTime = 5 * time.Second
+
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true})
+
+	var (
+		bck = cmn.Bck{
+			Name:     TestBucketName + "-reprotect",
+			Provider: cmn.ProviderAIS,
+		}
+		proxyURL = tutils.RandomProxyURL()
+		objName  = "obj-reprotect"
+		objPath  = ecTestDir + objName
+	)
+
+	o := ecOptions{
+		minTgt:    4,
+		dataCnt:   2,
+		parityCnt: 1,
+		objSize:   ecMinBigSize,
+	}.init(t, proxyURL)
+
+	baseParams := tutils.BaseAPIParams(proxyURL)
+	newLocalBckWithProps(t, baseParams, bck, defaultECBckProps(o), o)
+	defer tutils.DestroyBucket(t, proxyURL, bck)
+
+	totalCnt, objSize, sliceSize, doEC := randObjectSize(0, 1, o)
+	r, err := readers.NewRandReader(objSize, cmn.ChecksumNone)
+	tassert.CheckFatal(t, err)
+	defer r.Close()
+	putArgs := api.PutObjectArgs{BaseParams: baseParams, Bck: bck, Object: objPath, Reader: r}
+	tassert.CheckFatal(t, api.PutObject(putArgs))
+
+	foundParts, _ := waitForECFinishes(t, totalCnt, objSize, sliceSize, doEC, bck, objName)
+	ecCheckSlices(t, foundParts, bck, objPath, objSize, sliceSize, totalCnt)
+
+	for fqn := range foundParts {
+		ct, err := cluster.NewCTFromFQN(fqn, nil)
+		tassert.CheckFatal(t, err)
+		if ct.ContentType() != ec.SliceType {
+			continue
+		}
+		tutils.Logf("Damaging %s [removing %s]\n", objPath, fqn)
+		tassert.CheckFatal(t, os.Remove(fqn))
+		break
+	}
+
+	tutils.Logf("Re-protecting %s\n", objPath)
+	placement, err := api.ReprotectObject(baseParams, bck, objPath)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, !placement.ECReplica, "%s: expected slices, got replicas", objPath)
+	tassert.Errorf(t, len(placement.Slices) == o.sliceTotal(), "%s: expected %d slices, got %d",
+		objPath, o.sliceTotal(), len(placement.Slices))
+
+	var partsAfterReprotect map[string]ecSliceMD
+	deadline := time.Now().Add(sleepRestoreTime)
+	for time.Now().Before(deadline) {
+		time.Sleep(250 * time.Millisecond)
+		partsAfterReprotect, _ = ecGetAllSlices(t, bck, objName)
+		if len(partsAfterReprotect) == totalCnt {
+			break
+		}
+	}
+	ecCheckSlices(t, partsAfterReprotect, bck, objPath, objSize, sliceSize, totalCnt)
+}
+
+// Removes the main replicas of EC-ed objects and restores them in batches:
+// first, by template, then by prefix. The objects that do not match stay missing
+func TestECRestoreRange(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true})
+
+	const (
+		objCount = 10
+		pattern  = "obj-restore-%02d"
+		other    = "other-restore"
+	)
+
+	var (
+		bck = cmn.Bck{
+			Name:     TestBucketName + "-restore-range",
+			Provider: cmn.ProviderAIS,
+		}
+		proxyURL   = tutils.RandomProxyURL()
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		mainPaths  = make(map[string]string, objCount+1) // object name => FQN of the main replica
+	)
+
+	o := ecOptions{
+		minTgt:    4,
+		dataCnt:   2,
+		parityCnt: 1,
+	}.init(t, proxyURL)
+
+	newLocalBckWithProps(t, baseParams, bck, defaultECBckProps(o), o)
+	defer tutils.DestroyBucket(t, proxyURL, bck)
+
+	objNames := make([]string, 0, objCount+1)
+	for i := 0; i < objCount; i++ {
+		objNames = append(objNames, fmt.Sprintf(pattern, i))
+	}
+	objNames = append(objNames, other)
+	for _, objName := range objNames {
+		_, mainObjPath := createECFile(t, baseParams, bck, objName, o)
+		tutils.Logf("Removing main replica %s\n", mainObjPath)
+		tassert.CheckFatal(t, os.Remove(mainObjPath))
+		mainPaths[objName] = mainObjPath
+	}
+
+	restore := func(rng string, restored ...string) {
+		tutils.Logf("Restoring %q\n", rng)
+		xactID, err := api.ECRestoreRange(baseParams, bck, rng)
+		tassert.CheckFatal(t, err)
+		args := api.XactReqArgs{ID: xactID, Kind: cmn.ActECRestore, Bck: bck, Timeout: rebalanceTimeout}
+		tassert.CheckFatal(t, api.WaitForXaction(baseParams, args))
+		for _, objName := range restored {
+			_, err := os.Stat(mainPaths[objName])
+			tassert.Errorf(t, err == nil, "%s: main replica was not restored: %v", objName, err)
+		}
+	}
+	missing := func(objNames ...string) {
+		for _, objName := range objNames {
+			_, err := os.Stat(mainPaths[objName])
+			tassert.Errorf(t, os.IsNotExist(err), "%s: main replica must not be restored (err: %v)", objName, err)
+		}
+	}
+
+	restore(ecTestDir+"obj-restore-{00..04}", objNames[:objCount/2]...)
+	missing(objNames[objCount/2:]...)
+
+	restore(ecTestDir+"obj-restore-", objNames[:objCount]...)
+	missing(other)
+}
+
+// PUTs the objects with per-object EC policies that override the bucket's
+// configuration, and checks the replicas and slices that get created
+func TestECPolicyOverride(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true})
+
+	var (
+		bck = cmn.Bck{
+			Name:     TestBucketName + "-ec-policy",
+			Provider: cmn.ProviderAIS,
+		}
+		proxyURL   = tutils.RandomProxyURL()
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		objSize    = int64(ecMinBigSize * 2)
+	)
+
+	o := ecOptions{
+		minTgt:    3,
+		dataCnt:   1,
+		parityCnt: 1,
+	}.init(t, proxyURL)
+
+	newLocalBckWithProps(t, baseParams, bck, defaultECBckProps(o), o)
+	defer tutils.DestroyBucket(t, proxyURL, bck)
+
+	put := func(objName, policy string) error {
+		r, err := readers.NewRandReader(objSize, cmn.ChecksumNone)
+		tassert.CheckFatal(t, err)
+		defer r.Close()
+		return api.PutObject(api.PutObjectArgs{
+			BaseParams: baseParams,
+			Bck:        bck,
+			Object:     ecTestDir + objName,
+			Reader:     r,
+			ECPolicy:   policy,
+		})
+	}
+
+	tests := []struct {
+		name     string
+		policy   string
+		replicas int // including the main one
+		slices   int
+	}{
+		{name: "parity", policy: "parity=2", replicas: 1, slices: 3},
+		{name: "replication", policy: "replication=3", replicas: 3},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			objName := "obj-policy-" + test.name
+			tassert.CheckFatal(t, put(objName, test.policy))
+
+			var (
+				totalCnt  = (test.replicas + test.slices) * 2 // plus metafiles
+				sliceSize = objSize
+			)
+			if test.slices != 0 {
+				sliceSize = ec.SliceSize(objSize, o.dataCnt)
+			}
+			foundParts, _ := waitForECFinishes(t, totalCnt, objSize, sliceSize, test.slices != 0, bck, objName)
+			var replicas, slices int
+			for fqn := range foundParts {
+				ct, err := cluster.NewCTFromFQN(fqn, nil)
+				tassert.CheckFatal(t, err)
+				switch ct.ContentType() {
+				case fs.ObjectType:
+					replicas++
+				case ec.SliceType:
+					slices++
+				case ec.MetaType:
+					md, err := ec.LoadMetadata(fqn)
+					tassert.CheckFatal(t, err)
+					tassert.Errorf(t, md.Policy == test.policy, "expected policy %q, got %q", test.policy, md.Policy)
+				}
+			}
+			tassert.Errorf(t, replicas == test.replicas, "expected %d replicas, got %d", test.replicas, replicas)
+			tassert.Errorf(t, slices == test.slices, "expected %d slices, got %d", test.slices, slices)
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		tooMany := fmt.Sprintf("parity=%d", o.smap.CountTargets())
+		for _, policy := range []string{"parity=0", "copies=2", "replication=2,parity=1", tooMany} {
+			err := put("obj-policy-invalid", policy)
+			tassert.Errorf(t, err != nil, "expected error for EC policy %q", policy)
+		}
+	})
+}
+
+// the targets that store the EC slices (or replicas) of the objects (see api.ObjLocations)
+func TestECObjLocations(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true})
+
+	var (
+		bck = cmn.Bck{
+			Name:     TestBucketName + "-ec-locations",
+			Provider: cmn.ProviderAIS,
+		}
+		proxyURL   = tutils.RandomProxyURL()
+		baseParams = tutils.BaseAPIParams(proxyURL)
+	)
+
+	o := ecOptions{
+		minTgt:    3,
+		dataCnt:   1,
+		parityCnt: 1,
+	}.init(t, proxyURL)
+
+	newLocalBckWithProps(t, baseParams, bck, defaultECBckProps(o), o)
+	defer tutils.DestroyBucket(t, proxyURL, bck)
+
+	tests := []struct {
+		name    string
+		objSize int64
+		sliced  bool
+	}{
+		{name: "sliced", objSize: ecMinBigSize * 2, sliced: true},
+		{name: "replicated", objSize: ecMinSmallSize},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				objName   = "obj-locations-" + test.name
+				sliceSize = test.objSize
+				totalCnt  = 2 + 2*o.parityCnt // the object and its replicas, plus metafiles
+				ecTargets = o.parityCnt
+			)
+			if test.sliced {
+				sliceSize = ec.SliceSize(test.objSize, o.dataCnt)
+				totalCnt = 2 + 2*(o.dataCnt+o.parityCnt)
+				ecTargets = o.dataCnt + o.parityCnt
+			}
+			r, err := readers.NewRandReader(test.objSize, cmn.ChecksumNone)
+			tassert.CheckFatal(t, err)
+			err = api.PutObject(api.PutObjectArgs{BaseParams: baseParams, Bck: bck, Object: ecTestDir + objName, Reader: r})
+			r.Close()
+			tassert.CheckFatal(t, err)
+			waitForECFinishes(t, totalCnt, test.objSize, sliceSize, test.sliced, bck, objName)
+
+			locs, err := api.ObjLocations(baseParams, bck, []string{ecTestDir + objName})
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, len(locs.Objects) == 1, "expected 1 location, got %d", len(locs.Objects))
+			loc := locs.Objects[0]
+			tassert.Errorf(t, loc.Present && loc.ECReplica == !test.sliced, "unexpected %+v", loc)
+			tassert.Fatalf(t, len(loc.ECTargets) == ecTargets, "expected %d EC targets, got %v",
+				ecTargets, loc.ECTargets)
+			for _, sid := range loc.ECTargets {
+				tassert.Errorf(t, sid != loc.Target, "the main target %s must not store the slices", sid)
+				tassert.Errorf(t, locs.Hosts[sid] != "", "no host for %s (hosts: %v)", sid, locs.Hosts)
+			}
+		})
+	}
+}
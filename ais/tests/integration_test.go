@@ -5,6 +5,7 @@
 package integration
 
 import (
+	"bytes"
 	"fmt"
 	"math/rand"
 	"os"
@@ -19,6 +20,7 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/containers"
+	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/tutils"
 	"github.com/NVIDIA/aistore/tutils/readers"
 	"github.com/NVIDIA/aistore/tutils/tassert"
@@ -549,6 +551,62 @@ func TestRebalanceAfterUnregisterAndReregister(t *testing.T) {
 	m.assertClusterState()
 }
 
+// the objects of the bucket with at-rest encryption (see package encrypt) must
+// remain readable after having been migrated by the rebalance
+func TestRebalanceEncrypted(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true})
+
+	const num = 100
+	var (
+		m = ioContext{
+			t: t,
+		}
+		baseParams api.BaseParams
+		content    = func(i int) string { return strings.Repeat("encrypted-"+strconv.Itoa(i), 100) }
+	)
+	m.saveClusterState()
+	if m.originalTargetCount < 2 {
+		t.Fatalf("Must have 2 or more targets in the cluster, have only %d", m.originalTargetCount)
+	}
+	baseParams = tutils.BaseAPIParams(m.proxyURL)
+	tutils.CreateFreshBucket(t, m.proxyURL, m.bck, cmn.BucketPropsToUpdate{
+		Encryption: &cmn.EncryptionConfToUpdate{Enabled: api.Bool(true)},
+	})
+	defer tutils.DestroyBucket(t, m.proxyURL, m.bck)
+
+	target := tutils.ExtractTargetNodes(m.smap)[0]
+	tutils.Logf("Unregister target %s\n", target.URL(cmn.NetworkPublic))
+	tassert.CheckFatal(t, tutils.UnregisterNode(m.proxyURL, target.ID()))
+
+	for i := 0; i < num; i++ {
+		err := api.PutObject(api.PutObjectArgs{
+			BaseParams: baseParams,
+			Bck:        m.bck,
+			Object:     "obj" + strconv.Itoa(i),
+			Reader:     cmn.NewByteHandle([]byte(content(i))),
+		})
+		if err != nil && i == 0 && strings.Contains(err.Error(), "encrypt") {
+			tassert.CheckFatal(t, tutils.RegisterNode(m.proxyURL, target, m.smap))
+			tutils.WaitForRebalanceToComplete(t, baseParams, rebalanceTimeout)
+			t.Skipf("at-rest encryption is not configured: %v", err)
+		}
+		tassert.CheckFatal(t, err)
+	}
+
+	tutils.Logf("Register target %s\n", target.URL(cmn.NetworkPublic))
+	tassert.CheckFatal(t, tutils.RegisterNode(m.proxyURL, target, m.smap))
+	tutils.WaitForRebalanceToComplete(t, baseParams, rebalanceTimeout)
+
+	for i := 0; i < num; i++ {
+		objName := "obj" + strconv.Itoa(i)
+		writer := bytes.NewBuffer(nil)
+		_, err := api.GetObject(baseParams, m.bck, objName, api.GetObjectInput{Writer: writer})
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, writer.String() == content(i), "%s: invalid content after rebalance", objName)
+	}
+	m.assertClusterState()
+}
+
 func TestPutDuringRebalance(t *testing.T) {
 	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true})
 
@@ -1105,6 +1163,90 @@ func TestLocalAndGlobalRebalanceAfterAddingMountpath(t *testing.T) {
 	m.ensureNoErrors()
 }
 
+// Intended for a (local) deployment with labeled mountpaths (see FSPathsConf)
+// 1. PUT objects into ais bucket
+// 2. Restrict the bucket's placement to one of the labels
+// 3. Wait for resilver to move the objects onto the labeled mountpaths
+// 4. Check that the other mountpaths (of the targets that have the label) hold none
+func TestResilverAfterChangingPlacement(t *testing.T) {
+	tutils.CheckSkip(t, tutils.SkipTestArgs{Long: true})
+	if containers.DockerRunning() {
+		t.Skip("test requires access to the targets' mountpaths")
+	}
+
+	var (
+		m = ioContext{
+			t:               t,
+			num:             1000,
+			numGetsEachFile: 1,
+		}
+		label      string
+		mpaths     = make(map[string]map[string]string) // target ID => mountpath => label
+		baseParams = tutils.BaseAPIParams()
+	)
+
+	m.saveClusterState()
+	for _, target := range tutils.ExtractTargetNodes(m.smap) {
+		config := tutils.GetDaemonConfig(t, target.ID())
+		mpaths[target.ID()] = make(map[string]string, len(config.FSpaths.Paths))
+		for mpath := range config.FSpaths.Paths {
+			l := config.FSpaths.Labels[mpath]
+			mpaths[target.ID()][mpath] = l
+			if label == "" {
+				label = l
+			}
+		}
+	}
+	if label == "" {
+		t.Skip("test requires labeled mountpaths")
+	}
+
+	tutils.CreateFreshBucket(t, m.proxyURL, m.bck)
+	defer tutils.DestroyBucket(t, m.proxyURL, m.bck)
+
+	m.puts()
+
+	tutils.Logf("restricting placement of %s to %q mountpaths\n", m.bck, label)
+	err := api.SetBucketProps(baseParams, m.bck, cmn.BucketPropsToUpdate{
+		Placement: &cmn.PlacementConfToUpdate{Labels: api.String(label)},
+	})
+	tassert.CheckFatal(t, err)
+
+	tutils.WaitForRebalanceToComplete(t, baseParams, rebalanceTimeout)
+
+	for tid, labels := range mpaths {
+		var labeled bool
+		for _, l := range labels {
+			labeled = labeled || l == label
+		}
+		if !labeled {
+			continue // uses all its mountpaths
+		}
+		for mpath, l := range labels {
+			if l == label {
+				continue
+			}
+			fsWalkFunc := func(path string, info os.FileInfo, err error) error {
+				if err != nil {
+					return nil
+				}
+				if tutils.IsTrashDir(path) {
+					return filepath.SkipDir
+				}
+				if !info.IsDir() && strings.Contains(path, "/"+fs.ObjectType+"/") &&
+					strings.Contains(path, "/"+m.bck.Name+"/") {
+					t.Errorf("target %s: %q remains on mountpath %q (label %q)", tid, path, mpath, l)
+				}
+				return nil
+			}
+			filepath.Walk(mpath, fsWalkFunc)
+		}
+	}
+
+	m.gets()
+	m.ensureNoErrors()
+}
+
 func TestDisableAndEnableMountpath(t *testing.T) {
 	var (
 		m = ioContext{
@@ -26,6 +26,7 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/containers"
+	"github.com/NVIDIA/aistore/encrypt"
 	"github.com/NVIDIA/aistore/tutils"
 	"github.com/NVIDIA/aistore/tutils/readers"
 	"github.com/NVIDIA/aistore/tutils/tassert"
@@ -180,6 +181,54 @@ func TestAppendObject(t *testing.T) {
 	}
 }
 
+// APPEND to an object of the bucket with at-rest encryption (see package encrypt):
+// the flushed object must be encrypted and read back as is
+func TestAppendObjectEncrypted(t *testing.T) {
+	var (
+		proxyURL   = tutils.RandomProxyURL()
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		bck        = cmn.Bck{
+			Name:     TestBucketName,
+			Provider: cmn.ProviderAIS,
+		}
+		objName = "test/encrypted"
+		parts   = []string{"1111111111", "222222222222222", "333333333"}
+		handle  string
+		err     error
+	)
+	tutils.CreateFreshBucket(t, proxyURL, bck, cmn.BucketPropsToUpdate{
+		Encryption: &cmn.EncryptionConfToUpdate{Enabled: api.Bool(true)},
+	})
+	defer tutils.DestroyBucket(t, proxyURL, bck)
+
+	for _, part := range parts {
+		handle, err = api.AppendObject(api.AppendArgs{
+			BaseParams: baseParams,
+			Bck:        bck,
+			Object:     objName,
+			Handle:     handle,
+			Reader:     cmn.NewByteHandle([]byte(part)),
+		})
+		tassert.CheckFatal(t, err)
+	}
+	err = api.FlushObject(api.FlushArgs{BaseParams: baseParams, Bck: bck, Object: objName, Handle: handle})
+	if err != nil && strings.Contains(err.Error(), "encrypt") {
+		t.Skipf("at-rest encryption is not configured: %v", err)
+	}
+	tassert.CheckFatal(t, err)
+
+	props, err := api.HeadObject(baseParams, bck, objName)
+	tassert.CheckFatal(t, err)
+	_, ok := props.CustomMD[encrypt.AlgMD]
+	tassert.Errorf(t, ok, "expected %s to be encrypted, custom metadata: %v", objName, props.CustomMD)
+
+	content := strings.Join(parts, "")
+	writer := bytes.NewBuffer(nil)
+	_, err = api.GetObject(baseParams, bck, objName, api.GetObjectInput{Writer: writer})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, writer.String() == content, "invalid object content: %q, expected: %q", writer.String(), content)
+}
+
 // PUT, then delete
 func Test_putdelete(t *testing.T) {
 	const fileSize = 512 * cmn.KiB
@@ -1795,3 +1844,42 @@ func TestPutObjectWithChecksum(t *testing.T) {
 		}
 	}
 }
+
+func TestGetObjectWithProps(t *testing.T) {
+	var (
+		proxyURL   = tutils.RandomProxyURL()
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		bck        = cmn.Bck{
+			Name:     clibucket,
+			Provider: cmn.ProviderAIS,
+		}
+		objName = "mytestobj.txt"
+		objData = []byte("I am object data")
+		buf     = &bytes.Buffer{}
+	)
+	tutils.CreateFreshBucket(t, proxyURL, bck)
+	defer tutils.DestroyBucket(t, proxyURL, bck)
+
+	err := api.PutObject(api.PutObjectArgs{
+		BaseParams: baseParams,
+		Bck:        bck,
+		Object:     objName,
+		Reader:     readers.NewBytesReader(objData),
+	})
+	tassert.CheckFatal(t, err)
+
+	headProps, err := api.HeadObject(baseParams, bck, objName)
+	tassert.CheckFatal(t, err)
+	n, getProps, err := api.GetObjectWithProps(baseParams, bck, objName, api.GetObjectInput{Writer: buf})
+	tassert.CheckFatal(t, err)
+
+	tassert.Errorf(t, n == int64(len(objData)) && bytes.Equal(buf.Bytes(), objData), "GET returned wrong data")
+	tassert.Errorf(t, getProps.Present, "expected the object to be present")
+	tassert.Errorf(t, getProps.Name == headProps.Name && getProps.Size == headProps.Size,
+		"GET props (%s, %d) differ from HEAD props (%s, %d)", getProps.Name, getProps.Size,
+		headProps.Name, headProps.Size)
+	tassert.Errorf(t, getProps.NumCopies == headProps.NumCopies,
+		"GET copies %d differ from HEAD copies %d", getProps.NumCopies, headProps.NumCopies)
+	tassert.Errorf(t, getProps.Version == headProps.Version,
+		"GET version %q differs from HEAD version %q", getProps.Version, headProps.Version)
+}
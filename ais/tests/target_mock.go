@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"net"
 	"net/http"
 	"testing"
 
@@ -78,7 +79,7 @@ func registerMockTarget(proxyURL string, smap *cluster.Smap) error {
 		v.PublicNet = cluster.NetInfo{
 			NodeIPAddr: v.PublicNet.NodeIPAddr,
 			DaemonPort: mockTargetPort,
-			DirectURL:  "http://" + v.PublicNet.NodeIPAddr + ":" + mockTargetPort,
+			DirectURL:  "http://" + net.JoinHostPort(v.PublicNet.NodeIPAddr, mockTargetPort),
 		}
 		v.IntraControlNet = v.PublicNet
 		v.IntraDataNet = v.PublicNet
@@ -6,12 +6,18 @@ package ais
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"os"
+	"sync"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/xaction"
 )
 
@@ -29,7 +35,7 @@ func (t *targetrunner) listObjects(w http.ResponseWriter, r *http.Request, bck *
 	var msg cmn.SelectMsg
 	if err := cmn.MorphMarshal(actionMsg.Value, &msg); err != nil {
 		err := fmt.Errorf("unable to unmarshal 'value' in request to a cmn.SelectMsg: %v", actionMsg.Value)
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 	ok = t.doAsync(w, r, actionMsg.Action, bck, &msg)
@@ -46,7 +52,7 @@ func (t *targetrunner) bucketSummary(w http.ResponseWriter, r *http.Request, bck
 	var msg cmn.SelectMsg
 	if err := cmn.MorphMarshal(actionMsg.Value, &msg); err != nil {
 		err := fmt.Errorf("unable to unmarshal 'value' in request to a cmn.SelectMsg: %v", actionMsg.Value)
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 	ok = t.doAsync(w, r, actionMsg.Action, bck, &msg)
@@ -81,7 +87,7 @@ func (t *targetrunner) doAsync(w http.ResponseWriter, r *http.Request, action st
 		}
 
 		if err != nil {
-			t.invalmsghdlr(w, r, err.Error(), http.StatusInternalServerError)
+			t.invalmsghdlrErr(w, r, err, http.StatusInternalServerError)
 			return false
 		}
 
@@ -109,15 +115,20 @@ func (t *targetrunner) doAsync(w http.ResponseWriter, r *http.Request, action st
 	result, err := xact.Result()
 	if err != nil {
 		if cmn.IsErrBucketNought(err) {
-			t.invalmsghdlr(w, r, err.Error(), http.StatusGone)
+			t.invalmsghdlrErr(w, r, err, http.StatusGone)
 		} else {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 		}
 		return false
 	}
 
 	switch action {
 	case cmn.ActListObjects:
+		if smsg.Prefetch > 0 && taskAction == cmn.TaskResult {
+			if bckList, ok := result.(*cmn.BucketList); ok && bckList != nil {
+				t.warmUpListed(bck, bckList.Entries, int(smsg.Prefetch))
+			}
+		}
 		if !smsg.Fast {
 			break
 		}
@@ -161,3 +172,98 @@ func (t *targetrunner) doAsync(w http.ResponseWriter, r *http.Request, action st
 
 	return true
 }
+
+// Warm-up
+//
+// The listed objects (see cmn.SelectMsg.Prefetch) get warmed up in
+// anticipation of the client reading them in the listed order: the objects
+// that this target stores get read into page cache, those that are not cached
+// yet (cloud bucket) - fetched from the cloud. The warm-up is best-effort and
+// is done by a fixed number of workers; the objects that do not fit the
+// (bounded) queue are not warmed up.
+
+const (
+	warmUpWorkersPerMpath = 2
+	warmUpQueueSize       = 4096
+)
+
+type (
+	warmUpItem struct {
+		bck     *cluster.Bck
+		objName string
+	}
+	warmUpPool struct {
+		once   sync.Once
+		workCh chan warmUpItem
+	}
+)
+
+func (t *targetrunner) warmUpListed(bck *cluster.Bck, entries []*cmn.BucketEntry, count int) {
+	pool := &t.warmUp
+	pool.once.Do(func() {
+		pool.workCh = make(chan warmUpItem, warmUpQueueSize)
+		workers := cmn.Max(fs.Mountpaths.NumAvail(), 1) * warmUpWorkersPerMpath
+		for i := 0; i < workers; i++ {
+			go t.warmUpWorker(pool.workCh)
+		}
+	})
+	if count > len(entries) {
+		count = len(entries)
+	}
+	for i, entry := range entries[:count] {
+		select {
+		case pool.workCh <- warmUpItem{bck: bck, objName: entry.Name}:
+		default:
+			if glog.FastV(4, glog.SmoduleAIS) {
+				glog.Infof("%s: warm-up queue is full, skipping %d objects of %s", t.si, count-i, bck)
+			}
+			return
+		}
+	}
+}
+
+func (t *targetrunner) warmUpWorker(workCh <-chan warmUpItem) {
+	buf, slab := t.gmm.Alloc()
+	defer slab.Free(buf)
+	for item := range workCh {
+		t.warmUpObj(item, buf)
+	}
+}
+
+func (t *targetrunner) warmUpObj(item warmUpItem, buf []byte) {
+	lom := &cluster.LOM{T: t, ObjName: item.objName}
+	if err := lom.Init(item.bck.Bck); err != nil {
+		glog.Error(err)
+		return
+	}
+	smap := t.owner.smap.get()
+	if tsi, err := cluster.HrwTarget(lom.Uname(), &smap.Smap); err != nil || tsi.ID() != t.si.ID() {
+		return // the owner warms it up
+	}
+	lom.Lock(false)
+	err := lom.Load()
+	if err == nil {
+		err = t.readDiscard(lom.FQN, buf)
+	}
+	lom.Unlock(false)
+	if cmn.IsObjNotExist(err) && lom.Bck().IsRemote() {
+		if err, _ = t.GetCold(context.Background(), lom, true /*prefetch*/); errors.Is(err, cmn.ErrSkip) {
+			err = nil // being fetched by someone else
+		}
+	}
+	if err != nil {
+		glog.Errorf("%s: failed to warm up %s: %v", t.si, lom, err)
+	} else if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: warmed up %s", t.si, lom)
+	}
+}
+
+func (t *targetrunner) readDiscard(fqn string, buf []byte) error {
+	file, err := os.Open(fqn)
+	if err != nil {
+		return err
+	}
+	_, err = io.CopyBuffer(ioutil.Discard, file, buf)
+	file.Close()
+	return err
+}
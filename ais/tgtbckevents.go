@@ -0,0 +1,141 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Bucket events
+//
+// Each target counts the mutations (PUTs, APPENDs, DELETEs and evictions,
+// renames) of the buckets' objects that it stores and remembers the time of the
+// latest one. The proxy sums up the targets' counters when listing buckets
+// with cmn.URLParamBckEvents, so that, e.g., sync tools can cheaply find out
+// which buckets have changed. The counters are kept in memory - they start
+// from zero when the target (re)starts and when the bucket gets destroyed.
+// On the data path, counting takes a shared (read) lock and atomic increments;
+// the exclusive lock is only taken to add a bucket (upon its first mutation).
+
+type (
+	bckEvents struct {
+		mtx sync.RWMutex
+		m   map[string]*bckCounters // bucket uname => events
+	}
+	bckCounters struct {
+		puts         atomic.Int64
+		appends      atomic.Int64
+		deletes      atomic.Int64
+		renames      atomic.Int64
+		lastMutation atomic.Int64
+	}
+)
+
+func (e *bckEvents) counters(uname string) *bckCounters {
+	e.mtx.RLock()
+	cnts, ok := e.m[uname]
+	e.mtx.RUnlock()
+	if ok {
+		return cnts
+	}
+	e.mtx.Lock()
+	if e.m == nil {
+		e.m = make(map[string]*bckCounters, 8)
+	}
+	if cnts, ok = e.m[uname]; !ok {
+		cnts = &bckCounters{}
+		e.m[uname] = cnts
+	}
+	e.mtx.Unlock()
+	return cnts
+}
+
+func (e *bckEvents) add(bck *cluster.Bck, op string) {
+	cnts := e.counters(bck.MakeUname(""))
+	switch op {
+	case cmn.JournalPut:
+		cnts.puts.Inc()
+	case cmn.JournalAppend:
+		cnts.appends.Inc()
+	case cmn.JournalDelete, cmn.JournalEvict:
+		cnts.deletes.Inc()
+	case cmn.JournalRename:
+		cnts.renames.Inc()
+	}
+	cnts.lastMutation.Store(time.Now().UnixNano())
+}
+
+func (e *bckEvents) get(bck cmn.Bck) (events cmn.BckEvents) {
+	uname := cluster.NewBckEmbed(bck).MakeUname("")
+	e.mtx.RLock()
+	cnts, ok := e.m[uname]
+	e.mtx.RUnlock()
+	if ok {
+		events.Puts = cnts.puts.Load()
+		events.Appends = cnts.appends.Load()
+		events.Deletes = cnts.deletes.Load()
+		events.Renames = cnts.renames.Load()
+		events.LastMutation = cnts.lastMutation.Load()
+	}
+	return
+}
+
+func (e *bckEvents) clear(bck *cluster.Bck) {
+	e.mtx.Lock()
+	delete(e.m, bck.MakeUname(""))
+	e.mtx.Unlock()
+}
+
+func (t *targetrunner) listBucketsEvents(w http.ResponseWriter, r *http.Request, bucketNames cmn.BucketNames) {
+	res := make(cmn.BucketsEvents, 0, len(bucketNames))
+	for _, bck := range bucketNames {
+		res = append(res, cmn.BucketEvents{Bck: bck, Events: t.bckEvents.get(bck)})
+	}
+	t.writeJSON(w, r, res, listBuckets)
+}
+
+// sums up the targets' counters
+func (p *proxyrunner) listBucketsEvents(w http.ResponseWriter, r *http.Request) {
+	results := p.bcastGet(bcastArgs{
+		req: cmn.ReqArgs{
+			Path:  r.URL.Path,
+			Query: r.URL.Query(),
+		},
+	})
+	var (
+		res   = make(cmn.BucketsEvents, 0, 8)
+		index = make(map[string]int, 8) // bucket uname => index in `res`
+	)
+	for result := range results {
+		if result.err != nil {
+			p.invalmsghdlr(w, r, result.details)
+			return
+		}
+		var events cmn.BucketsEvents
+		if err := jsoniter.Unmarshal(result.outjson, &events); err != nil {
+			p.invalmsghdlrErr(w, r, err, http.StatusInternalServerError)
+			return
+		}
+		for _, ev := range events {
+			uname := cluster.NewBckEmbed(ev.Bck).MakeUname("")
+			if idx, ok := index[uname]; ok {
+				res[idx].Events.Aggregate(ev.Events)
+			} else {
+				index[uname] = len(res)
+				res = append(res, ev)
+			}
+		}
+	}
+	sort.Sort(res)
+	p.writeJSON(w, r, res, listBuckets)
+}
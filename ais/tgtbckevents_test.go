@@ -0,0 +1,49 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestBckEventsConcurrent(t *testing.T) {
+	const workers, perWkr = 8, 1000
+	var (
+		e    = &bckEvents{}
+		wg   = &sync.WaitGroup{}
+		bckA = cluster.NewBck("events-a", cmn.ProviderAIS, cmn.NsGlobal)
+		bckB = cluster.NewBck("events-b", cmn.ProviderAmazon, cmn.NsGlobal)
+		ops  = []string{cmn.JournalPut, cmn.JournalAppend, cmn.JournalDelete, cmn.JournalEvict, cmn.JournalRename}
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := 0; k < perWkr; k++ {
+				e.add(bckA, ops[k%len(ops)])
+				e.add(bckB, cmn.JournalPut)
+				_ = e.get(bckA.Bck)
+			}
+		}()
+	}
+	wg.Wait()
+
+	n := int64(workers * perWkr / len(ops))
+	events := e.get(bckA.Bck)
+	tassert.Errorf(t, events.Puts == n && events.Appends == n && events.Renames == n && events.Deletes == 2*n,
+		"unexpected %+v", events)
+	tassert.Errorf(t, events.LastMutation > 0, "expected the time of the last mutation")
+	events = e.get(bckB.Bck)
+	tassert.Errorf(t, events.Puts == workers*perWkr && events.Deletes == 0, "unexpected %+v", events)
+
+	e.clear(bckA)
+	tassert.Errorf(t, e.get(bckA.Bck) == cmn.BckEvents{}, "expected no events after clear")
+	tassert.Errorf(t, e.get(bckB.Bck).Puts == workers*perWkr, "expected %s events to be kept", bckB)
+}
@@ -23,6 +23,9 @@ type replicInfo struct {
 	localOnly bool // copy locally with no HRW=>target
 	uncache   bool // uncache the source
 	finalize  bool // copies and EC (as in poi.finalize())
+	plain     bool // the source is a file rather than an object: received as a new PUT (and encrypted if need be)
+	// if not nil, replaces the custom metadata of the destination object
+	customMD cmn.SimpleKVs
 }
 
 //
@@ -86,11 +89,17 @@ func (ri *replicInfo) copyObject(lom *cluster.LOM, objNameTo string) (copied boo
 	// the one on which the `lom` is placed then both `lom` and `dst` will have
 	// the same FQN in which case we should not copy.
 	if lom.FQN == dst.FQN {
+		if ri.customMD != nil {
+			err = ri.setCustomMD(lom)
+		}
 		return
 	}
 
 	if err = dst.Load(false); err == nil {
 		if lom.Cksum().Equal(dst.Cksum()) {
+			if ri.customMD != nil {
+				err = ri.setCustomMD(dst)
+			}
 			return
 		}
 	} else if cmn.IsErrBucketNought(err) {
@@ -98,6 +107,9 @@ func (ri *replicInfo) copyObject(lom *cluster.LOM, objNameTo string) (copied boo
 	}
 
 	dst, err = lom.CopyObject(dst.FQN, ri.buf)
+	if err == nil && ri.customMD != nil {
+		err = ri.setCustomMD(dst)
+	}
 	if err == nil {
 		copied = true
 		dst.ReCache()
@@ -108,6 +120,16 @@ func (ri *replicInfo) copyObject(lom *cluster.LOM, objNameTo string) (copied boo
 	return
 }
 
+// is called under the object's exclusive lock
+func (ri *replicInfo) setCustomMD(lom *cluster.LOM) error {
+	lom.SetCustomMD(ri.customMD)
+	if err := lom.Persist(); err != nil {
+		return err
+	}
+	lom.ReCache()
+	return nil
+}
+
 // TODO: reuse rebalancing code and streams
 func (ri *replicInfo) putRemote(lom *cluster.LOM, objNameTo string, si *cluster.Snode) (copied bool, err error) {
 	var file *cmn.FileHandle // Closed by `.Do()`
@@ -121,9 +143,17 @@ func (ri *replicInfo) putRemote(lom *cluster.LOM, objNameTo string, si *cluster.
 		query  = url.Values{}
 		header = lom.PopulateHdr(nil)
 	)
+	if ri.customMD != nil {
+		header.Del(cmn.HeaderObjCustomMD)
+		for k, v := range ri.customMD {
+			header.Add(cmn.HeaderObjCustomMD, k+"="+v)
+		}
+	}
 	query = cmn.AddBckToQuery(query, ri.bckTo.Bck)
 	query.Add(cmn.URLParamTargetID, ri.t.si.ID())
-	query.Add(cmn.URLParamRecvType, strconv.Itoa(int(cluster.Migrated)))
+	if !ri.plain {
+		query.Add(cmn.URLParamRecvType, strconv.Itoa(int(cluster.Migrated)))
+	}
 	reqArgs := cmn.ReqArgs{
 		Method: http.MethodPut,
 		Base:   si.URL(cmn.NetworkIntraData),
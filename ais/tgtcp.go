@@ -50,7 +50,7 @@ func (t *targetrunner) initHostIP() {
 	}
 	t.si.PublicNet.NodeIPAddr = extAddr.String()
 	t.si.PublicNet.DaemonPort = strconv.Itoa(extPort)
-	t.si.PublicNet.DirectURL = fmt.Sprintf("%s://%s:%d", config.Net.HTTP.Proto, extAddr.String(), extPort)
+	t.si.PublicNet.DirectURL = config.Net.HTTP.Proto + "://" + net.JoinHostPort(extAddr.String(), t.si.PublicNet.DaemonPort)
 	glog.Infof("AIS_HOST_IP=%s; PubNetwork=%s", hostIP, t.si.URL(cmn.NetworkPublic))
 }
 
@@ -157,10 +157,14 @@ func (t *targetrunner) daeputJSON(w http.ResponseWriter, r *http.Request) {
 		}
 		kvs := cmn.NewSimpleKVs(cmn.SimpleKVsEntry{Key: msg.Name, Value: value})
 		if err := t.setConfig(kvs); err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 		}
 	case cmn.ActShutdown:
 		_ = syscall.Kill(syscall.Getpid(), syscall.SIGINT)
+	case cmn.ActForceUnlock:
+		if !cluster.ForceUnlockLOM(msg.Name) {
+			t.invalmsghdlrstatusf(w, r, http.StatusNotFound, "%s: lock %q not found", t.si, msg.Name)
+		}
 	default:
 		t.invalmsghdlrf(w, r, fmtUnknownAct, msg)
 	}
@@ -185,7 +189,7 @@ func (t *targetrunner) daeputQuery(w http.ResponseWriter, r *http.Request, apite
 	case cmn.ActSetConfig: // setconfig #1 - via query parameters and "?n1=v1&n2=v2..."
 		kvs := cmn.NewSimpleKVsFromQuery(r.URL.Query())
 		if err := t.setConfig(kvs); err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 		}
 	case cmn.ActAttach, cmn.ActDetach:
 		var (
@@ -198,7 +202,7 @@ func (t *targetrunner) daeputQuery(w http.ResponseWriter, r *http.Request, apite
 			return
 		}
 		if err := t.attachDetachRemoteAIS(query, action); err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 			return
 		}
 		// NOTE: once validated, save this config unconditionally, and prior to attempting attachment(s)
@@ -212,7 +216,7 @@ func (t *targetrunner) daeputQuery(w http.ResponseWriter, r *http.Request, apite
 		aisConf, ok := cmn.GCO.Get().Cloud.ProviderConf(cmn.ProviderAIS)
 		cmn.Assert(ok)
 		if err := t.cloud.ais.Apply(aisConf, action); err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 		}
 	}
 }
@@ -256,7 +260,7 @@ func (t *targetrunner) httpdaesetprimaryproxy(w http.ResponseWriter, r *http.Req
 	psi := smap.GetProxy(proxyID)
 	if psi == nil {
 		err := &errNodeNotFound{"cannot set new primary", proxyID, t.si, smap}
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 
@@ -280,7 +284,8 @@ func (t *targetrunner) httpdaeget(w http.ResponseWriter, r *http.Request) {
 	getWhat := r.URL.Query().Get(cmn.URLParamWhat)
 	httpdaeWhat := "httpdaeget-" + getWhat
 	switch getWhat {
-	case cmn.GetWhatConfig, cmn.GetWhatSmap, cmn.GetWhatBMD, cmn.GetWhatSmapVote, cmn.GetWhatSnode:
+	case cmn.GetWhatConfig, cmn.GetWhatSmap, cmn.GetWhatBMD, cmn.GetWhatSmapVote, cmn.GetWhatSnode,
+		cmn.GetWhatPeerStats:
 		t.httprunner.httpdaeget(w, r)
 	case cmn.GetWhatSysInfo:
 		tsysinfo := cmn.TSysInfo{
@@ -332,6 +337,11 @@ func (t *targetrunner) httpdaeget(w http.ResponseWriter, r *http.Request) {
 	case cmn.GetWhatDiskStats:
 		diskStats := fs.Mountpaths.GetSelectedDiskStats()
 		t.writeJSON(w, r, diskStats, httpdaeWhat)
+	case cmn.GetWhatLocks:
+		locks := cluster.LomLocks(r.URL.Query().Get(cmn.URLParamPrefix))
+		t.writeJSON(w, r, locks, httpdaeWhat)
+	case cmn.GetWhatXactHistory:
+		t.queryXactHistory(w, r, httpdaeWhat)
 	case cmn.GetWhatRemoteAIS:
 		conf, ok := cmn.GCO.Get().Cloud.ProviderConf(cmn.ProviderAIS)
 		if !ok {
@@ -361,12 +371,12 @@ func (t *targetrunner) httpdaepost(w http.ResponseWriter, r *http.Request) {
 			gettargetkeepalive().keepalive.send(kaRegisterMsg)
 			body, err := cmn.ReadBytes(r)
 			if err != nil {
-				t.invalmsghdlr(w, r, err.Error())
+				t.invalmsghdlrErr(w, r, err)
 				return
 			}
 			caller := r.Header.Get(cmn.HeaderCallerName)
 			if err := t.applyRegMeta(body, caller); err != nil {
-				t.invalmsghdlr(w, r, err.Error())
+				t.invalmsghdlrErr(w, r, err)
 			}
 			return
 		case cmn.Mountpaths:
@@ -450,6 +460,8 @@ func (t *targetrunner) handleMountpathReq(w http.ResponseWriter, r *http.Request
 		t.handleAddMountpathReq(w, r, mountpath)
 	case cmn.ActMountpathRemove:
 		t.handleRemoveMountpathReq(w, r, mountpath)
+	case cmn.ActMountpathDrain:
+		t.handleDrainMountpathReq(w, r, mountpath)
 	default:
 		t.invalmsghdlrf(w, r, fmtUnknownAct, msg)
 	}
@@ -459,10 +471,10 @@ func (t *targetrunner) handleEnableMountpathReq(w http.ResponseWriter, r *http.R
 	enabled, err := t.fsprg.enableMountpath(mountpath)
 	if err != nil {
 		if _, ok := err.(cmn.NoMountpathError); ok {
-			t.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+			t.invalmsghdlrErr(w, r, err, http.StatusNotFound)
 		} else {
 			// cmn.InvalidMountpathError
-			t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+			t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		}
 		return
 	}
@@ -482,7 +494,7 @@ func (t *targetrunner) handleEnableMountpathReq(w http.ResponseWriter, r *http.R
 		return err != nil // break on error
 	})
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 
@@ -497,10 +509,10 @@ func (t *targetrunner) handleDisableMountpathReq(w http.ResponseWriter, r *http.
 	disabled, err := t.fsprg.disableMountpath(mountpath)
 	if err != nil {
 		if _, ok := err.(*cmn.NoMountpathError); ok {
-			t.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+			t.invalmsghdlrErr(w, r, err, http.StatusNotFound)
 		} else {
 			// cmn.InvalidMountpathError
-			t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+			t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		}
 		return
 	}
@@ -516,7 +528,7 @@ func (t *targetrunner) handleDisableMountpathReq(w http.ResponseWriter, r *http.
 func (t *targetrunner) handleAddMountpathReq(w http.ResponseWriter, r *http.Request, mountpath string) {
 	err := t.fsprg.addMountpath(mountpath)
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 	var (
@@ -531,7 +543,7 @@ func (t *targetrunner) handleAddMountpathReq(w http.ResponseWriter, r *http.Requ
 		return err != nil // break on error
 	})
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 
@@ -552,6 +564,19 @@ func (t *targetrunner) handleRemoveMountpathReq(w http.ResponseWriter, r *http.R
 	dsort.Managers.AbortAll(fmt.Errorf("mountpath %q has been removed and is unusable", mountpath))
 }
 
+func (t *targetrunner) handleDrainMountpathReq(w http.ResponseWriter, r *http.Request, mountpath string) {
+	xact, err := t.fsprg.drainMountpath(mountpath)
+	if err != nil {
+		if _, ok := err.(cmn.NoMountpathError); ok {
+			t.invalmsghdlrErr(w, r, err, http.StatusNotFound)
+		} else {
+			t.invalmsghdlrf(w, r, "Could not drain mountpath, error: %s", err.Error())
+		}
+		return
+	}
+	w.Write([]byte(xact.ID().String()))
+}
+
 func (t *targetrunner) receiveBMD(newBMD *bucketMD, msg *aisMsg, tag, caller string) (err error) {
 	if msg.UUID == "" {
 		err = t._recvBMD(newBMD, msg, tag, caller)
@@ -563,6 +588,13 @@ func (t *targetrunner) receiveBMD(newBMD *bucketMD, msg *aisMsg, tag, caller str
 		glog.Error(err)
 		return
 	}
+	if txn, errFind := t.transactions.find(msg.UUID, false); errFind == nil {
+		if txnSwap, ok := txn.(*txnSwapBuckets); ok {
+			if errSwap := txnSwap.swapDirs(); errSwap != nil {
+				glog.Errorf("%s: %s: %v", t.si, txnSwap, errSwap) // to be retried upon commit
+			}
+		}
+	}
 
 	err = t._recvBMD(newBMD, msg, tag, caller)
 
@@ -651,6 +683,7 @@ func (t *targetrunner) _recvBMD(newBMD *bucketMD, msg *aisMsg, tag, caller strin
 			}
 			if obck.Props.EC.Enabled && !nbck.Props.EC.Enabled {
 				xaction.Registry.DoAbort(cmn.ActECEncode, nbck)
+				ec.ClearEncodeCkpts(t.dbDriver, nbck.Bck)
 			}
 			return true
 		})
@@ -677,6 +710,9 @@ func (t *targetrunner) _recvBMD(newBMD *bucketMD, msg *aisMsg, tag, caller strin
 		go func(bcks ...*cluster.Bck) {
 			for _, b := range bcks {
 				cluster.EvictLomCache(b)
+				t.opJournal.clear(t.dbDriver, b)
+				t.bckEvents.clear(b)
+				ec.ClearEncodeCkpts(t.dbDriver, b.Bck)
 			}
 		}(bcksToDelete...)
 	}
@@ -705,9 +741,13 @@ func (t *targetrunner) receiveSmap(newSmap *smapX, msg *aisMsg, caller string) (
 		glog.Warningf("Error: %s\n%s", err, newSmap.pp())
 		return
 	}
+	psi := t.owner.smap.get().ProxySI
 	if err = t.owner.smap.synchronize(newSmap, true /* lesserIsErr */); err != nil {
 		return
 	}
+	if psi != nil && newSmap.ProxySI != nil && psi.ID() != newSmap.ProxySI.ID() {
+		t.transactions.abortOrphaned(psi.ID())
+	}
 	return
 }
 
@@ -733,6 +773,7 @@ func (t *targetrunner) receiveRMD(newRMD *rebMD, msg *aisMsg, caller string) (er
 	}
 
 	smap := t.owner.smap.Get()
+	t.rebalanceMisplaced()
 	if msg.Action == cmn.ActRebalance { // manual (triggered by user)
 		glog.Infof("%s: manual rebalance (version: %d)", t.si, newRMD.version())
 		go t.rebManager.RunRebalance(smap, newRMD.Version)
@@ -1007,7 +1048,7 @@ func (t *targetrunner) metasyncHandlerPost(w http.ResponseWriter, r *http.Reques
 	caller := r.Header.Get(cmn.HeaderCallerName)
 	newSmap, msg, err := t.extractSmap(payload, caller)
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 
@@ -1026,6 +1067,12 @@ func (t *targetrunner) healthHandler(w http.ResponseWriter, r *http.Request) {
 		query    = r.URL.Query()
 	)
 
+	setNodeTime(w)
+	if cmn.IsParseBool(query.Get(cmn.URLParamClockSkew)) {
+		_ = t.writeJSON(w, r, t.clock.get(smap), "clock-skew")
+		return
+	}
+
 	// external (i.e. not intra-cluster) call
 	if callerID == "" && caller == "" {
 		if glog.FastV(4, glog.SmoduleAIS) {
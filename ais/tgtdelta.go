@@ -0,0 +1,72 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/dbdriver"
+)
+
+// Delta resilver
+//
+// Upon clean shutdown the target records the time in its DB. When it then
+// restarts after an unclean shutdown (see nodeRestartedMarker), it runs the
+// delta resilver (see reb.RunDeltaResilver) that only visits the content
+// modified since the last recorded time - rather than the entire mountpaths.
+// Once done, the record moves forward to the time the delta resilver started,
+// so that the next unclean restart does not revisit the same content. With no
+// record (e.g., the very first start) there's nothing to compare against, and
+// the delta resilver is skipped.
+
+const (
+	targetCollection = "target"
+	cleanShutdownKey = "clean-shutdown"
+)
+
+func (t *targetrunner) putCleanShutdown(tm time.Time) {
+	if t.dbDriver == nil {
+		return
+	}
+	if err := t.dbDriver.SetString(targetCollection, cleanShutdownKey, strconv.FormatInt(tm.UnixNano(), 10)); err != nil {
+		glog.Errorf("%s: failed to record clean shutdown: %v", t.si, err)
+	}
+}
+
+func (t *targetrunner) getCleanShutdown() (tm time.Time, ok bool) {
+	s, err := t.dbDriver.GetString(targetCollection, cleanShutdownKey)
+	if err != nil {
+		if !dbdriver.IsErrNotFound(err) {
+			glog.Errorf("%s: failed to read the last clean shutdown time: %v", t.si, err)
+		}
+		return
+	}
+	ns, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		glog.Errorf("%s: invalid clean shutdown time %q: %v", t.si, s, err)
+		return
+	}
+	return time.Unix(0, ns), true
+}
+
+// runs (upon the target's startup after unclean shutdown) the delta resilver
+func (t *targetrunner) runDeltaResilver() {
+	since, ok := t.getCleanShutdown()
+	if !ok {
+		glog.Warningf("%s: unclean restart with no record of clean shutdown - skipping delta resilver", t.si)
+		return
+	}
+	for !t.ClusterStarted() {
+		time.Sleep(time.Second)
+	}
+	started := time.Now()
+	glog.Infof("%s: unclean restart - running delta resilver (content modified since %s)",
+		t.si, since.Format(time.RFC3339))
+	if t.rebManager.RunDeltaResilver(since) {
+		t.putCleanShutdown(started)
+	}
+}
@@ -31,7 +31,7 @@ func (t *targetrunner) downloadHandler(w http.ResponseWriter, r *http.Request) {
 	)
 	downloaderXact, err := xaction.Registry.RenewDownloader(t, t.statsT)
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error(), http.StatusInternalServerError)
+		t.invalmsghdlrErr(w, r, err, http.StatusInternalServerError)
 		return
 	}
 	switch r.Method {
@@ -51,17 +51,17 @@ func (t *targetrunner) downloadHandler(w http.ResponseWriter, r *http.Request) {
 		}
 		bck := cluster.NewBckEmbed(dlBodyBase.Bck)
 		if err := bck.Init(t.GetBowner(), t.Snode()); err != nil {
-			t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+			t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 			return
 		}
 		if err := bck.Allow(cmn.AccessSYNC); err != nil {
-			t.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			t.invalmsghdlrErr(w, r, err, http.StatusForbidden)
 			return
 		}
 
 		dlJob, err := downloader.ParseStartDownloadRequest(ctx, t, bck, uuid, dlb)
 		if err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 			return
 		}
 		if glog.FastV(4, glog.SmoduleAIS) {
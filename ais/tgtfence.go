@@ -0,0 +1,77 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/xaction"
+)
+
+// Write fencing
+//
+// While a bucket-level xaction (rename, copy, ec-encode) walks a bucket, client
+// PUTs of the bucket's objects race the walk: an object PUT behind the walk does
+// not get processed (e.g., copied), and an object PUT ahead of it may get
+// processed while being overwritten. Depending on the bucket's fencing mode
+// (cmn.FencingConf), the target:
+// * rejects such PUTs with 503 (the client is expected to retry later);
+// * holds them until the xaction finishes, for up to the configured timeout;
+// * lets them through and notifies the xaction (cluster.PutTracker) once the
+//   object is in place, so that the xaction processes it after the walk - or,
+//   if the xaction is already done with the tracked PUTs, processes it right away.
+// (EC-encoding does not need to be notified: the PUTs of the objects of an
+// EC-enabled bucket get encoded by the PUT path.)
+
+const fenceCheckIval = 500 * time.Millisecond
+
+// returns the xaction to notify once the object is PUT, if any
+func (t *targetrunner) fencePut(lom *cluster.LOM) (tracker cluster.PutTracker, err error, errCode int) {
+	xact := xaction.Registry.GetFencing(lom.Bck())
+	if xact == nil {
+		return
+	}
+	conf := &lom.Bprops().Fencing
+	switch conf.Mode {
+	case cmn.FenceReject:
+		err = fmt.Errorf("%s: cannot PUT %s while %s is running (fencing mode %q)", t.si, lom, xact, conf.Mode)
+		errCode = http.StatusServiceUnavailable
+	case cmn.FenceQueue:
+		timeout := conf.TimeoutDuration()
+		if timeout == 0 {
+			timeout = cmn.GCO.Get().Timeout.MaxHostBusy
+		}
+		started := time.Now()
+		for !xact.Finished() {
+			if time.Since(started) > timeout {
+				err = fmt.Errorf("%s: timed out (%v) waiting for %s to finish to PUT %s", t.si, timeout, xact, lom)
+				errCode = http.StatusServiceUnavailable
+				return
+			}
+			time.Sleep(fenceCheckIval)
+		}
+		if glog.FastV(4, glog.SmoduleAIS) {
+			glog.Infof("%s: PUT %s held for %v by %s", t.si, lom, time.Since(started), xact)
+		}
+	default:
+		tracker, _ = xact.(cluster.PutTracker)
+	}
+	return
+}
+
+// notify the xaction or, if it's too late for that, process the object in its stead
+func (t *targetrunner) trackPut(tracker cluster.PutTracker, lom *cluster.LOM) {
+	if tracker.TrackPut(lom) {
+		return
+	}
+	if err := tracker.ProcessPut(lom); err != nil && !cmn.IsErrObjNought(err) {
+		glog.Errorf("%s: failed to process %s PUT after %s: %v", t.si, lom, tracker, err)
+	}
+}
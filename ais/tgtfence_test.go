@@ -0,0 +1,63 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+	"github.com/NVIDIA/aistore/xaction"
+)
+
+// NOTE: `t` is the target (see TestMain)
+func TestFencePut(test *testing.T) {
+	var (
+		bck   = cluster.NewBck("fenced", cmn.ProviderAIS, cmn.NsGlobal)
+		bckTo = cluster.NewBck("fenced-renamed", cmn.ProviderAIS, cmn.NsGlobal)
+	)
+	setMode := func(mode, timeout string) *cluster.LOM {
+		bmd := t.owner.bmd.get().clone()
+		props := cmn.DefaultBucketProps()
+		props.Fencing = cmn.FencingConf{Mode: mode, Timeout: timeout}
+		if !bmd.add(bck, props) {
+			bmd.set(bck, props)
+		}
+		t.owner.bmd.put(bmd)
+		lom := &cluster.LOM{T: t, ObjName: "obj"}
+		tassert.CheckFatal(test, lom.Init(bck.Bck))
+		return lom
+	}
+
+	// nothing to race with
+	lom := setMode(cmn.FenceReject, "")
+	tracker, err, _ := t.fencePut(lom)
+	tassert.Errorf(test, tracker == nil && err == nil, "expected no fencing, got (%v, %v)", tracker, err)
+
+	xact, err := xaction.Registry.RenewBckFastRename(nil, 1, bck, bckTo, cmn.ActBegin, nil)
+	tassert.CheckFatal(test, err)
+	defer xact.Finish()
+
+	_, err, errCode := t.fencePut(lom)
+	tassert.Errorf(test, err != nil && errCode == http.StatusServiceUnavailable,
+		"expected PUT to be rejected, got (%v, %d)", err, errCode)
+
+	lom = setMode(cmn.FenceQueue, "10ms")
+	_, err, errCode = t.fencePut(lom)
+	tassert.Errorf(test, err != nil && errCode == http.StatusServiceUnavailable,
+		"expected PUT to time out, got (%v, %d)", err, errCode)
+
+	lom = setMode(cmn.FenceTrack, "")
+	tracker, err, _ = t.fencePut(lom)
+	tassert.CheckFatal(test, err)
+	tassert.Fatalf(test, tracker != nil, "expected %s to track the PUT", xact)
+
+	// done walking
+	xact.Finish()
+	tracker, err, _ = t.fencePut(lom)
+	tassert.Errorf(test, tracker == nil && err == nil, "expected no fencing, got (%v, %v)", tracker, err)
+}
@@ -75,6 +75,12 @@ func (t *targetrunner) RebalanceInfo() cluster.RebalanceInfo {
 	}
 }
 
+// returns nil if the bucket does not exist (see fs.QuotaManager)
+func (t *targetrunner) bckProps(bck cmn.Bck) *cmn.BucketProps {
+	props, _ := t.owner.bmd.get().Get(cluster.NewBckEmbed(bck))
+	return props
+}
+
 func (t *targetrunner) AvgCapUsed(config *cmn.Config, used ...int32) (capInfo cmn.CapacityInfo) {
 	if config == nil {
 		config = cmn.GCO.Get()
@@ -82,13 +88,17 @@ func (t *targetrunner) AvgCapUsed(config *cmn.Config, used ...int32) (capInfo cm
 	if len(used) > 0 {
 		t.capUsed.Lock()
 		t.capUsed.used = used[0]
+		oos := t.capUsed.oos
 		if t.capUsed.oos && int64(t.capUsed.used) < config.LRU.HighWM {
 			t.capUsed.oos = false
 		} else if !t.capUsed.oos && int64(t.capUsed.used) > config.LRU.OOS {
-			t.capUsed.oos = true
+			t.capUsed.oos, t.capUsed.misplaced = true, true
 		}
 		capInfo.UsedPct, capInfo.OOS = t.capUsed.used, t.capUsed.oos
 		t.capUsed.Unlock()
+		if oos != capInfo.OOS {
+			go t.notifyOOS(capInfo.OOS)
+		}
 	} else {
 		t.capUsed.RLock()
 		capInfo.UsedPct, capInfo.OOS = t.capUsed.used, t.capUsed.oos
@@ -124,6 +134,107 @@ func (t *targetrunner) RunLRU(id string) {
 	xlru.Finish()
 }
 
+// returns true if the objects that belong to this target may have been PUT elsewhere
+// while it was out of space - until the next rebalance (see capOOS)
+func (t *targetrunner) mayHaveMisplaced(bck *cluster.Bck) bool {
+	if !bck.Props.LRU.RedirectOnOOS {
+		return false
+	}
+	t.capUsed.RLock()
+	misplaced := t.capUsed.misplaced
+	t.capUsed.RUnlock()
+	return misplaced
+}
+
+// the rebalance (that moves the misplaced-by-capacity objects back) has started
+func (t *targetrunner) rebalanceMisplaced() {
+	t.capUsed.Lock()
+	if !t.capUsed.oos {
+		t.capUsed.misplaced = false
+	}
+	t.capUsed.Unlock()
+}
+
+// deletes the object that has been PUT elsewhere while this target was out
+// of space (see capOOS); returns false if there's no such object
+func (t *targetrunner) deleteMisplaced(r *http.Request, lom *cluster.LOM) bool {
+	if !t.mayHaveMisplaced(lom.Bck()) {
+		return false
+	}
+	smap := t.owner.smap.get()
+	tsi := t.lookupRemoteAll(lom, smap)
+	if tsi == nil || tsi.ID() == t.si.ID() {
+		return false
+	}
+	res := t.call(callArgs{
+		si: tsi,
+		req: cmn.ReqArgs{
+			Method: http.MethodDelete,
+			Base:   tsi.URL(cmn.NetworkIntraControl),
+			Path:   cmn.URLPath(cmn.Version, cmn.Objects, lom.BckName(), lom.ObjName),
+			Query:  cmn.AddBckToQuery(nil, lom.Bck().Bck),
+		},
+		timeout: lom.Config().Timeout.CplaneOperation,
+	})
+	if res.err != nil {
+		glog.Errorf("%s: failed to delete misplaced %s at %s: %v", t.si, lom, tsi, res.err)
+		return false
+	}
+	t.journal(lom, cmn.JournalDelete, "", t.requester(r))
+	return true
+}
+
+// tells the proxies that this target has run out of (or recovered) space - see capOOS
+func (t *targetrunner) notifyOOS(oos bool) {
+	msg := notifMsg{Ty: notifOOS, Snode: t.si, Data: cmn.MustMarshal(oos)}
+	path := cmn.URLPath(cmn.Version, cmn.Notifs)
+	for res := range t.callProxies(http.MethodPost, path, cmn.MustMarshal(&msg)) {
+		if res.err != nil {
+			glog.Warningf("%s: failed to notify %s (oos: %t): %v", t.si, res.si, oos, res.err)
+		}
+	}
+}
+
+// spillOnOOS kicks off the eviction of clean (already stored in the Cloud)
+// objects of a Cloud-backed bucket with lru.spill_on_oos enabled - unless LRU
+// is already running, in which case the latter is going to free up the space;
+// returns false if the bucket cannot spill
+func (t *targetrunner) spillOnOOS(bck *cluster.Bck) bool {
+	if !bck.IsCloud() || !bck.Props.LRU.SpillOnOOS {
+		return false
+	}
+	xlru := xaction.Registry.RenewLRU(cmn.GenUUID())
+	if xlru == nil {
+		return true // joining the running one
+	}
+	go func() {
+		ini := lru.InitLRU{
+			T:          t,
+			Xaction:    xlru,
+			StatsT:     t.statsT,
+			GetFSStats: ios.GetFSStats,
+		}
+		if _, err := lru.SpillBucket(&ini, bck); err != nil {
+			glog.Errorf("%s: failed to spill %s, err: %v", t.si, bck, err)
+		}
+		getstorstatsrunner().UpdateCapacities(nil)
+		xlru.Finish()
+	}()
+	return true
+}
+
+// waitSpill waits (up to spillWaitTime) for spilling to bring the target back from OOS
+func (t *targetrunner) waitSpill(config *cmn.Config) (capInfo cmn.CapacityInfo) {
+	const sleep = 100 * time.Millisecond
+	for i := time.Duration(0); i < spillWaitTime; i += sleep {
+		time.Sleep(sleep)
+		if capInfo = t.AvgCapUsed(config); !capInfo.OOS {
+			return
+		}
+	}
+	return
+}
+
 // slight variation vs t.httpobjget()
 func (t *targetrunner) GetObject(w io.Writer, lom *cluster.LOM, started time.Time) error {
 	goi := &getObjInfo{
@@ -146,6 +257,7 @@ func (t *targetrunner) PutObject(params cluster.PutObjectParams) error {
 		workFQN: params.WorkFQN,
 		ctx:     context.Background(),
 		started: params.Started,
+		size:    params.Size,
 		skipEC:  params.SkipEncode,
 	}
 	if params.RecvType == cluster.Migrated {
@@ -185,6 +297,14 @@ func (t *targetrunner) CopyObject(lom *cluster.LOM, bckTo *cluster.Bck, buf []by
 
 // FIXME: recomputes checksum if called with a bad one (optimize)
 func (t *targetrunner) GetCold(ctx context.Context, lom *cluster.LOM, prefetch bool) (err error, errCode int) {
+	return t.getCold(lom, prefetch, func(workFQN string) (error, int) {
+		return t.Cloud(lom.Bck()).GetObj(ctx, workFQN, lom)
+	})
+}
+
+// getCold fetches the object (via `fetch` callback) into a workfile and then
+// renames the latter and persists the lom
+func (t *targetrunner) getCold(lom *cluster.LOM, prefetch bool, fetch func(workFQN string) (error, int)) (err error, errCode int) {
 	if prefetch {
 		if !lom.TryLock(true) {
 			glog.Infof("prefetch: cold GET race: %s - skipping", lom)
@@ -196,7 +316,7 @@ func (t *targetrunner) GetCold(ctx context.Context, lom *cluster.LOM, prefetch b
 	var (
 		workFQN = fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfileColdget)
 	)
-	if err, errCode = t.Cloud(lom.Bck()).GetObj(ctx, workFQN, lom); err != nil {
+	if err, errCode = fetch(workFQN); err != nil {
 		lom.Unlock(true)
 		err = fmt.Errorf("%s: GET failed %d, err: %v", lom, errCode, err)
 		return
@@ -254,7 +374,7 @@ func (t *targetrunner) PromoteFile(srcFQN string, bck *cluster.Bck, objName stri
 		}
 		buf, slab := t.gmm.Alloc()
 		lom.FQN = srcFQN
-		ri := &replicInfo{smap: smap, t: t, bckTo: lom.Bck(), buf: buf, localOnly: false}
+		ri := &replicInfo{smap: smap, t: t, bckTo: lom.Bck(), buf: buf, localOnly: false, plain: true}
 
 		// TODO -- FIXME: handle overwrite (lookup first)
 		_, err = ri.putRemote(lom, lom.ObjName, si)
@@ -275,6 +395,9 @@ func (t *targetrunner) PromoteFile(srcFQN string, bck *cluster.Bck, objName stri
 		}
 		glog.Infof("promote%s %s => %s", s, srcFQN, lom)
 	}
+	if lom.Bprops().Encryption.Enabled {
+		return t.promoteEncrypted(srcFQN, lom, computedCksum, safe)
+	}
 	var (
 		cksum   *cmn.CksumHash
 		fi      os.FileInfo
@@ -322,6 +445,10 @@ func (t *targetrunner) PromoteFile(srcFQN string, bck *cluster.Bck, objName stri
 	cmn.Assert(workFQN != "")
 	poi.workFQN = workFQN
 	lom.SetSize(written)
+	// the file is promoted as is - drop the encryption metadata of the overwritten object, if any
+	if _, err = poi.encryptionKey(); err != nil {
+		return
+	}
 	err, _ = poi.finalize()
 	if err == nil {
 		nlom = lom
@@ -329,6 +456,41 @@ func (t *targetrunner) PromoteFile(srcFQN string, bck *cluster.Bck, objName stri
 	return
 }
 
+// the (plaintext) file gets encrypted into a work file that then becomes the object
+func (t *targetrunner) promoteEncrypted(srcFQN string, lom *cluster.LOM, computedCksum *cmn.Cksum,
+	safe bool) (nlom *cluster.LOM, err error) {
+	var (
+		fh  *os.File
+		fi  os.FileInfo
+		poi = &putObjInfo{
+			t:            t,
+			lom:          lom,
+			cksumToCheck: computedCksum,
+			workFQN:      fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfilePut),
+		}
+	)
+	if fh, err = os.Open(srcFQN); err != nil {
+		return
+	}
+	if fi, err = fh.Stat(); err != nil {
+		fh.Close()
+		return
+	}
+	poi.r, poi.size = fh, fi.Size()
+	if err = poi.writeToFile(); err != nil {
+		return
+	}
+	if err, _ = poi.finalize(); err != nil {
+		return
+	}
+	if !safe {
+		if errRm := cmn.RemoveFile(srcFQN); errRm != nil {
+			glog.Errorf("%s: failed to remove promoted %s: %v", lom, srcFQN, errRm)
+		}
+	}
+	return lom, nil
+}
+
 //
 // implements health.fspathDispatcher interface
 //
@@ -0,0 +1,37 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+// NOTE: `t` is the target (see TestMain)
+func TestWaitSpill(test *testing.T) {
+	setOOS := func(oos bool) {
+		t.capUsed.Lock()
+		t.capUsed.oos = oos
+		t.capUsed.Unlock()
+	}
+	defer setOOS(false)
+	config := cmn.GCO.Get()
+
+	// spilling frees up the space: the PUT gets admitted
+	setOOS(true)
+	time.AfterFunc(3*spillWaitTime/4, func() { setOOS(false) })
+	capInfo := t.waitSpill(config)
+	tassert.Errorf(test, !capInfo.OOS, "expected the space to be freed up")
+
+	// not in time: the PUT fails (to be retried)
+	setOOS(true)
+	started := time.Now()
+	capInfo = t.waitSpill(config)
+	tassert.Errorf(test, capInfo.OOS && capInfo.Err != nil, "expected OOS")
+	tassert.Errorf(test, time.Since(started) >= spillWaitTime, "expected to wait %v", spillWaitTime)
+}
@@ -0,0 +1,220 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Operation journal
+//
+// When enabled for a bucket (see cmn.JournalConf), each target records the
+// PUTs, APPENDs, DELETEs, evictions, and renames (and, optionally, GETs) of
+// the bucket's objects along with the requesters' identities. The records are
+// stored in the target's DB, in a ring of `journal.max_entries` slots: the
+// record with sequence number `seq` occupies the slot `seq % max_entries`,
+// so that the newest records overwrite the oldest ones. Each bucket has its
+// own sequence (see jbucket) - recording is lock-free once it's been loaded.
+
+const journalCollection = "journal"
+
+type (
+	opJournal struct {
+		mtx     sync.RWMutex
+		buckets map[string]*jbucket // bucket uname => journal state
+	}
+	jbucket struct {
+		once sync.Once    // loads the sequence number upon first use (see lastSeq)
+		seq  atomic.Int64 // sequence number of the last record
+	}
+	// identity of the requester, as recorded in the journal; the auth token
+	// gets validated only if (and when) the journal records the operation
+	jrequester struct {
+		authn *authManager
+		token string // the value of the Authorization header
+		addr  string
+		once  sync.Once
+		user  string
+	}
+)
+
+func newOpJournal() *opJournal {
+	return &opJournal{buckets: make(map[string]*jbucket, 4)}
+}
+
+func journalPrefix(bck *cluster.Bck) string { return bck.MakeUname("") }
+func journalKey(bck *cluster.Bck, slot int64) string {
+	return bck.MakeUname(fmt.Sprintf("%010d", slot))
+}
+
+func (j *opJournal) bucket(uname string) *jbucket {
+	j.mtx.RLock()
+	jb, ok := j.buckets[uname]
+	j.mtx.RUnlock()
+	if ok {
+		return jb
+	}
+	j.mtx.Lock()
+	if jb, ok = j.buckets[uname]; !ok {
+		jb = &jbucket{}
+		j.buckets[uname] = jb
+	}
+	j.mtx.Unlock()
+	return jb
+}
+
+func (j *opJournal) add(db dbdriver.Driver, bck *cluster.Bck, max int64, entry *cmn.JournalEntry) {
+	jb := j.bucket(journalPrefix(bck))
+	jb.once.Do(func() { jb.seq.Store(j.lastSeq(db, bck)) })
+	entry.Seq = jb.seq.Inc()
+	if err := db.Set(journalCollection, journalKey(bck, entry.Seq%max), entry); err != nil {
+		glog.Errorf("%s: failed to record %s %s: %v", bck, entry.Op, entry.ObjName, err)
+	}
+}
+
+// recovers the sequence number of the last record (e.g., after restart)
+func (j *opJournal) lastSeq(db dbdriver.Driver, bck *cluster.Bck) (seq int64) {
+	entries, err := j.load(db, bck)
+	if err != nil {
+		glog.Errorf("%s: failed to load journal: %v", bck, err)
+	}
+	for _, entry := range entries {
+		if entry.Seq > seq {
+			seq = entry.Seq
+		}
+	}
+	return
+}
+
+func (j *opJournal) load(db dbdriver.Driver, bck *cluster.Bck) ([]*cmn.JournalEntry, error) {
+	values, err := db.GetAll(journalCollection, journalPrefix(bck))
+	if err != nil && !dbdriver.IsErrNotFound(err) {
+		return nil, err
+	}
+	entries := make([]*cmn.JournalEntry, 0, len(values))
+	for key, value := range values {
+		entry := &cmn.JournalEntry{}
+		if err := jsoniter.UnmarshalFromString(value, entry); err != nil {
+			glog.Errorf("%s: damaged journal record %q: %v", bck, key, err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// returns the records of the objects with a given name prefix, oldest first
+func (j *opJournal) query(db dbdriver.Driver, bck *cluster.Bck, max int64, prefix string) ([]*cmn.JournalEntry, error) {
+	entries, err := j.load(db, bck)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(entries, func(i, k int) bool { return entries[i].Seq < entries[k].Seq })
+	// skip the stale records that remain after `max_entries` has been decreased
+	if l := len(entries); l > 0 {
+		oldest := entries[l-1].Seq - max
+		idx := sort.Search(l, func(i int) bool { return entries[i].Seq > oldest })
+		entries = entries[idx:]
+	}
+	if prefix == "" {
+		return entries, nil
+	}
+	filtered := entries[:0]
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.ObjName, prefix) {
+			filtered = append(filtered, entry)
+		}
+	}
+	return filtered, nil
+}
+
+func (j *opJournal) clear(db dbdriver.Driver, bck *cluster.Bck) {
+	j.mtx.Lock()
+	delete(j.buckets, journalPrefix(bck))
+	j.mtx.Unlock()
+	keys, err := db.List(journalCollection, journalPrefix(bck))
+	if err != nil {
+		if !dbdriver.IsErrNotFound(err) {
+			glog.Errorf("%s: failed to clear journal: %v", bck, err)
+		}
+		return
+	}
+	for _, key := range keys {
+		if err := db.Delete(journalCollection, key); err != nil && !dbdriver.IsErrNotFound(err) {
+			glog.Errorf("%s: failed to clear journal: %v", bck, err)
+			return
+		}
+	}
+}
+
+func (t *targetrunner) requester(r *http.Request) *jrequester {
+	return &jrequester{authn: t.authn, token: r.Header.Get(cmn.HeaderAuthorization), addr: r.RemoteAddr}
+}
+
+// the user comes from the auth token (if any)
+func (req *jrequester) userID() string {
+	req.once.Do(func() {
+		idx := strings.Index(req.token, " ")
+		if idx == -1 || req.token[:idx] != cmn.HeaderBearer || req.authn == nil {
+			return
+		}
+		if auth, err := req.authn.validateToken(req.token[idx+1:]); err == nil {
+			req.user = auth.UserID
+		}
+	})
+	return req.user
+}
+
+// records the operation if the journal is enabled for the object's bucket;
+// counts and publishes the mutations regardless (see bckEvents and events.Bus)
+func (t *targetrunner) journal(lom *cluster.LOM, op, info string, req *jrequester) {
+	if op != cmn.JournalGet {
+		t.bckEvents.add(lom.Bck(), op)
+		t.events.Publish(&cmn.Event{
+			Type:    "object." + op,
+			Bck:     lom.Bck().Bck,
+			ObjName: lom.ObjName,
+			Size:    lom.Size(),
+			Info:    info,
+		})
+	}
+	conf := &lom.Bck().Props.Journal
+	if !conf.Enabled || (op == cmn.JournalGet && !conf.Gets) {
+		return
+	}
+	entry := &cmn.JournalEntry{
+		Time:    time.Now().UnixNano(),
+		Op:      op,
+		ObjName: lom.ObjName,
+		Size:    lom.Size(),
+		Version: lom.Version(),
+		Info:    info,
+		User:    req.userID(),
+		Addr:    req.addr,
+		Target:  t.si.ID(),
+	}
+	t.opJournal.add(t.dbDriver, lom.Bck(), conf.Max(), entry)
+}
+
+func (t *targetrunner) queryJournal(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, msg *aisMsg) {
+	entries, err := t.opJournal.query(t.dbDriver, bck, bck.Props.Journal.Max(), msg.Name)
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	t.writeJSON(w, r, entries, "query-journal")
+}
@@ -0,0 +1,121 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func newJournalDB(test *testing.T) (db dbdriver.Driver, cleanup func()) {
+	dir, err := ioutil.TempDir("", "journal")
+	tassert.CheckFatal(test, err)
+	db, err = dbdriver.NewLogDriver(filepath.Join(dir, ".ais.kvlog"))
+	tassert.CheckFatal(test, err)
+	return db, func() {
+		db.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestJournalConcurrentAdd(test *testing.T) {
+	const (
+		workers = 8
+		perWkr  = 50
+		max     = int64(workers * perWkr)
+	)
+	db, cleanup := newJournalDB(test)
+	defer cleanup()
+
+	var (
+		j    = newOpJournal()
+		wg   = &sync.WaitGroup{}
+		bcks = []*cluster.Bck{
+			cluster.NewBck("journal-a", cmn.ProviderAIS, cmn.NsGlobal),
+			cluster.NewBck("journal-b", cmn.ProviderAIS, cmn.NsGlobal),
+		}
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for k := 0; k < perWkr; k++ {
+				for _, bck := range bcks {
+					j.add(db, bck, max, &cmn.JournalEntry{Op: cmn.JournalPut, ObjName: "obj"})
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	// each bucket has its own gap-free sequence
+	for _, bck := range bcks {
+		entries, err := j.query(db, bck, max, "")
+		tassert.CheckFatal(test, err)
+		tassert.Fatalf(test, int64(len(entries)) == max, "%s: expected %d records, got %d", bck, max, len(entries))
+		for i, entry := range entries {
+			tassert.Fatalf(test, entry.Seq == int64(i+1), "%s: expected seq %d, got %d", bck, i+1, entry.Seq)
+		}
+	}
+
+	// continues upon restart
+	j = newOpJournal()
+	j.add(db, bcks[0], max, &cmn.JournalEntry{Op: cmn.JournalDelete, ObjName: "obj"})
+	entries, err := j.query(db, bcks[0], max, "")
+	tassert.CheckFatal(test, err)
+	last := entries[len(entries)-1]
+	tassert.Errorf(test, last.Seq == max+1 && last.Op == cmn.JournalDelete, "unexpected last record %+v", last)
+
+	// cleared
+	j.clear(db, bcks[1])
+	entries, err = j.query(db, bcks[1], max, "")
+	tassert.CheckFatal(test, err)
+	tassert.Errorf(test, len(entries) == 0, "expected no records, got %d", len(entries))
+}
+
+// NOTE: `t` is the target (see TestMain)
+func TestJournalRequester(test *testing.T) {
+	var (
+		expires = time.Now().Add(time.Hour)
+		authn   = &authManager{
+			tokens: authList{
+				"valid":   &cmn.AuthToken{UserID: "user", Expires: expires},
+				"expired": &cmn.AuthToken{UserID: "user", Expires: time.Now().Add(-time.Hour)},
+			},
+			revokedTokens: make(map[string]bool),
+		}
+		r = &http.Request{RemoteAddr: "10.0.0.1:1234", Header: http.Header{}}
+	)
+	r.Header.Set(cmn.HeaderAuthorization, cmn.HeaderBearer+" valid")
+	req := t.requester(r)
+	req.authn = authn
+	tassert.Errorf(test, req.userID() == "user" && req.addr == r.RemoteAddr, "unexpected %q, %q", req.userID(), req.addr)
+
+	r.Header.Set(cmn.HeaderAuthorization, "Basic valid")
+	req = t.requester(r)
+	req.authn = authn
+	tassert.Errorf(test, req.userID() == "", "expected no user, got %q", req.userID())
+
+	// not recorded - not validated (validating the expired token removes it from the cache)
+	r.Header.Set(cmn.HeaderAuthorization, cmn.HeaderBearer+" expired")
+	req = t.requester(r)
+	req.authn = authn
+	lom := &cluster.LOM{T: t, ObjName: "journal-obj"}
+	tassert.CheckFatal(test, lom.Init(cmn.Bck{Name: testBucket, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}))
+	tassert.Fatalf(test, !lom.Bck().Props.Journal.Enabled, "expected the journal to be disabled")
+	t.journal(lom, cmn.JournalGet, "", req)
+	_, ok := authn.tokens["expired"]
+	tassert.Errorf(test, ok, "the token must not be validated when the journal is disabled")
+}
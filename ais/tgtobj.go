@@ -11,6 +11,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"os"
@@ -23,6 +24,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/encrypt"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/reb"
@@ -54,12 +56,21 @@ type (
 		cold bool
 		// if true, poi won't erasure-encode an object when finalizing
 		skipEC bool
+		// per-object policy that overrides the bucket's EC configuration (nil - as per bucket)
+		ecPolicy *ec.Policy
+		// encrypt at rest as requested (x-amz-server-side-encryption) - see also cmn.EncryptionConf
+		sse bool
+		// the bucket's quota reserved for the object (see fs.QuotaManager)
+		quota *fs.QuotaReservation
 	}
 
 	getObjInfo struct {
 		started time.Time // started time of receiving - used to calculate the recv duration
 		t       *targetrunner
 		lom     *cluster.LOM
+		// Remote AIS object when `lom` is its target-local cached replica
+		// (see cmn.RemoteCacheConf)
+		remote *cluster.LOM
 		// Writer where the object will be written.
 		w io.Writer
 		// Context used when receiving the object which is contained in cloud
@@ -73,6 +84,15 @@ type (
 		isGFN bool
 		// true: chunked transfer (en)coding as per https://tools.ietf.org/html/rfc7230#page-36
 		chunked bool
+		// Original client request (nil when the GET is internal); when set,
+		// GET of an object that has moved during rebalance gets redirected
+		redirReq *http.Request
+		// The target that the request has been redirected to (see above)
+		redirected *cluster.Snode
+		// Good local replica to read instead of the corrupted object (see tryRecoverObject)
+		fqn string
+		// true: include all the object's properties in the response header (see cmn.URLParamObjProps)
+		withProps bool
 	}
 
 	// Contains information packed in append handle.
@@ -98,6 +118,24 @@ type (
 		cksum *cmn.Cksum // Expected checksum of the final object.
 	}
 
+	writeAtObjInfo struct {
+		started time.Time // started time of receiving - used to calculate the recv duration
+		t       *targetrunner
+		lom     *cluster.LOM
+
+		// Reader with the content of the segment.
+		r io.ReadCloser
+		// Segment size aka Content-Length.
+		size int64
+		// Offset of the segment within the object.
+		offset int64
+		// WriteAt/Commit operation.
+		op       string
+		filePath string // Workfile the segments are stitched in (from handle).
+
+		cksum *cmn.Cksum // Expected checksum of the final object.
+	}
+
 	writerOnly struct{ io.Writer }
 )
 
@@ -119,6 +157,11 @@ func (poi *putObjInfo) putObject() (err error, errCode int) {
 	}
 
 	if !daemon.dryRun.disk {
+		if err := poi.reserveQuota(poi.size); err != nil {
+			cmn.DrainReader(poi.r)
+			return err, http.StatusInsufficientStorage
+		}
+		defer poi.quota.Release() // no-op once accounted for (see tryFinalize)
 		if err := poi.writeToFile(); err != nil {
 			return err, http.StatusInternalServerError
 		}
@@ -151,25 +194,55 @@ func (poi *putObjInfo) finalize() (err error, errCode int) {
 			}
 		}
 		poi.lom.Uncache()
+		poi.quota.Release()
 		return
 	}
 	if !poi.skipEC {
-		if ecErr := ec.ECM.EncodeObject(poi.lom); ecErr != nil && ecErr != ec.ErrorECDisabled {
+		if ecErr := ec.ECM.EncodeObjectPolicy(poi.lom, poi.ecPolicy); ecErr != nil && ecErr != ec.ErrorECDisabled {
 			err = ecErr
 			return
 		}
 	}
 
 	poi.t.putMirror(poi.lom)
+	poi.t.objWaiters.notify(poi.lom.Uname())
+	return
+}
+
+// reserves the bucket's quota (if any) for the object that is about to be
+// stored, unless reserved already; the objects that are migrated or cold-GET
+// are accounted for but never refused
+func (poi *putObjInfo) reserveQuota(size int64) (err error) {
+	lom := poi.lom
+	if poi.quota != nil || poi.migrated || poi.cold || !lom.Bprops().Quota.Enabled() {
+		return
+	}
+	objs := int64(1)
+	if finfo, errStat := os.Stat(lom.FQN); errStat == nil {
+		size -= finfo.Size()
+		objs = 0
+	}
+	smap := poi.t.owner.smap.get()
+	poi.quota, err = fs.Quota.Reserve(lom.Bprops(), lom.Bck().Bck, cmn.Max(smap.CountTargets(), 1), size, objs)
 	return
 }
 
 // poi.workFQN => LOM
 func (poi *putObjInfo) tryFinalize() (err error, errCode int) {
 	var (
-		lom = poi.lom
-		bck = lom.Bck()
+		lom      = poi.lom
+		bck      = lom.Bck()
+		quota    = lom.Bprops().Quota.Enabled()
+		workSize int64
 	)
+	if quota {
+		if finfo, errStat := os.Stat(poi.workFQN); errStat == nil {
+			workSize = finfo.Size()
+		}
+		if err = poi.reserveQuota(workSize); err != nil {
+			return err, http.StatusInsufficientStorage
+		}
+	}
 	if bck.IsRemote() && !poi.migrated {
 		var version string
 		if bck.IsCloud() {
@@ -201,13 +274,27 @@ func (poi *putObjInfo) tryFinalize() (err error, errCode int) {
 	defer lom.Unlock(true)
 
 	if bck.IsAIS() && lom.VerConf().Enabled && !poi.migrated {
+		if err = lom.KeepVersion(); err != nil {
+			return
+		}
+		lom.PruneVersions(lom.VerConf().MaxVersions)
 		if err = lom.IncVersion(); err != nil {
 			return
 		}
 	}
+	var prevSize, objs int64 = 0, 1
+	if quota {
+		if finfo, errStat := os.Stat(lom.FQN); errStat == nil {
+			prevSize, objs = finfo.Size(), 0
+		}
+	}
 	if err := cmn.Rename(poi.workFQN, lom.FQN); err != nil {
 		return fmt.Errorf("rename failed => %s: %w", lom, err), 0
 	}
+	if quota {
+		fs.Quota.Add(bck.Bck, lom.ParsedFQN.MpathInfo.Path, workSize-prevSize, objs)
+		poi.quota.Release()
+	}
 	if lom.HasCopies() {
 		if err = lom.DelAllCopies(); err != nil {
 			return
@@ -269,6 +356,7 @@ func (poi *putObjInfo) writeToFile() (err error) {
 		buf     []byte
 		slab    *memsys.Slab
 		reader  = poi.r
+		sparse  *cmn.SparseWriter // to preserve all-zero regions as holes
 		writer  io.Writer
 		writers = make([]io.Writer, 0, 4)
 		cksums  = struct {
@@ -276,15 +364,23 @@ func (poi *putObjInfo) writeToFile() (err error) {
 			given *cmn.CksumHash // compute additionally
 			expct *cmn.Cksum     // and validate against `expct` if required/available
 		}{}
-		conf = poi.lom.CksumConf()
+		conf    = poi.lom.CksumConf()
+		trusted bool // the checksum that has arrived with the object is used as is
+		objKey  *encrypt.ObjKey
 	)
 	if daemon.dryRun.disk {
 		return
 	}
+	if !poi.migrated {
+		if objKey, err = poi.encryptionKey(); err != nil {
+			return
+		}
+	}
 	if file, err = poi.lom.CreateFile(poi.workFQN); err != nil {
 		return
 	}
-	writer = file
+	sparse = cmn.NewSparseWriter(file)
+	writer = sparse
 	if poi.size == 0 {
 		buf, slab = poi.t.gmm.Alloc()
 	} else {
@@ -319,7 +415,7 @@ func (poi *putObjInfo) writeToFile() (err error) {
 			writers = append(writers, cksums.given.H)
 		}
 	} else {
-		if !poi.migrated || conf.ValidateObjMove {
+		if !poi.migrated || (conf.ValidateObjMove && !conf.VerifiedOnce) {
 			cksums.store = cmn.NewCksumHash(conf.Type)
 			writers = append(writers, cksums.store.H)
 			if poi.cksumToCheck != nil && poi.cksumToCheck.Type() != cmn.ChecksumNone {
@@ -328,16 +424,27 @@ func (poi *putObjInfo) writeToFile() (err error) {
 				writers = append(writers, cksums.given.H)
 			}
 		} else {
-			// if migration validation is not configured we can just take
-			// the checksum that has arrived with the object (and compute it if not present)
+			// if migration validation is not configured (or the checksum has been
+			// verified once, at the source) we can just take the checksum that
+			// has arrived with the object (and compute it if not present)
 			poi.lom.SetCksum(poi.cksumToCheck)
 			if poi.cksumToCheck == nil || poi.cksumToCheck.Type() == cmn.ChecksumNone {
 				cksums.store = cmn.NewCksumHash(conf.Type)
 				writers = append(writers, cksums.store.H)
+			} else {
+				trusted = true
 			}
 		}
 	}
 write:
+	if objKey != nil {
+		// the stored checksum is the one of the encrypted content (as stored)
+		cipherWriters := []io.Writer{sparse}
+		if cksums.store != nil {
+			writers, cipherWriters = writers[1:], []io.Writer{cksums.store.H, sparse}
+		}
+		writer = objKey.Writer(cmn.NewWriterMulti(cipherWriters...))
+	}
 	if len(writers) == 0 {
 		written, err = io.CopyBuffer(writer, reader, buf)
 	} else {
@@ -347,6 +454,13 @@ write:
 	if err != nil {
 		return
 	}
+	if err = sparse.Finalize(); err != nil {
+		return
+	}
+	if trusted && poi.size > 0 && written != poi.size {
+		err = fmt.Errorf("%s: received %d bytes, expected %d", poi.lom, written, poi.size)
+		return
+	}
 	// validate
 	if cksums.given != nil {
 		cksums.given.Finalize()
@@ -373,6 +487,38 @@ write:
 	return nil
 }
 
+// at-rest encryption (see package encrypt): returns the data key of the new
+// object, or nil if the object is not to be encrypted; updates the object's
+// custom metadata either way (the entries that come with the request or remain
+// from the previous version are never trusted)
+func (poi *putObjInfo) encryptionKey() (*encrypt.ObjKey, error) {
+	lom := poi.lom
+	md := lom.CustomMD()
+	if encrypt.IsEncrypted(md) {
+		md = encrypt.StripMD(md)
+		lom.SetCustomMD(md)
+	}
+	if poi.cold || (!poi.sse && !lom.Bprops().Encryption.Enabled) {
+		return nil, nil
+	}
+	if lom.Bck().IsRemote() {
+		return nil, fmt.Errorf("%s: encryption is not supported for remote buckets", lom)
+	}
+	objKey, encMD, err := encrypt.NewObjKey(encrypt.KeyID(lom.Bck().Bck))
+	if err != nil {
+		return nil, fmt.Errorf("%s: failed to encrypt: %v", lom, err)
+	}
+	newMD := make(cmn.SimpleKVs, len(md)+len(encMD))
+	for k, v := range md {
+		newMD[k] = v
+	}
+	for k, v := range encMD {
+		newMD[k] = v
+	}
+	lom.SetCustomMD(newMD)
+	return objKey, nil
+}
+
 ////////////////
 // GET OBJECT //
 ////////////////
@@ -405,7 +551,7 @@ do:
 		}
 	}
 
-	if coldGet && goi.lom.Bck().IsAIS() {
+	if coldGet && goi.lom.Bck().IsAIS() && goi.remote == nil {
 		// try lookup and restore
 		goi.lom.Unlock(false)
 		doubleCheck, err, errCode = goi.tryRestoreObject()
@@ -420,12 +566,25 @@ do:
 				}
 			}
 		}
-		if err != nil {
+		if err != nil || goi.redirected != nil {
 			return
 		}
 		goi.lom.Lock(false)
 		goto get
 	}
+	// erasure coded remote bucket: restore from the slices or, if too many are
+	// missing, cold GET and re-encode (ec.ErrorNoMetafile - regular cold GET)
+	if coldGet && goi.lom.Bck().IsRemote() && goi.remote == nil && goi.lom.Bprops().EC.Enabled {
+		goi.lom.Unlock(false)
+		ecErr := ec.ECM.RestoreObject(goi.lom)
+		goi.lom.Lock(false)
+		if ecErr != nil && ecErr != ec.ErrorNoMetafile {
+			glog.Errorf("%s: failed to EC-recover %s: %v", goi.t.si, goi.lom, ecErr)
+		}
+		if goi.lom.Load() == nil {
+			goto get
+		}
+	}
 	// exists && remote|cloud: check ver if requested
 	if !coldGet && goi.lom.Bck().IsRemote() {
 		if goi.lom.Version() != "" && goi.lom.VerConf().ValidateWarmGet {
@@ -437,6 +596,14 @@ do:
 			goi.lom.Lock(false)
 		}
 	}
+	// exists && cached replica of a remote AIS object: check the remote ETag
+	if !coldGet && goi.remote != nil {
+		goi.lom.Unlock(false)
+		if coldGet, err, errCode = goi.t.checkRemoteCache(goi.ctx, goi.remote, goi.lom); err != nil {
+			return
+		}
+		goi.lom.Lock(false)
+	}
 
 	// checksum validation, if requested
 	if !coldGet && goi.lom.CksumConf().ValidateWarmGet {
@@ -463,10 +630,18 @@ do:
 			return capInfo.Err, http.StatusBadRequest
 		}
 		goi.lom.SetAtimeUnix(goi.started.UnixNano())
-		if err, errCode := goi.t.GetCold(goi.ctx, goi.lom, false /*prefetch*/); err != nil {
+		goi.lom.RecordAccess(goi.started.UnixNano())
+		if goi.remote != nil {
+			err, errCode = goi.t.getColdCached(goi.ctx, goi.remote, goi.lom)
+		} else {
+			err, errCode = goi.t.GetCold(goi.ctx, goi.lom, false /*prefetch*/)
+		}
+		if err != nil {
 			return err, errCode
 		}
 		goi.t.putMirror(goi.lom)
+	} else if goi.remote != nil {
+		goi.t.statsT.Add(stats.RemoteCacheHitCount, 1)
 	}
 
 	// 4. get locally and stream back
@@ -475,6 +650,7 @@ get:
 	if retry && !retried {
 		glog.Warningf("GET %s: uncaching and retrying...", goi.lom)
 		retried = true
+		goi.fqn = ""
 		goi.lom.Uncache()
 		goto do
 	}
@@ -483,7 +659,8 @@ get:
 	return
 }
 
-// validate checksum; if corrupted try to recover from other replicas or EC slices
+// validate checksum; if corrupted, serve the object from a good local replica
+// (and repair the corrupted one in the background) or restore it from EC slices
 func (goi *getObjInfo) tryRecoverObject() (err error, code int, coldGet bool) {
 	var (
 		lom     = goi.lom
@@ -501,7 +678,13 @@ retry:
 	if _, ok := err.(*cmn.BadCksumError); !ok {
 		return
 	}
-	if !lom.Bck().IsAIS() {
+	if !retried {
+		goi.t.statsT.AddMany(
+			stats.NamedVal64{Name: stats.ErrCksumCount, Value: 1},
+			stats.NamedVal64{Name: stats.ErrCksumSize, Value: lom.Size()},
+		)
+	}
+	if !lom.Bck().IsAIS() || goi.remote != nil {
 		coldGet = true
 		return
 	}
@@ -523,18 +706,13 @@ retry:
 	//
 	// try to recover from BAD CHECKSUM
 	//
-	cmn.RemoveFile(lom.FQN) // TODO: ditto
-
 	if lom.HasCopies() {
-		retried = true
-		goi.lom.Unlock(false)
-		// lookup and restore the object from local replicas
-		restored := lom.RestoreObjectFromAny()
-		goi.lom.Lock(false)
-		if restored {
-			glog.Warningf("%s: recovered corrupted %s from local replica", goi.t.si, lom)
-			code = 0
-			goto retry
+		if copyFQN := goodCopy(lom); copyFQN != "" {
+			glog.Warningf("%s: serving corrupted %s from local replica %q, repairing", goi.t.si, lom, copyFQN)
+			goi.fqn = copyFQN
+			goi.t.statsT.Add(stats.GetCksumRepairCount, 1)
+			goi.t.repairAsync(lom, copyFQN)
+			return nil, 0, false
 		}
 	}
 	if lom.Bprops().EC.Enabled {
@@ -545,6 +723,7 @@ retry:
 		goi.lom.Lock(false)
 		if err == nil {
 			glog.Warningf("%s: recovered corrupted %s from EC slices", goi.t.si, lom)
+			goi.t.statsT.Add(stats.GetCksumRepairCount, 1)
 			code = 0
 			goto retry
 		}
@@ -557,11 +736,127 @@ retry:
 	return
 }
 
+// returns the FQN of the local replica with the content that matches the object's checksum
+func goodCopy(lom *cluster.LOM) string {
+	cksum := lom.Cksum()
+	if cksum == nil || cksum.Type() == cmn.ChecksumNone {
+		return ""
+	}
+	for copyFQN := range lom.GetCopies() {
+		if copyFQN == lom.FQN {
+			continue
+		}
+		computed, err := lom.Clone(copyFQN).ComputeCksum(cksum.Type())
+		if err == nil && computed.Equal(cksum) {
+			return copyFQN
+		}
+	}
+	return ""
+}
+
+// repairs the corrupted object in the background unless its repair is already in progress
+func (t *targetrunner) repairAsync(lom *cluster.LOM, copyFQN string) bool {
+	var (
+		uname   = lom.Uname()
+		bck     = lom.Bck().Bck
+		objName = lom.ObjName
+		cksum   = lom.Cksum().Clone()
+	)
+	if _, loaded := t.repairing.LoadOrStore(uname, copyFQN); loaded {
+		return false
+	}
+	go func() {
+		t.repairObject(bck, objName, copyFQN, cksum)
+		t.repairing.Delete(uname)
+	}()
+	return true
+}
+
+// replaces the corrupted object with its good local replica (see tryRecoverObject)
+// or, if the replica is no longer usable, restores it from EC slices - provided the object is still the one (with the given checksum) that was found corrupted
+func (t *targetrunner) repairObject(bck cmn.Bck, objName, copyFQN string, cksum *cmn.Cksum) {
+	lom := &cluster.LOM{T: t, ObjName: objName}
+	if err := lom.Init(bck); err != nil {
+		glog.Errorf("%s: failed to repair %s/%s: %v", t.si, bck, objName, err)
+		return
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	lom.Uncache()
+	if err := lom.Load(false); err != nil {
+		glog.Errorf("%s: failed to repair %s: %v", t.si, lom, err)
+		return
+	}
+	if !lom.Cksum().Equal(cksum) {
+		glog.Infof("%s: %s has been overwritten - nothing to repair", t.si, lom)
+		return
+	}
+	err := lom.ValidateContentChecksum()
+	if err == nil {
+		glog.Infof("%s: %s is no longer corrupted - nothing to repair", t.si, lom)
+		return
+	}
+	if _, ok := err.(*cmn.BadCksumError); !ok {
+		glog.Errorf("%s: failed to repair %s: %v", t.si, lom, err)
+		return
+	}
+	if err = t.copyReplica(lom, copyFQN); err == nil {
+		lom.Uncache()
+		glog.Infof("%s: repaired %s from local replica %q", t.si, lom, copyFQN)
+		return
+	}
+	if !lom.Bprops().EC.Enabled {
+		glog.Errorf("%s: failed to repair %s: %v", t.si, lom, err)
+		return
+	}
+	// the replica is gone (or unreadable) - rebuild from EC slices, yielding to client GETs
+	glog.Warningf("%s: failed to repair %s from local replica %q: %v - restoring from EC slices",
+		t.si, lom, copyFQN, err)
+	cmn.RemoveFile(lom.FQN)
+	lom.Uncache()
+	if err = ec.ECM.RestoreObjectBg(lom); err != nil {
+		glog.Errorf("%s: failed to restore %s from EC slices: %v", t.si, lom, err)
+		return
+	}
+	glog.Infof("%s: repaired %s from EC slices", t.si, lom)
+}
+
+// overwrites the object with its local replica
+func (t *targetrunner) copyReplica(lom *cluster.LOM, copyFQN string) (err error) {
+	src := &cluster.LOM{T: t, FQN: copyFQN}
+	if err = src.Init(lom.Bck().Bck); err != nil {
+		return
+	}
+	if err = src.Load(false); err != nil {
+		return
+	}
+	buf, slab := t.gmm.Alloc()
+	_, err = src.CopyObject(lom.FQN, buf)
+	slab.Free(buf)
+	return
+}
+
 // an attempt to restore an object that is missing in the ais bucket - from:
 // 1) local FS
 // 2) other FSes or targets when resilvering (rebalancing) is running (aka GFN)
 // 3) other targets if the bucket erasure coded
 // 4) Cloud
+// When rebalancing, the client's GET of an object that has already moved
+// gets redirected to the object's new owner; the new owner, in turn, waits
+// (for up to rebGetWaitTime) for the object that is still in transit - but
+// only if some other target has been seen holding it (see transitWait).
+// how long the new owner waits for the object in transit: the sender keeps the
+// object until the new owner acknowledges the receipt, and so the object that
+// no other target has (holder == nil) either does not exist or has already
+// arrived - no need to wait, just recheck (a GET of a non-existent object must
+// not stall for the duration of the rebalance)
+func transitWait(holder *cluster.Snode) time.Duration {
+	if holder == nil {
+		return 0
+	}
+	return rebGetWaitTime
+}
+
 func (goi *getObjInfo) tryRestoreObject() (doubleCheck bool, err error, errCode int) {
 	var (
 		tsi, gfnNode     *cluster.Snode
@@ -570,6 +865,7 @@ func (goi *getObjInfo) tryRestoreObject() (doubleCheck bool, err error, errCode
 		aborted, running = reb.IsRebalancing(cmn.ActResilver)
 		gfnActive        = goi.t.gfn.local.active()
 		ecEnabled        = goi.lom.Bprops().EC.Enabled
+		misplaced        = goi.t.mayHaveMisplaced(goi.lom.Bck())
 	)
 	tsi, err = cluster.HrwTarget(goi.lom.Uname(), &smap.Smap)
 	if err != nil {
@@ -599,11 +895,17 @@ func (goi *getObjInfo) tryRestoreObject() (doubleCheck bool, err error, errCode
 	gfnActive = goi.t.gfn.global.active()
 	if running && tsi.ID() != goi.t.si.ID() {
 		if goi.t.LookupRemoteSingle(goi.lom, tsi) {
+			// the object has already moved to its new owner - redirect
+			// the client there rather than copying it back
+			if goi.redirect(tsi) {
+				return
+			}
 			gfnNode = tsi
 			goto gfn
 		}
 	}
-	if running || !enoughECRestoreTargets || ((aborted || gfnActive) && !ecEnabled) {
+	// (misplaced: the object may have been PUT elsewhere when this target was out of space - see capOOS)
+	if running || !enoughECRestoreTargets || ((aborted || gfnActive || misplaced) && !ecEnabled) {
 		gfnNode = goi.t.lookupRemoteAll(goi.lom, smap)
 	}
 
@@ -617,6 +919,17 @@ gfn:
 		}
 	}
 
+	// this target is the new owner and the object may be still in transit -
+	// wait for the rebalance to deliver it
+	if running && tsi.ID() == goi.t.si.ID() {
+		if goi.t.waitObj(goi.reqCtx(), goi.lom, transitWait(gfnNode)) {
+			if glog.FastV(4, glog.SmoduleAIS) {
+				glog.Infof("%s: %s arrived (rebalance)", tname, goi.lom)
+			}
+			return
+		}
+	}
+
 	// restore from existing EC slices if possible
 	if ecErr := ec.ECM.RestoreObject(goi.lom); ecErr == nil {
 		if glog.FastV(4, glog.SmoduleAIS) {
@@ -637,6 +950,35 @@ gfn:
 	return
 }
 
+// redirects the client's GET to the target that now stores the object
+func (goi *getObjInfo) redirect(tsi *cluster.Snode) bool {
+	r := goi.redirReq
+	if r == nil || goi.isGFN || goi.ranges.Range != "" {
+		return false
+	}
+	w, ok := goi.w.(http.ResponseWriter)
+	if !ok {
+		return false
+	}
+	redirectURL := tsi.URL(cmn.NetworkPublic) + r.URL.Path
+	if r.URL.RawQuery != "" {
+		redirectURL += "?" + r.URL.RawQuery
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: %s moved to %s (rebalance) - redirecting", goi.t.si, goi.lom, tsi)
+	}
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+	goi.redirected = tsi
+	return true
+}
+
+func (goi *getObjInfo) reqCtx() context.Context {
+	if goi.redirReq != nil {
+		return goi.redirReq.Context()
+	}
+	return goi.ctx
+}
+
 func (goi *getObjInfo) getFromNeighbor(lom *cluster.LOM, tsi *cluster.Snode) (ok bool) {
 	query := url.Values{}
 	query.Add(cmn.URLParamIsGFNRequest, "true")
@@ -727,7 +1069,9 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, err error, errCode in
 	}
 
 	fqn := goi.lom.FQN
-	if !coldGet && !goi.isGFN {
+	if goi.fqn != "" {
+		fqn = goi.fqn
+	} else if !coldGet && !goi.isGFN {
 		// best-effort GET load balancing (see also mirror.findLeastUtilized())
 		// (TODO: check whether the timestamp is not too old)
 		fqn = goi.lom.LoadBalanceGET(goi.started)
@@ -746,9 +1090,16 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, err error, errCode in
 	}
 
 	var (
-		r    *cmn.HTTPRange
-		size = goi.lom.Size()
+		r      *cmn.HTTPRange
+		objKey *encrypt.ObjKey
+		size   = goi.lom.Size()
 	)
+	// GFN gets the object as stored - encrypted, if it is
+	if !goi.isGFN {
+		if objKey, err = encrypt.LoadObjKey(goi.lom.CustomMD()); err != nil {
+			return false, fmt.Errorf("%s: %w", goi.lom, err), http.StatusInternalServerError
+		}
+	}
 	if goi.ranges.Size > 0 {
 		size = goi.ranges.Size
 	}
@@ -776,10 +1127,11 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, err error, errCode in
 	}
 
 	cksumConf := goi.lom.CksumConf()
-	cksumRange := cksumConf.Type != cmn.ChecksumNone && r != nil && cksumConf.EnableReadRange
+	cksumRange := cksumConf.Type != cmn.ChecksumNone && r != nil && cksumConf.EnableReadRange && objKey == nil
 
 	if hdr != nil {
-		if goi.lom.Cksum() != nil && !cksumRange {
+		// (the checksum of an encrypted object is the one of its encrypted content)
+		if goi.lom.Cksum() != nil && !cksumRange && objKey == nil {
 			cksumType, cksumValue := goi.lom.Cksum().Get()
 			if cksumType != cmn.ChecksumNone {
 				hdr.Set(cmn.HeaderObjCksumType, cksumType)
@@ -791,6 +1143,15 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, err error, errCode in
 		}
 		hdr.Set(cmn.HeaderObjSize, strconv.FormatInt(goi.lom.Size(), 10))
 		hdr.Set(cmn.HeaderObjAtime, cmn.UnixNano2S(goi.lom.AtimeUnix()))
+		goi.lom.Bprops().Headers.Set(hdr, goi.lom.ObjName)
+		if goi.isGFN || goi.withProps {
+			for k, v := range goi.lom.CustomMD() {
+				hdr.Add(cmn.HeaderObjCustomMD, k+"="+v)
+			}
+		}
+		if goi.withProps {
+			objPropsToHdr(hdr, goi.lom, true /*exists*/)
+		}
 		if r != nil {
 			hdr.Set(cmn.HeaderContentLength, strconv.FormatInt(r.Length, 10))
 		} else {
@@ -800,7 +1161,15 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, err error, errCode in
 
 	w := goi.w
 	if goi.tag == "" {
-		if r == nil {
+		if objKey != nil {
+			start, length := int64(0), goi.lom.Size()
+			if r != nil {
+				start, length = r.Start, r.Length
+			}
+			buf, slab = goi.t.gmm.Alloc(length)
+			w = writerOnly{goi.w} // (decrypting - no sendfile)
+			reader = io.NewSectionReader(objKey.ReaderAt(file), start, length)
+		} else if r == nil {
 			reader = file
 			if goi.chunked {
 				w = writerOnly{goi.w} // hide ReadFrom; CopyBuffer will use the buffer instead
@@ -821,6 +1190,8 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, err error, errCode in
 			}
 		}
 		written, err = io.CopyBuffer(w, reader, buf)
+	} else if objKey != nil {
+		return false, fmt.Errorf("%s: cannot transform encrypted object", goi.lom), http.StatusBadRequest
 	} else {
 		written, err = transformTarToTFRecord(goi, r)
 	}
@@ -839,6 +1210,7 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry bool, err error, errCode in
 	// GFN: atime must be already set
 	if !coldGet && !goi.isGFN {
 		goi.lom.SetAtimeUnix(goi.started.UnixNano())
+		goi.lom.RecordAccess(goi.started.UnixNano())
 		goi.lom.ReCache() // GFN and cold GETs already did this
 	}
 
@@ -974,3 +1346,154 @@ func combineAppendHandle(nodeID, filePath string, partialCksum *cmn.CksumHash) s
 	cksumBinary := base64.StdEncoding.EncodeToString(buf)
 	return nodeID + "|" + filePath + "|" + cksumTy + "|" + cksumBinary
 }
+
+/////////////////////
+// WRITE-AT OBJECT //
+/////////////////////
+
+// Segments may be written concurrently and in any order: each request opens
+// the workfile on its own and writes at its own offset. Unlike APPEND, the
+// checksum of the resulting object can only be computed at commit time.
+func (woi *writeAtObjInfo) writeAtObject() (newHandle string, err error, errCode int) {
+	filePath := woi.filePath
+	if filePath != "" {
+		if err = woi.checkWorkfile(); err != nil {
+			return "", err, http.StatusBadRequest
+		}
+	}
+	switch woi.op {
+	case cmn.WriteAtOp:
+		var f *os.File
+		if woi.offset < 0 {
+			err = fmt.Errorf("invalid offset %d", woi.offset)
+			errCode = http.StatusBadRequest
+			return
+		}
+		if filePath == "" {
+			filePath = fs.CSM.GenContentParsedFQN(woi.lom.ParsedFQN, fs.WorkfileType, fs.WorkfileWriteAt)
+			f, err = woi.lom.CreateFile(filePath)
+		} else {
+			f, err = os.OpenFile(filePath, os.O_WRONLY, 0644)
+		}
+		if err != nil {
+			errCode = http.StatusInternalServerError
+			return
+		}
+		if _, err = f.Seek(woi.offset, io.SeekStart); err != nil {
+			debug.AssertNoErr(f.Close())
+			errCode = http.StatusInternalServerError
+			return
+		}
+
+		var (
+			buf  []byte
+			slab *memsys.Slab
+		)
+		if woi.size == 0 {
+			buf, slab = woi.t.gmm.Alloc()
+		} else {
+			buf, slab = woi.t.gmm.Alloc(woi.size)
+		}
+		_, err = io.CopyBuffer(writerOnly{f}, woi.r, buf)
+
+		slab.Free(buf)
+		debug.AssertNoErr(f.Close())
+		if err != nil {
+			errCode = http.StatusInternalServerError
+			return
+		}
+
+		newHandle = combineWriteAtHandle(woi.t.si.ID(), filePath)
+	case cmn.CommitOp:
+		if filePath == "" {
+			err = errors.New("handle not provided")
+			errCode = http.StatusBadRequest
+			return
+		}
+		var (
+			cksum     *cmn.Cksum
+			cksumType = woi.lom.CksumConf().Type
+		)
+		if woi.cksum != nil {
+			cksumType = woi.cksum.Type()
+		}
+		if cksum, err = woi.computeCksum(cksumType); err != nil {
+			errCode = http.StatusInternalServerError
+			return
+		}
+		if woi.cksum != nil && !cksum.Equal(woi.cksum) {
+			err = cmn.NewBadDataCksumError(cksum, woi.cksum)
+			errCode = http.StatusInternalServerError
+			return
+		}
+		if cksumType != woi.lom.CksumConf().Type {
+			cksum = nil // different type - let promote compute the one configured for the bucket
+		}
+		if _, err := woi.t.PromoteFile(filePath, woi.lom.Bck(), woi.lom.ObjName, cksum,
+			true /*overwrite*/, false /*safe*/, false /*verbose*/); err != nil {
+			return "", err, 0
+		}
+	default:
+		cmn.AssertMsg(false, woi.op)
+	}
+
+	delta := time.Since(woi.started)
+	woi.t.statsT.AddMany(
+		stats.NamedVal64{Name: stats.WriteAtCount, Value: 1},
+		stats.NamedVal64{Name: stats.WriteAtLatency, Value: int64(delta)},
+	)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("PUT %s (%s): %d µs", woi.lom, woi.op, int64(delta/time.Microsecond))
+	}
+	return
+}
+
+// the handle must refer to a workfile of the object's bucket
+func (woi *writeAtObjInfo) checkWorkfile() error {
+	parsed, err := fs.Mountpaths.ParseFQN(woi.filePath)
+	if err != nil {
+		return err
+	}
+	if parsed.ContentType != fs.WorkfileType || !parsed.Bck.Equal(woi.lom.Bck().Bck) {
+		return fmt.Errorf("invalid handle: %q does not belong to %s", woi.filePath, woi.lom)
+	}
+	return nil
+}
+
+func (woi *writeAtObjInfo) computeCksum(cksumType string) (cksum *cmn.Cksum, err error) {
+	var (
+		file      *os.File
+		cksumHash *cmn.CksumHash
+	)
+	if cksumType == cmn.ChecksumNone {
+		return
+	}
+	if file, err = os.Open(woi.filePath); err != nil {
+		return
+	}
+	buf, slab := woi.t.gmm.Alloc()
+	_, cksumHash, err = cmn.CopyAndChecksum(ioutil.Discard, file, buf, cksumType)
+	debug.AssertNoErr(file.Close())
+	slab.Free(buf)
+	if err != nil {
+		return
+	}
+	return cksumHash.Clone(), nil
+}
+
+func parseWriteAtHandle(handle string) (hi handleInfo, err error) {
+	if handle == "" {
+		return
+	}
+	p := strings.SplitN(handle, "|", 2)
+	if len(p) != 2 {
+		return hi, fmt.Errorf("invalid handle provided: %q", handle)
+	}
+	hi.nodeID = p[0]
+	hi.filePath = p[1]
+	return
+}
+
+func combineWriteAtHandle(nodeID, filePath string) string {
+	return nodeID + "|" + filePath
+}
@@ -5,12 +5,15 @@
 package ais
 
 import (
+	"bytes"
 	"flag"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
 	"path"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
@@ -20,6 +23,7 @@ import (
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/tutils/readers"
+	"github.com/NVIDIA/aistore/tutils/tassert"
 )
 
 const (
@@ -260,3 +264,181 @@ func BenchmarkObjGetDiscard(b *testing.B) {
 		})
 	}
 }
+
+// NOTE: `t` is the target (see TestMain)
+func TestRepairObject(test *testing.T) {
+	bck := cluster.NewBck("repair", cmn.ProviderAIS, cmn.NsGlobal)
+	bmd := t.owner.bmd.get().clone()
+	bmd.add(bck, &cmn.BucketProps{Cksum: cmn.CksumConf{Type: cmn.ChecksumXXHash}})
+	t.owner.bmd.put(bmd)
+	fs.Mountpaths.CreateBuckets("test", bck.Bck)
+	defer fs.Mountpaths.DestroyBuckets("test", bck.Bck)
+
+	lom := &cluster.LOM{T: t, ObjName: "obj"}
+	tassert.CheckFatal(test, lom.Init(bck.Bck))
+	tassert.CheckFatal(test, cmn.CreateDir(filepath.Dir(lom.FQN)))
+	tassert.CheckFatal(test, ioutil.WriteFile(lom.FQN, []byte("good content"), 0644))
+	cksum, err := lom.ComputeCksum(cmn.ChecksumXXHash)
+	tassert.CheckFatal(test, err)
+	lom.SetSize(int64(len("good content")))
+	lom.SetCksum(cksum.Clone())
+	tassert.CheckFatal(test, lom.Persist())
+	defer os.Remove(lom.FQN)
+
+	// at most one repair per object at a time
+	t.repairing.Store(lom.Uname(), "")
+	tassert.Errorf(test, !t.repairAsync(lom, "copy"), "expected repair to be in progress")
+	t.repairing.Delete(lom.Uname())
+
+	// no longer corrupted: nothing to do (and no replica to read)
+	t.repairObject(bck.Bck, lom.ObjName, "nonexistent", cksum.Clone())
+
+	// corrupted but then overwritten: nothing to do
+	tassert.CheckFatal(test, ioutil.WriteFile(lom.FQN, []byte("bad  content"), 0644))
+	t.repairObject(bck.Bck, lom.ObjName, "nonexistent", cmn.NewCksum(cmn.ChecksumXXHash, "0123456789abcdef"))
+	b, err := ioutil.ReadFile(lom.FQN)
+	tassert.CheckFatal(test, err)
+	tassert.Errorf(test, string(b) == "bad  content", "overwritten object must not be repaired")
+}
+
+// NOTE: `t` is the target (see TestMain)
+func TestPutQuota(test *testing.T) {
+	var (
+		bck   = cluster.NewBck("quota", cmn.ProviderAIS, cmn.NsGlobal)
+		props = &cmn.BucketProps{Cksum: cmn.CksumConf{Type: cmn.ChecksumNone}, Quota: cmn.QuotaConf{MaxObjects: 1}}
+		bmd   = t.owner.bmd.get().clone()
+	)
+	bmd.add(bck, props)
+	t.owner.bmd.put(bmd)
+	fs.Mountpaths.CreateBuckets("test", bck.Bck)
+	defer fs.Mountpaths.DestroyBuckets("test", bck.Bck)
+	defer fs.Quota.Forget(bck.Bck)
+
+	// wait for the usage to get computed (the quota is not enforced until then)
+	for i := 0; ; i++ {
+		r, err := fs.Quota.Reserve(props, bck.Bck, 1, 0, 1000)
+		r.Release()
+		if err != nil {
+			break
+		}
+		if i > 1000 {
+			test.Fatal("bucket usage is not computed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	put := func(objName string, migrated bool) (error, int) {
+		lom := &cluster.LOM{T: t, ObjName: objName}
+		tassert.CheckFatal(test, lom.Init(bck.Bck))
+		poi := &putObjInfo{
+			started:  time.Now(),
+			t:        t,
+			lom:      lom,
+			r:        ioutil.NopCloser(bytes.NewReader([]byte(objName))),
+			size:     int64(len(objName)),
+			workFQN:  fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfilePut),
+			migrated: migrated,
+		}
+		return poi.putObject()
+	}
+	err, _ := put("obj-1", false)
+	tassert.CheckFatal(test, err)
+	err, errCode := put("obj-2", false)
+	tassert.Fatalf(test, err != nil && errCode == http.StatusInsufficientStorage,
+		"expected quota exceeded, got %v(%d)", err, errCode)
+
+	// overwriting is not a new object
+	err, _ = put("obj-1", false)
+	tassert.CheckFatal(test, err)
+
+	// migrated objects are accounted for but never refused
+	err, _ = put("obj-2", true)
+	tassert.CheckFatal(test, err)
+	_, objs, _ := fs.Quota.Usage(bck.Bck)
+	tassert.Errorf(test, objs == 2, "expected 2 objects, got %d", objs)
+}
+
+// records the stats (see stats.Tracker)
+type statsRecorder struct {
+	stats.TrackerMock
+	mtx  sync.Mutex
+	vals map[string]int64
+}
+
+func (r *statsRecorder) Add(name string, val int64) {
+	r.mtx.Lock()
+	r.vals[name] += val
+	r.mtx.Unlock()
+}
+
+func (r *statsRecorder) AddMany(nvs ...stats.NamedVal64) {
+	for _, nv := range nvs {
+		r.Add(nv.Name, nv.Value)
+	}
+}
+
+func (r *statsRecorder) Get(name string) int64 {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	return r.vals[name]
+}
+
+// NOTE: `t` is the target (see TestMain)
+func TestWriteAtObject(test *testing.T) {
+	var (
+		recorder = &statsRecorder{vals: make(map[string]int64)}
+		statsT   = t.statsT
+		smap     = newSmap()
+		bck      = cmn.Bck{Name: testBucket, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+	)
+	smap.addTarget(t.si)
+	t.owner.smap.put(smap)
+	t.statsT = recorder
+	defer func() { t.statsT = statsT }()
+
+	lom := &cluster.LOM{T: t, ObjName: "write-at-obj"}
+	tassert.CheckFatal(test, lom.Init(bck))
+	defer os.Remove(lom.FQN)
+
+	write := func(op, filePath string, offset int64, data string) (string, error, int) {
+		woi := &writeAtObjInfo{
+			started:  time.Now(),
+			t:        t,
+			lom:      lom,
+			r:        ioutil.NopCloser(bytes.NewReader([]byte(data))),
+			size:     int64(len(data)),
+			offset:   offset,
+			op:       op,
+			filePath: filePath,
+		}
+		return woi.writeAtObject()
+	}
+
+	// segments - in any order
+	handle, err, _ := write(cmn.WriteAtOp, "", 6, "world")
+	tassert.CheckFatal(test, err)
+	hi, err := parseWriteAtHandle(handle)
+	tassert.CheckFatal(test, err)
+	_, err, _ = write(cmn.WriteAtOp, hi.filePath, 0, "hello ")
+	tassert.CheckFatal(test, err)
+
+	// not accessible until committed
+	tassert.Errorf(test, lom.Load(false) != nil, "expected %s not to exist before commit", lom)
+	_, err, _ = write(cmn.CommitOp, hi.filePath, 0, "")
+	tassert.CheckFatal(test, err)
+	b, err := ioutil.ReadFile(lom.FQN)
+	tassert.CheckFatal(test, err)
+	tassert.Errorf(test, string(b) == "hello world", "expected %q, got %q", "hello world", string(b))
+
+	// the handle must refer to a workfile of the same bucket
+	_, err, errCode := write(cmn.WriteAtOp, lom.FQN, 0, "overwrite")
+	tassert.Errorf(test, err != nil && errCode == http.StatusBadRequest, "expected invalid handle, got %v(%d)",
+		err, errCode)
+
+	// dedicated counters - not APPEND's
+	tassert.Errorf(test, recorder.Get(stats.WriteAtCount) == 3, "expected 3 write-at ops, got %d",
+		recorder.Get(stats.WriteAtCount))
+	tassert.Errorf(test, recorder.Get(stats.WriteAtLatency) > 0, "expected write-at latency")
+	tassert.Errorf(test, recorder.Get(stats.AppendCount) == 0 && recorder.Get(stats.AppendLatency) == 0,
+		"expected no appends, got %d", recorder.Get(stats.AppendCount))
+}
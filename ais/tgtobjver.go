@@ -0,0 +1,135 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/encrypt"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Time-travel GET
+//
+// GET of a given version of the object in a cloud bucket with versioning
+// enabled on the cloud side - either explicitly (cmn.URLParamObjVersion) or the
+// one that was current at a given time (cmn.URLParamAsOf). The version gets
+// fetched once and is then kept (and served) locally - separately from the
+// object itself, with its own metadata (see cluster.LOM.RemoteVersionLOM) - so
+// that reading a dataset as of a given time is reproducible regardless of the
+// subsequent updates. Requires the cloud provider to implement
+// cluster.VersionedCloudProvider.
+
+func (t *targetrunner) getObjVersion(w http.ResponseWriter, r *http.Request, lom *cluster.LOM) {
+	var (
+		version = r.URL.Query().Get(cmn.URLParamObjVersion)
+		asOf    = r.URL.Query().Get(cmn.URLParamAsOf)
+		ctx     = context.Background()
+	)
+	if !lom.Bck().IsCloud() {
+		t.invalmsghdlrf(w, r, "%s: GET by version (or as of time) requires cloud bucket", lom)
+		return
+	}
+	if version != "" && asOf != "" {
+		t.invalmsghdlrf(w, r, "%s: %q and %q are mutually exclusive", lom, cmn.URLParamObjVersion, cmn.URLParamAsOf)
+		return
+	}
+	cloud, ok := t.Cloud(lom.Bck()).(cluster.VersionedCloudProvider)
+	if !ok {
+		t.invalmsghdlrstatusf(w, r, http.StatusNotImplemented, "%s: cloud provider %q does not support object versions",
+			lom, t.Cloud(lom.Bck()).Provider())
+		return
+	}
+	if asOf != "" {
+		tm, err := cmn.S2Time(asOf)
+		if err != nil {
+			t.invalmsghdlrf(w, r, "%s: invalid time %q: %v", lom, asOf, err)
+			return
+		}
+		var errCode int
+		if version, err, errCode = cloud.ObjVersionAsOf(ctx, lom, tm); err != nil {
+			t.invalmsghdlrErr(w, r, err, errCode)
+			return
+		}
+	}
+	vlom := lom.RemoteVersionLOM(version)
+	lom.Lock(true)
+	if err := vlom.FromFS(); err != nil {
+		if !os.IsNotExist(err) {
+			lom.Unlock(true)
+			t.invalmsghdlrErr(w, r, err)
+			return
+		}
+		if err, errCode := t.getRemoteVersion(ctx, cloud, vlom, version); err != nil {
+			lom.Unlock(true)
+			t.invalmsghdlrErr(w, r, err, errCode)
+			return
+		}
+	}
+	lom.DowngradeLock()
+	defer lom.Unlock(false)
+
+	hdr := vlom.PopulateHdr(w.Header())
+	hdr.Set(cmn.HeaderObjVersion, version)
+	file, err := os.Open(vlom.FQN)
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	defer file.Close()
+	objKey, err := encrypt.LoadObjKey(vlom.CustomMD())
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	if objKey == nil {
+		http.ServeContent(w, r, "", vlom.Atime(), file) // (ranges)
+		return
+	}
+	http.ServeContent(w, r, "", vlom.Atime(), io.NewSectionReader(objKey.ReaderAt(file), 0, vlom.Size()))
+}
+
+// fetches a given version of the object into a workfile and then renames the
+// latter and persists the version's metadata
+// NOTE: must be called under write lock
+func (t *targetrunner) getRemoteVersion(ctx context.Context, cloud cluster.VersionedCloudProvider,
+	vlom *cluster.LOM, version string) (err error, errCode int) {
+	workFQN := fs.CSM.GenContentParsedFQN(vlom.ParsedFQN, fs.WorkfileType, fs.WorkfileColdget)
+	if err, errCode = cloud.GetObjVersion(ctx, workFQN, vlom, version); err != nil {
+		err = fmt.Errorf("%s: GET version %q failed %d, err: %v", vlom, version, errCode, err)
+		return
+	}
+	if err = cmn.Rename(workFQN, vlom.FQN); err != nil {
+		if errRemove := cmn.RemoveFile(workFQN); errRemove != nil {
+			glog.Errorf("Nested error %s => (remove %s => err: %v)", err, workFQN, errRemove)
+		}
+		t.fshc(err, vlom.FQN)
+		return
+	}
+	vlom.SetAtimeUnix(time.Now().UnixNano())
+	if err = vlom.Persist(); err != nil {
+		if errRemove := cmn.RemoveFile(vlom.FQN); errRemove != nil {
+			glog.Errorf("Nested error %s => (remove %s => err: %v)", err, vlom.FQN, errRemove)
+		}
+		return
+	}
+	t.statsT.AddMany(
+		stats.NamedVal64{Name: stats.GetColdCount, Value: 1},
+		stats.NamedVal64{Name: stats.GetColdSize, Value: vlom.Size()},
+	)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: fetched version %q of %s", t.si, version, vlom)
+	}
+	return
+}
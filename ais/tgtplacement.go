@@ -0,0 +1,187 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Placement simulation
+//
+// Given a hypothetical change of the cluster membership (see
+// cmn.SimPlacementMsg), each target walks the bucket's objects that it stores
+// and computes, under HRW, which of them would have to move to other targets.
+// The proxy sums up the results, thus estimating the cost of the rebalance
+// before the membership actually changes. Only the objects that are present
+// in the cluster are accounted for (that is, remote objects that are not
+// cached are not).
+
+// returns a copy of the Smap with the targets added and removed as per the message
+func simSmap(smap *smapX, msg *cmn.SimPlacementMsg) (*smapX, error) {
+	nsmap := smap.clone()
+	for _, sid := range msg.Remove {
+		if nsmap.GetTarget(sid) == nil {
+			return nil, fmt.Errorf("target %q is not present in the %s", sid, smap)
+		}
+		delete(nsmap.Tmap, sid)
+	}
+	for _, sid := range msg.Add {
+		if sid == "" {
+			return nil, fmt.Errorf("target ID cannot be empty")
+		}
+		if smap.containsID(sid) {
+			return nil, fmt.Errorf("node %q is already present in the %s", sid, smap)
+		}
+		nsmap.Tmap[sid] = &cluster.Snode{DaemonID: sid, DaemonType: cmn.Target}
+	}
+	if nsmap.CountTargets() == 0 {
+		return nil, cluster.ErrNoTargets
+	}
+	nsmap.InitDigests()
+	return nsmap, nil
+}
+
+func (t *targetrunner) simPlacement(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, msg *aisMsg) {
+	var simMsg cmn.SimPlacementMsg
+	if err := cmn.MorphMarshal(msg.Value, &simMsg); err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	nsmap, err := simSmap(t.owner.smap.get(), &simMsg)
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	var (
+		res               = cmn.NewSimPlacementResult()
+		sid               = t.si.ID()
+		availablePaths, _ = fs.Mountpaths.Get()
+	)
+	for _, mpathInfo := range availablePaths {
+		opts := &fs.Options{
+			Mpath: mpathInfo,
+			Bck:   bck.Bck,
+			CTs:   []string{fs.ObjectType},
+			Callback: func(fqn string, de fs.DirEntry) error {
+				if de.IsDir() {
+					return nil
+				}
+				lom := &cluster.LOM{T: t, FQN: fqn}
+				if err := lom.Init(bck.Bck); err != nil {
+					return nil
+				}
+				// skip local copies - each object is accounted for once
+				if !lom.IsHRW() {
+					return nil
+				}
+				if err := lom.Load(); err != nil {
+					return nil
+				}
+				res.Objects++
+				res.Size += lom.Size()
+				tsi, err := cluster.HrwTarget(lom.Uname(), &nsmap.Smap)
+				if err != nil {
+					return err
+				}
+				if tsi.ID() != sid {
+					res.Move(sid, tsi.ID(), lom.Size())
+				}
+				return nil
+			},
+			Sorted: false,
+		}
+		if err := fs.Walk(opts); err != nil {
+			t.invalmsghdlrErr(w, r, err)
+			return
+		}
+	}
+	t.writeJSON(w, r, res, "sim-placement")
+}
+
+// Object locations
+//
+// For the listed objects that it owns (under HRW), the target reports whether
+// the object is present and where: the mountpaths of the object and its local
+// copies, and the targets that store its EC slices or replicas (see
+// cmn.ObjLocations). The proxy fills in the hosts.
+
+func (t *targetrunner) objLocations(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, msg *aisMsg) {
+	var (
+		locMsg cmn.ObjLocationsMsg
+		smap   = t.owner.smap.get()
+		sid    = t.si.ID()
+	)
+	if err := cmn.MorphMarshal(msg.Value, &locMsg); err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	locs := make([]*cmn.ObjLocation, 0, len(locMsg.ObjNames)/cmn.Max(smap.CountTargets(), 1)+1)
+	for _, objName := range locMsg.ObjNames {
+		lom := &cluster.LOM{T: t, ObjName: objName}
+		if err := lom.Init(bck.Bck); err != nil {
+			t.invalmsghdlrErr(w, r, err)
+			return
+		}
+		tsi, err := cluster.HrwTarget(lom.Uname(), &smap.Smap)
+		if err != nil {
+			t.invalmsghdlrErr(w, r, err)
+			return
+		}
+		if tsi.ID() != sid {
+			continue
+		}
+		loc := &cmn.ObjLocation{Name: objName, Target: sid}
+		lom.Lock(false)
+		if err := lom.Load(); err == nil {
+			loc.Present = true
+			loc.Size = lom.Size()
+			loc.Mountpaths = append(loc.Mountpaths, lom.ParsedFQN.MpathInfo.Path)
+			for copyFQN, mpathInfo := range lom.GetCopies() {
+				if copyFQN != lom.FQN {
+					loc.Mountpaths = append(loc.Mountpaths, mpathInfo.Path)
+				}
+			}
+		}
+		lom.Unlock(false)
+		if lom.Bprops().EC.Enabled {
+			t.ecLocations(lom, loc, smap)
+		}
+		locs = append(locs, loc)
+	}
+	t.writeJSON(w, r, locs, "obj-locations")
+}
+
+// EC slices (or replicas) are placed on the targets that follow the main one
+// in the HRW order (see ec.putJogger)
+func (t *targetrunner) ecLocations(lom *cluster.LOM, loc *cmn.ObjLocation, smap *smapX) {
+	md, err := ec.ObjectMetadata(lom.Bck(), lom.ObjName)
+	if err != nil {
+		return // not (yet) encoded
+	}
+	var (
+		uname = lom.Uname()
+		cnt   = md.Parity
+	)
+	if md.PackName != "" {
+		uname = lom.Bck().MakeUname(md.PackName)
+	}
+	if !md.IsCopy {
+		cnt += md.Data
+	}
+	sis, err := cluster.HrwTargetListEC(uname, &smap.Smap, cnt+1, &lom.Bprops().EC)
+	if err != nil {
+		return
+	}
+	for _, tsi := range sis[1:] {
+		loc.ECTargets = append(loc.ECTargets, tsi.ID())
+	}
+	loc.ECReplica = md.IsCopy
+}
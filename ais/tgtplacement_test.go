@@ -0,0 +1,66 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestSimSmap(t *testing.T) {
+	smap := newSmap()
+	smap.addProxy(&cluster.Snode{DaemonID: "p1", DaemonType: cmn.Proxy})
+	for _, id := range []string{"t1", "t2", "t3"} {
+		smap.addTarget(&cluster.Snode{DaemonID: id, DaemonType: cmn.Target})
+	}
+	smap.InitDigests()
+
+	tests := []struct {
+		name   string
+		add    []string
+		remove []string
+		fail   bool
+	}{
+		{name: "add", add: []string{"t4"}},
+		{name: "remove", remove: []string{"t2"}},
+		{name: "replace", add: []string{"t4"}, remove: []string{"t1"}},
+		{name: "add existing", add: []string{"t1"}, fail: true},
+		{name: "add proxy", add: []string{"p1"}, fail: true},
+		{name: "add empty", add: []string{""}, fail: true},
+		{name: "remove missing", remove: []string{"t4"}, fail: true},
+		{name: "remove all", remove: []string{"t1", "t2", "t3"}, fail: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			nsmap, err := simSmap(smap, &cmn.SimPlacementMsg{Add: test.add, Remove: test.remove})
+			if test.fail {
+				tassert.Errorf(t, err != nil, "expected error")
+				return
+			}
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, smap.CountTargets() == 3, "the original Smap must not change")
+			tassert.Errorf(t, nsmap.CountTargets() == 3-len(test.remove)+len(test.add),
+				"unexpected number of targets: %d", nsmap.CountTargets())
+
+			// HRW: the objects move only from the removed targets and to the added ones
+			for i := 0; i < 1000; i++ {
+				uname := fmt.Sprintf("bck/obj-%d", i)
+				from, err := cluster.HrwTarget(uname, &smap.Smap)
+				tassert.CheckFatal(t, err)
+				to, err := cluster.HrwTarget(uname, &nsmap.Smap)
+				tassert.CheckFatal(t, err)
+				if from.ID() == to.ID() {
+					continue
+				}
+				tassert.Fatalf(t, cmn.StringInSlice(from.ID(), test.remove) || cmn.StringInSlice(to.ID(), test.add),
+					"unexpected move of %s: %s => %s", uname, from, to)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Watched promotion
+//
+// Each target watches the (local) directories configured in `promote.watch`
+// (see cmn.PromoteConf) and promotes the files that get written into (or moved
+// into) those directories as they appear - the same way a one-time promote
+// (cmn.ActPromote) of the directory would, but without re-walking it. The files
+// that exist when the watch starts are not promoted - use the promote API for
+// those. Failures (e.g., the object already exists and overwrite is not set)
+// get logged and the watch goes on.
+
+type (
+	promWatch struct {
+		conf cmn.PromoteWatchConf
+		w    *fs.DirWatcher
+	}
+	promWatcher struct {
+		cmn.Named
+		t       *targetrunner
+		mtx     sync.Mutex
+		watches []*promWatch
+		stopCh  *cmn.StopCh
+	}
+)
+
+// interface guard
+var (
+	_ cmn.Runner         = &promWatcher{}
+	_ cmn.ConfigListener = &promWatcher{}
+)
+
+func newPromWatcher(t *targetrunner) *promWatcher {
+	return &promWatcher{t: t, stopCh: cmn.NewStopCh()}
+}
+
+func (pw *promWatcher) Run() error {
+	glog.Infof("Starting %s", pw.GetRunName())
+	pw.mtx.Lock()
+	pw.watches = pw.start(cmn.GCO.Get().Promote.Watch)
+	pw.mtx.Unlock()
+	cmn.GCO.Subscribe(pw)
+	<-pw.stopCh.Listen()
+	pw.mtx.Lock()
+	stopPromWatches(pw.watches)
+	pw.watches = nil
+	pw.mtx.Unlock()
+	return nil
+}
+
+func (pw *promWatcher) Stop(err error) {
+	glog.Infof("Stopping %s, err: %v", pw.GetRunName(), err)
+	pw.stopCh.Close()
+}
+
+func (pw *promWatcher) ConfigUpdate(oldConf, newConf *cmn.Config) {
+	if reflect.DeepEqual(oldConf.Promote, newConf.Promote) {
+		return
+	}
+	pw.mtx.Lock()
+	select {
+	case <-pw.stopCh.Listen():
+	default:
+		stopPromWatches(pw.watches)
+		pw.watches = pw.start(newConf.Promote.Watch)
+	}
+	pw.mtx.Unlock()
+}
+
+func (pw *promWatcher) start(confs []cmn.PromoteWatchConf) (watches []*promWatch) {
+	for _, conf := range confs {
+		w, err := fs.NewDirWatcher(conf.Dir, conf.Recurs)
+		if err != nil {
+			glog.Errorf("%s: %v", pw.t.si, err)
+			continue
+		}
+		pwatch := &promWatch{conf: conf, w: w}
+		go w.Run(func(fqn string) { pw.promote(pwatch, fqn) })
+		glog.Infof("%s: watching %q => %s", pw.t.si, conf.Dir, conf.Bck)
+		watches = append(watches, pwatch)
+	}
+	return
+}
+
+func stopPromWatches(watches []*promWatch) {
+	for _, pwatch := range watches {
+		pwatch.w.Stop()
+	}
+}
+
+// NOTE: the same object naming as mirror.XactDirPromote
+func (pw *promWatcher) promote(pwatch *promWatch, fqn string) {
+	conf := &pwatch.conf
+	bck := cluster.NewBckEmbed(conf.Bck)
+	if err := bck.Init(pw.t.owner.bmd, pw.t.si); err != nil {
+		glog.Errorf("%s: failed to promote %q: %v", pw.t.si, fqn, err)
+		return
+	}
+	objName := conf.ObjName + strings.TrimPrefix(strings.TrimPrefix(fqn, conf.Dir), string(filepath.Separator))
+	objName = strings.Trim(objName, string(filepath.Separator))
+	if _, err := pw.t.PromoteFile(fqn, bck, objName, nil, /*expectedCksum*/
+		conf.Overwrite, true /*safe*/, false /*verbose*/); err != nil {
+		glog.Errorf("%s: failed to promote %q: %v", pw.t.si, fqn, err)
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: promoted %q => %s/%s", pw.t.si, fqn, bck, objName)
+	}
+}
@@ -46,14 +46,14 @@ func (t *targetrunner) httpquerypost(w http.ResponseWriter, r *http.Request) {
 
 	q, err := query.NewQueryFromMsg(&msg.QueryMsg)
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 
 	wi := walkinfo.NewDefaultWalkInfo(t, msg.QueryMsg.From.Bck.Name)
 	wi.SetObjectFilter(q.Filter())
 	if _, err = xaction.Registry.RenewObjectsListingXact(t, q, wi, handle); err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 }
@@ -93,7 +93,7 @@ func (t *targetrunner) httpqueryget(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if err != nil && err != io.EOF {
-		t.invalmsghdlr(w, r, err.Error(), http.StatusInternalServerError)
+		t.invalmsghdlrErr(w, r, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -0,0 +1,79 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/ais/cloud"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/housekeep/lru"
+)
+
+//
+// target-local caching of objects read from attached remote AIS clusters
+// (see cmn.RemoteCacheConf)
+//
+
+// how often the remote cache gets checked against its configured capacity
+const remoteCacheEvictIval = time.Minute
+
+// cached object name: <remote cluster uuid>/<remote bucket>/<object name>
+func remoteCacheObjName(bck cmn.Bck, objName string) string {
+	return path.Join(bck.Ns.UUID, bck.Name, objName)
+}
+
+// returns initialized (but not loaded) lom of the cached replica of a given remote AIS object
+func (t *targetrunner) remoteCacheLOM(lom *cluster.LOM, config *cmn.Config) (clom *cluster.LOM, err error) {
+	var (
+		rbck = lom.Bck().Bck
+		cbck = cmn.Bck{Name: config.RemoteCache.Bucket, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+	)
+	cmn.Assert(rbck.IsRemoteAIS())
+	clom = &cluster.LOM{T: t, ObjName: remoteCacheObjName(rbck, lom.ObjName)}
+	err = clom.Init(cbck, config)
+	return
+}
+
+// cold GET from the remote cluster directly into the cache bucket
+func (t *targetrunner) getColdCached(ctx context.Context, lom, clom *cluster.LOM) (err error, errCode int) {
+	return t.getCold(clom, false /*prefetch*/, func(workFQN string) (error, int) {
+		return t.cloud.ais.GetObjTo(ctx, workFQN, lom.Bck().Bck, lom.ObjName, clom)
+	})
+}
+
+// checks the cached replica against the remote object: HEAD the latter and
+// compare its ETag with the one recorded at cold GET time (see cloud.RemoteETag)
+func (t *targetrunner) checkRemoteCache(ctx context.Context, lom, clom *cluster.LOM) (stale bool, err error,
+	errCode int) {
+	var objMeta cmn.SimpleKVs
+	if objMeta, err, errCode = t.cloud.ais.HeadObj(ctx, lom); err != nil {
+		err = fmt.Errorf("%s: failed to head remote %s, err: %v", clom, lom, err)
+		return
+	}
+	var (
+		etag, ok   = clom.GetCustomMD(cluster.RemoteETagObjMD)
+		remoteETag = cloud.RemoteETag(objMeta)
+	)
+	if stale = !ok || etag != remoteETag; stale {
+		glog.Infof("%s: remote %s has changed (%q => %q)", clom, lom, etag, remoteETag)
+	}
+	return
+}
+
+func (t *targetrunner) housekeepRemoteCache() time.Duration {
+	if !cmn.GCO.Get().RemoteCache.Enabled {
+		return remoteCacheEvictIval
+	}
+	if err := lru.EvictRemoteCache(t, t.statsT); err != nil {
+		glog.Errorf("%s: failed to evict remote cache, err: %v", t.si, err)
+	}
+	return remoteCacheEvictIval
+}
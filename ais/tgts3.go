@@ -8,8 +8,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"os"
 	"path"
-	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -17,24 +17,95 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/encrypt"
 	"github.com/NVIDIA/aistore/tar2tf"
 )
 
-// PUT s3/bckName/objName
+// [METHOD] s3/bckName/objName
 func (t *targetrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 	apitems, err := t.checkRESTItems(w, r, 0, true, cmn.S3)
 	if err != nil {
 		return
 	}
+	// (the signature, if any, has been verified by the proxy - the payload is read here)
+	if err := s3compat.VerifyPayload(r); err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
 
+	var (
+		query          = r.URL.Query()
+		uploadID       = query.Get(s3compat.URLParamMultipartUpload)
+		_, mptInitiate = query[s3compat.URLParamMultipartUploads]
+		_, tagging     = query[s3compat.URLParamTagging]
+		_, acl         = query[s3compat.URLParamACL]
+		versionID      = query.Get(s3compat.URLParamVersionID)
+	)
 	switch r.Method {
 	case http.MethodHead:
+		if versionID != "" {
+			t.getObjVersionS3(w, r, apitems, versionID)
+			return
+		}
 		t.headObjS3(w, r, apitems)
 	case http.MethodGet:
+		if _, versions := query[s3compat.URLParamVersions]; versions && len(apitems) == 1 {
+			t.listObjVersionsS3(w, r, apitems[0])
+			return
+		}
+		if versionID != "" {
+			t.getObjVersionS3(w, r, apitems, versionID)
+			return
+		}
+		if uploadID != "" {
+			t.listPartsS3(w, r, apitems, uploadID)
+			return
+		}
+		if tagging {
+			t.getObjTaggingS3(w, r, apitems)
+			return
+		}
+		if acl {
+			t.getObjACLS3(w, r, apitems)
+			return
+		}
 		t.getObjS3(w, r, apitems)
 	case http.MethodPut:
+		if uploadID != "" {
+			t.putPartS3(w, r, apitems, uploadID)
+			return
+		}
+		if tagging {
+			t.putObjTaggingS3(w, r, apitems)
+			return
+		}
+		if acl {
+			t.putObjACLS3(w, r, apitems)
+			return
+		}
 		t.putObjS3(w, r, apitems)
+	case http.MethodPost:
+		switch {
+		case mptInitiate:
+			t.initiateMptS3(w, r, apitems)
+		case uploadID != "":
+			t.completeMptS3(w, r, apitems, uploadID)
+		default:
+			t.invalmsghdlr(w, r, "invalid request")
+		}
 	case http.MethodDelete:
+		if uploadID != "" {
+			t.abortMptS3(w, r, apitems, uploadID)
+			return
+		}
+		if tagging {
+			t.delObjTaggingS3(w, r, apitems)
+			return
+		}
+		if versionID != "" {
+			t.delObjVersionS3(w, r, apitems, versionID)
+			return
+		}
 		t.delObjS3(w, r, apitems)
 	default:
 		t.invalmsghdlrf(w, r, "Invalid HTTP Method: %v %s", r.Method, r.URL.Path)
@@ -47,37 +118,48 @@ func (t *targetrunner) copyObjS3(w http.ResponseWriter, r *http.Request, items [
 		return
 	}
 	config := cmn.GCO.Get()
-	src := r.Header.Get(s3compat.HeaderObjSrc)
-	src = strings.Trim(src, "/") // in AWS examples the path starts with "/"
-	parts := strings.SplitN(src, "/", 2)
-	if len(parts) < 2 {
-		t.invalmsghdlr(w, r, "copy is not an object name")
+	srcBck, objSrc, err := s3compat.ParseCopySource(r.Header.Get(s3compat.HeaderObjSrc))
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
-	bckSrc := cluster.NewBck(parts[0], cmn.ProviderAIS, cmn.NsGlobal)
-	objSrc := strings.Trim(parts[1], "/")
-	if err := bckSrc.Init(t.owner.bmd, nil); err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+	directive, err := s3compat.MetaDirective(r.Header)
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
-	lom := &cluster.LOM{T: t, ObjName: objSrc}
-	if err := lom.Init(bckSrc.Bck, config); err != nil {
-		if _, ok := err.(*cmn.ErrorRemoteBucketDoesNotExist); ok {
-			t.BMDVersionFixup(r, cmn.Bck{}, true /* sleep */)
-			err = lom.Init(bckSrc.Bck, config)
+	bckSrc := cluster.NewBckEmbed(srcBck)
+	if err := bckSrc.Init(t.owner.bmd, nil); err != nil {
+		if _, ok := err.(*cmn.ErrorRemoteBucketDoesNotExist); !ok {
+			t.invalmsghdlrErr(w, r, err)
+			return
 		}
-		if err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+		t.BMDVersionFixup(r, cmn.Bck{}, true /* sleep */)
+		if err := bckSrc.Init(t.owner.bmd, nil); err != nil {
+			t.invalmsghdlrErr(w, r, err)
+			return
 		}
+	}
+	lom := &cluster.LOM{T: t, ObjName: objSrc}
+	if err := lom.Init(bckSrc.Bck, config); err != nil {
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if err := lom.Load(); err != nil {
-		t.invalmsghdlr(w, r, err.Error())
-		return
+		if !cmn.IsObjNotExist(err) || lom.Bck().IsAIS() {
+			t.invalmsghdlrErr(w, r, err, http.StatusNotFound)
+			return
+		}
+		// remote source that is not cached yet
+		if err, errCode := t.GetCold(r.Context(), lom, false /*prefetch*/); err != nil {
+			t.invalmsghdlrErr(w, r, err, errCode)
+			return
+		}
+		lom.Unlock(false)
 	}
 	bckDst := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bckDst.Init(t.owner.bmd, nil); err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 
@@ -87,8 +169,15 @@ func (t *targetrunner) copyObjS3(w http.ResponseWriter, r *http.Request, items [
 		bckTo: bckDst,
 	}
 	objName := path.Join(items[1:]...)
+	if directive == s3compat.MetaDirectiveReplace {
+		ri.customMD = s3compat.ReplaceUserMD(lom.CustomMD(), r.Header)
+	} else if bckSrc.Bck.Equal(bckDst.Bck) && objSrc == objName {
+		t.invalmsghdlrf(w, r, "cannot copy %s onto itself without changing its metadata (%s: %s)",
+			lom, s3compat.HeaderMetaDirective, s3compat.MetaDirectiveReplace)
+		return
+	}
 	if _, err := ri.copyObject(lom, objName); err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 
@@ -112,7 +201,7 @@ func (t *targetrunner) directPutObjS3(w http.ResponseWriter, r *http.Request, it
 	}
 	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(t.owner.bmd, nil); err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if len(items) < 2 {
@@ -130,7 +219,7 @@ func (t *targetrunner) directPutObjS3(w http.ResponseWriter, r *http.Request, it
 			err = lom.Init(bck.Bck, config)
 		}
 		if err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 			return
 		}
 	}
@@ -138,18 +227,48 @@ func (t *targetrunner) directPutObjS3(w http.ResponseWriter, r *http.Request, it
 		lom.Load() // need to know the current version if versioning enabled
 	}
 	lom.SetAtimeUnix(started.UnixNano())
+	// the new object gets the tags from the request (if any) - not the old ones
+	tags, err := s3compat.ParseTaggingHeader(r.Header.Get(s3compat.HeaderTagging))
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	s3compat.SetTags(lom, tags)
+	s3compat.SetUserMD(lom, r.Header)
+	if sse := r.Header.Get(s3compat.HeaderSSE); sse != "" {
+		if sse != encrypt.AlgAES256 {
+			t.invalmsghdlrf(w, r, "unsupported server-side encryption %q (expecting %q)", sse, encrypt.AlgAES256)
+			return
+		}
+		if !encrypt.Enabled() {
+			t.invalmsghdlrErr(w, r, encrypt.ErrNoKeys)
+			return
+		}
+	}
 
 	// TODO: lom.SetCustomMD(cluster.AmazonMD5ObjMD, checksum)
 
 	if err, errCode := t.doPut(r, lom, started); err != nil {
 		t.fshc(err, lom.FQN)
-		t.invalmsghdlr(w, r, err.Error(), errCode)
+		t.invalmsghdlrErr(w, r, err, errCode)
 		return
 	}
+	if lom.VerConf().Enabled {
+		w.Header().Set(s3compat.HeaderVersion, s3compat.VersionID(lom))
+	}
+	if v, exists := lom.GetCustomMD(encrypt.AlgMD); exists {
+		w.Header().Set(s3compat.HeaderSSE, v)
+	}
+	t.journal(lom, cmn.JournalPut, "", t.requester(r))
 }
 
 // PUT s3/bckName/objName
 func (t *targetrunner) putObjS3(w http.ResponseWriter, r *http.Request, items []string) {
+	if len(items) > 1 && cmn.IsECPackName(path.Join(items[1:]...)) {
+		t.invalmsghdlrstatusf(w, r, http.StatusForbidden, "%q: reserved name (see %s)",
+			path.Join(items[1:]...), cmn.ECPackPrefix)
+		return
+	}
 	if r.Header.Get(s3compat.HeaderObjSrc) == "" {
 		t.directPutObjS3(w, r, items)
 		return
@@ -167,7 +286,7 @@ func (t *targetrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []
 	config := cmn.GCO.Get()
 	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(t.owner.bmd, nil); err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 	var (
@@ -195,12 +314,15 @@ func (t *targetrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []
 			err = lom.Init(bck.Bck, config)
 		}
 		if err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 		}
 		return
 	}
 	if err = lom.Load(true); err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	if !t.checkPreconditionsS3(w, r, lom) {
 		return
 	}
 
@@ -208,7 +330,7 @@ func (t *targetrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []
 	if tag != "" {
 		objSize, err = tar2tf.Cache.GetSize(lom)
 		if err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 			return
 		}
 	}
@@ -226,7 +348,7 @@ func (t *targetrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []
 		if cmn.IsErrConnectionReset(err) {
 			glog.Errorf("GET %s: %v", lom, err)
 		} else {
-			t.invalmsghdlr(w, r, err.Error(), errCode)
+			t.invalmsghdlrErr(w, r, err, errCode)
 		}
 	}
 }
@@ -244,7 +366,7 @@ func (t *targetrunner) headObjS3(w http.ResponseWriter, r *http.Request, items [
 	bucket, objName := items[0], path.Join(items[1:]...)
 	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(t.owner.bmd, nil); err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 	lom := &cluster.LOM{T: t, ObjName: objName}
@@ -254,7 +376,7 @@ func (t *targetrunner) headObjS3(w http.ResponseWriter, r *http.Request, items [
 			err = lom.Init(bck.Bck, config)
 		}
 		if err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 		}
 		return
 	}
@@ -262,7 +384,7 @@ func (t *targetrunner) headObjS3(w http.ResponseWriter, r *http.Request, items [
 	lom.Lock(false)
 	if err = lom.Load(true); err != nil && !cmn.IsObjNotExist(err) { // (doesnotexist -> ok, other)
 		lom.Unlock(false)
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 	lom.Unlock(false)
@@ -272,9 +394,32 @@ func (t *targetrunner) headObjS3(w http.ResponseWriter, r *http.Request, items [
 		t.invalmsghdlrstatusf(w, r, http.StatusNotFound, "%s/%s %s", bucket, objName, cmn.DoesNotExist)
 		return
 	}
+	if !t.checkPreconditionsS3(w, r, lom) {
+		return
+	}
 	s3compat.SetHeaderFromLOM(w.Header(), lom, lom.Size())
 }
 
+// Evaluates the conditional headers (If-Match, If-None-Match, etc.) of GET and HEAD;
+// returns false, having responded with 304 or 412, if the object is not to be returned.
+func (t *targetrunner) checkPreconditionsS3(w http.ResponseWriter, r *http.Request, lom *cluster.LOM) bool {
+	mtime := lom.Atime()
+	if finfo, err := os.Stat(lom.FQN); err == nil {
+		mtime = finfo.ModTime()
+	}
+	etag := s3compat.ETag(lom)
+	switch s3compat.CheckPreconditions(r.Header, etag, mtime) {
+	case http.StatusNotModified:
+		s3compat.SetNotModifiedHeader(w.Header(), lom)
+		w.WriteHeader(http.StatusNotModified)
+	case http.StatusPreconditionFailed:
+		t.invalmsghdlrstatusf(w, r, http.StatusPreconditionFailed, "%s: precondition failed", lom)
+	default:
+		return true
+	}
+	return false
+}
+
 // DEL s3/bckName/objName
 func (t *targetrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []string) {
 	var (
@@ -282,7 +427,7 @@ func (t *targetrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []
 		bck    = cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
 	)
 	if err := bck.Init(t.owner.bmd, nil); err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if len(items) < 2 {
@@ -292,7 +437,11 @@ func (t *targetrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []
 	objName := path.Join(items[1:]...)
 	lom := &cluster.LOM{T: t, ObjName: objName}
 	if err := lom.Init(bck.Bck, config); err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	if lom.VerConf().Enabled {
+		t.delObjKeepVersionS3(w, r, lom)
 		return
 	}
 	err, errCode := t.objDelete(context.Background(), lom, false)
@@ -307,6 +456,7 @@ func (t *targetrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []
 		}
 		return
 	}
+	t.journal(lom, cmn.JournalDelete, "", t.requester(r))
 	// EC cleanup if EC is enabled
 	ec.ECM.CleanupObject(lom)
 }
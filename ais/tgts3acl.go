@@ -0,0 +1,64 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/ais/s3compat"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/debug"
+)
+
+// Object ACLs: there are no per-object access attributes in AIS - an object's
+// ACL is its bucket's ACL (see s3compat/acl.go)
+
+// GET s3/bckName/objName?acl
+func (t *targetrunner) getObjACLS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom, err := t.s3LOM(w, r, items)
+	if err != nil {
+		return
+	}
+	lom.Lock(false)
+	err = lom.Load(true)
+	lom.Unlock(false)
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	w.Header().Set(cmn.HeaderContentType, s3compat.ContentType)
+	w.Write(s3compat.NewAccessControlPolicy(lom.Bprops().Access).MustMarshal())
+}
+
+// PUT s3/bckName/objName?acl
+// Succeeds only if the requested ACL is the one the object already has (via its bucket).
+func (t *targetrunner) putObjACLS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom, err := t.s3LOM(w, r, items)
+	if err != nil {
+		return
+	}
+	defer func() {
+		debug.AssertNoErr(r.Body.Close())
+	}()
+	access, err := s3compat.ParseACL(r)
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	lom.Lock(false)
+	err = lom.Load(true)
+	lom.Unlock(false)
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	requested, current := s3compat.AccessToCanned(access), s3compat.AccessToCanned(lom.Bprops().Access)
+	if requested != current {
+		err = fmt.Errorf("%s: per-object ACLs are not supported - the object's ACL is its bucket's %q (requested %q)",
+			lom, current, requested)
+		t.invalmsghdlrErr(w, r, err, http.StatusNotImplemented)
+	}
+}
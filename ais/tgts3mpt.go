@@ -0,0 +1,440 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/ais/s3compat"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// S3 multipart upload
+//
+// All requests of a given upload (initiate, upload part, list parts,
+// complete, abort) get redirected to the target that stores the object.
+// The target keeps track of the upload in memory and stores its parts next
+// to the object (fs.MptPartType). Upon completion, the parts get assembled
+// into the object - the same way a regular PUT does it. The uploads that have
+// been idle for longer than mptAbortAfter get aborted (see gcMptS3).
+//
+// NOTE: the uploads do not survive the target's restart, nor the change of
+// the object's location (e.g., due to a cluster membership change) - the
+// client gets "no such upload" and must start over.
+
+const (
+	mptAbortAfter = 24 * time.Hour // abort the uploads idle for that long, remove orphaned parts
+	mptGCIval     = time.Hour
+)
+
+type (
+	mptPart struct {
+		num   int
+		fqn   string // workfile
+		size  int64
+		md5   string // hex-encoded
+		mtime time.Time
+	}
+	mptUpload struct {
+		bck        cmn.Bck
+		objName    string
+		parts      map[int]*mptPart
+		atime      time.Time // last activity
+		completing bool      // taken over by complete
+	}
+	mptUploads struct {
+		mtx sync.Mutex
+		m   map[string]*mptUpload // upload ID => upload
+	}
+	// reads the parts one after another
+	mptReader struct {
+		io.Reader
+		files []*os.File
+	}
+)
+
+func (u *mptUploads) add(id string, upload *mptUpload) {
+	u.mtx.Lock()
+	if u.m == nil {
+		u.m = make(map[string]*mptUpload, 4)
+	}
+	u.m[id] = upload
+	u.mtx.Unlock()
+}
+
+// returns the upload of a given object; must be called under lock
+func (u *mptUploads) get(id string, lom *cluster.LOM) (*mptUpload, error) {
+	upload, ok := u.m[id]
+	if !ok || upload.completing || !upload.bck.Equal(lom.Bck().Bck) || upload.objName != lom.ObjName {
+		return nil, fmt.Errorf("%s: no such multipart upload %q", lom, id)
+	}
+	return upload, nil
+}
+
+// removes the upload along with its parts
+func (u *mptUploads) remove(id string, lom *cluster.LOM) error {
+	u.mtx.Lock()
+	upload, err := u.get(id, lom)
+	if err == nil {
+		delete(u.m, id)
+	}
+	u.mtx.Unlock()
+	if err != nil {
+		return err
+	}
+	upload.cleanup()
+	return nil
+}
+
+// aborts the uploads that have been idle for longer than a given time; returns
+// the parts of the remaining ones
+func (u *mptUploads) expire(expired time.Time) (parts cmn.StringSet) {
+	var aborted []*mptUpload
+	parts = make(cmn.StringSet)
+	u.mtx.Lock()
+	for id, upload := range u.m {
+		if !upload.completing && upload.atime.Before(expired) {
+			delete(u.m, id)
+			aborted = append(aborted, upload)
+			glog.Infof("aborting idle multipart upload %q of %s/%s", id, upload.bck, upload.objName)
+			continue
+		}
+		for _, part := range upload.parts {
+			parts.Add(part.fqn)
+		}
+	}
+	u.mtx.Unlock()
+	for _, upload := range aborted {
+		upload.cleanup()
+	}
+	return
+}
+
+func (upload *mptUpload) cleanup() {
+	for _, part := range upload.parts {
+		if err := cmn.RemoveFile(part.fqn); err != nil {
+			glog.Errorf("failed to remove multipart upload part %s: %v", part.fqn, err)
+		}
+	}
+}
+
+func (r *mptReader) Close() (err error) {
+	for _, file := range r.files {
+		if erc := file.Close(); erc != nil && err == nil {
+			err = erc
+		}
+	}
+	return
+}
+
+// returns the LOM of the object that the request refers to
+func (t *targetrunner) s3LOM(w http.ResponseWriter, r *http.Request, items []string) (lom *cluster.LOM, err error) {
+	if len(items) < 2 {
+		err = fmt.Errorf("object name is undefined")
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
+	if err = bck.Init(t.owner.bmd, nil); err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	lom = &cluster.LOM{T: t, ObjName: path.Join(items[1:]...)}
+	if err = lom.Init(bck.Bck); err != nil {
+		if _, ok := err.(*cmn.ErrorRemoteBucketDoesNotExist); ok {
+			t.BMDVersionFixup(r, cmn.Bck{}, true /*sleep*/)
+			err = lom.Init(bck.Bck)
+		}
+		if err != nil {
+			t.invalmsghdlrErr(w, r, err)
+		}
+	}
+	return
+}
+
+// POST s3/bckName/objName?uploads
+func (t *targetrunner) initiateMptS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom, err := t.s3LOM(w, r, items)
+	if err != nil {
+		return
+	}
+	id := cmn.GenUUID()
+	t.mptUploads.add(id, &mptUpload{
+		bck:     lom.Bck().Bck,
+		objName: lom.ObjName,
+		parts:   make(map[int]*mptPart, 8),
+		atime:   time.Now(),
+	})
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: initiated multipart upload %q", lom, id)
+	}
+	result := s3compat.NewInitiateMptUploadResult(lom.BckName(), lom.ObjName, id)
+	w.Header().Set(cmn.HeaderContentType, s3compat.ContentType)
+	w.Write(result.MustMarshal())
+}
+
+// PUT s3/bckName/objName?partNumber=N&uploadId=ID
+func (t *targetrunner) putPartS3(w http.ResponseWriter, r *http.Request, items []string, id string) {
+	lom, err := t.s3LOM(w, r, items)
+	if err != nil {
+		return
+	}
+	num, err := strconv.Atoi(r.URL.Query().Get(s3compat.URLParamMultipartPartNo))
+	if err != nil || num < 1 || num > s3compat.MaxMultipartParts {
+		t.invalmsghdlrf(w, r, "%s: invalid part number (expecting 1 to %d)", lom, s3compat.MaxMultipartParts)
+		return
+	}
+	t.mptUploads.mtx.Lock()
+	upload, err := t.mptUploads.get(id, lom)
+	if err == nil {
+		upload.atime = time.Now()
+	}
+	t.mptUploads.mtx.Unlock()
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	if capInfo := t.AvgCapUsed(cmn.GCO.Get()); capInfo.OOS {
+		t.invalmsghdlrErr(w, r, capInfo.Err)
+		return
+	}
+
+	var (
+		fqn       = fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.MptPartType, id)
+		buf, slab = t.gmm.Alloc()
+	)
+	cksum, err := cmn.SaveReader(fqn, r.Body, buf, cmn.ChecksumMD5, r.ContentLength, "")
+	slab.Free(buf)
+	if err != nil {
+		t.fshc(err, fqn)
+		t.invalmsghdlrErr(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	part := &mptPart{num: num, fqn: fqn, md5: cksum.Value(), mtime: time.Now()}
+	if finfo, err := os.Stat(fqn); err == nil {
+		part.size = finfo.Size()
+	}
+
+	// the upload may have been aborted or completed in the meantime
+	t.mptUploads.mtx.Lock()
+	upload, err = t.mptUploads.get(id, lom)
+	if err == nil {
+		upload.atime = time.Now()
+		if prev, ok := upload.parts[num]; ok {
+			fqn = prev.fqn // re-uploaded: remove the previous one
+		}
+		upload.parts[num] = part
+	}
+	t.mptUploads.mtx.Unlock()
+	if err != nil || fqn != part.fqn {
+		if erl := cmn.RemoveFile(fqn); erl != nil {
+			glog.Errorf("failed to remove multipart upload part %s: %v", fqn, erl)
+		}
+	}
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	s3compat.SetETag(w.Header(), part.md5)
+}
+
+// GET s3/bckName/objName?uploadId=ID
+func (t *targetrunner) listPartsS3(w http.ResponseWriter, r *http.Request, items []string, id string) {
+	lom, err := t.s3LOM(w, r, items)
+	if err != nil {
+		return
+	}
+	t.mptUploads.mtx.Lock()
+	upload, err := t.mptUploads.get(id, lom)
+	if err != nil {
+		t.mptUploads.mtx.Unlock()
+		t.invalmsghdlrErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	parts := make([]*s3compat.PartInfo, 0, len(upload.parts))
+	for _, part := range upload.parts {
+		parts = append(parts, &s3compat.PartInfo{
+			PartNumber:   part.num,
+			ETag:         s3compat.QuoteETag(part.md5),
+			Size:         part.size,
+			LastModified: s3compat.FormatTime(part.mtime),
+		})
+	}
+	t.mptUploads.mtx.Unlock()
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+
+	result := s3compat.NewListPartsResult(lom.BckName(), lom.ObjName, id, parts)
+	w.Header().Set(cmn.HeaderContentType, s3compat.ContentType)
+	w.Write(result.MustMarshal())
+}
+
+// POST s3/bckName/objName?uploadId=ID
+func (t *targetrunner) completeMptS3(w http.ResponseWriter, r *http.Request, items []string, id string) {
+	started := time.Now()
+	lom, err := t.s3LOM(w, r, items)
+	if err != nil {
+		return
+	}
+	req := &s3compat.CompleteMptUpload{}
+	if err := xml.NewDecoder(r.Body).Decode(req); err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	if err := req.Validate(); err != nil {
+		t.invalmsghdlrf(w, r, "%s: multipart upload %q: %v", lom, id, err)
+		return
+	}
+
+	// validate the parts and take the upload over (so that it can no longer be
+	// aborted, completed, or amended by others)
+	t.mptUploads.mtx.Lock()
+	upload, err := t.mptUploads.get(id, lom)
+	if err != nil {
+		t.mptUploads.mtx.Unlock()
+		t.invalmsghdlrErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	var (
+		parts = make([]*mptPart, 0, len(req.Parts))
+		md5s  = make([]string, 0, len(req.Parts))
+		size  int64
+	)
+	for _, p := range req.Parts {
+		part, ok := upload.parts[p.PartNumber]
+		if !ok || part.md5 != s3compat.UnquoteETag(p.ETag) {
+			t.mptUploads.mtx.Unlock()
+			t.invalmsghdlrf(w, r, "%s: multipart upload %q: part %d not found or its ETag does not match",
+				lom, id, p.PartNumber)
+			return
+		}
+		parts = append(parts, part)
+		md5s = append(md5s, part.md5)
+		size += part.size
+	}
+	upload.completing = true
+	t.mptUploads.mtx.Unlock()
+	defer func() {
+		t.mptUploads.mtx.Lock()
+		delete(t.mptUploads.m, id)
+		t.mptUploads.mtx.Unlock()
+		upload.cleanup()
+	}()
+
+	etag, err := s3compat.MptETag(md5s)
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err, http.StatusInternalServerError)
+		return
+	}
+	reader := &mptReader{files: make([]*os.File, 0, len(parts))}
+	readers := make([]io.Reader, 0, len(parts))
+	for _, part := range parts {
+		file, err := os.Open(part.fqn)
+		if err != nil {
+			debug.AssertNoErr(reader.Close())
+			t.invalmsghdlrErr(w, r, err, http.StatusInternalServerError)
+			return
+		}
+		reader.files = append(reader.files, file)
+		readers = append(readers, file)
+	}
+	reader.Reader = io.MultiReader(readers...)
+
+	if lom.VerConf().Enabled {
+		lom.Load() // need to know the current version if versioning enabled
+	}
+	lom.SetAtimeUnix(started.UnixNano())
+	poi := &putObjInfo{
+		started: started,
+		t:       t,
+		lom:     lom,
+		r:       reader,
+		size:    size,
+		ctx:     context.Background(),
+		workFQN: fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfilePut),
+	}
+	if err, errCode := poi.putObject(); err != nil {
+		t.fshc(err, lom.FQN)
+		t.invalmsghdlrErr(w, r, err, errCode)
+		return
+	}
+	t.journal(lom, cmn.JournalPut, "", t.requester(r))
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: completed multipart upload %q (%d parts)", lom, id, len(parts))
+	}
+	result := s3compat.NewCompleteMptUploadResult(lom.BckName(), lom.ObjName, etag)
+	w.Header().Set(cmn.HeaderContentType, s3compat.ContentType)
+	w.Write(result.MustMarshal())
+}
+
+// DELETE s3/bckName/objName?uploadId=ID
+func (t *targetrunner) abortMptS3(w http.ResponseWriter, r *http.Request, items []string, id string) {
+	lom, err := t.s3LOM(w, r, items)
+	if err != nil {
+		return
+	}
+	if err := t.mptUploads.remove(id, lom); err != nil {
+		t.invalmsghdlrErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// housekeeping: aborts the idle uploads and removes the orphaned parts (e.g.,
+// of the uploads that did not survive the target's restart)
+func (t *targetrunner) gcMptS3() time.Duration {
+	var (
+		expired           = time.Now().Add(-mptAbortAfter)
+		parts             = t.mptUploads.expire(expired)
+		availablePaths, _ = fs.Mountpaths.Get()
+		cnt               int
+	)
+	t.GetBowner().Get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		for _, mpathInfo := range availablePaths {
+			opts := &fs.Options{
+				Mpath: mpathInfo,
+				Bck:   bck.Bck,
+				CTs:   []string{fs.MptPartType},
+				Callback: func(fqn string, de fs.DirEntry) error {
+					if de.IsDir() || parts.Contains(fqn) {
+						return nil
+					}
+					finfo, err := os.Stat(fqn)
+					if err != nil || finfo.ModTime().After(expired) {
+						return nil // (the part of a new upload - may not be registered yet)
+					}
+					if err := cmn.RemoveFile(fqn); err != nil {
+						glog.Errorf("failed to remove multipart upload part %s: %v", fqn, err)
+						return nil
+					}
+					cnt++
+					return nil
+				},
+				Sorted: false,
+			}
+			if err := fs.Walk(opts); err != nil {
+				glog.Errorf("%s: failed to remove orphaned multipart upload parts: %v", bck, err)
+			}
+		}
+		return false
+	})
+	if cnt > 0 {
+		glog.Infof("%s: removed %d orphaned multipart upload part(s)", t.si, cnt)
+	}
+	return mptGCIval
+}
@@ -0,0 +1,58 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+func TestMptUploadsExpire(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mpt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var (
+		u       mptUploads
+		now     = time.Now()
+		expired = now.Add(-mptAbortAfter)
+		bck     = cmn.Bck{Name: "bck", Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+	)
+	newUpload := func(id string, atime time.Time) *mptUpload {
+		fqn := filepath.Join(dir, id)
+		if err := ioutil.WriteFile(fqn, []byte(id), 0644); err != nil {
+			t.Fatal(err)
+		}
+		upload := &mptUpload{bck: bck, objName: id, atime: atime, parts: map[int]*mptPart{1: {num: 1, fqn: fqn}}}
+		u.add(id, upload)
+		return upload
+	}
+	newUpload("active", now)
+	newUpload("idle", expired.Add(-time.Minute))
+	newUpload("completing", expired.Add(-time.Minute)).completing = true
+
+	parts := u.expire(expired)
+	if _, ok := u.m["idle"]; ok {
+		t.Error("expected the idle upload to be aborted")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "idle")); !os.IsNotExist(err) {
+		t.Errorf("expected the parts of the idle upload to be removed, err: %v", err)
+	}
+	for _, id := range []string{"active", "completing"} {
+		if _, ok := u.m[id]; !ok {
+			t.Errorf("expected the %s upload to remain", id)
+		}
+		if !parts.Contains(filepath.Join(dir, id)) {
+			t.Errorf("expected the parts of the %s upload to be returned", id)
+		}
+	}
+}
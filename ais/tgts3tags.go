@@ -0,0 +1,81 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/url"
+
+	"github.com/NVIDIA/aistore/ais/s3compat"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// GET s3/bckName/objName?tagging
+func (t *targetrunner) getObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom, err := t.s3LOM(w, r, items)
+	if err != nil {
+		return
+	}
+	lom.Lock(false)
+	err = lom.Load(true)
+	lom.Unlock(false)
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	w.Header().Set(cmn.HeaderContentType, s3compat.ContentType)
+	w.Write(s3compat.NewTagging(s3compat.GetTags(lom)).MustMarshal())
+}
+
+// PUT s3/bckName/objName?tagging
+func (t *targetrunner) putObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom, err := t.s3LOM(w, r, items)
+	if err != nil {
+		return
+	}
+	tagging := &s3compat.Tagging{}
+	if err := xml.NewDecoder(r.Body).Decode(tagging); err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	tags, err := tagging.Tags()
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	if err := t.setObjTags(lom, tags); err != nil {
+		t.invalmsghdlrErr(w, r, err, http.StatusNotFound)
+	}
+}
+
+// DELETE s3/bckName/objName?tagging
+func (t *targetrunner) delObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom, err := t.s3LOM(w, r, items)
+	if err != nil {
+		return
+	}
+	if err := t.setObjTags(lom, nil); err != nil {
+		t.invalmsghdlrErr(w, r, err, http.StatusNotFound)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// replaces the tags of an existing object
+func (t *targetrunner) setObjTags(lom *cluster.LOM, tags url.Values) error {
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false); err != nil {
+		return err
+	}
+	s3compat.SetTags(lom, tags)
+	if err := lom.Persist(); err != nil {
+		return err
+	}
+	lom.ReCache()
+	return nil
+}
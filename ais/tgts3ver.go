@@ -0,0 +1,204 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/ais/s3compat"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/encrypt"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Object versions: the current version is the object itself, the previous
+// ones are kept by the target that stores the object (see cluster.LOM.KeepVersion)
+
+// GET s3/bckName?versions (intra-cluster)
+// Returns the previous versions of this target's objects; the proxy merges
+// them with the current versions (see proxyrunner.listObjVersionsS3).
+func (t *targetrunner) listObjVersionsS3(w http.ResponseWriter, r *http.Request, bucket string) {
+	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(t.owner.bmd, nil); err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	var (
+		query             = r.URL.Query()
+		prefix            = query.Get("prefix")
+		marker            = query.Get("key-marker")
+		entries           = make([]*cmn.BucketEntry, 0, 16)
+		availablePaths, _ = fs.Mountpaths.Get()
+	)
+	cb := func(fqn string, de fs.DirEntry) error {
+		if de.IsDir() {
+			return nil
+		}
+		vlom := &cluster.LOM{T: t, FQN: fqn}
+		if err := vlom.Init(bck.Bck); err != nil {
+			return nil
+		}
+		if err := vlom.FromFS(); err != nil || vlom.Version() == "" {
+			return nil
+		}
+		objName := strings.TrimSuffix(vlom.ObjName, "."+vlom.Version())
+		if objName == vlom.ObjName || !strings.HasPrefix(objName, prefix) || objName <= marker {
+			return nil
+		}
+		entry := &cmn.BucketEntry{
+			Name:    objName,
+			Size:    vlom.Size(),
+			Version: vlom.Version(),
+			Atime:   cmn.FormatUnixNano(vlom.AtimeUnix(), time.RFC3339),
+		}
+		if cksum := vlom.Cksum(); cksum != nil {
+			entry.Checksum = cksum.Value()
+		}
+		entries = append(entries, entry)
+		return nil
+	}
+	for _, mpathInfo := range availablePaths {
+		opts := &fs.Options{
+			Mpath:    mpathInfo,
+			Bck:      bck.Bck,
+			CTs:      []string{fs.ObjVersionType},
+			Callback: cb,
+		}
+		if err := fs.Walk(opts); err != nil {
+			t.invalmsghdlrErr(w, r, err)
+			return
+		}
+	}
+	t.writeJSON(w, r, entries, "list-versions")
+}
+
+// GET and HEAD s3/bckName/objName?versionId=...
+func (t *targetrunner) getObjVersionS3(w http.ResponseWriter, r *http.Request, items []string, versionID string) {
+	lom, err := t.s3LOM(w, r, items)
+	if err != nil {
+		return
+	}
+	lom.Lock(false)
+	if err = lom.Load(true); err == nil && s3compat.VersionID(lom) == versionID {
+		lom.Unlock(false)
+		// the current version
+		if r.Method == http.MethodHead {
+			t.headObjS3(w, r, items)
+		} else {
+			t.getObjS3(w, r, items)
+		}
+		return
+	}
+	defer lom.Unlock(false)
+	if err != nil && !cmn.IsObjNotExist(err) {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	vlom, err := lom.LoadVersion(versionID)
+	if err != nil {
+		if os.IsNotExist(err) {
+			t.invalmsghdlrstatusf(w, r, http.StatusNotFound, "%s version %q %s", lom, versionID, cmn.DoesNotExist)
+		} else {
+			t.invalmsghdlrErr(w, r, err)
+		}
+		return
+	}
+	s3compat.SetHeaderFromLOM(w.Header(), vlom, vlom.Size())
+	if r.Method == http.MethodHead {
+		return
+	}
+	file, err := os.Open(vlom.FQN)
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	defer file.Close()
+	objKey, err := encrypt.LoadObjKey(vlom.CustomMD())
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	if objKey == nil {
+		http.ServeContent(w, r, "", vlom.Atime(), file) // (ranges)
+		return
+	}
+	http.ServeContent(w, r, "", vlom.Atime(), io.NewSectionReader(objKey.ReaderAt(file), 0, vlom.Size()))
+}
+
+// DEL s3/bckName/objName?versionId=...
+// Deleting the current version makes the latest previous one (if any) current.
+func (t *targetrunner) delObjVersionS3(w http.ResponseWriter, r *http.Request, items []string, versionID string) {
+	lom, err := t.s3LOM(w, r, items)
+	if err != nil {
+		return
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err = lom.Load(false); err != nil && !cmn.IsObjNotExist(err) {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	if err != nil || s3compat.VersionID(lom) != versionID {
+		if err = lom.DelVersion(versionID); err != nil {
+			if os.IsNotExist(err) {
+				t.invalmsghdlrsilent(w, r, lom.String()+" version "+versionID+" "+cmn.DoesNotExist, http.StatusNotFound)
+			} else {
+				t.invalmsghdlrErr(w, r, err)
+			}
+		}
+		return
+	}
+	if err = lom.Remove(); err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	ec.ECM.CleanupObject(lom)
+	t.journal(lom, cmn.JournalDelete, "", t.requester(r))
+	if versions := lom.PrevVersions(); len(versions) > 0 {
+		if err = lom.RestoreVersion(versions[0]); err != nil {
+			t.invalmsghdlrErr(w, r, err)
+			return
+		}
+		t.putMirror(lom)
+		if err = ec.ECM.EncodeObject(lom); err != nil && err != ec.ErrorECDisabled {
+			t.invalmsghdlrErr(w, r, err)
+		}
+	}
+}
+
+// DEL s3/bckName/objName (versioning enabled)
+// The current version is kept as a previous one (S3 would insert a "delete marker").
+func (t *targetrunner) delObjKeepVersionS3(w http.ResponseWriter, r *http.Request, lom *cluster.LOM) {
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false); err != nil {
+		if cmn.IsObjNotExist(err) {
+			t.invalmsghdlrsilent(w, r, lom.String()+" "+cmn.DoesNotExist, http.StatusNotFound)
+		} else {
+			t.invalmsghdlrErr(w, r, err)
+		}
+		return
+	}
+	if lom.HasCopies() {
+		if err := lom.DelAllCopies(); err != nil {
+			t.invalmsghdlrErr(w, r, err)
+			return
+		}
+	}
+	if err := lom.KeepVersion(); err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	lom.PruneVersions(lom.VerConf().MaxVersions)
+	lom.Uncache()
+	ec.ECM.CleanupObject(lom)
+	t.journal(lom, cmn.JournalDelete, "", t.requester(r))
+}
@@ -47,7 +47,7 @@ func (t *targetrunner) httptar2tfget(w http.ResponseWriter, r *http.Request) {
 	bucket := apiItems[1]
 	bck, err = newBckFromQuery(bucket, query)
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
 		return
 	}
 
@@ -70,7 +70,7 @@ func (t *targetrunner) httptar2tfget(w http.ResponseWriter, r *http.Request) {
 			_, err = xaction.Registry.NewTar2TfXact(job, t, bck)
 		}
 		if err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 			return
 		}
 
@@ -96,7 +96,7 @@ func (t *targetrunner) httptar2tfget(w http.ResponseWriter, r *http.Request) {
 			})
 		}
 		if err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 			return
 		}
 
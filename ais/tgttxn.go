@@ -14,7 +14,9 @@ import (
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
 	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/xaction"
 	jsoniter "github.com/json-iterator/go"
@@ -33,13 +35,19 @@ type txnServerCtx struct {
 	callerID   string
 	bck        *cluster.Bck
 	query      url.Values
+	dryRun     bool // validate only (see cmn.URLParamDryRun)
 	t          *targetrunner
 }
 
 // verb /v1/txn
 func (t *targetrunner) txnHandler(w http.ResponseWriter, r *http.Request) {
 	// 1. check
-	if r.Method != http.MethodPost {
+	switch r.Method {
+	case http.MethodGet:
+		t.httpTxnGet(w, r)
+		return
+	case http.MethodPost:
+	default:
 		cmn.InvalidHandlerWithMsg(w, r, "invalid method for /txn path")
 		return
 	}
@@ -58,40 +66,62 @@ func (t *targetrunner) txnHandler(w http.ResponseWriter, r *http.Request) {
 	// 2. gather all context
 	c, err := t.prepTxnServer(r, msg, apiItems)
 	if err != nil {
-		t.invalmsghdlr(w, r, err.Error(), http.StatusBadRequest)
+		t.invalmsghdlrErr(w, r, err, http.StatusBadRequest)
+		return
+	}
+	if c.phase == cmn.ActCommit && t.transactions.committing(c.uuid) {
+		// (retried) commit must not run concurrently with itself
+		t.invalmsghdlrstatusf(w, r, http.StatusServiceUnavailable, "%s: txn[%s] is being committed", t.si, c.uuid)
 		return
 	}
 	// 3. do
 	switch msg.Action {
 	case cmn.ActCreateLB, cmn.ActRegisterCB:
 		if err = t.createBucket(c); err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 		}
 	case cmn.ActMakeNCopies:
 		if err = t.makeNCopies(c); err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 		}
 	case cmn.ActSetBprops, cmn.ActResetBprops:
 		if err = t.setBucketProps(c); err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
+		}
+	case cmn.ActSetBpropsBatch:
+		if err = t.setBucketPropsBatch(c); err != nil {
+			t.invalmsghdlrErr(w, r, err)
 		}
 	case cmn.ActRenameLB:
 		if err = t.renameBucket(c); err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
+		}
+	case cmn.ActSwapLB:
+		if err = t.swapBuckets(c); err != nil {
+			t.invalmsghdlrErr(w, r, err)
 		}
 	case cmn.ActCopyBucket:
 		if err = t.copyBucket(c); err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 		}
 	case cmn.ActECEncode:
 		if err = t.ecEncode(c); err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 		}
 	default:
 		t.invalmsghdlrf(w, r, fmtUnknownAct, msg)
 	}
 }
 
+// GET /v1/txn: the caller (new primary) queries the transactions it must resolve
+func (t *targetrunner) httpTxnGet(w http.ResponseWriter, r *http.Request) {
+	if _, err := t.checkRESTItems(w, r, 0, false, cmn.Version, cmn.Txn); err != nil {
+		return
+	}
+	records := t.transactions.orphaned(r.Header.Get(cmn.HeaderCallerID))
+	t.writeJSON(w, r, records, "txn-orphaned")
+}
+
 //////////////////
 // createBucket //
 //////////////////
@@ -159,6 +189,7 @@ func (t *targetrunner) makeNCopies(c *txnServerCtx) error {
 		if err != nil {
 			return fmt.Errorf("%s %s: %v", t.si, txn, err)
 		}
+		xact.SetTags(c.msg.Tags)
 
 		xaction.Registry.DoAbort(cmn.ActPutCopies, c.bck)
 
@@ -208,6 +239,9 @@ func (t *targetrunner) setBucketProps(c *txnServerCtx) error {
 		if nprops, err = t.validateNprops(c.bck, c.msg); err != nil {
 			return err
 		}
+		if c.dryRun {
+			return nil
+		}
 		txn := newTxnSetBucketProps(c, nprops)
 		if err := t.transactions.begin(txn); err != nil {
 			return err
@@ -230,6 +264,7 @@ func (t *targetrunner) setBucketProps(c *txnServerCtx) error {
 			if err != nil {
 				return fmt.Errorf("%s %s: %v", t.si, txn, err)
 			}
+			xact.SetTags(c.msg.Tags)
 			xaction.Registry.DoAbort(cmn.ActPutCopies, c.bck)
 
 			c.addNotif(xact) // notify upon completion
@@ -241,10 +276,18 @@ func (t *targetrunner) setBucketProps(c *txnServerCtx) error {
 			if err != nil {
 				return err
 			}
+			xact.SetTags(c.msg.Tags)
 
 			c.addNotif(xact) // ditto
 			go xact.Run()
 		}
+		if txnSetBprops.bprops.Placement != txnSetBprops.nprops.Placement {
+			// move the bucket's objects to the mountpaths that now abide by the placement
+			if availablePaths, _ := fs.Mountpaths.Get(); len(availablePaths) > 1 {
+				t.gfn.local.Activate()
+				go t.rebManager.RunResilver("", true /*skipGlobMisplaced*/)
+			}
+		}
 	default:
 		cmn.Assert(false)
 	}
@@ -252,14 +295,17 @@ func (t *targetrunner) setBucketProps(c *txnServerCtx) error {
 }
 
 func (t *targetrunner) validateNprops(bck *cluster.Bck, msg *aisMsg) (nprops *cmn.BucketProps, err error) {
-	var (
-		body    = cmn.MustMarshal(msg.Value)
-		capInfo = t.AvgCapUsed(cmn.GCO.Get())
-	)
+	body := cmn.MustMarshal(msg.Value)
 	nprops = &cmn.BucketProps{}
 	if err = jsoniter.Unmarshal(body, nprops); err != nil {
 		return
 	}
+	err = t.checkNprops(bck, nprops)
+	return
+}
+
+func (t *targetrunner) checkNprops(bck *cluster.Bck, nprops *cmn.BucketProps) (err error) {
+	capInfo := t.AvgCapUsed(cmn.GCO.Get())
 	if nprops.Mirror.Enabled {
 		mpathCount := fs.Mountpaths.NumAvail()
 		if int(nprops.Mirror.Copies) > mpathCount {
@@ -268,7 +314,7 @@ func (t *targetrunner) validateNprops(bck *cluster.Bck, msg *aisMsg) (nprops *cm
 			return
 		}
 		if nprops.Mirror.Copies > bck.Props.Mirror.Copies && capInfo.Err != nil {
-			return nprops, capInfo.Err
+			return capInfo.Err
 		}
 	}
 	if nprops.EC.Enabled && !bck.Props.EC.Enabled {
@@ -277,6 +323,53 @@ func (t *targetrunner) validateNprops(bck *cluster.Bck, msg *aisMsg) (nprops *cm
 	return
 }
 
+/////////////////////////
+// setBucketPropsBatch //
+/////////////////////////
+
+func (t *targetrunner) setBucketPropsBatch(c *txnServerCtx) error {
+	switch c.phase {
+	case cmn.ActBegin:
+		var (
+			entries []bpropsBatchEntry
+			body    = cmn.MustMarshal(c.msg.Value)
+		)
+		if err := jsoniter.Unmarshal(body, &entries); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			bck := cluster.NewBckEmbed(entry.Bck)
+			if err := bck.Init(t.owner.bmd, t.si); err != nil {
+				return err
+			}
+			if err := t.checkNprops(bck, entry.Props); err != nil {
+				return err
+			}
+		}
+		if c.dryRun {
+			return nil
+		}
+		txn := newTxnSetBpropsBatch(c, len(entries))
+		if err := t.transactions.begin(txn); err != nil {
+			return err
+		}
+	case cmn.ActAbort:
+		t.transactions.find(c.uuid, true /* remove */)
+	case cmn.ActCommit:
+		txn, err := t.transactions.find(c.uuid, false)
+		if err != nil {
+			return fmt.Errorf("%s %s: %v", t.si, txn, err)
+		}
+		// wait for newBMD w/timeout
+		if err = t.transactions.wait(txn, c.timeout); err != nil {
+			return fmt.Errorf("%s %s: %v", t.si, txn, err)
+		}
+	default:
+		cmn.Assert(false)
+	}
+	return nil
+}
+
 func remirror(bprops, nprops *cmn.BucketProps) bool {
 	if !bprops.Mirror.Enabled && nprops.Mirror.Enabled {
 		return true
@@ -402,6 +495,87 @@ func (t *targetrunner) validateBckRenTxn(bckFrom *cluster.Bck, msg *aisMsg) (bck
 	return
 }
 
+/////////////////
+// swapBuckets //
+/////////////////
+
+func (t *targetrunner) swapBuckets(c *txnServerCtx) error {
+	if err := c.bck.Init(t.owner.bmd, t.si); err != nil {
+		return err
+	}
+	switch c.phase {
+	case cmn.ActBegin:
+		bckB, err := t.validateBckSwapTxn(c.bck, c.msg)
+		if err != nil {
+			return err
+		}
+		txn := newTxnSwapBuckets(c, c.bck, bckB)
+		if err := t.transactions.begin(txn); err != nil {
+			return err
+		}
+	case cmn.ActAbort:
+		t.transactions.find(c.uuid, true /* remove */)
+	case cmn.ActCommit:
+		txn, err := t.transactions.find(c.uuid, false)
+		if err != nil {
+			return fmt.Errorf("%s %s: %v", t.si, txn, err)
+		}
+		txnSwap := txn.(*txnSwapBuckets)
+		// wait for newBMD w/timeout
+		if err = t.transactions.wait(txn, c.timeout); err != nil {
+			return fmt.Errorf("%s %s: %v", t.si, txn, err)
+		}
+		// (normally, done upon receiving the new BMD - see receiveBMD)
+		if err = txnSwap.swapDirs(); err != nil {
+			return err // must not happen at commit time
+		}
+		var (
+			availablePaths, _ = fs.Mountpaths.Get()
+			resilver          = c.msg.RMDVersion == 0 && len(availablePaths) > 1
+		)
+		switch {
+		case c.msg.RMDVersion != 0:
+			// rebalance and resilver are coming with the new RMD
+			t.gfn.local.Activate()
+			t.gfn.global.activateTimed()
+		case resilver:
+			t.gfn.local.Activate()
+		}
+		// resilver (if needed) and notify upon completion
+		xact, err := xaction.Registry.RenewBckSwap(c.uuid, c.msg.RMDVersion, txnSwap.bckA, txnSwap.bckB,
+			resilver, t.rebManager)
+		if err != nil {
+			return err // must not happen at commit time
+		}
+		c.addNotif(xact)
+		go xact.Run()
+	default:
+		cmn.Assert(false)
+	}
+	return nil
+}
+
+func (t *targetrunner) validateBckSwapTxn(bckA *cluster.Bck, msg *aisMsg) (bckB *cluster.Bck, err error) {
+	var (
+		bck  = &cmn.Bck{}
+		body = cmn.MustMarshal(msg.Value)
+	)
+	if err = jsoniter.Unmarshal(body, bck); err != nil {
+		return
+	}
+	if err = t.coExists(bckA, msg); err != nil {
+		return
+	}
+	bckB = cluster.NewBck(bck.Name, bck.Provider, bck.Ns)
+	if err = bckB.Init(t.owner.bmd, t.si); err != nil {
+		return
+	}
+	if !bckA.IsAIS() || !bckB.IsAIS() {
+		err = fmt.Errorf("%s: cannot swap %s and %s - both buckets must be ais", t.si, bckA, bckB)
+	}
+	return
+}
+
 ////////////////
 // copyBucket //
 ////////////////
@@ -445,6 +619,7 @@ func (t *targetrunner) copyBucket(c *txnServerCtx) error {
 		if err != nil {
 			return err
 		}
+		xact.SetTags(c.msg.Tags)
 
 		c.addNotif(xact) // notify upon completion
 		go xact.Run()
@@ -488,7 +663,7 @@ func (t *targetrunner) ecEncode(c *txnServerCtx) error {
 	switch c.phase {
 	case cmn.ActBegin:
 		err := t.validateEcEncode(c.bck, c.msg)
-		if err == nil {
+		if err == nil && !c.dryRun {
 			_, err = xaction.Registry.RenewECEncodeXact(t, c.bck, c.uuid, cmn.ActBegin)
 		}
 		if err != nil {
@@ -502,6 +677,8 @@ func (t *targetrunner) ecEncode(c *txnServerCtx) error {
 			glog.Error(err)
 			return err
 		}
+		xact.SetTags(c.msg.Tags)
+		c.addNotif(xact) // notify upon completion and periodically in-between
 		go xact.Run()
 
 	default:
@@ -510,6 +687,28 @@ func (t *targetrunner) ecEncode(c *txnServerCtx) error {
 	return nil
 }
 
+// restarts (upon the target's startup) the ecencode xactions that were
+// interrupted - they resume from their checkpoints (see ec.XactBckEncode)
+func (t *targetrunner) resumeECEncode() {
+	for !t.ClusterStarted() {
+		time.Sleep(time.Second)
+	}
+	bmd := t.owner.bmd.get()
+	bmd.Range(nil, nil, func(bck *cluster.Bck) bool {
+		if !bck.Props.EC.Enabled || !ec.HasEncodeCkpts(t.dbDriver, bck.Bck) {
+			return false
+		}
+		xact, err := xaction.Registry.RenewECEncodeXact(t, bck, cmn.GenUUID(), cmn.ActCommit)
+		if err != nil {
+			glog.Errorf("%s: failed to resume %s: %v", t.si, cmn.ActECEncode, err)
+			return false
+		}
+		glog.Infof("%s: resuming %s", t.si, xact)
+		go xact.Run()
+		return false
+	})
+}
+
 func (t *targetrunner) validateEcEncode(bck *cluster.Bck, msg *aisMsg) (err error) {
 	if capInfo := t.AvgCapUsed(cmn.GCO.Get()); capInfo.Err != nil {
 		return capInfo.Err
@@ -542,6 +741,7 @@ func (t *targetrunner) prepTxnServer(r *http.Request, msg *aisMsg, apiItems []st
 	}
 	c.timeout, err = cmn.S2Duration(query.Get(cmn.URLParamTxnTimeout))
 	c.query = query // operation-specific values, if any
+	c.dryRun = cmn.IsParseBool(query.Get(cmn.URLParamDryRun))
 
 	c.smapVer = t.owner.smap.get().version()
 	c.bmdVer = t.owner.bmd.get().version()
@@ -577,8 +777,50 @@ func (c *txnServerCtx) addNotif(xact cmn.Xact) {
 		}
 	}
 	xact.AddNotif(&cmn.NotifXact{
-		NotifBase: cmn.NotifBase{When: cmn.UponTerm, Dsts: dsts, F: c.xactCallerNotify},
+		NotifBase: cmn.NotifBase{When: cmn.UponTerm | cmn.UponProgress, Dsts: dsts, F: c.xactCallerNotify},
 	})
+	if period := cmn.GCO.Get().Periodic.NotifTime; period > 0 {
+		go c.xactNotifProgress(xact, dsts[0], period)
+	}
+}
+
+// periodically notifies the caller of the xaction's progress - until the xaction finishes;
+// the progress includes this target's estimate of the total amount of work
+// (see also notifFlagProgress)
+func (c *txnServerCtx) xactNotifProgress(xact cmn.Xact, pid string, period time.Duration) {
+	var (
+		ticker = time.NewTicker(period)
+		prog   = cmn.XactProgress{UUID: xact.ID().String(), Kind: xact.Kind(), Bck: xact.Bck()}
+	)
+	defer ticker.Stop()
+	prog.TotalObjs, prog.TotalBytes = estimateBckSize(c.bck)
+	for range ticker.C {
+		if xact.Finished() {
+			return
+		}
+		prog.Objects, prog.Bytes = xact.ObjCount(), xact.BytesCount()
+		msg := notifMsg{Ty: notifXact, Flags: notifFlagProgress, Snode: c.t.si, Data: cmn.MustMarshal(&prog)}
+		c.t.notify(pid, cmn.MustMarshal(&msg))
+	}
+}
+
+// estimates the number of objects in a given bucket on this target, and their total size
+func estimateBckSize(bck *cluster.Bck) (objs, size int64) {
+	availablePaths, _ := fs.Mountpaths.Get()
+	for _, mpathInfo := range availablePaths {
+		path := mpathInfo.MakePathCT(bck.Bck, fs.ObjectType)
+		if cnt, err := ios.GetFileCount(path); err == nil {
+			objs += int64(cnt)
+		}
+		if dirSize, err := ios.GetDirSize(path); err == nil {
+			size += int64(dirSize)
+		}
+	}
+	if bck.Props != nil && bck.Props.Mirror.Enabled && bck.Props.Mirror.Copies > 1 {
+		copies := int64(bck.Props.Mirror.Copies)
+		objs, size = objs/copies, size/copies
+	}
+	return
 }
 
 func (c *txnServerCtx) xactCallerNotify(n cmn.Notif, err error) {
@@ -0,0 +1,121 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/cluster"
+)
+
+// Wait-for-object
+//
+// HEAD object with cmn.URLParamWaitTimeout blocks until the object appears
+// in the cluster (or the timeout expires, or the client disconnects), thus
+// removing the need to poll for it. The target that (under HRW) stores the
+// object registers the waiter and wakes it up when the object gets PUT,
+// promoted, or otherwise stored (see putObjInfo.finalize).
+//
+// NOTE: the object that lands on a different target (e.g., due to a cluster
+// membership change in the meantime) does not wake the waiter - it times out.
+//
+// The requested timeout is capped at objWaitMaxTimeout - waiters hold both
+// an HTTP connection and a server goroutine.
+
+// upper bound on the time a single wait-for-object request can take
+const objWaitMaxTimeout = 10 * time.Minute
+
+type objWaiters struct {
+	mtx sync.Mutex
+	m   map[string][]chan struct{} // object uname => waiters
+	n   atomic.Int32               // number of waiters (fast path for notify)
+}
+
+func (ow *objWaiters) add(uname string) chan struct{} {
+	ch := make(chan struct{})
+	ow.mtx.Lock()
+	if ow.m == nil {
+		ow.m = make(map[string][]chan struct{}, 4)
+	}
+	ow.m[uname] = append(ow.m[uname], ch)
+	ow.n.Inc()
+	ow.mtx.Unlock()
+	return ch
+}
+
+func (ow *objWaiters) remove(uname string, ch chan struct{}) {
+	ow.mtx.Lock()
+	chans := ow.m[uname]
+	for i, c := range chans {
+		if c != ch {
+			continue
+		}
+		chans = append(chans[:i], chans[i+1:]...)
+		if len(chans) == 0 {
+			delete(ow.m, uname)
+		} else {
+			ow.m[uname] = chans
+		}
+		ow.n.Dec()
+		break
+	}
+	ow.mtx.Unlock()
+}
+
+// wakes up all the waiters for the object
+func (ow *objWaiters) notify(uname string) {
+	if ow.n.Load() == 0 {
+		return
+	}
+	ow.mtx.Lock()
+	chans := ow.m[uname]
+	delete(ow.m, uname)
+	ow.n.Sub(int32(len(chans)))
+	ow.mtx.Unlock()
+	for _, ch := range chans {
+		close(ch)
+	}
+}
+
+// waits for the object to appear; returns true if it has (in which case the
+// LOM is loaded)
+func (t *targetrunner) waitObj(ctx context.Context, lom *cluster.LOM, timeout time.Duration) bool {
+	ch := t.objWaiters.add(lom.Uname())
+	defer t.objWaiters.remove(lom.Uname(), ch)
+
+	// the object may have arrived before the waiter's registered
+	if t.loadObj(lom) {
+		return true
+	}
+	if timeout <= 0 {
+		return false
+	}
+	timer := time.NewTimer(objWaitTimeout(timeout))
+	defer timer.Stop()
+	select {
+	case <-ch:
+		return t.loadObj(lom)
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	return false
+}
+
+func objWaitTimeout(timeout time.Duration) time.Duration {
+	if timeout > objWaitMaxTimeout {
+		return objWaitMaxTimeout
+	}
+	return timeout
+}
+
+func (t *targetrunner) loadObj(lom *cluster.LOM) bool {
+	lom.Lock(false)
+	err := lom.Load(true)
+	lom.Unlock(false)
+	return err == nil
+}
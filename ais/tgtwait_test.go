@@ -0,0 +1,76 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestTransitWait(test *testing.T) {
+	tassert.Errorf(test, transitWait(nil) == 0, "must not wait for the object that no target has")
+	tassert.Errorf(test, transitWait(&cluster.Snode{DaemonID: "holder"}) == rebGetWaitTime,
+		"expected to wait for the object in transit")
+}
+
+func TestObjWaitTimeout(test *testing.T) {
+	tassert.Errorf(test, objWaitTimeout(time.Second) == time.Second, "expected the requested timeout")
+	tassert.Errorf(test, objWaitTimeout(24*time.Hour) == objWaitMaxTimeout,
+		"expected the timeout to be capped at %v, got %v", objWaitMaxTimeout, objWaitTimeout(24*time.Hour))
+}
+
+// NOTE: `t` is the target (see TestMain)
+func TestWaitObj(test *testing.T) {
+	const timeout = 100 * time.Millisecond
+	lom := &cluster.LOM{T: t, ObjName: "wait-obj"}
+	tassert.CheckFatal(test, lom.Init(cmn.Bck{Name: testBucket, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}))
+	defer os.Remove(lom.FQN)
+
+	// no wait - just checking
+	started := time.Now()
+	tassert.Errorf(test, !t.waitObj(context.Background(), lom, 0), "expected %s not to exist", lom)
+	tassert.Errorf(test, time.Since(started) < timeout, "must not wait, waited %v", time.Since(started))
+
+	// times out
+	started = time.Now()
+	tassert.Errorf(test, !t.waitObj(context.Background(), lom, timeout), "expected %s not to exist", lom)
+	tassert.Errorf(test, time.Since(started) >= timeout, "expected to wait %v, waited %v", timeout, time.Since(started))
+
+	// the client goes away
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	started = time.Now()
+	tassert.Errorf(test, !t.waitObj(ctx, lom, time.Minute), "expected %s not to exist", lom)
+	tassert.Errorf(test, time.Since(started) < time.Second, "must not wait, waited %v", time.Since(started))
+
+	// arrives
+	go func() {
+		time.Sleep(timeout / 2)
+		plom := &cluster.LOM{T: t, ObjName: lom.ObjName}
+		if err := plom.Init(lom.Bck().Bck); err != nil {
+			return
+		}
+		if err := cmn.CreateDir(filepath.Dir(plom.FQN)); err != nil {
+			return
+		}
+		if err := ioutil.WriteFile(plom.FQN, []byte("arrived"), 0644); err != nil {
+			return
+		}
+		plom.SetSize(int64(len("arrived")))
+		if err := plom.Persist(); err != nil {
+			return
+		}
+		t.objWaiters.notify(plom.Uname())
+	}()
+	tassert.Errorf(test, t.waitObj(context.Background(), lom, time.Minute), "expected %s to arrive", lom)
+}
@@ -48,7 +48,7 @@ func (t *targetrunner) xactHandler(w http.ResponseWriter, r *http.Request) {
 			}
 		}
 		xactQuery := xaction.RegistryXactFilter{
-			ID: xactMsg.ID, Kind: xactMsg.Kind, Bck: bck, OnlyRunning: xactMsg.OnlyRunning,
+			ID: xactMsg.ID, Kind: xactMsg.Kind, Bck: bck, OnlyRunning: xactMsg.OnlyRunning, Tags: xactMsg.Tags,
 		}
 		t.queryMatchingXact(w, r, what, xactQuery)
 	case http.MethodPut:
@@ -59,25 +59,29 @@ func (t *targetrunner) xactHandler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if err := cmn.MorphMarshal(msg.Value, &xactMsg); err != nil {
-			t.invalmsghdlr(w, r, err.Error())
+			t.invalmsghdlrErr(w, r, err)
 			return
 		}
 		if !xactMsg.Bck.IsEmpty() {
 			bck = cluster.NewBckEmbed(xactMsg.Bck)
 			if err := bck.Init(t.owner.bmd, t.si); err != nil {
-				t.invalmsghdlr(w, r, err.Error())
+				t.invalmsghdlrErr(w, r, err)
 				return
 			}
 		}
 		switch msg.Action {
 		case cmn.ActXactStart:
 			if err := t.cmdXactStart(xactMsg, bck); err != nil {
-				t.invalmsghdlr(w, r, err.Error())
+				t.invalmsghdlrErr(w, r, err)
 				return
 			}
 		case cmn.ActXactStop:
 			xaction.Registry.DoAbort(xactMsg.Kind, bck)
 			return
+		case cmn.ActXactPause, cmn.ActXactResume:
+			if _, err := xaction.Registry.DoPause(xactMsg.Kind, bck, msg.Action == cmn.ActXactPause); err != nil {
+				t.invalmsghdlrErr(w, r, err)
+			}
 		default:
 			t.invalmsghdlrf(w, r, fmtUnknownAct, msg)
 		}
@@ -113,7 +117,7 @@ func (t *targetrunner) queryMatchingXact(w http.ResponseWriter, r *http.Request,
 	if _, ok := err.(cmn.XactionNotFoundError); ok {
 		t.invalmsghdlrsilent(w, r, err.Error(), http.StatusNotFound)
 	} else {
-		t.invalmsghdlr(w, r, err.Error())
+		t.invalmsghdlrErr(w, r, err)
 	}
 }
 
@@ -145,11 +149,21 @@ func (t *targetrunner) cmdXactStart(xactMsg cmn.XactReqMsg, bck *cluster.Bck) er
 		if err != nil {
 			return err
 		}
+		xact.SetTags(xactMsg.Tags)
+		go xact.Run()
+	case cmn.ActECMetaMigrate:
+		if bck == nil {
+			return fmt.Errorf(erfmn, xactMsg.Kind)
+		}
+		xact, err := xaction.Registry.RenewECMetaMigrateXact(t, bck, xactMsg.ID)
+		if err != nil {
+			return err
+		}
 		go xact.Run()
 	// 3. cannot start
 	case cmn.ActPutCopies:
 		return fmt.Errorf("cannot start xaction %q - it is invoked automatically by PUTs into mirrored bucket", xactMsg.Kind)
-	case cmn.ActDownload, cmn.ActEvictObjects, cmn.ActDelete, cmn.ActMakeNCopies, cmn.ActECEncode:
+	case cmn.ActDownload, cmn.ActEvictObjects, cmn.ActDelete, cmn.ActMakeNCopies, cmn.ActECEncode, cmn.ActECRestore:
 		return fmt.Errorf("initiating xaction %q must be done via a separate documented API", xactMsg.Kind)
 	// 4. unknown
 	case "":
@@ -0,0 +1,190 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/housekeep/hk"
+	"github.com/NVIDIA/aistore/xaction"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Xaction history
+//
+// Each target records its finished xactions (see cmn.XactRecord) in its DB, so
+// that the records survive both the registry cleanup and restarts. Every
+// xhistInterval (and, finally, upon shutdown) the target looks up the newly
+// finished xactions in the registry and records them; the records older than
+// `xaction_history.retention` get removed, as do the oldest records in excess
+// of `xaction_history.max_entries`. The keys start with the (zero-padded) end
+// time, so that the records sort in the order the xactions finished.
+
+const (
+	xhistCollection   = "xhistory"
+	xhistName         = "xaction-history"
+	xhistInterval     = 30 * time.Second
+	xhistIdleInterval = time.Minute // when disabled
+)
+
+type xactHistory struct {
+	mtx      sync.Mutex
+	t        *targetrunner
+	recorded cmn.StringSet // finished xactions (see xhistID) that are already recorded
+}
+
+func (h *xactHistory) init(t *targetrunner) {
+	h.t = t
+	h.recorded = make(cmn.StringSet, 16)
+	hk.Housekeeper.Register(xhistName, h.housekeep, xhistInterval)
+}
+
+// (the IDs of the demand xactions are empty)
+func xhistID(xact cmn.Xact) string {
+	return fmt.Sprintf("%s-%s-%d", xact.Kind(), xact.ID(), xact.StartTime().UnixNano())
+}
+
+func xhistKey(rec *cmn.XactRecord, id string) string {
+	return fmt.Sprintf("%019d-%s", rec.EndTimeX.UnixNano(), id)
+}
+
+func (h *xactHistory) housekeep() time.Duration {
+	conf := &cmn.GCO.Get().XactHistory
+	if conf.Retention == 0 {
+		return xhistIdleInterval
+	}
+	h.record()
+	h.trim(conf)
+	return xhistInterval
+}
+
+func (h *xactHistory) record() {
+	var (
+		db       = h.t.dbDriver
+		finished = xaction.Registry.GetFinished()
+		current  = make(cmn.StringSet, len(finished))
+	)
+	if cmn.GCO.Get().XactHistory.Retention == 0 {
+		return
+	}
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	for _, xact := range finished {
+		if xact.Kind() == cmn.ActListObjects {
+			continue
+		}
+		id := xhistID(xact)
+		current.Add(id)
+		if h.recorded.Contains(id) {
+			continue
+		}
+		stats := xact.Stats()
+		rec := &cmn.XactRecord{
+			BaseXactStats: cmn.BaseXactStats{
+				IDX:         stats.ID(),
+				KindX:       stats.Kind(),
+				BckX:        stats.Bck(),
+				StartTimeX:  stats.StartTime(),
+				EndTimeX:    stats.EndTime(),
+				ObjCountX:   stats.ObjCount(),
+				BytesCountX: stats.BytesCount(),
+				AbortedX:    stats.Aborted(),
+				TagsX:       stats.Tags(),
+			},
+			Target: h.t.si.ID(),
+		}
+		if err := xact.Err(); err != nil {
+			rec.Err = err.Error()
+		}
+		if err := db.Set(xhistCollection, xhistKey(rec, id), rec); err != nil {
+			glog.Errorf("%s: failed to record %s: %v", h.t.si, xact, err)
+			continue
+		}
+		h.recorded.Add(id)
+	}
+	// forget the xactions that are no longer in the registry
+	for id := range h.recorded {
+		if !current.Contains(id) {
+			delete(h.recorded, id)
+		}
+	}
+}
+
+func (h *xactHistory) trim(conf *cmn.XactHistoryConf) {
+	db := h.t.dbDriver
+	keys, err := db.List(xhistCollection, "")
+	if err != nil {
+		if !dbdriver.IsErrNotFound(err) {
+			glog.Errorf("%s: failed to list xaction history: %v", h.t.si, err)
+		}
+		return
+	}
+	sort.Strings(keys)
+	cutoff := fmt.Sprintf("%019d", time.Now().Add(-conf.Retention).UnixNano())
+	n := sort.SearchStrings(keys, cutoff)
+	if conf.MaxEntries > 0 && len(keys)-n > conf.MaxEntries {
+		n = len(keys) - conf.MaxEntries
+	}
+	for _, key := range keys[:n] {
+		if err := db.Delete(xhistCollection, key); err != nil && !dbdriver.IsErrNotFound(err) {
+			glog.Errorf("%s: failed to trim xaction history: %v", h.t.si, err)
+			return
+		}
+	}
+}
+
+// returns the records that match a given xaction ID, kind, and bucket (if
+// specified), in the order the xactions finished
+func (h *xactHistory) query(msg *cmn.XactReqMsg) ([]*cmn.XactRecord, error) {
+	values, err := h.t.dbDriver.GetAll(xhistCollection, "")
+	if err != nil && !dbdriver.IsErrNotFound(err) {
+		return nil, err
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	recs := make([]*cmn.XactRecord, 0, len(keys))
+	for _, key := range keys {
+		rec := &cmn.XactRecord{}
+		if err := jsoniter.UnmarshalFromString(values[key], rec); err != nil {
+			glog.Errorf("%s: damaged xaction history record %q: %v", h.t.si, key, err)
+			continue
+		}
+		if msg.ID != "" && rec.IDX != msg.ID {
+			continue
+		}
+		if msg.Kind != "" && rec.KindX != msg.Kind {
+			continue
+		}
+		if !msg.Bck.IsEmpty() && !rec.BckX.Equal(msg.Bck) {
+			continue
+		}
+		recs = append(recs, rec)
+	}
+	return recs, nil
+}
+
+func (t *targetrunner) queryXactHistory(w http.ResponseWriter, r *http.Request, what string) {
+	msg := &cmn.XactReqMsg{}
+	if cmn.ReadJSON(w, r, msg, true /*optional*/) != nil {
+		return
+	}
+	t.xhistory.record() // (include the ones that have just finished)
+	recs, err := t.xhistory.query(msg)
+	if err != nil {
+		t.invalmsghdlrErr(w, r, err)
+		return
+	}
+	t.writeJSON(w, r, recs, what)
+}
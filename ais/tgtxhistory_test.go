@@ -0,0 +1,83 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestXactHistory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "xhistory")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(dir)
+	db, err := dbdriver.NewLogDriver(filepath.Join(dir, ".ais.kvlog"))
+	tassert.CheckFatal(t, err)
+	defer db.Close()
+
+	var (
+		tgt  = &targetrunner{dbDriver: db}
+		h    = &xactHistory{t: tgt, recorded: make(cmn.StringSet)}
+		now  = time.Now()
+		bck  = cmn.Bck{Name: "xhist", Provider: cmn.ProviderAIS}
+		conf = &cmn.XactHistoryConf{Retention: time.Hour, MaxEntries: 3}
+		recs = []*cmn.XactRecord{
+			{BaseXactStats: cmn.BaseXactStats{IDX: "old", KindX: cmn.ActLRU, EndTimeX: now.Add(-2 * time.Hour)}},
+			{BaseXactStats: cmn.BaseXactStats{IDX: "x1", KindX: cmn.ActLRU, EndTimeX: now.Add(-4 * time.Minute)}},
+			{BaseXactStats: cmn.BaseXactStats{IDX: "x2", KindX: cmn.ActCopyBucket, BckX: bck,
+				EndTimeX: now.Add(-3 * time.Minute)}},
+			{BaseXactStats: cmn.BaseXactStats{IDX: "x4", KindX: cmn.ActECEncode, BckX: bck,
+				EndTimeX: now.Add(-time.Minute)}, Err: "failed"},
+			{BaseXactStats: cmn.BaseXactStats{IDX: "x3", KindX: cmn.ActLRU, EndTimeX: now.Add(-2 * time.Minute)}},
+		}
+	)
+	tgt.si = newSnode("target", httpProto, cmn.Target, &net.TCPAddr{}, &net.TCPAddr{}, &net.TCPAddr{})
+	for _, rec := range recs {
+		tassert.CheckFatal(t, db.Set(xhistCollection, xhistKey(rec, rec.IDX), rec))
+	}
+	tassert.CheckFatal(t, db.SetString(xhistCollection, xhistKey(recs[1], "damaged"), "{damaged"))
+
+	ids := func(msg *cmn.XactReqMsg) (ids []string) {
+		recs, err := h.query(msg)
+		tassert.CheckFatal(t, err)
+		for _, rec := range recs {
+			ids = append(ids, rec.IDX)
+		}
+		return
+	}
+	check := func(msg *cmn.XactReqMsg, expected ...string) {
+		got := ids(msg)
+		tassert.Errorf(t, len(got) == len(expected), "%+v: expected %v, got %v", msg, expected, got)
+		for i := 0; i < len(got) && i < len(expected); i++ {
+			tassert.Errorf(t, got[i] == expected[i], "%+v: expected %v, got %v", msg, expected, got)
+		}
+	}
+
+	// in the order the xactions finished; the damaged record is skipped
+	check(&cmn.XactReqMsg{}, "old", "x1", "x2", "x3", "x4")
+	check(&cmn.XactReqMsg{Kind: cmn.ActLRU}, "old", "x1", "x3")
+	check(&cmn.XactReqMsg{Bck: bck}, "x2", "x4")
+	check(&cmn.XactReqMsg{ID: "x4"}, "x4")
+	check(&cmn.XactReqMsg{ID: "x4", Kind: cmn.ActLRU})
+
+	// past the retention, and then the oldest in excess of max_entries
+	h.trim(conf)
+	check(&cmn.XactReqMsg{}, "x2", "x3", "x4")
+	recs, err = h.query(&cmn.XactReqMsg{ID: "x4"})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(recs) == 1 && recs[0].Err == "failed", "unexpected records %+v", recs)
+
+	// unlimited
+	h.trim(&cmn.XactHistoryConf{Retention: time.Hour})
+	check(&cmn.XactReqMsg{}, "x2", "x3", "x4")
+}
@@ -7,18 +7,22 @@ package ais
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/housekeep/hk"
 )
 
 const (
 	txnsTimeoutGC = time.Hour
 	txnsNumKeep   = 16
+
+	txnNotFound = "doesn't exist" // NOTE: the primary retrying commit relies on it (see txnCommit)
 )
 
 type (
@@ -27,8 +31,10 @@ type (
 		uuid() string
 		started(phase string, tm ...time.Time) time.Time
 		timeout() time.Duration
+		caller() string
 		String() string
 		isDone() (done bool, err error)
+		record() *txnRecord
 		// triggers
 		commitAfter(caller string, msg *aisMsg, err error, args ...interface{}) (bool, error)
 		rsvp(err error)
@@ -59,6 +65,10 @@ type (
 		callerName string
 		callerID   string
 		err        *txnError
+		// the original request (see orphaned)
+		path  string
+		query url.Values
+		body  []byte
 	}
 	txnBckBase struct {
 		txnBase
@@ -83,6 +93,10 @@ type (
 		bprops *cmn.BucketProps
 		nprops *cmn.BucketProps
 	}
+	txnSetBpropsBatch struct {
+		txnBckBase
+		n int // number of buckets
+	}
 	txnRenameBucket struct {
 		txnBckBase
 		bckFrom *cluster.Bck
@@ -93,6 +107,12 @@ type (
 		bckFrom *cluster.Bck
 		bckTo   *cluster.Bck
 	}
+	txnSwapBuckets struct {
+		txnBckBase
+		bckA    *cluster.Bck
+		bckB    *cluster.Bck
+		swapped bool // the buckets' directories (see swapDirs)
+	}
 )
 
 //////////////////
@@ -121,7 +141,7 @@ func (txns *transactions) find(uuid string, remove bool) (txn txn, err error) {
 	var ok bool
 	txns.Lock()
 	if txn, ok = txns.m[uuid]; !ok {
-		err = fmt.Errorf("%s: Txn[%s] doesn't exist (aborted?)", txns.t.si, uuid)
+		err = fmt.Errorf("%s: Txn[%s] %s (aborted?)", txns.t.si, uuid, txnNotFound)
 	} else if remove {
 		delete(txns.m, uuid)
 		delete(txns.rendezvous, uuid)
@@ -130,6 +150,15 @@ func (txns *transactions) find(uuid string, remove bool) (txn txn, err error) {
 	return
 }
 
+// the commit is in progress (see wait) - e.g., when the primary retries
+// the commit that it has given up waiting for
+func (txns *transactions) committing(uuid string) bool {
+	txns.RLock()
+	txn, ok := txns.m[uuid]
+	txns.RUnlock()
+	return ok && !txn.started(cmn.ActCommit).IsZero()
+}
+
 func (txns *transactions) commitBefore(caller string, msg *aisMsg) error {
 	var (
 		rndzvs rndzvs
@@ -222,6 +251,41 @@ func (txns *transactions) wait(txn txn, timeout time.Duration) (err error) {
 	return
 }
 
+// the transactions begun by other than the given (new) primary and not committed -
+// for the latter to resolve (see recoverTxns)
+func (txns *transactions) orphaned(primaryID string) (records []*txnRecord) {
+	txns.RLock()
+	for uuid, txn := range txns.m {
+		if txn.caller() == primaryID || !txn.started(cmn.ActCommit).IsZero() {
+			continue
+		}
+		rec := txn.record()
+		if _, ok := txns.rendezvous[uuid]; ok {
+			rec.Phase = txnPhaseMetasync // has received the updated BMD
+		}
+		records = append(records, rec)
+	}
+	txns.RUnlock()
+	return
+}
+
+// abort the transactions that the former primary has begun but not committed
+// (the ones that have already received the updated BMD excepted)
+func (txns *transactions) abortOrphaned(callerID string) {
+	txns.Lock()
+	for uuid, txn := range txns.m {
+		if txn.caller() != callerID || !txn.started(cmn.ActCommit).IsZero() {
+			continue
+		}
+		if _, ok := txns.rendezvous[uuid]; ok {
+			continue
+		}
+		glog.Warningf("%s: aborting orphaned %s", txns.t.si, txn)
+		delete(txns.m, uuid)
+	}
+	txns.Unlock()
+}
+
 // GC orphaned transactions //
 func (txns *transactions) garbageCollect() (d time.Duration) {
 	var errs, uids []string
@@ -271,6 +335,7 @@ func (txns *transactions) garbageCollect() (d time.Duration) {
 
 func (txn *txnBase) uuid() string           { return txn.uid }
 func (txn *txnBase) timeout() time.Duration { return txn.tout }
+func (txn *txnBase) caller() string         { return txn.callerID }
 func (txn *txnBase) started(phase string, tm ...time.Time) (ts time.Time) {
 	switch phase {
 	case cmn.ActBegin:
@@ -313,6 +378,21 @@ func (txn *txnBase) fillFromCtx(c *txnServerCtx) {
 	txn.bmdVer = c.bmdVer
 	txn.callerName = c.callerName
 	txn.callerID = c.callerID
+	txn.path = cmn.URLPath(cmn.Version, cmn.Txn, c.bck.Name)
+	txn.query = c.query
+	txn.body = cmn.MustMarshal(c.msg)
+}
+
+func (txn *txnBase) record() *txnRecord {
+	return &txnRecord{
+		UUID:    txn.uid,
+		Action:  txn.action,
+		Phase:   txnPhaseBegin,
+		Path:    txn.path,
+		Query:   txn.query,
+		Body:    txn.body,
+		Started: txn.started(cmn.ActBegin).UnixNano(),
+	}
 }
 
 ////////////////
@@ -411,6 +491,27 @@ func newTxnSetBucketProps(c *txnServerCtx, nprops *cmn.BucketProps) (txn *txnSet
 	return
 }
 
+///////////////////////
+// txnSetBpropsBatch //
+///////////////////////
+
+var _ txn = &txnSetBpropsBatch{}
+
+// c-tor
+func newTxnSetBpropsBatch(c *txnServerCtx, n int) (txn *txnSetBpropsBatch) {
+	txn = &txnSetBpropsBatch{
+		txnBckBase{txnBase{kind: "sbb"}, *c.bck},
+		n,
+	}
+	txn.fillFromCtx(c)
+	return
+}
+
+func (txn *txnSetBpropsBatch) String() string {
+	s := txn.txnBckBase.String()
+	return fmt.Sprintf("%s, buckets %d", s, txn.n)
+}
+
 /////////////////////
 // txnRenameBucket //
 /////////////////////
@@ -428,6 +529,39 @@ func newTxnRenameBucket(c *txnServerCtx, bckFrom, bckTo *cluster.Bck) (txn *txnR
 	return
 }
 
+////////////////////
+// txnSwapBuckets //
+////////////////////
+
+var _ txn = &txnSwapBuckets{}
+
+// c-tor
+func newTxnSwapBuckets(c *txnServerCtx, bckA, bckB *cluster.Bck) (txn *txnSwapBuckets) {
+	txn = &txnSwapBuckets{
+		txnBckBase: txnBckBase{txnBase{kind: "swb"}, *bckA},
+		bckA:       bckA,
+		bckB:       bckB,
+	}
+	txn.fillFromCtx(c)
+	return
+}
+
+// exchanges the content of the buckets - once. Must be done before the new
+// BMD (that exchanges their props) takes effect: otherwise, in between, the
+// objects of one bucket would be accessed by the name of the other
+func (txn *txnSwapBuckets) swapDirs() error {
+	txn.Lock()
+	defer txn.Unlock()
+	if txn.swapped {
+		return nil
+	}
+	if err := fs.Mountpaths.SwapBucketDirs(txn.bckA.Bck, txn.bckB.Bck); err != nil {
+		return err
+	}
+	txn.swapped = true
+	return nil
+}
+
 ///////////////////
 // txnCopyBucket //
 ///////////////////
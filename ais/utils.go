@@ -12,139 +12,126 @@ import (
 	"strings"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
 )
 
 //===========================================================================
 //
-// IPV4
+// IP addresses (IPv4 and IPv6)
 //
 //===========================================================================
 
 // Local unicast IP info
-type localIPv4Info struct {
-	ipv4 string
-	mtu  int
+type localIPInfo struct {
+	ip  net.IP
+	mtu int
 }
 
-// getLocalIPv4List returns a list of local unicast IPv4 with MTU
-func getLocalIPv4List() (addrlist []*localIPv4Info, err error) {
-	addrlist = make([]*localIPv4Info, 0, 4)
-	addrs, e := net.InterfaceAddrs()
-	if e != nil {
-		err = fmt.Errorf("failed to get host unicast IPs, err: %v", e)
-		return
+func (info *localIPInfo) String() string { return fmt.Sprintf("%s (MTU %d)", info.ip, info.mtu) }
+
+func ipFamily(ip net.IP) string {
+	if ip.To4() != nil {
+		return cmn.IPFamilyIPv4
 	}
+	return cmn.IPFamilyIPv6
+}
+
+// getLocalIPList returns a list of local unicast IPs (both IPv4 and IPv6) with MTU;
+// link-local IPv6 addresses are skipped as they require a zone (interface)
+// to be usable
+func getLocalIPList() (addrlist []*localIPInfo, err error) {
+	addrlist = make([]*localIPInfo, 0, 4)
 	iflist, e := net.Interfaces()
 	if e != nil {
 		err = fmt.Errorf("failed to get interface list: %v", e)
 		return
 	}
-
-	for _, addr := range addrs {
-		curr := &localIPv4Info{}
-		if ipnet, ok := addr.(*net.IPNet); ok && !ipnet.IP.IsLoopback() {
-			if ipnet.IP.To4() != nil {
-				curr.ipv4 = ipnet.IP.String()
-			}
-		}
-		if curr.ipv4 == "" {
+	for _, intf := range iflist {
+		ifAddrs, e := intf.Addrs()
+		// skip invalid interfaces
+		if e != nil {
 			continue
 		}
-
-		for _, intf := range iflist {
-			ifAddrs, e := intf.Addrs()
-			// skip invalid interfaces
-			if e != nil {
+		for _, ifAddr := range ifAddrs {
+			ipnet, ok := ifAddr.(*net.IPNet)
+			if !ok || ipnet.IP.IsLoopback() || ipnet.IP.IsLinkLocalUnicast() {
 				continue
 			}
-			for _, ifAddr := range ifAddrs {
-				if ipnet, ok := ifAddr.(*net.IPNet); ok && ipnet.IP.To4() != nil && ipnet.IP.String() == curr.ipv4 {
-					curr.mtu = intf.MTU
-					addrlist = append(addrlist, curr)
-					break
-				}
-			}
-			if curr.mtu != 0 {
-				break
-			}
+			addrlist = append(addrlist, &localIPInfo{ip: ipnet.IP, mtu: intf.MTU})
 		}
 	}
-
 	if len(addrlist) == 0 {
-		return addrlist, fmt.Errorf("the host does not have any IPv4 addresses")
+		return addrlist, fmt.Errorf("the host does not have any IP addresses")
 	}
-
 	return addrlist, nil
 }
 
-// selectConfiguredIPv4 returns the first IPv4 from a preconfigured IPv4 list that
-// matches any local unicast IPv4
-func selectConfiguredIPv4(addrlist []*localIPv4Info, configuredList []string) (ipv4addr string, err error) {
-	glog.Infof("Selecting one of the configured IPv4 addresses: %s...\n", configuredList)
+// selectConfiguredIP returns the first IP from a preconfigured IP list that
+// matches any local unicast IP
+func selectConfiguredIP(addrlist []*localIPInfo, configuredList []string) (ip net.IP, err error) {
+	glog.Infof("Selecting one of the configured IP addresses: %s...\n", configuredList)
 	localList := ""
 
 	for _, localaddr := range addrlist {
-		localList += " " + localaddr.ipv4
-		for _, ipv4 := range configuredList {
-			if localaddr.ipv4 == strings.TrimSpace(ipv4) {
-				glog.Warningf("Selected IPv4 %s from the configuration file\n", ipv4)
-				return ipv4, nil
+		localList += " " + localaddr.ip.String()
+		for _, addr := range configuredList {
+			if localaddr.ip.Equal(net.ParseIP(strings.TrimSpace(addr))) {
+				glog.Warningf("Selected IP %s from the configuration file\n", addr)
+				return localaddr.ip, nil
 			}
 		}
 	}
 
-	glog.Errorf("Configured IPv4 does not match any local one.\nLocal IPv4 list:%s; Configured ip: %s\n", localList, configuredList)
-	return "", fmt.Errorf("configured IPv4 does not match any local one")
+	glog.Errorf("Configured IP does not match any local one.\nLocal IP list:%s; Configured ip: %s\n", localList, configuredList)
+	return nil, fmt.Errorf("configured IP does not match any local one")
 }
 
-// detectLocalIPv4 takes a list of local IPv4s and returns the best fit for a deamon to listen on it
-func detectLocalIPv4(addrlist []*localIPv4Info) (ip net.IP, err error) {
+// detectLocalIP takes a list of local IPs and returns the best fit for a deamon to listen on it:
+// an IP of the preferred family, if available, or any other IP otherwise
+func detectLocalIP(addrlist []*localIPInfo, family string) (ip net.IP, err error) {
 	if len(addrlist) == 0 {
 		return nil, fmt.Errorf("no addresses to choose from")
-	} else if len(addrlist) == 1 {
-		msg := fmt.Sprintf("Found only one IPv4: %s, MTU %d", addrlist[0].ipv4, addrlist[0].mtu)
-		glog.Info(msg)
-		if addrlist[0].mtu <= 1500 {
-			glog.Warningf("IPv4 %s MTU size is small: %d\n", addrlist[0].ipv4, addrlist[0].mtu)
+	}
+	preferred := make([]*localIPInfo, 0, len(addrlist))
+	for _, info := range addrlist {
+		if ipFamily(info.ip) == family {
+			preferred = append(preferred, info)
 		}
-		ip = net.ParseIP(addrlist[0].ipv4)
-		if ip == nil {
-			return nil, fmt.Errorf("failed to parse IP address: %s", addrlist[0].ipv4)
+	}
+	if len(preferred) == 0 {
+		glog.Warningf("No %s addresses available, falling back to %s", family, ipFamily(addrlist[0].ip))
+		preferred = addrlist
+	}
+	if len(preferred) == 1 {
+		glog.Infof("Found only one %s: %s", family, preferred[0])
+		if preferred[0].mtu <= 1500 {
+			glog.Warningf("IP %s MTU size is small: %d\n", preferred[0].ip, preferred[0].mtu)
 		}
-		return ip, nil
+		return preferred[0].ip, nil
 	}
 
-	glog.Warningf("Warning: %d IPv4s available", len(addrlist))
-	for _, intf := range addrlist {
-		glog.Warningf("    %#v\n", *intf)
+	glog.Warningf("Warning: %d IPs available", len(preferred))
+	for _, info := range preferred {
+		glog.Warningf("    %s\n", info)
 	}
 	// FIXME: temp hack - make sure to keep working on laptops with dockers
-	ip = net.ParseIP(addrlist[0].ipv4)
-	if ip == nil {
-		return nil, fmt.Errorf("failed to parse IP address: %s", addrlist[0].ipv4)
-	}
-	return ip, nil
+	return preferred[0].ip, nil
 }
 
-// getIPv4 returns an IPv4 for proxy/target to listen on it.
-// 1. If there is an IPv4 in config - it tries to use it
-// 2. If config does not contain IPv4 - it chooses one of local IPv4s
-func getIPv4(addrList []*localIPv4Info, configuredIPv4s string) (ip net.IP, err error) {
-	if configuredIPv4s == "" {
-		return detectLocalIPv4(addrList)
-	}
-
-	configuredList := strings.Split(configuredIPv4s, ",")
-	selectedIPv4, err := selectConfiguredIPv4(addrList, configuredList)
-	if err != nil {
-		return nil, err
+// getIP returns an IP for proxy/target to listen on it.
+// 1. If there are IPs in config - it tries to use one of them
+// 2. If config does not contain IPs - it chooses one of local IPs, preferably
+//    of the configured family (IPv4 by default)
+func getIP(addrList []*localIPInfo, configuredIPs, family string) (ip net.IP, err error) {
+	if configuredIPs == "" {
+		if family == "" {
+			family = cmn.IPFamilyIPv4
+		}
+		return detectLocalIP(addrList, family)
 	}
 
-	ip = net.ParseIP(selectedIPv4)
-	if ip == nil {
-		return nil, fmt.Errorf("failed to parse ip %s", selectedIPv4)
-	}
-	return ip, nil
+	configuredList := strings.Split(configuredIPs, ",")
+	return selectConfiguredIP(addrList, configuredList)
 }
 
 // FIXME: usage
@@ -143,7 +143,7 @@ func (h *httprunner) httpproxyvote(w http.ResponseWriter, r *http.Request) {
 
 	vote, err := h.voteOnProxy(psi.ID(), currPrimaryID)
 	if err != nil {
-		h.invalmsghdlr(w, r, err.Error())
+		h.invalmsghdlrErr(w, r, err)
 		return
 	}
 	if glog.FastV(4, glog.SmoduleAIS) {
@@ -191,7 +191,7 @@ func (h *httprunner) httpsetprimaryproxy(w http.ResponseWriter, r *http.Request)
 		return nil
 	})
 	if err != nil {
-		h.invalmsghdlr(w, r, err.Error())
+		h.invalmsghdlrErr(w, r, err)
 	}
 }
 
@@ -0,0 +1,253 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/housekeep/hk"
+)
+
+// Mounted config and secrets
+//
+// In Kubernetes, the node's config, its TLS certificate and key, and the cloud
+// credentials are typically mounted from ConfigMaps and Secrets that get updated
+// in place (by way of the atomic symlink swap) when the latter change. Given
+// `-config_watch=<period>`, the node periodically checks the respective files
+// and, upon change, reloads:
+// - the config (see jsp.ReloadConfig) - except network, mountpaths, and log dir;
+// - the TLS certificate (see tlsCert) - new connections get the new one;
+// - the secrets (see `-secrets_dir` and loadSecrets) - cloud providers that
+//   (re)create their sessions per request (AWS, GCP) pick up the new credentials.
+
+const watcherName = "config-watcher"
+
+type (
+	watchedFile struct {
+		path   string
+		sig    string
+		reload func() error
+	}
+	fileWatcher struct {
+		files  []*watchedFile
+		period time.Duration
+	}
+	// TLS certificate that can be replaced at runtime (see tls.Config.GetCertificate)
+	tlsCert struct {
+		certFile, keyFile string
+		cert              atomic.Pointer
+	}
+)
+
+var (
+	// TLS certificates of the HTTPS listeners (see cmn.ListenersConf), by certificate file
+	tlsCerts = make(map[string]*tlsCert, 1)
+	// environment variables set by loadSecrets
+	secretEnv = make(cmn.StringSet)
+)
+
+/////////////////
+// fileWatcher //
+/////////////////
+
+func newFileWatcher(period time.Duration) *fileWatcher {
+	return &fileWatcher{period: period}
+}
+
+func (w *fileWatcher) add(path string, reload func() error) {
+	sig, err := fileSig(path)
+	if err != nil {
+		glog.Errorf("%s: %v", watcherName, err)
+	}
+	w.files = append(w.files, &watchedFile{path: path, sig: sig, reload: reload})
+}
+
+func (w *fileWatcher) start() {
+	for _, wf := range w.files {
+		glog.Infof("%s: watching %q every %v", watcherName, wf.path, w.period)
+	}
+	hk.Housekeeper.Register(watcherName, w.check, w.period)
+}
+
+func (w *fileWatcher) check() time.Duration {
+	for _, wf := range w.files {
+		sig, err := fileSig(wf.path)
+		if err != nil || sig == wf.sig {
+			continue // e.g., in the middle of the update
+		}
+		if err := wf.reload(); err != nil {
+			glog.Errorf("%s: failed to reload %q: %v", watcherName, wf.path, err)
+			continue // and retry next time
+		}
+		wf.sig = sig
+		glog.Infof("%s: reloaded %q", watcherName, wf.path)
+	}
+	return w.period
+}
+
+// file: modification time and size; directory: the same for all its (non-hidden) files
+func fileSig(path string) (string, error) {
+	fi, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	if !fi.IsDir() {
+		return fmt.Sprintf("%d-%d", fi.ModTime().UnixNano(), fi.Size()), nil
+	}
+	names, err := secretNames(path)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	for _, name := range names {
+		if fi, err = os.Stat(filepath.Join(path, name)); err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&sb, "%s-%d-%d;", name, fi.ModTime().UnixNano(), fi.Size())
+	}
+	return sb.String(), nil
+}
+
+/////////////
+// secrets //
+/////////////
+
+// Kubernetes Secret volume: one file per key and "..data" (et al.) for versioning
+func secretNames(dir string) (names []string, err error) {
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, fi := range fis {
+		if strings.HasPrefix(fi.Name(), ".") {
+			continue
+		}
+		names = append(names, fi.Name())
+	}
+	return
+}
+
+// sets the environment variables from the files in a given directory: one
+// variable per file (e.g., AWS_ACCESS_KEY_ID or AZURE_STORAGE_KEY) named after the file;
+// unsets the ones whose files are gone
+func loadSecrets(dir string) error {
+	names, err := secretNames(dir)
+	if err != nil {
+		return err
+	}
+	loaded := make(cmn.StringSet, len(names))
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		if fi, err := os.Stat(path); err != nil || fi.IsDir() {
+			continue
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if err := os.Setenv(name, strings.TrimSpace(string(b))); err != nil {
+			return fmt.Errorf("failed to set %q from %s: %v", name, dir, err)
+		}
+		loaded.Add(name)
+		secretEnv.Add(name)
+	}
+	for name := range secretEnv {
+		if !loaded.Contains(name) {
+			os.Unsetenv(name)
+			secretEnv.Delete(name)
+		}
+	}
+	return nil
+}
+
+/////////////
+// tlsCert //
+/////////////
+
+// loads the certificates of all HTTPS listeners
+func loadTLSCerts(netConf *cmn.NetConf) error {
+	for _, network := range cmn.KnownNetworks {
+		lc := netConf.Listener(network)
+		if !lc.UseHTTPS {
+			continue
+		}
+		if c, ok := tlsCerts[lc.CertFile]; ok {
+			if c.keyFile != lc.KeyFile {
+				return fmt.Errorf("%s: certificate %q is used with different keys", network, lc.CertFile)
+			}
+			continue
+		}
+		c := &tlsCert{}
+		if err := c.load(lc.CertFile, lc.KeyFile); err != nil {
+			return fmt.Errorf("%s: %v", network, err)
+		}
+		tlsCerts[lc.CertFile] = c
+	}
+	return nil
+}
+
+func (c *tlsCert) load(certFile, keyFile string) error {
+	c.certFile, c.keyFile = certFile, keyFile
+	return c.reload()
+}
+
+func (c *tlsCert) reload() error {
+	cert, err := tls.LoadX509KeyPair(c.certFile, c.keyFile)
+	if err != nil {
+		return err
+	}
+	c.cert.Store(unsafe.Pointer(&cert))
+	return nil
+}
+
+func (c *tlsCert) get(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	return (*tls.Certificate)(c.cert.Load()), nil
+}
+
+//////////////////
+// config watch //
+//////////////////
+
+// starts watching the mounted config, TLS certificate, and secrets (see above)
+func watchMounted(config *cmn.Config) {
+	w := newFileWatcher(daemon.cli.confWatch)
+	w.add(daemon.cli.confPath, func() error { return reloadConfig(daemon.cli.confPath) })
+	for _, c := range tlsCerts {
+		w.add(c.certFile, c.reload)
+		w.add(c.keyFile, c.reload)
+	}
+	if daemon.cli.secretsDir != "" {
+		w.add(daemon.cli.secretsDir, func() error { return loadSecrets(daemon.cli.secretsDir) })
+	}
+	w.start()
+}
+
+// reloads the config and re-applies the command-line overrides, if any
+func reloadConfig(confPath string) error {
+	changed, err := jsp.ReloadConfig(confPath)
+	if err != nil || !changed {
+		return err
+	}
+	if daemon.cli.confCustom == "" {
+		return nil
+	}
+	nvmap, err := confCustomKVs(daemon.cli.confCustom)
+	if err != nil {
+		return err
+	}
+	nvmap[cmn.ActTransient] = "true"
+	return jsp.SetConfigMany(nvmap)
+}
@@ -0,0 +1,162 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+// writes the file and moves its modification time forward (so that the
+// watcher sees the change even if the size remains the same)
+func writeWatched(t *testing.T, path string, b []byte) {
+	fi, err := os.Stat(path)
+	tassert.CheckFatal(t, ioutil.WriteFile(path, b, 0644))
+	if err == nil {
+		mtime := fi.ModTime().Add(time.Second)
+		tassert.CheckFatal(t, os.Chtimes(path, mtime, mtime))
+	}
+}
+
+// self-signed certificate and its key, both PEM-encoded
+func genCert(t *testing.T, cn string) (certPEM, keyPEM []byte) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	tassert.CheckFatal(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: cn},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	tassert.CheckFatal(t, err)
+	b, err := x509.MarshalECPrivateKey(key)
+	tassert.CheckFatal(t, err)
+	certPEM = pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: b})
+	return
+}
+
+func TestWatchConfig(t *testing.T) {
+	oldConfig := cmn.GCO.Get()
+	defer func() {
+		cmn.GCO.BeginUpdate()
+		cmn.GCO.CommitUpdate(oldConfig)
+	}()
+
+	b, err := ioutil.ReadFile(filepath.Join("..", "cmn", "tests", "configs", "configtest.json"))
+	tassert.CheckFatal(t, err)
+	dir, err := ioutil.TempDir("", "watch-config")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(dir)
+	confPath := filepath.Join(dir, "ais.json")
+	tassert.CheckFatal(t, ioutil.WriteFile(confPath, b, 0644))
+	jsp.LoadConfig(confPath)
+	tassert.Fatalf(t, cmn.GCO.Get().Periodic.StatsTime == 10*time.Second,
+		"unexpected %v", cmn.GCO.Get().Periodic.StatsTime)
+
+	w := newFileWatcher(time.Second)
+	w.add(confPath, func() error { return reloadConfig(confPath) })
+
+	// reloaded upon change
+	nb := strings.Replace(string(b), `"stats_time":        "10s"`, `"stats_time":        "20s"`, 1)
+	writeWatched(t, confPath, []byte(nb))
+	w.check()
+	tassert.Errorf(t, cmn.GCO.Get().Periodic.StatsTime == 20*time.Second, "expected config to be reloaded")
+
+	// invalid - not applied
+	writeWatched(t, confPath, []byte(strings.Replace(nb, `"20s"`, `"bad"`, 1)))
+	w.check()
+	tassert.Errorf(t, cmn.GCO.Get().Periodic.StatsTime == 20*time.Second, "invalid config must not be applied")
+}
+
+func TestWatchTLSCert(t *testing.T) {
+	dir, err := ioutil.TempDir("", "watch-tls")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(dir)
+	var (
+		certFile = filepath.Join(dir, "tls.crt")
+		keyFile  = filepath.Join(dir, "tls.key")
+		c        = &tlsCert{}
+	)
+	certPEM, keyPEM := genCert(t, "old")
+	tassert.CheckFatal(t, ioutil.WriteFile(certFile, certPEM, 0644))
+	tassert.CheckFatal(t, ioutil.WriteFile(keyFile, keyPEM, 0644))
+	tassert.CheckFatal(t, c.load(certFile, keyFile))
+	old, err := c.get(nil)
+	tassert.CheckFatal(t, err)
+
+	w := newFileWatcher(time.Second)
+	w.add(certFile, c.reload)
+	w.add(keyFile, c.reload)
+
+	// the certificate without its key (in the middle of the update) - keep the old one
+	certPEM, keyPEM = genCert(t, "new")
+	writeWatched(t, certFile, certPEM)
+	w.check()
+	cert, err := c.get(nil)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bytes.Equal(cert.Certificate[0], old.Certificate[0]), "expected the old certificate")
+
+	// and with it
+	writeWatched(t, keyFile, keyPEM)
+	w.check()
+	cert, err = c.get(nil)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, !bytes.Equal(cert.Certificate[0], old.Certificate[0]), "expected the new certificate")
+}
+
+func TestWatchSecrets(t *testing.T) {
+	const (
+		keyID  = "AIS_TEST_ACCESS_KEY_ID"
+		secret = "AIS_TEST_SECRET_ACCESS_KEY"
+	)
+	defer func() {
+		os.Unsetenv(keyID)
+		os.Unsetenv(secret)
+	}()
+	dir, err := ioutil.TempDir("", "watch-secrets")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(dir)
+	tassert.CheckFatal(t, ioutil.WriteFile(filepath.Join(dir, keyID), []byte("id-1\n"), 0644))
+	tassert.CheckFatal(t, ioutil.WriteFile(filepath.Join(dir, secret), []byte("secret-1"), 0644))
+	tassert.CheckFatal(t, ioutil.WriteFile(filepath.Join(dir, "..data"), []byte("ignored"), 0644))
+	tassert.CheckFatal(t, loadSecrets(dir))
+	tassert.Errorf(t, os.Getenv(keyID) == "id-1" && os.Getenv(secret) == "secret-1",
+		"unexpected %q, %q", os.Getenv(keyID), os.Getenv(secret))
+	_, ok := os.LookupEnv("..data")
+	tassert.Errorf(t, !ok, "hidden files must be skipped")
+
+	w := newFileWatcher(time.Second)
+	w.add(dir, func() error { return loadSecrets(dir) })
+
+	// updated
+	writeWatched(t, filepath.Join(dir, secret), []byte("secret-2"))
+	w.check()
+	tassert.Errorf(t, os.Getenv(secret) == "secret-2", "expected updated secret, got %q", os.Getenv(secret))
+
+	// removed
+	tassert.CheckFatal(t, os.Remove(filepath.Join(dir, keyID)))
+	w.check()
+	_, ok = os.LookupEnv(keyID)
+	tassert.Errorf(t, !ok, "expected %s to be unset", keyID)
+	tassert.Errorf(t, os.Getenv(secret) == "secret-2", "expected %s to remain set", secret)
+}
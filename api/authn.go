@@ -20,11 +20,15 @@ type (
 	}
 
 	loginRec struct {
-		Password string `json:"password"`
+		Password  string `json:"password"`
+		ClusterID string `json:"cluster_id,omitempty"`
 	}
 
 	AuthCreds struct {
 		Token string `json:"token"`
+		// S3 credentials (see cmn.S3AccessKeyID and cmn.S3SecretKey)
+		S3AccessKeyID string `json:"s3_access_key_id,omitempty"`
+		S3SecretKey   string `json:"s3_secret_key,omitempty"`
 	}
 )
 
@@ -60,13 +64,16 @@ func DeleteUser(baseParams BaseParams, userID string) error {
 	})
 }
 
-func LoginUser(baseParams BaseParams, userID, pass string) (token *AuthCreds, err error) {
+// LoginUser requests a token from AuthN. Non-empty clusterID (UUID or alias
+// of a registered cluster) scopes the token - the token is then rejected by
+// all other clusters.
+func LoginUser(baseParams BaseParams, userID, pass, clusterID string) (token *AuthCreds, err error) {
 	baseParams.Method = http.MethodPost
 
 	err = DoHTTPRequest(ReqParams{
 		BaseParams: baseParams,
 		Path:       cmn.URLPath(cmn.Version, cmn.Users, userID),
-		Body:       cmn.MustMarshal(loginRec{Password: pass}),
+		Body:       cmn.MustMarshal(loginRec{Password: pass, ClusterID: clusterID}),
 	}, &token)
 	if err != nil {
 		return nil, err
@@ -5,13 +5,20 @@
 package api
 
 import (
+	"bufio"
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
 )
 
 const (
@@ -29,6 +36,29 @@ func SetBucketProps(baseParams BaseParams, bck cmn.Bck, props cmn.BucketPropsToU
 	return patchBucketProps(baseParams, bck, b, query...)
 }
 
+// SetBucketPropsDryRun API
+//
+// Validates the properties on all targets (and the primary) without setting
+// them; returns the first problem found, if any
+func SetBucketPropsDryRun(baseParams BaseParams, bck cmn.Bck, props cmn.BucketPropsToUpdate) error {
+	return SetBucketProps(baseParams, bck, props, url.Values{cmn.URLParamDryRun: []string{"true"}})
+}
+
+// SetBucketPropsBatch API
+//
+// Set the same properties on a number of buckets - the listed ones and/or the ones
+// whose names match the regex - in a single transaction (and a single BMD update).
+func SetBucketPropsBatch(baseParams BaseParams, msg cmn.BpropsBatchMsg, query ...url.Values) error {
+	var q url.Values
+	if len(query) > 0 {
+		q = query[0]
+	}
+	baseParams.Method = http.MethodPatch
+	path := cmn.URLPath(cmn.Version, cmn.Buckets)
+	b := cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActSetBpropsBatch, Value: msg})
+	return DoHTTPRequest(ReqParams{BaseParams: baseParams, Path: path, Body: b, Query: q})
+}
+
 // ResetBucketProps API
 //
 // Reset the properties of a bucket, identified by its name, to the global configuration.
@@ -76,6 +106,43 @@ func HeadBucket(baseParams BaseParams, bck cmn.Bck, query ...url.Values) (p *cmn
 	return
 }
 
+// HeadBucketProvenance API
+//
+// Returns, for each property of a bucket, whether its value comes from the
+// cluster defaults or a bucket-level override, along with the BMD version at
+// which the property was last changed (see cmn.PropsProvenance).
+func HeadBucketProvenance(baseParams BaseParams, bck cmn.Bck) (map[string]cmn.PropProvenance, error) {
+	var (
+		path = cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name)
+		q    = url.Values{cmn.URLParamProvenance: []string{"true"}}
+	)
+	baseParams.Method = http.MethodHead
+	q = cmn.AddBckToQuery(q, bck)
+	resp, err := doHTTPRequestGetResp(ReqParams{BaseParams: baseParams, Path: path, Query: q}, nil)
+	if err != nil {
+		return nil, err
+	}
+	values := resp.Header[http.CanonicalHeaderKey(cmn.HeaderBucketProvenance)]
+	provenance := make(map[string]cmn.PropProvenance, len(values))
+	for _, v := range values {
+		// <property>=<source>:<version>
+		entry := strings.SplitN(v, "=", 2)
+		if len(entry) != 2 {
+			return nil, fmt.Errorf("invalid %s header %q", cmn.HeaderBucketProvenance, v)
+		}
+		i := strings.LastIndexByte(entry[1], ':')
+		if i < 0 {
+			return nil, fmt.Errorf("invalid %s header %q", cmn.HeaderBucketProvenance, v)
+		}
+		version, err := strconv.ParseInt(entry[1][i+1:], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s header %q", cmn.HeaderBucketProvenance, v)
+		}
+		provenance[entry[0]] = cmn.PropProvenance{Source: entry[1][:i], Version: version}
+	}
+	return provenance, nil
+}
+
 // ListBuckets API
 //
 // provider takes one of Cloud Provider enum names (see cmn/bucket.go). If provider is empty, return all names.
@@ -95,6 +162,27 @@ func ListBuckets(baseParams BaseParams, queryBcks cmn.QueryBcks) (cmn.BucketName
 	return bucketNames, nil
 }
 
+// ListBucketsEvents API
+//
+// Same as ListBuckets, plus the numbers of each bucket's mutations (PUTs,
+// APPENDs, DELETEs, renames) and the time of the latest one - as counted by
+// the targets since they (re)started. Comparing the results of the consecutive
+// calls tells which buckets have changed in the meantime.
+func ListBucketsEvents(baseParams BaseParams, queryBcks cmn.QueryBcks) (cmn.BucketsEvents, error) {
+	var (
+		events = cmn.BucketsEvents{}
+		path   = cmn.URLPath(cmn.Version, cmn.Buckets, cmn.AllBuckets)
+		query  = url.Values{cmn.URLParamBckEvents: []string{"true"}}
+	)
+	query = cmn.AddBckToQuery(query, cmn.Bck(queryBcks))
+	baseParams.Method = http.MethodGet
+	err := DoHTTPRequest(ReqParams{BaseParams: baseParams, Path: path, Query: query}, &events)
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
 // GetBucketsSummaries API
 //
 // Returns bucket summaries for the specified bucket provider (and all bucket summaries for unspecified ("") provider).
@@ -141,17 +229,50 @@ func CreateBucket(baseParams BaseParams, bck cmn.Bck, ops ...cmn.BucketPropsToUp
 
 // DestroyBucket API
 //
-// DestroyBucket sends a HTTP request to a proxy to remove an ais bucket with the given name
-func DestroyBucket(baseParams BaseParams, bck cmn.Bck) error {
+// DestroyBucket sends a HTTP request to a proxy to remove an ais bucket with the given name.
+// Optional confirmToken is required when the bucket is large (see ConfirmDelete).
+func DestroyBucket(baseParams BaseParams, bck cmn.Bck, confirmToken ...string) error {
 	baseParams.Method = http.MethodDelete
 	return DoHTTPRequest(ReqParams{
 		BaseParams: baseParams,
 		Path:       cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name),
 		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActDestroyLB}),
-		Query:      cmn.AddBckToQuery(nil, bck),
+		Query:      confirmQuery(bck, confirmToken),
 	})
 }
 
+// ConfirmDelete API
+//
+// ConfirmDelete returns a short-lived token that confirms a given destructive bulk
+// operation: destroying the bucket (cmn.ActDestroyLB) or deleting its objects by list,
+// range, or prefix (cmn.ActDelete with the corresponding value). The token must be
+// passed to the operation when the cluster is configured to protect against mass
+// deletion (`proxy.mass_delete_threshold`) and the operation exceeds the threshold.
+func ConfirmDelete(baseParams BaseParams, bck cmn.Bck, op cmn.ActionMsg) (token string, err error) {
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActConfirmDelete, Value: op}),
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+		},
+		Query: cmn.AddBckToQuery(nil, bck),
+	}, &token)
+	return
+}
+
+func confirmQuery(bck cmn.Bck, confirmToken []string) url.Values {
+	query := cmn.AddBckToQuery(nil, bck)
+	if len(confirmToken) > 0 && confirmToken[0] != "" {
+		if query == nil {
+			query = url.Values{}
+		}
+		query.Set(cmn.URLParamConfirmToken, confirmToken[0])
+	}
+	return query
+}
+
 // DoesBucketExist API
 //
 // DoesBucketExist queries a proxy or target to get a list of all ais buckets,
@@ -168,12 +289,13 @@ func DoesBucketExist(baseParams BaseParams, query cmn.QueryBcks) (bool, error) {
 //
 // CopyBucket creates a new ais bucket newName and
 // copies into it contents of the existing oldName bucket
-func CopyBucket(baseParams BaseParams, fromBck, toBck cmn.Bck) error {
+// (optional tags get attached to the copying xaction - see cmn.XactTags)
+func CopyBucket(baseParams BaseParams, fromBck, toBck cmn.Bck, tags ...cmn.XactTags) error {
 	baseParams.Method = http.MethodPost
 	return DoHTTPRequest(ReqParams{
 		BaseParams: baseParams,
 		Path:       cmn.URLPath(cmn.Version, cmn.Buckets, fromBck.Name),
-		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActCopyBucket, Name: toBck.Name}),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActCopyBucket, Name: toBck.Name, Tags: optTags(tags)}),
 	})
 }
 
@@ -189,36 +311,93 @@ func RenameBucket(baseParams BaseParams, oldBck, newBck cmn.Bck) error {
 	})
 }
 
+// RenameBucketAsync API
+//
+// RenameBucketAsync starts renaming a bucket and returns the UUID of the job
+// right away (see GetJobStatus)
+func RenameBucketAsync(baseParams BaseParams, oldBck, newBck cmn.Bck) (uuid string, err error) {
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Buckets, oldBck.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActRenameLB, Name: newBck.Name}),
+		Query:      url.Values{cmn.URLParamAsync: []string{"true"}},
+	}, &uuid)
+	return
+}
+
+// CopyBucketAsync API
+//
+// CopyBucketAsync starts copying a bucket and returns the UUID of the job
+// right away (see GetJobStatus)
+func CopyBucketAsync(baseParams BaseParams, fromBck, toBck cmn.Bck, tags ...cmn.XactTags) (uuid string, err error) {
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Buckets, fromBck.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActCopyBucket, Name: toBck.Name, Tags: optTags(tags)}),
+		Query:      url.Values{cmn.URLParamAsync: []string{"true"}},
+	}, &uuid)
+	return
+}
+
+// GetJobStatus API
+//
+// GetJobStatus returns the status of the bucket operation that was started asynchronously
+func GetJobStatus(baseParams BaseParams, uuid string) (status *cmn.JobStatus, err error) {
+	baseParams.Method = http.MethodGet
+	status = &cmn.JobStatus{}
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Txn, uuid),
+	}, status)
+	return
+}
+
+// SwapBuckets API
+//
+// SwapBuckets atomically exchanges the names of the two existing ais buckets
+func SwapBuckets(baseParams BaseParams, bckA, bckB cmn.Bck) error {
+	baseParams.Method = http.MethodPost
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Buckets, bckA.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActSwapLB, Name: bckB.Name}),
+	})
+}
+
 // DeleteList API
 //
-// DeleteList sends a HTTP request to remove a list of objects from a bucket
-func DeleteList(baseParams BaseParams, bck cmn.Bck, fileslist []string) error {
+// DeleteList sends a HTTP request to remove a list of objects from a bucket.
+// Optional confirmToken is required when the list is long (see ConfirmDelete).
+func DeleteList(baseParams BaseParams, bck cmn.Bck, fileslist []string, confirmToken ...string) error {
 	deleteMsg := cmn.ListMsg{ObjNames: fileslist}
-	return doListRangeRequest(baseParams, bck, cmn.ActDelete, http.MethodDelete, deleteMsg)
+	return doListRangeRequest(baseParams, bck, cmn.ActDelete, http.MethodDelete, deleteMsg, nil, confirmToken...)
 }
 
 // DeleteRange API
 //
-// DeleteRange sends a HTTP request to remove a range of objects from a bucket
-func DeleteRange(baseParams BaseParams, bck cmn.Bck, rng string) error {
+// DeleteRange sends a HTTP request to remove a range of objects from a bucket.
+// Optional confirmToken is required when the range is large (see ConfirmDelete).
+func DeleteRange(baseParams BaseParams, bck cmn.Bck, rng string, confirmToken ...string) error {
 	deleteMsg := cmn.RangeMsg{Template: rng}
-	return doListRangeRequest(baseParams, bck, cmn.ActDelete, http.MethodDelete, deleteMsg)
+	return doListRangeRequest(baseParams, bck, cmn.ActDelete, http.MethodDelete, deleteMsg, nil, confirmToken...)
 }
 
 // PrefetchList API
 //
 // PrefetchList sends a HTTP request to prefetch a list of objects from a cloud bucket
-func PrefetchList(baseParams BaseParams, bck cmn.Bck, fileslist []string) error {
+func PrefetchList(baseParams BaseParams, bck cmn.Bck, fileslist []string, tags ...cmn.XactTags) error {
 	prefetchMsg := cmn.ListMsg{ObjNames: fileslist}
-	return doListRangeRequest(baseParams, bck, cmn.ActPrefetch, http.MethodPost, prefetchMsg)
+	return doListRangeRequest(baseParams, bck, cmn.ActPrefetch, http.MethodPost, prefetchMsg, optTags(tags))
 }
 
 // PrefetchRange API
 //
 // PrefetchRange sends a HTTP request to prefetch a range of objects from a cloud bucket
-func PrefetchRange(baseParams BaseParams, bck cmn.Bck, rng string) error {
+func PrefetchRange(baseParams BaseParams, bck cmn.Bck, rng string, tags ...cmn.XactTags) error {
 	prefetchMsg := cmn.RangeMsg{Template: rng}
-	return doListRangeRequest(baseParams, bck, cmn.ActPrefetch, http.MethodPost, prefetchMsg)
+	return doListRangeRequest(baseParams, bck, cmn.ActPrefetch, http.MethodPost, prefetchMsg, optTags(tags))
 }
 
 // EvictList API
@@ -226,7 +405,7 @@ func PrefetchRange(baseParams BaseParams, bck cmn.Bck, rng string) error {
 // EvictList sends a HTTP request to evict a list of objects from a cloud bucket
 func EvictList(baseParams BaseParams, bck cmn.Bck, fileslist []string) error {
 	evictMsg := cmn.ListMsg{ObjNames: fileslist}
-	return doListRangeRequest(baseParams, bck, cmn.ActEvictObjects, http.MethodDelete, evictMsg)
+	return doListRangeRequest(baseParams, bck, cmn.ActEvictObjects, http.MethodDelete, evictMsg, nil)
 }
 
 // EvictRange API
@@ -234,7 +413,7 @@ func EvictList(baseParams BaseParams, bck cmn.Bck, fileslist []string) error {
 // EvictRange sends a HTTP request to evict a range of objects from a cloud bucket
 func EvictRange(baseParams BaseParams, bck cmn.Bck, rng string) error {
 	evictMsg := cmn.RangeMsg{Template: rng}
-	return doListRangeRequest(baseParams, bck, cmn.ActEvictObjects, http.MethodDelete, evictMsg)
+	return doListRangeRequest(baseParams, bck, cmn.ActEvictObjects, http.MethodDelete, evictMsg, nil)
 }
 
 // EvictCloudBucket API
@@ -451,6 +630,83 @@ func ListObjectsPage(baseParams BaseParams, bck cmn.Bck, smsg *cmn.SelectMsg) (*
 	return page, nil
 }
 
+// ListObjectsStream lists the bucket and calls `cb` for each object as the
+// pages arrive, without waiting for the entire listing to complete.
+// To cancel the listing, `cb` returns an error - the function then closes
+// the connection and returns the same error.
+func ListObjectsStream(baseParams BaseParams, bck cmn.Bck, smsg *cmn.SelectMsg, cb func(*cmn.BucketEntry) error) error {
+	if smsg == nil {
+		smsg = &cmn.SelectMsg{}
+	}
+	var (
+		q    = cmn.AddBckToQuery(url.Values{}, bck)
+		path = cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name)
+		body = cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActListObjects, Value: smsg})
+	)
+	q.Set(cmn.URLParamStream, "true")
+	req, err := http.NewRequest(http.MethodPost, baseParams.URL+path, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	reqParams := ReqParams{
+		BaseParams: baseParams,
+		Path:       path,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Query:      q,
+	}
+	reqParams.BaseParams.Method = http.MethodPost
+	setRequestOptParams(req, reqParams)
+	setAuthToken(req, baseParams)
+
+	resp, err := baseParams.Client.Do(req) // nolint:bodyclose // closed below
+	if err != nil {
+		return err
+	}
+	// NOTE: closing without draining - to cancel the listing
+	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		_, err = readResp(reqParams, resp, nil)
+		return err
+	}
+
+	var (
+		rd    = bufio.NewReader(resp.Body)
+		event string
+	)
+	for {
+		line, err := rd.ReadBytes('\n')
+		if err != nil {
+			if err == io.EOF {
+				err = errors.New("list-objects stream ended unexpectedly")
+			}
+			return err
+		}
+		line = bytes.TrimRight(line, "\n")
+		switch {
+		case bytes.HasPrefix(line, []byte("event: ")):
+			event = string(line[len("event: "):])
+		case bytes.HasPrefix(line, []byte("data: ")):
+			data := line[len("data: "):]
+			switch event {
+			case cmn.ListStreamEntries:
+				page := &cmn.BucketList{}
+				if err := jsoniter.Unmarshal(data, page); err != nil {
+					return err
+				}
+				for _, entry := range page.Entries {
+					if err := cb(entry); err != nil {
+						return err
+					}
+				}
+			case cmn.ListStreamEnd:
+				return nil
+			case cmn.ListStreamError:
+				return errors.New(string(data))
+			}
+		}
+	}
+}
+
 // ListObjectsFast returns list of objects in a bucket.
 // Build an object list with minimal set of properties: name and size.
 // All SelectMsg fields except prefix do not work and are skipped.
@@ -508,27 +764,198 @@ func ListObjectsInvalidateCache(params BaseParams, bck cmn.Bck, selMsg *cmn.Sele
 }
 
 // Handles the List/Range operations (delete, prefetch)
-func doListRangeRequest(baseParams BaseParams, bck cmn.Bck, action, method string, listRangeMsg interface{}) error {
+func doListRangeRequest(baseParams BaseParams, bck cmn.Bck, action, method string, listRangeMsg interface{},
+	tags cmn.XactTags, confirmToken ...string) error {
 	baseParams.Method = method
 	return DoHTTPRequest(ReqParams{
 		BaseParams: baseParams,
 		Path:       cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name),
-		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: action, Value: listRangeMsg}),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: action, Value: listRangeMsg, Tags: tags}),
 		Header: http.Header{
 			"Content-Type": []string{"application/json"},
 		},
-		Query: cmn.AddBckToQuery(nil, bck),
+		Query: confirmQuery(bck, confirmToken),
 	})
 }
 
-func ECEncodeBucket(baseParams BaseParams, bck cmn.Bck, data, parity int) error {
+// ECRestoreRange API
+//
+// ECRestoreRange starts restoring, from existing EC slices and replicas, all
+// objects of the bucket that match a given prefix or (bash-style) template.
+// Returns the ID of the xaction that can be used to monitor the progress.
+func ECRestoreRange(baseParams BaseParams, bck cmn.Bck, rng string) (xactID string, err error) {
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActECRestore, Value: cmn.RangeMsg{Template: rng}}),
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+		},
+		Query: cmn.AddBckToQuery(nil, bck),
+	}, &xactID)
+	return
+}
+
+// Warmup API
+//
+// Warmup reads a list or a template of the bucket's objects into the OS page
+// cache of the targets ahead of, e.g., a training run and, given
+// `msg.MlockBudget`, locks the objects in memory up to the budget (per target).
+// Returns the ID of the xaction that can be used to monitor the progress and
+// the cached fraction; aborting the xaction unlocks the objects.
+func Warmup(baseParams BaseParams, bck cmn.Bck, msg cmn.WarmupMsg) (xactID string, err error) {
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActWarmup, Value: msg}),
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+		},
+		Query: cmn.AddBckToQuery(nil, bck),
+	}, &xactID)
+	return
+}
+
+// ECUndelete API
+//
+// ECUndelete undeletes the recently deleted objects of the bucket that match
+// a given prefix or (bash-style) template. The objects can be undeleted only
+// within `ec.undelete_window` (see cluster configuration) after the deletion.
+// Returns the ID of the restoring xaction that can be used to monitor the progress.
+func ECUndelete(baseParams BaseParams, bck cmn.Bck, rng string) (xactID string, err error) {
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActECUndelete, Value: cmn.RangeMsg{Template: rng}}),
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+		},
+		Query: cmn.AddBckToQuery(nil, bck),
+	}, &xactID)
+	return
+}
+
+// QueryJournal API
+//
+// QueryJournal returns the operation journal records (see cmn.JournalConf) of
+// the bucket's objects that have names starting with a given prefix,
+// collected from all targets and sorted by time.
+func QueryJournal(baseParams BaseParams, bck cmn.Bck, prefix string) (entries []*cmn.JournalEntry, err error) {
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActQueryJournal, Name: prefix}),
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+		},
+		Query: cmn.AddBckToQuery(nil, bck),
+	}, &entries)
+	return
+}
+
+// SimulatePlacement API
+//
+// SimulatePlacement estimates the number and the size of the bucket's objects
+// that would move (under HRW) if the given targets joined and/or left the
+// cluster - that is, the cost of the rebalance that would follow.
+// The targets to add are identified by their (hypothetical) daemon IDs.
+func SimulatePlacement(baseParams BaseParams, bck cmn.Bck, add, remove []string) (res *cmn.SimPlacementResult, err error) {
+	baseParams.Method = http.MethodPost
+	res = &cmn.SimPlacementResult{}
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name),
+		Body: cmn.MustMarshal(cmn.ActionMsg{
+			Action: cmn.ActSimPlacement,
+			Value:  cmn.SimPlacementMsg{Add: add, Remove: remove},
+		}),
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+		},
+		Query: cmn.AddBckToQuery(nil, bck),
+	}, res)
+	return
+}
+
+// ObjLocations API
+//
+// ObjLocations returns, for each of the given objects, its owning target and
+// the target's host - so that external schedulers can place compute next to
+// the data - and, for the objects that are present in the cluster, also the
+// mountpaths of the object and its local copies and the targets that store
+// its EC slices or replicas.
+func ObjLocations(baseParams BaseParams, bck cmn.Bck, objNames []string) (locs *cmn.ObjLocations, err error) {
+	baseParams.Method = http.MethodPost
+	locs = &cmn.ObjLocations{}
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name),
+		Body: cmn.MustMarshal(cmn.ActionMsg{
+			Action: cmn.ActObjLocations,
+			Value:  cmn.ObjLocationsMsg{ObjNames: objNames},
+		}),
+		Header: http.Header{
+			"Content-Type": []string{"application/json"},
+		},
+		Query: cmn.AddBckToQuery(nil, bck),
+	}, locs)
+	return
+}
+
+func ECEncodeBucket(baseParams BaseParams, bck cmn.Bck, data, parity int, tags ...cmn.XactTags) error {
 	baseParams.Method = http.MethodPost
 	// without `string` conversion it makes base64 from []byte in `Body`
 	ecConf := string(cmn.MustMarshal(&cmn.ECConfToUpdate{DataSlices: &data, ParitySlices: &parity}))
 	return DoHTTPRequest(ReqParams{
 		BaseParams: baseParams,
 		Path:       cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name),
-		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActECEncode, Value: ecConf}),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActECEncode, Value: ecConf, Tags: optTags(tags)}),
 		Query:      cmn.AddBckToQuery(nil, bck),
 	})
 }
+
+// ECEncodeBucketDryRun API
+//
+// Validates erasure-coding the bucket with the given number of data and parity
+// slices without changing anything (see SetBucketPropsDryRun)
+func ECEncodeBucketDryRun(baseParams BaseParams, bck cmn.Bck, data, parity int) error {
+	baseParams.Method = http.MethodPost
+	ecConf := string(cmn.MustMarshal(&cmn.ECConfToUpdate{DataSlices: &data, ParitySlices: &parity}))
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActECEncode, Value: ecConf}),
+		Query:      cmn.AddBckToQuery(url.Values{cmn.URLParamDryRun: []string{"true"}}, bck),
+	})
+}
+
+// ECEncodeBucketAfter API
+//
+// ECEncodeBucketAfter erasure-codes a bucket upon successful completion of the
+// xaction (or asynchronous job) with a given UUID - e.g., of the copy-bucket
+// that creates the bucket (see CopyBucketAsync); returns the UUID of the job
+// right away (see GetJobStatus)
+func ECEncodeBucketAfter(baseParams BaseParams, bck cmn.Bck, data, parity int, waitFor string,
+	tags ...cmn.XactTags) (uuid string, err error) {
+	baseParams.Method = http.MethodPost
+	ecConf := string(cmn.MustMarshal(&cmn.ECConfToUpdate{DataSlices: &data, ParitySlices: &parity}))
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActECEncode, Value: ecConf, Tags: optTags(tags)}),
+		Query:      cmn.AddBckToQuery(url.Values{cmn.URLParamWaitFor: []string{waitFor}}, bck),
+	}, &uuid)
+	return
+}
+
+// the optional tags to attach to the xaction that an API call starts
+func optTags(tags []cmn.XactTags) cmn.XactTags {
+	if len(tags) > 0 {
+		return tags[0]
+	}
+	return nil
+}
@@ -7,6 +7,7 @@ package api
 import (
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -78,6 +79,39 @@ func GetTargetDiskStats(baseParams BaseParams, targetID string) (diskStats map[s
 	return
 }
 
+// GetTargetLocks API
+//
+// GetTargetLocks returns the object locks currently held by a given target;
+// an optional prefix filters the locks by object uname.
+func GetTargetLocks(baseParams BaseParams, targetID string, prefix ...string) (locks []cmn.LockInfo, err error) {
+	query := url.Values{cmn.URLParamWhat: []string{cmn.GetWhatLocks}}
+	if len(prefix) > 0 {
+		query.Set(cmn.URLParamPrefix, prefix[0])
+	}
+	baseParams.Method = http.MethodGet
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Reverse, cmn.Daemon),
+		Query:      query,
+		Header:     http.Header{cmn.HeaderNodeID: []string{targetID}},
+	}, &locks)
+	return
+}
+
+// ForceUnlock API
+//
+// ForceUnlock releases a given (by uname - see GetTargetLocks) object lock on
+// a given target, regardless of its holders. Requires admin permissions.
+// To be used only to recover from stuck operations.
+func ForceUnlock(baseParams BaseParams, targetID, uname string) error {
+	baseParams.Method = http.MethodPut
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Cluster),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActForceUnlock, Name: uname, Value: targetID}),
+	})
+}
+
 func GetRemoteAIS(baseParams BaseParams) (aisInfo cmn.CloudInfoAIS, err error) {
 	baseParams.Method = http.MethodGet
 	err = DoHTTPRequest(ReqParams{
@@ -100,6 +134,39 @@ func RegisterNode(baseParams BaseParams, nodeInfo *cluster.Snode) error {
 	})
 }
 
+// RegisterNodeWithToken API
+//
+// RegisterNodeWithToken is RegisterNode for clusters that admit new targets
+// only with a valid join token (see NewJoinToken).
+func RegisterNodeWithToken(baseParams BaseParams, nodeInfo *cluster.Snode, token string) error {
+	baseParams.Method = http.MethodPost
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Cluster, cmn.UserRegister),
+		Body:       cmn.MustMarshal(nodeInfo),
+		Query:      url.Values{cmn.URLParamJoinToken: []string{token}},
+	})
+}
+
+// NewJoinToken API
+//
+// NewJoinToken mints a signed token that allows a new storage target to join
+// the running cluster when the cluster is configured to require one (`proxy.join_tokens`).
+// The token expires after a given time; zero means the default lifetime (1h).
+func NewJoinToken(baseParams BaseParams, ttl time.Duration) (token string, err error) {
+	msg := cmn.ActionMsg{Action: cmn.ActJoinToken}
+	if ttl > 0 {
+		msg.Value = ttl.String()
+	}
+	baseParams.Method = http.MethodPut
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Cluster),
+		Body:       cmn.MustMarshal(msg),
+	}, &token)
+	return
+}
+
 // UnregisterNode API
 //
 // Unregisters an existing node from the clustermap.
@@ -179,3 +246,16 @@ func Health(baseParams BaseParams, si *cluster.Snode) error {
 		Query:      make(url.Values),
 	})
 }
+
+// GetClockSkews returns a given node's latest estimates of its peers' clock
+// offsets (see cmn.ClockSkew); the primary has them for all other nodes
+func GetClockSkews(baseParams BaseParams, si *cluster.Snode) (skews cmn.ClockSkews, err error) {
+	baseParams.Method = http.MethodGet
+	baseParams.URL = si.PublicNet.DirectURL
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Health),
+		Query:      url.Values{cmn.URLParamClockSkew: []string{"true"}},
+	}, &skews)
+	return
+}
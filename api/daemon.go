@@ -58,6 +58,23 @@ func RemoveMountpath(baseParams BaseParams, nodeID, mountpath string) error {
 	})
 }
 
+// DrainMountpath API
+//
+// DrainMountpath removes the mountpath safely: the target first copies the
+// mountpath's content to its other mountpaths and verifies the copies, and
+// only then removes the mountpath. Returns the ID of the (asynchronous)
+// xaction that reports the progress.
+func DrainMountpath(baseParams BaseParams, nodeID, mountpath string) (xactID string, err error) {
+	baseParams.Method = http.MethodDelete
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Reverse, cmn.Daemon, cmn.Mountpaths),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActMountpathDrain, Value: mountpath}),
+		Header:     http.Header{cmn.HeaderNodeID: []string{nodeID}},
+	}, &xactID)
+	return
+}
+
 // EnableMountpath API
 func EnableMountpath(baseParams BaseParams, node *cluster.Snode, mountpath string) error {
 	baseParams.Method = http.MethodPost
@@ -116,6 +133,21 @@ func GetDaemonSysInfo(baseParams BaseParams, nodeID string) (sysInfo *cmn.TSysIn
 	return sysInfo, err
 }
 
+// GetDaemonPeerStats API
+//
+// Returns the intra-cluster calls (counts, errors, and latencies) of a specific
+// daemon in the cluster, by destination node and channel - see cmn.PeerStats
+func GetDaemonPeerStats(baseParams BaseParams, nodeID string) (peerStats cmn.PeerStats, err error) {
+	baseParams.Method = http.MethodGet
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Reverse, cmn.Daemon),
+		Query:      url.Values{cmn.URLParamWhat: []string{cmn.GetWhatPeerStats}},
+		Header:     http.Header{cmn.HeaderNodeID: []string{nodeID}},
+	}, &peerStats)
+	return peerStats, err
+}
+
 // GetDaemonInfo API
 //
 // Returns the info of a specific Daemon in the cluster
@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
@@ -48,6 +49,7 @@ type PutObjectArgs struct {
 	Cksum      *cmn.Cksum
 	Reader     cmn.ReadOpenCloser
 	Size       uint64 // optional
+	ECPolicy   string // optional: overrides the bucket's EC configuration, e.g. "parity=3" or "replication=2"
 }
 
 type PromoteArgs struct {
@@ -78,6 +80,24 @@ type FlushArgs struct {
 	Cksum      *cmn.Cksum
 }
 
+type WriteAtArgs struct {
+	BaseParams BaseParams
+	Bck        cmn.Bck
+	Object     string
+	Handle     string
+	Offset     int64
+	Reader     cmn.ReadOpenCloser
+	Size       int64
+}
+
+type CommitArgs struct {
+	BaseParams BaseParams
+	Bck        cmn.Bck
+	Object     string
+	Handle     string
+	Cksum      *cmn.Cksum
+}
+
 // HeadObject API
 //
 // Returns the size and version of the object specified by bucket/object
@@ -103,8 +123,12 @@ func HeadObject(baseParams BaseParams, bck cmn.Bck, object string, checkExists .
 		return nil, err
 	}
 
+	return objPropsFromHdr(resp.Header)
+}
+
+func objPropsFromHdr(hdr http.Header) (*cmn.ObjectProps, error) {
 	objProps := &cmn.ObjectProps{}
-	if ecStr := resp.Header.Get(cmn.HeaderObjECMeta); ecStr != "" {
+	if ecStr := hdr.Get(cmn.HeaderObjECMeta); ecStr != "" {
 		md, err := ec.StringToMeta(ecStr)
 		if err != nil {
 			return nil, err
@@ -113,8 +137,16 @@ func HeadObject(baseParams BaseParams, bck cmn.Bck, object string, checkExists .
 		objProps.ParitySlices = md.Parity
 		objProps.IsECCopy = md.IsCopy
 	}
-	err = cmn.IterFields(objProps, func(tag string, field cmn.IterField) (error, bool) {
-		return field.SetValue(resp.Header.Get(tag), true /*force*/), false
+	if customMD := hdr[http.CanonicalHeaderKey(cmn.HeaderObjCustomMD)]; len(customMD) > 0 {
+		objProps.CustomMD = make(cmn.SimpleKVs, len(customMD))
+		for _, kv := range customMD {
+			if entry := strings.SplitN(kv, "=", 2); len(entry) == 2 {
+				objProps.CustomMD[entry[0]] = entry[1]
+			}
+		}
+	}
+	err := cmn.IterFields(objProps, func(tag string, field cmn.IterField) (error, bool) {
+		return field.SetValue(hdr.Get(tag), true /*force*/), false
 	}, cmn.IterOpts{OnlyRead: false})
 	if err != nil {
 		return nil, err
@@ -122,6 +154,25 @@ func HeadObject(baseParams BaseParams, bck cmn.Bck, object string, checkExists .
 	return objProps, nil
 }
 
+// WaitForObject API
+//
+// Blocks until the object specified by bucket/object appears in the cluster or
+// the timeout expires - in the latter case, returns cmn.HTTPError with
+// http.StatusNotFound. The target caps the timeout at 10 minutes. NOTE: the
+// HTTP client's timeout (if any) must exceed the specified timeout.
+func WaitForObject(baseParams BaseParams, bck cmn.Bck, object string, timeout time.Duration) error {
+	baseParams.Method = http.MethodHead
+	query := make(url.Values)
+	query.Add(cmn.URLParamCheckExists, "true")
+	query.Add(cmn.URLParamWaitTimeout, timeout.String())
+	query = cmn.AddBckToQuery(query, bck)
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Objects, bck.Name, object),
+		Query:      query,
+	})
+}
+
 // DeleteObject API
 //
 // Deletes an object specified by bucket/object
@@ -178,6 +229,91 @@ func GetObject(baseParams BaseParams, bck cmn.Bck, object string, options ...Get
 	return resp.n, nil
 }
 
+// GetObjectWithProps API
+//
+// Same as GetObject but, in addition, returns all the object's properties -
+// the same ones that HeadObject does - that come in the response header,
+// thus saving the HEAD request that otherwise precedes the GET.
+func GetObjectWithProps(baseParams BaseParams, bck cmn.Bck, object string,
+	options ...GetObjectInput) (n int64, objProps *cmn.ObjectProps, err error) {
+	var (
+		w   = ioutil.Discard
+		q   = make(url.Values, 4)
+		hdr http.Header
+	)
+	if len(options) != 0 {
+		var oq url.Values
+		w, oq, hdr = getObjectOptParams(options[0])
+		for k, v := range oq {
+			q[k] = v
+		}
+	}
+	q.Set(cmn.URLParamObjProps, "true")
+	q = cmn.AddBckToQuery(q, bck)
+	baseParams.Method = http.MethodGet
+	resp, err := doHTTPRequestGetResp(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Objects, bck.Name, object),
+		Query:      q,
+		Header:     hdr,
+	}, w)
+	if err != nil {
+		return 0, nil, err
+	}
+	if objProps, err = objPropsFromHdr(resp.Header); err != nil {
+		return resp.n, nil, err
+	}
+	return resp.n, objProps, nil
+}
+
+// GetObjectVersion API
+//
+// Same as GetObject but gets a given version of the object in a cloud bucket
+// with versioning enabled on the cloud side (the version is then kept and
+// served locally, separately from the object itself).
+func GetObjectVersion(baseParams BaseParams, bck cmn.Bck, object, version string,
+	options ...GetObjectInput) (n int64, err error) {
+	return getObjectVersion(baseParams, bck, object, cmn.URLParamObjVersion, version, options...)
+}
+
+// GetObjectAsOf API
+//
+// Same as GetObjectVersion but gets the version of the object that was current
+// at a given time.
+func GetObjectAsOf(baseParams BaseParams, bck cmn.Bck, object string, asOf time.Time,
+	options ...GetObjectInput) (n int64, err error) {
+	return getObjectVersion(baseParams, bck, object, cmn.URLParamAsOf, cmn.UnixNano2S(asOf.UnixNano()), options...)
+}
+
+func getObjectVersion(baseParams BaseParams, bck cmn.Bck, object, param, value string,
+	options ...GetObjectInput) (n int64, err error) {
+	var (
+		w   = ioutil.Discard
+		q   = make(url.Values, 4)
+		hdr http.Header
+	)
+	if len(options) != 0 {
+		var oq url.Values
+		w, oq, hdr = getObjectOptParams(options[0])
+		for k, v := range oq {
+			q[k] = v
+		}
+	}
+	q.Set(param, value)
+	q = cmn.AddBckToQuery(q, bck)
+	baseParams.Method = http.MethodGet
+	resp, err := doHTTPRequestGetResp(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Objects, bck.Name, object),
+		Query:      q,
+		Header:     hdr,
+	}, w)
+	if err != nil {
+		return 0, err
+	}
+	return resp.n, nil
+}
+
 // GetObjectWithValidation API
 //
 // Same behavior as GetObject, but performs checksum validation of the object
@@ -288,6 +424,9 @@ func PutObject(args PutObjectArgs) (err error) {
 			}
 			req.Header.Set(cmn.HeaderObjCksumVal, ckVal)
 		}
+		if args.ECPolicy != "" {
+			req.Header.Set(cmn.HeaderObjECPolicy, args.ECPolicy)
+		}
 		if args.Size != 0 {
 			req.ContentLength = int64(args.Size) // as per https://tools.ietf.org/html/rfc7230#section-3.3.2
 		}
@@ -418,6 +557,79 @@ func FlushObject(args FlushArgs) (err error) {
 	})
 }
 
+// WriteObjectAt API
+//
+// Writes a segment of the object at a given offset. The first call (with an empty
+// handle) creates the object's workfile and returns the handle that must be passed
+// to all subsequent `WriteObjectAt` and `CommitObject` requests. Once the handle is
+// known, segments can be written concurrently and in any order.
+//
+// NOTE: Until `CommitObject` is called one cannot access the object yet as
+// it is yet not fully operational.
+func WriteObjectAt(args WriteAtArgs) (handle string, err error) {
+	query := make(url.Values)
+	query.Add(cmn.URLParamAppendType, cmn.WriteAtOp)
+	query.Add(cmn.URLParamAppendHandle, args.Handle)
+	query.Add(cmn.URLParamOffset, strconv.FormatInt(args.Offset, 10))
+	query = cmn.AddBckToQuery(query, args.Bck)
+
+	reqArgs := cmn.ReqArgs{
+		Method: http.MethodPut,
+		Base:   args.BaseParams.URL,
+		Path:   cmn.URLPath(cmn.Version, cmn.Objects, args.Bck.Name, args.Object),
+		Query:  query,
+		BodyR:  args.Reader,
+	}
+
+	newRequest := func(reqArgs cmn.ReqArgs) (*http.Request, error) {
+		req, err := reqArgs.Req()
+		if err != nil {
+			return nil, cmn.NewFailedToCreateHTTPRequest(err)
+		}
+
+		req.GetBody = args.Reader.Open
+		if args.Size != 0 {
+			req.ContentLength = args.Size // as per https://tools.ietf.org/html/rfc7230#section-3.3.2
+		}
+
+		setAuthToken(req, args.BaseParams)
+		return req, nil
+	}
+
+	resp, err := DoReqWithRetry(args.BaseParams.Client, newRequest, reqArgs) // nolint:bodyclose // it's closed inside
+	if err != nil {
+		return "", fmt.Errorf("failed to %s, err: %v", http.MethodPut, err)
+	}
+	return resp.Header.Get(cmn.HeaderAppendHandle), err
+}
+
+// CommitObject API
+//
+// Commit should occur once all segments have been written successfully.
+// This call atomically publishes a fully operational object and requires handle to be set.
+// If provided, the checksum is validated against the content of the resulting object.
+func CommitObject(args CommitArgs) (err error) {
+	query := make(url.Values)
+	query.Add(cmn.URLParamAppendType, cmn.CommitOp)
+	query.Add(cmn.URLParamAppendHandle, args.Handle)
+	query = cmn.AddBckToQuery(query, args.Bck)
+
+	var header http.Header
+	if args.Cksum != nil {
+		header = make(http.Header)
+		header.Set(cmn.HeaderObjCksumType, args.Cksum.Type())
+		header.Set(cmn.HeaderObjCksumVal, args.Cksum.Value())
+	}
+
+	args.BaseParams.Method = http.MethodPut
+	return DoHTTPRequest(ReqParams{
+		BaseParams: args.BaseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Objects, args.Bck.Name, args.Object),
+		Query:      query,
+		Header:     header,
+	})
+}
+
 // RenameObject API
 //
 // Creates a cmn.ActionMsg with the new name of the object
@@ -433,6 +645,58 @@ func RenameObject(baseParams BaseParams, bck cmn.Bck, oldName, newName string) e
 	})
 }
 
+// CopyRemoteObject API
+//
+// Pulls object `srcObj` from the bucket `srcBck` of attached remote AIS cluster
+// (srcBck.Ns.UUID being the remote cluster's UUID or alias) and stores it as
+// `bck/objName` - the target that owns the latter reads the object directly
+// from the remote target that owns the former.
+func CopyRemoteObject(baseParams BaseParams, srcBck cmn.Bck, srcObj string, bck cmn.Bck, objName string) error {
+	baseParams.Method = http.MethodPost
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Objects, bck.Name, objName),
+		Body: cmn.MustMarshal(cmn.ActionMsg{
+			Action: cmn.ActCopyRemote,
+			Value:  &cmn.ActValCopyRemote{Bck: srcBck, ObjName: srcObj},
+		}),
+		Query: cmn.AddBckToQuery(nil, bck),
+	})
+}
+
+// UndeleteObject API
+//
+// Restores the object that was deleted last from the bucket's trash (see
+// cmn.TrashConf) - the bucket must be an ais bucket with trash enabled
+// at the time of deletion.
+func UndeleteObject(baseParams BaseParams, bck cmn.Bck, objName string) error {
+	baseParams.Method = http.MethodPost
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Objects, bck.Name, objName),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActUndelete}),
+		Query:      cmn.AddBckToQuery(nil, bck),
+	})
+}
+
+// ReprotectObject API
+//
+// Rebuilds the EC protection of the object from scratch - e.g., after the
+// object's file has been restored manually: removes its existing replicas
+// (slices) and erasure codes the object anew. Returns where the new replicas
+// (slices) go.
+func ReprotectObject(baseParams BaseParams, bck cmn.Bck, objName string) (placement *cmn.ECPlacement, err error) {
+	baseParams.Method = http.MethodPost
+	placement = &cmn.ECPlacement{}
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Objects, bck.Name, objName),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActECReprotect}),
+		Query:      cmn.AddBckToQuery(nil, bck),
+	}, placement)
+	return placement, err
+}
+
 // PromoteFileOrDir API
 //
 // promote AIS-colocated files and directories to objects (NOTE: advanced usage only)
@@ -112,6 +112,7 @@ func readResp(reqParams ReqParams, resp *http.Response, v interface{}) (*wrapped
 				URLPath: reqParams.Path,
 			}
 		}
+		httpErr.SetCode(cmn.ErrCodeFromStatus(resp.StatusCode))
 		return nil, httpErr
 	}
 	wresp := &wrappedResp{Response: resp}
@@ -27,6 +27,7 @@ type (
 		Bck     cmn.Bck // Optional bucket
 		Latest  bool    // Determines if we should get latest or all xactions
 		Timeout time.Duration
+		Tags    cmn.XactTags // start: tags to attach; query: tags to match (see cmn.XactTags)
 	}
 )
 
@@ -76,6 +77,7 @@ func StartXaction(baseParams BaseParams, args XactReqArgs) (id string, err error
 		Value: cmn.XactReqMsg{
 			Kind: args.Kind,
 			Bck:  args.Bck,
+			Tags: args.Tags,
 		},
 	}
 	baseParams.Method = http.MethodPut
@@ -109,6 +111,41 @@ func AbortXaction(baseParams BaseParams, args XactReqArgs) error {
 	})
 }
 
+// PauseXaction API
+//
+// PauseXaction pauses a given running xaction that can be paused (see
+// cmn.XactMetadata.Pausable) - on all targets and without losing progress.
+func PauseXaction(baseParams BaseParams, args XactReqArgs) error {
+	return pauseResumeXaction(baseParams, args, cmn.ActXactPause)
+}
+
+// ResumeXaction API
+//
+// ResumeXaction resumes a given paused xaction.
+func ResumeXaction(baseParams BaseParams, args XactReqArgs) error {
+	return pauseResumeXaction(baseParams, args, cmn.ActXactResume)
+}
+
+func pauseResumeXaction(baseParams BaseParams, args XactReqArgs, action string) error {
+	if !cmn.XactsMeta[args.Kind].Pausable {
+		return fmt.Errorf("cannot %s \"kind=%s\" xaction", action, args.Kind)
+	}
+	msg := cmn.ActionMsg{
+		Action: action,
+		Value: cmn.XactReqMsg{
+			Kind: args.Kind,
+			Bck:  args.Bck,
+		},
+	}
+	baseParams.Method = http.MethodPut
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Cluster),
+		Body:       cmn.MustMarshal(msg),
+		Query:      cmn.AddBckToQuery(nil, args.Bck),
+	})
+}
+
 // GetXactionStatsByID API
 //
 // GetXactionStatsByID gets all xaction stats for given id.
@@ -124,6 +161,23 @@ func GetXactionStatsByID(baseParams BaseParams, id string) (xactStats NodesXactS
 	return xactStats, err
 }
 
+// GetXactionProgress API
+//
+// GetXactionProgress gets the cluster-wide progress of the bucket xaction with
+// the given id (see cmn.XactProgress)
+func GetXactionProgress(baseParams BaseParams, id string) (prog *cmn.XactProgress, err error) {
+	baseParams.Method = http.MethodGet
+	prog = &cmn.XactProgress{}
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Cluster),
+		Query: url.Values{cmn.URLParamWhat: []string{cmn.GetWhatXactProgress},
+			cmn.URLParamUUID: []string{id},
+		},
+	}, prog)
+	return prog, err
+}
+
 // QueryXactionStats API
 //
 // QueryXactionStats gets all xaction stats for given kind and bucket (optional).
@@ -132,6 +186,7 @@ func QueryXactionStats(baseParams BaseParams, args XactReqArgs) (xactStats Nodes
 		ID:   args.ID,
 		Kind: args.Kind,
 		Bck:  args.Bck,
+		Tags: args.Tags,
 	}
 	if args.Latest {
 		msg.OnlyRunning = Bool(true)
@@ -146,6 +201,53 @@ func QueryXactionStats(baseParams BaseParams, args XactReqArgs) (xactStats Nodes
 	return xactStats, err
 }
 
+// GetXactionHistory API
+//
+// GetXactionHistory returns the finished xactions, as recorded by the targets
+// (see cmn.XactHistoryConf), that match a given ID, kind, and bucket (all
+// optional) - in the order the xactions finished.
+func GetXactionHistory(baseParams BaseParams, args XactReqArgs) (recs []*cmn.XactRecord, err error) {
+	msg := cmn.XactReqMsg{
+		ID:   args.ID,
+		Kind: args.Kind,
+		Bck:  args.Bck,
+	}
+	baseParams.Method = http.MethodGet
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Cluster),
+		Body:       cmn.MustMarshal(msg),
+		Query:      url.Values{cmn.URLParamWhat: []string{cmn.GetWhatXactHistory}},
+	}, &recs)
+	return recs, err
+}
+
+// GetXactionSummary API
+//
+// GetXactionSummary returns the cluster-wide summaries of the xactions that
+// match a given ID, kind, and bucket (all optional): the stats of each xaction
+// reported by the targets, merged - including the slowest target and the
+// targets that lag behind the rest (see cmn.MergeXactStats).
+func GetXactionSummary(baseParams BaseParams, args XactReqArgs) (summaries []*cmn.XactSummary, err error) {
+	msg := cmn.XactReqMsg{
+		ID:   args.ID,
+		Kind: args.Kind,
+		Bck:  args.Bck,
+		Tags: args.Tags,
+	}
+	if args.Latest {
+		msg.OnlyRunning = Bool(true)
+	}
+	baseParams.Method = http.MethodGet
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Cluster),
+		Body:       cmn.MustMarshal(msg),
+		Query:      url.Values{cmn.URLParamWhat: []string{cmn.GetWhatXactSummary}},
+	}, &summaries)
+	return summaries, err
+}
+
 // WaitForXaction API
 //
 // WaitForXaction waits for a given xaction to complete.
@@ -214,11 +316,28 @@ func WaitForXactionToStart(baseParams BaseParams, args XactReqArgs) error {
 // MakeNCopies API
 //
 // MakeNCopies starts an extended action (xaction) to bring a given bucket to a certain redundancy level (num copies)
-func MakeNCopies(baseParams BaseParams, bck cmn.Bck, copies int) error {
+func MakeNCopies(baseParams BaseParams, bck cmn.Bck, copies int, tags ...cmn.XactTags) error {
 	baseParams.Method = http.MethodPost
 	return DoHTTPRequest(ReqParams{
 		BaseParams: baseParams,
 		Path:       cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name),
-		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActMakeNCopies, Value: copies}),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActMakeNCopies, Value: copies, Tags: optTags(tags)}),
 	})
 }
+
+// MakeNCopiesAfter API
+//
+// MakeNCopiesAfter is MakeNCopies that starts upon successful completion of the
+// xaction (or asynchronous job) with a given UUID; returns the UUID of the job
+// right away (see GetJobStatus)
+func MakeNCopiesAfter(baseParams BaseParams, bck cmn.Bck, copies int, waitFor string,
+	tags ...cmn.XactTags) (uuid string, err error) {
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPath(cmn.Version, cmn.Buckets, bck.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActMakeNCopies, Value: copies, Tags: optTags(tags)}),
+		Query:      url.Values{cmn.URLParamWaitFor: []string{waitFor}},
+	}, &uuid)
+	return
+}
@@ -26,6 +26,7 @@ type (
 	NameLockPair struct {
 		uname string
 		nlc   *nlc
+		hid   uint64 // lock holder (see nameLocker)
 	}
 )
 
@@ -230,22 +231,22 @@ func (b *Bck) GetNameLockPair() (nlp NameLockPair) {
 
 const nlpTryDuration = 5 * time.Second
 
-func (nlp *NameLockPair) Lock()          { nlp.nlc.Lock(nlp.uname, true) }
-func (nlp *NameLockPair) Unlock()        { nlp.nlc.Unlock(nlp.uname, true) }
+func (nlp *NameLockPair) Lock()          { nlp.hid = nlp.nlc.Lock(nlp.uname, true) }
+func (nlp *NameLockPair) Unlock()        { nlp.nlc.Unlock(nlp.uname, true, nlp.hid) }
 func (nlp *NameLockPair) TryLock() bool  { return nlp.withRetry(nlpTryDuration, true) }
 func (nlp *NameLockPair) TryRLock() bool { return nlp.withRetry(nlpTryDuration, false) }
-func (nlp *NameLockPair) RUnlock()       { nlp.nlc.Unlock(nlp.uname, false) }
+func (nlp *NameLockPair) RUnlock()       { nlp.nlc.Unlock(nlp.uname, false, nlp.hid) }
 
-func (nlp *NameLockPair) withRetry(d time.Duration, exclusive bool) bool {
-	if nlp.nlc.TryLock(nlp.uname, exclusive) {
-		return true
+func (nlp *NameLockPair) withRetry(d time.Duration, exclusive bool) (ok bool) {
+	if nlp.hid, ok = nlp.nlc.TryLock(nlp.uname, exclusive); ok {
+		return
 	}
 	i := d / 10
 	for j := i; j < d; j += i {
 		time.Sleep(i)
-		if nlp.nlc.TryLock(nlp.uname, exclusive) {
-			return true
+		if nlp.hid, ok = nlp.nlc.TryLock(nlp.uname, exclusive); ok {
+			return
 		}
 	}
-	return false
+	return
 }
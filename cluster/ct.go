@@ -65,7 +65,7 @@ func NewCTFromBO(bckName, bckProvider, objName string, b Bowner, ctType ...strin
 			return
 		}
 	}
-	ct.parsedFQN.MpathInfo, ct.parsedFQN.Digest, err = HrwMpath(ct.bck.MakeUname(objName))
+	ct.parsedFQN.MpathInfo, ct.parsedFQN.Digest, err = HrwMpathBck(ct.bck, objName)
 	if err != nil {
 		return
 	}
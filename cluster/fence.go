@@ -0,0 +1,67 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+type (
+	// PutTracker is implemented by the bucket-level xactions that, given the
+	// bucket's cmn.FenceTrack fencing mode, process the objects PUT while they
+	// walk the bucket (the walk may or may not have visited those objects)
+	PutTracker interface {
+		// returns false if it's too late: the xaction has closed its tracked PUTs
+		TrackPut(lom *LOM) bool
+		// processes the object that could not be tracked (see above)
+		ProcessPut(lom *LOM) error
+	}
+
+	// TrackedPuts - names of the objects PUT during the walk (see PutTracker)
+	TrackedPuts struct {
+		mtx    sync.Mutex
+		names  cmn.StringSet
+		closed bool
+	}
+)
+
+// Add returns false once closed
+func (tp *TrackedPuts) Add(objName string) (added bool) {
+	tp.mtx.Lock()
+	if !tp.closed {
+		if tp.names == nil {
+			tp.names = make(cmn.StringSet, 16)
+		}
+		tp.names.Add(objName)
+		added = true
+	}
+	tp.mtx.Unlock()
+	return
+}
+
+// Drain returns the names tracked so far and forgets them
+func (tp *TrackedPuts) Drain() (names []string) {
+	tp.mtx.Lock()
+	if len(tp.names) > 0 {
+		names = tp.names.Keys()
+		tp.names = nil
+	}
+	tp.mtx.Unlock()
+	return
+}
+
+// Close stops tracking and returns the names that remain to be processed
+func (tp *TrackedPuts) Close() (names []string) {
+	tp.mtx.Lock()
+	if len(tp.names) > 0 {
+		names = tp.names.Keys()
+		tp.names = nil
+	}
+	tp.closed = true
+	tp.mtx.Unlock()
+	return
+}
@@ -30,11 +30,8 @@ func ResolveFQN(fqn string) (parsedFQN fs.ParsedFQN, hrwFQN string, err error) {
 }
 
 func HrwFQN(bck *Bck, contentType, objName string) (fqn string, digest uint64, err error) {
-	var (
-		mpathInfo *fs.MountpathInfo
-		uname     = bck.MakeUname(objName)
-	)
-	if mpathInfo, digest, err = HrwMpath(uname); err == nil {
+	var mpathInfo *fs.MountpathInfo
+	if mpathInfo, digest, err = HrwMpathBck(bck, objName); err == nil {
 		fqn = fs.CSM.FQN(mpathInfo, bck.Bck, contentType, objName)
 	}
 	return
@@ -75,6 +75,61 @@ func HrwTargetList(uname string, smap *Smap, count int) (sis Nodes, err error) {
 	return
 }
 
+// HrwTargetDomainList is a failure-domain aware variant of HrwTargetList: it first
+// selects the top-weighted target from each failure domain (so that no two selected
+// targets share the domain), and only if there are fewer domains than requested
+// falls back to the remaining targets in their HRW order. The first target in the
+// list is always the same as the one returned by HrwTarget.
+// Targets without a failure-domain label are considered domains of their own.
+func HrwTargetDomainList(uname string, smap *Smap, count int) (sis Nodes, err error) {
+	cmn.Assert(count > 0)
+	cnt := smap.CountTargets()
+	if cnt < count {
+		err = fmt.Errorf("insufficient targets (%d > %d)", count, smap.CountTargets())
+		return
+	}
+	var (
+		arr     = make([]tsi, 0, cnt)
+		rest    = make([]tsi, 0, cnt)
+		domains = make(map[string]struct{}, cnt)
+		digest  = xxhash.ChecksumString64S(uname, cmn.MLCG32)
+	)
+	sis = make(Nodes, 0, count)
+	for _, sinfo := range smap.Tmap {
+		cs := xoshiro256.Hash(sinfo.idDigest ^ digest)
+		arr = append(arr, tsi{sinfo, cs})
+	}
+	sort.Slice(arr, func(i, j int) bool { return arr[i].hash > arr[j].hash })
+	for _, t := range arr {
+		domain := t.node.Domain
+		if domain == "" {
+			domain = t.node.ID()
+		}
+		if _, ok := domains[domain]; ok {
+			rest = append(rest, t)
+			continue
+		}
+		domains[domain] = struct{}{}
+		if len(sis) < count {
+			sis = append(sis, t.node)
+		}
+	}
+	// fallback: not enough failure domains
+	for i := 0; len(sis) < count; i++ {
+		sis = append(sis, rest[i].node)
+	}
+	return
+}
+
+// HrwTargetListEC returns the list of targets for object's slices and replicas
+// in accordance with the bucket's EC placement policy (see cmn.ECConf)
+func HrwTargetListEC(uname string, smap *Smap, count int, conf *cmn.ECConf) (sis Nodes, err error) {
+	if conf.Placement == cmn.ECPlacementDomain {
+		return HrwTargetDomainList(uname, smap, count)
+	}
+	return HrwTargetList(uname, smap, count)
+}
+
 func HrwProxy(smap *Smap, idToSkip string) (pi *Snode, err error) {
 	var (
 		max     uint64
@@ -122,6 +177,20 @@ func HrwTargetTask(uuid string, smap *Smap) (si *Snode, err error) {
 }
 
 func HrwMpath(uname string) (mi *fs.MountpathInfo, digest uint64, err error) {
+	return hrwMpath(uname, nil)
+}
+
+// HrwMpathBck is HrwMpath that abides by the bucket's placement constraints
+// (see cmn.PlacementConf) - to select the mountpath for the bucket's content
+func HrwMpathBck(bck *Bck, objName string) (mi *fs.MountpathInfo, digest uint64, err error) {
+	var placement *cmn.PlacementConf
+	if bck.Props != nil && bck.Props.Placement.Enabled() {
+		placement = &bck.Props.Placement
+	}
+	return hrwMpath(bck.MakeUname(objName), placement)
+}
+
+func hrwMpath(uname string, placement *cmn.PlacementConf) (mi *fs.MountpathInfo, digest uint64, err error) {
 	var (
 		max               uint64
 		availablePaths, _ = fs.Mountpaths.Get()
@@ -132,12 +201,42 @@ func HrwMpath(uname string) (mi *fs.MountpathInfo, digest uint64, err error) {
 	}
 	digest = xxhash.ChecksumString64S(uname, cmn.MLCG32)
 	for _, mpathInfo := range availablePaths {
+		if placement != nil && !placement.Allows(mpathInfo.Label) {
+			continue
+		}
 		cs := xoshiro256.Hash(mpathInfo.PathDigest ^ digest)
 		if cs >= max {
 			max = cs
 			mi = mpathInfo
 		}
 	}
+	if mi == nil { // none of the mountpaths is labeled as required
+		return hrwMpath(uname, nil)
+	}
+	return
+}
+
+// HrwMpathExcluding is HrwMpath that disregards a given mountpath - e.g., the
+// one that is about to be removed
+func HrwMpathExcluding(uname, excluded string) (mi *fs.MountpathInfo, err error) {
+	var (
+		max               uint64
+		availablePaths, _ = fs.Mountpaths.Get()
+		digest            = xxhash.ChecksumString64S(uname, cmn.MLCG32)
+	)
+	for _, mpathInfo := range availablePaths {
+		if mpathInfo.Path == excluded {
+			continue
+		}
+		cs := xoshiro256.Hash(mpathInfo.PathDigest ^ digest)
+		if cs >= max {
+			max = cs
+			mi = mpathInfo
+		}
+	}
+	if mi == nil {
+		err = errors.New(cmn.NoMountpaths)
+	}
 	return
 }
 
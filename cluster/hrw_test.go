@@ -0,0 +1,231 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("HRW", func() {
+	newSmap := func(domains ...string) *Smap {
+		smap := &Smap{Tmap: make(NodeMap, len(domains))}
+		for i, domain := range domains {
+			si := &Snode{DaemonID: fmt.Sprintf("t%d", i), DaemonType: cmn.Target, Domain: domain}
+			si.Digest()
+			smap.Tmap[si.ID()] = si
+		}
+		return smap
+	}
+
+	Describe("HrwTargetDomainList", func() {
+		It("should place targets in distinct failure domains", func() {
+			smap := newSmap("r1", "r1", "r1", "r2", "r2", "r2", "r3", "r3", "r3")
+			for i := 0; i < 100; i++ {
+				uname := fmt.Sprintf("bck/obj%d", i)
+				sis, err := HrwTargetDomainList(uname, smap, 3)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sis).To(HaveLen(3))
+
+				domains := make(map[string]struct{}, 3)
+				for _, si := range sis {
+					domains[si.Domain] = struct{}{}
+				}
+				Expect(domains).To(HaveLen(3))
+
+				si, err := HrwTarget(uname, smap)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(sis[0].ID()).To(Equal(si.ID()))
+			}
+		})
+
+		It("should fall back to HRW when there are not enough failure domains", func() {
+			smap := newSmap("r1", "r1", "r1", "r2", "r2")
+			sis, err := HrwTargetDomainList("bck/obj", smap, 4)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sis).To(HaveLen(4))
+			Expect(sis[0].Domain).NotTo(Equal(sis[1].Domain))
+
+			ids := make(map[string]struct{}, 4)
+			for _, si := range sis {
+				ids[si.ID()] = struct{}{}
+			}
+			Expect(ids).To(HaveLen(4))
+		})
+
+		It("should consider unlabeled targets as distinct domains", func() {
+			smap := newSmap("", "", "", "")
+			sis, err := HrwTargetDomainList("bck/obj", smap, 4)
+			Expect(err).NotTo(HaveOccurred())
+			hrw, err := HrwTargetList("bck/obj", smap, 4)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sis).To(Equal(hrw))
+		})
+
+		It("should fail when there are not enough targets", func() {
+			smap := newSmap("r1", "r2")
+			_, err := HrwTargetDomainList("bck/obj", smap, 3)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("HrwMpathBck", func() {
+		const tmpDir = "/tmp/hrw_test"
+		var oldMountpaths *fs.MountedFS
+
+		BeforeEach(func() {
+			oldMountpaths = fs.Mountpaths
+			fs.Mountpaths = fs.NewMountedFS()
+			fs.Mountpaths.DisableFsIDCheck()
+			fs.Mountpaths.SetLabels(map[string]string{
+				tmpDir + "/mp0": "ssd", tmpDir + "/mp1": "ssd", tmpDir + "/mp2": "hdd",
+			})
+			for i := 0; i < 4; i++ {
+				mpath := fmt.Sprintf("%s/mp%d", tmpDir, i)
+				Expect(cmn.CreateDir(mpath)).NotTo(HaveOccurred())
+				Expect(fs.Mountpaths.Add(mpath)).NotTo(HaveOccurred())
+			}
+		})
+
+		AfterEach(func() {
+			fs.Mountpaths = oldMountpaths
+			os.RemoveAll(tmpDir)
+		})
+
+		It("should place on the mountpaths with the given labels", func() {
+			bck := NewBck("bck", cmn.ProviderAIS, cmn.NsGlobal, &cmn.BucketProps{
+				Placement: cmn.PlacementConf{Labels: "ssd"},
+			})
+			mpaths := make(map[string]struct{})
+			for i := 0; i < 100; i++ {
+				mi, _, err := HrwMpathBck(bck, fmt.Sprintf("obj%d", i))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mi.Label).To(Equal("ssd"))
+				mpaths[mi.Path] = struct{}{}
+			}
+			Expect(mpaths).To(HaveLen(2))
+		})
+
+		It("should be the same as HrwMpath without placement constraints", func() {
+			bck := NewBck("bck", cmn.ProviderAIS, cmn.NsGlobal, &cmn.BucketProps{})
+			for i := 0; i < 100; i++ {
+				objName := fmt.Sprintf("obj%d", i)
+				mi, _, err := HrwMpathBck(bck, objName)
+				Expect(err).NotTo(HaveOccurred())
+				hrw, _, err := HrwMpath(bck.MakeUname(objName))
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mi.Path).To(Equal(hrw.Path))
+			}
+		})
+
+		It("should find the objects misplaced once the bucket's placement changes", func() {
+			_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+			var (
+				bck   = NewBck("bck", cmn.ProviderAIS, cmn.NsGlobal, &cmn.BucketProps{})
+				tMock = NewTargetMock(NewBaseBownerMock(bck))
+				fqns  = make([]string, 0, 100)
+			)
+			for i := 0; i < 100; i++ {
+				lom := &LOM{T: tMock, ObjName: fmt.Sprintf("obj%d", i)}
+				Expect(lom.Init(bck.Bck)).NotTo(HaveOccurred())
+				fqns = append(fqns, lom.FQN)
+			}
+
+			// the placement changes on the live bucket - resilver visits the objects where they are
+			bck.Props.Placement = cmn.PlacementConf{Labels: "ssd"}
+			var misplaced int
+			for _, fqn := range fqns {
+				lom := &LOM{T: tMock, FQN: fqn}
+				Expect(lom.Init(cmn.Bck{})).NotTo(HaveOccurred())
+				hrw, _, err := ResolveFQN(lom.HrwFQN)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hrw.MpathInfo.Label).To(Equal("ssd"))
+				if lom.ParsedFQN.MpathInfo.Label != "ssd" {
+					Expect(lom.IsHRW()).To(BeFalse())
+					misplaced++
+				}
+			}
+			Expect(misplaced).NotTo(BeZero())
+		})
+
+		It("should use all mountpaths when none has the given labels", func() {
+			bck := NewBck("bck", cmn.ProviderAIS, cmn.NsGlobal, &cmn.BucketProps{
+				Placement: cmn.PlacementConf{Labels: "nvme, scratch"},
+			})
+			mi, _, err := HrwMpathBck(bck, "obj")
+			Expect(err).NotTo(HaveOccurred())
+			hrw, _, err := HrwMpath(bck.MakeUname("obj"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mi.Path).To(Equal(hrw.Path))
+		})
+	})
+
+	Describe("HrwMpathExcluding", func() {
+		const tmpDir = "/tmp/hrw_excl_test"
+		var oldMountpaths *fs.MountedFS
+
+		BeforeEach(func() {
+			oldMountpaths = fs.Mountpaths
+			fs.Mountpaths = fs.NewMountedFS()
+			fs.Mountpaths.DisableFsIDCheck()
+		})
+
+		AfterEach(func() {
+			fs.Mountpaths = oldMountpaths
+			os.RemoveAll(tmpDir)
+		})
+
+		addMpaths := func(n int) {
+			for i := 0; i < n; i++ {
+				mpath := fmt.Sprintf("%s/mp%d", tmpDir, i)
+				Expect(cmn.CreateDir(mpath)).NotTo(HaveOccurred())
+				Expect(fs.Mountpaths.Add(mpath)).NotTo(HaveOccurred())
+			}
+		}
+
+		It("should move only the content of the excluded mountpath", func() {
+			addMpaths(4)
+			var (
+				excluded = tmpDir + "/mp1"
+				moved    int
+				placed   = make(map[string]string, 100)
+			)
+			for i := 0; i < 100; i++ {
+				uname := fmt.Sprintf("bck/obj%d", i)
+				hrw, _, err := HrwMpath(uname)
+				Expect(err).NotTo(HaveOccurred())
+				mi, err := HrwMpathExcluding(uname, excluded)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(mi.Path).NotTo(Equal(excluded))
+				placed[uname] = mi.Path
+				if hrw.Path == excluded {
+					moved++
+				} else {
+					Expect(mi.Path).To(Equal(hrw.Path))
+				}
+			}
+			Expect(moved).NotTo(BeZero())
+
+			// once the mountpath is gone, the content is where HrwMpath expects it
+			Expect(fs.Mountpaths.Remove(excluded)).NotTo(HaveOccurred())
+			for uname, mpath := range placed {
+				hrw, _, err := HrwMpath(uname)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(hrw.Path).To(Equal(mpath))
+			}
+		})
+
+		It("should fail when no other mountpath is available", func() {
+			addMpaths(1)
+			_, err := HrwMpathExcluding("bck/obj", tmpDir+"/mp0")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -39,7 +39,7 @@ const fmtNestedErr = "nested err: %v"
 const lomInitialVersion = "1"
 
 type (
-	// NOTE: sizeof(lmeta) = 88 as of 5/26
+	// NOTE: sizeof(lmeta) = 96 - with the access statistics (see lom_access.go)
 	lmeta struct {
 		uname    string
 		version  string
@@ -50,6 +50,7 @@ type (
 		cksum    *cmn.Cksum // ReCache(ref)
 		copies   fs.MPI     // ditto
 		customMD cmn.SimpleKVs
+		access   *laccess // ditto (see lom_access.go)
 	}
 	LOM struct {
 		md      lmeta  // local meta
@@ -62,6 +63,7 @@ type (
 		config    *cmn.Config
 		ParsedFQN fs.ParsedFQN // redundant in-part; tradeoff to speed-up workfile name gen, etc.
 		loaded    bool
+		hid       uint64 // lock holder (see nameLocker)
 	}
 
 	ObjectFilter func(*LOM) bool
@@ -160,6 +162,9 @@ func (lom *LOM) PopulateHdr(hdr http.Header) http.Header {
 	if lom.AtimeUnix() != 0 {
 		hdr.Set(cmn.HeaderObjAtime, cmn.UnixNano2S(lom.AtimeUnix()))
 	}
+	if lom.AccessCount() != 0 {
+		hdr.Set(cmn.HeaderObjAccess, lom.md.packAccess())
+	}
 	for k, v := range lom.CustomMD() {
 		hdr.Add(cmn.HeaderObjCustomMD, strings.Join([]string{k, v}, "="))
 	}
@@ -176,6 +181,11 @@ func (lom *LOM) ParseHdr(hdr http.Header) {
 		atime, _ := cmn.S2UnixNano(atimeEntry)
 		lom.SetAtimeUnix(atime)
 	}
+	if accessEntry := hdr.Get(cmn.HeaderObjAccess); accessEntry != "" {
+		if err := lom.md.unpackAccess(accessEntry); err != nil {
+			glog.Errorf("%s: %v", lom, err)
+		}
+	}
 	if customMD := hdr[http.CanonicalHeaderKey(cmn.HeaderObjCustomMD)]; len(customMD) > 0 {
 		md := make(cmn.SimpleKVs, len(customMD)*2)
 		for _, v := range customMD {
@@ -382,11 +392,24 @@ func (lom *LOM) CopyObject(dstFQN string, buf []byte) (dst *LOM, err error) {
 		workFQN   = fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfilePut)
 		srcCksum  = lom.Cksum()
 		cksumType = cmn.ChecksumNone
+		written   int64
 	)
 	if srcCksum != nil {
 		cksumType = srcCksum.Type()
 	}
-	_, dstCksum, err = cmn.CopyFile(lom.FQN, workFQN, buf, cksumType)
+	// verified-once: trust the source checksum, do not recompute
+	verified := cksumType != cmn.ChecksumNone && lom.CksumConf().VerifiedOnce
+	if verified {
+		written, _, err = cmn.CopyFile(lom.FQN, workFQN, buf, cmn.ChecksumNone)
+		if err == nil && written != lom.Size() {
+			err = fmt.Errorf("%s: copied %d bytes, expected %d", lom, written, lom.Size())
+			if errRemove := cmn.RemoveFile(workFQN); errRemove != nil {
+				glog.Errorf(fmtNestedErr, errRemove)
+			}
+		}
+	} else {
+		_, dstCksum, err = cmn.CopyFile(lom.FQN, workFQN, buf, cksumType)
+	}
 	if err != nil {
 		return
 	}
@@ -404,7 +427,9 @@ func (lom *LOM) CopyObject(dstFQN string, buf []byte) (dst *LOM, err error) {
 		return
 	}
 
-	if cksumType != cmn.ChecksumNone {
+	if verified {
+		dst.SetCksum(srcCksum.Clone())
+	} else if cksumType != cmn.ChecksumNone {
 		if !dstCksum.Equal(lom.Cksum()) {
 			return nil, cmn.NewBadDataCksumError(&dstCksum.Cksum, lom.Cksum())
 		}
@@ -694,8 +719,14 @@ func (lom *LOM) Init(bck cmn.Bck, config ...*cmn.Config) (err error) {
 		return
 	}
 	lom.md.uname = lom.bck.MakeUname(lom.ObjName)
+	if lom.FQN != "" && lom.bck.Props != nil && lom.bck.Props.Placement.Enabled() {
+		// ResolveFQN does not know the bucket's placement constraints
+		if lom.HrwFQN, _, err = HrwFQN(lom.bck, lom.ParsedFQN.ContentType, lom.ObjName); err != nil {
+			return
+		}
+	}
 	if lom.FQN == "" {
-		lom.ParsedFQN.MpathInfo, lom.ParsedFQN.Digest, err = HrwMpath(lom.md.uname)
+		lom.ParsedFQN.MpathInfo, lom.ParsedFQN.Digest, err = HrwMpathBck(lom.bck, lom.ObjName)
 		if err != nil {
 			return
 		}
@@ -745,6 +776,7 @@ func (lom *LOM) Load(adds ...bool) (err error) {
 		lom.md.bckID = lom.Bprops().BID
 		err = lom.checkBucket()
 		if err == nil && add {
+			lom.md.initAccess()
 			md := &lmeta{}
 			*md = lom.md
 			cache.Store(hkey, md)
@@ -780,6 +812,7 @@ func (lom *LOM) ReCache() {
 		cache     = lom.ParsedFQN.MpathInfo.LomCache(idx)
 		md        = &lmeta{}
 	)
+	lom.md.initAccess()
 	*md = lom.md
 	md.bckID = lom.Bprops().BID
 	if md.bckID != 0 {
@@ -903,9 +936,13 @@ func lomCacheCleanup(t Target, d time.Duration) (evictedCnt, totalCnt int) {
 				var (
 					md    = value.(*lmeta)
 					atime = time.Unix(0, md.atime)
+					evict = now.Sub(atime) >= d
 				)
 				total.Add(1)
-				if now.Sub(atime) < d {
+				if md.access.dirty(evict) {
+					flushAccess(t, md, bmd)
+				}
+				if !evict {
 					return true
 				}
 				if md.atime != md.atimefs {
@@ -1015,40 +1052,50 @@ func lomCaches() []*sync.Map {
 
 func getLomLocker(idx int) *nlc { return &lomLocker[idx] }
 
+// LomLocks returns the currently held object locks (optionally, filtered by uname prefix)
+func LomLocks(prefix string) []cmn.LockInfo { return lomLocker.List(prefix) }
+
+// ForceUnlockLOM releases a given (by uname) object lock - see nameLocker.ForceUnlock
+func ForceUnlockLOM(uname string) bool { return lomLocker.ForceUnlock(uname) }
+
 func (lom *LOM) TryLock(exclusive bool) bool {
 	var (
 		_, idx = lom.Hkey()
 		nlc    = getLomLocker(idx)
 	)
-	return nlc.TryLock(lom.Uname(), exclusive)
+	hid, ok := nlc.TryLock(lom.Uname(), exclusive)
+	if ok {
+		lom.hid = hid
+	}
+	return ok
 }
 func (lom *LOM) Lock(exclusive bool) {
 	var (
 		_, idx = lom.Hkey()
 		nlc    = getLomLocker(idx)
 	)
-	nlc.Lock(lom.Uname(), exclusive)
+	lom.hid = nlc.Lock(lom.Uname(), exclusive)
 }
 func (lom *LOM) DowngradeLock() {
 	var (
 		_, idx = lom.Hkey()
 		nlc    = getLomLocker(idx)
 	)
-	nlc.DowngradeLock(lom.Uname())
+	nlc.DowngradeLock(lom.Uname(), lom.hid)
 }
 func (lom *LOM) TryUpgradeLock() bool {
 	var (
 		_, idx = lom.Hkey()
 		nlc    = getLomLocker(idx)
 	)
-	return nlc.TryUpgradeLock(lom.Uname())
+	return nlc.TryUpgradeLock(lom.Uname(), lom.hid)
 }
 func (lom *LOM) Unlock(exclusive bool) {
 	var (
 		_, idx = lom.Hkey()
 		nlc    = getLomLocker(idx)
 	)
-	nlc.Unlock(lom.Uname(), exclusive)
+	nlc.Unlock(lom.Uname(), exclusive, lom.hid)
 }
 
 //
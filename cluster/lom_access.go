@@ -0,0 +1,182 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+)
+
+// Object access statistics
+//
+// In addition to atime, each object keeps the number of times it was accessed
+// (read) and the times of the last AccessHistLen accesses - sampled at most
+// once per accessSampleIntval, so that a burst of reads does not wipe out the
+// older history. The statistics are shared by all LOMs loaded from the same
+// LOM cache entry and are updated concurrently (by the GETs holding the read
+// lock). They get persisted (as part of the object's metadata) whenever the
+// latter gets persisted and, otherwise, by the LOM cache housekeeper - in
+// batches of at least accessFlushBatch accesses and upon eviction from the
+// cache (see lomCacheCleanup). Tiering, pinning, and LRU policies can then use
+// the access frequency (see AccessCount, AccessHistory, and RecentAccesses)
+// rather than a single atime.
+
+const (
+	AccessHistLen      = 8
+	accessSampleIntval = time.Minute
+	accessFlushBatch   = 64
+	accessSepa         = ","
+)
+
+type laccess struct {
+	count   atomic.Int64
+	flushed atomic.Int64 // persisted count
+	sampled atomic.Int64 // the last sampled time (fast path)
+	mtx     sync.Mutex
+	hist    [AccessHistLen]int64 // under mtx
+}
+
+func (lom *LOM) AccessCount() int64 {
+	if a := lom.md.access; a != nil {
+		return a.count.Load()
+	}
+	return 0
+}
+
+// AccessHistory returns the sampled times of the last accesses, oldest first
+func (lom *LOM) AccessHistory() (hist []time.Time) {
+	for _, tu := range lom.md.access.history() {
+		if tu != 0 {
+			hist = append(hist, time.Unix(0, tu))
+		}
+	}
+	return
+}
+
+// RecentAccesses returns the number of the sampled accesses since a given time
+func (lom *LOM) RecentAccesses(since time.Time) (n int) {
+	tu := since.UnixNano()
+	for _, t := range lom.md.access.history() {
+		if t != 0 && t >= tu {
+			n++
+		}
+	}
+	return
+}
+
+// RecordAccess counts the access (at a given Unix time) and samples it into
+// the history; safe to call concurrently for the same (cached) object
+func (lom *LOM) RecordAccess(tu int64) {
+	md := &lom.md
+	if md.access == nil { // not cached (see initAccess)
+		md.access = &laccess{}
+	}
+	a := md.access
+	a.count.Inc()
+	if tu-a.sampled.Load() < int64(accessSampleIntval) {
+		return
+	}
+	a.mtx.Lock()
+	if tu-a.hist[AccessHistLen-1] >= int64(accessSampleIntval) {
+		copy(a.hist[:], a.hist[1:])
+		a.hist[AccessHistLen-1] = tu
+		a.sampled.Store(tu)
+	}
+	a.mtx.Unlock()
+}
+
+// cached objects always have their statistics - to be shared
+func (md *lmeta) initAccess() {
+	if md.access == nil {
+		md.access = &laccess{}
+	}
+}
+
+func (a *laccess) history() (hist [AccessHistLen]int64) {
+	if a != nil {
+		a.mtx.Lock()
+		hist = a.hist
+		a.mtx.Unlock()
+	}
+	return
+}
+
+// whether to persist the statistics of a cached object: in batches - or upon eviction
+func (a *laccess) dirty(evict bool) bool {
+	if a == nil {
+		return false
+	}
+	n := a.count.Load() - a.flushed.Load()
+	return n >= accessFlushBatch || (evict && n > 0)
+}
+
+//
+// packing: "<count>[,<time>...]" (decimal; times in Unix nanoseconds, oldest first)
+//
+
+func (md *lmeta) packAccess() string {
+	var sb strings.Builder
+	sb.WriteString(strconv.FormatInt(md.access.count.Load(), 10))
+	for _, tu := range md.access.history() {
+		if tu != 0 {
+			sb.WriteString(accessSepa)
+			sb.WriteString(strconv.FormatInt(tu, 10))
+		}
+	}
+	return sb.String()
+}
+
+func (md *lmeta) unpackAccess(s string) (err error) {
+	var (
+		count int64
+		a     = &laccess{}
+		parts = strings.Split(s, accessSepa)
+	)
+	if len(parts) > AccessHistLen+1 {
+		return errors.New("invalid access stats: history too long")
+	}
+	if count, err = strconv.ParseInt(parts[0], 10, 64); err != nil {
+		return
+	}
+	off := AccessHistLen - len(parts) + 1 // right-align: the newest is the last
+	for i, p := range parts[1:] {
+		if a.hist[off+i], err = strconv.ParseInt(p, 10, 64); err != nil {
+			return
+		}
+	}
+	a.count.Store(count)
+	a.flushed.Store(count)
+	a.sampled.Store(a.hist[AccessHistLen-1])
+	md.access = a
+	return
+}
+
+// persists the access statistics of a cached object (see lomCacheCleanup);
+// skips the object that's currently locked - until the next time
+func flushAccess(t Target, md *lmeta, bmd *BMD) {
+	lom, bucketExists := lomFromLmeta(md, bmd)
+	if !bucketExists {
+		return
+	}
+	lom.T = t
+	if err := lom.Init(lom.bck.Bck); err != nil {
+		return
+	}
+	if !lom.TryLock(true) {
+		return
+	}
+	defer lom.Unlock(true)
+	// under lock: the cached (shared) stats, the on-disk rest of the metadata
+	if _, err := lom.lmfs(true); err != nil {
+		return
+	}
+	lom.md.access = md.access
+	lom.Persist()
+}
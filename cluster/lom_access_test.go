@@ -0,0 +1,44 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Access statistics", func() {
+	It("should flush in batches and upon eviction", func() {
+		var a *laccess
+		Expect(a.dirty(true)).To(BeFalse())
+
+		a = &laccess{}
+		Expect(a.dirty(true)).To(BeFalse())
+		a.count.Inc()
+		Expect(a.dirty(false)).To(BeFalse())
+		Expect(a.dirty(true)).To(BeTrue())
+
+		a.count.Add(accessFlushBatch - 2)
+		Expect(a.dirty(false)).To(BeFalse())
+		a.count.Inc()
+		Expect(a.dirty(false)).To(BeTrue())
+
+		a.flushed.Store(a.count.Load())
+		Expect(a.dirty(false)).To(BeFalse())
+		Expect(a.dirty(true)).To(BeFalse())
+	})
+
+	It("should pack and unpack", func() {
+		md := &lmeta{}
+		lom := &LOM{md: lmeta{}}
+		for i := int64(1); i <= AccessHistLen+2; i++ {
+			lom.RecordAccess(i * int64(accessSampleIntval))
+		}
+		Expect(md.unpackAccess(lom.md.packAccess())).NotTo(HaveOccurred())
+		Expect(md.access.count.Load()).To(BeEquivalentTo(AccessHistLen + 2))
+		Expect(md.access.history()).To(Equal(lom.md.access.history()))
+		Expect(md.access.dirty(true)).To(BeFalse())
+	})
+})
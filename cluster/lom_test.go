@@ -68,6 +68,9 @@ var _ = Describe("LOM", func() {
 
 	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
 	_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.ObjVersionType, &fs.ObjVersionContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.RemoteVerType, &fs.RemoteVerContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.RecycleType, &fs.RecycleContentResolver{})
 
 	var (
 		bmd = cluster.NewBaseBownerMock(
@@ -713,6 +716,16 @@ var _ = Describe("LOM", func() {
 				Expect(copyObjHash).To(BeEquivalentTo(expectedHash))
 			})
 
+			It("should copy the object and keep the source checksum in verified-once mode", func() {
+				lom := prepareLOM(copyFQNs[0])
+				lom.CksumConf().VerifiedOnce = true
+				defer func() { lom.CksumConf().VerifiedOnce = false }()
+
+				copyLOM := prepareCopy(lom, copyFQNs[1])
+				Expect(copyLOM.Cksum().Equal(lom.Cksum())).To(BeTrue())
+				Expect(getTestFileHash(copyFQNs[1])).To(BeEquivalentTo(getTestFileHash(lom.FQN)))
+			})
+
 			It("should successfully copy the object in case it is mirror copy", func() {
 				lom := prepareLOM(mirrorFQNs[0])
 				copyLOM := prepareCopy(lom, mirrorFQNs[1])
@@ -908,6 +921,165 @@ var _ = Describe("LOM", func() {
 		})
 	})
 
+	Describe("previous versions", func() {
+		const testFileSize = 123
+		testObject := "foldr/test-obj.ext"
+		localFQN := mis[0].MakePathFQN(localBckA, fs.ObjectType, testObject)
+
+		It("should keep, load, restore, and delete previous versions", func() {
+			lom := filePut(localFQN, testFileSize, tMock)
+			Expect(lom.KeepVersion()).NotTo(HaveOccurred())
+			Expect(lom.Version()).To(Equal("1"))
+			_, err := os.Stat(localFQN)
+			Expect(os.IsNotExist(err)).To(BeTrue())
+
+			// put the next version
+			createTestFile(localFQN, testFileSize)
+			Expect(lom.IncVersion()).NotTo(HaveOccurred())
+			Expect(lom.Persist()).NotTo(HaveOccurred())
+			Expect(lom.PrevVersions()).To(Equal([]string{"1"}))
+
+			vlom, err := lom.LoadVersion("1")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(vlom.Version()).To(Equal("1"))
+			Expect(vlom.Size()).To(BeEquivalentTo(testFileSize))
+			Expect(vlom.FQN).To(Equal(lom.VersionFQN("1")))
+
+			Expect(lom.KeepVersion()).NotTo(HaveOccurred())
+			Expect(lom.PrevVersions()).To(Equal([]string{"2", "1"}))
+
+			// the object does not exist - its version continues the sequence
+			lom2 := NewBasicLom(localFQN, tMock)
+			Expect(lom2.KeepVersion()).NotTo(HaveOccurred())
+			Expect(lom2.Version()).To(Equal("2"))
+
+			Expect(lom.RestoreVersion("2")).NotTo(HaveOccurred())
+			Expect(lom.Version()).To(Equal("2"))
+			Expect(lom.PrevVersions()).To(Equal([]string{"1"}))
+			_, err = os.Stat(localFQN)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(lom.DelVersion("1")).NotTo(HaveOccurred())
+			Expect(lom.PrevVersions()).To(BeEmpty())
+			_, err = lom.LoadVersion("1")
+			Expect(os.IsNotExist(err)).To(BeTrue())
+		})
+
+		It("should prune and delete previous versions", func() {
+			lom := filePut(localFQN, testFileSize, tMock)
+			for i := 0; i < 3; i++ {
+				Expect(lom.KeepVersion()).NotTo(HaveOccurred())
+				createTestFile(localFQN, testFileSize)
+				Expect(lom.IncVersion()).NotTo(HaveOccurred())
+				Expect(lom.Persist()).NotTo(HaveOccurred())
+			}
+			Expect(lom.Version()).To(Equal("4"))
+			Expect(lom.PrevVersions()).To(Equal([]string{"3", "2", "1"}))
+			Expect(cluster.MayHavePrevVersions(lom.Version())).To(BeTrue())
+			Expect(cluster.MayHavePrevVersions("1")).To(BeFalse())
+
+			// the oldest ones go first
+			lom.PruneVersions(2)
+			Expect(lom.PrevVersions()).To(Equal([]string{"3", "2"}))
+			lom.PruneVersions(0) // unlimited
+			Expect(lom.PrevVersions()).To(Equal([]string{"3", "2"}))
+
+			lom.DelVersions()
+			Expect(lom.PrevVersions()).To(BeEmpty())
+			_, err := os.Stat(localFQN)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Describe("cloud versions", func() {
+		const testFileSize = 123
+		testObject := "foldr/test-obj.ext"
+		cloudFQN := mis[0].MakePathFQN(cloudBckA, fs.ObjectType, testObject)
+
+		It("should keep a given cloud version separately from the object", func() {
+			const version = "3HL4kqtJlcpXroDTDmJ+rmSpXd3dIbrHY+MTRCxf3vjVBH40Nr8X8gdRQBpUMLUo"
+			createTestFile(cloudFQN, testFileSize)
+			lom := NewBasicLom(cloudFQN, tMock)
+			vlom := lom.RemoteVersionLOM(version)
+			Expect(vlom.FQN).To(Equal(lom.RemoteVersionFQN(version)))
+			Expect(vlom.FQN).NotTo(Equal(lom.FQN))
+			Expect(vlom.Uname()).To(Equal(lom.Uname()))
+			Expect(vlom.Version()).To(BeEmpty())
+			Expect(os.IsNotExist(vlom.FromFS())).To(BeTrue())
+
+			resolver, info := fs.CSM.FileSpec(vlom.FQN)
+			Expect(resolver).NotTo(BeNil())
+			Expect(info.Type).To(Equal(fs.RemoteVerType))
+			Expect(info.Base).To(Equal(filepath.Base(testObject)))
+
+			createTestFile(vlom.FQN, testFileSize)
+			vlom.SetSize(testFileSize)
+			vlom.SetVersion(version)
+			Expect(vlom.Persist()).NotTo(HaveOccurred())
+			vlom = lom.RemoteVersionLOM(version)
+			Expect(vlom.FromFS()).NotTo(HaveOccurred())
+			Expect(vlom.Version()).To(Equal(version))
+			Expect(vlom.Size()).To(BeEquivalentTo(testFileSize))
+		})
+	})
+
+	Describe("trash", func() {
+		const (
+			testObjectName = "foldr/test-obj.ext"
+			testFileSize   = 101
+		)
+		var fqn string
+
+		BeforeEach(func() {
+			fqn = mis[0].MakePathFQN(localBckA, fs.ObjectType, testObjectName)
+		})
+
+		It("should trash and undelete the object", func() {
+			lom := filePut(fqn, testFileSize, tMock)
+			Expect(lom.Trash(time.Hour)).NotTo(HaveOccurred())
+			Expect(fqn).NotTo(BeAnExistingFile())
+
+			trashFQNs, _ := filepath.Glob(mis[0].MakePathFQN(localBckA, fs.RecycleType, testObjectName) + ".*")
+			Expect(trashFQNs).To(HaveLen(1))
+			Expect(cluster.TrashExpired(trashFQNs[0], time.Now())).To(BeFalse())
+			Expect(cluster.TrashExpired(trashFQNs[0], time.Now().Add(2*time.Hour))).To(BeTrue())
+
+			lom = NewBasicLom(fqn, tMock)
+			Expect(lom.Undelete()).NotTo(HaveOccurred())
+			Expect(fqn).To(BeAnExistingFile())
+			Expect(trashFQNs[0]).NotTo(BeAnExistingFile())
+			Expect(lom.Size()).To(BeEquivalentTo(testFileSize))
+		})
+
+		It("should undelete the object that was trashed last", func() {
+			lom := filePut(fqn, testFileSize, tMock)
+			Expect(lom.Trash(time.Hour)).NotTo(HaveOccurred())
+			lom = filePut(fqn, 2*testFileSize, tMock)
+			Expect(lom.Trash(2 * time.Hour)).NotTo(HaveOccurred())
+
+			lom = NewBasicLom(fqn, tMock)
+			Expect(lom.Undelete()).NotTo(HaveOccurred())
+			Expect(lom.Size()).To(BeEquivalentTo(2 * testFileSize))
+		})
+
+		It("should refuse to undelete the object that exists", func() {
+			lom := filePut(fqn, testFileSize, tMock)
+			Expect(lom.Trash(time.Hour)).NotTo(HaveOccurred())
+			filePut(fqn, 2*testFileSize, tMock)
+
+			lom = NewBasicLom(fqn, tMock)
+			Expect(os.IsExist(lom.Undelete())).To(BeTrue())
+			Expect(lom.Size()).To(BeEquivalentTo(2 * testFileSize))
+			trashFQNs, _ := filepath.Glob(mis[0].MakePathFQN(localBckA, fs.RecycleType, testObjectName) + ".*")
+			Expect(trashFQNs).To(HaveLen(1))
+		})
+
+		It("should fail to undelete the object that was never trashed", func() {
+			lom := NewBasicLom(fqn, tMock)
+			Expect(os.IsNotExist(lom.Undelete())).To(BeTrue())
+		})
+	})
+
 	Describe("local and cloud bucket with the same name", func() {
 		It("should have different fqn", func() {
 			testObject := "foldr/test-obj.ext"
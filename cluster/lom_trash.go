@@ -0,0 +1,118 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/karrick/godirwalk"
+)
+
+//
+// Deleted objects of the ais buckets with trash enabled (cmn.TrashConf).
+// Instead of being removed, the object is moved - along with its metadata
+// (xattr) - to the trash (fs.RecycleType) on the same mountpath, where it stays
+// until either undeleted or removed by lru.GCTrash once its TTL expires. The
+// object can be deleted (and trashed) multiple times - undelete restores the
+// one that was trashed last.
+//
+
+func (lom *LOM) trashFQN(mi *fs.MountpathInfo, expires string) string {
+	parsedFQN := lom.ParsedFQN
+	parsedFQN.MpathInfo = mi
+	return fs.CSM.GenContentParsedFQN(parsedFQN, fs.RecycleType, expires)
+}
+
+// Trash moves the object to the trash for a given time; the object's local
+// copies, if any, get removed
+// NOTE: must be called under write lock
+func (lom *LOM) Trash(ttl time.Duration) (err error) {
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	lom.Uncache()
+	if err = cmn.Rename(lom.FQN, lom.trashFQN(lom.ParsedFQN.MpathInfo, expires)); err != nil {
+		return
+	}
+	for copyFQN := range lom.md.copies {
+		if copyFQN == lom.FQN {
+			continue
+		}
+		if errRm := cmn.RemoveFile(copyFQN); errRm != nil {
+			lom.T.FSHC(errRm, copyFQN)
+		}
+	}
+	return
+}
+
+// Undelete restores the object that was trashed last unless the object exists
+// (in which case it must be deleted first)
+// NOTE: must be called under write lock
+func (lom *LOM) Undelete() (err error) {
+	var (
+		tlom   *LOM
+		fqn    string
+		latest int64
+		prefix = filepath.Base(lom.ObjName) + "."
+	)
+	if err = lom.Load(false); err == nil {
+		return &os.PathError{Op: "undelete", Path: lom.FQN, Err: os.ErrExist}
+	}
+	if !cmn.IsObjNotExist(err) {
+		return
+	}
+	availablePaths, _ := fs.Mountpaths.Get()
+	for _, mpathInfo := range availablePaths {
+		dir := filepath.Dir(lom.trashFQN(mpathInfo, "0"))
+		names, err := godirwalk.ReadDirnames(dir, nil)
+		if err != nil {
+			continue
+		}
+		for _, name := range names {
+			if !strings.HasPrefix(name, prefix) {
+				continue
+			}
+			// skip other objects, e.g. "obj.tar.1600000000" when looking for "obj"
+			expires, err := strconv.ParseInt(name[len(prefix):], 10, 64)
+			if err == nil && expires > latest {
+				fqn, latest = filepath.Join(dir, name), expires
+			}
+		}
+	}
+	if fqn == "" {
+		return &os.PathError{Op: "undelete", Path: lom.FQN, Err: os.ErrNotExist}
+	}
+	tlom = lom.Clone(fqn)
+	tlom.md = lmeta{uname: lom.md.uname}
+	if err = tlom.FromFS(); err != nil {
+		return
+	}
+	if err = cmn.Rename(fqn, lom.FQN); err != nil {
+		return
+	}
+	lom.md = tlom.md
+	lom.md.copies = nil // (the copies were removed when trashed)
+
+	if err = lom.Persist(); err != nil { // (in case the rename was in fact a copy)
+		return
+	}
+	lom.ReCache()
+	return
+}
+
+// TrashExpired returns true if a given trash FQN (fs.RecycleType) has expired
+func TrashExpired(fqn string, now time.Time) bool {
+	i := strings.LastIndexByte(fqn, '.')
+	if i < 0 {
+		return false
+	}
+	expires, err := strconv.ParseInt(fqn[i+1:], 10, 64)
+	return err == nil && now.Unix() >= expires
+}
+
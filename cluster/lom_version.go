@@ -0,0 +1,209 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/karrick/godirwalk"
+)
+
+//
+// Previous versions of the objects in the ais buckets with versioning enabled.
+// When an object gets overwritten (or deleted via S3 API) its current version
+// is kept aside - as a separate content item (fs.ObjVersionType) on the same
+// mountpath, with the metadata (xattr) moved along - until deleted by version,
+// pruned (versioning.max_versions), or evicted by LRU. Rebalance migrates the
+// previous versions along with the object.
+//
+
+// VersionFQN returns the FQN of a given previous version of the object
+func (lom *LOM) VersionFQN(version string) string {
+	return lom.versionFQN(lom.ParsedFQN.MpathInfo, version)
+}
+
+func (lom *LOM) versionFQN(mi *fs.MountpathInfo, version string) string {
+	parsedFQN := lom.ParsedFQN
+	parsedFQN.MpathInfo = mi
+	return fs.CSM.GenContentParsedFQN(parsedFQN, fs.ObjVersionType, version)
+}
+
+// KeepVersion keeps the current version of the object (if any) as a previous
+// one - the object is about to be overwritten or deleted. If the object does not
+// exist, sets its version to the latest of the previous ones (if any), so that
+// IncVersion continues the sequence.
+// NOTE: must be called under write lock.
+func (lom *LOM) KeepVersion() (err error) {
+	var md *lmeta
+	if _, err = os.Stat(lom.FQN); err != nil {
+		if !os.IsNotExist(err) {
+			return
+		}
+		if versions := lom.PrevVersions(); len(versions) > 0 {
+			lom.SetVersion(versions[0])
+		}
+		return nil
+	}
+	if md, err = lom.lmfs(false); err != nil {
+		return
+	}
+	if md.version == "" {
+		return // put before versioning was enabled - nothing to keep
+	}
+	lom.SetVersion(md.version)
+	return cmn.Rename(lom.FQN, lom.VersionFQN(md.version))
+}
+
+// PrevVersions returns the previous versions of the object, the latest first
+func (lom *LOM) PrevVersions() (versions []string) {
+	var (
+		prefix            = filepath.Base(lom.ObjName) + "."
+		availablePaths, _ = fs.Mountpaths.Get()
+	)
+	for _, mpathInfo := range availablePaths {
+		dir := filepath.Dir(lom.versionFQN(mpathInfo, lomInitialVersion))
+		names, err := godirwalk.ReadDirnames(dir, nil)
+		if err != nil {
+			continue
+		}
+		for _, name := range names {
+			if !strings.HasPrefix(name, prefix) {
+				continue
+			}
+			// skip other objects, e.g. "obj.tar.1" and "obj.1.1" when looking for "obj"
+			if ver := name[len(prefix):]; isNumVersion(ver) && !cmn.StringInSlice(ver, versions) {
+				versions = append(versions, ver)
+			}
+		}
+	}
+	sort.Slice(versions, func(i, j int) bool {
+		vi, _ := strconv.ParseUint(versions[i], 10, 64)
+		vj, _ := strconv.ParseUint(versions[j], 10, 64)
+		return vi > vj
+	})
+	return
+}
+
+// LoadVersion loads a given previous version of the object; the returned LOM
+// refers to the version's file (and must not be cached)
+func (lom *LOM) LoadVersion(version string) (vlom *LOM, err error) {
+	availablePaths, _ := fs.Mountpaths.Get()
+	fqns := make([]string, 0, len(availablePaths))
+	fqns = append(fqns, lom.VersionFQN(version))
+	for _, mpathInfo := range availablePaths {
+		if mpathInfo != lom.ParsedFQN.MpathInfo {
+			fqns = append(fqns, lom.versionFQN(mpathInfo, version))
+		}
+	}
+	for _, fqn := range fqns {
+		vlom = lom.Clone(fqn)
+		vlom.md = lmeta{uname: lom.md.uname}
+		if err = vlom.FromFS(); err == nil {
+			vlom.md.copies = nil // (the copies are the current version's)
+			return
+		}
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+// NewVersionLOM returns the LOM that refers to a given (new) previous version
+// of the object on the object's mountpath; the returned LOM must not be cached
+func (lom *LOM) NewVersionLOM(version string) (vlom *LOM) {
+	vlom = lom.Clone(lom.VersionFQN(version))
+	vlom.md = lmeta{uname: lom.md.uname}
+	return
+}
+
+// PruneVersions removes the oldest previous versions of the object to keep at
+// most `max` of them (zero - unlimited)
+func (lom *LOM) PruneVersions(max int) {
+	if max <= 0 {
+		return
+	}
+	versions := lom.PrevVersions()
+	for i := max; i < len(versions); i++ {
+		if err := lom.DelVersion(versions[i]); err != nil && !os.IsNotExist(err) {
+			glog.Errorf("%s: failed to remove version %s: %v", lom, versions[i], err)
+		}
+	}
+}
+
+// DelVersions removes all previous versions of the object
+func (lom *LOM) DelVersions() {
+	for _, version := range lom.PrevVersions() {
+		if err := lom.DelVersion(version); err != nil && !os.IsNotExist(err) {
+			glog.Errorf("%s: failed to remove version %s: %v", lom, version, err)
+		}
+	}
+}
+
+// DelVersion removes a given previous version of the object
+func (lom *LOM) DelVersion(version string) error {
+	vlom, err := lom.LoadVersion(version)
+	if err != nil {
+		return err
+	}
+	return cmn.RemoveFile(vlom.FQN)
+}
+
+// RestoreVersion makes a given previous version of the object current again
+// NOTE: must be called under write lock; the current version, if any, gets replaced
+func (lom *LOM) RestoreVersion(version string) (err error) {
+	var vlom *LOM
+	if vlom, err = lom.LoadVersion(version); err != nil {
+		return
+	}
+	if err = cmn.Rename(vlom.FQN, lom.FQN); err != nil {
+		return
+	}
+	lom.md = vlom.md
+	if err = lom.Persist(); err != nil { // (in case the rename was in fact a copy)
+		return
+	}
+	lom.ReCache()
+	return
+}
+
+//
+// Given versions of the objects in the cloud buckets with versioning enabled on
+// the cloud side (see VersionedCloudProvider): once fetched, a version is kept
+// as a separate content item (fs.RemoteVerType) that never gets replaced - the
+// version is immutable.
+//
+
+// RemoteVersionFQN returns the FQN of a given cloud version of the object
+func (lom *LOM) RemoteVersionFQN(version string) string {
+	return fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.RemoteVerType, version)
+}
+
+// RemoteVersionLOM returns the LOM that refers to a given cloud version of the
+// object; the returned LOM must not be cached
+func (lom *LOM) RemoteVersionLOM(version string) (vlom *LOM) {
+	vlom = lom.Clone(lom.RemoteVersionFQN(version))
+	vlom.md = lmeta{uname: lom.md.uname}
+	return
+}
+
+// MayHavePrevVersions returns false if the object of a given (current) version
+// cannot have previous versions - it has never been overwritten with versioning enabled
+func MayHavePrevVersions(version string) bool {
+	ver, err := strconv.ParseUint(version, 10, 64)
+	return err == nil && ver > 1
+}
+
+func isNumVersion(ver string) bool {
+	_, err := strconv.ParseUint(ver, 10, 64)
+	return err == nil
+}
@@ -50,6 +50,7 @@ const (
 	lomObjSize
 	lomObjCopies
 	lomCustomMD
+	lomAccess
 )
 
 // packing format separators
@@ -73,6 +74,10 @@ const (
 	VersionObjMD = "v"
 	CRC32CObjMD  = cmn.ChecksumCRC32C
 	MD5ObjMD     = cmn.ChecksumMD5
+	ETagObjMD    = "etag"    // multipart upload ETag (see cmn.AwsMultipartETag)
+	S3TagsObjMD  = "s3-tags" // S3 object tags, URL-encoded (see s3compat.SetTags)
+
+	RemoteETagObjMD = "remote-etag" // cached replica of a remote AIS object (see cmn.RemoteCacheConf)
 )
 
 func (lom *LOM) LoadMetaFromFS() error { _, err := lom.lmfs(true); return err }
@@ -122,9 +127,12 @@ func (lom *LOM) lmfs(populate bool) (md *lmeta, err error) {
 }
 
 func (lom *LOM) Persist() (err error) {
+	acount := lom.AccessCount() // (the accesses racing with this one get flushed next time)
 	buf, mm := lom._persist()
 	if err = fs.SetXattr(lom.FQN, XattrLOM, buf); err != nil {
 		lom.T.FSHC(err, lom.FQN)
+	} else if lom.md.access != nil {
+		lom.md.access.flushed.Store(acount)
 	}
 	mm.Free(buf)
 	return
@@ -271,6 +279,10 @@ func (md *lmeta) unmarshal(buf []byte) (err error) {
 			for i := 0; i < len(entries); i += 2 {
 				md.customMD[entries[i]] = entries[i+1]
 			}
+		case lomAccess:
+			if err := md.unpackAccess(val); err != nil {
+				return fmt.Errorf("%s #9: %v", invalid, err)
+			}
 		default:
 			return errors.New(invalid + " #6")
 		}
@@ -315,6 +327,10 @@ func (md *lmeta) marshal(mm *memsys.MMSA, mdSize int64) (buf []byte) {
 		buf = _marshRecord(mm, buf, lomCustomMD, "", false)
 		buf = _marshCustomMD(mm, buf, md.customMD)
 	}
+	if md.access != nil && md.access.count.Load() != 0 {
+		buf = mm.Append(buf, recordSepa)
+		buf = _marshRecord(mm, buf, lomAccess, md.packAccess(), false)
+	}
 
 	// checksum, prepend, and return
 	buf[0] = mdVersion
@@ -6,6 +6,8 @@ package cluster_test
 
 import (
 	"os"
+	"sync"
+	"time"
 
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -130,6 +132,68 @@ var _ = Describe("LOM Xattributes", func() {
 				Expect(lom.GetCopies()).To(HaveLen(3))
 				Expect(lom.GetCopies()).To(BeEquivalentTo(newLom.GetCopies()))
 			})
+
+			It("should save access statistics", func() {
+				lom := filePut(localFQN, testFileSize, tMock)
+				now := time.Now()
+				for i := 0; i < cluster.AccessHistLen+5; i++ {
+					lom.RecordAccess(now.Add(time.Duration(i) * time.Hour).UnixNano())
+					lom.RecordAccess(now.Add(time.Duration(i)*time.Hour + time.Second).UnixNano()) // not sampled
+				}
+				Expect(lom.Persist()).NotTo(HaveOccurred())
+
+				lom.Uncache()
+				newLom := NewBasicLom(localFQN, tMock)
+				err := newLom.Load(false)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(newLom.AccessCount()).To(BeEquivalentTo(2 * (cluster.AccessHistLen + 5)))
+				hist := newLom.AccessHistory()
+				Expect(hist).To(HaveLen(cluster.AccessHistLen))
+				Expect(hist[cluster.AccessHistLen-1].UnixNano()).To(Equal(now.Add((cluster.AccessHistLen + 4) * time.Hour).UnixNano()))
+				Expect(newLom.RecentAccesses(now.Add((cluster.AccessHistLen + 3) * time.Hour))).To(Equal(2))
+			})
+
+			It("should count concurrent accesses", func() {
+				const workers, perWkr = 8, 100
+				var (
+					wg  = &sync.WaitGroup{}
+					now = time.Now()
+					// caching requires bucket ID
+					bck = cluster.NewBck(bucketLocal, cmn.ProviderAIS, cmn.NsGlobal, &cmn.BucketProps{
+						Cksum: cmn.CksumConf{Type: cmn.ChecksumXXHash}, BID: 0xa5b6,
+					})
+					tMock = cluster.NewTargetMock(cluster.NewBaseBownerMock(bck))
+				)
+				filePut(localFQN, testFileSize, tMock)
+				Expect(NewBasicLom(localFQN, tMock).Load()).NotTo(HaveOccurred()) // cache it
+				for i := 0; i < workers; i++ {
+					wg.Add(1)
+					go func() {
+						defer GinkgoRecover()
+						defer wg.Done()
+						for k := 0; k < perWkr; k++ {
+							lom := NewBasicLom(localFQN, tMock)
+							Expect(lom.Load()).NotTo(HaveOccurred())
+							lom.RecordAccess(now.Add(time.Duration(k) * time.Millisecond).UnixNano())
+							lom.ReCache()
+						}
+					}()
+				}
+				wg.Wait()
+
+				lom := NewBasicLom(localFQN, tMock)
+				Expect(lom.Load()).NotTo(HaveOccurred())
+				Expect(lom.AccessCount()).To(BeEquivalentTo(workers * perWkr))
+				Expect(lom.AccessHistory()).To(HaveLen(1)) // sampled once a minute
+
+				// persisted
+				Expect(lom.Persist()).NotTo(HaveOccurred())
+				lom.Uncache()
+				lom = NewBasicLom(localFQN, tMock)
+				Expect(lom.Load(false)).NotTo(HaveOccurred())
+				Expect(lom.AccessCount()).To(BeEquivalentTo(workers * perWkr))
+				lom.Uncache()
+			})
 		})
 
 		Describe("LoadMetaFromFS", func() {
@@ -42,6 +42,7 @@ type (
 		PublicNet       NetInfo `json:"public_net"`        // cmn.NetworkPublic
 		IntraControlNet NetInfo `json:"intra_control_net"` // cmn.NetworkIntraControl
 		IntraDataNet    NetInfo `json:"intra_data_net"`    // cmn.NetworkIntraData
+		Domain          string  `json:"domain,omitempty"`  // failure domain (e.g., rack or zone)
 		idDigest        uint64
 		name            string
 		LocalNet        *net.IPNet `json:"-"`
@@ -123,7 +124,7 @@ func (d *Snode) URL(network string) string {
 }
 
 func (d *Snode) Equals(other *Snode) bool {
-	return d.ID() == other.ID() && d.DaemonType == other.DaemonType &&
+	return d.ID() == other.ID() && d.DaemonType == other.DaemonType && d.Domain == other.Domain &&
 		reflect.DeepEqual(d.PublicNet, other.PublicNet) &&
 		reflect.DeepEqual(d.IntraControlNet, other.IntraControlNet) &&
 		reflect.DeepEqual(d.IntraDataNet, other.IntraDataNet)
@@ -5,11 +5,15 @@
 package cluster
 
 import (
+	"fmt"
+	"runtime"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/debug"
 )
 
 // nameLocker is a 2-level structure utilized to lock objects of *any* kind
@@ -17,15 +21,27 @@ import (
 // In most cases, the digest will be some sort a hash of the name itself
 // (does not necessarily need to be cryptographic).
 // The lock can be exclusive (write) or shared (read).
+// Each lock keeps track of its holders: the holder ID returned by (Try)Lock
+// identifies the holder to Unlock (and to DowngradeLock/TryUpgradeLock), so that
+// the unlocks by the holders that have been force-released (see ForceUnlock)
+// are told apart from the unlocks by the current ones. For diagnostics, each
+// holder records when it took the lock and - in debug builds - its call stack.
 type (
 	nameLocker []nlc
 	nlc        struct {
-		mu sync.Mutex
-		m  map[string]*lockInfo
+		mu     sync.Mutex
+		m      map[string]*lockInfo
+		forced map[uint64]string // force-released holder => lock name
+		nextID uint64
 	}
 	lockInfo struct {
-		rc        int
 		exclusive bool
+		since     time.Time    // when the lock was taken (the 1st rlock, if shared)
+		holders   []lockHolder // one if exclusive
+	}
+	lockHolder struct {
+		id    uint64
+		stack [holderDepth]uintptr // (debug only)
 	}
 )
 
@@ -33,6 +49,7 @@ const (
 	initPollInterval = 10 * time.Microsecond
 	maxPollInterval  = 100 * time.Millisecond
 	initCapacity     = 128
+	holderDepth      = 6 // enough to get past the lock wrappers
 )
 
 //
@@ -43,61 +60,192 @@ func (nl nameLocker) init() {
 	for idx := 0; idx < len(nl); idx++ {
 		nlc := &nl[idx]
 		nlc.m = make(map[string]*lockInfo, initCapacity)
+		nlc.forced = make(map[uint64]string)
+	}
+}
+
+// List returns the currently held locks with names that start with a given prefix
+func (nl nameLocker) List(prefix string) []cmn.LockInfo {
+	var (
+		now   = time.Now()
+		locks = make([]cmn.LockInfo, 0, 16)
+	)
+	for idx := 0; idx < len(nl); idx++ {
+		nlc := &nl[idx]
+		nlc.mu.Lock()
+		for uname, info := range nlc.m {
+			if !strings.HasPrefix(uname, prefix) {
+				continue
+			}
+			lock := cmn.LockInfo{
+				Name:      uname,
+				Exclusive: info.exclusive,
+				Holder:    info.holders[len(info.holders)-1].name(),
+				Since:     info.since,
+				Age:       now.Sub(info.since),
+			}
+			if !info.exclusive {
+				lock.Readers = len(info.holders)
+			}
+			locks = append(locks, lock)
+		}
+		nlc.mu.Unlock()
 	}
+	return locks
+}
+
+// ForceUnlock releases a given lock regardless of its holders. The subsequent
+// unlocks by the (former) holders are then ignored.
+// NOTE: to be used (with care) only to recover from stuck operations.
+func (nl nameLocker) ForceUnlock(uname string) bool {
+	for idx := 0; idx < len(nl); idx++ {
+		if nl[idx].forceUnlock(uname) {
+			return true
+		}
+	}
+	return false
 }
 
 //
 // nlc
 //
 
-func (nlc *nlc) TryLock(uname string, exclusive bool) bool {
+func (nlc *nlc) forceUnlock(uname string) bool {
+	nlc.mu.Lock()
+	defer nlc.mu.Unlock()
+	info, found := nlc.m[uname]
+	if !found {
+		return false
+	}
+	for _, holder := range info.holders {
+		nlc.forced[holder.id] = uname
+	}
+	delete(nlc.m, uname)
+	glog.Warningf("force-released lock %s (exclusive: %t, holders: %d, holder: %s, age: %v)",
+		uname, info.exclusive, len(info.holders), info.holders[len(info.holders)-1].name(), time.Since(info.since))
+	return true
+}
+
+// under lock
+func (nlc *nlc) newHolder() (holder lockHolder) {
+	nlc.nextID++
+	holder.id = nlc.nextID
+	if debug.Enabled {
+		// skip runtime.Callers, newHolder, and TryLock
+		runtime.Callers(3, holder.stack[:])
+	}
+	return
+}
+
+// under lock; true if a given holder has been force-released (and is now forgotten)
+func (nlc *nlc) wasForced(uname string, hid uint64) bool {
+	if name, ok := nlc.forced[hid]; ok && name == uname {
+		delete(nlc.forced, hid)
+		return true
+	}
+	return false
+}
+
+//
+// lockInfo & lockHolder
+//
+
+// removes a given holder or, if unknown (e.g., unlocking via a different LOM), the latest one
+func (info *lockInfo) release(hid uint64) {
+	idx := len(info.holders) - 1
+	for i := range info.holders {
+		if info.holders[i].id == hid {
+			idx = i
+			break
+		}
+	}
+	info.holders = append(info.holders[:idx], info.holders[idx+1:]...)
+}
+
+// returns the first function on the holder's stack that is not a lock wrapper
+func (holder *lockHolder) name() string {
+	var (
+		pcs    = holder.stack[:]
+		frames *runtime.Frames
+	)
+	for i, pc := range pcs {
+		if pc == 0 {
+			pcs = pcs[:i]
+			break
+		}
+	}
+	if len(pcs) == 0 {
+		return ""
+	}
+	frames = runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if !isLockWrapper(frame.Function) {
+			return fmt.Sprintf("%s:%d", frame.Function, frame.Line)
+		}
+		if !more {
+			return frame.Function
+		}
+	}
+}
+
+func isLockWrapper(function string) bool {
+	if strings.Contains(function, ".(*nlc).") {
+		return true
+	}
+	idx := strings.LastIndex(function, ".")
+	return strings.Contains(function, "/cluster.") && strings.Contains(function[idx:], "Lock")
+}
+
+// returns the holder ID (to unlock with) and true, or (0, false) if the lock is taken
+func (nlc *nlc) TryLock(uname string, exclusive bool) (uint64, bool) {
 	nlc.mu.Lock()
 
 	realInfo, found := nlc.m[uname]
-	info := &lockInfo{}
 	if exclusive {
 		if found {
 			nlc.mu.Unlock()
-			return false
+			return 0, false
 		}
 
-		nlc.m[uname] = info
-		info.exclusive = true
+		holder := nlc.newHolder()
+		nlc.m[uname] = &lockInfo{exclusive: true, since: time.Now(), holders: []lockHolder{holder}}
 		nlc.mu.Unlock()
-		return true
+		return holder.id, true
 	}
 
 	// rlock
 	if found {
 		if realInfo.exclusive {
 			nlc.mu.Unlock()
-			return false
+			return 0, false
 		}
 	} else {
-		nlc.m[uname] = info // the 1st rlock
-		realInfo = info
+		realInfo = &lockInfo{since: time.Now()} // the 1st rlock
+		nlc.m[uname] = realInfo
 	}
-	realInfo.rc++
+	holder := nlc.newHolder()
+	realInfo.holders = append(realInfo.holders, holder)
 	nlc.mu.Unlock()
-	return true
+	return holder.id, true
 }
 
 // NOTE: Lock() stays in the loop for as long as needed to acquire the lock.
 //
 // The implementation is intentionally simple as we currently don't need
 // cancellation (via context.Context), timeout, sync.Cond.
-func (nlc *nlc) Lock(uname string, exclusive bool) {
-	if nlc.TryLock(uname, exclusive) {
-		return
+func (nlc *nlc) Lock(uname string, exclusive bool) uint64 {
+	if hid, ok := nlc.TryLock(uname, exclusive); ok {
+		return hid
 	}
 	sleep := initPollInterval
 	for {
 		time.Sleep(sleep)
-		if nlc.TryLock(uname, exclusive) {
+		if hid, ok := nlc.TryLock(uname, exclusive); ok {
 			if glog.FastV(4, glog.SmoduleCluster) {
 				glog.Infof("Lock %s(%t) - success", uname, exclusive)
 			}
-			return
+			return hid
 		}
 		if glog.FastV(4, glog.SmoduleCluster) {
 			glog.Infof("Lock %s(%t) - retrying...", uname, exclusive)
@@ -110,23 +258,29 @@ func (nlc *nlc) Lock(uname string, exclusive bool) {
 	}
 }
 
-func (nlc *nlc) DowngradeLock(uname string) {
+func (nlc *nlc) DowngradeLock(uname string, hid uint64) {
 	nlc.mu.Lock()
+	if name, ok := nlc.forced[hid]; ok && name == uname {
+		nlc.mu.Unlock() // force-released in the meantime - see Unlock
+		return
+	}
 	info, found := nlc.m[uname]
 	cmn.Assert(found && info.exclusive)
 	info.exclusive = false
-	info.rc++
-	cmn.Assert(info.rc == 1)
+	cmn.Assert(len(info.holders) == 1)
 	nlc.mu.Unlock()
 }
 
-func (nlc *nlc) TryUpgradeLock(uname string) bool {
+func (nlc *nlc) TryUpgradeLock(uname string, hid uint64) bool {
 	nlc.mu.Lock()
+	if name, ok := nlc.forced[hid]; ok && name == uname {
+		nlc.mu.Unlock() // force-released in the meantime - see Unlock
+		return false
+	}
 	info, found := nlc.m[uname]
-	cmn.Assert(found && !info.exclusive && info.rc > 0)
-	if info.rc == 1 {
+	cmn.Assert(found && !info.exclusive && len(info.holders) > 0)
+	if len(info.holders) == 1 {
 		info.exclusive = true
-		info.rc = 0
 		nlc.mu.Unlock()
 		return true
 	}
@@ -134,8 +288,13 @@ func (nlc *nlc) TryUpgradeLock(uname string) bool {
 	return false
 }
 
-func (nlc *nlc) Unlock(uname string, exclusive bool) {
+func (nlc *nlc) Unlock(uname string, exclusive bool, hid uint64) {
 	nlc.mu.Lock()
+	if nlc.wasForced(uname, hid) {
+		nlc.mu.Unlock()
+		glog.Warningf("unlock %s(%t): the lock has been force-released", uname, exclusive)
+		return
+	}
 	info, found := nlc.m[uname]
 	cmn.Assert(found)
 	if exclusive {
@@ -144,8 +303,8 @@ func (nlc *nlc) Unlock(uname string, exclusive bool) {
 		nlc.mu.Unlock()
 		return
 	}
-	info.rc--
-	if info.rc == 0 {
+	info.release(hid)
+	if len(info.holders) == 0 {
 		delete(nlc.m, uname)
 	}
 	nlc.mu.Unlock()
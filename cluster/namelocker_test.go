@@ -0,0 +1,98 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("NameLocker", func() {
+	var nl nameLocker
+
+	BeforeEach(func() {
+		nl = make(nameLocker, cmn.MultiSyncMapCount)
+		nl.init()
+	})
+
+	It("should list held locks along with their holders", func() {
+		nl[1].Lock("ais/@#/bck/obj1", true)
+		nl[2].Lock("ais/@#/bck/obj2", false)
+		nl[2].Lock("ais/@#/bck/obj2", false)
+		nl[3].Lock("ais/@#/other/obj", false)
+
+		locks := nl.List("ais/@#/bck/")
+		Expect(locks).To(HaveLen(2))
+		for _, lock := range locks {
+			switch lock.Name {
+			case "ais/@#/bck/obj1":
+				Expect(lock.Exclusive).To(BeTrue())
+			case "ais/@#/bck/obj2":
+				Expect(lock.Exclusive).To(BeFalse())
+				Expect(lock.Readers).To(Equal(2))
+			default:
+				Fail("unexpected lock " + lock.Name)
+			}
+			if debug.Enabled { // call stacks are captured in debug builds only
+				Expect(lock.Holder).NotTo(BeEmpty())
+				Expect(strings.Contains(lock.Holder, "(*nlc)")).To(BeFalse())
+			} else {
+				Expect(lock.Holder).To(BeEmpty())
+			}
+		}
+		Expect(nl.List("")).To(HaveLen(3))
+	})
+
+	It("should force-release locks and ignore stale unlocks", func() {
+		stale1 := nl[1].Lock("obj1", true)
+		stale2 := nl[2].Lock("obj2", false)
+		stale3 := nl[2].Lock("obj2", false)
+
+		Expect(nl.ForceUnlock("obj1")).To(BeTrue())
+		Expect(nl.ForceUnlock("obj2")).To(BeTrue())
+		Expect(nl.ForceUnlock("obj3")).To(BeFalse())
+		Expect(nl.List("")).To(BeEmpty())
+
+		// the locks can be taken again
+		hid1, ok := nl[1].TryLock("obj1", true)
+		Expect(ok).To(BeTrue())
+		nl[1].Unlock("obj1", true, stale1) // ignored: released by force
+		Expect(nl.List("obj1")).To(HaveLen(1))
+		nl[1].Unlock("obj1", true, hid1)
+		Expect(nl.List("obj1")).To(BeEmpty())
+
+		// new readers and the stale ones, interleaved
+		hid2 := nl[2].Lock("obj2", false)
+		nl[2].Unlock("obj2", false, stale2)
+		Expect(nl.List("obj2")).To(HaveLen(1))
+		hid3 := nl[2].Lock("obj2", false)
+		Expect(nl.List("obj2")[0].Readers).To(Equal(2))
+		nl[2].Unlock("obj2", false, hid2)
+		nl[2].Unlock("obj2", false, stale3)
+		Expect(nl.List("obj2")).To(HaveLen(1))
+		Expect(nl.List("obj2")[0].Readers).To(Equal(1))
+		nl[2].Unlock("obj2", false, hid3)
+		Expect(nl.List("obj2")).To(BeEmpty())
+		Expect(nl[1].forced).To(BeEmpty())
+		Expect(nl[2].forced).To(BeEmpty())
+	})
+
+	It("should not upgrade or downgrade force-released locks", func() {
+		stale := nl[1].Lock("obj", false)
+		Expect(nl.ForceUnlock("obj")).To(BeTrue())
+		hid := nl[1].Lock("obj", false)
+		Expect(nl[1].TryUpgradeLock("obj", stale)).To(BeFalse())
+		Expect(nl[1].TryUpgradeLock("obj", hid)).To(BeTrue())
+		nl[1].DowngradeLock("obj", hid)
+		Expect(nl.List("obj")[0].Exclusive).To(BeFalse())
+		nl[1].Unlock("obj", false, stale)
+		nl[1].Unlock("obj", false, hid)
+		Expect(nl.List("obj")).To(BeEmpty())
+	})
+})
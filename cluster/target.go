@@ -44,6 +44,15 @@ type CloudProvider interface {
 	ListBuckets(ctx context.Context, query cmn.QueryBcks) (buckets cmn.BucketNames, err error, errCode int)
 }
 
+// VersionedCloudProvider is implemented by the cloud providers that can get a
+// given version of the object (see cmn.URLParamObjVersion and cmn.URLParamAsOf)
+type VersionedCloudProvider interface {
+	// returns the version of the object that was current at a given time
+	ObjVersionAsOf(ctx context.Context, lom *LOM, asOf time.Time) (version string, err error, errCode int)
+	// same as CloudProvider.GetObj but gets a given version of the object
+	GetObjVersion(ctx context.Context, fqn string, lom *LOM, version string) (err error, errCode int)
+}
+
 // a callback called by EC PUT jogger after the object is processed and
 // all its slices/replicas are sent to other targets
 type OnFinishObj = func(lom *LOM, err error)
@@ -59,6 +68,7 @@ type PutObjectParams struct {
 	WorkFQN      string
 	RecvType     RecvType
 	Cksum        *cmn.Cksum // checksum to check
+	Size         int64      // expected size, if known
 	Started      time.Time
 	WithFinalize bool // determines if we should also finalize the object
 	SkipEncode   bool // Do not run EC encode after finalizing
@@ -12,6 +12,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/dbdriver"
 	"github.com/NVIDIA/aistore/tutils/tassert"
+	"github.com/dgrijalva/jwt-go"
 )
 
 // NOTE: when a fresh user manager is created, it initailized users DB and
@@ -93,7 +94,7 @@ func testUserDelete(mgr *userManager, t *testing.T) {
 		t.Errorf("Expected %d users but found %d", len(users)+2, len(srvUsers))
 	}
 
-	token, err := mgr.issueToken(username, userpass)
+	token, err := mgr.issueToken(username, userpass, "")
 	if err != nil || token == "" {
 		t.Errorf("Failed to generate token for %s: %v", username, err)
 	}
@@ -107,7 +108,7 @@ func testUserDelete(mgr *userManager, t *testing.T) {
 	if len(srvUsers) != len(users)+1 {
 		t.Errorf("Expected %d users but found %d", len(users)+1, len(srvUsers))
 	}
-	token, err = mgr.issueToken(username, userpass)
+	token, err = mgr.issueToken(username, userpass, "")
 	if err == nil {
 		t.Errorf("Token issued for deleted user  %s: %v", username, token)
 	} else if err != errInvalidCredentials {
@@ -138,7 +139,7 @@ func TestToken(t *testing.T) {
 
 	// correct user creds
 	shortExpiration := 2 * time.Second
-	token, err = mgr.issueToken(users[1], passs[1], shortExpiration)
+	token, err = mgr.issueToken(users[1], passs[1], "", shortExpiration)
 	if err != nil || token == "" {
 		t.Errorf("Failed to generate token for %s: %v", users[1], err)
 	}
@@ -155,7 +156,7 @@ func TestToken(t *testing.T) {
 	}
 
 	// incorrect user creds
-	tokenInval, err := mgr.issueToken(users[1], passs[0])
+	tokenInval, err := mgr.issueToken(users[1], passs[0], "")
 	if tokenInval != "" || err == nil {
 		t.Errorf("Some token generated for incorrect user creds: %v", tokenInval)
 	}
@@ -174,7 +175,7 @@ func TestToken(t *testing.T) {
 	}
 
 	// revoke token test
-	token, err = mgr.issueToken(users[1], passs[1])
+	token, err = mgr.issueToken(users[1], passs[1], "")
 	if err == nil {
 		_, err = mgr.userByToken(token)
 	}
@@ -194,3 +195,64 @@ func TestToken(t *testing.T) {
 
 	deleteUsers(mgr, false, t)
 }
+
+// returns the cluster ID claim of a given token
+func tokenClusterID(t *testing.T, token string) string {
+	parsed, err := jwt.Parse(token, func(*jwt.Token) (interface{}, error) { return []byte(conf.Auth.Secret), nil })
+	tassert.CheckFatal(t, err)
+	cluID, _ := parsed.Claims.(jwt.MapClaims)["cluster_id"].(string)
+	return cluID
+}
+
+func TestClusterToken(t *testing.T) {
+	const (
+		cluID    = "clu-uuid"
+		cluAlias = "clu-alias"
+	)
+	driver := dbdriver.NewDBMock()
+	mgr, err := newUserManager(driver)
+	tassert.CheckError(t, err)
+	createUsers(mgr, t)
+	defer deleteUsers(mgr, false, t)
+
+	// unregistered cluster
+	_, err = mgr.issueToken(users[1], passs[1], cluID)
+	if err == nil {
+		t.Errorf("Token issued for unregistered cluster %q", cluID)
+	}
+
+	err = mgr.addCluster(&cmn.AuthCluster{ID: cluID, Alias: cluAlias})
+	tassert.CheckFatal(t, err)
+	token, err := mgr.issueToken(users[1], passs[1], cluAlias)
+	tassert.CheckFatal(t, err)
+	if id := tokenClusterID(t, token); id != cluID {
+		t.Errorf("Expected token scoped to %q, got %q", cluID, id)
+	}
+
+	// unscoped token must be a different one
+	unscoped, err := mgr.issueToken(users[1], passs[1], "")
+	tassert.CheckFatal(t, err)
+	if unscoped == token {
+		t.Error("Unscoped login returned the scoped token")
+	}
+	if id := tokenClusterID(t, unscoped); id != "" {
+		t.Errorf("Expected unscoped token, got one scoped to %q", id)
+	}
+}
+
+func TestClusterTokenPermissions(t *testing.T) {
+	var (
+		bck = &cmn.Bck{Name: "bck", Provider: cmn.ProviderAIS}
+		tk  = &cmn.AuthToken{IsAdmin: true, ClusterID: "clu-uuid"}
+	)
+	if err := tk.CheckPermissions("clu-uuid", bck, cmn.AccessGET); err != nil {
+		t.Errorf("Token rejected by its own cluster: %v", err)
+	}
+	if err := tk.CheckPermissions("other-uuid", bck, cmn.AccessGET); err == nil {
+		t.Error("Token accepted by a different cluster")
+	}
+	tk.ClusterID = ""
+	if err := tk.CheckPermissions("other-uuid", bck, cmn.AccessGET); err != nil {
+		t.Errorf("Unscoped token rejected: %v", err)
+	}
+}
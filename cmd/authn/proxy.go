@@ -23,6 +23,13 @@ func (m *userManager) broadcastRevoked(token string) {
 	m.broadcast(http.MethodDelete, cmn.Tokens, body)
 }
 
+// register the token for S3 API access on all clusters (see cmn.S3AccessKeyID)
+func (m *userManager) broadcastS3Token(token string) {
+	tokenList := ais.TokenList{S3Tokens: []string{token}}
+	body := cmn.MustMarshal(tokenList)
+	m.broadcast(http.MethodPost, cmn.Tokens, body)
+}
+
 // broadcast the request to all clusters. If a cluster has a few URLS,
 // it sends to the first working one. Clusters are processed in parallel.
 func (m *userManager) broadcast(method, path string, body []byte) {
@@ -43,7 +50,7 @@ func (m *userManager) broadcast(method, path string, body []byte) {
 				}
 			}
 			if err != nil {
-				glog.Errorf("Failed to sync revoked tokens with %q: %v", clu.ID, err)
+				glog.Errorf("Failed to sync tokens with %q: %v", clu.ID, err)
 			}
 		}(clu)
 	}
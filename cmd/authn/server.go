@@ -25,9 +25,12 @@ const (
 // a message to generate token
 // POST: <version>/<pathUsers>/<username>
 //		Body: <loginMsg>
-//	Returns: <tokenMsg>
+//	Returns: <tokenMsg> and the user's S3 credentials
+//	(see cmn.S3AccessKeyID and cmn.S3SecretKey)
+//	Optional cluster ID (or alias) scopes the token to a given registered cluster
 type loginMsg struct {
-	Password string `json:"password"`
+	Password  string `json:"password"`
+	ClusterID string `json:"cluster_id,omitempty"`
 }
 
 // a message to test token validity and to revoke existing token
@@ -314,14 +317,18 @@ func (a *authServ) userLogin(w http.ResponseWriter, r *http.Request) {
 		glog.Infof("User: %s, pass: %s\n", userID, pass)
 	}
 
-	tokenString, err := a.users.issueToken(userID, pass)
+	tokenString, err := a.users.issueToken(userID, pass, msg.ClusterID)
 	if err != nil {
 		glog.Errorf("Failed to generate token: %v\n", err)
 		cmn.InvalidHandlerWithMsg(w, r, "Not authorized", http.StatusUnauthorized)
 		return
 	}
 
-	repl := fmt.Sprintf(`{"token": "%s"}`, tokenString)
+	// the clusters map the user's S3 access key ID to the token
+	a.users.broadcastS3Token(tokenString)
+	keyID := cmn.S3AccessKeyID(userID, conf.Auth.Secret)
+	repl := fmt.Sprintf(`{"token": "%s", "s3_access_key_id": "%s", "s3_secret_key": "%s"}`,
+		tokenString, keyID, cmn.S3SecretKey(keyID, conf.Auth.Secret))
 	a.writeBytes(w, []byte(repl), "auth")
 }
 
@@ -332,12 +332,21 @@ func (m *userManager) fixClusterIDs(lst []*cmn.AuthCluster) {
 // Generates a token for a user if user credentials are valid. If the token is
 // already generated and is not expired yet the existing token is returned.
 // Token includes user ID, permissions, and token expiration time.
+// Non-empty cluID (ID or alias of a registered cluster) scopes the token:
+// the token is then valid only for that cluster.
 // If a new token was generated then it sends the proxy a new valid token list
-func (m *userManager) issueToken(userID, pwd string, ttl ...time.Duration) (string, error) {
+func (m *userManager) issueToken(userID, pwd, cluID string, ttl ...time.Duration) (string, error) {
 	var (
 		err     error
 		expires time.Time
 	)
+	if cluID != "" {
+		cid := m.cluLookup(cluID, cluID)
+		if cid == "" {
+			return "", fmt.Errorf("cluster %q is not registered", cluID)
+		}
+		cluID = cid
+	}
 
 	uInfo := &cmn.AuthUser{}
 	err = m.db.Get(usersCollection, userID, uInfo)
@@ -351,7 +360,7 @@ func (m *userManager) issueToken(userID, pwd string, ttl ...time.Duration) (stri
 
 	tInfo := &cmn.AuthToken{}
 	err = m.db.Get(tokensCollection, userID, tInfo)
-	if err == nil && tInfo.Expires.After(time.Now()) {
+	if err == nil && tInfo.Expires.After(time.Now()) && tInfo.ClusterID == cluID {
 		return tInfo.Token, nil
 	}
 
@@ -369,22 +378,35 @@ func (m *userManager) issueToken(userID, pwd string, ttl ...time.Duration) (stri
 	// put all useful info into token: who owns the token, when it was issued,
 	// when it expires and credentials to log in AWS, GCP etc.
 	// If a user is a super user, it is enough to pass only isAdmin marker
-	var t *jwt.Token
+	var claims jwt.MapClaims
 	if uInfo.IsAdmin() {
-		t = jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		claims = jwt.MapClaims{
 			"expires":  expires,
 			"username": userID,
 			"admin":    true,
-		})
+		}
 	} else {
 		m.fixClusterIDs(uInfo.Clusters)
-		t = jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		clusters := uInfo.Clusters
+		if cluID != "" {
+			clusters = make([]*cmn.AuthCluster, 0, 1)
+			for _, clu := range uInfo.Clusters {
+				if clu.ID == cluID {
+					clusters = append(clusters, clu)
+				}
+			}
+		}
+		claims = jwt.MapClaims{
 			"expires":  expires,
 			"username": userID,
 			"buckets":  uInfo.Buckets,
-			"clusters": uInfo.Clusters,
-		})
+			"clusters": clusters,
+		}
+	}
+	if cluID != "" {
+		claims["cluster_id"] = cluID
 	}
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := t.SignedString([]byte(conf.Auth.Secret))
 	if err != nil {
 		return "", fmt.Errorf("failed to generate token: %v", err)
@@ -392,9 +414,10 @@ func (m *userManager) issueToken(userID, pwd string, ttl ...time.Duration) (stri
 
 	// TODO: multiple tokens per user
 	tInfo = &cmn.AuthToken{
-		UserID:  userID,
-		Expires: expires,
-		Token:   tokenString,
+		UserID:    userID,
+		Expires:   expires,
+		Token:     tokenString,
+		ClusterID: cluID,
 	}
 	err = m.db.Set(tokensCollection, userID, tInfo)
 	return tokenString, err
@@ -16,10 +16,12 @@ var (
 	bucketSpecificCmdsFlags = map[string][]cli.Flag{
 		commandSetCopies: {
 			copiesFlag,
+			tagsFlag,
 		},
 		commandECEncode: {
 			dataSlicesFlag,
 			paritySlicesFlag,
+			tagsFlag,
 		},
 	}
 
@@ -108,7 +108,11 @@ func renameBucket(c *cli.Context, fromBck, toBck cmn.Bck) (err error) {
 
 // Copy ais bucket
 func copyBucket(c *cli.Context, fromBck, toBck cmn.Bck) (err error) {
-	if err = api.CopyBucket(defaultAPIParams, fromBck, toBck); err != nil {
+	tags, err := parseXactTags(c)
+	if err != nil {
+		return
+	}
+	if err = api.CopyBucket(defaultAPIParams, fromBck, toBck, tags); err != nil {
 		return
 	}
 
@@ -380,6 +384,9 @@ func showBucketProps(c *cli.Context) (err error) {
 	if _, p, err = validateBucket(c, bck, "", false); err != nil {
 		return
 	}
+	if flagIsSet(c, provenanceFlag) {
+		return printBckProvenanceTable(c, bck, p, section)
+	}
 	if flagIsSet(c, jsonFlag) {
 		return templates.DisplayOutput(p, c.App.Writer, "", true)
 	}
@@ -387,6 +394,41 @@ func showBucketProps(c *cli.Context) (err error) {
 	return printBckHeadTable(c, p, section)
 }
 
+func printBckProvenanceTable(c *cli.Context, bck cmn.Bck, props *cmn.BucketProps, section string) error {
+	type prop struct {
+		Name    string
+		Value   string
+		Source  string
+		Version int64
+	}
+
+	provenance, err := api.HeadBucketProvenance(defaultAPIParams, bck)
+	if err != nil {
+		return err
+	}
+	if flagIsSet(c, jsonFlag) {
+		return templates.DisplayOutput(provenance, c.App.Writer, "", true)
+	}
+	propList := make([]prop, 0, len(provenance))
+	err = cmn.IterFields(props, func(uniqueTag string, field cmn.IterField) (error, bool) {
+		pp, ok := provenance[uniqueTag]
+		if !ok || !strings.HasPrefix(uniqueTag, section) {
+			return nil, false
+		}
+		value := fmt.Sprintf("%v", field.Value())
+		if uniqueTag == cmn.HeaderBucketAccessAttrs {
+			value = props.Access.Describe()
+		}
+		propList = append(propList, prop{Name: uniqueTag, Value: value, Source: pp.Source, Version: pp.Version})
+		return nil, false
+	})
+	cmn.AssertNoErr(err)
+	sort.Slice(propList, func(i, j int) bool {
+		return propList[i].Name < propList[j].Name
+	})
+	return templates.DisplayOutput(propList, c.App.Writer, templates.BucketPropsProvenanceTmpl)
+}
+
 func printBckHeadTable(c *cli.Context, props *cmn.BucketProps, section string) error {
 	type prop struct {
 		Name  string
@@ -418,6 +460,13 @@ func printBckHeadTable(c *cli.Context, props *cmn.BucketProps, section string) e
 			{"checksum", props.Cksum.String()},
 			{"mirror", props.Mirror.String()},
 			{"ec", props.EC.String()},
+			{"journal", props.Journal.String()},
+			{"headers", props.Headers.String()},
+			{"encryption", props.Encryption.String()},
+			{"quota", props.Quota.String()},
+			{"placement", props.Placement.String()},
+			{"trash", props.Trash.String()},
+			{"fencing", props.Fencing.String()},
 			{"lru", props.LRU.String()},
 			{"versioning", props.Versioning.String()},
 		}
@@ -441,7 +490,11 @@ func printBckHeadTable(c *cli.Context, props *cmn.BucketProps, section string) e
 
 // Configure bucket as n-way mirror
 func configureNCopies(c *cli.Context, bck cmn.Bck, copies int) (err error) {
-	if err = api.MakeNCopies(defaultAPIParams, bck, copies); err != nil {
+	tags, err := parseXactTags(c)
+	if err != nil {
+		return
+	}
+	if err = api.MakeNCopies(defaultAPIParams, bck, copies, tags); err != nil {
 		return
 	}
 	if copies > 1 {
@@ -454,7 +507,11 @@ func configureNCopies(c *cli.Context, bck cmn.Bck, copies int) (err error) {
 
 // erasure code the entire bucket
 func ecEncode(c *cli.Context, bck cmn.Bck, data, parity int) (err error) {
-	if err = api.ECEncodeBucket(defaultAPIParams, bck, data, parity); err != nil {
+	tags, err := parseXactTags(c)
+	if err != nil {
+		return
+	}
+	if err = api.ECEncodeBucket(defaultAPIParams, bck, data, parity, tags); err != nil {
 		return
 	}
 	fmt.Fprintf(c.App.Writer, "Erasure-coding bucket %q, use '%s %s %s %s %s' to monitor the progress\n",
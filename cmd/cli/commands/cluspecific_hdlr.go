@@ -7,7 +7,7 @@ package commands
 
 import (
 	"fmt"
-	"strings"
+	"net"
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cluster"
@@ -47,20 +47,20 @@ var (
 
 func joinNodeHandler(c *cli.Context) (err error) {
 	var (
-		daemonType      = c.Command.Name // proxy|target
-		prefix          string
-		daemonID        string
-		socketAddr      string
-		socketAddrParts []string
+		daemonType = c.Command.Name // proxy|target
+		prefix     string
+		daemonID   string
+		socketAddr string
+		host, port string
 	)
 	if c.NArg() < 1 {
 		return missingArgumentsError(c, "public socket address to communicate with the node")
 	}
 	socketAddr = c.Args().Get(0)
 
-	socketAddrParts = strings.Split(socketAddr, ":")
-	if len(socketAddrParts) != 2 {
-		return fmt.Errorf("invalid socket address, format 'IP:PORT' expected")
+	// IPv6: [IP]:PORT
+	if host, port, err = net.SplitHostPort(socketAddr); err != nil {
+		return fmt.Errorf("invalid socket address, format 'IP:PORT' or '[IPv6]:PORT' expected")
 	}
 
 	daemonID = c.Args().Get(1) // user-given ID
@@ -74,8 +74,8 @@ func joinNodeHandler(c *cli.Context) (err error) {
 	}
 
 	netInfo := cluster.NetInfo{
-		NodeIPAddr: socketAddrParts[0],
-		DaemonPort: socketAddrParts[1],
+		NodeIPAddr: host,
+		DaemonPort: port,
 		DirectURL:  prefix + socketAddr,
 	}
 	nodeInfo := &cluster.Snode{
@@ -38,6 +38,10 @@ const (
 	commandShow      = "show"
 	commandStart     = cmn.ActXactStart
 	commandStop      = cmn.ActXactStop
+	commandPause     = cmn.ActXactPause
+	commandResume    = cmn.ActXactResume
+	commandUndelete  = cmn.ActUndelete
+	commandWarmup    = cmn.ActWarmup
 	commandWait      = "wait"
 	commandSearch    = "search"
 
@@ -100,6 +104,10 @@ const (
 	subcmdStopDsort    = subcmdDsort
 	subcmdStopDownload = subcmdDownload
 
+	// Pause/Resume subcommands
+	subcmdPauseXaction  = subcmdXaction
+	subcmdResumeXaction = subcmdXaction
+
 	// Set subcommand
 	subcmdSetConfig  = subcmdConfig
 	subcmdSetProps   = subcmdProps
@@ -231,8 +239,12 @@ var (
 	pagedFlag         = cli.BoolFlag{Name: "paged", Usage: "fetch and print the bucket list page by page, ignored in fast mode"}
 	showUnmatchedFlag = cli.BoolTFlag{Name: "show-unmatched", Usage: "list objects that were not matched by regex and template"}
 	activeFlag        = cli.BoolFlag{Name: "active", Usage: "show only running xactions"}
+	historyFlag       = cli.BoolFlag{Name: "history", Usage: "show the finished xactions as recorded by the targets (see config 'xaction_history')"}
 	dataSlicesFlag    = cli.IntFlag{Name: "data-slices,data,d", Usage: "number of data slices", Required: true}
 	paritySlicesFlag  = cli.IntFlag{Name: "parity-slices,parity,p", Usage: "number of parity slices", Required: true}
+	provenanceFlag    = cli.BoolFlag{Name: "provenance", Usage: "show whether each property comes from cluster defaults or a bucket-level override, and when it was last changed"}
+	mlockFlag         = cli.StringFlag{Name: "mlock", Usage: "memory budget (per target, at most 25% of its memory) to lock the objects in, e.g. '10GiB' (until the xaction is stopped)"}
+	tagsFlag          = cli.StringFlag{Name: "tags", Usage: "comma-separated KEY=VALUE tags to attach to the xaction (show: to match), e.g. 'team=ml,run=42'"}
 
 	// Daeclu
 	countFlag = cli.IntFlag{Name: "count", Usage: "total number of generated reports", Value: countDefault}
@@ -271,7 +283,8 @@ var (
 	computeCksumFlag = cli.BoolFlag{Name: "compute-cksum", Usage: "compute the checksum with the type configured for the bucket"}
 	checksumFlags    = getCksumFlags()
 	// AuthN
-	tokenFileFlag = cli.StringFlag{Name: "file,f", Value: "", Usage: "save token to file"}
+	tokenFileFlag    = cli.StringFlag{Name: "file,f", Value: "", Usage: "save token to file"}
+	clusterTokenFlag = cli.StringFlag{Name: "cluster", Value: "", Usage: "issue token valid only for a given cluster (UUID or alias)"}
 
 	longRunFlags = []cli.Flag{refreshFlag, countFlag}
 
@@ -42,6 +42,14 @@ var (
 		subcmdStopDsort:    {},
 	}
 
+	pauseCmdsFlags = map[string][]cli.Flag{
+		subcmdPauseXaction: {},
+	}
+
+	resumeCmdsFlags = map[string][]cli.Flag{
+		subcmdResumeXaction: {},
+	}
+
 	controlCmds = []cli.Command{
 		{
 			Name:  commandStart,
@@ -102,6 +110,36 @@ var (
 				},
 			},
 		},
+		{
+			Name:  commandPause,
+			Usage: "pause jobs running in the cluster",
+			Subcommands: []cli.Command{
+				{
+					Name:         subcmdPauseXaction,
+					Usage:        "pause an xaction (without losing its progress)",
+					ArgsUsage:    "XACTION_NAME [BUCKET_NAME]",
+					Description:  xactionDesc(cmn.ActXactPause),
+					Flags:        pauseCmdsFlags[subcmdPauseXaction],
+					Action:       pauseXactionHandler,
+					BashComplete: xactionCompletions(cmn.ActXactPause),
+				},
+			},
+		},
+		{
+			Name:  commandResume,
+			Usage: "resume paused jobs",
+			Subcommands: []cli.Command{
+				{
+					Name:         subcmdResumeXaction,
+					Usage:        "resume a paused xaction",
+					ArgsUsage:    "XACTION_NAME [BUCKET_NAME]",
+					Description:  xactionDesc(cmn.ActXactResume),
+					Flags:        resumeCmdsFlags[subcmdResumeXaction],
+					Action:       resumeXactionHandler,
+					BashComplete: xactionCompletions(cmn.ActXactResume),
+				},
+			},
+		},
 	}
 )
 
@@ -163,6 +201,44 @@ func stopXactionHandler(c *cli.Context) (err error) {
 	return
 }
 
+func pauseXactionHandler(c *cli.Context) error {
+	return pauseResumeXaction(c, true /*pause*/)
+}
+
+func resumeXactionHandler(c *cli.Context) error {
+	return pauseResumeXaction(c, false /*pause*/)
+}
+
+func pauseResumeXaction(c *cli.Context, pause bool) (err error) {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "xaction name")
+	}
+	xactID, xactKind, bck, err := parseXactionFromArgs(c)
+	if err != nil {
+		return err
+	}
+	if xactID != "" {
+		return fmt.Errorf("%q is not a valid xaction", xactID)
+	}
+	xactArgs := api.XactReqArgs{Kind: xactKind, Bck: bck}
+	verb := "Paused"
+	if pause {
+		err = api.PauseXaction(defaultAPIParams, xactArgs)
+	} else {
+		verb = "Resumed"
+		err = api.ResumeXaction(defaultAPIParams, xactArgs)
+	}
+	if err != nil {
+		return
+	}
+	if bck.IsEmpty() {
+		fmt.Fprintf(c.App.Writer, "%s %s\n", verb, xactKind)
+	} else {
+		fmt.Fprintf(c.App.Writer, "%s %s, bucket=%s\n", verb, xactKind, bck)
+	}
+	return
+}
+
 func startDownloadHandler(c *cli.Context) error {
 	var (
 		description     = parseStrFlag(c, descriptionFlag)
@@ -14,7 +14,9 @@ import (
 
 var (
 	copyCmdsFlags = map[string][]cli.Flag{
-		subcmdCopyBucket: {},
+		subcmdCopyBucket: {
+			tagsFlag,
+		},
 	}
 
 	copyCmds = []cli.Command{
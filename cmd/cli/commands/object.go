@@ -709,8 +709,12 @@ func listOp(c *cli.Context, command string, bck cmn.Bck) (err error) {
 		err = api.DeleteList(defaultAPIParams, bck, fileList)
 		command = "removed"
 	case commandPrefetch:
+		var tags cmn.XactTags
+		if tags, err = parseXactTags(c); err != nil {
+			return
+		}
 		bck.Provider = cmn.AnyCloud
-		err = api.PrefetchList(defaultAPIParams, bck, fileList)
+		err = api.PrefetchList(defaultAPIParams, bck, fileList, tags)
 		command += "ed"
 	case commandEvict:
 		bck.Provider = cmn.AnyCloud
@@ -752,8 +756,12 @@ func rangeOp(c *cli.Context, command string, bck cmn.Bck) (err error) {
 		err = api.DeleteRange(defaultAPIParams, bck, rangeStr)
 		command = "removed"
 	case commandPrefetch:
+		var tags cmn.XactTags
+		if tags, err = parseXactTags(c); err != nil {
+			return
+		}
 		bck.Provider = cmn.AnyCloud
-		err = api.PrefetchRange(defaultAPIParams, bck, rangeStr)
+		err = api.PrefetchRange(defaultAPIParams, bck, rangeStr, tags)
 		command += "ed"
 	case commandEvict:
 		bck.Provider = cmn.AnyCloud
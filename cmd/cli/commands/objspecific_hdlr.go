@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/urfave/cli"
 )
@@ -18,6 +19,7 @@ var (
 		commandPrefetch: append(
 			baseLstRngFlags,
 			dryRunFlag,
+			tagsFlag,
 		),
 		commandEvict: append(
 			baseLstRngFlags,
@@ -56,6 +58,11 @@ var (
 			lengthFlag,
 			checksumFlag,
 		},
+		commandWarmup: {
+			listFlag,
+			templateFlag,
+			mlockFlag,
+		},
 	}
 
 	objectSpecificCmds = []cli.Command{
@@ -114,6 +121,21 @@ var (
 			Action:       catHandler,
 			BashComplete: bucketCompletions(bckCompletionsOpts{separator: true}),
 		},
+		{
+			Name:         commandWarmup,
+			Usage:        "read objects into the page cache of the targets (and, optionally, lock them in memory)",
+			ArgsUsage:    bucketArgument,
+			Flags:        objectSpecificCmdsFlags[commandWarmup],
+			Action:       warmupHandler,
+			BashComplete: bucketCompletions(),
+		},
+		{
+			Name:         commandUndelete,
+			Usage:        "restore the object that was deleted last from the ais bucket's trash",
+			ArgsUsage:    objectArgument,
+			Action:       undeleteHandler,
+			BashComplete: bucketCompletions(bckCompletionsOpts{separator: true, provider: cmn.ProviderAIS}),
+		},
 	}
 )
 
@@ -332,3 +354,74 @@ func catHandler(c *cli.Context) (err error) {
 	}
 	return getObject(c, bck, objName, fileStdIO)
 }
+
+func undeleteHandler(c *cli.Context) (err error) {
+	var (
+		bck         cmn.Bck
+		objName     string
+		fullObjName = c.Args().Get(0)
+	)
+	if c.NArg() < 1 {
+		return missingArgumentsError(c, "object name in the form bucket/object")
+	}
+	if c.NArg() > 1 {
+		return incorrectUsageMsg(c, "too many arguments")
+	}
+	if bck, objName, err = parseBckObjectURI(fullObjName); err != nil {
+		return
+	}
+	if objName == "" {
+		return incorrectUsageMsg(c, "%q: missing object name", fullObjName)
+	}
+	if bck.Provider != "" && !bck.IsAIS() {
+		return incorrectUsageMsg(c, "provider %q not supported", bck.Provider)
+	}
+	bck.Provider = cmn.ProviderAIS
+	if err = api.UndeleteObject(defaultAPIParams, bck, objName); err != nil {
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "%q restored in %s\n", objName, bck)
+	return
+}
+
+func warmupHandler(c *cli.Context) (err error) {
+	var (
+		bck     cmn.Bck
+		objName string
+		msg     cmn.WarmupMsg
+	)
+	if c.NArg() == 0 {
+		return incorrectUsageMsg(c, "missing bucket name")
+	}
+	if c.NArg() > 1 {
+		return incorrectUsageMsg(c, "too many arguments")
+	}
+	if flagIsSet(c, listFlag) == flagIsSet(c, templateFlag) {
+		return incorrectUsageMsg(c, "exactly one of the flags %q and %q must be set", listFlag.Name, templateFlag.Name)
+	}
+	if bck, objName, err = parseBckObjectURI(c.Args().First()); err != nil {
+		return
+	}
+	if objName != "" {
+		return incorrectUsageMsg(c, "object name not supported, use list flag or template flag")
+	}
+	if bck, _, err = validateBucket(c, bck, "", false); err != nil {
+		return
+	}
+	if flagIsSet(c, listFlag) {
+		msg.ObjNames = makeList(parseStrFlag(c, listFlag), ",")
+	} else {
+		msg.Template = parseStrFlag(c, templateFlag)
+	}
+	if flagIsSet(c, mlockFlag) {
+		if msg.MlockBudget, err = parseByteFlagToInt(c, mlockFlag); err != nil {
+			return
+		}
+	}
+	xactID, err := api.Warmup(defaultAPIParams, bck, msg)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "Warming up %s. To monitor the progress and the cached fraction, run: ais show xaction %s\n", bck, xactID)
+	return
+}
@@ -292,7 +292,7 @@ func xactionCompletions(cmd string) func(ctx *cli.Context) {
 	return func(c *cli.Context) {
 		if c.NArg() == 0 {
 			for kind, meta := range cmn.XactsMeta {
-				if (cmd != cmn.ActXactStart) || (cmd == cmn.ActXactStart && meta.Startable) {
+				if xactSupports(cmd, meta) {
 					fmt.Println(kind)
 				}
 			}
@@ -307,10 +307,21 @@ func xactionCompletions(cmd string) func(ctx *cli.Context) {
 	}
 }
 
+func xactSupports(cmd string, meta cmn.XactMetadata) bool {
+	switch cmd {
+	case cmn.ActXactStart:
+		return meta.Startable
+	case cmn.ActXactPause, cmn.ActXactResume:
+		return meta.Pausable
+	default:
+		return true
+	}
+}
+
 func xactionDesc(cmd string) string {
 	xactKinds := make([]string, 0, len(cmn.XactsMeta))
 	for kind, meta := range cmn.XactsMeta {
-		if (cmd != cmn.ActXactStart) || (cmd == cmn.ActXactStart && meta.Startable) {
+		if xactSupports(cmd, meta) {
 			xactKinds = append(xactKinds, kind)
 		}
 	}
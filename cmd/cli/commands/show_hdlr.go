@@ -69,6 +69,8 @@ var (
 			allItemsFlag,
 			activeFlag,
 			verboseFlag,
+			tagsFlag,
+			historyFlag,
 		},
 		subcmdShowRebalance: {
 			refreshFlag,
@@ -76,6 +78,7 @@ var (
 		subcmdShowBckProps: {
 			jsonFlag,
 			verboseFlag,
+			provenanceFlag,
 		},
 		subcmdShowConfig: {
 			jsonFlag,
@@ -275,6 +278,13 @@ func showXactionHandler(c *cli.Context) (err error) {
 	if err != nil {
 		return err
 	}
+	if flagIsSet(c, historyFlag) {
+		recs, err := api.GetXactionHistory(defaultAPIParams, api.XactReqArgs{ID: xactID, Kind: xactKind, Bck: bck})
+		if err != nil {
+			return err
+		}
+		return templates.DisplayOutput(recs, c.App.Writer, templates.XactHistoryTmpl, flagIsSet(c, jsonFlag))
+	}
 	var xactStats api.NodesXactStats
 	if xactID != "" {
 		xactStats, err = api.GetXactionStatsByID(defaultAPIParams, xactID)
@@ -282,7 +292,11 @@ func showXactionHandler(c *cli.Context) (err error) {
 			return
 		}
 	} else {
-		xactArgs := api.XactReqArgs{ID: xactID, Kind: xactKind, Bck: bck, Latest: !flagIsSet(c, allItemsFlag)}
+		var tags cmn.XactTags
+		if tags, err = parseXactTags(c); err != nil {
+			return
+		}
+		xactArgs := api.XactReqArgs{ID: xactID, Kind: xactKind, Bck: bck, Latest: !flagIsSet(c, allItemsFlag), Tags: tags}
 		xactStats, err = api.QueryXactionStats(defaultAPIParams, xactArgs)
 		if err != nil {
 			return
@@ -338,7 +352,37 @@ func showXactionHandler(c *cli.Context) (err error) {
 		Verbose: flagIsSet(c, verboseFlag),
 	}
 
-	return templates.DisplayOutput(ctx, c.App.Writer, templates.XactionsBodyTmpl, flagIsSet(c, jsonFlag))
+	if err = templates.DisplayOutput(ctx, c.App.Writer, templates.XactionsBodyTmpl, flagIsSet(c, jsonFlag)); err != nil {
+		return
+	}
+	if !flagIsSet(c, jsonFlag) {
+		showXactionProgress(c, xactStats)
+	}
+	return
+}
+
+// shows the cluster-wide percent complete of the (single) running bucket xaction, if known
+func showXactionProgress(c *cli.Context, xactStats api.NodesXactStats) {
+	var xactID string
+	for _, daemonStats := range xactStats {
+		for _, xact := range daemonStats {
+			if !xact.Running() {
+				continue
+			}
+			if xactID != "" && xactID != xact.ID() {
+				return // more than one
+			}
+			xactID = xact.ID()
+		}
+	}
+	if xactID == "" {
+		return
+	}
+	prog, err := api.GetXactionProgress(defaultAPIParams, xactID)
+	if err != nil || prog.Pct < 0 {
+		return // not notified of (e.g., not a bucket xaction) or not yet known
+	}
+	fmt.Fprintf(c.App.Writer, "\nProgress: %d%% (%d of ~%d objects)\n", prog.Pct, prog.Objects, prog.TotalObjs)
 }
 
 func showObjectHandler(c *cli.Context) (err error) {
@@ -32,7 +32,7 @@ const (
 
 var (
 	authFlags = map[string][]cli.Flag{
-		flagsAuthUserLogin: {tokenFileFlag},
+		flagsAuthUserLogin: {tokenFileFlag, clusterTokenFlag},
 		flagsAuthRoleAdd:   {descriptionFlag},
 	}
 	authCmds = []cli.Command{
@@ -257,7 +257,7 @@ func loginUserHandler(c *cli.Context) (err error) {
 	}
 	name := cliAuthnUserName(c)
 	password := cliAuthnUserPassword(c)
-	token, err := api.LoginUser(authParams, name, password)
+	token, err := api.LoginUser(authParams, name, password, parseStrFlag(c, clusterTokenFlag))
 	if err != nil {
 		return err
 	}
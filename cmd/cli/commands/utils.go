@@ -527,6 +527,22 @@ func makeList(list, delimiter string) []string {
 	return cleanList
 }
 
+// Parses the tagsFlag ("key=value,...")
+func parseXactTags(c *cli.Context) (tags cmn.XactTags, err error) {
+	if !flagIsSet(c, tagsFlag) {
+		return
+	}
+	tags = cmn.XactTags{}
+	for _, tag := range makeList(parseStrFlag(c, tagsFlag), ",") {
+		kv := strings.SplitN(tag, keyAndValueSeparator, 2)
+		if len(kv) != 2 || kv[0] == "" || kv[1] == "" {
+			return nil, fmt.Errorf("invalid tag %q: expecting KEY%sVALUE", tag, keyAndValueSeparator)
+		}
+		tags[strings.TrimSpace(kv[0])] = strings.TrimSpace(kv[1])
+	}
+	return
+}
+
 // Converts a list of "key value" and "key=value" into map
 func makePairs(args []string) (nvs cmn.SimpleKVs, err error) {
 	var (
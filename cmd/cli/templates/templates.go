@@ -106,7 +106,10 @@ const (
 		" Maximum Total Size:\t{{$obj.MaxTotal}}\n"
 	PeriodConfTmpl = "\n{{$obj := .Periodic}}Period Config\n" +
 		" Stats Time:\t{{$obj.StatsTimeStr}}\n" +
-		" Retry Sync Time:\t{{$obj.RetrySyncTimeStr}}\n"
+		" Retry Sync Time:\t{{$obj.RetrySyncTimeStr}}\n" +
+		" Workfile GC Age:\t{{$obj.WorkfileGCAgeStr}}\n" +
+		" Empty Dir GC Age:\t{{$obj.EmptyDirGCAgeStr}}\n" +
+		" Notification Time:\t{{$obj.NotifTimeStr}}\n"
 	TimeoutConfTmpl = "\n{{$obj := .Timeout}}Timeout Config\n" +
 		" Max Keep Alive:\t{{$obj.MaxKeepaliveStr}}\n" +
 		" Control Plane Operation:\t{{$obj.CplaneOperationStr}}\n" +
@@ -128,7 +131,9 @@ const (
 		" Out-of-Space:\t{{$obj.OOS}}\n" +
 		" Don't Evict Time:\t{{$obj.DontEvictTimeStr}}\n" +
 		" Capacity Update Time:\t{{$obj.CapacityUpdTimeStr}}\n" +
-		" Enabled:\t{{$obj.Enabled}}\n"
+		" Enabled:\t{{$obj.Enabled}}\n" +
+		" Spill On Out-of-Space:\t{{$obj.SpillOnOOS}}\n" +
+		" Redirect On Out-of-Space:\t{{$obj.RedirectOnOOS}}\n"
 	DiskConfTmpl = "\n{{$obj := .Disk}}Disk Config\n" +
 		" Disk Utilization Low WM:\t{{$obj.DiskUtilLowWM}}\n" +
 		" Disk Utilization High WM:\t{{$obj.DiskUtilHighWM}}\n" +
@@ -145,7 +150,8 @@ const (
 		" Validate On Cold Get:\t{{$obj.ValidateColdGet}}\n" +
 		" Validate On Warm Get:\t{{$obj.ValidateWarmGet}}\n" +
 		" Validate On Object Migration:\t{{$obj.ValidateObjMove}}\n" +
-		" Enable For Read Range:\t{{$obj.EnableReadRange}}\n"
+		" Enable For Read Range:\t{{$obj.EnableReadRange}}\n" +
+		" Verified Once:\t{{$obj.VerifiedOnce}}\n"
 	VerConfTmpl = "\n{{$obj := .Versioning}}Version Config\n" +
 		" Enabled:\t{{$obj.Enabled}}\n" +
 		" Validate Warm Get:\t{{$obj.ValidateWarmGet}}\n"
@@ -161,7 +167,10 @@ const (
 	NetConfTmpl = "\n{{$obj := .Net}}Network Config\n" +
 		" IPv4:\t{{$obj.IPv4}}\n" +
 		" IPv4 IntraControl:\t{{$obj.IPv4IntraControl}}\n" +
-		" IPv4 IntraData:\t{{$obj.IPv4IntraData}}\n\n" +
+		" IPv4 IntraData:\t{{$obj.IPv4IntraData}}\n" +
+		" IP Family:\t{{$obj.IPFamily}}\n" +
+		" IP Family IntraControl:\t{{$obj.IPFamilyIntraControl}}\n" +
+		" IP Family IntraData:\t{{$obj.IPFamilyIntraData}}\n\n" +
 		" HTTP\n" +
 		" Protocol:\t{{$obj.HTTP.Proto}}\n" +
 		" Reverse Proxy:\t{{$obj.HTTP.RevProxy}}\n" +
@@ -179,7 +188,13 @@ const (
 	FSHCConfTmpl = "\n{{$obj := .FSHC}}FSHC Config\n" +
 		" Enabled:\t{{$obj.Enabled}}\n" +
 		" Test File Count:\t{{$obj.TestFileCount}}\n" +
-		" Error Limit:\t{{$obj.ErrorLimit}}\n"
+		" Error Limit:\t{{$obj.ErrorLimit}}\n" +
+		" Watchdog:\t{{$obj.Watchdog.Enabled}}\n" +
+		" Watchdog Interval:\t{{$obj.Watchdog.IntervalStr}}\n" +
+		" Watchdog Error Rate:\t{{$obj.Watchdog.ErrorRate}}\n" +
+		" Watchdog Max Latency:\t{{$obj.Watchdog.MaxLatencyStr}}\n" +
+		" Watchdog Max Device Errors:\t{{$obj.Watchdog.MaxDevErrors}}\n" +
+		" Watchdog Probation:\t{{$obj.Watchdog.ProbationStr}}\n"
 	AuthConfTmpl = "\n{{$obj := .Auth}}Authentication Config\n" +
 		" Enabled:\t{{$obj.Enabled}}\n"
 	KeepaliveConfTmpl = "\n{{$obj := .KeepaliveTracker}}Keep Alive Tracker Config\n" +
@@ -228,6 +243,10 @@ const (
 		"{{range $p := . }}" +
 		"{{$p.Name}}\t {{$p.Value}}\n" +
 		"{{end}}"
+	BucketPropsProvenanceTmpl = "PROPERTY\t VALUE\t SOURCE\t BMD VERSION\n" +
+		"{{range $p := . }}" +
+		"{{$p.Name}}\t {{$p.Value}}\t {{$p.Source}}\t {{$p.Version}}\n" +
+		"{{end}}"
 
 	DownloadListHeader = "JOB ID\t STATUS\t ERRORS\t DESCRIPTION\n"
 	DownloadListBody   = "{{$value.ID}}\t " +
@@ -248,20 +267,35 @@ const (
 
 	XactionsBodyTmpl = XactionStatsHeader +
 		"{{range $daemon := $.Stats }}" + XactionBody + "{{end}}"
-	XactionStatsHeader = "DAEMON ID\t ID\t KIND\t BUCKET\t OBJECTS\t BYTES\t START\t END\t ABORTED" +
+	XactionStatsHeader = "DAEMON ID\t ID\t KIND\t BUCKET\t OBJECTS\t BYTES\t PROGRESS\t THROUGHPUT\t ETA\t START\t END\t ABORTED" +
 		"{{if $.Verbose}}\t MORE{{end}}\n"
 	XactionBody = "{{range $key, $xact := $daemon.Stats}}" + XactionStatsBody + "{{end}}" +
-		"{{if $daemon.Stats}}\t \t \t \t \t \t \t \t{{if $.Verbose}} \t {{end}}\n{{end}}"
+		"{{if $daemon.Stats}}\t \t \t \t \t \t \t \t \t \t \t{{if $.Verbose}} \t {{end}}\n{{end}}"
 	XactionStatsBody = "{{ $daemon.DaemonID }}\t " +
 		"{{if $xact.IDX}}{{$xact.IDX}}{{else}}-{{end}}\t " +
 		"{{$xact.KindX}}\t " +
 		"{{if $xact.BckX.Name}}{{$xact.BckX.Name}}{{else}}-{{end}}\t " +
 		"{{if (eq $xact.ObjCountX 0) }}-{{else}}{{$xact.ObjCountX}}{{end}}\t " +
 		"{{if (eq $xact.BytesCountX 0) }}-{{else}}{{FormatBytesSigned $xact.BytesCountX 2}}{{end}}\t " +
+		"{{if (eq $xact.TotalCountX 0) }}-{{else}}{{$xact.DoneCountX}}/{{$xact.TotalCountX}}{{end}}\t " +
+		"{{if (eq $xact.ThroughputX 0) }}-{{else}}{{FormatBytesSigned $xact.ThroughputX 2}}/s{{end}}\t " +
+		"{{if (eq $xact.EtaX 0) }}-{{else}}{{$xact.EtaX}}{{end}}\t " +
 		"{{FormatTime $xact.StartTimeX}}\t " +
 		"{{if (IsUnsetTime $xact.EndTimeX)}}-{{else}}{{FormatTime $xact.EndTimeX}}{{end}}\t " +
 		"{{$xact.AbortedX}}" +
 		"{{if $.Verbose}}\t " + XactionExtBody + "{{end}}\n"
+	XactHistoryTmpl = "TARGET\t ID\t KIND\t BUCKET\t OBJECTS\t BYTES\t END\t DURATION\t STATUS\n" +
+		"{{range $rec := .}}" +
+		"{{$rec.Target}}\t " +
+		"{{if $rec.IDX}}{{$rec.IDX}}{{else}}-{{end}}\t " +
+		"{{$rec.KindX}}\t " +
+		"{{if $rec.BckX.Name}}{{$rec.BckX.Name}}{{else}}-{{end}}\t " +
+		"{{if (eq $rec.ObjCountX 0) }}-{{else}}{{$rec.ObjCountX}}{{end}}\t " +
+		"{{if (eq $rec.BytesCountX 0) }}-{{else}}{{FormatBytesSigned $rec.BytesCountX 2}}{{end}}\t " +
+		"{{FormatTime $rec.EndTimeX}}\t " +
+		"{{$rec.Duration}}\t " +
+		"{{if $rec.Err}}{{$rec.Err}}{{else if $rec.AbortedX}}aborted{{else}}ok{{end}}\n" +
+		"{{end}}"
 	XactionExtBody = "{{if $xact.Ext}}" + // if not nil
 		"{{$first := true}}" +
 		"{{range $name, $val := $xact.Ext}}" +
@@ -330,17 +364,21 @@ var (
 		"status":     "{{FormatObjStatus $obj}}",
 		"copies":     "{{$obj.Copies}}",
 		"cached":     "{{FormatObjIsCached $obj}}",
+		"alloc_size": "{{FormatBytesSigned $obj.AllocSize 2}}",
 	}
 
 	ObjStatMap = map[string]string{
-		"name":     "{{.Bck.String}}/{{.Name}}",
-		"cached":   "{{FormatBool .Present}}",
-		"size":     "{{FormatBytesSigned .Size 2}}",
-		"version":  "{{.Version}}",
-		"atime":    "{{if (eq .Atime 0)}}-{{else}}{{FormatUnixNano .Atime}}{{end}}",
-		"copies":   "{{if .NumCopies}}{{.NumCopies}}{{else}}-{{end}}",
-		"checksum": "{{if .Checksum.Value}}{{.Checksum.Value}}{{else}}-{{end}}",
-		"ec":       "{{if (eq .DataSlices 0)}}-{{else}}{{FormatEC .DataSlices .ParitySlices .IsECCopy}}{{end}}",
+		"name":       "{{.Bck.String}}/{{.Name}}",
+		"cached":     "{{FormatBool .Present}}",
+		"size":       "{{FormatBytesSigned .Size 2}}",
+		"version":    "{{.Version}}",
+		"atime":      "{{if (eq .Atime 0)}}-{{else}}{{FormatUnixNano .Atime}}{{end}}",
+		"copies":     "{{if .NumCopies}}{{.NumCopies}}{{else}}-{{end}}",
+		"checksum":   "{{if .Checksum.Value}}{{.Checksum.Value}}{{else}}-{{end}}",
+		"ec":         "{{if (eq .DataSlices 0)}}-{{else}}{{FormatEC .DataSlices .ParitySlices .IsECCopy}}{{end}}",
+		"alloc_size": "{{FormatBytesSigned .AllocSize 2}}",
+
+		"access_count": "{{.AccessCount}}",
 	}
 
 	funcMap = template.FuncMap{
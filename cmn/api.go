@@ -8,16 +8,21 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
 )
 
 // ActionMsg is a JSON-formatted control structures for the REST API
 type ActionMsg struct {
-	Action string      `json:"action"` // ActShutdown, ActRebalance, and many more (see api_const.go)
-	Name   string      `json:"name"`   // action-specific (e.g., bucket name)
-	Value  interface{} `json:"value"`  // ditto
+	Action string      `json:"action"`         // ActShutdown, ActRebalance, and many more (see api_const.go)
+	Name   string      `json:"name"`           // action-specific (e.g., bucket name)
+	Value  interface{} `json:"value"`          // ditto
+	Tags   XactTags    `json:"tags,omitempty"` // to attach to the xaction(s) that the action starts
 }
 
 type ActValPromote struct {
@@ -28,6 +33,13 @@ type ActValPromote struct {
 	Verbose   bool   `json:"verbose"`
 }
 
+// source of the object to pull from attached remote AIS cluster (see ActCopyRemote);
+// Bck.Ns.UUID is the remote cluster's UUID or alias
+type ActValCopyRemote struct {
+	Bck     Bck    `json:"bck"`
+	ObjName string `json:"objname"`
+}
+
 // SelectMsg represents properties and options for requests which fetch entities
 // Note: if Fast is `true` then paging is disabled - all items are returned
 //       in one response. The result list is unsorted and contains only object
@@ -42,6 +54,7 @@ type SelectMsg struct {
 	PageSize         uint   `json:"pagesize"`    // maximum number of entries returned by list objects call
 	Fast             bool   `json:"fast"`        // performs a fast traversal of the bucket contents (returns only names)
 	Cached           bool   `json:"cached"`      // for cloud buckets - list only cached objects
+	Prefetch         uint   `json:"prefetch"`    // number of listed objects to warm up (asynchronously) for reading
 }
 
 type PageMarker string
@@ -56,6 +69,26 @@ type RangeMsg struct {
 	Template string `json:"template"`
 }
 
+// WarmupMsg contains either a list or a template of the objects to read into
+// the OS page cache (see ActWarmup) and, optionally, the per-target budget (in
+// bytes) of the memory to lock the objects in - the locked objects stay in
+// memory until the warm-up xaction gets aborted
+type WarmupMsg struct {
+	ObjNames    []string `json:"objnames,omitempty"`
+	Template    string   `json:"template,omitempty"`
+	MlockBudget int64    `json:"mlock_budget,string,omitempty"`
+}
+
+// BpropsBatchMsg contains the props to update (see ActSetBpropsBatch) along with the
+// buckets to update: the listed ones and/or the ones whose names match the regex
+// (and, optionally, the provider)
+type BpropsBatchMsg struct {
+	Buckets  []Bck               `json:"buckets,omitempty"`
+	Regex    string              `json:"regex,omitempty"`
+	Provider string              `json:"provider,omitempty"`
+	Props    BucketPropsToUpdate `json:"props"`
+}
+
 // MountpathList contains two lists:
 // * Available - list of local mountpaths available to the storage target
 // * Disabled  - list of disabled mountpaths, the mountpaths that generated
@@ -65,13 +98,30 @@ type MountpathList struct {
 	Disabled  []string `json:"disabled"`
 }
 
+// LockInfo describes a name lock currently held by a target
+// (in particular, an object lock), for diagnostics:
+// * Name      - uname of the locked object
+// * Exclusive - write (true) or read (false) lock
+// * Readers   - number of the read lock holders
+// * Holder    - the function (and line) that has taken the lock most recently (debug builds only)
+// * Since     - when the lock was taken (the 1st read lock, if shared)
+type LockInfo struct {
+	Name      string        `json:"name"`
+	Exclusive bool          `json:"exclusive"`
+	Readers   int           `json:"readers,omitempty"`
+	Holder    string        `json:"holder,omitempty"`
+	Since     time.Time     `json:"since"`
+	Age       time.Duration `json:"age"`
+}
+
 // GetPropsDefault is a list of default (most relevant) GetProps* options
 var GetPropsDefault = []string{
 	GetPropsName, GetPropsChecksum, GetPropsSize, GetPropsAtime, GetPropsVersion,
 }
 
 // GetPropsAll is a list of all GetProps* options
-var GetPropsAll = append(GetPropsDefault, GetPropsCached, GetTargetURL, GetPropsStatus, GetPropsCopies, GetPropsEC)
+var GetPropsAll = append(GetPropsDefault, GetPropsCached, GetTargetURL, GetPropsStatus, GetPropsCopies, GetPropsEC,
+	GetPropsAlloc, GetPropsCustom)
 
 // NeedLocalData returns true if ListObjects for a cloud bucket needs
 // to return object properties that can be retrieved only from local caches
@@ -79,7 +129,9 @@ func (msg *SelectMsg) NeedLocalData() bool {
 	return strings.Contains(msg.Props, GetPropsAtime) ||
 		strings.Contains(msg.Props, GetPropsStatus) ||
 		strings.Contains(msg.Props, GetPropsCopies) ||
-		strings.Contains(msg.Props, GetPropsCached)
+		strings.Contains(msg.Props, GetPropsAlloc) ||
+		strings.Contains(msg.Props, GetPropsCached) ||
+		strings.Contains(msg.Props, GetPropsCustom)
 }
 
 // WantProp returns true if msg request requires to return propName property
@@ -87,6 +139,23 @@ func (msg *SelectMsg) WantProp(propName string) bool {
 	return strings.Contains(msg.Props, propName)
 }
 
+// CustomMDKeys returns the custom metadata keys requested via "custom.<key>"
+// props; all is true if the entire custom metadata is requested ("custom")
+func (msg *SelectMsg) CustomMDKeys() (keys []string, all bool) {
+	if !msg.WantProp(GetPropsCustom) {
+		return
+	}
+	for _, prop := range strings.Split(msg.Props, ",") {
+		if prop == GetPropsCustom {
+			return nil, true
+		}
+		if strings.HasPrefix(prop, GetPropsCustom+".") {
+			keys = append(keys, prop[len(GetPropsCustom)+1:])
+		}
+	}
+	return
+}
+
 func (msg *SelectMsg) AddProps(propNames ...string) {
 	var props strings.Builder
 	props.WriteString(msg.Props)
@@ -154,6 +223,33 @@ type BucketEntry struct {
 	TargetURL string `json:"target_url,omitempty"`  // URL of target which has the entry
 	Copies    int16  `json:"copies,omitempty"`      // ## copies (non-replicated = 1)
 	Flags     uint16 `json:"flags,omitempty"`       // object flags, like CheckExists, IsMoved etc
+	// allocated (on disk) size in bytes - less than `Size` for sparse objects
+	AllocSize int64 `json:"alloc_size,string,omitempty"`
+	// type of the `Checksum` (e.g. ChecksumXXHash, ChecksumMultipartETag)
+	CksumType string `json:"checksum_type,omitempty"`
+	// custom metadata, as requested via GetPropsCustom
+	Custom SimpleKVs `json:"custom,omitempty"`
+}
+
+// SetCustomMD sets the requested (see SelectMsg.CustomMDKeys) custom metadata
+func (be *BucketEntry) SetCustomMD(md SimpleKVs, keys []string, all bool) {
+	if all {
+		if len(md) > 0 {
+			be.Custom = make(SimpleKVs, len(md))
+			for k, v := range md {
+				be.Custom[k] = v
+			}
+		}
+		return
+	}
+	for _, key := range keys {
+		if v, ok := md[key]; ok {
+			if be.Custom == nil {
+				be.Custom = make(SimpleKVs, len(keys))
+			}
+			be.Custom[key] = v
+		}
+	}
 }
 
 func (be *BucketEntry) CheckExists() bool {
@@ -180,6 +276,8 @@ type BucketSummary struct {
 	Size           uint64  `json:"size,string"`
 	TotalDisksSize uint64  `json:"disks_size,string"`
 	UsedPct        float64 `json:"used_pct"`
+	QuotaBytes     int64   `json:"quota_bytes,string,omitempty"`   // see QuotaConf
+	QuotaObjects   int64   `json:"quota_objects,string,omitempty"` // ditto
 }
 
 func (bs *BucketSummary) Aggregate(bckSummary BucketSummary) {
@@ -187,6 +285,8 @@ func (bs *BucketSummary) Aggregate(bckSummary BucketSummary) {
 	bs.Size += bckSummary.Size
 	bs.TotalDisksSize += bckSummary.TotalDisksSize
 	bs.UsedPct = float64(bs.Size) * 100 / float64(bs.TotalDisksSize)
+	bs.QuotaBytes = MaxI64(bs.QuotaBytes, bckSummary.QuotaBytes)
+	bs.QuotaObjects = MaxI64(bs.QuotaObjects, bckSummary.QuotaObjects)
 }
 
 type BucketsSummaries []BucketSummary
@@ -224,6 +324,37 @@ func (s BucketsSummaries) Get(bck Bck) (BucketSummary, bool) {
 	return BucketSummary{}, false
 }
 
+// BckEvents - the numbers of the bucket's mutations, as counted by the
+// targets since they (re)started (see api.ListBucketsEvents)
+type BckEvents struct {
+	Puts         int64 `json:"puts,string"`
+	Appends      int64 `json:"appends,string"`
+	Deletes      int64 `json:"deletes,string"` // including evictions
+	Renames      int64 `json:"renames,string"`
+	LastMutation int64 `json:"last_mutation,string"` // unix nanoseconds (0 - none)
+}
+
+func (e *BckEvents) Aggregate(other BckEvents) {
+	e.Puts += other.Puts
+	e.Appends += other.Appends
+	e.Deletes += other.Deletes
+	e.Renames += other.Renames
+	if other.LastMutation > e.LastMutation {
+		e.LastMutation = other.LastMutation
+	}
+}
+
+type BucketEvents struct {
+	Bck
+	Events BckEvents `json:"events"`
+}
+
+type BucketsEvents []BucketEvents
+
+func (s BucketsEvents) Len() int           { return len(s) }
+func (s BucketsEvents) Less(i, j int) bool { return s[i].Bck.Less(s[j].Bck) }
+func (s BucketsEvents) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+
 // BucketProps defines the configuration of the bucket with regard to
 // its type, checksum, and LRU. These characteristics determine its behavior
 // in response to operations on the bucket itself or the objects inside the bucket.
@@ -256,6 +387,27 @@ type BucketProps struct {
 	// EC defines erasure coding setting for the bucket
 	EC ECConf `json:"ec"`
 
+	// Journal defines per-bucket operation journal (see JournalEntry)
+	Journal JournalConf `json:"journal"`
+
+	// Headers defines the headers that targets add to GET responses
+	Headers HeadersConf `json:"headers"`
+
+	// Encryption defines at-rest encryption of the bucket's objects
+	Encryption EncryptionConf `json:"encryption"`
+
+	// Quota limits the bucket's capacity
+	Quota QuotaConf `json:"quota"`
+
+	// Placement restricts the mountpaths that store the bucket's objects
+	Placement PlacementConf `json:"placement"`
+
+	// Trash keeps deleted objects for a while, to be undeleted
+	Trash TrashConf `json:"trash"`
+
+	// Fencing defines what happens to the PUTs racing bucket-level xactions
+	Fencing FencingConf `json:"fencing"`
+
 	// Bucket access attributes - see Allow* above
 	Access AccessAttrs `json:"access,string"`
 
@@ -267,16 +419,26 @@ type BucketProps struct {
 
 	// non-empty when the bucket has been renamed (TODO: delayed deletion likewise)
 	Renamed string `list:"omit"`
+
+	// where the values of the properties come from (see PropsProvenance)
+	Provenance PropsProvenance `json:"provenance" list:"omit"`
 }
 
 type BucketPropsToUpdate struct {
-	BackendBck *BckToUpdate         `json:"backend_bck"`
-	Versioning *VersionConfToUpdate `json:"versioning"`
-	Cksum      *CksumConfToUpdate   `json:"checksum"`
-	LRU        *LRUConfToUpdate     `json:"lru"`
-	Mirror     *MirrorConfToUpdate  `json:"mirror"`
-	EC         *ECConfToUpdate      `json:"ec"`
-	Access     *AccessAttrs         `json:"access,string"`
+	BackendBck *BckToUpdate            `json:"backend_bck"`
+	Versioning *VersionConfToUpdate    `json:"versioning"`
+	Cksum      *CksumConfToUpdate      `json:"checksum"`
+	LRU        *LRUConfToUpdate        `json:"lru"`
+	Mirror     *MirrorConfToUpdate     `json:"mirror"`
+	EC         *ECConfToUpdate         `json:"ec"`
+	Journal    *JournalConfToUpdate    `json:"journal"`
+	Headers    *HeadersConfToUpdate    `json:"headers"`
+	Encryption *EncryptionConfToUpdate `json:"encryption"`
+	Quota      *QuotaConfToUpdate      `json:"quota"`
+	Placement  *PlacementConfToUpdate  `json:"placement"`
+	Trash      *TrashConfToUpdate      `json:"trash"`
+	Fencing    *FencingConfToUpdate    `json:"fencing"`
+	Access     *AccessAttrs            `json:"access,string"`
 }
 
 type BckToUpdate struct {
@@ -284,22 +446,319 @@ type BckToUpdate struct {
 	Provider *string `json:"provider"`
 }
 
+// ECPackPrefix is the name prefix of the containers that small objects are
+// packed into (see ECConf.PackSize). The containers are internal: they are
+// not listed and cannot be deleted by clients
+const ECPackPrefix = ".ec.pack/"
+
+func IsECPackName(objName string) bool { return strings.HasPrefix(objName, ECPackPrefix) }
+
 // ECConfig - per-bucket erasure coding configuration
 type ECConf struct {
-	ObjSizeLimit int64  `json:"objsize_limit"` // objects below this size are replicated instead of EC'ed
-	DataSlices   int    `json:"data_slices"`   // number of data slices
-	ParitySlices int    `json:"parity_slices"` // number of parity slices/replicas
-	Compression  string `json:"compression"`   // see CompressAlways, etc. enum
-	Enabled      bool   `json:"enabled"`       // EC is enabled
-	BatchSize    int    `json:"batch_size"`    // Batch size for EC rebalance
+	ObjSizeLimit   int64  `json:"objsize_limit"`    // objects below this size are replicated instead of EC'ed
+	DataSlices     int    `json:"data_slices"`      // number of data slices
+	ParitySlices   int    `json:"parity_slices"`    // number of parity slices/replicas
+	Compression    string `json:"compression"`      // see CompressAlways, etc. enum
+	Enabled        bool   `json:"enabled"`          // EC is enabled
+	BatchSize      int    `json:"batch_size"`       // Batch size for EC rebalance
+	Placement      string `json:"placement"`        // see ECPlacementHRW, etc. enum
+	SliceCacheSize int64  `json:"slice_cache_size"` // max size of the in-memory cache of fetched slices (0 - disabled)
+	PackSize       int64  `json:"pack_size"`        // small objects are packed into containers of this size and EC'ed (0 - replicated)
+	MetaQuorum     string `json:"meta_quorum"`      // see ECQuorumMajority, etc. enum
+
+	// UndeleteWindowStr: for how long the slices, replicas, and metafiles of
+	// a deleted object are kept (tombstoned) so that the object can still be
+	// undeleted (empty or zero - delete immediately)
+	UndeleteWindowStr string `json:"undelete_window"`
+	// UndeleteWindow is the parsed value of UndeleteWindowStr
+	UndeleteWindow time.Duration `json:"-"`
+	// RestoreMemLimit: max total size of the memory buffers of the restores
+	// in progress (all buckets); the restores that do not fit buffer their
+	// slices in workfiles instead (0 - unlimited)
+	RestoreMemLimit int64 `json:"restore_mem_limit"`
+	// SliceFanout: max number of targets to request slices from at once when
+	// restoring an object - data slices and the least loaded targets first;
+	// never fewer than needed to restore (0 - all targets that have slices)
+	SliceFanout int `json:"slice_fanout"`
+	// CompressParity: store parity slices lz4-compressed (data slices and
+	// replicas are never compressed)
+	CompressParity bool `json:"compress_parity"`
 }
 
 type ECConfToUpdate struct {
-	Enabled      *bool   `json:"enabled"`
-	ObjSizeLimit *int64  `json:"objsize_limit"`
-	DataSlices   *int    `json:"data_slices"`
-	ParitySlices *int    `json:"parity_slices"`
-	Compression  *string `json:"compression"`
+	Enabled        *bool   `json:"enabled"`
+	ObjSizeLimit   *int64  `json:"objsize_limit"`
+	DataSlices     *int    `json:"data_slices"`
+	ParitySlices   *int    `json:"parity_slices"`
+	Compression    *string `json:"compression"`
+	Placement      *string `json:"placement"`
+	SliceCacheSize *int64  `json:"slice_cache_size"`
+	PackSize       *int64  `json:"pack_size"`
+	MetaQuorum     *string `json:"meta_quorum"`
+	CompressParity *bool   `json:"compress_parity"`
+}
+
+// JournalConf - per-bucket operation journal: targets record the mutations
+// (and optionally, reads) of the bucket's objects along with the identities
+// of the requesters. The journal is bounded: the oldest records get overwritten.
+type JournalConf struct {
+	Enabled    bool  `json:"enabled"`
+	Gets       bool  `json:"gets"`        // record GETs as well
+	MaxEntries int64 `json:"max_entries"` // max number of records per target (0 - JournalDefaultMaxEntries)
+}
+
+type JournalConfToUpdate struct {
+	Enabled    *bool  `json:"enabled"`
+	Gets       *bool  `json:"gets"`
+	MaxEntries *int64 `json:"max_entries"`
+}
+
+// HeadersConf - per-bucket response headers that targets add to GET responses,
+// e.g. when serving the bucket's objects directly to browsers or CDNs.
+// ContentTypes maps object name extensions to content types, for instance:
+// ".jpg=image/jpeg,.json=application/json"; the objects with other (or no)
+// extensions get no Content-Type header.
+type HeadersConf struct {
+	CacheControl       string `json:"cache_control"`
+	ContentDisposition string `json:"content_disposition"`
+	ContentTypes       string `json:"content_types"`
+	// Types is the parsed ContentTypes (see ValidateAsProps) - distributed
+	// with the rest of the props, so that the targets do not parse it on GET
+	Types map[string]string `json:"types,omitempty" list:"omit"`
+}
+
+type HeadersConfToUpdate struct {
+	CacheControl       *string `json:"cache_control"`
+	ContentDisposition *string `json:"content_disposition"`
+	ContentTypes       *string `json:"content_types"`
+}
+
+// EncryptionConf - at-rest encryption: when enabled, targets encrypt all new
+// objects of the bucket; otherwise, only the objects PUT with the request to
+// encrypt them (x-amz-server-side-encryption). Requires the encryption keys
+// to be configured on the targets - see package encrypt.
+type EncryptionConf struct {
+	Enabled bool `json:"enabled"`
+}
+
+type EncryptionConfToUpdate struct {
+	Enabled *bool `json:"enabled"`
+}
+
+// QuotaConf - the maximum total size and the maximum number of the bucket's
+// objects (zero - unlimited); targets reject the PUTs that would exceed the
+// quota with 507 (Insufficient Storage) - see fs.QuotaManager.
+type QuotaConf struct {
+	MaxBytes   int64 `json:"max_bytes"`
+	MaxObjects int64 `json:"max_objects"`
+}
+
+type QuotaConfToUpdate struct {
+	MaxBytes   *int64 `json:"max_bytes"`
+	MaxObjects *int64 `json:"max_objects"`
+}
+
+// PlacementConf - comma-separated labels of the mountpaths (see FSPathsConf)
+// that the bucket's objects and EC slices are placed on; empty - all mountpaths.
+// Targets that have no mountpaths with any of the labels use all mountpaths.
+type PlacementConf struct {
+	Labels string `json:"labels"`
+}
+
+type PlacementConfToUpdate struct {
+	Labels *string `json:"labels"`
+}
+
+// TrashConf - when enabled, targets do not remove the deleted objects of the
+// (ais) bucket right away - the objects are moved to the trash instead and can
+// be undeleted (ActUndelete) until the TTL expires - see cluster.LOM.Trash.
+type TrashConf struct {
+	TTL     string `json:"ttl"` // e.g. "24h"
+	Enabled bool   `json:"enabled"`
+}
+
+type TrashConfToUpdate struct {
+	TTL     *string `json:"ttl"`
+	Enabled *bool   `json:"enabled"`
+}
+
+// FencingConf - what targets do with the client PUTs of the bucket's objects
+// while a bucket-level xaction (rename, copy, ec-encode) walks the bucket:
+// reject them, hold them until the xaction finishes (for up to the timeout),
+// or allow them and have the xaction process the objects once the walk is done.
+type FencingConf struct {
+	Mode    string `json:"mode"`    // one of FenceReject, FenceQueue, FenceTrack; empty - FenceTrack
+	Timeout string `json:"timeout"` // FenceQueue only; empty - timeout.max_host_busy
+}
+
+type FencingConfToUpdate struct {
+	Mode    *string `json:"mode"`
+	Timeout *string `json:"timeout"`
+}
+
+// FencingConf.Mode enum
+const (
+	FenceReject = "reject" // fail with 503 Service Unavailable
+	FenceQueue  = "queue"  // wait for the xaction to finish
+	FenceTrack  = "track"  // allow, and have the xaction process the object (see cluster.PutTracker)
+)
+
+// PropsProvenance tells where the values of the bucket properties come from:
+// cluster defaults (copied from the cluster config when the bucket was created
+// or its properties reset, at BMD version `Defaults`) or bucket-level overrides
+// (the properties that have been changed since - each with the BMD version of
+// its latest change).
+type PropsProvenance struct {
+	Defaults  int64          `json:"defaults,string"`
+	Overrides []PropOverride `json:"overrides,omitempty"`
+}
+
+type PropOverride struct {
+	Name    string `json:"name"`
+	Version int64  `json:"version,string"` // BMD version
+}
+
+// PropProvenance - provenance of a single property (see HeadBucketProvenance API)
+type PropProvenance struct {
+	Source  string `json:"source"`  // PropSourceDefault | PropSourceOverride
+	Version int64  `json:"version"` // BMD version of the latest change
+}
+
+// PropProvenance.Source enum
+const (
+	PropSourceDefault  = "default"
+	PropSourceOverride = "override"
+)
+
+// JournalEntry - a single record of the operation journal
+type JournalEntry struct {
+	Seq     int64  `json:"seq,string"`
+	Time    int64  `json:"time,string"` // unix nanoseconds
+	Op      string `json:"op"`          // see Journal* enum
+	ObjName string `json:"name"`
+	Size    int64  `json:"size,string,omitempty"`
+	Version string `json:"version,omitempty"`
+	Info    string `json:"info,omitempty"` // op-specific, e.g. the new name of a renamed object
+	User    string `json:"user,omitempty"` // from the auth token, if any
+	Addr    string `json:"addr,omitempty"` // requester's address
+	Target  string `json:"target"`
+}
+
+// Event - a cluster event as delivered to the configured sinks (see EventsConf)
+type Event struct {
+	Type    string `json:"type"`        // see Event* enum
+	Time    int64  `json:"time,string"` // unix nanoseconds
+	Node    string `json:"node"`        // ID of the node that generated the event
+	Bck     Bck    `json:"bck"`
+	ObjName string `json:"name,omitempty"`
+	Size    int64  `json:"size,string,omitempty"`
+	Info    string `json:"info,omitempty"` // event-specific, e.g. the new name of a renamed object
+	Xact    string `json:"xaction,omitempty"`
+	UUID    string `json:"uuid,omitempty"`
+	Err     string `json:"err,omitempty"`
+}
+
+type (
+	// SimPlacementMsg describes a hypothetical change of the cluster membership
+	// (see ActSimPlacement)
+	SimPlacementMsg struct {
+		Add    []string `json:"add,omitempty"`    // IDs of the targets to join
+		Remove []string `json:"remove,omitempty"` // IDs of the targets to leave
+	}
+	// SimPlacementResult estimates the cost of the rebalance that would follow
+	// the change: the number and the size of the bucket's objects to move
+	SimPlacementResult struct {
+		Objects      int64                          `json:"objects,string"`
+		Size         int64                          `json:"size,string"`
+		MovedObjects int64                          `json:"moved_objects,string"`
+		MovedSize    int64                          `json:"moved_size,string"`
+		Targets      map[string]*SimPlacementTarget `json:"targets"` // target ID => objects to send and receive
+	}
+	SimPlacementTarget struct {
+		SentObjects int64 `json:"sent_objects,string"`
+		SentSize    int64 `json:"sent_size,string"`
+		RecvObjects int64 `json:"recv_objects,string"`
+		RecvSize    int64 `json:"recv_size,string"`
+	}
+)
+
+type (
+	// ObjLocationsMsg contains the names of the objects to locate (see ActObjLocations)
+	ObjLocationsMsg struct {
+		ObjNames []string `json:"objnames"`
+	}
+	// ObjLocations tells external schedulers where the data is: for each
+	// object, its owning target (under HRW) and the target's host; for the
+	// objects that are present in the cluster, also the mountpaths of the
+	// object and its local copies, and the targets that store its EC slices
+	// (or replicas)
+	ObjLocations struct {
+		Objects []*ObjLocation `json:"objects"`
+		Hosts   SimpleKVs      `json:"hosts"` // target ID => host, for all the targets in the response
+	}
+	ObjLocation struct {
+		Name       string   `json:"name"`
+		Target     string   `json:"target"`
+		Host       string   `json:"host"`
+		Domain     string   `json:"domain,omitempty"`
+		Present    bool     `json:"present"`
+		Size       int64    `json:"size,string,omitempty"`
+		Mountpaths []string `json:"mountpaths,omitempty"` // the object's first, then its local copies
+		ECTargets  []string `json:"ec_targets,omitempty"` // EC slices or replicas (see ECReplica)
+		ECReplica  bool     `json:"ec_replica,omitempty"` // true: ECTargets store full replicas (see ECConf.ObjSizeLimit)
+	}
+	// ECPlacement is where the EC replicas or slices of the object go once
+	// its protection gets rebuilt (see ActECReprotect)
+	ECPlacement struct {
+		Name      string        `json:"name"`
+		Target    string        `json:"target"`         // the object's (main) target
+		ECReplica bool          `json:"ec_replica"`     // true: full replicas rather than slices
+		Data      int           `json:"data,omitempty"` // number of data slices
+		Parity    int           `json:"parity"`         // number of parity slices (replicas)
+		Slices    []*ECSliceLoc `json:"slices"`
+	}
+	ECSliceLoc struct {
+		SliceID int    `json:"slice_id"` // 1 through data + parity; 0 - full replica
+		Target  string `json:"target"`
+	}
+)
+
+func NewSimPlacementResult() *SimPlacementResult {
+	return &SimPlacementResult{Targets: make(map[string]*SimPlacementTarget, 4)}
+}
+
+func (r *SimPlacementResult) Target(id string) *SimPlacementTarget {
+	st, ok := r.Targets[id]
+	if !ok {
+		st = &SimPlacementTarget{}
+		r.Targets[id] = st
+	}
+	return st
+}
+
+// Move accounts for an object that moves from one target to another
+func (r *SimPlacementResult) Move(from, to string, size int64) {
+	r.MovedObjects++
+	r.MovedSize += size
+	src, dst := r.Target(from), r.Target(to)
+	src.SentObjects++
+	src.SentSize += size
+	dst.RecvObjects++
+	dst.RecvSize += size
+}
+
+func (r *SimPlacementResult) Merge(other *SimPlacementResult) {
+	r.Objects += other.Objects
+	r.Size += other.Size
+	r.MovedObjects += other.MovedObjects
+	r.MovedSize += other.MovedSize
+	for id, ost := range other.Targets {
+		st := r.Target(id)
+		st.SentObjects += ost.SentObjects
+		st.SentSize += ost.SentSize
+		st.RecvObjects += ost.RecvObjects
+		st.RecvSize += ost.RecvSize
+	}
 }
 
 func (c *VersionConf) String() string {
@@ -338,6 +797,9 @@ func (c *CksumConf) String() string {
 		toValidateStr = strings.Join(toValidate, ",")
 	}
 
+	if c.VerifiedOnce {
+		return fmt.Sprintf("Type: %s | Validate: %s | Verified-once", c.Type, toValidateStr)
+	}
 	return fmt.Sprintf("Type: %s | Validate: %s", c.Type, toValidateStr)
 }
 
@@ -349,6 +811,90 @@ func (c *LRUConf) String() string {
 		c.LowWM, c.HighWM, c.DontEvictTimeStr, c.OOS)
 }
 
+func (c *EncryptionConf) String() string {
+	if c.Enabled {
+		return "Enabled"
+	}
+	return "Disabled"
+}
+
+func (c *QuotaConf) Enabled() bool { return c.MaxBytes > 0 || c.MaxObjects > 0 }
+
+func (c *QuotaConf) String() string {
+	if !c.Enabled() {
+		return "Disabled"
+	}
+	maxBytes, maxObjects := "unlimited", "unlimited"
+	if c.MaxBytes > 0 {
+		maxBytes = B2S(c.MaxBytes, 2)
+	}
+	if c.MaxObjects > 0 {
+		maxObjects = strconv.FormatInt(c.MaxObjects, 10)
+	}
+	return fmt.Sprintf("Size: %s | Objects: %s", maxBytes, maxObjects)
+}
+
+func (c *TrashConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	return "TTL: " + c.TTL
+}
+
+// TTLDuration returns the (validated) TTL
+func (c *TrashConf) TTLDuration() time.Duration {
+	ttl, _ := time.ParseDuration(c.TTL)
+	return ttl
+}
+
+func (c *FencingConf) String() string {
+	mode := c.Mode
+	if mode == "" {
+		mode = FenceTrack
+	}
+	if mode == FenceQueue && c.Timeout != "" {
+		return mode + " | Timeout: " + c.Timeout
+	}
+	return mode
+}
+
+// TimeoutDuration returns the (validated) queueing timeout, zero if not set
+func (c *FencingConf) TimeoutDuration() time.Duration {
+	timeout, _ := time.ParseDuration(c.Timeout)
+	return timeout
+}
+
+func (c *PlacementConf) Enabled() bool { return c.Labels != "" }
+
+// Allows returns true if the mountpath with a given label may store the bucket's content
+func (c *PlacementConf) Allows(label string) bool {
+	if c.Labels == "" {
+		return true
+	}
+	if label == "" {
+		return false
+	}
+	for labels := c.Labels; labels != ""; {
+		var l string
+		if i := strings.IndexByte(labels, ','); i >= 0 {
+			l, labels = labels[:i], labels[i+1:]
+		} else {
+			l, labels = labels, ""
+		}
+		if strings.TrimSpace(l) == label {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *PlacementConf) String() string {
+	if !c.Enabled() {
+		return "Disabled"
+	}
+	return "Labels: " + c.Labels
+}
+
 func (c *MirrorConf) String() string {
 	if !c.Enabled {
 		return "Disabled"
@@ -357,6 +903,91 @@ func (c *MirrorConf) String() string {
 	return fmt.Sprintf("%d copies", c.Copies)
 }
 
+func (c *JournalConf) String() string {
+	if !c.Enabled {
+		return "Disabled"
+	}
+	if c.Gets {
+		return fmt.Sprintf("%d records (including GETs)", c.Max())
+	}
+	return fmt.Sprintf("%d records", c.Max())
+}
+
+func (c *JournalConf) Max() int64 {
+	if c.MaxEntries == 0 {
+		return JournalDefaultMaxEntries
+	}
+	return c.MaxEntries
+}
+
+func (c *JournalConf) ValidateAsProps(_ *ValidationArgs) error {
+	if c.MaxEntries < 0 || c.MaxEntries > JournalMaxEntries {
+		return fmt.Errorf("invalid journal.max_entries: %d (expected value in range [0, %d])",
+			c.MaxEntries, JournalMaxEntries)
+	}
+	return nil
+}
+
+func (c *HeadersConf) String() string {
+	var hdrs []string
+	if c.CacheControl != "" {
+		hdrs = append(hdrs, HeaderCacheControl+": "+c.CacheControl)
+	}
+	if c.ContentDisposition != "" {
+		hdrs = append(hdrs, HeaderContentDisposition+": "+c.ContentDisposition)
+	}
+	if c.ContentTypes != "" {
+		hdrs = append(hdrs, HeaderContentType+": "+c.ContentTypes)
+	}
+	if len(hdrs) == 0 {
+		return "-"
+	}
+	return strings.Join(hdrs, "; ")
+}
+
+func (c *HeadersConf) ValidateAsProps(_ *ValidationArgs) (err error) {
+	c.Types, err = parseContentTypes(c.ContentTypes)
+	return
+}
+
+// Set adds the configured headers to the GET response for a given object
+func (c *HeadersConf) Set(hdr http.Header, objName string) {
+	if c.CacheControl != "" {
+		hdr.Set(HeaderCacheControl, c.CacheControl)
+	}
+	if c.ContentDisposition != "" {
+		hdr.Set(HeaderContentDisposition, c.ContentDisposition)
+	}
+	if len(c.Types) == 0 {
+		return
+	}
+	ext := filepath.Ext(objName)
+	if ext == "" {
+		return
+	}
+	if ct, ok := c.Types[strings.ToLower(ext)]; ok {
+		hdr.Set(HeaderContentType, ct)
+	}
+}
+
+// ".jpg=image/jpeg,.json=application/json" => extension => content type
+func parseContentTypes(s string) (types map[string]string, err error) {
+	if s == "" {
+		return nil, nil
+	}
+	types = make(map[string]string, 4)
+	for _, kv := range strings.Split(s, ",") {
+		kv = strings.TrimSpace(kv)
+		idx := strings.Index(kv, "=")
+		if idx <= 1 || kv[0] != '.' || idx == len(kv)-1 {
+			return nil, fmt.Errorf("invalid headers.content_types %q: expecting comma-separated "+
+				"\".<extension>=<content type>\" pairs (e.g., \".jpg=image/jpeg\")", s)
+		}
+		types[strings.ToLower(kv[:idx])] = strings.TrimSpace(kv[idx+1:])
+	}
+	return types, nil
+}
+
 func (c *RebalanceConf) String() string {
 	if c.Enabled {
 		return "Enabled"
@@ -369,6 +1000,9 @@ func (c *ECConf) String() string {
 		return "Disabled"
 	}
 	objSizeLimit := c.ObjSizeLimit
+	if c.Placement == ECPlacementDomain {
+		return fmt.Sprintf("%d:%d (%s, %s-aware)", c.DataSlices, c.ParitySlices, B2S(objSizeLimit, 0), c.Placement)
+	}
 	return fmt.Sprintf("%d:%d (%s)", c.DataSlices, c.ParitySlices, B2S(objSizeLimit, 0))
 }
 
@@ -382,6 +1016,13 @@ func (c *ECConf) RequiredRestoreTargets() int {
 	return c.DataSlices + 1
 }
 
+// Normalize fills in the defaults of the (validated) values that may be omitted
+func (c *ECConf) Normalize() {
+	if c.Placement == "" {
+		c.Placement = ECPlacementHRW
+	}
+}
+
 // ObjectProps
 type ObjectProps struct {
 	Name         string           `json:"name"`
@@ -391,10 +1032,13 @@ type ObjectProps struct {
 	Atime        int64            `json:"atime"`
 	Checksum     ObjectCksumProps `json:"checksum"`
 	NumCopies    int              `json:"copies"`
+	AllocSize    int64            `json:"alloc_size"`
+	AccessCount  int64            `json:"access_count"`
 	DataSlices   int              `list:"omit"`
 	ParitySlices int              `list:"omit"`
 	IsECCopy     bool             `list:"omit"`
 	Present      bool             `json:"present"`
+	CustomMD     SimpleKVs        `json:"custom_md,omitempty" list:"omit"`
 }
 
 type ObjectCksumProps struct {
@@ -414,6 +1058,7 @@ func DefaultBucketProps() *BucketProps {
 		Versioning: c.Versioning,
 		Access:     AllAccess(),
 		EC:         c.EC,
+		Fencing:    FencingConf{Mode: FenceTrack},
 	}
 }
 
@@ -451,6 +1096,7 @@ func (bp *BucketProps) Equal(other *BucketProps) bool {
 	)
 	clone.BID = other.BID
 	clone.Created = other.Created
+	clone.Provenance = other.Provenance
 
 	s1, _ := jsonCompat.Marshal(clone)
 	s2, _ := jsonCompat.Marshal(other)
@@ -474,7 +1120,7 @@ func (bp *BucketProps) Validate(targetCnt int) error {
 	}
 
 	validationArgs := &ValidationArgs{TargetCnt: targetCnt}
-	validators := []PropsValidator{&bp.Cksum, &bp.LRU, &bp.Mirror, &bp.EC}
+	validators := []PropsValidator{&bp.Cksum, &bp.LRU, &bp.Mirror, &bp.EC, &bp.Journal, &bp.Headers}
 	for _, validator := range validators {
 		if err := validator.ValidateAsProps(validationArgs); err != nil {
 			return err
@@ -484,11 +1130,120 @@ func (bp *BucketProps) Validate(targetCnt int) error {
 	if bp.Mirror.Enabled && bp.EC.Enabled {
 		return fmt.Errorf("cannot enable mirroring and ec at the same time for the same bucket")
 	}
+	if bp.Encryption.Enabled && (bp.Provider != ProviderAIS || !bp.BackendBck.IsEmpty()) {
+		return fmt.Errorf("encryption is supported only for AIS buckets without backend bucket")
+	}
+	if bp.Quota.MaxBytes < 0 || bp.Quota.MaxObjects < 0 {
+		return fmt.Errorf("invalid quota (%+v): must be non-negative", bp.Quota)
+	}
+	if bp.Placement.Enabled() {
+		for _, label := range strings.Split(bp.Placement.Labels, ",") {
+			if strings.TrimSpace(label) == "" {
+				return fmt.Errorf("invalid placement labels %q: empty label", bp.Placement.Labels)
+			}
+		}
+	}
+	if bp.Trash.Enabled {
+		if bp.Provider != ProviderAIS || !bp.BackendBck.IsEmpty() {
+			return fmt.Errorf("trash is supported only for AIS buckets without backend bucket")
+		}
+		if ttl, err := time.ParseDuration(bp.Trash.TTL); err != nil || ttl <= 0 {
+			return fmt.Errorf("invalid trash ttl %q (expecting positive duration, e.g. \"24h\")", bp.Trash.TTL)
+		}
+	}
+	switch bp.Fencing.Mode {
+	case "", FenceReject, FenceQueue, FenceTrack:
+	default:
+		return fmt.Errorf("invalid fencing mode %q (expecting one of: %s, %s, %s)",
+			bp.Fencing.Mode, FenceReject, FenceQueue, FenceTrack)
+	}
+	if bp.Fencing.Timeout != "" {
+		if timeout, err := time.ParseDuration(bp.Fencing.Timeout); err != nil || timeout <= 0 {
+			return fmt.Errorf("invalid fencing timeout %q (expecting positive duration, e.g. \"1m\")", bp.Fencing.Timeout)
+		}
+	}
 	return nil
 }
 
 func (bp *BucketProps) Apply(propsToUpdate BucketPropsToUpdate) {
 	copyProps(propsToUpdate, bp)
+	if propsToUpdate.EC != nil && propsToUpdate.EC.Placement != nil {
+		bp.EC.Normalize()
+	}
+}
+
+// TrackProvenance updates the provenance of the properties that are about to
+// become the bucket's properties at BMD version `version`, given the current ones
+// (nil when adding the bucket). The properties with no provenance are new: the
+// cluster defaults, possibly with some overrides (or the result of reset).
+func (bp *BucketProps) TrackProvenance(prev *BucketProps, version int64) {
+	switch {
+	case bp.Provenance.Defaults == 0:
+		bp.Provenance = PropsProvenance{Defaults: version}
+		bp.Provenance.track(DefaultBucketProps(), bp, version)
+	case prev != nil:
+		bp.Provenance = prev.Provenance.clone()
+		bp.Provenance.track(prev, bp, version)
+	}
+}
+
+// Get returns the provenance of a given property
+func (pp *PropsProvenance) Get(name string) PropProvenance {
+	for _, o := range pp.Overrides {
+		if o.Name == name {
+			return PropProvenance{Source: PropSourceOverride, Version: o.Version}
+		}
+	}
+	return PropProvenance{Source: PropSourceDefault, Version: pp.Defaults}
+}
+
+func (pp *PropsProvenance) clone() PropsProvenance {
+	clone := PropsProvenance{Defaults: pp.Defaults}
+	if len(pp.Overrides) > 0 {
+		clone.Overrides = append([]PropOverride(nil), pp.Overrides...)
+	}
+	return clone
+}
+
+func (pp *PropsProvenance) override(name string, version int64) {
+	for i := range pp.Overrides {
+		if pp.Overrides[i].Name == name {
+			pp.Overrides[i].Version = version
+			return
+		}
+	}
+	pp.Overrides = append(pp.Overrides, PropOverride{Name: name, Version: version})
+}
+
+// records the (settable) properties that differ
+func (pp *PropsProvenance) track(from, to *BucketProps, version int64) {
+	fromValues, toValues := settablePropValues(from), settablePropValues(to)
+	for name, value := range toValues {
+		if fromValues[name] != value {
+			pp.override(name, version)
+		}
+	}
+	sort.Slice(pp.Overrides, func(i, j int) bool { return pp.Overrides[i].Name < pp.Overrides[j].Name })
+}
+
+func settablePropValues(bp *BucketProps) SimpleKVs {
+	var (
+		settable = make(map[string]struct{}, 32)
+		values   = make(SimpleKVs, 32)
+	)
+	err := IterFields(&BucketPropsToUpdate{}, func(name string, _ IterField) (error, bool) {
+		settable[name] = struct{}{}
+		return nil, false
+	})
+	AssertNoErr(err)
+	err = IterFields(bp, func(name string, field IterField) (error, bool) {
+		if _, ok := settable[name]; ok {
+			values[name] = fmt.Sprintf("%v", field.Value())
+		}
+		return nil, false
+	})
+	AssertNoErr(err)
+	return values
 }
 
 func NewBucketPropsToUpdate(nvs SimpleKVs) (props BucketPropsToUpdate, err error) {
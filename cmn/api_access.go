@@ -33,6 +33,7 @@ const (
 	AccessBckCreate
 	AccessBckLIST
 	AccessADMIN
+	AccessMassDELETE // bulk deletions without confirmation (see ProxyConf.MassDeleteThreshold)
 	// must be the last one
 	AccessMax
 
@@ -71,8 +72,9 @@ var accessOp = map[int]string{
 	AccessSYNC:        "SYNC-BUCKET",
 	AccessBckDELETE:   "DELETE-BUCKET",
 	// cluster
-	AccessBckCreate: "CREATE-BUCKET",
-	AccessADMIN:     "ADMIN",
+	AccessBckCreate:  "CREATE-BUCKET",
+	AccessADMIN:      "ADMIN",
+	AccessMassDELETE: "MASS-DELETE",
 }
 
 func NoAccess() AccessAttrs                      { return 0 }
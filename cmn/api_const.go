@@ -16,6 +16,9 @@ const (
 	ChecksumCRC32C = "crc32c"
 	ChecksumSHA256 = "sha256" // crypto.SHA512_256 (SHA-2)
 	ChecksumSHA512 = "sha512" // crypto.SHA512 (SHA-2)
+
+	// (listing only) ETag of an Amazon S3 object uploaded in multiple parts - see AwsMultipartETag
+	ChecksumMultipartETag = "multipart-etag"
 )
 
 // module names
@@ -27,32 +30,43 @@ const (
 const (
 	AppendOp = "append"
 	FlushOp  = "flush"
+
+	// byte-range write: write a segment at a given offset and, eventually, commit the object
+	WriteAtOp = "writeat"
+	CommitOp  = "commit"
 )
 
 // ActionMsg.Action enum (includes xactions)
 const (
 	ActShutdown       = "shutdown"
+	ActForceUnlock    = "forceunlock"
 	ActRebalance      = "rebalance"
 	ActResilver       = "resilver"
+	ActDrainMountpath = "drainmpath" // copy mountpath content elsewhere and remove the mountpath
 	ActLRU            = "lru"
 	ActSyncLB         = "synclb"
 	ActCreateLB       = "createlb"
 	ActDestroyLB      = "destroylb"
 	ActRenameLB       = "renamelb"
+	ActSwapLB         = "swaplb"
 	ActCopyBucket     = "copybck"
 	ActRegisterCB     = "registercb"
 	ActEvictCB        = "evictcb"
 	ActSetConfig      = "setconfig"
 	ActSetBprops      = "setbprops"
 	ActResetBprops    = "resetbprops"
+	ActSetBpropsBatch = "setbpropsbatch" // same props => multiple buckets, in one transaction
 	ActListObjects    = "listobj"
 	ActInvalListCache = "invallistobjcache"
 	ActSummaryBucket  = "summarybck"
 	ActRenameObject   = "renameobj"
 	ActPromote        = "promote"
+	ActCopyRemote     = "copyremote" // pull object from attached remote AIS cluster (see ActValCopyRemote)
+	ActUndelete       = "undelete"   // restore deleted object from the trash (see TrashConf)
 	ActEvictObjects   = "evictobj"
 	ActDelete         = "delete"
 	ActPrefetch       = "prefetch"
+	ActWarmup         = "warmup" // read (batch of) objects into page cache (see WarmupMsg)
 	ActDownload       = "download"
 	ActRegTarget      = "regtarget"
 	ActRegProxy       = "regproxy"
@@ -60,35 +74,74 @@ const (
 	ActUnregProxy     = "unregproxy"
 	ActNewPrimary     = "newprimary"
 	ActRevokeToken    = "revoketoken"
+	ActRegisterS3     = "registers3"    // register tokens for S3 API access (see S3AccessKeyID)
+	ActJoinToken      = "jointoken"     // mint a token to join the cluster (see ProxyConf.JoinTokens)
+	ActConfirmDelete  = "confirmdelete" // mint a token to confirm bulk deletion (see ProxyConf.MassDeleteThreshold)
 	ActElection       = "election"
 	ActPutCopies      = "putcopies"
 	ActMakeNCopies    = "makencopies"
 	ActLoadLomCache   = "loadlomcache"
-	ActECGet          = "ecget"    // erasure decode objects
-	ActECPut          = "ecput"    // erasure encode objects
-	ActECRespond      = "ecresp"   // respond to other targets' EC requests
-	ActECEncode       = "ecencode" // erasure code a bucket
+	ActECGet          = "ecget"         // erasure decode objects
+	ActECPut          = "ecput"         // erasure encode objects
+	ActECRespond      = "ecresp"        // respond to other targets' EC requests
+	ActECEncode       = "ecencode"      // erasure code a bucket
+	ActECRestore      = "ecrestore"     // restore (batch of) objects from EC slices
+	ActECMetaMigrate  = "ecmetamigrate" // convert EC metafiles to binary format
+	ActECUndelete     = "ecundelete"    // undelete (batch of) recently deleted EC objects
+	ActECReprotect    = "ecreprotect"   // rebuild EC protection of a given object from scratch (see ECPlacement)
 	ActStartGFN       = "metasync-start-gfn"
 	ActRecoverBck     = "recoverbck"
 	ActTar2Tf         = "tar2tf"
 	ActAttach         = "attach"
 	ActDetach         = "detach"
 	ActQuery          = "query"
+	ActQueryJournal   = "queryjournal" // query per-bucket operation journal (see JournalConf)
+	ActSimPlacement   = "simplacement" // estimate objects to move upon cluster membership change
+	ActObjLocations   = "objlocations" // locate objects: owning targets, hosts, copies, and EC slices
 
 	// Actions to manipulate mountpaths (/v1/daemon/mountpaths)
 	ActMountpathEnable  = "enable"
 	ActMountpathDisable = "disable"
 	ActMountpathAdd     = "add"
 	ActMountpathRemove  = "remove"
+	ActMountpathDrain   = "drain" // remove safely (see ActDrainMountpath)
 
 	// Actions on xactions
-	ActXactStop  = "stop"
-	ActXactStart = "start"
+	ActXactStop   = "stop"
+	ActXactStart  = "start"
+	ActXactPause  = "pause"  // see XactMetadata.Pausable
+	ActXactResume = "resume" // resume paused
 
 	// auxiliary
 	ActTransient = "transient" // do not save on the disk
 )
 
+// operation journal records (see JournalEntry)
+const (
+	JournalPut    = "put"
+	JournalGet    = "get"
+	JournalAppend = "append"
+	JournalDelete = "delete"
+	JournalEvict  = "evict"
+	JournalRename = "rename"
+)
+
+// cluster events (see Event); the part that precedes the dot is the event's category
+const (
+	EventObjPut     = "object." + JournalPut
+	EventObjAppend  = "object." + JournalAppend
+	EventObjDelete  = "object." + JournalDelete
+	EventObjEvict   = "object." + JournalEvict
+	EventObjRename  = "object." + JournalRename
+	EventBckCreate  = "bucket.create"
+	EventBckDestroy = "bucket.destroy"
+	EventXactFinish = "xaction.finish"
+
+	// event sink types (see EventSinkConf)
+	EventSinkWebhook = "webhook"
+	EventSinkNATS    = "nats"
+)
+
 // xaction begin-commit phases
 const (
 	ActBegin  = "begin"
@@ -114,30 +167,35 @@ const (
 	HeaderBucketVerValidateWarm = "versioning.validate_warm_get" // Validate version on warm GET
 	HeaderBucketAccessAttrs     = "access"                       // Bucket access attributes
 	HeaderBucketCreated         = "created"                      // Bucket creation time
+	HeaderBucketProvenance      = "provenance"                   // "<property>=<source>:<BMD version>" (see URLParamProvenance)
 
 	// object meta
 	HeaderObjCksumType = "checksum.type"  // Checksum Type, one of SupportedChecksums()
 	HeaderObjCksumVal  = "checksum.value" // Checksum Value
 	HeaderObjAtime     = "atime"          // Object access time
 	HeaderObjCustomMD  = "custom_md"      // Object custom metadata
+	HeaderObjAccess    = "access_stats"   // Object access statistics: "<count>[,<time>...]" (see cluster.RecordAccess)
 	HeaderObjSize      = "size"           // Object size (bytes)
 	HeaderObjVersion   = "version"        // Object version/generation - ais or Cloud
 	HeaderObjECMeta    = "ec_meta"        // Info about EC object/slice/replica
+	HeaderObjECPolicy  = "ec_policy"      // PUT: per-object EC policy, e.g. "parity=3" or "replication=2"
 
 	// intra-cluster: control
 	HeaderCallerID          = "caller.id"
 	HeaderCallerName        = "caller.name"
 	HeaderCallerSmapVersion = "caller.smap.ver"
 
-	HeaderNodeID  = "node.id"
-	HeaderNodeURL = "node.url"
+	HeaderNodeID   = "node.id"
+	HeaderNodeURL  = "node.url"
+	HeaderNodeTime = "node.time" // responder's Unix time (ns) - see cmn.ClockSkew
 
 	// custom
 	HeaderAppendHandle = "append.handle"
 
 	// intra-cluster: streams
 	HeaderSessID   = "session.id"
-	HeaderCompress = "compress" // LZ4Compression, etc.
+	HeaderCompress = "compress"  // LZ4Compression, etc.
+	HeaderSegCksum = "seg.cksum" // size of the segments of the payload that are checksummed (see transport.Extra)
 
 	HeaderHandle = "handle"
 )
@@ -155,8 +213,15 @@ const (
 	URLParamCheckExists = "check_cached" // true: check if object exists
 	URLParamProvider    = "provider"     // cloud provider
 	URLParamNamespace   = "namespace"
-	URLParamPrefix      = "prefix" // prefix for list objects in a bucket
-	URLParamRegex       = "regex"  // dsort/downloader regex
+	URLParamPrefix      = "prefix"     // prefix for list objects in a bucket
+	URLParamRegex       = "regex"      // dsort/downloader regex
+	URLParamWaitTimeout = "wait"       // HEAD object: wait for the object to appear, e.g. "30s"
+	URLParamObjProps    = "objprops"   // GET object: include all the object's properties (as in HEAD) in the response header
+	URLParamObjVersion  = "version"    // GET object: a given version of the cloud object
+	URLParamAsOf        = "asof"       // GET object: the version of the cloud object as of a given time (see cmn.S2Time)
+	URLParamBckEvents   = "events"     // list buckets: include mutation counters (see cmn.BckEvents)
+	URLParamProvenance  = "provenance" // HEAD bucket: include provenance of the properties (see cmn.PropsProvenance)
+	URLParamStream      = "stream"     // list objects: stream the pages as server-sent events (see ListStream* enum)
 	// internal use
 	URLParamCheckExistsAny   = "cea" // true: lookup object in all mountpaths (NOTE: compare with URLParamCheckExists)
 	URLParamProxyID          = "pid" // ID of the redirecting proxy
@@ -172,10 +237,15 @@ const (
 	URLParamRebData          = "rbd" // true: get EC rebalance data (pulling data if push way fails)
 	URLParamTaskAction       = "tac" // "start", "status", "result"
 	URLParamClusterInfo      = "cii" // true: Health to return ais.clusterInfo
+	URLParamClockSkew        = "csk" // true: Health to return the peers' clock skews (cmn.ClockSkews)
 	URLParamRecvType         = "rtp" // to tell real PUT from migration PUT
+	URLParamJoinToken        = "jtk" // join token (see cmn.ActJoinToken)
+	URLParamConfirmToken     = "cft" // bulk deletion confirmation token (see cmn.ActConfirmDelete)
+	URLParamMisplaced        = "mpl" // true: PUT redirected away from the out-of-space HRW target (see lru.redirect_on_oos)
 
 	URLParamAppendType   = "appendty"
 	URLParamAppendHandle = "handle"
+	URLParamOffset       = "offset" // byte offset of the segment (cmn.WriteAtOp)
 
 	// action (operation, transaction, task) UUID
 	URLParamUUID = "uuid"
@@ -188,6 +258,9 @@ const (
 	// 2PC transactions - control plane
 	URLParamTxnTimeout   = "txntout"  // transaction timeout
 	URLParamWaitMetasync = "txnwsync" // wait for metasync (used only when there's an alternative)
+	URLParamAsync        = "async"    // true: respond with the UUID of the operation right away (see cmn.JobStatus)
+	URLParamDryRun       = "dry_run"  // true: validate (begin and abort) without changing anything
+	URLParamWaitFor      = "wait_for" // UUID of the xaction (or job) to complete before starting the operation (implies async)
 
 	// notification target's node ID (usually, the node that initiates the operation)
 	URLParamNotifyMe = "nft"
@@ -200,6 +273,13 @@ const (
 	TaskResult = "result"
 )
 
+// enum: server-sent event types of the streaming list-objects (cmn.URLParamStream)
+const (
+	ListStreamEntries = "entries"
+	ListStreamEnd     = "end"
+	ListStreamError   = "error"
+)
+
 // URLParamWhat enum
 const (
 	GetWhatConfig       = "config"
@@ -213,8 +293,14 @@ const (
 	GetWhatDiskStats    = "disk"
 	GetWhatDaemonStatus = "status"
 	GetWhatRemoteAIS    = "remote"
+	GetWhatLocks        = "locks"
 	GetWhatXactStats    = "getxstats" // stats(xaction-by-uuid)
 	QueryXactStats      = "qryxstats" // stats(all-matching-xactions)
+	GetWhatXactProgress = "xprogress" // progress(xaction-by-uuid), cluster-wide
+	GetWhatXactHistory  = "xhistory"  // finished xactions (see XactHistoryConf)
+	GetWhatXactSummary  = "xsummary"  // stats(all-matching-xactions), merged cluster-wide (see XactSummary)
+	GetWhatPeerStats    = "peerstats" // intra-cluster calls by peer and channel (see PeerStats)
+	GetWhatNotifStatus  = "notifstat" // completion status(xaction-or-job-by-uuid), intra-cluster
 )
 
 // SelectMsg.TimeFormat enum
@@ -234,6 +320,8 @@ const (
 	GetPropsStatus   = "status"
 	GetPropsCopies   = "copies"
 	GetPropsEC       = "ec"
+	GetPropsAlloc    = "alloc_size" // allocated (on disk) size, may be less than `size` for sparse objects
+	GetPropsCustom   = "custom"     // custom metadata: "custom" - all keys, "custom.<key>" - a given key
 )
 
 // BucketEntry.Status
@@ -325,6 +413,19 @@ const (
 	CompressRatio  = "ratio=%d" // adaptive: min ratio that warrants compression
 )
 
+// enum: EC placement of slices and replicas
+const (
+	ECPlacementHRW    = "hrw"    // default: HRW-ordered targets
+	ECPlacementDomain = "domain" // no two slices in the same failure domain (rack, zone), if possible
+)
+
+// enum: EC metadata quorum - resolution of the metadata received from other targets when restoring
+const (
+	ECQuorumMajority = "majority" // default: the most frequent object checksum wins
+	ECQuorumStrict   = "strict"   // in addition, the winner must be confirmed by data_slices+1 targets
+	ECQuorumNewest   = "newest"   // the newest object version wins (versions must be numeric)
+)
+
 // AuthN consts
 const (
 	HeaderAuthorization = "Authorization"
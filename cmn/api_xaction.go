@@ -4,7 +4,10 @@
  */
 package cmn
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 const (
 	XactTypeGlobal = "global"
@@ -12,17 +15,25 @@ const (
 	XactTypeTask   = "task"
 )
 
+// a running target is a straggler if its ETA exceeds the median by this factor
+const xactStragglerFactor = 2
+
 type (
 	XactMetadata struct {
 		Type      string
 		Startable bool // determines if can be started via API
+		Pausable  bool // determines if can be paused (and resumed) via API
 	}
+	// XactTags are user-defined key-value pairs (e.g., team, pipeline run ID)
+	// attached to the xactions (see ActionMsg.Tags) to tell them apart
+	XactTags   map[string]string
 	XactReqMsg struct {
-		Target      string `json:"target,omitempty"`
-		ID          string `json:"id"`
-		Kind        string `json:"kind"`
-		Bck         Bck    `json:"bck"`
-		OnlyRunning *bool  `json:"show_active"`
+		Target      string   `json:"target,omitempty"`
+		ID          string   `json:"id"`
+		Kind        string   `json:"kind"`
+		Bck         Bck      `json:"bck"`
+		OnlyRunning *bool    `json:"show_active"`
+		Tags        XactTags `json:"tags,omitempty"` // start: tags to attach; query: tags to match (all)
 	}
 	BaseXactStats struct {
 		IDX         string    `json:"id"`
@@ -33,37 +44,188 @@ type (
 		ObjCountX   int64     `json:"obj_count,string"`
 		BytesCountX int64     `json:"bytes_count,string"`
 		AbortedX    bool      `json:"aborted"`
+		PausedX     bool      `json:"paused,omitempty"`
+		TagsX       XactTags  `json:"tags,omitempty"`
+		// progress (see XactBase.EstimateProgress)
+		TotalCountX int64         `json:"total_count,string,omitempty"` // objects to process; zero - unknown
+		DoneCountX  int64         `json:"done_count,string,omitempty"`  // objects processed so far, including skipped
+		ThroughputX int64         `json:"throughput,string,omitempty"`  // bytes per second (moving average)
+		EtaX        time.Duration `json:"eta,omitempty"`                // estimated time to completion
 	}
 	BaseXactStatsExt struct {
 		BaseXactStats
 		Ext interface{} `json:"ext"`
 	}
+	// XactRecord is a finished xaction, as recorded in the target's
+	// xaction history (see XactHistoryConf)
+	XactRecord struct {
+		BaseXactStats
+		Err    string `json:"error,omitempty"`
+		Target string `json:"target"`
+	}
+	// XactSummary is the cluster-wide summary of a given xaction: the stats
+	// of the xaction reported by the targets, merged (see MergeXactStats)
+	XactSummary struct {
+		ID         string        `json:"id"`
+		Kind       string        `json:"kind"`
+		Bck        Bck           `json:"bck"`
+		StartTime  time.Time     `json:"start_time"` // the earliest
+		EndTime    time.Time     `json:"end_time"`   // the latest; zero - still running on some target(s)
+		ObjCount   int64         `json:"obj_count,string"`
+		BytesCount int64         `json:"bytes_count,string"`
+		TotalCount int64         `json:"total_count,string,omitempty"` // zero - unknown on some target(s)
+		DoneCount  int64         `json:"done_count,string,omitempty"`
+		Throughput int64         `json:"throughput,string,omitempty"` // bytes per second, all targets
+		ETA        time.Duration `json:"eta,omitempty"`               // the longest of the running targets' ETAs
+		Aborted    bool          `json:"aborted"`                     // on any target
+		Targets    int           `json:"targets"`                     // number of targets that run (or have run) the xaction
+		Running    int           `json:"running"`                     // number of targets that still run the xaction
+		Slowest    string        `json:"slowest,omitempty"`           // target that finishes (or has finished) last
+		Stragglers []string      `json:"stragglers,omitempty"`        // running targets that lag behind the rest
+	}
 )
 
+func (r *XactRecord) Duration() time.Duration { return r.EndTimeX.Sub(r.StartTimeX) }
+
+// MergeXactStats merges the stats reported by the targets (target ID => stats
+// of the matching xactions) into the cluster-wide summaries, one per xaction -
+// the earliest started first. A running target is a straggler when most of the
+// targets have already finished the xaction or when its ETA exceeds the median
+// ETA of the running targets xactStragglerFactor times.
+func MergeXactStats(nodeStats map[string][]*BaseXactStats) []*XactSummary {
+	type node struct {
+		tid   string
+		stats *BaseXactStats
+	}
+	groups := make(map[string][]node, 4)
+	for tid, tstats := range nodeStats {
+		for _, stats := range tstats {
+			key := stats.KindX + "/" + stats.IDX + "/" + stats.BckX.String()
+			groups[key] = append(groups[key], node{tid, stats})
+		}
+	}
+	summaries := make([]*XactSummary, 0, len(groups))
+	for _, nodes := range groups {
+		sort.Slice(nodes, func(i, j int) bool { return nodes[i].tid < nodes[j].tid })
+		var (
+			first      = nodes[0].stats
+			totalKnown = true
+			slowest    *BaseXactStats
+			etas       = make([]time.Duration, 0, len(nodes))
+			s          = &XactSummary{ID: first.IDX, Kind: first.KindX, Bck: first.BckX, Targets: len(nodes)}
+		)
+		for _, n := range nodes {
+			stats := n.stats
+			if s.StartTime.IsZero() || stats.StartTimeX.Before(s.StartTime) {
+				s.StartTime = stats.StartTimeX
+			}
+			s.ObjCount += stats.ObjCountX
+			s.BytesCount += stats.BytesCountX
+			s.DoneCount += stats.DoneCountX
+			s.TotalCount += stats.TotalCountX
+			totalKnown = totalKnown && stats.TotalCountX > 0
+			s.Aborted = s.Aborted || stats.AbortedX
+			if stats.Finished() {
+				if stats.EndTimeX.After(s.EndTime) {
+					s.EndTime = stats.EndTimeX
+				}
+				continue
+			}
+			s.Running++
+			s.Throughput += stats.ThroughputX
+			if stats.EtaX > s.ETA {
+				s.ETA = stats.EtaX
+			}
+			if stats.EtaX > 0 {
+				etas = append(etas, stats.EtaX)
+			}
+			// the longest ETA or, given the same (or unknown) ETAs, the least progress
+			if slowest == nil || stats.EtaX > slowest.EtaX ||
+				(stats.EtaX == slowest.EtaX && stats.ObjCountX < slowest.ObjCountX) {
+				slowest, s.Slowest = stats, n.tid
+			}
+		}
+		if !totalKnown {
+			s.TotalCount = 0
+		}
+		if s.Running == 0 {
+			for _, n := range nodes {
+				if n.stats.EndTimeX.Equal(s.EndTime) {
+					s.Slowest = n.tid
+					break
+				}
+			}
+			if d := s.EndTime.Sub(s.StartTime); d > 0 {
+				s.Throughput = int64(float64(s.BytesCount) / d.Seconds())
+			}
+		} else {
+			s.EndTime = time.Time{}
+			var median time.Duration
+			if len(etas) > 1 {
+				sort.Slice(etas, func(i, j int) bool { return etas[i] < etas[j] })
+				median = etas[(len(etas)-1)/2]
+			}
+			mostFinished := 2*(s.Targets-s.Running) > s.Targets
+			for _, n := range nodes {
+				stats := n.stats
+				if stats.Finished() {
+					continue
+				}
+				if mostFinished || (median > 0 && stats.EtaX > xactStragglerFactor*median) {
+					s.Stragglers = append(s.Stragglers, n.tid)
+				}
+			}
+		}
+		summaries = append(summaries, s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].StartTime.Equal(summaries[j].StartTime) {
+			return summaries[i].ID < summaries[j].ID
+		}
+		return summaries[i].StartTime.Before(summaries[j].StartTime)
+	})
+	return summaries
+}
+
+// Match returns true if the tags include all of the `other` tags
+func (tags XactTags) Match(other XactTags) bool {
+	for k, v := range other {
+		if tv, ok := tags[k]; !ok || tv != v {
+			return false
+		}
+	}
+	return true
+}
+
 var XactsMeta = map[string]XactMetadata{
 	// NOTE -- TODO: extend to include: run-by-primary-only | progress-bar-supported | limited-coexistence #791
 	// global kinds
-	ActLRU:       {Type: XactTypeGlobal, Startable: true},
-	ActElection:  {Type: XactTypeGlobal, Startable: false},
-	ActResilver:  {Type: XactTypeGlobal, Startable: true},
-	ActRebalance: {Type: XactTypeGlobal, Startable: true},
-	ActDownload:  {Type: XactTypeGlobal, Startable: false},
+	ActLRU:            {Type: XactTypeGlobal, Startable: true},
+	ActElection:       {Type: XactTypeGlobal, Startable: false},
+	ActResilver:       {Type: XactTypeGlobal, Startable: true, Pausable: true},
+	ActRebalance:      {Type: XactTypeGlobal, Startable: true, Pausable: true},
+	ActDownload:       {Type: XactTypeGlobal, Startable: false},
+	ActDrainMountpath: {Type: XactTypeGlobal, Startable: false},
 
 	// bucket's kinds
-	ActECGet:        {Type: XactTypeBck, Startable: false},
-	ActECPut:        {Type: XactTypeBck, Startable: false},
-	ActECRespond:    {Type: XactTypeBck, Startable: false},
-	ActMakeNCopies:  {Type: XactTypeBck, Startable: false},
-	ActPutCopies:    {Type: XactTypeBck, Startable: false},
-	ActRenameLB:     {Type: XactTypeBck, Startable: false},
-	ActCopyBucket:   {Type: XactTypeBck, Startable: false},
-	ActECEncode:     {Type: XactTypeBck, Startable: false},
-	ActEvictObjects: {Type: XactTypeBck, Startable: false},
-	ActDelete:       {Type: XactTypeBck, Startable: false},
-	ActLoadLomCache: {Type: XactTypeBck, Startable: false},
-	ActPrefetch:     {Type: XactTypeBck, Startable: true},
-	ActPromote:      {Type: XactTypeBck, Startable: false},
-	ActQuery:        {Type: XactTypeBck, Startable: false},
+	ActECGet:         {Type: XactTypeBck, Startable: false},
+	ActECPut:         {Type: XactTypeBck, Startable: false},
+	ActECRespond:     {Type: XactTypeBck, Startable: false},
+	ActMakeNCopies:   {Type: XactTypeBck, Startable: false, Pausable: true},
+	ActPutCopies:     {Type: XactTypeBck, Startable: false},
+	ActRenameLB:      {Type: XactTypeBck, Startable: false},
+	ActSwapLB:        {Type: XactTypeBck, Startable: false},
+	ActCopyBucket:    {Type: XactTypeBck, Startable: false, Pausable: true},
+	ActECEncode:      {Type: XactTypeBck, Startable: false, Pausable: true},
+	ActECRestore:     {Type: XactTypeBck, Startable: false},
+	ActECMetaMigrate: {Type: XactTypeBck, Startable: true},
+	ActEvictObjects:  {Type: XactTypeBck, Startable: false},
+	ActDelete:        {Type: XactTypeBck, Startable: false},
+	ActLoadLomCache:  {Type: XactTypeBck, Startable: false, Pausable: true},
+	ActPrefetch:      {Type: XactTypeBck, Startable: true},
+	ActPromote:       {Type: XactTypeBck, Startable: false},
+	ActQuery:         {Type: XactTypeBck, Startable: false},
+	ActWarmup:        {Type: XactTypeBck, Startable: false},
 
 	ActListObjects:   {Type: XactTypeTask, Startable: false},
 	ActSummaryBucket: {Type: XactTypeTask, Startable: false},
@@ -5,6 +5,11 @@
 package cmn
 
 import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
@@ -18,6 +23,9 @@ const (
 	AuthClusterOwnerRole = "ClusterOwner"
 	AuthBucketOwnerRole  = "BucketOwner"
 	AuthGuestRole        = "Guest"
+
+	s3KeyIDPrefix = "AIS"
+	s3KeyIDLen    = 20
 )
 
 type (
@@ -58,10 +66,24 @@ type (
 		Clusters []*AuthCluster `json:"clusters"`
 		Buckets  []*AuthBucket  `json:"buckets,omitempty"`
 		IsAdmin  bool           `json:"admin"`
+		// non-empty if the token is valid only for a given cluster
+		ClusterID string `json:"cluster_id,omitempty"`
 	}
 	AuthClusterList struct {
 		Clusters map[string]*AuthCluster `json:"clusters,omitempty"`
 	}
+	// Permission for a new node to join a given cluster (see ActJoinToken)
+	JoinToken struct {
+		ClusterID string    `json:"join_cluster"`
+		Expires   time.Time `json:"expires"`
+	}
+	// Confirmation of a given bulk deletion (see ActConfirmDelete)
+	ConfirmToken struct {
+		ClusterID string    `json:"confirm_cluster"`
+		Op        string    `json:"confirm_op"`
+		Nonce     string    `json:"nonce"` // makes each token unique - to be used only once
+		Expires   time.Time `json:"expires"`
+	}
 )
 
 var (
@@ -70,6 +92,9 @@ var (
 )
 
 func (tk *AuthToken) CheckPermissions(clusterID string, bck *Bck, perms AccessAttrs) error {
+	if tk.ClusterID != "" && tk.ClusterID != clusterID {
+		return fmt.Errorf("token was issued for a different cluster (%q)", tk.ClusterID)
+	}
 	if tk.IsAdmin {
 		return nil
 	}
@@ -158,7 +183,7 @@ func MergeClusterACLs(oldACLs, newACLs []*AuthCluster) []*AuthCluster {
 	return oldACLs
 }
 
-func DecryptToken(tokenStr, secret string) (*AuthToken, error) {
+func parseToken(tokenStr, secret string) (jwt.MapClaims, error) {
 	token, err := jwt.Parse(tokenStr, func(tk *jwt.Token) (interface{}, error) {
 		if _, ok := tk.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", tk.Header["alg"])
@@ -173,9 +198,123 @@ func DecryptToken(tokenStr, secret string) (*AuthToken, error) {
 	if !ok || !token.Valid {
 		return nil, ErrInvalidToken
 	}
+	return claims, nil
+}
+
+func DecryptToken(tokenStr, secret string) (*AuthToken, error) {
+	claims, err := parseToken(tokenStr, secret)
+	if err != nil {
+		return nil, err
+	}
 	tInfo := &AuthToken{}
 	if err := MorphMarshal(claims, tInfo); err != nil {
 		return nil, ErrInvalidToken
 	}
 	return tInfo, nil
 }
+
+// S3AccessKeyID returns the S3 access key ID of a given user. Same as AWS, the
+// ID is not a secret: it is 20 characters long and does not reveal the user's
+// token (that, in turn, is never sent with S3 requests - see S3SecretKey).
+func S3AccessKeyID(userID, secret string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte("s3-access-key-id:" + userID))
+	return s3KeyIDPrefix + base32.StdEncoding.EncodeToString(h.Sum(nil))[:s3KeyIDLen-len(s3KeyIDPrefix)]
+}
+
+// S3SecretKey returns the S3 secret access key that pairs with a given access
+// key ID. AuthN hands out both at login; the proxy, when verifying S3 request
+// signatures, derives the secret key from the ID in the request - no need to
+// store or distribute the secret keys.
+func S3SecretKey(accessKeyID, secret string) string {
+	h := hmac.New(sha256.New, []byte(secret))
+	h.Write([]byte("s3-secret-key:" + accessKeyID))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// NewJoinToken mints a join token for a given cluster. The token is signed
+// with the (cluster-wide) secret and expires at a given time.
+func NewJoinToken(clusterID string, expires time.Time, secret string) (string, error) {
+	if secret == "" {
+		return "", errors.New("cannot sign join token: auth.secret is not set")
+	}
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"join_cluster": clusterID,
+		"expires":      expires,
+	})
+	return t.SignedString([]byte(secret))
+}
+
+// ValidateJoinToken checks that a join token is properly signed, was minted
+// for a given cluster, and has not expired yet.
+func ValidateJoinToken(tokenStr, clusterID, secret string) error {
+	claims, err := parseToken(tokenStr, secret)
+	if err != nil {
+		return err
+	}
+	var (
+		jt         = &JoinToken{}
+		expires, _ = claims["expires"].(string)
+	)
+	jt.ClusterID, _ = claims["join_cluster"].(string)
+	if jt.Expires, err = time.Parse(time.RFC3339Nano, expires); err != nil || jt.ClusterID == "" {
+		return ErrInvalidToken
+	}
+	if jt.ClusterID != clusterID {
+		return fmt.Errorf("join token was issued for a different cluster (%q)", jt.ClusterID)
+	}
+	if Expired(jt.Expires) {
+		return errors.New("join token expired")
+	}
+	return nil
+}
+
+// NewConfirmToken mints a token that confirms a given (destructive bulk)
+// operation in a given cluster. The token is signed with the (cluster-wide)
+// secret, carries a unique nonce, and expires at a given time.
+func NewConfirmToken(clusterID, op string, expires time.Time, secret string) (string, error) {
+	if secret == "" {
+		return "", errors.New("cannot sign confirmation token: auth.secret is not set")
+	}
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"confirm_cluster": clusterID,
+		"confirm_op":      op,
+		"nonce":           hex.EncodeToString(nonce),
+		"expires":         expires,
+	})
+	return t.SignedString([]byte(secret))
+}
+
+// ValidateConfirmToken checks that a confirmation token is properly signed,
+// was minted for a given operation in a given cluster, and has not expired yet.
+// It is up to the caller to make sure that the token (nonce) is used only once.
+func ValidateConfirmToken(tokenStr, clusterID, op, secret string) (*ConfirmToken, error) {
+	claims, err := parseToken(tokenStr, secret)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		ct         = &ConfirmToken{}
+		expires, _ = claims["expires"].(string)
+	)
+	ct.ClusterID, _ = claims["confirm_cluster"].(string)
+	ct.Op, _ = claims["confirm_op"].(string)
+	ct.Nonce, _ = claims["nonce"].(string)
+	if ct.Expires, err = time.Parse(time.RFC3339Nano, expires); err != nil || ct.ClusterID == "" || ct.Nonce == "" {
+		return nil, ErrInvalidToken
+	}
+	if ct.ClusterID != clusterID {
+		return nil, fmt.Errorf("confirmation token was issued for a different cluster (%q)", ct.ClusterID)
+	}
+	if ct.Op != op {
+		return nil, errors.New("confirmation token was issued for a different operation")
+	}
+	if Expired(ct.Expires) {
+		return nil, errors.New("confirmation token expired")
+	}
+	return ct, nil
+}
@@ -0,0 +1,56 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"strconv"
+	"time"
+)
+
+// Clock skew
+//
+// The node clocks are expected to be synchronized (NTP), but nothing enforces
+// it. Each node estimates the offsets of its peers' clocks from its own: the
+// health-ping and keepalive responses carry the responder's time
+// (HeaderNodeTime), and the offset is the difference between the latter and
+// the midpoint of the round trip - give or take half the round-trip time. The
+// checks that compare the local time with a timestamp minted elsewhere (token
+// expiration) allow for ClockConf.SkewTolerance, and the peers whose offsets
+// exceed ClockConf.MaxSkew get logged and reported via health (see
+// URLParamClockSkew).
+
+type (
+	ClockSkew struct {
+		Skew     time.Duration `json:"skew"`        // peer's clock minus ours
+		RTT      time.Duration `json:"rtt"`         // round trip of the measurement (uncertainty: RTT/2)
+		Time     int64         `json:"time,string"` // Unix time (nanoseconds) of the measurement
+		Exceeded bool          `json:"exceeded"`    // |skew| > clock.max_skew, RTT considered
+	}
+	// ClockSkews - by peer ID
+	ClockSkews map[string]*ClockSkew
+)
+
+// NewClockSkew estimates the offset of a peer's clock given the peer's time
+// (HeaderNodeTime) and the local times the request was sent and the response
+// received
+func NewClockSkew(peerTime string, sent, recv time.Time, conf *ClockConf) (*ClockSkew, error) {
+	ns, err := strconv.ParseInt(peerTime, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		rtt = recv.Sub(sent)
+		mid = sent.UnixNano() + int64(rtt/2)
+		cs  = &ClockSkew{Skew: time.Duration(ns - mid), RTT: rtt, Time: recv.UnixNano()}
+	)
+	cs.Exceeded = conf.MaxSkew > 0 && AbsDuration(cs.Skew)-rtt/2 > conf.MaxSkew
+	return cs, nil
+}
+
+// Expired returns true if a given time (e.g., token expiration) minted by
+// this or another node is past, allowing for the configured clock skew
+func Expired(expires time.Time) bool {
+	return time.Now().After(expires.Add(GCO.Get().Clock.SkewTolerance))
+}
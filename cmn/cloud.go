@@ -22,6 +22,20 @@ type (
 	}
 )
 
+// AwsMultipartETag returns the (unquoted) ETag of an object that has been
+// uploaded in multiple parts: unlike regular ETags, it is not the MD5 of the
+// object but the MD5 of the parts' MD5s followed by "-<number of parts>"
+func AwsMultipartETag(etag *string) (string, bool) {
+	if etag == nil {
+		return "", false
+	}
+	v, err := strconv.Unquote(*etag)
+	if err != nil {
+		v = *etag
+	}
+	return v, strings.Contains(v, awsMultipartDelim)
+}
+
 func awsIsVersionSet(version *string) bool {
 	return version != nil && *version != "" && *version != "null"
 }
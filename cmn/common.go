@@ -79,12 +79,17 @@ var (
 
 		SkipVerifyCrt string
 		UseHTTPS      string
+
+		FailureDomain string
+		JoinToken     string
 	}{
 		Endpoint:      "AIS_ENDPOINT",
 		IsPrimary:     "AIS_IS_PRIMARY",
 		PrimaryID:     "AIS_PRIMARY_ID",
 		SkipVerifyCrt: "AIS_SKIP_VERIFY_CRT",
 		UseHTTPS:      "AIS_USE_HTTPS",
+		FailureDomain: "AIS_FAILURE_DOMAIN",
+		JoinToken:     "AIS_JOIN_TOKEN",
 	}
 )
 
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -36,6 +37,10 @@ const (
 	// EC
 	MinSliceCount = 1  // minimum number of data or parity slices
 	MaxSliceCount = 32 // maximum number of data or parity slices
+
+	// operation journal (see JournalConf)
+	JournalDefaultMaxEntries = 10000
+	JournalMaxEntries        = 1000000
 )
 
 const (
@@ -51,6 +56,10 @@ const (
 	// L7
 	httpProto  = "http"
 	httpsProto = "https"
+
+	// ListenerConf.TLS
+	ListenerTLSOn  = "on"
+	ListenerTLSOff = "off"
 )
 
 type (
@@ -209,18 +218,27 @@ var (
 	_ Validator = &CloudConf{}
 	_ Validator = &CksumConf{}
 	_ Validator = &LRUConf{}
+	_ Validator = &RemoteCacheConf{}
 	_ Validator = &MirrorConf{}
 	_ Validator = &ECConf{}
 	_ Validator = &VersionConf{}
 	_ Validator = &KeepaliveConf{}
 	_ Validator = &PeriodConf{}
 	_ Validator = &TimeoutConf{}
+	_ Validator = &ClockConf{}
+	_ Validator = &TxnConf{}
+	_ Validator = &EventsConf{}
+	_ Validator = &XactHistoryConf{}
+	_ Validator = &XactBudgetConf{}
+	_ Validator = &PromoteConf{}
+	_ Validator = &FSHCConf{}
 	_ Validator = &ClientConf{}
 	_ Validator = &RebalanceConf{}
 	_ Validator = &NetConf{}
 	_ Validator = &DownloaderConf{}
 	_ Validator = &DSortConf{}
 	_ Validator = &FSPathsConf{}
+	_ Validator = &ContentRoutingConf{}
 	_ Validator = &TestfspathConf{}
 	_ Validator = &CompressionConf{}
 
@@ -228,6 +246,8 @@ var (
 	_ PropsValidator = &LRUConf{}
 	_ PropsValidator = &MirrorConf{}
 	_ PropsValidator = &ECConf{}
+	_ PropsValidator = &JournalConf{}
+	_ PropsValidator = &HeadersConf{}
 
 	_ json.Marshaler   = &CloudConf{}
 	_ json.Unmarshaler = &CloudConf{}
@@ -241,30 +261,38 @@ var (
 //
 // nolint:maligned // no performance critical code
 type Config struct {
-	Confdir          string          `json:"confdir"`
-	Cloud            CloudConf       `json:"cloud"`
-	Mirror           MirrorConf      `json:"mirror"`
-	EC               ECConf          `json:"ec"`
-	Log              LogConf         `json:"log"`
-	Periodic         PeriodConf      `json:"periodic"`
-	Timeout          TimeoutConf     `json:"timeout"`
-	Client           ClientConf      `json:"client"`
-	Proxy            ProxyConf       `json:"proxy"`
-	LRU              LRUConf         `json:"lru"`
-	Disk             DiskConf        `json:"disk"`
-	Rebalance        RebalanceConf   `json:"rebalance"`
-	Replication      ReplicationConf `json:"replication"`
-	Cksum            CksumConf       `json:"checksum"`
-	Versioning       VersionConf     `json:"versioning"`
-	FSpaths          FSPathsConf     `json:"fspaths"`
-	TestFSP          TestfspathConf  `json:"test_fspaths"`
-	Net              NetConf         `json:"net"`
-	FSHC             FSHCConf        `json:"fshc"`
-	Auth             AuthConf        `json:"auth"`
-	KeepaliveTracker KeepaliveConf   `json:"keepalivetracker"`
-	Downloader       DownloaderConf  `json:"downloader"`
-	DSort            DSortConf       `json:"distributed_sort"`
-	Compression      CompressionConf `json:"compression"`
+	Confdir          string             `json:"confdir"`
+	Cloud            CloudConf          `json:"cloud"`
+	Mirror           MirrorConf         `json:"mirror"`
+	EC               ECConf             `json:"ec"`
+	Log              LogConf            `json:"log"`
+	Periodic         PeriodConf         `json:"periodic"`
+	Timeout          TimeoutConf        `json:"timeout"`
+	Clock            ClockConf          `json:"clock"`
+	Txn              TxnConf            `json:"txn"`
+	Events           EventsConf         `json:"events"`
+	XactHistory      XactHistoryConf    `json:"xaction_history"`
+	XactBudget       XactBudgetConf     `json:"xaction_budget"`
+	Promote          PromoteConf        `json:"promote"`
+	Client           ClientConf         `json:"client"`
+	Proxy            ProxyConf          `json:"proxy"`
+	LRU              LRUConf            `json:"lru"`
+	RemoteCache      RemoteCacheConf    `json:"remote_cache"`
+	Disk             DiskConf           `json:"disk"`
+	Rebalance        RebalanceConf      `json:"rebalance"`
+	Replication      ReplicationConf    `json:"replication"`
+	Cksum            CksumConf          `json:"checksum"`
+	Versioning       VersionConf        `json:"versioning"`
+	FSpaths          FSPathsConf        `json:"fspaths"`
+	ContentRouting   ContentRoutingConf `json:"content_routing"`
+	TestFSP          TestfspathConf     `json:"test_fspaths"`
+	Net              NetConf            `json:"net"`
+	FSHC             FSHCConf           `json:"fshc"`
+	Auth             AuthConf           `json:"auth"`
+	KeepaliveTracker KeepaliveConf      `json:"keepalivetracker"`
+	Downloader       DownloaderConf     `json:"downloader"`
+	DSort            DSortConf          `json:"distributed_sort"`
+	Compression      CompressionConf    `json:"compression"`
 }
 
 type CloudConf struct {
@@ -313,9 +341,15 @@ type LogConf struct {
 type PeriodConf struct {
 	StatsTimeStr     string `json:"stats_time"`
 	RetrySyncTimeStr string `json:"retry_sync_time"`
+	WorkfileGCAgeStr string `json:"workfile_gc_age"`  // remove leftover workfiles older than that ("" or 0 - never)
+	EmptyDirGCAgeStr string `json:"empty_dir_gc_age"` // remove empty dirs not modified for that long ("" or 0 - never)
+	NotifTimeStr     string `json:"notif_time"`       // xaction progress notification period ("" or 0 - never)
 	// omitempty
 	StatsTime     time.Duration `json:"-"`
 	RetrySyncTime time.Duration `json:"-"`
+	WorkfileGCAge time.Duration `json:"-"`
+	EmptyDirGCAge time.Duration `json:"-"`
+	NotifTime     time.Duration `json:"-"`
 }
 
 // timeoutconfig contains timeouts used for intra-cluster communication
@@ -332,6 +366,87 @@ type TimeoutConf struct {
 	MaxHostBusy        time.Duration `json:"-"`
 }
 
+// ClockConf: node clocks (see ClockSkew) - how far a timestamp minted by
+// another node (e.g., token expiration) may be off and still be taken at face
+// value (`skew_tolerance`), and the estimated offset of a peer's clock beyond
+// which the node warns and reports the peer via health (`max_skew`)
+type ClockConf struct {
+	SkewToleranceStr string        `json:"skew_tolerance"`
+	SkewTolerance    time.Duration `json:"-"`
+	MaxSkewStr       string        `json:"max_skew"`
+	MaxSkew          time.Duration `json:"-"`
+}
+
+// TxnConf: control-plane transactions (creating buckets, setting bucket props, etc.)
+// that the primary runs with the targets
+type TxnConf struct {
+	// per-action overrides of the default timeouts: action (e.g., cmn.ActSetBprops) => timeouts
+	Timeouts map[string]TxnTimeoutConf `json:"timeouts,omitempty" list:"readonly"`
+	// number of times to retry the commit with the targets that fail to respond
+	// (0 - do not retry), and the initial delay between the retries (doubles with each retry)
+	CommitRetries    int           `json:"commit_retries"`
+	CommitBackoffStr string        `json:"commit_backoff"`
+	CommitBackoff    time.Duration `json:"-"`
+}
+
+// TxnTimeoutConf: transaction timeouts (empty - default)
+type TxnTimeoutConf struct {
+	BeginStr  string        `json:"begin,omitempty"`  // begin broadcast
+	CommitStr string        `json:"commit,omitempty"` // commit broadcast
+	WaitStr   string        `json:"wait,omitempty"`   // targets waiting for the metasync upon commit
+	Begin     time.Duration `json:"-"`
+	Commit    time.Duration `json:"-"`
+	Wait      time.Duration `json:"-"`
+}
+
+// XactHistoryConf: the records of the finished xactions (see XactRecord) that
+// each target keeps on disk - for `retention` (zero disables the history) and
+// up to `max_entries` (zero - unlimited), whichever comes first
+type XactHistoryConf struct {
+	RetentionStr string        `json:"retention"`
+	Retention    time.Duration `json:"-"`
+	MaxEntries   int           `json:"max_entries"`
+}
+
+// XactBudgetConf: the share (percentage) of a target's disk bandwidth (`disk_bw`,
+// bytes per second; zero disables budgeting) that the budgeted xactions -
+// rebalance and EC encoding - may consume collectively; the
+// running xaction kinds split the budget in proportion to their `weights` (by
+// kind; a kind without a weight has weight 1) - see XactBase.Throttle
+type XactBudgetConf struct {
+	DiskBWStr string         `json:"disk_bw"`
+	DiskBW    int64          `json:"-"`
+	Share     int            `json:"share"`
+	Weights   map[string]int `json:"weights" list:"readonly"`
+}
+
+// EventsConf: the sinks that the nodes deliver the cluster events to (see cmn.Event)
+type EventsConf struct {
+	Sinks []EventSinkConf `json:"sinks" list:"readonly"`
+}
+
+type EventSinkConf struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`              // EventSinkWebhook | EventSinkNATS
+	URL     string   `json:"url"`               // webhook: http(s) URL; NATS: [nats://]host:port
+	Subject string   `json:"subject,omitempty"` // NATS only
+	Events  []string `json:"events,omitempty"`  // event types and/or categories (e.g. "object"); empty - all
+	Buckets []string `json:"buckets,omitempty"` // bucket names; empty - all
+}
+
+// PromoteConf: the (local) directories that targets watch, to promote the new files (see cmn.ActPromote)
+type PromoteConf struct {
+	Watch []PromoteWatchConf `json:"watch" list:"readonly"`
+}
+
+type PromoteWatchConf struct {
+	Dir       string `json:"dir"`                 // absolute path
+	Bck       Bck    `json:"bucket"`              // destination bucket
+	ObjName   string `json:"objname,omitempty"`   // object name prefix (the rest is the file's path relative to Dir)
+	Recurs    bool   `json:"recurs,omitempty"`    // watch the subdirectories as well
+	Overwrite bool   `json:"overwrite,omitempty"` // overwrite existing objects
+}
+
 type ClientConf struct {
 	TimeoutStr     string        `json:"client_timeout"`
 	Timeout        time.Duration `json:"-"`
@@ -346,6 +461,14 @@ type ProxyConf struct {
 	OriginalURL  string `json:"original_url"`
 	DiscoveryURL string `json:"discovery_url"`
 	NonElectable bool   `json:"non_electable"`
+	// when enabled, a target that is not in the cluster map must present a valid
+	// join token to be admitted into the running cluster (see cmn.ActJoinToken);
+	// requires auth.secret
+	JoinTokens bool `json:"join_tokens"`
+	// destroying a bucket or deleting (by list, range, or prefix) more than
+	// this number of objects must be confirmed (see cmn.ActConfirmDelete);
+	// zero disables the protection
+	MassDeleteThreshold int64 `json:"mass_delete_threshold"`
 }
 
 type LRUConf struct {
@@ -378,13 +501,41 @@ type LRUConf struct {
 
 	// Enabled: LRU will only run when set to true
 	Enabled bool `json:"enabled"`
+
+	// SpillOnOOS: Cloud-backed buckets only - when out of space, evict clean
+	// (already stored in the Cloud) objects on the fly to admit new PUTs
+	SpillOnOOS bool `json:"spill_on_oos"`
+
+	// RedirectOnOOS: when the object's (HRW) target is out of space, PUT the
+	// object to another target, to be moved back by the (next) rebalance
+	RedirectOnOOS bool `json:"redirect_on_oos"`
 }
 
 type LRUConfToUpdate struct {
-	LowWM   *int64 `json:"lowwm"`
-	HighWM  *int64 `json:"highwm"`
-	OOS     *int64 `json:"out_of_space"`
-	Enabled *bool  `json:"enabled"`
+	LowWM         *int64 `json:"lowwm"`
+	HighWM        *int64 `json:"highwm"`
+	OOS           *int64 `json:"out_of_space"`
+	Enabled       *bool  `json:"enabled"`
+	SpillOnOOS    *bool  `json:"spill_on_oos"`
+	RedirectOnOOS *bool  `json:"redirect_on_oos"`
+}
+
+// RemoteCacheConf configures target-local caching of objects read from attached
+// remote AIS clusters. When enabled, objects fetched from a remote cluster are stored
+// in the designated (local) ais bucket and are evicted by their own LRU that keeps
+// the total size of the cache bucket (per target) below the configured capacity.
+type RemoteCacheConf struct {
+	// Bucket: name of the local ais bucket that holds cached objects
+	Bucket string `json:"bucket"`
+
+	// Capacity: max total size (bytes) of the cached objects on a given target
+	Capacity int64 `json:"capacity"`
+
+	// LowWM: when evicting, keep evicting until the cache size gets below LowWM (% of Capacity)
+	LowWM int64 `json:"lowwm"`
+
+	// Enabled: cache objects read from remote AIS clusters
+	Enabled bool `json:"enabled"`
 }
 
 type DiskConf struct {
@@ -396,8 +547,16 @@ type DiskConf struct {
 
 	IostatTimeLongStr  string `json:"iostat_time_long"`
 	IostatTimeShortStr string `json:"iostat_time_short"`
+
+	// max number of goroutines to walk a mountpath's directory tree (see fs.Options.Parallel),
+	// per mountpath label (see FSPathsConf), e.g. {"nvme": 8, "hdd": 1}; the WalkWorkersDefault
+	// entry applies to the mountpaths without a (listed) label; zero or one - walk serially
+	WalkWorkers map[string]int `json:"walk_workers,omitempty" list:"readonly"`
 }
 
+// DiskConf.WalkWorkers entry for the mountpaths that are not labeled (or not listed)
+const WalkWorkersDefault = "default"
+
 type RebalanceConf struct {
 	DontRunTimeStr   string        `json:"dont_run_time"`
 	DontRunTime      time.Duration `json:"-"`
@@ -434,6 +593,12 @@ type CksumConf struct {
 
 	// EnableReadRange: Return read range checksum otherwise return entire object checksum.
 	EnableReadRange bool `json:"enable_read_range"`
+
+	// VerifiedOnce: when objects get copied or moved within the cluster (mirroring,
+	// rebalance, replication), trust the checksum that was already validated and
+	// persisted at the source and do not recompute it at the destination (which
+	// still fails the object if its content is not received in full).
+	VerifiedOnce bool `json:"verified_once"`
 }
 
 type CksumConfToUpdate struct {
@@ -442,6 +607,7 @@ type CksumConfToUpdate struct {
 	ValidateWarmGet *bool   `json:"validate_warm_get"`
 	ValidateObjMove *bool   `json:"validate_obj_move"`
 	EnableReadRange *bool   `json:"enable_read_range"`
+	VerifiedOnce    *bool   `json:"verified_once"`
 }
 
 type VersionConf struct {
@@ -450,11 +616,17 @@ type VersionConf struct {
 
 	// Validate object version upon warm GET.
 	ValidateWarmGet bool `json:"validate_warm_get"`
+
+	// The maximum number of previous versions kept per object (ais buckets):
+	// the oldest ones get removed; zero - unlimited. In addition, previous
+	// versions are the first to go when LRU evicts.
+	MaxVersions int `json:"max_versions"`
 }
 
 type VersionConfToUpdate struct {
 	Enabled         *bool `json:"enabled"`
 	ValidateWarmGet *bool `json:"validate_warm_get"`
+	MaxVersions     *int  `json:"max_versions"`
 }
 
 type TestfspathConf struct {
@@ -463,14 +635,41 @@ type TestfspathConf struct {
 	Instance int    `json:"instance"`
 }
 
+// NOTE: despite their names, the `ipv4*` lists may contain IPv6 addresses as well;
+// `ip_family*` is the address family to prefer when the respective list is empty
 type NetConf struct {
-	IPv4             string   `json:"ipv4"`
-	IPv4IntraControl string   `json:"ipv4_intra_control"`
-	IPv4IntraData    string   `json:"ipv4_intra_data"`
-	L4               L4Conf   `json:"l4"`
-	HTTP             HTTPConf `json:"http"`
-	UseIntraControl  bool     `json:"-"`
-	UseIntraData     bool     `json:"-"`
+	IPv4                 string        `json:"ipv4"`
+	IPv4IntraControl     string        `json:"ipv4_intra_control"`
+	IPv4IntraData        string        `json:"ipv4_intra_data"`
+	IPFamily             string        `json:"ip_family"`               // "ipv4" (default) or "ipv6"
+	IPFamilyIntraControl string        `json:"ip_family_intra_control"` // ditto, intra-cluster control network
+	IPFamilyIntraData    string        `json:"ip_family_intra_data"`    // ditto, intra-cluster data network
+	L4                   L4Conf        `json:"l4"`
+	HTTP                 HTTPConf      `json:"http"`
+	Listeners            ListenersConf `json:"listeners"`
+	UseIntraControl      bool          `json:"-"`
+	UseIntraData         bool          `json:"-"`
+}
+
+// ListenersConf: per-network listener settings, each overriding net.http for
+// the respective network; the intra-cluster network that is not configured
+// separately (see NetConf.UseIntraControl and UseIntraData) shares the public one
+type ListenersConf struct {
+	Public       ListenerConf `json:"public"`
+	IntraControl ListenerConf `json:"intra_control"`
+	IntraData    ListenerConf `json:"intra_data"`
+}
+
+type ListenerConf struct {
+	TLS          string       `json:"tls"`           // "" (same as net.http.use_https) | ListenerTLSOn | ListenerTLSOff
+	Certificate  string       `json:"server_crt"`    // empty - net.http.server_crt
+	Key          string       `json:"server_key"`    // empty - net.http.server_key
+	AllowedCIDRs string       `json:"allowed_cidrs"` // comma-separated client networks; empty - any
+	UseHTTPS     bool         `json:"-"`             // (runtime)
+	Proto        string       `json:"-"`             // --/--
+	CertFile     string       `json:"-"`             // --/--
+	KeyFile      string       `json:"-"`             // --/--
+	Allowed      []*net.IPNet `json:"-"`             // --/--
 }
 
 type L4Conf struct {
@@ -498,9 +697,25 @@ type HTTPConf struct {
 }
 
 type FSHCConf struct {
-	TestFileCount int  `json:"test_files"`  // the number of files to read and write during a test
-	ErrorLimit    int  `json:"error_limit"` // max number of errors (exceeding any results in disabling mpath)
-	Enabled       bool `json:"enabled"`
+	TestFileCount int              `json:"test_files"`  // the number of files to read and write during a test
+	ErrorLimit    int              `json:"error_limit"` // max number of errors (exceeding any results in disabling mpath)
+	Enabled       bool             `json:"enabled"`
+	Watchdog      FSHCWatchdogConf `json:"watchdog"`
+}
+
+// FSHCWatchdogConf: periodic mountpath health check that disables the failing
+// mountpaths and re-enables them after probation (see ais/fspathwd.go);
+// zero limit means "not checked"
+type FSHCWatchdogConf struct {
+	IntervalStr   string        `json:"interval"`       // how often to check
+	Interval      time.Duration `json:"-"`              // (runtime)
+	ErrorRate     int64         `json:"error_rate"`     // max IO errors per interval
+	MaxLatencyStr string        `json:"max_latency"`    // max average disk latency (sustained over 3 intervals)
+	MaxLatency    time.Duration `json:"-"`              // (runtime)
+	MaxDevErrors  int64         `json:"max_dev_errors"` // max device-reported IO errors per interval
+	ProbationStr  string        `json:"probation"`      // re-enable the disabled mountpath after this time
+	Probation     time.Duration `json:"-"`              // (runtime)
+	Enabled       bool          `json:"enabled"`
 }
 
 type AuthConf struct {
@@ -543,6 +758,15 @@ type DSortConf struct {
 
 type FSPathsConf struct {
 	Paths map[string]struct{} `json:"paths,omitempty"`
+	// mountpath => label (e.g., "nvme", "hdd", "scratch"); the label is the
+	// (otherwise unused) value of the mountpath in the config
+	Labels map[string]string `json:"-"`
+}
+
+// ContentRoutingConf places the content of a given type (e.g., workfiles or
+// EC metafiles) on the mountpaths with a given label (see FSPathsConf)
+type ContentRoutingConf struct {
+	Rules map[string]string `json:"rules,omitempty"` // content type => mountpath label
 }
 
 // lz4 block and frame formats: http://fastcompression.blogspot.com/2013/04/lz4-streaming-format-final.html
@@ -588,20 +812,24 @@ func (c *Config) Validate() error {
 	}, opts)
 }
 
-// ipv4ListsOverlap checks if two comma-separated ipv4 address lists
-// contain at least one common ipv4 address
+// ipv4ListsOverlap checks if two comma-separated IP address lists
+// contain at least one common address (IPv6 addresses are compared
+// in their canonical form)
 func ipv4ListsOverlap(alist, blist string) (overlap bool, addr string) {
 	if alist == "" || blist == "" {
 		return
 	}
 	alistAddrs := strings.Split(alist, ",")
+	blistAddrs := strings.Split(blist, ",")
 	for _, a := range alistAddrs {
 		a = strings.TrimSpace(a)
 		if a == "" {
 			continue
 		}
-		if strings.Contains(blist, a) {
-			return true, a
+		for _, b := range blistAddrs {
+			if b = strings.TrimSpace(b); b == a || IPsEqual(a, b) {
+				return true, a
+			}
 		}
 	}
 	return
@@ -727,6 +955,11 @@ func (c *DiskConf) Validate(_ *Config) (err error) {
 		return fmt.Errorf("disk.iostat_time_long %v shorter than disk.iostat_time_short %v",
 			c.IostatTimeLong, c.IostatTimeShort)
 	}
+	for label, n := range c.WalkWorkers {
+		if n < 0 || n > 1024 {
+			return fmt.Errorf("invalid disk.walk_workers[%q]: %d (expected 0 to 1024)", label, n)
+		}
+	}
 
 	return nil
 }
@@ -752,6 +985,27 @@ func (c *LRUConf) ValidateAsProps(args *ValidationArgs) (err error) {
 	return c.Validate(nil)
 }
 
+func (c *RemoteCacheConf) Validate(_ *Config) (err error) {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Bucket == "" {
+		return errors.New("remote_cache.bucket must be specified when remote caching is enabled")
+	}
+	if c.Capacity <= 0 {
+		return fmt.Errorf("invalid remote_cache.capacity: %d (expected >0)", c.Capacity)
+	}
+	if c.LowWM <= 0 || c.LowWM >= 100 {
+		return fmt.Errorf("invalid remote_cache.lowwm: %d (expected value in range (0, 100))", c.LowWM)
+	}
+	return nil
+}
+
+// IsCacheBck returns true if the bucket is the (enabled) remote cache bucket.
+func (c *RemoteCacheConf) IsCacheBck(bck Bck) bool {
+	return c.Enabled && bck.IsAIS() && bck.Name == c.Bucket
+}
+
 func (c *CksumConf) Validate(_ *Config) (err error) {
 	return ValidateCksumType(c.Type)
 }
@@ -764,6 +1018,9 @@ func (c *VersionConf) Validate(_ *Config) error {
 	if !c.Enabled && c.ValidateWarmGet {
 		return errors.New("versioning.validate_warm_get requires versioning to be enabled")
 	}
+	if c.MaxVersions < 0 {
+		return fmt.Errorf("invalid versioning.max_versions: %d (expected >=0)", c.MaxVersions)
+	}
 	return nil
 }
 func (c *VersionConf) ValidateAsProps() error { return c.Validate(nil) }
@@ -807,6 +1064,40 @@ func (c *ECConf) Validate(_ *Config) error {
 	if c.BatchSize < 4 || c.BatchSize > 128 {
 		return fmt.Errorf("invalid ec.batch_size: %d (must be in the range 4..128)", c.ObjSizeLimit)
 	}
+	if c.Placement != "" && c.Placement != ECPlacementHRW && c.Placement != ECPlacementDomain {
+		return fmt.Errorf("invalid ec.placement: %q (expected %q or %q)", c.Placement, ECPlacementHRW, ECPlacementDomain)
+	}
+	if c.SliceCacheSize < 0 {
+		return fmt.Errorf("invalid ec.slice_cache_size: %d (expected >=0)", c.SliceCacheSize)
+	}
+	if c.PackSize < 0 || (c.PackSize > 0 && c.PackSize <= c.ObjSizeLimit) {
+		return fmt.Errorf("invalid ec.pack_size: %d (expected 0 or greater than ec.objsize_limit %d)",
+			c.PackSize, c.ObjSizeLimit)
+	}
+	if c.MetaQuorum == "" {
+		c.MetaQuorum = ECQuorumMajority
+	}
+	if c.MetaQuorum != ECQuorumMajority && c.MetaQuorum != ECQuorumStrict && c.MetaQuorum != ECQuorumNewest {
+		return fmt.Errorf("invalid ec.meta_quorum: %q (expected %q, %q, or %q)",
+			c.MetaQuorum, ECQuorumMajority, ECQuorumStrict, ECQuorumNewest)
+	}
+	c.UndeleteWindow = 0
+	if c.UndeleteWindowStr != "" {
+		window, err := time.ParseDuration(c.UndeleteWindowStr)
+		if err != nil {
+			return fmt.Errorf("invalid ec.undelete_window format %s, err %v", c.UndeleteWindowStr, err)
+		}
+		if window < 0 {
+			return fmt.Errorf("invalid ec.undelete_window: %v (expected >=0)", window)
+		}
+		c.UndeleteWindow = window
+	}
+	if c.RestoreMemLimit < 0 {
+		return fmt.Errorf("invalid ec.restore_mem_limit: %d (expected >=0)", c.RestoreMemLimit)
+	}
+	if c.SliceFanout < 0 {
+		return fmt.Errorf("invalid ec.slice_fanout: %d (expected >=0)", c.SliceFanout)
+	}
 	return nil
 }
 
@@ -829,6 +1120,16 @@ func (c *ECConf) ValidateAsProps(args *ValidationArgs) error {
 	return nil
 }
 
+func (c *ProxyConf) Validate(config *Config) error {
+	if c.MassDeleteThreshold < 0 {
+		return fmt.Errorf("invalid proxy.mass_delete_threshold: %d (expected >=0)", c.MassDeleteThreshold)
+	}
+	if c.JoinTokens && config.Auth.Secret == "" {
+		return errors.New("invalid proxy.join_tokens: join tokens require auth.secret to be set")
+	}
+	return nil
+}
+
 func (c *TimeoutConf) Validate(_ *Config) (err error) {
 	if c.MaxKeepalive, err = time.ParseDuration(c.MaxKeepaliveStr); err != nil {
 		return fmt.Errorf("invalid timeout.max_keepalive format %s, err %v", c.MaxKeepaliveStr, err)
@@ -848,6 +1149,163 @@ func (c *TimeoutConf) Validate(_ *Config) (err error) {
 	return nil
 }
 
+func (c *ClockConf) Validate(_ *Config) (err error) {
+	c.SkewTolerance, c.MaxSkew = 0, 0
+	if c.SkewToleranceStr != "" {
+		if c.SkewTolerance, err = time.ParseDuration(c.SkewToleranceStr); err != nil || c.SkewTolerance < 0 {
+			return fmt.Errorf("invalid clock.skew_tolerance: %q", c.SkewToleranceStr)
+		}
+	}
+	if c.MaxSkewStr != "" {
+		if c.MaxSkew, err = time.ParseDuration(c.MaxSkewStr); err != nil || c.MaxSkew < 0 {
+			return fmt.Errorf("invalid clock.max_skew: %q", c.MaxSkewStr)
+		}
+	}
+	return nil
+}
+
+func (c *TxnConf) Validate(_ *Config) (err error) {
+	for action, tc := range c.Timeouts {
+		for _, d := range []struct {
+			s    string
+			v    *time.Duration
+			name string
+		}{{tc.BeginStr, &tc.Begin, "begin"}, {tc.CommitStr, &tc.Commit, "commit"}, {tc.WaitStr, &tc.Wait, "wait"}} {
+			if d.s == "" {
+				continue
+			}
+			if *d.v, err = time.ParseDuration(d.s); err != nil || *d.v < 0 {
+				return fmt.Errorf("invalid txn.timeouts[%s].%s: %q", action, d.name, d.s)
+			}
+		}
+		c.Timeouts[action] = tc
+	}
+	if c.CommitRetries < 0 {
+		return fmt.Errorf("invalid txn.commit_retries: %d (expected >=0)", c.CommitRetries)
+	}
+	c.CommitBackoff = 0
+	if c.CommitBackoffStr != "" {
+		if c.CommitBackoff, err = time.ParseDuration(c.CommitBackoffStr); err != nil {
+			return fmt.Errorf("invalid txn.commit_backoff format %s, err %v", c.CommitBackoffStr, err)
+		}
+	}
+	return nil
+}
+
+func (c *XactHistoryConf) Validate(_ *Config) (err error) {
+	c.Retention = 0
+	if c.RetentionStr != "" {
+		if c.Retention, err = time.ParseDuration(c.RetentionStr); err != nil || c.Retention < 0 {
+			return fmt.Errorf("invalid xaction_history.retention: %q", c.RetentionStr)
+		}
+	}
+	if c.MaxEntries < 0 {
+		return fmt.Errorf("invalid xaction_history.max_entries: %d (expected >=0)", c.MaxEntries)
+	}
+	return nil
+}
+
+func (c *XactBudgetConf) Validate(_ *Config) (err error) {
+	c.DiskBW = 0
+	if c.DiskBWStr != "" {
+		if c.DiskBW, err = S2B(c.DiskBWStr); err != nil || c.DiskBW < 0 {
+			return fmt.Errorf("invalid xaction_budget.disk_bw: %q", c.DiskBWStr)
+		}
+	}
+	if c.DiskBW > 0 && (c.Share <= 0 || c.Share > 100) {
+		return fmt.Errorf("invalid xaction_budget.share: %d (expected (0, 100])", c.Share)
+	}
+	for kind, weight := range c.Weights {
+		if weight <= 0 {
+			return fmt.Errorf("invalid xaction_budget.weights: %s=%d (expected >0)", kind, weight)
+		}
+	}
+	return nil
+}
+
+func (c *XactBudgetConf) weight(kind string) int64 {
+	if weight, ok := c.Weights[kind]; ok {
+		return int64(weight)
+	}
+	return 1
+}
+
+func (c *EventsConf) Validate(_ *Config) error {
+	names := make(StringSet, len(c.Sinks))
+	for _, sink := range c.Sinks {
+		if sink.Name == "" {
+			return fmt.Errorf("invalid events.sinks: missing sink name")
+		}
+		if names.Contains(sink.Name) {
+			return fmt.Errorf("invalid events.sinks: duplicate sink name %q", sink.Name)
+		}
+		names.Add(sink.Name)
+		switch sink.Type {
+		case EventSinkWebhook:
+			if !strings.HasPrefix(sink.URL, "http://") && !strings.HasPrefix(sink.URL, "https://") {
+				return fmt.Errorf("invalid events.sinks[%s].url: %q (expected http(s) URL)", sink.Name, sink.URL)
+			}
+		case EventSinkNATS:
+			if sink.URL == "" {
+				return fmt.Errorf("invalid events.sinks[%s]: missing url", sink.Name)
+			}
+			if sink.Subject == "" {
+				return fmt.Errorf("invalid events.sinks[%s]: missing subject", sink.Name)
+			}
+		default:
+			return fmt.Errorf("invalid events.sinks[%s].type: %q (expected %q or %q)",
+				sink.Name, sink.Type, EventSinkWebhook, EventSinkNATS)
+		}
+	}
+	return nil
+}
+
+func (c *FSHCConf) Validate(_ *Config) (err error) {
+	wd := &c.Watchdog
+	if !wd.Enabled {
+		return nil
+	}
+	if wd.Interval, err = time.ParseDuration(wd.IntervalStr); err != nil || wd.Interval <= 0 {
+		return fmt.Errorf("invalid fshc.watchdog.interval: %q", wd.IntervalStr)
+	}
+	if wd.Probation, err = time.ParseDuration(wd.ProbationStr); err != nil || wd.Probation < wd.Interval {
+		return fmt.Errorf("invalid fshc.watchdog.probation: %q (expected duration >= interval)", wd.ProbationStr)
+	}
+	if wd.MaxLatencyStr != "" {
+		if wd.MaxLatency, err = time.ParseDuration(wd.MaxLatencyStr); err != nil || wd.MaxLatency < 0 {
+			return fmt.Errorf("invalid fshc.watchdog.max_latency: %q", wd.MaxLatencyStr)
+		}
+	}
+	if wd.ErrorRate < 0 || wd.MaxDevErrors < 0 {
+		return fmt.Errorf("invalid fshc.watchdog (error_rate, max_dev_errors): (%d, %d)", wd.ErrorRate, wd.MaxDevErrors)
+	}
+	return nil
+}
+
+func (c *PromoteConf) Validate(_ *Config) error {
+	dirs := make(StringSet, len(c.Watch))
+	for i := range c.Watch {
+		watch := &c.Watch[i]
+		if !filepath.IsAbs(watch.Dir) {
+			return fmt.Errorf("invalid promote.watch.dir: %q (expected absolute path)", watch.Dir)
+		}
+		watch.Dir = filepath.Clean(watch.Dir)
+		if dirs.Contains(watch.Dir) {
+			return fmt.Errorf("invalid promote.watch: duplicate dir %q", watch.Dir)
+		}
+		dirs.Add(watch.Dir)
+		if err := ValidateBckName(watch.Bck.Name); err != nil {
+			return fmt.Errorf("invalid promote.watch[%s].bucket: %v", watch.Dir, err)
+		}
+		if watch.Bck.Provider == "" {
+			watch.Bck.Provider = ProviderAIS
+		} else if !IsValidProvider(watch.Bck.Provider) {
+			return fmt.Errorf("invalid promote.watch[%s].bucket: unknown provider %q", watch.Dir, watch.Bck.Provider)
+		}
+	}
+	return nil
+}
+
 func (c *ClientConf) Validate(_ *Config) (err error) {
 	if c.Timeout, err = time.ParseDuration(c.TimeoutStr); err != nil {
 		return fmt.Errorf("invalid client.default format %s, err %v", c.TimeoutStr, err)
@@ -883,6 +1341,33 @@ func (c *PeriodConf) Validate(_ *Config) (err error) {
 	if c.RetrySyncTime, err = time.ParseDuration(c.RetrySyncTimeStr); err != nil {
 		return fmt.Errorf("invalid periodic.retry_sync_time format %s, err %v", c.RetrySyncTimeStr, err)
 	}
+	c.WorkfileGCAge = 0
+	if c.WorkfileGCAgeStr != "" {
+		if c.WorkfileGCAge, err = time.ParseDuration(c.WorkfileGCAgeStr); err != nil {
+			return fmt.Errorf("invalid periodic.workfile_gc_age format %s, err %v", c.WorkfileGCAgeStr, err)
+		}
+		if c.WorkfileGCAge < 0 {
+			return fmt.Errorf("invalid periodic.workfile_gc_age: %v (expected >=0)", c.WorkfileGCAge)
+		}
+	}
+	c.EmptyDirGCAge = 0
+	if c.EmptyDirGCAgeStr != "" {
+		if c.EmptyDirGCAge, err = time.ParseDuration(c.EmptyDirGCAgeStr); err != nil {
+			return fmt.Errorf("invalid periodic.empty_dir_gc_age format %s, err %v", c.EmptyDirGCAgeStr, err)
+		}
+		if c.EmptyDirGCAge < 0 {
+			return fmt.Errorf("invalid periodic.empty_dir_gc_age: %v (expected >=0)", c.EmptyDirGCAge)
+		}
+	}
+	c.NotifTime = 0
+	if c.NotifTimeStr != "" {
+		if c.NotifTime, err = time.ParseDuration(c.NotifTimeStr); err != nil {
+			return fmt.Errorf("invalid periodic.notif_time format %s, err %v", c.NotifTimeStr, err)
+		}
+		if c.NotifTime < 0 {
+			return fmt.Errorf("invalid periodic.notif_time: %v (expected >=0)", c.NotifTime)
+		}
+	}
 	return nil
 }
 
@@ -907,10 +1392,16 @@ func (c *NetConf) Validate(_ *Config) (err error) {
 		return fmt.Errorf("l4 proto is not recognized %s, expected one of: %s", c.L4.Proto, supportedL4Protos)
 	}
 
-	c.HTTP.Proto = httpProto // not validating: read-only, and can take only two values
-	if c.HTTP.UseHTTPS {
-		c.HTTP.Proto = httpsProto
+	for name, lc := range map[string]*ListenerConf{
+		NetworkPublic:       &c.Listeners.Public,
+		NetworkIntraControl: &c.Listeners.IntraControl,
+		NetworkIntraData:    &c.Listeners.IntraData,
+	} {
+		if err = lc.validate(&c.HTTP); err != nil {
+			return fmt.Errorf("invalid net.listeners.%s: %v", name, err)
+		}
 	}
+	c.HTTP.Proto = c.Listeners.Public.Proto // not validating: read-only, and can take only two values
 
 	// Parse ports
 	if c.L4.Port, err = ParsePort(c.L4.PortStr); err != nil {
@@ -931,17 +1422,23 @@ func (c *NetConf) Validate(_ *Config) (err error) {
 	c.IPv4IntraControl = strings.ReplaceAll(c.IPv4IntraControl, " ", "")
 	c.IPv4IntraData = strings.ReplaceAll(c.IPv4IntraData, " ", "")
 
+	for _, family := range []string{c.IPFamily, c.IPFamilyIntraControl, c.IPFamilyIntraData} {
+		if family != "" && family != IPFamilyIPv4 && family != IPFamilyIPv6 {
+			return fmt.Errorf("invalid ip_family %q (expecting: ''|%s|%s)", family, IPFamilyIPv4, IPFamilyIPv6)
+		}
+	}
+
 	if overlap, addr := ipv4ListsOverlap(c.IPv4, c.IPv4IntraControl); overlap {
-		return fmt.Errorf("public (%s) and intra-cluster control (%s) IPv4 lists overlap: %s", c.IPv4, c.IPv4IntraControl, addr)
+		return fmt.Errorf("public (%s) and intra-cluster control (%s) IP lists overlap: %s", c.IPv4, c.IPv4IntraControl, addr)
 	}
 	if overlap, addr := ipv4ListsOverlap(c.IPv4, c.IPv4IntraData); overlap {
-		return fmt.Errorf("public (%s) and intra-cluster data (%s) IPv4 lists overlap: %s", c.IPv4, c.IPv4IntraData, addr)
+		return fmt.Errorf("public (%s) and intra-cluster data (%s) IP lists overlap: %s", c.IPv4, c.IPv4IntraData, addr)
 	}
 	if overlap, addr := ipv4ListsOverlap(c.IPv4IntraControl, c.IPv4IntraData); overlap {
 		if ipv4ListsEqual(c.IPv4IntraControl, c.IPv4IntraData) {
 			glog.Warningf("control and data share one intra-cluster network (%s)", c.IPv4IntraData)
 		} else {
-			glog.Warningf("intra-cluster control (%s) and data (%s) IPv4 lists overlap: %s",
+			glog.Warningf("intra-cluster control (%s) and data (%s) IP lists overlap: %s",
 				c.IPv4IntraControl, c.IPv4IntraData, addr)
 		}
 	}
@@ -957,6 +1454,87 @@ func (c *NetConf) Validate(_ *Config) (err error) {
 	return nil
 }
 
+// Listener returns the listener settings of a given network (see ListenersConf)
+func (c *NetConf) Listener(network string) *ListenerConf {
+	switch network {
+	case NetworkIntraControl:
+		if c.UseIntraControl {
+			return &c.Listeners.IntraControl
+		}
+	case NetworkIntraData:
+		if c.UseIntraData {
+			return &c.Listeners.IntraData
+		}
+	}
+	return &c.Listeners.Public
+}
+
+// AnyHTTPS returns true if at least one of the listeners in use is HTTPS
+func (c *NetConf) AnyHTTPS() bool {
+	for _, network := range KnownNetworks {
+		if c.Listener(network).UseHTTPS {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ListenerConf) validate(http *HTTPConf) error {
+	switch c.TLS {
+	case "":
+		c.UseHTTPS = http.UseHTTPS
+	case ListenerTLSOn:
+		c.UseHTTPS = true
+	case ListenerTLSOff:
+		c.UseHTTPS = false
+	default:
+		return fmt.Errorf("tls %q (expecting: ''|%s|%s)", c.TLS, ListenerTLSOn, ListenerTLSOff)
+	}
+	c.Proto, c.CertFile, c.KeyFile = httpProto, "", ""
+	if c.UseHTTPS {
+		c.Proto, c.CertFile, c.KeyFile = httpsProto, c.Certificate, c.Key
+		if c.CertFile == "" {
+			c.CertFile = http.Certificate
+		}
+		if c.KeyFile == "" {
+			c.KeyFile = http.Key
+		}
+	}
+	c.Allowed = nil // (not reusing - shared by the config clones)
+	for _, cidr := range strings.Split(c.AllowedCIDRs, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr == "" {
+			continue
+		}
+		_, ipnet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return fmt.Errorf("allowed_cidrs: %v", err)
+		}
+		c.Allowed = append(c.Allowed, ipnet)
+	}
+	return nil
+}
+
+// IsAllowed returns true if the listener accepts requests from a given remote address (host:port)
+func (c *ListenerConf) IsAllowed(remoteAddr string) bool {
+	if len(c.Allowed) == 0 {
+		return true
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+	for _, ipnet := range c.Allowed {
+		if ipnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *DownloaderConf) Validate(_ *Config) (err error) {
 	if c.Timeout, err = time.ParseDuration(c.TimeoutStr); err != nil {
 		return fmt.Errorf("invalid downloader.timeout %s", c.TimeoutStr)
@@ -1013,8 +1591,12 @@ func (c *FSPathsConf) UnmarshalJSON(data []byte) error {
 	}
 
 	c.Paths = make(map[string]struct{})
-	for k := range m {
+	c.Labels = make(map[string]string)
+	for k, v := range m {
 		c.Paths[k] = struct{}{}
+		if label := strings.TrimSpace(v); label != "" {
+			c.Labels[k] = label
+		}
 	}
 
 	return nil
@@ -1024,12 +1606,25 @@ func (c *FSPathsConf) MarshalJSON() (data []byte, err error) {
 	m := make(map[string]string)
 
 	for k := range c.Paths {
-		m[k] = " "
+		if label, ok := c.Labels[k]; ok {
+			m[k] = label
+		} else {
+			m[k] = " "
+		}
 	}
 
 	return MustMarshal(m), nil
 }
 
+func (c *FSPathsConf) hasLabel(label string) bool {
+	for _, l := range c.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
 func (c *FSPathsConf) Validate(contextConfig *Config) (err error) {
 	// Don't validate if testing environment
 	if contextConfig.TestingEnv() {
@@ -1040,17 +1635,37 @@ func (c *FSPathsConf) Validate(contextConfig *Config) (err error) {
 		return fmt.Errorf("expected at least one mountpath in fspaths config")
 	}
 
-	cleanMpaths := make(map[string]struct{})
-
+	var (
+		cleanMpaths = make(map[string]struct{})
+		cleanLabels = make(map[string]string, len(c.Labels))
+	)
 	for k := range c.Paths {
 		cleanMpath, err := ValidateMpath(k)
 		if err != nil {
 			return err
 		}
 		cleanMpaths[cleanMpath] = struct{}{}
+		if label, ok := c.Labels[k]; ok {
+			cleanLabels[cleanMpath] = label
+		}
 	}
 
 	c.Paths = cleanMpaths
+	c.Labels = cleanLabels
+	return nil
+}
+
+func (c *ContentRoutingConf) Validate(contextConfig *Config) (err error) {
+	for contentType, label := range c.Rules {
+		if contentType == "" || label == "" {
+			return fmt.Errorf("invalid content_routing rule %q => %q", contentType, label)
+		}
+		// testing environment does not label mountpaths
+		if !contextConfig.TestingEnv() && !contextConfig.FSpaths.hasLabel(label) {
+			return fmt.Errorf("invalid content_routing rule %q => %q: no fspaths labeled %q",
+				contentType, label, label)
+		}
+	}
 	return nil
 }
 
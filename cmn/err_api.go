@@ -32,6 +32,13 @@ type (
 		oos    bool
 	}
 
+	ErrorQuotaExceeded struct {
+		bck   Bck
+		what  string
+		value int64
+		limit int64
+	}
+
 	BucketAccessDenied struct{ errAccessDenied }
 	ObjectAccessDenied struct{ errAccessDenied }
 	errAccessDenied    struct {
@@ -135,6 +142,14 @@ func (e *ErrorCapacityExceeded) Error() string {
 	return fmt.Sprintf("%s: used capacity %d%% exceeded high watermark %d%%", e.prefix, e.used, e.high)
 }
 
+func NewErrorQuotaExceeded(bck Bck, what string, value, limit int64) *ErrorQuotaExceeded {
+	return &ErrorQuotaExceeded{bck: bck, what: what, value: value, limit: limit}
+}
+
+func (e *ErrorQuotaExceeded) Error() string {
+	return fmt.Sprintf("bucket %s: quota exceeded (%s %d > %d)", e.bck, e.what, e.value, e.limit)
+}
+
 func (e InvalidCksumError) Error() string {
 	return fmt.Sprintf("checksum: expected [%s], actual [%s]", e.expectedHash, e.actualHash)
 }
@@ -0,0 +1,177 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+/////////////////////////////////////////////////////////////////////////
+// error codes - machine-readable classification of the API errors    //
+// (see HTTPError) so that clients don't need to match error messages //
+/////////////////////////////////////////////////////////////////////////
+
+const (
+	// generic, derived from HTTP status
+	ErrCodeInvalidRequest      = "InvalidRequest"
+	ErrCodeUnauthorized        = "Unauthorized"
+	ErrCodeAccessDenied        = "AccessDenied"
+	ErrCodeNotFound            = "NotFound"
+	ErrCodeMethodNotAllowed    = "MethodNotAllowed"
+	ErrCodeConflict            = "Conflict"
+	ErrCodePreconditionFailed  = "PreconditionFailed"
+	ErrCodeInvalidRange        = "InvalidRange"
+	ErrCodeTooManyRequests     = "TooManyRequests"
+	ErrCodeInternal            = "InternalError"
+	ErrCodeNotImplemented      = "NotImplemented"
+	ErrCodeBadGateway          = "BadGateway"
+	ErrCodeUnavailable         = "ServiceUnavailable"
+	ErrCodeTimeout             = "Timeout"
+	ErrCodeInsufficientStorage = "InsufficientStorage"
+
+	// specific, derived from error type
+	ErrCodeBucketNotFound      = "BucketNotFound"
+	ErrCodeBucketAlreadyExists = "BucketAlreadyExists"
+	ErrCodeBucketOffline       = "BucketOffline"
+	ErrCodeBucketBusy          = "BucketBusy"
+	ErrCodeObjectNotFound      = "ObjectNotFound"
+	ErrCodeCapacityExceeded    = "CapacityExceeded"
+	ErrCodeChecksumMismatch    = "ChecksumMismatch"
+	ErrCodeMountpathNotFound   = "MountpathNotFound"
+	ErrCodeInvalidMountpath    = "InvalidMountpath"
+	ErrCodeXactionNotFound     = "XactionNotFound"
+	ErrCodeAborted             = "Aborted"
+	ErrCodeBucketAccessDenied  = "BucketAccessDenied"
+	ErrCodeObjectAccessDenied  = "ObjectAccessDenied"
+)
+
+var retryableCodes = map[string]bool{
+	ErrCodeTooManyRequests: true,
+	ErrCodeBadGateway:      true,
+	ErrCodeUnavailable:     true,
+	ErrCodeTimeout:         true,
+	ErrCodeBucketOffline:   true,
+	ErrCodeBucketBusy:      true,
+}
+
+// IsRetryableCode returns true if the request that failed with a given error
+// code may succeed if retried (as is, after a while)
+func IsRetryableCode(code string) bool { return retryableCodes[code] }
+
+// ErrCodeFromStatus returns the generic error code for a given HTTP status
+func ErrCodeFromStatus(status int) string {
+	switch status {
+	case http.StatusUnauthorized:
+		return ErrCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrCodeAccessDenied
+	case http.StatusNotFound, http.StatusGone:
+		return ErrCodeNotFound
+	case http.StatusMethodNotAllowed:
+		return ErrCodeMethodNotAllowed
+	case http.StatusConflict:
+		return ErrCodeConflict
+	case http.StatusPreconditionFailed:
+		return ErrCodePreconditionFailed
+	case http.StatusRequestedRangeNotSatisfiable:
+		return ErrCodeInvalidRange
+	case http.StatusTooManyRequests:
+		return ErrCodeTooManyRequests
+	case http.StatusNotImplemented:
+		return ErrCodeNotImplemented
+	case http.StatusBadGateway:
+		return ErrCodeBadGateway
+	case http.StatusServiceUnavailable:
+		return ErrCodeUnavailable
+	case http.StatusGatewayTimeout, http.StatusRequestTimeout:
+		return ErrCodeTimeout
+	case http.StatusInsufficientStorage:
+		return ErrCodeInsufficientStorage
+	}
+	if status >= http.StatusInternalServerError {
+		return ErrCodeInternal
+	}
+	return ErrCodeInvalidRequest
+}
+
+// ErrCode returns the error code for a given error: specific, if the type of
+// the error is known, generic (as per HTTP status) otherwise
+func ErrCode(err error, status int) string {
+	switch e := err.(type) {
+	case *HTTPError:
+		if e.Code != "" {
+			return e.Code
+		}
+		return ErrCodeFromStatus(e.Status)
+	case *ErrorBucketDoesNotExist, *ErrorRemoteBucketDoesNotExist:
+		return ErrCodeBucketNotFound
+	case *ErrorBucketAlreadyExists:
+		return ErrCodeBucketAlreadyExists
+	case *ErrorCloudBucketOffline:
+		return ErrCodeBucketOffline
+	case *ErrorBucketIsBusy:
+		return ErrCodeBucketBusy
+	case *ErrorCapacityExceeded:
+		return ErrCodeCapacityExceeded
+	case *BucketAccessDenied:
+		return ErrCodeBucketAccessDenied
+	case *ObjectAccessDenied:
+		return ErrCodeObjectAccessDenied
+	case InvalidCksumError, *InvalidCksumError:
+		return ErrCodeChecksumMismatch
+	case NoMountpathError:
+		return ErrCodeMountpathNotFound
+	case InvalidMountpathError:
+		return ErrCodeInvalidMountpath
+	case XactionNotFoundError:
+		return ErrCodeXactionNotFound
+	}
+	if IsErrObjNought(err) {
+		return ErrCodeObjectNotFound
+	}
+	if aborted := (AbortedError{}); errors.As(err, &aborted) {
+		return ErrCodeAborted
+	}
+	return ErrCodeFromStatus(status)
+}
+
+// returns the bucket or object (if any) that a given API request refers to,
+// e.g. "ais://abc/obj" for "/v1/objects/abc/obj?provider=ais"
+func reqResource(r *http.Request) string {
+	if r == nil || r.URL == nil {
+		return ""
+	}
+	items := RESTItems(r.URL.Path)
+	if len(items) < 3 || items[0] != Version || (items[1] != Buckets && items[1] != Objects) {
+		return ""
+	}
+	if items[2] == AllBuckets {
+		return ""
+	}
+	bck := Bck{Name: items[2]}
+	if query, err := url.ParseQuery(r.URL.RawQuery); err == nil {
+		bck.Provider = query.Get(URLParamProvider)
+		bck.Ns = ParseNsUname(query.Get(URLParamNamespace))
+	}
+	if items[1] == Objects && len(items) > 3 {
+		return bck.String() + "/" + strings.Join(items[3:], "/")
+	}
+	return bck.String()
+}
+
+// IsErrCode returns true if a given API error carries a given error code
+func IsErrCode(err error, code string) bool {
+	httpErr := &HTTPError{}
+	return errors.As(err, &httpErr) && httpErr.Code == code
+}
+
+// IsErrRetryable returns true if the failed API request may be retried as is
+func IsErrRetryable(err error) bool {
+	httpErr := &HTTPError{}
+	return errors.As(err, &httpErr) && httpErr.Retryable
+}
@@ -36,11 +36,13 @@ var (
 )
 
 const (
-	HeaderRange         = "Range"
-	HeaderContentRange  = "Content-Range"
-	HeaderAcceptRanges  = "Accept-Ranges"
-	HeaderContentType   = "Content-Type"
-	HeaderContentLength = "Content-Length"
+	HeaderRange              = "Range"
+	HeaderContentRange       = "Content-Range"
+	HeaderAcceptRanges       = "Accept-Ranges"
+	HeaderContentType        = "Content-Type"
+	HeaderContentLength      = "Content-Length"
+	HeaderCacheControl       = "Cache-Control"
+	HeaderContentDisposition = "Content-Disposition"
 )
 
 type (
@@ -57,11 +59,14 @@ type (
 	// Error structure for HTTP errors
 	HTTPError struct {
 		Status     int    `json:"status"`
+		Code       string `json:"code,omitempty"` // see ErrCode
 		Message    string `json:"message"`
 		Method     string `json:"method"`
 		URLPath    string `json:"url_path"`
 		RemoteAddr string `json:"remote_addr"`
 		Trace      string `json:"trace"`
+		Resource   string `json:"resource,omitempty"` // bucket or object the request refers to
+		Retryable  bool   `json:"retryable,omitempty"`
 	}
 
 	// ReqArgs specifies http request that we want to send
@@ -104,9 +109,32 @@ func (e *HTTPError) Error() string {
 func NewHTTPError(r *http.Request, msg string, status int) (*HTTPError, bool) {
 	var httpErr HTTPError
 	if err := jsoniter.UnmarshalFromString(msg, &httpErr); err == nil {
+		httpErr.SetCode(ErrCodeFromStatus(httpErr.Status))
 		return &httpErr, true
 	}
-	return &HTTPError{Status: status, Message: msg, Method: r.Method, URLPath: r.URL.Path, RemoteAddr: r.RemoteAddr}, false
+	return newHTTPError(r, msg, ErrCodeFromStatus(status), status), false
+}
+
+func newHTTPError(r *http.Request, msg, code string, status int) *HTTPError {
+	httpErr := &HTTPError{
+		Status:     status,
+		Message:    msg,
+		Method:     r.Method,
+		URLPath:    r.URL.Path,
+		RemoteAddr: r.RemoteAddr,
+		Resource:   reqResource(r),
+	}
+	httpErr.SetCode(code)
+	return httpErr
+}
+
+// SetCode sets the error code unless already set (e.g., by the node that
+// has originally failed the request) and, accordingly, the retryable flag.
+func (e *HTTPError) SetCode(code string) {
+	if e.Code == "" {
+		e.Code = code
+	}
+	e.Retryable = IsRetryableCode(e.Code)
 }
 
 // URLPath returns a HTTP URL path by joining all segments with "/"
@@ -195,8 +223,16 @@ func InvalidHandlerWithMsg(w http.ResponseWriter, r *http.Request, msg string, e
 	writeError(w, err, status)
 }
 
-func invalidHandlerInternal(w http.ResponseWriter, r *http.Request, msg string, status int, silent bool) {
-	err, isHTTPError := NewHTTPError(r, msg, status)
+func invalidHandlerInternal(w http.ResponseWriter, r *http.Request, msg, code string, status int, silent bool) {
+	var (
+		err         *HTTPError
+		isHTTPError bool
+	)
+	if code == "" {
+		err, isHTTPError = NewHTTPError(r, msg, status)
+	} else {
+		err = newHTTPError(r, msg, code, status)
+	}
 
 	if silent {
 		writeError(w, err, status)
@@ -241,7 +277,17 @@ func InvalidHandlerDetailed(w http.ResponseWriter, r *http.Request, msg string,
 		status = errCode[0]
 	}
 
-	invalidHandlerInternal(w, r, msg, status, false /*silent*/)
+	invalidHandlerInternal(w, r, msg, "", status, false /*silent*/)
+}
+
+// InvalidHandlerCode is InvalidHandlerDetailed with a given error code (see ErrCode).
+func InvalidHandlerCode(w http.ResponseWriter, r *http.Request, msg, code string, errCode ...int) {
+	status := http.StatusBadRequest
+	if len(errCode) > 0 && errCode[0] >= http.StatusBadRequest {
+		status = errCode[0]
+	}
+
+	invalidHandlerInternal(w, r, msg, code, status, false /*silent*/)
 }
 
 // InvalidHandlerDetailedNoLog writes detailed error (includes line and file) to response writer. It does not log any error
@@ -251,7 +297,7 @@ func InvalidHandlerDetailedNoLog(w http.ResponseWriter, r *http.Request, msg str
 		status = errCode[0]
 	}
 
-	invalidHandlerInternal(w, r, msg, status, true /*silent*/)
+	invalidHandlerInternal(w, r, msg, "", status, true /*silent*/)
 }
 
 func ReadBytes(r *http.Request) (b []byte, err error) {
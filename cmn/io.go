@@ -7,12 +7,14 @@ package cmn
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"path/filepath"
 	"strconv"
+	"syscall"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -305,20 +307,56 @@ func CreateFile(fname string) (*os.File, error) {
 
 // Rename renames file ensuring that the parent's directory of dst exists. Creates
 // destination directory when it does not exist.
-// NOTE: Rename should not be used to move objects across different disks, see: fs.MvFile.
-func Rename(src, dst string) error {
-	if err := os.Rename(src, dst); err != nil {
-		if !os.IsNotExist(err) {
-			return err
+// NOTE: Rename should not be used to move objects across different disks - across
+// filesystems, it falls back to (slow) copying, see renameXdev.
+func Rename(src, dst string) (err error) {
+	if err = os.Rename(src, dst); err == nil {
+		return
+	}
+	if os.IsNotExist(err) {
+		// Retry with created directory - slow path.
+		if err = CreateDir(filepath.Dir(dst)); err != nil {
+			return
 		}
+		err = os.Rename(src, dst)
+	}
+	// different filesystems (e.g., a workfile routed to another mountpath)
+	if err != nil && errors.Is(err, syscall.EXDEV) {
+		err = renameXdev(src, dst)
+	}
+	return
+}
 
-		// Retry with created directory - slow path.
-		if err := CreateDir(filepath.Dir(dst)); err != nil {
-			return err
+// renameXdev copies the source into a temporary file next to the destination
+// (that is, on the destination's filesystem) and renames it - so that the
+// destination is replaced atomically and never gets observed partially written
+func renameXdev(src, dst string) (err error) {
+	tmp := dst + ".xdev." + GenTie()
+	if _, _, err = CopyFile(src, tmp, nil, ChecksumNone); err == nil {
+		err = fsyncFile(tmp)
+	}
+	if err == nil {
+		err = os.Rename(tmp, dst)
+	}
+	if err != nil {
+		if errRm := RemoveFile(tmp); errRm != nil {
+			glog.Errorf("Failed to remove %s: %v", tmp, errRm)
 		}
-		return os.Rename(src, dst)
+		return
 	}
-	return nil
+	return RemoveFile(src)
+}
+
+func fsyncFile(fqn string) error {
+	file, err := os.OpenFile(fqn, os.O_RDWR, 0)
+	if err != nil {
+		return err
+	}
+	err = file.Sync()
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	return err
 }
 
 // RemoveFile removes object from path and ignores if the path no longer exists.
@@ -329,20 +367,31 @@ func RemoveFile(path string) error {
 	return nil
 }
 
-// and computes checksum if requested
+// and computes checksum if requested; holes of a sparse source file are preserved
+// regardless of the allocated size the filesystem reports (speculative preallocation
+// and such - see IsSparse)
 func CopyFile(src, dst string, buf []byte, cksumType string) (written int64, cksum *CksumHash, err error) {
 	var (
 		srcFile, dstFile *os.File
+		fi               os.FileInfo
 	)
 	if srcFile, err = os.Open(src); err != nil {
 		return
 	}
+	if fi, err = srcFile.Stat(); err != nil {
+		debug.AssertNoErr(srcFile.Close())
+		return
+	}
 	if dstFile, err = CreateFile(dst); err != nil {
 		glog.Errorf("Failed to create %s: %v", dst, err)
 		debug.AssertNoErr(srcFile.Close())
 		return
 	}
-	written, cksum, err = CopyAndChecksum(dstFile, srcFile, buf, cksumType)
+	sw := NewSparseWriter(dstFile)
+	written, cksum, err = copySparse(sw, srcFile, fi.Size(), buf, cksumType)
+	if err == nil {
+		err = sw.Finalize()
+	}
 	if err != nil {
 		glog.Errorf("Failed to copy %s -> %s: %v", src, dst, err)
 	}
@@ -0,0 +1,54 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// across filesystems, the destination gets replaced as a whole (see renameXdev)
+func TestRenameXdev(t *testing.T) {
+	dir, err := ioutil.TempDir("", "rename-xdev")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	var (
+		src = filepath.Join(dir, "src")
+		dst = filepath.Join(dir, "dst")
+	)
+	if err := ioutil.WriteFile(src, []byte("new"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(dst, []byte("old content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := renameXdev(src, dst); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := ioutil.ReadFile(dst); err != nil || string(b) != "new" {
+		t.Errorf("expected %q, got %q (err: %v)", "new", b, err)
+	}
+	if _, err := os.Stat(src); !os.IsNotExist(err) {
+		t.Errorf("expected %s to be removed, err: %v", src, err)
+	}
+	if names, _ := filepath.Glob(dst + ".xdev.*"); len(names) != 0 {
+		t.Errorf("expected no temporary files, got %v", names)
+	}
+
+	// failed to copy - the destination stays intact
+	if err := renameXdev(src, dst); err == nil {
+		t.Error("expected error")
+	}
+	if b, _ := ioutil.ReadFile(dst); string(b) != "new" {
+		t.Errorf("expected %q, got %q", "new", b)
+	}
+	if names, _ := filepath.Glob(dst + ".xdev.*"); len(names) != 0 {
+		t.Errorf("expected no temporary files, got %v", names)
+	}
+}
@@ -8,6 +8,7 @@ package jsp
 import (
 	"errors"
 	"fmt"
+	"reflect"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cmn"
@@ -42,6 +43,7 @@ func loadConfig(confPath string) (config *cmn.Config, err error) {
 	if err := config.Validate(); err != nil {
 		return nil, err
 	}
+	config.EC.Normalize()
 
 	// glog rotate
 	glog.MaxSize = config.Log.MaxSize
@@ -73,6 +75,37 @@ func loadConfig(confPath string) (config *cmn.Config, err error) {
 	return
 }
 
+// ReloadConfig re-reads the config file - e.g., upon update of the Kubernetes ConfigMap
+// the file is mounted from - and applies the changes, if any. Network, mountpaths,
+// and log directory cannot change at runtime and are kept as is.
+func ReloadConfig(confPath string) (changed bool, err error) {
+	nconf := &cmn.Config{}
+	if err = Load(confPath, nconf, Options{}); err != nil {
+		return false, fmt.Errorf("failed to reload config %q, err: %v", confPath, err)
+	}
+	conf := cmn.GCO.BeginUpdate()
+	if conf.Net.HTTP.Certificate != nconf.Net.HTTP.Certificate || conf.Net.L4.Port != nconf.Net.L4.Port ||
+		!reflect.DeepEqual(conf.FSpaths.Paths, nconf.FSpaths.Paths) || conf.Log.Dir != nconf.Log.Dir {
+		glog.Warningf("%q: changes to network, mountpaths, and log.dir require restart - not applying", confPath)
+	}
+	nconf.Confdir, nconf.Net, nconf.FSpaths, nconf.TestFSP = conf.Confdir, conf.Net, conf.FSpaths, conf.TestFSP
+	nconf.Log.Dir = conf.Log.Dir
+	if err = nconf.Validate(); err != nil {
+		cmn.GCO.DiscardUpdate()
+		return
+	}
+	if reflect.DeepEqual(conf, nconf) {
+		cmn.GCO.DiscardUpdate()
+		return
+	}
+	if err = cmn.SetLogLevel(nconf, nconf.Log.Level); err != nil {
+		cmn.GCO.DiscardUpdate()
+		return
+	}
+	cmn.GCO.CommitUpdate(nconf)
+	return true, nil
+}
+
 func SetConfigMany(nvmap cmn.SimpleKVs) (err error) {
 	if len(nvmap) == 0 {
 		return errors.New("setConfig: empty nvmap")
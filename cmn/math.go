@@ -88,6 +88,14 @@ func MaxDuration(a, b time.Duration) time.Duration {
 	return b
 }
 
+// AbsDuration returns the absolute value of a given time.Duration
+func AbsDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
+
 func MinTime(a, b time.Time) time.Time {
 	if a.Before(b) {
 		return a
@@ -4,6 +4,8 @@
  */
 package cmn
 
+import "context"
+
 type (
 	Runner interface {
 		SetRunName(string)
@@ -11,6 +13,12 @@ type (
 		Run() error
 		Stop(error)
 	}
+	// GracefulRunner is a Runner that takes time to release its resources
+	// (e.g., to shut down its servers) - StopCtx must return by the ctx deadline
+	GracefulRunner interface {
+		Runner
+		StopCtx(ctx context.Context, err error)
+	}
 	Named struct {
 		name string
 	}
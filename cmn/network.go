@@ -20,6 +20,12 @@ const (
 	NetworkIntraData    = "intra_data"
 )
 
+// IP address families (see NetConf)
+const (
+	IPFamilyIPv4 = "ipv4"
+	IPFamilyIPv6 = "ipv6"
+)
+
 var (
 	KnownNetworks = []string{NetworkPublic, NetworkIntraControl, NetworkIntraData}
 )
@@ -59,6 +65,13 @@ func ParsePort(p string) (int, error) {
 	return port, nil
 }
 
+// IPsEqual returns true if both strings are IP addresses (IPv4 or IPv6) and
+// the addresses are equal (e.g., "::1" and "0:0:0:0:0:0:0:1")
+func IPsEqual(a, b string) bool {
+	ipa, ipb := net.ParseIP(a), net.ParseIP(b)
+	return ipa != nil && ipb != nil && ipa.Equal(ipb)
+}
+
 func NewTransport(args TransportArgs) *http.Transport {
 	var (
 		dialTimeout      = args.DialTimeout
@@ -68,6 +81,8 @@ func NewTransport(args TransportArgs) *http.Transport {
 	if dialTimeout == 0 {
 		dialTimeout = 30 * time.Second
 	}
+	// NOTE: dual-stack - when a hostname resolves to both IPv4 and IPv6
+	// addresses the dialer races the two families (RFC 6555)
 	dialer := &net.Dialer{
 		Timeout:   dialTimeout,
 		KeepAlive: 30 * time.Second,
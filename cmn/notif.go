@@ -47,3 +47,42 @@ var (
 
 func (notif *NotifBase) Callback(n Notif, err error) { notif.F(n, err) }
 func (notif *NotifBase) Upon(u Upon) bool            { return notif != nil && notif.When&u != 0 }
+
+//////////////////////////////////
+// asynchronous operation (job) //
+//////////////////////////////////
+
+// JobStatus is returned by GET /v1/txn/<uuid> - the status of the (bucket)
+// operation that was started asynchronously (see URLParamAsync)
+type JobStatus struct {
+	UUID     string `json:"uuid"`
+	Action   string `json:"action"`
+	Bck      Bck    `json:"bck"`
+	Started  int64  `json:"started,string"`  // Unix time (nanoseconds)
+	Finished int64  `json:"finished,string"` // ditto; zero while running
+	Err      string `json:"err,omitempty"`
+	WaitFor  string `json:"wait_for,omitempty"` // UUID of the xaction (or job) to wait for (see URLParamWaitFor)
+}
+
+func (js *JobStatus) Done() bool { return js.Finished != 0 }
+
+//////////////////////
+// xaction progress //
+//////////////////////
+
+// XactProgress is periodically notified by each target that runs a given (bucket)
+// xaction (see UponProgress) and, aggregated cluster-wide, returned by
+// GET /v1/cluster?what=xprogress&uuid=<uuid> (see GetWhatXactProgress)
+type XactProgress struct {
+	UUID       string `json:"uuid"`
+	Kind       string `json:"kind"`
+	Bck        Bck    `json:"bck"`
+	Objects    int64  `json:"objects,string"`       // processed so far
+	Bytes      int64  `json:"bytes,string"`         // ditto
+	TotalObjs  int64  `json:"total_objects,string"` // estimated number of objects to process (zero - unknown)
+	TotalBytes int64  `json:"total_bytes,string"`   // estimated size (ditto)
+	Targets    int    `json:"targets,omitempty"`    // cluster-wide: number of targets that run the xaction
+	Reported   int    `json:"reported,omitempty"`   // ditto: number of targets that have reported so far
+	Pct        int    `json:"pct"`                  // cluster-wide: percent complete (-1 - unknown)
+	Finished   bool   `json:"finished,omitempty"`
+}
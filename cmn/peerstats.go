@@ -0,0 +1,92 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+)
+
+// intra-cluster channels (see PeerStats)
+const (
+	PeerChanCall      = "call"      // point-to-point control-plane call
+	PeerChanBcast     = "bcast"     // control-plane broadcast
+	PeerChanMetasync  = "metasync"  // metadata synchronization
+	PeerChanTransport = "transport" // data-plane streams (objects sent)
+)
+
+type (
+	// PeerStats - the calls from a given node to its peers, by peer ID and channel
+	// (see PeerChanCall, et al.); the transport streams to the URLs that do not
+	// belong to any of the cluster nodes are keyed by the URL
+	PeerStats map[string]map[string]*PeerChanStats
+
+	PeerChanStats struct {
+		Count       int64         `json:"count,string"`
+		Errors      int64         `json:"errors,string"`
+		ErrRate     float64       `json:"err_rate"`    // errors/count
+		AvgLatency  time.Duration `json:"avg_latency"` // transport: enqueue-to-completion time
+		MaxLatency  time.Duration `json:"max_latency"`
+		LastErr     string        `json:"last_err,omitempty"`
+		LastErrTime int64         `json:"last_err_time,string,omitempty"` // Unix time (nanoseconds)
+	}
+
+	// PeerCallTracker accumulates the calls to a given peer via a given channel
+	PeerCallTracker struct {
+		count      atomic.Int64
+		errs       atomic.Int64
+		latency    atomic.Int64 // cumulative
+		maxLatency atomic.Int64
+		mtx        sync.Mutex
+		lastErr    string
+		lastErrTm  int64
+	}
+)
+
+func (pt *PeerCallTracker) Add(latency time.Duration, err error) {
+	pt.count.Inc()
+	pt.latency.Add(int64(latency))
+	for {
+		max := pt.maxLatency.Load()
+		if int64(latency) <= max || pt.maxLatency.CAS(max, int64(latency)) {
+			break
+		}
+	}
+	if err == nil {
+		return
+	}
+	pt.errs.Inc()
+	pt.mtx.Lock()
+	pt.lastErr, pt.lastErrTm = err.Error(), time.Now().UnixNano()
+	pt.mtx.Unlock()
+}
+
+func (pt *PeerCallTracker) Stats() *PeerChanStats {
+	st := &PeerChanStats{
+		Count:      pt.count.Load(),
+		Errors:     pt.errs.Load(),
+		MaxLatency: time.Duration(pt.maxLatency.Load()),
+	}
+	if st.Count > 0 {
+		st.ErrRate = float64(st.Errors) / float64(st.Count)
+		st.AvgLatency = time.Duration(pt.latency.Load() / st.Count)
+	}
+	pt.mtx.Lock()
+	st.LastErr, st.LastErrTime = pt.lastErr, pt.lastErrTm
+	pt.mtx.Unlock()
+	return st
+}
+
+// Add adds the stats of a given peer and channel
+func (ps PeerStats) Add(peer, ch string, st *PeerChanStats) {
+	chans, ok := ps[peer]
+	if !ok {
+		chans = make(map[string]*PeerChanStats, 4)
+		ps[peer] = chans
+	}
+	chans[ch] = st
+}
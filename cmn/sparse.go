@@ -0,0 +1,157 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"syscall"
+)
+
+//
+// sparse files: detecting all-zero content on write, preserving holes on copy
+//
+
+// SparseBlockSize is the granularity at which SparseWriter detects all-zero
+// content and leaves it unallocated. Smaller blocks are written as is - to
+// preserve sparseness without fragmenting the file.
+const SparseBlockSize = 64 * KiB
+
+var zeroBlock [SparseBlockSize]byte
+
+type SparseWriter struct {
+	f   *os.File
+	off int64 // logical offset (i.e., the number of bytes written so far, holes included)
+	pos int64 // file offset
+}
+
+func NewSparseWriter(f *os.File) *SparseWriter { return &SparseWriter{f: f} }
+
+// Write writes aligned all-zero blocks as holes, everything else - as data.
+func (sw *SparseWriter) Write(p []byte) (n int, err error) {
+	for len(p) > 0 {
+		l := SparseBlockSize - int(sw.off%SparseBlockSize)
+		if l > len(p) {
+			l = len(p)
+		}
+		if l == SparseBlockSize && bytes.Equal(p[:l], zeroBlock[:]) {
+			sw.off += int64(l)
+		} else {
+			if sw.pos != sw.off {
+				if sw.pos, err = sw.f.Seek(sw.off, io.SeekStart); err != nil {
+					return
+				}
+			}
+			var written int
+			written, err = sw.f.Write(p[:l])
+			sw.off += int64(written)
+			sw.pos = sw.off
+			if err != nil {
+				n += written
+				return
+			}
+		}
+		n += l
+		p = p[l:]
+	}
+	return
+}
+
+// skip leaves a hole of a given size
+func (sw *SparseWriter) skip(size int64) { sw.off += size }
+
+// Finalize makes sure that the file's size includes trailing hole (if any).
+// Must be called once the writing is done.
+func (sw *SparseWriter) Finalize() error {
+	if sw.pos == sw.off {
+		return nil
+	}
+	sw.pos = sw.off
+	return sw.f.Truncate(sw.off)
+}
+
+// AllocatedSize returns the number of bytes actually allocated on disk for a given file
+// which, for sparse files, is less than the file's (logical) size.
+func AllocatedSize(fi os.FileInfo) int64 {
+	if st, ok := fi.Sys().(*syscall.Stat_t); ok {
+		return st.Blocks * 512
+	}
+	return fi.Size()
+}
+
+// IsSparse returns true if a given file contains holes. Note that the converse
+// does not hold: the allocated size may include blocks preallocated by the filesystem.
+func IsSparse(fi os.FileInfo) bool { return AllocatedSize(fi) < fi.Size() }
+
+// copySparse copies a file region by region (see SEEK_DATA and SEEK_HOLE in
+// lseek(2)): data regions are copied, holes - preserved. The holes are
+// checksummed as zeros, as if they were read. Filesystems that do not support
+// SEEK_DATA get copied as is (see SparseWriter).
+func copySparse(sw *SparseWriter, src *os.File, size int64, buf []byte, cksumType string) (n int64, cksum *CksumHash, err error) {
+	var (
+		w   io.Writer = sw
+		off int64
+	)
+	if cksumType != ChecksumNone && cksumType != "" {
+		cksum = NewCksumHash(cksumType)
+		w = NewWriterMulti(cksum.H, sw)
+	}
+	for off < size {
+		var data, hole, written int64
+		if data, err = src.Seek(off, seekData); err != nil {
+			if off == 0 && errors.Is(err, syscall.EINVAL) {
+				return CopyAndChecksum(sw, src, buf, cksumType)
+			}
+			if !errors.Is(err, syscall.ENXIO) {
+				return
+			}
+			data, err = size, nil // no data beyond `off`
+		}
+		if data > off {
+			if cksum != nil {
+				cksumZeros(cksum, data-off)
+			}
+			sw.skip(data - off)
+			n += data - off
+			off = data
+			if off >= size {
+				break
+			}
+		}
+		if hole, err = src.Seek(data, seekHole); err != nil {
+			return
+		}
+		if _, err = src.Seek(data, io.SeekStart); err != nil {
+			return
+		}
+		written, err = io.CopyBuffer(w, io.LimitReader(src, hole-data), buf)
+		n += written
+		off += written
+		if err != nil {
+			return
+		}
+		if written != hole-data {
+			err = io.ErrUnexpectedEOF
+			return
+		}
+	}
+	if cksum != nil {
+		cksum.Finalize()
+	}
+	return
+}
+
+func cksumZeros(cksum *CksumHash, size int64) {
+	for size > 0 {
+		l := int64(SparseBlockSize)
+		if l > size {
+			l = size
+		}
+		cksum.H.Write(zeroBlock[:l])
+		size -= l
+	}
+}
@@ -0,0 +1,11 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+// lseek(2) whence values
+const (
+	seekHole = 3 // SEEK_HOLE
+	seekData = 4 // SEEK_DATA
+)
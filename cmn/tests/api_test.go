@@ -5,8 +5,11 @@
 package tests
 
 import (
+	"net/http"
+
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cmn"
+	jsoniter "github.com/json-iterator/go"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -179,4 +182,165 @@ var _ = Describe("API", func() {
 			),
 		)
 	})
+
+	Describe("HeadersConf", func() {
+		It("should set the configured headers", func() {
+			conf := cmn.HeadersConf{
+				CacheControl:       "max-age=3600",
+				ContentDisposition: "inline",
+				ContentTypes:       ".jpg=image/jpeg, .JSON=application/json",
+			}
+			Expect(conf.ValidateAsProps(nil)).NotTo(HaveOccurred())
+
+			hdr := http.Header{}
+			conf.Set(hdr, "dir/photo.JPG")
+			Expect(hdr.Get(cmn.HeaderCacheControl)).To(Equal("max-age=3600"))
+			Expect(hdr.Get(cmn.HeaderContentDisposition)).To(Equal("inline"))
+			Expect(hdr.Get(cmn.HeaderContentType)).To(Equal("image/jpeg"))
+
+			hdr = http.Header{}
+			conf.Set(hdr, "data.json")
+			Expect(hdr.Get(cmn.HeaderContentType)).To(Equal("application/json"))
+
+			hdr = http.Header{}
+			conf.Set(hdr, "readme")
+			Expect(hdr.Get(cmn.HeaderContentType)).To(BeEmpty())
+		})
+
+		It("should parse the content types once, when validated", func() {
+			conf := cmn.HeadersConf{ContentTypes: ".jpg=image/jpeg"}
+			hdr := http.Header{}
+			conf.Set(hdr, "photo.jpg")
+			Expect(hdr.Get(cmn.HeaderContentType)).To(BeEmpty())
+
+			Expect(conf.ValidateAsProps(nil)).NotTo(HaveOccurred())
+			Expect(conf.Types).To(Equal(map[string]string{".jpg": "image/jpeg"}))
+
+			// as received by the targets (with the rest of the bucket props)
+			props := cmn.DefaultBucketProps()
+			props.Headers = conf
+			received := &cmn.BucketProps{}
+			Expect(jsoniter.Unmarshal(cmn.MustMarshal(props), received)).NotTo(HaveOccurred())
+			hdr = http.Header{}
+			received.Headers.Set(hdr, "photo.jpg")
+			Expect(hdr.Get(cmn.HeaderContentType)).To(Equal("image/jpeg"))
+			Expect(received.Clone().Headers.Types).To(Equal(conf.Types))
+		})
+
+		DescribeTable("should reject invalid content types",
+			func(types string) {
+				conf := cmn.HeadersConf{ContentTypes: types}
+				Expect(conf.ValidateAsProps(nil)).To(HaveOccurred())
+			},
+			Entry("no extension", "=image/jpeg"),
+			Entry("no dot", "jpg=image/jpeg"),
+			Entry("no content type", ".jpg="),
+			Entry("no separator", ".jpg"),
+		)
+	})
+
+	Describe("TrackProvenance", func() {
+		It("should track defaults and overrides", func() {
+			props := cmn.DefaultBucketProps()
+			props.Mirror.Enabled = !props.Mirror.Enabled
+			props.TrackProvenance(nil, 5)
+			Expect(props.Provenance.Get("mirror.enabled")).To(Equal(cmn.PropProvenance{Source: cmn.PropSourceOverride, Version: 5}))
+			Expect(props.Provenance.Get("lru.enabled")).To(Equal(cmn.PropProvenance{Source: cmn.PropSourceDefault, Version: 5}))
+			Expect(props.Provenance.Overrides).To(HaveLen(1))
+
+			nprops := props.Clone()
+			nprops.Apply(cmn.BucketPropsToUpdate{EC: &cmn.ECConfToUpdate{Enabled: api.Bool(!props.EC.Enabled)}})
+			nprops.TrackProvenance(props, 7)
+			Expect(nprops.Provenance.Get("ec.enabled")).To(Equal(cmn.PropProvenance{Source: cmn.PropSourceOverride, Version: 7}))
+			Expect(nprops.Provenance.Get("mirror.enabled")).To(Equal(cmn.PropProvenance{Source: cmn.PropSourceOverride, Version: 5}))
+			Expect(nprops.Provenance.Get("lru.enabled")).To(Equal(cmn.PropProvenance{Source: cmn.PropSourceDefault, Version: 5}))
+			// (the previous props are not affected)
+			Expect(props.Provenance.Get("ec.enabled").Source).To(Equal(cmn.PropSourceDefault))
+
+			// reset
+			reset := cmn.DefaultBucketProps()
+			reset.TrackProvenance(nprops, 9)
+			Expect(reset.Provenance.Overrides).To(BeEmpty())
+			Expect(reset.Provenance.Get("mirror.enabled")).To(Equal(cmn.PropProvenance{Source: cmn.PropSourceDefault, Version: 9}))
+		})
+	})
+
+	Describe("custom metadata in list-objects", func() {
+		DescribeTable("should parse the requested custom metadata keys",
+			func(props string, keys []string, all bool) {
+				msg := &cmn.SelectMsg{Props: props}
+				k, a := msg.CustomMDKeys()
+				Expect(k).To(Equal(keys))
+				Expect(a).To(Equal(all))
+			},
+			Entry("none", "name,size", nil, false),
+			Entry("all", "name,custom", nil, true),
+			Entry("given keys", "name,custom.etag,size,custom.md5", []string{"etag", "md5"}, false),
+			Entry("all and given keys", "custom.etag,custom", nil, true),
+		)
+
+		It("should set the requested custom metadata", func() {
+			md := cmn.SimpleKVs{"etag": "abc-2", "md5": "xyz"}
+
+			entry := &cmn.BucketEntry{}
+			entry.SetCustomMD(md, nil, true)
+			Expect(entry.Custom).To(Equal(md))
+			entry.Custom["etag"] = "changed"
+			Expect(md["etag"]).To(Equal("abc-2"), "must be a copy")
+
+			entry = &cmn.BucketEntry{}
+			entry.SetCustomMD(md, []string{"md5", "source"}, false)
+			Expect(entry.Custom).To(Equal(cmn.SimpleKVs{"md5": "xyz"}))
+
+			entry = &cmn.BucketEntry{}
+			entry.SetCustomMD(md, []string{"source"}, false)
+			Expect(entry.Custom).To(BeNil())
+			entry.SetCustomMD(nil, nil, true)
+			Expect(entry.Custom).To(BeNil())
+		})
+	})
+
+	Describe("AwsMultipartETag", func() {
+		DescribeTable("should detect multipart ETags",
+			func(etag *string, expected string, multipart bool) {
+				v, ok := cmn.AwsMultipartETag(etag)
+				Expect(v).To(Equal(expected))
+				Expect(ok).To(Equal(multipart))
+			},
+			Entry("nil", nil, "", false),
+			Entry("regular", api.String(`"d41d8cd98f00b204e9800998ecf8427e"`), "d41d8cd98f00b204e9800998ecf8427e", false),
+			Entry("multipart", api.String(`"d41d8cd98f00b204e9800998ecf8427e-3"`), "d41d8cd98f00b204e9800998ecf8427e-3", true),
+			Entry("unquoted multipart", api.String("abc-12"), "abc-12", true),
+		)
+	})
+
+	Describe("SimPlacementResult", func() {
+		It("should account for the moved objects and merge the results", func() {
+			r1 := cmn.NewSimPlacementResult()
+			r1.Objects, r1.Size = 3, 300
+			r1.Move("t1", "t4", 100)
+			r1.Move("t1", "t2", 50)
+			Expect(r1.MovedObjects).To(BeEquivalentTo(2))
+			Expect(r1.MovedSize).To(BeEquivalentTo(150))
+			Expect(*r1.Targets["t1"]).To(Equal(cmn.SimPlacementTarget{SentObjects: 2, SentSize: 150}))
+			Expect(*r1.Targets["t4"]).To(Equal(cmn.SimPlacementTarget{RecvObjects: 1, RecvSize: 100}))
+
+			r2 := cmn.NewSimPlacementResult()
+			r2.Objects, r2.Size = 2, 20
+			r2.Move("t2", "t4", 10)
+
+			sim := cmn.NewSimPlacementResult()
+			sim.Merge(r1)
+			sim.Merge(r2)
+			Expect(sim.Objects).To(BeEquivalentTo(5))
+			Expect(sim.Size).To(BeEquivalentTo(320))
+			Expect(sim.MovedObjects).To(BeEquivalentTo(3))
+			Expect(sim.MovedSize).To(BeEquivalentTo(160))
+			Expect(sim.Targets).To(HaveLen(3))
+			Expect(*sim.Targets["t2"]).To(Equal(cmn.SimPlacementTarget{
+				SentObjects: 1, SentSize: 10, RecvObjects: 1, RecvSize: 50,
+			}))
+			Expect(*sim.Targets["t4"]).To(Equal(cmn.SimPlacementTarget{RecvObjects: 2, RecvSize: 110}))
+		})
+	})
 })
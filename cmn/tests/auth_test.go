@@ -0,0 +1,73 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestJoinToken(t *testing.T) {
+	const (
+		clusterID = "cluster-uuid"
+		secret    = "join-secret"
+	)
+	token, err := cmn.NewJoinToken(clusterID, time.Now().Add(time.Hour), secret)
+	tassert.CheckFatal(t, err)
+	tassert.CheckError(t, cmn.ValidateJoinToken(token, clusterID, secret))
+
+	err = cmn.ValidateJoinToken(token, "another-cluster", secret)
+	tassert.Errorf(t, err != nil, "expected error for a different cluster")
+	err = cmn.ValidateJoinToken(token, clusterID, "another-secret")
+	tassert.Errorf(t, err != nil, "expected error for a different secret")
+	err = cmn.ValidateJoinToken(token+"x", clusterID, secret)
+	tassert.Errorf(t, err != nil, "expected error for a damaged token")
+
+	expired, err := cmn.NewJoinToken(clusterID, time.Now().Add(-time.Minute), secret)
+	tassert.CheckFatal(t, err)
+	err = cmn.ValidateJoinToken(expired, clusterID, secret)
+	tassert.Errorf(t, err != nil, "expected error for an expired token")
+
+	_, err = cmn.NewJoinToken(clusterID, time.Now().Add(time.Hour), "")
+	tassert.Errorf(t, err != nil, "expected error for an empty secret")
+}
+
+func TestConfirmToken(t *testing.T) {
+	const (
+		clusterID = "cluster-uuid"
+		secret    = "confirm-secret"
+		op        = "destroylb ais/@#/bucket"
+	)
+	token, err := cmn.NewConfirmToken(clusterID, op, time.Now().Add(time.Minute), secret)
+	tassert.CheckFatal(t, err)
+	ct, err := cmn.ValidateConfirmToken(token, clusterID, op, secret)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, ct.Nonce != "", "expected non-empty nonce")
+
+	// each token is unique
+	another, err := cmn.NewConfirmToken(clusterID, op, time.Now().Add(time.Minute), secret)
+	tassert.CheckFatal(t, err)
+	act, err := cmn.ValidateConfirmToken(another, clusterID, op, secret)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, act.Nonce != ct.Nonce, "expected different nonces, got %q", ct.Nonce)
+
+	_, err = cmn.ValidateConfirmToken(token, clusterID, "destroylb ais/@#/another-bucket", secret)
+	tassert.Errorf(t, err != nil, "expected error for a different operation")
+	_, err = cmn.ValidateConfirmToken(token, "another-cluster", op, secret)
+	tassert.Errorf(t, err != nil, "expected error for a different cluster")
+	_, err = cmn.ValidateConfirmToken(token, clusterID, op, "another-secret")
+	tassert.Errorf(t, err != nil, "expected error for a different secret")
+
+	expired, err := cmn.NewConfirmToken(clusterID, op, time.Now().Add(-time.Second), secret)
+	tassert.CheckFatal(t, err)
+	_, err = cmn.ValidateConfirmToken(expired, clusterID, op, secret)
+	tassert.Errorf(t, err != nil, "expected error for an expired token")
+
+	_, err = cmn.NewConfirmToken(clusterID, op, time.Now().Add(time.Minute), "")
+	tassert.Errorf(t, err != nil, "expected error for an empty secret")
+}
@@ -0,0 +1,56 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestClockSkew(t *testing.T) {
+	var (
+		conf = &cmn.ClockConf{MaxSkew: time.Second}
+		sent = time.Now()
+		recv = sent.Add(200 * time.Millisecond)
+		peer = func(d time.Duration) string { return strconv.FormatInt(sent.Add(d).UnixNano(), 10) }
+	)
+	cs, err := cmn.NewClockSkew(peer(100*time.Millisecond), sent, recv, conf)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, cs.Skew == 0 && cs.RTT == 200*time.Millisecond && !cs.Exceeded, "wrong estimate: %+v", cs)
+
+	cs, err = cmn.NewClockSkew(peer(-2*time.Second), sent, recv, conf)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, cs.Skew == -2100*time.Millisecond && cs.Exceeded, "wrong estimate: %+v", cs)
+
+	// within the round-trip uncertainty
+	cs, err = cmn.NewClockSkew(peer(1150*time.Millisecond), sent, recv, conf)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, cs.Skew == 1050*time.Millisecond && !cs.Exceeded, "wrong estimate: %+v", cs)
+
+	_, err = cmn.NewClockSkew("abc", sent, recv, conf)
+	tassert.Errorf(t, err != nil, "expected error for an invalid time")
+}
+
+func TestExpiredWithSkew(t *testing.T) {
+	oldConfig := cmn.GCO.Get()
+	defer func() {
+		cmn.GCO.BeginUpdate()
+		cmn.GCO.CommitUpdate(oldConfig)
+	}()
+	config := cmn.GCO.BeginUpdate()
+	config.Clock.SkewTolerance = 2 * time.Second
+	cmn.GCO.CommitUpdate(config)
+
+	tassert.Errorf(t, !cmn.Expired(time.Now().Add(-time.Second)), "expected to tolerate 1s skew")
+	tassert.Errorf(t, cmn.Expired(time.Now().Add(-3*time.Second)), "expected to expire")
+
+	token, err := cmn.NewJoinToken("cluster-uuid", time.Now().Add(-time.Second), "secret")
+	tassert.CheckFatal(t, err)
+	tassert.CheckError(t, cmn.ValidateJoinToken(token, "cluster-uuid", "secret"))
+}
@@ -5,6 +5,7 @@
 package tests
 
 import (
+	"bytes"
 	"crypto/rand"
 	"io"
 	"io/ioutil"
@@ -209,6 +210,88 @@ var _ = Describe("Common file", func() {
 
 			Expect(srcData).To(Equal(dstData))
 		})
+
+		It("should copy a sparse file and preserve its holes", func() {
+			var (
+				data  = make([]byte, 0, 8*cmn.SparseBlockSize)
+				chunk = make([]byte, 1000)
+			)
+			_, err := rand.Read(chunk)
+			Expect(err).NotTo(HaveOccurred())
+			data = append(data, chunk...)
+			data = append(data, make([]byte, 4*cmn.SparseBlockSize)...)
+			data = append(data, chunk...)
+			data = append(data, make([]byte, 2*cmn.SparseBlockSize)...)
+
+			file, err := cmn.CreateFile(srcFilename)
+			Expect(err).NotTo(HaveOccurred())
+			sw := cmn.NewSparseWriter(file)
+			_, err = sw.Write(data)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(sw.Finalize()).NotTo(HaveOccurred())
+			Expect(file.Close()).NotTo(HaveOccurred())
+
+			fi, err := os.Stat(srcFilename)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(fi.Size()).To(BeEquivalentTo(len(data)))
+			Expect(cmn.IsSparse(fi)).To(BeTrue())
+
+			_, expectedCksum, err := cmn.CopyAndChecksum(ioutil.Discard, bytes.NewReader(data), nil, cmn.ChecksumXXHash)
+			Expect(err).NotTo(HaveOccurred())
+			written, cksum, err := cmn.CopyFile(srcFilename, dstFilename, make([]byte, 1000), cmn.ChecksumXXHash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(written).To(BeEquivalentTo(len(data)))
+			Expect(cksum.Value()).To(Equal(expectedCksum.Value()))
+
+			fi, err = os.Stat(dstFilename)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cmn.IsSparse(fi)).To(BeTrue())
+
+			dstData, err := ioutil.ReadFile(dstFilename)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dstData).To(Equal(data))
+		})
+
+		It("should preserve unaligned holes smaller than the sparse block", func() {
+			const holeSize = 3 * 4 * cmn.KiB
+			chunk := make([]byte, 5000)
+			_, err := rand.Read(chunk)
+			Expect(err).NotTo(HaveOccurred())
+
+			// data, hole, data, trailing hole - the way a regular (non-sparse) writer would seek
+			file, err := cmn.CreateFile(srcFilename)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = file.Write(chunk)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = file.Seek(holeSize, io.SeekCurrent)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = file.Write(chunk)
+			Expect(err).NotTo(HaveOccurred())
+			size := int64(2*len(chunk) + 2*holeSize)
+			Expect(file.Truncate(size)).NotTo(HaveOccurred())
+			Expect(file.Close()).NotTo(HaveOccurred())
+			data, err := ioutil.ReadFile(srcFilename)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, expectedCksum, err := cmn.CopyAndChecksum(ioutil.Discard, bytes.NewReader(data), nil, cmn.ChecksumXXHash)
+			Expect(err).NotTo(HaveOccurred())
+			written, cksum, err := cmn.CopyFile(srcFilename, dstFilename, nil, cmn.ChecksumXXHash)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(written).To(Equal(size))
+			Expect(cksum.Value()).To(Equal(expectedCksum.Value()))
+
+			srcFi, err := os.Stat(srcFilename)
+			Expect(err).NotTo(HaveOccurred())
+			dstFi, err := os.Stat(dstFilename)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dstFi.Size()).To(Equal(size))
+			Expect(cmn.AllocatedSize(dstFi)).To(BeNumerically("<=", cmn.AllocatedSize(srcFi)))
+			Expect(cmn.AllocatedSize(dstFi)).To(BeNumerically("<", size))
+
+			dstData, err := ioutil.ReadFile(dstFilename)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(dstData).To(Equal(data))
+		})
 	})
 
 	Context("Rename", func() {
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"testing"
+	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/jsp"
@@ -54,3 +55,139 @@ func TestValidateMpath(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateRemoteCache(t *testing.T) {
+	confs := []cmn.RemoteCacheConf{
+		{Enabled: true, Capacity: cmn.GiB, LowWM: 75},                 // no bucket
+		{Enabled: true, Bucket: "cache", LowWM: 75},                   // no capacity
+		{Enabled: true, Bucket: "cache", Capacity: cmn.GiB, LowWM: 0}, // invalid lowwm
+		{Enabled: true, Bucket: "cache", Capacity: cmn.GiB, LowWM: 100},
+	}
+	for _, conf := range confs {
+		if err := conf.Validate(nil); err == nil {
+			t.Errorf("validation of invalid remote cache config %+v succeeded", conf)
+		}
+	}
+	conf := cmn.RemoteCacheConf{Enabled: true, Bucket: "cache", Capacity: cmn.GiB, LowWM: 75}
+	tassert.CheckFatal(t, conf.Validate(nil))
+	tassert.Fatalf(t, conf.IsCacheBck(cmn.Bck{Name: "cache", Provider: cmn.ProviderAIS}), "expected cache bucket")
+	tassert.Fatalf(t, !conf.IsCacheBck(cmn.Bck{Name: "cache", Provider: cmn.ProviderAmazon}), "unexpected cache bucket")
+}
+
+func TestValidateNetIPFamily(t *testing.T) {
+	valid := cmn.NetConf{
+		IPv4:                 "fd00::10,10.0.0.1",
+		IPv4IntraControl:     "fd00::20",
+		IPFamily:             cmn.IPFamilyIPv6,
+		IPFamilyIntraControl: cmn.IPFamilyIPv4,
+		L4:                   cmn.L4Conf{Proto: "tcp", PortStr: "8080"},
+	}
+	tassert.CheckFatal(t, valid.Validate(nil))
+
+	confs := []cmn.NetConf{
+		{IPFamily: "ipv5", L4: cmn.L4Conf{Proto: "tcp", PortStr: "8080"}},
+		// same address, different notation
+		{IPv4: "fd00::10", IPv4IntraControl: "fd00:0:0:0:0:0:0:10", L4: cmn.L4Conf{Proto: "tcp", PortStr: "8080"}},
+	}
+	for _, conf := range confs {
+		if err := conf.Validate(nil); err == nil {
+			t.Errorf("validation of invalid net config %+v succeeded", conf)
+		}
+	}
+}
+
+func TestValidateNetListeners(t *testing.T) {
+	conf := cmn.NetConf{
+		L4:   cmn.L4Conf{Proto: "tcp", PortStr: "8080"},
+		HTTP: cmn.HTTPConf{UseHTTPS: true, Certificate: "server.crt", Key: "server.key"},
+		Listeners: cmn.ListenersConf{
+			IntraControl: cmn.ListenerConf{TLS: cmn.ListenerTLSOff, AllowedCIDRs: "10.0.0.0/8, fd00::/8"},
+			IntraData:    cmn.ListenerConf{Certificate: "data.crt", Key: "data.key"},
+		},
+		UseIntraControl: true,
+		UseIntraData:    true,
+	}
+	tassert.CheckFatal(t, conf.Validate(nil))
+	tassert.Errorf(t, conf.HTTP.Proto == "https", "expected public https, got %q", conf.HTTP.Proto)
+	tassert.Errorf(t, !conf.Listener(cmn.NetworkIntraControl).UseHTTPS, "expected intra-control http")
+	tassert.Errorf(t, conf.Listener(cmn.NetworkIntraData).CertFile == "data.crt", "expected intra-data certificate")
+	tassert.Errorf(t, conf.Listener(cmn.NetworkPublic).CertFile == "server.crt", "expected public certificate")
+
+	lc := conf.Listener(cmn.NetworkIntraControl)
+	tassert.Errorf(t, lc.IsAllowed("10.1.2.3:51000"), "expected 10.1.2.3 to be allowed")
+	tassert.Errorf(t, lc.IsAllowed("[fd00::1]:51000"), "expected fd00::1 to be allowed")
+	tassert.Errorf(t, !lc.IsAllowed("192.168.1.1:51000"), "expected 192.168.1.1 to be rejected")
+	tassert.Errorf(t, conf.Listener(cmn.NetworkPublic).IsAllowed("192.168.1.1:51000"), "expected any client on public")
+
+	// not configured separately - shares the public listener
+	conf.UseIntraControl = false
+	tassert.Errorf(t, conf.Listener(cmn.NetworkIntraControl).UseHTTPS, "expected intra-control to share public listener")
+
+	confs := []cmn.ListenersConf{
+		{Public: cmn.ListenerConf{TLS: "yes"}},
+		{IntraData: cmn.ListenerConf{AllowedCIDRs: "10.0.0.1"}},
+	}
+	for _, listeners := range confs {
+		conf := cmn.NetConf{L4: cmn.L4Conf{Proto: "tcp", PortStr: "8080"}, Listeners: listeners}
+		if err := conf.Validate(nil); err == nil {
+			t.Errorf("validation of invalid net listeners %+v succeeded", listeners)
+		}
+	}
+}
+
+func TestValidateECPlacement(t *testing.T) {
+	conf := cmn.ECConf{DataSlices: 2, ParitySlices: 2, BatchSize: 64, MetaQuorum: cmn.ECQuorumMajority}
+	tassert.CheckError(t, conf.Validate(nil))
+	tassert.Errorf(t, conf.Placement == "", "validation must not change ec.placement: %q", conf.Placement)
+	conf.Normalize()
+	tassert.Errorf(t, conf.Placement == cmn.ECPlacementHRW, "expected %q, got %q", cmn.ECPlacementHRW, conf.Placement)
+
+	conf.Placement = "rack"
+	tassert.Errorf(t, conf.Validate(nil) != nil, "expected error for invalid ec.placement")
+}
+
+func TestValidateECMetaQuorum(t *testing.T) {
+	conf := cmn.ECConf{DataSlices: 2, ParitySlices: 2, BatchSize: 64}
+	tassert.CheckError(t, conf.Validate(nil))
+	tassert.Errorf(t, conf.MetaQuorum == cmn.ECQuorumMajority, "expected %q, got %q", cmn.ECQuorumMajority, conf.MetaQuorum)
+
+	for _, quorum := range []string{cmn.ECQuorumStrict, cmn.ECQuorumNewest} {
+		conf.MetaQuorum = quorum
+		tassert.CheckError(t, conf.Validate(nil))
+	}
+	conf.MetaQuorum = "all"
+	tassert.Errorf(t, conf.Validate(nil) != nil, "expected error for invalid ec.meta_quorum")
+}
+
+func TestValidateECRestoreMemLimit(t *testing.T) {
+	conf := cmn.ECConf{DataSlices: 2, ParitySlices: 2, BatchSize: 64, RestoreMemLimit: cmn.GiB}
+	tassert.CheckError(t, conf.Validate(nil))
+	conf.RestoreMemLimit = -1
+	tassert.Errorf(t, conf.Validate(nil) != nil, "expected error for negative ec.restore_mem_limit")
+}
+
+func TestValidateXactHistory(t *testing.T) {
+	tests := []struct {
+		conf      cmn.XactHistoryConf
+		retention time.Duration
+		fail      bool
+	}{
+		{conf: cmn.XactHistoryConf{}},
+		{conf: cmn.XactHistoryConf{RetentionStr: "168h", MaxEntries: 100}, retention: 168 * time.Hour},
+		{conf: cmn.XactHistoryConf{RetentionStr: "0s"}},
+		{conf: cmn.XactHistoryConf{RetentionStr: "1 week"}, fail: true},
+		{conf: cmn.XactHistoryConf{RetentionStr: "-1h"}, fail: true},
+		{conf: cmn.XactHistoryConf{RetentionStr: "1h", MaxEntries: -1}, fail: true},
+	}
+	for _, test := range tests {
+		conf := test.conf
+		err := conf.Validate(nil)
+		if test.fail {
+			tassert.Errorf(t, err != nil, "expected error for %+v", test.conf)
+			continue
+		}
+		tassert.CheckError(t, err)
+		tassert.Errorf(t, conf.Retention == test.retention, "%+v: expected retention %v, got %v",
+			test.conf, test.retention, conf.Retention)
+	}
+}
@@ -7,10 +7,13 @@ package tests
 import (
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/tutils/tassert"
+	jsoniter "github.com/json-iterator/go"
 )
 
 func TestAbortedErrorAs(t *testing.T) {
@@ -23,3 +26,48 @@ func TestAbortedErrorAs(t *testing.T) {
 	mockError := fmt.Errorf("wrapping aborted error %w", abortedError)
 	tassert.Fatalf(t, errors.As(mockError, &cmn.AbortedError{}), "expected errors.As to return true on a wrapped error")
 }
+
+func TestErrCode(t *testing.T) {
+	bck := cmn.Bck{Name: "abc", Provider: cmn.ProviderAIS}
+	tests := []struct {
+		err    error
+		status int
+		code   string
+	}{
+		{cmn.NewErrorBucketDoesNotExist(bck, "node"), http.StatusNotFound, cmn.ErrCodeBucketNotFound},
+		{cmn.NewErrorBucketAlreadyExists(bck, "node"), http.StatusConflict, cmn.ErrCodeBucketAlreadyExists},
+		{cmn.NewErrorBucketIsBusy(bck, "node"), http.StatusBadRequest, cmn.ErrCodeBucketBusy},
+		{cmn.NewInvalidCksumError("a", "b"), http.StatusBadRequest, cmn.ErrCodeChecksumMismatch},
+		{fmt.Errorf("wrapped: %w", cmn.NewAbortedError("x")), http.StatusInternalServerError, cmn.ErrCodeAborted},
+		{errors.New("unknown"), http.StatusBadRequest, cmn.ErrCodeInvalidRequest},
+		{errors.New("unknown"), http.StatusServiceUnavailable, cmn.ErrCodeUnavailable},
+		{errors.New("unknown"), http.StatusInsufficientStorage, cmn.ErrCodeInsufficientStorage},
+	}
+	for _, test := range tests {
+		code := cmn.ErrCode(test.err, test.status)
+		tassert.Errorf(t, code == test.code, "%v (%d): expected code %q, got %q", test.err, test.status, test.code, code)
+	}
+}
+
+func TestInvalidHandlerCode(t *testing.T) {
+	var (
+		w       = httptest.NewRecorder()
+		r       = httptest.NewRequest(http.MethodGet, "/v1/objects/abc/dir/obj?provider=ais", nil)
+		httpErr = &cmn.HTTPError{}
+	)
+	cmn.InvalidHandlerCode(w, r, "bucket is busy", cmn.ErrCodeBucketBusy, http.StatusConflict)
+	tassert.Fatalf(t, w.Code == http.StatusConflict, "expected status %d, got %d", http.StatusConflict, w.Code)
+	err := jsoniter.NewDecoder(w.Body).Decode(httpErr)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, httpErr.Code == cmn.ErrCodeBucketBusy, "unexpected code %q", httpErr.Code)
+	tassert.Errorf(t, httpErr.Retryable, "expected the error to be retryable")
+	tassert.Errorf(t, httpErr.Resource == "ais://abc/dir/obj", "unexpected resource %q", httpErr.Resource)
+	tassert.Errorf(t, cmn.IsErrCode(fmt.Errorf("wrapped: %w", httpErr), cmn.ErrCodeBucketBusy), "expected IsErrCode to match")
+
+	// the error that is already structured retains its code
+	w = httptest.NewRecorder()
+	cmn.InvalidHandlerDetailed(w, r, httpErr.Error(), http.StatusBadRequest)
+	err = jsoniter.NewDecoder(w.Body).Decode(httpErr)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, httpErr.Code == cmn.ErrCodeBucketBusy, "unexpected code %q", httpErr.Code)
+}
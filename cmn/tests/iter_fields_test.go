@@ -64,21 +64,50 @@ var _ = Describe("IterFields", func() {
 					"mirror.burst_buffer": int64(0),
 					"mirror.optimize_put": false,
 
-					"ec.enabled":       true,
-					"ec.parity_slices": 1024,
-					"ec.data_slices":   0,
-					"ec.batch_size":    32,
-					"ec.objsize_limit": int64(0),
-					"ec.compression":   "",
+					"ec.enabled":           true,
+					"ec.parity_slices":     1024,
+					"ec.data_slices":       0,
+					"ec.batch_size":        32,
+					"ec.objsize_limit":     int64(0),
+					"ec.compression":       "",
+					"ec.placement":         "",
+					"ec.slice_cache_size":  int64(0),
+					"ec.pack_size":         int64(0),
+					"ec.meta_quorum":       "",
+					"ec.undelete_window":   "",
+					"ec.restore_mem_limit": int64(0),
+					"ec.slice_fanout":      0,
+					"ec.compress_parity":   false,
+
+					"journal.enabled":     false,
+					"journal.gets":        false,
+					"journal.max_entries": int64(0),
+
+					"headers.cache_control":       "",
+					"headers.content_disposition": "",
+					"headers.content_types":       "",
+
+					"encryption.enabled": false,
+
+					"quota.max_bytes":   int64(0),
+					"quota.max_objects": int64(0),
+
+					"placement.labels": "",
+					"trash.ttl":        "",
+					"trash.enabled":    false,
+					"fencing.mode":     "",
+					"fencing.timeout":  "",
 
 					"versioning.enabled":           false,
 					"versioning.validate_warm_get": false,
+					"versioning.max_versions":      0,
 
 					"checksum.type":              cmn.ChecksumXXHash,
 					"checksum.validate_warm_get": false,
 					"checksum.validate_cold_get": false,
 					"checksum.validate_obj_move": false,
 					"checksum.enable_read_range": false,
+					"checksum.verified_once":     false,
 
 					"lru.enabled":           false,
 					"lru.lowwm":             int64(0),
@@ -86,6 +115,8 @@ var _ = Describe("IterFields", func() {
 					"lru.out_of_space":      int64(0),
 					"lru.dont_evict_time":   "",
 					"lru.capacity_upd_time": "",
+					"lru.spill_on_oos":      false,
+					"lru.redirect_on_oos":   false,
 
 					"access":  cmn.AccessAttrs(0),
 					"created": int64(0),
@@ -113,25 +144,53 @@ var _ = Describe("IterFields", func() {
 					"mirror.burst_buffer": (*int64)(nil),
 					"mirror.optimize_put": (*bool)(nil),
 
-					"ec.enabled":       api.Bool(true),
-					"ec.parity_slices": api.Int(1024),
-					"ec.data_slices":   (*int)(nil),
-					"ec.objsize_limit": (*int64)(nil),
-					"ec.compression":   (*string)(nil),
+					"ec.enabled":          api.Bool(true),
+					"ec.parity_slices":    api.Int(1024),
+					"ec.data_slices":      (*int)(nil),
+					"ec.objsize_limit":    (*int64)(nil),
+					"ec.compression":      (*string)(nil),
+					"ec.placement":        (*string)(nil),
+					"ec.slice_cache_size": (*int64)(nil),
+					"ec.pack_size":        (*int64)(nil),
+					"ec.meta_quorum":      (*string)(nil),
+					"ec.compress_parity":  (*bool)(nil),
+
+					"journal.enabled":     (*bool)(nil),
+					"journal.gets":        (*bool)(nil),
+					"journal.max_entries": (*int64)(nil),
+
+					"headers.cache_control":       (*string)(nil),
+					"headers.content_disposition": (*string)(nil),
+					"headers.content_types":       (*string)(nil),
+
+					"encryption.enabled": (*bool)(nil),
+
+					"quota.max_bytes":   (*int64)(nil),
+					"quota.max_objects": (*int64)(nil),
+
+					"placement.labels": (*string)(nil),
+					"trash.ttl":        (*string)(nil),
+					"trash.enabled":    (*bool)(nil),
+					"fencing.mode":     (*string)(nil),
+					"fencing.timeout":  (*string)(nil),
 
 					"versioning.enabled":           (*bool)(nil),
 					"versioning.validate_warm_get": (*bool)(nil),
+					"versioning.max_versions":      (*int)(nil),
 
 					"checksum.type":              api.String(cmn.ChecksumXXHash),
 					"checksum.validate_warm_get": (*bool)(nil),
 					"checksum.validate_cold_get": (*bool)(nil),
 					"checksum.validate_obj_move": (*bool)(nil),
 					"checksum.enable_read_range": (*bool)(nil),
+					"checksum.verified_once":     (*bool)(nil),
 
-					"lru.enabled":      (*bool)(nil),
-					"lru.lowwm":        (*int64)(nil),
-					"lru.highwm":       (*int64)(nil),
-					"lru.out_of_space": (*int64)(nil),
+					"lru.enabled":         (*bool)(nil),
+					"lru.lowwm":           (*int64)(nil),
+					"lru.highwm":          (*int64)(nil),
+					"lru.out_of_space":    (*int64)(nil),
+					"lru.spill_on_oos":    (*bool)(nil),
+					"lru.redirect_on_oos": (*bool)(nil),
 
 					"access": api.AccessAttrs(1024),
 				},
@@ -0,0 +1,37 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestPeerCallTracker(t *testing.T) {
+	pt := &cmn.PeerCallTracker{}
+	st := pt.Stats()
+	tassert.Errorf(t, st.Count == 0 && st.AvgLatency == 0 && st.ErrRate == 0, "wrong initial stats: %+v", st)
+
+	pt.Add(10*time.Millisecond, nil)
+	pt.Add(30*time.Millisecond, nil)
+	pt.Add(20*time.Millisecond, errors.New("connection refused"))
+	pt.Add(40*time.Millisecond, nil)
+
+	st = pt.Stats()
+	tassert.Errorf(t, st.Count == 4 && st.Errors == 1, "wrong counts: %+v", st)
+	tassert.Errorf(t, st.ErrRate == 0.25, "wrong error rate: %f", st.ErrRate)
+	tassert.Errorf(t, st.AvgLatency == 25*time.Millisecond, "wrong average latency: %v", st.AvgLatency)
+	tassert.Errorf(t, st.MaxLatency == 40*time.Millisecond, "wrong max latency: %v", st.MaxLatency)
+	tassert.Errorf(t, st.LastErr == "connection refused" && st.LastErrTime > 0, "wrong last error: %+v", st)
+
+	ps := make(cmn.PeerStats)
+	ps.Add("t1", cmn.PeerChanCall, st)
+	ps.Add("t1", cmn.PeerChanTransport, &cmn.PeerChanStats{})
+	tassert.Errorf(t, len(ps) == 1 && len(ps["t1"]) == 2, "wrong peer stats: %+v", ps)
+}
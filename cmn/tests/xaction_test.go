@@ -0,0 +1,79 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestMergeXactStats(t *testing.T) {
+	var (
+		started = time.Now().Add(-time.Minute)
+		bck     = cmn.Bck{Name: "abc", Provider: cmn.ProviderAIS}
+		running = func(objs int64, eta time.Duration) *cmn.BaseXactStats {
+			return &cmn.BaseXactStats{
+				IDX: "xid", KindX: cmn.ActCopyBucket, BckX: bck, StartTimeX: started,
+				ObjCountX: objs, BytesCountX: objs * cmn.KiB, DoneCountX: objs, TotalCountX: 100,
+				ThroughputX: cmn.MiB, EtaX: eta,
+			}
+		}
+		other = &cmn.BaseXactStats{
+			IDX: "other", KindX: cmn.ActLRU, StartTimeX: started.Add(time.Second),
+			EndTimeX: started.Add(11 * time.Second), ObjCountX: 10, BytesCountX: 10 * cmn.MiB,
+		}
+	)
+	summaries := cmn.MergeXactStats(map[string][]*cmn.BaseXactStats{
+		"t1": {running(90, 10*time.Second), other},
+		"t2": {running(80, 15*time.Second)},
+		"t3": {running(20, 80*time.Second)},
+	})
+	tassert.Fatalf(t, len(summaries) == 2, "expected 2 summaries, got %d", len(summaries))
+
+	s := summaries[0]
+	tassert.Errorf(t, s.ID == "xid" && s.Targets == 3 && s.Running == 3, "wrong summary: %+v", s)
+	tassert.Errorf(t, s.ObjCount == 190 && s.BytesCount == 190*cmn.KiB, "wrong counts: %+v", s)
+	tassert.Errorf(t, s.TotalCount == 300 && s.DoneCount == 190, "wrong progress: %+v", s)
+	tassert.Errorf(t, s.Throughput == 3*cmn.MiB, "wrong throughput: %d", s.Throughput)
+	tassert.Errorf(t, s.ETA == 80*time.Second && s.EndTime.IsZero(), "wrong ETA: %v", s.ETA)
+	tassert.Errorf(t, s.Slowest == "t3", "wrong slowest target: %q", s.Slowest)
+	tassert.Errorf(t, len(s.Stragglers) == 1 && s.Stragglers[0] == "t3", "wrong stragglers: %v", s.Stragglers)
+
+	s = summaries[1]
+	tassert.Errorf(t, s.ID == "other" && s.Targets == 1 && s.Running == 0, "wrong summary: %+v", s)
+	tassert.Errorf(t, s.Throughput == cmn.MiB && s.Slowest == "t1", "wrong summary: %+v", s)
+
+	// most of the targets have finished
+	finished := running(100, 0)
+	finished.EndTimeX = time.Now()
+	summaries = cmn.MergeXactStats(map[string][]*cmn.BaseXactStats{
+		"t1": {finished},
+		"t2": {finished},
+		"t3": {running(50, 0)},
+	})
+	s = summaries[0]
+	tassert.Errorf(t, s.Running == 1 && s.Slowest == "t3", "wrong summary: %+v", s)
+	tassert.Errorf(t, len(s.Stragglers) == 1 && s.Stragglers[0] == "t3", "wrong stragglers: %v", s.Stragglers)
+}
+
+func TestXactErr(t *testing.T) {
+	errFail := errors.New("failed")
+	xact := cmn.NewXactBaseWithBucket("xid", cmn.ActECEncode, cmn.Bck{Name: "abc", Provider: cmn.ProviderAIS})
+	tassert.Errorf(t, xact.Err() == nil, "expected no error while running")
+	xact.Finish(errFail)
+	tassert.Errorf(t, xact.Finished() && xact.Err() == errFail, "expected %v, got %v", errFail, xact.Err())
+
+	xact = cmn.NewXactBase(cmn.XactBaseID("xid2"), cmn.ActLRU)
+	xact.Finish()
+	tassert.Errorf(t, xact.Finished() && xact.Err() == nil, "expected no error, got %v", xact.Err())
+
+	started := time.Now()
+	rec := &cmn.XactRecord{BaseXactStats: cmn.BaseXactStats{StartTimeX: started, EndTimeX: started.Add(time.Minute)}}
+	tassert.Errorf(t, rec.Duration() == time.Minute, "expected %v, got %v", time.Minute, rec.Duration())
+}
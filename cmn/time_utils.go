@@ -29,3 +29,11 @@ func S2Duration(s string) (time.Duration, error) {
 func UnixNano2S(unixnano int64) string   { return strconv.FormatInt(unixnano, 10) }
 func S2UnixNano(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) }
 func IsTimeZero(t time.Time) bool        { return t.IsZero() || t.UTC().Unix() == 0 } // https://github.com/golang/go/issues/33597
+
+// S2Time parses either RFC3339 time or Unix time in nanoseconds
+func S2Time(s string) (time.Time, error) {
+	if unixnano, err := S2UnixNano(s); err == nil {
+		return time.Unix(0, unixnano), nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
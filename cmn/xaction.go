@@ -7,8 +7,11 @@ package cmn
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strings"
+	ratomic "sync/atomic"
 	"time"
+	"unsafe"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -21,6 +24,15 @@ const (
 	// Default demand xaction idle timeout: how long the xaction must live after
 	// the end of the last request.
 	xactIdleTimeout = 2 * time.Minute
+
+	// how often a paused xaction checks whether it has been resumed
+	xactPauseCheckIval = time.Second
+
+	// progress estimation: the moving averages of the rates are updated (upon
+	// xaction stats requests) at most once per xactRateIval, with the weight of
+	// the past decaying with the time constant xactRateTau
+	xactRateIval = time.Second
+	xactRateTau  = 10 * time.Second
 )
 
 type (
@@ -42,14 +54,20 @@ type (
 		String() string
 		Finished() bool
 		Aborted() bool
+		Paused() bool
 		ChanAbort() <-chan struct{}
 		IsMountpathXact() bool
 		Result() (interface{}, error)
+		Err() error
 		Stats() XactStats
+		Tags() XactTags
 
 		// modifiers
 		Abort()
+		Pause()
+		Resume()
 		AddNotif(n Notif)
+		SetTags(tags XactTags)
 	}
 
 	XactStats interface {
@@ -61,8 +79,14 @@ type (
 		ObjCount() int64
 		BytesCount() int64
 		Aborted() bool
+		Paused() bool
 		Running() bool
 		Finished() bool
+		Tags() XactTags
+		TotalCount() int64
+		DoneCount() int64
+		Throughput() int64
+		ETA() time.Duration
 	}
 
 	XactBase struct {
@@ -75,7 +99,22 @@ type (
 		bck     Bck
 		abrt    chan struct{}
 		aborted atomic.Bool
+		paused  atomic.Bool
 		notif   *NotifXact
+		tags    XactTags
+		err     unsafe.Pointer // *error: the error the xaction has finished with (see Finish)
+		total   atomic.Int64   // number of objects to process, if known
+		done    atomic.Int64   // number of objects processed so far
+		rate    unsafe.Pointer // *xactRate: the latest sample (see EstimateProgress)
+	}
+
+	// sampled progress and the moving averages of the rates (per second)
+	xactRate struct {
+		time     time.Time
+		done     int64
+		bytes    int64
+		doneRate float64
+		byteRate float64
 	}
 
 	XactBaseID string
@@ -136,8 +175,14 @@ func (b *BaseXactStats) EndTime() time.Time   { return b.EndTimeX }
 func (b *BaseXactStats) ObjCount() int64      { return b.ObjCountX }
 func (b *BaseXactStats) BytesCount() int64    { return b.BytesCountX }
 func (b *BaseXactStats) Aborted() bool        { return b.AbortedX }
+func (b *BaseXactStats) Paused() bool         { return b.PausedX }
 func (b *BaseXactStats) Running() bool        { return b.EndTimeX.IsZero() }
 func (b *BaseXactStats) Finished() bool       { return !b.EndTimeX.IsZero() }
+func (b *BaseXactStats) Tags() XactTags       { return b.TagsX }
+func (b *BaseXactStats) TotalCount() int64    { return b.TotalCountX }
+func (b *BaseXactStats) DoneCount() int64     { return b.DoneCountX }
+func (b *BaseXactStats) Throughput() int64    { return b.ThroughputX }
+func (b *BaseXactStats) ETA() time.Duration   { return b.EtaX }
 
 //
 // XactBase - partially implements Xact interface
@@ -162,6 +207,11 @@ func (xact *XactBase) Bck() Bck                   { return xact.bck }
 func (xact *XactBase) Finished() bool             { return xact.eutime.Load() != 0 }
 func (xact *XactBase) ChanAbort() <-chan struct{} { return xact.abrt }
 func (xact *XactBase) Aborted() bool              { return xact.aborted.Load() }
+func (xact *XactBase) Paused() bool               { return xact.paused.Load() }
+func (xact *XactBase) Tags() XactTags             { return xact.tags }
+
+// NOTE: must be called before the xaction runs
+func (xact *XactBase) SetTags(tags XactTags) { xact.tags = tags }
 
 func (xact *XactBase) String() string {
 	var (
@@ -234,19 +284,58 @@ func (xact *XactBase) Abort() {
 	glog.Infof("ABORT: " + xact.String())
 }
 
+// Pause and Resume are advisory: the xaction's workers that support it (see
+// XactMetadata.Pausable) call WaitIfPaused in between the objects, so that
+// the xaction yields its resources without losing progress; aborting the paused
+// xaction releases the waiters
+func (xact *XactBase) Pause() {
+	if xact.Finished() || !xact.paused.CAS(false, true) {
+		return
+	}
+	glog.Infof("PAUSE: " + xact.String())
+}
+
+func (xact *XactBase) Resume() {
+	if !xact.paused.CAS(true, false) {
+		return
+	}
+	glog.Infof("RESUME: " + xact.String())
+}
+
+// WaitIfPaused blocks while the xaction is paused; returns upon resume or abort
+func (xact *XactBase) WaitIfPaused() {
+	for xact.paused.Load() {
+		select {
+		case <-xact.abrt:
+			return
+		case <-time.After(xactPauseCheckIval):
+		}
+	}
+}
+
 func (xact *XactBase) Finish(errs ...error) {
+	var err error
+	if len(errs) > 0 {
+		err = errs[0]
+	}
+	if err != nil {
+		ratomic.StorePointer(&xact.err, unsafe.Pointer(&err))
+	}
 	xact.setEndTime()
 
 	// notifications
 	if n := xact.Notif(); n != nil && n.Upon(UponTerm) {
-		var err error
-		if len(errs) > 0 {
-			err = errs[0]
-		}
 		n.Callback(n, err)
 	}
 }
 
+func (xact *XactBase) Err() error {
+	if p := ratomic.LoadPointer(&xact.err); p != nil {
+		return *(*error)(p)
+	}
+	return nil
+}
+
 func (xact *XactBase) Result() (interface{}, error) {
 	return nil, errors.New("getting result is not implemented")
 }
@@ -256,10 +345,22 @@ func (xact *XactBase) ObjectsInc() int64          { return xact.objects.Inc() }
 func (xact *XactBase) ObjectsAdd(cnt int64) int64 { return xact.objects.Add(cnt) }
 func (xact *XactBase) BytesCount() int64          { return xact.bytes.Load() }
 func (xact *XactBase) BytesAdd(size int64) int64  { return xact.bytes.Add(size) }
+func (xact *XactBase) TotalCount() int64          { return xact.total.Load() }
+func (xact *XactBase) TotalAdd(cnt int64) int64   { return xact.total.Add(cnt) }
+func (xact *XactBase) DoneCount() int64           { return xact.done.Load() }
+func (xact *XactBase) DoneInc() int64             { return xact.done.Inc() }
 
 func (xact *XactBase) IsMountpathXact() bool { Assert(false); return true } // must implement
 
 func (xact *XactBase) Stats() XactStats {
+	stats := xact.BaseStats()
+	xact.EstimateProgress(stats)
+	return stats
+}
+
+// BaseStats returns the stats without the progress estimation - for the
+// xactions that count (some of) the stats on their own, to estimate afterwards
+func (xact *XactBase) BaseStats() *BaseXactStats {
 	return &BaseXactStats{
 		IDX:         xact.ID().String(),
 		KindX:       xact.Kind(),
@@ -269,6 +370,54 @@ func (xact *XactBase) Stats() XactStats {
 		ObjCountX:   xact.ObjCount(),
 		BytesCountX: xact.BytesCount(),
 		AbortedX:    xact.Aborted(),
+		PausedX:     xact.Paused(),
+		TagsX:       xact.Tags(),
+		TotalCountX: xact.TotalCount(),
+		DoneCountX:  xact.DoneCount(),
+	}
+}
+
+// EstimateProgress fills in the throughput and the estimated time to completion
+// given the stats' byte and done counts. The rates are exponential moving
+// averages over the samples taken upon (polling) stats requests - the first
+// sample averages from the start. The time to completion requires the total
+// count, and is not estimated while paused. Once finished, the throughput is
+// the average over the xaction's lifetime.
+func (xact *XactBase) EstimateProgress(stats *BaseXactStats) {
+	if stats.Finished() {
+		if d := stats.EndTimeX.Sub(stats.StartTimeX); d > 0 {
+			stats.ThroughputX = int64(float64(stats.BytesCountX) / d.Seconds())
+		}
+		return
+	}
+	var (
+		now  = time.Now()
+		prev = (*xactRate)(ratomic.LoadPointer(&xact.rate))
+		curr = prev
+	)
+	if prev == nil || now.Sub(prev.time) >= xactRateIval {
+		curr = &xactRate{time: now, done: stats.DoneCountX, bytes: stats.BytesCountX}
+		if prev == nil {
+			if secs := now.Sub(stats.StartTimeX).Seconds(); secs > 0 {
+				curr.doneRate = float64(curr.done) / secs
+				curr.byteRate = float64(curr.bytes) / secs
+			}
+		} else {
+			secs := now.Sub(prev.time).Seconds()
+			alpha := 1 - math.Exp(-secs/xactRateTau.Seconds())
+			curr.doneRate = prev.doneRate + alpha*(float64(curr.done-prev.done)/secs-prev.doneRate)
+			curr.byteRate = prev.byteRate + alpha*(float64(curr.bytes-prev.bytes)/secs-prev.byteRate)
+		}
+		if !ratomic.CompareAndSwapPointer(&xact.rate, unsafe.Pointer(prev), unsafe.Pointer(curr)) {
+			curr = (*xactRate)(ratomic.LoadPointer(&xact.rate)) // (concurrent stats request)
+		}
+	}
+	stats.ThroughputX = int64(curr.byteRate)
+	if stats.TotalCountX > stats.DoneCountX && curr.doneRate > 0 && !stats.PausedX {
+		secs := float64(stats.TotalCountX-stats.DoneCountX) / curr.doneRate
+		if stats.EtaX = time.Duration(secs * float64(time.Second)); stats.EtaX > time.Second {
+			stats.EtaX = stats.EtaX.Round(time.Second)
+		}
 	}
 }
 
@@ -0,0 +1,90 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+// Xaction budgets
+//
+// The budgeted xactions call XactBase.Throttle with the size of each object
+// they are about to process. Given XactBudgetConf, the calls get paced so that
+// each running xaction kind consumes its weighted part of the budget: the kind
+// keeps the (monotonic) time when its next call may proceed, and each call
+// pushes that time forward by size/bandwidth. A kind counts as running for
+// xbudgetIdle after its last call, so that the xactions that have nothing to
+// process do not take their parts; a kind that has been lagging behind its
+// pace may catch up by at most xbudgetBurst.
+
+const (
+	xbudgetIdle  = time.Second
+	xbudgetBurst = 100 * time.Millisecond
+	xbudgetGC    = time.Minute // forget the kinds that have not been running
+)
+
+type (
+	xactBudget struct {
+		mtx   sync.Mutex
+		kinds map[string]*kindBudget
+	}
+	kindBudget struct {
+		next int64 // mono time when the next call may proceed
+		last int64 // mono time of the last call
+	}
+)
+
+var xbudget = &xactBudget{kinds: make(map[string]*kindBudget, 4)}
+
+// Throttle blocks the caller for as long as it takes for the xaction to stay
+// within its kind's part of the budget (see XactBudgetConf) - or until the
+// xaction gets aborted
+func (xact *XactBase) Throttle(size int64) {
+	conf := &GCO.Get().XactBudget
+	if conf.DiskBW == 0 {
+		return
+	}
+	wait := xbudget.delay(xact.kind, size, conf, mono.NanoTime())
+	if wait <= 0 {
+		return
+	}
+	timer := time.NewTimer(wait)
+	select {
+	case <-timer.C:
+	case <-xact.abrt:
+		timer.Stop()
+	}
+}
+
+// returns how long to wait before processing `size` bytes
+func (xb *xactBudget) delay(kind string, size int64, conf *XactBudgetConf, now int64) time.Duration {
+	xb.mtx.Lock()
+	kb, ok := xb.kinds[kind]
+	if !ok {
+		kb = &kindBudget{next: now}
+		xb.kinds[kind] = kb
+	}
+	kb.last = now
+	var total int64
+	for k, b := range xb.kinds {
+		switch idle := time.Duration(now - b.last); {
+		case idle <= xbudgetIdle:
+			total += conf.weight(k)
+		case idle > xbudgetGC:
+			delete(xb.kinds, k)
+		}
+	}
+	bw := float64(conf.DiskBW) * float64(conf.Share) / 100 * float64(conf.weight(kind)) / float64(total)
+	if lag := now - int64(xbudgetBurst); kb.next < lag {
+		kb.next = lag
+	}
+	wait := time.Duration(MaxI64(kb.next-now, 0))
+	kb.next += int64(float64(size) / bw * float64(time.Second))
+	xb.mtx.Unlock()
+	return wait
+}
@@ -0,0 +1,44 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"testing"
+	"time"
+)
+
+func TestXactBudgetDelay(t *testing.T) {
+	var (
+		xb   = &xactBudget{kinds: make(map[string]*kindBudget, 4)}
+		conf = &XactBudgetConf{DiskBW: 100 * MiB, Share: 50, Weights: map[string]int{ActRebalance: 4}}
+		now  = int64(time.Hour)
+	)
+	check := func(kind string, size int64, now int64, expected time.Duration) {
+		if wait := xb.delay(kind, size, conf, now); wait != expected {
+			t.Errorf("%s: expected to wait %v, got %v", kind, expected, wait)
+		}
+	}
+	// alone, rebalance gets the entire budget: 50MiB/s
+	check(ActRebalance, 10*MiB, now, 0)
+	check(ActRebalance, 10*MiB, now, 200*time.Millisecond)
+	// EC encoding (weight 1) joins: 10MiB/s vs 40MiB/s
+	check(ActECPut, MiB, now, 0)
+	check(ActECPut, MiB, now, 100*time.Millisecond)
+	check(ActRebalance, 10*MiB, now, 400*time.Millisecond)
+	check(ActRebalance, 0, now, 650*time.Millisecond)
+
+	// EC encoding goes idle - rebalance gets it all back (having caught up by the burst)
+	now += int64(2 * time.Second)
+	check(ActRebalance, 10*MiB, now, 0)
+	check(ActRebalance, 0, now, 200*time.Millisecond-xbudgetBurst)
+
+	// no catching up beyond the burst
+	now += int64(time.Hour)
+	check(ActRebalance, 50*MiB, now, 0)
+	check(ActRebalance, 0, now, time.Second-xbudgetBurst)
+	if _, ok := xb.kinds[ActECPut]; ok {
+		t.Errorf("expected idle %s to be forgotten", ActECPut)
+	}
+}
@@ -69,7 +69,7 @@ func makePath(collection, key string) string {
 }
 
 // Extract collection and key names from full key path
-func parsePath(path string) (string, string) { // nolint:unparam // unused now but will be used later
+func parsePath(path string) (string, string) {
 	pos := strings.Index(path, collectionSepa)
 	if pos < 0 {
 		return path, ""
@@ -183,3 +183,29 @@ func (bd *BuntDriver) GetAll(collection, pattern string) (map[string]string, err
 	})
 	return values, buntToCommonErr(err, collection, "")
 }
+
+// ImportBuntDB copies the entire content of the BuntDB database at a given path
+// into another driver - e.g., to migrate to LogDriver
+func ImportBuntDB(path string, dst Driver) (n int, err error) {
+	db, err := buntdb.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer db.Close()
+	err = db.View(func(tx *buntdb.Tx) error {
+		var errSet error
+		tx.AscendKeys("*", func(path, val string) bool {
+			collection, key := parsePath(path)
+			if key == "" {
+				return true
+			}
+			if errSet = dst.SetString(collection, key, val); errSet != nil {
+				return false
+			}
+			n++
+			return true
+		})
+		return errSet
+	})
+	return
+}
@@ -0,0 +1,503 @@
+// Package dbdriver provides a local database server for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package dbdriver
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/OneOfOne/xxhash"
+	jsoniter "github.com/json-iterator/go"
+	"github.com/tidwall/match"
+)
+
+// LogDriver:
+// A simple log-structured key-value store: one append-only log file per
+// (mountpath) directory. Each key is "homed" in one of the files (by hash),
+// all values are kept in memory.
+// - every update (set or delete) is appended to the key's home file as a
+//   single checksummed record and synced to disk prior to returning;
+// - on open, all files are replayed, the last (by sequence number) update of
+//   each key wins, and a torn (partially written) record at the end of a file
+//   is discarded;
+// - on open, and when the garbage (overwritten and deleted records) in a file
+//   grows beyond `autoShrinkSize` and half of the file's size, the file gets
+//   compacted - rewritten with only the live records;
+// - on open, the records that change their home get appended to the new home
+//   prior to compacting (and thus removing them from) the old one;
+// - each file lists all the files of the store (see logMembersKey); while some
+//   of them are missing, the deleted keys are kept as tombstones - so that the
+//   stale records of a returning file don't resurrect them.
+//
+// Record format (little endian):
+//   crc32(4) | length of the rest(4) | op(1) | seq(8) | key length(4) | key | value
+
+const (
+	logRecHdrSize  = 8
+	logRecMetaSize = 1 + 8 + 4
+
+	logOpSet = byte(1)
+	logOpDel = byte(2)
+
+	logMembersKey = "\x00members" // not a valid path (see makePath)
+)
+
+type (
+	LogDriver struct {
+		mtx   sync.RWMutex
+		files []*logFile
+		index map[string]*logEntry // full path (see makePath) => value
+		tombs map[string]*logEntry // deleted keys (kept while some of the files are missing)
+		// all the files of the store, including those that are currently missing
+		members []string
+		seq     uint64
+	}
+	logFile struct {
+		path string
+		fh   *os.File
+		idx  int // in LogDriver.files
+		size int64
+		live int64 // total size of the live records
+	}
+	logEntry struct {
+		value string
+		seq   uint64
+		size  int64
+	}
+)
+
+var (
+	_ Driver = &LogDriver{}
+
+	errLogCorrupted = errors.New("corrupted record")
+)
+
+// NewLogDriver opens (or creates) the store given the full pathnames of its log files.
+// The set of files may change between restarts - the keys are rehomed upon open.
+func NewLogDriver(paths ...string) (ld *LogDriver, err error) {
+	var records map[string]*logRecord
+
+	// 1. replay
+	if ld, records, err = replayLogs(paths); err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err != nil {
+			ld.Close()
+		}
+	}()
+
+	// 2. rehome: append to the new home first
+	if err = ld.rehome(records); err != nil {
+		return nil, err
+	}
+
+	// 3. compact, keeping the tombstones (the stale records may still be elsewhere)
+	for full, rec := range records {
+		entry := &logEntry{value: rec.value, seq: rec.seq}
+		entry.size = int64(len(encodeLogRecord(rec.op, rec.seq, full, rec.value)))
+		if rec.op == logOpDel {
+			ld.tombs[full] = entry
+		} else {
+			ld.index[full] = entry
+		}
+	}
+	for _, lf := range ld.files {
+		if err = ld.compact(lf, true /*keep tombstones*/); err != nil {
+			return nil, err
+		}
+	}
+
+	// 4. and drop them - unless some of the files are missing
+	if len(ld.tombs) > 0 && !ld.keepTombs() {
+		ld.tombs = make(map[string]*logEntry)
+		for _, lf := range ld.files {
+			if err = ld.compact(lf, false); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ld, nil
+}
+
+func replayLogs(paths []string) (ld *LogDriver, records map[string]*logRecord, err error) {
+	cmn.Assert(len(paths) > 0)
+	ld = &LogDriver{
+		files: make([]*logFile, 0, len(paths)),
+		index: make(map[string]*logEntry, 64),
+		tombs: make(map[string]*logEntry),
+	}
+	sort.Strings(paths)
+	records = make(map[string]*logRecord, 64)
+	members := make(cmn.StringSet, len(paths))
+	for _, path := range paths {
+		if err = cmn.CreateDir(filepath.Dir(path)); err != nil {
+			return
+		}
+		lf := &logFile{path: path, idx: len(ld.files)}
+		if lf.size, err = replayLog(path, lf.idx, records, members); err != nil {
+			return
+		}
+		ld.files = append(ld.files, lf)
+		members.Add(path)
+	}
+	ld.members = members.Keys()
+	sort.Strings(ld.members)
+	for _, rec := range records {
+		if rec.seq > ld.seq {
+			ld.seq = rec.seq
+		}
+	}
+	return
+}
+
+// append the records that are not in their home file yet
+func (ld *LogDriver) rehome(records map[string]*logRecord) error {
+	bufs := make([][]byte, len(ld.files))
+	for full, rec := range records {
+		if home := ld.home(full); home != rec.idx {
+			bufs[home] = append(bufs[home], encodeLogRecord(rec.op, rec.seq, full, rec.value)...)
+		}
+	}
+	for idx, buf := range bufs {
+		if len(buf) == 0 {
+			continue
+		}
+		lf := ld.files[idx]
+		if err := lf.open(); err != nil {
+			return err
+		}
+		if err := lf.append(buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ld *LogDriver) keepTombs() bool { return len(ld.members) > len(ld.files) }
+
+func (ld *LogDriver) home(full string) int {
+	return int(xxhash.ChecksumString64S(full, cmn.MLCG32) % uint64(len(ld.files)))
+}
+
+func (ld *LogDriver) Close() (err error) {
+	ld.mtx.Lock()
+	for _, lf := range ld.files {
+		if lf.fh == nil {
+			continue
+		}
+		if erc := lf.fh.Close(); erc != nil && err == nil {
+			err = erc
+		}
+		lf.fh = nil
+	}
+	ld.mtx.Unlock()
+	return
+}
+
+func (ld *LogDriver) Set(collection, key string, object interface{}) error {
+	b := cmn.MustMarshal(object)
+	return ld.SetString(collection, key, string(b))
+}
+
+func (ld *LogDriver) Get(collection, key string, object interface{}) error {
+	s, err := ld.GetString(collection, key)
+	if err != nil {
+		return err
+	}
+	return jsoniter.Unmarshal([]byte(s), object)
+}
+
+func (ld *LogDriver) SetString(collection, key, data string) error {
+	full := makePath(collection, key)
+	ld.mtx.Lock()
+	defer ld.mtx.Unlock()
+	return ld.update(logOpSet, full, data)
+}
+
+func (ld *LogDriver) GetString(collection, key string) (string, error) {
+	ld.mtx.RLock()
+	entry, ok := ld.index[makePath(collection, key)]
+	ld.mtx.RUnlock()
+	if !ok {
+		return "", NewErrNotFound(collection, key)
+	}
+	return entry.value, nil
+}
+
+func (ld *LogDriver) Delete(collection, key string) error {
+	full := makePath(collection, key)
+	ld.mtx.Lock()
+	defer ld.mtx.Unlock()
+	if _, ok := ld.index[full]; !ok {
+		return NewErrNotFound(collection, key)
+	}
+	return ld.update(logOpDel, full, "")
+}
+
+func (ld *LogDriver) DeleteCollection(collection string) error {
+	ld.mtx.Lock()
+	defer ld.mtx.Unlock()
+	for _, full := range ld.match(collection, "") {
+		if err := ld.update(logOpDel, full, ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (ld *LogDriver) List(collection, pattern string) ([]string, error) {
+	ld.mtx.RLock()
+	paths := ld.match(collection, pattern)
+	ld.mtx.RUnlock()
+	keys := make([]string, 0, len(paths))
+	for _, full := range paths {
+		_, key := parsePath(full)
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+func (ld *LogDriver) GetAll(collection, pattern string) (map[string]string, error) {
+	ld.mtx.RLock()
+	defer ld.mtx.RUnlock()
+	paths := ld.match(collection, pattern)
+	values := make(map[string]string, len(paths))
+	for _, full := range paths {
+		_, key := parsePath(full)
+		values[key] = ld.index[full].value
+	}
+	return values, nil
+}
+
+// under (r)lock; the semantics of the pattern is the same as in BuntDriver
+func (ld *LogDriver) match(collection, pattern string) (paths []string) {
+	if !strings.Contains(pattern, "*") && !strings.Contains(pattern, "?") {
+		pattern += "*"
+	}
+	prefix := makePath(collection, "")
+	for full := range ld.index {
+		if !strings.HasPrefix(full, prefix) {
+			continue
+		}
+		if key := full[len(prefix):]; key != "" && match.Match(key, pattern) {
+			paths = append(paths, full)
+		}
+	}
+	return
+}
+
+// under lock
+func (ld *LogDriver) update(op byte, full, value string) error {
+	var (
+		lf  = ld.files[ld.home(full)]
+		seq = ld.seq + 1
+		b   = encodeLogRecord(op, seq, full, value)
+	)
+	if err := lf.append(b); err != nil {
+		return err
+	}
+	ld.seq = seq
+	if prev, ok := ld.index[full]; ok {
+		lf.live -= prev.size
+	}
+	if prev, ok := ld.tombs[full]; ok {
+		delete(ld.tombs, full)
+		lf.live -= prev.size
+	}
+	entry := &logEntry{value: value, seq: seq, size: int64(len(b))}
+	if op == logOpDel {
+		delete(ld.index, full)
+		if ld.keepTombs() {
+			ld.tombs[full] = entry
+			lf.live += entry.size
+		}
+	} else {
+		ld.index[full] = entry
+		lf.live += entry.size
+	}
+	if garbage := lf.size - lf.live; garbage > autoShrinkSize && garbage > lf.size/2 {
+		if err := ld.compact(lf, ld.keepTombs()); err != nil {
+			glog.Errorf("failed to compact %s: %v", lf.path, err)
+		}
+	}
+	return nil
+}
+
+// rewrite a given file with only the live records homed in it (and the tombstones, if requested)
+func (ld *LogDriver) compact(lf *logFile, tombs bool) error {
+	buf := encodeLogRecord(logOpSet, 0, logMembersKey, string(cmn.MustMarshal(ld.members)))
+	for full, entry := range ld.index {
+		if ld.home(full) == lf.idx {
+			buf = append(buf, encodeLogRecord(logOpSet, entry.seq, full, entry.value)...)
+		}
+	}
+	if tombs {
+		for full, entry := range ld.tombs {
+			if ld.home(full) == lf.idx {
+				buf = append(buf, encodeLogRecord(logOpDel, entry.seq, full, "")...)
+			}
+		}
+	}
+	if err := lf.rewrite(buf); err != nil {
+		return err
+	}
+	lf.live = lf.size
+	return nil
+}
+
+/////////////
+// logFile //
+/////////////
+
+// open for appending, discarding a torn record at the end (if any)
+func (lf *logFile) open() (err error) {
+	if lf.fh, err = os.OpenFile(lf.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644); err != nil {
+		return
+	}
+	return lf.fh.Truncate(lf.size)
+}
+
+func (lf *logFile) append(b []byte) error {
+	if _, err := lf.fh.Write(b); err != nil {
+		// discard the (possibly) partially written record
+		if ert := lf.fh.Truncate(lf.size); ert != nil {
+			glog.Errorf("failed to truncate %s: %v", lf.path, ert)
+		}
+		return err
+	}
+	lf.size += int64(len(b))
+	return lf.fh.Sync()
+}
+
+// atomically replace the file's content, and reopen it for appending
+func (lf *logFile) rewrite(buf []byte) (err error) {
+	tmp := lf.path + ".tmp"
+	if err = writeSynced(tmp, buf); err != nil {
+		os.Remove(tmp)
+		return
+	}
+	if lf.fh != nil {
+		lf.fh.Close()
+		lf.fh = nil
+	}
+	if err = os.Rename(tmp, lf.path); err != nil {
+		return
+	}
+	if lf.fh, err = os.OpenFile(lf.path, os.O_WRONLY|os.O_APPEND, 0644); err != nil {
+		return
+	}
+	lf.size = int64(len(buf))
+	return
+}
+
+func writeSynced(path string, buf []byte) error {
+	fh, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = fh.Write(buf); err == nil {
+		err = fh.Sync()
+	}
+	if erc := fh.Close(); err == nil {
+		err = erc
+	}
+	return err
+}
+
+/////////////
+// records //
+/////////////
+
+type logRecord struct {
+	op    byte
+	seq   uint64
+	value string
+	idx   int // the file the record comes from
+}
+
+func encodeLogRecord(op byte, seq uint64, full, value string) []byte {
+	var (
+		l = logRecMetaSize + len(full) + len(value)
+		b = make([]byte, logRecHdrSize+l)
+	)
+	binary.LittleEndian.PutUint32(b[4:], uint32(l))
+	b[logRecHdrSize] = op
+	binary.LittleEndian.PutUint64(b[logRecHdrSize+1:], seq)
+	binary.LittleEndian.PutUint32(b[logRecHdrSize+9:], uint32(len(full)))
+	copy(b[logRecHdrSize+logRecMetaSize:], full)
+	copy(b[logRecHdrSize+logRecMetaSize+len(full):], value)
+	binary.LittleEndian.PutUint32(b, crc32.ChecksumIEEE(b[logRecHdrSize:]))
+	return b
+}
+
+func decodeLogRecord(b []byte) (full string, rec *logRecord, n int, err error) {
+	if len(b) < logRecHdrSize {
+		return "", nil, 0, errLogCorrupted
+	}
+	l := int(binary.LittleEndian.Uint32(b[4:]))
+	if l < logRecMetaSize || len(b) < logRecHdrSize+l {
+		return "", nil, 0, errLogCorrupted
+	}
+	body := b[logRecHdrSize : logRecHdrSize+l]
+	if crc32.ChecksumIEEE(body) != binary.LittleEndian.Uint32(b) {
+		return "", nil, 0, errLogCorrupted
+	}
+	kl := int(binary.LittleEndian.Uint32(body[9:]))
+	if logRecMetaSize+kl > l {
+		return "", nil, 0, errLogCorrupted
+	}
+	rec = &logRecord{op: body[0], seq: binary.LittleEndian.Uint64(body[1:])}
+	full = string(body[logRecMetaSize : logRecMetaSize+kl])
+	rec.value = string(body[logRecMetaSize+kl:])
+	return full, rec, logRecHdrSize + l, nil
+}
+
+// read all the records of a given file (if exists) - the newer ones override -
+// and the files it lists; returns the size of the valid part of the file
+func replayLog(path string, idx int, records map[string]*logRecord, members cmn.StringSet) (int64, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	off := 0
+	for off < len(b) {
+		full, rec, n, err := decodeLogRecord(b[off:])
+		if err != nil {
+			// torn write (the rest of the file is rewritten upon open)
+			glog.Errorf("%s: %v at offset %d (size %d) - discarding the rest", path, err, off, len(b))
+			break
+		}
+		off += n
+		if full == logMembersKey {
+			var paths []string
+			if err := jsoniter.Unmarshal([]byte(rec.value), &paths); err != nil {
+				glog.Errorf("%s: invalid list of files: %v", path, err)
+			}
+			for _, p := range paths {
+				members.Add(p)
+			}
+			continue
+		}
+		rec.idx = idx
+		if prev, ok := records[full]; !ok || prev.seq < rec.seq {
+			records[full] = rec
+		}
+	}
+	return int64(off), nil
+}
@@ -0,0 +1,193 @@
+// Package dbdriver provides a local database server for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package dbdriver
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+type testObj struct {
+	A int `json:"a"`
+}
+
+func logPaths(t *testing.T, n int) []string {
+	dir, err := ioutil.TempDir("", "logkv")
+	tassert.CheckFatal(t, err)
+	paths := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		paths = append(paths, filepath.Join(dir, fmt.Sprintf("mp%d", i), ".ais.kvlog"))
+	}
+	return paths
+}
+
+func TestLogDriverReopen(t *testing.T) {
+	paths := logPaths(t, 3)
+	defer os.RemoveAll(filepath.Dir(filepath.Dir(paths[0])))
+
+	ld, err := NewLogDriver(paths...)
+	tassert.CheckFatal(t, err)
+	for i := 0; i < 100; i++ {
+		tassert.CheckFatal(t, ld.SetString("coll", fmt.Sprintf("key-%02d", i), fmt.Sprintf("value-%d", i)))
+	}
+	tassert.CheckFatal(t, ld.Set("other", "key-00", &testObj{A: 1}))
+	for i := 0; i < 100; i += 2 {
+		tassert.CheckFatal(t, ld.Delete("coll", fmt.Sprintf("key-%02d", i)))
+	}
+	tassert.CheckFatal(t, ld.SetString("coll", "key-01", "updated"))
+	tassert.CheckFatal(t, ld.Close())
+
+	// reopen with a different set of files - the keys get rehomed
+	ld, err = NewLogDriver(paths[1:]...)
+	tassert.CheckFatal(t, err)
+	ld.Close()
+	ld, err = NewLogDriver(paths...)
+	tassert.CheckFatal(t, err)
+	defer ld.Close()
+
+	keys, err := ld.List("coll", "")
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(keys) == 50, "expected 50 keys, got %d", len(keys))
+	_, err = ld.GetString("coll", "key-00")
+	tassert.Errorf(t, IsErrNotFound(err), "expected not-found, got %v", err)
+	value, err := ld.GetString("coll", "key-01")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, value == "updated", "expected %q, got %q", "updated", value)
+	obj := &testObj{}
+	tassert.CheckFatal(t, ld.Get("other", "key-00", obj))
+	tassert.Errorf(t, obj.A == 1, "unexpected %+v", obj)
+
+	values, err := ld.GetAll("coll", "key-?1")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(values) == 10, "expected 10 values, got %d", len(values))
+
+	tassert.CheckFatal(t, ld.DeleteCollection("coll"))
+	keys, err = ld.List("coll", "")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(keys) == 0, "expected no keys, got %v", keys)
+}
+
+func TestLogDriverTornWrite(t *testing.T) {
+	paths := logPaths(t, 1)
+	defer os.RemoveAll(filepath.Dir(filepath.Dir(paths[0])))
+
+	ld, err := NewLogDriver(paths...)
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, ld.SetString("coll", "a", "1"))
+	tassert.CheckFatal(t, ld.SetString("coll", "b", "2"))
+	tassert.CheckFatal(t, ld.Close())
+
+	// simulate a crash in the middle of appending a record
+	b := encodeLogRecord(logOpSet, 100, makePath("coll", "c"), "3")
+	fh, err := os.OpenFile(paths[0], os.O_WRONLY|os.O_APPEND, 0644)
+	tassert.CheckFatal(t, err)
+	_, err = fh.Write(b[:len(b)-1])
+	tassert.CheckFatal(t, err)
+	fh.Close()
+
+	ld, err = NewLogDriver(paths...)
+	tassert.CheckFatal(t, err)
+	defer ld.Close()
+	keys, err := ld.List("coll", "")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(keys) == 2 && keys[0] == "a" && keys[1] == "b", "unexpected keys %v", keys)
+
+	// and keeps going
+	tassert.CheckFatal(t, ld.SetString("coll", "c", "3"))
+	value, err := ld.GetString("coll", "c")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, value == "3", "expected %q, got %q", "3", value)
+}
+
+// the keys deleted while one of the files is missing stay deleted once it returns
+func TestLogDriverMissingFile(t *testing.T) {
+	paths := logPaths(t, 3)
+	defer os.RemoveAll(filepath.Dir(filepath.Dir(paths[0])))
+
+	ld, err := NewLogDriver(paths...)
+	tassert.CheckFatal(t, err)
+	for i := 0; i < 30; i++ {
+		tassert.CheckFatal(t, ld.SetString("coll", fmt.Sprintf("key-%02d", i), "value"))
+	}
+	tassert.CheckFatal(t, ld.Close())
+
+	// the last file goes missing (with its records intact)
+	ld, err = NewLogDriver(paths[:2]...)
+	tassert.CheckFatal(t, err)
+	visible, err := ld.List("coll", "")
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(visible) > 0 && len(visible) < 30, "expected some of the keys to be missing")
+	tassert.CheckFatal(t, ld.DeleteCollection("coll"))
+	tassert.CheckFatal(t, ld.SetString("coll", "key-00", "new"))
+	tassert.CheckFatal(t, ld.Close())
+
+	// reopen again without it - tombstones survive compaction
+	ld, err = NewLogDriver(paths[:2]...)
+	tassert.CheckFatal(t, err)
+	deleted := len(ld.tombs)
+	tassert.CheckFatal(t, ld.Close())
+
+	// and returns
+	ld, err = NewLogDriver(paths...)
+	tassert.CheckFatal(t, err)
+	keys, err := ld.List("coll", "")
+	tassert.CheckFatal(t, err)
+	for _, key := range visible {
+		_, err := ld.GetString("coll", key)
+		if key == "key-00" {
+			tassert.CheckError(t, err)
+		} else {
+			tassert.Errorf(t, IsErrNotFound(err), "expected deleted %q to stay deleted, got %v", key, err)
+		}
+	}
+	// (the keys that were missing along with the file return with it)
+	tassert.Errorf(t, len(keys) == 30-len(visible)+1, "expected %d keys, got %v", 30-len(visible)+1, keys)
+	tassert.Errorf(t, deleted >= len(visible)-1, "expected at least %d tombstones, got %d", len(visible)-1, deleted)
+	value, err := ld.GetString("coll", "key-00")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, value == "new", "expected %q, got %q", "new", value)
+	// all files are present - no more tombstones
+	tassert.Errorf(t, len(ld.tombs) == 0, "expected no tombstones, got %d", len(ld.tombs))
+	tassert.CheckFatal(t, ld.Close())
+}
+
+// crash after rehoming and compacting only some of the files
+func TestLogDriverCrashRehome(t *testing.T) {
+	paths := logPaths(t, 3)
+	defer os.RemoveAll(filepath.Dir(filepath.Dir(paths[0])))
+
+	ld, err := NewLogDriver(paths...)
+	tassert.CheckFatal(t, err)
+	for i := 0; i < 100; i++ {
+		tassert.CheckFatal(t, ld.SetString("coll", fmt.Sprintf("key-%02d", i), "value"))
+	}
+	tassert.CheckFatal(t, ld.Close())
+
+	for crashAfter := 0; crashAfter < 2; crashAfter++ {
+		ld, records, err := replayLogs(paths[crashAfter : crashAfter+2])
+		tassert.CheckFatal(t, err)
+		tassert.CheckFatal(t, ld.rehome(records))
+		for full, rec := range records {
+			ld.index[full] = &logEntry{value: rec.value, seq: rec.seq}
+		}
+		for _, lf := range ld.files[:crashAfter+1] {
+			tassert.CheckFatal(t, ld.compact(lf, true))
+		}
+		ld.Close()
+
+		ld, err = NewLogDriver(paths...)
+		tassert.CheckFatal(t, err)
+		keys, err := ld.List("coll", "")
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, len(keys) == 100, "crash after compacting %d file(s): expected 100 keys, got %d",
+			crashAfter+1, len(keys))
+		tassert.CheckFatal(t, ld.Close())
+	}
+}
@@ -12,12 +12,20 @@ cat > $AIS_CONF_FILE <<EOL
 		"enabled":      ${MIRROR_ENABLED:-false}
 	},
 	"ec": {
-		"objsize_limit": ${OBJ_SIZE_LIMIT:-262144},
-		"data_slices":   ${DATA_SLICES:-1},
-		"parity_slices": ${PARITY_SLICES:-1},
-		"compression":   "${COMPRESSION:-never}",
-		"enabled":       ${EC_ENABLED:-false},
-		"batch_size":    ${EC_BATCH_SIZE:-64}
+		"objsize_limit":     ${OBJ_SIZE_LIMIT:-262144},
+		"data_slices":       ${DATA_SLICES:-1},
+		"parity_slices":     ${PARITY_SLICES:-1},
+		"compression":       "${COMPRESSION:-never}",
+		"enabled":           ${EC_ENABLED:-false},
+		"batch_size":        ${EC_BATCH_SIZE:-64},
+		"placement":         "${EC_PLACEMENT:-hrw}",
+		"slice_cache_size":  0,
+		"pack_size":         0,
+		"meta_quorum":       "majority",
+		"undelete_window":   "0s",
+		"restore_mem_limit": 0,
+		"slice_fanout":      0,
+		"compress_parity":   false
 	},
 	"log": {
 		"dir":       "${AIS_LOG_DIR:-/tmp/ais$NEXT_TIER/log}",
@@ -27,7 +35,10 @@ cat > $AIS_CONF_FILE <<EOL
 	},
 	"periodic": {
 		"stats_time":        "10s",
-		"retry_sync_time":   "2s"
+		"retry_sync_time":   "2s",
+		"workfile_gc_age":   "${WORKFILE_GC_AGE:-24h}",
+		"empty_dir_gc_age":  "${EMPTY_DIR_GC_AGE:-1h}",
+		"notif_time":        "10s"
 	},
 	"timeout": {
 		"max_keepalive":        "4s",
@@ -36,16 +47,41 @@ cat > $AIS_CONF_FILE <<EOL
 		"startup_time":         "1m",
 		"max_host_busy":        "1m"
 	},
+	"clock": {
+		"skew_tolerance": "2s",
+		"max_skew":       "500ms"
+	},
+	"txn": {
+		"commit_retries": 0,
+		"commit_backoff": ""
+	},
+	"events": {
+		"sinks": []
+	},
+	"xaction_history": {
+		"retention":   "168h",
+		"max_entries": 10000
+	},
+	"xaction_budget": {
+		"disk_bw": "0",
+		"share":   50,
+		"weights": {"rebalance": 2, "ecput": 1}
+	},
+	"promote": {
+		"watch": []
+	},
 	"client": {
 		"client_timeout":      "10s",
 		"client_long_timeout": "30m",
 		"list_timeout":        "2m"
 	},
 	"proxy": {
-		"primary_url":   "${AIS_PRIMARY_URL}",
-		"original_url":  "${AIS_PRIMARY_URL}",
-		"discovery_url": "${AIS_DISCOVERY_URL}",
-		"non_electable": ${NON_ELECTABLE:-false}
+		"primary_url":           "${AIS_PRIMARY_URL}",
+		"original_url":          "${AIS_PRIMARY_URL}",
+		"discovery_url":         "${AIS_DISCOVERY_URL}",
+		"non_electable":         ${NON_ELECTABLE:-false},
+		"join_tokens":           ${JOIN_TOKENS:-false},
+		"mass_delete_threshold": ${MASS_DELETE_THRESHOLD:-0}
 	},
 	"lru": {
 		"lowwm":             75,
@@ -53,14 +89,23 @@ cat > $AIS_CONF_FILE <<EOL
 		"out_of_space":      95,
 		"dont_evict_time":   "120m",
 		"capacity_upd_time": "10m",
-		"enabled":           true
+		"enabled":           true,
+		"spill_on_oos":      false,
+		"redirect_on_oos":   false
+	},
+	"remote_cache": {
+		"bucket":   "",
+		"capacity": 0,
+		"lowwm":    75,
+		"enabled":  false
 	},
 	"disk":{
 	    "iostat_time_long":  "${IOSTAT_TIME_LONG:-2s}",
 	    "iostat_time_short": "${IOSTAT_TIME_SHORT:-100ms}",
 	    "disk_util_low_wm":  20,
 	    "disk_util_high_wm": 80,
-	    "disk_util_max_wm":  95
+	    "disk_util_max_wm":  95,
+	    "walk_workers":      {"default": 1, "nvme": 8}
 	},
 	"rebalance": {
 		"enabled":         true,
@@ -75,7 +120,8 @@ cat > $AIS_CONF_FILE <<EOL
 		"validate_cold_get":	true,
 		"validate_warm_get":	false,
 		"validate_obj_move":	false,
-		"enable_read_range":	false
+		"enable_read_range":	false,
+		"verified_once":	false
 	},
 	"compression": {
 		"block_size": ${BLOCK_SIZE:-262144},
@@ -83,11 +129,15 @@ cat > $AIS_CONF_FILE <<EOL
 	},
 	"versioning": {
 		"enabled":           true,
-		"validate_warm_get": false
+		"validate_warm_get": false,
+		"max_versions":      10
 	},
 	"fspaths": {
 		$AIS_FS_PATHS
 	},
+	"content_routing": {
+		"rules": {}
+	},
 	"test_fspaths": {
 		"root":     "${TEST_FSPATH_ROOT:-/tmp/ais$NEXT_TIER/}",
 		"count":    ${TEST_FSPATH_COUNT:-0},
@@ -97,6 +147,9 @@ cat > $AIS_CONF_FILE <<EOL
 		"ipv4":                 "${IPV4LIST}",
 		"ipv4_intra_control":   "${IPV4LIST_INTRA_CONTROL}",
 		"ipv4_intra_data":      "${IPV4LIST_INTRA_DATA}",
+		"ip_family":               "${IP_FAMILY:-ipv4}",
+		"ip_family_intra_control": "${IP_FAMILY_INTRA_CONTROL:-ipv4}",
+		"ip_family_intra_data":    "${IP_FAMILY_INTRA_DATA:-ipv4}",
 		"l4": {
 			"proto":              "tcp",
 			"port":               "${PORT:-8080}",
@@ -114,12 +167,25 @@ cat > $AIS_CONF_FILE <<EOL
 			"skip_verify":       ${AIS_SKIP_VERIFY_CRT:-false},
 			"rproxy":            "",
 			"rproxy_cache":      true
+		},
+		"listeners": {
+			"public":        {"tls": "", "server_crt": "", "server_key": "", "allowed_cidrs": "${AIS_PUBLIC_ALLOWED_CIDRS}"},
+			"intra_control": {"tls": "", "server_crt": "", "server_key": "", "allowed_cidrs": "${AIS_INTRA_CONTROL_ALLOWED_CIDRS}"},
+			"intra_data":    {"tls": "", "server_crt": "", "server_key": "", "allowed_cidrs": "${AIS_INTRA_DATA_ALLOWED_CIDRS}"}
 		}
 	},
 	"fshc": {
 		"enabled":     true,
 		"test_files":  4,
-		"error_limit": 2
+		"error_limit": 2,
+		"watchdog": {
+			"enabled":        false,
+			"interval":       "30s",
+			"error_rate":     10,
+			"max_latency":    "",
+			"max_dev_errors": 1,
+			"probation":      "10m"
+		}
 	},
 	"auth": {
 		"secret":      "$AIS_SECRET_KEY",
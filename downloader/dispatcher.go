@@ -347,7 +347,7 @@ func (d *dispatcher) blockingDispatchDownloadSingle(task *singleObjectTask) (err
 		return err, true
 	}
 
-	mi, _, err := cluster.HrwMpath(bck.MakeUname(task.obj.objName))
+	mi, _, err := cluster.HrwMpathBck(bck, task.obj.objName)
 	if err != nil {
 		return err, false
 	}
@@ -728,7 +728,7 @@ func broadcast(method, path string, urlParams url.Values, body []byte, nodes clu
 	config := cmn.GCO.Get()
 	client := cmn.NewClient(cmn.TransportArgs{
 		Timeout:    config.Client.Timeout,
-		UseHTTPS:   config.Net.HTTP.UseHTTPS,
+		UseHTTPS:   config.Net.Listener(cmn.NetworkIntraControl).UseHTTPS,
 		SkipVerify: config.Net.HTTP.SkipVerify,
 	})
 	responses := make([]response, len(nodes))
@@ -191,7 +191,7 @@ func (m *Manager) init(rs *ParsedRequestSpec) error {
 	m.client = cmn.NewClient(cmn.TransportArgs{
 		DialTimeout: 5 * time.Minute,
 		Timeout:     30 * time.Minute,
-		UseHTTPS:    config.Net.HTTP.UseHTTPS,
+		UseHTTPS:    config.Net.Listener(cmn.NetworkIntraData).UseHTTPS,
 		SkipVerify:  config.Net.HTTP.SkipVerify,
 	})
 
@@ -7,14 +7,31 @@ package ec
 import (
 	"fmt"
 	"os"
+	"strings"
 	"sync"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
 	"github.com/NVIDIA/aistore/fs"
 )
 
+// Resumable encoding
+//
+// Each mountpath jogger walks the bucket's objects in sorted order and
+// periodically persists (in the target's DB) a checkpoint: the name of the
+// object such that all the objects up to (and including) it have been
+// processed. When the xaction gets restarted (e.g., after the target reboots),
+// the joggers skip the objects up to their checkpoints. The checkpoints are
+// removed once the xaction finishes, and ignored if the bucket's EC
+// configuration has changed in the meantime.
+
+const (
+	encodeCkptCollection = "ec-encode"
+	encodeCkptInterval   = 256 // number of walked objects between checkpoints
+)
+
 type (
 	XactBckEncode struct {
 		cmn.XactBase
@@ -33,7 +50,41 @@ type (
 		// to cache some info for quick access
 		smap     *cluster.Smap
 		daemonID string
+
+		// checkpointing
+		mtx      sync.Mutex
+		root     string        // content directory
+		ecConf   cmn.ECConf    // EC configuration the objects are encoded with
+		resume   string        // checkpoint to resume from (empty - from scratch)
+		ckpt     string        // the last persisted checkpoint
+		inflight []*encodeItem // walked objects, in walk order, that are not checkpointed yet
+		cnt      int
 	}
+	encodeItem struct {
+		objName string
+		done    bool
+	}
+	// persisted progress of a jogger
+	encodeCkpt struct {
+		ObjName string `json:"name"` // all the objects up to this one (in walk order) have been processed
+		Data    int    `json:"data"`
+		Parity  int    `json:"parity"`
+	}
+
+	BckEncodeTargetStats struct {
+		cmn.BaseXactStats
+		Ext ExtECEncodeStats `json:"ext"`
+	}
+	ExtECEncodeStats struct {
+		Total   int64 `json:"ec.encode.total.n,string"`  // number of objects to walk
+		Walked  int64 `json:"ec.encode.walked.n,string"` // number of objects walked so far
+		PctDone int   `json:"ec.encode.pct"`             // percentage complete
+	}
+)
+
+var (
+	// interface guard
+	_ cmn.XactStats = &BckEncodeTargetStats{}
 )
 
 func NewXactBckEncode(bck cmn.Bck, t cluster.Target, uuid string) *XactBckEncode {
@@ -49,6 +100,19 @@ func (r *XactBckEncode) done()                  { r.doneCh <- struct{}{} }
 func (r *XactBckEncode) target() cluster.Target { return r.t }
 func (r *XactBckEncode) IsMountpathXact() bool  { return true }
 
+// (the total and done counts are the numbers of objects to walk - all
+// mountpaths - and walked so far, including those skipped upon resume)
+func (r *XactBckEncode) Stats() cmn.XactStats {
+	baseStats := r.XactBase.Stats().(*cmn.BaseXactStats)
+	encodeStats := BckEncodeTargetStats{BaseXactStats: *baseStats}
+	encodeStats.Ext.Total = encodeStats.TotalCountX
+	encodeStats.Ext.Walked = encodeStats.DoneCountX
+	if encodeStats.Ext.Total > 0 {
+		encodeStats.Ext.PctDone = int(cmn.MinI64(encodeStats.Ext.Walked*100/encodeStats.Ext.Total, 100))
+	}
+	return &encodeStats
+}
+
 func (r *XactBckEncode) beforeECObj() { r.wg.Add(1) }
 func (r *XactBckEncode) afterECObj(lom *cluster.LOM, err error) {
 	if err == nil {
@@ -71,14 +135,14 @@ func (r *XactBckEncode) Run() (err error) {
 	if !bck.Props.EC.Enabled {
 		return fmt.Errorf("bucket %q does not have EC enabled", r.bck.Name)
 	}
-	if numjs, err = r.init(); err != nil {
+	if numjs, err = r.init(&bck.Props.EC); err != nil {
 		return
 	}
 	err = r.run(numjs)
 	return
 }
 
-func (r *XactBckEncode) init() (int, error) {
+func (r *XactBckEncode) init(ecConf *cmn.ECConf) (int, error) {
 	availablePaths, _ := fs.Mountpaths.Get()
 	numjs := len(availablePaths)
 	r.doneCh = make(chan struct{}, numjs)
@@ -92,8 +156,11 @@ func (r *XactBckEncode) init() (int, error) {
 			smap:      r.t.GetSowner().Get(),
 			daemonID:  r.t.Snode().ID(),
 			stopCh:    cmn.NewStopCh(),
+			ecConf:    *ecConf,
 		}
 		mpathLC := mpathInfo.MakePathCT(r.Bck(), fs.ObjectType)
+		jogger.root = mpathLC
+		jogger.loadCkpt()
 		r.mpathers[mpathLC] = jogger
 	}
 	for _, mpather := range r.mpathers {
@@ -115,6 +182,7 @@ func (r *XactBckEncode) run(numjs int) error {
 			if numjs == 0 {
 				glog.Infof("%s: all done. Waiting for EC finishes", r)
 				r.wg.Wait()
+				ClearEncodeCkpts(r.t.GetDB(), r.Bck())
 				r.mpathers = nil
 				r.stop()
 				return nil
@@ -137,24 +205,142 @@ func (r *XactBckEncode) stop() {
 func (j *joggerBckEncode) stop() { j.stopCh.Close() }
 
 func (j *joggerBckEncode) jog() {
+	j.count()
 	opts := &fs.Options{
 		Mpath: j.mpathInfo,
 		Bck:   j.parent.Bck(),
 		CTs:   []string{fs.ObjectType},
 
 		Callback: j.walk,
-		Sorted:   false,
+		Sorted:   true, // checkpoints rely on the walk order
+		Throttle: true,
 	}
 	if err := fs.Walk(opts); err != nil {
 		glog.Errorln(err)
 	}
+	j.checkpoint()
 	j.parent.done()
 }
 
+// counts the objects to walk - to report the percentage complete
+func (j *joggerBckEncode) count() {
+	var cnt int64
+	opts := &fs.Options{
+		Mpath: j.mpathInfo,
+		Bck:   j.parent.Bck(),
+		CTs:   []string{fs.ObjectType},
+		Callback: func(_ string, de fs.DirEntry) error {
+			if !de.IsDir() {
+				cnt++
+			}
+			return nil
+		},
+	}
+	if err := fs.Walk(opts); err != nil {
+		glog.Errorf("jogger[%s/%s]: failed to count objects: %v", j.mpathInfo, j.parent.Bck(), err)
+	}
+	j.parent.TotalAdd(cnt)
+}
+
+func encodeCkptKey(bck cmn.Bck, mpath string) string {
+	return cluster.NewBckEmbed(bck).MakeUname(mpath)
+}
+
+func (j *joggerBckEncode) loadCkpt() {
+	db := j.parent.t.GetDB()
+	if db == nil {
+		return
+	}
+	ckpt := &encodeCkpt{}
+	if err := db.Get(encodeCkptCollection, encodeCkptKey(j.parent.Bck(), j.mpathInfo.Path), ckpt); err != nil {
+		if !dbdriver.IsErrNotFound(err) {
+			glog.Errorf("jogger[%s/%s]: failed to load checkpoint: %v", j.mpathInfo, j.parent.Bck(), err)
+		}
+		return
+	}
+	if ckpt.Data != j.ecConf.DataSlices || ckpt.Parity != j.ecConf.ParitySlices {
+		return // EC configuration has changed - start over
+	}
+	j.resume, j.ckpt = ckpt.ObjName, ckpt.ObjName
+	glog.Infof("jogger[%s/%s]: resuming after %q", j.mpathInfo, j.parent.Bck(), j.resume)
+}
+
+// persists the name of the last object such that all the walked objects up
+// to (and including) it have been processed
+func (j *joggerBckEncode) checkpoint() {
+	db := j.parent.t.GetDB()
+	if db == nil {
+		return
+	}
+	j.mtx.Lock()
+	i := 0
+	for ; i < len(j.inflight) && j.inflight[i].done; i++ {
+	}
+	if i == 0 {
+		j.mtx.Unlock()
+		return
+	}
+	objName := j.inflight[i-1].objName
+	j.inflight = j.inflight[i:]
+	j.mtx.Unlock()
+	if objName == j.ckpt {
+		return
+	}
+	ckpt := &encodeCkpt{ObjName: objName, Data: j.ecConf.DataSlices, Parity: j.ecConf.ParitySlices}
+	if err := db.Set(encodeCkptCollection, encodeCkptKey(j.parent.Bck(), j.mpathInfo.Path), ckpt); err != nil {
+		glog.Errorf("jogger[%s/%s]: failed to save checkpoint: %v", j.mpathInfo, j.parent.Bck(), err)
+		return
+	}
+	j.ckpt = objName
+}
+
+// adds a walked object; the returned item is to be marked done once the object is processed
+func (j *joggerBckEncode) addItem(objName string, done bool) *encodeItem {
+	item := &encodeItem{objName: objName, done: done}
+	j.mtx.Lock()
+	j.inflight = append(j.inflight, item)
+	j.mtx.Unlock()
+	j.parent.DoneInc()
+	if j.cnt++; j.cnt%encodeCkptInterval == 0 {
+		j.checkpoint()
+	}
+	return item
+}
+
+// ClearEncodeCkpts removes the bucket's encoding checkpoints (see XactBckEncode)
+func ClearEncodeCkpts(db dbdriver.Driver, bck cmn.Bck) {
+	if db == nil {
+		return
+	}
+	keys, err := db.List(encodeCkptCollection, cluster.NewBckEmbed(bck).MakeUname(""))
+	if err != nil {
+		if !dbdriver.IsErrNotFound(err) {
+			glog.Errorf("%s: failed to clear EC encode checkpoints: %v", bck, err)
+		}
+		return
+	}
+	for _, key := range keys {
+		if err := db.Delete(encodeCkptCollection, key); err != nil && !dbdriver.IsErrNotFound(err) {
+			glog.Errorf("%s: failed to clear EC encode checkpoints: %v", bck, err)
+		}
+	}
+}
+
+// HasEncodeCkpts returns true if the bucket's encoding has been interrupted
+// (e.g., by the target's restart) and can be resumed
+func HasEncodeCkpts(db dbdriver.Driver, bck cmn.Bck) bool {
+	if db == nil {
+		return false
+	}
+	keys, err := db.List(encodeCkptCollection, cluster.NewBckEmbed(bck).MakeUname(""))
+	return err == nil && len(keys) > 0
+}
+
 // Walks through all files in 'obj' directory, and calls EC.Encode for every
 // file whose HRW points to this file and the file does not have corresponding
 // metadata file in 'meta' directory
 func (j *joggerBckEncode) walk(fqn string, de fs.DirEntry) error {
+	j.parent.WaitIfPaused()
 	select {
 	case <-j.stopCh.Listen():
 		return fmt.Errorf("jogger[%s/%s] aborted, exiting", j.mpathInfo, j.parent.Bck())
@@ -164,52 +350,78 @@ func (j *joggerBckEncode) walk(fqn string, de fs.DirEntry) error {
 	if de.IsDir() {
 		return nil
 	}
+	objName := strings.TrimPrefix(fqn, j.root+"/")
+	if j.resume != "" && fs.CmpWalkOrder(objName, j.resume) <= 0 {
+		j.parent.DoneInc() // processed before restart
+		return nil
+	}
+	if !j.needEC(fqn) {
+		j.addItem(objName, true)
+		return nil
+	}
+	lom := &cluster.LOM{T: j.parent.target(), FQN: fqn}
+	if err := lom.Init(j.parent.Bck(), j.config); err != nil {
+		j.addItem(objName, true)
+		return nil
+	}
+
+	// beforeECObj increases a counter, and callback afterECObj decreases it.
+	// After Walk finishes, the xaction waits until counter drops to zero.
+	// That means all objects have been processed and xaction can finalize.
+	item := j.addItem(objName, false)
+	j.parent.beforeECObj()
+	cb := func(lom *cluster.LOM, err error) {
+		j.mtx.Lock()
+		item.done = true
+		j.mtx.Unlock()
+		j.parent.afterECObj(lom, err)
+	}
+	if err := ECM.EncodeObject(lom, cb); err != nil {
+		// something wrong with EC, interrupt file walk - it is critical
+		return fmt.Errorf("failed to EC object %q: %v", fqn, err)
+	}
+
+	return nil
+}
+
+// returns true if the object must be erasure coded by this target
+func (j *joggerBckEncode) needEC(fqn string) bool {
 	lom := &cluster.LOM{T: j.parent.target(), FQN: fqn}
 	err := lom.Init(j.parent.Bck(), j.config)
 	if err != nil {
-		return nil
+		return false
 	}
 	if err := lom.Load(); err != nil {
-		return nil
+		return false
 	}
 
 	// a mirror of the object - skip EC
 	if !lom.IsHRW() {
-		return nil
+		return false
 	}
 	si, err := cluster.HrwTarget(lom.Uname(), j.smap)
 	if err != nil {
 		glog.Errorf("%s: %s", lom, err)
-		return nil
+		return false
 	}
 	// an object replica - skip EC
 	if j.daemonID != si.ID() {
-		return nil
+		return false
 	}
 
 	mdFQN, _, err := cluster.HrwFQN(lom.Bck(), MetaType, lom.ObjName)
 	if err != nil {
 		glog.Warningf("metadata FQN generation failed %q: %v", fqn, err)
-		return nil
+		return false
 	}
 	_, err = os.Stat(mdFQN)
 	// metadata file exists - the object was already EC'ed before
 	if err == nil {
-		return nil
+		return false
 	}
 	if !os.IsNotExist(err) {
 		glog.Warningf("failed to stat %q: %v", mdFQN, err)
-		return nil
-	}
-
-	// beforeECObj increases a counter, and callback afterECObj decreases it.
-	// After Walk finishes, the xaction waits until counter drops to zero.
-	// That means all objects have been processed and xaction can finalize.
-	j.parent.beforeECObj()
-	if err = ECM.EncodeObject(lom, j.parent.afterECObj); err != nil {
-		// something wrong with EC, interrupt file walk - it is critical
-		return fmt.Errorf("failed to EC object %q: %v", fqn, err)
+		return false
 	}
-
-	return nil
+	return true
 }
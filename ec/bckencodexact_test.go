@@ -0,0 +1,90 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+type encodeTargetMock struct {
+	*cluster.TargetMock
+	db dbdriver.Driver
+}
+
+func (t *encodeTargetMock) GetDB() dbdriver.Driver { return t.db }
+
+func TestBckEncodeCkpt(t *testing.T) {
+	dir, err := ioutil.TempDir("", "ecencodeckpt")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(dir)
+	dbPath := filepath.Join(dir, ".ais.kvlog")
+	db, err := dbdriver.NewLogDriver(dbPath)
+	tassert.CheckFatal(t, err)
+
+	var (
+		bck    = cmn.Bck{Name: "ecencode", Provider: cmn.ProviderAIS}
+		other  = cmn.Bck{Name: "ecencode-other", Provider: cmn.ProviderAIS}
+		ecConf = cmn.ECConf{Enabled: true, DataSlices: 2, ParitySlices: 1}
+		mpath  = &fs.MountpathInfo{Path: "/tmp/mp1"}
+		tMock  = &encodeTargetMock{TargetMock: cluster.NewTargetMock(cluster.NewBaseBownerMock()), db: db}
+		newJog = func(r *XactBckEncode, ecConf cmn.ECConf) *joggerBckEncode {
+			j := &joggerBckEncode{parent: r, mpathInfo: mpath, ecConf: ecConf}
+			j.loadCkpt()
+			return j
+		}
+	)
+
+	// from scratch
+	r := NewXactBckEncode(bck, tMock, "")
+	j := newJog(r, ecConf)
+	tassert.Fatalf(t, j.resume == "", "expected no checkpoint, got %q", j.resume)
+	tassert.Fatalf(t, !HasEncodeCkpts(db, bck), "expected no checkpoints")
+
+	// the checkpoint advances only past the contiguous processed objects
+	j.addItem("a", true)
+	b := j.addItem("b", false)
+	j.addItem("c", true)
+	j.checkpoint()
+	tassert.Errorf(t, j.ckpt == "a", "expected checkpoint %q, got %q", "a", j.ckpt)
+	tassert.Errorf(t, len(j.inflight) == 2, "expected 2 objects in flight, got %d", len(j.inflight))
+	b.done = true
+	j.checkpoint()
+	tassert.Errorf(t, j.ckpt == "c", "expected checkpoint %q, got %q", "c", j.ckpt)
+	tassert.Errorf(t, len(j.inflight) == 0, "expected no objects in flight, got %d", len(j.inflight))
+	tassert.Errorf(t, HasEncodeCkpts(db, bck), "expected checkpoints")
+	tassert.Errorf(t, !HasEncodeCkpts(db, other), "expected no checkpoints for another bucket")
+
+	r.TotalAdd(6)
+	stats := r.Stats().(*BckEncodeTargetStats)
+	tassert.Errorf(t, stats.Ext.Walked == 3 && stats.Ext.Total == 6 && stats.Ext.PctDone == 50,
+		"unexpected stats %+v", stats.Ext)
+
+	// restart: resume from the persisted checkpoint
+	tassert.CheckFatal(t, db.Close())
+	db, err = dbdriver.NewLogDriver(dbPath)
+	tassert.CheckFatal(t, err)
+	defer db.Close()
+	tMock.db = db
+	j = newJog(NewXactBckEncode(bck, tMock, ""), ecConf)
+	tassert.Errorf(t, j.resume == "c" && j.ckpt == "c", "expected to resume after %q, got %q", "c", j.resume)
+
+	// EC configuration has changed - start over
+	j = newJog(NewXactBckEncode(bck, tMock, ""), cmn.ECConf{Enabled: true, DataSlices: 2, ParitySlices: 2})
+	tassert.Errorf(t, j.resume == "", "expected no checkpoint, got %q", j.resume)
+
+	ClearEncodeCkpts(db, bck)
+	tassert.Errorf(t, !HasEncodeCkpts(db, bck), "expected the checkpoints to be removed")
+	j = newJog(NewXactBckEncode(bck, tMock, ""), ecConf)
+	tassert.Errorf(t, j.resume == "", "expected no checkpoint, got %q", j.resume)
+}
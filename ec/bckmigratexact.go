@@ -0,0 +1,135 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+type (
+	// XactBckMetaMigrate converts all the bucket's metafiles stored in the
+	// legacy JSON format to the (compact) binary format - see NewPack.
+	// Metafiles that are already binary are skipped.
+	XactBckMetaMigrate struct {
+		cmn.XactBase
+		t cluster.Target
+		// progress
+		converted atomic.Int64
+		errCount  atomic.Int64
+	}
+
+	MetaMigrateTargetStats struct {
+		cmn.BaseXactStats
+		Ext ExtECMetaMigrateStats `json:"ext"`
+	}
+	ExtECMetaMigrateStats struct {
+		Converted int64 `json:"ec.meta.converted.n,string"` // number of converted metafiles
+		ErrCount  int64 `json:"ec.meta.err.n,string"`
+	}
+)
+
+var (
+	// interface guard
+	_ cmn.XactStats = &MetaMigrateTargetStats{}
+)
+
+func NewXactBckMetaMigrate(bck cmn.Bck, t cluster.Target, uuid string) *XactBckMetaMigrate {
+	return &XactBckMetaMigrate{
+		XactBase: *cmn.NewXactBaseWithBucket(uuid, cmn.ActECMetaMigrate, bck),
+		t:        t,
+	}
+}
+
+func (r *XactBckMetaMigrate) IsMountpathXact() bool { return true }
+func (r *XactBckMetaMigrate) Stop(error)            { r.Abort() }
+
+func (r *XactBckMetaMigrate) Stats() cmn.XactStats {
+	baseStats := r.XactBase.Stats().(*cmn.BaseXactStats)
+	migrateStats := MetaMigrateTargetStats{BaseXactStats: *baseStats}
+	migrateStats.Ext.Converted = r.converted.Load()
+	migrateStats.Ext.ErrCount = r.errCount.Load()
+	return &migrateStats
+}
+
+func (r *XactBckMetaMigrate) Run() (err error) {
+	defer func() { r.Finish(err) }()
+
+	bck := cluster.NewBckEmbed(r.Bck())
+	if err = bck.Init(r.t.GetBowner(), r.t.Snode()); err != nil {
+		return
+	}
+	availablePaths, _ := fs.Mountpaths.Get()
+	for _, mpathInfo := range availablePaths {
+		opts := &fs.Options{
+			Mpath: mpathInfo,
+			Bck:   bck.Bck,
+			CTs:   []string{MetaType},
+			Callback: func(fqn string, de fs.DirEntry) error {
+				if r.Aborted() {
+					return fmt.Errorf("%s aborted, exiting", r)
+				}
+				if de.IsDir() {
+					return nil
+				}
+				r.convert(fqn)
+				r.ObjectsInc()
+				return nil
+			},
+			Sorted: false,
+		}
+		if err = fs.Walk(opts); err != nil {
+			return
+		}
+	}
+	glog.Infof("%s: all done, converted %d metafile(s), errors: %d", r, r.converted.Load(), r.errCount.Load())
+	return
+}
+
+func (r *XactBckMetaMigrate) convert(fqn string) {
+	ct, err := cluster.NewCTFromFQN(fqn, r.t.GetBowner())
+	if err != nil {
+		glog.Errorf("%s: %v", r, err)
+		r.errCount.Inc()
+		return
+	}
+	// serialize with the EC (and other) writers of the object's metafile
+	lom := &cluster.LOM{T: r.t, ObjName: ct.ObjName()}
+	if err = lom.Init(ct.Bck().Bck); err != nil {
+		glog.Errorf("%s: %v", r, err)
+		r.errCount.Inc()
+		return
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+
+	b, err := ioutil.ReadFile(fqn)
+	if err != nil || IsBinaryMeta(b) {
+		return // removed in the meantime or nothing to do
+	}
+	md, err := MetaFromBytes(b)
+	if err != nil {
+		glog.Errorf("%s: damaged metafile %q: %v", r, fqn, err)
+		r.errCount.Inc()
+		return
+	}
+	packed := md.NewPack()
+	if err = ct.Write(r.t, bytes.NewReader(packed), -1, ct.Make(fs.WorkfileType)); err != nil {
+		glog.Errorf("%s: failed to convert %q: %v", r, fqn, err)
+		r.errCount.Inc()
+		return
+	}
+	r.converted.Inc()
+	if glog.V(4) {
+		glog.Infof("%s: converted %q", r, fqn)
+	}
+}
@@ -0,0 +1,254 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+type (
+	// XactBckRestore restores (via getJogger's background lane) all the objects
+	// that match a given prefix or template and that this target is the main
+	// target for. With a prefix, the objects are discovered by walking the local
+	// EC metafiles: when the main target of an object gets lost, its HRW successor
+	// is (as far as placement goes) one of the targets that hold its slices.
+	XactBckRestore struct {
+		cmn.XactBase
+		t        cluster.Target
+		template string
+		// progress
+		total    atomic.Int64
+		restored atomic.Int64
+		errCount atomic.Int64
+	}
+
+	RestoreTargetStats struct {
+		cmn.BaseXactStats
+		Ext ExtECRestoreStats `json:"ext"`
+	}
+	ExtECRestoreStats struct {
+		Total    int64 `json:"ec.restore.total.n,string"` // number of objects to process
+		Restored int64 `json:"ec.restore.n,string"`       // number of actually restored objects
+		ErrCount int64 `json:"ec.restore.err.n,string"`
+	}
+)
+
+var (
+	// interface guard
+	_ cmn.XactStats = &RestoreTargetStats{}
+)
+
+func NewXactBckRestore(bck cmn.Bck, t cluster.Target, uuid, template string) *XactBckRestore {
+	return &XactBckRestore{
+		XactBase: *cmn.NewXactBaseWithBucket(uuid, cmn.ActECRestore, bck),
+		t:        t,
+		template: template,
+	}
+}
+
+func (r *XactBckRestore) IsMountpathXact() bool { return false }
+func (r *XactBckRestore) Stop(error)            { r.Abort() }
+
+func (r *XactBckRestore) Stats() cmn.XactStats {
+	baseStats := r.XactBase.Stats().(*cmn.BaseXactStats)
+	restoreStats := RestoreTargetStats{BaseXactStats: *baseStats}
+	restoreStats.Ext.Total = r.total.Load()
+	restoreStats.Ext.Restored = r.restored.Load()
+	restoreStats.Ext.ErrCount = r.errCount.Load()
+	return &restoreStats
+}
+
+func (r *XactBckRestore) Run() (err error) {
+	var objNames []string
+	defer func() { r.Finish(err) }()
+
+	bck := cluster.NewBckEmbed(r.Bck())
+	if err = bck.Init(r.t.GetBowner(), r.t.Snode()); err != nil {
+		return
+	}
+	if !bck.Props.EC.Enabled {
+		return fmt.Errorf("bucket %q does not have EC enabled", bck.Name)
+	}
+	if objNames, err = r.collect(bck); err != nil {
+		return
+	}
+	r.total.Store(int64(len(objNames)))
+	glog.Infof("%s: %d object(s) to check", r, len(objNames))
+
+	config := cmn.GCO.Get()
+	for _, objName := range objNames {
+		if r.Aborted() {
+			return fmt.Errorf("%s aborted, exiting", r)
+		}
+		r.restore(bck, objName, config)
+		r.ObjectsInc()
+	}
+	glog.Infof("%s: all done, restored %d object(s), errors: %d", r, r.restored.Load(), r.errCount.Load())
+	return
+}
+
+// returns (sorted) names of the matching objects this target is responsible for
+func (r *XactBckRestore) collect(bck *cluster.Bck) ([]string, error) {
+	var (
+		pt   cmn.ParsedTemplate
+		err  error
+		smap = r.t.GetSowner().Get()
+		sid  = r.t.Snode().ID()
+		objs = make(map[string]struct{})
+	)
+	if pt, err = cmn.ParseBashTemplate(r.template); err != nil {
+		if pt, err = cmn.ParseAtTemplate(r.template); err != nil {
+			pt = cmn.ParsedTemplate{Prefix: r.template}
+		}
+	}
+	isLocal := func(objName string) (bool, error) {
+		si, err := cluster.HrwTarget(bck.MakeUname(objName), smap)
+		if err != nil {
+			return false, err
+		}
+		return si.ID() == sid, nil
+	}
+
+	if len(pt.Ranges) != 0 {
+		getNext := pt.Iter()
+		for objName, hasNext := getNext(); hasNext; objName, hasNext = getNext() {
+			local, err := isLocal(objName)
+			if err != nil {
+				return nil, err
+			}
+			if local {
+				objs[objName] = struct{}{}
+			}
+		}
+	} else {
+		availablePaths, _ := fs.Mountpaths.Get()
+		for _, mpathInfo := range availablePaths {
+			opts := &fs.Options{
+				Mpath: mpathInfo,
+				Bck:   bck.Bck,
+				CTs:   []string{MetaType},
+				Callback: func(fqn string, de fs.DirEntry) error {
+					if r.Aborted() {
+						return fmt.Errorf("%s aborted, exiting", r)
+					}
+					if de.IsDir() {
+						return nil
+					}
+					parsed, err := fs.Mountpaths.ParseFQN(fqn)
+					if err != nil || !strings.HasPrefix(parsed.ObjName, pt.Prefix) {
+						return nil
+					}
+					if local, err := isLocal(parsed.ObjName); err != nil || !local {
+						return err
+					}
+					objs[parsed.ObjName] = struct{}{}
+					return nil
+				},
+				Sorted: false,
+			}
+			if err := fs.Walk(opts); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// packed objects: the metafiles may be lost along with the main target,
+	// so the objects are looked up in the indices of the containers
+	if err := r.collectPacked(bck, pt, isLocal, objs); err != nil {
+		return nil, err
+	}
+
+	objNames := make([]string, 0, len(objs))
+	for objName := range objs {
+		objNames = append(objNames, objName)
+	}
+	sort.Strings(objNames)
+	return objNames, nil
+}
+
+// adds the matching packed objects and re-creates their missing metafiles
+func (r *XactBckRestore) collectPacked(bck *cluster.Bck, pt cmn.ParsedTemplate,
+	isLocal func(string) (bool, error), objs map[string]struct{}) error {
+	var names map[string]struct{}
+	members, err := packedMembers(bck.Bck)
+	if err != nil || len(members) == 0 {
+		return err
+	}
+	if len(pt.Ranges) != 0 {
+		names = make(map[string]struct{})
+		getNext := pt.Iter()
+		for objName, hasNext := getNext(); hasNext; objName, hasNext = getNext() {
+			names[objName] = struct{}{}
+		}
+	}
+	for objName, md := range members {
+		if names != nil {
+			if _, ok := names[objName]; !ok {
+				continue
+			}
+		} else if !strings.HasPrefix(objName, pt.Prefix) {
+			continue
+		}
+		if local, err := isLocal(objName); err != nil || !local {
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		objs[objName] = struct{}{}
+		if _, err := ObjectMetadata(bck, objName); err == nil {
+			continue
+		}
+		lom := &cluster.LOM{T: r.t, ObjName: objName}
+		if err := lom.Init(bck.Bck); err != nil {
+			return err
+		}
+		ctMeta := cluster.NewCTFromLOM(lom, MetaType)
+		if err := ctMeta.Write(r.t, bytes.NewReader(md.NewPack()), -1); err != nil {
+			glog.Errorf("%s: failed to re-create metafile of %s: %v", r, lom, err)
+			r.errCount.Inc()
+		}
+	}
+	return nil
+}
+
+func (r *XactBckRestore) restore(bck *cluster.Bck, objName string, config *cmn.Config) {
+	lom := &cluster.LOM{T: r.t, ObjName: objName}
+	if err := lom.Init(bck.Bck, config); err != nil {
+		glog.Errorf("%s: %v", r, err)
+		r.errCount.Inc()
+		return
+	}
+	err := lom.Load()
+	if err == nil {
+		return // nothing to do
+	}
+	if !cmn.IsErrObjNought(err) {
+		glog.Errorf("%s: failed to load %s, err: %v", r, lom, err)
+		r.errCount.Inc()
+		return
+	}
+	if err = ECM.RestoreObjectBg(lom); err != nil {
+		glog.Errorf("%s: failed to restore %s, err: %v", r, lom, err)
+		r.errCount.Inc()
+		return
+	}
+	r.restored.Inc()
+	if err = lom.Load(); err == nil {
+		r.BytesAdd(lom.Size())
+	}
+	if glog.V(4) {
+		glog.Infof("%s: restored %s", r, lom)
+	}
+}
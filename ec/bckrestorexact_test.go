@@ -0,0 +1,96 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+// the target (one of the two) with a given cluster map
+type restoreTargetMock struct {
+	*cluster.TargetMock
+	si   *cluster.Snode
+	smap *cluster.Smap
+}
+
+func (t *restoreTargetMock) Snode() *cluster.Snode          { return t.si }
+func (t *restoreTargetMock) GetSowner() cluster.Sowner      { return t }
+func (t *restoreTargetMock) Get() *cluster.Smap             { return t.smap }
+func (*restoreTargetMock) Listeners() cluster.SmapListeners { return nil }
+
+func TestBckRestoreCollect(t *testing.T) {
+	cluster.InitTarget()
+	fs.Mountpaths = fs.NewMountedFS(ios.NewIOStaterMock())
+	fs.Mountpaths.DisableFsIDCheck()
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.RegisterContentType(MetaType, &MetaSpec{})
+
+	mpath, err := ioutil.TempDir("", "ecrestorecollect")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(mpath)
+	tassert.CheckFatal(t, fs.Mountpaths.Add(mpath))
+
+	var (
+		bck = cluster.NewBck("ecrestore", cmn.ProviderAIS, cmn.NsGlobal, &cmn.BucketProps{
+			EC: cmn.ECConf{Enabled: true, DataSlices: 1, ParitySlices: 1},
+		})
+		smap  = &cluster.Smap{Tmap: cluster.NodeMap{}}
+		tMock = &restoreTargetMock{TargetMock: cluster.NewTargetMock(cluster.NewBaseBownerMock(bck)), smap: smap}
+	)
+	for _, id := range []string{"t1", "t2"} {
+		si := &cluster.Snode{DaemonID: id, DaemonType: cmn.Target}
+		si.Digest() // (HRW assumes it is initialized)
+		smap.Tmap[id] = si
+	}
+	tMock.si = smap.Tmap["t1"]
+	isLocal := func(objName string) bool {
+		si, err := cluster.HrwTarget(bck.MakeUname(objName), smap)
+		tassert.CheckFatal(t, err)
+		return si.ID() == "t1"
+	}
+
+	// template: the objects (this target is the main target for) need not exist
+	r := NewXactBckRestore(bck.Bck, tMock, "", "obj-{00..99}")
+	objNames, err := r.collect(bck)
+	tassert.CheckFatal(t, err)
+	var expected []string
+	for i := 0; i < 100; i++ {
+		if objName := fmt.Sprintf("obj-%02d", i); isLocal(objName) {
+			expected = append(expected, objName)
+		}
+	}
+	tassert.Fatalf(t, len(expected) > 0 && len(expected) < 100, "expected both targets to get some objects")
+	tassert.Fatalf(t, sort.StringsAreSorted(objNames), "expected sorted names, got %v", objNames)
+	tassert.Fatalf(t, cmn.StrSlicesEqual(objNames, expected), "expected %v, got %v", expected, objNames)
+
+	// prefix: the objects are discovered by their local metafiles
+	expected = expected[:0]
+	for i := 0; i < 20; i++ {
+		for _, objName := range []string{fmt.Sprintf("a/obj-%02d", i), fmt.Sprintf("b/obj-%02d", i)} {
+			ct, err := cluster.NewCTFromBO(bck.Name, bck.Provider, objName, tMock.GetBowner(), MetaType)
+			tassert.CheckFatal(t, err)
+			tassert.CheckFatal(t, cmn.CreateDir(filepath.Dir(ct.FQN())))
+			tassert.CheckFatal(t, ioutil.WriteFile(ct.FQN(), []byte("md"), 0644))
+			if objName[0] == 'a' && isLocal(objName) {
+				expected = append(expected, objName)
+			}
+		}
+	}
+	r = NewXactBckRestore(bck.Bck, tMock, "", "a/")
+	objNames, err = r.collect(bck)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, cmn.StrSlicesEqual(objNames, expected), "expected %v, got %v", expected, objNames)
+}
@@ -0,0 +1,74 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"io"
+	"os"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/pierrec/lz4/v3"
+)
+
+// Parity slice compression
+//
+// When enabled for a bucket (see cmn.ECConf.CompressParity), targets store the
+// parity slices - that are read only to restore lost data - lz4-compressed,
+// while data slices and replicas remain uncompressed. The compression is
+// transparent for the rest of EC: slices always travel uncompressed - they get
+// compressed when written (see WriteSliceAndMeta) and decompressed when read
+// (see OpenSlice). Metadata.Compressed records how a given slice is stored.
+
+type zsliceReader struct {
+	fh *os.File
+	zr *lz4.Reader
+}
+
+// interface guard
+var _ cmn.ReadOpenCloser = &zsliceReader{}
+
+func compressSlice(bck *cluster.Bck, meta *Metadata) bool {
+	return bck.Props != nil && bck.Props.EC.CompressParity && meta.SliceID > meta.Data
+}
+
+// OpenSlice opens the slice file to read the slice's (uncompressed) content
+func OpenSlice(fqn string, meta *Metadata) (cmn.ReadOpenCloser, error) {
+	if !meta.Compressed {
+		return cmn.NewFileHandle(fqn)
+	}
+	return openZslice(fqn)
+}
+
+func openZslice(fqn string) (*zsliceReader, error) {
+	fh, err := os.Open(fqn)
+	if err != nil {
+		return nil, err
+	}
+	return &zsliceReader{fh: fh, zr: lz4.NewReader(fh)}, nil
+}
+
+func (z *zsliceReader) Read(b []byte) (int, error)   { return z.zr.Read(b) }
+func (z *zsliceReader) Close() error                 { return z.fh.Close() }
+func (z *zsliceReader) Open() (io.ReadCloser, error) { return openZslice(z.fh.Name()) }
+
+// compresses the slice on the fly while writing it
+func writeZslice(t cluster.Target, ct *cluster.CT, data io.Reader, size int64, workFQN string) error {
+	pr, pw := io.Pipe()
+	go func() {
+		zw := lz4.NewWriter(pw)
+		if size >= 0 {
+			data = io.LimitReader(data, size)
+		}
+		_, err := io.Copy(zw, data)
+		if erc := zw.Close(); err == nil {
+			err = erc
+		}
+		pw.CloseWithError(err)
+	}()
+	err := ct.Write(t, pr, -1, workFQN)
+	pr.Close() // unblocks the compressing goroutine if the write fails
+	return err
+}
@@ -14,6 +14,7 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
@@ -22,6 +23,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/housekeep/hk"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/transport"
 	jsoniter "github.com/json-iterator/go"
@@ -46,7 +48,8 @@ import (
 // data slice and #ParitySlices replicas
 //
 // NOTE: All slices and replicas must be on the different targets. The target
-// list is calculated by HrwTargetList. The first target in the list is the
+// list is calculated by HrwTargetList (or, HrwTargetDomainList - when the bucket's
+// EC placement is failure-domain aware). The first target in the list is the
 // "main" target that keeps the full object, the others keep only slices/replicas
 //
 // NOTE: All slices must be of the same size. So, the last slice can be padded
@@ -117,6 +120,7 @@ import (
 const (
 	SliceType = "ec" // object slice prefix
 	MetaType  = "mt" // metafile prefix
+	TrashType = "tr" // tombstoned metafiles, replicas, and slices (see trash.go)
 
 	ActSplit   = "split"
 	ActRestore = "restore"
@@ -134,6 +138,16 @@ const (
 	// EC switches to disk from SGL when memory pressure is high and the amount of
 	// memory required to encode an object exceeds the limit
 	objSizeHighMem = 50 * cmn.MiB
+
+	// max time to wait for the mountpath joggers to complete the requests in progress
+	joggerStopTimeout = time.Minute
+)
+
+// restore priorities: getJogger always processes client-blocking restores
+// before background ones
+const (
+	prioClient     = iota // restore blocks a client request (e.g., GET)
+	prioBackground        // background rebuild (e.g., scrubbing, rebalance)
 )
 
 type (
@@ -144,10 +158,16 @@ type (
 		ErrCh    chan error   // for final EC result
 		Callback cluster.OnFinishObj
 
-		putTime time.Time // time when the object is put into main queue
-		tm      time.Time // to measure different steps
-		IsCopy  bool      // replicate or use erasure coding
-		rebuild bool      // true - internal request to reencode, e.g., from ec-encode xaction
+		putTime time.Time   // time when the object is put into main queue
+		tm      time.Time   // to measure different steps
+		IsCopy  bool        // replicate or use erasure coding
+		rebuild bool        // true - internal request to reencode, e.g., from ec-encode xaction
+		prio    int         // restore priority (prioClient or prioBackground)
+		packed  []PackEntry // container: the index of the packed objects (see pack.go)
+		noPack  bool        // replicate a small object even if packing is enabled
+		reprot  bool        // remove the replicas (slices) kept by the other targets (see Manager.ReprotectObject)
+		policy  *Policy     // per-object override of the bucket's EC configuration (see policy.go)
+		traceID string      // restore: unique ID passed along with the transfers it triggers
 	}
 
 	RequestsControlMsg struct {
@@ -168,6 +188,7 @@ type (
 		workFQN string             // FQN for temporary slice/replica
 		cksum   *cmn.Cksum         // checksum of the slice
 		version string             // version of the remote object
+		cached  bool               // taken from the slice cache
 	}
 
 	// a source for data response: the data to send to the caller
@@ -175,6 +196,13 @@ type (
 	// the obj's counter is decreased. And if its value drops to zero the
 	// allocated SGL is freed. This logic is required to send a set of
 	// sliceReaders that point to the same SGL (broadcasting data slices)
+	// a target to request a slice from when restoring (see requestSlices)
+	sliceSrc struct {
+		daemonID string
+		sliceID  int
+		load     int32 // number of slice requests in flight to the target
+	}
+
 	dataSource struct {
 		reader   cmn.ReadOpenCloser // a reader to sent to a remote target
 		size     int64              // size of the data
@@ -182,6 +210,7 @@ type (
 		metadata *Metadata          // object's metadata
 		isSlice  bool               // is it slice or replica
 		reqType  intraReqType       // request's type, slice/meta request/response
+		traceID  string             // ID of the originating restore request (if any)
 	}
 
 	XactRegistry interface {
@@ -229,6 +258,8 @@ var (
 	mm           *memsys.MMSA       // memory manager and slab/SGL allocator
 	slicePadding = make([]byte, 64) // for padding EC slices
 	XactCount    atomic.Int32       // the number of currently active EC xactions
+	restoreMem   memBudget          // memory buffers of the restores in progress (all buckets)
+	sliceReqs    targetLoad         // slice requests in flight, per target (all buckets)
 
 	ErrorECDisabled          = errors.New("EC is disabled for bucket")
 	ErrorNoMetafile          = errors.New("no metafile")
@@ -236,13 +267,46 @@ var (
 	ErrorInsufficientTargets = errors.New("insufficient targets")
 )
 
-func Init(t cluster.Target, reg XactRegistry) {
+// ErrMetaConflict is returned when the EC metadata received from other
+// targets does not satisfy the bucket's `ec.meta_quorum`. The caller gets
+// all the received metadata (including the minority) to act upon.
+type ErrMetaConflict struct {
+	ObjName string
+	Quorum  string               // see cmn.ECQuorumMajority, etc. enum
+	Need    int                  // number of matching metadata required by the quorum
+	Metas   map[string]*Metadata // target ID => metadata
+}
+
+func (e *ErrMetaConflict) Error() string {
+	cksums := make(map[string]int, 2)
+	for _, md := range e.Metas {
+		cksums[md.ObjCksum]++
+	}
+	return fmt.Sprintf("%s: EC metadata conflict (quorum %q, need %d): %d target(s), %d distinct checksum(s)",
+		e.ObjName, e.Quorum, e.Need, len(e.Metas), len(cksums))
+}
+
+func IsErrMetaConflict(err error) bool {
+	_, ok := err.(*ErrMetaConflict)
+	return ok
+}
+
+func Init(t cluster.Target, reg XactRegistry) error {
 	mm = t.GetMMSA() // TODO: try to introduce and benchmark a separate MMSA for EC
-	fs.CSM.RegisterContentType(SliceType, &SliceSpec{})
-	fs.CSM.RegisterContentType(MetaType, &MetaSpec{})
+	if err := fs.CSM.RegisterContentType(SliceType, &SliceSpec{}); err != nil {
+		return err
+	}
+	if err := fs.CSM.RegisterContentType(MetaType, &MetaSpec{}); err != nil {
+		return err
+	}
+	if err := fs.CSM.RegisterContentType(TrashType, &TrashSpec{}); err != nil {
+		return err
+	}
 	if err := initManager(t, reg); err != nil {
-		glog.Fatal(err)
+		return err
 	}
+	hk.Housekeeper.Register(trashHkName, purgeTrashAll, trashHkIdle)
+	return nil
 }
 
 // SliceSize returns the size of one slice that EC will create for the object
@@ -297,6 +361,86 @@ func useDisk(objSize int64) bool {
 	}
 }
 
+// memBudget accounts for the memory that the restores in progress buffer
+// their slices (or replicas) in. A restore that does not fit into
+// `ec.restore_mem_limit` is not rejected - it buffers its slices in workfiles
+// on the jogger's mountpath instead (same as when memory pressure is high).
+type memBudget struct {
+	used atomic.Int64
+}
+
+// returns false if `size` bytes do not fit into the budget
+func (b *memBudget) reserve(size int64) bool {
+	limit := cmn.GCO.Get().EC.RestoreMemLimit
+	if limit == 0 {
+		b.used.Add(size)
+		return true
+	}
+	for {
+		used := b.used.Load()
+		if used+size > limit {
+			return false
+		}
+		if b.used.CAS(used, used+size) {
+			return true
+		}
+	}
+}
+
+func (b *memBudget) release(size int64) {
+	if size != 0 {
+		b.used.Sub(size)
+	}
+}
+
+// targetLoad counts the slice requests in flight, per target - to request the
+// slices from the least loaded targets first (see requestSlices)
+type targetLoad struct {
+	m sync.Map // daemonID => *atomic.Int32
+}
+
+func (l *targetLoad) get(daemonID string) int32 {
+	if cnt, ok := l.m.Load(daemonID); ok {
+		return cnt.(*atomic.Int32).Load()
+	}
+	return 0
+}
+
+func (l *targetLoad) inc(daemonIDs []string) {
+	for _, id := range daemonIDs {
+		cnt, _ := l.m.LoadOrStore(id, &atomic.Int32{})
+		cnt.(*atomic.Int32).Inc()
+	}
+}
+
+func (l *targetLoad) dec(daemonIDs []string) {
+	for _, id := range daemonIDs {
+		if cnt, ok := l.m.Load(id); ok {
+			cnt.(*atomic.Int32).Dec()
+		}
+	}
+}
+
+// (approximate) amount of memory to restore the object and its missing
+// replicas or slices: all data and parity slices are kept in memory until
+// the missing ones are sent to their targets
+func restoreMemSize(meta *Metadata) int64 {
+	if meta.IsCopy {
+		return meta.Size
+	}
+	return SliceSize(meta.Size, meta.Data) * int64(meta.Data+meta.Parity)
+}
+
+// effective EC configuration of the object: the bucket's, unless overridden
+// by the per-object policy (validated when the request is created)
+func (req *Request) ecConf() cmn.ECConf {
+	conf := req.LOM.Bprops().EC
+	if req.policy != nil {
+		conf, _ = req.policy.Apply(conf)
+	}
+	return conf
+}
+
 // Frees allocated memory if it is SGL or closes the file handle in case of regular file
 func freeObject(r interface{}) {
 	if r == nil {
@@ -372,18 +516,25 @@ func WriteObject(t cluster.Target, lom *cluster.LOM, reader io.Reader, size int6
 	})
 }
 
-// Saves slice and its metafile
-func WriteSliceAndMeta(t cluster.Target, hdr transport.Header, data io.Reader, md []byte) error {
+// Saves slice and its metafile; compresses parity slices if configured (see compress.go)
+func WriteSliceAndMeta(t cluster.Target, hdr transport.Header, data io.Reader, meta *Metadata) error {
 	ct, err := cluster.NewCTFromBO(hdr.Bck.Name, hdr.Bck.Provider, hdr.ObjName, t.GetBowner(), SliceType)
 	if err != nil {
 		return err
 	}
 	tmpFQN := ct.Make(fs.WorkfileType)
-	if err := ct.Write(t, data, hdr.ObjAttrs.Size, tmpFQN); err != nil {
+	mcopy := *meta
+	mcopy.Compressed = compressSlice(ct.Bck(), meta)
+	if mcopy.Compressed {
+		err = writeZslice(t, ct, data, hdr.ObjAttrs.Size, tmpFQN)
+	} else {
+		err = ct.Write(t, data, hdr.ObjAttrs.Size, tmpFQN)
+	}
+	if err != nil {
 		return err
 	}
 	ctMeta := ct.Clone(MetaType)
-	err = ctMeta.Write(t, bytes.NewReader(md), -1)
+	err = ctMeta.Write(t, bytes.NewReader(mcopy.NewPack()), -1)
 	if err != nil {
 		if rmErr := os.Remove(ct.FQN()); rmErr != nil && !os.IsNotExist(rmErr) {
 			glog.Errorf("nested error: save replica -> remove replica: %v", rmErr)
@@ -407,6 +558,7 @@ func LomFromHeader(t cluster.Target, hdr transport.Header) (*cluster.LOM, error)
 	if hdr.ObjAttrs.CksumType != cmn.ChecksumNone && hdr.ObjAttrs.CksumValue != "" {
 		lom.SetCksum(cmn.NewCksum(hdr.ObjAttrs.CksumType, hdr.ObjAttrs.CksumValue))
 	}
+	lom.SetCustomMD(hdr.ObjAttrs.CustomMD)
 	return lom, nil
 }
 
@@ -0,0 +1,85 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func setRestoreMemLimit(limit int64) (restore func()) {
+	config := cmn.GCO.BeginUpdate()
+	prev := config.EC.RestoreMemLimit
+	config.EC.RestoreMemLimit = limit
+	cmn.GCO.CommitUpdate(config)
+	return func() { setRestoreMemLimit(prev) }
+}
+
+func TestRestoreMemBudget(t *testing.T) {
+	// unlimited
+	defer setRestoreMemLimit(0)()
+	b := &memBudget{}
+	tassert.Errorf(t, b.reserve(cmn.GiB) && b.reserve(cmn.GiB), "expected unlimited budget")
+	b.release(2 * cmn.GiB)
+	tassert.Errorf(t, b.used.Load() == 0, "expected nothing reserved, got %d", b.used.Load())
+
+	setRestoreMemLimit(100)
+	tassert.Errorf(t, b.reserve(60), "expected 60 bytes to fit")
+	tassert.Errorf(t, !b.reserve(50), "expected 50 bytes not to fit")
+	tassert.Errorf(t, b.reserve(40), "expected 40 bytes to fit")
+	b.release(60)
+	tassert.Errorf(t, b.reserve(50), "expected 50 bytes to fit once released")
+	b.release(90)
+	b.release(0)
+	tassert.Errorf(t, b.used.Load() == 0, "expected nothing reserved, got %d", b.used.Load())
+
+	// concurrent restores never exceed the limit
+	var (
+		wg       sync.WaitGroup
+		inflight atomic.Int64
+		maxUsed  atomic.Int64
+	)
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				if !b.reserve(30) {
+					continue // spill
+				}
+				if used := inflight.Add(30); used > maxUsed.Load() {
+					maxUsed.Store(used)
+				}
+				inflight.Sub(30)
+				b.release(30)
+			}
+		}()
+	}
+	wg.Wait()
+	tassert.Errorf(t, maxUsed.Load() <= 100, "exceeded the limit: %d", maxUsed.Load())
+	tassert.Errorf(t, b.used.Load() == 0, "expected nothing reserved, got %d", b.used.Load())
+}
+
+func TestRestoreMemSize(t *testing.T) {
+	tests := []struct {
+		name string
+		meta *Metadata
+		size int64
+	}{
+		{name: "replicas", meta: &Metadata{IsCopy: true, Size: 1000, Data: 1, Parity: 2}, size: 1000},
+		{name: "slices", meta: &Metadata{Size: 1000, Data: 2, Parity: 2}, size: 2000},
+		{name: "slices, padded", meta: &Metadata{Size: 1000, Data: 3, Parity: 1}, size: 334 * 4},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			size := restoreMemSize(test.meta)
+			tassert.Errorf(t, size == test.size, "expected %d, got %d", test.size, size)
+		})
+	}
+}
@@ -6,12 +6,15 @@ package ec
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 	"unsafe"
@@ -30,10 +33,13 @@ import (
 type getJogger struct {
 	parent *XactGet
 	client *http.Client
-	mpath  string // mountpath that the jogger manages
+	mpath  string      // mountpath that the jogger manages
+	stats  *mpathStats // per-mountpath counters
 
-	workCh chan *Request // channel to request TOP priority operation (restore)
-	stopCh chan struct{} // jogger management channel: to stop it
+	clientCh chan *Request // restores that block client requests (TOP priority)
+	bgCh     chan *Request // background restores (processed only when clientCh is empty)
+	stopCh   *cmn.StopCh   // jogger management channel: to stop it
+	doneCh   chan struct{} // closed upon exit
 
 	jobID  uint64
 	jobs   map[uint64]bgProcess
@@ -43,24 +49,52 @@ type getJogger struct {
 
 func (c *getJogger) run() {
 	glog.Infof("started EC for mountpath: %s, bucket %s", c.mpath, c.parent.bck)
+	defer close(c.doneCh)
 
 	for {
+		// first, process all client-blocking requests
 		select {
-		case req := <-c.workCh:
-			c.parent.stats.updateWaitTime(time.Since(req.tm))
-			req.tm = time.Now()
-			c.ec(req)
-			c.parent.DecPending()
-		case <-c.stopCh:
+		case req := <-c.clientCh:
+			c.processRequest(req)
+			continue
+		case <-c.stopCh.Listen():
+			return
+		default:
+		}
+
+		// then, whatever comes first
+		select {
+		case req := <-c.clientCh:
+			c.processRequest(req)
+		case req := <-c.bgCh:
+			c.processRequest(req)
+		case <-c.stopCh.Listen():
 			return
 		}
 	}
 }
 
-func (c *getJogger) stop() {
+func (c *getJogger) processRequest(req *Request) {
+	wait := time.Since(req.tm)
+	c.parent.stats.dequeue(req.prio)
+	c.parent.stats.updateWaitTime(wait)
+	c.stats.dequeue(wait)
+	req.tm = time.Now()
+	c.ec(req)
+	c.parent.DecPending()
+}
+
+// stopCtx stops the jogger and waits for the request in progress, if any, to complete -
+// but not past the ctx deadline
+func (c *getJogger) stopCtx(ctx context.Context) error {
 	glog.Infof("stopping EC for mountpath: %s, bucket: %s", c.mpath, c.parent.bck)
-	c.stopCh <- struct{}{}
-	close(c.stopCh)
+	c.stopCh.Close()
+	select {
+	case <-c.doneCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // starts EC process
@@ -84,6 +118,7 @@ func (c *getJogger) ec(req *Request) {
 		restore := func(req *Request, toDisk bool, cb func(error)) {
 			err := c.restore(req, toDisk)
 			c.parent.stats.updateDecodeTime(time.Since(req.tm), err != nil)
+			c.stats.update(time.Since(req.tm), req.LOM.Size(), err != nil)
 			if cb != nil {
 				cb(err)
 			}
@@ -112,12 +147,14 @@ func (c *getJogger) ec(req *Request) {
 // the final step of replica restoration process: the main target detects which
 // nodes do not have replicas and copy it to them
 // * bucket/objName - object path
+// * req - original restore request
 // * reader - replica content to sent to remote targets
 // * metadata - object's EC metadata
 // * nodes - targets that have metadata and replica - filled by requestMeta
 // * replicaCnt - total number of replicas including main one
-func (c *getJogger) copyMissingReplicas(lom *cluster.LOM, reader cmn.ReadOpenCloser, metadata *Metadata, nodes map[string]*Metadata, replicaCnt int) {
-	targets, err := cluster.HrwTargetList(lom.Uname(), c.parent.smap.Get(), replicaCnt)
+func (c *getJogger) copyMissingReplicas(req *Request, reader cmn.ReadOpenCloser, metadata *Metadata, nodes map[string]*Metadata, replicaCnt int) {
+	lom := req.LOM
+	targets, err := cluster.HrwTargetListEC(lom.Uname(), c.parent.smap.Get(), replicaCnt, &lom.Bprops().EC)
 	if err != nil {
 		freeObject(reader)
 		glog.Errorf("failed to get list of %d targets: %s", replicaCnt, err)
@@ -167,7 +204,8 @@ func (c *getJogger) copyMissingReplicas(lom *cluster.LOM, reader cmn.ReadOpenClo
 	// Reason: memsys.Reader does not provide access to internal memsys.SGL that must be freed
 	cb := func(hdr transport.Header, _ io.ReadCloser, _ unsafe.Pointer, err error) {
 		if err != nil {
-			glog.Errorf("%s failed to send %s/%s to %v: %v", c.parent.t.Snode(), lom.Bck(), lom.ObjName, daemons, err)
+			glog.Errorf("%s failed to send %s/%s to %v (trace %s): %v",
+				c.parent.t.Snode(), lom.Bck(), lom.ObjName, daemons, req.traceID, err)
 		}
 		freeObject(reader)
 	}
@@ -177,10 +215,17 @@ func (c *getJogger) copyMissingReplicas(lom *cluster.LOM, reader cmn.ReadOpenClo
 		size:     lom.Size(),
 		metadata: metadata,
 		reqType:  reqPut,
+		traceID:  req.traceID,
+	}
+	if glog.V(4) {
+		glog.Infof("%s[trace %s]: copying replica %s/%s to %v", c.parent, req.traceID, lom.Bck(), lom.ObjName, daemons)
 	}
 	if err := c.parent.writeRemote(daemons, lom, src, cb); err != nil {
-		glog.Errorf("%s failed to copy replica %s/%s to %v: %v", c.parent.t.Snode(), lom.Bck(), lom.ObjName, daemons, err)
+		glog.Errorf("%s failed to copy replica %s/%s to %v (trace %s): %v",
+			c.parent.t.Snode(), lom.Bck(), lom.ObjName, daemons, req.traceID, err)
+		return
 	}
+	c.parent.stats.updateRepair(len(daemons), int64(len(daemons))*src.size)
 }
 
 // starting point of restoration of the object that was replicated
@@ -188,7 +233,9 @@ func (c *getJogger) copyMissingReplicas(lom *cluster.LOM, reader cmn.ReadOpenClo
 // * meta - rebuilt object's metadata
 // * nodes - filled by requestMeta the list of targets what responsed to GET
 //      metadata request with valid metafile
-func (c *getJogger) restoreReplicatedFromMemory(req *Request, meta *Metadata, nodes map[string]*Metadata) error {
+// * reserved - memory reserved in the restore budget; released when the
+//      replica is handed over to the transport
+func (c *getJogger) restoreReplicatedFromMemory(req *Request, meta *Metadata, nodes map[string]*Metadata, reserved int64) error {
 	var (
 		writer *memsys.SGL
 		mm     = c.parent.t.GetSmallMMSA()
@@ -219,19 +266,25 @@ func (c *getJogger) restoreReplicatedFromMemory(req *Request, meta *Metadata, no
 	}
 
 	if writer == nil {
+		restoreMem.release(reserved)
 		return errors.New("failed to read a replica from any target")
 	}
 
-	b := cmn.MustMarshal(meta)
+	b := meta.NewPack()
 	req.LOM.SetSize(writer.Size())
+	req.LOM.SetCustomMD(meta.CustomMD)
 	if err := WriteReplicaAndMeta(c.parent.t, req.LOM, memsys.NewReader(writer), b, meta.CksumType, meta.CksumValue); err != nil {
 		writer.Free()
+		restoreMem.release(reserved)
 		return err
 	}
 
 	// now a client can read the object, but EC needs to restore missing
 	// replicas. So, execute copying replicas in background and return
-	go c.copyMissingReplicas(req.LOM, writer, meta, nodes, meta.Parity+1)
+	go func() {
+		c.copyMissingReplicas(req, writer, meta, nodes, meta.Parity+1)
+		restoreMem.release(reserved)
+	}()
 
 	return nil
 }
@@ -280,11 +333,12 @@ func (c *getJogger) restoreReplicatedFromDisk(req *Request, meta *Metadata, node
 		return err
 	}
 
+	req.LOM.SetCustomMD(meta.CustomMD)
 	if err := req.LOM.Persist(); err != nil {
 		return err
 	}
 
-	b := cmn.MustMarshal(meta)
+	b := meta.NewPack()
 	ctMeta := cluster.NewCTFromLOM(req.LOM, MetaType)
 	if err := ctMeta.Write(c.parent.t, bytes.NewReader(b), -1); err != nil {
 		return err
@@ -296,7 +350,7 @@ func (c *getJogger) restoreReplicatedFromDisk(req *Request, meta *Metadata, node
 	if err != nil {
 		return err
 	}
-	go c.copyMissingReplicas(req.LOM, reader, meta, nodes, meta.Parity+1)
+	go c.copyMissingReplicas(req, reader, meta, nodes, meta.Parity+1)
 
 	return nil
 }
@@ -311,31 +365,93 @@ func (c *getJogger) restoreReplicatedFromDisk(req *Request, meta *Metadata, node
 // * []slice - a list of received slices in correct order (missing slices = nil)
 // * map[int]string - a map of slice locations: SliceID <-> DaemonID
 func (c *getJogger) requestSlices(req *Request, meta *Metadata, nodes map[string]*Metadata, toDisk bool) ([]*slice, map[int]string, error) {
-	wgSlices := cmn.NewTimeoutGroup()
-	sliceCnt := meta.Data + meta.Parity
-	slices := make([]*slice, sliceCnt)
-	daemons := make([]string, 0, len(nodes)) // target to be requested for a slice
-	idToNode := make(map[int]string)         // which target what slice returned
-
+	var (
+		sliceCnt = meta.Data + meta.Parity
+		slices   = make([]*slice, sliceCnt)
+		idToNode = make(map[int]string) // which target what slice returned
+		useCache = req.LOM.Bprops().EC.SliceCacheSize > 0
+		srcs     = make([]sliceSrc, 0, len(nodes)) // targets to request a slice from
+		fanout   = cmn.GCO.Get().EC.SliceFanout
+		have     int
+	)
 	for k, v := range nodes {
 		if v.SliceID < 1 || v.SliceID > sliceCnt {
 			glog.Warningf("Node %s has invalid slice ID %d", k, v.SliceID)
 			continue
 		}
+		idToNode[v.SliceID] = k
+		if useCache {
+			if writer := c.parent.sliceCache.get(req.LOM.Uname(), v.SliceID, meta); writer != nil {
+				if glog.V(4) {
+					glog.Infof("Slice %s/%s ID %d found in cache", req.LOM.Bck(), req.LOM.ObjName, v.SliceID)
+				}
+				lom := *(req.LOM)
+				writer.lom = &lom
+				slices[v.SliceID-1] = writer
+				have++
+				continue
+			}
+		}
+		srcs = append(srcs, sliceSrc{daemonID: k, sliceID: v.SliceID, load: sliceReqs.get(k)})
+	}
+
+	// data slices first (to restore, there is no need to compute the missing data
+	// slices when all of them are present), least loaded targets first
+	sort.Slice(srcs, func(i, j int) bool {
+		di, dj := srcs[i].sliceID <= meta.Data, srcs[j].sliceID <= meta.Data
+		if di != dj {
+			return di
+		}
+		if srcs[i].load != srcs[j].load {
+			return srcs[i].load < srcs[j].load
+		}
+		return srcs[i].sliceID < srcs[j].sliceID
+	})
+
+	// request (at least) as many slices as needed to restore the object, and all
+	// the data slices - from no more than `ec.slice_fanout` targets at a time
+	// (0 - all at once); request more if some of the targets fail to deliver
+	for len(srcs) > 0 && (have < meta.Data || srcs[0].sliceID <= meta.Data) {
+		n := len(srcs)
+		if fanout > 0 {
+			n = cmn.Min(n, cmn.Max(fanout, meta.Data-have))
+			for n < len(srcs) && srcs[n].sliceID <= meta.Data {
+				n++
+			}
+		}
+		got, err := c.fetchSlices(req, meta, slices, srcs[:n], toDisk)
+		if err != nil {
+			freeSlices(slices)
+			return nil, nil, err
+		}
+		have += got
+		srcs = srcs[n:]
+	}
+	// NOTE: the slices that have not been requested stay in idToNode (their targets keep them)
+	return slices, idToNode, nil
+}
 
+// request the slices from a given set of targets and wait for all of them
+// to respond; returns the number of slices received
+func (c *getJogger) fetchSlices(req *Request, meta *Metadata, slices []*slice, srcs []sliceSrc, toDisk bool) (int, error) {
+	wgSlices := cmn.NewTimeoutGroup()
+	daemons := make([]string, 0, len(srcs)) // target to be requested for a slice
+	for _, src := range srcs {
 		if glog.V(4) {
-			glog.Infof("Slice %s/%s ID %d requesting from %s", req.LOM.Bck(), req.LOM.ObjName, v.SliceID, k)
+			glog.Infof("Slice %s/%s ID %d requesting from %s", req.LOM.Bck(), req.LOM.ObjName, src.sliceID, src.daemonID)
 		}
 		// create SGL to receive the slice data and save it to correct
 		// position in the slice list
-		var writer *slice
-		var lom = *(req.LOM)
+		var (
+			writer *slice
+			lom    = *(req.LOM)
+		)
 		if toDisk {
-			prefix := fmt.Sprintf("ec-restore-%d", v.SliceID)
+			prefix := fmt.Sprintf("ec-restore-%d", src.sliceID)
 			fqn := fs.CSM.GenContentFQN(req.LOM.FQN, fs.WorkfileType, prefix)
 			fh, err := req.LOM.CreateFile(fqn)
 			if err != nil {
-				return slices, nil, err
+				return 0, err
 			}
 			writer = &slice{
 				writer:  fh,
@@ -350,14 +466,16 @@ func (c *getJogger) requestSlices(req *Request, meta *Metadata, nodes map[string
 				lom:    &lom,
 			}
 		}
-		slices[v.SliceID-1] = writer
-		idToNode[v.SliceID] = k
+		slices[src.sliceID-1] = writer
 		wgSlices.Add(1)
-		uname := unique(k, req.LOM.Bck(), req.LOM.ObjName)
+		uname := unique(src.daemonID, req.LOM.Bck(), req.LOM.ObjName)
 		if c.parent.regWriter(uname, writer) {
-			daemons = append(daemons, k)
+			daemons = append(daemons, src.daemonID)
 		}
 	}
+	if len(daemons) == 0 {
+		return 0, nil
+	}
 
 	iReq := c.parent.newIntraReq(reqGet, meta)
 	iReq.isSlice = true
@@ -373,17 +491,25 @@ func (c *getJogger) requestSlices(req *Request, meta *Metadata, nodes map[string
 	if glog.V(4) {
 		glog.Infof("Requesting daemons %v for slices of %s/%s", daemons, req.LOM.Bck(), req.LOM.ObjName)
 	}
+	sliceReqs.inc(daemons)
+	defer sliceReqs.dec(daemons)
 	if err := c.parent.sendByDaemonID(daemons, hdr, nil, nil, true); err != nil {
-		freeSlices(slices)
 		mm.Free(request)
-		return nil, nil, err
+		return 0, err
 	}
 	conf := cmn.GCO.Get()
 	if wgSlices.WaitTimeout(conf.Timeout.SendFile) {
 		glog.Errorf("%s timed out waiting for %s/%s slices", c.parent.t.Snode(), req.LOM.Bck(), req.LOM.ObjName)
 	}
 	mm.Free(request)
-	return slices, idToNode, nil
+
+	got := 0
+	for _, src := range srcs {
+		if sl := slices[src.sliceID-1]; sl != nil && sl.n != 0 {
+			got++
+		}
+	}
+	return got, nil
 }
 
 func noSliceWriter(req *Request, writers []io.Writer, restored []*slice, cksums []*cmn.CksumHash,
@@ -482,6 +608,10 @@ func (c *getJogger) restoreMainObj(req *Request, meta *Metadata, slices []*slice
 				sl.writer = nil
 			}
 		}
+		if sl == nil && i >= meta.Data && idToNode[i+1] != "" {
+			// not requested (see ec.slice_fanout) - the target keeps it
+			continue
+		}
 		if sl == nil || sl.writer == nil {
 			if err = noSliceWriter(req, writers, restored, cksums, conf.Type, idToNode, toDisk, i, sliceSize); err != nil {
 				break
@@ -586,9 +716,10 @@ func (c *getJogger) restoreMainObj(req *Request, meta *Metadata, slices []*slice
 		req.LOM.SetVersion(version)
 	}
 	req.LOM.SetSize(meta.Size)
+	req.LOM.SetCustomMD(meta.CustomMD)
 	mainMeta := *meta
 	mainMeta.SliceID = 0
-	metaBuf := mainMeta.Marshal()
+	metaBuf := mainMeta.NewPack()
 	err = WriteReplicaAndMeta(c.parent.t, req.LOM, src, metaBuf, conf.Type, "")
 	return restored, err
 }
@@ -629,7 +760,7 @@ func (c *getJogger) uploadRestoredSlices(req *Request, meta *Metadata, slices []
 	// generate the list of targets that should have a slice and find out
 	// the targets without any one
 	// FIXME: when fewer targets than sliceCnt+1, send slices to those available anyway
-	targets, err := cluster.HrwTargetList(req.LOM.Uname(), c.parent.smap.Get(), sliceCnt+1)
+	targets, err := cluster.HrwTargetListEC(req.LOM.Uname(), c.parent.smap.Get(), sliceCnt+1, &req.LOM.Bprops().EC)
 	if err != nil {
 		glog.Warning(err)
 		return
@@ -671,7 +802,8 @@ func (c *getJogger) uploadRestoredSlices(req *Request, meta *Metadata, slices []
 		cb := func(daemonID string, s *slice) transport.SendCallback {
 			return func(hdr transport.Header, reader io.ReadCloser, _ unsafe.Pointer, err error) {
 				if err != nil {
-					glog.Errorf("%s failed to send %s/%s to %v: %v", c.parent.t.Snode(), req.LOM.Bck(), req.LOM.ObjName, daemonID, err)
+					glog.Errorf("%s failed to send %s/%s to %v (trace %s): %v",
+						c.parent.t.Snode(), req.LOM.Bck(), req.LOM.ObjName, daemonID, req.traceID, err)
 				}
 				if s != nil {
 					s.free()
@@ -699,17 +831,21 @@ func (c *getJogger) uploadRestoredSlices(req *Request, meta *Metadata, slices []
 			metadata: &sliceMeta,
 			isSlice:  true,
 			reqType:  reqPut,
+			traceID:  req.traceID,
 		}
 
 		if glog.V(4) {
-			glog.Infof("Sending slice %d %s/%s to %s", sliceMeta.SliceID+1, req.LOM.Bck(), req.LOM.ObjName, tgt)
+			glog.Infof("%s[trace %s]: sending slice %d %s/%s to %s",
+				c.parent, req.traceID, sliceMeta.SliceID, req.LOM.Bck(), req.LOM.ObjName, tgt)
 		}
 		if sl.cksum != nil {
 			sliceMeta.CksumType, sliceMeta.CksumValue = sl.cksum.Get()
 		}
 		if err := c.parent.writeRemote([]string{tgt}, req.LOM, dataSrc, cb); err != nil {
-			glog.Errorf("%s failed to send slice %d of %s/%s to %s",
-				c.parent.t.Snode(), idx+1, req.LOM.Bck(), req.LOM.ObjName, tgt)
+			glog.Errorf("%s failed to send slice %d of %s/%s to %s (trace %s)",
+				c.parent.t.Snode(), idx+1, req.LOM.Bck(), req.LOM.ObjName, tgt, req.traceID)
+		} else {
+			c.parent.stats.updateRepair(1, sl.n)
 		}
 
 		idx = nextIdx
@@ -726,11 +862,29 @@ func (c *getJogger) uploadRestoredSlices(req *Request, meta *Metadata, slices []
 	}
 }
 
+// puts the slices received from remote targets to the slice cache, skipping
+// the ones that have been rebuilt (e.g., due to checksum mismatch)
+func (c *getJogger) cacheSlices(req *Request, meta *Metadata, slices, restored []*slice) {
+	capacity := req.LOM.Bprops().EC.SliceCacheSize
+	if capacity <= 0 {
+		return
+	}
+	uname := req.LOM.Uname()
+	for i, sl := range slices {
+		if sl == nil || sl.cached || sl.writer == nil || restored[i] != nil {
+			continue
+		}
+		c.parent.sliceCache.put(uname, i+1, meta, sl, capacity)
+	}
+}
+
 // main function that starts restoring an object that was encoded
 // * req - original request
 // * meta - rebuild object's metadata
 // * nodes - the list of targets that responded with valid metadata
-func (c *getJogger) restoreEncoded(req *Request, meta *Metadata, nodes map[string]*Metadata, toDisk bool) error {
+// * reserved - memory reserved in the restore budget (zero if toDisk)
+func (c *getJogger) restoreEncoded(req *Request, meta *Metadata, nodes map[string]*Metadata, toDisk bool,
+	reserved int64) error {
 	if glog.V(4) {
 		glog.Infof("Starting EC restore %s/%s", req.LOM.Bck(), req.LOM.ObjName)
 	}
@@ -747,6 +901,7 @@ func (c *getJogger) restoreEncoded(req *Request, meta *Metadata, nodes map[strin
 	slices, idToNode, err := c.requestSlices(req, meta, nodes, toDisk)
 	if err != nil {
 		freeWriters()
+		restoreMem.release(reserved)
 		return err
 	}
 
@@ -757,6 +912,7 @@ func (c *getJogger) restoreEncoded(req *Request, meta *Metadata, nodes map[strin
 		freeWriters()
 		freeSlices(restored)
 		freeSlices(slices)
+		restoreMem.release(reserved)
 		return err
 	}
 
@@ -767,11 +923,13 @@ func (c *getJogger) restoreEncoded(req *Request, meta *Metadata, nodes map[strin
 	// Start a background process that uploads reconstructed data to
 	// remote targets and then return from the function
 	go func() {
+		c.cacheSlices(req, meta, slices, restored)
 		c.uploadRestoredSlices(req, meta, restored, idToNode)
 
 		// do not free `restored` here - it is done in transport callback when
 		// transport completes sending restored slices to correct target
 		freeSlices(slices)
+		restoreMem.release(reserved)
 		if glog.V(4) {
 			glog.Infof("Slices %s/%s restored successfully", req.LOM.Bck(), req.LOM.ObjName)
 		}
@@ -793,38 +951,87 @@ func (c *getJogger) restore(req *Request, toDisk bool) error {
 	if glog.V(4) {
 		glog.Infof("Restoring %s/%s", req.LOM.Bck(), req.LOM.ObjName)
 	}
+	// a packed object is extracted from its container (see pack.go)
+	if md, err := ObjectMetadata(req.LOM.Bck(), req.LOM.ObjName); err == nil && md.PackName != "" {
+		return c.restoreFromPack(req, md, toDisk)
+	}
 	meta, nodes, err := c.requestMeta(req)
 	if glog.V(4) {
 		glog.Infof("Find meta for %s/%s: %v, err: %v", req.LOM.Bck(), req.LOM.ObjName, meta != nil, err)
 	}
 	if err != nil {
+		if IsErrMetaConflict(err) && req.LOM.Bck().IsRemote() {
+			return c.restoreFromCloud(req, err)
+		}
 		return err
 	}
 
+	if !meta.IsCopy && len(nodes) < meta.Data {
+		err := fmt.Errorf("cannot restore: too many slices missing (found %d slices, need %d or more)", len(nodes), meta.Data)
+		if req.LOM.Bck().IsRemote() {
+			return c.restoreFromCloud(req, err)
+		}
+		return err
+	}
+
+	// buffer slices in workfiles if the restore does not fit into the memory budget
+	var reserved int64
+	if !toDisk {
+		size := restoreMemSize(meta)
+		if restoreMem.reserve(size) {
+			reserved = size
+		} else {
+			toDisk = true
+			c.parent.spills.Inc()
+			if glog.V(4) {
+				glog.Infof("%s/%s: restore memory budget exceeded, spilling to disk", req.LOM.Bck(), req.LOM.ObjName)
+			}
+		}
+	}
+
 	if meta.IsCopy {
 		if toDisk {
 			return c.restoreReplicatedFromDisk(req, meta, nodes)
 		}
-		return c.restoreReplicatedFromMemory(req, meta, nodes)
+		return c.restoreReplicatedFromMemory(req, meta, nodes, reserved)
 	}
+	return c.restoreEncoded(req, meta, nodes, toDisk, reserved)
+}
 
-	if len(nodes) < meta.Data {
-		return fmt.Errorf("cannot restore: too many slices missing (found %d slices, need %d or more)", meta.Data, len(nodes))
+// last resort: re-fetches the object from the Cloud (or backend bucket) and
+// re-encodes it to repopulate the slices; fails if either fails (in the latter
+// case, the object is restored but remains unprotected)
+func (c *getJogger) restoreFromCloud(req *Request, errRestore error) error {
+	glog.Warningf("%s: %v - fetching from %s", req.LOM, errRestore, req.LOM.Bck())
+	if err, _ := c.parent.t.GetCold(context.Background(), req.LOM, false /*prefetch*/); err != nil {
+		return fmt.Errorf("%v; cold GET failed: %v", errRestore, err)
 	}
-
-	return c.restoreEncoded(req, meta, nodes, toDisk)
+	// GetCold keeps the read lock
+	req.LOM.Unlock(false)
+	// re-encode as per the per-object policy, if any (see policy.go)
+	var policy *Policy
+	if md, err := ObjectMetadata(req.LOM.Bck(), req.LOM.ObjName); err == nil && md.Policy != "" {
+		if policy, err = ParsePolicy(md.Policy); err != nil {
+			glog.Errorf("%s: %v", req.LOM, err)
+		}
+	}
+	if err := ECM.EncodeObjectPolicy(req.LOM, policy); err != nil {
+		return fmt.Errorf("%v; failed to re-encode %s after cold GET: %v", errRestore, req.LOM, err)
+	}
+	if glog.V(4) {
+		glog.Infof("restored %s from %s", req.LOM, req.LOM.Bck())
+	}
+	return nil
 }
 
 // broadcast request for object's metadata. The function returns the list of
-// nodes(with their EC metadata) that have the lastest object version
+// nodes(with their EC metadata) that have the lastest object version, as
+// determined by the bucket's `ec.meta_quorum`
 func (c *getJogger) requestMeta(req *Request) (meta *Metadata, nodes map[string]*Metadata, err error) {
 	tmap := c.parent.smap.Get().Tmap
 	wg := &sync.WaitGroup{}
 	mtx := &sync.Mutex{}
 	metas := make(map[string]*Metadata, len(tmap))
-	chk := make(map[string]int, len(tmap))
-	chkMax := 0
-	chkVal := ""
 	for _, node := range tmap {
 		if node.ID() == c.parent.si.ID() {
 			continue
@@ -842,16 +1049,6 @@ func (c *getJogger) requestMeta(req *Request) (meta *Metadata, nodes map[string]
 
 			mtx.Lock()
 			metas[si.ID()] = md
-			// detect the metadata with the latest version on the fly.
-			// At this moment it is the most frequent hash in the list.
-			// TODO: fix when an EC Metadata versioning is introduced
-			cnt := chk[md.ObjCksum]
-			cnt++
-			chk[md.ObjCksum] = cnt
-			if cnt > chkMax {
-				chkMax = cnt
-				chkVal = md.ObjCksum
-			}
 			mtx.Unlock()
 		}(node)
 	}
@@ -861,9 +1058,41 @@ func (c *getJogger) requestMeta(req *Request) (meta *Metadata, nodes map[string]
 	if len(metas) == 0 {
 		return meta, nodes, ErrorNoMetafile
 	}
+	return resolveMeta(req.LOM.ObjName, metas, req.LOM.Bprops().EC.MetaQuorum)
+}
+
+// selects the metadata (and the nodes that have it) according to a given quorum
+func resolveMeta(objName string, metas map[string]*Metadata, quorum string) (meta *Metadata,
+	nodes map[string]*Metadata, err error) {
+	// group the nodes by object checksum
+	groups := make(map[string][]string, 2)
+	for tid, md := range metas {
+		groups[md.ObjCksum] = append(groups[md.ObjCksum], tid)
+	}
+	conflict := &ErrMetaConflict{ObjName: objName, Quorum: quorum, Metas: metas}
+	chkVal := ""
+	if quorum == cmn.ECQuorumNewest && len(groups) > 1 {
+		newest := int64(-1)
+		for cksum, tids := range groups {
+			version, err := strconv.ParseInt(metas[tids[0]].ObjVersion, 10, 64)
+			if err != nil {
+				return nil, nil, conflict // versions cannot be compared
+			}
+			if version > newest || (version == newest && len(tids) > len(groups[chkVal])) {
+				newest, chkVal = version, cksum
+			}
+		}
+	} else {
+		// TODO: fix when an EC Metadata versioning is introduced
+		for cksum, tids := range groups {
+			if len(tids) > len(groups[chkVal]) {
+				chkVal = cksum
+			}
+		}
+	}
 
 	// cleanup: delete all metadatas that have "obsolete" information
-	nodes = make(map[string]*Metadata)
+	nodes = make(map[string]*Metadata, len(groups[chkVal]))
 	for k, v := range metas {
 		if v.ObjCksum == chkVal {
 			meta = v
@@ -872,6 +1101,14 @@ func (c *getJogger) requestMeta(req *Request) (meta *Metadata, nodes map[string]
 			glog.Warningf("Hashes of target %s[slice id %d] mismatch: %s == %s", k, v.SliceID, chkVal, v.ObjCksum)
 		}
 	}
-
+	if quorum == cmn.ECQuorumStrict {
+		conflict.Need = meta.Data + 1
+		if meta.IsCopy {
+			conflict.Need = meta.Parity/2 + 1
+		}
+		if len(nodes) < conflict.Need {
+			return nil, nil, conflict
+		}
+	}
 	return meta, nodes, nil
 }
@@ -0,0 +1,219 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+// cold GET from the Cloud: fails with a given error, if any
+type coldGetTargetMock struct {
+	*cluster.TargetMock
+	err error
+}
+
+func (t *coldGetTargetMock) GetCold(_ context.Context, lom *cluster.LOM, _ bool) (error, int) {
+	if t.err != nil {
+		return t.err, http.StatusNotFound
+	}
+	lom.Lock(false) // returns with the object locked
+	return nil, http.StatusOK
+}
+
+func TestRestoreFromCloud(t *testing.T) {
+	cluster.InitTarget()
+	fs.Mountpaths = fs.NewMountedFS(ios.NewIOStaterMock())
+	fs.Mountpaths.DisableFsIDCheck()
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.RegisterContentType(MetaType, &MetaSpec{})
+
+	mpath, err := ioutil.TempDir("", "ecrestore")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(mpath)
+	tassert.CheckFatal(t, fs.Mountpaths.Add(mpath))
+
+	config := cmn.GCO.BeginUpdate()
+	provider := config.Cloud.Provider
+	config.Cloud.Provider = cmn.ProviderAmazon
+	cmn.GCO.CommitUpdate(config)
+	defer func() {
+		config := cmn.GCO.BeginUpdate()
+		config.Cloud.Provider = provider
+		cmn.GCO.CommitUpdate(config)
+	}()
+
+	var (
+		bck = cluster.NewBck("ecrestore", cmn.ProviderAmazon, cmn.NsGlobal, &cmn.BucketProps{
+			EC: cmn.ECConf{Enabled: true, DataSlices: 2, ParitySlices: 2},
+		})
+		tMock     = &coldGetTargetMock{TargetMock: cluster.NewTargetMock(cluster.NewBaseBownerMock(bck))}
+		c         = &getJogger{parent: &XactGet{xactECBase: xactECBase{t: tMock}}}
+		errSlices = errors.New("too many slices missing")
+		oldECM    = ECM
+	)
+	ECM = &Manager{} // no targets to re-encode
+	defer func() { ECM = oldECM }()
+
+	lom := &cluster.LOM{T: tMock, ObjName: "obj"}
+	tassert.CheckFatal(t, lom.Init(bck.Bck))
+
+	// cold GET fails
+	tMock.err = errors.New("cloud is down")
+	err = c.restoreFromCloud(&Request{LOM: lom}, errSlices)
+	tassert.Fatalf(t, err != nil && strings.Contains(err.Error(), "cloud is down"), "expected cold GET error, got %v", err)
+
+	// cold GET succeeds but re-encoding fails
+	tMock.err = nil
+	err = c.restoreFromCloud(&Request{LOM: lom}, errSlices)
+	tassert.Fatalf(t, err != nil && strings.Contains(err.Error(), ErrorInsufficientTargets.Error()),
+		"expected re-encode error, got %v", err)
+
+	// (the object lock must have been released)
+	lom.Lock(true)
+	lom.Unlock(true)
+}
+
+func TestJoggerStopCtx(t *testing.T) {
+	newJogger := func() *getJogger {
+		return &getJogger{
+			parent: &XactGet{}, mpath: "/tmp/mpath",
+			clientCh: make(chan *Request), bgCh: make(chan *Request),
+			stopCh: cmn.NewStopCh(), doneCh: make(chan struct{}),
+		}
+	}
+	c := newJogger()
+	go c.run()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	tassert.CheckError(t, c.stopCtx(ctx))
+	cancel()
+
+	// not running - returns by the deadline
+	c = newJogger()
+	ctx, cancel = context.WithTimeout(context.Background(), 50*time.Millisecond)
+	err := c.stopCtx(ctx)
+	cancel()
+	tassert.Errorf(t, err == context.DeadlineExceeded, "expected %v, got %v", context.DeadlineExceeded, err)
+}
+
+func TestJoggerClientFirst(t *testing.T) {
+	c := &getJogger{
+		parent: &XactGet{}, mpath: "/tmp/mpath", stats: &mpathStats{},
+		clientCh: make(chan *Request, 1), bgCh: make(chan *Request, 1),
+		stopCh: cmn.NewStopCh(), doneCh: make(chan struct{}),
+	}
+	// (the action is invalid, so that each request fails right away - in the order of processing)
+	var (
+		lom       = &cluster.LOM{ObjName: "obj"}
+		bgReq     = &Request{Action: "none", LOM: lom, ErrCh: make(chan error), prio: prioBackground}
+		clientReq = &Request{Action: "none", LOM: lom, ErrCh: make(chan error), prio: prioClient}
+	)
+	c.bgCh <- bgReq
+	c.clientCh <- clientReq
+	go c.run()
+	defer c.stopCh.Close()
+
+	select {
+	case <-clientReq.ErrCh:
+	case <-bgReq.ErrCh:
+		t.Fatal("background restore preempted the client's one")
+	case <-time.After(time.Second):
+		t.Fatal("timed out")
+	}
+	select {
+	case <-bgReq.ErrCh:
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for the background restore")
+	}
+}
+
+func TestResolveMeta(t *testing.T) {
+	md := func(cksum, version string) *Metadata {
+		return &Metadata{ObjCksum: cksum, ObjVersion: version, Data: 2, Parity: 2}
+	}
+	tests := []struct {
+		name     string
+		quorum   string
+		metas    map[string]*Metadata
+		cksum    string // expected winner ("" - conflict)
+		winnerOf int    // expected number of nodes with the winner
+	}{
+		{
+			name:   "majority",
+			quorum: cmn.ECQuorumMajority,
+			metas: map[string]*Metadata{
+				"t1": md("a", "1"), "t2": md("a", "1"), "t3": md("b", "2"),
+			},
+			cksum: "a", winnerOf: 2,
+		},
+		{
+			name:   "strict: confirmed by data+1 targets",
+			quorum: cmn.ECQuorumStrict,
+			metas: map[string]*Metadata{
+				"t1": md("a", "1"), "t2": md("a", "1"), "t3": md("a", "1"), "t4": md("b", "2"),
+			},
+			cksum: "a", winnerOf: 3,
+		},
+		{
+			name:   "strict: not enough targets",
+			quorum: cmn.ECQuorumStrict,
+			metas: map[string]*Metadata{
+				"t1": md("a", "1"), "t2": md("a", "1"), "t3": md("b", "2"),
+			},
+		},
+		{
+			name:   "newest",
+			quorum: cmn.ECQuorumNewest,
+			metas: map[string]*Metadata{
+				"t1": md("a", "1"), "t2": md("a", "1"), "t3": md("b", "2"),
+			},
+			cksum: "b", winnerOf: 1,
+		},
+		{
+			name:   "newest: versions are not numeric",
+			quorum: cmn.ECQuorumNewest,
+			metas: map[string]*Metadata{
+				"t1": md("a", "v1"), "t2": md("b", "v2"),
+			},
+		},
+		{
+			name:   "newest: no conflict",
+			quorum: cmn.ECQuorumNewest,
+			metas: map[string]*Metadata{
+				"t1": md("a", "v1"), "t2": md("a", "v1"),
+			},
+			cksum: "a", winnerOf: 2,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			meta, nodes, err := resolveMeta("obj", test.metas, test.quorum)
+			if test.cksum == "" {
+				tassert.Fatalf(t, IsErrMetaConflict(err), "expected metadata conflict, got %v", err)
+				conflict := err.(*ErrMetaConflict)
+				tassert.Errorf(t, len(conflict.Metas) == len(test.metas), "expected all metadata in the error")
+				return
+			}
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, meta.ObjCksum == test.cksum, "expected %q, got %q", test.cksum, meta.ObjCksum)
+			tassert.Errorf(t, len(nodes) == test.winnerOf, "expected %d nodes, got %d", test.winnerOf, len(nodes))
+			for tid, md := range nodes {
+				tassert.Errorf(t, md.ObjCksum == test.cksum, "%s: unexpected %q", tid, md.ObjCksum)
+			}
+		})
+	}
+}
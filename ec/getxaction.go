@@ -5,10 +5,12 @@
 package ec
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"time"
 
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -24,6 +26,8 @@ type (
 		xactECBase
 		xactReqBase
 		getJoggers map[string]*getJogger // mountpath joggers for GET
+		sliceCache *sliceCache           // recently fetched slices (see ECConf.SliceCacheSize)
+		spills     atomic.Int64          // number of restores that did not fit into ECConf.RestoreMemLimit
 	}
 )
 
@@ -43,6 +47,7 @@ func NewGetXact(t cluster.Target, smap cluster.Sowner,
 
 	runner := &XactGet{
 		getJoggers:  make(map[string]*getJogger, totalPaths),
+		sliceCache:  newSliceCache(),
 		xactECBase:  newXactECBase(t, smap, si, bck, reqBundle, respBundle),
 		xactReqBase: newXactReqECBase(),
 	}
@@ -96,17 +101,20 @@ func (r *XactGet) newGetJogger(mpath string) *getJogger {
 	config := cmn.GCO.Get()
 	client := cmn.NewClient(cmn.TransportArgs{
 		Timeout:    config.Client.Timeout,
-		UseHTTPS:   config.Net.HTTP.UseHTTPS,
+		UseHTTPS:   config.Net.Listener(cmn.NetworkIntraData).UseHTTPS,
 		SkipVerify: config.Net.HTTP.SkipVerify,
 	})
 	return &getJogger{
-		parent: r,
-		mpath:  mpath,
-		client: client,
-		workCh: make(chan *Request, requestBufSizeFS),
-		stopCh: make(chan struct{}, 1),
-		jobs:   make(map[uint64]bgProcess, 4),
-		sema:   make(chan struct{}, maxBgJobsPerJogger),
+		parent:   r,
+		mpath:    mpath,
+		stats:    r.stats.mpath(mpath),
+		client:   client,
+		clientCh: make(chan *Request, requestBufSizeFS),
+		bgCh:     make(chan *Request, requestBufSizeFS),
+		stopCh:   cmn.NewStopCh(),
+		doneCh:   make(chan struct{}),
+		jobs:     make(map[uint64]bgProcess, 4),
+		sema:     make(chan struct{}, maxBgJobsPerJogger),
 	}
 }
 
@@ -193,9 +201,14 @@ func (r *XactGet) stop() {
 
 	XactCount.Dec()
 	r.XactDemandBase.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), joggerStopTimeout)
 	for _, jog := range r.getJoggers {
-		jog.stop()
+		if err := jog.stopCtx(ctx); err != nil {
+			glog.Errorf("%s: failed to stop %s jogger: %v", r, jog.mpath, err)
+		}
 	}
+	cancel()
+	r.sliceCache.clear()
 
 	// Don't close bundles, they are shared between bucket's EC actions
 
@@ -266,8 +279,15 @@ func (r *XactGet) dispatchRequest(req *Request) {
 
 	jogger, ok := r.getJoggers[req.LOM.ParsedFQN.MpathInfo.Path]
 	cmn.AssertMsg(ok, "Invalid mountpath given in EC request")
-	r.stats.updateQueue(len(jogger.workCh))
-	jogger.workCh <- req
+	r.stats.enqueue(req.prio)
+	jogger.stats.queueLen.Inc()
+	if req.prio == prioBackground {
+		r.stats.updateQueue(len(jogger.bgCh))
+		jogger.bgCh <- req
+		return
+	}
+	r.stats.updateQueue(len(jogger.clientCh))
+	jogger.clientCh <- req
 }
 
 //
@@ -287,7 +307,11 @@ func (r *XactGet) addMpath(mpath string) {
 func (r *XactGet) removeMpath(mpath string) {
 	getJog, ok := r.getJoggers[mpath]
 	cmn.AssertMsg(ok, "Mountpath unregister handler for EC called with invalid mountpath")
-	getJog.stop()
+	ctx, cancel := context.WithTimeout(context.Background(), joggerStopTimeout)
+	if err := getJog.stopCtx(ctx); err != nil {
+		glog.Errorf("%s: failed to stop %s jogger: %v", r, mpath, err)
+	}
+	cancel()
 	delete(r.getJoggers, mpath)
 }
 
@@ -301,6 +325,18 @@ type ExtECGetStats struct {
 	ErrCount    int64   `json:"ec.decode.err.n,string"`
 	AvgObjTime  int64   `json:"ec.obj.process.time,string"`
 	AvgQueueLen float64 `json:"ec.queue.len.n"`
+	// current number of pending restores per priority
+	ClientQueueLen int64 `json:"ec.queue.client.n,string"`
+	BgQueueLen     int64 `json:"ec.queue.bg.n,string"`
+	// number of slices taken from the slice cache
+	SliceCacheHits int64 `json:"ec.slice_cache.hit.n,string"`
+	// number of restores that buffered slices in workfiles for lack of memory budget
+	Spills int64 `json:"ec.restore.spill.n,string"`
+	// replicas and slices sent to other targets to complete the restores
+	RepairCount int64 `json:"ec.repair.n,string"`
+	RepairSize  int64 `json:"ec.repair.size,string"`
+	// per-mountpath (jogger) stats
+	Mpaths map[string]*MpathStats `json:"ec.mpaths,omitempty"`
 }
 
 var (
@@ -317,5 +353,12 @@ func (r *XactGet) Stats() cmn.XactStats {
 	getStats.ObjCountX = st.GetReq
 	getStats.Ext.AvgObjTime = st.ObjTime.Nanoseconds()
 	getStats.Ext.AvgQueueLen = st.QueueLen
+	getStats.Ext.ClientQueueLen = st.ClientQueueLen
+	getStats.Ext.BgQueueLen = st.BgQueueLen
+	getStats.Ext.SliceCacheHits = r.sliceCache.hits.Load()
+	getStats.Ext.Spills = r.spills.Load()
+	getStats.Ext.RepairCount = st.RepairCnt
+	getStats.Ext.RepairSize = st.RepairSize
+	getStats.Ext.Mpaths = r.stats.mpathStats()
 	return &getStats
 }
@@ -22,6 +22,12 @@ const (
 	// a target cleans up the object and notifies all other targets to do
 	// cleanup as well. Destinations do not have to respond
 	reqDel
+	// a target has converted the object from replicas to slices or vice versa
+	// (the object's size has crossed `ec.objsize_limit`), and notifies the
+	// targets that keep the obsolete ones: a destination removes its slice
+	// if `isSlice` is set, and its replica otherwise. Metafiles are kept (they
+	// get overwritten). Destinations do not have to respond
+	reqDelStale
 )
 
 type (
@@ -45,6 +51,9 @@ type (
 		exists bool
 		// The sent data is slice or full replica
 		isSlice bool
+		// ID of the restore request that triggered the transfer (empty
+		// otherwise), to trace repair chains across targets
+		traceID string
 	}
 )
 
@@ -56,11 +65,11 @@ var (
 
 func (r *intraReq) PackedSize() int {
 	if r.meta == nil {
-		// int8(type)+sender(string)+int8+int8+ptr_marker
-		return cmn.SizeofLen + len(r.sender) + 4
+		// int8(type)+sender(string)+int8+int8+traceID(string)+ptr_marker
+		return cmn.SizeofLen + len(r.sender) + cmn.SizeofLen + len(r.traceID) + 4
 	}
-	// int8(type)+sender(string)+int8+int8+ptr_marker+sizeof(meta)
-	return cmn.SizeofLen + len(r.sender) + r.meta.PackedSize() + 4
+	// int8(type)+sender(string)+int8+int8+traceID(string)+ptr_marker+sizeof(meta)
+	return cmn.SizeofLen + len(r.sender) + cmn.SizeofLen + len(r.traceID) + r.meta.PackedSize() + 4
 }
 
 func (r *intraReq) Pack(packer *cmn.BytePack) {
@@ -68,6 +77,7 @@ func (r *intraReq) Pack(packer *cmn.BytePack) {
 	packer.WriteString(r.sender)
 	packer.WriteBool(r.exists)
 	packer.WriteBool(r.isSlice)
+	packer.WriteString(r.traceID)
 	if r.meta == nil {
 		packer.WriteByte(0)
 	} else {
@@ -94,6 +104,9 @@ func (r *intraReq) Unpack(unpacker *cmn.ByteUnpack) error {
 	if r.isSlice, err = unpacker.ReadBool(); err != nil {
 		return err
 	}
+	if r.traceID, err = unpacker.ReadString(); err != nil {
+		return err
+	}
 	if i, err = unpacker.ReadByte(); err != nil {
 		return err
 	}
@@ -98,6 +98,7 @@ func (mgr *Manager) initECBundles() {
 	extraReq := transport.Extra{
 		Callback:    cbReq,
 		Compression: compression,
+		SegCksum:    true,
 	}
 
 	reqSbArgs := transport.SBArgs{
@@ -111,7 +112,7 @@ func (mgr *Manager) initECBundles() {
 		Multiplier: transport.IntraBundleMultiplier,
 		Trname:     RespStreamName,
 		Network:    mgr.netResp,
-		Extra:      &transport.Extra{Compression: compression},
+		Extra:      &transport.Extra{Compression: compression, SegCksum: true},
 	}
 
 	sowner := mgr.t.GetSowner()
@@ -284,16 +285,33 @@ func (mgr *Manager) recvResponse(w http.ResponseWriter, hdr transport.Header, ob
 //   - intra - if true, it is internal request and has low priority
 //   - cb - optional callback that is called after the object is encoded
 func (mgr *Manager) EncodeObject(lom *cluster.LOM, cb ...cluster.OnFinishObj) error {
+	return mgr.encodeObject(lom, nil, cb...)
+}
+
+// EncodeObjectPolicy encodes the object as per the per-object policy that
+// overrides the bucket's EC configuration (see policy.go); nil policy - as per bucket
+func (mgr *Manager) EncodeObjectPolicy(lom *cluster.LOM, policy *Policy) error {
+	return mgr.encodeObject(lom, policy)
+}
+
+func (mgr *Manager) encodeObject(lom *cluster.LOM, policy *Policy, cb ...cluster.OnFinishObj) error {
 	if !lom.Bprops().EC.Enabled {
 		return ErrorECDisabled
 	}
+	ecConf := lom.Bprops().EC
+	if policy != nil {
+		var err error
+		if ecConf, err = policy.Apply(ecConf); err != nil {
+			return err
+		}
+	}
 
-	isECCopy := IsECCopy(lom.Size(), &lom.Bprops().EC)
+	isECCopy := IsECCopy(lom.Size(), &ecConf)
 	targetCnt := mgr.targetCnt.Load()
 
 	// tradeoff: encoding small object might require just 1 additional target available
 	// we will start xaction to satisfy this request
-	if required := lom.Bprops().EC.RequiredEncodeTargets(); !isECCopy && int(targetCnt) < required {
+	if required := ecConf.RequiredEncodeTargets(); !isECCopy && int(targetCnt) < required {
 		glog.Warningf("not enough targets to encode the object; actual: %v, required: %v", targetCnt, required)
 		return ErrorInsufficientTargets
 	}
@@ -312,9 +330,10 @@ func (mgr *Manager) EncodeObject(lom *cluster.LOM, cb ...cluster.OnFinishObj) er
 
 	req := &Request{
 		Action:  ActSplit,
-		IsCopy:  IsECCopy(lom.Size(), &lom.Bprops().EC),
+		IsCopy:  isECCopy,
 		LOM:     lom,
 		rebuild: len(cb) != 0,
+		policy:  policy,
 	}
 	if len(cb) != 0 {
 		req.Callback = cb[0]
@@ -325,6 +344,91 @@ func (mgr *Manager) EncodeObject(lom *cluster.LOM, cb ...cluster.OnFinishObj) er
 	return nil
 }
 
+// ReprotectObject rebuilds the EC protection of the object from scratch - e.g.,
+// after the object's file has been restored manually: removes the replicas
+// (slices) that other targets keep, if any, and encodes the object anew as per
+// its policy (if any) or the bucket's configuration. Waits for the encoding to
+// complete and returns where the new replicas (slices) go.
+// NOTE: must be called by the object's main target, under the object's lock.
+func (mgr *Manager) ReprotectObject(lom *cluster.LOM) (*cmn.ECPlacement, error) {
+	if !lom.Bprops().EC.Enabled {
+		return nil, ErrorECDisabled
+	}
+	req := &Request{
+		Action: ActSplit,
+		LOM:    lom,
+		ErrCh:  make(chan error), // unbuffered
+		noPack: true,
+		reprot: true,
+	}
+	if md, err := ObjectMetadata(lom.Bck(), lom.ObjName); err == nil {
+		if md.PackName != "" {
+			return nil, fmt.Errorf("%s is packed into container %s - re-protect the container instead", lom, md.PackName)
+		}
+		if md.Policy != "" {
+			if req.policy, err = ParsePolicy(md.Policy); err != nil {
+				return nil, err
+			}
+		}
+		req.packed = md.Packed
+	}
+	ecConf := req.ecConf()
+	req.IsCopy = IsECCopy(lom.Size(), &ecConf)
+	cnt := ecConf.ParitySlices
+	if !req.IsCopy {
+		cnt += ecConf.DataSlices
+	}
+	if targetCnt := int(mgr.targetCnt.Load()); targetCnt < cnt+1 {
+		return nil, ErrorInsufficientTargets
+	}
+	targets, err := cluster.HrwTargetListEC(lom.Uname(), lom.T.GetSowner().Get(), cnt+1, &ecConf)
+	if err != nil {
+		return nil, err
+	}
+
+	mgr.RestoreBckPutXact(lom.Bck()).Encode(req)
+	if err := <-req.ErrCh; err != nil {
+		return nil, err
+	}
+
+	placement := &cmn.ECPlacement{
+		Name:      lom.ObjName,
+		Target:    targets[0].ID(),
+		ECReplica: req.IsCopy,
+		Parity:    ecConf.ParitySlices,
+		Slices:    make([]*cmn.ECSliceLoc, 0, cnt),
+	}
+	if !req.IsCopy {
+		placement.Data = ecConf.DataSlices
+	}
+	for i, si := range targets[1:] {
+		loc := &cmn.ECSliceLoc{Target: si.ID()}
+		if !req.IsCopy {
+			loc.SliceID = i + 1
+		}
+		placement.Slices = append(placement.Slices, loc)
+	}
+	return placement, nil
+}
+
+// encodePack erasure codes a container of small objects (see pack.go)
+func (mgr *Manager) encodePack(lom *cluster.LOM, index []PackEntry) error {
+	if !lom.Bprops().EC.Enabled {
+		return ErrorECDisabled
+	}
+	if required := lom.Bprops().EC.RequiredEncodeTargets(); int(mgr.targetCnt.Load()) < required {
+		return ErrorInsufficientTargets
+	}
+	req := &Request{
+		Action: ActSplit,
+		IsCopy: IsECCopy(lom.Size(), &lom.Bprops().EC),
+		LOM:    lom,
+		packed: index,
+	}
+	mgr.RestoreBckPutXact(lom.Bck()).Encode(req)
+	return nil
+}
+
 func (mgr *Manager) CleanupObject(lom *cluster.LOM) {
 	if !lom.Bprops().EC.Enabled {
 		return
@@ -339,7 +443,19 @@ func (mgr *Manager) CleanupObject(lom *cluster.LOM) {
 	mgr.RestoreBckPutXact(lom.Bck()).Cleanup(req)
 }
 
+// RestoreObject restores the object on behalf of a client that waits for it
+// (e.g., GET) - the request preempts all pending background restores
 func (mgr *Manager) RestoreObject(lom *cluster.LOM) error {
+	return mgr.restoreObject(lom, prioClient)
+}
+
+// RestoreObjectBg restores the object in background (e.g., when scrubbing or
+// rebalancing) - the request yields to client-blocking restores
+func (mgr *Manager) RestoreObjectBg(lom *cluster.LOM) error {
+	return mgr.restoreObject(lom, prioBackground)
+}
+
+func (mgr *Manager) restoreObject(lom *cluster.LOM, prio int) error {
 	if !lom.Bprops().EC.Enabled {
 		return ErrorECDisabled
 	}
@@ -353,9 +469,11 @@ func (mgr *Manager) RestoreObject(lom *cluster.LOM) error {
 
 	cmn.Assert(lom.ParsedFQN.MpathInfo != nil && lom.ParsedFQN.MpathInfo.Path != "")
 	req := &Request{
-		Action: ActRestore,
-		LOM:    lom,
-		ErrCh:  make(chan error), // unbuffered
+		Action:  ActRestore,
+		LOM:     lom,
+		ErrCh:   make(chan error), // unbuffered
+		prio:    prio,
+		traceID: cmn.GenUUID(),
 	}
 
 	mgr.RestoreBckGetXact(lom.Bck()).Decode(req)
@@ -5,6 +5,7 @@
 package ec
 
 import (
+	"errors"
 	"fmt"
 	"io/ioutil"
 
@@ -24,8 +25,37 @@ type Metadata struct {
 	Parity     int    `json:"parity"`                    // the number of parity slices
 	SliceID    int    `json:"sliceid,omitempty"`         // 0 for full replica, 1 to N for slices
 	IsCopy     bool   `json:"copy"`                      // object is replicated(true) or encoded(false)
+	// small objects packed into containers (see pack.go)
+	PackName   string      `json:"pack,omitempty"`        // name of the container that holds the (small) object
+	PackOffset int64       `json:"pack_offset,omitempty"` // offset of the object in the container
+	Packed     []PackEntry `json:"packed,omitempty"`      // container's index: the objects it holds
+	// per-object policy that has overridden the bucket's EC configuration (see policy.go)
+	Policy string `json:"policy,omitempty"`
+	// the slice is stored lz4-compressed (see compress.go)
+	Compressed bool `json:"compressed,omitempty"`
+	// custom metadata of the object (e.g., the sealed data key of an encrypted object)
+	CustomMD cmn.SimpleKVs `json:"custom_md,omitempty"`
 }
 
+// PackEntry - an object packed into a container
+type PackEntry struct {
+	ObjName    string `json:"name"`
+	Offset     int64  `json:"offset"`
+	Size       int64  `json:"size"`
+	ObjCksum   string `json:"obj_chk,omitempty"`
+	ObjVersion string `json:"obj_version,omitempty"`
+}
+
+// Binary metafile format: a marker byte, format version, and the packed
+// Metadata (see Pack). The marker can never start a JSON document, which
+// is how the metafiles of the (legacy) JSON format are told apart.
+const (
+	MetaVersion = 1 // current version of the binary metafile format
+
+	metaMarker  = 0xEC
+	metaHdrSize = 2 // marker + version
+)
+
 var (
 	// interface guard
 	_ cmn.Unpacker = &Metadata{}
@@ -38,8 +68,8 @@ func LoadMetadata(fqn string) (*Metadata, error) {
 	if err != nil {
 		return nil, err
 	}
-	md := &Metadata{}
-	if err := jsoniter.Unmarshal(b, md); err != nil {
+	md, err := MetaFromBytes(b)
+	if err != nil {
 		err := fmt.Errorf("damaged metafile %q: %v", fqn, err)
 		return nil, err
 	}
@@ -47,10 +77,49 @@ func LoadMetadata(fqn string) (*Metadata, error) {
 	return md, nil
 }
 
+// MetaFromBytes parses the content of a metafile: binary or JSON (legacy)
+func MetaFromBytes(b []byte) (*Metadata, error) {
+	md := &Metadata{}
+	if !IsBinaryMeta(b) {
+		if err := jsoniter.Unmarshal(b, md); err != nil {
+			return nil, err
+		}
+		return md, nil
+	}
+	if len(b) < metaHdrSize {
+		return nil, errors.New("metadata header is too short")
+	}
+	var (
+		err      error
+		unpacker = cmn.NewUnpacker(b[metaHdrSize:])
+	)
+	if b[1] != MetaVersion {
+		return nil, fmt.Errorf("unsupported metafile format version %d (expected %d)", b[1], MetaVersion)
+	}
+	if err = unpacker.ReadAny(md); err != nil {
+		return nil, err
+	}
+	return md, nil
+}
+
+// IsBinaryMeta returns true if the content of a metafile is in binary format
+func IsBinaryMeta(b []byte) bool { return len(b) > 0 && b[0] == metaMarker }
+
+// Marshal returns JSON-encoded metadata - to send it over HTTP and to
+// show it to a user. Metafiles are stored in binary format (see NewPack)
 func (md *Metadata) Marshal() []byte {
 	return cmn.MustMarshal(md)
 }
 
+// NewPack returns metadata in binary format - to store it in a metafile
+func (md *Metadata) NewPack() []byte {
+	packer := cmn.NewPacker(nil, metaHdrSize+md.PackedSize())
+	packer.WriteByte(metaMarker)
+	packer.WriteByte(MetaVersion)
+	packer.WriteAny(md)
+	return packer.Bytes()
+}
+
 func MetaToString(md *Metadata) string {
 	if md == nil {
 		return ""
@@ -77,7 +146,10 @@ func ObjectMetadata(bck *cluster.Bck, objName string) (*Metadata, error) {
 }
 
 func (md *Metadata) Unpack(unpacker *cmn.ByteUnpack) (err error) {
-	var i uint16
+	var (
+		i   uint16
+		cnt uint32
+	)
 	if md.Size, err = unpacker.ReadInt64(); err != nil {
 		return
 	}
@@ -105,7 +177,61 @@ func (md *Metadata) Unpack(unpacker *cmn.ByteUnpack) (err error) {
 	if md.CksumType, err = unpacker.ReadString(); err != nil {
 		return
 	}
-	md.CksumValue, err = unpacker.ReadString()
+	if md.CksumValue, err = unpacker.ReadString(); err != nil {
+		return
+	}
+	if md.PackName, err = unpacker.ReadString(); err != nil {
+		return
+	}
+	if md.PackOffset, err = unpacker.ReadInt64(); err != nil {
+		return
+	}
+	if cnt, err = unpacker.ReadUint32(); err != nil {
+		return
+	}
+	if cnt > 0 {
+		md.Packed = make([]PackEntry, cnt)
+	}
+	for i := range md.Packed {
+		entry := &md.Packed[i]
+		if entry.ObjName, err = unpacker.ReadString(); err != nil {
+			return
+		}
+		if entry.Offset, err = unpacker.ReadInt64(); err != nil {
+			return
+		}
+		if entry.Size, err = unpacker.ReadInt64(); err != nil {
+			return
+		}
+		if entry.ObjCksum, err = unpacker.ReadString(); err != nil {
+			return
+		}
+		if entry.ObjVersion, err = unpacker.ReadString(); err != nil {
+			return
+		}
+	}
+	if md.Policy, err = unpacker.ReadString(); err != nil {
+		return
+	}
+	if md.Compressed, err = unpacker.ReadBool(); err != nil {
+		return
+	}
+	if cnt, err = unpacker.ReadUint32(); err != nil {
+		return
+	}
+	if cnt > 0 {
+		md.CustomMD = make(cmn.SimpleKVs, cnt)
+	}
+	for ; cnt > 0; cnt-- {
+		var k, v string
+		if k, err = unpacker.ReadString(); err != nil {
+			return
+		}
+		if v, err = unpacker.ReadString(); err != nil {
+			return
+		}
+		md.CustomMD[k] = v
+	}
 	return
 }
 
@@ -119,11 +245,40 @@ func (md *Metadata) Pack(packer *cmn.BytePack) {
 	packer.WriteString(md.ObjVersion)
 	packer.WriteString(md.CksumType)
 	packer.WriteString(md.CksumValue)
+	packer.WriteString(md.PackName)
+	packer.WriteInt64(md.PackOffset)
+	packer.WriteUint32(uint32(len(md.Packed)))
+	for i := range md.Packed {
+		entry := &md.Packed[i]
+		packer.WriteString(entry.ObjName)
+		packer.WriteInt64(entry.Offset)
+		packer.WriteInt64(entry.Size)
+		packer.WriteString(entry.ObjCksum)
+		packer.WriteString(entry.ObjVersion)
+	}
+	packer.WriteString(md.Policy)
+	packer.WriteBool(md.Compressed)
+	packer.WriteUint32(uint32(len(md.CustomMD)))
+	for k, v := range md.CustomMD {
+		packer.WriteString(k)
+		packer.WriteString(v)
+	}
 }
 
 // int16 is sufficient to keep Data,Parity, and SliceID, so:
 //    int64 + 3*int16 + bool + 4 strings
+// plus the container's name, offset, and index, the policy, the compression flag, and custom metadata
 func (md *Metadata) PackedSize() int {
-	return cmn.SizeofI64 + cmn.SizeofI16*3 + 1 + cmn.SizeofLen*4 +
-		len(md.ObjCksum) + len(md.ObjVersion) + len(md.CksumType) + len(md.CksumValue)
+	size := cmn.SizeofI64 + cmn.SizeofI16*3 + 1 + cmn.SizeofLen*4 +
+		len(md.ObjCksum) + len(md.ObjVersion) + len(md.CksumType) + len(md.CksumValue) +
+		cmn.SizeofLen + len(md.PackName) + cmn.SizeofI64 + cmn.SizeofI32 +
+		cmn.SizeofLen + len(md.Policy) + 1 + cmn.SizeofI32
+	for i := range md.Packed {
+		entry := &md.Packed[i]
+		size += cmn.SizeofLen*3 + cmn.SizeofI64*2 + len(entry.ObjName) + len(entry.ObjCksum) + len(entry.ObjVersion)
+	}
+	for k, v := range md.CustomMD {
+		size += cmn.SizeofLen*2 + len(k) + len(v)
+	}
+	return size
 }
@@ -0,0 +1,95 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestMetaPackRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		md   *Metadata
+	}{
+		{name: "empty", md: &Metadata{}},
+		{
+			name: "replica",
+			md: &Metadata{
+				Size: 1024, ObjCksum: "0123456789abcdef", ObjVersion: "3",
+				Data: 2, Parity: 2, IsCopy: true, Policy: "2:1",
+			},
+		},
+		{
+			name: "slice",
+			md: &Metadata{
+				Size: 1 << 20, ObjCksum: "fedcba9876543210", ObjVersion: "1",
+				CksumType: "xxhash", CksumValue: "aabbccdd", Data: 4, Parity: 2, SliceID: 5, Compressed: true,
+				CustomMD: cmn.SimpleKVs{"sse": "aes-256-gcm", "sse-data-key": "c2VhbGVk"},
+			},
+		},
+		{
+			name: "packed",
+			md: &Metadata{
+				Size: 100, ObjCksum: "01", PackName: ".ec.pack/abc", PackOffset: 4096,
+				Packed: []PackEntry{
+					{ObjName: "obj-1", Offset: 0, Size: 10, ObjCksum: "1a", ObjVersion: "1"},
+					{ObjName: "obj-2", Offset: 10, Size: 90},
+				},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := test.md.NewPack()
+			tassert.Fatalf(t, IsBinaryMeta(b), "expected binary metafile")
+			tassert.Fatalf(t, len(b) == metaHdrSize+test.md.PackedSize(),
+				"expected %d bytes, got %d", metaHdrSize+test.md.PackedSize(), len(b))
+			md, err := MetaFromBytes(b)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, reflect.DeepEqual(md, test.md), "expected %+v, got %+v", test.md, md)
+		})
+	}
+}
+
+// metafiles of the legacy (JSON) format are read as is
+func TestMetaLegacyJSON(t *testing.T) {
+	const legacy = `{"size":1024,"obj_chk":"0123","obj_version":"2",` +
+		`"slice_ck_type":"xxhash","slice_chk_value":"abcd","data":2,"parity":1,"sliceid":3,"copy":false}`
+	b := []byte(legacy)
+	tassert.Fatalf(t, !IsBinaryMeta(b), "legacy metafile taken for binary")
+	md, err := MetaFromBytes(b)
+	tassert.CheckFatal(t, err)
+	expected := &Metadata{
+		Size: 1024, ObjCksum: "0123", ObjVersion: "2", CksumType: "xxhash", CksumValue: "abcd",
+		Data: 2, Parity: 1, SliceID: 3,
+	}
+	tassert.Errorf(t, reflect.DeepEqual(md, expected), "expected %+v, got %+v", expected, md)
+
+	// and converted (see XactBckMetaMigrate)
+	md, err = MetaFromBytes(md.NewPack())
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, reflect.DeepEqual(md, expected), "expected %+v, got %+v", expected, md)
+}
+
+func TestMetaDamaged(t *testing.T) {
+	b := (&Metadata{Size: 1024, ObjCksum: "0123", Data: 2, Parity: 1}).NewPack()
+	tests := []struct {
+		name string
+		b    []byte
+	}{
+		{name: "header-only", b: b[:1]},
+		{name: "truncated", b: b[:len(b)-3]},
+		{name: "unknown-version", b: append([]byte{metaMarker, MetaVersion + 1}, b[metaHdrSize:]...)},
+		{name: "json", b: []byte(`{"size":`)},
+	}
+	for _, test := range tests {
+		_, err := MetaFromBytes(test.b)
+		tassert.Errorf(t, err != nil, "%s: expected error", test.name)
+	}
+}
@@ -0,0 +1,483 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+	"unsafe"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/transport"
+)
+
+// Packing small objects
+//
+// Objects below `ec.objsize_limit` are replicated by default, which costs
+// `ec.parity_slices` full copies (and metafiles) per object. With
+// `ec.pack_size` set, the main target also accumulates the small objects it
+// receives into a container. Once the container reaches `ec.pack_size` (or
+// does not grow for a while), it is stored as a regular object of the same
+// bucket (named cmn.ECPackPrefix + unique ID, and always local to the target
+// that has created it) and erasure coded as a whole. The index of the
+// container (see PackEntry) is kept in the container's EC metadata, while
+// the metafile of each packed object points to the container and the
+// object's offset in it.
+//
+// Until then, the objects are replicated as usual: the replicas get removed
+// only after the container is erasure coded (and are simply kept if packing
+// fails). The containers are internal - not listed, and neither deleted nor
+// overwritten by clients.
+//
+// A packed object is restored by restoring (if needed) its container and
+// extracting the object from it. When the main target of a packed object
+// is lost together with the object's metafile, the object is restored by
+// the `ecrestore` xaction that looks up the objects in the containers' indices.
+//
+// Containers are never rewritten. Instead, they get compacted (see compact):
+// once most of a container is taken by the objects that have been deleted or
+// overwritten, the rest of its objects are packed anew, and the container is
+// removed when none of its objects remains.
+
+const (
+	packNameTries       = 1024      // to generate a container name that maps to this target
+	packCompactInterval = time.Hour // how often the containers are checked for garbage
+	packCompactRatio    = 2         // re-pack when less than 1/ratio of a container is alive
+)
+
+type (
+	// packer accumulates (on the main target) small objects into a container
+	packer struct {
+		mtx        sync.Mutex
+		parent     *XactPut
+		t          cluster.Target
+		bck        cmn.Bck
+		compacting atomic.Bool
+		compacted  time.Time
+		sgl        *memsys.SGL
+		index      []PackEntry
+		members    []*cluster.LOM
+		metas      []*Metadata
+		started    time.Time
+	}
+	// sealed container, to be stored and erasure coded
+	sealedPack struct {
+		sgl     *memsys.SGL
+		index   []PackEntry
+		members []*cluster.LOM
+		metas   []*Metadata
+	}
+)
+
+func newPacker(parent *XactPut, bck cmn.Bck) *packer {
+	return &packer{parent: parent, t: parent.t, bck: bck, compacted: time.Now()}
+}
+
+// returns true if a given (replicated) object must also be packed
+// (objects with custom metadata are not - the container's index does not keep it)
+func (p *packer) wants(req *Request, ecConf *cmn.ECConf) bool {
+	return req.IsCopy && ecConf.PackSize > 0 && !req.noPack && req.packed == nil && !cmn.IsECPackName(req.LOM.ObjName) &&
+		len(req.LOM.CustomMD()) == 0
+}
+
+// adds a small object to the container, and seals the container when it's full
+func (p *packer) add(lom *cluster.LOM, meta *Metadata, packSize int64) error {
+	fh, err := cmn.NewFileHandle(lom.FQN)
+	if err != nil {
+		return err
+	}
+	defer func() { debug.AssertNoErr(fh.Close()) }()
+
+	p.mtx.Lock()
+	if p.sgl == nil {
+		p.sgl = mm.NewSGL(packSize)
+		p.started = time.Now()
+	}
+	offset := p.sgl.Size()
+	n, err := io.Copy(p.sgl, fh)
+	if err == nil && n != lom.Size() {
+		err = fmt.Errorf("%s: size mismatch (%d vs %d)", lom, n, lom.Size())
+	}
+	if err != nil {
+		// the container is damaged: the objects added so far remain replicated
+		p.abortLocked()
+		p.mtx.Unlock()
+		return err
+	}
+	p.index = append(p.index, PackEntry{
+		ObjName:    lom.ObjName,
+		Offset:     offset,
+		Size:       n,
+		ObjCksum:   meta.ObjCksum,
+		ObjVersion: lom.Version(),
+	})
+	p.members = append(p.members, lom)
+	p.metas = append(p.metas, meta)
+	var sealed *sealedPack
+	if p.sgl.Size() >= packSize {
+		sealed = p.sealLocked()
+	}
+	p.mtx.Unlock()
+
+	if sealed != nil {
+		go p.store(sealed)
+	}
+	return nil
+}
+
+// seals the container if it has not grown for a given time
+func (p *packer) flush(idle time.Duration) {
+	p.mtx.Lock()
+	if p.sgl == nil || time.Since(p.started) < idle {
+		p.mtx.Unlock()
+		return
+	}
+	sealed := p.sealLocked()
+	p.mtx.Unlock()
+	go p.store(sealed)
+}
+
+// must be called under lock
+func (p *packer) sealLocked() *sealedPack {
+	sealed := &sealedPack{sgl: p.sgl, index: p.index, members: p.members, metas: p.metas}
+	p.sgl, p.index, p.members, p.metas = nil, nil, nil, nil
+	return sealed
+}
+
+// must be called under lock
+func (p *packer) abortLocked() {
+	if p.sgl != nil {
+		p.sgl.Free()
+	}
+	p.sgl, p.index, p.members, p.metas = nil, nil, nil, nil
+}
+
+// stores the container locally and erasure codes it; then points the
+// metafiles of the packed objects to the container and removes their replicas
+func (p *packer) store(sealed *sealedPack) {
+	defer sealed.sgl.Free()
+	lom, err := p.newPackLOM()
+	if err == nil {
+		lom.SetSize(sealed.sgl.Size())
+		err = WriteObject(p.t, lom, memsys.NewReader(sealed.sgl), sealed.sgl.Size(), lom.CksumConf().Type)
+	}
+	if err == nil {
+		err = ECM.encodePack(lom, sealed.index)
+	}
+	if err != nil {
+		glog.Errorf("failed to pack %d object(s) of %s (the objects remain replicated): %v",
+			len(sealed.members), p.bck, err)
+		return
+	}
+	var n int
+	for i, member := range sealed.members {
+		if p.repoint(member, sealed.metas[i], lom.ObjName, &sealed.index[i]) {
+			n++
+		}
+	}
+	if glog.V(4) {
+		glog.Infof("packed %d object(s) into %s", n, lom)
+	}
+}
+
+// points the object's metafile to the container, and removes its replicas -
+// unless the object has been deleted or overwritten in the meantime (in which
+// case its entry in the container is garbage - see compact)
+func (p *packer) repoint(member *cluster.LOM, meta *Metadata, packName string, entry *PackEntry) bool {
+	lom := &cluster.LOM{T: p.t, ObjName: member.ObjName}
+	if err := lom.Init(p.bck); err != nil {
+		glog.Error(err)
+		return false
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false); err != nil || lom.Version() != entry.ObjVersion || lom.Size() != entry.Size {
+		return false
+	}
+	if entry.ObjCksum != "" && lom.Cksum() != nil {
+		if _, value := lom.Cksum().Get(); value != entry.ObjCksum {
+			return false
+		}
+	}
+	md := *meta
+	md.PackName = packName
+	md.PackOffset = entry.Offset
+	ctMeta := cluster.NewCTFromLOM(lom, MetaType)
+	if err := ctMeta.Write(p.t, bytes.NewReader(md.NewPack()), -1); err != nil {
+		glog.Errorf("failed to update metafile of %s: %v", lom, err)
+		return false
+	}
+	if err := p.dropReplicas(lom, &md); err != nil {
+		glog.Errorf("%s: failed to remove replicas: %v", lom, err)
+	}
+	return true
+}
+
+// removes the replicas (and their metafiles) of a packed object
+func (p *packer) dropReplicas(lom *cluster.LOM, md *Metadata) error {
+	ecConf := lom.Bprops().EC
+	targets, err := cluster.HrwTargetListEC(lom.Uname(), p.parent.smap.Get(), md.Parity+1, &ecConf)
+	if err != nil {
+		return err
+	}
+	iReq := p.parent.newIntraReq(reqDel, nil)
+	hdr := transport.Header{
+		Bck:     lom.Bck().Bck,
+		ObjName: lom.ObjName,
+		Opaque:  iReq.NewPack(p.t.GetSmallMMSA()),
+	}
+	cb := func(hdr transport.Header, _ io.ReadCloser, _ unsafe.Pointer, err error) {
+		p.t.GetSmallMMSA().Free(hdr.Opaque)
+		if err != nil {
+			glog.Errorf("failed to send o[%s/%s], err: %v", hdr.Bck, hdr.ObjName, err)
+		}
+	}
+	return p.parent.reqBundle.Send(transport.Obj{Hdr: hdr, Callback: cb}, nil, targets[1:]...)
+}
+
+// generates the name of a container that this target is the main target for
+func (p *packer) newPackLOM() (*cluster.LOM, error) {
+	var (
+		bck  = cluster.NewBckEmbed(p.bck)
+		smap = p.t.GetSowner().Get()
+		sid  = p.t.Snode().ID()
+	)
+	for i := 0; i < packNameTries; i++ {
+		objName := cmn.ECPackPrefix + cmn.GenUUID()
+		si, err := cluster.HrwTarget(bck.MakeUname(objName), smap)
+		if err != nil {
+			return nil, err
+		}
+		if si.ID() != sid {
+			continue
+		}
+		lom := &cluster.LOM{T: p.t, ObjName: objName}
+		if err := lom.Init(p.bck); err != nil {
+			return nil, err
+		}
+		return lom, nil
+	}
+	return nil, fmt.Errorf("%s: failed to generate container name", p.t.Snode())
+}
+
+// checks the containers for garbage once in a while (see compact)
+func (p *packer) maybeCompact() {
+	if time.Since(p.compacted) < packCompactInterval || !p.compacting.CAS(false, true) {
+		return
+	}
+	p.compacted = time.Now()
+	go func() {
+		p.compact()
+		p.compacting.Store(false)
+	}()
+}
+
+// compacts the bucket's containers that this target is the main target for:
+// removes the containers none of whose objects remains, and re-packs the live
+// objects of the containers that are mostly garbage (so that they get removed
+// the next time around)
+func (p *packer) compact() {
+	var (
+		containers []*cluster.LOM
+		sid        = p.t.Snode().ID()
+		smap       = p.parent.smap.Get()
+	)
+	availablePaths, _ := fs.Mountpaths.Get()
+	for _, mpathInfo := range availablePaths {
+		opts := &fs.Options{
+			Dir: mpathInfo.MakePathFQN(p.bck, fs.ObjectType, cmn.ECPackPrefix),
+			Callback: func(fqn string, de fs.DirEntry) error {
+				if de.IsDir() {
+					return nil
+				}
+				lom := &cluster.LOM{T: p.t, FQN: fqn}
+				if err := lom.Init(p.bck); err != nil || !lom.IsHRW() {
+					return nil
+				}
+				containers = append(containers, lom)
+				return nil
+			},
+		}
+		if err := fs.Walk(opts); err != nil && !os.IsNotExist(err) {
+			glog.Errorf("%s: failed to walk containers: %v", p.bck, err)
+			return
+		}
+	}
+	for _, lom := range containers {
+		md, err := LoadMetadata(cluster.NewCTFromLOM(lom, MetaType).FQN())
+		if err != nil {
+			continue
+		}
+		load := func(objName string) (*Metadata, error) {
+			si, err := cluster.HrwTarget(cluster.NewBckEmbed(p.bck).MakeUname(objName), smap)
+			if err != nil || si.ID() != sid {
+				return nil, err // the object's metafile is kept by another target
+			}
+			fqn, _, err := cluster.HrwFQN(cluster.NewBckEmbed(p.bck), MetaType, objName)
+			if err != nil {
+				return nil, err
+			}
+			return LoadMetadata(fqn)
+		}
+		live, liveSize := liveEntries(lom.ObjName, md.Packed, load)
+		switch {
+		case len(live) == 0:
+			p.removePack(lom)
+		case liveSize*packCompactRatio < md.Size:
+			for i := range live {
+				member := &cluster.LOM{T: p.t, ObjName: live[i].ObjName}
+				if err := member.Init(p.bck); err != nil {
+					continue
+				}
+				member.Lock(false)
+				err := member.Load(false)
+				member.Unlock(false)
+				if err == nil {
+					ECM.RestoreBckPutXact(member.Bck()).Encode(&Request{Action: ActSplit, IsCopy: true, LOM: member})
+				}
+			}
+		}
+	}
+}
+
+// returns the entries of the container's index that are still in use - the
+// ones that the metafiles of their objects point to. Metafiles are loaded by
+// a given callback that returns nil (and no error) when it does not know
+// (e.g., the metafile is kept by another target): such entries count as live.
+func liveEntries(packName string, index []PackEntry, load func(objName string) (*Metadata, error)) (
+	live []PackEntry, liveSize int64) {
+	for _, entry := range index {
+		md, err := load(entry.ObjName)
+		if err != nil {
+			if !os.IsNotExist(err) {
+				live = append(live, entry) // can't tell
+				liveSize += entry.Size
+			}
+			continue
+		}
+		if md != nil && (md.PackName != packName || md.PackOffset != entry.Offset) {
+			continue // deleted or overwritten
+		}
+		live = append(live, entry)
+		liveSize += entry.Size
+	}
+	return
+}
+
+func (p *packer) removePack(lom *cluster.LOM) {
+	lom.Lock(true)
+	err := lom.Remove()
+	lom.Unlock(true)
+	if err != nil && !os.IsNotExist(err) {
+		glog.Errorf("failed to remove container %s: %v", lom, err)
+		return
+	}
+	ECM.CleanupObject(lom)
+	if glog.V(4) {
+		glog.Infof("removed container %s", lom)
+	}
+}
+
+// restores a packed object from its container: the container is restored
+// first if it's missing
+func (c *getJogger) restoreFromPack(req *Request, md *Metadata, toDisk bool) error {
+	packLOM := &cluster.LOM{T: c.parent.t, ObjName: md.PackName}
+	if err := packLOM.Init(req.LOM.Bck().Bck); err != nil {
+		return err
+	}
+	// serialize restoring of the objects packed into the same container
+	packLOM.Lock(true)
+	defer packLOM.Unlock(true)
+	if err := packLOM.Load(); err != nil {
+		if !cmn.IsErrObjNought(err) {
+			return err
+		}
+		packReq := &Request{Action: ActRestore, LOM: packLOM, prio: req.prio, putTime: req.putTime, tm: req.tm}
+		if err := c.restore(packReq, toDisk); err != nil {
+			return fmt.Errorf("failed to restore container %s: %v", packLOM, err)
+		}
+	}
+	fh, err := os.Open(packLOM.FQN)
+	if err != nil {
+		return err
+	}
+	defer func() { debug.AssertNoErr(fh.Close()) }()
+
+	if md.ObjVersion != "" {
+		req.LOM.SetVersion(md.ObjVersion)
+	}
+	req.LOM.SetSize(md.Size)
+	reader := io.NewSectionReader(fh, md.PackOffset, md.Size)
+	if err := WriteObject(c.parent.t, req.LOM, reader, md.Size, md.CksumType); err != nil {
+		return err
+	}
+	if md.ObjCksum != "" && req.LOM.Cksum() != nil {
+		if _, value := req.LOM.Cksum().Get(); value != md.ObjCksum {
+			if err := os.Remove(req.LOM.FQN); err != nil && !os.IsNotExist(err) {
+				glog.Errorf("nested error: restore from container -> remove %s: %v", req.LOM, err)
+			}
+			return fmt.Errorf("%s restored from %s: checksum mismatch (%s vs %s)",
+				req.LOM, packLOM, value, md.ObjCksum)
+		}
+	}
+	if glog.V(4) {
+		glog.Infof("restored %s from %s", req.LOM, packLOM)
+	}
+	return nil
+}
+
+// returns metadata of the objects packed into the bucket's containers that
+// are stored (as slices or replicas) on this target
+func packedMembers(bck cmn.Bck) (map[string]*Metadata, error) {
+	members := make(map[string]*Metadata)
+	availablePaths, _ := fs.Mountpaths.Get()
+	for _, mpathInfo := range availablePaths {
+		opts := &fs.Options{
+			Dir: mpathInfo.MakePathFQN(bck, MetaType, cmn.ECPackPrefix),
+			Callback: func(fqn string, de fs.DirEntry) error {
+				if de.IsDir() {
+					return nil
+				}
+				md, err := LoadMetadata(fqn)
+				if err != nil {
+					glog.Error(err)
+					return nil
+				}
+				parsed, err := fs.Mountpaths.ParseFQN(fqn)
+				if err != nil {
+					return nil
+				}
+				for _, entry := range md.Packed {
+					members[entry.ObjName] = &Metadata{
+						Size:       entry.Size,
+						ObjCksum:   entry.ObjCksum,
+						ObjVersion: entry.ObjVersion,
+						CksumType:  md.CksumType,
+						Data:       md.Data,
+						Parity:     md.Parity,
+						IsCopy:     true,
+						PackName:   parsed.ObjName,
+						PackOffset: entry.Offset,
+					}
+				}
+				return nil
+			},
+		}
+		if err := fs.Walk(opts); err != nil {
+			return nil, err
+		}
+	}
+	return members, nil
+}
@@ -0,0 +1,71 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestPackLiveEntries(t *testing.T) {
+	const packName = cmn.ECPackPrefix + "pack-1"
+	var (
+		index = []PackEntry{
+			{ObjName: "live", Offset: 0, Size: 10},
+			{ObjName: "deleted", Offset: 10, Size: 20},
+			{ObjName: "overwritten", Offset: 30, Size: 30},
+			{ObjName: "repacked", Offset: 60, Size: 40},
+			{ObjName: "foreign", Offset: 100, Size: 50},
+			{ObjName: "unreadable", Offset: 150, Size: 60},
+		}
+		metas = map[string]*Metadata{
+			"live":        {PackName: packName, PackOffset: 0},
+			"overwritten": {},
+			"repacked":    {PackName: cmn.ECPackPrefix + "pack-2", PackOffset: 0},
+		}
+	)
+	load := func(objName string) (*Metadata, error) {
+		switch objName {
+		case "foreign":
+			return nil, nil
+		case "unreadable":
+			return nil, errors.New("damaged metafile")
+		}
+		md, ok := metas[objName]
+		if !ok {
+			return nil, os.ErrNotExist
+		}
+		return md, nil
+	}
+	live, liveSize := liveEntries(packName, index, load)
+	names := make([]string, 0, len(live))
+	for _, entry := range live {
+		names = append(names, entry.ObjName)
+	}
+	tassert.Errorf(t, len(live) == 3 && liveSize == 10+50+60, "unexpected live entries %v (size %d)", names, liveSize)
+
+	// nothing is left
+	live, _ = liveEntries(packName, index[1:4], load)
+	tassert.Errorf(t, len(live) == 0, "expected no live entries, got %d", len(live))
+}
+
+func TestPackName(t *testing.T) {
+	tests := []struct {
+		objName string
+		packed  bool
+	}{
+		{objName: cmn.ECPackPrefix + "abc", packed: true},
+		{objName: ".ec.pack", packed: false},
+		{objName: ".ec.packed/abc", packed: false},
+		{objName: "dir/" + cmn.ECPackPrefix + "abc", packed: false},
+	}
+	for _, test := range tests {
+		tassert.Errorf(t, cmn.IsECPackName(test.objName) == test.packed, "%q: expected %t", test.objName, test.packed)
+	}
+}
@@ -0,0 +1,107 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Per-object protection policy
+//
+// A PUT request can override the bucket's EC configuration for the object
+// that it puts (see cmn.HeaderObjECPolicy):
+//   - "parity=P", "data=D", or "data=D,parity=P" - erasure code the object
+//     with a given number of data and/or parity slices (the rest as per bucket);
+//   - "replication=N" - keep N full replicas of the object, including the
+//     main one, regardless of the object's size.
+// The (normalized) policy is stored in the object's EC metadata along with the
+// resulting numbers of slices - restores go by the latter.
+
+const (
+	policyData        = "data"
+	policyParity      = "parity"
+	policyReplication = "replication"
+)
+
+type Policy struct {
+	Data     int // number of data slices (0 - as per bucket)
+	Parity   int // number of parity slices (0 - as per bucket)
+	Replicas int // number of replicas including the main one (0 - erasure code as per bucket)
+}
+
+// ParsePolicy parses the value of cmn.HeaderObjECPolicy
+func ParsePolicy(s string) (*Policy, error) {
+	p := &Policy{}
+	for _, kv := range strings.Split(s, ",") {
+		idx := strings.IndexByte(kv, '=')
+		if idx < 0 {
+			return nil, fmt.Errorf("invalid EC policy %q: expected key=value", s)
+		}
+		key, value := strings.TrimSpace(kv[:idx]), strings.TrimSpace(kv[idx+1:])
+		n, err := strconv.Atoi(value)
+		if err != nil || n <= 0 {
+			return nil, fmt.Errorf("invalid EC policy %q: %s must be a positive number", s, key)
+		}
+		switch key {
+		case policyData:
+			p.Data = n
+		case policyParity:
+			p.Parity = n
+		case policyReplication:
+			if n < 2 {
+				return nil, fmt.Errorf("invalid EC policy %q: %s must be at least 2", s, key)
+			}
+			p.Replicas = n
+		default:
+			return nil, fmt.Errorf("invalid EC policy %q: unknown key %q (expected %q, %q, or %q)",
+				s, key, policyData, policyParity, policyReplication)
+		}
+	}
+	if p.Replicas != 0 && (p.Data != 0 || p.Parity != 0) {
+		return nil, fmt.Errorf("invalid EC policy %q: %s cannot be combined with %s or %s",
+			s, policyReplication, policyData, policyParity)
+	}
+	return p, nil
+}
+
+func (p *Policy) String() string {
+	if p.Replicas != 0 {
+		return fmt.Sprintf("%s=%d", policyReplication, p.Replicas)
+	}
+	parts := make([]string, 0, 2)
+	if p.Data != 0 {
+		parts = append(parts, fmt.Sprintf("%s=%d", policyData, p.Data))
+	}
+	if p.Parity != 0 {
+		parts = append(parts, fmt.Sprintf("%s=%d", policyParity, p.Parity))
+	}
+	return strings.Join(parts, ",")
+}
+
+// Apply returns the bucket's EC configuration overridden by the policy. The
+// objects with the policy are never packed (see pack.go).
+func (p *Policy) Apply(conf cmn.ECConf) (cmn.ECConf, error) {
+	if p.Replicas != 0 {
+		conf.ParitySlices = p.Replicas - 1
+		conf.ObjSizeLimit = math.MaxInt64 // see IsECCopy
+	} else {
+		if p.Data != 0 {
+			conf.DataSlices = p.Data
+		}
+		if p.Parity != 0 {
+			conf.ParitySlices = p.Parity
+		}
+	}
+	conf.PackSize = 0
+	if err := conf.Validate(nil); err != nil {
+		return conf, fmt.Errorf("EC policy %q: %v", p, err)
+	}
+	return conf, nil
+}
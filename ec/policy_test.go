@@ -0,0 +1,90 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestParsePolicy(t *testing.T) {
+	tests := []struct {
+		value  string
+		policy Policy
+		str    string
+		fail   bool
+	}{
+		{value: "parity=3", policy: Policy{Parity: 3}, str: "parity=3"},
+		{value: "data=4", policy: Policy{Data: 4}, str: "data=4"},
+		{value: " parity = 2 , data=6", policy: Policy{Data: 6, Parity: 2}, str: "data=6,parity=2"},
+		{value: "replication=2", policy: Policy{Replicas: 2}, str: "replication=2"},
+
+		{value: "", fail: true},
+		{value: "parity", fail: true},
+		{value: "parity=0", fail: true},
+		{value: "parity=-1", fail: true},
+		{value: "parity=x", fail: true},
+		{value: "copies=2", fail: true},
+		{value: "replication=1", fail: true},
+		{value: "replication=2,parity=1", fail: true},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			p, err := ParsePolicy(test.value)
+			if test.fail {
+				tassert.Errorf(t, err != nil, "expected error")
+				return
+			}
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, *p == test.policy, "expected %+v, got %+v", test.policy, *p)
+			tassert.Errorf(t, p.String() == test.str, "expected %q, got %q", test.str, p.String())
+
+			// normalized policy (as stored in metadata) parses back the same
+			np, err := ParsePolicy(p.String())
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, *np == *p, "expected %+v, got %+v", *p, *np)
+		})
+	}
+}
+
+func TestPolicyApply(t *testing.T) {
+	bprops := cmn.ECConf{
+		Enabled: true, DataSlices: 2, ParitySlices: 1, ObjSizeLimit: cmn.KiB, PackSize: cmn.MiB, BatchSize: 64,
+	}
+	tests := []struct {
+		name         string
+		policy       Policy
+		data, parity int
+		copy         bool // a large object is replicated
+		targets      int
+		fail         bool
+	}{
+		{name: "parity", policy: Policy{Parity: 3}, data: 2, parity: 3, targets: 6},
+		{name: "data and parity", policy: Policy{Data: 4, Parity: 2}, data: 4, parity: 2, targets: 7},
+		{name: "replication", policy: Policy{Replicas: 3}, data: 2, parity: 2, copy: true},
+		{name: "too many slices", policy: Policy{Parity: cmn.MaxSliceCount + 1}, fail: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			conf, err := test.policy.Apply(bprops)
+			if test.fail {
+				tassert.Errorf(t, err != nil, "expected error")
+				return
+			}
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, conf.DataSlices == test.data && conf.ParitySlices == test.parity,
+				"expected %d:%d, got %d:%d", test.data, test.parity, conf.DataSlices, conf.ParitySlices)
+			tassert.Errorf(t, IsECCopy(cmn.GiB, &conf) == test.copy, "expected copy %t", test.copy)
+			tassert.Errorf(t, conf.PackSize == 0, "objects with the policy must not be packed")
+			if test.targets != 0 {
+				tassert.Errorf(t, conf.RequiredEncodeTargets() == test.targets,
+					"expected %d targets, got %d", test.targets, conf.RequiredEncodeTargets())
+			}
+		})
+	}
+	tassert.Errorf(t, bprops.ParitySlices == 1 && bprops.PackSize == cmn.MiB, "the bucket's config must not change")
+}
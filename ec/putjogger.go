@@ -6,6 +6,7 @@ package ec
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -34,10 +35,12 @@ type putJogger struct {
 	slab   *memsys.Slab
 	buffer []byte
 	mpath  string
+	stats  *mpathStats // per-mountpath counters
 
 	putCh  chan *Request // top priority operation (object PUT)
 	xactCh chan *Request // low priority operation (ec-encode)
-	stopCh chan struct{} // jogger management channel: to stop it
+	stopCh *cmn.StopCh   // jogger management channel: to stop it
+	doneCh chan struct{} // closed upon exit
 
 	toDisk bool // use files or SGL
 }
@@ -49,12 +52,21 @@ func (c *putJogger) freeResources() {
 }
 
 func (c *putJogger) processRequest(req *Request) {
-	ecConf := req.LOM.Bprops().EC
-	c.parent.stats.updateWaitTime(time.Since(req.tm))
+	var (
+		ecConf = req.ecConf()
+		wait   = time.Since(req.tm)
+		size   int64
+	)
+	c.parent.stats.updateWaitTime(wait)
+	c.stats.dequeue(wait)
 	memRequired := req.LOM.Size() * int64(ecConf.DataSlices+ecConf.ParitySlices) / int64(ecConf.ParitySlices)
 	c.toDisk = useDisk(memRequired)
 	req.tm = time.Now()
 	err := c.ec(req)
+	if req.Action == ActSplit {
+		size = req.LOM.Size()
+	}
+	c.stats.update(time.Since(req.tm), size, err != nil)
 	c.parent.DecPending()
 	if req.Callback != nil {
 		req.Callback(req.LOM, err)
@@ -65,6 +77,7 @@ func (c *putJogger) run() {
 	glog.Infof("Started EC for mountpath: %s, bucket %s", c.mpath, c.parent.bck)
 	c.buffer, c.slab = mm.Alloc()
 	putsDone := 0
+	defer close(c.doneCh)
 
 	for {
 		// first, process requests with high priority
@@ -76,7 +89,7 @@ func (c *putJogger) run() {
 			if putsDone < putBatchSize {
 				continue
 			}
-		case <-c.stopCh:
+		case <-c.stopCh.Listen():
 			c.freeResources()
 			return
 		default:
@@ -89,17 +102,24 @@ func (c *putJogger) run() {
 			c.processRequest(req)
 		case req := <-c.xactCh:
 			c.processRequest(req)
-		case <-c.stopCh:
+		case <-c.stopCh.Listen():
 			c.freeResources()
 			return
 		}
 	}
 }
 
-func (c *putJogger) stop() {
+// stopCtx stops the jogger and waits for the request in progress, if any, to complete -
+// but not past the ctx deadline
+func (c *putJogger) stopCtx(ctx context.Context) error {
 	glog.Infof("Stopping EC for mountpath: %s, bucket %s", c.mpath, c.parent.bck)
-	c.stopCh <- struct{}{}
-	close(c.stopCh)
+	c.stopCh.Close()
+	select {
+	case <-c.doneCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 // starts EC process
@@ -143,7 +163,7 @@ func (c *putJogger) encode(req *Request) error {
 	}
 	var (
 		cksumValue, cksumType string
-		ecConf                = req.LOM.Bprops().EC
+		ecConf                = req.ecConf()
 	)
 	if req.LOM.Cksum() != nil {
 		cksumType, cksumValue = req.LOM.Cksum().Get()
@@ -155,6 +175,11 @@ func (c *putJogger) encode(req *Request) error {
 		IsCopy:    req.IsCopy,
 		ObjCksum:  cksumValue,
 		CksumType: cksumType,
+		Packed:    req.packed,
+		CustomMD:  req.LOM.CustomMD(),
+	}
+	if req.policy != nil {
+		meta.Policy = req.policy.String()
 	}
 
 	// calculate the number of targets required to encode the object
@@ -170,9 +195,12 @@ func (c *putJogger) encode(req *Request) error {
 			req.LOM.Bck(), req.LOM.ObjName, reqTargets, targetCnt)
 	}
 
+	c.parent.Throttle(req.LOM.Size())
+
 	// Save metadata before encoding the object
 	ctMeta := cluster.NewCTFromLOM(req.LOM, MetaType)
-	metaBuf := bytes.NewReader(meta.Marshal())
+	c.cleanupStale(req, ctMeta.FQN(), &ecConf)
+	metaBuf := bytes.NewReader(meta.NewPack())
 	if err := ctMeta.Write(c.parent.t, metaBuf, -1); err != nil {
 		return err
 	}
@@ -180,18 +208,33 @@ func (c *putJogger) encode(req *Request) error {
 	c.parent.ObjectsInc()
 	c.parent.BytesAdd(req.LOM.Size())
 
-	// if an object is small just make `parity` copies
+	// if an object is small just make `parity` copies (and pack it, if enabled)
 	if meta.IsCopy {
-		if err := c.createCopies(req, meta); err != nil {
+		err := c.createCopies(req, meta)
+		if err != nil {
 			c.cleanup(req)
+			return err
+		}
+		if req.reprot {
+			c.cleanupOthers(req, &ecConf, reqTargets)
+		}
+		if c.parent.packer.wants(req, &ecConf) {
+			if err := c.parent.packer.add(req.LOM, meta, ecConf.PackSize); err != nil {
+				glog.Errorf("failed to pack %s (remains replicated): %v", req.LOM, err)
+			}
 		}
 		return nil
 	}
 
 	// big object is erasure encoded
-	if slices, err := c.sendSlices(req, meta); err != nil {
+	slices, err := c.sendSlices(req, meta)
+	if err != nil {
 		freeSlices(slices)
 		c.cleanup(req)
+		return err
+	}
+	if req.reprot {
+		c.cleanupOthers(req, &ecConf, reqTargets)
 	}
 	return nil
 }
@@ -206,6 +249,7 @@ func (c *putJogger) ctSendCallback(hdr transport.Header, _ io.ReadCloser, _ unsa
 // a client has deleted the main object and requested to cleanup all its
 // replicas and slices
 // Just remove local metafile if it exists and broadcast the request to all
+// (with `ec.undelete_window` set, the files are tombstoned rather than removed)
 func (c *putJogger) cleanup(req *Request) error {
 	fqnMeta, _, err := cluster.HrwFQN(req.LOM.Bck(), MetaType, req.LOM.ObjName)
 	if err != nil {
@@ -213,33 +257,128 @@ func (c *putJogger) cleanup(req *Request) error {
 		return nil
 	}
 
-	if err := os.RemoveAll(fqnMeta); err != nil {
+	if err := trashOrRemove(fqnMeta, MetaType, cmn.GCO.Get().EC.UndeleteWindow); err != nil {
 		// logs the error but move on - notify all other target to do cleanup
 		glog.Errorf("Error removing metafile %q", fqnMeta)
 	}
 
-	mm := c.parent.t.GetSmallMMSA()
-	request := c.parent.newIntraReq(reqDel, nil).NewPack(mm)
+	return c.sendDel(req, reqDel, false)
+}
+
+// Upon the object's conversion from replicas to slices or vice versa (that
+// is, when its size crosses `ec.objsize_limit` after an overwrite or append),
+// removes the obsolete replicas or slices from the targets that keep them,
+// so that the object's protection stays consistent with the threshold.
+// Must be called before the object's new metafile is written.
+func (c *putJogger) cleanupStale(req *Request, metaFQN string, ecConf *cmn.ECConf) {
+	old, err := LoadMetadata(metaFQN)
+	// NOTE: packed objects have no replicas, and containers are never rewritten
+	if err != nil || old.IsCopy == req.IsCopy || old.PackName != "" {
+		return
+	}
+	oldCnt, newCnt := old.Parity, ecConf.ParitySlices
+	if !old.IsCopy {
+		oldCnt += old.Data
+	}
+	if !req.IsCopy {
+		newCnt += ecConf.DataSlices
+	}
+	targets, err := cluster.HrwTargetListEC(req.LOM.Uname(), c.parent.smap.Get(), cmn.Max(oldCnt, newCnt)+1, ecConf)
+	if err != nil {
+		glog.Errorf("%s: failed to cleanup stale replicas/slices: %v", req.LOM, err)
+		return
+	}
+	stale, gone := staleTargets(targets[1:], oldCnt, newCnt)
+	if glog.V(4) {
+		glog.Infof("%s: converting (copy: %t => %t), cleanup %d + %d target(s)",
+			req.LOM, old.IsCopy, req.IsCopy, len(stale), len(gone))
+	}
+	if len(stale) > 0 {
+		if err := c.sendDel(req, reqDelStale, !old.IsCopy, stale...); err != nil {
+			glog.Errorf("%s: failed to cleanup stale replicas/slices: %v", req.LOM, err)
+		}
+	}
+	if len(gone) > 0 {
+		if err := c.sendDel(req, reqDel, false, gone...); err != nil {
+			glog.Errorf("%s: failed to cleanup stale replicas/slices: %v", req.LOM, err)
+		}
+	}
+}
+
+// removes the replicas, slices, and metafiles of the object from all the targets
+// except the ones that have just received the new replicas (slices) - see
+// Manager.ReprotectObject; runs only upon successful encoding, so that the
+// object does not go unprotected while the new replicas (slices) are being sent
+func (c *putJogger) cleanupOthers(req *Request, ecConf *cmn.ECConf, reqTargets int) {
+	smap := c.parent.smap.Get()
+	targets, err := cluster.HrwTargetListEC(req.LOM.Uname(), smap, reqTargets, ecConf)
+	if err != nil {
+		glog.Errorf("%s: failed to cleanup replicas/slices: %v", req.LOM, err)
+		return
+	}
+	others := make([]*cluster.Snode, 0, len(smap.Tmap))
+outer:
+	for _, si := range smap.Tmap {
+		for _, tsi := range targets {
+			if si.ID() == tsi.ID() {
+				continue outer
+			}
+		}
+		others = append(others, si)
+	}
+	if len(others) == 0 {
+		return
+	}
+	if err := c.sendDel(req, reqDel, false, others...); err != nil {
+		glog.Errorf("%s: failed to cleanup replicas/slices: %v", req.LOM, err)
+	}
+}
+
+// Given the targets that keep (or are to keep) the object's replicas or slices
+// in HRW order, returns those that are to receive the new replicas (slices)
+// and must remove their old slices (replicas) - stale, and those that no longer
+// keep the object at all and must remove everything - gone.
+func staleTargets(targets []*cluster.Snode, oldCnt, newCnt int) (stale, gone []*cluster.Snode) {
+	stale = make([]*cluster.Snode, 0, oldCnt)
+	gone = make([]*cluster.Snode, 0, oldCnt)
+	for i, si := range targets {
+		if i >= oldCnt {
+			break
+		}
+		if i < newCnt {
+			stale = append(stale, si)
+		} else {
+			gone = append(gone, si)
+		}
+	}
+	return
+}
+
+// sends a cleanup request to the given targets (all, if none given)
+func (c *putJogger) sendDel(req *Request, act intraReqType, isSlice bool, nodes ...*cluster.Snode) error {
+	iReq := c.parent.newIntraReq(act, nil)
+	iReq.isSlice = isSlice
 	hdr := transport.Header{
 		Bck:     req.LOM.Bck().Bck,
 		ObjName: req.LOM.ObjName,
-		Opaque:  request,
+		Opaque:  iReq.NewPack(c.parent.t.GetSmallMMSA()),
 		ObjAttrs: transport.ObjectAttrs{
 			Size: 0,
 		},
 	}
-	return c.parent.reqBundle.Send(transport.Obj{Hdr: hdr, Callback: c.ctSendCallback}, nil)
+	return c.parent.reqBundle.Send(transport.Obj{Hdr: hdr, Callback: c.ctSendCallback}, nil, nodes...)
 }
 
 // Sends object replicas to targets that must have replicas after the client
 // uploads the main replica
 func (c *putJogger) createCopies(req *Request, metadata *Metadata) error {
 	var (
-		copies = req.LOM.Bprops().EC.ParitySlices
+		ecConf = req.ecConf()
+		copies = ecConf.ParitySlices
 	)
 
 	// generate a list of target to send the replica (all excluding this one)
-	targets, err := cluster.HrwTargetList(req.LOM.Uname(), c.parent.smap.Get(), copies+1)
+	targets, err := cluster.HrwTargetListEC(req.LOM.Uname(), c.parent.smap.Get(), copies+1, &ecConf)
 	if err != nil {
 		return err
 	}
@@ -514,12 +653,12 @@ func generateSlicesToDisk(lom *cluster.LOM, dataSlices, paritySlices int) (cmn.R
 // Returns:
 // * list of all slices, sent to targets
 func (c *putJogger) sendSlices(req *Request, meta *Metadata) ([]*slice, error) {
-	ecConf := req.LOM.Bprops().EC
+	ecConf := req.ecConf()
 	totalCnt := ecConf.ParitySlices + ecConf.DataSlices
 
 	// totalCnt+1: first node gets the full object, other totalCnt nodes
 	// gets a slice each
-	targets, err := cluster.HrwTargetList(req.LOM.Uname(), c.parent.smap.Get(), totalCnt+1)
+	targets, err := cluster.HrwTargetListEC(req.LOM.Uname(), c.parent.smap.Get(), totalCnt+1, &ecConf)
 	if err != nil {
 		return nil, err
 	}
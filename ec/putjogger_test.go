@@ -0,0 +1,48 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestStaleTargets(t *testing.T) {
+	targets := make([]*cluster.Snode, 0, 6)
+	for i := 0; i < 6; i++ {
+		targets = append(targets, &cluster.Snode{DaemonID: fmt.Sprintf("t%d", i)})
+	}
+	ids := func(sis []*cluster.Snode) []string {
+		res := make([]string, 0, len(sis))
+		for _, si := range sis {
+			res = append(res, si.ID())
+		}
+		return res
+	}
+	tests := []struct {
+		name           string
+		oldCnt, newCnt int
+		stale, gone    []string
+	}{
+		// 2 replicas => 2 data + 2 parity slices
+		{name: "replicas to slices", oldCnt: 2, newCnt: 4, stale: []string{"t0", "t1"}, gone: []string{}},
+		// 2 data + 2 parity slices => 2 replicas
+		{name: "slices to replicas", oldCnt: 4, newCnt: 2, stale: []string{"t0", "t1"}, gone: []string{"t2", "t3"}},
+		{name: "same count", oldCnt: 3, newCnt: 3, stale: []string{"t0", "t1", "t2"}, gone: []string{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			stale, gone := staleTargets(targets, test.oldCnt, test.newCnt)
+			tassert.Errorf(t, cmn.StrSlicesEqual(ids(stale), test.stale), "expected stale %v, got %v",
+				test.stale, ids(stale))
+			tassert.Errorf(t, cmn.StrSlicesEqual(ids(gone), test.gone), "expected gone %v, got %v",
+				test.gone, ids(gone))
+		})
+	}
+}
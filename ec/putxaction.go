@@ -5,6 +5,7 @@
 package ec
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -23,6 +24,7 @@ type (
 		xactECBase
 		xactReqBase
 		putJoggers map[string]*putJogger // mountpath joggers for PUT/DEL
+		packer     *packer               // accumulates small objects (see pack.go)
 	}
 )
 
@@ -40,6 +42,7 @@ func NewPutXact(t cluster.Target, smap cluster.Sowner,
 		xactECBase:  newXactECBase(t, smap, si, bck, reqBundle, respBundle),
 		xactReqBase: newXactReqECBase(),
 	}
+	runner.packer = newPacker(runner, bck)
 
 	// create all runners but do not start them until Run is called
 	for mpath := range availablePaths {
@@ -58,9 +61,11 @@ func (r *XactPut) newPutJogger(mpath string) *putJogger {
 	return &putJogger{
 		parent: r,
 		mpath:  mpath,
+		stats:  r.stats.mpath(mpath),
 		putCh:  make(chan *Request, requestBufSizeFS),
 		xactCh: make(chan *Request, requestBufSizeEncode),
-		stopCh: make(chan struct{}, 1),
+		stopCh: cmn.NewStopCh(),
+		doneCh: make(chan struct{}),
 	}
 }
 
@@ -96,6 +101,8 @@ func (r *XactPut) Run() (err error) {
 			if s := fmt.Sprintf("%v", r.Stats()); s != "" {
 				glog.Info(s)
 			}
+			r.packer.flush(cfg.Periodic.StatsTime)
+			r.packer.maybeCompact()
 		case req := <-r.ecCh:
 			switch req.Action {
 			case ActSplit:
@@ -154,9 +161,14 @@ func (r *XactPut) stop() {
 
 	XactCount.Dec()
 	r.XactDemandBase.Stop()
+	ctx, cancel := context.WithTimeout(context.Background(), joggerStopTimeout)
 	for _, jog := range r.putJoggers {
-		jog.stop()
+		if err := jog.stopCtx(ctx); err != nil {
+			glog.Errorf("%s: failed to stop %s jogger: %v", r, jog.mpath, err)
+		}
 	}
+	cancel()
+	r.packer.flush(0)
 
 	// Don't close bundles, they are shared between different EC xactions
 
@@ -211,6 +223,7 @@ func (r *XactPut) dispatchRequest(req *Request) {
 	if glog.V(4) {
 		glog.Infof("ECXAction (bg queue = %d): dispatching object %s....", len(jogger.putCh), req.LOM.Uname())
 	}
+	jogger.stats.queueLen.Inc()
 	if req.rebuild {
 		jogger.xactCh <- req
 	} else {
@@ -234,6 +247,8 @@ type ExtECPutStats struct {
 	DeleteErrCount int64   `json:"ec.delete.err.n,string"`
 	AvgObjTime     int64   `json:"ec.obj.process.time,string"`
 	AvgQueueLen    float64 `json:"ec.queue.len.n"`
+	// per-mountpath (jogger) stats
+	Mpaths map[string]*MpathStats `json:"ec.mpaths,omitempty"`
 }
 
 var (
@@ -254,6 +269,7 @@ func (r *XactPut) Stats() cmn.XactStats {
 	putStats.Ext.DeleteCount = st.DelReq
 	putStats.Ext.AvgObjTime = st.ObjTime.Nanoseconds()
 	putStats.Ext.AvgQueueLen = st.QueueLen
+	putStats.Ext.Mpaths = r.stats.mpathStats()
 
 	putStats.ObjCountX = st.PutReq + st.DelReq
 	putStats.BytesCountX = st.EncodeSize
@@ -7,7 +7,6 @@ package ec
 import (
 	"fmt"
 	"io"
-	"os"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -77,12 +76,14 @@ func (r *XactRespond) removeObjAndMeta(bck *cluster.Bck, objName string) error {
 	// responds that it has the object because it has metafile. We delete
 	// metafile that makes remained slices/replicas outdated and can be cleaned
 	// up later by LRU or other runner
+	// With `ec.undelete_window` set, the files are tombstoned (see trash.go)
+	window := cmn.GCO.Get().EC.UndeleteWindow
 	for _, tp := range []string{MetaType, fs.ObjectType, SliceType} {
 		fqnMeta, _, err := cluster.HrwFQN(bck, tp, objName)
 		if err != nil {
 			return err
 		}
-		if err := os.RemoveAll(fqnMeta); err != nil {
+		if err := trashOrRemove(fqnMeta, tp, window); err != nil {
 			return fmt.Errorf("error removing %s %q: %w", tp, fqnMeta, err)
 		}
 	}
@@ -90,6 +91,32 @@ func (r *XactRespond) removeObjAndMeta(bck *cluster.Bck, objName string) error {
 	return nil
 }
 
+// removes the replica or slice that has become obsolete after the object has
+// been converted (see reqDelStale); the metafile is overwritten by the sender
+func (r *XactRespond) removeStale(bck *cluster.Bck, objName string, isSlice bool) error {
+	ct := fs.ObjectType
+	if isSlice {
+		ct = SliceType
+	} else {
+		// never remove the main replica
+		si, err := cluster.HrwTarget(bck.MakeUname(objName), r.t.GetSowner().Get())
+		if err != nil {
+			return err
+		}
+		if si.ID() == r.t.Snode().ID() {
+			return nil
+		}
+	}
+	fqn, _, err := cluster.HrwFQN(bck, ct, objName)
+	if err != nil {
+		return err
+	}
+	if err := cmn.RemoveFile(fqn); err != nil {
+		return fmt.Errorf("error removing %s %q: %w", ct, fqn, err)
+	}
+	return nil
+}
+
 // DispatchReq is responsible for handling request from other targets
 func (r *XactRespond) DispatchReq(iReq intraReq, bck *cluster.Bck, objName string) {
 	daemonID := iReq.sender
@@ -99,6 +126,10 @@ func (r *XactRespond) DispatchReq(iReq intraReq, bck *cluster.Bck, objName strin
 		if err := r.removeObjAndMeta(bck, objName); err != nil {
 			glog.Errorf("%s failed to delete %s/%s: %v", r.t.Snode(), bck.Name, objName, err)
 		}
+	case reqDelStale:
+		if err := r.removeStale(bck, objName, iReq.isSlice); err != nil {
+			glog.Errorf("%s failed to delete stale %s/%s: %v", r.t.Snode(), bck.Name, objName, err)
+		}
 	case reqGet:
 		// slice or replica request: send the object's data to the caller
 		var (
@@ -160,24 +191,30 @@ func (r *XactRespond) DispatchResp(iReq intraReq, hdr transport.Header, object i
 		}
 
 		if glog.V(4) {
-			glog.Infof("Got slice=%t from %s (#%d of %s/%s) v%s",
-				iReq.isSlice, iReq.sender, iReq.meta.SliceID, hdr.Bck, hdr.ObjName, meta.ObjVersion)
+			glog.Infof("Got slice=%t from %s (#%d of %s/%s) v%s, trace %q",
+				iReq.isSlice, iReq.sender, iReq.meta.SliceID, hdr.Bck, hdr.ObjName, meta.ObjVersion, iReq.traceID)
 		}
-		md := meta.Marshal()
 		if iReq.isSlice {
-			err = WriteSliceAndMeta(r.t, hdr, object, md)
+			err = WriteSliceAndMeta(r.t, hdr, object, meta)
 		} else {
 			var lom *cluster.LOM
 			lom, err = LomFromHeader(r.t, hdr)
 			if err == nil {
-				err = WriteReplicaAndMeta(r.t, lom, object, md, hdr.ObjAttrs.CksumType, hdr.ObjAttrs.CksumValue)
+				err = WriteReplicaAndMeta(r.t, lom, object, meta.NewPack(), hdr.ObjAttrs.CksumType, hdr.ObjAttrs.CksumValue)
 			}
 		}
 		if err != nil {
 			drain()
-			glog.Error(err)
+			if iReq.traceID != "" {
+				glog.Errorf("%v (trace %s)", err, iReq.traceID)
+			} else {
+				glog.Error(err)
+			}
 			return
 		}
+		if iReq.traceID != "" {
+			r.stats.updateRepair(1, hdr.ObjAttrs.Size)
+		}
 		r.ObjectsInc()
 		r.BytesAdd(hdr.ObjAttrs.Size)
 	default:
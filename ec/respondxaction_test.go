@@ -0,0 +1,73 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestRespondRemoveStale(t *testing.T) {
+	mi, bck, cleanup := trashSetup(t)
+	defer cleanup()
+
+	var (
+		smap  = &cluster.Smap{Tmap: cluster.NodeMap{}}
+		tMock = &restoreTargetMock{TargetMock: cluster.NewTargetMock(cluster.NewBaseBownerMock(bck)), smap: smap}
+	)
+	for _, id := range []string{"t1", "t2"} {
+		si := &cluster.Snode{DaemonID: id, DaemonType: cmn.Target}
+		si.Digest()
+		smap.Tmap[id] = si
+	}
+	tMock.si = smap.Tmap["t1"]
+	r := NewRespondXact(tMock, tMock, tMock.si, bck.Bck, nil, nil)
+
+	// objects this target is (and is not) the main target for
+	var mainObj, otherObj string
+	for i := 0; mainObj == "" || otherObj == ""; i++ {
+		objName := fmt.Sprintf("obj-%d", i)
+		si, err := cluster.HrwTarget(bck.MakeUname(objName), smap)
+		tassert.CheckFatal(t, err)
+		if si.ID() == tMock.si.ID() {
+			mainObj = objName
+		} else {
+			otherObj = objName
+		}
+	}
+	create := func(objName string) (objFQN, sliceFQN, metaFQN string) {
+		objFQN = mi.MakePathFQN(bck.Bck, fs.ObjectType, objName)
+		sliceFQN = mi.MakePathFQN(bck.Bck, SliceType, objName)
+		metaFQN = mi.MakePathFQN(bck.Bck, MetaType, objName)
+		for _, fqn := range []string{objFQN, sliceFQN, metaFQN} {
+			trashCreate(t, fqn)
+		}
+		return
+	}
+
+	// replicas => slices: the slice is removed, the replica and metafile are kept
+	objFQN, sliceFQN, metaFQN := create(otherObj)
+	tassert.CheckFatal(t, r.removeStale(bck, otherObj, true))
+	tassert.Errorf(t, !trashExists(sliceFQN), "expected the slice to be removed")
+	tassert.Errorf(t, trashExists(objFQN) && trashExists(metaFQN), "expected the replica and metafile to be kept")
+
+	// slices => replicas: the replica is removed
+	tassert.CheckFatal(t, r.removeStale(bck, otherObj, false))
+	tassert.Errorf(t, !trashExists(objFQN), "expected the replica to be removed")
+	tassert.Errorf(t, trashExists(metaFQN), "expected the metafile to be kept")
+
+	// never the main replica
+	objFQN, _, _ = create(mainObj)
+	tassert.CheckFatal(t, r.removeStale(bck, mainObj, false))
+	tassert.Errorf(t, trashExists(objFQN), "expected the main replica to be kept")
+
+	// nothing to remove
+	tassert.CheckFatal(t, r.removeStale(bck, otherObj, false))
+}
@@ -0,0 +1,144 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"container/list"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+type (
+	// sliceCache is an in-memory LRU cache of the slices fetched from remote
+	// targets while restoring objects. When the same (hot) object gets restored
+	// over and over again during a degraded period, the cached slices are used
+	// instead of re-transferring them from remote targets.
+	// Entries are keyed by object uname and SliceID.
+	sliceCache struct {
+		mtx     sync.Mutex
+		lru     *list.List               // front - most recently used
+		entries map[string]*list.Element // key -> *sliceCacheEntry
+		size    int64                    // total size of the cached slices
+		hits    atomic.Int64
+	}
+	sliceCacheEntry struct {
+		key        string
+		sgl        *memsys.SGL // slice data
+		cksum      *cmn.Cksum  // slice checksum
+		version    string      // version of the remote object
+		objCksum   string      // to detect that the object has changed
+		objVersion string
+	}
+)
+
+func newSliceCache() *sliceCache {
+	return &sliceCache{
+		lru:     list.New(),
+		entries: make(map[string]*list.Element, 64),
+	}
+}
+
+func sliceCacheKey(uname string, sliceID int) string {
+	return uname + "/" + strconv.Itoa(sliceID)
+}
+
+// get returns a copy of a cached slice, or nil if the slice is not cached or
+// the cached one belongs to a different version of the object.
+// The caller owns the returned slice.
+func (c *sliceCache) get(uname string, sliceID int, meta *Metadata) *slice {
+	key := sliceCacheKey(uname, sliceID)
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	entry := el.Value.(*sliceCacheEntry)
+	if entry.objCksum != meta.ObjCksum || entry.objVersion != meta.ObjVersion {
+		c.remove(el)
+		return nil
+	}
+	c.lru.MoveToFront(el)
+	sgl := mm.NewSGL(entry.sgl.Size())
+	if _, err := entry.sgl.WriteTo(sgl); err != nil {
+		sgl.Free()
+		return nil
+	}
+	c.hits.Inc()
+	return &slice{writer: sgl, n: sgl.Size(), cksum: entry.cksum, version: entry.version, cached: true}
+}
+
+// put adds a copy of a slice to the cache, evicting the least recently used
+// slices to keep the total size of the cache within a given capacity.
+func (c *sliceCache) put(uname string, sliceID int, meta *Metadata, sl *slice, capacity int64) {
+	if sl.n <= 0 || sl.n > capacity {
+		return
+	}
+	sgl := mm.NewSGL(sl.n)
+	if err := readSlice(sl, sgl); err != nil || sgl.Size() != sl.n {
+		sgl.Free()
+		return
+	}
+	entry := &sliceCacheEntry{
+		key:        sliceCacheKey(uname, sliceID),
+		sgl:        sgl,
+		cksum:      sl.cksum,
+		version:    sl.version,
+		objCksum:   meta.ObjCksum,
+		objVersion: meta.ObjVersion,
+	}
+
+	c.mtx.Lock()
+	if el, ok := c.entries[entry.key]; ok {
+		c.remove(el)
+	}
+	c.entries[entry.key] = c.lru.PushFront(entry)
+	c.size += sgl.Size()
+	for c.size > capacity {
+		c.remove(c.lru.Back())
+	}
+	c.mtx.Unlock()
+}
+
+// must be called under lock
+func (c *sliceCache) remove(el *list.Element) {
+	entry := c.lru.Remove(el).(*sliceCacheEntry)
+	delete(c.entries, entry.key)
+	c.size -= entry.sgl.Size()
+	entry.sgl.Free()
+}
+
+// clear evicts all cached slices
+func (c *sliceCache) clear() {
+	c.mtx.Lock()
+	for c.lru.Len() > 0 {
+		c.remove(c.lru.Back())
+	}
+	c.mtx.Unlock()
+}
+
+// copies the content of a received slice (memory or disk)
+func readSlice(sl *slice, sgl *memsys.SGL) error {
+	if src, ok := sl.writer.(*memsys.SGL); ok {
+		_, err := src.WriteTo(sgl)
+		return err
+	}
+	if sl.workFQN == "" {
+		return fmt.Errorf("unsupported slice source: %T", sl.writer)
+	}
+	fh, err := cmn.NewFileHandle(sl.workFQN)
+	if err != nil {
+		return err
+	}
+	_, err = sgl.ReadFrom(fh)
+	debug.AssertNoErr(fh.Close())
+	return err
+}
@@ -0,0 +1,111 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func newCachedSlice(t *testing.T, data string) *slice {
+	sgl := mm.NewSGL(int64(len(data)))
+	_, err := sgl.Write([]byte(data))
+	tassert.CheckFatal(t, err)
+	return &slice{writer: sgl, n: int64(len(data)), cksum: cmn.NewCksum(cmn.ChecksumXXHash, data)}
+}
+
+func readCachedSlice(t *testing.T, sl *slice) string {
+	sgl := sl.writer.(*memsys.SGL)
+	defer sgl.Free()
+	b, err := ioutil.ReadAll(sgl)
+	tassert.CheckFatal(t, err)
+	return string(b)
+}
+
+func TestSliceCache(t *testing.T) {
+	if mm == nil {
+		mm = memsys.DefaultPageMM()
+	}
+	var (
+		c    = newSliceCache()
+		meta = &Metadata{ObjCksum: "cksum", ObjVersion: "1"}
+	)
+	defer c.clear()
+
+	// miss, then hit
+	tassert.Fatalf(t, c.get("obj", 1, meta) == nil, "expected miss")
+	src := newCachedSlice(t, "slice-1")
+	c.put("obj", 1, meta, src, 100)
+	src.writer.(*memsys.SGL).Free()
+	sl := c.get("obj", 1, meta)
+	tassert.Fatalf(t, sl != nil && sl.cached, "expected hit")
+	tassert.Errorf(t, sl.cksum.Value() == "slice-1", "unexpected checksum %s", sl.cksum)
+	tassert.Errorf(t, readCachedSlice(t, sl) == "slice-1", "unexpected content")
+	tassert.Errorf(t, c.hits.Load() == 1, "expected 1 hit, got %d", c.hits.Load())
+	tassert.Errorf(t, c.get("obj", 2, meta) == nil, "expected miss for another slice")
+
+	// the object has changed - the cached slice is dropped
+	tassert.Errorf(t, c.get("obj", 1, &Metadata{ObjCksum: "cksum", ObjVersion: "2"}) == nil, "expected miss")
+	tassert.Errorf(t, c.get("obj", 1, meta) == nil, "expected the stale slice to be dropped")
+	tassert.Errorf(t, c.size == 0, "expected empty cache, got %d bytes", c.size)
+
+	// least recently used slices get evicted to stay within capacity
+	for i, data := range []string{"aaaaa", "bbbbb", "ccccc"} {
+		sl := newCachedSlice(t, data)
+		c.put("obj", i+1, meta, sl, 10)
+		sl.writer.(*memsys.SGL).Free()
+		if i == 1 {
+			// touch the first one
+			got := c.get("obj", 1, meta)
+			tassert.Fatalf(t, got != nil, "expected hit")
+			got.writer.(*memsys.SGL).Free()
+		}
+	}
+	tassert.Errorf(t, c.size == 10, "expected 10 bytes cached, got %d", c.size)
+	tassert.Errorf(t, c.get("obj", 2, meta) == nil, "expected the least recently used slice to be evicted")
+	for _, id := range []int{1, 3} {
+		sl := c.get("obj", id, meta)
+		tassert.Fatalf(t, sl != nil, "expected slice %d to be cached", id)
+		sl.writer.(*memsys.SGL).Free()
+	}
+
+	// larger than the cache - not cached
+	sl = newCachedSlice(t, "too large for the cache")
+	c.put("large", 1, meta, sl, 10)
+	sl.writer.(*memsys.SGL).Free()
+	tassert.Errorf(t, c.get("large", 1, meta) == nil, "expected the slice larger than the cache to be skipped")
+
+	c.clear()
+	tassert.Errorf(t, c.size == 0 && c.lru.Len() == 0 && len(c.entries) == 0, "expected empty cache")
+}
+
+func TestSliceCacheFromDisk(t *testing.T) {
+	if mm == nil {
+		mm = memsys.DefaultPageMM()
+	}
+	dir, err := ioutil.TempDir("", "slicecache")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(dir)
+	workFQN := filepath.Join(dir, "slice")
+	tassert.CheckFatal(t, ioutil.WriteFile(workFQN, []byte("on-disk slice"), 0644))
+
+	var (
+		c    = newSliceCache()
+		meta = &Metadata{ObjCksum: "cksum"}
+		src  = &slice{writer: &bytes.Buffer{}, workFQN: workFQN, n: int64(len("on-disk slice"))}
+	)
+	defer c.clear()
+	c.put("obj", 1, meta, src, cmn.MiB)
+	sl := c.get("obj", 1, meta)
+	tassert.Fatalf(t, sl != nil, "expected hit")
+	tassert.Errorf(t, readCachedSlice(t, sl) == "on-disk slice", "unexpected content")
+}
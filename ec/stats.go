@@ -7,6 +7,7 @@ package ec
 import (
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
@@ -32,6 +33,42 @@ type stats struct {
 	deleteErr  atomic.Int64
 	objTime    atomic.Int64
 	objCnt     atomic.Int64
+	// replicas and slices transferred on behalf of restores (repair amplification):
+	// sent by the restoring target, received by the others
+	repairCnt  atomic.Int64
+	repairSize atomic.Int64
+	// current queue depth per restore priority
+	clientQueue atomic.Int64
+	bgQueue     atomic.Int64
+	// per-mountpath (jogger) counters: mpath => *mpathStats
+	mpaths *sync.Map
+}
+
+// internal per-mountpath (jogger) stats: only counters
+type mpathStats struct {
+	queueLen atomic.Int64 // current number of queued requests
+	waitTime atomic.Int64
+	waitCnt  atomic.Int64
+	reqCnt   atomic.Int64 // number of processed requests
+	size     atomic.Int64 // total size of the encoded/restored objects
+	busyTime atomic.Int64 // total time spent encoding/restoring
+	errCnt   atomic.Int64
+}
+
+// MpathStats are per-mountpath (jogger) stats, to identify hot mountpaths
+type MpathStats struct {
+	// current number of queued requests
+	QueueLen int64 `json:"queue.n,string"`
+	// time between the xaction dispatches a request and the jogger starts processing it
+	AvgWaitTime int64 `json:"wait.time,string"`
+	// number of processed requests
+	Count int64 `json:"n,string"`
+	// total size of the encoded (or restored) objects
+	Size int64 `json:"size,string"`
+	// encoding (or restoring) throughput, in bytes per second of processing time
+	Throughput int64 `json:"bps,string"`
+	// number of failed requests
+	ErrCount int64 `json:"err.n,string"`
 }
 
 // ECStats are stats for clients-side apps - calculated from raw counters
@@ -39,6 +76,10 @@ type stats struct {
 type ECStats struct {
 	// mpathrunner(not ecrunner) queue len
 	QueueLen float64
+	// number of pending client-blocking restores
+	ClientQueueLen int64
+	// number of pending background restores
+	BgQueueLen int64
 	// time between ecrunner receives an object and mpathrunner starts processing it
 	WaitTime time.Duration
 	// EC encoding time (for both EC'ed and replicated objects)
@@ -64,15 +105,82 @@ type ECStats struct {
 	GetReq int64
 	// total number of encode requests
 	PutReq int64
+	// number of replicas and slices transferred on behalf of restores
+	RepairCnt int64
+	// total size of the replicas and slices transferred on behalf of restores
+	RepairSize int64
 	// name of the bucket
 	Bck cmn.Bck
 }
 
+// returns counters of a given mountpath
+func (s *stats) mpath(mpath string) *mpathStats {
+	st, _ := s.mpaths.LoadOrStore(mpath, &mpathStats{})
+	return st.(*mpathStats)
+}
+
+func (s *stats) mpathStats() map[string]*MpathStats {
+	all := make(map[string]*MpathStats, 4)
+	s.mpaths.Range(func(key, value interface{}) bool {
+		all[key.(string)] = value.(*mpathStats).stats()
+		return true
+	})
+	return all
+}
+
+func (s *mpathStats) dequeue(wait time.Duration) {
+	s.queueLen.Dec()
+	s.waitTime.Add(int64(wait))
+	s.waitCnt.Inc()
+}
+
+func (s *mpathStats) update(busy time.Duration, size int64, failed bool) {
+	s.busyTime.Add(int64(busy))
+	s.reqCnt.Inc()
+	if failed {
+		s.errCnt.Inc()
+	} else {
+		s.size.Add(size)
+	}
+}
+
+func (s *mpathStats) stats() *MpathStats {
+	st := &MpathStats{
+		QueueLen: s.queueLen.Load(),
+		Count:    s.reqCnt.Load(),
+		Size:     s.size.Load(),
+		ErrCount: s.errCnt.Load(),
+	}
+	if cnt := s.waitCnt.Load(); cnt > 0 {
+		st.AvgWaitTime = s.waitTime.Load() / cnt
+	}
+	if busy := s.busyTime.Load(); busy > 0 {
+		st.Throughput = int64(float64(st.Size) / time.Duration(busy).Seconds())
+	}
+	return st
+}
+
 func (s *stats) updateQueue(l int) {
 	s.queueLen.Add(int64(l))
 	s.queueCnt.Inc()
 }
 
+func (s *stats) enqueue(prio int) {
+	if prio == prioBackground {
+		s.bgQueue.Inc()
+	} else {
+		s.clientQueue.Inc()
+	}
+}
+
+func (s *stats) dequeue(prio int) {
+	if prio == prioBackground {
+		s.bgQueue.Dec()
+	} else {
+		s.clientQueue.Dec()
+	}
+}
+
 func (s *stats) updateEncode(size int64) {
 	s.encodeSize.Add(size)
 	s.encodeReq.Inc()
@@ -107,6 +215,11 @@ func (s *stats) updateDeleteTime(d time.Duration, failed bool) {
 	}
 }
 
+func (s *stats) updateRepair(cnt int, size int64) {
+	s.repairCnt.Add(int64(cnt))
+	s.repairSize.Add(size)
+}
+
 func (s *stats) updateWaitTime(d time.Duration) {
 	s.waitTime.Add(int64(d))
 	s.waitCnt.Inc()
@@ -161,9 +274,14 @@ func (s *stats) stats() *ECStats {
 		st.ObjTime = time.Duration(val / cnt)
 	}
 
+	st.ClientQueueLen = s.clientQueue.Load()
+	st.BgQueueLen = s.bgQueue.Load()
+
 	st.EncodeErr = s.encodeErr.Load()
 	st.DecodeErr = s.decodeErr.Load()
 	st.DeleteErr = s.deleteErr.Load()
+	st.RepairCnt = s.repairCnt.Load()
+	st.RepairSize = s.repairSize.Load()
 
 	return st
 }
@@ -177,6 +295,7 @@ func (s *ECStats) String() string {
 	lines = append(lines,
 		fmt.Sprintf("EC stats for bucket %s", s.Bck),
 		fmt.Sprintf("Queue avg len: %.4f, avg wait time: %v", s.QueueLen, s.WaitTime),
+		fmt.Sprintf("Pending restores: client %d, background %d", s.ClientQueueLen, s.BgQueueLen),
 		fmt.Sprintf("Avg object processing time: %v", s.ObjTime),
 	)
 
@@ -193,6 +312,9 @@ func (s *ECStats) String() string {
 	}
 
 	lines = append(lines, fmt.Sprintf("Requests count: encode %d, restore %d, delete %d", s.PutReq, s.GetReq, s.DelReq))
+	if s.RepairCnt != 0 {
+		lines = append(lines, fmt.Sprintf("Repair transfers: %d, size: %d", s.RepairCnt, s.RepairSize))
+	}
 
 	return strings.Join(lines, "\n")
 }
@@ -0,0 +1,46 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestMpathStats(t *testing.T) {
+	s := &stats{mpaths: &sync.Map{}}
+	tassert.Errorf(t, len(s.mpathStats()) == 0, "expected no mountpaths")
+
+	mp1 := s.mpath("/mp1")
+	s.mpath("/mp2")
+	tassert.Errorf(t, s.mpath("/mp1") == mp1, "expected the same counters for the same mountpath")
+
+	// mp1: 3 requests queued, 2 processed (one failed)
+	for i := 0; i < 3; i++ {
+		mp1.queueLen.Inc()
+	}
+	mp1.dequeue(time.Millisecond)
+	mp1.update(time.Second, 4096, false)
+	mp1.dequeue(3 * time.Millisecond)
+	mp1.update(time.Second, 1024, true)
+
+	all := s.mpathStats()
+	tassert.Fatalf(t, len(all) == 2, "expected 2 mountpaths, got %d", len(all))
+	st := all["/mp1"]
+	tassert.Errorf(t, st.QueueLen == 1, "expected 1 queued, got %d", st.QueueLen)
+	tassert.Errorf(t, st.AvgWaitTime == int64(2*time.Millisecond), "expected 2ms average wait, got %d", st.AvgWaitTime)
+	tassert.Errorf(t, st.Count == 2 && st.ErrCount == 1, "expected 2 requests, 1 failed, got %d, %d",
+		st.Count, st.ErrCount)
+	// (the size of the failed ones does not count)
+	tassert.Errorf(t, st.Size == 4096, "expected 4096 bytes, got %d", st.Size)
+	tassert.Errorf(t, st.Throughput == 2048, "expected 2048 B/s, got %d", st.Throughput)
+
+	// nothing processed yet
+	st = all["/mp2"]
+	tassert.Errorf(t, *st == MpathStats{}, "expected zero stats, got %+v", *st)
+}
@@ -0,0 +1,249 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Trash (tombstoned) content
+//
+// When `ec.undelete_window` is set, the metafiles, replicas, and slices of
+// a deleted object are not removed right away. Instead, each of them is moved
+// to the trash content type on the same mountpath, with its original content
+// type kept as the suffix of the name. The tombstoned files are then either
+// moved back (see Undelete) or removed once the window expires - by the
+// housekeeper, regardless of whether EC is running (see purgeTrashAll).
+
+const (
+	trashHkName = "ec.trash"
+	trashHkIdle = 10 * time.Minute // when the undelete window is disabled
+	trashHkMin  = time.Minute
+)
+
+type TrashSpec struct{}
+
+var _ fs.ContentResolver = &TrashSpec{}
+
+func (wf *TrashSpec) PermToMove() bool    { return false }
+func (wf *TrashSpec) PermToEvict() bool   { return true }
+func (wf *TrashSpec) PermToProcess() bool { return false }
+
+// the prefix is the original content type of the tombstoned file
+func (wf *TrashSpec) GenUniqueFQN(base, prefix string) string { return base + "." + prefix }
+func (wf *TrashSpec) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	idx := strings.LastIndex(base, ".")
+	if idx < 0 {
+		return "", false, false
+	}
+	return base[:idx], false, true
+}
+
+// removes the file or, if the undelete window is enabled, moves it to trash
+func trashOrRemove(fqn, contentType string, window time.Duration) error {
+	if window <= 0 {
+		return os.RemoveAll(fqn)
+	}
+	if _, err := os.Stat(fqn); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	trashFQN := fs.CSM.GenContentFQN(fqn, TrashType, contentType)
+	if err := cmn.CreateDir(filepath.Dir(trashFQN)); err != nil {
+		return err
+	}
+	if err := os.Rename(fqn, trashFQN); err != nil {
+		return err
+	}
+	// the window starts when the object gets deleted
+	now := time.Now()
+	return os.Chtimes(trashFQN, now, now)
+}
+
+// parses the name of a tombstoned file
+func parseTrashFQN(fqn string) (objName, contentType string, err error) {
+	parsed, err := fs.Mountpaths.ParseFQN(fqn)
+	if err != nil {
+		return "", "", err
+	}
+	idx := strings.LastIndex(parsed.ObjName, ".")
+	if idx < 0 {
+		return "", "", fmt.Errorf("invalid trash name %q", fqn)
+	}
+	return parsed.ObjName[:idx], parsed.ObjName[idx+1:], nil
+}
+
+// Undelete moves back the tombstoned metafiles, replicas, and slices of the
+// objects that match a given prefix or (bash-style) template, provided that
+// they are still within the undelete window. Files that have been
+// re-created in the meantime are not overwritten. The main objects themselves
+// must then be restored from the slices (see XactBckRestore).
+// Returns the number of files that have been moved back.
+func Undelete(bck *cluster.Bck, template string) (n int, err error) {
+	var (
+		pt     cmn.ParsedTemplate
+		names  map[string]struct{}
+		metas  = make([]string, 0, 16)
+		others = make([]string, 0, 16)
+		window = cmn.GCO.Get().EC.UndeleteWindow
+		now    = time.Now()
+	)
+	if window <= 0 {
+		return 0, nil
+	}
+	if pt, err = cmn.ParseBashTemplate(template); err != nil {
+		if pt, err = cmn.ParseAtTemplate(template); err != nil {
+			pt = cmn.ParsedTemplate{Prefix: template}
+		}
+	}
+	if len(pt.Ranges) != 0 {
+		names = make(map[string]struct{})
+		getNext := pt.Iter()
+		for objName, hasNext := getNext(); hasNext; objName, hasNext = getNext() {
+			names[objName] = struct{}{}
+		}
+	}
+
+	availablePaths, _ := fs.Mountpaths.Get()
+	for _, mpathInfo := range availablePaths {
+		opts := &fs.Options{
+			Mpath: mpathInfo,
+			Bck:   bck.Bck,
+			CTs:   []string{TrashType},
+			Callback: func(fqn string, de fs.DirEntry) error {
+				if de.IsDir() {
+					return nil
+				}
+				objName, contentType, err := parseTrashFQN(fqn)
+				if err != nil {
+					return nil
+				}
+				if names != nil {
+					if _, ok := names[objName]; !ok {
+						return nil
+					}
+				} else if !strings.HasPrefix(objName, pt.Prefix) {
+					return nil
+				}
+				if contentType == MetaType {
+					metas = append(metas, fqn)
+				} else {
+					others = append(others, fqn)
+				}
+				return nil
+			},
+			Sorted: false,
+		}
+		if err = fs.Walk(opts); err != nil {
+			return
+		}
+	}
+
+	// to be consistent with PUT, the replicas and slices go first, the
+	// metafiles - last (in reverse order of the deletion)
+	for _, fqn := range append(others, metas...) {
+		if untrash(fqn, now.Add(-window)) {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func untrash(fqn string, expired time.Time) bool {
+	finfo, err := os.Stat(fqn)
+	if err != nil || finfo.ModTime().Before(expired) {
+		return false
+	}
+	parsed, err := fs.Mountpaths.ParseFQN(fqn)
+	if err != nil {
+		return false
+	}
+	objName, contentType, err := parseTrashFQN(fqn)
+	if err != nil {
+		return false
+	}
+	if _, ok := fs.CSM.RegisteredContentTypes[contentType]; !ok {
+		glog.Errorf("%q: unknown content type %s", fqn, contentType)
+		return false
+	}
+	origFQN := fs.CSM.FQN(parsed.MpathInfo, parsed.Bck, contentType, objName)
+	if _, err := os.Stat(origFQN); err == nil {
+		return false // re-created - keep the newer one
+	}
+	if err := cmn.CreateDir(filepath.Dir(origFQN)); err != nil {
+		glog.Error(err)
+		return false
+	}
+	if err := os.Rename(fqn, origFQN); err != nil {
+		glog.Errorf("failed to undelete %q: %v", origFQN, err)
+		return false
+	}
+	if glog.V(4) {
+		glog.Infof("undeleted %q", origFQN)
+	}
+	return true
+}
+
+// removes the expired tombstones of all buckets (at most once per undelete window)
+func purgeTrashAll() time.Duration {
+	window := cmn.GCO.Get().EC.UndeleteWindow
+	if window <= 0 {
+		return trashHkIdle
+	}
+	ECM.t.GetBowner().Get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		purgeTrash(bck.Bck, window)
+		return false
+	})
+	return cmn.MaxDuration(window, trashHkMin)
+}
+
+// removes the bucket's tombstoned files that are older than the undelete window
+func purgeTrash(bck cmn.Bck, window time.Duration) {
+	var (
+		expired = time.Now().Add(-window)
+		cnt     int
+	)
+	availablePaths, _ := fs.Mountpaths.Get()
+	for _, mpathInfo := range availablePaths {
+		opts := &fs.Options{
+			Mpath: mpathInfo,
+			Bck:   bck,
+			CTs:   []string{TrashType},
+			Callback: func(fqn string, de fs.DirEntry) error {
+				if de.IsDir() {
+					return nil
+				}
+				finfo, err := os.Stat(fqn)
+				if err != nil || !finfo.ModTime().Before(expired) {
+					return nil
+				}
+				if err := os.Remove(fqn); err != nil && !os.IsNotExist(err) {
+					glog.Errorf("failed to remove %q: %v", fqn, err)
+					return nil
+				}
+				cnt++
+				return nil
+			},
+			Sorted: false,
+		}
+		if err := fs.Walk(opts); err != nil {
+			glog.Errorf("%s: failed to purge trash: %v", bck, err)
+		}
+	}
+	if cnt > 0 {
+		glog.Infof("%s: purged %d expired tombstone(s)", bck, cnt)
+	}
+}
@@ -0,0 +1,148 @@
+// Package ec provides erasure coding (EC) based data protection for AIStore.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ec
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+const testUndeleteWindow = time.Hour
+
+func trashSetup(t *testing.T) (mi *fs.MountpathInfo, bck *cluster.Bck, cleanup func()) {
+	fs.Mountpaths = fs.NewMountedFS(ios.NewIOStaterMock())
+	fs.Mountpaths.DisableFsIDCheck()
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.RegisterContentType(SliceType, &SliceSpec{})
+	_ = fs.CSM.RegisterContentType(MetaType, &MetaSpec{})
+	_ = fs.CSM.RegisterContentType(TrashType, &TrashSpec{})
+
+	mpath, err := ioutil.TempDir("", "ectrash")
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, fs.Mountpaths.Add(mpath))
+	avail, _ := fs.Mountpaths.Get()
+	mi = avail[mpath]
+
+	config := cmn.GCO.BeginUpdate()
+	window := config.EC.UndeleteWindow
+	config.EC.UndeleteWindow = testUndeleteWindow
+	cmn.GCO.CommitUpdate(config)
+
+	bck = cluster.NewBck("ectrash", cmn.ProviderAIS, cmn.NsGlobal)
+	cleanup = func() {
+		config := cmn.GCO.BeginUpdate()
+		config.EC.UndeleteWindow = window
+		cmn.GCO.CommitUpdate(config)
+		os.RemoveAll(mpath)
+	}
+	return
+}
+
+func trashCreate(t *testing.T, fqn string) {
+	tassert.CheckFatal(t, cmn.CreateDir(filepath.Dir(fqn)))
+	tassert.CheckFatal(t, ioutil.WriteFile(fqn, []byte(fqn), 0644))
+}
+
+func trashExists(fqn string) bool {
+	_, err := os.Stat(fqn)
+	return err == nil
+}
+
+func TestTrashUndelete(t *testing.T) {
+	mi, bck, cleanup := trashSetup(t)
+	defer cleanup()
+
+	var (
+		metaFQN  = mi.MakePathFQN(bck.Bck, MetaType, "dir/obj")
+		sliceFQN = mi.MakePathFQN(bck.Bck, SliceType, "dir/obj")
+		otherFQN = mi.MakePathFQN(bck.Bck, MetaType, "other")
+	)
+	for _, fqn := range []string{metaFQN, sliceFQN, otherFQN} {
+		trashCreate(t, fqn)
+	}
+	tassert.CheckFatal(t, trashOrRemove(metaFQN, MetaType, testUndeleteWindow))
+	tassert.CheckFatal(t, trashOrRemove(sliceFQN, SliceType, testUndeleteWindow))
+	tassert.CheckFatal(t, trashOrRemove(otherFQN, MetaType, testUndeleteWindow))
+
+	// tombstoned
+	tombstone := fs.CSM.GenContentFQN(metaFQN, TrashType, MetaType)
+	tassert.Fatalf(t, !trashExists(metaFQN) && trashExists(tombstone), "expected %q to be tombstoned", metaFQN)
+	objName, contentType, err := parseTrashFQN(tombstone)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, objName == "dir/obj" && contentType == MetaType, "unexpected %q, %q", objName, contentType)
+
+	// only the objects that match the prefix get undeleted
+	n, err := Undelete(bck, "dir/")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, n == 2, "expected 2 files undeleted, got %d", n)
+	tassert.Errorf(t, trashExists(metaFQN) && trashExists(sliceFQN), "expected %q to be undeleted", "dir/obj")
+	tassert.Errorf(t, !trashExists(otherFQN), "expected %q to remain tombstoned", otherFQN)
+}
+
+func TestTrashUndeleteRecreated(t *testing.T) {
+	mi, bck, cleanup := trashSetup(t)
+	defer cleanup()
+
+	metaFQN := mi.MakePathFQN(bck.Bck, MetaType, "obj")
+	trashCreate(t, metaFQN)
+	tassert.CheckFatal(t, trashOrRemove(metaFQN, MetaType, testUndeleteWindow))
+
+	// re-created in the meantime - must not be overwritten
+	tassert.CheckFatal(t, ioutil.WriteFile(metaFQN, []byte("new"), 0644))
+	n, err := Undelete(bck, "obj")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, n == 0, "expected nothing undeleted, got %d", n)
+	b, err := ioutil.ReadFile(metaFQN)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, string(b) == "new", "expected the re-created %q to be kept", metaFQN)
+}
+
+func TestTrashPurge(t *testing.T) {
+	mi, bck, cleanup := trashSetup(t)
+	defer cleanup()
+
+	var (
+		oldFQN  = mi.MakePathFQN(bck.Bck, MetaType, "old")
+		newFQN  = mi.MakePathFQN(bck.Bck, MetaType, "new")
+		expired = time.Now().Add(-2 * testUndeleteWindow)
+	)
+	trashCreate(t, oldFQN)
+	trashCreate(t, newFQN)
+	tassert.CheckFatal(t, trashOrRemove(oldFQN, MetaType, testUndeleteWindow))
+	tassert.CheckFatal(t, trashOrRemove(newFQN, MetaType, testUndeleteWindow))
+	oldTombstone := fs.CSM.GenContentFQN(oldFQN, TrashType, MetaType)
+	newTombstone := fs.CSM.GenContentFQN(newFQN, TrashType, MetaType)
+	tassert.CheckFatal(t, os.Chtimes(oldTombstone, expired, expired))
+
+	// expired tombstones can't be undeleted...
+	n, err := Undelete(bck, "old")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, n == 0, "expected nothing undeleted, got %d", n)
+
+	// ...and get purged
+	purgeTrash(bck.Bck, testUndeleteWindow)
+	tassert.Errorf(t, !trashExists(oldTombstone), "expected %q to be purged", oldTombstone)
+	tassert.Errorf(t, trashExists(newTombstone), "expected %q to be kept", newTombstone)
+}
+
+func TestTrashDisabled(t *testing.T) {
+	mi, bck, cleanup := trashSetup(t)
+	defer cleanup()
+
+	metaFQN := mi.MakePathFQN(bck.Bck, MetaType, "obj")
+	trashCreate(t, metaFQN)
+	tassert.CheckFatal(t, trashOrRemove(metaFQN, MetaType, 0))
+	tombstone := fs.CSM.GenContentFQN(metaFQN, TrashType, MetaType)
+	tassert.Errorf(t, !trashExists(metaFQN) && !trashExists(tombstone), "expected %q to be removed", metaFQN)
+}
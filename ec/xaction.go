@@ -77,7 +77,7 @@ func newXactECBase(t cluster.Target, smap cluster.Sowner,
 		t:     t,
 		smap:  smap,
 		si:    si,
-		stats: stats{bck: bck},
+		stats: stats{bck: bck, mpaths: &sync.Map{}},
 		bck:   bck,
 
 		dOwner: &dataOwner{
@@ -150,7 +150,10 @@ func (r *xactECBase) newSliceResponse(md *Metadata, attrs *transport.ObjectAttrs
 		return nil, err
 	}
 	attrs.Size = stat.Size()
-	reader, err = cmn.NewFileHandle(fqn)
+	if md.Compressed {
+		attrs.Size = SliceSize(md.Size, md.Data)
+	}
+	reader, err = OpenSlice(fqn, md)
 	if err != nil {
 		glog.Warningf("Failed to read file stats: %s", err)
 		return nil, err
@@ -354,13 +357,15 @@ func (r *xactECBase) writeRemote(daemonIDs []string, lom *cluster.LOM, src *data
 	}
 	req := r.newIntraReq(src.reqType, src.metadata)
 	req.isSlice = src.isSlice
+	req.traceID = src.traceID
 
 	mm := r.t.GetSmallMMSA()
 	putData := req.NewPack(mm)
 	objAttrs := transport.ObjectAttrs{
-		Size:    src.size,
-		Version: lom.Version(),
-		Atime:   lom.AtimeUnix(),
+		Size:     src.size,
+		Version:  lom.Version(),
+		Atime:    lom.AtimeUnix(),
+		CustomMD: lom.CustomMD(),
 	}
 	if src.metadata != nil && src.metadata.SliceID != 0 {
 		// for a slice read everything from slice's metadata
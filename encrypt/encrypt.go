@@ -0,0 +1,286 @@
+// Package encrypt provides at-rest encryption of the objects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package encrypt
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// At-rest encryption (envelope):
+//
+// - each object is encrypted with its own randomly generated 256-bit data key
+//   using AES-256 in CTR mode - which preserves the size of the object and
+//   allows reading any range of it without decrypting the rest;
+// - the data key is sealed (AES-256-GCM) with the key of the bucket and stored
+//   in the object's custom metadata along with the ID of the bucket key, so
+//   that the object remains readable when copied or moved to other buckets;
+// - bucket keys come from the KeyProvider - by default, derived from the master
+//   key (EnvMasterKey) as HMAC-SHA256(master key, key ID).
+//
+// The integrity of the content is protected by the object's checksum - the one
+// of the encrypted content (as stored).
+
+const (
+	AlgAES256 = "AES256" // (the only one - as in x-amz-server-side-encryption)
+
+	// base64-encoded 256-bit master key
+	EnvMasterKey = "AIS_SSE_MASTER_KEY"
+
+	// object's custom metadata
+	AlgMD     = "sse"          // encryption algorithm (AlgAES256)
+	KeyIDMD   = "sse-key-id"   // bucket key ID
+	DataKeyMD = "sse-data-key" // sealed data key and IV (base64)
+
+	keySize = 32
+)
+
+type (
+	// KeyProvider returns the key (256 bits) with a given ID; the key ID is
+	// the name of the bucket (see KeyID)
+	KeyProvider interface {
+		Key(keyID string) ([]byte, error)
+	}
+
+	// ObjKey: data key and IV of a given object
+	ObjKey struct {
+		block cipher.Block
+		iv    []byte
+	}
+
+	masterKeyProvider struct {
+		master []byte
+	}
+
+	// decrypting io.ReaderAt (see ObjKey.ReaderAt)
+	readerAt struct {
+		r   io.ReaderAt
+		key *ObjKey
+	}
+	writer struct {
+		w      io.Writer
+		stream cipher.Stream
+		buf    []byte
+	}
+)
+
+var (
+	ErrNoKeys = errors.New("encryption is not configured (no keys)")
+
+	keys struct {
+		sync.RWMutex
+		provider KeyProvider
+	}
+)
+
+// Init initializes the default key provider from the environment; returns
+// false if the master key is not set
+func Init() (bool, error) {
+	val := os.Getenv(EnvMasterKey)
+	if val == "" {
+		return false, nil
+	}
+	master, err := base64.StdEncoding.DecodeString(val)
+	if err != nil || len(master) != keySize {
+		return false, fmt.Errorf("invalid %s: expecting base64-encoded %d-byte key", EnvMasterKey, keySize)
+	}
+	SetKeyProvider(&masterKeyProvider{master: master})
+	return true, nil
+}
+
+// SetKeyProvider sets the source of the bucket keys (e.g., a KMS client)
+func SetKeyProvider(provider KeyProvider) {
+	keys.Lock()
+	keys.provider = provider
+	keys.Unlock()
+}
+
+func Enabled() bool {
+	keys.RLock()
+	defer keys.RUnlock()
+	return keys.provider != nil
+}
+
+// KeyID returns the ID of the key of a given bucket
+func KeyID(bck cmn.Bck) string {
+	return bck.Provider + cmn.BckProviderSeparator + bck.Ns.Uname() + bck.Name
+}
+
+func (p *masterKeyProvider) Key(keyID string) ([]byte, error) {
+	mac := hmac.New(sha256.New, p.master)
+	mac.Write([]byte(keyID))
+	return mac.Sum(nil), nil
+}
+
+func bucketKey(keyID string) (key []byte, err error) {
+	keys.RLock()
+	provider := keys.provider
+	keys.RUnlock()
+	if provider == nil {
+		return nil, ErrNoKeys
+	}
+	if key, err = provider.Key(keyID); err != nil {
+		return nil, err
+	}
+	if len(key) != keySize {
+		return nil, fmt.Errorf("invalid key %q: expecting %d bytes, got %d", keyID, keySize, len(key))
+	}
+	return
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+////////////
+// ObjKey //
+////////////
+
+// NewObjKey generates the data key of a new object and returns it along with
+// the custom metadata to store with the object
+func NewObjKey(keyID string) (objKey *ObjKey, md cmn.SimpleKVs, err error) {
+	bkey, err := bucketKey(keyID)
+	if err != nil {
+		return nil, nil, err
+	}
+	gcm, err := newGCM(bkey)
+	if err != nil {
+		return nil, nil, err
+	}
+	plain := make([]byte, keySize+aes.BlockSize) // data key and IV
+	if _, err = io.ReadFull(rand.Reader, plain); err != nil {
+		return nil, nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, nil, err
+	}
+	sealed := gcm.Seal(nonce, nonce, plain, []byte(keyID))
+	if objKey, err = newObjKey(plain); err != nil {
+		return nil, nil, err
+	}
+	md = cmn.SimpleKVs{
+		AlgMD:     AlgAES256,
+		KeyIDMD:   keyID,
+		DataKeyMD: base64.RawStdEncoding.EncodeToString(sealed),
+	}
+	return
+}
+
+// LoadObjKey unseals the data key of an existing object given its custom
+// metadata; returns nil if the object is not encrypted
+func LoadObjKey(md cmn.SimpleKVs) (*ObjKey, error) {
+	if md[AlgMD] == "" {
+		return nil, nil
+	}
+	if md[AlgMD] != AlgAES256 {
+		return nil, fmt.Errorf("unsupported encryption algorithm %q", md[AlgMD])
+	}
+	keyID := md[KeyIDMD]
+	bkey, err := bucketKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+	gcm, err := newGCM(bkey)
+	if err != nil {
+		return nil, err
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(md[DataKeyMD])
+	if err != nil || len(sealed) < gcm.NonceSize() {
+		return nil, fmt.Errorf("invalid data key (key ID %q)", keyID)
+	}
+	nonce := sealed[:gcm.NonceSize()]
+	plain, err := gcm.Open(nil, nonce, sealed[gcm.NonceSize():], []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unseal data key (key ID %q): %v", keyID, err)
+	}
+	return newObjKey(plain)
+}
+
+// IsEncrypted returns true if the custom metadata is the one of an encrypted object
+func IsEncrypted(md cmn.SimpleKVs) bool { return md[AlgMD] != "" }
+
+// StripMD returns a copy of the custom metadata without the encryption entries
+func StripMD(md cmn.SimpleKVs) cmn.SimpleKVs {
+	stripped := make(cmn.SimpleKVs, len(md))
+	for k, v := range md {
+		if k != AlgMD && k != KeyIDMD && k != DataKeyMD {
+			stripped[k] = v
+		}
+	}
+	return stripped
+}
+
+func newObjKey(plain []byte) (*ObjKey, error) {
+	if len(plain) != keySize+aes.BlockSize {
+		return nil, errors.New("invalid data key")
+	}
+	block, err := aes.NewCipher(plain[:keySize])
+	if err != nil {
+		return nil, err
+	}
+	return &ObjKey{block: block, iv: plain[keySize:]}, nil
+}
+
+// the stream positioned at a given offset of the content
+func (k *ObjKey) stream(offset int64) cipher.Stream {
+	var (
+		iv    = make([]byte, aes.BlockSize)
+		carry = uint64(offset / aes.BlockSize)
+	)
+	copy(iv, k.iv)
+	// add the number of blocks to the (big-endian) counter
+	for i := aes.BlockSize - 1; i >= 0 && carry > 0; i-- {
+		sum := uint64(iv[i]) + carry&0xff
+		iv[i] = byte(sum)
+		carry = carry>>8 + sum>>8
+	}
+	stream := cipher.NewCTR(k.block, iv)
+	if skip := int(offset % aes.BlockSize); skip > 0 {
+		discard := make([]byte, skip)
+		stream.XORKeyStream(discard, discard)
+	}
+	return stream
+}
+
+// Writer encrypts the content written to the returned writer
+func (k *ObjKey) Writer(w io.Writer) io.Writer {
+	return &writer{w: w, stream: k.stream(0)}
+}
+
+func (w *writer) Write(p []byte) (n int, err error) {
+	if cap(w.buf) < len(p) {
+		w.buf = make([]byte, len(p))
+	}
+	buf := w.buf[:len(p)]
+	w.stream.XORKeyStream(buf, p)
+	return w.w.Write(buf)
+}
+
+// ReaderAt decrypts the content read from the underlying (encrypted) ReaderAt
+func (k *ObjKey) ReaderAt(r io.ReaderAt) io.ReaderAt { return &readerAt{r: r, key: k} }
+
+func (r *readerAt) ReadAt(p []byte, off int64) (n int, err error) {
+	n, err = r.r.ReadAt(p, off)
+	if n > 0 {
+		r.key.stream(off).XORKeyStream(p[:n], p[:n])
+	}
+	return
+}
@@ -0,0 +1,114 @@
+// Package encrypt provides at-rest encryption of the objects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package encrypt
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+type testKeyProvider struct {
+	keys map[string][]byte
+}
+
+func (p *testKeyProvider) Key(keyID string) ([]byte, error) {
+	if key, ok := p.keys[keyID]; ok {
+		return key, nil
+	}
+	return nil, ErrNoKeys
+}
+
+func encryptContent(t *testing.T, keyID string, content []byte) ([]byte, cmn.SimpleKVs) {
+	objKey, md, err := NewObjKey(keyID)
+	tassert.CheckFatal(t, err)
+	var (
+		encrypted = &bytes.Buffer{}
+		w         = objKey.Writer(encrypted)
+	)
+	// in chunks that are not aligned with the AES block
+	for off := 0; off < len(content); off += 1000 {
+		end := cmn.Min(off+1000, len(content))
+		_, err := w.Write(content[off:end])
+		tassert.CheckFatal(t, err)
+	}
+	return encrypted.Bytes(), md
+}
+
+func TestEncryptDecrypt(t *testing.T) {
+	SetKeyProvider(&masterKeyProvider{master: bytes.Repeat([]byte{7}, keySize)})
+	defer SetKeyProvider(nil)
+
+	content := make([]byte, 100*1024+3)
+	rand.Read(content)
+	keyID := KeyID(cmn.Bck{Name: "test", Provider: cmn.ProviderAIS})
+	encrypted, md := encryptContent(t, keyID, content)
+	tassert.Fatalf(t, len(encrypted) == len(content), "size changed: %d != %d", len(encrypted), len(content))
+	tassert.Fatalf(t, !bytes.Equal(encrypted, content), "content not encrypted")
+	tassert.Fatalf(t, IsEncrypted(md), "expected encryption metadata, got %v", md)
+
+	objKey, err := LoadObjKey(md)
+	tassert.CheckFatal(t, err)
+	ra := objKey.ReaderAt(bytes.NewReader(encrypted))
+
+	// whole
+	decrypted := make([]byte, len(content))
+	_, err = io.ReadFull(io.NewSectionReader(ra, 0, int64(len(content))), decrypted)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, bytes.Equal(decrypted, content), "decrypted content differs")
+
+	// ranges
+	for _, rng := range [][2]int{{0, 1}, {15, 2}, {16, 16}, {17, 4000}, {len(content) - 5, 5}} {
+		b := make([]byte, rng[1])
+		_, err := ra.ReadAt(b, int64(rng[0]))
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, bytes.Equal(b, content[rng[0]:rng[0]+rng[1]]), "range %v: decrypted content differs", rng)
+	}
+}
+
+func TestWrongKey(t *testing.T) {
+	provider := &testKeyProvider{keys: map[string][]byte{
+		"a": bytes.Repeat([]byte{1}, keySize),
+		"b": bytes.Repeat([]byte{2}, keySize),
+	}}
+	SetKeyProvider(provider)
+	defer SetKeyProvider(nil)
+
+	_, md := encryptContent(t, "a", []byte("content"))
+
+	// the key ID is authenticated
+	md[KeyIDMD] = "b"
+	_, err := LoadObjKey(md)
+	tassert.Errorf(t, err != nil, "expected error loading data key with a different key ID")
+
+	// the bucket key is not the one
+	md[KeyIDMD] = "a"
+	provider.keys["a"] = bytes.Repeat([]byte{3}, keySize)
+	_, err = LoadObjKey(md)
+	tassert.Errorf(t, err != nil, "expected error loading data key with a different key")
+
+	// no keys
+	SetKeyProvider(nil)
+	_, err = LoadObjKey(md)
+	tassert.Errorf(t, err == ErrNoKeys, "expected %v, got %v", ErrNoKeys, err)
+}
+
+func TestStripMD(t *testing.T) {
+	SetKeyProvider(&masterKeyProvider{master: bytes.Repeat([]byte{7}, keySize)})
+	defer SetKeyProvider(nil)
+
+	_, md := encryptContent(t, "a", []byte("content"))
+	md["user"] = "value"
+	stripped := StripMD(md)
+	tassert.Errorf(t, !IsEncrypted(stripped), "expected no encryption metadata, got %v", stripped)
+	tassert.Errorf(t, len(stripped) == 1 && stripped["user"] == "value", "expected user metadata only, got %v", stripped)
+
+	objKey, err := LoadObjKey(stripped)
+	tassert.Errorf(t, objKey == nil && err == nil, "expected no data key (%v)", err)
+}
@@ -0,0 +1,203 @@
+// Package events delivers cluster events (object and bucket mutations, xaction completions)
+// to the external sinks: HTTP webhooks and NATS.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package events
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Event bus
+//
+// Each node publishes its own events: targets - the object mutations, proxies -
+// the bucket lifecycle and the completion of the xactions they are notified
+// about. The bus matches each event against the filters of the configured sinks
+// (see cmn.EventsConf) and queues it to the sinks that are interested. Each sink
+// has its own queue and goroutine, so that a slow (or unreachable) sink does not
+// hold up the others - nor the datapath: when the queue is full, the event
+// gets dropped. Delivery is best-effort, at-most-once.
+
+const (
+	sinkQueueSize  = 1024
+	dropLogEvery   = 1000 // log every so many dropped events
+	sinkErrLogTime = time.Minute
+)
+
+type (
+	sink interface {
+		send(b []byte) error
+		close()
+	}
+	sinkRunner struct {
+		conf    cmn.EventSinkConf
+		s       sink
+		workCh  chan []byte
+		stopCh  *cmn.StopCh
+		wg      sync.WaitGroup
+		dropped atomic.Int64
+		lastErr time.Time
+	}
+	Bus struct {
+		cmn.Named
+		node   string
+		mtx    sync.RWMutex
+		sinks  []*sinkRunner
+		stopCh *cmn.StopCh
+	}
+)
+
+// interface guard
+var (
+	_ cmn.Runner         = &Bus{}
+	_ cmn.ConfigListener = &Bus{}
+)
+
+func NewBus(node string) *Bus {
+	return &Bus{node: node, stopCh: cmn.NewStopCh()}
+}
+
+func (b *Bus) Run() error {
+	glog.Infof("Starting %s", b.GetRunName())
+	b.mtx.Lock()
+	b.sinks = startSinks(cmn.GCO.Get().Events.Sinks)
+	b.mtx.Unlock()
+	cmn.GCO.Subscribe(b)
+	<-b.stopCh.Listen()
+	b.mtx.Lock()
+	stopSinks(b.sinks)
+	b.sinks = nil
+	b.mtx.Unlock()
+	return nil
+}
+
+func (b *Bus) Stop(err error) {
+	glog.Infof("Stopping %s, err: %v", b.GetRunName(), err)
+	b.stopCh.Close()
+}
+
+func (b *Bus) ConfigUpdate(oldConf, newConf *cmn.Config) {
+	if reflect.DeepEqual(oldConf.Events, newConf.Events) {
+		return
+	}
+	b.mtx.Lock()
+	select {
+	case <-b.stopCh.Listen():
+	default:
+		stopSinks(b.sinks)
+		b.sinks = startSinks(newConf.Events.Sinks)
+	}
+	b.mtx.Unlock()
+}
+
+// Publish queues the event to all the sinks that are interested; never blocks
+func (b *Bus) Publish(ev *cmn.Event) {
+	if b == nil {
+		return
+	}
+	var data []byte
+	b.mtx.RLock()
+	for _, sr := range b.sinks {
+		if !sr.match(ev) {
+			continue
+		}
+		if data == nil {
+			if ev.Time == 0 {
+				ev.Time = time.Now().UnixNano()
+			}
+			ev.Node = b.node
+			data = cmn.MustMarshal(ev)
+		}
+		select {
+		case sr.workCh <- data:
+		default:
+			if n := sr.dropped.Inc(); n%dropLogEvery == 1 {
+				glog.Warningf("event sink %q: queue full, dropped %d event(s) so far", sr.conf.Name, n)
+			}
+		}
+	}
+	b.mtx.RUnlock()
+}
+
+////////////////
+// sinkRunner //
+////////////////
+
+func startSinks(confs []cmn.EventSinkConf) (sinks []*sinkRunner) {
+	for _, conf := range confs {
+		sr := &sinkRunner{
+			conf:   conf,
+			workCh: make(chan []byte, sinkQueueSize),
+			stopCh: cmn.NewStopCh(),
+		}
+		switch conf.Type {
+		case cmn.EventSinkWebhook:
+			sr.s = newWebhook(conf.URL)
+		case cmn.EventSinkNATS:
+			sr.s = newNATS(conf.URL, conf.Subject)
+		default:
+			cmn.AssertMsg(false, conf.Type) // see EventsConf.Validate
+		}
+		sr.wg.Add(1)
+		go sr.run()
+		sinks = append(sinks, sr)
+	}
+	return
+}
+
+func stopSinks(sinks []*sinkRunner) {
+	for _, sr := range sinks {
+		sr.stopCh.Close()
+	}
+	for _, sr := range sinks {
+		sr.wg.Wait()
+	}
+}
+
+func (sr *sinkRunner) run() {
+	defer sr.wg.Done()
+	for {
+		select {
+		case data := <-sr.workCh:
+			if err := sr.s.send(data); err != nil {
+				// rate-limit the errors of an unreachable sink
+				if now := time.Now(); now.Sub(sr.lastErr) > sinkErrLogTime {
+					glog.Errorf("event sink %q: %v", sr.conf.Name, err)
+					sr.lastErr = now
+				}
+			}
+		case <-sr.stopCh.Listen():
+			sr.s.close()
+			return
+		}
+	}
+}
+
+// an empty filter matches all; event filter entries are either event types
+// (e.g. "object.put") or categories (e.g. "object")
+func (sr *sinkRunner) match(ev *cmn.Event) bool {
+	if len(sr.conf.Buckets) > 0 && !cmn.StringInSlice(ev.Bck.Name, sr.conf.Buckets) {
+		return false
+	}
+	if len(sr.conf.Events) == 0 {
+		return true
+	}
+	category := ev.Type
+	if i := strings.IndexByte(ev.Type, '.'); i > 0 {
+		category = ev.Type[:i]
+	}
+	for _, ty := range sr.conf.Events {
+		if ty == ev.Type || ty == category {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,146 @@
+// Package events delivers cluster events (object and bucket mutations, xaction completions)
+// to the external sinks: HTTP webhooks and NATS.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package events
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+	jsoniter "github.com/json-iterator/go"
+)
+
+func startBus(t *testing.T, sinks ...cmn.EventSinkConf) *Bus {
+	config := cmn.GCO.BeginUpdate()
+	config.Timeout.CplaneOperation = time.Second
+	config.Events.Sinks = sinks
+	cmn.GCO.CommitUpdate(config)
+
+	b := NewBus("t1")
+	go b.Run()
+	// wait for the sinks to start
+	for i := 0; i < 100; i++ {
+		b.mtx.RLock()
+		n := len(b.sinks)
+		b.mtx.RUnlock()
+		if n == len(sinks) {
+			return b
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("%d sink(s) failed to start", len(sinks))
+	return nil
+}
+
+func receive(t *testing.T, ch chan *cmn.Event) *cmn.Event {
+	select {
+	case ev := <-ch:
+		return ev
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for event")
+	}
+	return nil
+}
+
+func TestWebhookFilters(t *testing.T) {
+	ch := make(chan *cmn.Event, 16)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ev := &cmn.Event{}
+		if err := jsoniter.NewDecoder(r.Body).Decode(ev); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		ch <- ev
+	}))
+	defer srv.Close()
+
+	b := startBus(t, cmn.EventSinkConf{
+		Name:    "wh",
+		Type:    cmn.EventSinkWebhook,
+		URL:     srv.URL,
+		Events:  []string{cmn.EventObjDelete, "bucket"},
+		Buckets: []string{"b1"},
+	})
+	defer b.Stop(nil)
+
+	b.Publish(&cmn.Event{Type: cmn.EventObjPut, Bck: cmn.Bck{Name: "b1"}, ObjName: "o1"})    // type
+	b.Publish(&cmn.Event{Type: cmn.EventObjDelete, Bck: cmn.Bck{Name: "b2"}, ObjName: "o2"}) // bucket
+	b.Publish(&cmn.Event{Type: cmn.EventObjDelete, Bck: cmn.Bck{Name: "b1"}, ObjName: "o3"})
+	b.Publish(&cmn.Event{Type: cmn.EventBckCreate, Bck: cmn.Bck{Name: "b1"}})
+
+	ev := receive(t, ch)
+	tassert.Errorf(t, ev.Type == cmn.EventObjDelete && ev.ObjName == "o3", "unexpected %+v", ev)
+	tassert.Errorf(t, ev.Node == "t1" && ev.Time != 0, "unexpected %+v", ev)
+	ev = receive(t, ch)
+	tassert.Errorf(t, ev.Type == cmn.EventBckCreate, "unexpected %+v", ev)
+	select {
+	case ev := <-ch:
+		t.Errorf("unexpected %+v", ev)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestNATS(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	tassert.CheckFatal(t, err)
+	defer l.Close()
+
+	// fake NATS server: greets, pings once, and forwards the published payloads
+	ch := make(chan *cmn.Event, 16)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		fmt.Fprintf(conn, "INFO {\"server_id\":\"test\"}\r\nPING\r\n")
+		rd := bufio.NewReader(conn)
+		for {
+			line, err := rd.ReadString('\n')
+			if err != nil {
+				return
+			}
+			var (
+				subject string
+				size    int
+			)
+			if !strings.HasPrefix(line, "PUB") {
+				continue
+			}
+			if _, err := fmt.Sscanf(line, "PUB %s %d", &subject, &size); err != nil || subject != "ais" {
+				return
+			}
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(rd, payload); err != nil {
+				return
+			}
+			ev := &cmn.Event{}
+			if jsoniter.Unmarshal(payload[:size], ev) == nil {
+				ch <- ev
+			}
+		}
+	}()
+
+	b := startBus(t, cmn.EventSinkConf{
+		Name:    "nats",
+		Type:    cmn.EventSinkNATS,
+		URL:     "nats://" + l.Addr().String(),
+		Subject: "ais",
+	})
+	defer b.Stop(nil)
+
+	b.Publish(&cmn.Event{Type: cmn.EventXactFinish, UUID: "x1"})
+	ev := receive(t, ch)
+	tassert.Errorf(t, ev.Type == cmn.EventXactFinish && ev.UUID == "x1", "unexpected %+v", ev)
+}
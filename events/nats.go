@@ -0,0 +1,114 @@
+// Package events delivers cluster events (object and bucket mutations, xaction completions)
+// to the external sinks: HTTP webhooks and NATS.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package events
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// NATS: publishes each event (JSON) to the configured subject.
+//
+// The sink speaks the (text-based) NATS client protocol directly and implements
+// only what's needed to publish: CONNECT, PUB, and answering the server's PINGs.
+// The connection is established lazily and re-established upon the next event
+// after a failure.
+
+const natsScheme = "nats://"
+
+type natsSink struct {
+	addr    string
+	subject string
+	mtx     sync.Mutex // protects writes to conn (PUB vs PONG)
+	conn    net.Conn
+}
+
+func newNATS(url, subject string) *natsSink {
+	return &natsSink{addr: strings.TrimPrefix(url, natsScheme), subject: subject}
+}
+
+func (ns *natsSink) send(b []byte) (err error) {
+	ns.mtx.Lock()
+	defer ns.mtx.Unlock()
+	if ns.conn == nil {
+		if err = ns.connect(); err != nil {
+			return
+		}
+	}
+	ns.conn.SetWriteDeadline(time.Now().Add(cmn.GCO.Get().Timeout.CplaneOperation))
+	if _, err = fmt.Fprintf(ns.conn, "PUB %s %d\r\n%s\r\n", ns.subject, len(b), b); err != nil {
+		ns.conn.Close()
+		ns.conn = nil
+	}
+	return
+}
+
+func (ns *natsSink) close() {
+	ns.mtx.Lock()
+	if ns.conn != nil {
+		ns.conn.Close()
+		ns.conn = nil
+	}
+	ns.mtx.Unlock()
+}
+
+// under lock
+func (ns *natsSink) connect() error {
+	timeout := cmn.GCO.Get().Timeout.CplaneOperation
+	conn, err := net.DialTimeout("tcp", ns.addr, timeout)
+	if err != nil {
+		return err
+	}
+	// the server greets with INFO {...}
+	conn.SetDeadline(time.Now().Add(timeout))
+	rd := bufio.NewReader(conn)
+	line, err := rd.ReadString('\n')
+	if err == nil && !strings.HasPrefix(line, "INFO") {
+		err = fmt.Errorf("unexpected greeting %q", strings.TrimSpace(line))
+	}
+	if err == nil {
+		_, err = fmt.Fprintf(conn, "CONNECT {\"verbose\":false,\"pedantic\":false,\"name\":\"aistore\"}\r\n")
+	}
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("NATS %s: %v", ns.addr, err)
+	}
+	conn.SetDeadline(time.Time{})
+	ns.conn = conn
+	go ns.read(conn, rd)
+	return nil
+}
+
+// answers the server's PINGs (otherwise, the server would drop the connection
+// as stale) and logs its errors; exits when the connection gets closed
+func (ns *natsSink) read(conn net.Conn, rd *bufio.Reader) {
+	for {
+		line, err := rd.ReadString('\n')
+		if err != nil {
+			return
+		}
+		switch {
+		case strings.HasPrefix(line, "PING"):
+			ns.mtx.Lock()
+			if ns.conn == conn {
+				_, err = conn.Write([]byte("PONG\r\n"))
+			}
+			ns.mtx.Unlock()
+		case strings.HasPrefix(line, "-ERR"):
+			glog.Errorf("NATS %s: %s", ns.addr, strings.TrimSpace(line))
+		}
+		if err != nil {
+			return
+		}
+	}
+}
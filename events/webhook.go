@@ -0,0 +1,48 @@
+// Package events delivers cluster events (object and bucket mutations, xaction completions)
+// to the external sinks: HTTP webhooks and NATS.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package events
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// webhook: POSTs each event (JSON) to the configured URL
+
+type webhook struct {
+	url    string
+	client *http.Client
+}
+
+func newWebhook(url string) *webhook {
+	config := cmn.GCO.Get()
+	return &webhook{
+		url: url,
+		client: cmn.NewClient(cmn.TransportArgs{
+			Timeout:    config.Timeout.CplaneOperation,
+			UseHTTPS:   cmn.IsHTTPS(url),
+			SkipVerify: config.Net.HTTP.SkipVerify,
+		}),
+	}
+}
+
+func (wh *webhook) send(b []byte) error {
+	resp, err := wh.client.Post(wh.url, "application/json", bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	cmn.DrainReader(resp.Body) // to reuse the connection
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("POST %s: %s", wh.url, resp.Status)
+	}
+	return nil
+}
+
+func (wh *webhook) close() { wh.client.CloseIdleConnections() }
@@ -5,10 +5,12 @@
 package fs
 
 import (
+	"encoding/base64"
 	"fmt"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cmn"
@@ -33,6 +35,10 @@ const (
 	contentTypeLen = 2
 	ObjectType     = "ob"
 	WorkfileType   = "wk"
+	ObjVersionType = "ov" // previous versions of the objects (see cluster.LOM.KeepVersion)
+	RecycleType    = "rb" // deleted objects (see cluster.LOM.Trash) - not to be confused with ec.TrashType
+	RemoteVerType  = "rv" // given versions of the cloud objects (see cluster.LOM.RemoteVersionFQN)
+	MptPartType    = "mp" // parts of the S3 multipart uploads in progress (owned by the target, not GC-ed as workfiles)
 )
 
 type (
@@ -90,9 +96,18 @@ func (f *ContentSpecMgr) GenContentFQN(fqn, contentType, prefix string) string {
 }
 
 func (f *ContentSpecMgr) GenContentParsedFQN(parsedFQN ParsedFQN, contentType, prefix string) (fqn string) {
-	spec := f.RegisteredContentTypes[contentType]
+	var (
+		spec = f.RegisteredContentTypes[contentType]
+		mi   = parsedFQN.MpathInfo
+	)
+	// routed content does not reside on the object's mountpath (see routing.go)
+	if parsedFQN.Routed && Mountpaths.route(contentType) == "" {
+		if hrw := Mountpaths.hrwMpath(makeUname(parsedFQN.Bck, parsedFQN.ObjName), ""); hrw != nil {
+			mi = hrw
+		}
+	}
 	fqn = f.FQN(
-		parsedFQN.MpathInfo,
+		mi,
 		parsedFQN.Bck,
 		contentType,
 		spec.GenUniqueFQN(parsedFQN.ObjName, prefix))
@@ -124,7 +139,10 @@ func (f *ContentSpecMgr) FileSpec(fqn string) (resolver ContentResolver, info *C
 	return
 }
 
+// FQN returns the FQN of a given content on a given mountpath, unless the
+// content type is routed to other mountpaths (see routing.go)
 func (f *ContentSpecMgr) FQN(mi *MountpathInfo, bck cmn.Bck, contentType, objName string) (fqn string) {
+	mi = Mountpaths.routeMpath(mi, bck, contentType, objName)
 	return mi.MakePathFQN(bck, contentType, objName)
 }
 
@@ -158,8 +176,11 @@ func (f *ContentSpecMgr) PermToProcess(fqn string) (ok bool) {
 // FIXME: This should be probably placed somewhere else \/
 
 type (
-	ObjectContentResolver   struct{}
-	WorkfileContentResolver struct{}
+	ObjectContentResolver     struct{}
+	WorkfileContentResolver   struct{}
+	ObjVersionContentResolver struct{}
+	RecycleContentResolver    struct{}
+	RemoteVerContentResolver  struct{}
 )
 
 func (wf *ObjectContentResolver) PermToMove() bool    { return true }
@@ -211,3 +232,68 @@ func (wf *WorkfileContentResolver) ParseUniqueFQN(base string) (orig string, old
 
 	return base[:tieIndex], filePID != pid, true
 }
+
+// NOTE: previous versions migrate along with the object (see reb) and get
+// evicted by LRU before the objects
+func (ov *ObjVersionContentResolver) PermToMove() bool    { return true }
+func (ov *ObjVersionContentResolver) PermToEvict() bool   { return true }
+func (ov *ObjVersionContentResolver) PermToProcess() bool { return false }
+
+// <object name>.<version>
+func (ov *ObjVersionContentResolver) GenUniqueFQN(base, version string) string {
+	return base + "." + version
+}
+
+func (ov *ObjVersionContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	verIndex := strings.LastIndex(base, ".")
+	if verIndex <= 0 || verIndex == len(base)-1 {
+		return "", false, false
+	}
+	return base[:verIndex], false, true
+}
+
+// NOTE: trashed objects are not moved and not evicted - they remain on the
+// mountpath the object was stored on until the TTL expires (see lru.GCTrash)
+func (tr *RecycleContentResolver) PermToMove() bool    { return false }
+func (tr *RecycleContentResolver) PermToEvict() bool   { return false }
+func (tr *RecycleContentResolver) PermToProcess() bool { return false }
+
+// <object name>.<expiration time (Unix seconds)>
+func (tr *RecycleContentResolver) GenUniqueFQN(base, expires string) string {
+	return base + "." + expires
+}
+
+func (tr *RecycleContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	expIndex := strings.LastIndex(base, ".")
+	if expIndex <= 0 || expIndex == len(base)-1 {
+		return "", false, false
+	}
+	expires, err := strconv.ParseInt(base[expIndex+1:], 10, 64)
+	if err != nil {
+		return "", false, false
+	}
+	return base[:expIndex], time.Now().Unix() >= expires, true
+}
+
+// NOTE: the cached versions of the cloud objects are neither moved nor evicted
+// by LRU - they get removed along with the bucket's content (e.g., upon eviction)
+func (rv *RemoteVerContentResolver) PermToMove() bool    { return false }
+func (rv *RemoteVerContentResolver) PermToEvict() bool   { return true }
+func (rv *RemoteVerContentResolver) PermToProcess() bool { return false }
+
+// <object name>.<base64url-encoded version>
+// (the versions are opaque strings that may contain, e.g., slashes)
+func (rv *RemoteVerContentResolver) GenUniqueFQN(base, version string) string {
+	return base + "." + base64.RawURLEncoding.EncodeToString([]byte(version))
+}
+
+func (rv *RemoteVerContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	verIndex := strings.LastIndex(base, ".")
+	if verIndex <= 0 || verIndex == len(base)-1 {
+		return "", false, false
+	}
+	if _, err := base64.RawURLEncoding.DecodeString(base[verIndex+1:]); err != nil {
+		return "", false, false
+	}
+	return base[:verIndex], false, true
+}
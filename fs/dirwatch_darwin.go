@@ -0,0 +1,18 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import "errors"
+
+// DirWatcher is linux-only (inotify)
+type DirWatcher struct{}
+
+func NewDirWatcher(string, bool) (*DirWatcher, error) {
+	return nil, errors.New("watching directories is not supported on darwin")
+}
+
+func (*DirWatcher) Dir() string      { return "" }
+func (*DirWatcher) Stop()            {}
+func (*DirWatcher) Run(func(string)) {}
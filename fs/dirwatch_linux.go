@@ -0,0 +1,152 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+	"unsafe"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"golang.org/x/sys/unix"
+)
+
+// DirWatcher reports the regular files that get written (and closed) in, or
+// moved into, a given directory and, if recursive, its subdirectories - by way
+// of inotify(7). The files that already exist when the watch starts are not
+// reported, except for the content of the subdirectories that appear later
+// (the files may get there before the subdirectory is being watched).
+
+const (
+	dirWatchMask  = unix.IN_CLOSE_WRITE | unix.IN_MOVED_TO | unix.IN_CREATE | unix.IN_ONLYDIR
+	dirWatchPoll  = 500 * time.Millisecond // to check for Stop
+	dirWatchBufSz = 64 * (unix.SizeofInotifyEvent + unix.NAME_MAX + 1)
+)
+
+type DirWatcher struct {
+	dir    string
+	recurs bool
+	fd     int
+	wds    map[int]string // watch descriptor => directory
+	stopCh *cmn.StopCh
+}
+
+func NewDirWatcher(dir string, recurs bool) (*DirWatcher, error) {
+	fd, err := unix.InotifyInit1(unix.IN_NONBLOCK | unix.IN_CLOEXEC)
+	if err != nil {
+		return nil, os.NewSyscallError("inotify_init1", err)
+	}
+	w := &DirWatcher{dir: dir, recurs: recurs, fd: fd, wds: make(map[int]string), stopCh: cmn.NewStopCh()}
+	if err := w.add(dir, nil); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *DirWatcher) Dir() string { return w.dir }
+func (w *DirWatcher) Stop()       { w.stopCh.Close() }
+
+// Run calls `cb` for each new file until stopped
+func (w *DirWatcher) Run(cb func(fqn string)) {
+	var (
+		buf = make([]byte, dirWatchBufSz)
+		pfd = []unix.PollFd{{Fd: int32(w.fd), Events: unix.POLLIN}}
+	)
+	defer unix.Close(w.fd)
+	for {
+		select {
+		case <-w.stopCh.Listen():
+			return
+		default:
+		}
+		n, err := unix.Poll(pfd, int(dirWatchPoll/time.Millisecond))
+		if err != nil && err != unix.EINTR {
+			glog.Errorf("watch %q: %v", w.dir, os.NewSyscallError("poll", err))
+			return
+		}
+		if n <= 0 {
+			continue
+		}
+		if n, err = unix.Read(w.fd, buf); err != nil {
+			if err == unix.EAGAIN || err == unix.EINTR {
+				continue
+			}
+			glog.Errorf("watch %q: %v", w.dir, os.NewSyscallError("read", err))
+			return
+		}
+		w.handle(buf[:n], cb)
+	}
+}
+
+func (w *DirWatcher) handle(buf []byte, cb func(fqn string)) {
+	for off := 0; off+unix.SizeofInotifyEvent <= len(buf); {
+		var (
+			ev   = (*unix.InotifyEvent)(unsafe.Pointer(&buf[off]))
+			name = buf[off+unix.SizeofInotifyEvent : off+unix.SizeofInotifyEvent+int(ev.Len)]
+		)
+		off += unix.SizeofInotifyEvent + int(ev.Len)
+		if ev.Mask&unix.IN_Q_OVERFLOW != 0 {
+			glog.Errorf("watch %q: event queue overflow - some of the new files may not get reported", w.dir)
+			continue
+		}
+		dir, ok := w.wds[int(ev.Wd)]
+		if !ok {
+			continue
+		}
+		if ev.Mask&unix.IN_IGNORED != 0 { // removed or unmounted
+			delete(w.wds, int(ev.Wd))
+			continue
+		}
+		fqn := filepath.Join(dir, string(bytes.TrimRight(name, "\x00")))
+		switch {
+		case ev.Mask&unix.IN_ISDIR != 0:
+			if w.recurs && ev.Mask&(unix.IN_CREATE|unix.IN_MOVED_TO) != 0 {
+				if err := w.add(fqn, cb); err != nil {
+					glog.Errorf("watch %q: %v", w.dir, err)
+				}
+			}
+		case ev.Mask&(unix.IN_CLOSE_WRITE|unix.IN_MOVED_TO) != 0:
+			cb(fqn)
+		}
+	}
+}
+
+// adds the directory and, if recursive, its subdirectories; reports their files if `cb` is given
+func (w *DirWatcher) add(dir string, cb func(fqn string)) error {
+	wd, err := unix.InotifyAddWatch(w.fd, dir, dirWatchMask)
+	if err != nil {
+		return fmt.Errorf("failed to watch %q: %v", dir, os.NewSyscallError("inotify_add_watch", err))
+	}
+	w.wds[wd] = dir
+	if !w.recurs {
+		return nil
+	}
+	opts := &Options{
+		Dir: dir,
+		Callback: func(fqn string, de DirEntry) error {
+			if !de.IsDir() {
+				if cb != nil {
+					cb(fqn)
+				}
+				return nil
+			}
+			if fqn == dir {
+				return nil
+			}
+			wd, err := unix.InotifyAddWatch(w.fd, fqn, dirWatchMask)
+			if err != nil {
+				return fmt.Errorf("failed to watch %q: %v", fqn, os.NewSyscallError("inotify_add_watch", err))
+			}
+			w.wds[wd] = fqn
+			return nil
+		},
+	}
+	return Walk(opts)
+}
@@ -0,0 +1,66 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func TestDirWatcher(t *testing.T) {
+	dir, err := ioutil.TempDir("", "dirwatch")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(dir)
+	tassert.CheckFatal(t, ioutil.WriteFile(filepath.Join(dir, "existing"), []byte("x"), 0644))
+
+	w, err := fs.NewDirWatcher(dir, true /*recurs*/)
+	tassert.CheckFatal(t, err)
+	ch := make(chan string, 16)
+	go w.Run(func(fqn string) { ch <- fqn })
+	defer w.Stop()
+
+	receive := func() string {
+		select {
+		case fqn := <-ch:
+			return fqn
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for file")
+		}
+		return ""
+	}
+
+	// written
+	fqn := filepath.Join(dir, "a")
+	tassert.CheckFatal(t, ioutil.WriteFile(fqn, []byte("a"), 0644))
+	tassert.Errorf(t, receive() == fqn, "expected %q", fqn)
+
+	// moved in
+	tmp := filepath.Join(os.TempDir(), filepath.Base(dir)+".b")
+	tassert.CheckFatal(t, ioutil.WriteFile(tmp, []byte("b"), 0644))
+	fqn = filepath.Join(dir, "b")
+	tassert.CheckFatal(t, os.Rename(tmp, fqn))
+	tassert.Errorf(t, receive() == fqn, "expected %q", fqn)
+
+	// new subdirectory (with content)
+	sub := filepath.Join(dir, "sub")
+	tassert.CheckFatal(t, os.Mkdir(sub, 0755))
+	fqn = filepath.Join(sub, "c")
+	tassert.CheckFatal(t, ioutil.WriteFile(fqn, []byte("c"), 0644))
+	got := receive()
+	tassert.Errorf(t, got == fqn, "expected %q, got %q", fqn, got)
+
+	select {
+	case fqn := <-ch:
+		// the file may get reported twice: by the subdirectory walk and by the watch
+		tassert.Errorf(t, fqn == got, "unexpected %q", fqn)
+	case <-time.After(100 * time.Millisecond):
+	}
+}
@@ -0,0 +1,178 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"golang.org/x/sys/unix"
+)
+
+// Walk filtering
+//
+// WalkFilter is a predicate that gets pushed down into the walk (see
+// Options.Filter) and the Scanner, so that the files that do not match never
+// make it to the callback. The checks go from the cheapest to the most
+// expensive: content types and names come with the directory entries, and only
+// the files that pass those get stat-ed - if and only if the size is constrained.
+// Content types are matched at the directory level: the walk does not descend
+// into the content type directories (see MakePathCT) that are not in the set.
+// Directories always make it to the callback.
+
+type (
+	WalkFilter struct {
+		CTs     []string // content types; empty - any
+		Exts    []string // file name extensions including the dot, e.g. ".tar"; empty - any
+		Pattern string   // filepath.Match pattern of the file name (base); empty - any
+		MinSize int64    // in bytes
+		MaxSize int64    // in bytes; zero - unlimited
+	}
+
+	// stats the files of the same directory relative to the directory's
+	// descriptor, which is opened once - see Walk and Scanner
+	dirStat struct {
+		mtx   sync.Mutex
+		dir   string
+		dirfd int
+	}
+	walkFilter struct {
+		opts *Options
+		ds   *dirStat // nil when parallel (see Options.Parallel)
+	}
+)
+
+func (f *WalkFilter) sized() bool { return f.MinSize > 0 || f.MaxSize > 0 }
+
+// returns false if the content type directory must be skipped
+func (f *WalkFilter) matchDir(fqn string) bool {
+	if len(f.CTs) == 0 {
+		return true
+	}
+	name := filepath.Base(fqn)
+	if len(name) != contentTypeLen+1 || name[0] != prefCT {
+		return true
+	}
+	if _, ok := CSM.RegisteredContentTypes[name[1:]]; !ok {
+		return true
+	}
+	// the content type directory is the bucket's child (as opposed to, e.g.,
+	// a virtual directory of an object that happens to have the same name)
+	grandparent := filepath.Base(filepath.Dir(filepath.Dir(fqn)))
+	if grandparent == "" || (grandparent[0] != prefProvider && grandparent[0] != prefNsName) {
+		return true
+	}
+	return cmn.StringInSlice(name[1:], f.CTs)
+}
+
+// content type (see matchDir) aside, matches the name only
+func (f *WalkFilter) matchName(name string) bool {
+	if len(f.Exts) > 0 {
+		var ok bool
+		for _, ext := range f.Exts {
+			if strings.HasSuffix(name, ext) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return false
+		}
+	}
+	if f.Pattern != "" {
+		if ok, _ := filepath.Match(f.Pattern, name); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (f *WalkFilter) matchSize(size int64) bool {
+	return size >= f.MinSize && (f.MaxSize == 0 || size <= f.MaxSize)
+}
+
+/////////////
+// dirStat //
+/////////////
+
+func newDirStat() *dirStat { return &dirStat{dirfd: -1} }
+
+func (ds *dirStat) size(fqn string) (int64, error) {
+	var (
+		st        unix.Stat_t
+		dir, name = filepath.Split(fqn)
+	)
+	ds.mtx.Lock()
+	defer ds.mtx.Unlock()
+	if dir != ds.dir {
+		ds.closeUnlocked()
+		fd, err := unix.Open(dir, unix.O_RDONLY|unix.O_DIRECTORY, 0)
+		if err != nil {
+			return 0, &os.PathError{Op: "open", Path: dir, Err: err}
+		}
+		ds.dir, ds.dirfd = dir, fd
+	}
+	if err := unix.Fstatat(ds.dirfd, name, &st, unix.AT_SYMLINK_NOFOLLOW); err != nil {
+		return 0, &os.PathError{Op: "lstat", Path: fqn, Err: err}
+	}
+	return st.Size, nil
+}
+
+func (ds *dirStat) close() {
+	ds.mtx.Lock()
+	ds.closeUnlocked()
+	ds.mtx.Unlock()
+}
+
+func (ds *dirStat) closeUnlocked() {
+	if ds.dirfd >= 0 {
+		unix.Close(ds.dirfd)
+	}
+	ds.dir, ds.dirfd = "", -1
+}
+
+////////////////
+// walkFilter //
+////////////////
+
+func (wf *walkFilter) callback(fqn string, de DirEntry) error {
+	f := wf.opts.Filter
+	if de.IsDir() {
+		if !f.matchDir(fqn) {
+			return filepath.SkipDir
+		}
+		return wf.opts.Callback(fqn, de)
+	}
+	if !f.matchName(filepath.Base(fqn)) {
+		return nil
+	}
+	if f.sized() {
+		var (
+			size int64
+			err  error
+		)
+		if wf.ds != nil {
+			size, err = wf.ds.size(fqn)
+		} else {
+			var finfo os.FileInfo
+			if finfo, err = os.Lstat(fqn); err == nil {
+				size = finfo.Size()
+			}
+		}
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if !f.matchSize(size) {
+			return nil
+		}
+	}
+	return wf.opts.Callback(fqn, de)
+}
@@ -21,11 +21,13 @@ const (
 
 const (
 	// prefixes for workfiles created by various services
-	WorkfileRemote  = "remote" // getting object from neighbor target while rebalance is running
-	WorkfileColdget = "cold"   // object GET: coldget
-	WorkfilePut     = "put"    // object PUT
-	WorkfileAppend  = "append" // object APPEND
-	WorkfileFSHC    = "fshc"   // FSHC test file
+	WorkfileRemote  = "remote"     // getting object from neighbor target while rebalance is running
+	WorkfileColdget = "cold"       // object GET: coldget
+	WorkfilePut     = "put"        // object PUT
+	WorkfileAppend  = "append"     // object APPEND
+	WorkfileWriteAt = "writeat"    // object byte-range write
+	WorkfileSpill   = "list-spill" // list-objects results that the client has not fetched yet (see query)
+	WorkfileFSHC    = "fshc"       // FSHC test file
 )
 
 type ParsedFQN struct {
@@ -34,6 +36,7 @@ type ParsedFQN struct {
 	Bck         cmn.Bck
 	ObjName     string
 	Digest      uint64
+	Routed      bool // content type is routed to labeled mountpaths (see routing.go)
 }
 
 // ParseFQN splits a provided FQN (created by `MakePathFQN`) or reports
@@ -105,6 +108,7 @@ func (mfs *MountedFS) ParseFQN(fqn string) (parsed ParsedFQN, err error) {
 				return
 			}
 			parsed.ContentType = item
+			parsed.Routed = mfs.route(item) != ""
 
 			// Object name
 			objName := rel[i+1:]
@@ -6,9 +6,11 @@ package fs_test
 
 import (
 	"os"
+	"strconv"
 	"strings"
 	"testing"
 
+	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/ios"
@@ -346,3 +348,68 @@ func BenchmarkParseFQN(b *testing.B) {
 		parsedFQN, _ = mfs.ParseFQN(fqn)
 	}
 }
+
+func TestContentRouting(t *testing.T) {
+	const routedType = "rt"
+	var (
+		mpathA = "/tmp/routing-a"
+		mpathB = "/tmp/routing-b"
+		mfs    = fs.NewMountedFS(ios.NewIOStaterMock())
+		bck    = cmn.Bck{Name: "bucket", Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+	)
+	mfs.DisableFsIDCheck()
+	mfs.SetLabels(map[string]string{mpathB: "scratch"})
+	for _, mpath := range []string{mpathA, mpathB} {
+		cmn.CreateDir(mpath)
+		defer os.RemoveAll(mpath)
+		if err := mfs.Add(mpath); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fs.Mountpaths = mfs
+	fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	fs.CSM.RegisterContentType(routedType, &fs.ObjectContentResolver{})
+	if err := mfs.SetContentRouting(map[string]string{fs.ObjectType: "scratch"}); err == nil {
+		t.Fatal("expected error routing objects")
+	}
+	if err := mfs.SetContentRouting(map[string]string{routedType: "scratch"}); err != nil {
+		t.Fatal(err)
+	}
+	defer mfs.SetContentRouting(nil)
+
+	mpaths, _ := mfs.Get()
+	for i := 0; i < 64; i++ {
+		objName := "obj-" + strconv.Itoa(i)
+		for _, mpath := range []string{mpathA, mpathB} {
+			objFQN := mpaths[mpath].MakePathFQN(bck, fs.ObjectType, objName)
+			parsed, err := mfs.ParseFQN(objFQN)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed.Routed {
+				t.Errorf("%s: objects are not routed", objFQN)
+			}
+			// routed content always resides on the labeled mountpath
+			routedFQN := fs.CSM.GenContentParsedFQN(parsed, routedType, "")
+			if want := mpaths[mpathB].MakePathFQN(bck, routedType, objName); routedFQN != want {
+				t.Errorf("routed FQN = %s, want %s", routedFQN, want)
+			}
+			parsedRouted, err := mfs.ParseFQN(routedFQN)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !parsedRouted.Routed {
+				t.Errorf("%s: expected routed content", routedFQN)
+			}
+			// and resolves back to the object's HRW mountpath
+			hrwMpath, _, err := cluster.HrwMpath(cluster.NewBckEmbed(bck).MakeUname(objName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			gotFQN := fs.CSM.GenContentParsedFQN(parsedRouted, fs.ObjectType, "")
+			if want := hrwMpath.MakePathFQN(bck, fs.ObjectType, objName); gotFQN != want {
+				t.Errorf("object FQN = %s, want %s", gotFQN, want)
+			}
+		}
+	}
+}
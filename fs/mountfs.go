@@ -76,6 +76,7 @@ type (
 		Fsid       syscall.Fsid
 		FileSystem string
 		PathDigest uint64
+		Label      string // e.g., "nvme", "hdd", "scratch" (see cmn.FSPathsConf)
 
 		// LOM caches
 		lomCaches cmn.MultiSyncMap
@@ -100,6 +101,10 @@ type (
 		xattrMpath atomic.Pointer
 		// Iostats for the available mountpaths
 		ios ios.IOStater
+		// Configured mountpath labels (mountpath => label)
+		labels map[string]string
+		// Content routing rules (content type => label), see routing.go
+		routes atomic.Pointer
 	}
 	ChangeReq struct {
 		Action string // MountPath action enum (above)
@@ -188,7 +193,19 @@ func (mi *MountpathInfo) IsIdle(config *cmn.Config, timestamp time.Time) bool {
 	return curr >= 0 && curr < config.Disk.DiskUtilLowWM
 }
 
+// WalkWorkers returns the max number of goroutines to walk the mountpath's
+// directory tree, depending on its label (see cmn.DiskConf.WalkWorkers)
+func (mi *MountpathInfo) WalkWorkers(config *cmn.Config) int {
+	if n, ok := config.Disk.WalkWorkers[mi.Label]; ok && mi.Label != "" {
+		return n
+	}
+	return config.Disk.WalkWorkers[cmn.WalkWorkersDefault]
+}
+
 func (mi *MountpathInfo) String() string {
+	if mi.Label != "" {
+		return fmt.Sprintf("mp[%s, fs=%s, label=%s]", mi.Path, mi.FileSystem, mi.Label)
+	}
 	return fmt.Sprintf("mp[%s, fs=%s]", mi.Path, mi.FileSystem)
 }
 
@@ -335,6 +352,9 @@ func (mfs *MountedFS) LogAppend(lines []string) []string {
 func (mfs *MountedFS) GetSelectedDiskStats() (m map[string]*ios.SelectedDiskStats) {
 	return mfs.ios.GetSelectedDiskStats()
 }
+func (mfs *MountedFS) GetMpathHealth(mpath string) ios.MpathHealth {
+	return mfs.ios.GetMpathHealth(mpath)
+}
 
 // Init prepares and adds provided mountpaths. Also validates the mountpaths
 // for duplication and availability.
@@ -353,6 +373,13 @@ func (mfs *MountedFS) Init(fsPaths []string) error {
 	return nil
 }
 
+// SetLabels sets the labels of the mountpaths that will be added (see cmn.FSPathsConf).
+func (mfs *MountedFS) SetLabels(labels map[string]string) {
+	mfs.mu.Lock()
+	mfs.labels = labels
+	mfs.mu.Unlock()
+}
+
 // Add adds new mountpath to the target's mountpaths.
 // FIXME: unify error messages for original and clean mountpath
 func (mfs *MountedFS) Add(mpath string) error {
@@ -389,6 +416,7 @@ func (mfs *MountedFS) Add(mpath string) error {
 
 	mfs.ios.AddMpath(mp.Path, mp.FileSystem)
 
+	mp.Label = mfs.labels[mp.Path]
 	availablePaths[mp.Path] = mp
 	mfs.fsIDs[mp.Fsid] = cleanMpath
 	mfs.updatePaths(availablePaths, disabledPaths)
@@ -636,6 +664,61 @@ func (mfs *MountedFS) RenameBucketDirs(bckFrom, bckTo cmn.Bck) (err error) {
 	return
 }
 
+// SwapBucketDirs exchanges the (entire) content of the two buckets on each
+// available mountpath; the swap is rolled back on error.
+func (mfs *MountedFS) SwapBucketDirs(bckA, bckB cmn.Bck) (err error) {
+	availablePaths, _ := mfs.Get()
+	swapped := make([]*MountpathInfo, 0, len(availablePaths))
+	for _, mpathInfo := range availablePaths {
+		if err = mpathInfo.swapBucketDirs(bckA, bckB); err != nil {
+			break
+		}
+		swapped = append(swapped, mpathInfo)
+	}
+
+	if err == nil {
+		return
+	}
+	for _, mpathInfo := range swapped {
+		if erd := mpathInfo.swapBucketDirs(bckA, bckB); erd != nil {
+			glog.Error(erd)
+		}
+	}
+	return
+}
+
+func (mi *MountpathInfo) swapBucketDirs(bckA, bckB cmn.Bck) (err error) {
+	var (
+		pathA    = mi.MakePathBck(bckA)
+		pathB    = mi.MakePathBck(bckB)
+		trashDir = mi.MakePathTrash()
+		tmpDir   = filepath.Join(trashDir, fmt.Sprintf("$swap-%d", mono.NanoTime()))
+	)
+	if err = cmn.CreateDir(trashDir); err != nil {
+		return
+	}
+	if err = os.Rename(pathA, tmpDir); err != nil {
+		return
+	}
+	if err = os.Rename(pathB, pathA); err != nil {
+		if erd := os.Rename(tmpDir, pathA); erd != nil {
+			glog.Error(erd)
+		}
+		return
+	}
+	if err = os.Rename(tmpDir, pathB); err != nil {
+		if erd := os.Rename(pathA, pathB); erd != nil {
+			glog.Error(erd)
+		} else if erd := os.Rename(tmpDir, pathA); erd != nil {
+			glog.Error(erd)
+		}
+		return
+	}
+	// cached metadata refers to the objects that are no longer there
+	mi.evictLomCache()
+	return
+}
+
 func (mi *MountpathInfo) CreateMissingBckDirs(bck cmn.Bck) (err error) {
 	for contentType := range CSM.RegisteredContentTypes {
 		dir := mi.MakePathCT(bck, contentType)
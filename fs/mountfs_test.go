@@ -7,6 +7,7 @@ package fs_test
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/NVIDIA/aistore/cmn"
@@ -346,6 +347,41 @@ func TestMoveToTrash(t *testing.T) {
 	}
 }
 
+func TestSwapBucketDirs(t *testing.T) {
+	var (
+		mfs  = fs.NewMountedFS()
+		bckA = cmn.Bck{Name: "a", Provider: cmn.ProviderAIS}
+		bckB = cmn.Bck{Name: "b", Provider: cmn.ProviderAIS}
+	)
+	mpathDir, err := ioutil.TempDir("", "")
+	tassert.CheckFatal(t, err)
+	err = mfs.Add(mpathDir)
+	tassert.CheckFatal(t, err)
+
+	defer os.RemoveAll(mpathDir)
+
+	mpaths, _ := mfs.Get()
+	for _, mi := range mpaths {
+		for _, bck := range []cmn.Bck{bckA, bckB} {
+			fqn := mi.MakePathFQN(bck, fs.ObjectType, "obj")
+			tassert.CheckFatal(t, cmn.CreateDir(filepath.Dir(fqn)))
+			tassert.CheckFatal(t, ioutil.WriteFile(fqn, []byte(bck.Name), 0644))
+		}
+	}
+
+	err = mfs.SwapBucketDirs(bckA, bckB)
+	tassert.CheckFatal(t, err)
+
+	for _, mi := range mpaths {
+		for bck, content := range map[string]string{"a": "b", "b": "a"} {
+			fqn := mi.MakePathFQN(cmn.Bck{Name: bck, Provider: cmn.ProviderAIS}, fs.ObjectType, "obj")
+			b, err := ioutil.ReadFile(fqn)
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, string(b) == content, "%s: expected %q, got %q", fqn, content, b)
+		}
+	}
+}
+
 func BenchmarkMakePathFQN(b *testing.B) {
 	var (
 		bck = cmn.Bck{
@@ -0,0 +1,293 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+// Bucket quotas
+//
+// The quota (see cmn.QuotaConf) limits the total size and/or the number of the
+// bucket's objects cluster-wide. Given that the objects are distributed across
+// the targets uniformly (HRW), each target enforces its equal share of the
+// limit. The writer reserves the space before it starts writing (see Reserve)
+// and, once the object is stored, accounts for the actual size and releases
+// the reservation - so that concurrent writes can't jointly exceed the quota.
+//
+// The target tracks the bucket's usage per mountpath: first computed by walking
+// the bucket in the background (the quota is not enforced until the walk
+// completes), then updated by the writes and deletions as they happen, and
+// recomputed every quotaRefreshTime to account for everything else (rebalance,
+// eviction, EC, etc.). The updates that take place while the bucket is being
+// walked are added to the walk's result. The buckets that have not been
+// checked for quotaIdleTime are no longer tracked.
+
+const (
+	quotaRefreshTime = 10 * time.Minute
+	quotaIdleTime    = time.Hour
+)
+
+type (
+	QuotaManager struct {
+		mtx  sync.Mutex
+		bcks map[cmn.Bck]*bckUsage
+	}
+	// QuotaReservation is the space reserved by a write in progress; nil when
+	// the bucket has no quota
+	QuotaReservation struct {
+		bu         *bckUsage
+		size, objs int64
+	}
+	bckUsage struct {
+		bck        cmn.Bck
+		mtx        sync.Mutex // reservations
+		size, objs int64      // reserved
+		mpaths     sync.Map   // mountpath => *mpathUsage
+		checked    atomic.Int64
+		once       sync.Once
+		ready      atomic.Bool // computed (see refresh)
+		refreshing atomic.Bool
+	}
+	mpathUsage struct {
+		mtx        sync.Mutex
+		size, objs int64
+		// updates that took place while the mountpath was being walked
+		walking      bool
+		dsize, dobjs int64
+	}
+)
+
+var Quota = NewQuotaManager()
+
+func NewQuotaManager() *QuotaManager {
+	return &QuotaManager{bcks: make(map[cmn.Bck]*bckUsage)}
+}
+
+// Reserve returns error if storing `size` more bytes in `objs` more objects
+// (zero when overwriting) would exceed the target's share of the bucket's
+// quota. Otherwise, it reserves as much - until the object gets stored (and
+// accounted for, see Add) or the write fails, whichever comes first, the caller
+// must release the reservation.
+func (q *QuotaManager) Reserve(props *cmn.BucketProps, bck cmn.Bck, targetCnt int,
+	size, objs int64) (*QuotaReservation, error) {
+	conf := &props.Quota
+	if !conf.Enabled() {
+		return nil, nil
+	}
+	bu := q.get(bck)
+	bu.checked.Store(mono.NanoTime())
+	bu.once.Do(func() { go bu.refresh(props) })
+
+	bu.mtx.Lock()
+	defer bu.mtx.Unlock()
+	if bu.ready.Load() {
+		usedSize, usedObjs := bu.usage()
+		usedSize += bu.size
+		usedObjs += bu.objs
+		if conf.MaxBytes > 0 && size > 0 {
+			if share := cmn.DivCeil(conf.MaxBytes, int64(targetCnt)); usedSize+size > share {
+				return nil, cmn.NewErrorQuotaExceeded(bck, "size", usedSize+size, share)
+			}
+		}
+		if conf.MaxObjects > 0 && objs > 0 {
+			if share := cmn.DivCeil(conf.MaxObjects, int64(targetCnt)); usedObjs+objs > share {
+				return nil, cmn.NewErrorQuotaExceeded(bck, "objects", usedObjs+objs, share)
+			}
+		}
+	}
+	bu.size += size
+	bu.objs += objs
+	return &QuotaReservation{bu: bu, size: size, objs: objs}, nil
+}
+
+// Add accounts for a new (positive) or removed (negative) object; no-op if the bucket is not tracked
+func (q *QuotaManager) Add(bck cmn.Bck, mpath string, size, objs int64) {
+	q.mtx.Lock()
+	bu, ok := q.bcks[bck]
+	q.mtx.Unlock()
+	if !ok {
+		return
+	}
+	bu.mpath(mpath).add(size, objs)
+}
+
+// Usage returns the bucket's tracked usage on this target: (size, objects, tracked)
+func (q *QuotaManager) Usage(bck cmn.Bck) (size, objs int64, ok bool) {
+	q.mtx.Lock()
+	bu, ok := q.bcks[bck]
+	q.mtx.Unlock()
+	if !ok {
+		return
+	}
+	size, objs = bu.usage()
+	return
+}
+
+// Forget stops tracking the bucket, e.g. when the bucket is destroyed
+func (q *QuotaManager) Forget(bck cmn.Bck) {
+	q.mtx.Lock()
+	delete(q.bcks, bck)
+	q.mtx.Unlock()
+}
+
+// Housekeep recomputes the usage of the tracked buckets (see above)
+func (q *QuotaManager) Housekeep(getProps func(bck cmn.Bck) *cmn.BucketProps) time.Duration {
+	var (
+		now  = mono.NanoTime()
+		bcks = make([]*bckUsage, 0, 8)
+	)
+	q.mtx.Lock()
+	for bck, bu := range q.bcks {
+		if time.Duration(now-bu.checked.Load()) > quotaIdleTime {
+			delete(q.bcks, bck)
+			continue
+		}
+		bcks = append(bcks, bu)
+	}
+	q.mtx.Unlock()
+	for _, bu := range bcks {
+		props := getProps(bu.bck)
+		if props == nil || !props.Quota.Enabled() {
+			q.Forget(bu.bck)
+			continue
+		}
+		go bu.refresh(props)
+	}
+	return quotaRefreshTime
+}
+
+func (q *QuotaManager) get(bck cmn.Bck) *bckUsage {
+	q.mtx.Lock()
+	bu, ok := q.bcks[bck]
+	if !ok {
+		bu = &bckUsage{bck: bck}
+		q.bcks[bck] = bu
+	}
+	q.mtx.Unlock()
+	return bu
+}
+
+//////////////////////
+// QuotaReservation //
+//////////////////////
+
+// Release releases the reservation (no-op if nil or released already)
+func (r *QuotaReservation) Release() {
+	if r == nil || r.bu == nil {
+		return
+	}
+	bu := r.bu
+	bu.mtx.Lock()
+	bu.size -= r.size
+	bu.objs -= r.objs
+	bu.mtx.Unlock()
+	r.bu = nil
+}
+
+//////////////
+// bckUsage //
+//////////////
+
+func (bu *bckUsage) mpath(mpath string) *mpathUsage {
+	v, ok := bu.mpaths.Load(mpath)
+	if !ok {
+		v, _ = bu.mpaths.LoadOrStore(mpath, &mpathUsage{})
+	}
+	return v.(*mpathUsage)
+}
+
+func (bu *bckUsage) usage() (size, objs int64) {
+	bu.mpaths.Range(func(_, v interface{}) bool {
+		mu := v.(*mpathUsage)
+		mu.mtx.Lock()
+		size += mu.size
+		objs += mu.objs
+		mu.mtx.Unlock()
+		return true
+	})
+	return
+}
+
+// walks the bucket on all mountpaths; local mirror copies are not counted
+func (bu *bckUsage) refresh(props *cmn.BucketProps) {
+	if !bu.refreshing.CAS(false, true) {
+		return
+	}
+	defer bu.refreshing.Store(false)
+	copies := int64(1)
+	if props.Mirror.Enabled && props.Mirror.Copies > 1 {
+		copies = props.Mirror.Copies
+	}
+	availablePaths, _ := Mountpaths.Get()
+	for mpath, mpathInfo := range availablePaths {
+		var (
+			size, objs int64
+			mu         = bu.mpath(mpath)
+		)
+		opts := &Options{
+			Mpath: mpathInfo,
+			Bck:   bu.bck,
+			CTs:   []string{ObjectType},
+			Callback: func(fqn string, de DirEntry) error {
+				if de.IsDir() {
+					return nil
+				}
+				if finfo, err := os.Stat(fqn); err == nil {
+					size += finfo.Size()
+					objs++
+				}
+				return nil
+			},
+			Throttle: true,
+		}
+		mu.startWalk()
+		err := Walk(opts)
+		if err != nil {
+			glog.Errorf("%s[%s]: failed to compute quota usage: %v", bu.bck, mpath, err)
+		}
+		mu.endWalk(size/copies, objs/copies, err == nil)
+	}
+	bu.ready.Store(true)
+}
+
+////////////////
+// mpathUsage //
+////////////////
+
+func (mu *mpathUsage) add(size, objs int64) {
+	mu.mtx.Lock()
+	mu.size += size
+	mu.objs += objs
+	if mu.walking {
+		mu.dsize += size
+		mu.dobjs += objs
+	}
+	mu.mtx.Unlock()
+}
+
+func (mu *mpathUsage) startWalk() {
+	mu.mtx.Lock()
+	mu.walking, mu.dsize, mu.dobjs = true, 0, 0
+	mu.mtx.Unlock()
+}
+
+// sets the usage to the walk's result (unless failed) plus the updates that
+// took place in the meantime
+func (mu *mpathUsage) endWalk(size, objs int64, ok bool) {
+	mu.mtx.Lock()
+	if ok {
+		mu.size, mu.objs = size+mu.dsize, objs+mu.dobjs
+	}
+	mu.walking = false
+	mu.mtx.Unlock()
+}
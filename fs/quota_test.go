@@ -0,0 +1,144 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/ios"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+func quotaSetup(t *testing.T, objs int) (mpath string, bck cmn.Bck) {
+	Mountpaths = NewMountedFS(ios.NewIOStaterMock())
+	Mountpaths.DisableFsIDCheck()
+	_ = CSM.RegisterContentType(ObjectType, &ObjectContentResolver{})
+	mpath, err := ioutil.TempDir("", "quota")
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, Mountpaths.Add(mpath))
+	bck = cmn.Bck{Name: "quota", Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+	avail, _ := Mountpaths.Get()
+	for i := 0; i < objs; i++ {
+		fqn := avail[mpath].MakePathFQN(bck, ObjectType, fmt.Sprintf("obj-%d", i))
+		tassert.CheckFatal(t, cmn.CreateDir(filepath.Dir(fqn)))
+		tassert.CheckFatal(t, ioutil.WriteFile(fqn, make([]byte, 10), 0644))
+	}
+	return
+}
+
+func quotaWaitReady(t *testing.T, bu *bckUsage) {
+	for i := 0; !bu.ready.Load(); i++ {
+		if i > 1000 {
+			t.Fatal("usage is not computed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestQuotaReserve(t *testing.T) {
+	mpath, bck := quotaSetup(t, 3)
+	defer os.RemoveAll(mpath)
+	var (
+		q     = NewQuotaManager()
+		props = &cmn.BucketProps{Quota: cmn.QuotaConf{MaxObjects: 5, MaxBytes: 100}}
+	)
+	// not enforced until the usage gets computed
+	r, err := q.Reserve(props, bck, 1, 1000, 1)
+	tassert.CheckFatal(t, err)
+	r.Release()
+	quotaWaitReady(t, q.get(bck))
+	size, objs, _ := q.Usage(bck)
+	tassert.Fatalf(t, size == 30 && objs == 3, "expected (30, 3), got (%d, %d)", size, objs)
+
+	// concurrent writes can't jointly exceed the quota
+	r1, err := q.Reserve(props, bck, 1, 10, 1)
+	tassert.CheckFatal(t, err)
+	r2, err := q.Reserve(props, bck, 1, 10, 1)
+	tassert.CheckFatal(t, err)
+	_, err = q.Reserve(props, bck, 1, 10, 1)
+	_, ok := err.(*cmn.ErrorQuotaExceeded)
+	tassert.Fatalf(t, ok, "expected quota exceeded, got %v", err)
+
+	// failed write releases the reservation...
+	r1.Release()
+	r1.Release() // no-op
+	r3, err := q.Reserve(props, bck, 1, 10, 1)
+	tassert.CheckFatal(t, err)
+
+	// ...and the successful one - accounts for the object
+	q.Add(bck, mpath, 10, 1)
+	r2.Release()
+	_, err = q.Reserve(props, bck, 1, 10, 1)
+	_, ok = err.(*cmn.ErrorQuotaExceeded)
+	tassert.Fatalf(t, ok, "expected quota exceeded, got %v", err)
+	r3.Release()
+
+	// overwrite (no new objects) that fits in size
+	r, err = q.Reserve(props, bck, 1, 50, 0)
+	tassert.CheckFatal(t, err)
+	r.Release()
+
+	// the quota is shared by the targets
+	_, err = q.Reserve(props, bck, 2, 30, 0)
+	_, ok = err.(*cmn.ErrorQuotaExceeded)
+	tassert.Errorf(t, ok, "expected quota exceeded, got %v", err)
+
+	// disabled
+	r, err = q.Reserve(&cmn.BucketProps{}, bck, 1, 1000, 1000)
+	tassert.Errorf(t, r == nil && err == nil, "expected no reservation, got %v, %v", r, err)
+}
+
+// the updates that take place while the bucket is being walked are not lost
+func TestQuotaRefreshKeepsUpdates(t *testing.T) {
+	mu := &mpathUsage{}
+	mu.add(100, 10) // before the walk - included in its result
+	mu.startWalk()
+	mu.add(7, 1)
+	mu.add(-3, -1)
+	mu.endWalk(100, 10, true)
+	tassert.Errorf(t, mu.size == 104 && mu.objs == 10, "expected (104, 10), got (%d, %d)", mu.size, mu.objs)
+
+	// failed walk keeps the usage as is
+	mu.startWalk()
+	mu.add(6, 1)
+	mu.endWalk(0, 0, false)
+	tassert.Errorf(t, mu.size == 110 && mu.objs == 11, "expected (110, 11), got (%d, %d)", mu.size, mu.objs)
+
+	// not walking
+	mu.add(1, 1)
+	tassert.Errorf(t, mu.dsize == 6 && mu.dobjs == 1, "unexpected deltas (%d, %d)", mu.dsize, mu.dobjs)
+}
+
+func TestQuotaHousekeep(t *testing.T) {
+	mpath, bck := quotaSetup(t, 2)
+	defer os.RemoveAll(mpath)
+	var (
+		q     = NewQuotaManager()
+		props = &cmn.BucketProps{Quota: cmn.QuotaConf{MaxObjects: 5}}
+	)
+	r, err := q.Reserve(props, bck, 1, 0, 1)
+	tassert.CheckFatal(t, err)
+	r.Release()
+	bu := q.get(bck)
+	quotaWaitReady(t, bu)
+
+	// refreshed in the background
+	bu.ready.Store(false)
+	q.Housekeep(func(cmn.Bck) *cmn.BucketProps { return props })
+	quotaWaitReady(t, bu)
+	_, objs, ok := q.Usage(bck)
+	tassert.Errorf(t, ok && objs == 2, "expected 2 objects, got %d (tracked: %t)", objs, ok)
+
+	// no longer tracked once the quota is removed
+	q.Housekeep(func(cmn.Bck) *cmn.BucketProps { return &cmn.BucketProps{} })
+	_, _, ok = q.Usage(bck)
+	tassert.Errorf(t, !ok, "expected the bucket to be forgotten")
+}
@@ -0,0 +1,98 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"fmt"
+	"path/filepath"
+	"unsafe"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/xoshiro256"
+	"github.com/OneOfOne/xxhash"
+)
+
+// Content routing
+//
+// By default, all content that belongs to an object (the object itself, its
+// EC slice and metafile, workfiles, etc.) resides on the same mountpath - the
+// one selected by HRW for the object's name. Mountpaths can be labeled (e.g.,
+// "nvme", "hdd", "scratch" - see cmn.FSPathsConf), and a content type can be
+// routed to the mountpaths with a given label (see cmn.ContentRoutingConf):
+// e.g., workfiles to scratch drives, and EC metafiles to NVMe. Out of the
+// labeled mountpaths, the routed content goes to the one selected by HRW.
+// If none of the labeled mountpaths is available, the content stays where it
+// would be without routing. Objects themselves cannot be routed.
+//
+// The routing is enforced by ContentSpecMgr when generating FQNs. ParseFQN
+// marks the FQNs of the routed content types (see ParsedFQN.Routed) so that
+// an FQN of another (not routed) content type generated from a parsed one
+// resolves to the object's own mountpath.
+
+// SetContentRouting sets the content routing rules (content type => label).
+func (mfs *MountedFS) SetContentRouting(rules map[string]string) error {
+	routes := make(map[string]string, len(rules))
+	for contentType, label := range rules {
+		if contentType == ObjectType {
+			return fmt.Errorf("content type %q (objects) cannot be routed", contentType)
+		}
+		routes[contentType] = label
+	}
+	mfs.routes.Store(unsafe.Pointer(&routes))
+	return nil
+}
+
+// returns the label of the mountpaths that a given content type is routed to (if any)
+func (mfs *MountedFS) route(contentType string) (label string) {
+	if mfs == nil {
+		return
+	}
+	routes := (*map[string]string)(mfs.routes.Load())
+	if routes == nil {
+		return
+	}
+	return (*routes)[contentType]
+}
+
+// selects (HRW) one of the available mountpaths with a given label, or one
+// of all available mountpaths if the label is empty - the latter being the
+// same as cluster.HrwMpath
+// same as cluster.Bck.MakeUname (cluster.HrwMpath and hrwMpath must agree)
+func makeUname(bck cmn.Bck, objName string) string {
+	sep := string(filepath.Separator)
+	return bck.Provider + sep + bck.Ns.Uname() + sep + bck.Name + sep + objName
+}
+
+func (mfs *MountedFS) hrwMpath(uname, label string) (mi *MountpathInfo) {
+	var (
+		max               uint64
+		availablePaths, _ = mfs.Get()
+		digest            = xxhash.ChecksumString64S(uname, cmn.MLCG32)
+	)
+	for _, mpathInfo := range availablePaths {
+		if label != "" && mpathInfo.Label != label {
+			continue
+		}
+		cs := xoshiro256.Hash(mpathInfo.PathDigest ^ digest)
+		if cs >= max {
+			max = cs
+			mi = mpathInfo
+		}
+	}
+	return
+}
+
+// returns the mountpath to store a given content on: `mi` unless the content
+// type is routed
+func (mfs *MountedFS) routeMpath(mi *MountpathInfo, bck cmn.Bck, contentType, objName string) *MountpathInfo {
+	label := mfs.route(contentType)
+	if label == "" {
+		return mi
+	}
+	if routed := mfs.hrwMpath(makeUname(bck, objName), label); routed != nil {
+		return routed
+	}
+	return mi
+}
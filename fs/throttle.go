@@ -0,0 +1,60 @@
+// Package fs provides mountpath and FQN abstractions and methods to resolve/map stored content
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Walk throttling
+//
+// Background walks (see Options.Throttle) yield to the client-facing IO: every
+// throttleEvery entries, the walk checks the utilization of its mountpath's
+// disks and sleeps (see cmn.DiskConf): briefly when the utilization is between
+// the low and the high watermarks, longer - between the high and the max. Above
+// the max watermark the walk pauses, re-checking every cmn.ThrottleSleepMax,
+// for as long as the utilization stays there but no longer than throttlePauseMax
+// at a time - so that the walk does not starve.
+
+const (
+	throttleEvery    = 64
+	throttlePauseMax = 10 * time.Second
+)
+
+type walkThrottle struct {
+	opts *Options
+	cnt  atomic.Int64 // (walk may be parallel)
+}
+
+func (wt *walkThrottle) callback(fqn string, de DirEntry) error {
+	if wt.cnt.Inc()%throttleEvery == 0 {
+		wt.throttle()
+	}
+	return wt.opts.Callback(fqn, de)
+}
+
+func (wt *walkThrottle) throttle() {
+	var (
+		config = cmn.GCO.Get()
+		mpath  = wt.opts.Mpath.Path
+		now    = time.Now()
+		util   = Mountpaths.GetMpathUtil(mpath, now)
+	)
+	switch {
+	case util < config.Disk.DiskUtilLowWM: // including unknown (negative)
+	case util < config.Disk.DiskUtilHighWM:
+		time.Sleep(cmn.ThrottleSleepMin)
+	case util < config.Disk.DiskUtilMaxWM:
+		time.Sleep(cmn.ThrottleSleepAvg)
+	default:
+		for util >= config.Disk.DiskUtilMaxWM && time.Since(now) < throttlePauseMax {
+			time.Sleep(cmn.ThrottleSleepMax)
+			util = Mountpaths.GetMpathUtil(mpath, time.Now())
+		}
+	}
+}
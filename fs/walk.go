@@ -11,6 +11,8 @@ import (
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
+	"sync"
 
 	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -49,12 +51,38 @@ type (
 		ErrCallback errFunc
 		Callback    WalkFunc
 		Sorted      bool
+
+		// Walk the sub-directories concurrently, with up to Mpath.WalkWorkers()
+		// goroutines. The callback must be thread-safe. Not supported when Sorted.
+		Parallel bool
+
+		// Checkpointing (Sorted only): the walk skips everything up to and
+		// including ResumeAfter - the token previously passed to Checkpoint,
+		// which gets called with the FQN of the last visited file every
+		// CkptEvery files.
+		ResumeAfter string
+		CkptEvery   int
+		Checkpoint  func(token string)
+
+		// Background walk that slows down and pauses when the mountpath's
+		// disks are busy with the client-facing IO (see walkThrottle)
+		Throttle bool
+
+		// Only the files that match the filter get to the callback (see WalkFilter)
+		Filter *WalkFilter
 	}
 
 	WalkBckOptions struct {
 		Options
 		ValidateCallback WalkFunc // should return filepath.SkipDir to skip directory without an error
+
+		// Checkpointing: same as Options.ResumeAfter and Options.Checkpoint,
+		// with a separate token per mountpath (see WalkBckToken)
+		Resume        WalkBckToken
+		CheckpointBck func(token WalkBckToken)
 	}
+	// mountpath => the last visited FQN (see Options.ResumeAfter)
+	WalkBckToken map[string]string
 
 	errCallbackWrapper struct {
 		counter atomic.Int64
@@ -67,6 +95,29 @@ type (
 		dirEntry DirEntry
 	}
 	objInfos []objInfo
+
+	// see Options.ResumeAfter and Options.Checkpoint
+	ckptWalk struct {
+		opts *Options
+		cnt  int
+	}
+	// see WalkBckOptions.CheckpointBck
+	walkBckCkpt struct {
+		opts   *WalkBckOptions
+		mpaths []string // by mpathIdx
+		last   WalkBckToken
+		cnt    int
+	}
+
+	// bounded-concurrency walk of a single directory tree (see Options.Parallel)
+	pwalk struct {
+		opts    *Options
+		workers chan struct{}
+		wg      sync.WaitGroup
+		mtx     sync.Mutex
+		err     error
+		halted  atomic.Bool
+	}
 )
 
 // PathErrToAction is a default error callback for fast godirwalk.Walk.
@@ -112,6 +163,64 @@ func (opts *Options) callback(fqn string, de *godirwalk.Dirent) error {
 	return opts.Callback(fqn, de)
 }
 
+func (opts *Options) checkpointed() bool { return opts.ResumeAfter != "" || opts.Checkpoint != nil }
+
+// CmpWalkOrder compares two paths (FQNs or object names) in the order of the
+// sorted walk: component by component
+func CmpWalkOrder(a, b string) int {
+	for {
+		ia, ib := strings.IndexByte(a, '/'), strings.IndexByte(b, '/')
+		ca, cb := a, b
+		if ia >= 0 {
+			ca = a[:ia]
+		}
+		if ib >= 0 {
+			cb = b[:ib]
+		}
+		if ca != cb {
+			if ca < cb {
+				return -1
+			}
+			return 1
+		}
+		switch {
+		case ia < 0 && ib < 0:
+			return 0
+		case ia < 0:
+			return -1
+		case ib < 0:
+			return 1
+		}
+		a, b = a[ia+1:], b[ib+1:]
+	}
+}
+
+//////////////
+// ckptWalk //
+//////////////
+
+func (cw *ckptWalk) callback(fqn string, de DirEntry) error {
+	opts := cw.opts
+	if opts.ResumeAfter != "" {
+		if c := CmpWalkOrder(fqn, opts.ResumeAfter); c <= 0 {
+			// skip the directories visited in their entirety
+			if de.IsDir() && c < 0 && !strings.HasPrefix(opts.ResumeAfter, fqn+"/") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+	}
+	if err := opts.Callback(fqn, de); err != nil {
+		return err
+	}
+	if opts.Checkpoint != nil && !de.IsDir() {
+		if cw.cnt++; cw.cnt%opts.CkptEvery == 0 {
+			opts.Checkpoint(fqn)
+		}
+	}
+	return nil
+}
+
 func (h objInfos) Len() int           { return len(h) }
 func (h objInfos) Less(i, j int) bool { return h[i].objName < h[j].objName }
 func (h objInfos) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
@@ -189,15 +298,49 @@ func Walk(opts *Options) error {
 		}
 	}
 
+	if opts.checkpointed() {
+		cmn.Assert(opts.Sorted && (opts.Checkpoint == nil || opts.CkptEvery > 0))
+		sort.Slice(fqns, func(i, j int) bool { return CmpWalkOrder(fqns[i], fqns[j]) < 0 })
+		o := *opts
+		o.Callback = (&ckptWalk{opts: opts}).callback
+		opts = &o
+	}
+	if opts.Filter != nil {
+		wf := &walkFilter{opts: opts}
+		if opts.Filter.sized() && !opts.Parallel {
+			wf.ds = newDirStat()
+			defer wf.ds.close()
+		}
+		o := *opts
+		o.Callback = wf.callback
+		opts = &o
+	}
+	if opts.Throttle && opts.Mpath != nil {
+		o := *opts
+		o.Callback = (&walkThrottle{opts: opts}).callback
+		opts = &o
+	}
+
 	gOpts := &godirwalk.Options{
 		ErrorCallback: opts.ErrCallback,
 		Callback:      opts.callback,
 		Unsorted:      !opts.Sorted,
 	}
+	var workers int
+	if opts.Parallel {
+		cmn.Assert(!opts.Sorted && opts.Mpath != nil)
+		workers = opts.Mpath.WalkWorkers(cmn.GCO.Get())
+	}
 
 	var err error
 	for _, fqn := range fqns {
-		if err1 := godirwalk.Walk(fqn, gOpts); err1 != nil && !os.IsNotExist(err1) {
+		var err1 error
+		if workers > 1 {
+			err1 = walkParallel(fqn, opts, workers)
+		} else {
+			err1 = godirwalk.Walk(fqn, gOpts)
+		}
+		if err1 != nil && !os.IsNotExist(err1) {
 			if errors.As(err1, &cmn.AbortedError{}) {
 				// Errors different from cmn.AbortedError should not be overwritten
 				// by cmn.AbortedError. Assign err = err1 only when there wasn't any other error
@@ -213,31 +356,165 @@ func Walk(opts *Options) error {
 	return err
 }
 
+///////////
+// pwalk //
+///////////
+
+// Same semantics as godirwalk.Walk (unsorted), except that a sub-directory
+// gets handed over to another goroutine when one is available (up to `workers`
+// in total) - and is walked in place otherwise, which also makes it deadlock-free.
+func walkParallel(root string, opts *Options, workers int) error {
+	de, err := godirwalk.NewDirent(root)
+	if err != nil {
+		return err
+	}
+	pw := &pwalk{opts: opts, workers: make(chan struct{}, workers-1)}
+	if !pw.visit(root, de) {
+		return pw.err
+	}
+	if de.IsDir() {
+		pw.walkDir(root)
+	}
+	pw.wg.Wait()
+	return pw.err
+}
+
+func (pw *pwalk) halt(err error) {
+	pw.mtx.Lock()
+	if pw.err == nil {
+		pw.err = err
+	}
+	pw.mtx.Unlock()
+	pw.halted.Store(true)
+}
+
+// returns false when the node is to be skipped (or the walk is halted)
+func (pw *pwalk) visit(fqn string, de *godirwalk.Dirent) bool {
+	err := pw.opts.Callback(fqn, de)
+	if err == nil {
+		return true
+	}
+	if err != filepath.SkipDir && pw.opts.ErrCallback(fqn, err) != godirwalk.SkipNode {
+		pw.halt(err)
+	}
+	return false
+}
+
+func (pw *pwalk) walkDir(dir string) {
+	// (unlike godirwalk.Scanner, does not keep the directory open while walking its children)
+	dirents, err := godirwalk.ReadDirents(dir, nil)
+	if err != nil {
+		if pw.opts.ErrCallback(dir, err) != godirwalk.SkipNode {
+			pw.halt(err)
+		}
+		return
+	}
+	for _, de := range dirents {
+		if pw.halted.Load() {
+			return
+		}
+		fqn := filepath.Join(dir, de.Name())
+		if err := pw.opts.Callback(fqn, de); err != nil {
+			if err == filepath.SkipDir {
+				if de.IsDir() {
+					continue
+				}
+				return // SkipDir on a file: skip the remaining siblings
+			}
+			if pw.opts.ErrCallback(fqn, err) != godirwalk.SkipNode {
+				pw.halt(err)
+				return
+			}
+			continue
+		}
+		if !de.IsDir() {
+			continue
+		}
+		select {
+		case pw.workers <- struct{}{}:
+			pw.wg.Add(1)
+			go func(dir string) {
+				pw.walkDir(dir)
+				<-pw.workers
+				pw.wg.Done()
+			}(fqn)
+		default:
+			pw.walkDir(fqn)
+		}
+	}
+}
+
+// WalkBck walks the bucket across all mountpaths. When opts.Sorted, it merges
+// the per-mountpath (sorted) results so that the callback gets invoked in the
+// global order; otherwise, the entries get streamed to the callback as they
+// arrive from any of the mountpaths. Either way, the callback is invoked from
+// a single goroutine.
+//
+// Given opts.CheckpointBck, the callback is also the one to emit the tokens,
+// every opts.CkptEvery files - each token thus marks the position up to which
+// all the files (from all mountpaths) have been processed by the callback.
 func WalkBck(opts *WalkBckOptions) error {
 	type walkEntry struct {
 		fqn      string
 		dirEntry DirEntry
+		mpathIdx int
 	}
 
 	var (
 		mpaths, _ = Mountpaths.Get()
 		mpathChs  = make([]chan *walkEntry, len(mpaths))
+		wg        = &sync.WaitGroup{}
+		ckpt      = newWalkBckCkpt(opts, len(mpaths))
 
 		group, ctx = errgroup.WithContext(context.Background())
 	)
 
-	for i := 0; i < len(mpaths); i++ {
-		mpathChs[i] = make(chan *walkEntry, mpathQueueSize)
+	if opts.Sorted {
+		for i := 0; i < len(mpaths); i++ {
+			mpathChs[i] = make(chan *walkEntry, mpathQueueSize)
+		}
+	} else {
+		// all mountpaths share a single channel
+		ch := make(chan *walkEntry, mpathQueueSize*cmn.Max(len(mpaths), 1))
+		for i := 0; i < len(mpaths); i++ {
+			mpathChs[i] = ch
+		}
+		wg.Add(len(mpaths))
+		go func() {
+			wg.Wait()
+			close(ch)
+		}()
+		group.Go(func() error {
+			for entry := range ch {
+				if err := opts.Callback(entry.fqn, entry.dirEntry); err != nil {
+					return err
+				}
+				ckpt.visited(entry.mpathIdx, entry.fqn)
+			}
+			return nil
+		})
 	}
 
 	cmn.Assert(opts.Mpath == nil)
 	idx := 0
 	for _, mpath := range mpaths {
+		if ckpt != nil {
+			ckpt.mpaths = append(ckpt.mpaths, mpath.Path)
+		}
 		group.Go(func(idx int, mpath *MountpathInfo) func() error {
 			return func() error {
-				defer close(mpathChs[idx])
+				if opts.Sorted {
+					defer close(mpathChs[idx])
+				} else {
+					defer wg.Done()
+				}
 				o := *opts
 				o.Mpath = mpath
+				o.Checkpoint = nil // see ckpt.visited
+				if ckpt != nil {
+					o.Sorted = true // the tokens rely on the walk order
+					o.ResumeAfter = opts.Resume[mpath.Path]
+				}
 				o.Callback = func(fqn string, de DirEntry) error {
 					select {
 					case <-ctx.Done():
@@ -262,7 +539,7 @@ func WalkBck(opts *WalkBckOptions) error {
 					select {
 					case <-ctx.Done():
 						return cmn.NewAbortedError("mpath: " + mpath.Path)
-					case mpathChs[idx] <- &walkEntry{fqn, de}:
+					case mpathChs[idx] <- &walkEntry{fqn, de, idx}:
 						return nil
 					}
 				}
@@ -272,8 +549,9 @@ func WalkBck(opts *WalkBckOptions) error {
 		idx++
 	}
 
-	// TODO: handle case when `opts.Sorted == false`
-	cmn.Assert(opts.Sorted)
+	if !opts.Sorted {
+		return group.Wait()
+	}
 	group.Go(func() error {
 		var (
 			h = &objInfos{}
@@ -292,6 +570,7 @@ func WalkBck(opts *WalkBckOptions) error {
 			if err := opts.Callback(info.fqn, info.dirEntry); err != nil {
 				return err
 			}
+			ckpt.visited(info.mpathIdx, info.fqn)
 			if pair, ok := <-mpathChs[info.mpathIdx]; ok {
 				heap.Push(h, objInfo{mpathIdx: info.mpathIdx, fqn: pair.fqn, dirEntry: pair.dirEntry})
 			}
@@ -302,11 +581,52 @@ func WalkBck(opts *WalkBckOptions) error {
 	return group.Wait()
 }
 
-func Scanner(dir string, cb func(fqn string, entry DirEntry) error) error {
+/////////////////
+// walkBckCkpt //
+/////////////////
+
+func newWalkBckCkpt(opts *WalkBckOptions, cnt int) *walkBckCkpt {
+	if opts.Resume == nil && opts.CheckpointBck == nil {
+		return nil
+	}
+	cmn.Assert(opts.CheckpointBck == nil || opts.CkptEvery > 0)
+	ckpt := &walkBckCkpt{opts: opts, mpaths: make([]string, 0, cnt), last: make(WalkBckToken, cnt)}
+	for mpath, fqn := range opts.Resume {
+		ckpt.last[mpath] = fqn
+	}
+	return ckpt
+}
+
+func (ckpt *walkBckCkpt) visited(mpathIdx int, fqn string) {
+	if ckpt == nil || ckpt.opts.CheckpointBck == nil {
+		return
+	}
+	ckpt.last[ckpt.mpaths[mpathIdx]] = fqn
+	if ckpt.cnt++; ckpt.cnt%ckpt.opts.CkptEvery != 0 {
+		return
+	}
+	token := make(WalkBckToken, len(ckpt.last))
+	for mpath, fqn := range ckpt.last {
+		token[mpath] = fqn
+	}
+	ckpt.opts.CheckpointBck(token)
+}
+
+// Scanner invokes the callback for each entry of a given directory; given
+// filter, only for the (sub)directories and the matching files (see WalkFilter)
+func Scanner(dir string, cb func(fqn string, entry DirEntry) error, filter ...*WalkFilter) error {
 	scanner, err := godirwalk.NewScanner(dir)
 	if err != nil {
 		return err
 	}
+	if len(filter) > 0 && filter[0] != nil {
+		wf := &walkFilter{opts: &Options{Callback: cb, Filter: filter[0]}}
+		if filter[0].sized() {
+			wf.ds = newDirStat()
+			defer wf.ds.close()
+		}
+		cb = wf.callback
+	}
 	for scanner.Scan() {
 		dirent, err := scanner.Dirent()
 		if err != nil {
@@ -5,12 +5,14 @@
 package fs_test
 
 import (
+	"errors"
 	"io/ioutil"
 	"math/rand"
 	"os"
 	"path/filepath"
 	"reflect"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -31,6 +33,8 @@ func TestWalkBck(t *testing.T) {
 		}{
 			{name: "simple_sorted", mpathCnt: 1, sorted: true},
 			{name: "10mpaths_sorted", mpathCnt: 10, sorted: true},
+			{name: "simple_unsorted", mpathCnt: 1, sorted: false},
+			{name: "10mpaths_unsorted", mpathCnt: 10, sorted: false},
 		}
 	)
 
@@ -98,8 +102,10 @@ func TestWalkBck(t *testing.T) {
 			})
 			tassert.CheckFatal(t, err)
 
-			sorted := sort.IsSorted(sort.StringSlice(objs))
-			tassert.Fatalf(t, sorted == test.sorted, "expected the output to be sorted=%t", test.sorted)
+			if test.sorted {
+				sorted := sort.IsSorted(sort.StringSlice(objs))
+				tassert.Fatalf(t, sorted, "expected the output to be sorted")
+			}
 
 			sort.Strings(fqns)
 			sort.Strings(fileNames)
@@ -193,3 +199,356 @@ func TestWalkBckSkipDir(t *testing.T) {
 	}
 	tassert.Fatalf(t, expectedTotal == len(fqns), "expected %d objects, got %d", expectedTotal, len(fqns))
 }
+
+func TestWalkParallel(t *testing.T) {
+	var (
+		bck   = cmn.Bck{Name: "name", Provider: cmn.ProviderAIS}
+		tests = []struct {
+			name    string
+			label   string
+			workers int
+		}{
+			{name: "serial", label: "hdd", workers: 1},
+			{name: "parallel", label: "nvme", workers: 8},
+		}
+	)
+	config := cmn.GCO.BeginUpdate()
+	config.Disk.WalkWorkers = map[string]int{"hdd": 1, "nvme": 8}
+	cmn.GCO.CommitUpdate(config)
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mpath, err := ioutil.TempDir("", "testwalk")
+			tassert.CheckFatal(t, err)
+			defer os.RemoveAll(mpath)
+
+			fs.Mountpaths = fs.NewMountedFS(ios.NewIOStaterMock())
+			fs.Mountpaths.DisableFsIDCheck()
+			fs.Mountpaths.SetLabels(map[string]string{mpath: test.label})
+			_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+			tassert.CheckFatal(t, fs.Mountpaths.Add(mpath))
+
+			avail, _ := fs.Mountpaths.Get()
+			mpathInfo := avail[mpath]
+			tassert.Fatalf(t, mpathInfo.WalkWorkers(cmn.GCO.Get()) == test.workers, "unexpected number of walk workers")
+
+			dir := mpathInfo.MakePathCT(bck, fs.ObjectType)
+			tassert.CheckFatal(t, cmn.CreateDir(dir))
+			_, fileNames := tutils.PrepareDirTree(t, tutils.DirTreeDesc{
+				InitDir: dir,
+				Dirs:    rand.Int()%10 + 2,
+				Files:   rand.Int()%10 + 1,
+				Depth:   rand.Int()%4 + 1,
+				Empty:   false,
+			})
+
+			var (
+				mtx  sync.Mutex
+				fqns = make([]string, 0, len(fileNames))
+			)
+			err = fs.Walk(&fs.Options{
+				Mpath: mpathInfo,
+				Bck:   bck,
+				CTs:   []string{fs.ObjectType},
+				Callback: func(fqn string, de fs.DirEntry) error {
+					if de.IsDir() {
+						return nil
+					}
+					mtx.Lock()
+					fqns = append(fqns, fqn)
+					mtx.Unlock()
+					return nil
+				},
+				Parallel: true,
+			})
+			tassert.CheckFatal(t, err)
+
+			sort.Strings(fqns)
+			sort.Strings(fileNames)
+			tassert.Fatalf(t, reflect.DeepEqual(fqns, fileNames), "found objects don't match expected objects")
+		})
+	}
+}
+
+func TestWalkCheckpoint(t *testing.T) {
+	var (
+		bck      = cmn.Bck{Name: "name", Provider: cmn.ProviderAIS}
+		mpathCnt = 3
+	)
+	fs.Mountpaths = fs.NewMountedFS(ios.NewIOStaterMock())
+	fs.Mountpaths.DisableFsIDCheck()
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	for i := 0; i < mpathCnt; i++ {
+		mpath, err := ioutil.TempDir("", "testwalk")
+		tassert.CheckFatal(t, err)
+		defer os.RemoveAll(mpath)
+		tassert.CheckFatal(t, fs.Mountpaths.Add(mpath))
+	}
+	avail, _ := fs.Mountpaths.Get()
+	var fileNames []string
+	for _, mpathInfo := range avail {
+		dir := mpathInfo.MakePathCT(bck, fs.ObjectType)
+		tassert.CheckFatal(t, cmn.CreateDir(dir))
+		_, names := tutils.PrepareDirTree(t, tutils.DirTreeDesc{
+			InitDir: dir,
+			Dirs:    rand.Int()%5 + 2,
+			Files:   rand.Int()%10 + 5,
+			Depth:   rand.Int()%2 + 2,
+			Empty:   false,
+		})
+		fileNames = append(fileNames, names...)
+	}
+	sort.Strings(fileNames)
+
+	t.Run("walk", func(t *testing.T) {
+		for _, mpathInfo := range avail {
+			var (
+				all, resumed []string
+				tokens       = make(map[string]int) // token => number of files visited up to (and including) it
+			)
+			opts := &fs.Options{
+				Mpath: mpathInfo,
+				Bck:   bck,
+				CTs:   []string{fs.ObjectType},
+				Callback: func(fqn string, de fs.DirEntry) error {
+					if !de.IsDir() {
+						all = append(all, fqn)
+					}
+					return nil
+				},
+				Sorted:     true,
+				CkptEvery:  3,
+				Checkpoint: func(token string) { tokens[token] = len(all) },
+			}
+			tassert.CheckFatal(t, fs.Walk(opts))
+			tassert.Fatalf(t, len(tokens) == len(all)/3, "expected %d tokens, got %d", len(all)/3, len(tokens))
+			for token, n := range tokens { // (random order)
+				resumed = resumed[:0]
+				err := fs.Walk(&fs.Options{
+					Mpath: mpathInfo,
+					Bck:   bck,
+					CTs:   []string{fs.ObjectType},
+					Callback: func(fqn string, de fs.DirEntry) error {
+						if !de.IsDir() {
+							resumed = append(resumed, fqn)
+						}
+						return nil
+					},
+					Sorted:      true,
+					ResumeAfter: token,
+				})
+				tassert.CheckFatal(t, err)
+				// (compare the elements - empty vs. nil slice)
+				tassert.Fatalf(t, len(resumed) == len(all)-n, "resumed after %q: expected %d files, got %d",
+					token, len(all)-n, len(resumed))
+				for i, fqn := range resumed {
+					tassert.Fatalf(t, fqn == all[n+i], "resumed after %q: expected %q, got %q", token, all[n+i], fqn)
+				}
+			}
+		}
+	})
+
+	t.Run("walk_bck", func(t *testing.T) {
+		var (
+			visited []string
+			token   fs.WalkBckToken
+			errStop = errors.New("stop")
+		)
+		// interrupt half-way through
+		err := fs.WalkBck(&fs.WalkBckOptions{
+			Options: fs.Options{
+				Bck: bck,
+				CTs: []string{fs.ObjectType},
+				Callback: func(fqn string, de fs.DirEntry) error {
+					if len(visited) == len(fileNames)/2 {
+						return errStop
+					}
+					visited = append(visited, fqn)
+					return nil
+				},
+				Sorted:    true,
+				CkptEvery: 2,
+			},
+			CheckpointBck: func(t fs.WalkBckToken) { token = t },
+		})
+		tassert.Fatalf(t, errors.Is(err, errStop), "expected interrupted walk, got %v", err)
+		tassert.Fatalf(t, token != nil, "expected checkpoint")
+		// the last token covers an even number of files - drop the rest
+		visited = visited[:len(visited)/2*2]
+
+		err = fs.WalkBck(&fs.WalkBckOptions{
+			Options: fs.Options{
+				Bck: bck,
+				CTs: []string{fs.ObjectType},
+				Callback: func(fqn string, de fs.DirEntry) error {
+					visited = append(visited, fqn)
+					return nil
+				},
+				Sorted: true,
+			},
+			Resume: token,
+		})
+		tassert.CheckFatal(t, err)
+		sort.Strings(visited)
+		tassert.Fatalf(t, reflect.DeepEqual(visited, fileNames), "resumed walk: found objects don't match expected objects")
+	})
+}
+
+func TestWalkThrottle(t *testing.T) {
+	var (
+		bck   = cmn.Bck{Name: "name", Provider: cmn.ProviderAIS}
+		mios  = ios.NewIOStaterMock()
+		tests = []struct {
+			name    string
+			util    int64
+			minTime time.Duration
+		}{
+			{name: "idle", util: 10},
+			{name: "busy", util: 90, minTime: 3 * cmn.ThrottleSleepAvg},
+		}
+	)
+	config := cmn.GCO.BeginUpdate()
+	config.Disk.DiskUtilLowWM, config.Disk.DiskUtilHighWM, config.Disk.DiskUtilMaxWM = 20, 80, 95
+	cmn.GCO.CommitUpdate(config)
+
+	mpath, err := ioutil.TempDir("", "testwalk")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(mpath)
+	fs.Mountpaths = fs.NewMountedFS(mios)
+	fs.Mountpaths.DisableFsIDCheck()
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	tassert.CheckFatal(t, fs.Mountpaths.Add(mpath))
+	avail, _ := fs.Mountpaths.Get()
+	mpathInfo := avail[mpath]
+
+	dir := mpathInfo.MakePathCT(bck, fs.ObjectType)
+	tassert.CheckFatal(t, cmn.CreateDir(dir))
+	_, fileNames := tutils.PrepareDirTree(t, tutils.DirTreeDesc{
+		InitDir: dir,
+		Dirs:    0,
+		Files:   200,
+		Depth:   1,
+		Empty:   false,
+	})
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mios.Utils[mpath] = test.util
+			var (
+				cnt     int
+				started = time.Now()
+			)
+			err := fs.Walk(&fs.Options{
+				Mpath: mpathInfo,
+				Bck:   bck,
+				CTs:   []string{fs.ObjectType},
+				Callback: func(fqn string, de fs.DirEntry) error {
+					if !de.IsDir() {
+						cnt++
+					}
+					return nil
+				},
+				Throttle: true,
+			})
+			tassert.CheckFatal(t, err)
+			tassert.Fatalf(t, cnt == len(fileNames), "expected %d files, got %d", len(fileNames), cnt)
+			elapsed := time.Since(started)
+			tassert.Errorf(t, elapsed >= test.minTime, "expected the walk to take at least %v, took %v", test.minTime, elapsed)
+			if test.minTime == 0 {
+				tassert.Errorf(t, elapsed < cmn.ThrottleSleepAvg, "expected no throttling, took %v", elapsed)
+			}
+		})
+	}
+}
+
+func TestWalkFilter(t *testing.T) {
+	var (
+		bck   = cmn.Bck{Name: "name", Provider: cmn.ProviderAIS}
+		files = map[string]int{ // name => size
+			"a.tar":          100,
+			"b.tar":          5000,
+			"c.tgz":          2000,
+			"d.txt":          10,
+			"dir/e.tar":      3000,
+			"dir/f.json":     300,
+			"%ob/g.tar":      400, // virtual directory that looks like a content type
+			"dir/%ob/h.json": 500,
+		}
+		tests = []struct {
+			name     string
+			filter   fs.WalkFilter
+			parallel bool
+			expected []string
+		}{
+			{name: "ext", filter: fs.WalkFilter{Exts: []string{".tar"}}, expected: []string{"%ob/g.tar", "a.tar", "b.tar", "dir/e.tar"}},
+			{name: "exts", filter: fs.WalkFilter{Exts: []string{".tgz", ".json"}}, expected: []string{"c.tgz", "dir/%ob/h.json", "dir/f.json"}},
+			{name: "pattern", filter: fs.WalkFilter{Pattern: "[a-c].*"}, expected: []string{"a.tar", "b.tar", "c.tgz"}},
+			{name: "size", filter: fs.WalkFilter{MinSize: 300, MaxSize: 3000}, expected: []string{"%ob/g.tar", "c.tgz", "dir/%ob/h.json", "dir/e.tar", "dir/f.json"}},
+			{name: "size_parallel", filter: fs.WalkFilter{MinSize: 300, MaxSize: 3000}, parallel: true, expected: []string{"%ob/g.tar", "c.tgz", "dir/%ob/h.json", "dir/e.tar", "dir/f.json"}},
+			{name: "ext_and_size", filter: fs.WalkFilter{Exts: []string{".tar"}, MinSize: 1000}, expected: []string{"b.tar", "dir/e.tar"}},
+			{name: "content_type", filter: fs.WalkFilter{CTs: []string{fs.WorkfileType}}, expected: []string{"work"}},
+		}
+	)
+	mpath, err := ioutil.TempDir("", "testwalk")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(mpath)
+	fs.Mountpaths = fs.NewMountedFS(ios.NewIOStaterMock())
+	fs.Mountpaths.DisableFsIDCheck()
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	tassert.CheckFatal(t, fs.Mountpaths.Add(mpath))
+	avail, _ := fs.Mountpaths.Get()
+	mpathInfo := avail[mpath]
+
+	objDir := mpathInfo.MakePathCT(bck, fs.ObjectType)
+	for name, size := range files {
+		fqn := filepath.Join(objDir, name)
+		tassert.CheckFatal(t, cmn.CreateDir(filepath.Dir(fqn)))
+		tassert.CheckFatal(t, ioutil.WriteFile(fqn, make([]byte, size), 0644))
+	}
+	workDir := mpathInfo.MakePathCT(bck, fs.WorkfileType)
+	tassert.CheckFatal(t, cmn.CreateDir(workDir))
+	tassert.CheckFatal(t, ioutil.WriteFile(filepath.Join(workDir, "work"), []byte("work"), 0644))
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				mtx    sync.Mutex
+				filter = test.filter
+				names  = make([]string, 0, len(files))
+			)
+			err := fs.Walk(&fs.Options{
+				Dir:   mpathInfo.MakePathBck(bck),
+				Mpath: mpathInfo,
+				Callback: func(fqn string, de fs.DirEntry) error {
+					if de.IsDir() {
+						return nil
+					}
+					parsedFQN, err := fs.Mountpaths.ParseFQN(fqn)
+					tassert.CheckFatal(t, err)
+					mtx.Lock()
+					names = append(names, parsedFQN.ObjName)
+					mtx.Unlock()
+					return nil
+				},
+				Filter:   &filter,
+				Parallel: test.parallel,
+			})
+			tassert.CheckFatal(t, err)
+			sort.Strings(names)
+			tassert.Fatalf(t, reflect.DeepEqual(names, test.expected), "expected %v, got %v", test.expected, names)
+		})
+	}
+
+	t.Run("scanner", func(t *testing.T) {
+		names := make([]string, 0, len(files))
+		err := fs.Scanner(objDir, func(fqn string, de fs.DirEntry) error {
+			names = append(names, filepath.Base(fqn))
+			return nil
+		}, &fs.WalkFilter{Exts: []string{".tar"}, MaxSize: 1000})
+		tassert.CheckFatal(t, err)
+		sort.Strings(names)
+		expected := []string{"%ob", "a.tar", "dir"}
+		tassert.Fatalf(t, reflect.DeepEqual(names, expected), "expected %v, got %v", expected, names)
+	})
+}
@@ -29,6 +29,7 @@ require (
 	github.com/seiflotfy/cuckoofilter v0.0.0-20190302225222-764cb5258d9b
 	github.com/teris-io/shortid v0.0.0-20171029131806-771a37caa5cf
 	github.com/tidwall/buntdb v1.1.2
+	github.com/tidwall/match v1.0.1
 	github.com/tinylib/msgp v1.1.2
 	github.com/urfave/cli v1.22.4
 	github.com/valyala/fasthttp v1.11.0
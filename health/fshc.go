@@ -39,7 +39,7 @@ type (
 	}
 	FSHC struct {
 		cmn.Named
-		stopCh     chan struct{}
+		stopCh     *cmn.StopCh
 		fileListCh chan string
 
 		// pointers to common data
@@ -62,7 +62,7 @@ func NewFSHC(dispatcher fspathDispatcher, mountpaths *fs.MountedFS, mm *memsys.M
 	return &FSHC{
 		mountpaths:  mountpaths,
 		mm:          mm,
-		stopCh:      make(chan struct{}),
+		stopCh:      cmn.NewStopCh(),
 		fileListCh:  make(chan string, 100),
 		dispatcher:  dispatcher,
 		ctxResolver: ctxResolver,
@@ -83,7 +83,7 @@ func (f *FSHC) Run() error {
 			}
 
 			f.runMpathTest(mpathInfo.Path, filePath)
-		case <-f.stopCh:
+		case <-f.stopCh.Listen():
 			return nil
 		}
 	}
@@ -91,7 +91,7 @@ func (f *FSHC) Run() error {
 
 func (f *FSHC) Stop(err error) {
 	glog.Infof("Stopping %s, err: %v", f.GetRunName(), err)
-	f.stopCh <- struct{}{}
+	f.stopCh.Close()
 }
 
 func (f *FSHC) OnErr(fqn string) {
@@ -7,6 +7,7 @@ package hk
 
 import (
 	"container/heap"
+	"context"
 	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
@@ -34,6 +35,7 @@ type (
 
 	housekeeper struct {
 		stopCh   *cmn.StopCh
+		doneCh   chan struct{} // closed upon exit
 		cleanups *timedCleanups
 		timer    *time.Timer
 		workCh   chan request
@@ -54,6 +56,7 @@ func initCleaner() {
 	Housekeeper = &housekeeper{
 		workCh:   make(chan request, 10),
 		stopCh:   cmn.NewStopCh(),
+		doneCh:   make(chan struct{}),
 		cleanups: &timedCleanups{},
 	}
 	heap.Init(Housekeeper.cleanups)
@@ -96,7 +99,10 @@ func (hk *housekeeper) Unregister(name string) {
 
 func (hk *housekeeper) run() {
 	hk.timer = time.NewTimer(time.Hour)
-	defer hk.timer.Stop()
+	defer func() {
+		hk.timer.Stop()
+		close(hk.doneCh)
+	}()
 
 	for {
 		select {
@@ -154,3 +160,15 @@ func (hk *housekeeper) updateTimer() {
 func (hk *housekeeper) Abort() {
 	hk.stopCh.Close()
 }
+
+// StopCtx stops the housekeeper and waits for the callback in progress, if any,
+// to return - but not past the ctx deadline
+func (hk *housekeeper) StopCtx(ctx context.Context) error {
+	hk.stopCh.Close()
+	select {
+	case <-hk.doneCh:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
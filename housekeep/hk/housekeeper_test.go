@@ -6,6 +6,7 @@
 package hk
 
 import (
+	"context"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -139,4 +140,34 @@ var _ = Describe("Housekeeper", func() {
 		time.Sleep(time.Second)
 		Expect(fired).To(BeFalse())
 	})
+
+	It("should stop and wait for the callback in progress", func() {
+		var (
+			started  = make(chan struct{})
+			release  = make(chan struct{})
+			returned = make(chan struct{})
+		)
+		Housekeeper.Register("slow", func() time.Duration {
+			close(started)
+			<-release
+			return time.Hour
+		}, time.Millisecond)
+		<-started
+
+		// deadline
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		Expect(Housekeeper.StopCtx(ctx)).To(Equal(context.DeadlineExceeded))
+
+		// and upon completion
+		go func() {
+			defer GinkgoRecover()
+			Expect(Housekeeper.StopCtx(context.Background())).To(Succeed())
+			close(returned)
+		}()
+		time.Sleep(20 * time.Millisecond)
+		Expect(returned).NotTo(BeClosed())
+		close(release)
+		Eventually(returned).Should(BeClosed())
+	})
 })
@@ -0,0 +1,128 @@
+// Package lru provides least recently used cache replacement policy for stored objects
+// and serves as a generic garbage-collection mechanism for orphaned workfiles.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package lru
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/karrick/godirwalk"
+)
+
+// directories younger than that are never removed, whatever the configured
+// `periodic.empty_dir_gc_age` - PUT creates the object's directory and only
+// then the object itself (see cluster.LOM.CreateFile)
+const emptyDirGCGrace = 10 * time.Minute
+
+// GCEmptyDirs removes the empty directories that deleted (moved, evicted)
+// objects and other content types leave behind and that slow down the
+// subsequent walks. Only the directories that have not been modified for
+// longer than `periodic.empty_dir_gc_age` (and emptyDirGCGrace) are removed -
+// the recently created (or modified) ones may be about to receive new content.
+// Mountpaths are processed in parallel. Returns the time until the next run.
+func GCEmptyDirs(bowner cluster.Bowner, statsT stats.Tracker) time.Duration {
+	var (
+		age    = cmn.GCO.Get().Periodic.EmptyDirGCAge
+		bmd    = bowner.Get()
+		cnt    atomic.Int64
+		wg     = &sync.WaitGroup{}
+		cts    = make([]string, 0, len(fs.CSM.RegisteredContentTypes))
+		mpaths map[string]*fs.MountpathInfo
+	)
+	if age <= 0 {
+		return workGCIvalDisabled
+	}
+	if age < emptyDirGCGrace {
+		age = emptyDirGCGrace
+	}
+	for ct := range fs.CSM.RegisteredContentTypes {
+		cts = append(cts, ct)
+	}
+	mpaths, _ = fs.Mountpaths.Get()
+	for _, mpathInfo := range mpaths {
+		wg.Add(1)
+		go func(mpathInfo *fs.MountpathInfo) {
+			expired := time.Now().Add(-age)
+			bmd.Range(nil, nil, func(bck *cluster.Bck) bool {
+				for _, ct := range cts {
+					cnt.Add(pruneEmptyDirs(mpathInfo.MakePathCT(bck.Bck, ct), expired))
+				}
+				return false
+			})
+			wg.Done()
+		}(mpathInfo)
+	}
+	wg.Wait()
+	if n := cnt.Load(); n > 0 {
+		glog.Infof("removed %d empty dir(s)", n)
+		statsT.Add(stats.EmptyDirGCCount, n)
+	}
+	ival := age / 4
+	if ival < workGCIvalMin {
+		ival = workGCIvalMin
+	} else if ival > workGCIvalMax {
+		ival = workGCIvalMax
+	}
+	return ival
+}
+
+// removes (bottom-up) the empty directories under the root that have not been
+// modified since `expired`; the root itself is kept
+func pruneEmptyDirs(root string, expired time.Time) (cnt int64) {
+	var (
+		// removing a directory updates its parent's mtime - remember the latter
+		// as it was before the walk got to its children
+		mtimes = make(map[string]time.Time, 16)
+		// parents of the directories removed by this walk
+		emptied = make(cmn.StringSet, 16)
+	)
+	opts := &godirwalk.Options{
+		Callback: func(dir string, de *godirwalk.Dirent) error {
+			if !de.IsDir() {
+				return nil
+			}
+			if finfo, err := os.Stat(dir); err == nil {
+				mtimes[dir] = finfo.ModTime()
+			}
+			return nil
+		},
+		PostChildrenCallback: func(dir string, _ *godirwalk.Dirent) error {
+			mtime, ok := mtimes[dir]
+			delete(mtimes, dir)
+			_, parent := emptied[dir]
+			delete(emptied, dir)
+			if !ok || dir == root || mtime.After(expired) {
+				return nil
+			}
+			// unless modified by this walk, check again: the directory may
+			// have been (re)used in the meantime, e.g., by PUT
+			if !parent {
+				if finfo, err := os.Stat(dir); err != nil || finfo.ModTime().After(expired) {
+					return nil
+				}
+			}
+			if err := os.Remove(dir); err == nil { // fails if not empty
+				cnt++
+				emptied.Add(filepath.Dir(dir))
+			}
+			return nil
+		},
+		ErrorCallback: func(_ string, _ error) godirwalk.ErrorAction { return godirwalk.SkipNode },
+		Unsorted:      true,
+	}
+	if err := godirwalk.Walk(root, opts); err != nil && !os.IsNotExist(err) {
+		glog.Errorf("failed to prune empty directories under %q: %v", root, err)
+	}
+	return
+}
@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"sync"
 	"time"
 
@@ -65,7 +66,7 @@ func (lctx *lruCtx) jog(wg *sync.WaitGroup, joggers map[string]*lruCtx, errCh ch
 	opts := &fs.Options{
 		Mpath: lctx.mpathInfo,
 		Bck:   lctx.bck,
-		CTs:   []string{fs.WorkfileType, fs.ObjectType},
+		CTs:   []string{fs.WorkfileType, fs.ObjectType, fs.ObjVersionType, fs.RemoteVerType},
 
 		Callback: lctx.walk,
 		Sorted:   false,
@@ -107,6 +108,10 @@ func (lctx *lruCtx) walk(fqn string, de fs.DirEntry) error {
 	if err != nil {
 		return nil
 	}
+	// remote cache has its own capacity-driven LRU (see EvictRemoteCache)
+	if lctx.config.RemoteCache.IsCacheBck(lom.Bck().Bck) {
+		return nil
+	}
 
 	// workfiles: remove old or do nothing
 	if lom.ParsedFQN.ContentType == fs.WorkfileType {
@@ -118,6 +123,15 @@ func (lctx *lruCtx) walk(fqn string, de fs.DirEntry) error {
 		}
 		return nil
 	}
+	dontEvictTime := time.Now().Add(-lctx.config.LRU.DontEvictTime)
+	// previous versions of the objects and the fetched versions of the cloud
+	// objects (see time-travel GET): evicted before the objects
+	if ct := lom.ParsedFQN.ContentType; ct == fs.ObjVersionType || ct == fs.RemoteVerType {
+		if finfo, err := os.Stat(fqn); err == nil && finfo.ModTime().Before(dontEvictTime) {
+			lctx.versions = append(lctx.versions, prevVersion{fqn: fqn, size: finfo.Size(), mtime: finfo.ModTime()})
+		}
+		return nil
+	}
 	// TODO: extend LRU for other content types
 	cmn.Assert(lom.ParsedFQN.ContentType == fs.ObjectType)
 
@@ -126,7 +140,6 @@ func (lctx *lruCtx) walk(fqn string, de fs.DirEntry) error {
 		return nil
 	}
 
-	dontEvictTime := time.Now().Add(-lctx.config.LRU.DontEvictTime)
 	if lom.Atime().After(dontEvictTime) {
 		return nil
 	}
@@ -195,18 +208,35 @@ func (lctx *lruCtx) evict() (err error) {
 		} else if err = lom.DelExtraCopies(); err != nil {
 			glog.Warningf("%s: %v", lom, err)
 		}
-		if capCheck, err = lctx.postRemove(capCheck, lom); err != nil {
+		if capCheck, err = lctx.postRemove(capCheck, lom.Size()); err != nil {
 			return
 		}
 	}
 	lctx.misplaced = lctx.misplaced[:0]
-	// 3.
+	// 3. previous (and given cloud) versions of the objects, the oldest first
+	sort.Slice(lctx.versions, func(i, j int) bool { return lctx.versions[i].mtime.Before(lctx.versions[j].mtime) })
+	for _, ver := range lctx.versions {
+		if lctx.totalSize <= 0 {
+			break
+		}
+		if err = cmn.RemoveFile(ver.fqn); err != nil {
+			glog.Warningf("Failed to remove previous version %q: %v", ver.fqn, err)
+			continue
+		}
+		bevicted += ver.size
+		fevicted++
+		if capCheck, err = lctx.postRemove(capCheck, ver.size); err != nil {
+			return
+		}
+	}
+	lctx.versions = lctx.versions[:0]
+	// 4.
 	for h.Len() > 0 && lctx.totalSize > 0 {
 		lom := heap.Pop(h).(*cluster.LOM)
 		if lctx.evictObj(lom) {
 			bevicted += lom.Size()
 			fevicted++
-			if capCheck, err = lctx.postRemove(capCheck, lom); err != nil {
+			if capCheck, err = lctx.postRemove(capCheck, lom.Size()); err != nil {
 				return
 			}
 		}
@@ -218,9 +248,9 @@ func (lctx *lruCtx) evict() (err error) {
 	return nil
 }
 
-func (lctx *lruCtx) postRemove(capCheck int64, lom *cluster.LOM) (int64, error) {
-	lctx.totalSize -= lom.Size()
-	capCheck += lom.Size()
+func (lctx *lruCtx) postRemove(capCheck, size int64) (int64, error) {
+	lctx.totalSize -= size
+	capCheck += size
 	if err := lctx.yieldTerm(); err != nil {
 		return 0, err
 	}
@@ -66,6 +66,7 @@ type (
 		heap      *fileInfoMinHeap
 		oldWork   []string
 		misplaced []*cluster.LOM
+		versions  []prevVersion
 		// init-time
 		ini        InitLRU
 		stopCh     chan struct{}
@@ -80,6 +81,13 @@ type (
 	Xaction struct {
 		cmn.XactBase
 	}
+	// previous version of an object (see cluster.LOM.KeepVersion) or a given
+	// version of a cloud object (see cluster.LOM.RemoteVersionLOM)
+	prevVersion struct {
+		fqn   string
+		size  int64
+		mtime time.Time
+	}
 )
 
 func (r *Xaction) IsMountpathXact() bool { return true }
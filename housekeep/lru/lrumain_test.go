@@ -113,6 +113,7 @@ func createAndAddMountpath(path string) {
 
 	fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
 	fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	fs.CSM.RegisterContentType(fs.RemoteVerType, &fs.RemoteVerContentResolver{})
 }
 
 func getRandomFileName(fileCounter int) string {
@@ -127,7 +128,11 @@ func saveRandomFile(t cluster.Target, filename string, size int64) {
 	err = lom.Init(cmn.Bck{})
 	Expect(err).NotTo(HaveOccurred())
 	lom.SetSize(size)
-	lom.IncVersion()
+	if lom.Bck().IsAIS() {
+		lom.IncVersion()
+	} else {
+		lom.SetVersion("1")
+	}
 	Expect(lom.Persist()).NotTo(HaveOccurred())
 }
 
@@ -216,6 +221,32 @@ var _ = Describe("LRU tests", func() {
 				}
 			})
 
+			It("should evict given versions of the cloud objects before the objects", func() {
+				const numberOfFiles = 6
+
+				ini.GetFSStats = getMockGetFSStats(numberOfFiles)
+
+				saveRandomFiles(t, filesPath, numberOfFiles/2)
+				files, err := ioutil.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				versions := make([]string, 0, len(files))
+				for i, f := range files {
+					fqn := fs.CSM.GenContentFQN(path.Join(filesPath, f.Name()), fs.RemoteVerType, fmt.Sprintf("v%d", i))
+					saveRandomFile(t, fqn, fileSize)
+					versions = append(versions, fqn)
+				}
+				Expect(fs.CSM.RegisteredContentTypes[fs.RemoteVerType].PermToEvict()).To(BeTrue())
+
+				InitAndRun(ini)
+
+				for _, fqn := range versions {
+					Expect(fqn).NotTo(BeAnExistingFile())
+				}
+				filesLeft, err := ioutil.ReadDir(filesPath)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(len(filesLeft)).To(Equal(len(files)))
+			})
+
 			It("should evict files of different sizes", func() {
 				const totalSize = 32 * cmn.MiB
 
@@ -316,4 +347,193 @@ var _ = Describe("LRU tests", func() {
 			})
 		})
 	})
+
+	Describe("SpillBucket", func() {
+		var (
+			t   *cluster.TargetMock
+			ini *InitLRU
+			bck *cluster.Bck
+
+			filesPath string
+		)
+
+		BeforeEach(func() {
+			initConfig()
+			createAndAddMountpath(basePath)
+			bck = cluster.NewBck(
+				bucketName, cmn.ProviderAmazon, cmn.NsGlobal,
+				&cmn.BucketProps{
+					Cksum:  cmn.CksumConf{Type: cmn.ChecksumNone},
+					LRU:    cmn.LRUConf{Enabled: true, SpillOnOOS: true},
+					Access: cmn.AllAccess(),
+				},
+			)
+			t = cluster.NewTargetMock(cluster.NewBaseBownerMock(bck))
+			ini = newInitLRU(t)
+			ini.Xaction = &Xaction{XactBase: *cmn.NewXactBase(cmn.XactBaseID("spill"), cmn.ActLRU)}
+
+			mpaths, _ := fs.Mountpaths.Get()
+			filesPath = mpaths[basePath].MakePathCT(bck.Bck, fs.ObjectType)
+			cmn.CreateDir(filesPath)
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(basePath)
+		})
+
+		It("should evict down to the high watermark", func() {
+			saveRandomFiles(t, filesPath, numberOfCreatedFiles)
+
+			evicted, err := SpillBucket(ini, bck)
+			Expect(err).NotTo(HaveOccurred())
+
+			// 90% used => evict 10% of the capacity (5 files out of 45 at 90%)
+			files, err := ioutil.ReadDir(filesPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(files)).To(Equal(numberOfCreatedFiles - 5))
+			Expect(evicted).To(Equal(int64(5 * fileSize)))
+		})
+
+		It("should stop when aborted", func() {
+			saveRandomFiles(t, filesPath, numberOfCreatedFiles)
+			ini.Xaction.Abort()
+
+			_, err := SpillBucket(ini, bck)
+			Expect(err).To(HaveOccurred())
+
+			files, err := ioutil.ReadDir(filesPath)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(len(files)).To(Equal(numberOfCreatedFiles))
+		})
+	})
+
+	Describe("GCWorkfiles", func() {
+		const workfileGCAge = time.Hour
+
+		var (
+			t      *cluster.TargetMock
+			mi     *fs.MountpathInfo
+			objFQN string
+			bck    = cmn.Bck{Name: bucketName, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+			oldAge = cmn.GCO.Get().Periodic.WorkfileGCAge
+		)
+
+		saveWorkfile := func(fqn string, mtime time.Time) string {
+			Expect(cmn.CreateDir(path.Dir(fqn))).NotTo(HaveOccurred())
+			Expect(ioutil.WriteFile(fqn, []byte("workfile"), 0644)).NotTo(HaveOccurred())
+			Expect(os.Chtimes(fqn, mtime, mtime)).NotTo(HaveOccurred())
+			return fqn
+		}
+
+		BeforeEach(func() {
+			config := cmn.GCO.BeginUpdate()
+			config.Periodic.WorkfileGCAge = workfileGCAge
+			cmn.GCO.CommitUpdate(config)
+			createAndAddMountpath(basePath)
+			t = newTargetLRUMock()
+
+			mpaths, _ := fs.Mountpaths.Get()
+			mi = mpaths[basePath]
+			objFQN = mi.MakePathFQN(bck, fs.ObjectType, "dir/obj")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(basePath)
+			config := cmn.GCO.BeginUpdate()
+			config.Periodic.WorkfileGCAge = oldAge
+			cmn.GCO.CommitUpdate(config)
+		})
+
+		It("should remove only old transient workfiles", func() {
+			var (
+				old = time.Now().Add(-2 * workfileGCAge)
+				put = saveWorkfile(fs.CSM.GenContentFQN(objFQN, fs.WorkfileType, fs.WorkfilePut), old)
+				ec  = saveWorkfile(fs.CSM.GenContentFQN(objFQN, fs.WorkfileType, "ec-write-1"), old)
+				cur = saveWorkfile(fs.CSM.GenContentFQN(objFQN, fs.WorkfileType, fs.WorkfileAppend), time.Now())
+
+				// left behind by a listing (see query)
+				spill = saveWorkfile(fs.CSM.GenContentFQN(objFQN, fs.WorkfileType, fs.WorkfileSpill), old)
+
+				// owned by their respective services
+				tf = saveWorkfile(fs.CSM.FQN(mi, bck, fs.WorkfileType, "dir/obj.tf"), old)
+			)
+
+			GCWorkfiles(t.GetBowner(), stats.NewTrackerMock())
+
+			Expect(put).NotTo(BeAnExistingFile())
+			Expect(ec).NotTo(BeAnExistingFile())
+			Expect(cur).To(BeAnExistingFile())
+			Expect(spill).NotTo(BeAnExistingFile())
+			Expect(tf).To(BeAnExistingFile())
+		})
+	})
+
+	Describe("GCEmptyDirs", func() {
+		var (
+			t       *cluster.TargetMock
+			objPath string
+			bck     = cmn.Bck{Name: bucketName, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+			oldAge  = cmn.GCO.Get().Periodic.EmptyDirGCAge
+		)
+
+		makeDir := func(dir string, mtime time.Time) string {
+			dir = path.Join(objPath, dir)
+			Expect(cmn.CreateDir(dir)).NotTo(HaveOccurred())
+			Expect(os.Chtimes(dir, mtime, mtime)).NotTo(HaveOccurred())
+			return dir
+		}
+		setAge := func(age time.Duration) {
+			config := cmn.GCO.BeginUpdate()
+			config.Periodic.EmptyDirGCAge = age
+			cmn.GCO.CommitUpdate(config)
+		}
+
+		BeforeEach(func() {
+			createAndAddMountpath(basePath)
+			t = newTargetLRUMock()
+			mpaths, _ := fs.Mountpaths.Get()
+			objPath = mpaths[basePath].MakePathCT(bck, fs.ObjectType)
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(basePath)
+			setAge(oldAge)
+		})
+
+		It("should remove old empty directories, bottom-up", func() {
+			setAge(time.Hour)
+			var (
+				old    = time.Now().Add(-2 * time.Hour)
+				leaf   = makeDir("a/b/c", old)
+				young  = makeDir("d/e", time.Now())
+				parent = path.Dir(young)
+			)
+			for _, dir := range []string{path.Dir(leaf), path.Dir(path.Dir(leaf)), parent} {
+				Expect(os.Chtimes(dir, old, old)).NotTo(HaveOccurred())
+			}
+			nonEmpty := makeDir("f", old)
+			Expect(ioutil.WriteFile(path.Join(nonEmpty, "obj"), []byte("obj"), 0644)).NotTo(HaveOccurred())
+			Expect(os.Chtimes(nonEmpty, old, old)).NotTo(HaveOccurred())
+
+			GCEmptyDirs(t.GetBowner(), stats.NewTrackerMock())
+
+			Expect(path.Join(objPath, "a")).NotTo(BeADirectory())
+			Expect(young).To(BeADirectory())
+			Expect(nonEmpty).To(BeADirectory())
+			Expect(objPath).To(BeADirectory())
+		})
+
+		It("should keep directories younger than the grace period", func() {
+			setAge(time.Second)
+			var (
+				recent = makeDir("recent", time.Now().Add(-emptyDirGCGrace/2))
+				old    = makeDir("old", time.Now().Add(-2*emptyDirGCGrace))
+			)
+
+			GCEmptyDirs(t.GetBowner(), stats.NewTrackerMock())
+
+			Expect(recent).To(BeADirectory())
+			Expect(old).NotTo(BeADirectory())
+		})
+	})
 })
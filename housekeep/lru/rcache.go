@@ -0,0 +1,93 @@
+// Package lru provides least recently used cache replacement policy for stored objects
+// and serves as a generic garbage-collection mechanism for orphaned workfiles.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package lru
+
+import (
+	"container/heap"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// EvictRemoteCache enforces the capacity of the target-local remote cache bucket
+// (see cmn.RemoteCacheConf). Unlike the main LRU, remote cache eviction does not
+// depend on the local filesystem utilization: once the total size of the cached
+// objects exceeds the configured capacity, the least recently accessed objects
+// get evicted until the size drops below the low watermark.
+func EvictRemoteCache(t cluster.Target, statsT stats.Tracker) error {
+	var (
+		config    = cmn.GCO.Get()
+		conf      = &config.RemoteCache
+		bck       = cmn.Bck{Name: conf.Bucket, Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+		h         = &fileInfoMinHeap{}
+		totalSize int64
+	)
+	if !conf.Enabled {
+		return nil
+	}
+	heap.Init(h)
+	availablePaths, _ := fs.Mountpaths.Get()
+	for _, mpathInfo := range availablePaths {
+		opts := &fs.Options{
+			Mpath: mpathInfo,
+			Bck:   bck,
+			CTs:   []string{fs.ObjectType},
+			Callback: func(fqn string, de fs.DirEntry) error {
+				if de.IsDir() {
+					return nil
+				}
+				lom := &cluster.LOM{T: t, FQN: fqn}
+				if err := lom.Init(bck, config); err != nil {
+					return nil
+				}
+				if err := lom.Load(false); err != nil {
+					return nil
+				}
+				if lom.IsCopy() {
+					return nil
+				}
+				heap.Push(h, lom)
+				totalSize += lom.Size()
+				return nil
+			},
+			Sorted: false,
+		}
+		if err := fs.Walk(opts); err != nil {
+			return err
+		}
+	}
+	if totalSize <= conf.Capacity {
+		return nil
+	}
+
+	var (
+		lwm                = conf.Capacity * conf.LowWM / 100
+		fevicted, bevicted int64
+	)
+	glog.Infof("remote cache %s: size %s exceeds capacity %s, evicting...",
+		bck, cmn.B2S(totalSize, 2), cmn.B2S(conf.Capacity, 2))
+	for h.Len() > 0 && totalSize > lwm {
+		lom := heap.Pop(h).(*cluster.LOM)
+		lom.Lock(true)
+		err := lom.Remove()
+		lom.Unlock(true)
+		if err != nil {
+			glog.Errorf("%s: failed to remove, err: %v", lom, err)
+			continue
+		}
+		totalSize -= lom.Size()
+		bevicted += lom.Size()
+		fevicted++
+	}
+	statsT.AddMany(
+		stats.NamedVal64{Name: stats.RemoteCacheEvictCount, Value: fevicted},
+		stats.NamedVal64{Name: stats.RemoteCacheEvictSize, Value: bevicted},
+	)
+	return nil
+}
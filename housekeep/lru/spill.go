@@ -0,0 +1,103 @@
+// Package lru provides least recently used cache replacement policy for stored objects
+// and serves as a generic garbage-collection mechanism for orphaned workfiles.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package lru
+
+import (
+	"container/heap"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// SpillBucket runs asynchronously (as an LRU xaction) when a PUT into a given
+// Cloud-backed bucket finds the target out of space. For each mountpath above
+// the high watermark it evicts clean objects of the bucket - the objects that
+// are already stored in the Cloud and can be cold-GET again - until the
+// mountpath's used capacity drops below the high watermark. Objects are evicted
+// in the least-recently-used order; objects that are currently locked (e.g.,
+// being written) are skipped.
+func SpillBucket(ini *InitLRU, bck *cluster.Bck) (evicted int64, err error) {
+	var (
+		config            = cmn.GCO.Get()
+		dontEvictTime     = time.Now().Add(-config.LRU.DontEvictTime)
+		availablePaths, _ = fs.Mountpaths.Get()
+		fevicted          int64
+	)
+	cmn.Assert(bck.IsCloud())
+	for _, mpathInfo := range availablePaths {
+		var (
+			h         = &fileInfoMinHeap{}
+			totalSize int64
+		)
+		blocks, bavail, bsize, err := ini.GetFSStats(mpathInfo.Path)
+		if err != nil {
+			return evicted, err
+		}
+		used := blocks - bavail
+		hwmBlocks := blocks * uint64(config.LRU.HighWM) / 100
+		if used <= hwmBlocks {
+			continue
+		}
+		totalSize = int64(used-hwmBlocks) * bsize
+		heap.Init(h)
+		opts := &fs.Options{
+			Mpath: mpathInfo,
+			Bck:   bck.Bck,
+			CTs:   []string{fs.ObjectType},
+			Callback: func(fqn string, de fs.DirEntry) error {
+				if de.IsDir() {
+					return nil
+				}
+				if ini.Xaction.Aborted() {
+					return cmn.NewAbortedError(ini.Xaction.String())
+				}
+				lom := &cluster.LOM{T: ini.T, FQN: fqn}
+				if err := lom.Init(bck.Bck, config); err != nil {
+					return nil
+				}
+				if err := lom.Load(false); err != nil {
+					return nil
+				}
+				if lom.IsCopy() || lom.Atime().After(dontEvictTime) {
+					return nil
+				}
+				heap.Push(h, lom)
+				return nil
+			},
+			Sorted: false,
+		}
+		if err := fs.Walk(opts); err != nil {
+			return evicted, err
+		}
+		for h.Len() > 0 && totalSize > 0 {
+			lom := heap.Pop(h).(*cluster.LOM)
+			if !lom.TryLock(true) {
+				continue
+			}
+			err := lom.Remove()
+			lom.Unlock(true)
+			if err != nil {
+				glog.Errorf("%s: failed to remove, err: %v", lom, err)
+				continue
+			}
+			totalSize -= lom.Size()
+			evicted += lom.Size()
+			fevicted++
+		}
+	}
+	if fevicted > 0 {
+		glog.Infof("%s: spilled %d object(s) (%s) to free up space", bck, fevicted, cmn.B2S(evicted, 2))
+		ini.StatsT.AddMany(
+			stats.NamedVal64{Name: stats.LruEvictSize, Value: evicted},
+			stats.NamedVal64{Name: stats.LruEvictCount, Value: fevicted},
+		)
+	}
+	return evicted, nil
+}
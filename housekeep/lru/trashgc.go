@@ -0,0 +1,75 @@
+// Package lru provides least recently used cache replacement policy for stored objects
+// and serves as a generic garbage-collection mechanism for orphaned workfiles.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package lru
+
+import (
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+const trashGCIval = 10 * time.Minute
+
+// GCTrash removes the deleted objects whose time in the trash has expired (see
+// cluster.LOM.Trash) - in all buckets, including those that have had the trash
+// disabled since. Returns the time until the next run.
+func GCTrash(bowner cluster.Bowner, statsT stats.Tracker) time.Duration {
+	var (
+		now       = time.Now()
+		bmd       = bowner.Get()
+		cnt, size int64
+	)
+	availablePaths, _ := fs.Mountpaths.Get()
+	for _, mpathInfo := range availablePaths {
+		bmd.Range(nil, nil, func(bck *cluster.Bck) bool {
+			if !bck.IsAIS() {
+				return false
+			}
+			opts := &fs.Options{
+				Mpath: mpathInfo,
+				Bck:   bck.Bck,
+				CTs:   []string{fs.RecycleType},
+				Callback: func(fqn string, de fs.DirEntry) error {
+					if de.IsDir() || !cluster.TrashExpired(fqn, now) {
+						return nil
+					}
+					finfo, err := os.Stat(fqn)
+					if err != nil {
+						return nil
+					}
+					if err := os.Remove(fqn); err != nil {
+						if !os.IsNotExist(err) {
+							glog.Errorf("failed to remove trashed object %q: %v", fqn, err)
+						}
+						return nil
+					}
+					cnt++
+					size += finfo.Size()
+					return nil
+				},
+				Sorted:   false,
+				Throttle: true,
+			}
+			if err := fs.Walk(opts); err != nil {
+				glog.Errorf("%s: failed to empty trash: %v", bck, err)
+			}
+			return false
+		})
+	}
+	if cnt > 0 {
+		glog.Infof("removed %d expired trashed object(s), reclaimed %s", cnt, cmn.B2S(size, 2))
+		statsT.AddMany(
+			stats.NamedVal64{Name: stats.TrashGCCount, Value: cnt},
+			stats.NamedVal64{Name: stats.TrashGCSize, Value: size},
+		)
+	}
+	return trashGCIval
+}
@@ -0,0 +1,127 @@
+// Package lru provides least recently used cache replacement policy for stored objects
+// and serves as a generic garbage-collection mechanism for orphaned workfiles.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package lru
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/sys"
+)
+
+const (
+	workGCIvalDisabled = 10 * time.Minute // to recheck the configuration
+	workGCIvalMin      = time.Minute
+	workGCIvalMax      = time.Hour
+)
+
+// transient workfiles, by prefix (see fs.WorkfileContentResolver): they are only
+// open while being written (or, in case of list-spill, while the listing lasts)
+// and renamed or removed thereafter - unless the target crashes. Workfiles that
+// are meant to stay (e.g., tar2tf cache) are owned and cleaned up by their
+// respective services and must not be GC-ed.
+var transientWorkfiles = cmn.StringSet{
+	fs.WorkfileSpill:   {},
+	fs.WorkfilePut:     {},
+	fs.WorkfileAppend:  {},
+	fs.WorkfileWriteAt: {},
+	fs.WorkfileColdget: {},
+	fs.WorkfileRemote:  {},
+	"drain":            {}, // mirror
+	"":                 {}, // cluster.CT (EC)
+}
+
+func isTransientWorkfile(fqn string) bool {
+	var (
+		base = filepath.Base(fqn)
+		i    = strings.IndexByte(base, '.')
+	)
+	if i < 0 {
+		return false
+	}
+	if _, _, ok := fs.CSM.RegisteredContentTypes[fs.WorkfileType].ParseUniqueFQN(base); !ok {
+		return false
+	}
+	prefix := base[:i]
+	return transientWorkfiles.Contains(prefix) || strings.HasPrefix(prefix, "ec")
+}
+
+// GCWorkfiles removes the leftover transient workfiles (e.g., of crashed PUTs
+// and EC operations) that have not been modified for longer than
+// `periodic.workfile_gc_age`, in all buckets. Workfiles that are currently open
+// by the target are never removed. Unlike the main LRU, the cleanup runs
+// regardless of the filesystem utilization. Returns the time until the next run.
+func GCWorkfiles(bowner cluster.Bowner, statsT stats.Tracker) time.Duration {
+	var (
+		age          = cmn.GCO.Get().Periodic.WorkfileGCAge
+		expired      = time.Now().Add(-age)
+		bmd          = bowner.Get()
+		cnt, size    int64
+		openFiles, _ = sys.OpenFiles() // nil if unknown
+	)
+	if age <= 0 {
+		return workGCIvalDisabled
+	}
+	availablePaths, _ := fs.Mountpaths.Get()
+	for _, mpathInfo := range availablePaths {
+		bmd.Range(nil, nil, func(bck *cluster.Bck) bool {
+			opts := &fs.Options{
+				Mpath: mpathInfo,
+				Bck:   bck.Bck,
+				CTs:   []string{fs.WorkfileType},
+				Callback: func(fqn string, de fs.DirEntry) error {
+					if de.IsDir() || !isTransientWorkfile(fqn) {
+						return nil
+					}
+					finfo, err := os.Stat(fqn)
+					if err != nil || finfo.ModTime().After(expired) {
+						return nil
+					}
+					if _, ok := openFiles[fqn]; ok {
+						return nil
+					}
+					if err := os.Remove(fqn); err != nil {
+						if !os.IsNotExist(err) {
+							glog.Errorf("failed to remove workfile %q: %v", fqn, err)
+						}
+						return nil
+					}
+					cnt++
+					size += finfo.Size()
+					return nil
+				},
+				Sorted:   false,
+				Throttle: true,
+			}
+			if err := fs.Walk(opts); err != nil {
+				glog.Errorf("%s: failed to remove old workfiles: %v", bck, err)
+			}
+			return false
+		})
+	}
+	if cnt > 0 {
+		glog.Infof("removed %d leftover workfile(s), reclaimed %s", cnt, cmn.B2S(size, 2))
+		statsT.AddMany(
+			stats.NamedVal64{Name: stats.WorkfileGCCount, Value: cnt},
+			stats.NamedVal64{Name: stats.WorkfileGCSize, Value: size},
+		)
+	}
+	// run a few times within the age
+	ival := age / 4
+	if ival < workGCIvalMin {
+		ival = workGCIvalMin
+	} else if ival > workGCIvalMax {
+		ival = workGCIvalMax
+	}
+	return ival
+}
@@ -13,6 +13,7 @@ type (
 		IOMs() int64
 		WriteMs() int64
 		ReadMs() int64
+		IOs() int64 // completed reads and writes
 	}
 
 	diskBlockStats map[string]diskBlockStat
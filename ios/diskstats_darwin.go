@@ -16,6 +16,7 @@ type dblockStat struct {
 	writeBytes int64
 	writeMs    int64
 	ioMs       int64
+	ios        int64
 }
 
 var (
@@ -38,6 +39,7 @@ func readDiskStats(disks, sysfnames cmn.SimpleKVs) diskBlockStats {
 			writeBytes: driveStat.BytesWritten,
 			writeMs:    driveStat.TotalWriteTime.Milliseconds(),
 			ioMs:       driveStat.TotalReadTime.Milliseconds() + driveStat.TotalWriteTime.Milliseconds(),
+			ios:        driveStat.NumRead + driveStat.NumWrite,
 		}
 	}
 	return dblockStats
@@ -48,3 +50,6 @@ func (dbs dblockStat) WriteBytes() int64 { return dbs.writeBytes }
 func (dbs dblockStat) IOMs() int64       { return dbs.ioMs }
 func (dbs dblockStat) WriteMs() int64    { return dbs.writeMs }
 func (dbs dblockStat) ReadMs() int64     { return dbs.readMs }
+func (dbs dblockStat) IOs() int64        { return dbs.ios }
+
+func readDevErrs(string) int64 { return 0 }
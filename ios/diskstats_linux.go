@@ -7,6 +7,8 @@ package ios
 
 import (
 	"bufio"
+	"fmt"
+	"io/ioutil"
 	"os"
 	"strconv"
 	"strings"
@@ -105,3 +107,18 @@ func (dbs dblockStat) WriteBytes() int64 { return dbs.writeSectors * sectorSize
 func (dbs dblockStat) IOMs() int64       { return dbs.ioMs }
 func (dbs dblockStat) WriteMs() int64    { return dbs.writeMs }
 func (dbs dblockStat) ReadMs() int64     { return dbs.readMs }
+func (dbs dblockStat) IOs() int64        { return dbs.readComplete + dbs.writeComplete }
+
+// readDevErrs returns the number of I/O errors reported by the (SCSI) device,
+// zero if not available
+func readDevErrs(disk string) int64 {
+	b, err := ioutil.ReadFile(fmt.Sprintf("/sys/class/block/%s/device/ioerr_cnt", disk))
+	if err != nil {
+		return 0
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(string(b)), 0, 64) // e.g. "0x1a"
+	if err != nil {
+		return 0
+	}
+	return n
+}
@@ -37,6 +37,11 @@ type (
 	SelectedDiskStats struct {
 		RBps, WBps, Util int64
 	}
+	// MpathHealth: disk signals of a given mountpath (see ais/fspathwd.go)
+	MpathHealth struct {
+		Latency time.Duration // average latency of the I/Os completed in the last interval (max across the disks)
+		DevErrs int64         // total I/O errors reported by the disk devices, if available
+	}
 	ioStatCache struct {
 		expireTime time.Time
 		timestamp  time.Time
@@ -49,8 +54,11 @@ type (
 		diskWms    map[string]int64
 		diskWBytes map[string]int64
 		diskWBps   map[string]int64
+		diskIOs    map[string]int64
+		diskLat    map[string]int64 // µs
 
 		mpathUtil map[string]int64 // average utilization of the disks, max 100
+		mpathLat  map[string]int64 // max latency of the disks, µs
 		mpathRR   map[string]*atomic.Int32
 	}
 
@@ -61,6 +69,7 @@ type (
 		RemoveMpath(mpath string)
 		LogAppend(log []string) []string
 		GetSelectedDiskStats() (m map[string]*SelectedDiskStats)
+		GetMpathHealth(mpath string) MpathHealth
 	}
 )
 
@@ -90,7 +99,10 @@ func newIostatCache() *ioStatCache {
 		diskWms:    make(map[string]int64),
 		diskWBytes: make(map[string]int64),
 		diskWBps:   make(map[string]int64),
+		diskIOs:    make(map[string]int64),
+		diskLat:    make(map[string]int64),
 		mpathUtil:  make(map[string]int64),
+		mpathLat:   make(map[string]int64),
 		mpathRR:    make(map[string]*atomic.Int32),
 	}
 }
@@ -183,6 +195,17 @@ func (ctx *IostatContext) GetSelectedDiskStats() (m map[string]*SelectedDiskStat
 	return
 }
 
+func (ctx *IostatContext) GetMpathHealth(mpath string) (h MpathHealth) {
+	cache := ctx.refreshIostatCache()
+	h.Latency = time.Duration(cache.mpathLat[mpath]) * time.Microsecond
+	ctx.mpathLock.Lock()
+	for disk := range ctx.mpath2disks[mpath] {
+		h.DevErrs += readDevErrs(disk)
+	}
+	ctx.mpathLock.Unlock()
+	return
+}
+
 func (ctx *IostatContext) LogAppend(lines []string) []string {
 	var cache = ctx.refreshIostatCache()
 	for _, disk := range ctx.sorted {
@@ -243,6 +266,7 @@ func (ctx *IostatContext) refreshIostatCache(nows ...time.Time) *ioStatCache {
 	ncache.timestamp = now
 	for mpath := range ctx.mpath2disks {
 		ncache.mpathUtil[mpath] = 0
+		ncache.mpathLat[mpath] = 0
 		if rr, ok := ncache.mpathRR[mpath]; ok {
 			rr.Store(0)
 		} else {
@@ -260,6 +284,7 @@ func (ctx *IostatContext) refreshIostatCache(nows ...time.Time) *ioStatCache {
 		ncache.diskRBps[disk] = 0
 		ncache.diskWBps[disk] = 0
 		ncache.diskUtil[disk] = 0
+		ncache.diskLat[disk] = 0
 		stat, ok := disksStats[disk]
 		if !ok {
 			glog.Errorf("no block stats for disk %s", disk) // TODO: remove
@@ -270,6 +295,7 @@ func (ctx *IostatContext) refreshIostatCache(nows ...time.Time) *ioStatCache {
 		ncache.diskRBytes[disk] = stat.ReadBytes()
 		ncache.diskWms[disk] = stat.WriteMs()
 		ncache.diskWBytes[disk] = stat.WriteBytes()
+		ncache.diskIOs[disk] = stat.IOs()
 
 		if _, ok := statsCache.diskIOms[disk]; !ok {
 			missingInfo = true
@@ -280,7 +306,13 @@ func (ctx *IostatContext) refreshIostatCache(nows ...time.Time) *ioStatCache {
 			ioms       = stat.IOMs() - statsCache.diskIOms[disk]
 			readBytes  = stat.ReadBytes() - statsCache.diskRBytes[disk]
 			writeBytes = stat.WriteBytes() - statsCache.diskWBytes[disk]
+			rwms       = stat.ReadMs() + stat.WriteMs() - statsCache.diskRms[disk] - statsCache.diskWms[disk]
+			nios       = stat.IOs() - statsCache.diskIOs[disk]
 		)
+		if nios > 0 {
+			ncache.diskLat[disk] = cmn.DivRound(rwms*1000, nios)
+		}
+		ncache.mpathLat[mpath] = cmn.MaxI64(ncache.mpathLat[mpath], ncache.diskLat[disk])
 		if elapsedMillis > 0 {
 			ncache.diskUtil[disk] = cmn.DivRound(ioms*100, elapsedMillis)
 		} else {
@@ -18,13 +18,15 @@ var (
 
 type (
 	IOStaterMock struct {
-		Utils map[string]int64
+		Utils  map[string]int64
+		Health map[string]MpathHealth
 	}
 )
 
 func NewIOStaterMock() *IOStaterMock {
 	return &IOStaterMock{
-		Utils: make(map[string]int64, 10),
+		Utils:  make(map[string]int64, 10),
+		Health: make(map[string]MpathHealth, 10),
 	}
 }
 
@@ -36,3 +38,4 @@ func (m *IOStaterMock) AddMpath(mpath, fs string)                           {}
 func (m *IOStaterMock) RemoveMpath(mpath string)                            {}
 func (m *IOStaterMock) LogAppend(l []string) []string                       { return l }
 func (m *IOStaterMock) GetSelectedDiskStats() map[string]*SelectedDiskStats { return nil }
+func (m *IOStaterMock) GetMpathHealth(mpath string) MpathHealth             { return m.Health[mpath] }
@@ -0,0 +1,109 @@
+// Package ios is a collection of interfaces to the local storage subsystem;
+// the package includes OS-dependent implementations for those interfaces.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ios
+
+import (
+	"os"
+	"runtime"
+	"unsafe"
+
+	"golang.org/x/sys/unix"
+)
+
+// MappedFile is a file that is memory-mapped (read-only) for the purposes of
+// reading it into the OS page cache and, optionally, locking it there (mlock)
+type MappedFile struct {
+	data   []byte
+	locked bool
+}
+
+var pageSize = os.Getpagesize()
+
+// MapFile memory-maps the first `size` bytes of a given file
+func MapFile(fqn string, size int64) (mf *MappedFile, err error) {
+	mf = &MappedFile{}
+	if size == 0 {
+		return
+	}
+	file, err := os.Open(fqn)
+	if err != nil {
+		return nil, err
+	}
+	mf.data, err = unix.Mmap(int(file.Fd()), 0, int(size), unix.PROT_READ, unix.MAP_SHARED)
+	file.Close()
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// Warm reads the file into the page cache (touching each page of the mapping
+// is enough) and returns the number of bytes that are resident upon return
+func (mf *MappedFile) Warm() (resident int64, err error) {
+	if len(mf.data) == 0 {
+		return
+	}
+	_ = unix.Madvise(mf.data, unix.MADV_WILLNEED)
+	var sum byte
+	for off := 0; off < len(mf.data); off += pageSize {
+		sum += mf.data[off]
+	}
+	runtime.KeepAlive(sum) // (so that the compiler does not optimize out the reads)
+	return mf.resident()
+}
+
+// NOTE: the kernel may evict some pages right after having read them, which
+// is exactly what the caller wants to know
+func (mf *MappedFile) resident() (resident int64, err error) {
+	var (
+		pages = (len(mf.data) + pageSize - 1) / pageSize
+		vec   = make([]byte, pages)
+	)
+	_, _, errno := unix.Syscall(unix.SYS_MINCORE, uintptr(unsafe.Pointer(&mf.data[0])),
+		uintptr(len(mf.data)), uintptr(unsafe.Pointer(&vec[0])))
+	if errno != 0 {
+		return 0, errno
+	}
+	for i, v := range vec {
+		if v&1 == 0 {
+			continue
+		}
+		if i == pages-1 {
+			resident += int64(len(mf.data) - i*pageSize)
+		} else {
+			resident += int64(pageSize)
+		}
+	}
+	return
+}
+
+// Lock locks the mapped file in memory; fails if the process exceeds its
+// RLIMIT_MEMLOCK (or lacks CAP_IPC_LOCK)
+func (mf *MappedFile) Lock() (err error) {
+	if len(mf.data) == 0 {
+		return
+	}
+	if err = unix.Mlock(mf.data); err == nil {
+		mf.locked = true
+	}
+	return
+}
+
+func (mf *MappedFile) Size() int64 { return int64(len(mf.data)) }
+
+// Close unlocks (if locked) and unmaps the file
+func (mf *MappedFile) Close() (err error) {
+	if len(mf.data) == 0 {
+		return
+	}
+	if mf.locked {
+		_ = unix.Munlock(mf.data)
+		mf.locked = false
+	}
+	err = unix.Munmap(mf.data)
+	mf.data = nil
+	return
+}
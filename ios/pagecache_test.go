@@ -0,0 +1,57 @@
+// Package ios is a collection of interfaces to the local storage subsystem;
+// the package includes OS-dependent implementations for those interfaces.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ios
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+)
+
+func TestMappedFileWarm(t *testing.T) {
+	file, err := ioutil.TempFile("/tmp", t.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	size := int64(3*pageSize + 100)
+	if _, err := file.Write(make([]byte, size)); err != nil {
+		t.Fatal(err)
+	}
+	file.Close()
+
+	mf, err := MapFile(file.Name(), size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer mf.Close()
+	resident, err := mf.Warm()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resident <= 0 || resident > size {
+		t.Errorf("resident %d, expected (0, %d]", resident, size)
+	}
+	if mf.Size() != size {
+		t.Errorf("size %d, expected %d", mf.Size(), size)
+	}
+}
+
+func TestMappedFileEmpty(t *testing.T) {
+	mf, err := MapFile("/nonexistent", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resident, err := mf.Warm(); err != nil || resident != 0 {
+		t.Errorf("resident %d (err %v), expected 0", resident, err)
+	}
+	if err := mf.Lock(); err != nil {
+		t.Error(err)
+	}
+	if err := mf.Close(); err != nil {
+		t.Error(err)
+	}
+}
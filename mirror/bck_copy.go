@@ -22,6 +22,7 @@ type (
 		slab    *memsys.Slab
 		bckFrom *cluster.Bck
 		bckTo   *cluster.Bck
+		tracked cluster.TrackedPuts // see TrackPut
 	}
 	bccJogger struct { // one per mountpath
 		joggerBckBase
@@ -47,16 +48,73 @@ func (r *XactBckCopy) Run() (err error) {
 	mpathCount := r.init()
 	glog.Infoln(r.String(), r.bckFrom.Bck, "=>", r.bckTo.Bck)
 	err = r.xactBckBase.run(mpathCount)
+	if err == nil {
+		r.copyTracked()
+	} else {
+		r.tracked.Close()
+	}
 	r.Finish(err)
 	return
 }
 
-func (r *XactBckCopy) String() string { return fmt.Sprintf("%s <= %s", r.XactBase.String(), r.bckFrom) }
+func (r *XactBckCopy) String() string        { return fmt.Sprintf("%s <= %s", r.XactBase.String(), r.bckFrom) }
+func (r *XactBckCopy) BckFrom() *cluster.Bck { return r.bckFrom }
+
+// TrackPut implements cluster.PutTracker: the objects PUT into the source
+// bucket while the joggers walk it get copied (again) once the walk is done
+func (r *XactBckCopy) TrackPut(lom *cluster.LOM) bool { return r.tracked.Add(lom.ObjName) }
+
+// ProcessPut implements cluster.PutTracker
+func (r *XactBckCopy) ProcessPut(lom *cluster.LOM) error {
+	if r.Aborted() {
+		return nil
+	}
+	buf := r.slab.Alloc()
+	_, err := r.copyOne(lom.ObjName, buf)
+	r.slab.Free(buf)
+	return err
+}
 
 //
 // private methods
 //
 
+// copy the objects PUT during the walk, including those that get PUT while
+// copying, until the tracker is closed
+func (r *XactBckCopy) copyTracked() {
+	buf := r.slab.Alloc()
+	defer r.slab.Free(buf)
+	for {
+		names := r.tracked.Drain()
+		if len(names) == 0 {
+			if names = r.tracked.Close(); len(names) == 0 {
+				return
+			}
+		}
+		for _, objName := range names {
+			if r.Aborted() {
+				r.tracked.Close()
+				return
+			}
+			if _, err := r.copyOne(objName, buf); err != nil && !cmn.IsErrObjNought(err) {
+				glog.Errorf("%s: failed to copy %s: %v", r, objName, err)
+			}
+		}
+	}
+}
+
+func (r *XactBckCopy) copyOne(objName string, buf []byte) (copied bool, err error) {
+	lom := &cluster.LOM{T: r.Target(), ObjName: objName}
+	if err = lom.Init(r.bckFrom.Bck); err != nil {
+		return
+	}
+	if copied, err = r.Target().CopyObject(lom, r.bckTo, buf, false); copied {
+		r.ObjectsInc()
+		r.BytesAdd(lom.Size() + lom.Size())
+	}
+	return
+}
+
 func (r *XactBckCopy) init() (mpathCount int) {
 	var (
 		availablePaths, _ = fs.Mountpaths.Get()
@@ -97,6 +155,7 @@ func newBCCJogger(parent *XactBckCopy, mpathInfo *fs.MountpathInfo, config *cmn.
 func (j *bccJogger) jog() {
 	glog.Infof("jogger[%s/%s] started", j.mpathInfo, j.parent.bckFrom.Bck)
 	j.buf = j.parent.slab.Alloc()
+	j.count()
 	j.joggerBckBase.jog()
 	j.parent.slab.Free(j.buf)
 }
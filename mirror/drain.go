@@ -0,0 +1,276 @@
+// Package mirror provides local mirroring and replica management
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package mirror
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// XactMpathDrain safely removes a mountpath. Unlike the regular removal that
+// detaches the mountpath right away and relies on resilvering (and on the
+// redundancy provided by other targets), the xaction first copies the
+// mountpath's content - objects and all other content types that are
+// permitted to move (e.g., EC slices and metafiles) - to the locations the
+// content will have once the mountpath is gone (see cluster.HrwMpathExcluding),
+// while the mountpath keeps serving reads. Next, it verifies that each file
+// has a valid copy in its new location, copying again those that do not
+// (e.g., the objects PUT in the meantime). Only when a verification pass
+// finds nothing to copy, does the xaction remove the mountpath.
+
+const drainMaxPasses = 4 // the first pass copies, the rest verify (and copy what's missing)
+
+type (
+	XactMpathDrain struct {
+		cmn.XactBase
+		t         cluster.Target
+		mpathInfo *fs.MountpathInfo
+		remove    func(mpath string) error // called once the content is verified to be in place
+		buf       []byte
+		// progress
+		pass     atomic.Int64 // current pass, starting from 1
+		total    atomic.Int64 // number of files to drain
+		walked   atomic.Int64 // number of files walked in the current pass
+		copied   atomic.Int64 // number of files copied (all passes)
+		verified atomic.Int64 // number of files found in place in the current pass
+		errCount atomic.Int64
+	}
+
+	MpathDrainTargetStats struct {
+		cmn.BaseXactStats
+		Ext ExtMpathDrainStats `json:"ext"`
+	}
+	ExtMpathDrainStats struct {
+		Mpath    string `json:"mpath"`
+		Pass     int64  `json:"drain.pass"`
+		Total    int64  `json:"drain.total.n,string"`
+		Walked   int64  `json:"drain.walked.n,string"`
+		Copied   int64  `json:"drain.copied.n,string"`
+		Verified int64  `json:"drain.verified.n,string"`
+		ErrCount int64  `json:"drain.err.n,string"`
+		PctDone  int    `json:"drain.pct"` // percentage complete (current pass)
+	}
+)
+
+var (
+	// interface guard
+	_ cmn.XactStats = &MpathDrainTargetStats{}
+)
+
+func NewXactMpathDrain(id string, t cluster.Target, mpathInfo *fs.MountpathInfo,
+	remove func(mpath string) error) *XactMpathDrain {
+	return &XactMpathDrain{
+		XactBase:  *cmn.NewXactBase(cmn.XactBaseID(id), cmn.ActDrainMountpath),
+		t:         t,
+		mpathInfo: mpathInfo,
+		remove:    remove,
+	}
+}
+
+// NOTE: not aborted upon mountpath events - the passes that follow take care of
+// the content that has changed its location in the meantime
+func (r *XactMpathDrain) IsMountpathXact() bool { return false }
+func (r *XactMpathDrain) Mpath() string         { return r.mpathInfo.Path }
+
+func (r *XactMpathDrain) String() string {
+	return fmt.Sprintf("%s, mountpath %s", r.XactBase.String(), r.mpathInfo)
+}
+
+func (r *XactMpathDrain) Stats() cmn.XactStats {
+	baseStats := r.XactBase.Stats().(*cmn.BaseXactStats)
+	drainStats := MpathDrainTargetStats{BaseXactStats: *baseStats}
+	drainStats.Ext.Mpath = r.mpathInfo.Path
+	drainStats.Ext.Pass = r.pass.Load()
+	drainStats.Ext.Total = r.total.Load()
+	drainStats.Ext.Walked = r.walked.Load()
+	drainStats.Ext.Copied = r.copied.Load()
+	drainStats.Ext.Verified = r.verified.Load()
+	drainStats.Ext.ErrCount = r.errCount.Load()
+	if drainStats.Ext.Total > 0 {
+		drainStats.Ext.PctDone = int(cmn.MinI64(drainStats.Ext.Walked*100/drainStats.Ext.Total, 100))
+	}
+	return &drainStats
+}
+
+func (r *XactMpathDrain) Run() (err error) {
+	defer func() { r.Finish(err) }()
+	glog.Infoln(r.String())
+
+	slab, err := r.t.GetMMSA().GetSlab(memsys.MaxPageSlabSize)
+	if err != nil {
+		return
+	}
+	r.buf = slab.Alloc()
+	defer slab.Free(r.buf)
+
+	if err = r.traverse(r.count); err != nil {
+		return
+	}
+	for pass := int64(1); pass <= drainMaxPasses; pass++ {
+		r.pass.Store(pass)
+		r.walked.Store(0)
+		r.verified.Store(0)
+		var (
+			copied = r.copied.Load()
+			errs   = r.errCount.Load()
+		)
+		if err = r.traverse(r.drain); err != nil {
+			return
+		}
+		if pass > 1 && r.copied.Load() == copied && r.errCount.Load() == errs {
+			// verified: all the content is in place
+			glog.Infof("%s: verified %d file(s), removing", r, r.verified.Load())
+			err = r.remove(r.mpathInfo.Path)
+			return
+		}
+	}
+	err = fmt.Errorf("%s: failed to verify the content in %d passes (copied %d, errors %d) - not removing",
+		r, drainMaxPasses, r.copied.Load(), r.errCount.Load())
+	return
+}
+
+// content types to drain
+func drainCTs() []string {
+	cts := make([]string, 0, len(fs.CSM.RegisteredContentTypes))
+	for contentType, spec := range fs.CSM.RegisteredContentTypes {
+		if spec.PermToMove() {
+			cts = append(cts, contentType)
+		}
+	}
+	return cts
+}
+
+// walks the mountpath's content of all the buckets
+func (r *XactMpathDrain) traverse(callback fs.WalkFunc) (err error) {
+	opts := &fs.Options{
+		Mpath:    r.mpathInfo,
+		CTs:      drainCTs(),
+		Callback: callback,
+		Sorted:   false,
+	}
+	r.t.GetBowner().Get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		opts.Bck = bck.Bck
+		if err = fs.Walk(opts); err != nil {
+			if cmn.IsErrBucketLevel(err) {
+				err = nil // the bucket has been destroyed in the meantime
+				return false
+			}
+			return true
+		}
+		return false
+	})
+	return
+}
+
+func (r *XactMpathDrain) count(_ string, de fs.DirEntry) error {
+	if r.Aborted() {
+		return cmn.NewAbortedErrorDetails("traversal", r.String())
+	}
+	if !de.IsDir() {
+		r.total.Inc()
+	}
+	return nil
+}
+
+func (r *XactMpathDrain) drain(fqn string, de fs.DirEntry) error {
+	if r.Aborted() {
+		return cmn.NewAbortedErrorDetails("traversal", r.String())
+	}
+	if de.IsDir() {
+		return nil
+	}
+	r.walked.Inc()
+	ct, err := cluster.NewCTFromFQN(fqn, r.t.GetBowner())
+	if err != nil {
+		if cmn.IsErrBucketLevel(err) {
+			return err
+		}
+		return nil
+	}
+	uname := ct.Bck().MakeUname(ct.ObjName())
+	mi, err := cluster.HrwMpathExcluding(uname, r.mpathInfo.Path)
+	if err != nil {
+		return err
+	}
+	dstFQN := mi.MakePathFQN(ct.Bck().Bck, ct.ContentType(), ct.ObjName())
+	if ct.ContentType() == fs.ObjectType {
+		err = r.drainObject(fqn, dstFQN)
+	} else {
+		err = r.drainFile(fqn, dstFQN)
+	}
+	if err != nil {
+		glog.Errorf("%s: failed to drain %q => %q: %v", r, fqn, dstFQN, err)
+		r.errCount.Inc()
+	}
+	return nil
+}
+
+// copies the object unless it already has a valid copy in its new location
+func (r *XactMpathDrain) drainObject(fqn, dstFQN string) error {
+	lom := &cluster.LOM{T: r.t, FQN: fqn}
+	if err := lom.Init(cmn.Bck{}); err != nil {
+		return err
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false); err != nil {
+		if cmn.IsErrObjNought(err) {
+			return nil // removed in the meantime
+		}
+		return err
+	}
+	dst := &cluster.LOM{T: r.t, FQN: dstFQN}
+	if err := dst.Init(cmn.Bck{}); err != nil {
+		return err
+	}
+	if err := dst.FromFS(); err == nil && dst.Size() == lom.Size() {
+		if lom.Cksum() == nil || dst.Cksum().Equal(lom.Cksum()) {
+			r.verified.Inc()
+			return nil
+		}
+	}
+	if _, err := lom.CopyObject(dstFQN, r.buf); err != nil {
+		return err
+	}
+	r.copied.Inc()
+	r.ObjectsInc()
+	r.BytesAdd(lom.Size())
+	return nil
+}
+
+// copies the file unless its new location already has a file of the same size
+func (r *XactMpathDrain) drainFile(fqn, dstFQN string) error {
+	finfo, err := os.Stat(fqn)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil // removed in the meantime
+		}
+		return err
+	}
+	if dinfo, err := os.Stat(dstFQN); err == nil && dinfo.Size() == finfo.Size() {
+		r.verified.Inc()
+		return nil
+	}
+	workFQN := fs.CSM.GenContentFQN(dstFQN, fs.WorkfileType, "drain")
+	if _, _, err = cmn.CopyFile(fqn, workFQN, r.buf, cmn.ChecksumNone); err == nil {
+		err = cmn.Rename(workFQN, dstFQN)
+	}
+	if err != nil {
+		if errRemove := cmn.RemoveFile(workFQN); errRemove != nil {
+			glog.Errorf("nested err: %v", errRemove)
+		}
+		return err
+	}
+	r.copied.Inc()
+	r.BytesAdd(finfo.Size())
+	return nil
+}
@@ -0,0 +1,144 @@
+// Package mirror provides local mirroring and replica management
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package mirror
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("MpathDrain", func() {
+	const (
+		tmpDir  = "/tmp/drain_test"
+		drained = tmpDir + "/mp1"
+		numObjs = 50
+	)
+
+	var (
+		oldMountpaths *fs.MountedFS
+		bck           = cluster.NewBck("bck", cmn.ProviderAIS, cmn.NsGlobal,
+			&cmn.BucketProps{Cksum: cmn.CksumConf{Type: cmn.ChecksumXXHash}})
+		tMock = cluster.NewTargetMock(cluster.NewBaseBownerMock(bck))
+	)
+
+	// creates the object in its HRW location
+	createObject := func(objName, content string) *cluster.LOM {
+		lom := &cluster.LOM{T: tMock, ObjName: objName}
+		Expect(lom.Init(bck.Bck)).NotTo(HaveOccurred())
+		Expect(cmn.CreateDir(filepath.Dir(lom.FQN))).NotTo(HaveOccurred())
+		Expect(ioutil.WriteFile(lom.FQN, []byte(content), 0644)).NotTo(HaveOccurred())
+		lom.SetSize(int64(len(content)))
+		cksum, err := lom.ComputeCksumIfMissing()
+		Expect(err).NotTo(HaveOccurred())
+		lom.SetCksum(cksum)
+		Expect(lom.Persist()).NotTo(HaveOccurred())
+		lom.Uncache()
+		return lom
+	}
+
+	// runs the xaction to completion; returns the removed mountpath
+	runDrain := func() (*XactMpathDrain, string) {
+		var (
+			removed           string
+			availablePaths, _ = fs.Mountpaths.Get()
+		)
+		xdrain := NewXactMpathDrain("drain-id", tMock, availablePaths[drained], func(mpath string) error {
+			removed = mpath
+			return fs.Mountpaths.Remove(mpath)
+		})
+		Expect(xdrain.Run()).NotTo(HaveOccurred())
+		return xdrain, removed
+	}
+
+	cluster.InitTarget()
+
+	BeforeEach(func() {
+		oldMountpaths = fs.Mountpaths
+		fs.Mountpaths = fs.NewMountedFS()
+		fs.Mountpaths.DisableFsIDCheck()
+		for i := 0; i < 3; i++ {
+			mpath := fmt.Sprintf("%s/mp%d", tmpDir, i)
+			Expect(cmn.CreateDir(mpath)).NotTo(HaveOccurred())
+			Expect(fs.Mountpaths.Add(mpath)).NotTo(HaveOccurred())
+		}
+		_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+		_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	})
+
+	AfterEach(func() {
+		fs.Mountpaths = oldMountpaths
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should copy the content and remove the mountpath once verified", func() {
+		var onDrained []string
+		for i := 0; i < numObjs; i++ {
+			objName := fmt.Sprintf("obj-%d", i)
+			if lom := createObject(objName, "content of "+objName); lom.ParsedFQN.MpathInfo.Path == drained {
+				onDrained = append(onDrained, objName)
+			}
+		}
+		Expect(onDrained).NotTo(BeEmpty())
+
+		xdrain, removed := runDrain()
+		Expect(removed).To(Equal(drained))
+		stats := xdrain.Stats().(*MpathDrainTargetStats)
+		Expect(stats.Ext.Total).To(BeEquivalentTo(len(onDrained)))
+		Expect(stats.Ext.Copied).To(BeEquivalentTo(len(onDrained)))
+		Expect(stats.Ext.Pass).To(BeEquivalentTo(2))
+		Expect(stats.Ext.Verified).To(BeEquivalentTo(len(onDrained)))
+		Expect(stats.Ext.ErrCount).To(BeZero())
+
+		// all the objects are in their (new) HRW locations
+		for i := 0; i < numObjs; i++ {
+			objName := fmt.Sprintf("obj-%d", i)
+			lom := &cluster.LOM{T: tMock, ObjName: objName}
+			Expect(lom.Init(bck.Bck)).NotTo(HaveOccurred())
+			Expect(lom.ParsedFQN.MpathInfo.Path).NotTo(Equal(drained))
+			Expect(lom.Load(false)).NotTo(HaveOccurred())
+			Expect(lom.ValidateContentChecksum()).NotTo(HaveOccurred())
+			b, err := ioutil.ReadFile(lom.FQN)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(b)).To(Equal("content of " + objName))
+		}
+	})
+
+	It("should replace the copy that does not match the object", func() {
+		var objName string
+		for i := 0; objName == ""; i++ {
+			name := fmt.Sprintf("obj-%d", i)
+			if lom := createObject(name, "good content"); lom.ParsedFQN.MpathInfo.Path == drained {
+				objName = name
+			}
+		}
+		// a stale copy (same size, different checksum) in the new location
+		mi, err := cluster.HrwMpathExcluding(bck.MakeUname(objName), drained)
+		Expect(err).NotTo(HaveOccurred())
+		stale := &cluster.LOM{T: tMock, FQN: mi.MakePathFQN(bck.Bck, fs.ObjectType, objName)}
+		Expect(stale.Init(cmn.Bck{})).NotTo(HaveOccurred())
+		Expect(cmn.CreateDir(filepath.Dir(stale.FQN))).NotTo(HaveOccurred())
+		Expect(ioutil.WriteFile(stale.FQN, []byte("stale conten"), 0644)).NotTo(HaveOccurred())
+		stale.SetSize(int64(len("stale conten")))
+		cksum, err := stale.ComputeCksumIfMissing()
+		Expect(err).NotTo(HaveOccurred())
+		stale.SetCksum(cksum)
+		Expect(stale.Persist()).NotTo(HaveOccurred())
+		stale.Uncache()
+
+		_, removed := runDrain()
+		Expect(removed).To(Equal(drained))
+		b, err := ioutil.ReadFile(stale.FQN)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(b)).To(Equal("good content"))
+	})
+})
@@ -21,6 +21,9 @@ type (
 		DoneCh() chan struct{}
 		Target() cluster.Target
 		Mpathers() map[string]mpather
+		WaitIfPaused()
+		TotalAdd(cnt int64) int64
+		DoneInc() int64
 	}
 	xactBckBase struct {
 		// implements cmn.Xact and cmn.Runner interfaces
@@ -114,6 +117,7 @@ func (j *joggerBckBase) jog() {
 		CTs:      []string{fs.ObjectType},
 		Callback: j.walk,
 		Sorted:   false,
+		Throttle: true,
 	}
 	if err := fs.Walk(opts); err != nil {
 		if errors.As(err, &cmn.AbortedError{}) {
@@ -126,9 +130,11 @@ func (j *joggerBckBase) jog() {
 }
 
 func (j *joggerBckBase) walk(fqn string, de fs.DirEntry) error {
+	j.parent.WaitIfPaused()
 	if de.IsDir() {
 		return nil
 	}
+	j.parent.DoneInc()
 	lom := &cluster.LOM{T: j.parent.Target(), FQN: fqn}
 	err := lom.Init(j.bck, j.config)
 	if err != nil {
@@ -145,6 +151,26 @@ func (j *joggerBckBase) walk(fqn string, de fs.DirEntry) error {
 	return j.callback(lom)
 }
 
+// counts the objects to walk - for the xactions that report their progress
+func (j *joggerBckBase) count() {
+	var cnt int64
+	opts := &fs.Options{
+		Mpath: j.mpathInfo,
+		Bck:   j.bck,
+		CTs:   []string{fs.ObjectType},
+		Callback: func(_ string, de fs.DirEntry) error {
+			if !de.IsDir() {
+				cnt++
+			}
+			return nil
+		},
+	}
+	if err := fs.Walk(opts); err != nil {
+		glog.Errorf("jogger[%s/%s]: failed to count objects: %v", j.mpathInfo, j.bck, err)
+	}
+	j.parent.TotalAdd(cnt)
+}
+
 // [throttle]
 func (j *joggerBckBase) yieldTerm() error {
 	diskConf := &j.config.Disk
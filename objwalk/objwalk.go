@@ -7,6 +7,7 @@ package objwalk
 import (
 	"context"
 	"io"
+	"os"
 
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -93,6 +94,9 @@ func (w *Walk) CloudObjPage() (*cmn.BucketList, error) {
 		needCksum   = w.msg.WantProp(cmn.GetPropsChecksum)
 		needVersion = w.msg.WantProp(cmn.GetPropsVersion)
 		needCopies  = w.msg.WantProp(cmn.GetPropsCopies)
+		needAlloc   = w.msg.WantProp(cmn.GetPropsAlloc)
+
+		customKeys, customAll = w.msg.CustomMDKeys()
 	)
 
 	for _, e := range objList.Entries {
@@ -122,8 +126,7 @@ func (w *Walk) CloudObjPage() (*cmn.BucketList, error) {
 			e.Atime = cmn.FormatUnixNano(lom.AtimeUnix(), w.msg.TimeFormat)
 		}
 		if needCksum && lom.Cksum() != nil {
-			_, storedCksum := lom.Cksum().Get()
-			e.Checksum = storedCksum
+			e.CksumType, e.Checksum = lom.Cksum().Get()
 		}
 		if needVersion && lom.Version() != "" {
 			e.Version = lom.Version()
@@ -131,6 +134,14 @@ func (w *Walk) CloudObjPage() (*cmn.BucketList, error) {
 		if needCopies {
 			e.Copies = int16(lom.NumCopies())
 		}
+		if needAlloc {
+			if fi, err := os.Stat(lom.FQN); err == nil {
+				e.AllocSize = cmn.AllocatedSize(fi)
+			}
+		}
+		if customAll || len(customKeys) > 0 {
+			e.SetCustomMD(lom.CustomMD(), customKeys, customAll)
+		}
 
 		if postCallback != nil {
 			postCallback(lom)
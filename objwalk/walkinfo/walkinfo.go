@@ -25,6 +25,8 @@ type (
 		postCallback PostCallbackFunc
 		objectFilter cluster.ObjectFilter
 		propNeeded   map[string]bool
+		customKeys   []string // custom metadata keys to return (see cmn.SelectMsg.CustomMDKeys)
+		customAll    bool     // return the entire custom metadata
 		prefix       string
 		Marker       string
 		markerDir    string
@@ -50,6 +52,7 @@ var (
 		cmn.GetPropsStatus,
 		cmn.GetPropsCopies,
 		cmn.GetTargetURL,
+		cmn.GetPropsAlloc,
 	}
 )
 
@@ -71,6 +74,7 @@ func NewWalkInfo(ctx context.Context, t cluster.Target, bucket string, msg *cmn.
 	for _, prop := range wiProps {
 		propNeeded[prop] = msg.WantProp(prop)
 	}
+	customKeys, customAll := msg.CustomMDKeys()
 	return &WalkInfo{
 		t:            t, // targetrunner
 		smap:         t.GetSowner().Get(),
@@ -83,6 +87,8 @@ func NewWalkInfo(ctx context.Context, t cluster.Target, bucket string, msg *cmn.
 		fast:         msg.Fast,
 		timeFormat:   msg.TimeFormat,
 		propNeeded:   propNeeded,
+		customKeys:   customKeys,
+		customAll:    customAll,
 	}
 }
 
@@ -96,6 +102,7 @@ func NewDefaultWalkInfo(t cluster.Target, bucket string) *WalkInfo {
 		smap:       t.GetSowner().Get(),
 		bucket:     bucket,
 		propNeeded: propNeeded,
+		customAll:  true,
 	}
 }
 
@@ -106,6 +113,7 @@ func (wi *WalkInfo) needVersion() bool   { return wi.propNeeded[cmn.GetPropsVers
 func (wi *WalkInfo) needStatus() bool    { return wi.propNeeded[cmn.GetPropsStatus] } //nolint:unused // left for consistency
 func (wi *WalkInfo) needCopies() bool    { return wi.propNeeded[cmn.GetPropsCopies] }
 func (wi *WalkInfo) needTargetURL() bool { return wi.propNeeded[cmn.GetTargetURL] }
+func (wi *WalkInfo) needAlloc() bool     { return wi.propNeeded[cmn.GetPropsAlloc] }
 
 // Checks if the directory should be processed by cache list call
 // Does checks:
@@ -124,6 +132,10 @@ func (wi *WalkInfo) ProcessDir(fqn string) error {
 	if wi.prefix != "" && !(strings.HasPrefix(wi.prefix, ct.ObjName()) || strings.HasPrefix(ct.ObjName(), wi.prefix)) {
 		return filepath.SkipDir
 	}
+	// EC containers are internal (see cmn.ECPackPrefix)
+	if cmn.IsECPackName(ct.ObjName() + "/") {
+		return filepath.SkipDir
+	}
 
 	// When markerDir = "b/c/d/" we should skip directories: "a/", "b/a/",
 	// "b/b/" etc. but should not skip entire "b/" or "b/c/" since it is our
@@ -158,6 +170,9 @@ func (wi *WalkInfo) lsObject(lom *cluster.LOM, objStatus uint16) *cmn.BucketEntr
 	if wi.Marker != "" && cmn.PageMarkerIncludesObject(wi.Marker, objName) {
 		return nil
 	}
+	if cmn.IsECPackName(objName) {
+		return nil
+	}
 	if wi.objectFilter != nil && !wi.objectFilter(lom) {
 		return nil
 	}
@@ -173,8 +188,7 @@ func (wi *WalkInfo) lsObject(lom *cluster.LOM, objStatus uint16) *cmn.BucketEntr
 		fileInfo.Atime = cmn.FormatUnixNano(lom.AtimeUnix(), wi.timeFormat)
 	}
 	if wi.needCksum() && lom.Cksum() != nil {
-		_, storedCksum := lom.Cksum().Get()
-		fileInfo.Checksum = storedCksum
+		fileInfo.CksumType, fileInfo.Checksum = lom.Cksum().Get()
 	}
 	if wi.needVersion() {
 		fileInfo.Version = lom.Version()
@@ -185,6 +199,14 @@ func (wi *WalkInfo) lsObject(lom *cluster.LOM, objStatus uint16) *cmn.BucketEntr
 	if wi.needTargetURL() {
 		fileInfo.TargetURL = wi.t.Snode().URL(cmn.NetworkPublic)
 	}
+	if wi.needAlloc() {
+		if fi, err := os.Stat(lom.FQN); err == nil {
+			fileInfo.AllocSize = cmn.AllocatedSize(fi)
+		}
+	}
+	if wi.customAll || len(wi.customKeys) > 0 {
+		fileInfo.SetCustomMD(lom.CustomMD(), wi.customKeys, wi.customAll)
+	}
 	fileInfo.Size = lom.Size()
 	if wi.postCallback != nil {
 		wi.postCallback(lom)
@@ -0,0 +1,274 @@
+// Package query provides interface to iterate over objects with additional filtering
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package query
+
+import (
+	"bufio"
+	"os"
+	"sync"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// resultQueue holds the listing results that the walk has produced and the
+// consumer has not fetched (discarded) yet. The first memEntriesLimit results
+// are kept in memory, the rest is appended to a workfile and read back as the
+// consumer gets to it. When the workfile reaches spillEntriesLimit the walk
+// pauses until the consumer catches up - a slow client costs some disk space
+// but neither the target's memory nor a full walk of the bucket.
+// The results of a listing without a handle cannot be fetched by the next
+// request - such walk stays at most walkAheadLimit results ahead, in memory,
+// unless the consumer waits for more (see demand).
+
+const (
+	memEntriesLimit   = 64 * 1024
+	spillEntriesLimit = 4 * 1024 * 1024
+	walkAheadLimit    = 1024
+)
+
+type (
+	resultQueue struct {
+		mtx       sync.Mutex
+		bck       cmn.Bck
+		id        string // to place the workfile (see newSpillFile)
+		mem       []*cmn.BucketEntry
+		spill     *spillFile // nil when nothing is spilled
+		spillable bool       // the results can be fetched by the requests that follow (have a handle)
+		noSpill   bool       // failed to spill - keeping everything in memory
+		err       error      // walk error (returned after the results that precede it)
+		done      bool       // walk has finished
+		wanted    int        // the number of results the consumer waits for (see demand)
+		walkCh    chan struct{}
+		readyCh   chan struct{}
+	}
+	spillFile struct {
+		fqn string
+		w   *os.File
+		bw  *bufio.Writer
+		r   *os.File
+		br  *bufio.Reader
+		n   int // number of entries in the file not read back yet
+	}
+)
+
+func newResultQueue(bck cmn.Bck, id string) *resultQueue {
+	if id == "" {
+		id = cmn.GenUUID()
+	}
+	return &resultQueue{
+		bck:     bck,
+		id:      id,
+		walkCh:  make(chan struct{}, 1),
+		readyCh: make(chan struct{}, 1),
+	}
+}
+
+func notify(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// put appends the entry; returns true when the queue is full and the walk
+// must wait (see walkCh) until the consumer fetches some of the results
+func (q *resultQueue) put(entry *cmn.BucketEntry) (full bool) {
+	q.mtx.Lock()
+	if q.spill == nil && (len(q.mem) < memEntriesLimit || !q.spillable || q.noSpill) {
+		q.mem = append(q.mem, entry)
+	} else if err := q.spillEntry(entry); err != nil {
+		glog.Errorf("%s: failed to spill list-objects results, keeping them in memory: %v", q.bck, err)
+		q.noSpill = true
+		q.mem = append(q.mem, entry)
+	}
+	full = q._full()
+	q.mtx.Unlock()
+	notify(q.readyCh)
+	return
+}
+
+func (q *resultQueue) full() bool {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return q._full()
+}
+
+func (q *resultQueue) _full() bool {
+	if !q.spillable {
+		return len(q.mem) >= cmn.Max(walkAheadLimit, q.wanted)
+	}
+	return q.spill != nil && q.spill.n >= spillEntriesLimit
+}
+
+// demand tells the queue how many results the consumer is about to wait for
+// (math.MaxInt32 - all of them) so that the walk does not pause before it
+// produces as many
+func (q *resultQueue) demand(n int) {
+	q.mtx.Lock()
+	q.wanted = n
+	q.mtx.Unlock()
+	notify(q.walkCh)
+}
+
+// finish is called by the walk when it is done (with an error, if any)
+func (q *resultQueue) finish(err error) {
+	q.mtx.Lock()
+	if err != nil && q.err == nil {
+		q.err = err
+	}
+	q.done = true
+	q.mtx.Unlock()
+	notify(q.readyCh)
+}
+
+// peek returns the first n results (all of them, if n is zero) - or fewer, if
+// there are not as many yet; done is true when no more results will follow
+// the returned ones, and err is the walk error, if any
+func (q *resultQueue) peek(n int) (entries []*cmn.BucketEntry, done bool, err error) {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	if q.spill != nil && (n == 0 || len(q.mem) < n) {
+		q.unspill(n)
+	}
+	if n == 0 || n > len(q.mem) {
+		n = len(q.mem)
+	}
+	if q.spill == nil && n == len(q.mem) {
+		done, err = q.done, q.err
+	}
+	return q.mem[:n], done, err
+}
+
+// discard removes the first n results (that must have been peeked);
+// returns the name of the last removed one
+func (q *resultQueue) discard(n int) (last string) {
+	q.mtx.Lock()
+	if n > len(q.mem) {
+		n = len(q.mem)
+	}
+	if n > 0 {
+		last = q.mem[n-1].Name
+		q.mem = q.mem[n:]
+	}
+	q.mtx.Unlock()
+	notify(q.walkCh)
+	return
+}
+
+// peeked returns the results that are in memory (a snapshot)
+func (q *resultQueue) peeked() []*cmn.BucketEntry {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return q.mem
+}
+
+func (q *resultQueue) empty() bool {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+	return len(q.mem) == 0 && q.spill == nil
+}
+
+// cleanup drops the results, including the workfile, if any (the results
+// are not to be fetched)
+func (q *resultQueue) cleanup() {
+	q.mtx.Lock()
+	q.mem = nil
+	if q.spill != nil {
+		q.spill.remove()
+		q.spill = nil
+	}
+	q.mtx.Unlock()
+}
+
+//
+// spilling (must be called under lock)
+//
+
+func (q *resultQueue) spillEntry(entry *cmn.BucketEntry) (err error) {
+	if q.spill == nil {
+		if q.spill, err = newSpillFile(q.bck, q.id); err != nil {
+			return
+		}
+	}
+	b, err := jsoniter.Marshal(entry)
+	if err != nil {
+		return
+	}
+	b = append(b, '\n')
+	if _, err = q.spill.bw.Write(b); err != nil {
+		return
+	}
+	q.spill.n++
+	return
+}
+
+// reads back the spilled entries - at least as many as needed to have n
+// entries in memory (all, if n is zero) and up to memEntriesLimit
+func (q *resultQueue) unspill(n int) {
+	if err := q.spill.bw.Flush(); err != nil {
+		q.failUnspill(err)
+		return
+	}
+	for q.spill.n > 0 && (n == 0 || len(q.mem) < n || len(q.mem) < memEntriesLimit) {
+		line, err := q.spill.br.ReadBytes('\n')
+		if err != nil {
+			q.failUnspill(err)
+			return
+		}
+		entry := &cmn.BucketEntry{}
+		if err := jsoniter.Unmarshal(line, entry); err != nil {
+			q.failUnspill(err)
+			return
+		}
+		q.mem = append(q.mem, entry)
+		q.spill.n--
+	}
+	if q.spill.n == 0 {
+		q.spill.remove()
+		q.spill = nil
+	}
+	notify(q.walkCh)
+}
+
+func (q *resultQueue) failUnspill(err error) {
+	glog.Errorf("%s: failed to read back spilled list-objects results: %v", q.bck, err)
+	if q.err == nil {
+		q.err = err
+	}
+	q.done = true
+	q.spill.remove()
+	q.spill = nil
+}
+
+func newSpillFile(bck cmn.Bck, id string) (spill *spillFile, err error) {
+	mi, _, err := cluster.HrwMpath(cluster.NewBckEmbed(bck).MakeUname(id))
+	if err != nil {
+		return nil, err
+	}
+	spill = &spillFile{
+		fqn: fs.CSM.GenContentFQN(mi.MakePathFQN(bck, fs.ObjectType, id), fs.WorkfileType, fs.WorkfileSpill),
+	}
+	if spill.w, err = cmn.CreateFile(spill.fqn); err != nil {
+		return nil, err
+	}
+	if spill.r, err = os.Open(spill.fqn); err != nil {
+		spill.w.Close()
+		return nil, err
+	}
+	spill.bw, spill.br = bufio.NewWriter(spill.w), bufio.NewReader(spill.r)
+	return
+}
+
+func (spill *spillFile) remove() {
+	spill.w.Close()
+	spill.r.Close()
+	if err := os.Remove(spill.fqn); err != nil && !os.IsNotExist(err) {
+		glog.Error(err)
+	}
+}
@@ -0,0 +1,116 @@
+// Package query provides interface to iterate over objects with additional filtering
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package query
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
+	"github.com/NVIDIA/aistore/tutils/tassert"
+)
+
+// emulates the walk (see ObjectsListingXact.putResult) that pauses whenever
+// the queue is full
+func walkQueue(q *resultQueue, cnt int) {
+	for i := 0; i < cnt; i++ {
+		if q.put(&cmn.BucketEntry{Name: fmt.Sprintf("obj-%06d", i)}) {
+			for q.full() {
+				<-q.walkCh
+			}
+		}
+	}
+	q.finish(nil)
+}
+
+func TestPeekNWalkAhead(t *testing.T) {
+	const cnt = 3 * walkAheadLimit
+	tests := []struct {
+		name     string
+		n        uint
+		expected int
+		err      error
+	}{
+		{name: "page", n: 100, expected: 100},
+		{name: "page>walk-ahead", n: 2 * walkAheadLimit, expected: 2 * walkAheadLimit},
+		{name: "page>total", n: 2 * cnt, expected: cnt, err: io.EOF},
+		{name: "all", n: 0, expected: cnt, err: io.EOF},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				bck = cmn.Bck{Name: "bck", Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+				r   = &ObjectsListingXact{results: newResultQueue(bck, "peek-test")}
+				ch  = make(chan struct{})
+
+				entries []*cmn.BucketEntry
+				err     error
+			)
+			go walkQueue(r.results, cnt)
+			go func() {
+				entries, err = r.peekN(test.n)
+				close(ch)
+			}()
+			select {
+			case <-ch:
+			case <-time.After(10 * time.Second):
+				t.Fatalf("peekN(%d) is stuck", test.n)
+			}
+			tassert.Errorf(t, err == test.err, "expected %v, got %v", test.err, err)
+			tassert.Fatalf(t, len(entries) == test.expected, "expected %d entries, got %d", test.expected, len(entries))
+			tassert.Errorf(t, entries[0].Name == "obj-000000", "unexpected first entry %q", entries[0].Name)
+		})
+	}
+}
+
+func TestHousekeepIdle(t *testing.T) {
+	fs.Mountpaths = fs.NewMountedFS(ios.NewIOStaterMock())
+	fs.Mountpaths.DisableFsIDCheck()
+	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	mpath, err := ioutil.TempDir("", "query-idle")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(mpath)
+	tassert.CheckFatal(t, fs.Mountpaths.Add(mpath))
+
+	var (
+		bck    = cmn.Bck{Name: "bck", Provider: cmn.ProviderAIS, Ns: cmn.NsGlobal}
+		idle   = &ObjectsListingXact{XactBase: *cmn.NewXactBaseWithBucket("idle", cmn.ActQuery, bck)}
+		active = &ObjectsListingXact{XactBase: *cmn.NewXactBaseWithBucket("active", cmn.ActQuery, bck)}
+	)
+	for _, r := range []*ObjectsListingXact{idle, active} {
+		r.handle = r.ID().String()
+		r.results = newResultQueue(bck, r.handle)
+		r.results.spillable = true
+		r.fetchingDone = true
+		walkQueue(r.results, 10)
+		Registry.Put(r.handle, r)
+		r.Finish()
+	}
+	// as if the walk has spilled some of the results
+	idle.results.spill, err = newSpillFile(bck, idle.handle)
+	tassert.CheckFatal(t, err)
+	spillFQN := idle.results.spill.fqn
+	idle.lastAccess.Store(time.Now().Add(-2 * idleTTL).UnixNano())
+	active.touch()
+
+	Registry.Housekeep()
+	tassert.Errorf(t, Registry.Get(idle.handle) == nil, "expected the idle listing to be removed")
+	tassert.Errorf(t, idle.results.empty(), "expected the idle listing results to be removed")
+	_, err = os.Stat(spillFQN)
+	tassert.Errorf(t, os.IsNotExist(err), "expected %q to be removed, err: %v", spillFQN, err)
+
+	tassert.Errorf(t, Registry.Get(active.handle) == active, "expected the active listing to stay")
+	entries, err := active.NextN(20)
+	tassert.Errorf(t, err == io.EOF, "expected %v, got %v", io.EOF, err)
+	tassert.Errorf(t, len(entries) == 10, "expected %d entries, got %d", 10, len(entries))
+	tassert.Errorf(t, Registry.Get(active.handle) == nil, "expected the fetched listing to be removed")
+}
@@ -6,6 +6,9 @@ package query
 
 import (
 	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
 )
 
 type (
@@ -43,3 +46,25 @@ func (r *QueryRegistry) Delete(handle string) {
 	delete(r.m, handle)
 	r.mtx.Unlock()
 }
+
+// Housekeep removes the listings that the clients have abandoned (have not
+// requested for longer than idleTTL) - together with their results
+func (r *QueryRegistry) Housekeep() time.Duration {
+	var (
+		now  = time.Now()
+		idle []*ObjectsListingXact
+	)
+	r.mtx.Lock()
+	for handle, xact := range r.m {
+		if xact.idle(now) {
+			idle = append(idle, xact)
+			delete(r.m, handle)
+		}
+	}
+	r.mtx.Unlock()
+	for _, xact := range idle {
+		glog.Infof("%s: removing idle listing %q", xact, xact.handle)
+		xact.Release()
+	}
+	return idleTTL / 2
+}
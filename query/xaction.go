@@ -6,9 +6,11 @@ package query
 
 import (
 	"io"
+	"math"
 	"sync"
 	"time"
 
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
@@ -23,13 +25,13 @@ type (
 		timer        *time.Timer
 		wi           *walkinfo.WalkInfo
 		mtx          sync.Mutex
-		buff         []*cmn.BucketEntry
+		results      *resultQueue
 		fetchingDone bool
 
 		query               *ObjectsQuery
-		resultCh            chan *Result
 		lastDiscardedResult string
 		handle              string
+		lastAccess          atomic.Int64 // when the results were requested most recently (see Registry.Housekeep)
 	}
 
 	Result struct {
@@ -40,18 +42,21 @@ type (
 
 const (
 	xactionTTL = 10 * time.Minute // TODO: it should be Xaction argument
+	idleTTL    = xactionTTL       // remove the listing that nobody has requested for as long
 )
 
 func NewObjectsListing(t cluster.Target, query *ObjectsQuery, wi *walkinfo.WalkInfo, id string) *ObjectsListingXact {
 	cmn.Assert(query.BckSource.Bck != nil)
-	return &ObjectsListingXact{
+	r := &ObjectsListingXact{
 		XactBase: *cmn.NewXactBaseWithBucket(id, cmn.ActQuery, *query.BckSource.Bck),
 		t:        t,
 		wi:       wi,
-		resultCh: make(chan *Result),
+		results:  newResultQueue(*query.BckSource.Bck, id),
 		query:    query,
 		timer:    time.NewTimer(xactionTTL),
 	}
+	r.touch()
+	return r
 }
 
 // Start without specified handle means that we won't be able
@@ -62,13 +67,33 @@ func (r *ObjectsListingXact) Start() {
 }
 
 func (r *ObjectsListingXact) stop() {
-	close(r.resultCh)
+	r.results.finish(nil)
+	if r.Aborted() {
+		r.results.cleanup()
+	}
 	r.timer.Stop()
 	r.Finish()
 }
 
 func (r *ObjectsListingXact) IsMountpathXact() bool { return false } // TODO -- FIXME
 
+func (r *ObjectsListingXact) touch() { r.lastAccess.Store(time.Now().UnixNano()) }
+
+func (r *ObjectsListingXact) idle(now time.Time) bool {
+	return now.Sub(time.Unix(0, r.lastAccess.Load())) > idleTTL
+}
+
+// Release aborts the listing (if still running) and removes the results that
+// have not been fetched, including the ones spilled to disk
+func (r *ObjectsListingXact) Release() {
+	if !r.Aborted() {
+		r.Abort()
+	}
+	// the walk cleans up after itself as well (see stop) - in case it
+	// manages to spill one more result in the meantime
+	r.results.cleanup()
+}
+
 func (r *ObjectsListingXact) StartWithHandle(handle string) {
 	defer func() {
 		r.fetchingDone = true
@@ -80,6 +105,7 @@ func (r *ObjectsListingXact) StartWithHandle(handle string) {
 
 	Registry.Put(handle, r)
 	r.handle = handle
+	r.results.spillable = handle != ""
 
 	if r.query.ObjectsSource.Pt != nil {
 		r.startFromTemplate()
@@ -94,15 +120,37 @@ func (r *ObjectsListingXact) LastDiscardedResult() string {
 	return r.lastDiscardedResult
 }
 
+// putResult queues the result without waiting for the consumer unless the
+// consumer lags too much (see resultQueue) - in which case the walk pauses
+// until the consumer catches up, the xaction gets aborted, or xactionTTL expires
 func (r *ObjectsListingXact) putResult(res *Result) (end bool) {
+	if res.err != nil {
+		r.results.finish(res.err)
+		return true
+	}
+	if r.results.put(res.entry) {
+		for r.results.full() {
+			select {
+			case <-r.ChanAbort():
+				return true
+			case <-r.timer.C:
+				return true
+			case <-r.results.walkCh:
+			}
+		}
+	}
+	if !r.timer.Stop() {
+		select {
+		case <-r.timer.C:
+		default:
+		}
+	}
+	r.timer.Reset(xactionTTL)
 	select {
 	case <-r.ChanAbort():
 		return true
-	case <-r.timer.C:
-		return true
-	case r.resultCh <- res:
-		r.timer.Reset(xactionTTL)
-		return res.err != nil
+	default:
+		return false
 	}
 }
 
@@ -195,39 +243,35 @@ func (r *ObjectsListingXact) startFromBck() {
 
 // Should be called with lock acquired.
 func (r *ObjectsListingXact) peekN(n uint) (result []*cmn.BucketEntry, err error) {
-	if len(r.buff) >= int(n) && n != 0 {
-		return r.buff[:n], nil
+	if n == 0 {
+		r.results.demand(math.MaxInt32) // all
+	} else {
+		r.results.demand(int(n))
 	}
-
-	for len(r.buff) < int(n) || n == 0 {
-		res, ok := <-r.resultCh
-		if !ok {
-			err = io.EOF
-			break
+	for {
+		entries, done, err := r.results.peek(int(n))
+		if n != 0 && len(entries) >= int(n) {
+			return entries, nil
 		}
-		if res.err != nil {
-			err = res.err
-			break
+		if done {
+			if err == nil {
+				err = io.EOF
+			}
+			return entries, err
 		}
-		r.buff = append(r.buff, res.entry)
+		<-r.results.readyCh
 	}
-
-	size := cmn.Min(int(n), len(r.buff))
-	if size == 0 {
-		size = len(r.buff)
-	}
-	return r.buff[:size], err
 }
 
 // Should be called with lock acquired.
 func (r *ObjectsListingXact) discardN(n uint) {
-	if len(r.buff) > 0 && n > 0 {
-		size := cmn.Min(int(n), len(r.buff))
-		r.lastDiscardedResult = r.buff[size-1].Name
-		r.buff = r.buff[size:]
+	if n > 0 {
+		if last := r.results.discard(int(n)); last != "" {
+			r.lastDiscardedResult = last
+		}
 	}
 
-	if r.fetchingDone && len(r.buff) == 0 {
+	if r.fetchingDone && r.results.empty() {
 		Registry.Delete(r.handle)
 	}
 }
@@ -237,6 +281,7 @@ func (r *ObjectsListingXact) discardN(n uint) {
 func (r *ObjectsListingXact) PeekN(n uint) (result []*cmn.BucketEntry, err error) {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
+	r.touch()
 	return r.peekN(n)
 }
 
@@ -244,14 +289,16 @@ func (r *ObjectsListingXact) PeekN(n uint) (result []*cmn.BucketEntry, err error
 func (r *ObjectsListingXact) DiscardUntil(last string) {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
+	r.touch()
 
-	if len(r.buff) == 0 {
+	buff := r.results.peeked()
+	if len(buff) == 0 {
 		return
 	}
 
 	i := 0
-	for ; i < len(r.buff); i++ {
-		if !cmn.PageMarkerIncludesObject(last, r.buff[i].Name) {
+	for ; i < len(buff); i++ {
+		if !cmn.PageMarkerIncludesObject(last, buff[i].Name) {
 			break
 		}
 	}
@@ -270,6 +317,7 @@ func (r *ObjectsListingXact) nextN(n uint) (result []*cmn.BucketEntry, err error
 func (r *ObjectsListingXact) NextN(n uint) (result []*cmn.BucketEntry, err error) {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
+	r.touch()
 	return r.nextN(n)
 }
 
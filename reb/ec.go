@@ -355,7 +355,7 @@ func (rr *globalCTList) addCT(md *rebArgs, ct *rebCT, tgt cluster.Target) error
 			if found.SliceID != ct.SliceID {
 				continue
 			}
-			tgtList, errHrw := cluster.HrwTargetList(b.MakeUname(obj.objName), md.smap, len(md.smap.Tmap))
+			tgtList, errHrw := cluster.HrwTargetListEC(b.MakeUname(obj.objName), md.smap, len(md.smap.Tmap), &b.Props.EC)
 			if errHrw != nil {
 				return errHrw
 			}
@@ -447,8 +447,8 @@ func (reb *Manager) sendFromDisk(ct *rebCT, target *cluster.Snode) error {
 	} else {
 		lom = nil // sending slice
 	}
-	// open
-	fh, err := cmn.NewFileHandle(fqn)
+	// open (decompressing, if need be)
+	fh, err := ec.OpenSlice(fqn, ct.meta)
 	if err != nil {
 		return err
 	}
@@ -470,6 +470,7 @@ func (reb *Manager) sendFromDisk(ct *rebCT, target *cluster.Snode) error {
 	if lom != nil {
 		hdr.ObjAttrs.Atime = lom.AtimeUnix()
 		hdr.ObjAttrs.Version = lom.Version()
+		hdr.ObjAttrs.CustomMD = lom.CustomMD()
 		if cksum := lom.Cksum(); cksum != nil {
 			hdr.ObjAttrs.CksumType, hdr.ObjAttrs.CksumValue = cksum.Get()
 		}
@@ -576,13 +577,13 @@ func (reb *Manager) saveCTToDisk(data io.Reader, req *pushReq, hdr transport.Hea
 		return nil
 	}
 
-	md := req.md.Marshal()
 	if req.md.SliceID != 0 {
-		err = ec.WriteSliceAndMeta(reb.t, hdr, data, md)
+		err = ec.WriteSliceAndMeta(reb.t, hdr, data, req.md)
 	} else {
 		var lom *cluster.LOM
 		lom, err = ec.LomFromHeader(reb.t, hdr)
 		if err == nil {
+			md := req.md.NewPack()
 			err = ec.WriteReplicaAndMeta(reb.t, lom, data, md, hdr.ObjAttrs.CksumType, hdr.ObjAttrs.CksumValue)
 		}
 	}
@@ -920,6 +921,7 @@ func (reb *Manager) jogEC(mpathInfo *fs.MountpathInfo, bck cmn.Bck, wg *sync.Wai
 // - calculates where "main" object for the CT is
 // - store all the info above to memory
 func (reb *Manager) walkEC(fqn string, de fs.DirEntry) (err error) {
+	reb.xact().WaitIfPaused()
 	if reb.xact().Aborted() {
 		// notify `dir.Walk` to stop iterations
 		return cmn.NewAbortedError("interrupt walk - xaction aborted")
@@ -1226,7 +1228,7 @@ func (reb *Manager) calcLocalProps(bck *cluster.Bck, obj *rebObject, smap *clust
 	obj.hasAllSlices = ctCnt >= obj.dataSlices+obj.paritySlices
 
 	genCount := cmn.Max(ctReq, len(smap.Tmap))
-	obj.hrwTargets, err = cluster.HrwTargetList(bck.MakeUname(obj.objName), smap, genCount)
+	obj.hrwTargets, err = cluster.HrwTargetListEC(bck.MakeUname(obj.objName), smap, genCount, ecConfig)
 	if err != nil {
 		return err
 	}
@@ -1924,7 +1926,7 @@ func (reb *Manager) restoreObject(obj *rebObject, objMD ec.Metadata, src io.Read
 		return err
 	}
 	lom.SetSize(obj.objSize)
-	metaBuf := cmn.MustMarshal(&objMD)
+	metaBuf := objMD.NewPack()
 	return ec.WriteReplicaAndMeta(reb.t, lom, src, metaBuf, lom.Bprops().Cksum.Type, "")
 }
 
@@ -519,6 +519,7 @@ func (rj *rebalanceJogger) jog(mpathInfo *fs.MountpathInfo) {
 		CTs:      []string{fs.ObjectType},
 		Callback: rj.walk,
 		Sorted:   false,
+		Parallel: true, // see cmn.DiskConf.WalkWorkers
 	}
 	rj.m.t.GetBowner().Get().Range(nil, nil, func(bck *cluster.Bck) bool {
 		opts.ErrCallback = nil
@@ -560,6 +561,56 @@ func (rj *rebalanceJogger) objSentCallback(hdr transport.Header, r io.ReadCloser
 		stats.NamedVal64{Name: stats.RebTxSize, Value: hdr.ObjAttrs.Size})
 }
 
+// previous versions of the object migrate along with it (see cluster.LOM.KeepVersion)
+func (rj *rebalanceJogger) sendVersions(lom *cluster.LOM, tsi *cluster.Snode) {
+	if !lom.Bck().IsAIS() || !cluster.MayHavePrevVersions(lom.Version()) {
+		return
+	}
+	for _, version := range lom.PrevVersions() {
+		vlom, err := lom.LoadVersion(version)
+		if err != nil {
+			glog.Errorf("%s: failed to load version %s: %v", lom, version, err)
+			continue
+		}
+		file, err := cmn.NewFileHandle(vlom.FQN)
+		if err != nil {
+			glog.Error(err)
+			continue
+		}
+		var (
+			msg    = versionMsg{rebID: rj.m.RebID(), version: version}
+			mm     = rj.m.t.GetSmallMMSA()
+			opaque = msg.NewPack(mm)
+			hdr    = transport.Header{
+				Bck:     lom.Bck().Bck,
+				ObjName: lom.ObjName,
+				Opaque:  opaque,
+				ObjAttrs: transport.ObjectAttrs{
+					Size:     vlom.Size(),
+					Atime:    vlom.AtimeUnix(),
+					Version:  version,
+					CustomMD: vlom.CustomMD(),
+				},
+			}
+		)
+		if cksum := vlom.Cksum(); cksum != nil {
+			hdr.ObjAttrs.CksumType, hdr.ObjAttrs.CksumValue = cksum.Get()
+		}
+		if err := rj.m.streams.Send(transport.Obj{Hdr: hdr, Callback: rj.versionSentCallback}, file, tsi); err != nil {
+			mm.Free(opaque)
+			glog.Errorf("%s: failed to send version %s: %v", lom, version, err)
+		}
+	}
+}
+
+func (rj *rebalanceJogger) versionSentCallback(hdr transport.Header, _ io.ReadCloser, _ unsafe.Pointer, err error) {
+	rj.m.t.GetSmallMMSA().Free(hdr.Opaque)
+	if err != nil {
+		glog.Errorf("%s: failed to send o[%s/%s] version %s, err: %v",
+			rj.m.t.Snode(), hdr.Bck, hdr.ObjName, hdr.ObjAttrs.Version, err)
+	}
+}
+
 // the walking callback is executed by the LRU xaction
 func (rj *rebalanceJogger) walk(fqn string, de fs.DirEntry) (err error) {
 	var (
@@ -567,6 +618,7 @@ func (rj *rebalanceJogger) walk(fqn string, de fs.DirEntry) (err error) {
 		tsi *cluster.Snode
 		t   = rj.m.t
 	)
+	rj.xreb.WaitIfPaused()
 	if rj.xreb.Aborted() || rj.xreb.Finished() {
 		return cmn.NewAbortedErrorDetails("traversal", rj.xreb.String())
 	}
@@ -589,6 +641,10 @@ func (rj *rebalanceJogger) walk(fqn string, de fs.DirEntry) (err error) {
 	if lom.Bck().Props.EC.Enabled {
 		return filepath.SkipDir
 	}
+	// Skip target-local remote cache
+	if lom.Config().RemoteCache.IsCacheBck(lom.Bck().Bck) {
+		return filepath.SkipDir
+	}
 
 	// Rebalance, maybe
 	tsi, err = cluster.HrwTarget(lom.Uname(), rj.smap)
@@ -610,6 +666,7 @@ func (rj *rebalanceJogger) walk(fqn string, de fs.DirEntry) (err error) {
 	if err := lom.Load(); err != nil {
 		return err
 	}
+	rj.xreb.Throttle(lom.Size())
 	if rj.sema == nil { // rebalance.multiplier == 1
 		err = rj.send(lom, tsi, true /*addAck*/)
 	} else { // // rebalance.multiplier > 1
@@ -658,6 +715,7 @@ func (rj *rebalanceJogger) send(lom *cluster.LOM, tsi *cluster.Snode, addAck boo
 	if file, err = cmn.NewFileHandle(lom.FQN); err != nil {
 		return
 	}
+	rj.sendVersions(lom, tsi) // (ahead of the object - see recvRegularAck)
 	if addAck {
 		// cache it as pending-acknowledgement (optimistically - see objSentCallback)
 		rj.m.addLomAck(lom)
@@ -677,6 +735,7 @@ func (rj *rebalanceJogger) send(lom *cluster.LOM, tsi *cluster.Snode, addAck boo
 				CksumType:  cksumType,
 				CksumValue: cksumValue,
 				Version:    lom.Version(),
+				CustomMD:   lom.CustomMD(),
 			},
 		}
 		o = transport.Obj{Hdr: hdr, Callback: rj.objSentCallback, CmplPtr: unsafe.Pointer(lom)}
@@ -204,7 +204,7 @@ func NewManager(t cluster.Target, config *cmn.Config, strunner *stats.Trunner) *
 		stages:     newNodeStages(),
 		client: cmn.NewClient(cmn.TransportArgs{
 			Timeout:    config.Client.Timeout,
-			UseHTTPS:   config.Net.HTTP.UseHTTPS,
+			UseHTTPS:   config.Net.Listener(cmn.NetworkIntraData).UseHTTPS,
 			SkipVerify: config.Net.HTTP.SkipVerify,
 		}),
 	}
@@ -340,7 +340,8 @@ func (reb *Manager) beginStreams(md *rebArgs) {
 		Extra: &transport.Extra{
 			Compression: md.config.Rebalance.Compression,
 			Config:      md.config,
-			MMSA:        reb.t.GetMMSA()},
+			MMSA:        reb.t.GetMMSA(),
+			SegCksum:    true},
 		Multiplier:   int(md.config.Rebalance.Multiplier),
 		ManualResync: true,
 	}
@@ -413,6 +414,7 @@ func (reb *Manager) recvObjRegular(hdr transport.Header, smap *cluster.Smap, unp
 	}
 	lom.SetAtimeUnix(hdr.ObjAttrs.Atime)
 	lom.SetVersion(hdr.ObjAttrs.Version)
+	lom.SetCustomMD(hdr.ObjAttrs.CustomMD) // (including the sealed data key of an encrypted object)
 
 	if err := reb.t.PutObject(cluster.PutObjectParams{
 		LOM:          lom,
@@ -420,6 +422,7 @@ func (reb *Manager) recvObjRegular(hdr transport.Header, smap *cluster.Smap, unp
 		WorkFQN:      fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfilePut),
 		RecvType:     cluster.Migrated,
 		Cksum:        cmn.NewCksum(hdr.ObjAttrs.CksumType, hdr.ObjAttrs.CksumValue),
+		Size:         hdr.ObjAttrs.Size,
 		Started:      time.Now(),
 		WithFinalize: true,
 	}); err != nil {
@@ -454,6 +457,51 @@ func (reb *Manager) recvObjRegular(hdr transport.Header, smap *cluster.Smap, unp
 	}
 }
 
+// receives a previous version of the object (see rebalanceJogger.sendVersions)
+func (reb *Manager) recvVersion(hdr transport.Header, unpacker *cmn.ByteUnpack, objReader io.Reader) {
+	defer cmn.DrainReader(objReader)
+
+	msg := &versionMsg{}
+	if err := unpacker.ReadAny(msg); err != nil {
+		glog.Errorf("Failed to parse version message: %v", err)
+		return
+	}
+	if msg.rebID != reb.RebID() {
+		glog.Warningf("received object %s/%s version %s: %s", hdr.Bck, hdr.ObjName, msg.version,
+			reb.rebIDMismatchMsg(msg.rebID))
+		return
+	}
+	lom := &cluster.LOM{T: reb.t, ObjName: hdr.ObjName}
+	if err := lom.Init(hdr.Bck); err != nil {
+		glog.Error(err)
+		return
+	}
+	var (
+		vlom      = lom.NewVersionLOM(msg.version)
+		workFQN   = fs.CSM.GenContentParsedFQN(lom.ParsedFQN, fs.WorkfileType, fs.WorkfilePut)
+		buf, slab = reb.t.GetMMSA().Alloc()
+	)
+	_, err := cmn.SaveReaderSafe(workFQN, vlom.FQN, objReader, buf, cmn.ChecksumNone, hdr.ObjAttrs.Size, "")
+	slab.Free(buf)
+	if err != nil {
+		glog.Errorf("%s: failed to receive version %s: %v", lom, msg.version, err)
+		return
+	}
+	vlom.SetSize(hdr.ObjAttrs.Size)
+	vlom.SetVersion(msg.version)
+	vlom.SetAtimeUnix(hdr.ObjAttrs.Atime)
+	vlom.SetCustomMD(hdr.ObjAttrs.CustomMD)
+	if hdr.ObjAttrs.CksumType != cmn.ChecksumNone && hdr.ObjAttrs.CksumValue != "" {
+		vlom.SetCksum(cmn.NewCksum(hdr.ObjAttrs.CksumType, hdr.ObjAttrs.CksumValue))
+	}
+	if err := vlom.Persist(); err != nil {
+		glog.Errorf("%s: failed to persist version %s: %v", lom, msg.version, err)
+		if err := cmn.RemoveFile(vlom.FQN); err != nil {
+			glog.Error(err)
+		}
+	}
+}
+
 func (reb *Manager) rackSentCallback(hdr transport.Header, _ io.ReadCloser, _ unsafe.Pointer, _ error) {
 	reb.t.GetSmallMMSA().Free(hdr.Opaque)
 }
@@ -508,6 +556,10 @@ func (reb *Manager) recvObj(w http.ResponseWriter, hdr transport.Header, objRead
 		reb.recvObjRegular(hdr, smap, unpacker, objReader)
 		return
 	}
+	if act == rebMsgVersion {
+		reb.recvVersion(hdr, unpacker, objReader)
+		return
+	}
 
 	if act != rebMsgEC {
 		glog.Errorf("Invalid ACK type %d, expected %d", act, rebMsgEC)
@@ -620,6 +672,10 @@ func (reb *Manager) recvRegularAck(hdr transport.Header, unpacker *cmn.ByteUnpac
 	if err := lom.Remove(); err != nil {
 		glog.Errorf("%s: error removing %s, err: %v", reb.t.Snode(), lom, err)
 	}
+	// the previous versions, if any, have been sent ahead of the object (see sendVersions)
+	if lom.Bck().IsAIS() && cluster.MayHavePrevVersions(hdr.ObjAttrs.Version) {
+		lom.DelVersions()
+	}
 	lom.Unlock(true)
 }
 
@@ -17,6 +17,7 @@ const (
 	rebMsgRegular   = iota // regular rebalance: acknowledge/Object
 	rebMsgEC               // EC rebalance: acknowledge/CT/Namespace
 	rebMsgPushStage        // push notification of target moved to the next stage
+	rebMsgVersion          // regular rebalance: previous version of the object
 )
 const rebMsgKindSize = 1
 
@@ -25,6 +26,11 @@ type (
 		rebID    int64
 		daemonID string // sender's DaemonID
 	}
+	// previous version of the object (see cluster.LOM.KeepVersion)
+	versionMsg struct {
+		rebID   int64
+		version string
+	}
 	ecAck struct {
 		rebID    int64
 		daemonID string // sender's DaemonID
@@ -48,6 +54,8 @@ var (
 	_ cmn.Packer   = &regularAck{}
 	_ cmn.Packer   = &ecAck{}
 	_ cmn.Packer   = &pushReq{}
+	_ cmn.Packer   = &versionMsg{}
+	_ cmn.Unpacker = &versionMsg{}
 	_ cmn.Unpacker = &pushReq{}
 )
 
@@ -78,6 +86,33 @@ func (rack *regularAck) PackedSize() int {
 	return cmn.SizeofI64 + cmn.SizeofLen + len(rack.daemonID)
 }
 
+func (vmsg *versionMsg) Unpack(unpacker *cmn.ByteUnpack) (err error) {
+	if vmsg.rebID, err = unpacker.ReadInt64(); err != nil {
+		return
+	}
+	vmsg.version, err = unpacker.ReadString()
+	return
+}
+
+func (vmsg *versionMsg) Pack(packer *cmn.BytePack) {
+	packer.WriteInt64(vmsg.rebID)
+	packer.WriteString(vmsg.version)
+}
+
+func (vmsg *versionMsg) NewPack(mm *memsys.MMSA) []byte {
+	l := rebMsgKindSize + vmsg.PackedSize()
+	buf, _ := mm.Alloc(int64(l))
+	packer := cmn.NewPacker(buf, l)
+	packer.WriteByte(rebMsgVersion)
+	packer.WriteAny(vmsg)
+	return packer.Bytes()
+}
+
+// rebID + length of version + version
+func (vmsg *versionMsg) PackedSize() int {
+	return cmn.SizeofI64 + cmn.SizeofLen + len(vmsg.version)
+}
+
 func (eack *ecAck) Unpack(unpacker *cmn.ByteUnpack) (err error) {
 	if eack.rebID, err = unpacker.ReadInt64(); err != nil {
 		return
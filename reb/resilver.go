@@ -5,9 +5,11 @@
 package reb
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"sync"
+	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cluster"
@@ -24,17 +26,33 @@ type (
 		slab              *memsys.Slab
 		buf               []byte
 		skipGlobMisplaced bool
+		// delta resilver (see RunDeltaResilver)
+		since     int64 // Unix time (nanoseconds)
+		validated int64
+		removed   int64
 	}
 )
 
 // TODO: support non-object content types
 func (reb *Manager) RunResilver(id string, skipGlobMisplaced bool) {
-	var (
-		availablePaths, _ = fs.Mountpaths.Get()
-		err               = fs.PutMarker(getMarkerName(cmn.ActResilver))
-	)
-	if err != nil {
-		glog.Errorln("failed to create resilver marker", err)
+	reb.runResilver(id, skipGlobMisplaced, 0)
+}
+
+// RunDeltaResilver only visits the content modified since a given time - and
+// validates the objects (see validateObject) in addition to resilvering them.
+// Returns false if aborted.
+func (reb *Manager) RunDeltaResilver(since time.Time) bool {
+	return reb.runResilver("", false, since.UnixNano())
+}
+
+func (reb *Manager) runResilver(id string, skipGlobMisplaced bool, since int64) (ok bool) {
+	availablePaths, _ := fs.Mountpaths.Get()
+	// NOTE: interrupted delta resilver is not resumed as the full one - it reruns
+	// upon the next (unclean) restart
+	if since == 0 {
+		if err := fs.PutMarker(getMarkerName(cmn.ActResilver)); err != nil {
+			glog.Errorln("failed to create resilver marker", err)
+		}
 	}
 
 	xreb := xaction.Registry.RenewResilver(id)
@@ -52,6 +70,7 @@ func (reb *Manager) RunResilver(id string, skipGlobMisplaced bool) {
 				joggerBase:        joggerBase{m: reb, xreb: &xreb.RebBase, wg: wg},
 				slab:              slab,
 				skipGlobMisplaced: skipGlobMisplaced,
+				since:             since,
 			}
 		)
 		wg.Add(1)
@@ -59,13 +78,15 @@ func (reb *Manager) RunResilver(id string, skipGlobMisplaced bool) {
 	}
 	wg.Wait()
 
-	if !xreb.Aborted() {
+	ok = !xreb.Aborted()
+	if ok && since == 0 {
 		if err := fs.RemoveMarker(getMarkerName(cmn.ActResilver)); err != nil {
 			glog.Errorf("%s: failed to remove in-progress mark, err: %v", reb.t.Snode(), err)
 		}
 	}
 	reb.t.GetGFN(cluster.GFNLocal).Deactivate()
 	xreb.Finish()
+	return
 }
 
 //
@@ -100,23 +121,25 @@ func (rj *resilverJogger) jog(mpathInfo *fs.MountpathInfo) {
 		}
 		return rj.xreb.Aborted()
 	})
+	if rj.since > 0 {
+		glog.Infof("%s: delta resilver validated %d object(s), removed %d", mpathInfo, rj.validated, rj.removed)
+	}
 }
 
 // Copies a slice and its metafile (if exists) to the current mpath. At the
 // end does proper cleanup: removes ether source files(on success), or
 // destination files(on copy failure)
 func (rj *resilverJogger) moveSlice(fqn string, ct *cluster.CT) {
-	uname := ct.Bck().MakeUname(ct.ObjName())
-	destMpath, _, err := cluster.HrwMpath(uname)
+	destMpath, _, err := cluster.HrwMpathBck(ct.Bck(), ct.ObjName())
 	if err != nil {
 		glog.Warning(err)
 		return
 	}
-	if destMpath.Path == ct.ParsedFQN().MpathInfo.Path {
+	// NOTE: slices routed to labeled mountpaths (see fs/routing.go) stay in place
+	destFQN := fs.CSM.FQN(destMpath, ct.Bck().Bck, ec.SliceType, ct.ObjName())
+	if destFQN == fqn {
 		return
 	}
-
-	destFQN := destMpath.MakePathFQN(ct.Bck().Bck, ec.SliceType, ct.ObjName())
 	srcMetaFQN, destMetaFQN, err := rj.moveECMeta(ct, ct.ParsedFQN().MpathInfo, destMpath)
 	if err != nil {
 		return
@@ -130,11 +153,16 @@ func (rj *resilverJogger) moveSlice(fqn string, ct *cluster.CT) {
 	}
 	if _, _, err = cmn.CopyFile(fqn, destFQN, rj.buf, cmn.ChecksumNone); err != nil {
 		glog.Errorf("failed to copy %q -> %q: %v. Rolling back", fqn, destFQN, err)
-		if err = os.Remove(destMetaFQN); err != nil {
-			glog.Warningf("failed to cleanup metafile copy %q: %v", destMetaFQN, err)
+		if destMetaFQN != srcMetaFQN {
+			if err = os.Remove(destMetaFQN); err != nil {
+				glog.Warningf("failed to cleanup metafile copy %q: %v", destMetaFQN, err)
+			}
 		}
 	}
-	errMeta := os.Remove(srcMetaFQN)
+	var errMeta error
+	if destMetaFQN != srcMetaFQN {
+		errMeta = os.Remove(srcMetaFQN)
+	}
 	errSlice := os.Remove(fqn)
 	if errMeta != nil || errSlice != nil {
 		glog.Warningf("failed to cleanup %q: %v, %v", fqn, errSlice, errMeta)
@@ -143,17 +171,21 @@ func (rj *resilverJogger) moveSlice(fqn string, ct *cluster.CT) {
 
 // Copies EC metafile to correct mpath. It returns FQNs of the source and
 // destination for a caller to do proper cleanup. Empty values means: either
-// the source FQN does not exist(err==nil), or copying failed
+// the source FQN does not exist(err==nil), or copying failed. Equal values
+// mean that the metafile is already in place (e.g., routed to a labeled mountpath)
 func (rj *resilverJogger) moveECMeta(ct *cluster.CT, srcMpath, dstMpath *fs.MountpathInfo) (
 	string, string, error) {
-	src := srcMpath.MakePathFQN(ct.Bck().Bck, ec.MetaType, ct.ObjName())
+	src := fs.CSM.FQN(srcMpath, ct.Bck().Bck, ec.MetaType, ct.ObjName())
 	// If metafile does not exist it may mean that EC has not processed the
 	// object yet (e.g, EC was enabled after the bucket was filled), or
 	// the metafile has gone
 	if err := fs.Access(src); os.IsNotExist(err) {
 		return "", "", nil
 	}
-	dst := dstMpath.MakePathFQN(ct.Bck().Bck, ec.MetaType, ct.ObjName())
+	dst := fs.CSM.FQN(dstMpath, ct.Bck().Bck, ec.MetaType, ct.ObjName())
+	if dst == src {
+		return src, dst, nil
+	}
 	_, _, err := cmn.CopyFile(src, dst, rj.buf, cmn.ChecksumNone)
 	if err == nil {
 		return src, dst, err
@@ -204,7 +236,7 @@ func (rj *resilverJogger) moveObject(fqn string, ct *cluster.CT) {
 		if err != nil {
 			glog.Warningf("%s: %v", lom, err)
 		}
-		if metaNewPath != "" {
+		if metaNewPath != "" && metaNewPath != metaOldPath {
 			if err = os.Remove(metaNewPath); err != nil {
 				glog.Warningf("nested %s: %v", metaNewPath, err)
 			}
@@ -212,7 +244,7 @@ func (rj *resilverJogger) moveObject(fqn string, ct *cluster.CT) {
 		return
 	}
 	// if everything is OK, remove the original metafile
-	if metaOldPath != "" {
+	if metaOldPath != "" && metaOldPath != metaNewPath {
 		if err := os.Remove(metaOldPath); err != nil {
 			glog.Warningf("Failed to cleanup old metafile %q: %v", metaOldPath, err)
 		}
@@ -230,12 +262,18 @@ func (rj *resilverJogger) moveObject(fqn string, ct *cluster.CT) {
 
 func (rj *resilverJogger) walk(fqn string, de fs.DirEntry) (err error) {
 	var t = rj.m.t
+	rj.xreb.WaitIfPaused()
 	if rj.xreb.Aborted() {
 		return cmn.NewAbortedErrorDetails("traversal", rj.xreb.String())
 	}
 	if de.IsDir() {
 		return nil
 	}
+	if rj.since > 0 {
+		if finfo, err := os.Stat(fqn); err != nil || finfo.ModTime().UnixNano() < rj.since {
+			return nil
+		}
+	}
 
 	ct, err := cluster.NewCTFromFQN(fqn, t.GetBowner())
 	if err != nil {
@@ -268,6 +306,45 @@ func (rj *resilverJogger) walk(fqn string, de fs.DirEntry) (err error) {
 		return nil
 	}
 	cmn.Assert(ct.ContentType() == fs.ObjectType)
+	if rj.since > 0 && !rj.validateObject(fqn) {
+		return nil
+	}
 	rj.moveObject(fqn, ct)
 	return nil
 }
+
+// The object modified shortly before unclean shutdown may have been left
+// partially written: removes the one without (valid) metadata or with the
+// content that does not match its checksum - the object will then be restored
+// from its other copies or slices, if any.
+func (rj *resilverJogger) validateObject(fqn string) (ok bool) {
+	lom := &cluster.LOM{T: rj.m.t, FQN: fqn}
+	if err := lom.Init(cmn.Bck{}); err != nil {
+		return
+	}
+	rj.validated++
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	err := lom.Load(false)
+	if err == nil {
+		if err = lom.ValidateContentChecksum(); err == nil {
+			return true
+		}
+		if !errors.Is(err, &cmn.BadCksumError{}) {
+			glog.Warningf("%s: %v", lom, err)
+			return
+		}
+	} else if cmn.IsErrBucketLevel(err) {
+		return
+	} else if _, errStat := os.Stat(fqn); errStat != nil {
+		return // removed in the meantime
+	}
+	glog.Errorf("%s: invalid (%v) - removing", lom, err)
+	if err := cmn.RemoveFile(fqn); err != nil {
+		glog.Errorf("%s: %v", lom, err)
+		return
+	}
+	lom.Uncache()
+	rj.removed++
+	return
+}
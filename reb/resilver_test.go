@@ -0,0 +1,121 @@
+// Package reb provides resilvering and rebalancing functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package reb
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+	"github.com/NVIDIA/aistore/xaction"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Delta resilver", func() {
+	const (
+		tmpDir = "/tmp/resilver_test"
+		mpath  = tmpDir + "/mpath"
+	)
+
+	var (
+		oldMountpaths *fs.MountedFS
+		bck           = cluster.NewBck("bck", cmn.ProviderAIS, cmn.NsGlobal,
+			&cmn.BucketProps{Cksum: cmn.CksumConf{Type: cmn.ChecksumXXHash}})
+		tMock = cluster.NewTargetMock(cluster.NewBaseBownerMock(bck))
+		since = time.Now().Add(-time.Hour)
+	)
+
+	// creates the object modified at a given time; corrupted - the content
+	// does not match the checksum
+	createObject := func(objName string, mtime time.Time, corrupted bool) string {
+		lom := &cluster.LOM{T: tMock, ObjName: objName}
+		Expect(lom.Init(bck.Bck)).NotTo(HaveOccurred())
+		Expect(cmn.CreateDir(filepath.Dir(lom.FQN))).NotTo(HaveOccurred())
+		Expect(ioutil.WriteFile(lom.FQN, []byte("good content"), 0644)).NotTo(HaveOccurred())
+		cksum, err := lom.ComputeCksumIfMissing()
+		Expect(err).NotTo(HaveOccurred())
+		lom.SetCksum(cksum)
+		lom.SetSize(int64(len("good content")))
+		Expect(lom.Persist()).NotTo(HaveOccurred())
+		if corrupted {
+			f, err := os.OpenFile(lom.FQN, os.O_WRONLY, 0644)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = f.WriteAt([]byte("bad"), 0)
+			Expect(err).NotTo(HaveOccurred())
+			f.Close()
+		}
+		Expect(os.Chtimes(lom.FQN, mtime, mtime)).NotTo(HaveOccurred())
+		lom.Uncache()
+		return lom.FQN
+	}
+
+	runJogger := func(since time.Time) *resilverJogger {
+		slab, err := memsys.DefaultPageMM().GetSlab(memsys.MaxPageSlabSize)
+		Expect(err).NotTo(HaveOccurred())
+		var (
+			wg = &sync.WaitGroup{}
+			rj = &resilverJogger{
+				joggerBase: joggerBase{m: &Manager{t: tMock}, xreb: &xaction.RebBase{}, wg: wg},
+				slab:       slab,
+				since:      since.UnixNano(),
+			}
+			availablePaths, _ = fs.Mountpaths.Get()
+		)
+		wg.Add(1)
+		rj.jog(availablePaths[mpath])
+		return rj
+	}
+
+	cluster.InitTarget()
+
+	BeforeEach(func() {
+		oldMountpaths = fs.Mountpaths
+		fs.Mountpaths = fs.NewMountedFS()
+		fs.Mountpaths.DisableFsIDCheck()
+		Expect(cmn.CreateDir(mpath)).NotTo(HaveOccurred())
+		Expect(fs.Mountpaths.Add(mpath)).NotTo(HaveOccurred())
+		_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+		_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	})
+
+	AfterEach(func() {
+		fs.Mountpaths = oldMountpaths
+		os.RemoveAll(tmpDir)
+	})
+
+	It("should remove the corrupted objects modified since a given time", func() {
+		var (
+			recent     = time.Now()
+			good       = createObject("good", recent, false)
+			corrupted  = createObject("corrupted", recent, true)
+			oldCorrupt = createObject("old-corrupted", since.Add(-time.Minute), true)
+		)
+		rj := runJogger(since)
+		Expect(rj.validated).To(BeEquivalentTo(2))
+		Expect(rj.removed).To(BeEquivalentTo(1))
+
+		Expect(good).To(BeARegularFile())
+		Expect(corrupted).NotTo(BeAnExistingFile())
+		// not modified since - not visited
+		Expect(oldCorrupt).To(BeARegularFile())
+	})
+
+	It("should remove the objects with no metadata", func() {
+		fqn := createObject("no-md", time.Now(), false)
+		Expect(os.Remove(fqn)).NotTo(HaveOccurred())
+		Expect(ioutil.WriteFile(fqn, []byte("partially written"), 0644)).NotTo(HaveOccurred())
+
+		rj := runJogger(since)
+		Expect(rj.removed).To(BeEquivalentTo(1))
+		Expect(fqn).NotTo(BeAnExistingFile())
+	})
+})
@@ -6,6 +6,7 @@
 package stats
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -63,6 +64,7 @@ const (
 	GetCount         = "get.n"
 	PutCount         = "put.n"
 	AppendCount      = "append.n"
+	WriteAtCount     = "writeat.n"
 	PostCount        = "pst.n"
 	DeleteCount      = "del.n"
 	RenameCount      = "ren.n"
@@ -154,7 +156,8 @@ type (
 	// implements Tracker, inherited by Prunner and Trunner
 	statsRunner struct {
 		cmn.Named
-		stopCh    chan struct{}
+		stopCh    *cmn.StopCh
+		doneCh    chan struct{} // closed upon exit
 		workCh    chan NamedVal64
 		ticker    *time.Ticker
 		ctracker  copyTracker // to avoid making it at runtime
@@ -384,6 +387,7 @@ func (tracker statsTracker) registerCommonStats() {
 	tracker.register(GetCount, KindCounter, true)
 	tracker.register(PutCount, KindCounter, true)
 	tracker.register(AppendCount, KindCounter, true)
+	tracker.register(WriteAtCount, KindCounter, true)
 	tracker.register(PostCount, KindCounter, true)
 	tracker.register(DeleteCount, KindCounter, true)
 	tracker.register(RenameCount, KindCounter, true)
@@ -424,12 +428,13 @@ func (r *statsRunner) runcommon(logger statslogger) error {
 		config   = cmn.GCO.Get()
 		deadline = startupDeadlineMultiplier * config.Timeout.Startup
 	)
+	defer close(r.doneCh)
 dummy:
 	for {
 		select {
 		case <-r.workCh:
 			// drain workCh until the daemon (proxy or target) starts up
-		case <-r.stopCh:
+		case <-r.stopCh.Listen():
 			ticker.Stop()
 			return nil
 		case <-ticker.C:
@@ -482,13 +487,27 @@ dummy:
 			} else {
 				checkNumGorHigh = time.Time{}
 			}
-		case <-r.stopCh:
+		case <-r.stopCh.Listen():
 			r.ticker.Stop()
+			// apply the updates queued so far and log the final stats
+			r.drain(logger)
+			logger.log(time.Since(startTime))
 			return nil
 		}
 	}
 }
 
+func (r *statsRunner) drain(logger statslogger) {
+	for {
+		select {
+		case nv := <-r.workCh:
+			logger.doAdd(nv)
+		default:
+			return
+		}
+	}
+}
+
 func (r *statsRunner) StartedUp() bool { return r.startedUp.Load() }
 
 func (r *statsRunner) ConfigUpdate(oldConf, newConf *cmn.Config) {
@@ -500,8 +519,17 @@ func (r *statsRunner) ConfigUpdate(oldConf, newConf *cmn.Config) {
 
 func (r *statsRunner) Stop(err error) {
 	glog.Infof("Stopping %s, err: %v", r.GetRunName(), err)
-	r.stopCh <- struct{}{}
-	close(r.stopCh)
+	r.stopCh.Close()
+}
+
+// StopCtx stops the runner and waits for it to log the final stats - but not past the ctx deadline
+func (r *statsRunner) StopCtx(ctx context.Context, err error) {
+	r.Stop(err)
+	select {
+	case <-r.doneCh:
+	case <-ctx.Done():
+		glog.Errorf("%s: timed out waiting to exit", r.GetRunName())
+	}
 }
 
 // common impl
@@ -49,7 +49,8 @@ func (r *Prunner) Init(p cluster.Proxy) *atomic.Bool {
 
 	r.statsRunner.daemon = p
 
-	r.statsRunner.stopCh = make(chan struct{}, 4)
+	r.statsRunner.stopCh = cmn.NewStopCh()
+	r.statsRunner.doneCh = make(chan struct{})
 	r.statsRunner.workCh = make(chan NamedVal64, 256)
 
 	// subscribe to config changes
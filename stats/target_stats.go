@@ -30,6 +30,19 @@ const (
 	LruEvictCount  = "lru.evict.n"
 	VerChangeCount = "vchange.n"
 	VerChangeSize  = "vchange.size"
+	// remote cache
+	RemoteCacheHitCount   = "rcache.hit.n"
+	RemoteCacheEvictCount = "rcache.evict.n"
+	RemoteCacheEvictSize  = "rcache.evict.size"
+	// leftover workfiles
+	WorkfileGCCount = "workfile.gc.n"
+	WorkfileGCSize  = "workfile.gc.size"
+	// empty directories
+	EmptyDirGCCount = "emptydir.gc.n"
+	// trash (see cmn.TrashConf)
+	TrashGCCount  = "trash.gc.n"
+	TrashGCSize   = "trash.gc.size"
+	UndeleteCount = "undelete.n"
 	// rebalance
 	RebTxCount = "reb.tx.n"
 	RebTxSize  = "reb.tx.size"
@@ -42,10 +55,18 @@ const (
 	ErrIOCount       = "err.io.n"
 	// special
 	RestartCount = "restart.n"
+	// PUTs of the objects that belong to out-of-space targets (see lru.redirect_on_oos)
+	PutMisplacedCount = "put.misplaced.n"
+	// GETs of the corrupted objects served from a good replica or EC (see cksum.validate_warm_get)
+	GetCksumRepairCount = "get.cksum.repair.n"
+	// mountpaths disabled and re-enabled by the health watchdog (see fshc.watchdog)
+	FSHCDisableCount = "fshc.disable.n"
+	FSHCEnableCount  = "fshc.enable.n"
 
 	// KindLatency
 	PutLatency      = "put.µs"
 	AppendLatency   = "append.µs"
+	WriteAtLatency  = "writeat.µs"
 	GetRedirLatency = "get.redir.µs"
 	PutRedirLatency = "put.redir.µs"
 	DownloadLatency = "dl.µs"
@@ -119,7 +140,8 @@ func (r *Trunner) Init(t cluster.Target) *atomic.Bool {
 
 	r.statsRunner.daemon = t
 
-	r.statsRunner.stopCh = make(chan struct{}, 4)
+	r.statsRunner.stopCh = cmn.NewStopCh()
+	r.statsRunner.doneCh = make(chan struct{})
 	r.statsRunner.workCh = make(chan NamedVal64, 256)
 
 	// subscribe to config changes
@@ -130,6 +152,7 @@ func (r *Trunner) Init(t cluster.Target) *atomic.Bool {
 func (r *Trunner) RegisterAll() {
 	r.Register(PutLatency, KindLatency)
 	r.Register(AppendLatency, KindLatency)
+	r.Register(WriteAtLatency, KindLatency)
 	r.Register(GetColdCount, KindCounter)
 	r.Register(GetColdSize, KindCounter)
 	r.Register(GetThroughput, KindThroughput)
@@ -137,6 +160,17 @@ func (r *Trunner) RegisterAll() {
 	r.Register(LruEvictCount, KindCounter)
 	r.Register(VerChangeCount, KindCounter)
 	r.Register(VerChangeSize, KindCounter)
+	r.Register(RemoteCacheHitCount, KindCounter)
+	r.Register(RemoteCacheEvictCount, KindCounter)
+	r.Register(RemoteCacheEvictSize, KindCounter)
+	r.Register(WorkfileGCCount, KindCounter)
+	r.Register(WorkfileGCSize, KindCounter)
+	r.Register(EmptyDirGCCount, KindCounter)
+	r.Register(TrashGCCount, KindCounter)
+	r.Register(TrashGCSize, KindCounter)
+	r.Register(UndeleteCount, KindCounter)
+	r.Register(PutMisplacedCount, KindCounter)
+	r.Register(GetCksumRepairCount, KindCounter)
 	r.Register(GetRedirLatency, KindLatency)
 	r.Register(PutRedirLatency, KindLatency)
 
@@ -145,6 +179,8 @@ func (r *Trunner) RegisterAll() {
 	r.Register(ErrCksumSize, KindCounter)
 	r.Register(ErrMetadataCount, KindCounter)
 	r.Register(ErrIOCount, KindCounter)
+	r.Register(FSHCDisableCount, KindCounter)
+	r.Register(FSHCEnableCount, KindCounter)
 
 	// rebalance
 	r.Register(RebTxCount, KindCounter)
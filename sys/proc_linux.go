@@ -6,6 +6,9 @@ package sys
 
 import (
 	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 
@@ -69,3 +72,20 @@ func procCPU(pid int) (ProcCPUStats, error) {
 
 	return cpu, nil
 }
+
+// OpenFiles returns the paths of all the files currently open by this process
+func OpenFiles() (map[string]struct{}, error) {
+	const fdDir = "/proc/self/fd"
+	fds, err := ioutil.ReadDir(fdDir)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string]struct{}, len(fds))
+	for _, fd := range fds {
+		// the descriptor may get closed in the meantime - skip it
+		if path, err := os.Readlink(filepath.Join(fdDir, fd.Name())); err == nil {
+			files[path] = struct{}{}
+		}
+	}
+	return files, nil
+}
@@ -7,6 +7,7 @@ package sys
 // Do not import main 'tutils' package because of circular dependency
 // Use t.Logf or t.Errorf instead of tutils.Logf
 import (
+	"io/ioutil"
 	"math"
 	"os"
 	"runtime"
@@ -126,3 +127,22 @@ func TestProc(t *testing.T) {
 	tassert.Errorf(t, newStats.CPU.LastTime > stats.CPU.LastTime, "Time must change: new %d, old %d", newStats.CPU.LastTime, stats.CPU.LastTime)
 	t.Logf("Process CPU usage: %6.2f%%", newStats.CPU.Percent)
 }
+
+func TestOpenFiles(t *testing.T) {
+	checkSkipOS(t, "darwin")
+
+	f, err := ioutil.TempFile("", "openfiles")
+	tassert.CheckFatal(t, err)
+	defer os.Remove(f.Name())
+
+	files, err := OpenFiles()
+	tassert.CheckFatal(t, err)
+	_, ok := files[f.Name()]
+	tassert.Errorf(t, ok, "expected %q to be open", f.Name())
+
+	f.Close()
+	files, err = OpenFiles()
+	tassert.CheckFatal(t, err)
+	_, ok = files[f.Name()]
+	tassert.Errorf(t, !ok, "expected %q to be closed", f.Name())
+}
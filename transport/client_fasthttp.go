@@ -35,7 +35,7 @@ func dialTimeout(addr string) (net.Conn, error) {
 // intra-cluster networking: fasthttp client
 func NewIntraDataClient() Client {
 	config := cmn.GCO.Get()
-	if !config.Net.HTTP.UseHTTPS {
+	if !config.Net.Listener(cmn.NetworkIntraData).UseHTTPS {
 		return &fasthttp.Client{
 			Dial:            dialTimeout,
 			ReadBufferSize:  config.Net.HTTP.ReadBufferSize,
@@ -60,6 +60,9 @@ func (s *Stream) do(body io.Reader) (err error) {
 		req.Header.Set(cmn.HeaderCompress, cmn.LZ4Compression)
 	}
 	req.Header.Set(cmn.HeaderSessID, strconv.FormatInt(s.sessID, 10))
+	if s.seg.h != nil {
+		req.Header.Set(cmn.HeaderSegCksum, strconv.FormatInt(s.seg.size, 10))
+	}
 	// do
 	err = s.client.Do(req, resp)
 	if err != nil {
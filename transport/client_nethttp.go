@@ -30,7 +30,7 @@ func NewIntraDataClient() *http.Client {
 		SndRcvBufSize:   config.Net.L4.SndRcvBufSize,
 		WriteBufferSize: config.Net.HTTP.WriteBufferSize,
 		ReadBufferSize:  config.Net.HTTP.ReadBufferSize,
-		UseHTTPS:        config.Net.HTTP.UseHTTPS,
+		UseHTTPS:        config.Net.Listener(cmn.NetworkIntraData).UseHTTPS,
 		SkipVerify:      config.Net.HTTP.SkipVerify,
 	})
 }
@@ -48,6 +48,9 @@ func (s *Stream) do(body io.Reader) (err error) {
 		request.Header.Set(cmn.HeaderCompress, cmn.LZ4Compression)
 	}
 	request.Header.Set(cmn.HeaderSessID, strconv.FormatInt(s.sessID, 10))
+	if s.seg.h != nil {
+		request.Header.Set(cmn.HeaderSegCksum, strconv.FormatInt(s.seg.size, 10))
+	}
 
 	// do
 	response, err = s.client.Do(request)
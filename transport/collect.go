@@ -7,6 +7,7 @@ package transport
 
 import (
 	"container/heap"
+	"context"
 	"errors"
 	"time"
 
@@ -27,6 +28,7 @@ func Init() *StreamCollector {
 	// real stream collector
 	gc = &collector{
 		stopCh:  cmn.NewStopCh(),
+		doneCh:  make(chan struct{}),
 		ctrlCh:  make(chan ctrl, 64),
 		streams: make(map[string]*Stream, 64),
 		heap:    make([]*Stream, 0, 64), // min-heap sorted by stream.time.ticks
@@ -46,8 +48,19 @@ func (sc *StreamCollector) Stop(err error) {
 	gc.stop()
 }
 
+// StopCtx stops all streams and waits for them to terminate - but not past the ctx deadline
+func (sc *StreamCollector) StopCtx(ctx context.Context, err error) {
+	sc.Stop(err)
+	select {
+	case <-gc.doneCh:
+	case <-ctx.Done():
+		glog.Errorf("%s: timed out waiting for the streams to terminate", sc.GetRunName())
+	}
+}
+
 func (gc *collector) run() (err error) {
 	gc.ticker = time.NewTicker(tickUnit)
+	defer close(gc.doneCh)
 	for {
 		select {
 		case <-gc.ticker.C:
@@ -67,9 +80,11 @@ func (gc *collector) run() (err error) {
 				s.time.ticks = 1
 			}
 		case <-gc.stopCh.Listen():
+			gc.ticker.Stop()
 			for _, s := range gc.streams {
 				s.Stop()
 			}
+			gc.wait()
 			gc.streams = nil
 			return
 		}
@@ -172,6 +187,22 @@ func (gc *collector) do() {
 	// 2. heap[i+1].ticks >= heap[i].ticks
 }
 
+// wait for the stopped streams to terminate and complete their pending objects;
+// the streams' removals are no longer needed and get discarded
+func (gc *collector) wait() {
+	ticker := time.NewTicker(tickUnit / 10)
+	defer ticker.Stop()
+	for _, s := range gc.streams {
+		for !s.Terminated() {
+			select {
+			case <-gc.ctrlCh:
+			case <-ticker.C:
+			}
+		}
+		gc.drain(s)
+	}
+}
+
 // drain terminated stream
 func (gc *collector) drain(s *Stream) {
 DrainFor:
@@ -41,12 +41,23 @@ type (
 		body      io.Reader
 		fbuf      *fixedBuffer // when extraBuffering == true
 		headerBuf []byte
+		seg       *segReader // segmented checksum (see segCksum)
 	}
 	objReader struct {
 		body io.Reader
 		off  int64
 		fbuf *fixedBuffer // ditto
 		hdr  Header
+		it   *iterator
+	}
+	// receiving side of the segmented checksum: reads and validates the next
+	// segment of the payload prior to handing it over
+	segReader struct {
+		buf  []byte
+		slab *memsys.Slab
+		data []byte // validated and not yet read part of the current segment
+		size int64  // segment size
+		off  int64  // offset of the current object's payload read so far
 	}
 	handler struct {
 		trname      string
@@ -238,6 +249,15 @@ func (h *handler) receive(w http.ResponseWriter, r *http.Request) {
 
 	// Rx loop
 	it := &iterator{trname: trname, body: reader, fbuf: fbuf, headerBuf: make([]byte, maxHeaderSize)}
+	if segSize := r.Header.Get(cmn.HeaderSegCksum); segSize != "" {
+		size, err := strconv.ParseInt(segSize, 10, 64)
+		if err != nil || size <= 0 || size > memsys.MaxPageSlabSize {
+			cmn.InvalidHandlerDetailed(w, r, fmt.Sprintf("%s[:%d]: invalid segment size %q", trname, sessID, segSize))
+			return
+		}
+		it.seg = newSegReader(size, h.mem)
+		defer it.seg.free()
+	}
 	for {
 		objReader, hl64, err := it.next()
 		if hl64 != 0 {
@@ -364,7 +384,10 @@ func (it *iterator) next() (obj *objReader, hl64 int64, err error) {
 		return
 	}
 
-	obj = &objReader{body: it.body, fbuf: it.fbuf, hdr: hdr}
+	obj = &objReader{body: it.body, fbuf: it.fbuf, hdr: hdr, it: it}
+	if it.seg != nil {
+		it.seg.data, it.seg.off = nil, 0
+	}
 	return
 }
 
@@ -373,6 +396,9 @@ func (it *iterator) next() (obj *objReader, hl64 int64, err error) {
 //
 
 func (obj *objReader) Read(b []byte) (n int, err error) {
+	if obj.it.seg != nil {
+		return obj.readSeg(b)
+	}
 	rem := obj.hdr.ObjAttrs.Size - obj.off
 	if rem < int64(len(b)) {
 		b = b[:int(rem)]
@@ -405,6 +431,67 @@ func (obj *objReader) Read(b []byte) (n int, err error) {
 	return
 }
 
+// same as above with segmented checksum: returns BadDataCksumError upon mismatch
+// (and before handing over any of the segment's data)
+func (obj *objReader) readSeg(b []byte) (n int, err error) {
+	var (
+		seg  = obj.it.seg
+		size = obj.hdr.ObjAttrs.Size
+	)
+	if len(seg.data) == 0 {
+		if obj.off >= size {
+			return 0, io.EOF
+		}
+		if err = seg.next(obj.it, size, &obj.hdr); err != nil {
+			glog.Errorln(err)
+			return
+		}
+	}
+	n = copy(b, seg.data)
+	seg.data = seg.data[n:]
+	obj.off += int64(n)
+	if obj.off >= size {
+		err = io.EOF
+	}
+	return
+}
+
+///////////////
+// segReader //
+///////////////
+
+func newSegReader(size int64, mem *memsys.MMSA) *segReader {
+	if mem == nil {
+		mem = memsys.DefaultPageMM()
+	}
+	buf, slab := mem.Alloc(size + int64(cmn.SizeofI64))
+	return &segReader{buf: buf, slab: slab, size: size}
+}
+
+func (seg *segReader) free() { seg.slab.Free(seg.buf) }
+
+// read the next segment of the object's payload followed by its checksum, and validate
+func (seg *segReader) next(r io.Reader, objSize int64, hdr *Header) error {
+	l := cmn.MinI64(seg.size, objSize-seg.off)
+	buf := seg.buf[:l+int64(cmn.SizeofI64)]
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return err
+	}
+	var (
+		expected = binary.BigEndian.Uint64(buf[l:])
+		actual   = xxhash.Checksum64S(buf[:l], 0)
+	)
+	if actual != expected {
+		return cmn.NewBadDataCksumError(
+			cmn.NewCksum(cmn.ChecksumXXHash, strconv.FormatUint(expected, 16)),
+			cmn.NewCksum(cmn.ChecksumXXHash, strconv.FormatUint(actual, 16)),
+			fmt.Sprintf("%s/%s[%d:%d]", hdr.Bck, hdr.ObjName, seg.off, seg.off+l))
+	}
+	seg.data = buf[:l]
+	seg.off += l
+	return nil
+}
+
 //
 // fixedBuffer - a fixed-size reusable buffer and io.Reader
 //
@@ -474,6 +561,16 @@ func extAttrs(off int, from []byte) (n int, attr ObjectAttrs) {
 	off, attr.CksumType = extString(off, from)
 	off, attr.CksumValue = extString(off, from)
 	off, attr.Version = extString(off, from)
+	off, cnt := extInt64(off, from)
+	if cnt > 0 {
+		attr.CustomMD = make(cmn.SimpleKVs, cnt)
+	}
+	for i := int64(0); i < cnt; i++ {
+		var k, v string
+		off, k = extString(off, from)
+		off, v = extString(off, from)
+		attr.CustomMD[k] = v
+	}
 	return off, attr
 }
 
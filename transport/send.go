@@ -24,19 +24,22 @@ import (
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/cmn/mono"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/xoshiro256"
+	"github.com/OneOfOne/xxhash"
 	"github.com/pierrec/lz4/v3"
 )
 
 // transport defaults
 const (
-	maxHeaderSize  = 1024
+	maxHeaderSize  = 4 * cmn.KiB
 	lastMarker     = math.MaxInt64
 	tickMarker     = math.MaxInt64 ^ 0xa5a5a5a5
 	tickUnit       = time.Second
 	defaultIdleOut = time.Second * 2
 	burstNum       = 32 // default max num objects that can be posted for sending without any back-pressure
+	segCksumSize   = 64 * cmn.KiB
 )
 
 // stream TCP/HTTP session: inactive <=> active transitions
@@ -89,6 +92,8 @@ type (
 			reason     *string
 		}
 		lz4s lz4Stream
+		seg  segCksum
+		peer *cmn.PeerCallTracker // per-destination stats (see GetPeerStats)
 	}
 	// advanced usage: additional stream control
 	Extra struct {
@@ -97,6 +102,7 @@ type (
 		Compression string        // see CompressAlways, etc. enum
 		MMSA        *memsys.MMSA  // compression-related buffering
 		Config      *cmn.Config
+		SegCksum    bool // true: checksum the payload segment by segment - see segCksum
 	}
 	// stream stats
 	Stats struct {
@@ -109,11 +115,12 @@ type (
 
 	// object attrs
 	ObjectAttrs struct {
-		Atime      int64  // access time - nanoseconds since UNIX epoch
-		Size       int64  // size of objects in bytes
-		CksumType  string // checksum type
-		CksumValue string // checksum of the object produced by given checksum type
-		Version    string // version of the object
+		Atime      int64         // access time - nanoseconds since UNIX epoch
+		Size       int64         // size of objects in bytes
+		CksumType  string        // checksum type
+		CksumValue string        // checksum of the object produced by given checksum type
+		Version    string        // version of the object
+		CustomMD   cmn.SimpleKVs // custom metadata of the object (e.g., encryption - see package encrypt)
 	}
 	// object header
 	Header struct {
@@ -129,7 +136,8 @@ type (
 		Callback SendCallback   // callback fired when sending is done OR when the stream terminates (see term.reason)
 		CmplPtr  unsafe.Pointer // local pointer that gets returned to the caller via Send completion callback
 		// private
-		prc *atomic.Int64 // if present, ref-counts num sent objects to call SendCallback only once
+		prc  *atomic.Int64 // if present, ref-counts num sent objects to call SendCallback only once
+		sent int64         // mono time when enqueued (see GetPeerStats)
 	}
 
 	// object-sent callback that has the following signature can optionally be defined on a:
@@ -159,6 +167,17 @@ type (
 		// in progress
 		off int64
 		dod int64
+		seg int64 // segmented checksum: offset in the current segment
+		trl int   // ditto: remaining (unsent) bytes of the segment's checksum
+	}
+	// segmented checksum: the payload of each object is sent in segments of
+	// segCksumSize bytes (the last one can be shorter), each followed by its
+	// xxhash - for the receiver to verify the segment before handing it over
+	// and to fail the object (and the stream) upon the first mismatch
+	segCksum struct {
+		size    int64
+		h       *xxhash.XXHash64
+		trailer [cmn.SizeofI64]byte
 	}
 	cmpl struct { // send completions => SCQ
 		obj Obj
@@ -171,6 +190,7 @@ type (
 		heap    []*Stream
 		ticker  *time.Ticker
 		stopCh  *cmn.StopCh
+		doneCh  chan struct{} // closed when all streams are stopped
 		ctrlCh  chan ctrl
 	}
 	ctrl struct { // add/del channel to/from collector
@@ -199,7 +219,7 @@ func NewStream(client Client, toURL string, extra *Extra) (s *Stream) {
 		glog.Errorf("Failed to parse %s: %v", toURL, err)
 		return
 	}
-	s = &Stream{client: client, toURL: toURL}
+	s = &Stream{client: client, toURL: toURL, peer: peerTracker(u)}
 
 	s.time.idleOut = defaultIdleOut
 	if extra != nil {
@@ -207,6 +227,10 @@ func NewStream(client Client, toURL string, extra *Extra) (s *Stream) {
 		if extra.IdleTimeout > 0 {
 			s.time.idleOut = extra.IdleTimeout
 		}
+		if extra.SegCksum {
+			s.seg.size = segCksumSize
+			s.seg.h = xxhash.New64()
+		}
 		if extra.compressed() {
 			config := extra.Config
 			if config == nil {
@@ -312,6 +336,11 @@ func (s *Stream) Send(obj Obj) (err error) {
 		glog.Errorln(err)
 		return
 	}
+	if l := hdr.packedSize(); l > maxHeaderSize {
+		err = fmt.Errorf("%s: cannot send [%s/%s]: header size %d exceeds %d", s, hdr.Bck, hdr.ObjName, l, maxHeaderSize)
+		glog.Errorln(err)
+		return
+	}
 	if s.sessST.CAS(inactive, active) {
 		s.postCh <- struct{}{}
 		if glog.FastV(4, glog.SmoduleTransport) {
@@ -323,6 +352,7 @@ func (s *Stream) Send(obj Obj) (err error) {
 		cmn.Assert(hdr.IsHeaderOnly())
 		obj.Reader = nopRC
 	}
+	obj.sent = mono.NanoTime()
 	s.workCh <- obj
 	if glog.FastV(4, glog.SmoduleTransport) {
 		glog.Infof("%s: send %s/%s(%d)[sq=%d]", s, hdr.Bck, hdr.ObjName, hdr.ObjAttrs.Size, len(s.workCh))
@@ -388,6 +418,18 @@ func (s *Stream) GetStats() (stats Stats) {
 	return
 }
 
+// the size of the header as sent (see insHeader)
+func (hdr *Header) packedSize() (l int) {
+	l = cmn.SizeofI64*8 + len(hdr.Bck.Name) + len(hdr.ObjName) + len(hdr.Bck.Provider) +
+		len(hdr.Bck.Ns.Name) + len(hdr.Bck.Ns.UUID) + len(hdr.Opaque)
+	attr := &hdr.ObjAttrs
+	l += cmn.SizeofI64*6 + len(attr.CksumType) + len(attr.CksumValue) + len(attr.Version)
+	for k, v := range attr.CustomMD {
+		l += cmn.SizeofI64*2 + len(k) + len(v)
+	}
+	return
+}
+
 func (hdr *Header) IsLast() bool       { return hdr.ObjAttrs.Size == lastMarker }
 func (hdr *Header) IsIdleTick() bool   { return hdr.ObjAttrs.Size == tickMarker }
 func (hdr *Header) IsHeaderOnly() bool { return hdr.ObjAttrs.Size == 0 || hdr.IsLast() }
@@ -454,6 +496,9 @@ func (s *Stream) cmplLoop() {
 // refcount, invoke Sendcallback, and *always* close the reader
 func (s *Stream) objDone(obj *Obj, err error) {
 	var rc int64
+	if obj.sent != 0 {
+		s.peer.Add(mono.Since(obj.sent), err)
+	}
 	if obj.prc != nil {
 		rc = obj.prc.Dec()
 		cmn.Assert(rc >= 0) // remove
@@ -594,6 +639,9 @@ func (s *Stream) sendData(b []byte) (n int, err error) {
 		obj     = &s.sendoff.obj
 		objSize = obj.Hdr.ObjAttrs.Size
 	)
+	if s.seg.h != nil {
+		return s.sendSegData(b)
+	}
 	n, err = obj.Reader.Read(b)
 	s.sendoff.off += int64(n)
 	if err != nil {
@@ -610,6 +658,45 @@ func (s *Stream) sendData(b []byte) (n int, err error) {
 	return
 }
 
+// same as above with segmented checksum (see segCksum)
+func (s *Stream) sendSegData(b []byte) (n int, err error) {
+	var (
+		obj     = &s.sendoff.obj
+		objSize = obj.Hdr.ObjAttrs.Size
+	)
+	if s.sendoff.trl > 0 {
+		n = copy(b, s.seg.trailer[cmn.SizeofI64-s.sendoff.trl:])
+		s.sendoff.trl -= n
+		if s.sendoff.trl == 0 && s.sendoff.off >= objSize {
+			s.eoObj(nil)
+		}
+		return
+	}
+	if rem := s.seg.size - s.sendoff.seg; int64(len(b)) > rem {
+		b = b[:rem]
+	}
+	n, err = obj.Reader.Read(b)
+	s.sendoff.off += int64(n)
+	s.sendoff.seg += int64(n)
+	s.seg.h.Write(b[:n])
+	if err != nil {
+		if err != io.EOF {
+			s.eoObj(err)
+			return
+		}
+		if s.sendoff.off < objSize {
+			return n, fmt.Errorf("%s: read (%d) shorter than expected (%d)", s, s.sendoff.off, objSize)
+		}
+		err = nil
+	}
+	if s.sendoff.seg >= s.seg.size || s.sendoff.off >= objSize {
+		binary.BigEndian.PutUint64(s.seg.trailer[:], s.seg.h.Sum64())
+		s.seg.h.Reset()
+		s.sendoff.seg, s.sendoff.trl = 0, cmn.SizeofI64
+	}
+	return
+}
+
 //
 // end-of-object: updates stats, reset idle timeout, and post completion
 // NOTE: reader.Close() is done by the completion handling code objDone
@@ -654,6 +741,7 @@ func (s *Stream) insHeader(hdr Header) (l int) {
 	l = insString(l, s.maxheader, hdr.Bck.Ns.UUID)
 	l = insByte(l, s.maxheader, hdr.Opaque)
 	l = insAttrs(l, s.maxheader, hdr.ObjAttrs)
+	debug.Assert(l == hdr.packedSize())
 	hlen := l - cmn.SizeofI64*2
 	insInt64(0, s.maxheader, int64(hlen))
 	checksum := xoshiro256.Hash(uint64(hlen))
@@ -689,6 +777,11 @@ func insAttrs(off int, to []byte, attr ObjectAttrs) int {
 	off = insString(off, to, attr.CksumType)
 	off = insString(off, to, attr.CksumValue)
 	off = insString(off, to, attr.Version)
+	off = insInt64(off, to, int64(len(attr.CustomMD)))
+	for k, v := range attr.CustomMD {
+		off = insString(off, to, k)
+		off = insString(off, to, v)
+	}
 	return off
 }
 
@@ -699,6 +792,10 @@ func (s *Stream) dryrun() {
 	buf := make([]byte, cmn.KiB*32)
 	scloser := ioutil.NopCloser(s)
 	it := iterator{trname: s.trname, body: scloser, headerBuf: make([]byte, maxHeaderSize)}
+	if s.seg.h != nil {
+		it.seg = newSegReader(s.seg.size, nil)
+		defer it.seg.free()
+	}
 	for {
 		objReader, _, err := it.next()
 		if objReader != nil {
@@ -722,6 +819,29 @@ func (stats *Stats) CompressionRatio() float64 {
 	return float64(bytesRead) / float64(bytesSent)
 }
 
+//
+// per-destination stats -----------
+//
+
+// all streams to a given node share the same tracker
+var peerTrackers sync.Map // [scheme://host => *cmn.PeerCallTracker]
+
+func peerTracker(u *url.URL) *cmn.PeerCallTracker {
+	pt, _ := peerTrackers.LoadOrStore(u.Scheme+"://"+u.Host, &cmn.PeerCallTracker{})
+	return pt.(*cmn.PeerCallTracker)
+}
+
+// GetPeerStats returns the stats of the objects sent so far, by destination
+// (scheme://host) - across all streams and since startup
+func GetPeerStats() map[string]*cmn.PeerChanStats {
+	stats := make(map[string]*cmn.PeerChanStats, 16)
+	peerTrackers.Range(func(key, value interface{}) bool {
+		stats[key.(string)] = value.(*cmn.PeerCallTracker).Stats()
+		return true
+	})
+	return stats
+}
+
 //
 // nopReadCloser ---------------------------
 //
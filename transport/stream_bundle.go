@@ -6,6 +6,7 @@
 package transport
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"sync"
@@ -129,6 +130,24 @@ func (sb *StreamBundle) Close(gracefully bool) {
 	}
 }
 
+// StopCtx closes the bundle gracefully, as in Close(true), but waits for the pending objects
+// to complete only until the ctx deadline - upon which the streams get stopped and the
+// (remaining) completions happen asynchronously
+func (sb *StreamBundle) StopCtx(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		sb.Close(true /*gracefully*/)
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		sb.apply(closeStop)
+		return ctx.Err()
+	}
+}
+
 // (nodes == nil): transmit via all established streams
 func (sb *StreamBundle) Send(obj Obj, roc cmn.ReadOpenCloser, nodes ...*cluster.Snode) (err error) {
 	var (
@@ -6,6 +6,7 @@
 package transport_test
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -187,3 +188,56 @@ func addTarget(smap *cluster.Smap, ts *httptest.Server, i int) {
 	tid := "t_" + strconv.FormatInt(int64(i), 10)
 	smap.Tmap[tid] = &cluster.Snode{PublicNet: netinfo, IntraControlNet: netinfo, IntraDataNet: netinfo}
 }
+
+func Test_BundleStopCtx(t *testing.T) {
+	var (
+		numCompleted, numFailed atomic.Int64
+		network                 = cmn.NetworkIntraData
+		trname                  = "bundle-stop"
+		release                 = make(chan struct{})
+		mux                     = mux.NewServeMux()
+	)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+	smap.Tmap = make(cluster.NodeMap, 1)
+	addTarget(&smap, ts, 0)
+	smap.Version = 1
+	transport.SetMux(network, mux)
+
+	// the receiver hangs - the graceful close cannot complete
+	receive := func(w http.ResponseWriter, hdr transport.Header, objReader io.Reader, err error) {
+		<-release
+		io.Copy(ioutil.Discard, objReader)
+	}
+	callback := func(_ transport.Header, _ io.ReadCloser, _ unsafe.Pointer, err error) {
+		if err != nil {
+			numFailed.Inc()
+		}
+		numCompleted.Inc()
+	}
+	_, err := transport.Register(network, trname, receive)
+	tassert.CheckFatal(t, err)
+
+	sb := transport.NewStreamBundle(&sowner{}, &cluster.Snode{DaemonID: "local"}, transport.NewIntraDataClient(),
+		transport.SBArgs{Network: network, Trname: trname})
+	const num = 10
+	for i := 0; i < num; i++ {
+		hdr := transport.Header{ObjName: "obj" + strconv.Itoa(i)}
+		tassert.CheckFatal(t, sb.Send(transport.Obj{Hdr: hdr, Callback: callback}, nil))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	started := time.Now()
+	err = sb.StopCtx(ctx)
+	tassert.Errorf(t, err == context.DeadlineExceeded, "expected %v, got %v", context.DeadlineExceeded, err)
+	tassert.Errorf(t, time.Since(started) < 5*time.Second, "expected to return by the deadline")
+
+	// all objects get completed, one way or another, once the receiver "unhangs"
+	close(release)
+	for i := 0; i < 50 && numCompleted.Load() < num; i++ {
+		time.Sleep(100 * time.Millisecond)
+	}
+	tassert.Errorf(t, numCompleted.Load() == num, "expected %d completions, got %d (failed %d)",
+		num, numCompleted.Load(), numFailed.Load())
+}
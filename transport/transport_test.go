@@ -21,6 +21,7 @@ package transport_test
 //
 
 import (
+	"bytes"
 	"encoding/binary"
 	"flag"
 	"fmt"
@@ -34,6 +35,7 @@ import (
 	"path"
 	"reflect"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -121,8 +123,8 @@ func Example_headers() {
 	stream.Fin()
 
 	// Output:
-	// {Bck:aws://@uuid#namespace/abc ObjName:X ObjAttrs:{Atime:663346294 Size:231 CksumType:xxhash CksumValue:hash Version:2} Opaque:[]} (119)
-	// {Bck:ais://abracadabra ObjName:p/q/s ObjAttrs:{Atime:663346294 Size:213 CksumType:xxhash CksumValue:hash Version:2} Opaque:[49 50 51]} (121)
+	// {Bck:aws://@uuid#namespace/abc ObjName:X ObjAttrs:{Atime:663346294 Size:231 CksumType:xxhash CksumValue:hash Version:2 CustomMD:map[]} Opaque:[]} (127)
+	// {Bck:ais://abracadabra ObjName:p/q/s ObjAttrs:{Atime:663346294 Size:213 CksumType:xxhash CksumValue:hash Version:2 CustomMD:map[]} Opaque:[49 50 51]} (129)
 }
 
 func sendText(stream *transport.Stream, txt1, txt2 string) {
@@ -387,6 +389,7 @@ func Test_ObjAttrs(t *testing.T) {
 			CksumType:  cmn.ChecksumXXHash,
 			CksumValue: "120421",
 			Version:    "102.44",
+			CustomMD:   cmn.SimpleKVs{"sse": "AES256", "sse-key-id": "ais/@#/bck", "tag": ""},
 		},
 		{
 			Size:       0,
@@ -443,12 +446,114 @@ func Test_ObjAttrs(t *testing.T) {
 			t.Fatal(err)
 		}
 	}
+	// header too large to send
+	hdr := transport.Header{
+		Bck:      cmn.Bck{Provider: cmn.ProviderAIS},
+		ObjAttrs: transport.ObjectAttrs{CustomMD: cmn.SimpleKVs{"large": strings.Repeat("x", 8*cmn.KiB)}},
+	}
+	if err := stream.Send(transport.Obj{Hdr: hdr}); err == nil {
+		t.Error("expected error sending a header that exceeds the max size")
+	}
 	stream.Fin()
 	if receivedCount.Load() != int64(len(testAttrs)) {
 		t.Fatalf("invalid received count: %d, expected: %d", receivedCount.Load(), len(testAttrs))
 	}
 }
 
+func Test_SegCksum(t *testing.T) {
+	sizes := []int64{0, 1, 64*cmn.KiB - 1, 64 * cmn.KiB, 64*cmn.KiB + 1, 3*64*cmn.KiB + 5, cmn.MiB}
+
+	mux := mux.NewServeMux()
+	transport.SetMux("n1", mux)
+	ts := httptest.NewServer(mux)
+	defer ts.Close()
+
+	var (
+		random  = newRand(mono.NanoTime())
+		objects = make([][]byte, len(sizes))
+		recvCnt atomic.Int64
+	)
+	recvFunc := func(w http.ResponseWriter, hdr transport.Header, objReader io.Reader, err error) {
+		tassert.CheckFatal(t, err)
+		b, err := ioutil.ReadAll(objReader)
+		tassert.CheckFatal(t, err)
+		idx := hdr.Opaque[0]
+		tassert.Errorf(t, reflect.DeepEqual(b, objects[idx]), "object #%d (size %d) differs", idx, len(b))
+		recvCnt.Inc()
+	}
+	path, err := transport.Register("n1", "segcksum", recvFunc)
+	tassert.CheckFatal(t, err)
+	httpclient := transport.NewIntraDataClient()
+	stream := transport.NewStream(httpclient, ts.URL+path, &transport.Extra{SegCksum: true})
+
+	for idx, size := range sizes {
+		objects[idx] = make([]byte, size)
+		random.Read(objects[idx])
+		hdr := transport.Header{Bck: cmn.Bck{Provider: cmn.ProviderAIS}, Opaque: []byte{byte(idx)}}
+		hdr.ObjAttrs.Size = size
+		reader := ioutil.NopCloser(bytes.NewReader(objects[idx]))
+		tassert.CheckFatal(t, stream.Send(transport.Obj{Hdr: hdr, Reader: reader}))
+	}
+	stream.Fin()
+	tassert.Fatalf(t, recvCnt.Load() == int64(len(sizes)), "received %d, expected %d", recvCnt.Load(), len(sizes))
+}
+
+// flips a single byte of the stream in flight
+type corruptingReader struct {
+	io.ReadCloser
+	off, at int64
+}
+
+func (r *corruptingReader) Read(p []byte) (n int, err error) {
+	n, err = r.ReadCloser.Read(p)
+	if r.at >= r.off && r.at < r.off+int64(n) {
+		p[r.at-r.off] ^= 0xff
+	}
+	r.off += int64(n)
+	return
+}
+
+func Test_SegCksumCorrupted(t *testing.T) {
+	mux := mux.NewServeMux()
+	transport.SetMux("n1", mux)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.Body = &corruptingReader{ReadCloser: r.Body, at: 3 * 64 * cmn.KiB}
+		mux.ServeHTTP(w, r)
+	}))
+	defer ts.Close()
+
+	var (
+		objSize  = int64(cmn.MiB)
+		read     atomic.Int64
+		cksumErr atomic.Bool
+	)
+	recvFunc := func(w http.ResponseWriter, hdr transport.Header, objReader io.Reader, err error) {
+		if objReader == nil { // the stream is aborted
+			return
+		}
+		n, err := io.Copy(ioutil.Discard, objReader)
+		read.Store(n)
+		if _, ok := err.(*cmn.BadCksumError); ok {
+			cksumErr.Store(true)
+		}
+	}
+	path, err := transport.Register("n1", "segcksum-corrupted", recvFunc)
+	tassert.CheckFatal(t, err)
+	httpclient := transport.NewIntraDataClient()
+	stream := transport.NewStream(httpclient, ts.URL+path, &transport.Extra{SegCksum: true})
+
+	hdr := transport.Header{Bck: cmn.Bck{Provider: cmn.ProviderAIS}}
+	hdr.ObjAttrs.Size = objSize
+	buf := make([]byte, objSize)
+	reader := ioutil.NopCloser(bytes.NewReader(buf))
+	tassert.CheckFatal(t, stream.Send(transport.Obj{Hdr: hdr, Reader: reader}))
+	stream.Fin()
+
+	tassert.Errorf(t, cksumErr.Load(), "expected checksum error")
+	// the data that precedes the corrupted segment is delivered, nothing after
+	tassert.Errorf(t, read.Load() < 3*64*cmn.KiB, "read %d bytes past the corrupted segment", read.Load())
+}
+
 //
 // test helpers
 //
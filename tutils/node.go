@@ -20,7 +20,15 @@ import (
 
 func RegisterNode(proxyURL string, node *cluster.Snode, smap *cluster.Smap) error {
 	baseParams := BaseAPIParams(proxyURL)
-	if err := api.RegisterNode(baseParams, node); err != nil {
+	// targets are admitted with a join token when the cluster requires one (proxy.join_tokens)
+	// (without auth.secret, no token can be minted)
+	token, err := api.NewJoinToken(baseParams, 0)
+	if err != nil {
+		err = api.RegisterNode(baseParams, node)
+	} else {
+		err = api.RegisterNodeWithToken(baseParams, node, token)
+	}
+	if err != nil {
 		return err
 	}
 
@@ -155,6 +155,66 @@ func (e *ecEncodeEntry) preRenewHook(previousEntry bucketEntry) (keep bool, err
 	return
 }
 
+//
+// ecRestoreEntry
+//
+type ecRestoreEntry struct {
+	baseBckEntry
+	t        cluster.Target
+	xact     *ec.XactBckRestore
+	template string
+}
+
+func (e *ecRestoreEntry) Start(bck cmn.Bck) error {
+	e.xact = ec.NewXactBckRestore(bck, e.t, e.uuid, e.template)
+	return nil
+}
+
+func (*ecRestoreEntry) Kind() string    { return cmn.ActECRestore }
+func (e *ecRestoreEntry) Get() cmn.Xact { return e.xact }
+func (e *ecRestoreEntry) preRenewHook(_ bucketEntry) (keep bool, err error) {
+	return false, nil
+}
+
+func (r *registry) RenewECRestoreXact(t cluster.Target, bck *cluster.Bck, uuid, template string) (*ec.XactBckRestore, error) {
+	e := &ecRestoreEntry{baseBckEntry: baseBckEntry{uuid}, t: t, template: template}
+	ee, err := r.renewBucketXaction(e, bck)
+	if err == nil {
+		return ee.Get().(*ec.XactBckRestore), nil
+	}
+	return nil, err
+}
+
+//
+// ecMetaMigrateEntry
+//
+type ecMetaMigrateEntry struct {
+	baseBckEntry
+	t    cluster.Target
+	xact *ec.XactBckMetaMigrate
+}
+
+func (e *ecMetaMigrateEntry) Start(bck cmn.Bck) error {
+	e.xact = ec.NewXactBckMetaMigrate(bck, e.t, e.uuid)
+	return nil
+}
+
+func (*ecMetaMigrateEntry) Kind() string    { return cmn.ActECMetaMigrate }
+func (e *ecMetaMigrateEntry) Get() cmn.Xact { return e.xact }
+func (e *ecMetaMigrateEntry) preRenewHook(previousEntry bucketEntry) (keep bool, err error) {
+	err = fmt.Errorf("%s is already running", previousEntry.Get())
+	return
+}
+
+func (r *registry) RenewECMetaMigrateXact(t cluster.Target, bck *cluster.Bck, uuid string) (*ec.XactBckMetaMigrate, error) {
+	e := &ecMetaMigrateEntry{baseBckEntry: baseBckEntry{uuid}, t: t}
+	ee, err := r.renewBucketXaction(e, bck)
+	if err == nil {
+		return ee.Get().(*ec.XactBckMetaMigrate), nil
+	}
+	return nil, err
+}
+
 //
 // mncEntry
 //
@@ -380,12 +440,25 @@ type (
 		t          cluster.Target
 		bckFrom    *cluster.Bck
 		bckTo      *cluster.Bck
+		tracked    cluster.TrackedPuts // see TrackPut
 	}
 )
 
 func (r *FastRen) IsMountpathXact() bool { return true }
 func (r *FastRen) String() string        { return fmt.Sprintf("%s <= %s", r.XactBase.String(), r.bckFrom) }
 
+// TrackPut implements cluster.PutTracker: the objects PUT into the bucket that
+// is being renamed get copied to the new one right before the old one goes away
+func (r *FastRen) TrackPut(lom *cluster.LOM) bool { return r.tracked.Add(lom.ObjName) }
+
+// ProcessPut implements cluster.PutTracker
+func (r *FastRen) ProcessPut(lom *cluster.LOM) error {
+	buf, slab := r.t.GetMMSA().Alloc()
+	_, err := r.t.CopyObject(lom, r.bckTo, buf, false)
+	slab.Free(buf)
+	return err
+}
+
 func (r *FastRen) Run() error {
 	glog.Infoln(r.String())
 
@@ -402,11 +475,39 @@ func (r *FastRen) Run() error {
 		}
 	}
 
+	r.copyTracked()
 	r.t.BMDVersionFixup(nil, r.bckFrom.Bck, false) // piggyback bucket renaming (last step) on getting updated BMD
 	r.Finish()
 	return nil
 }
 
+// copy the objects PUT during the rename, including those that get PUT while
+// copying, until the tracker is closed
+func (r *FastRen) copyTracked() {
+	slab, err := r.t.GetMMSA().GetSlab(memsys.MaxPageSlabSize)
+	cmn.AssertNoErr(err)
+	buf := slab.Alloc()
+	defer slab.Free(buf)
+	for {
+		names := r.tracked.Drain()
+		if len(names) == 0 {
+			if names = r.tracked.Close(); len(names) == 0 {
+				return
+			}
+		}
+		for _, objName := range names {
+			lom := &cluster.LOM{T: r.t, ObjName: objName}
+			if err := lom.Init(r.bckFrom.Bck); err != nil {
+				glog.Errorf("%s: %v", r, err)
+				continue
+			}
+			if _, err := r.t.CopyObject(lom, r.bckTo, buf, false); err != nil && !cmn.IsErrObjNought(err) {
+				glog.Errorf("%s: failed to copy %s: %v", r, lom, err)
+			}
+		}
+	}
+}
+
 func (e *FastRenEntry) Start(bck cmn.Bck) error {
 	e.xact = &FastRen{
 		XactBase:   *cmn.NewXactBaseWithBucket(e.uuid, e.Kind(), bck),
@@ -457,6 +558,117 @@ func (r *registry) RenewBckFastRename(t cluster.Target, rmdVersion int64, bckFro
 	return nil, err
 }
 
+//
+// BckSwapEntry & BckSwap
+//
+type (
+	BckSwapEntry struct {
+		baseBckEntry
+		reg        *registry
+		rebManager cluster.RebManager
+		xact       *BckSwap
+		bckB       *cluster.Bck
+		rebID      int64
+		resilver   bool
+	}
+	// BckSwap finishes when the objects of the swapped buckets are in place:
+	// it waits for the rebalance (and resilver) that comes with the new RMD or,
+	// if there's no rebalance, resilvers locally
+	BckSwap struct {
+		cmn.XactBase
+		reg        *registry
+		rebManager cluster.RebManager
+		bckB       *cluster.Bck
+		rebID      int64 // 0: no rebalance
+		resilver   bool  // resilver locally (when there's no rebalance)
+	}
+)
+
+const swapWaitIval = 10 * time.Second
+
+func (r *BckSwap) IsMountpathXact() bool { return true }
+func (r *BckSwap) String() string        { return fmt.Sprintf("%s <=> %s", r.XactBase.String(), r.bckB) }
+
+func (r *BckSwap) Run() error {
+	glog.Infoln(r.String())
+	if r.resilver {
+		r.rebManager.RunResilver("", true /*skipGlobMisplaced*/)
+	}
+	ticker := time.NewTicker(swapWaitIval)
+	defer ticker.Stop()
+	for {
+		waiting, err := r.waiting()
+		if !waiting {
+			r.Finish(err)
+			return err
+		}
+		select {
+		case <-ticker.C:
+		case <-r.ChanAbort():
+			err = fmt.Errorf("%s: aborted", r)
+			r.Finish(err)
+			return err
+		}
+	}
+}
+
+// returns true while the rebalance (if any) and resilver are still moving the objects;
+// NOTE: same as FastRen, assuming that rebalance takes longer than resilver
+func (r *BckSwap) waiting() (bool, error) {
+	if r.rebID != 0 {
+		entry := r.reg.GetLatest(RegistryXactFilter{Kind: cmn.ActRebalance})
+		if entry == nil {
+			return true, nil // not started yet
+		}
+		reb := entry.Get()
+		if reb.ID().Compare(strconv.FormatInt(r.rebID, 10)) < 0 || !reb.Finished() {
+			return true, nil
+		}
+		if reb.Aborted() {
+			return false, fmt.Errorf("%s: %s aborted", r, reb)
+		}
+	}
+	return r.reg.IsXactRunning(RegistryXactFilter{Kind: cmn.ActResilver}), nil
+}
+
+func (e *BckSwapEntry) Start(bck cmn.Bck) error {
+	e.xact = &BckSwap{
+		XactBase:   *cmn.NewXactBaseWithBucket(e.uuid, e.Kind(), bck),
+		reg:        e.reg,
+		rebManager: e.rebManager,
+		bckB:       e.bckB,
+		rebID:      e.rebID,
+		resilver:   e.resilver,
+	}
+	return nil
+}
+func (e *BckSwapEntry) Kind() string  { return cmn.ActSwapLB }
+func (e *BckSwapEntry) Get() cmn.Xact { return e.xact }
+
+func (e *BckSwapEntry) preRenewHook(previousEntry bucketEntry) (keep bool, err error) {
+	prev := previousEntry.(*BckSwapEntry)
+	err = fmt.Errorf("%s: cannot swap %s, %s is still in progress", e.Kind(), e.bckB, prev.xact)
+	return
+}
+
+// RenewBckSwap: rebID is the version of the RMD that comes along with the swap (0: no rebalance)
+func (r *registry) RenewBckSwap(uuid string, rebID int64, bckA, bckB *cluster.Bck, resilver bool,
+	mgr cluster.RebManager) (*BckSwap, error) {
+	e := &BckSwapEntry{
+		baseBckEntry: baseBckEntry{uuid},
+		reg:          r,
+		rebManager:   mgr,
+		bckB:         bckB,
+		rebID:        rebID,
+		resilver:     resilver,
+	}
+	ee, err := r.renewBucketXaction(e, bckA)
+	if err == nil {
+		return ee.Get().(*BckSwap), nil
+	}
+	return nil, err
+}
+
 //
 // EvictDeleteEntry & EvictDelete
 //
@@ -481,6 +693,7 @@ type (
 		ListMsg  *cmn.ListMsg
 		UUID     string
 		Evict    bool
+		Deleted  func(lom *cluster.LOM) // optional, called for each deleted (evicted) object
 	}
 	objCallback = func(args *DeletePrefetchArgs, objName string) error
 )
@@ -15,6 +15,7 @@ import (
 	"github.com/NVIDIA/aistore/downloader"
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/housekeep/lru"
+	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/stats"
 )
 
@@ -192,6 +193,29 @@ func (e *downloaderEntry) Start(_ cmn.Bck) error {
 func (e *downloaderEntry) Get() cmn.Xact { return e.xact }
 func (e *downloaderEntry) Kind() string  { return cmn.ActDownload }
 
+//
+// mpathDrainEntry
+//
+
+type mpathDrainEntry struct {
+	id        string
+	xact      *mirror.XactMpathDrain
+	t         cluster.Target
+	mpathInfo *fs.MountpathInfo
+	remove    func(mpath string) error
+}
+
+func (e *mpathDrainEntry) Start(_ cmn.Bck) error {
+	e.xact = mirror.NewXactMpathDrain(e.id, e.t, e.mpathInfo, e.remove)
+	return nil
+}
+func (e *mpathDrainEntry) Get() cmn.Xact { return e.xact }
+func (e *mpathDrainEntry) Kind() string  { return cmn.ActDrainMountpath }
+
+// one mountpath at a time
+func (e *mpathDrainEntry) preRenewHook(_ globalEntry) bool { return true }
+func (e *mpathDrainEntry) postRenewHook(_ globalEntry)     {}
+
 //
 // baseGlobalEntry
 //
@@ -88,6 +88,9 @@ func (r *EvictDelete) objDelete(args *DeletePrefetchArgs, lom *cluster.LOM) erro
 }
 
 func (r *EvictDelete) doObjEvictDelete(args *DeletePrefetchArgs, objName string) error {
+	if cmn.IsECPackName(objName) {
+		return nil // internal (see cmn.ECPackPrefix)
+	}
 	lom := &cluster.LOM{T: r.t, ObjName: objName}
 	err := lom.Init(r.Bck())
 	if err != nil {
@@ -107,6 +110,9 @@ func (r *EvictDelete) doObjEvictDelete(args *DeletePrefetchArgs, objName string)
 	}
 	r.ObjectsInc()
 	r.BytesAdd(lom.Size())
+	if args.Deleted != nil {
+		args.Deleted(lom)
+	}
 	return nil
 }
 
@@ -16,8 +16,11 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/downloader"
+	"github.com/NVIDIA/aistore/ec"
+	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/housekeep/hk"
 	"github.com/NVIDIA/aistore/housekeep/lru"
+	"github.com/NVIDIA/aistore/mirror"
 	"github.com/NVIDIA/aistore/stats"
 )
 
@@ -95,6 +98,7 @@ type (
 		Kind        string
 		Bck         *cluster.Bck
 		OnlyRunning *bool
+		Tags        cmn.XactTags
 	}
 	registryEntries struct {
 		mtx       sync.RWMutex
@@ -108,6 +112,12 @@ type (
 		// All entries in the registry. The entries are periodically cleaned up
 		// to make sure that we don't keep old entries forever.
 		entries *registryEntries
+
+		// running xactions that fence client PUTs, by walked bucket (see GetFencing)
+		fencing struct {
+			sync.RWMutex
+			m map[cmn.Bck][]cmn.Xact
+		}
 	}
 )
 
@@ -122,6 +132,9 @@ func (rxf RegistryXactFilter) genericMatcher(xact cmn.Xact) bool {
 	if rxf.Bck != nil {
 		condition = condition && xact.Bck().Equal(rxf.Bck.Bck)
 	}
+	if len(rxf.Tags) > 0 {
+		condition = condition && xact.Tags().Match(rxf.Tags)
+	}
 	return condition
 }
 
@@ -165,9 +178,9 @@ func (xact *Rebalance) AbortedAfter(dur time.Duration) (aborted bool) {
 
 // override  - extend cmn.XactBase.Stats()
 func (xact *Rebalance) Stats() cmn.XactStats {
-	baseStats := xact.XactBase.Stats().(*cmn.BaseXactStats)
-	rebStats := stats.RebalanceTargetStats{BaseXactStats: *baseStats}
+	rebStats := stats.RebalanceTargetStats{BaseXactStats: *xact.BaseStats()}
 	rebStats.FillFromTrunner(xact.statsRunner)
+	xact.EstimateProgress(&rebStats.BaseXactStats)
 	return &rebStats
 }
 
@@ -292,6 +305,7 @@ func newRegistry() *registry {
 	xar := &registry{
 		entries: newRegistryEntries(),
 	}
+	xar.fencing.m = make(map[cmn.Bck][]cmn.Xact, 4)
 	hk.Housekeeper.Register("xactions", xar.cleanUpFinished)
 	return xar
 }
@@ -308,6 +322,79 @@ func (r *registry) GetXact(uuid string) (xact cmn.Xact) {
 	return
 }
 
+// GetFinished returns the finished xactions that are still in the registry
+// (see cleanUpFinished)
+func (r *registry) GetFinished() (xacts []cmn.Xact) {
+	r.entries.forEach(func(entry baseEntry) bool {
+		if xact := entry.Get(); xact != nil && xact.Finished() {
+			xacts = append(xacts, xact)
+		}
+		return true
+	})
+	return
+}
+
+// returns the bucket walked by the xaction that fences client PUTs, if it is one
+// (the source bucket in case of copy and rename)
+func fencingBck(xact cmn.Xact) (walked cmn.Bck, ok bool) {
+	switch x := xact.(type) {
+	case *ec.XactBckEncode:
+		walked, ok = x.Bck(), true
+	case *mirror.XactBckCopy:
+		walked, ok = x.BckFrom().Bck, true
+	case *FastRen:
+		walked, ok = x.bckFrom.Bck, true
+	}
+	return
+}
+
+func (r *registry) addFencing(xact cmn.Xact) {
+	walked, ok := fencingBck(xact)
+	if !ok {
+		return
+	}
+	r.fencing.Lock()
+	r.fencing.m[walked] = append(r.fencing.m[walked], xact)
+	r.fencing.Unlock()
+}
+
+// GetFencing returns the running bucket-level xaction that walks a given bucket -
+// the xaction that the client PUTs of the bucket's objects race with (see cmn.FencingConf)
+// NOTE: called on every PUT - a map lookup in the absence of such xactions
+func (r *registry) GetFencing(bck *cluster.Bck) (xact cmn.Xact) {
+	var finished bool
+	r.fencing.RLock()
+	for _, x := range r.fencing.m[bck.Bck] {
+		if x.Finished() {
+			finished = true
+		} else if xact == nil {
+			xact = x
+		}
+	}
+	r.fencing.RUnlock()
+	if finished {
+		r.fencing.Lock()
+		r.pruneFencing(bck.Bck)
+		r.fencing.Unlock()
+	}
+	return
+}
+
+// under lock
+func (r *registry) pruneFencing(bck cmn.Bck) {
+	xacts := r.fencing.m[bck][:0]
+	for _, x := range r.fencing.m[bck] {
+		if !x.Finished() {
+			xacts = append(xacts, x)
+		}
+	}
+	if len(xacts) == 0 {
+		delete(r.fencing.m, bck)
+	} else {
+		r.fencing.m[bck] = xacts
+	}
+}
+
 func (r *registry) GetRunning(query RegistryXactFilter) baseEntry {
 	query.OnlyRunning = api.Bool(true)
 	entry := r.entries.find(query)
@@ -404,6 +491,20 @@ func (r *registry) matchingXactsStats(match func(xact cmn.Xact) bool) []cmn.Xact
 }
 
 func (r *registry) GetStats(query RegistryXactFilter) ([]cmn.XactStats, error) {
+	sts, err := r.getStats(query)
+	if err != nil || len(query.Tags) == 0 {
+		return sts, err
+	}
+	matching := sts[:0]
+	for _, st := range sts {
+		if st.Tags().Match(query.Tags) {
+			matching = append(matching, st)
+		}
+	}
+	return matching, nil
+}
+
+func (r *registry) getStats(query RegistryXactFilter) ([]cmn.XactStats, error) {
 	if query.ID != "" {
 		if query.OnlyRunning == nil || (query.OnlyRunning != nil && *query.OnlyRunning) {
 			return r.matchingXactsStats(func(xact cmn.Xact) bool {
@@ -471,6 +572,24 @@ func (r *registry) DoAbort(kind string, bck *cluster.Bck) (aborted bool) {
 	return
 }
 
+// DoPause pauses (or resumes) the running xaction of a given kind; returns
+// false if there's none
+func (r *registry) DoPause(kind string, bck *cluster.Bck, pause bool) (found bool, err error) {
+	if !cmn.XactsMeta[kind].Pausable {
+		return false, fmt.Errorf("xaction %q cannot be paused", kind)
+	}
+	entry := r.GetRunning(RegistryXactFilter{Kind: kind, Bck: bck})
+	if entry == nil {
+		return false, nil
+	}
+	if pause {
+		entry.Get().Pause()
+	} else {
+		entry.Get().Resume()
+	}
+	return true, nil
+}
+
 func (r *registry) removeFinishedByID(id string) error {
 	entry := r.entries.find(RegistryXactFilter{ID: id})
 	if entry == nil {
@@ -490,6 +609,9 @@ func (r *registry) removeFinishedByID(id string) error {
 
 func (r *registry) storeEntry(entry baseEntry) {
 	r.entries.insert(entry)
+	if xact := entry.Get(); xact != nil {
+		r.addFencing(xact)
+	}
 }
 
 // FIXME: cleanup might not remove the most old entries for each kind
@@ -499,6 +621,11 @@ func (r *registry) storeEntry(entry baseEntry) {
 // but not more often than cleanupInterval
 func (r *registry) cleanUpFinished() time.Duration {
 	startTime := time.Now()
+	r.fencing.Lock()
+	for bck := range r.fencing.m {
+		r.pruneFencing(bck)
+	}
+	r.fencing.Unlock()
 	if r.entries.taskCount.Load() == 0 {
 		if r.entries.len() <= entriesSizeHW {
 			return cleanupInterval
@@ -662,6 +789,21 @@ func (r *registry) RenewResilver(id string) *Resilver {
 	return entry.xact
 }
 
+// RenewMpathDrain returns an error if another mountpath is being drained
+func (r *registry) RenewMpathDrain(t cluster.Target, mpathInfo *fs.MountpathInfo,
+	remove func(mpath string) error) (*mirror.XactMpathDrain, error) {
+	e := &mpathDrainEntry{id: cmn.GenUUID(), t: t, mpathInfo: mpathInfo, remove: remove}
+	ee, keep, err := r.renewGlobalXaction(e)
+	if err != nil {
+		return nil, err
+	}
+	entry := ee.(*mpathDrainEntry)
+	if keep {
+		return nil, fmt.Errorf("%s: mountpath %s is being drained", t.Snode(), entry.xact.Mpath())
+	}
+	return entry.xact, nil
+}
+
 func (r *registry) RenewElection() *Election {
 	e := &electionEntry{}
 	ee, keep, _ := r.renewGlobalXaction(e)
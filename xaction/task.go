@@ -134,7 +134,7 @@ func (t *bckListTask) localObjPage(wi *walkinfo.WalkInfo) (*cmn.BucketList, erro
 	if xact.PageMarkerUnsatisfiable(t.msg.PageMarker) {
 		// We would miss some objects so we have to start from the beginning. Last fetched object by this xaction
 		// is later (in sorted order) than our page marker.
-		xact.Abort()
+		xact.Release()
 		if glog.V(4) {
 			glog.Infof("page marker before last result: %q vs %q", t.msg.PageMarker, xact.LastDiscardedResult())
 		}
@@ -237,6 +237,8 @@ func (t *bckSummaryTask) Run() error {
 				summary = cmn.BucketSummary{
 					Bck:            bck.Bck,
 					TotalDisksSize: totalDisksSize,
+					QuotaBytes:     bck.Props.Quota.MaxBytes,
+					QuotaObjects:   bck.Props.Quota.MaxObjects,
 				}
 			)
 
@@ -0,0 +1,191 @@
+// Package xaction provides core functionality for the AIStore extended actions.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package xaction
+
+import (
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/ios"
+	"github.com/NVIDIA/aistore/sys"
+)
+
+//
+// Warmup reads a list or range of the bucket's objects into the OS page cache
+// (see cmn.WarmupMsg) and reports the fraction of bytes that are cached upon
+// reading. Given mlock budget, the objects are also locked in memory, in the
+// order they are read and for as long as the total locked size fits into the
+// budget; in this case the xaction keeps running (and the objects stay locked)
+// until aborted. A new warm-up of the same bucket aborts the previous one.
+//
+// The mlock budget is capped at mlockMaxPct of the (host or container) memory:
+// RLIMIT_MEMLOCK does not restrict privileged processes.
+//
+
+const mlockMaxPct = 25
+
+type (
+	warmupEntry struct {
+		baseBckEntry
+		t    cluster.Target
+		xact *Warmup
+		args *WarmupArgs
+	}
+	WarmupArgs struct {
+		DeletePrefetchArgs
+		MlockBudget int64
+	}
+	Warmup struct {
+		listRangeBase
+		budget    int64
+		cached    atomic.Int64 // bytes in page cache upon reading
+		locked    atomic.Int64 // bytes locked in memory
+		mapped    []*ios.MappedFile
+		lockFails bool
+	}
+	WarmupTargetStats struct {
+		cmn.BaseXactStats
+		Ext ExtWarmupStats `json:"ext"`
+	}
+	ExtWarmupStats struct {
+		Cached    int64 `json:"cached,string"`
+		Locked    int64 `json:"locked,string"`
+		PctCached int   `json:"pct_cached"`
+	}
+)
+
+func (e *warmupEntry) Kind() string  { return cmn.ActWarmup }
+func (e *warmupEntry) Get() cmn.Xact { return e.xact }
+func (e *warmupEntry) preRenewHook(_ bucketEntry) (keep bool, err error) {
+	return false, nil
+}
+func (e *warmupEntry) postRenewHook(previousEntry bucketEntry) {
+	previousEntry.Get().Abort()
+}
+func (e *warmupEntry) Start(bck cmn.Bck) error {
+	e.xact = &Warmup{
+		listRangeBase: listRangeBase{
+			XactBase: *cmn.NewXactBaseWithBucket(e.uuid, e.Kind(), bck),
+			t:        e.t,
+			args:     &e.args.DeletePrefetchArgs,
+		},
+		budget: capMlockBudget(e.args.MlockBudget),
+	}
+	return nil
+}
+
+func capMlockBudget(budget int64) int64 {
+	if budget <= 0 {
+		return 0
+	}
+	mem, err := sys.Mem()
+	if err != nil {
+		glog.Errorf("failed to get memory stats, not locking objects in memory: %v", err)
+		return 0
+	}
+	capped := mlockBudget(budget, mem.Total)
+	if capped < budget {
+		glog.Warningf("mlock budget %s capped at %d%% of memory: %s", cmn.B2S(budget, 2), mlockMaxPct,
+			cmn.B2S(capped, 2))
+	}
+	return capped
+}
+
+func mlockBudget(budget int64, memTotal uint64) int64 {
+	if max := int64(memTotal / 100 * mlockMaxPct); budget > max {
+		return max
+	}
+	return budget
+}
+
+func (r *registry) RenewWarmup(t cluster.Target, bck *cluster.Bck, args *WarmupArgs) (*Warmup, error) {
+	e := &warmupEntry{
+		baseBckEntry: baseBckEntry{args.UUID},
+		t:            t,
+		args:         args,
+	}
+	ee, err := r.renewBucketXaction(e, bck)
+	if err == nil {
+		return ee.Get().(*Warmup), nil
+	}
+	return nil, err
+}
+
+func (r *Warmup) IsMountpathXact() bool { return false }
+
+func (r *Warmup) Run() error {
+	var err error
+	if r.args.RangeMsg != nil {
+		err = r.iterateRange(r.args, r.warmup)
+	} else {
+		err = r.iterateList(r.args, r.args.ListMsg, r.warmup)
+	}
+	if err == nil && len(r.mapped) > 0 && !r.Aborted() {
+		glog.Infof("%s: %s locked in memory until aborted", r, cmn.B2S(r.locked.Load(), 2))
+		<-r.ChanAbort()
+	}
+	for _, mf := range r.mapped {
+		mf.Close()
+	}
+	r.mapped = nil
+	r.Finish(err)
+	return err
+}
+
+func (r *Warmup) Stats() cmn.XactStats {
+	baseStats := r.XactBase.Stats().(*cmn.BaseXactStats)
+	warmupStats := WarmupTargetStats{BaseXactStats: *baseStats}
+	warmupStats.Ext.Cached = r.cached.Load()
+	warmupStats.Ext.Locked = r.locked.Load()
+	if size := r.BytesCount(); size > 0 {
+		warmupStats.Ext.PctCached = int(warmupStats.Ext.Cached * 100 / size)
+	} else {
+		warmupStats.Ext.PctCached = 100
+	}
+	return &warmupStats
+}
+
+func (r *Warmup) warmup(_ *DeletePrefetchArgs, objName string) error {
+	lom := &cluster.LOM{T: r.t, ObjName: objName}
+	if err := lom.Init(r.Bck()); err != nil {
+		return err
+	}
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	if err := lom.Load(); err != nil {
+		if cmn.IsErrObjNought(err) {
+			return nil // (not cached or does not exist)
+		}
+		return err
+	}
+	mf, err := ios.MapFile(lom.FQN, lom.Size())
+	if err != nil {
+		glog.Errorf("%s: failed to map %s: %v", r, lom, err)
+		return nil
+	}
+	resident, err := mf.Warm()
+	if err != nil {
+		glog.Errorf("%s: failed to warm up %s: %v", r, lom, err)
+	}
+	r.ObjectsInc()
+	r.BytesAdd(lom.Size())
+	r.cached.Add(resident)
+
+	if r.lockFails || r.locked.Load()+mf.Size() > r.budget || mf.Size() == 0 {
+		mf.Close()
+		return nil
+	}
+	if err := mf.Lock(); err != nil {
+		// most likely, RLIMIT_MEMLOCK - don't try again
+		glog.Errorf("%s: failed to lock %s in memory: %v", r, lom, err)
+		r.lockFails = true
+		mf.Close()
+		return nil
+	}
+	r.locked.Add(mf.Size())
+	r.mapped = append(r.mapped, mf)
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cluster"
@@ -163,6 +164,71 @@ func TestXactionAbortBuckets(t *testing.T) {
 	xactions.AbortAll()
 }
 
+func TestXactionPauseResume(t *testing.T) {
+	var (
+		xactions = newRegistry()
+		waitCh   = make(chan struct{})
+	)
+	defer xactions.AbortAll()
+
+	xactGlob := xactions.RenewLRU("")
+	tassert.Errorf(t, xactGlob != nil, "Xaction must be created")
+	_, err := xactions.DoPause(cmn.ActLRU, nil, true)
+	tassert.Errorf(t, err != nil, "expected LRU xaction to be non-pausable")
+
+	xactRes := xactions.RenewResilver("")
+	found, err := xactions.DoPause(cmn.ActResilver, nil, true)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, found && xactRes.Paused(), "expected resilver xaction to be paused")
+	tassert.Errorf(t, xactRes.Stats().Paused(), "expected stats to report paused")
+
+	go func() {
+		xactRes.WaitIfPaused()
+		close(waitCh)
+	}()
+	select {
+	case <-waitCh:
+		t.Fatal("expected to wait while paused")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	_, err = xactions.DoPause(cmn.ActResilver, nil, false)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, !xactRes.Paused(), "expected resilver xaction to be resumed")
+	select {
+	case <-waitCh:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected to stop waiting upon resume")
+	}
+}
+
+func TestXactionProgress(t *testing.T) {
+	xactions := newRegistry()
+	defer xactions.AbortAll()
+
+	xactRes := xactions.RenewResilver("")
+	xactRes.TotalAdd(100)
+	for i := 0; i < 25; i++ {
+		xactRes.DoneInc()
+		xactRes.BytesAdd(cmn.KiB)
+	}
+	time.Sleep(10 * time.Millisecond)
+	stats := xactRes.Stats()
+	tassert.Errorf(t, stats.TotalCount() == 100 && stats.DoneCount() == 25,
+		"expected 25/100, got %d/%d", stats.DoneCount(), stats.TotalCount())
+	tassert.Errorf(t, stats.Throughput() > 0, "expected positive throughput")
+	tassert.Errorf(t, stats.ETA() > 0, "expected positive ETA")
+
+	xactRes.Pause()
+	tassert.Errorf(t, xactRes.Stats().ETA() == 0, "expected no ETA while paused")
+	xactRes.Resume()
+
+	xactRes.Finish()
+	stats = xactRes.Stats()
+	tassert.Errorf(t, stats.ETA() == 0, "expected no ETA once finished")
+	tassert.Errorf(t, stats.Throughput() > 0, "expected positive (average) throughput once finished")
+}
+
 // TODO: extend this to include all cases of the Query
 func TestXactionQueryFinished(t *testing.T) {
 	type testConfig struct {
@@ -245,3 +311,142 @@ func TestXactionQueryFinished(t *testing.T) {
 		f(t, test)
 	}
 }
+
+// resilvers synchronously (see BckSwap)
+type resilverMock struct {
+	xactions *registry
+	cnt      int
+}
+
+func (m *resilverMock) RunResilver(id string, _ bool) {
+	m.cnt++
+	m.xactions.RenewResilver(id).Finish()
+}
+func (m *resilverMock) RunRebalance(_ *cluster.Smap, _ int64) {}
+
+func TestXactionBckSwapWaitsForRebalance(t *testing.T) {
+	var (
+		xactions = newRegistry()
+		bckA     = cluster.NewBck("swap-a", cmn.ProviderAIS, cmn.NsGlobal)
+		bckB     = cluster.NewBck("swap-b", cmn.ProviderAIS, cmn.NsGlobal)
+	)
+	defer xactions.AbortAll()
+
+	xact, err := xactions.RenewBckSwap("swap-uuid", 5, bckA, bckB, false, nil)
+	tassert.CheckFatal(t, err)
+	_, err = xactions.RenewBckSwap("another", 6, bckA, bckB, false, nil)
+	tassert.Errorf(t, err != nil, "expected error: %s is in progress", xact)
+
+	waiting, _ := xact.waiting()
+	tassert.Errorf(t, waiting, "expected to wait for the rebalance to start")
+
+	// older rebalance
+	xreb := xactions.RenewRebalance(4, nil)
+	xreb.Finish()
+	waiting, _ = xact.waiting()
+	tassert.Errorf(t, waiting, "expected to wait for the rebalance g5")
+
+	xreb = xactions.RenewRebalance(5, nil)
+	waiting, _ = xact.waiting()
+	tassert.Errorf(t, waiting, "expected to wait while %s is running", xreb)
+
+	xres := xactions.RenewResilver("")
+	xreb.Finish()
+	waiting, _ = xact.waiting()
+	tassert.Errorf(t, waiting, "expected to wait while %s is running", xres)
+
+	xres.Finish()
+	waiting, err = xact.waiting()
+	tassert.Errorf(t, !waiting && err == nil, "expected to be done, got (%t, %v)", waiting, err)
+
+	// aborted rebalance
+	xact, err = xactions.RenewBckSwap("swap-uuid2", 7, bckB, bckA, false, nil)
+	tassert.CheckFatal(t, err)
+	xreb = xactions.RenewRebalance(7, nil)
+	xreb.Abort()
+	waiting, err = xact.waiting()
+	tassert.Errorf(t, !waiting && err != nil, "expected error upon aborted rebalance, got (%t, %v)", waiting, err)
+}
+
+func TestXactionBckSwapResilver(t *testing.T) {
+	var (
+		xactions = newRegistry()
+		bckA     = cluster.NewBck("swap-a", cmn.ProviderAIS, cmn.NsGlobal)
+		bckB     = cluster.NewBck("swap-b", cmn.ProviderAIS, cmn.NsGlobal)
+		mgr      = &resilverMock{xactions: xactions}
+		notified = make(chan error, 1)
+	)
+	defer xactions.AbortAll()
+
+	// no rebalance: resilvers locally and notifies upon completion
+	xact, err := xactions.RenewBckSwap("swap-uuid", 0, bckA, bckB, true, mgr)
+	tassert.CheckFatal(t, err)
+	xact.AddNotif(&cmn.NotifXact{
+		NotifBase: cmn.NotifBase{When: cmn.UponTerm, F: func(_ cmn.Notif, err error) { notified <- err }},
+	})
+	go xact.Run()
+	select {
+	case err := <-notified:
+		tassert.CheckError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected notification upon completion")
+	}
+	tassert.Errorf(t, mgr.cnt == 1, "expected resilver to run once, got %d", mgr.cnt)
+	tassert.Errorf(t, xact.Finished(), "expected %s to finish", xact)
+}
+
+func TestXactionGetFencing(t *testing.T) {
+	var (
+		xactions = newRegistry()
+		bckFrom  = cluster.NewBck("fence-from", cmn.ProviderAIS, cmn.NsGlobal)
+		bckTo    = cluster.NewBck("fence-to", cmn.ProviderAIS, cmn.NsGlobal)
+	)
+	defer xactions.AbortAll()
+
+	tassert.Errorf(t, xactions.GetFencing(bckFrom) == nil, "expected no fencing xaction")
+	xact, err := xactions.RenewBckFastRename(nil, 1, bckFrom, bckTo, cmn.ActBegin, nil)
+	tassert.CheckFatal(t, err)
+	// the walked (source) bucket is fenced
+	tassert.Errorf(t, xactions.GetFencing(bckFrom) == xact, "expected %s to fence %s", xact, bckFrom)
+	tassert.Errorf(t, xactions.GetFencing(bckTo) == nil, "expected %s not to be fenced", bckTo)
+
+	xact.Finish()
+	tassert.Errorf(t, xactions.GetFencing(bckFrom) == nil, "expected no fencing upon %s finished", xact)
+	xactions.fencing.RLock()
+	l := len(xactions.fencing.m)
+	xactions.fencing.RUnlock()
+	tassert.Errorf(t, l == 0, "expected finished xaction to be removed, got %d", l)
+}
+
+func TestXactionTrackPut(t *testing.T) {
+	var (
+		xactions = newRegistry()
+		bckFrom  = cluster.NewBck("track-from", cmn.ProviderAIS, cmn.NsGlobal)
+		bckTo    = cluster.NewBck("track-to", cmn.ProviderAIS, cmn.NsGlobal)
+	)
+	defer xactions.AbortAll()
+
+	xact, err := xactions.RenewBckFastRename(nil, 1, bckFrom, bckTo, cmn.ActBegin, nil)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, xact.TrackPut(&cluster.LOM{ObjName: "obj-1"}), "expected PUT to be tracked")
+	names := xact.tracked.Drain()
+	tassert.Errorf(t, len(names) == 1 && names[0] == "obj-1", "unexpected %v", names)
+
+	// PUT in between the last drain and close
+	tassert.Errorf(t, xact.TrackPut(&cluster.LOM{ObjName: "obj-2"}), "expected PUT to be tracked")
+	names = xact.tracked.Close()
+	tassert.Errorf(t, len(names) == 1 && names[0] == "obj-2", "unexpected %v", names)
+
+	// too late
+	tassert.Errorf(t, !xact.TrackPut(&cluster.LOM{ObjName: "obj-3"}), "expected PUT not to be tracked once closed")
+	tassert.Errorf(t, len(xact.tracked.Drain()) == 0, "expected nothing tracked once closed")
+}
+
+func TestWarmupMlockBudget(t *testing.T) {
+	const memTotal = 64 * cmn.GiB
+	tassert.Errorf(t, mlockBudget(cmn.GiB, memTotal) == cmn.GiB, "expected the budget within the limit as is")
+	budget := mlockBudget(memTotal, memTotal)
+	tassert.Errorf(t, budget == memTotal/100*mlockMaxPct, "expected the budget capped at %d%% of memory, got %s",
+		mlockMaxPct, cmn.B2S(budget, 2))
+	tassert.Errorf(t, capMlockBudget(0) == 0, "expected no budget")
+}